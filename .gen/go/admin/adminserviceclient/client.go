@@ -48,11 +48,58 @@ type Interface interface {
 		opts ...yarpc.CallOption,
 	) (*admin.DescribeWorkflowExecutionResponse, error)
 
+	ExportWorkflowExecution(
+		ctx context.Context,
+		Request *admin.ExportWorkflowExecutionRequest,
+		opts ...yarpc.CallOption,
+	) (*admin.ExportWorkflowExecutionResponse, error)
+
+	GetDLQReplicationTasks(
+		ctx context.Context,
+		Request *admin.GetDLQReplicationTasksRequest,
+		opts ...yarpc.CallOption,
+	) (*admin.GetDLQReplicationTasksResponse, error)
+
+	GetReplicationTasks(
+		ctx context.Context,
+		Request *admin.GetReplicationTasksRequest,
+		opts ...yarpc.CallOption,
+	) (*admin.GetReplicationTasksResponse, error)
+
 	GetWorkflowExecutionRawHistory(
 		ctx context.Context,
 		GetRequest *admin.GetWorkflowExecutionRawHistoryRequest,
 		opts ...yarpc.CallOption,
 	) (*admin.GetWorkflowExecutionRawHistoryResponse, error)
+
+	ImportWorkflowExecution(
+		ctx context.Context,
+		Request *admin.ImportWorkflowExecutionRequest,
+		opts ...yarpc.CallOption,
+	) (*admin.ImportWorkflowExecutionResponse, error)
+	MigrateWorkflowExecution(
+		ctx context.Context,
+		Request *admin.MigrateWorkflowExecutionRequest,
+		opts ...yarpc.CallOption,
+	) (*admin.MigrateWorkflowExecutionResponse, error)
+
+	AddRemoteCluster(
+		ctx context.Context,
+		Request *admin.AddRemoteClusterRequest,
+		opts ...yarpc.CallOption,
+	) (*admin.AddRemoteClusterResponse, error)
+
+	AddSearchAttribute(
+		ctx context.Context,
+		Request *admin.AddSearchAttributeRequest,
+		opts ...yarpc.CallOption,
+	) (*admin.AddSearchAttributeResponse, error)
+
+	GetReplicationConsumerLag(
+		ctx context.Context,
+		Request *admin.GetReplicationConsumerLagRequest,
+		opts ...yarpc.CallOption,
+	) (*admin.GetReplicationConsumerLagResponse, error)
 }
 
 // New builds a new client for the AdminService service.
@@ -125,6 +172,75 @@ func (c client) DescribeWorkflowExecution(
 	return
 }
 
+func (c client) ExportWorkflowExecution(
+	ctx context.Context,
+	_Request *admin.ExportWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (success *admin.ExportWorkflowExecutionResponse, err error) {
+
+	args := admin.AdminService_ExportWorkflowExecution_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_ExportWorkflowExecution_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = admin.AdminService_ExportWorkflowExecution_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) GetDLQReplicationTasks(
+	ctx context.Context,
+	_Request *admin.GetDLQReplicationTasksRequest,
+	opts ...yarpc.CallOption,
+) (success *admin.GetDLQReplicationTasksResponse, err error) {
+
+	args := admin.AdminService_GetDLQReplicationTasks_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_GetDLQReplicationTasks_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = admin.AdminService_GetDLQReplicationTasks_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) GetReplicationTasks(
+	ctx context.Context,
+	_Request *admin.GetReplicationTasksRequest,
+	opts ...yarpc.CallOption,
+) (success *admin.GetReplicationTasksResponse, err error) {
+
+	args := admin.AdminService_GetReplicationTasks_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_GetReplicationTasks_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = admin.AdminService_GetReplicationTasks_Helper.UnwrapResponse(&result)
+	return
+}
+
 func (c client) GetWorkflowExecutionRawHistory(
 	ctx context.Context,
 	_GetRequest *admin.GetWorkflowExecutionRawHistoryRequest,
@@ -147,3 +263,118 @@ func (c client) GetWorkflowExecutionRawHistory(
 	success, err = admin.AdminService_GetWorkflowExecutionRawHistory_Helper.UnwrapResponse(&result)
 	return
 }
+
+func (c client) ImportWorkflowExecution(
+	ctx context.Context,
+	_Request *admin.ImportWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (success *admin.ImportWorkflowExecutionResponse, err error) {
+
+	args := admin.AdminService_ImportWorkflowExecution_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_ImportWorkflowExecution_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = admin.AdminService_ImportWorkflowExecution_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) MigrateWorkflowExecution(
+	ctx context.Context,
+	_Request *admin.MigrateWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (success *admin.MigrateWorkflowExecutionResponse, err error) {
+
+	args := admin.AdminService_MigrateWorkflowExecution_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_MigrateWorkflowExecution_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = admin.AdminService_MigrateWorkflowExecution_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) AddRemoteCluster(
+	ctx context.Context,
+	_Request *admin.AddRemoteClusterRequest,
+	opts ...yarpc.CallOption,
+) (success *admin.AddRemoteClusterResponse, err error) {
+
+	args := admin.AdminService_AddRemoteCluster_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_AddRemoteCluster_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = admin.AdminService_AddRemoteCluster_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) AddSearchAttribute(
+	ctx context.Context,
+	_Request *admin.AddSearchAttributeRequest,
+	opts ...yarpc.CallOption,
+) (success *admin.AddSearchAttributeResponse, err error) {
+
+	args := admin.AdminService_AddSearchAttribute_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_AddSearchAttribute_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = admin.AdminService_AddSearchAttribute_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) GetReplicationConsumerLag(
+	ctx context.Context,
+	_Request *admin.GetReplicationConsumerLagRequest,
+	opts ...yarpc.CallOption,
+) (success *admin.GetReplicationConsumerLagResponse, err error) {
+
+	args := admin.AdminService_GetReplicationConsumerLag_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_GetReplicationConsumerLag_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = admin.AdminService_GetReplicationConsumerLag_Helper.UnwrapResponse(&result)
+	return
+}
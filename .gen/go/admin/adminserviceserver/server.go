@@ -44,10 +44,49 @@ type Interface interface {
 		Request *admin.DescribeWorkflowExecutionRequest,
 	) (*admin.DescribeWorkflowExecutionResponse, error)
 
+	ExportWorkflowExecution(
+		ctx context.Context,
+		Request *admin.ExportWorkflowExecutionRequest,
+	) (*admin.ExportWorkflowExecutionResponse, error)
+
+	GetDLQReplicationTasks(
+		ctx context.Context,
+		Request *admin.GetDLQReplicationTasksRequest,
+	) (*admin.GetDLQReplicationTasksResponse, error)
+
+	GetReplicationTasks(
+		ctx context.Context,
+		Request *admin.GetReplicationTasksRequest,
+	) (*admin.GetReplicationTasksResponse, error)
+
 	GetWorkflowExecutionRawHistory(
 		ctx context.Context,
 		GetRequest *admin.GetWorkflowExecutionRawHistoryRequest,
 	) (*admin.GetWorkflowExecutionRawHistoryResponse, error)
+
+	ImportWorkflowExecution(
+		ctx context.Context,
+		Request *admin.ImportWorkflowExecutionRequest,
+	) (*admin.ImportWorkflowExecutionResponse, error)
+	MigrateWorkflowExecution(
+		ctx context.Context,
+		Request *admin.MigrateWorkflowExecutionRequest,
+	) (*admin.MigrateWorkflowExecutionResponse, error)
+
+	AddRemoteCluster(
+		ctx context.Context,
+		Request *admin.AddRemoteClusterRequest,
+	) (*admin.AddRemoteClusterResponse, error)
+
+	AddSearchAttribute(
+		ctx context.Context,
+		Request *admin.AddSearchAttributeRequest,
+	) (*admin.AddSearchAttributeResponse, error)
+
+	GetReplicationConsumerLag(
+		ctx context.Context,
+		Request *admin.GetReplicationConsumerLagRequest,
+	) (*admin.GetReplicationConsumerLagResponse, error)
 }
 
 // New prepares an implementation of the AdminService service for
@@ -83,6 +122,39 @@ func New(impl Interface, opts ...thrift.RegisterOption) []transport.Procedure {
 				ThriftModule: admin.ThriftModule,
 			},
 
+			thrift.Method{
+				Name: "ExportWorkflowExecution",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.ExportWorkflowExecution),
+				},
+				Signature:    "ExportWorkflowExecution(Request *admin.ExportWorkflowExecutionRequest) (*admin.ExportWorkflowExecutionResponse)",
+				ThriftModule: admin.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "GetDLQReplicationTasks",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.GetDLQReplicationTasks),
+				},
+				Signature:    "GetDLQReplicationTasks(Request *admin.GetDLQReplicationTasksRequest) (*admin.GetDLQReplicationTasksResponse)",
+				ThriftModule: admin.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "GetReplicationTasks",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.GetReplicationTasks),
+				},
+				Signature:    "GetReplicationTasks(Request *admin.GetReplicationTasksRequest) (*admin.GetReplicationTasksResponse)",
+				ThriftModule: admin.ThriftModule,
+			},
+
 			thrift.Method{
 				Name: "GetWorkflowExecutionRawHistory",
 				HandlerSpec: thrift.HandlerSpec{
@@ -93,10 +165,64 @@ func New(impl Interface, opts ...thrift.RegisterOption) []transport.Procedure {
 				Signature:    "GetWorkflowExecutionRawHistory(GetRequest *admin.GetWorkflowExecutionRawHistoryRequest) (*admin.GetWorkflowExecutionRawHistoryResponse)",
 				ThriftModule: admin.ThriftModule,
 			},
+
+			thrift.Method{
+				Name: "ImportWorkflowExecution",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.ImportWorkflowExecution),
+				},
+				Signature:    "ImportWorkflowExecution(Request *admin.ImportWorkflowExecutionRequest) (*admin.ImportWorkflowExecutionResponse)",
+				ThriftModule: admin.ThriftModule,
+			},
+			thrift.Method{
+				Name: "MigrateWorkflowExecution",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.MigrateWorkflowExecution),
+				},
+				Signature:    "MigrateWorkflowExecution(Request *admin.MigrateWorkflowExecutionRequest) (*admin.MigrateWorkflowExecutionResponse)",
+				ThriftModule: admin.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "AddRemoteCluster",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.AddRemoteCluster),
+				},
+				Signature:    "AddRemoteCluster(Request *admin.AddRemoteClusterRequest) (*admin.AddRemoteClusterResponse)",
+				ThriftModule: admin.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "AddSearchAttribute",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.AddSearchAttribute),
+				},
+				Signature:    "AddSearchAttribute(Request *admin.AddSearchAttributeRequest) (*admin.AddSearchAttributeResponse)",
+				ThriftModule: admin.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "GetReplicationConsumerLag",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.GetReplicationConsumerLag),
+				},
+				Signature:    "GetReplicationConsumerLag(Request *admin.GetReplicationConsumerLagRequest) (*admin.GetReplicationConsumerLagResponse)",
+				ThriftModule: admin.ThriftModule,
+			},
 		},
 	}
 
-	procedures := make([]transport.Procedure, 0, 3)
+	procedures := make([]transport.Procedure, 0, 11)
 	procedures = append(procedures, thrift.BuildProcedures(service, opts...)...)
 	return procedures
 }
@@ -141,6 +267,63 @@ func (h handler) DescribeWorkflowExecution(ctx context.Context, body wire.Value)
 	return response, err
 }
 
+func (h handler) ExportWorkflowExecution(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_ExportWorkflowExecution_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.ExportWorkflowExecution(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := admin.AdminService_ExportWorkflowExecution_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) GetDLQReplicationTasks(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_GetDLQReplicationTasks_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.GetDLQReplicationTasks(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := admin.AdminService_GetDLQReplicationTasks_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) GetReplicationTasks(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_GetReplicationTasks_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.GetReplicationTasks(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := admin.AdminService_GetReplicationTasks_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
 func (h handler) GetWorkflowExecutionRawHistory(ctx context.Context, body wire.Value) (thrift.Response, error) {
 	var args admin.AdminService_GetWorkflowExecutionRawHistory_Args
 	if err := args.FromWire(body); err != nil {
@@ -159,3 +342,98 @@ func (h handler) GetWorkflowExecutionRawHistory(ctx context.Context, body wire.V
 	}
 	return response, err
 }
+
+func (h handler) ImportWorkflowExecution(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_ImportWorkflowExecution_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.ImportWorkflowExecution(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := admin.AdminService_ImportWorkflowExecution_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) MigrateWorkflowExecution(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_MigrateWorkflowExecution_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.MigrateWorkflowExecution(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := admin.AdminService_MigrateWorkflowExecution_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) AddRemoteCluster(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_AddRemoteCluster_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.AddRemoteCluster(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := admin.AdminService_AddRemoteCluster_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) AddSearchAttribute(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_AddSearchAttribute_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.AddSearchAttribute(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := admin.AdminService_AddSearchAttribute_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) GetReplicationConsumerLag(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_GetReplicationConsumerLag_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.GetReplicationConsumerLag(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := admin.AdminService_GetReplicationConsumerLag_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
@@ -129,6 +129,105 @@ func (mr *_MockClientRecorder) DescribeWorkflowExecution(
 	return mr.mock.ctrl.RecordCall(mr.mock, "DescribeWorkflowExecution", args...)
 }
 
+// ExportWorkflowExecution responds to a ExportWorkflowExecution call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().ExportWorkflowExecution(gomock.Any(), ...).Return(...)
+// 	... := client.ExportWorkflowExecution(...)
+func (m *MockClient) ExportWorkflowExecution(
+	ctx context.Context,
+	_Request *admin.ExportWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (success *admin.ExportWorkflowExecutionResponse, err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "ExportWorkflowExecution", args...)
+	success, _ = ret[i].(*admin.ExportWorkflowExecutionResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) ExportWorkflowExecution(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "ExportWorkflowExecution", args...)
+}
+
+// GetDLQReplicationTasks responds to a GetDLQReplicationTasks call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().GetDLQReplicationTasks(gomock.Any(), ...).Return(...)
+// 	... := client.GetDLQReplicationTasks(...)
+func (m *MockClient) GetDLQReplicationTasks(
+	ctx context.Context,
+	_Request *admin.GetDLQReplicationTasksRequest,
+	opts ...yarpc.CallOption,
+) (success *admin.GetDLQReplicationTasksResponse, err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "GetDLQReplicationTasks", args...)
+	success, _ = ret[i].(*admin.GetDLQReplicationTasksResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) GetDLQReplicationTasks(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "GetDLQReplicationTasks", args...)
+}
+
+// GetReplicationTasks responds to a GetReplicationTasks call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().GetReplicationTasks(gomock.Any(), ...).Return(...)
+// 	... := client.GetReplicationTasks(...)
+func (m *MockClient) GetReplicationTasks(
+	ctx context.Context,
+	_Request *admin.GetReplicationTasksRequest,
+	opts ...yarpc.CallOption,
+) (success *admin.GetReplicationTasksResponse, err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "GetReplicationTasks", args...)
+	success, _ = ret[i].(*admin.GetReplicationTasksResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) GetReplicationTasks(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "GetReplicationTasks", args...)
+}
+
 // GetWorkflowExecutionRawHistory responds to a GetWorkflowExecutionRawHistory call based on the mock expectations. This
 // call will fail if the mock does not expect this call. Use EXPECT to expect
 // a call to this function.
@@ -161,3 +260,168 @@ func (mr *_MockClientRecorder) GetWorkflowExecutionRawHistory(
 	args := append([]interface{}{ctx, _GetRequest}, opts...)
 	return mr.mock.ctrl.RecordCall(mr.mock, "GetWorkflowExecutionRawHistory", args...)
 }
+
+// ImportWorkflowExecution responds to a ImportWorkflowExecution call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().ImportWorkflowExecution(gomock.Any(), ...).Return(...)
+// 	... := client.ImportWorkflowExecution(...)
+func (m *MockClient) ImportWorkflowExecution(
+	ctx context.Context,
+	_Request *admin.ImportWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (success *admin.ImportWorkflowExecutionResponse, err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "ImportWorkflowExecution", args...)
+	success, _ = ret[i].(*admin.ImportWorkflowExecutionResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) ImportWorkflowExecution(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "ImportWorkflowExecution", args...)
+}
+
+// MigrateWorkflowExecution responds to a MigrateWorkflowExecution call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().MigrateWorkflowExecution(gomock.Any(), ...).Return(...)
+// 	... := client.MigrateWorkflowExecution(...)
+func (m *MockClient) MigrateWorkflowExecution(
+	ctx context.Context,
+	_Request *admin.MigrateWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (success *admin.MigrateWorkflowExecutionResponse, err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "MigrateWorkflowExecution", args...)
+	success, _ = ret[i].(*admin.MigrateWorkflowExecutionResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) MigrateWorkflowExecution(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "MigrateWorkflowExecution", args...)
+}
+
+// AddRemoteCluster responds to a AddRemoteCluster call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().AddRemoteCluster(gomock.Any(), ...).Return(...)
+// 	... := client.AddRemoteCluster(...)
+func (m *MockClient) AddRemoteCluster(
+	ctx context.Context,
+	_Request *admin.AddRemoteClusterRequest,
+	opts ...yarpc.CallOption,
+) (success *admin.AddRemoteClusterResponse, err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "AddRemoteCluster", args...)
+	success, _ = ret[i].(*admin.AddRemoteClusterResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) AddRemoteCluster(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "AddRemoteCluster", args...)
+}
+
+// AddSearchAttribute responds to a AddSearchAttribute call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().AddSearchAttribute(gomock.Any(), ...).Return(...)
+// 	... := client.AddSearchAttribute(...)
+func (m *MockClient) AddSearchAttribute(
+	ctx context.Context,
+	_Request *admin.AddSearchAttributeRequest,
+	opts ...yarpc.CallOption,
+) (success *admin.AddSearchAttributeResponse, err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "AddSearchAttribute", args...)
+	success, _ = ret[i].(*admin.AddSearchAttributeResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) AddSearchAttribute(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "AddSearchAttribute", args...)
+}
+
+// GetReplicationConsumerLag responds to a GetReplicationConsumerLag call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().GetReplicationConsumerLag(gomock.Any(), ...).Return(...)
+// 	... := client.GetReplicationConsumerLag(...)
+func (m *MockClient) GetReplicationConsumerLag(
+	ctx context.Context,
+	_Request *admin.GetReplicationConsumerLagRequest,
+	opts ...yarpc.CallOption,
+) (success *admin.GetReplicationConsumerLagResponse, err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "GetReplicationConsumerLag", args...)
+	success, _ = ret[i].(*admin.GetReplicationConsumerLagResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) GetReplicationConsumerLag(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "GetReplicationConsumerLag", args...)
+}
@@ -24,6 +24,7 @@
 package admin
 
 import (
+	replicator "github.com/uber/cadence/.gen/go/replicator"
 	shared "github.com/uber/cadence/.gen/go/shared"
 	thriftreflect "go.uber.org/thriftrw/thriftreflect"
 )
@@ -33,11 +34,12 @@ var ThriftModule = &thriftreflect.ThriftModule{
 	Name:     "admin",
 	Package:  "github.com/uber/cadence/.gen/go/admin",
 	FilePath: "admin.thrift",
-	SHA1:     "5136f687aeefc01dd9b57994864926bb8f277b9f",
+	SHA1:     "579d972a1175a4a30d5e79c0acc8aee87339547a",
 	Includes: []*thriftreflect.ThriftModule{
+		replicator.ThriftModule,
 		shared.ThriftModule,
 	},
 	Raw: rawIDL,
 }
 
-const rawIDL = "// Copyright (c) 2017 Uber Technologies, Inc.\n//\n// Permission is hereby granted, free of charge, to any person obtaining a copy\n// of this software and associated documentation files (the \"Software\"), to deal\n// in the Software without restriction, including without limitation the rights\n// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell\n// copies of the Software, and to permit persons to whom the Software is\n// furnished to do so, subject to the following conditions:\n//\n// The above copyright notice and this permission notice shall be included in\n// all copies or substantial portions of the Software.\n//\n// THE SOFTWARE IS PROVIDED \"AS IS\", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR\n// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,\n// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE\n// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER\n// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,\n// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN\n// THE SOFTWARE.\n\nnamespace java com.uber.cadence.admin\n\ninclude \"shared.thrift\"\n\n/**\n* AdminService provides advanced APIs for debugging and analysis with admin privillege\n**/\nservice AdminService {\n  /**\n  * DescribeWorkflowExecution returns information about the internal states of workflow execution.\n  **/\n  DescribeWorkflowExecutionResponse DescribeWorkflowExecution(1: DescribeWorkflowExecutionRequest request)\n    throws (\n      1: shared.BadRequestError         badRequestError,\n      2: shared.InternalServiceError    internalServiceError,\n      3: shared.EntityNotExistsError    entityNotExistError,\n      4: shared.AccessDeniedError       accessDeniedError,\n    )\n\n  /**\n  * DescribeHistoryHost returns information about the internal states of a history host\n  **/\n  shared.DescribeHistoryHostResponse DescribeHistoryHost(1: shared.DescribeHistoryHostRequest request)\n    throws (\n      1: shared.BadRequestError       badRequestError,\n      2: shared.InternalServiceError  internalServiceError,\n      3: shared.AccessDeniedError     accessDeniedError,\n    )\n\n  /**\n  * Returns the raw history of specified workflow execution.  It fails with 'EntityNotExistError' if speficied workflow\n  * execution in unknown to the service.\n  **/\n  GetWorkflowExecutionRawHistoryResponse GetWorkflowExecutionRawHistory(1: GetWorkflowExecutionRawHistoryRequest getRequest)\n    throws (\n      1: shared.BadRequestError badRequestError,\n      2: shared.InternalServiceError internalServiceError,\n      3: shared.EntityNotExistsError entityNotExistError,\n      4: shared.ServiceBusyError serviceBusyError,\n    )\n}\n\nstruct DescribeWorkflowExecutionRequest {\n  10: optional string                       domain\n  20: optional shared.WorkflowExecution     execution\n}\n\nstruct DescribeWorkflowExecutionResponse{\n  10: optional string shardId\n  20: optional string historyAddr\n  40: optional string mutableStateInCache\n  50: optional string mutableStateInDatabase\n}\n\nstruct GetWorkflowExecutionRawHistoryRequest {\n  10: optional string domain\n  20: optional shared.WorkflowExecution execution\n  30: optional i64 (js.type = \"Long\") firstEventId\n  40: optional i64 (js.type = \"Long\") nextEventId\n  50: optional i32 maximumPageSize\n  60: optional binary nextPageToken\n}\n\nstruct GetWorkflowExecutionRawHistoryResponse {\n  10: optional binary nextPageToken\n  20: optional list<shared.DataBlob> historyBatches\n  30: optional map<string, shared.ReplicationInfo> replicationInfo\n  40: optional i32 eventStoreVersion\n}"
+const rawIDL = "// Copyright (c) 2017 Uber Technologies, Inc.\n//\n// Permission is hereby granted, free of charge, to any person obtaining a copy\n// of this software and associated documentation files (the \"Software\"), to deal\n// in the Software without restriction, including without limitation the rights\n// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell\n// copies of the Software, and to permit persons to whom the Software is\n// furnished to do so, subject to the following conditions:\n//\n// The above copyright notice and this permission notice shall be included in\n// all copies or substantial portions of the Software.\n//\n// THE SOFTWARE IS PROVIDED \"AS IS\", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR\n// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,\n// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE\n// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER\n// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,\n// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN\n// THE SOFTWARE.\n\nnamespace java com.uber.cadence.admin\n\ninclude \"shared.thrift\"\ninclude \"replicator.thrift\"\n\n/**\n* AdminService provides advanced APIs for debugging and analysis with admin privillege\n**/\nservice AdminService {\n  /**\n  * DescribeWorkflowExecution returns information about the internal states of workflow execution.\n  **/\n  DescribeWorkflowExecutionResponse DescribeWorkflowExecution(1: DescribeWorkflowExecutionRequest request)\n    throws (\n      1: shared.BadRequestError         badRequestError,\n      2: shared.InternalServiceError    internalServiceError,\n      3: shared.EntityNotExistsError    entityNotExistError,\n      4: shared.AccessDeniedError       accessDeniedError,\n    )\n\n  /**\n  * DescribeHistoryHost returns information about the internal states of a history host\n  **/\n  shared.DescribeHistoryHostResponse DescribeHistoryHost(1: shared.DescribeHistoryHostRequest request)\n    throws (\n      1: shared.BadRequestError       badRequestError,\n      2: shared.InternalServiceError  internalServiceError,\n      3: shared.AccessDeniedError     accessDeniedError,\n    )\n\n  /**\n  * Returns the raw history of specified workflow execution.  It fails with 'EntityNotExistError' if speficied workflow\n  * execution in unknown to the service.\n  **/\n  GetWorkflowExecutionRawHistoryResponse GetWorkflowExecutionRawHistory(1: GetWorkflowExecutionRawHistoryRequest getRequest)\n    throws (\n      1: shared.BadRequestError badRequestError,\n      2: shared.InternalServiceError internalServiceError,\n      3: shared.EntityNotExistsError entityNotExistError,\n      4: shared.ServiceBusyError serviceBusyError,\n    )\n\n  /**\n  * ExportWorkflowExecution returns a bundle of the complete history, the mutable state snapshot and the execution info\n  * of specified workflow execution, encoded using the requested encoding type. The bundle can be imported into another\n  * cluster using ImportWorkflowExecution, or inspected locally to reproduce issues.\n  **/\n  ExportWorkflowExecutionResponse ExportWorkflowExecution(1: ExportWorkflowExecutionRequest request)\n    throws (\n      1: shared.BadRequestError badRequestError,\n      2: shared.InternalServiceError internalServiceError,\n      3: shared.EntityNotExistsError entityNotExistError,\n      4: shared.ServiceBusyError serviceBusyError,\n    )\n\n  /**\n  * ImportWorkflowExecution recreates the workflow execution contained in a bundle returned by ExportWorkflowExecution\n  * by replicating its history into specified domain. It fails with 'BadRequestError' if the domain is not global.\n  **/\n  ImportWorkflowExecutionResponse ImportWorkflowExecution(1: ImportWorkflowExecutionRequest request)\n    throws (\n      1: shared.BadRequestError badRequestError,\n      2: shared.InternalServiceError internalServiceError,\n      3: shared.EntityNotExistsError entityNotExistError,\n      4: shared.ServiceBusyError serviceBusyError,\n    )\n\n  /**\n  * MigrateWorkflowExecution copies a workflow execution of specified domain from a remote cluster into this cluster.\n  * Only history batches missing in this cluster are imported, so it can be called repeatedly while the execution is\n  * still making progress in the remote cluster. The migration is verified by comparing the next event ID of both\n  * copies of the execution. It fails with 'BadRequestError' if the domain is not global in this cluster.\n  **/\n  MigrateWorkflowExecutionResponse MigrateWorkflowExecution(1: MigrateWorkflowExecutionRequest request)\n    throws (\n      1: shared.BadRequestError badRequestError,\n      2: shared.InternalServiceError internalServiceError,\n      3: shared.EntityNotExistsError entityNotExistError,\n      4: shared.ServiceBusyError serviceBusyError,\n    )\n\n  /**\n  * AddRemoteCluster adds a remote cluster to the cluster metadata at runtime. The cluster is persisted and picked up\n  * by all the hosts of this cluster, which start replicating from it without a redeployment. It fails with\n  * 'BadRequestError' if the cluster name or the initial failover version is already used.\n  **/\n  AddRemoteClusterResponse AddRemoteCluster(1: AddRemoteClusterRequest request)\n    throws (\n      1: shared.BadRequestError badRequestError,\n      2: shared.InternalServiceError internalServiceError,\n      3: shared.EntityNotExistsError entityNotExistError,\n      4: shared.ServiceBusyError serviceBusyError,\n    )\n\n  /**\n  * GetReplicationTasks returns the replication tasks of the shards in [minShardId, maxShardId], in the form they are\n  * published to remote clusters, so external tooling can audit replication without consuming the replication topic.\n  * Shards are read in order and the next page token tracks both the shard and the position within the shard.\n  **/\n  GetReplicationTasksResponse GetReplicationTasks(1: GetReplicationTasksRequest request)\n    throws (\n      1: shared.BadRequestError badRequestError,\n      2: shared.InternalServiceError internalServiceError,\n      3: shared.EntityNotExistsError entityNotExistError,\n      4: shared.ServiceBusyError serviceBusyError,\n    )\n\n  /**\n  * GetDLQReplicationTasks returns the replication tasks of the shards in [minShardId, maxShardId] which this cluster\n  * failed to apply and moved to its replication DLQ topic. The tasks are read without being consumed.\n  **/\n  GetDLQReplicationTasksResponse GetDLQReplicationTasks(1: GetDLQReplicationTasksRequest request)\n    throws (\n      1: shared.BadRequestError badRequestError,\n      2: shared.InternalServiceError internalServiceError,\n      3: shared.EntityNotExistsError entityNotExistError,\n      4: shared.ServiceBusyError serviceBusyError,\n    )\n\n  /**\n  * AddSearchAttribute adds search attribute keys to the ones visibility queries can use. The keys are added to the\n  * mapping of the ElasticSearch visibility index and persisted in the cluster metadata. It fails with\n  * 'BadRequestError' if a key is already valid or advanced visibility is not enabled.\n  **/\n  AddSearchAttributeResponse AddSearchAttribute(1: AddSearchAttributeRequest request)\n    throws (\n      1: shared.BadRequestError badRequestError,\n      2: shared.InternalServiceError internalServiceError,\n      3: shared.EntityNotExistsError entityNotExistError,\n      4: shared.ServiceBusyError serviceBusyError,\n    )\n\n  /**\n  * GetReplicationConsumerLag returns how far the replicator of this cluster is behind on the replication topic of\n  * each remote cluster, or of 'sourceCluster' when set. The committed offsets of the replicator consumers are read\n  * twice, 'sampleIntervalInSeconds' apart, to compute the processing and produce rates of each topic.\n  **/\n  GetReplicationConsumerLagResponse GetReplicationConsumerLag(1: GetReplicationConsumerLagRequest request)\n    throws (\n      1: shared.BadRequestError badRequestError,\n      2: shared.InternalServiceError internalServiceError,\n      3: shared.EntityNotExistsError entityNotExistError,\n      4: shared.ServiceBusyError serviceBusyError,\n    )\n}\n\nstruct DescribeWorkflowExecutionRequest {\n  10: optional string                       domain\n  20: optional shared.WorkflowExecution     execution\n}\n\nstruct DescribeWorkflowExecutionResponse{\n  10: optional string shardId\n  20: optional string historyAddr\n  40: optional string mutableStateInCache\n  50: optional string mutableStateInDatabase\n}\n\nstruct GetWorkflowExecutionRawHistoryRequest {\n  10: optional string domain\n  20: optional shared.WorkflowExecution execution\n  30: optional i64 (js.type = \"Long\") firstEventId\n  40: optional i64 (js.type = \"Long\") nextEventId\n  50: optional i32 maximumPageSize\n  60: optional binary nextPageToken\n}\n\nstruct GetWorkflowExecutionRawHistoryResponse {\n  10: optional binary nextPageToken\n  20: optional list<shared.DataBlob> historyBatches\n  30: optional map<string, shared.ReplicationInfo> replicationInfo\n  40: optional i32 eventStoreVersion\n}\n\nstruct ExportWorkflowExecutionRequest {\n  10: optional string domain\n  20: optional shared.WorkflowExecution execution\n  30: optional shared.EncodingType encodingType\n}\n\nstruct ExportWorkflowExecutionResponse {\n  10: optional shared.DataBlob bundle\n}\n\nstruct ImportWorkflowExecutionRequest {\n  10: optional string domain\n  20: optional shared.DataBlob bundle\n}\n\nstruct ImportWorkflowExecutionResponse {\n  10: optional shared.WorkflowExecution execution\n}\n\nstruct MigrateWorkflowExecutionRequest {\n  10: optional string domain\n  20: optional shared.WorkflowExecution execution\n  30: optional string sourceCluster\n}\n\nstruct MigrateWorkflowExecutionResponse {\n  10: optional i32 importedBatchCount\n  20: optional i64 (js.type = \"Long\") nextEventId\n}\n\nstruct AddRemoteClusterRequest {\n  10: optional string clusterName\n  20: optional i64 (js.type = \"Long\") initialFailoverVersion\n  30: optional string rpcName\n  40: optional string rpcAddress\n}\n\nstruct AddRemoteClusterResponse {\n}\n\nstruct GetReplicationTasksRequest {\n  10: optional i32 minShardId\n  20: optional i32 maxShardId\n  30: optional i64 (js.type = \"Long\") minTaskId\n  40: optional i64 (js.type = \"Long\") maxTaskId\n  50: optional i32 maximumPageSize\n  60: optional binary nextPageToken\n}\n\nstruct GetReplicationTasksResponse {\n  10: optional list<replicator.ReplicationTask> tasks\n  20: optional binary nextPageToken\n}\n\nstruct GetDLQReplicationTasksRequest {\n  10: optional i32 minShardId\n  20: optional i32 maxShardId\n  30: optional i32 maximumPageSize\n  40: optional binary nextPageToken\n}\n\nstruct GetDLQReplicationTasksResponse {\n  10: optional list<replicator.ReplicationTask> tasks\n  20: optional binary nextPageToken\n}\n\nstruct AddSearchAttributeRequest {\n  10: optional map<string, shared.IndexedValueType> searchAttribute\n}\n\nstruct AddSearchAttributeResponse {\n}\n\nstruct GetReplicationConsumerLagRequest {\n  10: optional string sourceCluster\n  20: optional i32 sampleIntervalInSeconds\n}\n\nstruct GetReplicationConsumerLagResponse {\n  10: optional list<ReplicationConsumerLag> consumers\n}\n\nstruct ReplicationConsumerLag {\n  10: optional string sourceCluster\n  20: optional string topic\n  30: optional string consumerName\n  40: optional i64 lag\n  // messages committed by the consumer per second\n  50: optional double processingRate\n  // messages published to the topic per second\n  60: optional double produceRate\n  70: optional list<ReplicationPartitionLag> partitions\n}\n\nstruct ReplicationPartitionLag {\n  10: optional i32 partition\n  20: optional i64 committedOffset\n  30: optional i64 highWatermark\n  40: optional i64 lag\n}\n\nstruct WorkflowExecutionBundle {\n  10: optional string domain\n  20: optional shared.WorkflowExecution execution\n  30: optional i32 eventStoreVersion\n  40: optional map<string, shared.ReplicationInfo> replicationInfo\n  50: optional list<shared.History> historyBatches\n  60: optional string mutableState\n  70: optional shared.WorkflowExecutionInfo executionInfo\n}\n"
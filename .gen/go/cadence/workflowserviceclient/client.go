@@ -36,6 +36,12 @@ import (
 
 // Interface is a client for the WorkflowService service.
 type Interface interface {
+	CountOpenWorkflowExecutions(
+		ctx context.Context,
+		CountRequest *shared.CountOpenWorkflowExecutionsRequest,
+		opts ...yarpc.CallOption,
+	) (*shared.CountOpenWorkflowExecutionsResponse, error)
+
 	DeprecateDomain(
 		ctx context.Context,
 		DeprecateRequest *shared.DeprecateDomainRequest,
@@ -60,12 +66,29 @@ type Interface interface {
 		opts ...yarpc.CallOption,
 	) (*shared.DescribeWorkflowExecutionResponse, error)
 
+	DescribeWorkflowExecutions(
+		ctx context.Context,
+		DescribeRequest *shared.DescribeWorkflowExecutionsRequest,
+		opts ...yarpc.CallOption,
+	) (*shared.DescribeWorkflowExecutionsResponse, error)
+
+	GetSearchAttributes(
+		ctx context.Context,
+		opts ...yarpc.CallOption,
+	) (*shared.GetSearchAttributesResponse, error)
+
 	GetWorkflowExecutionHistory(
 		ctx context.Context,
 		GetRequest *shared.GetWorkflowExecutionHistoryRequest,
 		opts ...yarpc.CallOption,
 	) (*shared.GetWorkflowExecutionHistoryResponse, error)
 
+	GetWorkflowExecutionStatistics(
+		ctx context.Context,
+		StatisticsRequest *shared.GetWorkflowExecutionStatisticsRequest,
+		opts ...yarpc.CallOption,
+	) (*shared.GetWorkflowExecutionStatisticsResponse, error)
+
 	ListClosedWorkflowExecutions(
 		ctx context.Context,
 		ListRequest *shared.ListClosedWorkflowExecutionsRequest,
@@ -221,6 +244,12 @@ type Interface interface {
 		UpdateRequest *shared.UpdateDomainRequest,
 		opts ...yarpc.CallOption,
 	) (*shared.UpdateDomainResponse, error)
+
+	UpdateWorkflowExecutionLabels(
+		ctx context.Context,
+		UpdateRequest *shared.UpdateWorkflowExecutionLabelsRequest,
+		opts ...yarpc.CallOption,
+	) error
 }
 
 // New builds a new client for the WorkflowService service.
@@ -247,6 +276,29 @@ type client struct {
 	c thrift.Client
 }
 
+func (c client) CountOpenWorkflowExecutions(
+	ctx context.Context,
+	_CountRequest *shared.CountOpenWorkflowExecutionsRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.CountOpenWorkflowExecutionsResponse, err error) {
+
+	args := cadence.WorkflowService_CountOpenWorkflowExecutions_Helper.Args(_CountRequest)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result cadence.WorkflowService_CountOpenWorkflowExecutions_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = cadence.WorkflowService_CountOpenWorkflowExecutions_Helper.UnwrapResponse(&result)
+	return
+}
+
 func (c client) DeprecateDomain(
 	ctx context.Context,
 	_DeprecateRequest *shared.DeprecateDomainRequest,
@@ -339,6 +391,51 @@ func (c client) DescribeWorkflowExecution(
 	return
 }
 
+func (c client) DescribeWorkflowExecutions(
+	ctx context.Context,
+	_DescribeRequest *shared.DescribeWorkflowExecutionsRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.DescribeWorkflowExecutionsResponse, err error) {
+
+	args := cadence.WorkflowService_DescribeWorkflowExecutions_Helper.Args(_DescribeRequest)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result cadence.WorkflowService_DescribeWorkflowExecutions_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = cadence.WorkflowService_DescribeWorkflowExecutions_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) GetSearchAttributes(
+	ctx context.Context,
+	opts ...yarpc.CallOption,
+) (success *shared.GetSearchAttributesResponse, err error) {
+
+	args := cadence.WorkflowService_GetSearchAttributes_Helper.Args()
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result cadence.WorkflowService_GetSearchAttributes_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = cadence.WorkflowService_GetSearchAttributes_Helper.UnwrapResponse(&result)
+	return
+}
+
 func (c client) GetWorkflowExecutionHistory(
 	ctx context.Context,
 	_GetRequest *shared.GetWorkflowExecutionHistoryRequest,
@@ -362,6 +459,29 @@ func (c client) GetWorkflowExecutionHistory(
 	return
 }
 
+func (c client) GetWorkflowExecutionStatistics(
+	ctx context.Context,
+	_StatisticsRequest *shared.GetWorkflowExecutionStatisticsRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.GetWorkflowExecutionStatisticsResponse, err error) {
+
+	args := cadence.WorkflowService_GetWorkflowExecutionStatistics_Helper.Args(_StatisticsRequest)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result cadence.WorkflowService_GetWorkflowExecutionStatistics_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = cadence.WorkflowService_GetWorkflowExecutionStatistics_Helper.UnwrapResponse(&result)
+	return
+}
+
 func (c client) ListClosedWorkflowExecutions(
 	ctx context.Context,
 	_ListRequest *shared.ListClosedWorkflowExecutionsRequest,
@@ -959,3 +1079,26 @@ func (c client) UpdateDomain(
 	success, err = cadence.WorkflowService_UpdateDomain_Helper.UnwrapResponse(&result)
 	return
 }
+
+func (c client) UpdateWorkflowExecutionLabels(
+	ctx context.Context,
+	_UpdateRequest *shared.UpdateWorkflowExecutionLabelsRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := cadence.WorkflowService_UpdateWorkflowExecutionLabels_Helper.Args(_UpdateRequest)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result cadence.WorkflowService_UpdateWorkflowExecutionLabels_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	err = cadence.WorkflowService_UpdateWorkflowExecutionLabels_Helper.UnwrapResponse(&result)
+	return
+}
@@ -62,6 +62,39 @@ func (m *MockClient) EXPECT() *_MockClientRecorder {
 	return m.recorder
 }
 
+// CountOpenWorkflowExecutions responds to a CountOpenWorkflowExecutions call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().CountOpenWorkflowExecutions(gomock.Any(), ...).Return(...)
+// 	... := client.CountOpenWorkflowExecutions(...)
+func (m *MockClient) CountOpenWorkflowExecutions(
+	ctx context.Context,
+	_CountRequest *shared.CountOpenWorkflowExecutionsRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.CountOpenWorkflowExecutionsResponse, err error) {
+
+	args := []interface{}{ctx, _CountRequest}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "CountOpenWorkflowExecutions", args...)
+	success, _ = ret[i].(*shared.CountOpenWorkflowExecutionsResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) CountOpenWorkflowExecutions(
+	ctx interface{},
+	_CountRequest interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _CountRequest}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "CountOpenWorkflowExecutions", args...)
+}
+
 // DeprecateDomain responds to a DeprecateDomain call based on the mock expectations. This
 // call will fail if the mock does not expect this call. Use EXPECT to expect
 // a call to this function.
@@ -192,6 +225,70 @@ func (mr *_MockClientRecorder) DescribeWorkflowExecution(
 	return mr.mock.ctrl.RecordCall(mr.mock, "DescribeWorkflowExecution", args...)
 }
 
+// DescribeWorkflowExecutions responds to a DescribeWorkflowExecutions call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().DescribeWorkflowExecutions(gomock.Any(), ...).Return(...)
+// 	... := client.DescribeWorkflowExecutions(...)
+func (m *MockClient) DescribeWorkflowExecutions(
+	ctx context.Context,
+	_DescribeRequest *shared.DescribeWorkflowExecutionsRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.DescribeWorkflowExecutionsResponse, err error) {
+
+	args := []interface{}{ctx, _DescribeRequest}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "DescribeWorkflowExecutions", args...)
+	success, _ = ret[i].(*shared.DescribeWorkflowExecutionsResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) DescribeWorkflowExecutions(
+	ctx interface{},
+	_DescribeRequest interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _DescribeRequest}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "DescribeWorkflowExecutions", args...)
+}
+
+// GetSearchAttributes responds to a GetSearchAttributes call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().GetSearchAttributes(gomock.Any(), ...).Return(...)
+// 	... := client.GetSearchAttributes(...)
+func (m *MockClient) GetSearchAttributes(
+	ctx context.Context,
+	opts ...yarpc.CallOption,
+) (success *shared.GetSearchAttributesResponse, err error) {
+
+	args := []interface{}{ctx}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "GetSearchAttributes", args...)
+	success, _ = ret[i].(*shared.GetSearchAttributesResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) GetSearchAttributes(
+	ctx interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "GetSearchAttributes", args...)
+}
+
 // GetWorkflowExecutionHistory responds to a GetWorkflowExecutionHistory call based on the mock expectations. This
 // call will fail if the mock does not expect this call. Use EXPECT to expect
 // a call to this function.
@@ -225,6 +322,39 @@ func (mr *_MockClientRecorder) GetWorkflowExecutionHistory(
 	return mr.mock.ctrl.RecordCall(mr.mock, "GetWorkflowExecutionHistory", args...)
 }
 
+// GetWorkflowExecutionStatistics responds to a GetWorkflowExecutionStatistics call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().GetWorkflowExecutionStatistics(gomock.Any(), ...).Return(...)
+// 	... := client.GetWorkflowExecutionStatistics(...)
+func (m *MockClient) GetWorkflowExecutionStatistics(
+	ctx context.Context,
+	_StatisticsRequest *shared.GetWorkflowExecutionStatisticsRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.GetWorkflowExecutionStatisticsResponse, err error) {
+
+	args := []interface{}{ctx, _StatisticsRequest}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "GetWorkflowExecutionStatistics", args...)
+	success, _ = ret[i].(*shared.GetWorkflowExecutionStatisticsResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) GetWorkflowExecutionStatistics(
+	ctx interface{},
+	_StatisticsRequest interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _StatisticsRequest}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "GetWorkflowExecutionStatistics", args...)
+}
+
 // ListClosedWorkflowExecutions responds to a ListClosedWorkflowExecutions call based on the mock expectations. This
 // call will fail if the mock does not expect this call. Use EXPECT to expect
 // a call to this function.
@@ -1058,3 +1188,34 @@ func (mr *_MockClientRecorder) UpdateDomain(
 	args := append([]interface{}{ctx, _UpdateRequest}, opts...)
 	return mr.mock.ctrl.RecordCall(mr.mock, "UpdateDomain", args...)
 }
+
+// UpdateWorkflowExecutionLabels responds to a UpdateWorkflowExecutionLabels call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().UpdateWorkflowExecutionLabels(gomock.Any(), ...).Return(...)
+// 	... := client.UpdateWorkflowExecutionLabels(...)
+func (m *MockClient) UpdateWorkflowExecutionLabels(
+	ctx context.Context,
+	_UpdateRequest *shared.UpdateWorkflowExecutionLabelsRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := []interface{}{ctx, _UpdateRequest}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "UpdateWorkflowExecutionLabels", args...)
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) UpdateWorkflowExecutionLabels(
+	ctx interface{},
+	_UpdateRequest interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _UpdateRequest}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "UpdateWorkflowExecutionLabels", args...)
+}
@@ -54,12 +54,24 @@ type Interface interface {
 		opts ...yarpc.CallOption,
 	) (*shared.DescribeWorkflowExecutionResponse, error)
 
+	DescribeWorkflowExecutions(
+		ctx context.Context,
+		DescribeRequest *history.DescribeWorkflowExecutionsRequest,
+		opts ...yarpc.CallOption,
+	) (*shared.DescribeWorkflowExecutionsResponse, error)
+
 	GetMutableState(
 		ctx context.Context,
 		GetRequest *history.GetMutableStateRequest,
 		opts ...yarpc.CallOption,
 	) (*history.GetMutableStateResponse, error)
 
+	GetReplicationTasks(
+		ctx context.Context,
+		Request *history.GetReplicationTasksRequest,
+		opts ...yarpc.CallOption,
+	) (*history.GetReplicationTasksResponse, error)
+
 	RecordActivityTaskHeartbeat(
 		ctx context.Context,
 		HeartbeatRequest *history.RecordActivityTaskHeartbeatRequest,
@@ -102,6 +114,12 @@ type Interface interface {
 		opts ...yarpc.CallOption,
 	) error
 
+	ReplicateEventsV2(
+		ctx context.Context,
+		ReplicateV2Request *history.ReplicateEventsV2Request,
+		opts ...yarpc.CallOption,
+	) error
+
 	RequestCancelWorkflowExecution(
 		ctx context.Context,
 		CancelRequest *history.RequestCancelWorkflowExecutionRequest,
@@ -191,6 +209,12 @@ type Interface interface {
 		TerminateRequest *history.TerminateWorkflowExecutionRequest,
 		opts ...yarpc.CallOption,
 	) error
+
+	UpdateWorkflowExecutionLabels(
+		ctx context.Context,
+		UpdateRequest *history.UpdateWorkflowExecutionLabelsRequest,
+		opts ...yarpc.CallOption,
+	) error
 }
 
 // New builds a new client for the HistoryService service.
@@ -286,6 +310,29 @@ func (c client) DescribeWorkflowExecution(
 	return
 }
 
+func (c client) DescribeWorkflowExecutions(
+	ctx context.Context,
+	_DescribeRequest *history.DescribeWorkflowExecutionsRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.DescribeWorkflowExecutionsResponse, err error) {
+
+	args := history.HistoryService_DescribeWorkflowExecutions_Helper.Args(_DescribeRequest)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result history.HistoryService_DescribeWorkflowExecutions_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = history.HistoryService_DescribeWorkflowExecutions_Helper.UnwrapResponse(&result)
+	return
+}
+
 func (c client) GetMutableState(
 	ctx context.Context,
 	_GetRequest *history.GetMutableStateRequest,
@@ -309,6 +356,29 @@ func (c client) GetMutableState(
 	return
 }
 
+func (c client) GetReplicationTasks(
+	ctx context.Context,
+	_Request *history.GetReplicationTasksRequest,
+	opts ...yarpc.CallOption,
+) (success *history.GetReplicationTasksResponse, err error) {
+
+	args := history.HistoryService_GetReplicationTasks_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result history.HistoryService_GetReplicationTasks_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = history.HistoryService_GetReplicationTasks_Helper.UnwrapResponse(&result)
+	return
+}
+
 func (c client) RecordActivityTaskHeartbeat(
 	ctx context.Context,
 	_HeartbeatRequest *history.RecordActivityTaskHeartbeatRequest,
@@ -470,6 +540,29 @@ func (c client) ReplicateRawEvents(
 	return
 }
 
+func (c client) ReplicateEventsV2(
+	ctx context.Context,
+	_ReplicateV2Request *history.ReplicateEventsV2Request,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := history.HistoryService_ReplicateEventsV2_Helper.Args(_ReplicateV2Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result history.HistoryService_ReplicateEventsV2_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	err = history.HistoryService_ReplicateEventsV2_Helper.UnwrapResponse(&result)
+	return
+}
+
 func (c client) RequestCancelWorkflowExecution(
 	ctx context.Context,
 	_CancelRequest *history.RequestCancelWorkflowExecutionRequest,
@@ -814,3 +907,26 @@ func (c client) TerminateWorkflowExecution(
 	err = history.HistoryService_TerminateWorkflowExecution_Helper.UnwrapResponse(&result)
 	return
 }
+
+func (c client) UpdateWorkflowExecutionLabels(
+	ctx context.Context,
+	_UpdateRequest *history.UpdateWorkflowExecutionLabelsRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := history.HistoryService_UpdateWorkflowExecutionLabels_Helper.Args(_UpdateRequest)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result history.HistoryService_UpdateWorkflowExecutionLabels_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	err = history.HistoryService_UpdateWorkflowExecutionLabels_Helper.UnwrapResponse(&result)
+	return
+}
@@ -599,6 +599,192 @@ func (v *DescribeWorkflowExecutionRequest) IsSetRequest() bool {
 	return v != nil && v.Request != nil
 }
 
+type DescribeWorkflowExecutionsRequest struct {
+	DomainUUID *string                                   `json:"domainUUID,omitempty"`
+	Request    *shared.DescribeWorkflowExecutionsRequest `json:"request,omitempty"`
+}
+
+// ToWire translates a DescribeWorkflowExecutionsRequest struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *DescribeWorkflowExecutionsRequest) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.DomainUUID != nil {
+		w, err = wire.NewValueString(*(v.DomainUUID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.Request != nil {
+		w, err = v.Request.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _DescribeWorkflowExecutionsRequest_Read(w wire.Value) (*shared.DescribeWorkflowExecutionsRequest, error) {
+	var v shared.DescribeWorkflowExecutionsRequest
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a DescribeWorkflowExecutionsRequest struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a DescribeWorkflowExecutionsRequest struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v DescribeWorkflowExecutionsRequest
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *DescribeWorkflowExecutionsRequest) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.DomainUUID = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TStruct {
+				v.Request, err = _DescribeWorkflowExecutionsRequest_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a DescribeWorkflowExecutionsRequest
+// struct.
+func (v *DescribeWorkflowExecutionsRequest) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [2]string
+	i := 0
+	if v.DomainUUID != nil {
+		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
+		i++
+	}
+	if v.Request != nil {
+		fields[i] = fmt.Sprintf("Request: %v", v.Request)
+		i++
+	}
+
+	return fmt.Sprintf("DescribeWorkflowExecutionsRequest{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this DescribeWorkflowExecutionsRequest match the
+// provided DescribeWorkflowExecutionsRequest.
+//
+// This function performs a deep comparison.
+func (v *DescribeWorkflowExecutionsRequest) Equals(rhs *DescribeWorkflowExecutionsRequest) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
+		return false
+	}
+	if !((v.Request == nil && rhs.Request == nil) || (v.Request != nil && rhs.Request != nil && v.Request.Equals(rhs.Request))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of DescribeWorkflowExecutionsRequest.
+func (v *DescribeWorkflowExecutionsRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.DomainUUID != nil {
+		enc.AddString("domainUUID", *v.DomainUUID)
+	}
+	if v.Request != nil {
+		err = multierr.Append(err, enc.AddObject("request", v.Request))
+	}
+	return err
+}
+
+// GetDomainUUID returns the value of DomainUUID if it is set or its
+// zero value if it is unset.
+func (v *DescribeWorkflowExecutionsRequest) GetDomainUUID() (o string) {
+	if v != nil && v.DomainUUID != nil {
+		return *v.DomainUUID
+	}
+
+	return
+}
+
+// IsSetDomainUUID returns true if DomainUUID is not nil.
+func (v *DescribeWorkflowExecutionsRequest) IsSetDomainUUID() bool {
+	return v != nil && v.DomainUUID != nil
+}
+
+// GetRequest returns the value of Request if it is set or its
+// zero value if it is unset.
+func (v *DescribeWorkflowExecutionsRequest) GetRequest() (o *shared.DescribeWorkflowExecutionsRequest) {
+	if v != nil && v.Request != nil {
+		return v.Request
+	}
+
+	return
+}
+
+// IsSetRequest returns true if Request is not nil.
+func (v *DescribeWorkflowExecutionsRequest) IsSetRequest() bool {
+	return v != nil && v.Request != nil
+}
+
 type EventAlreadyStartedError struct {
 	Message string `json:"message,required"`
 }
@@ -1841,14 +2027,15 @@ func (v *GetMutableStateResponse) IsSetReplicationInfo() bool {
 	return v != nil && v.ReplicationInfo != nil
 }
 
-type ParentExecutionInfo struct {
-	DomainUUID  *string                   `json:"domainUUID,omitempty"`
-	Domain      *string                   `json:"domain,omitempty"`
-	Execution   *shared.WorkflowExecution `json:"execution,omitempty"`
-	InitiatedId *int64                    `json:"initiatedId,omitempty"`
+type GetReplicationTasksRequest struct {
+	ShardId         *int32 `json:"shardId,omitempty"`
+	ReadLevel       *int64 `json:"readLevel,omitempty"`
+	MaxReadLevel    *int64 `json:"maxReadLevel,omitempty"`
+	MaximumPageSize *int32 `json:"maximumPageSize,omitempty"`
+	NextPageToken   []byte `json:"nextPageToken,omitempty"`
 }
 
-// ToWire translates a ParentExecutionInfo struct into a Thrift-level intermediate
+// ToWire translates a GetReplicationTasksRequest struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -1863,55 +2050,63 @@ type ParentExecutionInfo struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *ParentExecutionInfo) ToWire() (wire.Value, error) {
+func (v *GetReplicationTasksRequest) ToWire() (wire.Value, error) {
 	var (
-		fields [4]wire.Field
+		fields [5]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
 	)
 
-	if v.DomainUUID != nil {
-		w, err = wire.NewValueString(*(v.DomainUUID)), error(nil)
+	if v.ShardId != nil {
+		w, err = wire.NewValueI32(*(v.ShardId)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.Domain != nil {
-		w, err = wire.NewValueString(*(v.Domain)), error(nil)
+	if v.ReadLevel != nil {
+		w, err = wire.NewValueI64(*(v.ReadLevel)), error(nil)
 		if err != nil {
 			return w, err
 		}
-		fields[i] = wire.Field{ID: 15, Value: w}
+		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
-	if v.Execution != nil {
-		w, err = v.Execution.ToWire()
+	if v.MaxReadLevel != nil {
+		w, err = wire.NewValueI64(*(v.MaxReadLevel)), error(nil)
 		if err != nil {
 			return w, err
 		}
-		fields[i] = wire.Field{ID: 20, Value: w}
+		fields[i] = wire.Field{ID: 30, Value: w}
 		i++
 	}
-	if v.InitiatedId != nil {
-		w, err = wire.NewValueI64(*(v.InitiatedId)), error(nil)
+	if v.MaximumPageSize != nil {
+		w, err = wire.NewValueI32(*(v.MaximumPageSize)), error(nil)
 		if err != nil {
 			return w, err
 		}
-		fields[i] = wire.Field{ID: 30, Value: w}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+	if v.NextPageToken != nil {
+		w, err = wire.NewValueBinary(v.NextPageToken), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 50, Value: w}
 		i++
 	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-// FromWire deserializes a ParentExecutionInfo struct from its Thrift-level
+// FromWire deserializes a GetReplicationTasksRequest struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a ParentExecutionInfo struct
+// An error is returned if we were unable to build a GetReplicationTasksRequest struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -1919,39 +2114,31 @@ func (v *ParentExecutionInfo) ToWire() (wire.Value, error) {
 //     return nil, err
 //   }
 //
-//   var v ParentExecutionInfo
+//   var v GetReplicationTasksRequest
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *ParentExecutionInfo) FromWire(w wire.Value) error {
+func (v *GetReplicationTasksRequest) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
 		case 10:
-			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.DomainUUID = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		case 15:
-			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.Domain = &x
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.ShardId = &x
 				if err != nil {
 					return err
 				}
 
 			}
 		case 20:
-			if field.Value.Type() == wire.TStruct {
-				v.Execution, err = _WorkflowExecution_Read(field.Value)
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.ReadLevel = &x
 				if err != nil {
 					return err
 				}
@@ -1961,7 +2148,25 @@ func (v *ParentExecutionInfo) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TI64 {
 				var x int64
 				x, err = field.Value.GetI64(), error(nil)
-				v.InitiatedId = &x
+				v.MaxReadLevel = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.MaximumPageSize = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 50:
+			if field.Value.Type() == wire.TBinary {
+				v.NextPageToken, err = field.Value.GetBinary(), error(nil)
 				if err != nil {
 					return err
 				}
@@ -1973,55 +2178,62 @@ func (v *ParentExecutionInfo) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a ParentExecutionInfo
+// String returns a readable string representation of a GetReplicationTasksRequest
 // struct.
-func (v *ParentExecutionInfo) String() string {
+func (v *GetReplicationTasksRequest) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [4]string
+	var fields [5]string
 	i := 0
-	if v.DomainUUID != nil {
-		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
+	if v.ShardId != nil {
+		fields[i] = fmt.Sprintf("ShardId: %v", *(v.ShardId))
 		i++
 	}
-	if v.Domain != nil {
-		fields[i] = fmt.Sprintf("Domain: %v", *(v.Domain))
+	if v.ReadLevel != nil {
+		fields[i] = fmt.Sprintf("ReadLevel: %v", *(v.ReadLevel))
 		i++
 	}
-	if v.Execution != nil {
-		fields[i] = fmt.Sprintf("Execution: %v", v.Execution)
+	if v.MaxReadLevel != nil {
+		fields[i] = fmt.Sprintf("MaxReadLevel: %v", *(v.MaxReadLevel))
 		i++
 	}
-	if v.InitiatedId != nil {
-		fields[i] = fmt.Sprintf("InitiatedId: %v", *(v.InitiatedId))
+	if v.MaximumPageSize != nil {
+		fields[i] = fmt.Sprintf("MaximumPageSize: %v", *(v.MaximumPageSize))
+		i++
+	}
+	if v.NextPageToken != nil {
+		fields[i] = fmt.Sprintf("NextPageToken: %v", v.NextPageToken)
 		i++
 	}
 
-	return fmt.Sprintf("ParentExecutionInfo{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("GetReplicationTasksRequest{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this ParentExecutionInfo match the
-// provided ParentExecutionInfo.
+// Equals returns true if all the fields of this GetReplicationTasksRequest match the
+// provided GetReplicationTasksRequest.
 //
 // This function performs a deep comparison.
-func (v *ParentExecutionInfo) Equals(rhs *ParentExecutionInfo) bool {
+func (v *GetReplicationTasksRequest) Equals(rhs *GetReplicationTasksRequest) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
 		return false
 	}
-	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
+	if !_I32_EqualsPtr(v.ShardId, rhs.ShardId) {
 		return false
 	}
-	if !_String_EqualsPtr(v.Domain, rhs.Domain) {
+	if !_I64_EqualsPtr(v.ReadLevel, rhs.ReadLevel) {
 		return false
 	}
-	if !((v.Execution == nil && rhs.Execution == nil) || (v.Execution != nil && rhs.Execution != nil && v.Execution.Equals(rhs.Execution))) {
+	if !_I64_EqualsPtr(v.MaxReadLevel, rhs.MaxReadLevel) {
 		return false
 	}
-	if !_I64_EqualsPtr(v.InitiatedId, rhs.InitiatedId) {
+	if !_I32_EqualsPtr(v.MaximumPageSize, rhs.MaximumPageSize) {
+		return false
+	}
+	if !((v.NextPageToken == nil && rhs.NextPageToken == nil) || (v.NextPageToken != nil && rhs.NextPageToken != nil && bytes.Equal(v.NextPageToken, rhs.NextPageToken))) {
 		return false
 	}
 
@@ -2029,92 +2241,139 @@ func (v *ParentExecutionInfo) Equals(rhs *ParentExecutionInfo) bool {
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of ParentExecutionInfo.
-func (v *ParentExecutionInfo) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of GetReplicationTasksRequest.
+func (v *GetReplicationTasksRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
-	if v.DomainUUID != nil {
-		enc.AddString("domainUUID", *v.DomainUUID)
+	if v.ShardId != nil {
+		enc.AddInt32("shardId", *v.ShardId)
 	}
-	if v.Domain != nil {
-		enc.AddString("domain", *v.Domain)
+	if v.ReadLevel != nil {
+		enc.AddInt64("readLevel", *v.ReadLevel)
 	}
-	if v.Execution != nil {
-		err = multierr.Append(err, enc.AddObject("execution", v.Execution))
+	if v.MaxReadLevel != nil {
+		enc.AddInt64("maxReadLevel", *v.MaxReadLevel)
 	}
-	if v.InitiatedId != nil {
-		enc.AddInt64("initiatedId", *v.InitiatedId)
+	if v.MaximumPageSize != nil {
+		enc.AddInt32("maximumPageSize", *v.MaximumPageSize)
+	}
+	if v.NextPageToken != nil {
+		enc.AddString("nextPageToken", base64.StdEncoding.EncodeToString(v.NextPageToken))
 	}
 	return err
 }
 
-// GetDomainUUID returns the value of DomainUUID if it is set or its
+// GetShardId returns the value of ShardId if it is set or its
 // zero value if it is unset.
-func (v *ParentExecutionInfo) GetDomainUUID() (o string) {
-	if v != nil && v.DomainUUID != nil {
-		return *v.DomainUUID
+func (v *GetReplicationTasksRequest) GetShardId() (o int32) {
+	if v != nil && v.ShardId != nil {
+		return *v.ShardId
 	}
 
 	return
 }
 
-// IsSetDomainUUID returns true if DomainUUID is not nil.
-func (v *ParentExecutionInfo) IsSetDomainUUID() bool {
-	return v != nil && v.DomainUUID != nil
+// IsSetShardId returns true if ShardId is not nil.
+func (v *GetReplicationTasksRequest) IsSetShardId() bool {
+	return v != nil && v.ShardId != nil
 }
 
-// GetDomain returns the value of Domain if it is set or its
+// GetReadLevel returns the value of ReadLevel if it is set or its
 // zero value if it is unset.
-func (v *ParentExecutionInfo) GetDomain() (o string) {
-	if v != nil && v.Domain != nil {
-		return *v.Domain
+func (v *GetReplicationTasksRequest) GetReadLevel() (o int64) {
+	if v != nil && v.ReadLevel != nil {
+		return *v.ReadLevel
 	}
 
 	return
 }
 
-// IsSetDomain returns true if Domain is not nil.
-func (v *ParentExecutionInfo) IsSetDomain() bool {
-	return v != nil && v.Domain != nil
+// IsSetReadLevel returns true if ReadLevel is not nil.
+func (v *GetReplicationTasksRequest) IsSetReadLevel() bool {
+	return v != nil && v.ReadLevel != nil
 }
 
-// GetExecution returns the value of Execution if it is set or its
+// GetMaxReadLevel returns the value of MaxReadLevel if it is set or its
 // zero value if it is unset.
-func (v *ParentExecutionInfo) GetExecution() (o *shared.WorkflowExecution) {
-	if v != nil && v.Execution != nil {
-		return v.Execution
+func (v *GetReplicationTasksRequest) GetMaxReadLevel() (o int64) {
+	if v != nil && v.MaxReadLevel != nil {
+		return *v.MaxReadLevel
 	}
 
 	return
 }
 
-// IsSetExecution returns true if Execution is not nil.
-func (v *ParentExecutionInfo) IsSetExecution() bool {
-	return v != nil && v.Execution != nil
+// IsSetMaxReadLevel returns true if MaxReadLevel is not nil.
+func (v *GetReplicationTasksRequest) IsSetMaxReadLevel() bool {
+	return v != nil && v.MaxReadLevel != nil
 }
 
-// GetInitiatedId returns the value of InitiatedId if it is set or its
+// GetMaximumPageSize returns the value of MaximumPageSize if it is set or its
 // zero value if it is unset.
-func (v *ParentExecutionInfo) GetInitiatedId() (o int64) {
-	if v != nil && v.InitiatedId != nil {
-		return *v.InitiatedId
+func (v *GetReplicationTasksRequest) GetMaximumPageSize() (o int32) {
+	if v != nil && v.MaximumPageSize != nil {
+		return *v.MaximumPageSize
 	}
 
 	return
 }
 
-// IsSetInitiatedId returns true if InitiatedId is not nil.
-func (v *ParentExecutionInfo) IsSetInitiatedId() bool {
-	return v != nil && v.InitiatedId != nil
+// IsSetMaximumPageSize returns true if MaximumPageSize is not nil.
+func (v *GetReplicationTasksRequest) IsSetMaximumPageSize() bool {
+	return v != nil && v.MaximumPageSize != nil
 }
 
-type RecordActivityTaskHeartbeatRequest struct {
-	DomainUUID       *string                                    `json:"domainUUID,omitempty"`
-	HeartbeatRequest *shared.RecordActivityTaskHeartbeatRequest `json:"heartbeatRequest,omitempty"`
+// GetNextPageToken returns the value of NextPageToken if it is set or its
+// zero value if it is unset.
+func (v *GetReplicationTasksRequest) GetNextPageToken() (o []byte) {
+	if v != nil && v.NextPageToken != nil {
+		return v.NextPageToken
+	}
+
+	return
 }
 
-// ToWire translates a RecordActivityTaskHeartbeatRequest struct into a Thrift-level intermediate
+// IsSetNextPageToken returns true if NextPageToken is not nil.
+func (v *GetReplicationTasksRequest) IsSetNextPageToken() bool {
+	return v != nil && v.NextPageToken != nil
+}
+
+type GetReplicationTasksResponse struct {
+	Tasks         []*shared.DataBlob `json:"tasks,omitempty"`
+	NextPageToken []byte             `json:"nextPageToken,omitempty"`
+}
+
+type _List_DataBlob_ValueList []*shared.DataBlob
+
+func (v _List_DataBlob_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_DataBlob_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_DataBlob_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_DataBlob_ValueList) Close() {}
+
+// ToWire translates a GetReplicationTasksResponse struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -2129,7 +2388,7 @@ type RecordActivityTaskHeartbeatRequest struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *RecordActivityTaskHeartbeatRequest) ToWire() (wire.Value, error) {
+func (v *GetReplicationTasksResponse) ToWire() (wire.Value, error) {
 	var (
 		fields [2]wire.Field
 		i      int = 0
@@ -2137,16 +2396,16 @@ func (v *RecordActivityTaskHeartbeatRequest) ToWire() (wire.Value, error) {
 		err    error
 	)
 
-	if v.DomainUUID != nil {
-		w, err = wire.NewValueString(*(v.DomainUUID)), error(nil)
+	if v.Tasks != nil {
+		w, err = wire.NewValueList(_List_DataBlob_ValueList(v.Tasks)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.HeartbeatRequest != nil {
-		w, err = v.HeartbeatRequest.ToWire()
+	if v.NextPageToken != nil {
+		w, err = wire.NewValueBinary(v.NextPageToken), error(nil)
 		if err != nil {
 			return w, err
 		}
@@ -2157,17 +2416,29 @@ func (v *RecordActivityTaskHeartbeatRequest) ToWire() (wire.Value, error) {
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _RecordActivityTaskHeartbeatRequest_Read(w wire.Value) (*shared.RecordActivityTaskHeartbeatRequest, error) {
-	var v shared.RecordActivityTaskHeartbeatRequest
-	err := v.FromWire(w)
-	return &v, err
+func _List_DataBlob_Read(l wire.ValueList) ([]*shared.DataBlob, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make([]*shared.DataBlob, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _DataBlob_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
 }
 
-// FromWire deserializes a RecordActivityTaskHeartbeatRequest struct from its Thrift-level
+// FromWire deserializes a GetReplicationTasksResponse struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a RecordActivityTaskHeartbeatRequest struct
+// An error is returned if we were unable to build a GetReplicationTasksResponse struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -2175,29 +2446,27 @@ func _RecordActivityTaskHeartbeatRequest_Read(w wire.Value) (*shared.RecordActiv
 //     return nil, err
 //   }
 //
-//   var v RecordActivityTaskHeartbeatRequest
+//   var v GetReplicationTasksResponse
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *RecordActivityTaskHeartbeatRequest) FromWire(w wire.Value) error {
+func (v *GetReplicationTasksResponse) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
 		case 10:
-			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.DomainUUID = &x
+			if field.Value.Type() == wire.TList {
+				v.Tasks, err = _List_DataBlob_Read(field.Value.GetList())
 				if err != nil {
 					return err
 				}
 
 			}
 		case 20:
-			if field.Value.Type() == wire.TStruct {
-				v.HeartbeatRequest, err = _RecordActivityTaskHeartbeatRequest_Read(field.Value)
+			if field.Value.Type() == wire.TBinary {
+				v.NextPageToken, err = field.Value.GetBinary(), error(nil)
 				if err != nil {
 					return err
 				}
@@ -2209,102 +2478,126 @@ func (v *RecordActivityTaskHeartbeatRequest) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a RecordActivityTaskHeartbeatRequest
+// String returns a readable string representation of a GetReplicationTasksResponse
 // struct.
-func (v *RecordActivityTaskHeartbeatRequest) String() string {
+func (v *GetReplicationTasksResponse) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
 	var fields [2]string
 	i := 0
-	if v.DomainUUID != nil {
-		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
+	if v.Tasks != nil {
+		fields[i] = fmt.Sprintf("Tasks: %v", v.Tasks)
 		i++
 	}
-	if v.HeartbeatRequest != nil {
-		fields[i] = fmt.Sprintf("HeartbeatRequest: %v", v.HeartbeatRequest)
+	if v.NextPageToken != nil {
+		fields[i] = fmt.Sprintf("NextPageToken: %v", v.NextPageToken)
 		i++
 	}
 
-	return fmt.Sprintf("RecordActivityTaskHeartbeatRequest{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("GetReplicationTasksResponse{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this RecordActivityTaskHeartbeatRequest match the
-// provided RecordActivityTaskHeartbeatRequest.
+func _List_DataBlob_Equals(lhs, rhs []*shared.DataBlob) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Equals returns true if all the fields of this GetReplicationTasksResponse match the
+// provided GetReplicationTasksResponse.
 //
 // This function performs a deep comparison.
-func (v *RecordActivityTaskHeartbeatRequest) Equals(rhs *RecordActivityTaskHeartbeatRequest) bool {
+func (v *GetReplicationTasksResponse) Equals(rhs *GetReplicationTasksResponse) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
 		return false
 	}
-	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
+	if !((v.Tasks == nil && rhs.Tasks == nil) || (v.Tasks != nil && rhs.Tasks != nil && _List_DataBlob_Equals(v.Tasks, rhs.Tasks))) {
 		return false
 	}
-	if !((v.HeartbeatRequest == nil && rhs.HeartbeatRequest == nil) || (v.HeartbeatRequest != nil && rhs.HeartbeatRequest != nil && v.HeartbeatRequest.Equals(rhs.HeartbeatRequest))) {
+	if !((v.NextPageToken == nil && rhs.NextPageToken == nil) || (v.NextPageToken != nil && rhs.NextPageToken != nil && bytes.Equal(v.NextPageToken, rhs.NextPageToken))) {
 		return false
 	}
 
 	return true
 }
 
+type _List_DataBlob_Zapper []*shared.DataBlob
+
+// MarshalLogArray implements zapcore.ArrayMarshaler, enabling
+// fast logging of _List_DataBlob_Zapper.
+func (l _List_DataBlob_Zapper) MarshalLogArray(enc zapcore.ArrayEncoder) (err error) {
+	for _, v := range l {
+		err = multierr.Append(err, enc.AppendObject(v))
+	}
+	return err
+}
+
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of RecordActivityTaskHeartbeatRequest.
-func (v *RecordActivityTaskHeartbeatRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of GetReplicationTasksResponse.
+func (v *GetReplicationTasksResponse) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
-	if v.DomainUUID != nil {
-		enc.AddString("domainUUID", *v.DomainUUID)
+	if v.Tasks != nil {
+		err = multierr.Append(err, enc.AddArray("tasks", (_List_DataBlob_Zapper)(v.Tasks)))
 	}
-	if v.HeartbeatRequest != nil {
-		err = multierr.Append(err, enc.AddObject("heartbeatRequest", v.HeartbeatRequest))
+	if v.NextPageToken != nil {
+		enc.AddString("nextPageToken", base64.StdEncoding.EncodeToString(v.NextPageToken))
 	}
 	return err
 }
 
-// GetDomainUUID returns the value of DomainUUID if it is set or its
+// GetTasks returns the value of Tasks if it is set or its
 // zero value if it is unset.
-func (v *RecordActivityTaskHeartbeatRequest) GetDomainUUID() (o string) {
-	if v != nil && v.DomainUUID != nil {
-		return *v.DomainUUID
+func (v *GetReplicationTasksResponse) GetTasks() (o []*shared.DataBlob) {
+	if v != nil && v.Tasks != nil {
+		return v.Tasks
 	}
 
 	return
 }
 
-// IsSetDomainUUID returns true if DomainUUID is not nil.
-func (v *RecordActivityTaskHeartbeatRequest) IsSetDomainUUID() bool {
-	return v != nil && v.DomainUUID != nil
+// IsSetTasks returns true if Tasks is not nil.
+func (v *GetReplicationTasksResponse) IsSetTasks() bool {
+	return v != nil && v.Tasks != nil
 }
 
-// GetHeartbeatRequest returns the value of HeartbeatRequest if it is set or its
+// GetNextPageToken returns the value of NextPageToken if it is set or its
 // zero value if it is unset.
-func (v *RecordActivityTaskHeartbeatRequest) GetHeartbeatRequest() (o *shared.RecordActivityTaskHeartbeatRequest) {
-	if v != nil && v.HeartbeatRequest != nil {
-		return v.HeartbeatRequest
+func (v *GetReplicationTasksResponse) GetNextPageToken() (o []byte) {
+	if v != nil && v.NextPageToken != nil {
+		return v.NextPageToken
 	}
 
 	return
 }
 
-// IsSetHeartbeatRequest returns true if HeartbeatRequest is not nil.
-func (v *RecordActivityTaskHeartbeatRequest) IsSetHeartbeatRequest() bool {
-	return v != nil && v.HeartbeatRequest != nil
+// IsSetNextPageToken returns true if NextPageToken is not nil.
+func (v *GetReplicationTasksResponse) IsSetNextPageToken() bool {
+	return v != nil && v.NextPageToken != nil
 }
 
-type RecordActivityTaskStartedRequest struct {
-	DomainUUID        *string                            `json:"domainUUID,omitempty"`
-	WorkflowExecution *shared.WorkflowExecution          `json:"workflowExecution,omitempty"`
-	ScheduleId        *int64                             `json:"scheduleId,omitempty"`
-	TaskId            *int64                             `json:"taskId,omitempty"`
-	RequestId         *string                            `json:"requestId,omitempty"`
-	PollRequest       *shared.PollForActivityTaskRequest `json:"pollRequest,omitempty"`
+type ParentExecutionInfo struct {
+	DomainUUID  *string                   `json:"domainUUID,omitempty"`
+	Domain      *string                   `json:"domain,omitempty"`
+	Execution   *shared.WorkflowExecution `json:"execution,omitempty"`
+	InitiatedId *int64                    `json:"initiatedId,omitempty"`
 }
 
-// ToWire translates a RecordActivityTaskStartedRequest struct into a Thrift-level intermediate
+// ToWire translates a ParentExecutionInfo struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -2319,9 +2612,9 @@ type RecordActivityTaskStartedRequest struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *RecordActivityTaskStartedRequest) ToWire() (wire.Value, error) {
+func (v *ParentExecutionInfo) ToWire() (wire.Value, error) {
 	var (
-		fields [6]wire.Field
+		fields [4]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -2335,61 +2628,39 @@ func (v *RecordActivityTaskStartedRequest) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.WorkflowExecution != nil {
-		w, err = v.WorkflowExecution.ToWire()
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 20, Value: w}
-		i++
-	}
-	if v.ScheduleId != nil {
-		w, err = wire.NewValueI64(*(v.ScheduleId)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 30, Value: w}
-		i++
-	}
-	if v.TaskId != nil {
-		w, err = wire.NewValueI64(*(v.TaskId)), error(nil)
+	if v.Domain != nil {
+		w, err = wire.NewValueString(*(v.Domain)), error(nil)
 		if err != nil {
 			return w, err
 		}
-		fields[i] = wire.Field{ID: 40, Value: w}
+		fields[i] = wire.Field{ID: 15, Value: w}
 		i++
 	}
-	if v.RequestId != nil {
-		w, err = wire.NewValueString(*(v.RequestId)), error(nil)
+	if v.Execution != nil {
+		w, err = v.Execution.ToWire()
 		if err != nil {
 			return w, err
 		}
-		fields[i] = wire.Field{ID: 45, Value: w}
+		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
-	if v.PollRequest != nil {
-		w, err = v.PollRequest.ToWire()
+	if v.InitiatedId != nil {
+		w, err = wire.NewValueI64(*(v.InitiatedId)), error(nil)
 		if err != nil {
 			return w, err
 		}
-		fields[i] = wire.Field{ID: 50, Value: w}
+		fields[i] = wire.Field{ID: 30, Value: w}
 		i++
 	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _PollForActivityTaskRequest_Read(w wire.Value) (*shared.PollForActivityTaskRequest, error) {
-	var v shared.PollForActivityTaskRequest
-	err := v.FromWire(w)
-	return &v, err
-}
-
-// FromWire deserializes a RecordActivityTaskStartedRequest struct from its Thrift-level
+// FromWire deserializes a ParentExecutionInfo struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a RecordActivityTaskStartedRequest struct
+// An error is returned if we were unable to build a ParentExecutionInfo struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -2397,12 +2668,12 @@ func _PollForActivityTaskRequest_Read(w wire.Value) (*shared.PollForActivityTask
 //     return nil, err
 //   }
 //
-//   var v RecordActivityTaskStartedRequest
+//   var v ParentExecutionInfo
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *RecordActivityTaskStartedRequest) FromWire(w wire.Value) error {
+func (v *ParentExecutionInfo) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
@@ -2417,100 +2688,74 @@ func (v *RecordActivityTaskStartedRequest) FromWire(w wire.Value) error {
 				}
 
 			}
-		case 20:
-			if field.Value.Type() == wire.TStruct {
-				v.WorkflowExecution, err = _WorkflowExecution_Read(field.Value)
+		case 15:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Domain = &x
 				if err != nil {
 					return err
 				}
 
 			}
-		case 30:
-			if field.Value.Type() == wire.TI64 {
-				var x int64
-				x, err = field.Value.GetI64(), error(nil)
-				v.ScheduleId = &x
+		case 20:
+			if field.Value.Type() == wire.TStruct {
+				v.Execution, err = _WorkflowExecution_Read(field.Value)
 				if err != nil {
 					return err
 				}
 
 			}
-		case 40:
+		case 30:
 			if field.Value.Type() == wire.TI64 {
 				var x int64
 				x, err = field.Value.GetI64(), error(nil)
-				v.TaskId = &x
+				v.InitiatedId = &x
 				if err != nil {
 					return err
 				}
 
 			}
-		case 45:
-			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.RequestId = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		case 50:
-			if field.Value.Type() == wire.TStruct {
-				v.PollRequest, err = _PollForActivityTaskRequest_Read(field.Value)
-				if err != nil {
-					return err
-				}
-
-			}
-		}
-	}
+		}
+	}
 
 	return nil
 }
 
-// String returns a readable string representation of a RecordActivityTaskStartedRequest
+// String returns a readable string representation of a ParentExecutionInfo
 // struct.
-func (v *RecordActivityTaskStartedRequest) String() string {
+func (v *ParentExecutionInfo) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [6]string
+	var fields [4]string
 	i := 0
 	if v.DomainUUID != nil {
 		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
 		i++
 	}
-	if v.WorkflowExecution != nil {
-		fields[i] = fmt.Sprintf("WorkflowExecution: %v", v.WorkflowExecution)
-		i++
-	}
-	if v.ScheduleId != nil {
-		fields[i] = fmt.Sprintf("ScheduleId: %v", *(v.ScheduleId))
-		i++
-	}
-	if v.TaskId != nil {
-		fields[i] = fmt.Sprintf("TaskId: %v", *(v.TaskId))
+	if v.Domain != nil {
+		fields[i] = fmt.Sprintf("Domain: %v", *(v.Domain))
 		i++
 	}
-	if v.RequestId != nil {
-		fields[i] = fmt.Sprintf("RequestId: %v", *(v.RequestId))
+	if v.Execution != nil {
+		fields[i] = fmt.Sprintf("Execution: %v", v.Execution)
 		i++
 	}
-	if v.PollRequest != nil {
-		fields[i] = fmt.Sprintf("PollRequest: %v", v.PollRequest)
+	if v.InitiatedId != nil {
+		fields[i] = fmt.Sprintf("InitiatedId: %v", *(v.InitiatedId))
 		i++
 	}
 
-	return fmt.Sprintf("RecordActivityTaskStartedRequest{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("ParentExecutionInfo{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this RecordActivityTaskStartedRequest match the
-// provided RecordActivityTaskStartedRequest.
+// Equals returns true if all the fields of this ParentExecutionInfo match the
+// provided ParentExecutionInfo.
 //
 // This function performs a deep comparison.
-func (v *RecordActivityTaskStartedRequest) Equals(rhs *RecordActivityTaskStartedRequest) bool {
+func (v *ParentExecutionInfo) Equals(rhs *ParentExecutionInfo) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
@@ -2519,19 +2764,13 @@ func (v *RecordActivityTaskStartedRequest) Equals(rhs *RecordActivityTaskStarted
 	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
 		return false
 	}
-	if !((v.WorkflowExecution == nil && rhs.WorkflowExecution == nil) || (v.WorkflowExecution != nil && rhs.WorkflowExecution != nil && v.WorkflowExecution.Equals(rhs.WorkflowExecution))) {
-		return false
-	}
-	if !_I64_EqualsPtr(v.ScheduleId, rhs.ScheduleId) {
-		return false
-	}
-	if !_I64_EqualsPtr(v.TaskId, rhs.TaskId) {
+	if !_String_EqualsPtr(v.Domain, rhs.Domain) {
 		return false
 	}
-	if !_String_EqualsPtr(v.RequestId, rhs.RequestId) {
+	if !((v.Execution == nil && rhs.Execution == nil) || (v.Execution != nil && rhs.Execution != nil && v.Execution.Equals(rhs.Execution))) {
 		return false
 	}
-	if !((v.PollRequest == nil && rhs.PollRequest == nil) || (v.PollRequest != nil && rhs.PollRequest != nil && v.PollRequest.Equals(rhs.PollRequest))) {
+	if !_I64_EqualsPtr(v.InitiatedId, rhs.InitiatedId) {
 		return false
 	}
 
@@ -2539,35 +2778,29 @@ func (v *RecordActivityTaskStartedRequest) Equals(rhs *RecordActivityTaskStarted
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of RecordActivityTaskStartedRequest.
-func (v *RecordActivityTaskStartedRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of ParentExecutionInfo.
+func (v *ParentExecutionInfo) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
 	if v.DomainUUID != nil {
 		enc.AddString("domainUUID", *v.DomainUUID)
 	}
-	if v.WorkflowExecution != nil {
-		err = multierr.Append(err, enc.AddObject("workflowExecution", v.WorkflowExecution))
-	}
-	if v.ScheduleId != nil {
-		enc.AddInt64("scheduleId", *v.ScheduleId)
-	}
-	if v.TaskId != nil {
-		enc.AddInt64("taskId", *v.TaskId)
+	if v.Domain != nil {
+		enc.AddString("domain", *v.Domain)
 	}
-	if v.RequestId != nil {
-		enc.AddString("requestId", *v.RequestId)
+	if v.Execution != nil {
+		err = multierr.Append(err, enc.AddObject("execution", v.Execution))
 	}
-	if v.PollRequest != nil {
-		err = multierr.Append(err, enc.AddObject("pollRequest", v.PollRequest))
+	if v.InitiatedId != nil {
+		enc.AddInt64("initiatedId", *v.InitiatedId)
 	}
 	return err
 }
 
 // GetDomainUUID returns the value of DomainUUID if it is set or its
 // zero value if it is unset.
-func (v *RecordActivityTaskStartedRequest) GetDomainUUID() (o string) {
+func (v *ParentExecutionInfo) GetDomainUUID() (o string) {
 	if v != nil && v.DomainUUID != nil {
 		return *v.DomainUUID
 	}
@@ -2576,96 +2809,61 @@ func (v *RecordActivityTaskStartedRequest) GetDomainUUID() (o string) {
 }
 
 // IsSetDomainUUID returns true if DomainUUID is not nil.
-func (v *RecordActivityTaskStartedRequest) IsSetDomainUUID() bool {
+func (v *ParentExecutionInfo) IsSetDomainUUID() bool {
 	return v != nil && v.DomainUUID != nil
 }
 
-// GetWorkflowExecution returns the value of WorkflowExecution if it is set or its
-// zero value if it is unset.
-func (v *RecordActivityTaskStartedRequest) GetWorkflowExecution() (o *shared.WorkflowExecution) {
-	if v != nil && v.WorkflowExecution != nil {
-		return v.WorkflowExecution
-	}
-
-	return
-}
-
-// IsSetWorkflowExecution returns true if WorkflowExecution is not nil.
-func (v *RecordActivityTaskStartedRequest) IsSetWorkflowExecution() bool {
-	return v != nil && v.WorkflowExecution != nil
-}
-
-// GetScheduleId returns the value of ScheduleId if it is set or its
-// zero value if it is unset.
-func (v *RecordActivityTaskStartedRequest) GetScheduleId() (o int64) {
-	if v != nil && v.ScheduleId != nil {
-		return *v.ScheduleId
-	}
-
-	return
-}
-
-// IsSetScheduleId returns true if ScheduleId is not nil.
-func (v *RecordActivityTaskStartedRequest) IsSetScheduleId() bool {
-	return v != nil && v.ScheduleId != nil
-}
-
-// GetTaskId returns the value of TaskId if it is set or its
+// GetDomain returns the value of Domain if it is set or its
 // zero value if it is unset.
-func (v *RecordActivityTaskStartedRequest) GetTaskId() (o int64) {
-	if v != nil && v.TaskId != nil {
-		return *v.TaskId
+func (v *ParentExecutionInfo) GetDomain() (o string) {
+	if v != nil && v.Domain != nil {
+		return *v.Domain
 	}
 
 	return
 }
 
-// IsSetTaskId returns true if TaskId is not nil.
-func (v *RecordActivityTaskStartedRequest) IsSetTaskId() bool {
-	return v != nil && v.TaskId != nil
+// IsSetDomain returns true if Domain is not nil.
+func (v *ParentExecutionInfo) IsSetDomain() bool {
+	return v != nil && v.Domain != nil
 }
 
-// GetRequestId returns the value of RequestId if it is set or its
+// GetExecution returns the value of Execution if it is set or its
 // zero value if it is unset.
-func (v *RecordActivityTaskStartedRequest) GetRequestId() (o string) {
-	if v != nil && v.RequestId != nil {
-		return *v.RequestId
+func (v *ParentExecutionInfo) GetExecution() (o *shared.WorkflowExecution) {
+	if v != nil && v.Execution != nil {
+		return v.Execution
 	}
 
 	return
 }
 
-// IsSetRequestId returns true if RequestId is not nil.
-func (v *RecordActivityTaskStartedRequest) IsSetRequestId() bool {
-	return v != nil && v.RequestId != nil
+// IsSetExecution returns true if Execution is not nil.
+func (v *ParentExecutionInfo) IsSetExecution() bool {
+	return v != nil && v.Execution != nil
 }
 
-// GetPollRequest returns the value of PollRequest if it is set or its
+// GetInitiatedId returns the value of InitiatedId if it is set or its
 // zero value if it is unset.
-func (v *RecordActivityTaskStartedRequest) GetPollRequest() (o *shared.PollForActivityTaskRequest) {
-	if v != nil && v.PollRequest != nil {
-		return v.PollRequest
+func (v *ParentExecutionInfo) GetInitiatedId() (o int64) {
+	if v != nil && v.InitiatedId != nil {
+		return *v.InitiatedId
 	}
 
 	return
 }
 
-// IsSetPollRequest returns true if PollRequest is not nil.
-func (v *RecordActivityTaskStartedRequest) IsSetPollRequest() bool {
-	return v != nil && v.PollRequest != nil
+// IsSetInitiatedId returns true if InitiatedId is not nil.
+func (v *ParentExecutionInfo) IsSetInitiatedId() bool {
+	return v != nil && v.InitiatedId != nil
 }
 
-type RecordActivityTaskStartedResponse struct {
-	ScheduledEvent                  *shared.HistoryEvent `json:"scheduledEvent,omitempty"`
-	StartedTimestamp                *int64               `json:"startedTimestamp,omitempty"`
-	Attempt                         *int64               `json:"attempt,omitempty"`
-	ScheduledTimestampOfThisAttempt *int64               `json:"scheduledTimestampOfThisAttempt,omitempty"`
-	HeartbeatDetails                []byte               `json:"heartbeatDetails,omitempty"`
-	WorkflowType                    *shared.WorkflowType `json:"workflowType,omitempty"`
-	WorkflowDomain                  *string              `json:"workflowDomain,omitempty"`
+type RecordActivityTaskHeartbeatRequest struct {
+	DomainUUID       *string                                    `json:"domainUUID,omitempty"`
+	HeartbeatRequest *shared.RecordActivityTaskHeartbeatRequest `json:"heartbeatRequest,omitempty"`
 }
 
-// ToWire translates a RecordActivityTaskStartedResponse struct into a Thrift-level intermediate
+// ToWire translates a RecordActivityTaskHeartbeatRequest struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -2680,85 +2878,45 @@ type RecordActivityTaskStartedResponse struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *RecordActivityTaskStartedResponse) ToWire() (wire.Value, error) {
+func (v *RecordActivityTaskHeartbeatRequest) ToWire() (wire.Value, error) {
 	var (
-		fields [7]wire.Field
+		fields [2]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
 	)
 
-	if v.ScheduledEvent != nil {
-		w, err = v.ScheduledEvent.ToWire()
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 20, Value: w}
-		i++
-	}
-	if v.StartedTimestamp != nil {
-		w, err = wire.NewValueI64(*(v.StartedTimestamp)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 30, Value: w}
-		i++
-	}
-	if v.Attempt != nil {
-		w, err = wire.NewValueI64(*(v.Attempt)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 40, Value: w}
-		i++
-	}
-	if v.ScheduledTimestampOfThisAttempt != nil {
-		w, err = wire.NewValueI64(*(v.ScheduledTimestampOfThisAttempt)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 50, Value: w}
-		i++
-	}
-	if v.HeartbeatDetails != nil {
-		w, err = wire.NewValueBinary(v.HeartbeatDetails), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 60, Value: w}
-		i++
-	}
-	if v.WorkflowType != nil {
-		w, err = v.WorkflowType.ToWire()
+	if v.DomainUUID != nil {
+		w, err = wire.NewValueString(*(v.DomainUUID)), error(nil)
 		if err != nil {
 			return w, err
 		}
-		fields[i] = wire.Field{ID: 70, Value: w}
+		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.WorkflowDomain != nil {
-		w, err = wire.NewValueString(*(v.WorkflowDomain)), error(nil)
+	if v.HeartbeatRequest != nil {
+		w, err = v.HeartbeatRequest.ToWire()
 		if err != nil {
 			return w, err
 		}
-		fields[i] = wire.Field{ID: 80, Value: w}
+		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _HistoryEvent_Read(w wire.Value) (*shared.HistoryEvent, error) {
-	var v shared.HistoryEvent
+func _RecordActivityTaskHeartbeatRequest_Read(w wire.Value) (*shared.RecordActivityTaskHeartbeatRequest, error) {
+	var v shared.RecordActivityTaskHeartbeatRequest
 	err := v.FromWire(w)
 	return &v, err
 }
 
-// FromWire deserializes a RecordActivityTaskStartedResponse struct from its Thrift-level
+// FromWire deserializes a RecordActivityTaskHeartbeatRequest struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a RecordActivityTaskStartedResponse struct
+// An error is returned if we were unable to build a RecordActivityTaskHeartbeatRequest struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -2766,75 +2924,29 @@ func _HistoryEvent_Read(w wire.Value) (*shared.HistoryEvent, error) {
 //     return nil, err
 //   }
 //
-//   var v RecordActivityTaskStartedResponse
+//   var v RecordActivityTaskHeartbeatRequest
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *RecordActivityTaskStartedResponse) FromWire(w wire.Value) error {
+func (v *RecordActivityTaskHeartbeatRequest) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
-		case 20:
-			if field.Value.Type() == wire.TStruct {
-				v.ScheduledEvent, err = _HistoryEvent_Read(field.Value)
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.DomainUUID = &x
 				if err != nil {
 					return err
 				}
 
 			}
-		case 30:
-			if field.Value.Type() == wire.TI64 {
-				var x int64
-				x, err = field.Value.GetI64(), error(nil)
-				v.StartedTimestamp = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		case 40:
-			if field.Value.Type() == wire.TI64 {
-				var x int64
-				x, err = field.Value.GetI64(), error(nil)
-				v.Attempt = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		case 50:
-			if field.Value.Type() == wire.TI64 {
-				var x int64
-				x, err = field.Value.GetI64(), error(nil)
-				v.ScheduledTimestampOfThisAttempt = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		case 60:
-			if field.Value.Type() == wire.TBinary {
-				v.HeartbeatDetails, err = field.Value.GetBinary(), error(nil)
-				if err != nil {
-					return err
-				}
-
-			}
-		case 70:
-			if field.Value.Type() == wire.TStruct {
-				v.WorkflowType, err = _WorkflowType_Read(field.Value)
-				if err != nil {
-					return err
-				}
-
-			}
-		case 80:
-			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.WorkflowDomain = &x
+		case 20:
+			if field.Value.Type() == wire.TStruct {
+				v.HeartbeatRequest, err = _RecordActivityTaskHeartbeatRequest_Read(field.Value)
 				if err != nil {
 					return err
 				}
@@ -2846,76 +2958,41 @@ func (v *RecordActivityTaskStartedResponse) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a RecordActivityTaskStartedResponse
+// String returns a readable string representation of a RecordActivityTaskHeartbeatRequest
 // struct.
-func (v *RecordActivityTaskStartedResponse) String() string {
+func (v *RecordActivityTaskHeartbeatRequest) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [7]string
+	var fields [2]string
 	i := 0
-	if v.ScheduledEvent != nil {
-		fields[i] = fmt.Sprintf("ScheduledEvent: %v", v.ScheduledEvent)
-		i++
-	}
-	if v.StartedTimestamp != nil {
-		fields[i] = fmt.Sprintf("StartedTimestamp: %v", *(v.StartedTimestamp))
-		i++
-	}
-	if v.Attempt != nil {
-		fields[i] = fmt.Sprintf("Attempt: %v", *(v.Attempt))
-		i++
-	}
-	if v.ScheduledTimestampOfThisAttempt != nil {
-		fields[i] = fmt.Sprintf("ScheduledTimestampOfThisAttempt: %v", *(v.ScheduledTimestampOfThisAttempt))
-		i++
-	}
-	if v.HeartbeatDetails != nil {
-		fields[i] = fmt.Sprintf("HeartbeatDetails: %v", v.HeartbeatDetails)
-		i++
-	}
-	if v.WorkflowType != nil {
-		fields[i] = fmt.Sprintf("WorkflowType: %v", v.WorkflowType)
+	if v.DomainUUID != nil {
+		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
 		i++
 	}
-	if v.WorkflowDomain != nil {
-		fields[i] = fmt.Sprintf("WorkflowDomain: %v", *(v.WorkflowDomain))
+	if v.HeartbeatRequest != nil {
+		fields[i] = fmt.Sprintf("HeartbeatRequest: %v", v.HeartbeatRequest)
 		i++
 	}
 
-	return fmt.Sprintf("RecordActivityTaskStartedResponse{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("RecordActivityTaskHeartbeatRequest{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this RecordActivityTaskStartedResponse match the
-// provided RecordActivityTaskStartedResponse.
+// Equals returns true if all the fields of this RecordActivityTaskHeartbeatRequest match the
+// provided RecordActivityTaskHeartbeatRequest.
 //
 // This function performs a deep comparison.
-func (v *RecordActivityTaskStartedResponse) Equals(rhs *RecordActivityTaskStartedResponse) bool {
+func (v *RecordActivityTaskHeartbeatRequest) Equals(rhs *RecordActivityTaskHeartbeatRequest) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
 		return false
 	}
-	if !((v.ScheduledEvent == nil && rhs.ScheduledEvent == nil) || (v.ScheduledEvent != nil && rhs.ScheduledEvent != nil && v.ScheduledEvent.Equals(rhs.ScheduledEvent))) {
-		return false
-	}
-	if !_I64_EqualsPtr(v.StartedTimestamp, rhs.StartedTimestamp) {
-		return false
-	}
-	if !_I64_EqualsPtr(v.Attempt, rhs.Attempt) {
-		return false
-	}
-	if !_I64_EqualsPtr(v.ScheduledTimestampOfThisAttempt, rhs.ScheduledTimestampOfThisAttempt) {
-		return false
-	}
-	if !((v.HeartbeatDetails == nil && rhs.HeartbeatDetails == nil) || (v.HeartbeatDetails != nil && rhs.HeartbeatDetails != nil && bytes.Equal(v.HeartbeatDetails, rhs.HeartbeatDetails))) {
-		return false
-	}
-	if !((v.WorkflowType == nil && rhs.WorkflowType == nil) || (v.WorkflowType != nil && rhs.WorkflowType != nil && v.WorkflowType.Equals(rhs.WorkflowType))) {
+	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
 		return false
 	}
-	if !_String_EqualsPtr(v.WorkflowDomain, rhs.WorkflowDomain) {
+	if !((v.HeartbeatRequest == nil && rhs.HeartbeatRequest == nil) || (v.HeartbeatRequest != nil && rhs.HeartbeatRequest != nil && v.HeartbeatRequest.Equals(rhs.HeartbeatRequest))) {
 		return false
 	}
 
@@ -2923,154 +3000,60 @@ func (v *RecordActivityTaskStartedResponse) Equals(rhs *RecordActivityTaskStarte
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of RecordActivityTaskStartedResponse.
-func (v *RecordActivityTaskStartedResponse) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of RecordActivityTaskHeartbeatRequest.
+func (v *RecordActivityTaskHeartbeatRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
-	if v.ScheduledEvent != nil {
-		err = multierr.Append(err, enc.AddObject("scheduledEvent", v.ScheduledEvent))
-	}
-	if v.StartedTimestamp != nil {
-		enc.AddInt64("startedTimestamp", *v.StartedTimestamp)
-	}
-	if v.Attempt != nil {
-		enc.AddInt64("attempt", *v.Attempt)
-	}
-	if v.ScheduledTimestampOfThisAttempt != nil {
-		enc.AddInt64("scheduledTimestampOfThisAttempt", *v.ScheduledTimestampOfThisAttempt)
-	}
-	if v.HeartbeatDetails != nil {
-		enc.AddString("heartbeatDetails", base64.StdEncoding.EncodeToString(v.HeartbeatDetails))
-	}
-	if v.WorkflowType != nil {
-		err = multierr.Append(err, enc.AddObject("workflowType", v.WorkflowType))
+	if v.DomainUUID != nil {
+		enc.AddString("domainUUID", *v.DomainUUID)
 	}
-	if v.WorkflowDomain != nil {
-		enc.AddString("workflowDomain", *v.WorkflowDomain)
+	if v.HeartbeatRequest != nil {
+		err = multierr.Append(err, enc.AddObject("heartbeatRequest", v.HeartbeatRequest))
 	}
 	return err
 }
 
-// GetScheduledEvent returns the value of ScheduledEvent if it is set or its
-// zero value if it is unset.
-func (v *RecordActivityTaskStartedResponse) GetScheduledEvent() (o *shared.HistoryEvent) {
-	if v != nil && v.ScheduledEvent != nil {
-		return v.ScheduledEvent
-	}
-
-	return
-}
-
-// IsSetScheduledEvent returns true if ScheduledEvent is not nil.
-func (v *RecordActivityTaskStartedResponse) IsSetScheduledEvent() bool {
-	return v != nil && v.ScheduledEvent != nil
-}
-
-// GetStartedTimestamp returns the value of StartedTimestamp if it is set or its
-// zero value if it is unset.
-func (v *RecordActivityTaskStartedResponse) GetStartedTimestamp() (o int64) {
-	if v != nil && v.StartedTimestamp != nil {
-		return *v.StartedTimestamp
-	}
-
-	return
-}
-
-// IsSetStartedTimestamp returns true if StartedTimestamp is not nil.
-func (v *RecordActivityTaskStartedResponse) IsSetStartedTimestamp() bool {
-	return v != nil && v.StartedTimestamp != nil
-}
-
-// GetAttempt returns the value of Attempt if it is set or its
-// zero value if it is unset.
-func (v *RecordActivityTaskStartedResponse) GetAttempt() (o int64) {
-	if v != nil && v.Attempt != nil {
-		return *v.Attempt
-	}
-
-	return
-}
-
-// IsSetAttempt returns true if Attempt is not nil.
-func (v *RecordActivityTaskStartedResponse) IsSetAttempt() bool {
-	return v != nil && v.Attempt != nil
-}
-
-// GetScheduledTimestampOfThisAttempt returns the value of ScheduledTimestampOfThisAttempt if it is set or its
-// zero value if it is unset.
-func (v *RecordActivityTaskStartedResponse) GetScheduledTimestampOfThisAttempt() (o int64) {
-	if v != nil && v.ScheduledTimestampOfThisAttempt != nil {
-		return *v.ScheduledTimestampOfThisAttempt
-	}
-
-	return
-}
-
-// IsSetScheduledTimestampOfThisAttempt returns true if ScheduledTimestampOfThisAttempt is not nil.
-func (v *RecordActivityTaskStartedResponse) IsSetScheduledTimestampOfThisAttempt() bool {
-	return v != nil && v.ScheduledTimestampOfThisAttempt != nil
-}
-
-// GetHeartbeatDetails returns the value of HeartbeatDetails if it is set or its
-// zero value if it is unset.
-func (v *RecordActivityTaskStartedResponse) GetHeartbeatDetails() (o []byte) {
-	if v != nil && v.HeartbeatDetails != nil {
-		return v.HeartbeatDetails
-	}
-
-	return
-}
-
-// IsSetHeartbeatDetails returns true if HeartbeatDetails is not nil.
-func (v *RecordActivityTaskStartedResponse) IsSetHeartbeatDetails() bool {
-	return v != nil && v.HeartbeatDetails != nil
-}
-
-// GetWorkflowType returns the value of WorkflowType if it is set or its
+// GetDomainUUID returns the value of DomainUUID if it is set or its
 // zero value if it is unset.
-func (v *RecordActivityTaskStartedResponse) GetWorkflowType() (o *shared.WorkflowType) {
-	if v != nil && v.WorkflowType != nil {
-		return v.WorkflowType
+func (v *RecordActivityTaskHeartbeatRequest) GetDomainUUID() (o string) {
+	if v != nil && v.DomainUUID != nil {
+		return *v.DomainUUID
 	}
 
 	return
 }
 
-// IsSetWorkflowType returns true if WorkflowType is not nil.
-func (v *RecordActivityTaskStartedResponse) IsSetWorkflowType() bool {
-	return v != nil && v.WorkflowType != nil
+// IsSetDomainUUID returns true if DomainUUID is not nil.
+func (v *RecordActivityTaskHeartbeatRequest) IsSetDomainUUID() bool {
+	return v != nil && v.DomainUUID != nil
 }
 
-// GetWorkflowDomain returns the value of WorkflowDomain if it is set or its
+// GetHeartbeatRequest returns the value of HeartbeatRequest if it is set or its
 // zero value if it is unset.
-func (v *RecordActivityTaskStartedResponse) GetWorkflowDomain() (o string) {
-	if v != nil && v.WorkflowDomain != nil {
-		return *v.WorkflowDomain
+func (v *RecordActivityTaskHeartbeatRequest) GetHeartbeatRequest() (o *shared.RecordActivityTaskHeartbeatRequest) {
+	if v != nil && v.HeartbeatRequest != nil {
+		return v.HeartbeatRequest
 	}
 
 	return
 }
 
-// IsSetWorkflowDomain returns true if WorkflowDomain is not nil.
-func (v *RecordActivityTaskStartedResponse) IsSetWorkflowDomain() bool {
-	return v != nil && v.WorkflowDomain != nil
+// IsSetHeartbeatRequest returns true if HeartbeatRequest is not nil.
+func (v *RecordActivityTaskHeartbeatRequest) IsSetHeartbeatRequest() bool {
+	return v != nil && v.HeartbeatRequest != nil
 }
 
-// RecordChildExecutionCompletedRequest is used for reporting the completion of child execution to parent workflow
-// execution which started it.  When a child execution is completed it creates this request and calls the
-// RecordChildExecutionCompleted API with the workflowExecution of parent.  It also sets the completedExecution of the
-// child as it could potentially be different than the ChildExecutionStartedEvent of parent in the situation when
-// child creates multiple runs through ContinueAsNew before finally completing.
-type RecordChildExecutionCompletedRequest struct {
-	DomainUUID         *string                   `json:"domainUUID,omitempty"`
-	WorkflowExecution  *shared.WorkflowExecution `json:"workflowExecution,omitempty"`
-	InitiatedId        *int64                    `json:"initiatedId,omitempty"`
-	CompletedExecution *shared.WorkflowExecution `json:"completedExecution,omitempty"`
-	CompletionEvent    *shared.HistoryEvent      `json:"completionEvent,omitempty"`
+type RecordActivityTaskStartedRequest struct {
+	DomainUUID        *string                            `json:"domainUUID,omitempty"`
+	WorkflowExecution *shared.WorkflowExecution          `json:"workflowExecution,omitempty"`
+	ScheduleId        *int64                             `json:"scheduleId,omitempty"`
+	TaskId            *int64                             `json:"taskId,omitempty"`
+	RequestId         *string                            `json:"requestId,omitempty"`
+	PollRequest       *shared.PollForActivityTaskRequest `json:"pollRequest,omitempty"`
 }
 
-// ToWire translates a RecordChildExecutionCompletedRequest struct into a Thrift-level intermediate
+// ToWire translates a RecordActivityTaskStartedRequest struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -3085,9 +3068,9 @@ type RecordChildExecutionCompletedRequest struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *RecordChildExecutionCompletedRequest) ToWire() (wire.Value, error) {
+func (v *RecordActivityTaskStartedRequest) ToWire() (wire.Value, error) {
 	var (
-		fields [5]wire.Field
+		fields [6]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -3109,24 +3092,32 @@ func (v *RecordChildExecutionCompletedRequest) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
-	if v.InitiatedId != nil {
-		w, err = wire.NewValueI64(*(v.InitiatedId)), error(nil)
+	if v.ScheduleId != nil {
+		w, err = wire.NewValueI64(*(v.ScheduleId)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 30, Value: w}
 		i++
 	}
-	if v.CompletedExecution != nil {
-		w, err = v.CompletedExecution.ToWire()
+	if v.TaskId != nil {
+		w, err = wire.NewValueI64(*(v.TaskId)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 40, Value: w}
 		i++
 	}
-	if v.CompletionEvent != nil {
-		w, err = v.CompletionEvent.ToWire()
+	if v.RequestId != nil {
+		w, err = wire.NewValueString(*(v.RequestId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 45, Value: w}
+		i++
+	}
+	if v.PollRequest != nil {
+		w, err = v.PollRequest.ToWire()
 		if err != nil {
 			return w, err
 		}
@@ -3137,11 +3128,17 @@ func (v *RecordChildExecutionCompletedRequest) ToWire() (wire.Value, error) {
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-// FromWire deserializes a RecordChildExecutionCompletedRequest struct from its Thrift-level
+func _PollForActivityTaskRequest_Read(w wire.Value) (*shared.PollForActivityTaskRequest, error) {
+	var v shared.PollForActivityTaskRequest
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a RecordActivityTaskStartedRequest struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a RecordChildExecutionCompletedRequest struct
+// An error is returned if we were unable to build a RecordActivityTaskStartedRequest struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -3149,12 +3146,12 @@ func (v *RecordChildExecutionCompletedRequest) ToWire() (wire.Value, error) {
 //     return nil, err
 //   }
 //
-//   var v RecordChildExecutionCompletedRequest
+//   var v RecordActivityTaskStartedRequest
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *RecordChildExecutionCompletedRequest) FromWire(w wire.Value) error {
+func (v *RecordActivityTaskStartedRequest) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
@@ -3181,15 +3178,27 @@ func (v *RecordChildExecutionCompletedRequest) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TI64 {
 				var x int64
 				x, err = field.Value.GetI64(), error(nil)
-				v.InitiatedId = &x
+				v.ScheduleId = &x
 				if err != nil {
 					return err
 				}
 
 			}
 		case 40:
-			if field.Value.Type() == wire.TStruct {
-				v.CompletedExecution, err = _WorkflowExecution_Read(field.Value)
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.TaskId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 45:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.RequestId = &x
 				if err != nil {
 					return err
 				}
@@ -3197,7 +3206,7 @@ func (v *RecordChildExecutionCompletedRequest) FromWire(w wire.Value) error {
 			}
 		case 50:
 			if field.Value.Type() == wire.TStruct {
-				v.CompletionEvent, err = _HistoryEvent_Read(field.Value)
+				v.PollRequest, err = _PollForActivityTaskRequest_Read(field.Value)
 				if err != nil {
 					return err
 				}
@@ -3209,14 +3218,14 @@ func (v *RecordChildExecutionCompletedRequest) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a RecordChildExecutionCompletedRequest
+// String returns a readable string representation of a RecordActivityTaskStartedRequest
 // struct.
-func (v *RecordChildExecutionCompletedRequest) String() string {
+func (v *RecordActivityTaskStartedRequest) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [5]string
+	var fields [6]string
 	i := 0
 	if v.DomainUUID != nil {
 		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
@@ -3226,27 +3235,31 @@ func (v *RecordChildExecutionCompletedRequest) String() string {
 		fields[i] = fmt.Sprintf("WorkflowExecution: %v", v.WorkflowExecution)
 		i++
 	}
-	if v.InitiatedId != nil {
-		fields[i] = fmt.Sprintf("InitiatedId: %v", *(v.InitiatedId))
+	if v.ScheduleId != nil {
+		fields[i] = fmt.Sprintf("ScheduleId: %v", *(v.ScheduleId))
 		i++
 	}
-	if v.CompletedExecution != nil {
-		fields[i] = fmt.Sprintf("CompletedExecution: %v", v.CompletedExecution)
+	if v.TaskId != nil {
+		fields[i] = fmt.Sprintf("TaskId: %v", *(v.TaskId))
 		i++
 	}
-	if v.CompletionEvent != nil {
-		fields[i] = fmt.Sprintf("CompletionEvent: %v", v.CompletionEvent)
+	if v.RequestId != nil {
+		fields[i] = fmt.Sprintf("RequestId: %v", *(v.RequestId))
+		i++
+	}
+	if v.PollRequest != nil {
+		fields[i] = fmt.Sprintf("PollRequest: %v", v.PollRequest)
 		i++
 	}
 
-	return fmt.Sprintf("RecordChildExecutionCompletedRequest{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("RecordActivityTaskStartedRequest{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this RecordChildExecutionCompletedRequest match the
-// provided RecordChildExecutionCompletedRequest.
+// Equals returns true if all the fields of this RecordActivityTaskStartedRequest match the
+// provided RecordActivityTaskStartedRequest.
 //
 // This function performs a deep comparison.
-func (v *RecordChildExecutionCompletedRequest) Equals(rhs *RecordChildExecutionCompletedRequest) bool {
+func (v *RecordActivityTaskStartedRequest) Equals(rhs *RecordActivityTaskStartedRequest) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
@@ -3258,13 +3271,16 @@ func (v *RecordChildExecutionCompletedRequest) Equals(rhs *RecordChildExecutionC
 	if !((v.WorkflowExecution == nil && rhs.WorkflowExecution == nil) || (v.WorkflowExecution != nil && rhs.WorkflowExecution != nil && v.WorkflowExecution.Equals(rhs.WorkflowExecution))) {
 		return false
 	}
-	if !_I64_EqualsPtr(v.InitiatedId, rhs.InitiatedId) {
+	if !_I64_EqualsPtr(v.ScheduleId, rhs.ScheduleId) {
 		return false
 	}
-	if !((v.CompletedExecution == nil && rhs.CompletedExecution == nil) || (v.CompletedExecution != nil && rhs.CompletedExecution != nil && v.CompletedExecution.Equals(rhs.CompletedExecution))) {
+	if !_I64_EqualsPtr(v.TaskId, rhs.TaskId) {
 		return false
 	}
-	if !((v.CompletionEvent == nil && rhs.CompletionEvent == nil) || (v.CompletionEvent != nil && rhs.CompletionEvent != nil && v.CompletionEvent.Equals(rhs.CompletionEvent))) {
+	if !_String_EqualsPtr(v.RequestId, rhs.RequestId) {
+		return false
+	}
+	if !((v.PollRequest == nil && rhs.PollRequest == nil) || (v.PollRequest != nil && rhs.PollRequest != nil && v.PollRequest.Equals(rhs.PollRequest))) {
 		return false
 	}
 
@@ -3272,8 +3288,8 @@ func (v *RecordChildExecutionCompletedRequest) Equals(rhs *RecordChildExecutionC
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of RecordChildExecutionCompletedRequest.
-func (v *RecordChildExecutionCompletedRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of RecordActivityTaskStartedRequest.
+func (v *RecordActivityTaskStartedRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
@@ -3283,21 +3299,24 @@ func (v *RecordChildExecutionCompletedRequest) MarshalLogObject(enc zapcore.Obje
 	if v.WorkflowExecution != nil {
 		err = multierr.Append(err, enc.AddObject("workflowExecution", v.WorkflowExecution))
 	}
-	if v.InitiatedId != nil {
-		enc.AddInt64("initiatedId", *v.InitiatedId)
+	if v.ScheduleId != nil {
+		enc.AddInt64("scheduleId", *v.ScheduleId)
 	}
-	if v.CompletedExecution != nil {
-		err = multierr.Append(err, enc.AddObject("completedExecution", v.CompletedExecution))
+	if v.TaskId != nil {
+		enc.AddInt64("taskId", *v.TaskId)
 	}
-	if v.CompletionEvent != nil {
-		err = multierr.Append(err, enc.AddObject("completionEvent", v.CompletionEvent))
+	if v.RequestId != nil {
+		enc.AddString("requestId", *v.RequestId)
+	}
+	if v.PollRequest != nil {
+		err = multierr.Append(err, enc.AddObject("pollRequest", v.PollRequest))
 	}
 	return err
 }
 
 // GetDomainUUID returns the value of DomainUUID if it is set or its
 // zero value if it is unset.
-func (v *RecordChildExecutionCompletedRequest) GetDomainUUID() (o string) {
+func (v *RecordActivityTaskStartedRequest) GetDomainUUID() (o string) {
 	if v != nil && v.DomainUUID != nil {
 		return *v.DomainUUID
 	}
@@ -3306,13 +3325,13 @@ func (v *RecordChildExecutionCompletedRequest) GetDomainUUID() (o string) {
 }
 
 // IsSetDomainUUID returns true if DomainUUID is not nil.
-func (v *RecordChildExecutionCompletedRequest) IsSetDomainUUID() bool {
+func (v *RecordActivityTaskStartedRequest) IsSetDomainUUID() bool {
 	return v != nil && v.DomainUUID != nil
 }
 
 // GetWorkflowExecution returns the value of WorkflowExecution if it is set or its
 // zero value if it is unset.
-func (v *RecordChildExecutionCompletedRequest) GetWorkflowExecution() (o *shared.WorkflowExecution) {
+func (v *RecordActivityTaskStartedRequest) GetWorkflowExecution() (o *shared.WorkflowExecution) {
 	if v != nil && v.WorkflowExecution != nil {
 		return v.WorkflowExecution
 	}
@@ -3321,65 +3340,81 @@ func (v *RecordChildExecutionCompletedRequest) GetWorkflowExecution() (o *shared
 }
 
 // IsSetWorkflowExecution returns true if WorkflowExecution is not nil.
-func (v *RecordChildExecutionCompletedRequest) IsSetWorkflowExecution() bool {
+func (v *RecordActivityTaskStartedRequest) IsSetWorkflowExecution() bool {
 	return v != nil && v.WorkflowExecution != nil
 }
 
-// GetInitiatedId returns the value of InitiatedId if it is set or its
+// GetScheduleId returns the value of ScheduleId if it is set or its
 // zero value if it is unset.
-func (v *RecordChildExecutionCompletedRequest) GetInitiatedId() (o int64) {
-	if v != nil && v.InitiatedId != nil {
-		return *v.InitiatedId
+func (v *RecordActivityTaskStartedRequest) GetScheduleId() (o int64) {
+	if v != nil && v.ScheduleId != nil {
+		return *v.ScheduleId
 	}
 
 	return
 }
 
-// IsSetInitiatedId returns true if InitiatedId is not nil.
-func (v *RecordChildExecutionCompletedRequest) IsSetInitiatedId() bool {
-	return v != nil && v.InitiatedId != nil
+// IsSetScheduleId returns true if ScheduleId is not nil.
+func (v *RecordActivityTaskStartedRequest) IsSetScheduleId() bool {
+	return v != nil && v.ScheduleId != nil
 }
 
-// GetCompletedExecution returns the value of CompletedExecution if it is set or its
+// GetTaskId returns the value of TaskId if it is set or its
 // zero value if it is unset.
-func (v *RecordChildExecutionCompletedRequest) GetCompletedExecution() (o *shared.WorkflowExecution) {
-	if v != nil && v.CompletedExecution != nil {
-		return v.CompletedExecution
+func (v *RecordActivityTaskStartedRequest) GetTaskId() (o int64) {
+	if v != nil && v.TaskId != nil {
+		return *v.TaskId
 	}
 
 	return
 }
 
-// IsSetCompletedExecution returns true if CompletedExecution is not nil.
-func (v *RecordChildExecutionCompletedRequest) IsSetCompletedExecution() bool {
-	return v != nil && v.CompletedExecution != nil
+// IsSetTaskId returns true if TaskId is not nil.
+func (v *RecordActivityTaskStartedRequest) IsSetTaskId() bool {
+	return v != nil && v.TaskId != nil
 }
 
-// GetCompletionEvent returns the value of CompletionEvent if it is set or its
+// GetRequestId returns the value of RequestId if it is set or its
 // zero value if it is unset.
-func (v *RecordChildExecutionCompletedRequest) GetCompletionEvent() (o *shared.HistoryEvent) {
-	if v != nil && v.CompletionEvent != nil {
-		return v.CompletionEvent
+func (v *RecordActivityTaskStartedRequest) GetRequestId() (o string) {
+	if v != nil && v.RequestId != nil {
+		return *v.RequestId
 	}
 
 	return
 }
 
-// IsSetCompletionEvent returns true if CompletionEvent is not nil.
-func (v *RecordChildExecutionCompletedRequest) IsSetCompletionEvent() bool {
-	return v != nil && v.CompletionEvent != nil
+// IsSetRequestId returns true if RequestId is not nil.
+func (v *RecordActivityTaskStartedRequest) IsSetRequestId() bool {
+	return v != nil && v.RequestId != nil
 }
 
-type RecordDecisionTaskStartedRequest struct {
-	DomainUUID        *string                            `json:"domainUUID,omitempty"`
-	WorkflowExecution *shared.WorkflowExecution          `json:"workflowExecution,omitempty"`
-	ScheduleId        *int64                             `json:"scheduleId,omitempty"`
-	TaskId            *int64                             `json:"taskId,omitempty"`
-	RequestId         *string                            `json:"requestId,omitempty"`
-	PollRequest       *shared.PollForDecisionTaskRequest `json:"pollRequest,omitempty"`
+// GetPollRequest returns the value of PollRequest if it is set or its
+// zero value if it is unset.
+func (v *RecordActivityTaskStartedRequest) GetPollRequest() (o *shared.PollForActivityTaskRequest) {
+	if v != nil && v.PollRequest != nil {
+		return v.PollRequest
+	}
+
+	return
 }
 
-// ToWire translates a RecordDecisionTaskStartedRequest struct into a Thrift-level intermediate
+// IsSetPollRequest returns true if PollRequest is not nil.
+func (v *RecordActivityTaskStartedRequest) IsSetPollRequest() bool {
+	return v != nil && v.PollRequest != nil
+}
+
+type RecordActivityTaskStartedResponse struct {
+	ScheduledEvent                  *shared.HistoryEvent `json:"scheduledEvent,omitempty"`
+	StartedTimestamp                *int64               `json:"startedTimestamp,omitempty"`
+	Attempt                         *int64               `json:"attempt,omitempty"`
+	ScheduledTimestampOfThisAttempt *int64               `json:"scheduledTimestampOfThisAttempt,omitempty"`
+	HeartbeatDetails                []byte               `json:"heartbeatDetails,omitempty"`
+	WorkflowType                    *shared.WorkflowType `json:"workflowType,omitempty"`
+	WorkflowDomain                  *string              `json:"workflowDomain,omitempty"`
+}
+
+// ToWire translates a RecordActivityTaskStartedResponse struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -3394,77 +3429,85 @@ type RecordDecisionTaskStartedRequest struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *RecordDecisionTaskStartedRequest) ToWire() (wire.Value, error) {
+func (v *RecordActivityTaskStartedResponse) ToWire() (wire.Value, error) {
 	var (
-		fields [6]wire.Field
+		fields [7]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
 	)
 
-	if v.DomainUUID != nil {
-		w, err = wire.NewValueString(*(v.DomainUUID)), error(nil)
+	if v.ScheduledEvent != nil {
+		w, err = v.ScheduledEvent.ToWire()
 		if err != nil {
 			return w, err
 		}
-		fields[i] = wire.Field{ID: 10, Value: w}
+		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
-	if v.WorkflowExecution != nil {
-		w, err = v.WorkflowExecution.ToWire()
+	if v.StartedTimestamp != nil {
+		w, err = wire.NewValueI64(*(v.StartedTimestamp)), error(nil)
 		if err != nil {
 			return w, err
 		}
-		fields[i] = wire.Field{ID: 20, Value: w}
+		fields[i] = wire.Field{ID: 30, Value: w}
 		i++
 	}
-	if v.ScheduleId != nil {
-		w, err = wire.NewValueI64(*(v.ScheduleId)), error(nil)
+	if v.Attempt != nil {
+		w, err = wire.NewValueI64(*(v.Attempt)), error(nil)
 		if err != nil {
 			return w, err
 		}
-		fields[i] = wire.Field{ID: 30, Value: w}
+		fields[i] = wire.Field{ID: 40, Value: w}
 		i++
 	}
-	if v.TaskId != nil {
-		w, err = wire.NewValueI64(*(v.TaskId)), error(nil)
+	if v.ScheduledTimestampOfThisAttempt != nil {
+		w, err = wire.NewValueI64(*(v.ScheduledTimestampOfThisAttempt)), error(nil)
 		if err != nil {
 			return w, err
 		}
-		fields[i] = wire.Field{ID: 40, Value: w}
+		fields[i] = wire.Field{ID: 50, Value: w}
 		i++
 	}
-	if v.RequestId != nil {
-		w, err = wire.NewValueString(*(v.RequestId)), error(nil)
+	if v.HeartbeatDetails != nil {
+		w, err = wire.NewValueBinary(v.HeartbeatDetails), error(nil)
 		if err != nil {
 			return w, err
 		}
-		fields[i] = wire.Field{ID: 45, Value: w}
+		fields[i] = wire.Field{ID: 60, Value: w}
 		i++
 	}
-	if v.PollRequest != nil {
-		w, err = v.PollRequest.ToWire()
+	if v.WorkflowType != nil {
+		w, err = v.WorkflowType.ToWire()
 		if err != nil {
 			return w, err
 		}
-		fields[i] = wire.Field{ID: 50, Value: w}
+		fields[i] = wire.Field{ID: 70, Value: w}
+		i++
+	}
+	if v.WorkflowDomain != nil {
+		w, err = wire.NewValueString(*(v.WorkflowDomain)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 80, Value: w}
 		i++
 	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _PollForDecisionTaskRequest_Read(w wire.Value) (*shared.PollForDecisionTaskRequest, error) {
-	var v shared.PollForDecisionTaskRequest
+func _HistoryEvent_Read(w wire.Value) (*shared.HistoryEvent, error) {
+	var v shared.HistoryEvent
 	err := v.FromWire(w)
 	return &v, err
 }
 
-// FromWire deserializes a RecordDecisionTaskStartedRequest struct from its Thrift-level
+// FromWire deserializes a RecordActivityTaskStartedResponse struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a RecordDecisionTaskStartedRequest struct
+// An error is returned if we were unable to build a RecordActivityTaskStartedResponse struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -3472,67 +3515,75 @@ func _PollForDecisionTaskRequest_Read(w wire.Value) (*shared.PollForDecisionTask
 //     return nil, err
 //   }
 //
-//   var v RecordDecisionTaskStartedRequest
+//   var v RecordActivityTaskStartedResponse
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *RecordDecisionTaskStartedRequest) FromWire(w wire.Value) error {
+func (v *RecordActivityTaskStartedResponse) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
-		case 10:
-			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.DomainUUID = &x
+		case 20:
+			if field.Value.Type() == wire.TStruct {
+				v.ScheduledEvent, err = _HistoryEvent_Read(field.Value)
 				if err != nil {
 					return err
 				}
 
 			}
-		case 20:
-			if field.Value.Type() == wire.TStruct {
-				v.WorkflowExecution, err = _WorkflowExecution_Read(field.Value)
+		case 30:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.StartedTimestamp = &x
 				if err != nil {
 					return err
 				}
 
 			}
-		case 30:
+		case 40:
 			if field.Value.Type() == wire.TI64 {
 				var x int64
 				x, err = field.Value.GetI64(), error(nil)
-				v.ScheduleId = &x
+				v.Attempt = &x
 				if err != nil {
 					return err
 				}
 
 			}
-		case 40:
+		case 50:
 			if field.Value.Type() == wire.TI64 {
 				var x int64
 				x, err = field.Value.GetI64(), error(nil)
-				v.TaskId = &x
+				v.ScheduledTimestampOfThisAttempt = &x
 				if err != nil {
 					return err
 				}
 
 			}
-		case 45:
+		case 60:
 			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.RequestId = &x
+				v.HeartbeatDetails, err = field.Value.GetBinary(), error(nil)
 				if err != nil {
 					return err
 				}
 
 			}
-		case 50:
+		case 70:
 			if field.Value.Type() == wire.TStruct {
-				v.PollRequest, err = _PollForDecisionTaskRequest_Read(field.Value)
+				v.WorkflowType, err = _WorkflowType_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 80:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.WorkflowDomain = &x
 				if err != nil {
 					return err
 				}
@@ -3544,69 +3595,76 @@ func (v *RecordDecisionTaskStartedRequest) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a RecordDecisionTaskStartedRequest
+// String returns a readable string representation of a RecordActivityTaskStartedResponse
 // struct.
-func (v *RecordDecisionTaskStartedRequest) String() string {
+func (v *RecordActivityTaskStartedResponse) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [6]string
+	var fields [7]string
 	i := 0
-	if v.DomainUUID != nil {
-		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
+	if v.ScheduledEvent != nil {
+		fields[i] = fmt.Sprintf("ScheduledEvent: %v", v.ScheduledEvent)
 		i++
 	}
-	if v.WorkflowExecution != nil {
-		fields[i] = fmt.Sprintf("WorkflowExecution: %v", v.WorkflowExecution)
+	if v.StartedTimestamp != nil {
+		fields[i] = fmt.Sprintf("StartedTimestamp: %v", *(v.StartedTimestamp))
 		i++
 	}
-	if v.ScheduleId != nil {
-		fields[i] = fmt.Sprintf("ScheduleId: %v", *(v.ScheduleId))
+	if v.Attempt != nil {
+		fields[i] = fmt.Sprintf("Attempt: %v", *(v.Attempt))
 		i++
 	}
-	if v.TaskId != nil {
-		fields[i] = fmt.Sprintf("TaskId: %v", *(v.TaskId))
+	if v.ScheduledTimestampOfThisAttempt != nil {
+		fields[i] = fmt.Sprintf("ScheduledTimestampOfThisAttempt: %v", *(v.ScheduledTimestampOfThisAttempt))
 		i++
 	}
-	if v.RequestId != nil {
-		fields[i] = fmt.Sprintf("RequestId: %v", *(v.RequestId))
+	if v.HeartbeatDetails != nil {
+		fields[i] = fmt.Sprintf("HeartbeatDetails: %v", v.HeartbeatDetails)
 		i++
 	}
-	if v.PollRequest != nil {
-		fields[i] = fmt.Sprintf("PollRequest: %v", v.PollRequest)
+	if v.WorkflowType != nil {
+		fields[i] = fmt.Sprintf("WorkflowType: %v", v.WorkflowType)
+		i++
+	}
+	if v.WorkflowDomain != nil {
+		fields[i] = fmt.Sprintf("WorkflowDomain: %v", *(v.WorkflowDomain))
 		i++
 	}
 
-	return fmt.Sprintf("RecordDecisionTaskStartedRequest{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("RecordActivityTaskStartedResponse{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this RecordDecisionTaskStartedRequest match the
-// provided RecordDecisionTaskStartedRequest.
+// Equals returns true if all the fields of this RecordActivityTaskStartedResponse match the
+// provided RecordActivityTaskStartedResponse.
 //
 // This function performs a deep comparison.
-func (v *RecordDecisionTaskStartedRequest) Equals(rhs *RecordDecisionTaskStartedRequest) bool {
+func (v *RecordActivityTaskStartedResponse) Equals(rhs *RecordActivityTaskStartedResponse) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
 		return false
 	}
-	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
+	if !((v.ScheduledEvent == nil && rhs.ScheduledEvent == nil) || (v.ScheduledEvent != nil && rhs.ScheduledEvent != nil && v.ScheduledEvent.Equals(rhs.ScheduledEvent))) {
 		return false
 	}
-	if !((v.WorkflowExecution == nil && rhs.WorkflowExecution == nil) || (v.WorkflowExecution != nil && rhs.WorkflowExecution != nil && v.WorkflowExecution.Equals(rhs.WorkflowExecution))) {
+	if !_I64_EqualsPtr(v.StartedTimestamp, rhs.StartedTimestamp) {
 		return false
 	}
-	if !_I64_EqualsPtr(v.ScheduleId, rhs.ScheduleId) {
+	if !_I64_EqualsPtr(v.Attempt, rhs.Attempt) {
 		return false
 	}
-	if !_I64_EqualsPtr(v.TaskId, rhs.TaskId) {
+	if !_I64_EqualsPtr(v.ScheduledTimestampOfThisAttempt, rhs.ScheduledTimestampOfThisAttempt) {
 		return false
 	}
-	if !_String_EqualsPtr(v.RequestId, rhs.RequestId) {
+	if !((v.HeartbeatDetails == nil && rhs.HeartbeatDetails == nil) || (v.HeartbeatDetails != nil && rhs.HeartbeatDetails != nil && bytes.Equal(v.HeartbeatDetails, rhs.HeartbeatDetails))) {
 		return false
 	}
-	if !((v.PollRequest == nil && rhs.PollRequest == nil) || (v.PollRequest != nil && rhs.PollRequest != nil && v.PollRequest.Equals(rhs.PollRequest))) {
+	if !((v.WorkflowType == nil && rhs.WorkflowType == nil) || (v.WorkflowType != nil && rhs.WorkflowType != nil && v.WorkflowType.Equals(rhs.WorkflowType))) {
+		return false
+	}
+	if !_String_EqualsPtr(v.WorkflowDomain, rhs.WorkflowDomain) {
 		return false
 	}
 
@@ -3614,137 +3672,154 @@ func (v *RecordDecisionTaskStartedRequest) Equals(rhs *RecordDecisionTaskStarted
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of RecordDecisionTaskStartedRequest.
-func (v *RecordDecisionTaskStartedRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of RecordActivityTaskStartedResponse.
+func (v *RecordActivityTaskStartedResponse) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
-	if v.DomainUUID != nil {
-		enc.AddString("domainUUID", *v.DomainUUID)
+	if v.ScheduledEvent != nil {
+		err = multierr.Append(err, enc.AddObject("scheduledEvent", v.ScheduledEvent))
 	}
-	if v.WorkflowExecution != nil {
-		err = multierr.Append(err, enc.AddObject("workflowExecution", v.WorkflowExecution))
+	if v.StartedTimestamp != nil {
+		enc.AddInt64("startedTimestamp", *v.StartedTimestamp)
 	}
-	if v.ScheduleId != nil {
-		enc.AddInt64("scheduleId", *v.ScheduleId)
+	if v.Attempt != nil {
+		enc.AddInt64("attempt", *v.Attempt)
 	}
-	if v.TaskId != nil {
-		enc.AddInt64("taskId", *v.TaskId)
+	if v.ScheduledTimestampOfThisAttempt != nil {
+		enc.AddInt64("scheduledTimestampOfThisAttempt", *v.ScheduledTimestampOfThisAttempt)
 	}
-	if v.RequestId != nil {
-		enc.AddString("requestId", *v.RequestId)
+	if v.HeartbeatDetails != nil {
+		enc.AddString("heartbeatDetails", base64.StdEncoding.EncodeToString(v.HeartbeatDetails))
 	}
-	if v.PollRequest != nil {
-		err = multierr.Append(err, enc.AddObject("pollRequest", v.PollRequest))
+	if v.WorkflowType != nil {
+		err = multierr.Append(err, enc.AddObject("workflowType", v.WorkflowType))
+	}
+	if v.WorkflowDomain != nil {
+		enc.AddString("workflowDomain", *v.WorkflowDomain)
 	}
 	return err
 }
 
-// GetDomainUUID returns the value of DomainUUID if it is set or its
+// GetScheduledEvent returns the value of ScheduledEvent if it is set or its
 // zero value if it is unset.
-func (v *RecordDecisionTaskStartedRequest) GetDomainUUID() (o string) {
-	if v != nil && v.DomainUUID != nil {
-		return *v.DomainUUID
+func (v *RecordActivityTaskStartedResponse) GetScheduledEvent() (o *shared.HistoryEvent) {
+	if v != nil && v.ScheduledEvent != nil {
+		return v.ScheduledEvent
 	}
 
 	return
 }
 
-// IsSetDomainUUID returns true if DomainUUID is not nil.
-func (v *RecordDecisionTaskStartedRequest) IsSetDomainUUID() bool {
-	return v != nil && v.DomainUUID != nil
+// IsSetScheduledEvent returns true if ScheduledEvent is not nil.
+func (v *RecordActivityTaskStartedResponse) IsSetScheduledEvent() bool {
+	return v != nil && v.ScheduledEvent != nil
 }
 
-// GetWorkflowExecution returns the value of WorkflowExecution if it is set or its
+// GetStartedTimestamp returns the value of StartedTimestamp if it is set or its
 // zero value if it is unset.
-func (v *RecordDecisionTaskStartedRequest) GetWorkflowExecution() (o *shared.WorkflowExecution) {
-	if v != nil && v.WorkflowExecution != nil {
-		return v.WorkflowExecution
+func (v *RecordActivityTaskStartedResponse) GetStartedTimestamp() (o int64) {
+	if v != nil && v.StartedTimestamp != nil {
+		return *v.StartedTimestamp
 	}
 
 	return
 }
 
-// IsSetWorkflowExecution returns true if WorkflowExecution is not nil.
-func (v *RecordDecisionTaskStartedRequest) IsSetWorkflowExecution() bool {
-	return v != nil && v.WorkflowExecution != nil
+// IsSetStartedTimestamp returns true if StartedTimestamp is not nil.
+func (v *RecordActivityTaskStartedResponse) IsSetStartedTimestamp() bool {
+	return v != nil && v.StartedTimestamp != nil
 }
 
-// GetScheduleId returns the value of ScheduleId if it is set or its
+// GetAttempt returns the value of Attempt if it is set or its
 // zero value if it is unset.
-func (v *RecordDecisionTaskStartedRequest) GetScheduleId() (o int64) {
-	if v != nil && v.ScheduleId != nil {
-		return *v.ScheduleId
+func (v *RecordActivityTaskStartedResponse) GetAttempt() (o int64) {
+	if v != nil && v.Attempt != nil {
+		return *v.Attempt
 	}
 
 	return
 }
 
-// IsSetScheduleId returns true if ScheduleId is not nil.
-func (v *RecordDecisionTaskStartedRequest) IsSetScheduleId() bool {
-	return v != nil && v.ScheduleId != nil
+// IsSetAttempt returns true if Attempt is not nil.
+func (v *RecordActivityTaskStartedResponse) IsSetAttempt() bool {
+	return v != nil && v.Attempt != nil
 }
 
-// GetTaskId returns the value of TaskId if it is set or its
+// GetScheduledTimestampOfThisAttempt returns the value of ScheduledTimestampOfThisAttempt if it is set or its
 // zero value if it is unset.
-func (v *RecordDecisionTaskStartedRequest) GetTaskId() (o int64) {
-	if v != nil && v.TaskId != nil {
-		return *v.TaskId
+func (v *RecordActivityTaskStartedResponse) GetScheduledTimestampOfThisAttempt() (o int64) {
+	if v != nil && v.ScheduledTimestampOfThisAttempt != nil {
+		return *v.ScheduledTimestampOfThisAttempt
 	}
 
 	return
 }
 
-// IsSetTaskId returns true if TaskId is not nil.
-func (v *RecordDecisionTaskStartedRequest) IsSetTaskId() bool {
-	return v != nil && v.TaskId != nil
+// IsSetScheduledTimestampOfThisAttempt returns true if ScheduledTimestampOfThisAttempt is not nil.
+func (v *RecordActivityTaskStartedResponse) IsSetScheduledTimestampOfThisAttempt() bool {
+	return v != nil && v.ScheduledTimestampOfThisAttempt != nil
 }
 
-// GetRequestId returns the value of RequestId if it is set or its
+// GetHeartbeatDetails returns the value of HeartbeatDetails if it is set or its
 // zero value if it is unset.
-func (v *RecordDecisionTaskStartedRequest) GetRequestId() (o string) {
-	if v != nil && v.RequestId != nil {
-		return *v.RequestId
+func (v *RecordActivityTaskStartedResponse) GetHeartbeatDetails() (o []byte) {
+	if v != nil && v.HeartbeatDetails != nil {
+		return v.HeartbeatDetails
 	}
 
 	return
 }
 
-// IsSetRequestId returns true if RequestId is not nil.
-func (v *RecordDecisionTaskStartedRequest) IsSetRequestId() bool {
-	return v != nil && v.RequestId != nil
+// IsSetHeartbeatDetails returns true if HeartbeatDetails is not nil.
+func (v *RecordActivityTaskStartedResponse) IsSetHeartbeatDetails() bool {
+	return v != nil && v.HeartbeatDetails != nil
 }
 
-// GetPollRequest returns the value of PollRequest if it is set or its
+// GetWorkflowType returns the value of WorkflowType if it is set or its
 // zero value if it is unset.
-func (v *RecordDecisionTaskStartedRequest) GetPollRequest() (o *shared.PollForDecisionTaskRequest) {
-	if v != nil && v.PollRequest != nil {
-		return v.PollRequest
+func (v *RecordActivityTaskStartedResponse) GetWorkflowType() (o *shared.WorkflowType) {
+	if v != nil && v.WorkflowType != nil {
+		return v.WorkflowType
 	}
 
 	return
 }
 
-// IsSetPollRequest returns true if PollRequest is not nil.
-func (v *RecordDecisionTaskStartedRequest) IsSetPollRequest() bool {
-	return v != nil && v.PollRequest != nil
+// IsSetWorkflowType returns true if WorkflowType is not nil.
+func (v *RecordActivityTaskStartedResponse) IsSetWorkflowType() bool {
+	return v != nil && v.WorkflowType != nil
 }
 
-type RecordDecisionTaskStartedResponse struct {
-	WorkflowType              *shared.WorkflowType          `json:"workflowType,omitempty"`
-	PreviousStartedEventId    *int64                        `json:"previousStartedEventId,omitempty"`
-	ScheduledEventId          *int64                        `json:"scheduledEventId,omitempty"`
-	StartedEventId            *int64                        `json:"startedEventId,omitempty"`
-	NextEventId               *int64                        `json:"nextEventId,omitempty"`
-	Attempt                   *int64                        `json:"attempt,omitempty"`
-	StickyExecutionEnabled    *bool                         `json:"stickyExecutionEnabled,omitempty"`
-	DecisionInfo              *shared.TransientDecisionInfo `json:"decisionInfo,omitempty"`
-	WorkflowExecutionTaskList *shared.TaskList              `json:"WorkflowExecutionTaskList,omitempty"`
-	EventStoreVersion         *int32                        `json:"eventStoreVersion,omitempty"`
-	BranchToken               []byte                        `json:"branchToken,omitempty"`
+// GetWorkflowDomain returns the value of WorkflowDomain if it is set or its
+// zero value if it is unset.
+func (v *RecordActivityTaskStartedResponse) GetWorkflowDomain() (o string) {
+	if v != nil && v.WorkflowDomain != nil {
+		return *v.WorkflowDomain
+	}
+
+	return
 }
 
-// ToWire translates a RecordDecisionTaskStartedResponse struct into a Thrift-level intermediate
+// IsSetWorkflowDomain returns true if WorkflowDomain is not nil.
+func (v *RecordActivityTaskStartedResponse) IsSetWorkflowDomain() bool {
+	return v != nil && v.WorkflowDomain != nil
+}
+
+// RecordChildExecutionCompletedRequest is used for reporting the completion of child execution to parent workflow
+// execution which started it.  When a child execution is completed it creates this request and calls the
+// RecordChildExecutionCompleted API with the workflowExecution of parent.  It also sets the completedExecution of the
+// child as it could potentially be different than the ChildExecutionStartedEvent of parent in the situation when
+// child creates multiple runs through ContinueAsNew before finally completing.
+type RecordChildExecutionCompletedRequest struct {
+	DomainUUID         *string                   `json:"domainUUID,omitempty"`
+	WorkflowExecution  *shared.WorkflowExecution `json:"workflowExecution,omitempty"`
+	InitiatedId        *int64                    `json:"initiatedId,omitempty"`
+	CompletedExecution *shared.WorkflowExecution `json:"completedExecution,omitempty"`
+	CompletionEvent    *shared.HistoryEvent      `json:"completionEvent,omitempty"`
+}
+
+// ToWire translates a RecordChildExecutionCompletedRequest struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -3759,117 +3834,63 @@ type RecordDecisionTaskStartedResponse struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *RecordDecisionTaskStartedResponse) ToWire() (wire.Value, error) {
+func (v *RecordChildExecutionCompletedRequest) ToWire() (wire.Value, error) {
 	var (
-		fields [11]wire.Field
+		fields [5]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
 	)
 
-	if v.WorkflowType != nil {
-		w, err = v.WorkflowType.ToWire()
+	if v.DomainUUID != nil {
+		w, err = wire.NewValueString(*(v.DomainUUID)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.PreviousStartedEventId != nil {
-		w, err = wire.NewValueI64(*(v.PreviousStartedEventId)), error(nil)
+	if v.WorkflowExecution != nil {
+		w, err = v.WorkflowExecution.ToWire()
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
-	if v.ScheduledEventId != nil {
-		w, err = wire.NewValueI64(*(v.ScheduledEventId)), error(nil)
+	if v.InitiatedId != nil {
+		w, err = wire.NewValueI64(*(v.InitiatedId)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 30, Value: w}
 		i++
 	}
-	if v.StartedEventId != nil {
-		w, err = wire.NewValueI64(*(v.StartedEventId)), error(nil)
+	if v.CompletedExecution != nil {
+		w, err = v.CompletedExecution.ToWire()
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 40, Value: w}
 		i++
 	}
-	if v.NextEventId != nil {
-		w, err = wire.NewValueI64(*(v.NextEventId)), error(nil)
+	if v.CompletionEvent != nil {
+		w, err = v.CompletionEvent.ToWire()
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 50, Value: w}
 		i++
 	}
-	if v.Attempt != nil {
-		w, err = wire.NewValueI64(*(v.Attempt)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 60, Value: w}
-		i++
-	}
-	if v.StickyExecutionEnabled != nil {
-		w, err = wire.NewValueBool(*(v.StickyExecutionEnabled)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 70, Value: w}
-		i++
-	}
-	if v.DecisionInfo != nil {
-		w, err = v.DecisionInfo.ToWire()
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 80, Value: w}
-		i++
-	}
-	if v.WorkflowExecutionTaskList != nil {
-		w, err = v.WorkflowExecutionTaskList.ToWire()
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 90, Value: w}
-		i++
-	}
-	if v.EventStoreVersion != nil {
-		w, err = wire.NewValueI32(*(v.EventStoreVersion)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 100, Value: w}
-		i++
-	}
-	if v.BranchToken != nil {
-		w, err = wire.NewValueBinary(v.BranchToken), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 110, Value: w}
-		i++
-	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _TransientDecisionInfo_Read(w wire.Value) (*shared.TransientDecisionInfo, error) {
-	var v shared.TransientDecisionInfo
-	err := v.FromWire(w)
-	return &v, err
-}
-
-// FromWire deserializes a RecordDecisionTaskStartedResponse struct from its Thrift-level
+// FromWire deserializes a RecordChildExecutionCompletedRequest struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a RecordDecisionTaskStartedResponse struct
+// An error is returned if we were unable to build a RecordChildExecutionCompletedRequest struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -3877,29 +3898,29 @@ func _TransientDecisionInfo_Read(w wire.Value) (*shared.TransientDecisionInfo, e
 //     return nil, err
 //   }
 //
-//   var v RecordDecisionTaskStartedResponse
+//   var v RecordChildExecutionCompletedRequest
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *RecordDecisionTaskStartedResponse) FromWire(w wire.Value) error {
+func (v *RecordChildExecutionCompletedRequest) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
 		case 10:
-			if field.Value.Type() == wire.TStruct {
-				v.WorkflowType, err = _WorkflowType_Read(field.Value)
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.DomainUUID = &x
 				if err != nil {
 					return err
 				}
 
 			}
 		case 20:
-			if field.Value.Type() == wire.TI64 {
-				var x int64
-				x, err = field.Value.GetI64(), error(nil)
-				v.PreviousStartedEventId = &x
+			if field.Value.Type() == wire.TStruct {
+				v.WorkflowExecution, err = _WorkflowExecution_Read(field.Value)
 				if err != nil {
 					return err
 				}
@@ -3909,81 +3930,23 @@ func (v *RecordDecisionTaskStartedResponse) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TI64 {
 				var x int64
 				x, err = field.Value.GetI64(), error(nil)
-				v.ScheduledEventId = &x
+				v.InitiatedId = &x
 				if err != nil {
 					return err
 				}
 
 			}
 		case 40:
-			if field.Value.Type() == wire.TI64 {
-				var x int64
-				x, err = field.Value.GetI64(), error(nil)
-				v.StartedEventId = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		case 50:
-			if field.Value.Type() == wire.TI64 {
-				var x int64
-				x, err = field.Value.GetI64(), error(nil)
-				v.NextEventId = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		case 60:
-			if field.Value.Type() == wire.TI64 {
-				var x int64
-				x, err = field.Value.GetI64(), error(nil)
-				v.Attempt = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		case 70:
-			if field.Value.Type() == wire.TBool {
-				var x bool
-				x, err = field.Value.GetBool(), error(nil)
-				v.StickyExecutionEnabled = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		case 80:
 			if field.Value.Type() == wire.TStruct {
-				v.DecisionInfo, err = _TransientDecisionInfo_Read(field.Value)
+				v.CompletedExecution, err = _WorkflowExecution_Read(field.Value)
 				if err != nil {
 					return err
 				}
 
 			}
-		case 90:
+		case 50:
 			if field.Value.Type() == wire.TStruct {
-				v.WorkflowExecutionTaskList, err = _TaskList_Read(field.Value)
-				if err != nil {
-					return err
-				}
-
-			}
-		case 100:
-			if field.Value.Type() == wire.TI32 {
-				var x int32
-				x, err = field.Value.GetI32(), error(nil)
-				v.EventStoreVersion = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		case 110:
-			if field.Value.Type() == wire.TBinary {
-				v.BranchToken, err = field.Value.GetBinary(), error(nil)
+				v.CompletionEvent, err = _HistoryEvent_Read(field.Value)
 				if err != nil {
 					return err
 				}
@@ -3995,104 +3958,62 @@ func (v *RecordDecisionTaskStartedResponse) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a RecordDecisionTaskStartedResponse
+// String returns a readable string representation of a RecordChildExecutionCompletedRequest
 // struct.
-func (v *RecordDecisionTaskStartedResponse) String() string {
+func (v *RecordChildExecutionCompletedRequest) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [11]string
+	var fields [5]string
 	i := 0
-	if v.WorkflowType != nil {
-		fields[i] = fmt.Sprintf("WorkflowType: %v", v.WorkflowType)
-		i++
-	}
-	if v.PreviousStartedEventId != nil {
-		fields[i] = fmt.Sprintf("PreviousStartedEventId: %v", *(v.PreviousStartedEventId))
-		i++
-	}
-	if v.ScheduledEventId != nil {
-		fields[i] = fmt.Sprintf("ScheduledEventId: %v", *(v.ScheduledEventId))
-		i++
-	}
-	if v.StartedEventId != nil {
-		fields[i] = fmt.Sprintf("StartedEventId: %v", *(v.StartedEventId))
-		i++
-	}
-	if v.NextEventId != nil {
-		fields[i] = fmt.Sprintf("NextEventId: %v", *(v.NextEventId))
-		i++
-	}
-	if v.Attempt != nil {
-		fields[i] = fmt.Sprintf("Attempt: %v", *(v.Attempt))
-		i++
-	}
-	if v.StickyExecutionEnabled != nil {
-		fields[i] = fmt.Sprintf("StickyExecutionEnabled: %v", *(v.StickyExecutionEnabled))
+	if v.DomainUUID != nil {
+		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
 		i++
 	}
-	if v.DecisionInfo != nil {
-		fields[i] = fmt.Sprintf("DecisionInfo: %v", v.DecisionInfo)
+	if v.WorkflowExecution != nil {
+		fields[i] = fmt.Sprintf("WorkflowExecution: %v", v.WorkflowExecution)
 		i++
 	}
-	if v.WorkflowExecutionTaskList != nil {
-		fields[i] = fmt.Sprintf("WorkflowExecutionTaskList: %v", v.WorkflowExecutionTaskList)
+	if v.InitiatedId != nil {
+		fields[i] = fmt.Sprintf("InitiatedId: %v", *(v.InitiatedId))
 		i++
 	}
-	if v.EventStoreVersion != nil {
-		fields[i] = fmt.Sprintf("EventStoreVersion: %v", *(v.EventStoreVersion))
+	if v.CompletedExecution != nil {
+		fields[i] = fmt.Sprintf("CompletedExecution: %v", v.CompletedExecution)
 		i++
 	}
-	if v.BranchToken != nil {
-		fields[i] = fmt.Sprintf("BranchToken: %v", v.BranchToken)
+	if v.CompletionEvent != nil {
+		fields[i] = fmt.Sprintf("CompletionEvent: %v", v.CompletionEvent)
 		i++
 	}
 
-	return fmt.Sprintf("RecordDecisionTaskStartedResponse{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("RecordChildExecutionCompletedRequest{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this RecordDecisionTaskStartedResponse match the
-// provided RecordDecisionTaskStartedResponse.
+// Equals returns true if all the fields of this RecordChildExecutionCompletedRequest match the
+// provided RecordChildExecutionCompletedRequest.
 //
 // This function performs a deep comparison.
-func (v *RecordDecisionTaskStartedResponse) Equals(rhs *RecordDecisionTaskStartedResponse) bool {
+func (v *RecordChildExecutionCompletedRequest) Equals(rhs *RecordChildExecutionCompletedRequest) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
 		return false
 	}
-	if !((v.WorkflowType == nil && rhs.WorkflowType == nil) || (v.WorkflowType != nil && rhs.WorkflowType != nil && v.WorkflowType.Equals(rhs.WorkflowType))) {
-		return false
-	}
-	if !_I64_EqualsPtr(v.PreviousStartedEventId, rhs.PreviousStartedEventId) {
-		return false
-	}
-	if !_I64_EqualsPtr(v.ScheduledEventId, rhs.ScheduledEventId) {
-		return false
-	}
-	if !_I64_EqualsPtr(v.StartedEventId, rhs.StartedEventId) {
-		return false
-	}
-	if !_I64_EqualsPtr(v.NextEventId, rhs.NextEventId) {
-		return false
-	}
-	if !_I64_EqualsPtr(v.Attempt, rhs.Attempt) {
-		return false
-	}
-	if !_Bool_EqualsPtr(v.StickyExecutionEnabled, rhs.StickyExecutionEnabled) {
+	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
 		return false
 	}
-	if !((v.DecisionInfo == nil && rhs.DecisionInfo == nil) || (v.DecisionInfo != nil && rhs.DecisionInfo != nil && v.DecisionInfo.Equals(rhs.DecisionInfo))) {
+	if !((v.WorkflowExecution == nil && rhs.WorkflowExecution == nil) || (v.WorkflowExecution != nil && rhs.WorkflowExecution != nil && v.WorkflowExecution.Equals(rhs.WorkflowExecution))) {
 		return false
 	}
-	if !((v.WorkflowExecutionTaskList == nil && rhs.WorkflowExecutionTaskList == nil) || (v.WorkflowExecutionTaskList != nil && rhs.WorkflowExecutionTaskList != nil && v.WorkflowExecutionTaskList.Equals(rhs.WorkflowExecutionTaskList))) {
+	if !_I64_EqualsPtr(v.InitiatedId, rhs.InitiatedId) {
 		return false
 	}
-	if !_I32_EqualsPtr(v.EventStoreVersion, rhs.EventStoreVersion) {
+	if !((v.CompletedExecution == nil && rhs.CompletedExecution == nil) || (v.CompletedExecution != nil && rhs.CompletedExecution != nil && v.CompletedExecution.Equals(rhs.CompletedExecution))) {
 		return false
 	}
-	if !((v.BranchToken == nil && rhs.BranchToken == nil) || (v.BranchToken != nil && rhs.BranchToken != nil && bytes.Equal(v.BranchToken, rhs.BranchToken))) {
+	if !((v.CompletionEvent == nil && rhs.CompletionEvent == nil) || (v.CompletionEvent != nil && rhs.CompletionEvent != nil && v.CompletionEvent.Equals(rhs.CompletionEvent))) {
 		return false
 	}
 
@@ -4100,219 +4021,114 @@ func (v *RecordDecisionTaskStartedResponse) Equals(rhs *RecordDecisionTaskStarte
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of RecordDecisionTaskStartedResponse.
-func (v *RecordDecisionTaskStartedResponse) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of RecordChildExecutionCompletedRequest.
+func (v *RecordChildExecutionCompletedRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
-	if v.WorkflowType != nil {
-		err = multierr.Append(err, enc.AddObject("workflowType", v.WorkflowType))
-	}
-	if v.PreviousStartedEventId != nil {
-		enc.AddInt64("previousStartedEventId", *v.PreviousStartedEventId)
-	}
-	if v.ScheduledEventId != nil {
-		enc.AddInt64("scheduledEventId", *v.ScheduledEventId)
-	}
-	if v.StartedEventId != nil {
-		enc.AddInt64("startedEventId", *v.StartedEventId)
-	}
-	if v.NextEventId != nil {
-		enc.AddInt64("nextEventId", *v.NextEventId)
-	}
-	if v.Attempt != nil {
-		enc.AddInt64("attempt", *v.Attempt)
-	}
-	if v.StickyExecutionEnabled != nil {
-		enc.AddBool("stickyExecutionEnabled", *v.StickyExecutionEnabled)
+	if v.DomainUUID != nil {
+		enc.AddString("domainUUID", *v.DomainUUID)
 	}
-	if v.DecisionInfo != nil {
-		err = multierr.Append(err, enc.AddObject("decisionInfo", v.DecisionInfo))
+	if v.WorkflowExecution != nil {
+		err = multierr.Append(err, enc.AddObject("workflowExecution", v.WorkflowExecution))
 	}
-	if v.WorkflowExecutionTaskList != nil {
-		err = multierr.Append(err, enc.AddObject("WorkflowExecutionTaskList", v.WorkflowExecutionTaskList))
+	if v.InitiatedId != nil {
+		enc.AddInt64("initiatedId", *v.InitiatedId)
 	}
-	if v.EventStoreVersion != nil {
-		enc.AddInt32("eventStoreVersion", *v.EventStoreVersion)
+	if v.CompletedExecution != nil {
+		err = multierr.Append(err, enc.AddObject("completedExecution", v.CompletedExecution))
 	}
-	if v.BranchToken != nil {
-		enc.AddString("branchToken", base64.StdEncoding.EncodeToString(v.BranchToken))
+	if v.CompletionEvent != nil {
+		err = multierr.Append(err, enc.AddObject("completionEvent", v.CompletionEvent))
 	}
 	return err
 }
 
-// GetWorkflowType returns the value of WorkflowType if it is set or its
-// zero value if it is unset.
-func (v *RecordDecisionTaskStartedResponse) GetWorkflowType() (o *shared.WorkflowType) {
-	if v != nil && v.WorkflowType != nil {
-		return v.WorkflowType
-	}
-
-	return
-}
-
-// IsSetWorkflowType returns true if WorkflowType is not nil.
-func (v *RecordDecisionTaskStartedResponse) IsSetWorkflowType() bool {
-	return v != nil && v.WorkflowType != nil
-}
-
-// GetPreviousStartedEventId returns the value of PreviousStartedEventId if it is set or its
-// zero value if it is unset.
-func (v *RecordDecisionTaskStartedResponse) GetPreviousStartedEventId() (o int64) {
-	if v != nil && v.PreviousStartedEventId != nil {
-		return *v.PreviousStartedEventId
-	}
-
-	return
-}
-
-// IsSetPreviousStartedEventId returns true if PreviousStartedEventId is not nil.
-func (v *RecordDecisionTaskStartedResponse) IsSetPreviousStartedEventId() bool {
-	return v != nil && v.PreviousStartedEventId != nil
-}
-
-// GetScheduledEventId returns the value of ScheduledEventId if it is set or its
-// zero value if it is unset.
-func (v *RecordDecisionTaskStartedResponse) GetScheduledEventId() (o int64) {
-	if v != nil && v.ScheduledEventId != nil {
-		return *v.ScheduledEventId
-	}
-
-	return
-}
-
-// IsSetScheduledEventId returns true if ScheduledEventId is not nil.
-func (v *RecordDecisionTaskStartedResponse) IsSetScheduledEventId() bool {
-	return v != nil && v.ScheduledEventId != nil
-}
-
-// GetStartedEventId returns the value of StartedEventId if it is set or its
-// zero value if it is unset.
-func (v *RecordDecisionTaskStartedResponse) GetStartedEventId() (o int64) {
-	if v != nil && v.StartedEventId != nil {
-		return *v.StartedEventId
-	}
-
-	return
-}
-
-// IsSetStartedEventId returns true if StartedEventId is not nil.
-func (v *RecordDecisionTaskStartedResponse) IsSetStartedEventId() bool {
-	return v != nil && v.StartedEventId != nil
-}
-
-// GetNextEventId returns the value of NextEventId if it is set or its
-// zero value if it is unset.
-func (v *RecordDecisionTaskStartedResponse) GetNextEventId() (o int64) {
-	if v != nil && v.NextEventId != nil {
-		return *v.NextEventId
-	}
-
-	return
-}
-
-// IsSetNextEventId returns true if NextEventId is not nil.
-func (v *RecordDecisionTaskStartedResponse) IsSetNextEventId() bool {
-	return v != nil && v.NextEventId != nil
-}
-
-// GetAttempt returns the value of Attempt if it is set or its
-// zero value if it is unset.
-func (v *RecordDecisionTaskStartedResponse) GetAttempt() (o int64) {
-	if v != nil && v.Attempt != nil {
-		return *v.Attempt
-	}
-
-	return
-}
-
-// IsSetAttempt returns true if Attempt is not nil.
-func (v *RecordDecisionTaskStartedResponse) IsSetAttempt() bool {
-	return v != nil && v.Attempt != nil
-}
-
-// GetStickyExecutionEnabled returns the value of StickyExecutionEnabled if it is set or its
+// GetDomainUUID returns the value of DomainUUID if it is set or its
 // zero value if it is unset.
-func (v *RecordDecisionTaskStartedResponse) GetStickyExecutionEnabled() (o bool) {
-	if v != nil && v.StickyExecutionEnabled != nil {
-		return *v.StickyExecutionEnabled
+func (v *RecordChildExecutionCompletedRequest) GetDomainUUID() (o string) {
+	if v != nil && v.DomainUUID != nil {
+		return *v.DomainUUID
 	}
 
 	return
 }
 
-// IsSetStickyExecutionEnabled returns true if StickyExecutionEnabled is not nil.
-func (v *RecordDecisionTaskStartedResponse) IsSetStickyExecutionEnabled() bool {
-	return v != nil && v.StickyExecutionEnabled != nil
+// IsSetDomainUUID returns true if DomainUUID is not nil.
+func (v *RecordChildExecutionCompletedRequest) IsSetDomainUUID() bool {
+	return v != nil && v.DomainUUID != nil
 }
 
-// GetDecisionInfo returns the value of DecisionInfo if it is set or its
+// GetWorkflowExecution returns the value of WorkflowExecution if it is set or its
 // zero value if it is unset.
-func (v *RecordDecisionTaskStartedResponse) GetDecisionInfo() (o *shared.TransientDecisionInfo) {
-	if v != nil && v.DecisionInfo != nil {
-		return v.DecisionInfo
+func (v *RecordChildExecutionCompletedRequest) GetWorkflowExecution() (o *shared.WorkflowExecution) {
+	if v != nil && v.WorkflowExecution != nil {
+		return v.WorkflowExecution
 	}
 
 	return
 }
 
-// IsSetDecisionInfo returns true if DecisionInfo is not nil.
-func (v *RecordDecisionTaskStartedResponse) IsSetDecisionInfo() bool {
-	return v != nil && v.DecisionInfo != nil
+// IsSetWorkflowExecution returns true if WorkflowExecution is not nil.
+func (v *RecordChildExecutionCompletedRequest) IsSetWorkflowExecution() bool {
+	return v != nil && v.WorkflowExecution != nil
 }
 
-// GetWorkflowExecutionTaskList returns the value of WorkflowExecutionTaskList if it is set or its
+// GetInitiatedId returns the value of InitiatedId if it is set or its
 // zero value if it is unset.
-func (v *RecordDecisionTaskStartedResponse) GetWorkflowExecutionTaskList() (o *shared.TaskList) {
-	if v != nil && v.WorkflowExecutionTaskList != nil {
-		return v.WorkflowExecutionTaskList
+func (v *RecordChildExecutionCompletedRequest) GetInitiatedId() (o int64) {
+	if v != nil && v.InitiatedId != nil {
+		return *v.InitiatedId
 	}
 
 	return
 }
 
-// IsSetWorkflowExecutionTaskList returns true if WorkflowExecutionTaskList is not nil.
-func (v *RecordDecisionTaskStartedResponse) IsSetWorkflowExecutionTaskList() bool {
-	return v != nil && v.WorkflowExecutionTaskList != nil
+// IsSetInitiatedId returns true if InitiatedId is not nil.
+func (v *RecordChildExecutionCompletedRequest) IsSetInitiatedId() bool {
+	return v != nil && v.InitiatedId != nil
 }
 
-// GetEventStoreVersion returns the value of EventStoreVersion if it is set or its
+// GetCompletedExecution returns the value of CompletedExecution if it is set or its
 // zero value if it is unset.
-func (v *RecordDecisionTaskStartedResponse) GetEventStoreVersion() (o int32) {
-	if v != nil && v.EventStoreVersion != nil {
-		return *v.EventStoreVersion
+func (v *RecordChildExecutionCompletedRequest) GetCompletedExecution() (o *shared.WorkflowExecution) {
+	if v != nil && v.CompletedExecution != nil {
+		return v.CompletedExecution
 	}
 
 	return
 }
 
-// IsSetEventStoreVersion returns true if EventStoreVersion is not nil.
-func (v *RecordDecisionTaskStartedResponse) IsSetEventStoreVersion() bool {
-	return v != nil && v.EventStoreVersion != nil
+// IsSetCompletedExecution returns true if CompletedExecution is not nil.
+func (v *RecordChildExecutionCompletedRequest) IsSetCompletedExecution() bool {
+	return v != nil && v.CompletedExecution != nil
 }
 
-// GetBranchToken returns the value of BranchToken if it is set or its
+// GetCompletionEvent returns the value of CompletionEvent if it is set or its
 // zero value if it is unset.
-func (v *RecordDecisionTaskStartedResponse) GetBranchToken() (o []byte) {
-	if v != nil && v.BranchToken != nil {
-		return v.BranchToken
+func (v *RecordChildExecutionCompletedRequest) GetCompletionEvent() (o *shared.HistoryEvent) {
+	if v != nil && v.CompletionEvent != nil {
+		return v.CompletionEvent
 	}
 
 	return
 }
 
-// IsSetBranchToken returns true if BranchToken is not nil.
-func (v *RecordDecisionTaskStartedResponse) IsSetBranchToken() bool {
-	return v != nil && v.BranchToken != nil
+// IsSetCompletionEvent returns true if CompletionEvent is not nil.
+func (v *RecordChildExecutionCompletedRequest) IsSetCompletionEvent() bool {
+	return v != nil && v.CompletionEvent != nil
 }
 
-type RemoveSignalMutableStateRequest struct {
-	DomainUUID        *string                   `json:"domainUUID,omitempty"`
-	WorkflowExecution *shared.WorkflowExecution `json:"workflowExecution,omitempty"`
-	RequestId         *string                   `json:"requestId,omitempty"`
+type RecordDecisionTaskStartedRequest struct {
+	DomainUUID        *string                            `json:"domainUUID,omitempty"`
+	WorkflowExecution *shared.WorkflowExecution          `json:"workflowExecution,omitempty"`
+	ScheduleId        *int64                             `json:"scheduleId,omitempty"`
+	TaskId            *int64                             `json:"taskId,omitempty"`
+	RequestId         *string                            `json:"requestId,omitempty"`
+	PollRequest       *shared.PollForDecisionTaskRequest `json:"pollRequest,omitempty"`
 }
 
-// ToWire translates a RemoveSignalMutableStateRequest struct into a Thrift-level intermediate
+// ToWire translates a RecordDecisionTaskStartedRequest struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -4327,9 +4143,9 @@ type RemoveSignalMutableStateRequest struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *RemoveSignalMutableStateRequest) ToWire() (wire.Value, error) {
+func (v *RecordDecisionTaskStartedRequest) ToWire() (wire.Value, error) {
 	var (
-		fields [3]wire.Field
+		fields [6]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -4351,23 +4167,53 @@ func (v *RemoveSignalMutableStateRequest) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
+	if v.ScheduleId != nil {
+		w, err = wire.NewValueI64(*(v.ScheduleId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+	if v.TaskId != nil {
+		w, err = wire.NewValueI64(*(v.TaskId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
 	if v.RequestId != nil {
 		w, err = wire.NewValueString(*(v.RequestId)), error(nil)
 		if err != nil {
 			return w, err
 		}
-		fields[i] = wire.Field{ID: 30, Value: w}
+		fields[i] = wire.Field{ID: 45, Value: w}
+		i++
+	}
+	if v.PollRequest != nil {
+		w, err = v.PollRequest.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 50, Value: w}
 		i++
 	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-// FromWire deserializes a RemoveSignalMutableStateRequest struct from its Thrift-level
+func _PollForDecisionTaskRequest_Read(w wire.Value) (*shared.PollForDecisionTaskRequest, error) {
+	var v shared.PollForDecisionTaskRequest
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a RecordDecisionTaskStartedRequest struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a RemoveSignalMutableStateRequest struct
+// An error is returned if we were unable to build a RecordDecisionTaskStartedRequest struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -4375,12 +4221,12 @@ func (v *RemoveSignalMutableStateRequest) ToWire() (wire.Value, error) {
 //     return nil, err
 //   }
 //
-//   var v RemoveSignalMutableStateRequest
+//   var v RecordDecisionTaskStartedRequest
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *RemoveSignalMutableStateRequest) FromWire(w wire.Value) error {
+func (v *RecordDecisionTaskStartedRequest) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
@@ -4404,10 +4250,38 @@ func (v *RemoveSignalMutableStateRequest) FromWire(w wire.Value) error {
 
 			}
 		case 30:
-			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.RequestId = &x
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.ScheduleId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.TaskId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 45:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.RequestId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 50:
+			if field.Value.Type() == wire.TStruct {
+				v.PollRequest, err = _PollForDecisionTaskRequest_Read(field.Value)
 				if err != nil {
 					return err
 				}
@@ -4419,14 +4293,14 @@ func (v *RemoveSignalMutableStateRequest) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a RemoveSignalMutableStateRequest
+// String returns a readable string representation of a RecordDecisionTaskStartedRequest
 // struct.
-func (v *RemoveSignalMutableStateRequest) String() string {
+func (v *RecordDecisionTaskStartedRequest) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [3]string
+	var fields [6]string
 	i := 0
 	if v.DomainUUID != nil {
 		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
@@ -4436,19 +4310,31 @@ func (v *RemoveSignalMutableStateRequest) String() string {
 		fields[i] = fmt.Sprintf("WorkflowExecution: %v", v.WorkflowExecution)
 		i++
 	}
+	if v.ScheduleId != nil {
+		fields[i] = fmt.Sprintf("ScheduleId: %v", *(v.ScheduleId))
+		i++
+	}
+	if v.TaskId != nil {
+		fields[i] = fmt.Sprintf("TaskId: %v", *(v.TaskId))
+		i++
+	}
 	if v.RequestId != nil {
 		fields[i] = fmt.Sprintf("RequestId: %v", *(v.RequestId))
 		i++
 	}
+	if v.PollRequest != nil {
+		fields[i] = fmt.Sprintf("PollRequest: %v", v.PollRequest)
+		i++
+	}
 
-	return fmt.Sprintf("RemoveSignalMutableStateRequest{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("RecordDecisionTaskStartedRequest{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this RemoveSignalMutableStateRequest match the
-// provided RemoveSignalMutableStateRequest.
+// Equals returns true if all the fields of this RecordDecisionTaskStartedRequest match the
+// provided RecordDecisionTaskStartedRequest.
 //
 // This function performs a deep comparison.
-func (v *RemoveSignalMutableStateRequest) Equals(rhs *RemoveSignalMutableStateRequest) bool {
+func (v *RecordDecisionTaskStartedRequest) Equals(rhs *RecordDecisionTaskStartedRequest) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
@@ -4460,16 +4346,25 @@ func (v *RemoveSignalMutableStateRequest) Equals(rhs *RemoveSignalMutableStateRe
 	if !((v.WorkflowExecution == nil && rhs.WorkflowExecution == nil) || (v.WorkflowExecution != nil && rhs.WorkflowExecution != nil && v.WorkflowExecution.Equals(rhs.WorkflowExecution))) {
 		return false
 	}
+	if !_I64_EqualsPtr(v.ScheduleId, rhs.ScheduleId) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.TaskId, rhs.TaskId) {
+		return false
+	}
 	if !_String_EqualsPtr(v.RequestId, rhs.RequestId) {
 		return false
 	}
+	if !((v.PollRequest == nil && rhs.PollRequest == nil) || (v.PollRequest != nil && rhs.PollRequest != nil && v.PollRequest.Equals(rhs.PollRequest))) {
+		return false
+	}
 
 	return true
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of RemoveSignalMutableStateRequest.
-func (v *RemoveSignalMutableStateRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of RecordDecisionTaskStartedRequest.
+func (v *RecordDecisionTaskStartedRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
@@ -4479,15 +4374,24 @@ func (v *RemoveSignalMutableStateRequest) MarshalLogObject(enc zapcore.ObjectEnc
 	if v.WorkflowExecution != nil {
 		err = multierr.Append(err, enc.AddObject("workflowExecution", v.WorkflowExecution))
 	}
+	if v.ScheduleId != nil {
+		enc.AddInt64("scheduleId", *v.ScheduleId)
+	}
+	if v.TaskId != nil {
+		enc.AddInt64("taskId", *v.TaskId)
+	}
 	if v.RequestId != nil {
 		enc.AddString("requestId", *v.RequestId)
 	}
+	if v.PollRequest != nil {
+		err = multierr.Append(err, enc.AddObject("pollRequest", v.PollRequest))
+	}
 	return err
 }
 
 // GetDomainUUID returns the value of DomainUUID if it is set or its
 // zero value if it is unset.
-func (v *RemoveSignalMutableStateRequest) GetDomainUUID() (o string) {
+func (v *RecordDecisionTaskStartedRequest) GetDomainUUID() (o string) {
 	if v != nil && v.DomainUUID != nil {
 		return *v.DomainUUID
 	}
@@ -4496,13 +4400,13 @@ func (v *RemoveSignalMutableStateRequest) GetDomainUUID() (o string) {
 }
 
 // IsSetDomainUUID returns true if DomainUUID is not nil.
-func (v *RemoveSignalMutableStateRequest) IsSetDomainUUID() bool {
+func (v *RecordDecisionTaskStartedRequest) IsSetDomainUUID() bool {
 	return v != nil && v.DomainUUID != nil
 }
 
 // GetWorkflowExecution returns the value of WorkflowExecution if it is set or its
 // zero value if it is unset.
-func (v *RemoveSignalMutableStateRequest) GetWorkflowExecution() (o *shared.WorkflowExecution) {
+func (v *RecordDecisionTaskStartedRequest) GetWorkflowExecution() (o *shared.WorkflowExecution) {
 	if v != nil && v.WorkflowExecution != nil {
 		return v.WorkflowExecution
 	}
@@ -4511,13 +4415,43 @@ func (v *RemoveSignalMutableStateRequest) GetWorkflowExecution() (o *shared.Work
 }
 
 // IsSetWorkflowExecution returns true if WorkflowExecution is not nil.
-func (v *RemoveSignalMutableStateRequest) IsSetWorkflowExecution() bool {
+func (v *RecordDecisionTaskStartedRequest) IsSetWorkflowExecution() bool {
 	return v != nil && v.WorkflowExecution != nil
 }
 
+// GetScheduleId returns the value of ScheduleId if it is set or its
+// zero value if it is unset.
+func (v *RecordDecisionTaskStartedRequest) GetScheduleId() (o int64) {
+	if v != nil && v.ScheduleId != nil {
+		return *v.ScheduleId
+	}
+
+	return
+}
+
+// IsSetScheduleId returns true if ScheduleId is not nil.
+func (v *RecordDecisionTaskStartedRequest) IsSetScheduleId() bool {
+	return v != nil && v.ScheduleId != nil
+}
+
+// GetTaskId returns the value of TaskId if it is set or its
+// zero value if it is unset.
+func (v *RecordDecisionTaskStartedRequest) GetTaskId() (o int64) {
+	if v != nil && v.TaskId != nil {
+		return *v.TaskId
+	}
+
+	return
+}
+
+// IsSetTaskId returns true if TaskId is not nil.
+func (v *RecordDecisionTaskStartedRequest) IsSetTaskId() bool {
+	return v != nil && v.TaskId != nil
+}
+
 // GetRequestId returns the value of RequestId if it is set or its
 // zero value if it is unset.
-func (v *RemoveSignalMutableStateRequest) GetRequestId() (o string) {
+func (v *RecordDecisionTaskStartedRequest) GetRequestId() (o string) {
 	if v != nil && v.RequestId != nil {
 		return *v.RequestId
 	}
@@ -4526,27 +4460,40 @@ func (v *RemoveSignalMutableStateRequest) GetRequestId() (o string) {
 }
 
 // IsSetRequestId returns true if RequestId is not nil.
-func (v *RemoveSignalMutableStateRequest) IsSetRequestId() bool {
+func (v *RecordDecisionTaskStartedRequest) IsSetRequestId() bool {
 	return v != nil && v.RequestId != nil
 }
 
-type ReplicateEventsRequest struct {
-	SourceCluster           *string                            `json:"sourceCluster,omitempty"`
-	DomainUUID              *string                            `json:"domainUUID,omitempty"`
-	WorkflowExecution       *shared.WorkflowExecution          `json:"workflowExecution,omitempty"`
-	FirstEventId            *int64                             `json:"firstEventId,omitempty"`
-	NextEventId             *int64                             `json:"nextEventId,omitempty"`
-	Version                 *int64                             `json:"version,omitempty"`
-	ReplicationInfo         map[string]*shared.ReplicationInfo `json:"replicationInfo,omitempty"`
-	History                 *shared.History                    `json:"history,omitempty"`
-	NewRunHistory           *shared.History                    `json:"newRunHistory,omitempty"`
-	ForceBufferEvents       *bool                              `json:"forceBufferEvents,omitempty"`
-	EventStoreVersion       *int32                             `json:"eventStoreVersion,omitempty"`
-	NewRunEventStoreVersion *int32                             `json:"newRunEventStoreVersion,omitempty"`
-	ResetWorkflow           *bool                              `json:"resetWorkflow,omitempty"`
+// GetPollRequest returns the value of PollRequest if it is set or its
+// zero value if it is unset.
+func (v *RecordDecisionTaskStartedRequest) GetPollRequest() (o *shared.PollForDecisionTaskRequest) {
+	if v != nil && v.PollRequest != nil {
+		return v.PollRequest
+	}
+
+	return
 }
 
-// ToWire translates a ReplicateEventsRequest struct into a Thrift-level intermediate
+// IsSetPollRequest returns true if PollRequest is not nil.
+func (v *RecordDecisionTaskStartedRequest) IsSetPollRequest() bool {
+	return v != nil && v.PollRequest != nil
+}
+
+type RecordDecisionTaskStartedResponse struct {
+	WorkflowType              *shared.WorkflowType          `json:"workflowType,omitempty"`
+	PreviousStartedEventId    *int64                        `json:"previousStartedEventId,omitempty"`
+	ScheduledEventId          *int64                        `json:"scheduledEventId,omitempty"`
+	StartedEventId            *int64                        `json:"startedEventId,omitempty"`
+	NextEventId               *int64                        `json:"nextEventId,omitempty"`
+	Attempt                   *int64                        `json:"attempt,omitempty"`
+	StickyExecutionEnabled    *bool                         `json:"stickyExecutionEnabled,omitempty"`
+	DecisionInfo              *shared.TransientDecisionInfo `json:"decisionInfo,omitempty"`
+	WorkflowExecutionTaskList *shared.TaskList              `json:"WorkflowExecutionTaskList,omitempty"`
+	EventStoreVersion         *int32                        `json:"eventStoreVersion,omitempty"`
+	BranchToken               []byte                        `json:"branchToken,omitempty"`
+}
+
+// ToWire translates a RecordDecisionTaskStartedResponse struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -4561,40 +4508,40 @@ type ReplicateEventsRequest struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *ReplicateEventsRequest) ToWire() (wire.Value, error) {
+func (v *RecordDecisionTaskStartedResponse) ToWire() (wire.Value, error) {
 	var (
-		fields [13]wire.Field
+		fields [11]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
 	)
 
-	if v.SourceCluster != nil {
-		w, err = wire.NewValueString(*(v.SourceCluster)), error(nil)
+	if v.WorkflowType != nil {
+		w, err = v.WorkflowType.ToWire()
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.DomainUUID != nil {
-		w, err = wire.NewValueString(*(v.DomainUUID)), error(nil)
+	if v.PreviousStartedEventId != nil {
+		w, err = wire.NewValueI64(*(v.PreviousStartedEventId)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
-	if v.WorkflowExecution != nil {
-		w, err = v.WorkflowExecution.ToWire()
+	if v.ScheduledEventId != nil {
+		w, err = wire.NewValueI64(*(v.ScheduledEventId)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 30, Value: w}
 		i++
 	}
-	if v.FirstEventId != nil {
-		w, err = wire.NewValueI64(*(v.FirstEventId)), error(nil)
+	if v.StartedEventId != nil {
+		w, err = wire.NewValueI64(*(v.StartedEventId)), error(nil)
 		if err != nil {
 			return w, err
 		}
@@ -4609,85 +4556,69 @@ func (v *ReplicateEventsRequest) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 50, Value: w}
 		i++
 	}
-	if v.Version != nil {
-		w, err = wire.NewValueI64(*(v.Version)), error(nil)
+	if v.Attempt != nil {
+		w, err = wire.NewValueI64(*(v.Attempt)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 60, Value: w}
 		i++
 	}
-	if v.ReplicationInfo != nil {
-		w, err = wire.NewValueMap(_Map_String_ReplicationInfo_MapItemList(v.ReplicationInfo)), error(nil)
+	if v.StickyExecutionEnabled != nil {
+		w, err = wire.NewValueBool(*(v.StickyExecutionEnabled)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 70, Value: w}
 		i++
 	}
-	if v.History != nil {
-		w, err = v.History.ToWire()
+	if v.DecisionInfo != nil {
+		w, err = v.DecisionInfo.ToWire()
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 80, Value: w}
 		i++
 	}
-	if v.NewRunHistory != nil {
-		w, err = v.NewRunHistory.ToWire()
+	if v.WorkflowExecutionTaskList != nil {
+		w, err = v.WorkflowExecutionTaskList.ToWire()
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 90, Value: w}
 		i++
 	}
-	if v.ForceBufferEvents != nil {
-		w, err = wire.NewValueBool(*(v.ForceBufferEvents)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 100, Value: w}
-		i++
-	}
 	if v.EventStoreVersion != nil {
 		w, err = wire.NewValueI32(*(v.EventStoreVersion)), error(nil)
 		if err != nil {
 			return w, err
 		}
-		fields[i] = wire.Field{ID: 110, Value: w}
-		i++
-	}
-	if v.NewRunEventStoreVersion != nil {
-		w, err = wire.NewValueI32(*(v.NewRunEventStoreVersion)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 120, Value: w}
+		fields[i] = wire.Field{ID: 100, Value: w}
 		i++
 	}
-	if v.ResetWorkflow != nil {
-		w, err = wire.NewValueBool(*(v.ResetWorkflow)), error(nil)
+	if v.BranchToken != nil {
+		w, err = wire.NewValueBinary(v.BranchToken), error(nil)
 		if err != nil {
 			return w, err
 		}
-		fields[i] = wire.Field{ID: 130, Value: w}
+		fields[i] = wire.Field{ID: 110, Value: w}
 		i++
 	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _History_Read(w wire.Value) (*shared.History, error) {
-	var v shared.History
+func _TransientDecisionInfo_Read(w wire.Value) (*shared.TransientDecisionInfo, error) {
+	var v shared.TransientDecisionInfo
 	err := v.FromWire(w)
 	return &v, err
 }
 
-// FromWire deserializes a ReplicateEventsRequest struct from its Thrift-level
+// FromWire deserializes a RecordDecisionTaskStartedResponse struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a ReplicateEventsRequest struct
+// An error is returned if we were unable to build a RecordDecisionTaskStartedResponse struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -4695,49 +4626,49 @@ func _History_Read(w wire.Value) (*shared.History, error) {
 //     return nil, err
 //   }
 //
-//   var v ReplicateEventsRequest
+//   var v RecordDecisionTaskStartedResponse
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *ReplicateEventsRequest) FromWire(w wire.Value) error {
+func (v *RecordDecisionTaskStartedResponse) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
 		case 10:
-			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.SourceCluster = &x
+			if field.Value.Type() == wire.TStruct {
+				v.WorkflowType, err = _WorkflowType_Read(field.Value)
 				if err != nil {
 					return err
 				}
 
 			}
 		case 20:
-			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.DomainUUID = &x
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.PreviousStartedEventId = &x
 				if err != nil {
 					return err
 				}
 
 			}
 		case 30:
-			if field.Value.Type() == wire.TStruct {
-				v.WorkflowExecution, err = _WorkflowExecution_Read(field.Value)
-				if err != nil {
-					return err
-				}
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.ScheduledEventId = &x
+				if err != nil {
+					return err
+				}
 
 			}
 		case 40:
 			if field.Value.Type() == wire.TI64 {
 				var x int64
 				x, err = field.Value.GetI64(), error(nil)
-				v.FirstEventId = &x
+				v.StartedEventId = &x
 				if err != nil {
 					return err
 				}
@@ -4757,15 +4688,17 @@ func (v *ReplicateEventsRequest) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TI64 {
 				var x int64
 				x, err = field.Value.GetI64(), error(nil)
-				v.Version = &x
+				v.Attempt = &x
 				if err != nil {
 					return err
 				}
 
 			}
 		case 70:
-			if field.Value.Type() == wire.TMap {
-				v.ReplicationInfo, err = _Map_String_ReplicationInfo_Read(field.Value.GetMap())
+			if field.Value.Type() == wire.TBool {
+				var x bool
+				x, err = field.Value.GetBool(), error(nil)
+				v.StickyExecutionEnabled = &x
 				if err != nil {
 					return err
 				}
@@ -4773,7 +4706,7 @@ func (v *ReplicateEventsRequest) FromWire(w wire.Value) error {
 			}
 		case 80:
 			if field.Value.Type() == wire.TStruct {
-				v.History, err = _History_Read(field.Value)
+				v.DecisionInfo, err = _TransientDecisionInfo_Read(field.Value)
 				if err != nil {
 					return err
 				}
@@ -4781,23 +4714,13 @@ func (v *ReplicateEventsRequest) FromWire(w wire.Value) error {
 			}
 		case 90:
 			if field.Value.Type() == wire.TStruct {
-				v.NewRunHistory, err = _History_Read(field.Value)
+				v.WorkflowExecutionTaskList, err = _TaskList_Read(field.Value)
 				if err != nil {
 					return err
 				}
 
 			}
 		case 100:
-			if field.Value.Type() == wire.TBool {
-				var x bool
-				x, err = field.Value.GetBool(), error(nil)
-				v.ForceBufferEvents = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		case 110:
 			if field.Value.Type() == wire.TI32 {
 				var x int32
 				x, err = field.Value.GetI32(), error(nil)
@@ -4807,21 +4730,9 @@ func (v *ReplicateEventsRequest) FromWire(w wire.Value) error {
 				}
 
 			}
-		case 120:
-			if field.Value.Type() == wire.TI32 {
-				var x int32
-				x, err = field.Value.GetI32(), error(nil)
-				v.NewRunEventStoreVersion = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		case 130:
-			if field.Value.Type() == wire.TBool {
-				var x bool
-				x, err = field.Value.GetBool(), error(nil)
-				v.ResetWorkflow = &x
+		case 110:
+			if field.Value.Type() == wire.TBinary {
+				v.BranchToken, err = field.Value.GetBinary(), error(nil)
 				if err != nil {
 					return err
 				}
@@ -4833,118 +4744,104 @@ func (v *ReplicateEventsRequest) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a ReplicateEventsRequest
+// String returns a readable string representation of a RecordDecisionTaskStartedResponse
 // struct.
-func (v *ReplicateEventsRequest) String() string {
+func (v *RecordDecisionTaskStartedResponse) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [13]string
+	var fields [11]string
 	i := 0
-	if v.SourceCluster != nil {
-		fields[i] = fmt.Sprintf("SourceCluster: %v", *(v.SourceCluster))
+	if v.WorkflowType != nil {
+		fields[i] = fmt.Sprintf("WorkflowType: %v", v.WorkflowType)
 		i++
 	}
-	if v.DomainUUID != nil {
-		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
+	if v.PreviousStartedEventId != nil {
+		fields[i] = fmt.Sprintf("PreviousStartedEventId: %v", *(v.PreviousStartedEventId))
 		i++
 	}
-	if v.WorkflowExecution != nil {
-		fields[i] = fmt.Sprintf("WorkflowExecution: %v", v.WorkflowExecution)
+	if v.ScheduledEventId != nil {
+		fields[i] = fmt.Sprintf("ScheduledEventId: %v", *(v.ScheduledEventId))
 		i++
 	}
-	if v.FirstEventId != nil {
-		fields[i] = fmt.Sprintf("FirstEventId: %v", *(v.FirstEventId))
+	if v.StartedEventId != nil {
+		fields[i] = fmt.Sprintf("StartedEventId: %v", *(v.StartedEventId))
 		i++
 	}
 	if v.NextEventId != nil {
 		fields[i] = fmt.Sprintf("NextEventId: %v", *(v.NextEventId))
 		i++
 	}
-	if v.Version != nil {
-		fields[i] = fmt.Sprintf("Version: %v", *(v.Version))
-		i++
-	}
-	if v.ReplicationInfo != nil {
-		fields[i] = fmt.Sprintf("ReplicationInfo: %v", v.ReplicationInfo)
+	if v.Attempt != nil {
+		fields[i] = fmt.Sprintf("Attempt: %v", *(v.Attempt))
 		i++
 	}
-	if v.History != nil {
-		fields[i] = fmt.Sprintf("History: %v", v.History)
+	if v.StickyExecutionEnabled != nil {
+		fields[i] = fmt.Sprintf("StickyExecutionEnabled: %v", *(v.StickyExecutionEnabled))
 		i++
 	}
-	if v.NewRunHistory != nil {
-		fields[i] = fmt.Sprintf("NewRunHistory: %v", v.NewRunHistory)
+	if v.DecisionInfo != nil {
+		fields[i] = fmt.Sprintf("DecisionInfo: %v", v.DecisionInfo)
 		i++
 	}
-	if v.ForceBufferEvents != nil {
-		fields[i] = fmt.Sprintf("ForceBufferEvents: %v", *(v.ForceBufferEvents))
+	if v.WorkflowExecutionTaskList != nil {
+		fields[i] = fmt.Sprintf("WorkflowExecutionTaskList: %v", v.WorkflowExecutionTaskList)
 		i++
 	}
 	if v.EventStoreVersion != nil {
 		fields[i] = fmt.Sprintf("EventStoreVersion: %v", *(v.EventStoreVersion))
 		i++
 	}
-	if v.NewRunEventStoreVersion != nil {
-		fields[i] = fmt.Sprintf("NewRunEventStoreVersion: %v", *(v.NewRunEventStoreVersion))
-		i++
-	}
-	if v.ResetWorkflow != nil {
-		fields[i] = fmt.Sprintf("ResetWorkflow: %v", *(v.ResetWorkflow))
+	if v.BranchToken != nil {
+		fields[i] = fmt.Sprintf("BranchToken: %v", v.BranchToken)
 		i++
 	}
 
-	return fmt.Sprintf("ReplicateEventsRequest{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("RecordDecisionTaskStartedResponse{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this ReplicateEventsRequest match the
-// provided ReplicateEventsRequest.
+// Equals returns true if all the fields of this RecordDecisionTaskStartedResponse match the
+// provided RecordDecisionTaskStartedResponse.
 //
 // This function performs a deep comparison.
-func (v *ReplicateEventsRequest) Equals(rhs *ReplicateEventsRequest) bool {
+func (v *RecordDecisionTaskStartedResponse) Equals(rhs *RecordDecisionTaskStartedResponse) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
 		return false
 	}
-	if !_String_EqualsPtr(v.SourceCluster, rhs.SourceCluster) {
+	if !((v.WorkflowType == nil && rhs.WorkflowType == nil) || (v.WorkflowType != nil && rhs.WorkflowType != nil && v.WorkflowType.Equals(rhs.WorkflowType))) {
 		return false
 	}
-	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
+	if !_I64_EqualsPtr(v.PreviousStartedEventId, rhs.PreviousStartedEventId) {
 		return false
 	}
-	if !((v.WorkflowExecution == nil && rhs.WorkflowExecution == nil) || (v.WorkflowExecution != nil && rhs.WorkflowExecution != nil && v.WorkflowExecution.Equals(rhs.WorkflowExecution))) {
+	if !_I64_EqualsPtr(v.ScheduledEventId, rhs.ScheduledEventId) {
 		return false
 	}
-	if !_I64_EqualsPtr(v.FirstEventId, rhs.FirstEventId) {
+	if !_I64_EqualsPtr(v.StartedEventId, rhs.StartedEventId) {
 		return false
 	}
 	if !_I64_EqualsPtr(v.NextEventId, rhs.NextEventId) {
 		return false
 	}
-	if !_I64_EqualsPtr(v.Version, rhs.Version) {
-		return false
-	}
-	if !((v.ReplicationInfo == nil && rhs.ReplicationInfo == nil) || (v.ReplicationInfo != nil && rhs.ReplicationInfo != nil && _Map_String_ReplicationInfo_Equals(v.ReplicationInfo, rhs.ReplicationInfo))) {
+	if !_I64_EqualsPtr(v.Attempt, rhs.Attempt) {
 		return false
 	}
-	if !((v.History == nil && rhs.History == nil) || (v.History != nil && rhs.History != nil && v.History.Equals(rhs.History))) {
+	if !_Bool_EqualsPtr(v.StickyExecutionEnabled, rhs.StickyExecutionEnabled) {
 		return false
 	}
-	if !((v.NewRunHistory == nil && rhs.NewRunHistory == nil) || (v.NewRunHistory != nil && rhs.NewRunHistory != nil && v.NewRunHistory.Equals(rhs.NewRunHistory))) {
+	if !((v.DecisionInfo == nil && rhs.DecisionInfo == nil) || (v.DecisionInfo != nil && rhs.DecisionInfo != nil && v.DecisionInfo.Equals(rhs.DecisionInfo))) {
 		return false
 	}
-	if !_Bool_EqualsPtr(v.ForceBufferEvents, rhs.ForceBufferEvents) {
+	if !((v.WorkflowExecutionTaskList == nil && rhs.WorkflowExecutionTaskList == nil) || (v.WorkflowExecutionTaskList != nil && rhs.WorkflowExecutionTaskList != nil && v.WorkflowExecutionTaskList.Equals(rhs.WorkflowExecutionTaskList))) {
 		return false
 	}
 	if !_I32_EqualsPtr(v.EventStoreVersion, rhs.EventStoreVersion) {
 		return false
 	}
-	if !_I32_EqualsPtr(v.NewRunEventStoreVersion, rhs.NewRunEventStoreVersion) {
-		return false
-	}
-	if !_Bool_EqualsPtr(v.ResetWorkflow, rhs.ResetWorkflow) {
+	if !((v.BranchToken == nil && rhs.BranchToken == nil) || (v.BranchToken != nil && rhs.BranchToken != nil && bytes.Equal(v.BranchToken, rhs.BranchToken))) {
 		return false
 	}
 
@@ -4952,116 +4849,110 @@ func (v *ReplicateEventsRequest) Equals(rhs *ReplicateEventsRequest) bool {
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of ReplicateEventsRequest.
-func (v *ReplicateEventsRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of RecordDecisionTaskStartedResponse.
+func (v *RecordDecisionTaskStartedResponse) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
-	if v.SourceCluster != nil {
-		enc.AddString("sourceCluster", *v.SourceCluster)
+	if v.WorkflowType != nil {
+		err = multierr.Append(err, enc.AddObject("workflowType", v.WorkflowType))
 	}
-	if v.DomainUUID != nil {
-		enc.AddString("domainUUID", *v.DomainUUID)
+	if v.PreviousStartedEventId != nil {
+		enc.AddInt64("previousStartedEventId", *v.PreviousStartedEventId)
 	}
-	if v.WorkflowExecution != nil {
-		err = multierr.Append(err, enc.AddObject("workflowExecution", v.WorkflowExecution))
+	if v.ScheduledEventId != nil {
+		enc.AddInt64("scheduledEventId", *v.ScheduledEventId)
 	}
-	if v.FirstEventId != nil {
-		enc.AddInt64("firstEventId", *v.FirstEventId)
+	if v.StartedEventId != nil {
+		enc.AddInt64("startedEventId", *v.StartedEventId)
 	}
 	if v.NextEventId != nil {
 		enc.AddInt64("nextEventId", *v.NextEventId)
 	}
-	if v.Version != nil {
-		enc.AddInt64("version", *v.Version)
-	}
-	if v.ReplicationInfo != nil {
-		err = multierr.Append(err, enc.AddObject("replicationInfo", (_Map_String_ReplicationInfo_Zapper)(v.ReplicationInfo)))
+	if v.Attempt != nil {
+		enc.AddInt64("attempt", *v.Attempt)
 	}
-	if v.History != nil {
-		err = multierr.Append(err, enc.AddObject("history", v.History))
+	if v.StickyExecutionEnabled != nil {
+		enc.AddBool("stickyExecutionEnabled", *v.StickyExecutionEnabled)
 	}
-	if v.NewRunHistory != nil {
-		err = multierr.Append(err, enc.AddObject("newRunHistory", v.NewRunHistory))
+	if v.DecisionInfo != nil {
+		err = multierr.Append(err, enc.AddObject("decisionInfo", v.DecisionInfo))
 	}
-	if v.ForceBufferEvents != nil {
-		enc.AddBool("forceBufferEvents", *v.ForceBufferEvents)
+	if v.WorkflowExecutionTaskList != nil {
+		err = multierr.Append(err, enc.AddObject("WorkflowExecutionTaskList", v.WorkflowExecutionTaskList))
 	}
 	if v.EventStoreVersion != nil {
 		enc.AddInt32("eventStoreVersion", *v.EventStoreVersion)
 	}
-	if v.NewRunEventStoreVersion != nil {
-		enc.AddInt32("newRunEventStoreVersion", *v.NewRunEventStoreVersion)
-	}
-	if v.ResetWorkflow != nil {
-		enc.AddBool("resetWorkflow", *v.ResetWorkflow)
+	if v.BranchToken != nil {
+		enc.AddString("branchToken", base64.StdEncoding.EncodeToString(v.BranchToken))
 	}
 	return err
 }
 
-// GetSourceCluster returns the value of SourceCluster if it is set or its
+// GetWorkflowType returns the value of WorkflowType if it is set or its
 // zero value if it is unset.
-func (v *ReplicateEventsRequest) GetSourceCluster() (o string) {
-	if v != nil && v.SourceCluster != nil {
-		return *v.SourceCluster
+func (v *RecordDecisionTaskStartedResponse) GetWorkflowType() (o *shared.WorkflowType) {
+	if v != nil && v.WorkflowType != nil {
+		return v.WorkflowType
 	}
 
 	return
 }
 
-// IsSetSourceCluster returns true if SourceCluster is not nil.
-func (v *ReplicateEventsRequest) IsSetSourceCluster() bool {
-	return v != nil && v.SourceCluster != nil
+// IsSetWorkflowType returns true if WorkflowType is not nil.
+func (v *RecordDecisionTaskStartedResponse) IsSetWorkflowType() bool {
+	return v != nil && v.WorkflowType != nil
 }
 
-// GetDomainUUID returns the value of DomainUUID if it is set or its
+// GetPreviousStartedEventId returns the value of PreviousStartedEventId if it is set or its
 // zero value if it is unset.
-func (v *ReplicateEventsRequest) GetDomainUUID() (o string) {
-	if v != nil && v.DomainUUID != nil {
-		return *v.DomainUUID
+func (v *RecordDecisionTaskStartedResponse) GetPreviousStartedEventId() (o int64) {
+	if v != nil && v.PreviousStartedEventId != nil {
+		return *v.PreviousStartedEventId
 	}
 
 	return
 }
 
-// IsSetDomainUUID returns true if DomainUUID is not nil.
-func (v *ReplicateEventsRequest) IsSetDomainUUID() bool {
-	return v != nil && v.DomainUUID != nil
+// IsSetPreviousStartedEventId returns true if PreviousStartedEventId is not nil.
+func (v *RecordDecisionTaskStartedResponse) IsSetPreviousStartedEventId() bool {
+	return v != nil && v.PreviousStartedEventId != nil
 }
 
-// GetWorkflowExecution returns the value of WorkflowExecution if it is set or its
+// GetScheduledEventId returns the value of ScheduledEventId if it is set or its
 // zero value if it is unset.
-func (v *ReplicateEventsRequest) GetWorkflowExecution() (o *shared.WorkflowExecution) {
-	if v != nil && v.WorkflowExecution != nil {
-		return v.WorkflowExecution
+func (v *RecordDecisionTaskStartedResponse) GetScheduledEventId() (o int64) {
+	if v != nil && v.ScheduledEventId != nil {
+		return *v.ScheduledEventId
 	}
 
 	return
 }
 
-// IsSetWorkflowExecution returns true if WorkflowExecution is not nil.
-func (v *ReplicateEventsRequest) IsSetWorkflowExecution() bool {
-	return v != nil && v.WorkflowExecution != nil
+// IsSetScheduledEventId returns true if ScheduledEventId is not nil.
+func (v *RecordDecisionTaskStartedResponse) IsSetScheduledEventId() bool {
+	return v != nil && v.ScheduledEventId != nil
 }
 
-// GetFirstEventId returns the value of FirstEventId if it is set or its
+// GetStartedEventId returns the value of StartedEventId if it is set or its
 // zero value if it is unset.
-func (v *ReplicateEventsRequest) GetFirstEventId() (o int64) {
-	if v != nil && v.FirstEventId != nil {
-		return *v.FirstEventId
+func (v *RecordDecisionTaskStartedResponse) GetStartedEventId() (o int64) {
+	if v != nil && v.StartedEventId != nil {
+		return *v.StartedEventId
 	}
 
 	return
 }
 
-// IsSetFirstEventId returns true if FirstEventId is not nil.
-func (v *ReplicateEventsRequest) IsSetFirstEventId() bool {
-	return v != nil && v.FirstEventId != nil
+// IsSetStartedEventId returns true if StartedEventId is not nil.
+func (v *RecordDecisionTaskStartedResponse) IsSetStartedEventId() bool {
+	return v != nil && v.StartedEventId != nil
 }
 
 // GetNextEventId returns the value of NextEventId if it is set or its
 // zero value if it is unset.
-func (v *ReplicateEventsRequest) GetNextEventId() (o int64) {
+func (v *RecordDecisionTaskStartedResponse) GetNextEventId() (o int64) {
 	if v != nil && v.NextEventId != nil {
 		return *v.NextEventId
 	}
@@ -5070,128 +4961,1371 @@ func (v *ReplicateEventsRequest) GetNextEventId() (o int64) {
 }
 
 // IsSetNextEventId returns true if NextEventId is not nil.
-func (v *ReplicateEventsRequest) IsSetNextEventId() bool {
+func (v *RecordDecisionTaskStartedResponse) IsSetNextEventId() bool {
 	return v != nil && v.NextEventId != nil
 }
 
-// GetVersion returns the value of Version if it is set or its
+// GetAttempt returns the value of Attempt if it is set or its
 // zero value if it is unset.
-func (v *ReplicateEventsRequest) GetVersion() (o int64) {
-	if v != nil && v.Version != nil {
-		return *v.Version
+func (v *RecordDecisionTaskStartedResponse) GetAttempt() (o int64) {
+	if v != nil && v.Attempt != nil {
+		return *v.Attempt
+	}
+
+	return
+}
+
+// IsSetAttempt returns true if Attempt is not nil.
+func (v *RecordDecisionTaskStartedResponse) IsSetAttempt() bool {
+	return v != nil && v.Attempt != nil
+}
+
+// GetStickyExecutionEnabled returns the value of StickyExecutionEnabled if it is set or its
+// zero value if it is unset.
+func (v *RecordDecisionTaskStartedResponse) GetStickyExecutionEnabled() (o bool) {
+	if v != nil && v.StickyExecutionEnabled != nil {
+		return *v.StickyExecutionEnabled
+	}
+
+	return
+}
+
+// IsSetStickyExecutionEnabled returns true if StickyExecutionEnabled is not nil.
+func (v *RecordDecisionTaskStartedResponse) IsSetStickyExecutionEnabled() bool {
+	return v != nil && v.StickyExecutionEnabled != nil
+}
+
+// GetDecisionInfo returns the value of DecisionInfo if it is set or its
+// zero value if it is unset.
+func (v *RecordDecisionTaskStartedResponse) GetDecisionInfo() (o *shared.TransientDecisionInfo) {
+	if v != nil && v.DecisionInfo != nil {
+		return v.DecisionInfo
+	}
+
+	return
+}
+
+// IsSetDecisionInfo returns true if DecisionInfo is not nil.
+func (v *RecordDecisionTaskStartedResponse) IsSetDecisionInfo() bool {
+	return v != nil && v.DecisionInfo != nil
+}
+
+// GetWorkflowExecutionTaskList returns the value of WorkflowExecutionTaskList if it is set or its
+// zero value if it is unset.
+func (v *RecordDecisionTaskStartedResponse) GetWorkflowExecutionTaskList() (o *shared.TaskList) {
+	if v != nil && v.WorkflowExecutionTaskList != nil {
+		return v.WorkflowExecutionTaskList
+	}
+
+	return
+}
+
+// IsSetWorkflowExecutionTaskList returns true if WorkflowExecutionTaskList is not nil.
+func (v *RecordDecisionTaskStartedResponse) IsSetWorkflowExecutionTaskList() bool {
+	return v != nil && v.WorkflowExecutionTaskList != nil
+}
+
+// GetEventStoreVersion returns the value of EventStoreVersion if it is set or its
+// zero value if it is unset.
+func (v *RecordDecisionTaskStartedResponse) GetEventStoreVersion() (o int32) {
+	if v != nil && v.EventStoreVersion != nil {
+		return *v.EventStoreVersion
+	}
+
+	return
+}
+
+// IsSetEventStoreVersion returns true if EventStoreVersion is not nil.
+func (v *RecordDecisionTaskStartedResponse) IsSetEventStoreVersion() bool {
+	return v != nil && v.EventStoreVersion != nil
+}
+
+// GetBranchToken returns the value of BranchToken if it is set or its
+// zero value if it is unset.
+func (v *RecordDecisionTaskStartedResponse) GetBranchToken() (o []byte) {
+	if v != nil && v.BranchToken != nil {
+		return v.BranchToken
+	}
+
+	return
+}
+
+// IsSetBranchToken returns true if BranchToken is not nil.
+func (v *RecordDecisionTaskStartedResponse) IsSetBranchToken() bool {
+	return v != nil && v.BranchToken != nil
+}
+
+type RemoveSignalMutableStateRequest struct {
+	DomainUUID        *string                   `json:"domainUUID,omitempty"`
+	WorkflowExecution *shared.WorkflowExecution `json:"workflowExecution,omitempty"`
+	RequestId         *string                   `json:"requestId,omitempty"`
+}
+
+// ToWire translates a RemoveSignalMutableStateRequest struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *RemoveSignalMutableStateRequest) ToWire() (wire.Value, error) {
+	var (
+		fields [3]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.DomainUUID != nil {
+		w, err = wire.NewValueString(*(v.DomainUUID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.WorkflowExecution != nil {
+		w, err = v.WorkflowExecution.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.RequestId != nil {
+		w, err = wire.NewValueString(*(v.RequestId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a RemoveSignalMutableStateRequest struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a RemoveSignalMutableStateRequest struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v RemoveSignalMutableStateRequest
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *RemoveSignalMutableStateRequest) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.DomainUUID = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TStruct {
+				v.WorkflowExecution, err = _WorkflowExecution_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.RequestId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a RemoveSignalMutableStateRequest
+// struct.
+func (v *RemoveSignalMutableStateRequest) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [3]string
+	i := 0
+	if v.DomainUUID != nil {
+		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
+		i++
+	}
+	if v.WorkflowExecution != nil {
+		fields[i] = fmt.Sprintf("WorkflowExecution: %v", v.WorkflowExecution)
+		i++
+	}
+	if v.RequestId != nil {
+		fields[i] = fmt.Sprintf("RequestId: %v", *(v.RequestId))
+		i++
+	}
+
+	return fmt.Sprintf("RemoveSignalMutableStateRequest{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this RemoveSignalMutableStateRequest match the
+// provided RemoveSignalMutableStateRequest.
+//
+// This function performs a deep comparison.
+func (v *RemoveSignalMutableStateRequest) Equals(rhs *RemoveSignalMutableStateRequest) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
+		return false
+	}
+	if !((v.WorkflowExecution == nil && rhs.WorkflowExecution == nil) || (v.WorkflowExecution != nil && rhs.WorkflowExecution != nil && v.WorkflowExecution.Equals(rhs.WorkflowExecution))) {
+		return false
+	}
+	if !_String_EqualsPtr(v.RequestId, rhs.RequestId) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of RemoveSignalMutableStateRequest.
+func (v *RemoveSignalMutableStateRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.DomainUUID != nil {
+		enc.AddString("domainUUID", *v.DomainUUID)
+	}
+	if v.WorkflowExecution != nil {
+		err = multierr.Append(err, enc.AddObject("workflowExecution", v.WorkflowExecution))
+	}
+	if v.RequestId != nil {
+		enc.AddString("requestId", *v.RequestId)
+	}
+	return err
+}
+
+// GetDomainUUID returns the value of DomainUUID if it is set or its
+// zero value if it is unset.
+func (v *RemoveSignalMutableStateRequest) GetDomainUUID() (o string) {
+	if v != nil && v.DomainUUID != nil {
+		return *v.DomainUUID
+	}
+
+	return
+}
+
+// IsSetDomainUUID returns true if DomainUUID is not nil.
+func (v *RemoveSignalMutableStateRequest) IsSetDomainUUID() bool {
+	return v != nil && v.DomainUUID != nil
+}
+
+// GetWorkflowExecution returns the value of WorkflowExecution if it is set or its
+// zero value if it is unset.
+func (v *RemoveSignalMutableStateRequest) GetWorkflowExecution() (o *shared.WorkflowExecution) {
+	if v != nil && v.WorkflowExecution != nil {
+		return v.WorkflowExecution
+	}
+
+	return
+}
+
+// IsSetWorkflowExecution returns true if WorkflowExecution is not nil.
+func (v *RemoveSignalMutableStateRequest) IsSetWorkflowExecution() bool {
+	return v != nil && v.WorkflowExecution != nil
+}
+
+// GetRequestId returns the value of RequestId if it is set or its
+// zero value if it is unset.
+func (v *RemoveSignalMutableStateRequest) GetRequestId() (o string) {
+	if v != nil && v.RequestId != nil {
+		return *v.RequestId
+	}
+
+	return
+}
+
+// IsSetRequestId returns true if RequestId is not nil.
+func (v *RemoveSignalMutableStateRequest) IsSetRequestId() bool {
+	return v != nil && v.RequestId != nil
+}
+
+type ReplicateEventsRequest struct {
+	SourceCluster           *string                            `json:"sourceCluster,omitempty"`
+	DomainUUID              *string                            `json:"domainUUID,omitempty"`
+	WorkflowExecution       *shared.WorkflowExecution          `json:"workflowExecution,omitempty"`
+	FirstEventId            *int64                             `json:"firstEventId,omitempty"`
+	NextEventId             *int64                             `json:"nextEventId,omitempty"`
+	Version                 *int64                             `json:"version,omitempty"`
+	ReplicationInfo         map[string]*shared.ReplicationInfo `json:"replicationInfo,omitempty"`
+	History                 *shared.History                    `json:"history,omitempty"`
+	NewRunHistory           *shared.History                    `json:"newRunHistory,omitempty"`
+	ForceBufferEvents       *bool                              `json:"forceBufferEvents,omitempty"`
+	EventStoreVersion       *int32                             `json:"eventStoreVersion,omitempty"`
+	NewRunEventStoreVersion *int32                             `json:"newRunEventStoreVersion,omitempty"`
+	ResetWorkflow           *bool                              `json:"resetWorkflow,omitempty"`
+}
+
+// ToWire translates a ReplicateEventsRequest struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *ReplicateEventsRequest) ToWire() (wire.Value, error) {
+	var (
+		fields [13]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.SourceCluster != nil {
+		w, err = wire.NewValueString(*(v.SourceCluster)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.DomainUUID != nil {
+		w, err = wire.NewValueString(*(v.DomainUUID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.WorkflowExecution != nil {
+		w, err = v.WorkflowExecution.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+	if v.FirstEventId != nil {
+		w, err = wire.NewValueI64(*(v.FirstEventId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+	if v.NextEventId != nil {
+		w, err = wire.NewValueI64(*(v.NextEventId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 50, Value: w}
+		i++
+	}
+	if v.Version != nil {
+		w, err = wire.NewValueI64(*(v.Version)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 60, Value: w}
+		i++
+	}
+	if v.ReplicationInfo != nil {
+		w, err = wire.NewValueMap(_Map_String_ReplicationInfo_MapItemList(v.ReplicationInfo)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 70, Value: w}
+		i++
+	}
+	if v.History != nil {
+		w, err = v.History.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 80, Value: w}
+		i++
+	}
+	if v.NewRunHistory != nil {
+		w, err = v.NewRunHistory.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 90, Value: w}
+		i++
+	}
+	if v.ForceBufferEvents != nil {
+		w, err = wire.NewValueBool(*(v.ForceBufferEvents)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 100, Value: w}
+		i++
+	}
+	if v.EventStoreVersion != nil {
+		w, err = wire.NewValueI32(*(v.EventStoreVersion)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 110, Value: w}
+		i++
+	}
+	if v.NewRunEventStoreVersion != nil {
+		w, err = wire.NewValueI32(*(v.NewRunEventStoreVersion)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 120, Value: w}
+		i++
+	}
+	if v.ResetWorkflow != nil {
+		w, err = wire.NewValueBool(*(v.ResetWorkflow)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 130, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _History_Read(w wire.Value) (*shared.History, error) {
+	var v shared.History
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a ReplicateEventsRequest struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a ReplicateEventsRequest struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v ReplicateEventsRequest
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *ReplicateEventsRequest) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.SourceCluster = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.DomainUUID = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TStruct {
+				v.WorkflowExecution, err = _WorkflowExecution_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.FirstEventId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 50:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.NextEventId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 60:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.Version = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 70:
+			if field.Value.Type() == wire.TMap {
+				v.ReplicationInfo, err = _Map_String_ReplicationInfo_Read(field.Value.GetMap())
+				if err != nil {
+					return err
+				}
+
+			}
+		case 80:
+			if field.Value.Type() == wire.TStruct {
+				v.History, err = _History_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 90:
+			if field.Value.Type() == wire.TStruct {
+				v.NewRunHistory, err = _History_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 100:
+			if field.Value.Type() == wire.TBool {
+				var x bool
+				x, err = field.Value.GetBool(), error(nil)
+				v.ForceBufferEvents = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 110:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.EventStoreVersion = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 120:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.NewRunEventStoreVersion = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 130:
+			if field.Value.Type() == wire.TBool {
+				var x bool
+				x, err = field.Value.GetBool(), error(nil)
+				v.ResetWorkflow = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a ReplicateEventsRequest
+// struct.
+func (v *ReplicateEventsRequest) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [13]string
+	i := 0
+	if v.SourceCluster != nil {
+		fields[i] = fmt.Sprintf("SourceCluster: %v", *(v.SourceCluster))
+		i++
+	}
+	if v.DomainUUID != nil {
+		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
+		i++
+	}
+	if v.WorkflowExecution != nil {
+		fields[i] = fmt.Sprintf("WorkflowExecution: %v", v.WorkflowExecution)
+		i++
+	}
+	if v.FirstEventId != nil {
+		fields[i] = fmt.Sprintf("FirstEventId: %v", *(v.FirstEventId))
+		i++
+	}
+	if v.NextEventId != nil {
+		fields[i] = fmt.Sprintf("NextEventId: %v", *(v.NextEventId))
+		i++
+	}
+	if v.Version != nil {
+		fields[i] = fmt.Sprintf("Version: %v", *(v.Version))
+		i++
+	}
+	if v.ReplicationInfo != nil {
+		fields[i] = fmt.Sprintf("ReplicationInfo: %v", v.ReplicationInfo)
+		i++
+	}
+	if v.History != nil {
+		fields[i] = fmt.Sprintf("History: %v", v.History)
+		i++
+	}
+	if v.NewRunHistory != nil {
+		fields[i] = fmt.Sprintf("NewRunHistory: %v", v.NewRunHistory)
+		i++
+	}
+	if v.ForceBufferEvents != nil {
+		fields[i] = fmt.Sprintf("ForceBufferEvents: %v", *(v.ForceBufferEvents))
+		i++
+	}
+	if v.EventStoreVersion != nil {
+		fields[i] = fmt.Sprintf("EventStoreVersion: %v", *(v.EventStoreVersion))
+		i++
+	}
+	if v.NewRunEventStoreVersion != nil {
+		fields[i] = fmt.Sprintf("NewRunEventStoreVersion: %v", *(v.NewRunEventStoreVersion))
+		i++
+	}
+	if v.ResetWorkflow != nil {
+		fields[i] = fmt.Sprintf("ResetWorkflow: %v", *(v.ResetWorkflow))
+		i++
+	}
+
+	return fmt.Sprintf("ReplicateEventsRequest{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this ReplicateEventsRequest match the
+// provided ReplicateEventsRequest.
+//
+// This function performs a deep comparison.
+func (v *ReplicateEventsRequest) Equals(rhs *ReplicateEventsRequest) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_String_EqualsPtr(v.SourceCluster, rhs.SourceCluster) {
+		return false
+	}
+	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
+		return false
+	}
+	if !((v.WorkflowExecution == nil && rhs.WorkflowExecution == nil) || (v.WorkflowExecution != nil && rhs.WorkflowExecution != nil && v.WorkflowExecution.Equals(rhs.WorkflowExecution))) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.FirstEventId, rhs.FirstEventId) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.NextEventId, rhs.NextEventId) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.Version, rhs.Version) {
+		return false
+	}
+	if !((v.ReplicationInfo == nil && rhs.ReplicationInfo == nil) || (v.ReplicationInfo != nil && rhs.ReplicationInfo != nil && _Map_String_ReplicationInfo_Equals(v.ReplicationInfo, rhs.ReplicationInfo))) {
+		return false
+	}
+	if !((v.History == nil && rhs.History == nil) || (v.History != nil && rhs.History != nil && v.History.Equals(rhs.History))) {
+		return false
+	}
+	if !((v.NewRunHistory == nil && rhs.NewRunHistory == nil) || (v.NewRunHistory != nil && rhs.NewRunHistory != nil && v.NewRunHistory.Equals(rhs.NewRunHistory))) {
+		return false
+	}
+	if !_Bool_EqualsPtr(v.ForceBufferEvents, rhs.ForceBufferEvents) {
+		return false
+	}
+	if !_I32_EqualsPtr(v.EventStoreVersion, rhs.EventStoreVersion) {
+		return false
+	}
+	if !_I32_EqualsPtr(v.NewRunEventStoreVersion, rhs.NewRunEventStoreVersion) {
+		return false
+	}
+	if !_Bool_EqualsPtr(v.ResetWorkflow, rhs.ResetWorkflow) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of ReplicateEventsRequest.
+func (v *ReplicateEventsRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.SourceCluster != nil {
+		enc.AddString("sourceCluster", *v.SourceCluster)
+	}
+	if v.DomainUUID != nil {
+		enc.AddString("domainUUID", *v.DomainUUID)
+	}
+	if v.WorkflowExecution != nil {
+		err = multierr.Append(err, enc.AddObject("workflowExecution", v.WorkflowExecution))
+	}
+	if v.FirstEventId != nil {
+		enc.AddInt64("firstEventId", *v.FirstEventId)
+	}
+	if v.NextEventId != nil {
+		enc.AddInt64("nextEventId", *v.NextEventId)
+	}
+	if v.Version != nil {
+		enc.AddInt64("version", *v.Version)
+	}
+	if v.ReplicationInfo != nil {
+		err = multierr.Append(err, enc.AddObject("replicationInfo", (_Map_String_ReplicationInfo_Zapper)(v.ReplicationInfo)))
+	}
+	if v.History != nil {
+		err = multierr.Append(err, enc.AddObject("history", v.History))
+	}
+	if v.NewRunHistory != nil {
+		err = multierr.Append(err, enc.AddObject("newRunHistory", v.NewRunHistory))
+	}
+	if v.ForceBufferEvents != nil {
+		enc.AddBool("forceBufferEvents", *v.ForceBufferEvents)
+	}
+	if v.EventStoreVersion != nil {
+		enc.AddInt32("eventStoreVersion", *v.EventStoreVersion)
+	}
+	if v.NewRunEventStoreVersion != nil {
+		enc.AddInt32("newRunEventStoreVersion", *v.NewRunEventStoreVersion)
+	}
+	if v.ResetWorkflow != nil {
+		enc.AddBool("resetWorkflow", *v.ResetWorkflow)
+	}
+	return err
+}
+
+// GetSourceCluster returns the value of SourceCluster if it is set or its
+// zero value if it is unset.
+func (v *ReplicateEventsRequest) GetSourceCluster() (o string) {
+	if v != nil && v.SourceCluster != nil {
+		return *v.SourceCluster
+	}
+
+	return
+}
+
+// IsSetSourceCluster returns true if SourceCluster is not nil.
+func (v *ReplicateEventsRequest) IsSetSourceCluster() bool {
+	return v != nil && v.SourceCluster != nil
+}
+
+// GetDomainUUID returns the value of DomainUUID if it is set or its
+// zero value if it is unset.
+func (v *ReplicateEventsRequest) GetDomainUUID() (o string) {
+	if v != nil && v.DomainUUID != nil {
+		return *v.DomainUUID
+	}
+
+	return
+}
+
+// IsSetDomainUUID returns true if DomainUUID is not nil.
+func (v *ReplicateEventsRequest) IsSetDomainUUID() bool {
+	return v != nil && v.DomainUUID != nil
+}
+
+// GetWorkflowExecution returns the value of WorkflowExecution if it is set or its
+// zero value if it is unset.
+func (v *ReplicateEventsRequest) GetWorkflowExecution() (o *shared.WorkflowExecution) {
+	if v != nil && v.WorkflowExecution != nil {
+		return v.WorkflowExecution
+	}
+
+	return
+}
+
+// IsSetWorkflowExecution returns true if WorkflowExecution is not nil.
+func (v *ReplicateEventsRequest) IsSetWorkflowExecution() bool {
+	return v != nil && v.WorkflowExecution != nil
+}
+
+// GetFirstEventId returns the value of FirstEventId if it is set or its
+// zero value if it is unset.
+func (v *ReplicateEventsRequest) GetFirstEventId() (o int64) {
+	if v != nil && v.FirstEventId != nil {
+		return *v.FirstEventId
+	}
+
+	return
+}
+
+// IsSetFirstEventId returns true if FirstEventId is not nil.
+func (v *ReplicateEventsRequest) IsSetFirstEventId() bool {
+	return v != nil && v.FirstEventId != nil
+}
+
+// GetNextEventId returns the value of NextEventId if it is set or its
+// zero value if it is unset.
+func (v *ReplicateEventsRequest) GetNextEventId() (o int64) {
+	if v != nil && v.NextEventId != nil {
+		return *v.NextEventId
+	}
+
+	return
+}
+
+// IsSetNextEventId returns true if NextEventId is not nil.
+func (v *ReplicateEventsRequest) IsSetNextEventId() bool {
+	return v != nil && v.NextEventId != nil
+}
+
+// GetVersion returns the value of Version if it is set or its
+// zero value if it is unset.
+func (v *ReplicateEventsRequest) GetVersion() (o int64) {
+	if v != nil && v.Version != nil {
+		return *v.Version
+	}
+
+	return
+}
+
+// IsSetVersion returns true if Version is not nil.
+func (v *ReplicateEventsRequest) IsSetVersion() bool {
+	return v != nil && v.Version != nil
+}
+
+// GetReplicationInfo returns the value of ReplicationInfo if it is set or its
+// zero value if it is unset.
+func (v *ReplicateEventsRequest) GetReplicationInfo() (o map[string]*shared.ReplicationInfo) {
+	if v != nil && v.ReplicationInfo != nil {
+		return v.ReplicationInfo
+	}
+
+	return
+}
+
+// IsSetReplicationInfo returns true if ReplicationInfo is not nil.
+func (v *ReplicateEventsRequest) IsSetReplicationInfo() bool {
+	return v != nil && v.ReplicationInfo != nil
+}
+
+// GetHistory returns the value of History if it is set or its
+// zero value if it is unset.
+func (v *ReplicateEventsRequest) GetHistory() (o *shared.History) {
+	if v != nil && v.History != nil {
+		return v.History
+	}
+
+	return
+}
+
+// IsSetHistory returns true if History is not nil.
+func (v *ReplicateEventsRequest) IsSetHistory() bool {
+	return v != nil && v.History != nil
+}
+
+// GetNewRunHistory returns the value of NewRunHistory if it is set or its
+// zero value if it is unset.
+func (v *ReplicateEventsRequest) GetNewRunHistory() (o *shared.History) {
+	if v != nil && v.NewRunHistory != nil {
+		return v.NewRunHistory
+	}
+
+	return
+}
+
+// IsSetNewRunHistory returns true if NewRunHistory is not nil.
+func (v *ReplicateEventsRequest) IsSetNewRunHistory() bool {
+	return v != nil && v.NewRunHistory != nil
+}
+
+// GetForceBufferEvents returns the value of ForceBufferEvents if it is set or its
+// zero value if it is unset.
+func (v *ReplicateEventsRequest) GetForceBufferEvents() (o bool) {
+	if v != nil && v.ForceBufferEvents != nil {
+		return *v.ForceBufferEvents
+	}
+
+	return
+}
+
+// IsSetForceBufferEvents returns true if ForceBufferEvents is not nil.
+func (v *ReplicateEventsRequest) IsSetForceBufferEvents() bool {
+	return v != nil && v.ForceBufferEvents != nil
+}
+
+// GetEventStoreVersion returns the value of EventStoreVersion if it is set or its
+// zero value if it is unset.
+func (v *ReplicateEventsRequest) GetEventStoreVersion() (o int32) {
+	if v != nil && v.EventStoreVersion != nil {
+		return *v.EventStoreVersion
+	}
+
+	return
+}
+
+// IsSetEventStoreVersion returns true if EventStoreVersion is not nil.
+func (v *ReplicateEventsRequest) IsSetEventStoreVersion() bool {
+	return v != nil && v.EventStoreVersion != nil
+}
+
+// GetNewRunEventStoreVersion returns the value of NewRunEventStoreVersion if it is set or its
+// zero value if it is unset.
+func (v *ReplicateEventsRequest) GetNewRunEventStoreVersion() (o int32) {
+	if v != nil && v.NewRunEventStoreVersion != nil {
+		return *v.NewRunEventStoreVersion
+	}
+
+	return
+}
+
+// IsSetNewRunEventStoreVersion returns true if NewRunEventStoreVersion is not nil.
+func (v *ReplicateEventsRequest) IsSetNewRunEventStoreVersion() bool {
+	return v != nil && v.NewRunEventStoreVersion != nil
+}
+
+// GetResetWorkflow returns the value of ResetWorkflow if it is set or its
+// zero value if it is unset.
+func (v *ReplicateEventsRequest) GetResetWorkflow() (o bool) {
+	if v != nil && v.ResetWorkflow != nil {
+		return *v.ResetWorkflow
+	}
+
+	return
+}
+
+// IsSetResetWorkflow returns true if ResetWorkflow is not nil.
+func (v *ReplicateEventsRequest) IsSetResetWorkflow() bool {
+	return v != nil && v.ResetWorkflow != nil
+}
+
+type ReplicateEventsV2Request struct {
+	DomainUUID          *string                      `json:"domainUUID,omitempty"`
+	WorkflowExecution   *shared.WorkflowExecution    `json:"workflowExecution,omitempty"`
+	VersionHistoryItems []*shared.VersionHistoryItem `json:"versionHistoryItems,omitempty"`
+	Events              *shared.DataBlob             `json:"events,omitempty"`
+	NewRunEvents        *shared.DataBlob             `json:"newRunEvents,omitempty"`
+}
+
+type _List_VersionHistoryItem_ValueList []*shared.VersionHistoryItem
+
+func (v _List_VersionHistoryItem_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_VersionHistoryItem_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_VersionHistoryItem_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_VersionHistoryItem_ValueList) Close() {}
+
+// ToWire translates a ReplicateEventsV2Request struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *ReplicateEventsV2Request) ToWire() (wire.Value, error) {
+	var (
+		fields [5]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.DomainUUID != nil {
+		w, err = wire.NewValueString(*(v.DomainUUID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.WorkflowExecution != nil {
+		w, err = v.WorkflowExecution.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.VersionHistoryItems != nil {
+		w, err = wire.NewValueList(_List_VersionHistoryItem_ValueList(v.VersionHistoryItems)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+	if v.Events != nil {
+		w, err = v.Events.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+	if v.NewRunEvents != nil {
+		w, err = v.NewRunEvents.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 50, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _VersionHistoryItem_Read(w wire.Value) (*shared.VersionHistoryItem, error) {
+	var v shared.VersionHistoryItem
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _List_VersionHistoryItem_Read(l wire.ValueList) ([]*shared.VersionHistoryItem, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make([]*shared.VersionHistoryItem, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _VersionHistoryItem_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+// FromWire deserializes a ReplicateEventsV2Request struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a ReplicateEventsV2Request struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v ReplicateEventsV2Request
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *ReplicateEventsV2Request) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.DomainUUID = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TStruct {
+				v.WorkflowExecution, err = _WorkflowExecution_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TList {
+				v.VersionHistoryItems, err = _List_VersionHistoryItem_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TStruct {
+				v.Events, err = _DataBlob_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 50:
+			if field.Value.Type() == wire.TStruct {
+				v.NewRunEvents, err = _DataBlob_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a ReplicateEventsV2Request
+// struct.
+func (v *ReplicateEventsV2Request) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [5]string
+	i := 0
+	if v.DomainUUID != nil {
+		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
+		i++
+	}
+	if v.WorkflowExecution != nil {
+		fields[i] = fmt.Sprintf("WorkflowExecution: %v", v.WorkflowExecution)
+		i++
+	}
+	if v.VersionHistoryItems != nil {
+		fields[i] = fmt.Sprintf("VersionHistoryItems: %v", v.VersionHistoryItems)
+		i++
+	}
+	if v.Events != nil {
+		fields[i] = fmt.Sprintf("Events: %v", v.Events)
+		i++
+	}
+	if v.NewRunEvents != nil {
+		fields[i] = fmt.Sprintf("NewRunEvents: %v", v.NewRunEvents)
+		i++
+	}
+
+	return fmt.Sprintf("ReplicateEventsV2Request{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _List_VersionHistoryItem_Equals(lhs, rhs []*shared.VersionHistoryItem) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
 	}
 
-	return
-}
-
-// IsSetVersion returns true if Version is not nil.
-func (v *ReplicateEventsRequest) IsSetVersion() bool {
-	return v != nil && v.Version != nil
+	return true
 }
 
-// GetReplicationInfo returns the value of ReplicationInfo if it is set or its
-// zero value if it is unset.
-func (v *ReplicateEventsRequest) GetReplicationInfo() (o map[string]*shared.ReplicationInfo) {
-	if v != nil && v.ReplicationInfo != nil {
-		return v.ReplicationInfo
+// Equals returns true if all the fields of this ReplicateEventsV2Request match the
+// provided ReplicateEventsV2Request.
+//
+// This function performs a deep comparison.
+func (v *ReplicateEventsV2Request) Equals(rhs *ReplicateEventsV2Request) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
+		return false
+	}
+	if !((v.WorkflowExecution == nil && rhs.WorkflowExecution == nil) || (v.WorkflowExecution != nil && rhs.WorkflowExecution != nil && v.WorkflowExecution.Equals(rhs.WorkflowExecution))) {
+		return false
+	}
+	if !((v.VersionHistoryItems == nil && rhs.VersionHistoryItems == nil) || (v.VersionHistoryItems != nil && rhs.VersionHistoryItems != nil && _List_VersionHistoryItem_Equals(v.VersionHistoryItems, rhs.VersionHistoryItems))) {
+		return false
+	}
+	if !((v.Events == nil && rhs.Events == nil) || (v.Events != nil && rhs.Events != nil && v.Events.Equals(rhs.Events))) {
+		return false
+	}
+	if !((v.NewRunEvents == nil && rhs.NewRunEvents == nil) || (v.NewRunEvents != nil && rhs.NewRunEvents != nil && v.NewRunEvents.Equals(rhs.NewRunEvents))) {
+		return false
 	}
 
-	return
+	return true
 }
 
-// IsSetReplicationInfo returns true if ReplicationInfo is not nil.
-func (v *ReplicateEventsRequest) IsSetReplicationInfo() bool {
-	return v != nil && v.ReplicationInfo != nil
-}
+type _List_VersionHistoryItem_Zapper []*shared.VersionHistoryItem
 
-// GetHistory returns the value of History if it is set or its
-// zero value if it is unset.
-func (v *ReplicateEventsRequest) GetHistory() (o *shared.History) {
-	if v != nil && v.History != nil {
-		return v.History
+// MarshalLogArray implements zapcore.ArrayMarshaler, enabling
+// fast logging of _List_VersionHistoryItem_Zapper.
+func (l _List_VersionHistoryItem_Zapper) MarshalLogArray(enc zapcore.ArrayEncoder) (err error) {
+	for _, v := range l {
+		err = multierr.Append(err, enc.AppendObject(v))
 	}
-
-	return
+	return err
 }
 
-// IsSetHistory returns true if History is not nil.
-func (v *ReplicateEventsRequest) IsSetHistory() bool {
-	return v != nil && v.History != nil
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of ReplicateEventsV2Request.
+func (v *ReplicateEventsV2Request) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.DomainUUID != nil {
+		enc.AddString("domainUUID", *v.DomainUUID)
+	}
+	if v.WorkflowExecution != nil {
+		err = multierr.Append(err, enc.AddObject("workflowExecution", v.WorkflowExecution))
+	}
+	if v.VersionHistoryItems != nil {
+		err = multierr.Append(err, enc.AddArray("versionHistoryItems", (_List_VersionHistoryItem_Zapper)(v.VersionHistoryItems)))
+	}
+	if v.Events != nil {
+		err = multierr.Append(err, enc.AddObject("events", v.Events))
+	}
+	if v.NewRunEvents != nil {
+		err = multierr.Append(err, enc.AddObject("newRunEvents", v.NewRunEvents))
+	}
+	return err
 }
 
-// GetNewRunHistory returns the value of NewRunHistory if it is set or its
+// GetDomainUUID returns the value of DomainUUID if it is set or its
 // zero value if it is unset.
-func (v *ReplicateEventsRequest) GetNewRunHistory() (o *shared.History) {
-	if v != nil && v.NewRunHistory != nil {
-		return v.NewRunHistory
+func (v *ReplicateEventsV2Request) GetDomainUUID() (o string) {
+	if v != nil && v.DomainUUID != nil {
+		return *v.DomainUUID
 	}
 
 	return
 }
 
-// IsSetNewRunHistory returns true if NewRunHistory is not nil.
-func (v *ReplicateEventsRequest) IsSetNewRunHistory() bool {
-	return v != nil && v.NewRunHistory != nil
+// IsSetDomainUUID returns true if DomainUUID is not nil.
+func (v *ReplicateEventsV2Request) IsSetDomainUUID() bool {
+	return v != nil && v.DomainUUID != nil
 }
 
-// GetForceBufferEvents returns the value of ForceBufferEvents if it is set or its
+// GetWorkflowExecution returns the value of WorkflowExecution if it is set or its
 // zero value if it is unset.
-func (v *ReplicateEventsRequest) GetForceBufferEvents() (o bool) {
-	if v != nil && v.ForceBufferEvents != nil {
-		return *v.ForceBufferEvents
+func (v *ReplicateEventsV2Request) GetWorkflowExecution() (o *shared.WorkflowExecution) {
+	if v != nil && v.WorkflowExecution != nil {
+		return v.WorkflowExecution
 	}
 
 	return
 }
 
-// IsSetForceBufferEvents returns true if ForceBufferEvents is not nil.
-func (v *ReplicateEventsRequest) IsSetForceBufferEvents() bool {
-	return v != nil && v.ForceBufferEvents != nil
+// IsSetWorkflowExecution returns true if WorkflowExecution is not nil.
+func (v *ReplicateEventsV2Request) IsSetWorkflowExecution() bool {
+	return v != nil && v.WorkflowExecution != nil
 }
 
-// GetEventStoreVersion returns the value of EventStoreVersion if it is set or its
+// GetVersionHistoryItems returns the value of VersionHistoryItems if it is set or its
 // zero value if it is unset.
-func (v *ReplicateEventsRequest) GetEventStoreVersion() (o int32) {
-	if v != nil && v.EventStoreVersion != nil {
-		return *v.EventStoreVersion
+func (v *ReplicateEventsV2Request) GetVersionHistoryItems() (o []*shared.VersionHistoryItem) {
+	if v != nil && v.VersionHistoryItems != nil {
+		return v.VersionHistoryItems
 	}
 
 	return
 }
 
-// IsSetEventStoreVersion returns true if EventStoreVersion is not nil.
-func (v *ReplicateEventsRequest) IsSetEventStoreVersion() bool {
-	return v != nil && v.EventStoreVersion != nil
+// IsSetVersionHistoryItems returns true if VersionHistoryItems is not nil.
+func (v *ReplicateEventsV2Request) IsSetVersionHistoryItems() bool {
+	return v != nil && v.VersionHistoryItems != nil
 }
 
-// GetNewRunEventStoreVersion returns the value of NewRunEventStoreVersion if it is set or its
+// GetEvents returns the value of Events if it is set or its
 // zero value if it is unset.
-func (v *ReplicateEventsRequest) GetNewRunEventStoreVersion() (o int32) {
-	if v != nil && v.NewRunEventStoreVersion != nil {
-		return *v.NewRunEventStoreVersion
+func (v *ReplicateEventsV2Request) GetEvents() (o *shared.DataBlob) {
+	if v != nil && v.Events != nil {
+		return v.Events
 	}
 
 	return
 }
 
-// IsSetNewRunEventStoreVersion returns true if NewRunEventStoreVersion is not nil.
-func (v *ReplicateEventsRequest) IsSetNewRunEventStoreVersion() bool {
-	return v != nil && v.NewRunEventStoreVersion != nil
+// IsSetEvents returns true if Events is not nil.
+func (v *ReplicateEventsV2Request) IsSetEvents() bool {
+	return v != nil && v.Events != nil
 }
 
-// GetResetWorkflow returns the value of ResetWorkflow if it is set or its
+// GetNewRunEvents returns the value of NewRunEvents if it is set or its
 // zero value if it is unset.
-func (v *ReplicateEventsRequest) GetResetWorkflow() (o bool) {
-	if v != nil && v.ResetWorkflow != nil {
-		return *v.ResetWorkflow
+func (v *ReplicateEventsV2Request) GetNewRunEvents() (o *shared.DataBlob) {
+	if v != nil && v.NewRunEvents != nil {
+		return v.NewRunEvents
 	}
 
 	return
 }
 
-// IsSetResetWorkflow returns true if ResetWorkflow is not nil.
-func (v *ReplicateEventsRequest) IsSetResetWorkflow() bool {
-	return v != nil && v.ResetWorkflow != nil
+// IsSetNewRunEvents returns true if NewRunEvents is not nil.
+func (v *ReplicateEventsV2Request) IsSetNewRunEvents() bool {
+	return v != nil && v.NewRunEvents != nil
 }
 
 type ReplicateRawEventsRequest struct {
@@ -9847,3 +10981,189 @@ func (v *TerminateWorkflowExecutionRequest) GetTerminateRequest() (o *shared.Ter
 func (v *TerminateWorkflowExecutionRequest) IsSetTerminateRequest() bool {
 	return v != nil && v.TerminateRequest != nil
 }
+
+type UpdateWorkflowExecutionLabelsRequest struct {
+	DomainUUID    *string                                      `json:"domainUUID,omitempty"`
+	UpdateRequest *shared.UpdateWorkflowExecutionLabelsRequest `json:"updateRequest,omitempty"`
+}
+
+// ToWire translates a UpdateWorkflowExecutionLabelsRequest struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *UpdateWorkflowExecutionLabelsRequest) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.DomainUUID != nil {
+		w, err = wire.NewValueString(*(v.DomainUUID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.UpdateRequest != nil {
+		w, err = v.UpdateRequest.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _UpdateWorkflowExecutionLabelsRequest_Read(w wire.Value) (*shared.UpdateWorkflowExecutionLabelsRequest, error) {
+	var v shared.UpdateWorkflowExecutionLabelsRequest
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a UpdateWorkflowExecutionLabelsRequest struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a UpdateWorkflowExecutionLabelsRequest struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v UpdateWorkflowExecutionLabelsRequest
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *UpdateWorkflowExecutionLabelsRequest) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.DomainUUID = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TStruct {
+				v.UpdateRequest, err = _UpdateWorkflowExecutionLabelsRequest_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a UpdateWorkflowExecutionLabelsRequest
+// struct.
+func (v *UpdateWorkflowExecutionLabelsRequest) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [2]string
+	i := 0
+	if v.DomainUUID != nil {
+		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
+		i++
+	}
+	if v.UpdateRequest != nil {
+		fields[i] = fmt.Sprintf("UpdateRequest: %v", v.UpdateRequest)
+		i++
+	}
+
+	return fmt.Sprintf("UpdateWorkflowExecutionLabelsRequest{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this UpdateWorkflowExecutionLabelsRequest match the
+// provided UpdateWorkflowExecutionLabelsRequest.
+//
+// This function performs a deep comparison.
+func (v *UpdateWorkflowExecutionLabelsRequest) Equals(rhs *UpdateWorkflowExecutionLabelsRequest) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
+		return false
+	}
+	if !((v.UpdateRequest == nil && rhs.UpdateRequest == nil) || (v.UpdateRequest != nil && rhs.UpdateRequest != nil && v.UpdateRequest.Equals(rhs.UpdateRequest))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of UpdateWorkflowExecutionLabelsRequest.
+func (v *UpdateWorkflowExecutionLabelsRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.DomainUUID != nil {
+		enc.AddString("domainUUID", *v.DomainUUID)
+	}
+	if v.UpdateRequest != nil {
+		err = multierr.Append(err, enc.AddObject("updateRequest", v.UpdateRequest))
+	}
+	return err
+}
+
+// GetDomainUUID returns the value of DomainUUID if it is set or its
+// zero value if it is unset.
+func (v *UpdateWorkflowExecutionLabelsRequest) GetDomainUUID() (o string) {
+	if v != nil && v.DomainUUID != nil {
+		return *v.DomainUUID
+	}
+
+	return
+}
+
+// IsSetDomainUUID returns true if DomainUUID is not nil.
+func (v *UpdateWorkflowExecutionLabelsRequest) IsSetDomainUUID() bool {
+	return v != nil && v.DomainUUID != nil
+}
+
+// GetUpdateRequest returns the value of UpdateRequest if it is set or its
+// zero value if it is unset.
+func (v *UpdateWorkflowExecutionLabelsRequest) GetUpdateRequest() (o *shared.UpdateWorkflowExecutionLabelsRequest) {
+	if v != nil && v.UpdateRequest != nil {
+		return v.UpdateRequest
+	}
+
+	return
+}
+
+// IsSetUpdateRequest returns true if UpdateRequest is not nil.
+func (v *UpdateWorkflowExecutionLabelsRequest) IsSetUpdateRequest() bool {
+	return v != nil && v.UpdateRequest != nil
+}
@@ -36,12 +36,39 @@ import (
 )
 
 type Field struct {
-	Type       *FieldType `json:"type,omitempty"`
-	StringData *string    `json:"stringData,omitempty"`
-	IntData    *int64     `json:"intData,omitempty"`
-	BoolData   *bool      `json:"boolData,omitempty"`
+	Type           *FieldType `json:"type,omitempty"`
+	StringData     *string    `json:"stringData,omitempty"`
+	IntData        *int64     `json:"intData,omitempty"`
+	BoolData       *bool      `json:"boolData,omitempty"`
+	StringListData []string   `json:"stringListData,omitempty"`
 }
 
+type _List_String_ValueList []string
+
+func (v _List_String_ValueList) ForEach(f func(wire.Value) error) error {
+	for _, x := range v {
+		w, err := wire.NewValueString(x), error(nil)
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_String_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_String_ValueList) ValueType() wire.Type {
+	return wire.TBinary
+}
+
+func (_List_String_ValueList) Close() {}
+
 // ToWire translates a Field struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
@@ -59,7 +86,7 @@ type Field struct {
 //   }
 func (v *Field) ToWire() (wire.Value, error) {
 	var (
-		fields [4]wire.Field
+		fields [5]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -97,6 +124,14 @@ func (v *Field) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 40, Value: w}
 		i++
 	}
+	if v.StringListData != nil {
+		w, err = wire.NewValueList(_List_String_ValueList(v.StringListData)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 50, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -107,6 +142,24 @@ func _FieldType_Read(w wire.Value) (FieldType, error) {
 	return v, err
 }
 
+func _List_String_Read(l wire.ValueList) ([]string, error) {
+	if l.ValueType() != wire.TBinary {
+		return nil, nil
+	}
+
+	o := make([]string, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := x.GetString(), error(nil)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
 // FromWire deserializes a Field struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
@@ -168,6 +221,14 @@ func (v *Field) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 50:
+			if field.Value.Type() == wire.TList {
+				v.StringListData, err = _List_String_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -182,7 +243,7 @@ func (v *Field) String() string {
 		return "<nil>"
 	}
 
-	var fields [4]string
+	var fields [5]string
 	i := 0
 	if v.Type != nil {
 		fields[i] = fmt.Sprintf("Type: %v", *(v.Type))
@@ -200,6 +261,10 @@ func (v *Field) String() string {
 		fields[i] = fmt.Sprintf("BoolData: %v", *(v.BoolData))
 		i++
 	}
+	if v.StringListData != nil {
+		fields[i] = fmt.Sprintf("StringListData: %v", v.StringListData)
+		i++
+	}
 
 	return fmt.Sprintf("Field{%v}", strings.Join(fields[:i], ", "))
 }
@@ -244,6 +309,21 @@ func _Bool_EqualsPtr(lhs, rhs *bool) bool {
 	return lhs == nil && rhs == nil
 }
 
+func _List_String_Equals(lhs, rhs []string) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !(lv == rv) {
+			return false
+		}
+	}
+
+	return true
+}
+
 // Equals returns true if all the fields of this Field match the
 // provided Field.
 //
@@ -266,10 +346,24 @@ func (v *Field) Equals(rhs *Field) bool {
 	if !_Bool_EqualsPtr(v.BoolData, rhs.BoolData) {
 		return false
 	}
+	if !((v.StringListData == nil && rhs.StringListData == nil) || (v.StringListData != nil && rhs.StringListData != nil && _List_String_Equals(v.StringListData, rhs.StringListData))) {
+		return false
+	}
 
 	return true
 }
 
+type _List_String_Zapper []string
+
+// MarshalLogArray implements zapcore.ArrayMarshaler, enabling
+// fast logging of _List_String_Zapper.
+func (l _List_String_Zapper) MarshalLogArray(enc zapcore.ArrayEncoder) (err error) {
+	for _, v := range l {
+		enc.AppendString(v)
+	}
+	return err
+}
+
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
 // fast logging of Field.
 func (v *Field) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
@@ -288,6 +382,9 @@ func (v *Field) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v.BoolData != nil {
 		enc.AddBool("boolData", *v.BoolData)
 	}
+	if v.StringListData != nil {
+		err = multierr.Append(err, enc.AddArray("stringListData", (_List_String_Zapper)(v.StringListData)))
+	}
 	return err
 }
 
@@ -351,12 +448,28 @@ func (v *Field) IsSetBoolData() bool {
 	return v != nil && v.BoolData != nil
 }
 
+// GetStringListData returns the value of StringListData if it is set or its
+// zero value if it is unset.
+func (v *Field) GetStringListData() (o []string) {
+	if v != nil && v.StringListData != nil {
+		return v.StringListData
+	}
+
+	return
+}
+
+// IsSetStringListData returns true if StringListData is not nil.
+func (v *Field) IsSetStringListData() bool {
+	return v != nil && v.StringListData != nil
+}
+
 type FieldType int32
 
 const (
-	FieldTypeString FieldType = 0
-	FieldTypeInt    FieldType = 1
-	FieldTypeBool   FieldType = 2
+	FieldTypeString     FieldType = 0
+	FieldTypeInt        FieldType = 1
+	FieldTypeBool       FieldType = 2
+	FieldTypeStringList FieldType = 3
 )
 
 // FieldType_Values returns all recognized values of FieldType.
@@ -365,6 +478,7 @@ func FieldType_Values() []FieldType {
 		FieldTypeString,
 		FieldTypeInt,
 		FieldTypeBool,
+		FieldTypeStringList,
 	}
 }
 
@@ -384,6 +498,9 @@ func (v *FieldType) UnmarshalText(value []byte) error {
 	case "Bool":
 		*v = FieldTypeBool
 		return nil
+	case "StringList":
+		*v = FieldTypeStringList
+		return nil
 	default:
 		val, err := strconv.ParseInt(s, 10, 32)
 		if err != nil {
@@ -408,6 +525,8 @@ func (v FieldType) MarshalText() ([]byte, error) {
 		return []byte("Int"), nil
 	case 2:
 		return []byte("Bool"), nil
+	case 3:
+		return []byte("StringList"), nil
 	}
 	return []byte(strconv.FormatInt(int64(v), 10)), nil
 }
@@ -425,6 +544,8 @@ func (v FieldType) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 		enc.AddString("name", "Int")
 	case 2:
 		enc.AddString("name", "Bool")
+	case 3:
+		enc.AddString("name", "StringList")
 	}
 	return nil
 }
@@ -471,6 +592,8 @@ func (v FieldType) String() string {
 		return "Int"
 	case 2:
 		return "Bool"
+	case 3:
+		return "StringList"
 	}
 	return fmt.Sprintf("FieldType(%d)", w)
 }
@@ -495,6 +618,8 @@ func (v FieldType) MarshalJSON() ([]byte, error) {
 		return ([]byte)("\"Int\""), nil
 	case 2:
 		return ([]byte)("\"Bool\""), nil
+	case 3:
+		return ([]byte)("\"StringList\""), nil
 	}
 	return ([]byte)(strconv.FormatInt(int64(v), 10)), nil
 }
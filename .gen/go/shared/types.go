@@ -2558,8 +2558,10 @@ func (v *ActivityType) IsSetName() bool {
 type ArchivalStatus int32
 
 const (
-	ArchivalStatusDisabled ArchivalStatus = 0
-	ArchivalStatusEnabled  ArchivalStatus = 1
+	ArchivalStatusDisabled  ArchivalStatus = 0
+	ArchivalStatusEnabled   ArchivalStatus = 1
+	ArchivalStatusPaused    ArchivalStatus = 2
+	ArchivalStatusMigrating ArchivalStatus = 3
 )
 
 // ArchivalStatus_Values returns all recognized values of ArchivalStatus.
@@ -2567,6 +2569,8 @@ func ArchivalStatus_Values() []ArchivalStatus {
 	return []ArchivalStatus{
 		ArchivalStatusDisabled,
 		ArchivalStatusEnabled,
+		ArchivalStatusPaused,
+		ArchivalStatusMigrating,
 	}
 }
 
@@ -2583,6 +2587,12 @@ func (v *ArchivalStatus) UnmarshalText(value []byte) error {
 	case "ENABLED":
 		*v = ArchivalStatusEnabled
 		return nil
+	case "PAUSED":
+		*v = ArchivalStatusPaused
+		return nil
+	case "MIGRATING":
+		*v = ArchivalStatusMigrating
+		return nil
 	default:
 		val, err := strconv.ParseInt(s, 10, 32)
 		if err != nil {
@@ -2605,6 +2615,10 @@ func (v ArchivalStatus) MarshalText() ([]byte, error) {
 		return []byte("DISABLED"), nil
 	case 1:
 		return []byte("ENABLED"), nil
+	case 2:
+		return []byte("PAUSED"), nil
+	case 3:
+		return []byte("MIGRATING"), nil
 	}
 	return []byte(strconv.FormatInt(int64(v), 10)), nil
 }
@@ -2620,6 +2634,10 @@ func (v ArchivalStatus) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 		enc.AddString("name", "DISABLED")
 	case 1:
 		enc.AddString("name", "ENABLED")
+	case 2:
+		enc.AddString("name", "PAUSED")
+	case 3:
+		enc.AddString("name", "MIGRATING")
 	}
 	return nil
 }
@@ -2664,6 +2682,10 @@ func (v ArchivalStatus) String() string {
 		return "DISABLED"
 	case 1:
 		return "ENABLED"
+	case 2:
+		return "PAUSED"
+	case 3:
+		return "MIGRATING"
 	}
 	return fmt.Sprintf("ArchivalStatus(%d)", w)
 }
@@ -2686,6 +2708,10 @@ func (v ArchivalStatus) MarshalJSON() ([]byte, error) {
 		return ([]byte)("\"DISABLED\""), nil
 	case 1:
 		return ([]byte)("\"ENABLED\""), nil
+	case 2:
+		return ([]byte)("\"PAUSED\""), nil
+	case 3:
+		return ([]byte)("\"MIGRATING\""), nil
 	}
 	return ([]byte)(strconv.FormatInt(int64(v), 10)), nil
 }
@@ -7164,6 +7190,282 @@ func (v *ContinueAsNewWorkflowExecutionDecisionAttributes) IsSetCronSchedule() b
 	return v != nil && v.CronSchedule != nil
 }
 
+type CountOpenWorkflowExecutionsRequest struct {
+	Domain *string `json:"domain,omitempty"`
+}
+
+// ToWire translates a CountOpenWorkflowExecutionsRequest struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *CountOpenWorkflowExecutionsRequest) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Domain != nil {
+		w, err = wire.NewValueString(*(v.Domain)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a CountOpenWorkflowExecutionsRequest struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a CountOpenWorkflowExecutionsRequest struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v CountOpenWorkflowExecutionsRequest
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *CountOpenWorkflowExecutionsRequest) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Domain = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a CountOpenWorkflowExecutionsRequest
+// struct.
+func (v *CountOpenWorkflowExecutionsRequest) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [1]string
+	i := 0
+	if v.Domain != nil {
+		fields[i] = fmt.Sprintf("Domain: %v", *(v.Domain))
+		i++
+	}
+
+	return fmt.Sprintf("CountOpenWorkflowExecutionsRequest{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this CountOpenWorkflowExecutionsRequest match the
+// provided CountOpenWorkflowExecutionsRequest.
+//
+// This function performs a deep comparison.
+func (v *CountOpenWorkflowExecutionsRequest) Equals(rhs *CountOpenWorkflowExecutionsRequest) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_String_EqualsPtr(v.Domain, rhs.Domain) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of CountOpenWorkflowExecutionsRequest.
+func (v *CountOpenWorkflowExecutionsRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Domain != nil {
+		enc.AddString("domain", *v.Domain)
+	}
+	return err
+}
+
+// GetDomain returns the value of Domain if it is set or its
+// zero value if it is unset.
+func (v *CountOpenWorkflowExecutionsRequest) GetDomain() (o string) {
+	if v != nil && v.Domain != nil {
+		return *v.Domain
+	}
+
+	return
+}
+
+// IsSetDomain returns true if Domain is not nil.
+func (v *CountOpenWorkflowExecutionsRequest) IsSetDomain() bool {
+	return v != nil && v.Domain != nil
+}
+
+type CountOpenWorkflowExecutionsResponse struct {
+	Count *int64 `json:"count,omitempty"`
+}
+
+// ToWire translates a CountOpenWorkflowExecutionsResponse struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *CountOpenWorkflowExecutionsResponse) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Count != nil {
+		w, err = wire.NewValueI64(*(v.Count)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a CountOpenWorkflowExecutionsResponse struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a CountOpenWorkflowExecutionsResponse struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v CountOpenWorkflowExecutionsResponse
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *CountOpenWorkflowExecutionsResponse) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.Count = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a CountOpenWorkflowExecutionsResponse
+// struct.
+func (v *CountOpenWorkflowExecutionsResponse) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [1]string
+	i := 0
+	if v.Count != nil {
+		fields[i] = fmt.Sprintf("Count: %v", *(v.Count))
+		i++
+	}
+
+	return fmt.Sprintf("CountOpenWorkflowExecutionsResponse{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this CountOpenWorkflowExecutionsResponse match the
+// provided CountOpenWorkflowExecutionsResponse.
+//
+// This function performs a deep comparison.
+func (v *CountOpenWorkflowExecutionsResponse) Equals(rhs *CountOpenWorkflowExecutionsResponse) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_I64_EqualsPtr(v.Count, rhs.Count) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of CountOpenWorkflowExecutionsResponse.
+func (v *CountOpenWorkflowExecutionsResponse) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Count != nil {
+		enc.AddInt64("count", *v.Count)
+	}
+	return err
+}
+
+// GetCount returns the value of Count if it is set or its
+// zero value if it is unset.
+func (v *CountOpenWorkflowExecutionsResponse) GetCount() (o int64) {
+	if v != nil && v.Count != nil {
+		return *v.Count
+	}
+
+	return
+}
+
+// IsSetCount returns true if Count is not nil.
+func (v *CountOpenWorkflowExecutionsResponse) IsSetCount() bool {
+	return v != nil && v.Count != nil
+}
+
 type DataBlob struct {
 	EncodingType *EncodingType `json:"EncodingType,omitempty"`
 	Data         []byte        `json:"Data,omitempty"`
@@ -8425,6 +8727,8 @@ const (
 	DecisionTaskFailedCauseFailoverCloseDecision                               DecisionTaskFailedCause = 17
 	DecisionTaskFailedCauseBadSignalInputSize                                  DecisionTaskFailedCause = 18
 	DecisionTaskFailedCauseResetWorkflow                                       DecisionTaskFailedCause = 19
+	DecisionTaskFailedCausePendingChildWorkflowsLimitExceeded                  DecisionTaskFailedCause = 20
+	DecisionTaskFailedCausePendingActivitiesLimitExceeded                      DecisionTaskFailedCause = 21
 )
 
 // DecisionTaskFailedCause_Values returns all recognized values of DecisionTaskFailedCause.
@@ -8450,6 +8754,8 @@ func DecisionTaskFailedCause_Values() []DecisionTaskFailedCause {
 		DecisionTaskFailedCauseFailoverCloseDecision,
 		DecisionTaskFailedCauseBadSignalInputSize,
 		DecisionTaskFailedCauseResetWorkflow,
+		DecisionTaskFailedCausePendingChildWorkflowsLimitExceeded,
+		DecisionTaskFailedCausePendingActivitiesLimitExceeded,
 	}
 }
 
@@ -8520,6 +8826,12 @@ func (v *DecisionTaskFailedCause) UnmarshalText(value []byte) error {
 	case "RESET_WORKFLOW":
 		*v = DecisionTaskFailedCauseResetWorkflow
 		return nil
+	case "PENDING_CHILD_WORKFLOWS_LIMIT_EXCEEDED":
+		*v = DecisionTaskFailedCausePendingChildWorkflowsLimitExceeded
+		return nil
+	case "PENDING_ACTIVITIES_LIMIT_EXCEEDED":
+		*v = DecisionTaskFailedCausePendingActivitiesLimitExceeded
+		return nil
 	default:
 		val, err := strconv.ParseInt(s, 10, 32)
 		if err != nil {
@@ -8578,6 +8890,10 @@ func (v DecisionTaskFailedCause) MarshalText() ([]byte, error) {
 		return []byte("BAD_SIGNAL_INPUT_SIZE"), nil
 	case 19:
 		return []byte("RESET_WORKFLOW"), nil
+	case 20:
+		return []byte("PENDING_CHILD_WORKFLOWS_LIMIT_EXCEEDED"), nil
+	case 21:
+		return []byte("PENDING_ACTIVITIES_LIMIT_EXCEEDED"), nil
 	}
 	return []byte(strconv.FormatInt(int64(v), 10)), nil
 }
@@ -8629,6 +8945,10 @@ func (v DecisionTaskFailedCause) MarshalLogObject(enc zapcore.ObjectEncoder) err
 		enc.AddString("name", "BAD_SIGNAL_INPUT_SIZE")
 	case 19:
 		enc.AddString("name", "RESET_WORKFLOW")
+	case 20:
+		enc.AddString("name", "PENDING_CHILD_WORKFLOWS_LIMIT_EXCEEDED")
+	case 21:
+		enc.AddString("name", "PENDING_ACTIVITIES_LIMIT_EXCEEDED")
 	}
 	return nil
 }
@@ -8709,6 +9029,10 @@ func (v DecisionTaskFailedCause) String() string {
 		return "BAD_SIGNAL_INPUT_SIZE"
 	case 19:
 		return "RESET_WORKFLOW"
+	case 20:
+		return "PENDING_CHILD_WORKFLOWS_LIMIT_EXCEEDED"
+	case 21:
+		return "PENDING_ACTIVITIES_LIMIT_EXCEEDED"
 	}
 	return fmt.Sprintf("DecisionTaskFailedCause(%d)", w)
 }
@@ -8767,6 +9091,10 @@ func (v DecisionTaskFailedCause) MarshalJSON() ([]byte, error) {
 		return ([]byte)("\"BAD_SIGNAL_INPUT_SIZE\""), nil
 	case 19:
 		return ([]byte)("\"RESET_WORKFLOW\""), nil
+	case 20:
+		return ([]byte)("\"PENDING_CHILD_WORKFLOWS_LIMIT_EXCEEDED\""), nil
+	case 21:
+		return ([]byte)("\"PENDING_ACTIVITIES_LIMIT_EXCEEDED\""), nil
 	}
 	return ([]byte)(strconv.FormatInt(int64(v), 10)), nil
 }
@@ -12329,6 +12657,8 @@ type DescribeWorkflowExecutionResponse struct {
 	ExecutionConfiguration *WorkflowExecutionConfiguration `json:"executionConfiguration,omitempty"`
 	WorkflowExecutionInfo  *WorkflowExecutionInfo          `json:"workflowExecutionInfo,omitempty"`
 	PendingActivities      []*PendingActivityInfo          `json:"pendingActivities,omitempty"`
+	PendingChildren        []*PendingChildExecutionInfo    `json:"pendingChildren,omitempty"`
+	PendingDecision        *PendingDecisionInfo            `json:"pendingDecision,omitempty"`
 }
 
 type _List_PendingActivityInfo_ValueList []*PendingActivityInfo
@@ -12360,6 +12690,35 @@ func (_List_PendingActivityInfo_ValueList) ValueType() wire.Type {
 
 func (_List_PendingActivityInfo_ValueList) Close() {}
 
+type _List_PendingChildExecutionInfo_ValueList []*PendingChildExecutionInfo
+
+func (v _List_PendingChildExecutionInfo_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_PendingChildExecutionInfo_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_PendingChildExecutionInfo_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_PendingChildExecutionInfo_ValueList) Close() {}
+
 // ToWire translates a DescribeWorkflowExecutionResponse struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
@@ -12377,7 +12736,7 @@ func (_List_PendingActivityInfo_ValueList) Close() {}
 //   }
 func (v *DescribeWorkflowExecutionResponse) ToWire() (wire.Value, error) {
 	var (
-		fields [3]wire.Field
+		fields [5]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -12407,6 +12766,22 @@ func (v *DescribeWorkflowExecutionResponse) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 30, Value: w}
 		i++
 	}
+	if v.PendingChildren != nil {
+		w, err = wire.NewValueList(_List_PendingChildExecutionInfo_ValueList(v.PendingChildren)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+	if v.PendingDecision != nil {
+		w, err = v.PendingDecision.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 50, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -12447,6 +12822,36 @@ func _List_PendingActivityInfo_Read(l wire.ValueList) ([]*PendingActivityInfo, e
 	return o, err
 }
 
+func _PendingChildExecutionInfo_Read(w wire.Value) (*PendingChildExecutionInfo, error) {
+	var v PendingChildExecutionInfo
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _List_PendingChildExecutionInfo_Read(l wire.ValueList) ([]*PendingChildExecutionInfo, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make([]*PendingChildExecutionInfo, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _PendingChildExecutionInfo_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+func _PendingDecisionInfo_Read(w wire.Value) (*PendingDecisionInfo, error) {
+	var v PendingDecisionInfo
+	err := v.FromWire(w)
+	return &v, err
+}
+
 // FromWire deserializes a DescribeWorkflowExecutionResponse struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
@@ -12492,6 +12897,22 @@ func (v *DescribeWorkflowExecutionResponse) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 40:
+			if field.Value.Type() == wire.TList {
+				v.PendingChildren, err = _List_PendingChildExecutionInfo_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
+			}
+		case 50:
+			if field.Value.Type() == wire.TStruct {
+				v.PendingDecision, err = _PendingDecisionInfo_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -12506,7 +12927,7 @@ func (v *DescribeWorkflowExecutionResponse) String() string {
 		return "<nil>"
 	}
 
-	var fields [3]string
+	var fields [5]string
 	i := 0
 	if v.ExecutionConfiguration != nil {
 		fields[i] = fmt.Sprintf("ExecutionConfiguration: %v", v.ExecutionConfiguration)
@@ -12520,6 +12941,14 @@ func (v *DescribeWorkflowExecutionResponse) String() string {
 		fields[i] = fmt.Sprintf("PendingActivities: %v", v.PendingActivities)
 		i++
 	}
+	if v.PendingChildren != nil {
+		fields[i] = fmt.Sprintf("PendingChildren: %v", v.PendingChildren)
+		i++
+	}
+	if v.PendingDecision != nil {
+		fields[i] = fmt.Sprintf("PendingDecision: %v", v.PendingDecision)
+		i++
+	}
 
 	return fmt.Sprintf("DescribeWorkflowExecutionResponse{%v}", strings.Join(fields[:i], ", "))
 }
@@ -12539,6 +12968,21 @@ func _List_PendingActivityInfo_Equals(lhs, rhs []*PendingActivityInfo) bool {
 	return true
 }
 
+func _List_PendingChildExecutionInfo_Equals(lhs, rhs []*PendingChildExecutionInfo) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+
+	return true
+}
+
 // Equals returns true if all the fields of this DescribeWorkflowExecutionResponse match the
 // provided DescribeWorkflowExecutionResponse.
 //
@@ -12558,6 +13002,12 @@ func (v *DescribeWorkflowExecutionResponse) Equals(rhs *DescribeWorkflowExecutio
 	if !((v.PendingActivities == nil && rhs.PendingActivities == nil) || (v.PendingActivities != nil && rhs.PendingActivities != nil && _List_PendingActivityInfo_Equals(v.PendingActivities, rhs.PendingActivities))) {
 		return false
 	}
+	if !((v.PendingChildren == nil && rhs.PendingChildren == nil) || (v.PendingChildren != nil && rhs.PendingChildren != nil && _List_PendingChildExecutionInfo_Equals(v.PendingChildren, rhs.PendingChildren))) {
+		return false
+	}
+	if !((v.PendingDecision == nil && rhs.PendingDecision == nil) || (v.PendingDecision != nil && rhs.PendingDecision != nil && v.PendingDecision.Equals(rhs.PendingDecision))) {
+		return false
+	}
 
 	return true
 }
@@ -12573,6 +13023,17 @@ func (l _List_PendingActivityInfo_Zapper) MarshalLogArray(enc zapcore.ArrayEncod
 	return err
 }
 
+type _List_PendingChildExecutionInfo_Zapper []*PendingChildExecutionInfo
+
+// MarshalLogArray implements zapcore.ArrayMarshaler, enabling
+// fast logging of _List_PendingChildExecutionInfo_Zapper.
+func (l _List_PendingChildExecutionInfo_Zapper) MarshalLogArray(enc zapcore.ArrayEncoder) (err error) {
+	for _, v := range l {
+		err = multierr.Append(err, enc.AppendObject(v))
+	}
+	return err
+}
+
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
 // fast logging of DescribeWorkflowExecutionResponse.
 func (v *DescribeWorkflowExecutionResponse) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
@@ -12588,6 +13049,12 @@ func (v *DescribeWorkflowExecutionResponse) MarshalLogObject(enc zapcore.ObjectE
 	if v.PendingActivities != nil {
 		err = multierr.Append(err, enc.AddArray("pendingActivities", (_List_PendingActivityInfo_Zapper)(v.PendingActivities)))
 	}
+	if v.PendingChildren != nil {
+		err = multierr.Append(err, enc.AddArray("pendingChildren", (_List_PendingChildExecutionInfo_Zapper)(v.PendingChildren)))
+	}
+	if v.PendingDecision != nil {
+		err = multierr.Append(err, enc.AddObject("pendingDecision", v.PendingDecision))
+	}
 	return err
 }
 
@@ -12636,6 +13103,504 @@ func (v *DescribeWorkflowExecutionResponse) IsSetPendingActivities() bool {
 	return v != nil && v.PendingActivities != nil
 }
 
+// GetPendingChildren returns the value of PendingChildren if it is set or its
+// zero value if it is unset.
+func (v *DescribeWorkflowExecutionResponse) GetPendingChildren() (o []*PendingChildExecutionInfo) {
+	if v != nil && v.PendingChildren != nil {
+		return v.PendingChildren
+	}
+
+	return
+}
+
+// IsSetPendingChildren returns true if PendingChildren is not nil.
+func (v *DescribeWorkflowExecutionResponse) IsSetPendingChildren() bool {
+	return v != nil && v.PendingChildren != nil
+}
+
+// GetPendingDecision returns the value of PendingDecision if it is set or its
+// zero value if it is unset.
+func (v *DescribeWorkflowExecutionResponse) GetPendingDecision() (o *PendingDecisionInfo) {
+	if v != nil && v.PendingDecision != nil {
+		return v.PendingDecision
+	}
+
+	return
+}
+
+// IsSetPendingDecision returns true if PendingDecision is not nil.
+func (v *DescribeWorkflowExecutionResponse) IsSetPendingDecision() bool {
+	return v != nil && v.PendingDecision != nil
+}
+
+type DescribeWorkflowExecutionsRequest struct {
+	Domain     *string              `json:"domain,omitempty"`
+	Executions []*WorkflowExecution `json:"executions,omitempty"`
+}
+
+type _List_WorkflowExecution_ValueList []*WorkflowExecution
+
+func (v _List_WorkflowExecution_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_WorkflowExecution_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_WorkflowExecution_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_WorkflowExecution_ValueList) Close() {}
+
+// ToWire translates a DescribeWorkflowExecutionsRequest struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *DescribeWorkflowExecutionsRequest) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Domain != nil {
+		w, err = wire.NewValueString(*(v.Domain)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.Executions != nil {
+		w, err = wire.NewValueList(_List_WorkflowExecution_ValueList(v.Executions)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _List_WorkflowExecution_Read(l wire.ValueList) ([]*WorkflowExecution, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make([]*WorkflowExecution, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _WorkflowExecution_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+// FromWire deserializes a DescribeWorkflowExecutionsRequest struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a DescribeWorkflowExecutionsRequest struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v DescribeWorkflowExecutionsRequest
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *DescribeWorkflowExecutionsRequest) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Domain = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TList {
+				v.Executions, err = _List_WorkflowExecution_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a DescribeWorkflowExecutionsRequest
+// struct.
+func (v *DescribeWorkflowExecutionsRequest) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [2]string
+	i := 0
+	if v.Domain != nil {
+		fields[i] = fmt.Sprintf("Domain: %v", *(v.Domain))
+		i++
+	}
+	if v.Executions != nil {
+		fields[i] = fmt.Sprintf("Executions: %v", v.Executions)
+		i++
+	}
+
+	return fmt.Sprintf("DescribeWorkflowExecutionsRequest{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _List_WorkflowExecution_Equals(lhs, rhs []*WorkflowExecution) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Equals returns true if all the fields of this DescribeWorkflowExecutionsRequest match the
+// provided DescribeWorkflowExecutionsRequest.
+//
+// This function performs a deep comparison.
+func (v *DescribeWorkflowExecutionsRequest) Equals(rhs *DescribeWorkflowExecutionsRequest) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_String_EqualsPtr(v.Domain, rhs.Domain) {
+		return false
+	}
+	if !((v.Executions == nil && rhs.Executions == nil) || (v.Executions != nil && rhs.Executions != nil && _List_WorkflowExecution_Equals(v.Executions, rhs.Executions))) {
+		return false
+	}
+
+	return true
+}
+
+type _List_WorkflowExecution_Zapper []*WorkflowExecution
+
+// MarshalLogArray implements zapcore.ArrayMarshaler, enabling
+// fast logging of _List_WorkflowExecution_Zapper.
+func (l _List_WorkflowExecution_Zapper) MarshalLogArray(enc zapcore.ArrayEncoder) (err error) {
+	for _, v := range l {
+		err = multierr.Append(err, enc.AppendObject(v))
+	}
+	return err
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of DescribeWorkflowExecutionsRequest.
+func (v *DescribeWorkflowExecutionsRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Domain != nil {
+		enc.AddString("domain", *v.Domain)
+	}
+	if v.Executions != nil {
+		err = multierr.Append(err, enc.AddArray("executions", (_List_WorkflowExecution_Zapper)(v.Executions)))
+	}
+	return err
+}
+
+// GetDomain returns the value of Domain if it is set or its
+// zero value if it is unset.
+func (v *DescribeWorkflowExecutionsRequest) GetDomain() (o string) {
+	if v != nil && v.Domain != nil {
+		return *v.Domain
+	}
+
+	return
+}
+
+// IsSetDomain returns true if Domain is not nil.
+func (v *DescribeWorkflowExecutionsRequest) IsSetDomain() bool {
+	return v != nil && v.Domain != nil
+}
+
+// GetExecutions returns the value of Executions if it is set or its
+// zero value if it is unset.
+func (v *DescribeWorkflowExecutionsRequest) GetExecutions() (o []*WorkflowExecution) {
+	if v != nil && v.Executions != nil {
+		return v.Executions
+	}
+
+	return
+}
+
+// IsSetExecutions returns true if Executions is not nil.
+func (v *DescribeWorkflowExecutionsRequest) IsSetExecutions() bool {
+	return v != nil && v.Executions != nil
+}
+
+type DescribeWorkflowExecutionsResponse struct {
+	Executions []*DescribeWorkflowExecutionResponse `json:"executions,omitempty"`
+}
+
+type _List_DescribeWorkflowExecutionResponse_ValueList []*DescribeWorkflowExecutionResponse
+
+func (v _List_DescribeWorkflowExecutionResponse_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_DescribeWorkflowExecutionResponse_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_DescribeWorkflowExecutionResponse_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_DescribeWorkflowExecutionResponse_ValueList) Close() {}
+
+// ToWire translates a DescribeWorkflowExecutionsResponse struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *DescribeWorkflowExecutionsResponse) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Executions != nil {
+		w, err = wire.NewValueList(_List_DescribeWorkflowExecutionResponse_ValueList(v.Executions)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _DescribeWorkflowExecutionResponse_Read(w wire.Value) (*DescribeWorkflowExecutionResponse, error) {
+	var v DescribeWorkflowExecutionResponse
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _List_DescribeWorkflowExecutionResponse_Read(l wire.ValueList) ([]*DescribeWorkflowExecutionResponse, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make([]*DescribeWorkflowExecutionResponse, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _DescribeWorkflowExecutionResponse_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+// FromWire deserializes a DescribeWorkflowExecutionsResponse struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a DescribeWorkflowExecutionsResponse struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v DescribeWorkflowExecutionsResponse
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *DescribeWorkflowExecutionsResponse) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TList {
+				v.Executions, err = _List_DescribeWorkflowExecutionResponse_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a DescribeWorkflowExecutionsResponse
+// struct.
+func (v *DescribeWorkflowExecutionsResponse) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [1]string
+	i := 0
+	if v.Executions != nil {
+		fields[i] = fmt.Sprintf("Executions: %v", v.Executions)
+		i++
+	}
+
+	return fmt.Sprintf("DescribeWorkflowExecutionsResponse{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _List_DescribeWorkflowExecutionResponse_Equals(lhs, rhs []*DescribeWorkflowExecutionResponse) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Equals returns true if all the fields of this DescribeWorkflowExecutionsResponse match the
+// provided DescribeWorkflowExecutionsResponse.
+//
+// This function performs a deep comparison.
+func (v *DescribeWorkflowExecutionsResponse) Equals(rhs *DescribeWorkflowExecutionsResponse) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.Executions == nil && rhs.Executions == nil) || (v.Executions != nil && rhs.Executions != nil && _List_DescribeWorkflowExecutionResponse_Equals(v.Executions, rhs.Executions))) {
+		return false
+	}
+
+	return true
+}
+
+type _List_DescribeWorkflowExecutionResponse_Zapper []*DescribeWorkflowExecutionResponse
+
+// MarshalLogArray implements zapcore.ArrayMarshaler, enabling
+// fast logging of _List_DescribeWorkflowExecutionResponse_Zapper.
+func (l _List_DescribeWorkflowExecutionResponse_Zapper) MarshalLogArray(enc zapcore.ArrayEncoder) (err error) {
+	for _, v := range l {
+		err = multierr.Append(err, enc.AppendObject(v))
+	}
+	return err
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of DescribeWorkflowExecutionsResponse.
+func (v *DescribeWorkflowExecutionsResponse) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Executions != nil {
+		err = multierr.Append(err, enc.AddArray("executions", (_List_DescribeWorkflowExecutionResponse_Zapper)(v.Executions)))
+	}
+	return err
+}
+
+// GetExecutions returns the value of Executions if it is set or its
+// zero value if it is unset.
+func (v *DescribeWorkflowExecutionsResponse) GetExecutions() (o []*DescribeWorkflowExecutionResponse) {
+	if v != nil && v.Executions != nil {
+		return v.Executions
+	}
+
+	return
+}
+
+// IsSetExecutions returns true if Executions is not nil.
+func (v *DescribeWorkflowExecutionsResponse) IsSetExecutions() bool {
+	return v != nil && v.Executions != nil
+}
+
 type DomainAlreadyExistsError struct {
 	Message string `json:"message,required"`
 }
@@ -12959,6 +13924,7 @@ type DomainConfiguration struct {
 	ArchivalRetentionPeriodInDays          *int32          `json:"archivalRetentionPeriodInDays,omitempty"`
 	ArchivalStatus                         *ArchivalStatus `json:"archivalStatus,omitempty"`
 	ArchivalBucketOwner                    *string         `json:"archivalBucketOwner,omitempty"`
+	ArchivalTargetBucketName               *string         `json:"archivalTargetBucketName,omitempty"`
 }
 
 // ToWire translates a DomainConfiguration struct into a Thrift-level intermediate
@@ -12978,7 +13944,7 @@ type DomainConfiguration struct {
 //   }
 func (v *DomainConfiguration) ToWire() (wire.Value, error) {
 	var (
-		fields [6]wire.Field
+		fields [7]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -13032,6 +13998,14 @@ func (v *DomainConfiguration) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 60, Value: w}
 		i++
 	}
+	if v.ArchivalTargetBucketName != nil {
+		w, err = wire.NewValueString(*(v.ArchivalTargetBucketName)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 70, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -13123,6 +14097,16 @@ func (v *DomainConfiguration) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 70:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.ArchivalTargetBucketName = &x
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -13137,7 +14121,7 @@ func (v *DomainConfiguration) String() string {
 		return "<nil>"
 	}
 
-	var fields [6]string
+	var fields [7]string
 	i := 0
 	if v.WorkflowExecutionRetentionPeriodInDays != nil {
 		fields[i] = fmt.Sprintf("WorkflowExecutionRetentionPeriodInDays: %v", *(v.WorkflowExecutionRetentionPeriodInDays))
@@ -13163,6 +14147,10 @@ func (v *DomainConfiguration) String() string {
 		fields[i] = fmt.Sprintf("ArchivalBucketOwner: %v", *(v.ArchivalBucketOwner))
 		i++
 	}
+	if v.ArchivalTargetBucketName != nil {
+		fields[i] = fmt.Sprintf("ArchivalTargetBucketName: %v", *(v.ArchivalTargetBucketName))
+		i++
+	}
 
 	return fmt.Sprintf("DomainConfiguration{%v}", strings.Join(fields[:i], ", "))
 }
@@ -13205,6 +14193,9 @@ func (v *DomainConfiguration) Equals(rhs *DomainConfiguration) bool {
 	if !_String_EqualsPtr(v.ArchivalBucketOwner, rhs.ArchivalBucketOwner) {
 		return false
 	}
+	if !_String_EqualsPtr(v.ArchivalTargetBucketName, rhs.ArchivalTargetBucketName) {
+		return false
+	}
 
 	return true
 }
@@ -13233,6 +14224,9 @@ func (v *DomainConfiguration) MarshalLogObject(enc zapcore.ObjectEncoder) (err e
 	if v.ArchivalBucketOwner != nil {
 		enc.AddString("archivalBucketOwner", *v.ArchivalBucketOwner)
 	}
+	if v.ArchivalTargetBucketName != nil {
+		enc.AddString("archivalTargetBucketName", *v.ArchivalTargetBucketName)
+	}
 	return err
 }
 
@@ -13326,6 +14320,21 @@ func (v *DomainConfiguration) IsSetArchivalBucketOwner() bool {
 	return v != nil && v.ArchivalBucketOwner != nil
 }
 
+// GetArchivalTargetBucketName returns the value of ArchivalTargetBucketName if it is set or its
+// zero value if it is unset.
+func (v *DomainConfiguration) GetArchivalTargetBucketName() (o string) {
+	if v != nil && v.ArchivalTargetBucketName != nil {
+		return *v.ArchivalTargetBucketName
+	}
+
+	return
+}
+
+// IsSetArchivalTargetBucketName returns true if ArchivalTargetBucketName is not nil.
+func (v *DomainConfiguration) IsSetArchivalTargetBucketName() bool {
+	return v != nil && v.ArchivalTargetBucketName != nil
+}
+
 type DomainInfo struct {
 	Name        *string           `json:"name,omitempty"`
 	Status      *DomainStatus     `json:"status,omitempty"`
@@ -14478,12 +15487,14 @@ type EncodingType int32
 
 const (
 	EncodingTypeThriftRW EncodingType = 0
+	EncodingTypeJSON     EncodingType = 1
 )
 
 // EncodingType_Values returns all recognized values of EncodingType.
 func EncodingType_Values() []EncodingType {
 	return []EncodingType{
 		EncodingTypeThriftRW,
+		EncodingTypeJSON,
 	}
 }
 
@@ -14497,6 +15508,9 @@ func (v *EncodingType) UnmarshalText(value []byte) error {
 	case "ThriftRW":
 		*v = EncodingTypeThriftRW
 		return nil
+	case "JSON":
+		*v = EncodingTypeJSON
+		return nil
 	default:
 		val, err := strconv.ParseInt(s, 10, 32)
 		if err != nil {
@@ -14517,6 +15531,8 @@ func (v EncodingType) MarshalText() ([]byte, error) {
 	switch int32(v) {
 	case 0:
 		return []byte("ThriftRW"), nil
+	case 1:
+		return []byte("JSON"), nil
 	}
 	return []byte(strconv.FormatInt(int64(v), 10)), nil
 }
@@ -14530,6 +15546,8 @@ func (v EncodingType) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	switch int32(v) {
 	case 0:
 		enc.AddString("name", "ThriftRW")
+	case 1:
+		enc.AddString("name", "JSON")
 	}
 	return nil
 }
@@ -14572,6 +15590,8 @@ func (v EncodingType) String() string {
 	switch w {
 	case 0:
 		return "ThriftRW"
+	case 1:
+		return "JSON"
 	}
 	return fmt.Sprintf("EncodingType(%d)", w)
 }
@@ -14592,6 +15612,8 @@ func (v EncodingType) MarshalJSON() ([]byte, error) {
 	switch int32(v) {
 	case 0:
 		return ([]byte)("\"ThriftRW\""), nil
+	case 1:
+		return ([]byte)("\"JSON\""), nil
 	}
 	return ([]byte)(strconv.FormatInt(int64(v), 10)), nil
 }
@@ -16114,6 +17136,239 @@ func (v *FailWorkflowExecutionDecisionAttributes) IsSetDetails() bool {
 	return v != nil && v.Details != nil
 }
 
+type GetSearchAttributesResponse struct {
+	Keys map[string]IndexedValueType `json:"keys,omitempty"`
+}
+
+type _Map_String_IndexedValueType_MapItemList map[string]IndexedValueType
+
+func (m _Map_String_IndexedValueType_MapItemList) ForEach(f func(wire.MapItem) error) error {
+	for k, v := range m {
+		kw, err := wire.NewValueString(k), error(nil)
+		if err != nil {
+			return err
+		}
+
+		vw, err := v.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(wire.MapItem{Key: kw, Value: vw})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m _Map_String_IndexedValueType_MapItemList) Size() int {
+	return len(m)
+}
+
+func (_Map_String_IndexedValueType_MapItemList) KeyType() wire.Type {
+	return wire.TBinary
+}
+
+func (_Map_String_IndexedValueType_MapItemList) ValueType() wire.Type {
+	return wire.TI32
+}
+
+func (_Map_String_IndexedValueType_MapItemList) Close() {}
+
+// ToWire translates a GetSearchAttributesResponse struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *GetSearchAttributesResponse) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Keys != nil {
+		w, err = wire.NewValueMap(_Map_String_IndexedValueType_MapItemList(v.Keys)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _IndexedValueType_Read(w wire.Value) (IndexedValueType, error) {
+	var v IndexedValueType
+	err := v.FromWire(w)
+	return v, err
+}
+
+func _Map_String_IndexedValueType_Read(m wire.MapItemList) (map[string]IndexedValueType, error) {
+	if m.KeyType() != wire.TBinary {
+		return nil, nil
+	}
+
+	if m.ValueType() != wire.TI32 {
+		return nil, nil
+	}
+
+	o := make(map[string]IndexedValueType, m.Size())
+	err := m.ForEach(func(x wire.MapItem) error {
+		k, err := x.Key.GetString(), error(nil)
+		if err != nil {
+			return err
+		}
+
+		v, err := _IndexedValueType_Read(x.Value)
+		if err != nil {
+			return err
+		}
+
+		o[k] = v
+		return nil
+	})
+	m.Close()
+	return o, err
+}
+
+// FromWire deserializes a GetSearchAttributesResponse struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a GetSearchAttributesResponse struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v GetSearchAttributesResponse
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *GetSearchAttributesResponse) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TMap {
+				v.Keys, err = _Map_String_IndexedValueType_Read(field.Value.GetMap())
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a GetSearchAttributesResponse
+// struct.
+func (v *GetSearchAttributesResponse) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [1]string
+	i := 0
+	if v.Keys != nil {
+		fields[i] = fmt.Sprintf("Keys: %v", v.Keys)
+		i++
+	}
+
+	return fmt.Sprintf("GetSearchAttributesResponse{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _Map_String_IndexedValueType_Equals(lhs, rhs map[string]IndexedValueType) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for lk, lv := range lhs {
+		rv, ok := rhs[lk]
+		if !ok {
+			return false
+		}
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equals returns true if all the fields of this GetSearchAttributesResponse match the
+// provided GetSearchAttributesResponse.
+//
+// This function performs a deep comparison.
+func (v *GetSearchAttributesResponse) Equals(rhs *GetSearchAttributesResponse) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.Keys == nil && rhs.Keys == nil) || (v.Keys != nil && rhs.Keys != nil && _Map_String_IndexedValueType_Equals(v.Keys, rhs.Keys))) {
+		return false
+	}
+
+	return true
+}
+
+type _Map_String_IndexedValueType_Zapper map[string]IndexedValueType
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of _Map_String_IndexedValueType_Zapper.
+func (m _Map_String_IndexedValueType_Zapper) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	for k, v := range m {
+		err = multierr.Append(err, enc.AddObject((string)(k), v))
+	}
+	return err
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of GetSearchAttributesResponse.
+func (v *GetSearchAttributesResponse) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Keys != nil {
+		err = multierr.Append(err, enc.AddObject("keys", (_Map_String_IndexedValueType_Zapper)(v.Keys)))
+	}
+	return err
+}
+
+// GetKeys returns the value of Keys if it is set or its
+// zero value if it is unset.
+func (v *GetSearchAttributesResponse) GetKeys() (o map[string]IndexedValueType) {
+	if v != nil && v.Keys != nil {
+		return v.Keys
+	}
+
+	return
+}
+
+// IsSetKeys returns true if Keys is not nil.
+func (v *GetSearchAttributesResponse) IsSetKeys() bool {
+	return v != nil && v.Keys != nil
+}
+
 type GetWorkflowExecutionHistoryRequest struct {
 	Domain                 *string                 `json:"domain,omitempty"`
 	Execution              *WorkflowExecution      `json:"execution,omitempty"`
@@ -16121,8 +17376,36 @@ type GetWorkflowExecutionHistoryRequest struct {
 	NextPageToken          []byte                  `json:"nextPageToken,omitempty"`
 	WaitForNewEvent        *bool                   `json:"waitForNewEvent,omitempty"`
 	HistoryEventFilterType *HistoryEventFilterType `json:"HistoryEventFilterType,omitempty"`
+	ReverseOrder           *bool                   `json:"reverseOrder,omitempty"`
+	EventTypes             []EventType             `json:"eventTypes,omitempty"`
 }
 
+type _List_EventType_ValueList []EventType
+
+func (v _List_EventType_ValueList) ForEach(f func(wire.Value) error) error {
+	for _, x := range v {
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_EventType_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_EventType_ValueList) ValueType() wire.Type {
+	return wire.TI32
+}
+
+func (_List_EventType_ValueList) Close() {}
+
 // ToWire translates a GetWorkflowExecutionHistoryRequest struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
@@ -16140,7 +17423,7 @@ type GetWorkflowExecutionHistoryRequest struct {
 //   }
 func (v *GetWorkflowExecutionHistoryRequest) ToWire() (wire.Value, error) {
 	var (
-		fields [6]wire.Field
+		fields [8]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -16194,6 +17477,22 @@ func (v *GetWorkflowExecutionHistoryRequest) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 60, Value: w}
 		i++
 	}
+	if v.ReverseOrder != nil {
+		w, err = wire.NewValueBool(*(v.ReverseOrder)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 70, Value: w}
+		i++
+	}
+	if v.EventTypes != nil {
+		w, err = wire.NewValueList(_List_EventType_ValueList(v.EventTypes)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 80, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -16204,6 +17503,24 @@ func _HistoryEventFilterType_Read(w wire.Value) (HistoryEventFilterType, error)
 	return v, err
 }
 
+func _List_EventType_Read(l wire.ValueList) ([]EventType, error) {
+	if l.ValueType() != wire.TI32 {
+		return nil, nil
+	}
+
+	o := make([]EventType, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _EventType_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
 // FromWire deserializes a GetWorkflowExecutionHistoryRequest struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
@@ -16281,6 +17598,24 @@ func (v *GetWorkflowExecutionHistoryRequest) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 70:
+			if field.Value.Type() == wire.TBool {
+				var x bool
+				x, err = field.Value.GetBool(), error(nil)
+				v.ReverseOrder = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 80:
+			if field.Value.Type() == wire.TList {
+				v.EventTypes, err = _List_EventType_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -16295,7 +17630,7 @@ func (v *GetWorkflowExecutionHistoryRequest) String() string {
 		return "<nil>"
 	}
 
-	var fields [6]string
+	var fields [8]string
 	i := 0
 	if v.Domain != nil {
 		fields[i] = fmt.Sprintf("Domain: %v", *(v.Domain))
@@ -16321,6 +17656,14 @@ func (v *GetWorkflowExecutionHistoryRequest) String() string {
 		fields[i] = fmt.Sprintf("HistoryEventFilterType: %v", *(v.HistoryEventFilterType))
 		i++
 	}
+	if v.ReverseOrder != nil {
+		fields[i] = fmt.Sprintf("ReverseOrder: %v", *(v.ReverseOrder))
+		i++
+	}
+	if v.EventTypes != nil {
+		fields[i] = fmt.Sprintf("EventTypes: %v", v.EventTypes)
+		i++
+	}
 
 	return fmt.Sprintf("GetWorkflowExecutionHistoryRequest{%v}", strings.Join(fields[:i], ", "))
 }
@@ -16335,6 +17678,21 @@ func _HistoryEventFilterType_EqualsPtr(lhs, rhs *HistoryEventFilterType) bool {
 	return lhs == nil && rhs == nil
 }
 
+func _List_EventType_Equals(lhs, rhs []EventType) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+
+	return true
+}
+
 // Equals returns true if all the fields of this GetWorkflowExecutionHistoryRequest match the
 // provided GetWorkflowExecutionHistoryRequest.
 //
@@ -16363,10 +17721,27 @@ func (v *GetWorkflowExecutionHistoryRequest) Equals(rhs *GetWorkflowExecutionHis
 	if !_HistoryEventFilterType_EqualsPtr(v.HistoryEventFilterType, rhs.HistoryEventFilterType) {
 		return false
 	}
+	if !_Bool_EqualsPtr(v.ReverseOrder, rhs.ReverseOrder) {
+		return false
+	}
+	if !((v.EventTypes == nil && rhs.EventTypes == nil) || (v.EventTypes != nil && rhs.EventTypes != nil && _List_EventType_Equals(v.EventTypes, rhs.EventTypes))) {
+		return false
+	}
 
 	return true
 }
 
+type _List_EventType_Zapper []EventType
+
+// MarshalLogArray implements zapcore.ArrayMarshaler, enabling
+// fast logging of _List_EventType_Zapper.
+func (l _List_EventType_Zapper) MarshalLogArray(enc zapcore.ArrayEncoder) (err error) {
+	for _, v := range l {
+		err = multierr.Append(err, enc.AppendObject(v))
+	}
+	return err
+}
+
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
 // fast logging of GetWorkflowExecutionHistoryRequest.
 func (v *GetWorkflowExecutionHistoryRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
@@ -16391,6 +17766,12 @@ func (v *GetWorkflowExecutionHistoryRequest) MarshalLogObject(enc zapcore.Object
 	if v.HistoryEventFilterType != nil {
 		err = multierr.Append(err, enc.AddObject("HistoryEventFilterType", *v.HistoryEventFilterType))
 	}
+	if v.ReverseOrder != nil {
+		enc.AddBool("reverseOrder", *v.ReverseOrder)
+	}
+	if v.EventTypes != nil {
+		err = multierr.Append(err, enc.AddArray("eventTypes", (_List_EventType_Zapper)(v.EventTypes)))
+	}
 	return err
 }
 
@@ -16484,6 +17865,36 @@ func (v *GetWorkflowExecutionHistoryRequest) IsSetHistoryEventFilterType() bool
 	return v != nil && v.HistoryEventFilterType != nil
 }
 
+// GetReverseOrder returns the value of ReverseOrder if it is set or its
+// zero value if it is unset.
+func (v *GetWorkflowExecutionHistoryRequest) GetReverseOrder() (o bool) {
+	if v != nil && v.ReverseOrder != nil {
+		return *v.ReverseOrder
+	}
+
+	return
+}
+
+// IsSetReverseOrder returns true if ReverseOrder is not nil.
+func (v *GetWorkflowExecutionHistoryRequest) IsSetReverseOrder() bool {
+	return v != nil && v.ReverseOrder != nil
+}
+
+// GetEventTypes returns the value of EventTypes if it is set or its
+// zero value if it is unset.
+func (v *GetWorkflowExecutionHistoryRequest) GetEventTypes() (o []EventType) {
+	if v != nil && v.EventTypes != nil {
+		return v.EventTypes
+	}
+
+	return
+}
+
+// IsSetEventTypes returns true if EventTypes is not nil.
+func (v *GetWorkflowExecutionHistoryRequest) IsSetEventTypes() bool {
+	return v != nil && v.EventTypes != nil
+}
+
 type GetWorkflowExecutionHistoryResponse struct {
 	History       *History `json:"history,omitempty"`
 	NextPageToken []byte   `json:"nextPageToken,omitempty"`
@@ -16712,49 +18123,14 @@ func (v *GetWorkflowExecutionHistoryResponse) IsSetArchived() bool {
 	return v != nil && v.Archived != nil
 }
 
-type Header struct {
-	Fields map[string][]byte `json:"fields,omitempty"`
-}
-
-type _Map_String_Binary_MapItemList map[string][]byte
-
-func (m _Map_String_Binary_MapItemList) ForEach(f func(wire.MapItem) error) error {
-	for k, v := range m {
-		if v == nil {
-			return fmt.Errorf("invalid [%v]: value is nil", k)
-		}
-		kw, err := wire.NewValueString(k), error(nil)
-		if err != nil {
-			return err
-		}
-
-		vw, err := wire.NewValueBinary(v), error(nil)
-		if err != nil {
-			return err
-		}
-		err = f(wire.MapItem{Key: kw, Value: vw})
-		if err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
-func (m _Map_String_Binary_MapItemList) Size() int {
-	return len(m)
-}
-
-func (_Map_String_Binary_MapItemList) KeyType() wire.Type {
-	return wire.TBinary
-}
-
-func (_Map_String_Binary_MapItemList) ValueType() wire.Type {
-	return wire.TBinary
+type GetWorkflowExecutionStatisticsRequest struct {
+	Domain                     *string `json:"domain,omitempty"`
+	EarliestCloseTime          *int64  `json:"earliestCloseTime,omitempty"`
+	LatestCloseTime            *int64  `json:"latestCloseTime,omitempty"`
+	CloseTimeIntervalInSeconds *int32  `json:"closeTimeIntervalInSeconds,omitempty"`
 }
 
-func (_Map_String_Binary_MapItemList) Close() {}
-
-// ToWire translates a Header struct into a Thrift-level intermediate
+// ToWire translates a GetWorkflowExecutionStatisticsRequest struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -16769,59 +18145,55 @@ func (_Map_String_Binary_MapItemList) Close() {}
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *Header) ToWire() (wire.Value, error) {
+func (v *GetWorkflowExecutionStatisticsRequest) ToWire() (wire.Value, error) {
 	var (
-		fields [1]wire.Field
+		fields [4]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
 	)
 
-	if v.Fields != nil {
-		w, err = wire.NewValueMap(_Map_String_Binary_MapItemList(v.Fields)), error(nil)
+	if v.Domain != nil {
+		w, err = wire.NewValueString(*(v.Domain)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-
-	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
-}
-
-func _Map_String_Binary_Read(m wire.MapItemList) (map[string][]byte, error) {
-	if m.KeyType() != wire.TBinary {
-		return nil, nil
-	}
-
-	if m.ValueType() != wire.TBinary {
-		return nil, nil
+	if v.EarliestCloseTime != nil {
+		w, err = wire.NewValueI64(*(v.EarliestCloseTime)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
 	}
-
-	o := make(map[string][]byte, m.Size())
-	err := m.ForEach(func(x wire.MapItem) error {
-		k, err := x.Key.GetString(), error(nil)
+	if v.LatestCloseTime != nil {
+		w, err = wire.NewValueI64(*(v.LatestCloseTime)), error(nil)
 		if err != nil {
-			return err
+			return w, err
 		}
-
-		v, err := x.Value.GetBinary(), error(nil)
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+	if v.CloseTimeIntervalInSeconds != nil {
+		w, err = wire.NewValueI32(*(v.CloseTimeIntervalInSeconds)), error(nil)
 		if err != nil {
-			return err
+			return w, err
 		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
 
-		o[k] = v
-		return nil
-	})
-	m.Close()
-	return o, err
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-// FromWire deserializes a Header struct from its Thrift-level
+// FromWire deserializes a GetWorkflowExecutionStatisticsRequest struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a Header struct
+// An error is returned if we were unable to build a GetWorkflowExecutionStatisticsRequest struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -16829,19 +18201,51 @@ func _Map_String_Binary_Read(m wire.MapItemList) (map[string][]byte, error) {
 //     return nil, err
 //   }
 //
-//   var v Header
+//   var v GetWorkflowExecutionStatisticsRequest
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *Header) FromWire(w wire.Value) error {
+func (v *GetWorkflowExecutionStatisticsRequest) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
 		case 10:
-			if field.Value.Type() == wire.TMap {
-				v.Fields, err = _Map_String_Binary_Read(field.Value.GetMap())
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Domain = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.EarliestCloseTime = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.LatestCloseTime = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.CloseTimeIntervalInSeconds = &x
 				if err != nil {
 					return err
 				}
@@ -16853,102 +18257,152 @@ func (v *Header) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a Header
+// String returns a readable string representation of a GetWorkflowExecutionStatisticsRequest
 // struct.
-func (v *Header) String() string {
+func (v *GetWorkflowExecutionStatisticsRequest) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [1]string
+	var fields [4]string
 	i := 0
-	if v.Fields != nil {
-		fields[i] = fmt.Sprintf("Fields: %v", v.Fields)
+	if v.Domain != nil {
+		fields[i] = fmt.Sprintf("Domain: %v", *(v.Domain))
 		i++
 	}
-
-	return fmt.Sprintf("Header{%v}", strings.Join(fields[:i], ", "))
-}
-
-func _Map_String_Binary_Equals(lhs, rhs map[string][]byte) bool {
-	if len(lhs) != len(rhs) {
-		return false
+	if v.EarliestCloseTime != nil {
+		fields[i] = fmt.Sprintf("EarliestCloseTime: %v", *(v.EarliestCloseTime))
+		i++
 	}
-
-	for lk, lv := range lhs {
-		rv, ok := rhs[lk]
-		if !ok {
-			return false
-		}
-		if !bytes.Equal(lv, rv) {
-			return false
-		}
+	if v.LatestCloseTime != nil {
+		fields[i] = fmt.Sprintf("LatestCloseTime: %v", *(v.LatestCloseTime))
+		i++
 	}
-	return true
+	if v.CloseTimeIntervalInSeconds != nil {
+		fields[i] = fmt.Sprintf("CloseTimeIntervalInSeconds: %v", *(v.CloseTimeIntervalInSeconds))
+		i++
+	}
+
+	return fmt.Sprintf("GetWorkflowExecutionStatisticsRequest{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this Header match the
-// provided Header.
+// Equals returns true if all the fields of this GetWorkflowExecutionStatisticsRequest match the
+// provided GetWorkflowExecutionStatisticsRequest.
 //
 // This function performs a deep comparison.
-func (v *Header) Equals(rhs *Header) bool {
+func (v *GetWorkflowExecutionStatisticsRequest) Equals(rhs *GetWorkflowExecutionStatisticsRequest) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
 		return false
 	}
-	if !((v.Fields == nil && rhs.Fields == nil) || (v.Fields != nil && rhs.Fields != nil && _Map_String_Binary_Equals(v.Fields, rhs.Fields))) {
+	if !_String_EqualsPtr(v.Domain, rhs.Domain) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.EarliestCloseTime, rhs.EarliestCloseTime) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.LatestCloseTime, rhs.LatestCloseTime) {
+		return false
+	}
+	if !_I32_EqualsPtr(v.CloseTimeIntervalInSeconds, rhs.CloseTimeIntervalInSeconds) {
 		return false
 	}
 
 	return true
 }
 
-type _Map_String_Binary_Zapper map[string][]byte
-
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of _Map_String_Binary_Zapper.
-func (m _Map_String_Binary_Zapper) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
-	for k, v := range m {
-		enc.AddString((string)(k), base64.StdEncoding.EncodeToString(v))
+// fast logging of GetWorkflowExecutionStatisticsRequest.
+func (v *GetWorkflowExecutionStatisticsRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Domain != nil {
+		enc.AddString("domain", *v.Domain)
+	}
+	if v.EarliestCloseTime != nil {
+		enc.AddInt64("earliestCloseTime", *v.EarliestCloseTime)
+	}
+	if v.LatestCloseTime != nil {
+		enc.AddInt64("latestCloseTime", *v.LatestCloseTime)
+	}
+	if v.CloseTimeIntervalInSeconds != nil {
+		enc.AddInt32("closeTimeIntervalInSeconds", *v.CloseTimeIntervalInSeconds)
 	}
 	return err
 }
 
-// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of Header.
-func (v *Header) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
-	if v == nil {
-		return nil
+// GetDomain returns the value of Domain if it is set or its
+// zero value if it is unset.
+func (v *GetWorkflowExecutionStatisticsRequest) GetDomain() (o string) {
+	if v != nil && v.Domain != nil {
+		return *v.Domain
 	}
-	if v.Fields != nil {
-		err = multierr.Append(err, enc.AddObject("fields", (_Map_String_Binary_Zapper)(v.Fields)))
+
+	return
+}
+
+// IsSetDomain returns true if Domain is not nil.
+func (v *GetWorkflowExecutionStatisticsRequest) IsSetDomain() bool {
+	return v != nil && v.Domain != nil
+}
+
+// GetEarliestCloseTime returns the value of EarliestCloseTime if it is set or its
+// zero value if it is unset.
+func (v *GetWorkflowExecutionStatisticsRequest) GetEarliestCloseTime() (o int64) {
+	if v != nil && v.EarliestCloseTime != nil {
+		return *v.EarliestCloseTime
 	}
-	return err
+
+	return
 }
 
-// GetFields returns the value of Fields if it is set or its
+// IsSetEarliestCloseTime returns true if EarliestCloseTime is not nil.
+func (v *GetWorkflowExecutionStatisticsRequest) IsSetEarliestCloseTime() bool {
+	return v != nil && v.EarliestCloseTime != nil
+}
+
+// GetLatestCloseTime returns the value of LatestCloseTime if it is set or its
 // zero value if it is unset.
-func (v *Header) GetFields() (o map[string][]byte) {
-	if v != nil && v.Fields != nil {
-		return v.Fields
+func (v *GetWorkflowExecutionStatisticsRequest) GetLatestCloseTime() (o int64) {
+	if v != nil && v.LatestCloseTime != nil {
+		return *v.LatestCloseTime
 	}
 
 	return
 }
 
-// IsSetFields returns true if Fields is not nil.
-func (v *Header) IsSetFields() bool {
-	return v != nil && v.Fields != nil
+// IsSetLatestCloseTime returns true if LatestCloseTime is not nil.
+func (v *GetWorkflowExecutionStatisticsRequest) IsSetLatestCloseTime() bool {
+	return v != nil && v.LatestCloseTime != nil
 }
 
-type History struct {
-	Events []*HistoryEvent `json:"events,omitempty"`
+// GetCloseTimeIntervalInSeconds returns the value of CloseTimeIntervalInSeconds if it is set or its
+// zero value if it is unset.
+func (v *GetWorkflowExecutionStatisticsRequest) GetCloseTimeIntervalInSeconds() (o int32) {
+	if v != nil && v.CloseTimeIntervalInSeconds != nil {
+		return *v.CloseTimeIntervalInSeconds
+	}
+
+	return
 }
 
-type _List_HistoryEvent_ValueList []*HistoryEvent
+// IsSetCloseTimeIntervalInSeconds returns true if CloseTimeIntervalInSeconds is not nil.
+func (v *GetWorkflowExecutionStatisticsRequest) IsSetCloseTimeIntervalInSeconds() bool {
+	return v != nil && v.CloseTimeIntervalInSeconds != nil
+}
 
-func (v _List_HistoryEvent_ValueList) ForEach(f func(wire.Value) error) error {
+type GetWorkflowExecutionStatisticsResponse struct {
+	TotalCount          *int64                          `json:"totalCount,omitempty"`
+	CountsByType        []*WorkflowExecutionCountBucket `json:"countsByType,omitempty"`
+	CountsByCloseStatus []*WorkflowExecutionCountBucket `json:"countsByCloseStatus,omitempty"`
+	CountsByCloseTime   []*WorkflowExecutionTimeBucket  `json:"countsByCloseTime,omitempty"`
+}
+
+type _List_WorkflowExecutionCountBucket_ValueList []*WorkflowExecutionCountBucket
+
+func (v _List_WorkflowExecutionCountBucket_ValueList) ForEach(f func(wire.Value) error) error {
 	for i, x := range v {
 		if x == nil {
 			return fmt.Errorf("invalid [%v]: value is nil", i)
@@ -16965,17 +18419,46 @@ func (v _List_HistoryEvent_ValueList) ForEach(f func(wire.Value) error) error {
 	return nil
 }
 
-func (v _List_HistoryEvent_ValueList) Size() int {
+func (v _List_WorkflowExecutionCountBucket_ValueList) Size() int {
 	return len(v)
 }
 
-func (_List_HistoryEvent_ValueList) ValueType() wire.Type {
+func (_List_WorkflowExecutionCountBucket_ValueList) ValueType() wire.Type {
 	return wire.TStruct
 }
 
-func (_List_HistoryEvent_ValueList) Close() {}
+func (_List_WorkflowExecutionCountBucket_ValueList) Close() {}
 
-// ToWire translates a History struct into a Thrift-level intermediate
+type _List_WorkflowExecutionTimeBucket_ValueList []*WorkflowExecutionTimeBucket
+
+func (v _List_WorkflowExecutionTimeBucket_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_WorkflowExecutionTimeBucket_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_WorkflowExecutionTimeBucket_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_WorkflowExecutionTimeBucket_ValueList) Close() {}
+
+// ToWire translates a GetWorkflowExecutionStatisticsResponse struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -16990,40 +18473,64 @@ func (_List_HistoryEvent_ValueList) Close() {}
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *History) ToWire() (wire.Value, error) {
+func (v *GetWorkflowExecutionStatisticsResponse) ToWire() (wire.Value, error) {
 	var (
-		fields [1]wire.Field
+		fields [4]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
 	)
 
-	if v.Events != nil {
-		w, err = wire.NewValueList(_List_HistoryEvent_ValueList(v.Events)), error(nil)
+	if v.TotalCount != nil {
+		w, err = wire.NewValueI64(*(v.TotalCount)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
+	if v.CountsByType != nil {
+		w, err = wire.NewValueList(_List_WorkflowExecutionCountBucket_ValueList(v.CountsByType)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.CountsByCloseStatus != nil {
+		w, err = wire.NewValueList(_List_WorkflowExecutionCountBucket_ValueList(v.CountsByCloseStatus)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+	if v.CountsByCloseTime != nil {
+		w, err = wire.NewValueList(_List_WorkflowExecutionTimeBucket_ValueList(v.CountsByCloseTime)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _HistoryEvent_Read(w wire.Value) (*HistoryEvent, error) {
-	var v HistoryEvent
+func _WorkflowExecutionCountBucket_Read(w wire.Value) (*WorkflowExecutionCountBucket, error) {
+	var v WorkflowExecutionCountBucket
 	err := v.FromWire(w)
 	return &v, err
 }
 
-func _List_HistoryEvent_Read(l wire.ValueList) ([]*HistoryEvent, error) {
+func _List_WorkflowExecutionCountBucket_Read(l wire.ValueList) ([]*WorkflowExecutionCountBucket, error) {
 	if l.ValueType() != wire.TStruct {
 		return nil, nil
 	}
 
-	o := make([]*HistoryEvent, 0, l.Size())
+	o := make([]*WorkflowExecutionCountBucket, 0, l.Size())
 	err := l.ForEach(func(x wire.Value) error {
-		i, err := _HistoryEvent_Read(x)
+		i, err := _WorkflowExecutionCountBucket_Read(x)
 		if err != nil {
 			return err
 		}
@@ -17034,11 +18541,35 @@ func _List_HistoryEvent_Read(l wire.ValueList) ([]*HistoryEvent, error) {
 	return o, err
 }
 
-// FromWire deserializes a History struct from its Thrift-level
+func _WorkflowExecutionTimeBucket_Read(w wire.Value) (*WorkflowExecutionTimeBucket, error) {
+	var v WorkflowExecutionTimeBucket
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _List_WorkflowExecutionTimeBucket_Read(l wire.ValueList) ([]*WorkflowExecutionTimeBucket, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make([]*WorkflowExecutionTimeBucket, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _WorkflowExecutionTimeBucket_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+// FromWire deserializes a GetWorkflowExecutionStatisticsResponse struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a History struct
+// An error is returned if we were unable to build a GetWorkflowExecutionStatisticsResponse struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -17046,19 +18577,45 @@ func _List_HistoryEvent_Read(l wire.ValueList) ([]*HistoryEvent, error) {
 //     return nil, err
 //   }
 //
-//   var v History
+//   var v GetWorkflowExecutionStatisticsResponse
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *History) FromWire(w wire.Value) error {
+func (v *GetWorkflowExecutionStatisticsResponse) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
 		case 10:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.TotalCount = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
 			if field.Value.Type() == wire.TList {
-				v.Events, err = _List_HistoryEvent_Read(field.Value.GetList())
+				v.CountsByType, err = _List_WorkflowExecutionCountBucket_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TList {
+				v.CountsByCloseStatus, err = _List_WorkflowExecutionCountBucket_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TList {
+				v.CountsByCloseTime, err = _List_WorkflowExecutionTimeBucket_Read(field.Value.GetList())
 				if err != nil {
 					return err
 				}
@@ -17070,24 +18627,36 @@ func (v *History) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a History
+// String returns a readable string representation of a GetWorkflowExecutionStatisticsResponse
 // struct.
-func (v *History) String() string {
+func (v *GetWorkflowExecutionStatisticsResponse) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [1]string
+	var fields [4]string
 	i := 0
-	if v.Events != nil {
-		fields[i] = fmt.Sprintf("Events: %v", v.Events)
+	if v.TotalCount != nil {
+		fields[i] = fmt.Sprintf("TotalCount: %v", *(v.TotalCount))
+		i++
+	}
+	if v.CountsByType != nil {
+		fields[i] = fmt.Sprintf("CountsByType: %v", v.CountsByType)
+		i++
+	}
+	if v.CountsByCloseStatus != nil {
+		fields[i] = fmt.Sprintf("CountsByCloseStatus: %v", v.CountsByCloseStatus)
+		i++
+	}
+	if v.CountsByCloseTime != nil {
+		fields[i] = fmt.Sprintf("CountsByCloseTime: %v", v.CountsByCloseTime)
 		i++
 	}
 
-	return fmt.Sprintf("History{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("GetWorkflowExecutionStatisticsResponse{%v}", strings.Join(fields[:i], ", "))
 }
 
-func _List_HistoryEvent_Equals(lhs, rhs []*HistoryEvent) bool {
+func _List_WorkflowExecutionCountBucket_Equals(lhs, rhs []*WorkflowExecutionCountBucket) bool {
 	if len(lhs) != len(rhs) {
 		return false
 	}
@@ -17102,28 +18671,63 @@ func _List_HistoryEvent_Equals(lhs, rhs []*HistoryEvent) bool {
 	return true
 }
 
-// Equals returns true if all the fields of this History match the
-// provided History.
+func _List_WorkflowExecutionTimeBucket_Equals(lhs, rhs []*WorkflowExecutionTimeBucket) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Equals returns true if all the fields of this GetWorkflowExecutionStatisticsResponse match the
+// provided GetWorkflowExecutionStatisticsResponse.
 //
 // This function performs a deep comparison.
-func (v *History) Equals(rhs *History) bool {
+func (v *GetWorkflowExecutionStatisticsResponse) Equals(rhs *GetWorkflowExecutionStatisticsResponse) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
 		return false
 	}
-	if !((v.Events == nil && rhs.Events == nil) || (v.Events != nil && rhs.Events != nil && _List_HistoryEvent_Equals(v.Events, rhs.Events))) {
+	if !_I64_EqualsPtr(v.TotalCount, rhs.TotalCount) {
+		return false
+	}
+	if !((v.CountsByType == nil && rhs.CountsByType == nil) || (v.CountsByType != nil && rhs.CountsByType != nil && _List_WorkflowExecutionCountBucket_Equals(v.CountsByType, rhs.CountsByType))) {
+		return false
+	}
+	if !((v.CountsByCloseStatus == nil && rhs.CountsByCloseStatus == nil) || (v.CountsByCloseStatus != nil && rhs.CountsByCloseStatus != nil && _List_WorkflowExecutionCountBucket_Equals(v.CountsByCloseStatus, rhs.CountsByCloseStatus))) {
+		return false
+	}
+	if !((v.CountsByCloseTime == nil && rhs.CountsByCloseTime == nil) || (v.CountsByCloseTime != nil && rhs.CountsByCloseTime != nil && _List_WorkflowExecutionTimeBucket_Equals(v.CountsByCloseTime, rhs.CountsByCloseTime))) {
 		return false
 	}
 
 	return true
 }
 
-type _List_HistoryEvent_Zapper []*HistoryEvent
+type _List_WorkflowExecutionCountBucket_Zapper []*WorkflowExecutionCountBucket
 
 // MarshalLogArray implements zapcore.ArrayMarshaler, enabling
-// fast logging of _List_HistoryEvent_Zapper.
-func (l _List_HistoryEvent_Zapper) MarshalLogArray(enc zapcore.ArrayEncoder) (err error) {
+// fast logging of _List_WorkflowExecutionCountBucket_Zapper.
+func (l _List_WorkflowExecutionCountBucket_Zapper) MarshalLogArray(enc zapcore.ArrayEncoder) (err error) {
+	for _, v := range l {
+		err = multierr.Append(err, enc.AppendObject(v))
+	}
+	return err
+}
+
+type _List_WorkflowExecutionTimeBucket_Zapper []*WorkflowExecutionTimeBucket
+
+// MarshalLogArray implements zapcore.ArrayMarshaler, enabling
+// fast logging of _List_WorkflowExecutionTimeBucket_Zapper.
+func (l _List_WorkflowExecutionTimeBucket_Zapper) MarshalLogArray(enc zapcore.ArrayEncoder) (err error) {
 	for _, v := range l {
 		err = multierr.Append(err, enc.AppendObject(v))
 	}
@@ -17131,50 +18735,107 @@ func (l _List_HistoryEvent_Zapper) MarshalLogArray(enc zapcore.ArrayEncoder) (er
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of History.
-func (v *History) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of GetWorkflowExecutionStatisticsResponse.
+func (v *GetWorkflowExecutionStatisticsResponse) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
-	if v.Events != nil {
-		err = multierr.Append(err, enc.AddArray("events", (_List_HistoryEvent_Zapper)(v.Events)))
+	if v.TotalCount != nil {
+		enc.AddInt64("totalCount", *v.TotalCount)
+	}
+	if v.CountsByType != nil {
+		err = multierr.Append(err, enc.AddArray("countsByType", (_List_WorkflowExecutionCountBucket_Zapper)(v.CountsByType)))
+	}
+	if v.CountsByCloseStatus != nil {
+		err = multierr.Append(err, enc.AddArray("countsByCloseStatus", (_List_WorkflowExecutionCountBucket_Zapper)(v.CountsByCloseStatus)))
+	}
+	if v.CountsByCloseTime != nil {
+		err = multierr.Append(err, enc.AddArray("countsByCloseTime", (_List_WorkflowExecutionTimeBucket_Zapper)(v.CountsByCloseTime)))
 	}
 	return err
 }
 
-// GetEvents returns the value of Events if it is set or its
+// GetTotalCount returns the value of TotalCount if it is set or its
 // zero value if it is unset.
-func (v *History) GetEvents() (o []*HistoryEvent) {
-	if v != nil && v.Events != nil {
-		return v.Events
+func (v *GetWorkflowExecutionStatisticsResponse) GetTotalCount() (o int64) {
+	if v != nil && v.TotalCount != nil {
+		return *v.TotalCount
 	}
 
 	return
 }
 
-// IsSetEvents returns true if Events is not nil.
-func (v *History) IsSetEvents() bool {
-	return v != nil && v.Events != nil
+// IsSetTotalCount returns true if TotalCount is not nil.
+func (v *GetWorkflowExecutionStatisticsResponse) IsSetTotalCount() bool {
+	return v != nil && v.TotalCount != nil
 }
 
-type HistoryBranch struct {
-	TreeID    *string               `json:"treeID,omitempty"`
-	BranchID  *string               `json:"branchID,omitempty"`
-	Ancestors []*HistoryBranchRange `json:"ancestors,omitempty"`
+// GetCountsByType returns the value of CountsByType if it is set or its
+// zero value if it is unset.
+func (v *GetWorkflowExecutionStatisticsResponse) GetCountsByType() (o []*WorkflowExecutionCountBucket) {
+	if v != nil && v.CountsByType != nil {
+		return v.CountsByType
+	}
+
+	return
 }
 
-type _List_HistoryBranchRange_ValueList []*HistoryBranchRange
+// IsSetCountsByType returns true if CountsByType is not nil.
+func (v *GetWorkflowExecutionStatisticsResponse) IsSetCountsByType() bool {
+	return v != nil && v.CountsByType != nil
+}
 
-func (v _List_HistoryBranchRange_ValueList) ForEach(f func(wire.Value) error) error {
-	for i, x := range v {
-		if x == nil {
-			return fmt.Errorf("invalid [%v]: value is nil", i)
+// GetCountsByCloseStatus returns the value of CountsByCloseStatus if it is set or its
+// zero value if it is unset.
+func (v *GetWorkflowExecutionStatisticsResponse) GetCountsByCloseStatus() (o []*WorkflowExecutionCountBucket) {
+	if v != nil && v.CountsByCloseStatus != nil {
+		return v.CountsByCloseStatus
+	}
+
+	return
+}
+
+// IsSetCountsByCloseStatus returns true if CountsByCloseStatus is not nil.
+func (v *GetWorkflowExecutionStatisticsResponse) IsSetCountsByCloseStatus() bool {
+	return v != nil && v.CountsByCloseStatus != nil
+}
+
+// GetCountsByCloseTime returns the value of CountsByCloseTime if it is set or its
+// zero value if it is unset.
+func (v *GetWorkflowExecutionStatisticsResponse) GetCountsByCloseTime() (o []*WorkflowExecutionTimeBucket) {
+	if v != nil && v.CountsByCloseTime != nil {
+		return v.CountsByCloseTime
+	}
+
+	return
+}
+
+// IsSetCountsByCloseTime returns true if CountsByCloseTime is not nil.
+func (v *GetWorkflowExecutionStatisticsResponse) IsSetCountsByCloseTime() bool {
+	return v != nil && v.CountsByCloseTime != nil
+}
+
+type Header struct {
+	Fields map[string][]byte `json:"fields,omitempty"`
+}
+
+type _Map_String_Binary_MapItemList map[string][]byte
+
+func (m _Map_String_Binary_MapItemList) ForEach(f func(wire.MapItem) error) error {
+	for k, v := range m {
+		if v == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", k)
 		}
-		w, err := x.ToWire()
+		kw, err := wire.NewValueString(k), error(nil)
 		if err != nil {
 			return err
 		}
-		err = f(w)
+
+		vw, err := wire.NewValueBinary(v), error(nil)
+		if err != nil {
+			return err
+		}
+		err = f(wire.MapItem{Key: kw, Value: vw})
 		if err != nil {
 			return err
 		}
@@ -17182,17 +18843,21 @@ func (v _List_HistoryBranchRange_ValueList) ForEach(f func(wire.Value) error) er
 	return nil
 }
 
-func (v _List_HistoryBranchRange_ValueList) Size() int {
-	return len(v)
+func (m _Map_String_Binary_MapItemList) Size() int {
+	return len(m)
 }
 
-func (_List_HistoryBranchRange_ValueList) ValueType() wire.Type {
-	return wire.TStruct
+func (_Map_String_Binary_MapItemList) KeyType() wire.Type {
+	return wire.TBinary
 }
 
-func (_List_HistoryBranchRange_ValueList) Close() {}
+func (_Map_String_Binary_MapItemList) ValueType() wire.Type {
+	return wire.TBinary
+}
 
-// ToWire translates a HistoryBranch struct into a Thrift-level intermediate
+func (_Map_String_Binary_MapItemList) Close() {}
+
+// ToWire translates a Header struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -17207,25 +18872,463 @@ func (_List_HistoryBranchRange_ValueList) Close() {}
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *HistoryBranch) ToWire() (wire.Value, error) {
+func (v *Header) ToWire() (wire.Value, error) {
 	var (
-		fields [3]wire.Field
+		fields [1]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
 	)
 
-	if v.TreeID != nil {
-		w, err = wire.NewValueString(*(v.TreeID)), error(nil)
+	if v.Fields != nil {
+		w, err = wire.NewValueMap(_Map_String_Binary_MapItemList(v.Fields)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.BranchID != nil {
-		w, err = wire.NewValueString(*(v.BranchID)), error(nil)
-		if err != nil {
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _Map_String_Binary_Read(m wire.MapItemList) (map[string][]byte, error) {
+	if m.KeyType() != wire.TBinary {
+		return nil, nil
+	}
+
+	if m.ValueType() != wire.TBinary {
+		return nil, nil
+	}
+
+	o := make(map[string][]byte, m.Size())
+	err := m.ForEach(func(x wire.MapItem) error {
+		k, err := x.Key.GetString(), error(nil)
+		if err != nil {
+			return err
+		}
+
+		v, err := x.Value.GetBinary(), error(nil)
+		if err != nil {
+			return err
+		}
+
+		o[k] = v
+		return nil
+	})
+	m.Close()
+	return o, err
+}
+
+// FromWire deserializes a Header struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a Header struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v Header
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *Header) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TMap {
+				v.Fields, err = _Map_String_Binary_Read(field.Value.GetMap())
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a Header
+// struct.
+func (v *Header) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [1]string
+	i := 0
+	if v.Fields != nil {
+		fields[i] = fmt.Sprintf("Fields: %v", v.Fields)
+		i++
+	}
+
+	return fmt.Sprintf("Header{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _Map_String_Binary_Equals(lhs, rhs map[string][]byte) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for lk, lv := range lhs {
+		rv, ok := rhs[lk]
+		if !ok {
+			return false
+		}
+		if !bytes.Equal(lv, rv) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equals returns true if all the fields of this Header match the
+// provided Header.
+//
+// This function performs a deep comparison.
+func (v *Header) Equals(rhs *Header) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.Fields == nil && rhs.Fields == nil) || (v.Fields != nil && rhs.Fields != nil && _Map_String_Binary_Equals(v.Fields, rhs.Fields))) {
+		return false
+	}
+
+	return true
+}
+
+type _Map_String_Binary_Zapper map[string][]byte
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of _Map_String_Binary_Zapper.
+func (m _Map_String_Binary_Zapper) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	for k, v := range m {
+		enc.AddString((string)(k), base64.StdEncoding.EncodeToString(v))
+	}
+	return err
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of Header.
+func (v *Header) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Fields != nil {
+		err = multierr.Append(err, enc.AddObject("fields", (_Map_String_Binary_Zapper)(v.Fields)))
+	}
+	return err
+}
+
+// GetFields returns the value of Fields if it is set or its
+// zero value if it is unset.
+func (v *Header) GetFields() (o map[string][]byte) {
+	if v != nil && v.Fields != nil {
+		return v.Fields
+	}
+
+	return
+}
+
+// IsSetFields returns true if Fields is not nil.
+func (v *Header) IsSetFields() bool {
+	return v != nil && v.Fields != nil
+}
+
+type History struct {
+	Events []*HistoryEvent `json:"events,omitempty"`
+}
+
+type _List_HistoryEvent_ValueList []*HistoryEvent
+
+func (v _List_HistoryEvent_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_HistoryEvent_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_HistoryEvent_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_HistoryEvent_ValueList) Close() {}
+
+// ToWire translates a History struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *History) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Events != nil {
+		w, err = wire.NewValueList(_List_HistoryEvent_ValueList(v.Events)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _HistoryEvent_Read(w wire.Value) (*HistoryEvent, error) {
+	var v HistoryEvent
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _List_HistoryEvent_Read(l wire.ValueList) ([]*HistoryEvent, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make([]*HistoryEvent, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _HistoryEvent_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+// FromWire deserializes a History struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a History struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v History
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *History) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TList {
+				v.Events, err = _List_HistoryEvent_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a History
+// struct.
+func (v *History) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [1]string
+	i := 0
+	if v.Events != nil {
+		fields[i] = fmt.Sprintf("Events: %v", v.Events)
+		i++
+	}
+
+	return fmt.Sprintf("History{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _List_HistoryEvent_Equals(lhs, rhs []*HistoryEvent) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Equals returns true if all the fields of this History match the
+// provided History.
+//
+// This function performs a deep comparison.
+func (v *History) Equals(rhs *History) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.Events == nil && rhs.Events == nil) || (v.Events != nil && rhs.Events != nil && _List_HistoryEvent_Equals(v.Events, rhs.Events))) {
+		return false
+	}
+
+	return true
+}
+
+type _List_HistoryEvent_Zapper []*HistoryEvent
+
+// MarshalLogArray implements zapcore.ArrayMarshaler, enabling
+// fast logging of _List_HistoryEvent_Zapper.
+func (l _List_HistoryEvent_Zapper) MarshalLogArray(enc zapcore.ArrayEncoder) (err error) {
+	for _, v := range l {
+		err = multierr.Append(err, enc.AppendObject(v))
+	}
+	return err
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of History.
+func (v *History) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Events != nil {
+		err = multierr.Append(err, enc.AddArray("events", (_List_HistoryEvent_Zapper)(v.Events)))
+	}
+	return err
+}
+
+// GetEvents returns the value of Events if it is set or its
+// zero value if it is unset.
+func (v *History) GetEvents() (o []*HistoryEvent) {
+	if v != nil && v.Events != nil {
+		return v.Events
+	}
+
+	return
+}
+
+// IsSetEvents returns true if Events is not nil.
+func (v *History) IsSetEvents() bool {
+	return v != nil && v.Events != nil
+}
+
+type HistoryBranch struct {
+	TreeID    *string               `json:"treeID,omitempty"`
+	BranchID  *string               `json:"branchID,omitempty"`
+	Ancestors []*HistoryBranchRange `json:"ancestors,omitempty"`
+}
+
+type _List_HistoryBranchRange_ValueList []*HistoryBranchRange
+
+func (v _List_HistoryBranchRange_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_HistoryBranchRange_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_HistoryBranchRange_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_HistoryBranchRange_ValueList) Close() {}
+
+// ToWire translates a HistoryBranch struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *HistoryBranch) ToWire() (wire.Value, error) {
+	var (
+		fields [3]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.TreeID != nil {
+		w, err = wire.NewValueString(*(v.TreeID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.BranchID != nil {
+		w, err = wire.NewValueString(*(v.BranchID)), error(nil)
+		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 20, Value: w}
@@ -20155,6 +22258,229 @@ func (v *HistoryEventFilterType) UnmarshalJSON(text []byte) error {
 	}
 }
 
+type IndexedValueType int32
+
+const (
+	IndexedValueTypeString   IndexedValueType = 0
+	IndexedValueTypeKeyword  IndexedValueType = 1
+	IndexedValueTypeInt      IndexedValueType = 2
+	IndexedValueTypeDouble   IndexedValueType = 3
+	IndexedValueTypeBool     IndexedValueType = 4
+	IndexedValueTypeDatetime IndexedValueType = 5
+)
+
+// IndexedValueType_Values returns all recognized values of IndexedValueType.
+func IndexedValueType_Values() []IndexedValueType {
+	return []IndexedValueType{
+		IndexedValueTypeString,
+		IndexedValueTypeKeyword,
+		IndexedValueTypeInt,
+		IndexedValueTypeDouble,
+		IndexedValueTypeBool,
+		IndexedValueTypeDatetime,
+	}
+}
+
+// UnmarshalText tries to decode IndexedValueType from a byte slice
+// containing its name.
+//
+//   var v IndexedValueType
+//   err := v.UnmarshalText([]byte("STRING"))
+func (v *IndexedValueType) UnmarshalText(value []byte) error {
+	switch s := string(value); s {
+	case "STRING":
+		*v = IndexedValueTypeString
+		return nil
+	case "KEYWORD":
+		*v = IndexedValueTypeKeyword
+		return nil
+	case "INT":
+		*v = IndexedValueTypeInt
+		return nil
+	case "DOUBLE":
+		*v = IndexedValueTypeDouble
+		return nil
+	case "BOOL":
+		*v = IndexedValueTypeBool
+		return nil
+	case "DATETIME":
+		*v = IndexedValueTypeDatetime
+		return nil
+	default:
+		val, err := strconv.ParseInt(s, 10, 32)
+		if err != nil {
+			return fmt.Errorf("unknown enum value %q for %q: %v", s, "IndexedValueType", err)
+		}
+		*v = IndexedValueType(val)
+		return nil
+	}
+}
+
+// MarshalText encodes IndexedValueType to text.
+//
+// If the enum value is recognized, its name is returned. Otherwise,
+// its integer value is returned.
+//
+// This implements the TextMarshaler interface.
+func (v IndexedValueType) MarshalText() ([]byte, error) {
+	switch int32(v) {
+	case 0:
+		return []byte("STRING"), nil
+	case 1:
+		return []byte("KEYWORD"), nil
+	case 2:
+		return []byte("INT"), nil
+	case 3:
+		return []byte("DOUBLE"), nil
+	case 4:
+		return []byte("BOOL"), nil
+	case 5:
+		return []byte("DATETIME"), nil
+	}
+	return []byte(strconv.FormatInt(int64(v), 10)), nil
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of IndexedValueType.
+// Enums are logged as objects, where the value is logged with key "value", and
+// if this value's name is known, the name is logged with key "name".
+func (v IndexedValueType) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddInt32("value", int32(v))
+	switch int32(v) {
+	case 0:
+		enc.AddString("name", "STRING")
+	case 1:
+		enc.AddString("name", "KEYWORD")
+	case 2:
+		enc.AddString("name", "INT")
+	case 3:
+		enc.AddString("name", "DOUBLE")
+	case 4:
+		enc.AddString("name", "BOOL")
+	case 5:
+		enc.AddString("name", "DATETIME")
+	}
+	return nil
+}
+
+// Ptr returns a pointer to this enum value.
+func (v IndexedValueType) Ptr() *IndexedValueType {
+	return &v
+}
+
+// ToWire translates IndexedValueType into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// Enums are represented as 32-bit integers over the wire.
+func (v IndexedValueType) ToWire() (wire.Value, error) {
+	return wire.NewValueI32(int32(v)), nil
+}
+
+// FromWire deserializes IndexedValueType from its Thrift-level
+// representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TI32)
+//   if err != nil {
+//     return IndexedValueType(0), err
+//   }
+//
+//   var v IndexedValueType
+//   if err := v.FromWire(x); err != nil {
+//     return IndexedValueType(0), err
+//   }
+//   return v, nil
+func (v *IndexedValueType) FromWire(w wire.Value) error {
+	*v = (IndexedValueType)(w.GetI32())
+	return nil
+}
+
+// String returns a readable string representation of IndexedValueType.
+func (v IndexedValueType) String() string {
+	w := int32(v)
+	switch w {
+	case 0:
+		return "STRING"
+	case 1:
+		return "KEYWORD"
+	case 2:
+		return "INT"
+	case 3:
+		return "DOUBLE"
+	case 4:
+		return "BOOL"
+	case 5:
+		return "DATETIME"
+	}
+	return fmt.Sprintf("IndexedValueType(%d)", w)
+}
+
+// Equals returns true if this IndexedValueType value matches the provided
+// value.
+func (v IndexedValueType) Equals(rhs IndexedValueType) bool {
+	return v == rhs
+}
+
+// MarshalJSON serializes IndexedValueType into JSON.
+//
+// If the enum value is recognized, its name is returned. Otherwise,
+// its integer value is returned.
+//
+// This implements json.Marshaler.
+func (v IndexedValueType) MarshalJSON() ([]byte, error) {
+	switch int32(v) {
+	case 0:
+		return ([]byte)("\"STRING\""), nil
+	case 1:
+		return ([]byte)("\"KEYWORD\""), nil
+	case 2:
+		return ([]byte)("\"INT\""), nil
+	case 3:
+		return ([]byte)("\"DOUBLE\""), nil
+	case 4:
+		return ([]byte)("\"BOOL\""), nil
+	case 5:
+		return ([]byte)("\"DATETIME\""), nil
+	}
+	return ([]byte)(strconv.FormatInt(int64(v), 10)), nil
+}
+
+// UnmarshalJSON attempts to decode IndexedValueType from its JSON
+// representation.
+//
+// This implementation supports both, numeric and string inputs. If a
+// string is provided, it must be a known enum name.
+//
+// This implements json.Unmarshaler.
+func (v *IndexedValueType) UnmarshalJSON(text []byte) error {
+	d := json.NewDecoder(bytes.NewReader(text))
+	d.UseNumber()
+	t, err := d.Token()
+	if err != nil {
+		return err
+	}
+
+	switch w := t.(type) {
+	case json.Number:
+		x, err := w.Int64()
+		if err != nil {
+			return err
+		}
+		if x > math.MaxInt32 {
+			return fmt.Errorf("enum overflow from JSON %q for %q", text, "IndexedValueType")
+		}
+		if x < math.MinInt32 {
+			return fmt.Errorf("enum underflow from JSON %q for %q", text, "IndexedValueType")
+		}
+		*v = (IndexedValueType)(x)
+		return nil
+	case string:
+		return v.UnmarshalText([]byte(w))
+	default:
+		return fmt.Errorf("invalid JSON value %q (%T) to unmarshal into %q", t, t, "IndexedValueType")
+	}
+}
+
 type InternalServiceError struct {
 	Message string `json:"message,required"`
 }
@@ -20289,6 +22615,188 @@ func (v *InternalServiceError) Error() string {
 	return v.String()
 }
 
+type LabelFilter struct {
+	Key   *string `json:"key,omitempty"`
+	Value *string `json:"value,omitempty"`
+}
+
+// ToWire translates a LabelFilter struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *LabelFilter) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Key != nil {
+		w, err = wire.NewValueString(*(v.Key)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.Value != nil {
+		w, err = wire.NewValueString(*(v.Value)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a LabelFilter struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a LabelFilter struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v LabelFilter
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *LabelFilter) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Key = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Value = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a LabelFilter
+// struct.
+func (v *LabelFilter) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [2]string
+	i := 0
+	if v.Key != nil {
+		fields[i] = fmt.Sprintf("Key: %v", *(v.Key))
+		i++
+	}
+	if v.Value != nil {
+		fields[i] = fmt.Sprintf("Value: %v", *(v.Value))
+		i++
+	}
+
+	return fmt.Sprintf("LabelFilter{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this LabelFilter match the
+// provided LabelFilter.
+//
+// This function performs a deep comparison.
+func (v *LabelFilter) Equals(rhs *LabelFilter) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_String_EqualsPtr(v.Key, rhs.Key) {
+		return false
+	}
+	if !_String_EqualsPtr(v.Value, rhs.Value) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of LabelFilter.
+func (v *LabelFilter) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Key != nil {
+		enc.AddString("key", *v.Key)
+	}
+	if v.Value != nil {
+		enc.AddString("value", *v.Value)
+	}
+	return err
+}
+
+// GetKey returns the value of Key if it is set or its
+// zero value if it is unset.
+func (v *LabelFilter) GetKey() (o string) {
+	if v != nil && v.Key != nil {
+		return *v.Key
+	}
+
+	return
+}
+
+// IsSetKey returns true if Key is not nil.
+func (v *LabelFilter) IsSetKey() bool {
+	return v != nil && v.Key != nil
+}
+
+// GetValue returns the value of Value if it is set or its
+// zero value if it is unset.
+func (v *LabelFilter) GetValue() (o string) {
+	if v != nil && v.Value != nil {
+		return *v.Value
+	}
+
+	return
+}
+
+// IsSetValue returns true if Value is not nil.
+func (v *LabelFilter) IsSetValue() bool {
+	return v != nil && v.Value != nil
+}
+
 type LimitExceededError struct {
 	Message string `json:"message,required"`
 }
@@ -20431,6 +22939,7 @@ type ListClosedWorkflowExecutionsRequest struct {
 	ExecutionFilter *WorkflowExecutionFilter      `json:"executionFilter,omitempty"`
 	TypeFilter      *WorkflowTypeFilter           `json:"typeFilter,omitempty"`
 	StatusFilter    *WorkflowExecutionCloseStatus `json:"statusFilter,omitempty"`
+	LabelFilter     *LabelFilter                  `json:"labelFilter,omitempty"`
 }
 
 // ToWire translates a ListClosedWorkflowExecutionsRequest struct into a Thrift-level intermediate
@@ -20450,7 +22959,7 @@ type ListClosedWorkflowExecutionsRequest struct {
 //   }
 func (v *ListClosedWorkflowExecutionsRequest) ToWire() (wire.Value, error) {
 	var (
-		fields [7]wire.Field
+		fields [8]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -20512,6 +23021,14 @@ func (v *ListClosedWorkflowExecutionsRequest) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 70, Value: w}
 		i++
 	}
+	if v.LabelFilter != nil {
+		w, err = v.LabelFilter.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 80, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -20540,6 +23057,12 @@ func _WorkflowExecutionCloseStatus_Read(w wire.Value) (WorkflowExecutionCloseSta
 	return v, err
 }
 
+func _LabelFilter_Read(w wire.Value) (*LabelFilter, error) {
+	var v LabelFilter
+	err := v.FromWire(w)
+	return &v, err
+}
+
 // FromWire deserializes a ListClosedWorkflowExecutionsRequest struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
@@ -20623,6 +23146,14 @@ func (v *ListClosedWorkflowExecutionsRequest) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 80:
+			if field.Value.Type() == wire.TStruct {
+				v.LabelFilter, err = _LabelFilter_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -20637,7 +23168,7 @@ func (v *ListClosedWorkflowExecutionsRequest) String() string {
 		return "<nil>"
 	}
 
-	var fields [7]string
+	var fields [8]string
 	i := 0
 	if v.Domain != nil {
 		fields[i] = fmt.Sprintf("Domain: %v", *(v.Domain))
@@ -20667,6 +23198,10 @@ func (v *ListClosedWorkflowExecutionsRequest) String() string {
 		fields[i] = fmt.Sprintf("StatusFilter: %v", *(v.StatusFilter))
 		i++
 	}
+	if v.LabelFilter != nil {
+		fields[i] = fmt.Sprintf("LabelFilter: %v", v.LabelFilter)
+		i++
+	}
 
 	return fmt.Sprintf("ListClosedWorkflowExecutionsRequest{%v}", strings.Join(fields[:i], ", "))
 }
@@ -20712,6 +23247,9 @@ func (v *ListClosedWorkflowExecutionsRequest) Equals(rhs *ListClosedWorkflowExec
 	if !_WorkflowExecutionCloseStatus_EqualsPtr(v.StatusFilter, rhs.StatusFilter) {
 		return false
 	}
+	if !((v.LabelFilter == nil && rhs.LabelFilter == nil) || (v.LabelFilter != nil && rhs.LabelFilter != nil && v.LabelFilter.Equals(rhs.LabelFilter))) {
+		return false
+	}
 
 	return true
 }
@@ -20743,6 +23281,9 @@ func (v *ListClosedWorkflowExecutionsRequest) MarshalLogObject(enc zapcore.Objec
 	if v.StatusFilter != nil {
 		err = multierr.Append(err, enc.AddObject("statusFilter", *v.StatusFilter))
 	}
+	if v.LabelFilter != nil {
+		err = multierr.Append(err, enc.AddObject("labelFilter", v.LabelFilter))
+	}
 	return err
 }
 
@@ -20851,6 +23392,21 @@ func (v *ListClosedWorkflowExecutionsRequest) IsSetStatusFilter() bool {
 	return v != nil && v.StatusFilter != nil
 }
 
+// GetLabelFilter returns the value of LabelFilter if it is set or its
+// zero value if it is unset.
+func (v *ListClosedWorkflowExecutionsRequest) GetLabelFilter() (o *LabelFilter) {
+	if v != nil && v.LabelFilter != nil {
+		return v.LabelFilter
+	}
+
+	return
+}
+
+// IsSetLabelFilter returns true if LabelFilter is not nil.
+func (v *ListClosedWorkflowExecutionsRequest) IsSetLabelFilter() bool {
+	return v != nil && v.LabelFilter != nil
+}
+
 type ListClosedWorkflowExecutionsResponse struct {
 	Executions    []*WorkflowExecutionInfo `json:"executions,omitempty"`
 	NextPageToken []byte                   `json:"nextPageToken,omitempty"`
@@ -21546,6 +24102,7 @@ type ListOpenWorkflowExecutionsRequest struct {
 	StartTimeFilter *StartTimeFilter         `json:"StartTimeFilter,omitempty"`
 	ExecutionFilter *WorkflowExecutionFilter `json:"executionFilter,omitempty"`
 	TypeFilter      *WorkflowTypeFilter      `json:"typeFilter,omitempty"`
+	LabelFilter     *LabelFilter             `json:"labelFilter,omitempty"`
 }
 
 // ToWire translates a ListOpenWorkflowExecutionsRequest struct into a Thrift-level intermediate
@@ -21565,7 +24122,7 @@ type ListOpenWorkflowExecutionsRequest struct {
 //   }
 func (v *ListOpenWorkflowExecutionsRequest) ToWire() (wire.Value, error) {
 	var (
-		fields [6]wire.Field
+		fields [7]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -21619,6 +24176,14 @@ func (v *ListOpenWorkflowExecutionsRequest) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 60, Value: w}
 		i++
 	}
+	if v.LabelFilter != nil {
+		w, err = v.LabelFilter.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 70, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -21696,6 +24261,14 @@ func (v *ListOpenWorkflowExecutionsRequest) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 70:
+			if field.Value.Type() == wire.TStruct {
+				v.LabelFilter, err = _LabelFilter_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -21710,7 +24283,7 @@ func (v *ListOpenWorkflowExecutionsRequest) String() string {
 		return "<nil>"
 	}
 
-	var fields [6]string
+	var fields [7]string
 	i := 0
 	if v.Domain != nil {
 		fields[i] = fmt.Sprintf("Domain: %v", *(v.Domain))
@@ -21736,6 +24309,10 @@ func (v *ListOpenWorkflowExecutionsRequest) String() string {
 		fields[i] = fmt.Sprintf("TypeFilter: %v", v.TypeFilter)
 		i++
 	}
+	if v.LabelFilter != nil {
+		fields[i] = fmt.Sprintf("LabelFilter: %v", v.LabelFilter)
+		i++
+	}
 
 	return fmt.Sprintf("ListOpenWorkflowExecutionsRequest{%v}", strings.Join(fields[:i], ", "))
 }
@@ -21768,6 +24345,9 @@ func (v *ListOpenWorkflowExecutionsRequest) Equals(rhs *ListOpenWorkflowExecutio
 	if !((v.TypeFilter == nil && rhs.TypeFilter == nil) || (v.TypeFilter != nil && rhs.TypeFilter != nil && v.TypeFilter.Equals(rhs.TypeFilter))) {
 		return false
 	}
+	if !((v.LabelFilter == nil && rhs.LabelFilter == nil) || (v.LabelFilter != nil && rhs.LabelFilter != nil && v.LabelFilter.Equals(rhs.LabelFilter))) {
+		return false
+	}
 
 	return true
 }
@@ -21796,6 +24376,9 @@ func (v *ListOpenWorkflowExecutionsRequest) MarshalLogObject(enc zapcore.ObjectE
 	if v.TypeFilter != nil {
 		err = multierr.Append(err, enc.AddObject("typeFilter", v.TypeFilter))
 	}
+	if v.LabelFilter != nil {
+		err = multierr.Append(err, enc.AddObject("labelFilter", v.LabelFilter))
+	}
 	return err
 }
 
@@ -21889,6 +24472,21 @@ func (v *ListOpenWorkflowExecutionsRequest) IsSetTypeFilter() bool {
 	return v != nil && v.TypeFilter != nil
 }
 
+// GetLabelFilter returns the value of LabelFilter if it is set or its
+// zero value if it is unset.
+func (v *ListOpenWorkflowExecutionsRequest) GetLabelFilter() (o *LabelFilter) {
+	if v != nil && v.LabelFilter != nil {
+		return v.LabelFilter
+	}
+
+	return
+}
+
+// IsSetLabelFilter returns true if LabelFilter is not nil.
+func (v *ListOpenWorkflowExecutionsRequest) IsSetLabelFilter() bool {
+	return v != nil && v.LabelFilter != nil
+}
+
 type ListOpenWorkflowExecutionsResponse struct {
 	Executions    []*WorkflowExecutionInfo `json:"executions,omitempty"`
 	NextPageToken []byte                   `json:"nextPageToken,omitempty"`
@@ -22350,6 +24948,8 @@ type PendingActivityInfo struct {
 	MaximumAttempts        *int32                `json:"maximumAttempts,omitempty"`
 	ScheduledTimestamp     *int64                `json:"scheduledTimestamp,omitempty"`
 	ExpirationTimestamp    *int64                `json:"expirationTimestamp,omitempty"`
+	LastFailureReason      *string               `json:"lastFailureReason,omitempty"`
+	LastWorkerIdentity     *string               `json:"lastWorkerIdentity,omitempty"`
 }
 
 // ToWire translates a PendingActivityInfo struct into a Thrift-level intermediate
@@ -22369,7 +24969,7 @@ type PendingActivityInfo struct {
 //   }
 func (v *PendingActivityInfo) ToWire() (wire.Value, error) {
 	var (
-		fields [10]wire.Field
+		fields [12]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -22455,6 +25055,22 @@ func (v *PendingActivityInfo) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 100, Value: w}
 		i++
 	}
+	if v.LastFailureReason != nil {
+		w, err = wire.NewValueString(*(v.LastFailureReason)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 110, Value: w}
+		i++
+	}
+	if v.LastWorkerIdentity != nil {
+		w, err = wire.NewValueString(*(v.LastWorkerIdentity)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 120, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -22582,6 +25198,26 @@ func (v *PendingActivityInfo) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 110:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.LastFailureReason = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 120:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.LastWorkerIdentity = &x
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -22596,7 +25232,7 @@ func (v *PendingActivityInfo) String() string {
 		return "<nil>"
 	}
 
-	var fields [10]string
+	var fields [12]string
 	i := 0
 	if v.ActivityID != nil {
 		fields[i] = fmt.Sprintf("ActivityID: %v", *(v.ActivityID))
@@ -22638,6 +25274,14 @@ func (v *PendingActivityInfo) String() string {
 		fields[i] = fmt.Sprintf("ExpirationTimestamp: %v", *(v.ExpirationTimestamp))
 		i++
 	}
+	if v.LastFailureReason != nil {
+		fields[i] = fmt.Sprintf("LastFailureReason: %v", *(v.LastFailureReason))
+		i++
+	}
+	if v.LastWorkerIdentity != nil {
+		fields[i] = fmt.Sprintf("LastWorkerIdentity: %v", *(v.LastWorkerIdentity))
+		i++
+	}
 
 	return fmt.Sprintf("PendingActivityInfo{%v}", strings.Join(fields[:i], ", "))
 }
@@ -22692,6 +25336,12 @@ func (v *PendingActivityInfo) Equals(rhs *PendingActivityInfo) bool {
 	if !_I64_EqualsPtr(v.ExpirationTimestamp, rhs.ExpirationTimestamp) {
 		return false
 	}
+	if !_String_EqualsPtr(v.LastFailureReason, rhs.LastFailureReason) {
+		return false
+	}
+	if !_String_EqualsPtr(v.LastWorkerIdentity, rhs.LastWorkerIdentity) {
+		return false
+	}
 
 	return true
 }
@@ -22732,6 +25382,12 @@ func (v *PendingActivityInfo) MarshalLogObject(enc zapcore.ObjectEncoder) (err e
 	if v.ExpirationTimestamp != nil {
 		enc.AddInt64("expirationTimestamp", *v.ExpirationTimestamp)
 	}
+	if v.LastFailureReason != nil {
+		enc.AddString("lastFailureReason", *v.LastFailureReason)
+	}
+	if v.LastWorkerIdentity != nil {
+		enc.AddString("lastWorkerIdentity", *v.LastWorkerIdentity)
+	}
 	return err
 }
 
@@ -22885,6 +25541,36 @@ func (v *PendingActivityInfo) IsSetExpirationTimestamp() bool {
 	return v != nil && v.ExpirationTimestamp != nil
 }
 
+// GetLastFailureReason returns the value of LastFailureReason if it is set or its
+// zero value if it is unset.
+func (v *PendingActivityInfo) GetLastFailureReason() (o string) {
+	if v != nil && v.LastFailureReason != nil {
+		return *v.LastFailureReason
+	}
+
+	return
+}
+
+// IsSetLastFailureReason returns true if LastFailureReason is not nil.
+func (v *PendingActivityInfo) IsSetLastFailureReason() bool {
+	return v != nil && v.LastFailureReason != nil
+}
+
+// GetLastWorkerIdentity returns the value of LastWorkerIdentity if it is set or its
+// zero value if it is unset.
+func (v *PendingActivityInfo) GetLastWorkerIdentity() (o string) {
+	if v != nil && v.LastWorkerIdentity != nil {
+		return *v.LastWorkerIdentity
+	}
+
+	return
+}
+
+// IsSetLastWorkerIdentity returns true if LastWorkerIdentity is not nil.
+func (v *PendingActivityInfo) IsSetLastWorkerIdentity() bool {
+	return v != nil && v.LastWorkerIdentity != nil
+}
+
 type PendingActivityState int32
 
 const (
@@ -23069,14 +25755,15 @@ func (v *PendingActivityState) UnmarshalJSON(text []byte) error {
 	}
 }
 
-type PollForActivityTaskRequest struct {
-	Domain           *string           `json:"domain,omitempty"`
-	TaskList         *TaskList         `json:"taskList,omitempty"`
-	Identity         *string           `json:"identity,omitempty"`
-	TaskListMetadata *TaskListMetadata `json:"taskListMetadata,omitempty"`
+type PendingChildExecutionInfo struct {
+	WorkflowID      *string `json:"workflowID,omitempty"`
+	RunID           *string `json:"runID,omitempty"`
+	WorkflowTypName *string `json:"workflowTypName,omitempty"`
+	InitiatedID     *int64  `json:"initiatedID,omitempty"`
+	Domain          *string `json:"domain,omitempty"`
 }
 
-// ToWire translates a PollForActivityTaskRequest struct into a Thrift-level intermediate
+// ToWire translates a PendingChildExecutionInfo struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -23091,61 +25778,63 @@ type PollForActivityTaskRequest struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *PollForActivityTaskRequest) ToWire() (wire.Value, error) {
+func (v *PendingChildExecutionInfo) ToWire() (wire.Value, error) {
 	var (
-		fields [4]wire.Field
+		fields [5]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
 	)
 
-	if v.Domain != nil {
-		w, err = wire.NewValueString(*(v.Domain)), error(nil)
+	if v.WorkflowID != nil {
+		w, err = wire.NewValueString(*(v.WorkflowID)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.TaskList != nil {
-		w, err = v.TaskList.ToWire()
+	if v.RunID != nil {
+		w, err = wire.NewValueString(*(v.RunID)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
-	if v.Identity != nil {
-		w, err = wire.NewValueString(*(v.Identity)), error(nil)
+	if v.WorkflowTypName != nil {
+		w, err = wire.NewValueString(*(v.WorkflowTypName)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 30, Value: w}
 		i++
 	}
-	if v.TaskListMetadata != nil {
-		w, err = v.TaskListMetadata.ToWire()
+	if v.InitiatedID != nil {
+		w, err = wire.NewValueI64(*(v.InitiatedID)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 40, Value: w}
 		i++
 	}
+	if v.Domain != nil {
+		w, err = wire.NewValueString(*(v.Domain)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 50, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _TaskListMetadata_Read(w wire.Value) (*TaskListMetadata, error) {
-	var v TaskListMetadata
-	err := v.FromWire(w)
-	return &v, err
-}
-
-// FromWire deserializes a PollForActivityTaskRequest struct from its Thrift-level
+// FromWire deserializes a PendingChildExecutionInfo struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a PollForActivityTaskRequest struct
+// An error is returned if we were unable to build a PendingChildExecutionInfo struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -23153,12 +25842,12 @@ func _TaskListMetadata_Read(w wire.Value) (*TaskListMetadata, error) {
 //     return nil, err
 //   }
 //
-//   var v PollForActivityTaskRequest
+//   var v PendingChildExecutionInfo
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *PollForActivityTaskRequest) FromWire(w wire.Value) error {
+func (v *PendingChildExecutionInfo) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
@@ -23167,15 +25856,17 @@ func (v *PollForActivityTaskRequest) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TBinary {
 				var x string
 				x, err = field.Value.GetString(), error(nil)
-				v.Domain = &x
+				v.WorkflowID = &x
 				if err != nil {
 					return err
 				}
 
 			}
 		case 20:
-			if field.Value.Type() == wire.TStruct {
-				v.TaskList, err = _TaskList_Read(field.Value)
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.RunID = &x
 				if err != nil {
 					return err
 				}
@@ -23185,15 +25876,27 @@ func (v *PollForActivityTaskRequest) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TBinary {
 				var x string
 				x, err = field.Value.GetString(), error(nil)
-				v.Identity = &x
+				v.WorkflowTypName = &x
 				if err != nil {
 					return err
 				}
 
 			}
 		case 40:
-			if field.Value.Type() == wire.TStruct {
-				v.TaskListMetadata, err = _TaskListMetadata_Read(field.Value)
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.InitiatedID = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 50:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Domain = &x
 				if err != nil {
 					return err
 				}
@@ -23205,55 +25908,62 @@ func (v *PollForActivityTaskRequest) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a PollForActivityTaskRequest
+// String returns a readable string representation of a PendingChildExecutionInfo
 // struct.
-func (v *PollForActivityTaskRequest) String() string {
+func (v *PendingChildExecutionInfo) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [4]string
+	var fields [5]string
 	i := 0
-	if v.Domain != nil {
-		fields[i] = fmt.Sprintf("Domain: %v", *(v.Domain))
+	if v.WorkflowID != nil {
+		fields[i] = fmt.Sprintf("WorkflowID: %v", *(v.WorkflowID))
 		i++
 	}
-	if v.TaskList != nil {
-		fields[i] = fmt.Sprintf("TaskList: %v", v.TaskList)
+	if v.RunID != nil {
+		fields[i] = fmt.Sprintf("RunID: %v", *(v.RunID))
 		i++
 	}
-	if v.Identity != nil {
-		fields[i] = fmt.Sprintf("Identity: %v", *(v.Identity))
+	if v.WorkflowTypName != nil {
+		fields[i] = fmt.Sprintf("WorkflowTypName: %v", *(v.WorkflowTypName))
 		i++
 	}
-	if v.TaskListMetadata != nil {
-		fields[i] = fmt.Sprintf("TaskListMetadata: %v", v.TaskListMetadata)
+	if v.InitiatedID != nil {
+		fields[i] = fmt.Sprintf("InitiatedID: %v", *(v.InitiatedID))
+		i++
+	}
+	if v.Domain != nil {
+		fields[i] = fmt.Sprintf("Domain: %v", *(v.Domain))
 		i++
 	}
 
-	return fmt.Sprintf("PollForActivityTaskRequest{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("PendingChildExecutionInfo{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this PollForActivityTaskRequest match the
-// provided PollForActivityTaskRequest.
+// Equals returns true if all the fields of this PendingChildExecutionInfo match the
+// provided PendingChildExecutionInfo.
 //
 // This function performs a deep comparison.
-func (v *PollForActivityTaskRequest) Equals(rhs *PollForActivityTaskRequest) bool {
+func (v *PendingChildExecutionInfo) Equals(rhs *PendingChildExecutionInfo) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
 		return false
 	}
-	if !_String_EqualsPtr(v.Domain, rhs.Domain) {
+	if !_String_EqualsPtr(v.WorkflowID, rhs.WorkflowID) {
 		return false
 	}
-	if !((v.TaskList == nil && rhs.TaskList == nil) || (v.TaskList != nil && rhs.TaskList != nil && v.TaskList.Equals(rhs.TaskList))) {
+	if !_String_EqualsPtr(v.RunID, rhs.RunID) {
 		return false
 	}
-	if !_String_EqualsPtr(v.Identity, rhs.Identity) {
+	if !_String_EqualsPtr(v.WorkflowTypName, rhs.WorkflowTypName) {
 		return false
 	}
-	if !((v.TaskListMetadata == nil && rhs.TaskListMetadata == nil) || (v.TaskListMetadata != nil && rhs.TaskListMetadata != nil && v.TaskListMetadata.Equals(rhs.TaskListMetadata))) {
+	if !_I64_EqualsPtr(v.InitiatedID, rhs.InitiatedID) {
+		return false
+	}
+	if !_String_EqualsPtr(v.Domain, rhs.Domain) {
 		return false
 	}
 
@@ -23261,105 +25971,112 @@ func (v *PollForActivityTaskRequest) Equals(rhs *PollForActivityTaskRequest) boo
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of PollForActivityTaskRequest.
-func (v *PollForActivityTaskRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of PendingChildExecutionInfo.
+func (v *PendingChildExecutionInfo) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
-	if v.Domain != nil {
-		enc.AddString("domain", *v.Domain)
+	if v.WorkflowID != nil {
+		enc.AddString("workflowID", *v.WorkflowID)
 	}
-	if v.TaskList != nil {
-		err = multierr.Append(err, enc.AddObject("taskList", v.TaskList))
+	if v.RunID != nil {
+		enc.AddString("runID", *v.RunID)
 	}
-	if v.Identity != nil {
-		enc.AddString("identity", *v.Identity)
+	if v.WorkflowTypName != nil {
+		enc.AddString("workflowTypName", *v.WorkflowTypName)
 	}
-	if v.TaskListMetadata != nil {
-		err = multierr.Append(err, enc.AddObject("taskListMetadata", v.TaskListMetadata))
+	if v.InitiatedID != nil {
+		enc.AddInt64("initiatedID", *v.InitiatedID)
+	}
+	if v.Domain != nil {
+		enc.AddString("domain", *v.Domain)
 	}
 	return err
 }
 
-// GetDomain returns the value of Domain if it is set or its
+// GetWorkflowID returns the value of WorkflowID if it is set or its
 // zero value if it is unset.
-func (v *PollForActivityTaskRequest) GetDomain() (o string) {
-	if v != nil && v.Domain != nil {
-		return *v.Domain
+func (v *PendingChildExecutionInfo) GetWorkflowID() (o string) {
+	if v != nil && v.WorkflowID != nil {
+		return *v.WorkflowID
 	}
 
 	return
 }
 
-// IsSetDomain returns true if Domain is not nil.
-func (v *PollForActivityTaskRequest) IsSetDomain() bool {
-	return v != nil && v.Domain != nil
+// IsSetWorkflowID returns true if WorkflowID is not nil.
+func (v *PendingChildExecutionInfo) IsSetWorkflowID() bool {
+	return v != nil && v.WorkflowID != nil
 }
 
-// GetTaskList returns the value of TaskList if it is set or its
+// GetRunID returns the value of RunID if it is set or its
 // zero value if it is unset.
-func (v *PollForActivityTaskRequest) GetTaskList() (o *TaskList) {
-	if v != nil && v.TaskList != nil {
-		return v.TaskList
+func (v *PendingChildExecutionInfo) GetRunID() (o string) {
+	if v != nil && v.RunID != nil {
+		return *v.RunID
 	}
 
 	return
 }
 
-// IsSetTaskList returns true if TaskList is not nil.
-func (v *PollForActivityTaskRequest) IsSetTaskList() bool {
-	return v != nil && v.TaskList != nil
+// IsSetRunID returns true if RunID is not nil.
+func (v *PendingChildExecutionInfo) IsSetRunID() bool {
+	return v != nil && v.RunID != nil
 }
 
-// GetIdentity returns the value of Identity if it is set or its
+// GetWorkflowTypName returns the value of WorkflowTypName if it is set or its
 // zero value if it is unset.
-func (v *PollForActivityTaskRequest) GetIdentity() (o string) {
-	if v != nil && v.Identity != nil {
-		return *v.Identity
+func (v *PendingChildExecutionInfo) GetWorkflowTypName() (o string) {
+	if v != nil && v.WorkflowTypName != nil {
+		return *v.WorkflowTypName
 	}
 
 	return
 }
 
-// IsSetIdentity returns true if Identity is not nil.
-func (v *PollForActivityTaskRequest) IsSetIdentity() bool {
-	return v != nil && v.Identity != nil
+// IsSetWorkflowTypName returns true if WorkflowTypName is not nil.
+func (v *PendingChildExecutionInfo) IsSetWorkflowTypName() bool {
+	return v != nil && v.WorkflowTypName != nil
 }
 
-// GetTaskListMetadata returns the value of TaskListMetadata if it is set or its
+// GetInitiatedID returns the value of InitiatedID if it is set or its
 // zero value if it is unset.
-func (v *PollForActivityTaskRequest) GetTaskListMetadata() (o *TaskListMetadata) {
-	if v != nil && v.TaskListMetadata != nil {
-		return v.TaskListMetadata
+func (v *PendingChildExecutionInfo) GetInitiatedID() (o int64) {
+	if v != nil && v.InitiatedID != nil {
+		return *v.InitiatedID
 	}
 
 	return
 }
 
-// IsSetTaskListMetadata returns true if TaskListMetadata is not nil.
-func (v *PollForActivityTaskRequest) IsSetTaskListMetadata() bool {
-	return v != nil && v.TaskListMetadata != nil
+// IsSetInitiatedID returns true if InitiatedID is not nil.
+func (v *PendingChildExecutionInfo) IsSetInitiatedID() bool {
+	return v != nil && v.InitiatedID != nil
 }
 
-type PollForActivityTaskResponse struct {
-	TaskToken                       []byte             `json:"taskToken,omitempty"`
-	WorkflowExecution               *WorkflowExecution `json:"workflowExecution,omitempty"`
-	ActivityId                      *string            `json:"activityId,omitempty"`
-	ActivityType                    *ActivityType      `json:"activityType,omitempty"`
-	Input                           []byte             `json:"input,omitempty"`
-	ScheduledTimestamp              *int64             `json:"scheduledTimestamp,omitempty"`
-	ScheduleToCloseTimeoutSeconds   *int32             `json:"scheduleToCloseTimeoutSeconds,omitempty"`
-	StartedTimestamp                *int64             `json:"startedTimestamp,omitempty"`
-	StartToCloseTimeoutSeconds      *int32             `json:"startToCloseTimeoutSeconds,omitempty"`
-	HeartbeatTimeoutSeconds         *int32             `json:"heartbeatTimeoutSeconds,omitempty"`
-	Attempt                         *int32             `json:"attempt,omitempty"`
-	ScheduledTimestampOfThisAttempt *int64             `json:"scheduledTimestampOfThisAttempt,omitempty"`
-	HeartbeatDetails                []byte             `json:"heartbeatDetails,omitempty"`
-	WorkflowType                    *WorkflowType      `json:"workflowType,omitempty"`
-	WorkflowDomain                  *string            `json:"workflowDomain,omitempty"`
+// GetDomain returns the value of Domain if it is set or its
+// zero value if it is unset.
+func (v *PendingChildExecutionInfo) GetDomain() (o string) {
+	if v != nil && v.Domain != nil {
+		return *v.Domain
+	}
+
+	return
 }
 
-// ToWire translates a PollForActivityTaskResponse struct into a Thrift-level intermediate
+// IsSetDomain returns true if Domain is not nil.
+func (v *PendingChildExecutionInfo) IsSetDomain() bool {
+	return v != nil && v.Domain != nil
+}
+
+type PendingDecisionInfo struct {
+	State            *PendingDecisionState `json:"state,omitempty"`
+	ScheduledEventId *int64                `json:"scheduledEventId,omitempty"`
+	StartedTimestamp *int64                `json:"startedTimestamp,omitempty"`
+	Attempt          *int64                `json:"attempt,omitempty"`
+}
+
+// ToWire translates a PendingDecisionInfo struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -23374,143 +26091,61 @@ type PollForActivityTaskResponse struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *PollForActivityTaskResponse) ToWire() (wire.Value, error) {
+func (v *PendingDecisionInfo) ToWire() (wire.Value, error) {
 	var (
-		fields [15]wire.Field
+		fields [4]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
 	)
 
-	if v.TaskToken != nil {
-		w, err = wire.NewValueBinary(v.TaskToken), error(nil)
+	if v.State != nil {
+		w, err = v.State.ToWire()
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.WorkflowExecution != nil {
-		w, err = v.WorkflowExecution.ToWire()
+	if v.ScheduledEventId != nil {
+		w, err = wire.NewValueI64(*(v.ScheduledEventId)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
-	if v.ActivityId != nil {
-		w, err = wire.NewValueString(*(v.ActivityId)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 30, Value: w}
-		i++
-	}
-	if v.ActivityType != nil {
-		w, err = v.ActivityType.ToWire()
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 40, Value: w}
-		i++
-	}
-	if v.Input != nil {
-		w, err = wire.NewValueBinary(v.Input), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 50, Value: w}
-		i++
-	}
-	if v.ScheduledTimestamp != nil {
-		w, err = wire.NewValueI64(*(v.ScheduledTimestamp)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 70, Value: w}
-		i++
-	}
-	if v.ScheduleToCloseTimeoutSeconds != nil {
-		w, err = wire.NewValueI32(*(v.ScheduleToCloseTimeoutSeconds)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 80, Value: w}
-		i++
-	}
 	if v.StartedTimestamp != nil {
 		w, err = wire.NewValueI64(*(v.StartedTimestamp)), error(nil)
 		if err != nil {
 			return w, err
 		}
-		fields[i] = wire.Field{ID: 90, Value: w}
-		i++
-	}
-	if v.StartToCloseTimeoutSeconds != nil {
-		w, err = wire.NewValueI32(*(v.StartToCloseTimeoutSeconds)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 100, Value: w}
-		i++
-	}
-	if v.HeartbeatTimeoutSeconds != nil {
-		w, err = wire.NewValueI32(*(v.HeartbeatTimeoutSeconds)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 110, Value: w}
+		fields[i] = wire.Field{ID: 30, Value: w}
 		i++
 	}
 	if v.Attempt != nil {
-		w, err = wire.NewValueI32(*(v.Attempt)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 120, Value: w}
-		i++
-	}
-	if v.ScheduledTimestampOfThisAttempt != nil {
-		w, err = wire.NewValueI64(*(v.ScheduledTimestampOfThisAttempt)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 130, Value: w}
-		i++
-	}
-	if v.HeartbeatDetails != nil {
-		w, err = wire.NewValueBinary(v.HeartbeatDetails), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 140, Value: w}
-		i++
-	}
-	if v.WorkflowType != nil {
-		w, err = v.WorkflowType.ToWire()
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 150, Value: w}
-		i++
-	}
-	if v.WorkflowDomain != nil {
-		w, err = wire.NewValueString(*(v.WorkflowDomain)), error(nil)
+		w, err = wire.NewValueI64(*(v.Attempt)), error(nil)
 		if err != nil {
 			return w, err
 		}
-		fields[i] = wire.Field{ID: 160, Value: w}
+		fields[i] = wire.Field{ID: 40, Value: w}
 		i++
 	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-// FromWire deserializes a PollForActivityTaskResponse struct from its Thrift-level
+func _PendingDecisionState_Read(w wire.Value) (PendingDecisionState, error) {
+	var v PendingDecisionState
+	err := v.FromWire(w)
+	return v, err
+}
+
+// FromWire deserializes a PendingDecisionInfo struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a PollForActivityTaskResponse struct
+// An error is returned if we were unable to build a PendingDecisionInfo struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -23518,79 +26153,37 @@ func (v *PollForActivityTaskResponse) ToWire() (wire.Value, error) {
 //     return nil, err
 //   }
 //
-//   var v PollForActivityTaskResponse
+//   var v PendingDecisionInfo
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *PollForActivityTaskResponse) FromWire(w wire.Value) error {
+func (v *PendingDecisionInfo) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
 		case 10:
-			if field.Value.Type() == wire.TBinary {
-				v.TaskToken, err = field.Value.GetBinary(), error(nil)
+			if field.Value.Type() == wire.TI32 {
+				var x PendingDecisionState
+				x, err = _PendingDecisionState_Read(field.Value)
+				v.State = &x
 				if err != nil {
 					return err
 				}
 
 			}
 		case 20:
-			if field.Value.Type() == wire.TStruct {
-				v.WorkflowExecution, err = _WorkflowExecution_Read(field.Value)
-				if err != nil {
-					return err
-				}
-
-			}
-		case 30:
-			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.ActivityId = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		case 40:
-			if field.Value.Type() == wire.TStruct {
-				v.ActivityType, err = _ActivityType_Read(field.Value)
-				if err != nil {
-					return err
-				}
-
-			}
-		case 50:
-			if field.Value.Type() == wire.TBinary {
-				v.Input, err = field.Value.GetBinary(), error(nil)
-				if err != nil {
-					return err
-				}
-
-			}
-		case 70:
 			if field.Value.Type() == wire.TI64 {
 				var x int64
 				x, err = field.Value.GetI64(), error(nil)
-				v.ScheduledTimestamp = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		case 80:
-			if field.Value.Type() == wire.TI32 {
-				var x int32
-				x, err = field.Value.GetI32(), error(nil)
-				v.ScheduleToCloseTimeoutSeconds = &x
+				v.ScheduledEventId = &x
 				if err != nil {
 					return err
 				}
 
 			}
-		case 90:
+		case 30:
 			if field.Value.Type() == wire.TI64 {
 				var x int64
 				x, err = field.Value.GetI64(), error(nil)
@@ -23600,67 +26193,11 @@ func (v *PollForActivityTaskResponse) FromWire(w wire.Value) error {
 				}
 
 			}
-		case 100:
-			if field.Value.Type() == wire.TI32 {
-				var x int32
-				x, err = field.Value.GetI32(), error(nil)
-				v.StartToCloseTimeoutSeconds = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		case 110:
-			if field.Value.Type() == wire.TI32 {
-				var x int32
-				x, err = field.Value.GetI32(), error(nil)
-				v.HeartbeatTimeoutSeconds = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		case 120:
-			if field.Value.Type() == wire.TI32 {
-				var x int32
-				x, err = field.Value.GetI32(), error(nil)
-				v.Attempt = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		case 130:
+		case 40:
 			if field.Value.Type() == wire.TI64 {
 				var x int64
 				x, err = field.Value.GetI64(), error(nil)
-				v.ScheduledTimestampOfThisAttempt = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		case 140:
-			if field.Value.Type() == wire.TBinary {
-				v.HeartbeatDetails, err = field.Value.GetBinary(), error(nil)
-				if err != nil {
-					return err
-				}
-
-			}
-		case 150:
-			if field.Value.Type() == wire.TStruct {
-				v.WorkflowType, err = _WorkflowType_Read(field.Value)
-				if err != nil {
-					return err
-				}
-
-			}
-		case 160:
-			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.WorkflowDomain = &x
+				v.Attempt = &x
 				if err != nil {
 					return err
 				}
@@ -23672,132 +26209,65 @@ func (v *PollForActivityTaskResponse) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a PollForActivityTaskResponse
+// String returns a readable string representation of a PendingDecisionInfo
 // struct.
-func (v *PollForActivityTaskResponse) String() string {
+func (v *PendingDecisionInfo) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [15]string
+	var fields [4]string
 	i := 0
-	if v.TaskToken != nil {
-		fields[i] = fmt.Sprintf("TaskToken: %v", v.TaskToken)
+	if v.State != nil {
+		fields[i] = fmt.Sprintf("State: %v", *(v.State))
 		i++
 	}
-	if v.WorkflowExecution != nil {
-		fields[i] = fmt.Sprintf("WorkflowExecution: %v", v.WorkflowExecution)
-		i++
-	}
-	if v.ActivityId != nil {
-		fields[i] = fmt.Sprintf("ActivityId: %v", *(v.ActivityId))
-		i++
-	}
-	if v.ActivityType != nil {
-		fields[i] = fmt.Sprintf("ActivityType: %v", v.ActivityType)
-		i++
-	}
-	if v.Input != nil {
-		fields[i] = fmt.Sprintf("Input: %v", v.Input)
-		i++
-	}
-	if v.ScheduledTimestamp != nil {
-		fields[i] = fmt.Sprintf("ScheduledTimestamp: %v", *(v.ScheduledTimestamp))
-		i++
-	}
-	if v.ScheduleToCloseTimeoutSeconds != nil {
-		fields[i] = fmt.Sprintf("ScheduleToCloseTimeoutSeconds: %v", *(v.ScheduleToCloseTimeoutSeconds))
+	if v.ScheduledEventId != nil {
+		fields[i] = fmt.Sprintf("ScheduledEventId: %v", *(v.ScheduledEventId))
 		i++
 	}
 	if v.StartedTimestamp != nil {
 		fields[i] = fmt.Sprintf("StartedTimestamp: %v", *(v.StartedTimestamp))
 		i++
 	}
-	if v.StartToCloseTimeoutSeconds != nil {
-		fields[i] = fmt.Sprintf("StartToCloseTimeoutSeconds: %v", *(v.StartToCloseTimeoutSeconds))
-		i++
-	}
-	if v.HeartbeatTimeoutSeconds != nil {
-		fields[i] = fmt.Sprintf("HeartbeatTimeoutSeconds: %v", *(v.HeartbeatTimeoutSeconds))
-		i++
-	}
 	if v.Attempt != nil {
 		fields[i] = fmt.Sprintf("Attempt: %v", *(v.Attempt))
 		i++
 	}
-	if v.ScheduledTimestampOfThisAttempt != nil {
-		fields[i] = fmt.Sprintf("ScheduledTimestampOfThisAttempt: %v", *(v.ScheduledTimestampOfThisAttempt))
-		i++
-	}
-	if v.HeartbeatDetails != nil {
-		fields[i] = fmt.Sprintf("HeartbeatDetails: %v", v.HeartbeatDetails)
-		i++
-	}
-	if v.WorkflowType != nil {
-		fields[i] = fmt.Sprintf("WorkflowType: %v", v.WorkflowType)
-		i++
-	}
-	if v.WorkflowDomain != nil {
-		fields[i] = fmt.Sprintf("WorkflowDomain: %v", *(v.WorkflowDomain))
-		i++
-	}
 
-	return fmt.Sprintf("PollForActivityTaskResponse{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("PendingDecisionInfo{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this PollForActivityTaskResponse match the
-// provided PollForActivityTaskResponse.
+func _PendingDecisionState_EqualsPtr(lhs, rhs *PendingDecisionState) bool {
+	if lhs != nil && rhs != nil {
+
+		x := *lhs
+		y := *rhs
+		return x.Equals(y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+// Equals returns true if all the fields of this PendingDecisionInfo match the
+// provided PendingDecisionInfo.
 //
 // This function performs a deep comparison.
-func (v *PollForActivityTaskResponse) Equals(rhs *PollForActivityTaskResponse) bool {
+func (v *PendingDecisionInfo) Equals(rhs *PendingDecisionInfo) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
 		return false
 	}
-	if !((v.TaskToken == nil && rhs.TaskToken == nil) || (v.TaskToken != nil && rhs.TaskToken != nil && bytes.Equal(v.TaskToken, rhs.TaskToken))) {
-		return false
-	}
-	if !((v.WorkflowExecution == nil && rhs.WorkflowExecution == nil) || (v.WorkflowExecution != nil && rhs.WorkflowExecution != nil && v.WorkflowExecution.Equals(rhs.WorkflowExecution))) {
-		return false
-	}
-	if !_String_EqualsPtr(v.ActivityId, rhs.ActivityId) {
-		return false
-	}
-	if !((v.ActivityType == nil && rhs.ActivityType == nil) || (v.ActivityType != nil && rhs.ActivityType != nil && v.ActivityType.Equals(rhs.ActivityType))) {
-		return false
-	}
-	if !((v.Input == nil && rhs.Input == nil) || (v.Input != nil && rhs.Input != nil && bytes.Equal(v.Input, rhs.Input))) {
-		return false
-	}
-	if !_I64_EqualsPtr(v.ScheduledTimestamp, rhs.ScheduledTimestamp) {
+	if !_PendingDecisionState_EqualsPtr(v.State, rhs.State) {
 		return false
 	}
-	if !_I32_EqualsPtr(v.ScheduleToCloseTimeoutSeconds, rhs.ScheduleToCloseTimeoutSeconds) {
+	if !_I64_EqualsPtr(v.ScheduledEventId, rhs.ScheduledEventId) {
 		return false
 	}
 	if !_I64_EqualsPtr(v.StartedTimestamp, rhs.StartedTimestamp) {
 		return false
 	}
-	if !_I32_EqualsPtr(v.StartToCloseTimeoutSeconds, rhs.StartToCloseTimeoutSeconds) {
-		return false
-	}
-	if !_I32_EqualsPtr(v.HeartbeatTimeoutSeconds, rhs.HeartbeatTimeoutSeconds) {
-		return false
-	}
-	if !_I32_EqualsPtr(v.Attempt, rhs.Attempt) {
-		return false
-	}
-	if !_I64_EqualsPtr(v.ScheduledTimestampOfThisAttempt, rhs.ScheduledTimestampOfThisAttempt) {
-		return false
-	}
-	if !((v.HeartbeatDetails == nil && rhs.HeartbeatDetails == nil) || (v.HeartbeatDetails != nil && rhs.HeartbeatDetails != nil && bytes.Equal(v.HeartbeatDetails, rhs.HeartbeatDetails))) {
-		return false
-	}
-	if !((v.WorkflowType == nil && rhs.WorkflowType == nil) || (v.WorkflowType != nil && rhs.WorkflowType != nil && v.WorkflowType.Equals(rhs.WorkflowType))) {
-		return false
-	}
-	if !_String_EqualsPtr(v.WorkflowDomain, rhs.WorkflowDomain) {
+	if !_I64_EqualsPtr(v.Attempt, rhs.Attempt) {
 		return false
 	}
 
@@ -23805,167 +26275,59 @@ func (v *PollForActivityTaskResponse) Equals(rhs *PollForActivityTaskResponse) b
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of PollForActivityTaskResponse.
-func (v *PollForActivityTaskResponse) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of PendingDecisionInfo.
+func (v *PendingDecisionInfo) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
-	if v.TaskToken != nil {
-		enc.AddString("taskToken", base64.StdEncoding.EncodeToString(v.TaskToken))
-	}
-	if v.WorkflowExecution != nil {
-		err = multierr.Append(err, enc.AddObject("workflowExecution", v.WorkflowExecution))
-	}
-	if v.ActivityId != nil {
-		enc.AddString("activityId", *v.ActivityId)
-	}
-	if v.ActivityType != nil {
-		err = multierr.Append(err, enc.AddObject("activityType", v.ActivityType))
-	}
-	if v.Input != nil {
-		enc.AddString("input", base64.StdEncoding.EncodeToString(v.Input))
-	}
-	if v.ScheduledTimestamp != nil {
-		enc.AddInt64("scheduledTimestamp", *v.ScheduledTimestamp)
+	if v.State != nil {
+		err = multierr.Append(err, enc.AddObject("state", *v.State))
 	}
-	if v.ScheduleToCloseTimeoutSeconds != nil {
-		enc.AddInt32("scheduleToCloseTimeoutSeconds", *v.ScheduleToCloseTimeoutSeconds)
+	if v.ScheduledEventId != nil {
+		enc.AddInt64("scheduledEventId", *v.ScheduledEventId)
 	}
 	if v.StartedTimestamp != nil {
 		enc.AddInt64("startedTimestamp", *v.StartedTimestamp)
 	}
-	if v.StartToCloseTimeoutSeconds != nil {
-		enc.AddInt32("startToCloseTimeoutSeconds", *v.StartToCloseTimeoutSeconds)
-	}
-	if v.HeartbeatTimeoutSeconds != nil {
-		enc.AddInt32("heartbeatTimeoutSeconds", *v.HeartbeatTimeoutSeconds)
-	}
 	if v.Attempt != nil {
-		enc.AddInt32("attempt", *v.Attempt)
-	}
-	if v.ScheduledTimestampOfThisAttempt != nil {
-		enc.AddInt64("scheduledTimestampOfThisAttempt", *v.ScheduledTimestampOfThisAttempt)
-	}
-	if v.HeartbeatDetails != nil {
-		enc.AddString("heartbeatDetails", base64.StdEncoding.EncodeToString(v.HeartbeatDetails))
-	}
-	if v.WorkflowType != nil {
-		err = multierr.Append(err, enc.AddObject("workflowType", v.WorkflowType))
-	}
-	if v.WorkflowDomain != nil {
-		enc.AddString("workflowDomain", *v.WorkflowDomain)
+		enc.AddInt64("attempt", *v.Attempt)
 	}
 	return err
 }
 
-// GetTaskToken returns the value of TaskToken if it is set or its
-// zero value if it is unset.
-func (v *PollForActivityTaskResponse) GetTaskToken() (o []byte) {
-	if v != nil && v.TaskToken != nil {
-		return v.TaskToken
-	}
-
-	return
-}
-
-// IsSetTaskToken returns true if TaskToken is not nil.
-func (v *PollForActivityTaskResponse) IsSetTaskToken() bool {
-	return v != nil && v.TaskToken != nil
-}
-
-// GetWorkflowExecution returns the value of WorkflowExecution if it is set or its
-// zero value if it is unset.
-func (v *PollForActivityTaskResponse) GetWorkflowExecution() (o *WorkflowExecution) {
-	if v != nil && v.WorkflowExecution != nil {
-		return v.WorkflowExecution
-	}
-
-	return
-}
-
-// IsSetWorkflowExecution returns true if WorkflowExecution is not nil.
-func (v *PollForActivityTaskResponse) IsSetWorkflowExecution() bool {
-	return v != nil && v.WorkflowExecution != nil
-}
-
-// GetActivityId returns the value of ActivityId if it is set or its
-// zero value if it is unset.
-func (v *PollForActivityTaskResponse) GetActivityId() (o string) {
-	if v != nil && v.ActivityId != nil {
-		return *v.ActivityId
-	}
-
-	return
-}
-
-// IsSetActivityId returns true if ActivityId is not nil.
-func (v *PollForActivityTaskResponse) IsSetActivityId() bool {
-	return v != nil && v.ActivityId != nil
-}
-
-// GetActivityType returns the value of ActivityType if it is set or its
-// zero value if it is unset.
-func (v *PollForActivityTaskResponse) GetActivityType() (o *ActivityType) {
-	if v != nil && v.ActivityType != nil {
-		return v.ActivityType
-	}
-
-	return
-}
-
-// IsSetActivityType returns true if ActivityType is not nil.
-func (v *PollForActivityTaskResponse) IsSetActivityType() bool {
-	return v != nil && v.ActivityType != nil
-}
-
-// GetInput returns the value of Input if it is set or its
-// zero value if it is unset.
-func (v *PollForActivityTaskResponse) GetInput() (o []byte) {
-	if v != nil && v.Input != nil {
-		return v.Input
-	}
-
-	return
-}
-
-// IsSetInput returns true if Input is not nil.
-func (v *PollForActivityTaskResponse) IsSetInput() bool {
-	return v != nil && v.Input != nil
-}
-
-// GetScheduledTimestamp returns the value of ScheduledTimestamp if it is set or its
+// GetState returns the value of State if it is set or its
 // zero value if it is unset.
-func (v *PollForActivityTaskResponse) GetScheduledTimestamp() (o int64) {
-	if v != nil && v.ScheduledTimestamp != nil {
-		return *v.ScheduledTimestamp
+func (v *PendingDecisionInfo) GetState() (o PendingDecisionState) {
+	if v != nil && v.State != nil {
+		return *v.State
 	}
 
 	return
 }
 
-// IsSetScheduledTimestamp returns true if ScheduledTimestamp is not nil.
-func (v *PollForActivityTaskResponse) IsSetScheduledTimestamp() bool {
-	return v != nil && v.ScheduledTimestamp != nil
+// IsSetState returns true if State is not nil.
+func (v *PendingDecisionInfo) IsSetState() bool {
+	return v != nil && v.State != nil
 }
 
-// GetScheduleToCloseTimeoutSeconds returns the value of ScheduleToCloseTimeoutSeconds if it is set or its
+// GetScheduledEventId returns the value of ScheduledEventId if it is set or its
 // zero value if it is unset.
-func (v *PollForActivityTaskResponse) GetScheduleToCloseTimeoutSeconds() (o int32) {
-	if v != nil && v.ScheduleToCloseTimeoutSeconds != nil {
-		return *v.ScheduleToCloseTimeoutSeconds
+func (v *PendingDecisionInfo) GetScheduledEventId() (o int64) {
+	if v != nil && v.ScheduledEventId != nil {
+		return *v.ScheduledEventId
 	}
 
 	return
 }
 
-// IsSetScheduleToCloseTimeoutSeconds returns true if ScheduleToCloseTimeoutSeconds is not nil.
-func (v *PollForActivityTaskResponse) IsSetScheduleToCloseTimeoutSeconds() bool {
-	return v != nil && v.ScheduleToCloseTimeoutSeconds != nil
+// IsSetScheduledEventId returns true if ScheduledEventId is not nil.
+func (v *PendingDecisionInfo) IsSetScheduledEventId() bool {
+	return v != nil && v.ScheduledEventId != nil
 }
 
 // GetStartedTimestamp returns the value of StartedTimestamp if it is set or its
 // zero value if it is unset.
-func (v *PollForActivityTaskResponse) GetStartedTimestamp() (o int64) {
+func (v *PendingDecisionInfo) GetStartedTimestamp() (o int64) {
 	if v != nil && v.StartedTimestamp != nil {
 		return *v.StartedTimestamp
 	}
@@ -23974,122 +26336,204 @@ func (v *PollForActivityTaskResponse) GetStartedTimestamp() (o int64) {
 }
 
 // IsSetStartedTimestamp returns true if StartedTimestamp is not nil.
-func (v *PollForActivityTaskResponse) IsSetStartedTimestamp() bool {
+func (v *PendingDecisionInfo) IsSetStartedTimestamp() bool {
 	return v != nil && v.StartedTimestamp != nil
 }
 
-// GetStartToCloseTimeoutSeconds returns the value of StartToCloseTimeoutSeconds if it is set or its
+// GetAttempt returns the value of Attempt if it is set or its
 // zero value if it is unset.
-func (v *PollForActivityTaskResponse) GetStartToCloseTimeoutSeconds() (o int32) {
-	if v != nil && v.StartToCloseTimeoutSeconds != nil {
-		return *v.StartToCloseTimeoutSeconds
+func (v *PendingDecisionInfo) GetAttempt() (o int64) {
+	if v != nil && v.Attempt != nil {
+		return *v.Attempt
 	}
 
 	return
 }
 
-// IsSetStartToCloseTimeoutSeconds returns true if StartToCloseTimeoutSeconds is not nil.
-func (v *PollForActivityTaskResponse) IsSetStartToCloseTimeoutSeconds() bool {
-	return v != nil && v.StartToCloseTimeoutSeconds != nil
+// IsSetAttempt returns true if Attempt is not nil.
+func (v *PendingDecisionInfo) IsSetAttempt() bool {
+	return v != nil && v.Attempt != nil
 }
 
-// GetHeartbeatTimeoutSeconds returns the value of HeartbeatTimeoutSeconds if it is set or its
-// zero value if it is unset.
-func (v *PollForActivityTaskResponse) GetHeartbeatTimeoutSeconds() (o int32) {
-	if v != nil && v.HeartbeatTimeoutSeconds != nil {
-		return *v.HeartbeatTimeoutSeconds
-	}
+type PendingDecisionState int32
 
-	return
-}
+const (
+	PendingDecisionStateScheduled PendingDecisionState = 0
+	PendingDecisionStateStarted   PendingDecisionState = 1
+)
 
-// IsSetHeartbeatTimeoutSeconds returns true if HeartbeatTimeoutSeconds is not nil.
-func (v *PollForActivityTaskResponse) IsSetHeartbeatTimeoutSeconds() bool {
-	return v != nil && v.HeartbeatTimeoutSeconds != nil
+// PendingDecisionState_Values returns all recognized values of PendingDecisionState.
+func PendingDecisionState_Values() []PendingDecisionState {
+	return []PendingDecisionState{
+		PendingDecisionStateScheduled,
+		PendingDecisionStateStarted,
+	}
 }
 
-// GetAttempt returns the value of Attempt if it is set or its
-// zero value if it is unset.
-func (v *PollForActivityTaskResponse) GetAttempt() (o int32) {
-	if v != nil && v.Attempt != nil {
-		return *v.Attempt
+// UnmarshalText tries to decode PendingDecisionState from a byte slice
+// containing its name.
+//
+//   var v PendingDecisionState
+//   err := v.UnmarshalText([]byte("SCHEDULED"))
+func (v *PendingDecisionState) UnmarshalText(value []byte) error {
+	switch s := string(value); s {
+	case "SCHEDULED":
+		*v = PendingDecisionStateScheduled
+		return nil
+	case "STARTED":
+		*v = PendingDecisionStateStarted
+		return nil
+	default:
+		val, err := strconv.ParseInt(s, 10, 32)
+		if err != nil {
+			return fmt.Errorf("unknown enum value %q for %q: %v", s, "PendingDecisionState", err)
+		}
+		*v = PendingDecisionState(val)
+		return nil
 	}
-
-	return
 }
 
-// IsSetAttempt returns true if Attempt is not nil.
-func (v *PollForActivityTaskResponse) IsSetAttempt() bool {
-	return v != nil && v.Attempt != nil
+// MarshalText encodes PendingDecisionState to text.
+//
+// If the enum value is recognized, its name is returned. Otherwise,
+// its integer value is returned.
+//
+// This implements the TextMarshaler interface.
+func (v PendingDecisionState) MarshalText() ([]byte, error) {
+	switch int32(v) {
+	case 0:
+		return []byte("SCHEDULED"), nil
+	case 1:
+		return []byte("STARTED"), nil
+	}
+	return []byte(strconv.FormatInt(int64(v), 10)), nil
 }
 
-// GetScheduledTimestampOfThisAttempt returns the value of ScheduledTimestampOfThisAttempt if it is set or its
-// zero value if it is unset.
-func (v *PollForActivityTaskResponse) GetScheduledTimestampOfThisAttempt() (o int64) {
-	if v != nil && v.ScheduledTimestampOfThisAttempt != nil {
-		return *v.ScheduledTimestampOfThisAttempt
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of PendingDecisionState.
+// Enums are logged as objects, where the value is logged with key "value", and
+// if this value's name is known, the name is logged with key "name".
+func (v PendingDecisionState) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddInt32("value", int32(v))
+	switch int32(v) {
+	case 0:
+		enc.AddString("name", "SCHEDULED")
+	case 1:
+		enc.AddString("name", "STARTED")
 	}
-
-	return
+	return nil
 }
 
-// IsSetScheduledTimestampOfThisAttempt returns true if ScheduledTimestampOfThisAttempt is not nil.
-func (v *PollForActivityTaskResponse) IsSetScheduledTimestampOfThisAttempt() bool {
-	return v != nil && v.ScheduledTimestampOfThisAttempt != nil
+// Ptr returns a pointer to this enum value.
+func (v PendingDecisionState) Ptr() *PendingDecisionState {
+	return &v
 }
 
-// GetHeartbeatDetails returns the value of HeartbeatDetails if it is set or its
-// zero value if it is unset.
-func (v *PollForActivityTaskResponse) GetHeartbeatDetails() (o []byte) {
-	if v != nil && v.HeartbeatDetails != nil {
-		return v.HeartbeatDetails
-	}
-
-	return
+// ToWire translates PendingDecisionState into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// Enums are represented as 32-bit integers over the wire.
+func (v PendingDecisionState) ToWire() (wire.Value, error) {
+	return wire.NewValueI32(int32(v)), nil
 }
 
-// IsSetHeartbeatDetails returns true if HeartbeatDetails is not nil.
-func (v *PollForActivityTaskResponse) IsSetHeartbeatDetails() bool {
-	return v != nil && v.HeartbeatDetails != nil
+// FromWire deserializes PendingDecisionState from its Thrift-level
+// representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TI32)
+//   if err != nil {
+//     return PendingDecisionState(0), err
+//   }
+//
+//   var v PendingDecisionState
+//   if err := v.FromWire(x); err != nil {
+//     return PendingDecisionState(0), err
+//   }
+//   return v, nil
+func (v *PendingDecisionState) FromWire(w wire.Value) error {
+	*v = (PendingDecisionState)(w.GetI32())
+	return nil
 }
 
-// GetWorkflowType returns the value of WorkflowType if it is set or its
-// zero value if it is unset.
-func (v *PollForActivityTaskResponse) GetWorkflowType() (o *WorkflowType) {
-	if v != nil && v.WorkflowType != nil {
-		return v.WorkflowType
+// String returns a readable string representation of PendingDecisionState.
+func (v PendingDecisionState) String() string {
+	w := int32(v)
+	switch w {
+	case 0:
+		return "SCHEDULED"
+	case 1:
+		return "STARTED"
 	}
-
-	return
+	return fmt.Sprintf("PendingDecisionState(%d)", w)
 }
 
-// IsSetWorkflowType returns true if WorkflowType is not nil.
-func (v *PollForActivityTaskResponse) IsSetWorkflowType() bool {
-	return v != nil && v.WorkflowType != nil
+// Equals returns true if this PendingDecisionState value matches the provided
+// value.
+func (v PendingDecisionState) Equals(rhs PendingDecisionState) bool {
+	return v == rhs
 }
 
-// GetWorkflowDomain returns the value of WorkflowDomain if it is set or its
-// zero value if it is unset.
-func (v *PollForActivityTaskResponse) GetWorkflowDomain() (o string) {
-	if v != nil && v.WorkflowDomain != nil {
-		return *v.WorkflowDomain
+// MarshalJSON serializes PendingDecisionState into JSON.
+//
+// If the enum value is recognized, its name is returned. Otherwise,
+// its integer value is returned.
+//
+// This implements json.Marshaler.
+func (v PendingDecisionState) MarshalJSON() ([]byte, error) {
+	switch int32(v) {
+	case 0:
+		return ([]byte)("\"SCHEDULED\""), nil
+	case 1:
+		return ([]byte)("\"STARTED\""), nil
 	}
-
-	return
+	return ([]byte)(strconv.FormatInt(int64(v), 10)), nil
 }
 
-// IsSetWorkflowDomain returns true if WorkflowDomain is not nil.
-func (v *PollForActivityTaskResponse) IsSetWorkflowDomain() bool {
-	return v != nil && v.WorkflowDomain != nil
+// UnmarshalJSON attempts to decode PendingDecisionState from its JSON
+// representation.
+//
+// This implementation supports both, numeric and string inputs. If a
+// string is provided, it must be a known enum name.
+//
+// This implements json.Unmarshaler.
+func (v *PendingDecisionState) UnmarshalJSON(text []byte) error {
+	d := json.NewDecoder(bytes.NewReader(text))
+	d.UseNumber()
+	t, err := d.Token()
+	if err != nil {
+		return err
+	}
+
+	switch w := t.(type) {
+	case json.Number:
+		x, err := w.Int64()
+		if err != nil {
+			return err
+		}
+		if x > math.MaxInt32 {
+			return fmt.Errorf("enum overflow from JSON %q for %q", text, "PendingDecisionState")
+		}
+		if x < math.MinInt32 {
+			return fmt.Errorf("enum underflow from JSON %q for %q", text, "PendingDecisionState")
+		}
+		*v = (PendingDecisionState)(x)
+		return nil
+	case string:
+		return v.UnmarshalText([]byte(w))
+	default:
+		return fmt.Errorf("invalid JSON value %q (%T) to unmarshal into %q", t, t, "PendingDecisionState")
+	}
 }
 
-type PollForDecisionTaskRequest struct {
-	Domain   *string   `json:"domain,omitempty"`
-	TaskList *TaskList `json:"taskList,omitempty"`
-	Identity *string   `json:"identity,omitempty"`
+type PollForActivityTaskRequest struct {
+	Domain           *string           `json:"domain,omitempty"`
+	TaskList         *TaskList         `json:"taskList,omitempty"`
+	Identity         *string           `json:"identity,omitempty"`
+	TaskListMetadata *TaskListMetadata `json:"taskListMetadata,omitempty"`
 }
 
-// ToWire translates a PollForDecisionTaskRequest struct into a Thrift-level intermediate
+// ToWire translates a PollForActivityTaskRequest struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -24104,9 +26548,9 @@ type PollForDecisionTaskRequest struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *PollForDecisionTaskRequest) ToWire() (wire.Value, error) {
+func (v *PollForActivityTaskRequest) ToWire() (wire.Value, error) {
 	var (
-		fields [3]wire.Field
+		fields [4]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -24136,15 +26580,29 @@ func (v *PollForDecisionTaskRequest) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 30, Value: w}
 		i++
 	}
+	if v.TaskListMetadata != nil {
+		w, err = v.TaskListMetadata.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-// FromWire deserializes a PollForDecisionTaskRequest struct from its Thrift-level
+func _TaskListMetadata_Read(w wire.Value) (*TaskListMetadata, error) {
+	var v TaskListMetadata
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a PollForActivityTaskRequest struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a PollForDecisionTaskRequest struct
+// An error is returned if we were unable to build a PollForActivityTaskRequest struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -24152,12 +26610,12 @@ func (v *PollForDecisionTaskRequest) ToWire() (wire.Value, error) {
 //     return nil, err
 //   }
 //
-//   var v PollForDecisionTaskRequest
+//   var v PollForActivityTaskRequest
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *PollForDecisionTaskRequest) FromWire(w wire.Value) error {
+func (v *PollForActivityTaskRequest) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
@@ -24189,6 +26647,14 @@ func (v *PollForDecisionTaskRequest) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 40:
+			if field.Value.Type() == wire.TStruct {
+				v.TaskListMetadata, err = _TaskListMetadata_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -24196,14 +26662,14 @@ func (v *PollForDecisionTaskRequest) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a PollForDecisionTaskRequest
+// String returns a readable string representation of a PollForActivityTaskRequest
 // struct.
-func (v *PollForDecisionTaskRequest) String() string {
+func (v *PollForActivityTaskRequest) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [3]string
+	var fields [4]string
 	i := 0
 	if v.Domain != nil {
 		fields[i] = fmt.Sprintf("Domain: %v", *(v.Domain))
@@ -24217,15 +26683,19 @@ func (v *PollForDecisionTaskRequest) String() string {
 		fields[i] = fmt.Sprintf("Identity: %v", *(v.Identity))
 		i++
 	}
+	if v.TaskListMetadata != nil {
+		fields[i] = fmt.Sprintf("TaskListMetadata: %v", v.TaskListMetadata)
+		i++
+	}
 
-	return fmt.Sprintf("PollForDecisionTaskRequest{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("PollForActivityTaskRequest{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this PollForDecisionTaskRequest match the
-// provided PollForDecisionTaskRequest.
+// Equals returns true if all the fields of this PollForActivityTaskRequest match the
+// provided PollForActivityTaskRequest.
 //
 // This function performs a deep comparison.
-func (v *PollForDecisionTaskRequest) Equals(rhs *PollForDecisionTaskRequest) bool {
+func (v *PollForActivityTaskRequest) Equals(rhs *PollForActivityTaskRequest) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
@@ -24240,13 +26710,16 @@ func (v *PollForDecisionTaskRequest) Equals(rhs *PollForDecisionTaskRequest) boo
 	if !_String_EqualsPtr(v.Identity, rhs.Identity) {
 		return false
 	}
+	if !((v.TaskListMetadata == nil && rhs.TaskListMetadata == nil) || (v.TaskListMetadata != nil && rhs.TaskListMetadata != nil && v.TaskListMetadata.Equals(rhs.TaskListMetadata))) {
+		return false
+	}
 
 	return true
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of PollForDecisionTaskRequest.
-func (v *PollForDecisionTaskRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of PollForActivityTaskRequest.
+func (v *PollForActivityTaskRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
@@ -24259,12 +26732,15 @@ func (v *PollForDecisionTaskRequest) MarshalLogObject(enc zapcore.ObjectEncoder)
 	if v.Identity != nil {
 		enc.AddString("identity", *v.Identity)
 	}
+	if v.TaskListMetadata != nil {
+		err = multierr.Append(err, enc.AddObject("taskListMetadata", v.TaskListMetadata))
+	}
 	return err
 }
 
 // GetDomain returns the value of Domain if it is set or its
 // zero value if it is unset.
-func (v *PollForDecisionTaskRequest) GetDomain() (o string) {
+func (v *PollForActivityTaskRequest) GetDomain() (o string) {
 	if v != nil && v.Domain != nil {
 		return *v.Domain
 	}
@@ -24273,13 +26749,13 @@ func (v *PollForDecisionTaskRequest) GetDomain() (o string) {
 }
 
 // IsSetDomain returns true if Domain is not nil.
-func (v *PollForDecisionTaskRequest) IsSetDomain() bool {
+func (v *PollForActivityTaskRequest) IsSetDomain() bool {
 	return v != nil && v.Domain != nil
 }
 
 // GetTaskList returns the value of TaskList if it is set or its
 // zero value if it is unset.
-func (v *PollForDecisionTaskRequest) GetTaskList() (o *TaskList) {
+func (v *PollForActivityTaskRequest) GetTaskList() (o *TaskList) {
 	if v != nil && v.TaskList != nil {
 		return v.TaskList
 	}
@@ -24288,13 +26764,13 @@ func (v *PollForDecisionTaskRequest) GetTaskList() (o *TaskList) {
 }
 
 // IsSetTaskList returns true if TaskList is not nil.
-func (v *PollForDecisionTaskRequest) IsSetTaskList() bool {
+func (v *PollForActivityTaskRequest) IsSetTaskList() bool {
 	return v != nil && v.TaskList != nil
 }
 
 // GetIdentity returns the value of Identity if it is set or its
 // zero value if it is unset.
-func (v *PollForDecisionTaskRequest) GetIdentity() (o string) {
+func (v *PollForActivityTaskRequest) GetIdentity() (o string) {
 	if v != nil && v.Identity != nil {
 		return *v.Identity
 	}
@@ -24303,25 +26779,44 @@ func (v *PollForDecisionTaskRequest) GetIdentity() (o string) {
 }
 
 // IsSetIdentity returns true if Identity is not nil.
-func (v *PollForDecisionTaskRequest) IsSetIdentity() bool {
+func (v *PollForActivityTaskRequest) IsSetIdentity() bool {
 	return v != nil && v.Identity != nil
 }
 
-type PollForDecisionTaskResponse struct {
-	TaskToken                 []byte             `json:"taskToken,omitempty"`
-	WorkflowExecution         *WorkflowExecution `json:"workflowExecution,omitempty"`
-	WorkflowType              *WorkflowType      `json:"workflowType,omitempty"`
-	PreviousStartedEventId    *int64             `json:"previousStartedEventId,omitempty"`
-	StartedEventId            *int64             `json:"startedEventId,omitempty"`
-	Attempt                   *int64             `json:"attempt,omitempty"`
-	BacklogCountHint          *int64             `json:"backlogCountHint,omitempty"`
-	History                   *History           `json:"history,omitempty"`
-	NextPageToken             []byte             `json:"nextPageToken,omitempty"`
-	Query                     *WorkflowQuery     `json:"query,omitempty"`
-	WorkflowExecutionTaskList *TaskList          `json:"WorkflowExecutionTaskList,omitempty"`
+// GetTaskListMetadata returns the value of TaskListMetadata if it is set or its
+// zero value if it is unset.
+func (v *PollForActivityTaskRequest) GetTaskListMetadata() (o *TaskListMetadata) {
+	if v != nil && v.TaskListMetadata != nil {
+		return v.TaskListMetadata
+	}
+
+	return
 }
 
-// ToWire translates a PollForDecisionTaskResponse struct into a Thrift-level intermediate
+// IsSetTaskListMetadata returns true if TaskListMetadata is not nil.
+func (v *PollForActivityTaskRequest) IsSetTaskListMetadata() bool {
+	return v != nil && v.TaskListMetadata != nil
+}
+
+type PollForActivityTaskResponse struct {
+	TaskToken                       []byte             `json:"taskToken,omitempty"`
+	WorkflowExecution               *WorkflowExecution `json:"workflowExecution,omitempty"`
+	ActivityId                      *string            `json:"activityId,omitempty"`
+	ActivityType                    *ActivityType      `json:"activityType,omitempty"`
+	Input                           []byte             `json:"input,omitempty"`
+	ScheduledTimestamp              *int64             `json:"scheduledTimestamp,omitempty"`
+	ScheduleToCloseTimeoutSeconds   *int32             `json:"scheduleToCloseTimeoutSeconds,omitempty"`
+	StartedTimestamp                *int64             `json:"startedTimestamp,omitempty"`
+	StartToCloseTimeoutSeconds      *int32             `json:"startToCloseTimeoutSeconds,omitempty"`
+	HeartbeatTimeoutSeconds         *int32             `json:"heartbeatTimeoutSeconds,omitempty"`
+	Attempt                         *int32             `json:"attempt,omitempty"`
+	ScheduledTimestampOfThisAttempt *int64             `json:"scheduledTimestampOfThisAttempt,omitempty"`
+	HeartbeatDetails                []byte             `json:"heartbeatDetails,omitempty"`
+	WorkflowType                    *WorkflowType      `json:"workflowType,omitempty"`
+	WorkflowDomain                  *string            `json:"workflowDomain,omitempty"`
+}
+
+// ToWire translates a PollForActivityTaskResponse struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -24336,9 +26831,9 @@ type PollForDecisionTaskResponse struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *PollForDecisionTaskResponse) ToWire() (wire.Value, error) {
+func (v *PollForActivityTaskResponse) ToWire() (wire.Value, error) {
 	var (
-		fields [11]wire.Field
+		fields [15]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -24360,93 +26855,119 @@ func (v *PollForDecisionTaskResponse) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
-	if v.WorkflowType != nil {
-		w, err = v.WorkflowType.ToWire()
+	if v.ActivityId != nil {
+		w, err = wire.NewValueString(*(v.ActivityId)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 30, Value: w}
 		i++
 	}
-	if v.PreviousStartedEventId != nil {
-		w, err = wire.NewValueI64(*(v.PreviousStartedEventId)), error(nil)
+	if v.ActivityType != nil {
+		w, err = v.ActivityType.ToWire()
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 40, Value: w}
 		i++
 	}
-	if v.StartedEventId != nil {
-		w, err = wire.NewValueI64(*(v.StartedEventId)), error(nil)
+	if v.Input != nil {
+		w, err = wire.NewValueBinary(v.Input), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 50, Value: w}
 		i++
 	}
-	if v.Attempt != nil {
-		w, err = wire.NewValueI64(*(v.Attempt)), error(nil)
+	if v.ScheduledTimestamp != nil {
+		w, err = wire.NewValueI64(*(v.ScheduledTimestamp)), error(nil)
 		if err != nil {
 			return w, err
 		}
-		fields[i] = wire.Field{ID: 51, Value: w}
+		fields[i] = wire.Field{ID: 70, Value: w}
 		i++
 	}
-	if v.BacklogCountHint != nil {
-		w, err = wire.NewValueI64(*(v.BacklogCountHint)), error(nil)
+	if v.ScheduleToCloseTimeoutSeconds != nil {
+		w, err = wire.NewValueI32(*(v.ScheduleToCloseTimeoutSeconds)), error(nil)
 		if err != nil {
 			return w, err
 		}
-		fields[i] = wire.Field{ID: 54, Value: w}
+		fields[i] = wire.Field{ID: 80, Value: w}
 		i++
 	}
-	if v.History != nil {
-		w, err = v.History.ToWire()
+	if v.StartedTimestamp != nil {
+		w, err = wire.NewValueI64(*(v.StartedTimestamp)), error(nil)
 		if err != nil {
 			return w, err
 		}
-		fields[i] = wire.Field{ID: 60, Value: w}
+		fields[i] = wire.Field{ID: 90, Value: w}
 		i++
 	}
-	if v.NextPageToken != nil {
-		w, err = wire.NewValueBinary(v.NextPageToken), error(nil)
+	if v.StartToCloseTimeoutSeconds != nil {
+		w, err = wire.NewValueI32(*(v.StartToCloseTimeoutSeconds)), error(nil)
 		if err != nil {
 			return w, err
 		}
-		fields[i] = wire.Field{ID: 70, Value: w}
+		fields[i] = wire.Field{ID: 100, Value: w}
 		i++
 	}
-	if v.Query != nil {
-		w, err = v.Query.ToWire()
+	if v.HeartbeatTimeoutSeconds != nil {
+		w, err = wire.NewValueI32(*(v.HeartbeatTimeoutSeconds)), error(nil)
 		if err != nil {
 			return w, err
 		}
-		fields[i] = wire.Field{ID: 80, Value: w}
+		fields[i] = wire.Field{ID: 110, Value: w}
 		i++
 	}
-	if v.WorkflowExecutionTaskList != nil {
-		w, err = v.WorkflowExecutionTaskList.ToWire()
+	if v.Attempt != nil {
+		w, err = wire.NewValueI32(*(v.Attempt)), error(nil)
 		if err != nil {
 			return w, err
 		}
-		fields[i] = wire.Field{ID: 90, Value: w}
+		fields[i] = wire.Field{ID: 120, Value: w}
+		i++
+	}
+	if v.ScheduledTimestampOfThisAttempt != nil {
+		w, err = wire.NewValueI64(*(v.ScheduledTimestampOfThisAttempt)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 130, Value: w}
+		i++
+	}
+	if v.HeartbeatDetails != nil {
+		w, err = wire.NewValueBinary(v.HeartbeatDetails), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 140, Value: w}
+		i++
+	}
+	if v.WorkflowType != nil {
+		w, err = v.WorkflowType.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 150, Value: w}
+		i++
+	}
+	if v.WorkflowDomain != nil {
+		w, err = wire.NewValueString(*(v.WorkflowDomain)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 160, Value: w}
 		i++
 	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _WorkflowQuery_Read(w wire.Value) (*WorkflowQuery, error) {
-	var v WorkflowQuery
-	err := v.FromWire(w)
-	return &v, err
-}
-
-// FromWire deserializes a PollForDecisionTaskResponse struct from its Thrift-level
+// FromWire deserializes a PollForActivityTaskResponse struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a PollForDecisionTaskResponse struct
+// An error is returned if we were unable to build a PollForActivityTaskResponse struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -24454,12 +26975,12 @@ func _WorkflowQuery_Read(w wire.Value) (*WorkflowQuery, error) {
 //     return nil, err
 //   }
 //
-//   var v PollForDecisionTaskResponse
+//   var v PollForActivityTaskResponse
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *PollForDecisionTaskResponse) FromWire(w wire.Value) error {
+func (v *PollForActivityTaskResponse) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
@@ -24481,80 +27002,122 @@ func (v *PollForDecisionTaskResponse) FromWire(w wire.Value) error {
 
 			}
 		case 30:
-			if field.Value.Type() == wire.TStruct {
-				v.WorkflowType, err = _WorkflowType_Read(field.Value)
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.ActivityId = &x
 				if err != nil {
 					return err
 				}
 
 			}
 		case 40:
-			if field.Value.Type() == wire.TI64 {
-				var x int64
-				x, err = field.Value.GetI64(), error(nil)
-				v.PreviousStartedEventId = &x
+			if field.Value.Type() == wire.TStruct {
+				v.ActivityType, err = _ActivityType_Read(field.Value)
 				if err != nil {
 					return err
 				}
 
 			}
 		case 50:
-			if field.Value.Type() == wire.TI64 {
-				var x int64
-				x, err = field.Value.GetI64(), error(nil)
-				v.StartedEventId = &x
+			if field.Value.Type() == wire.TBinary {
+				v.Input, err = field.Value.GetBinary(), error(nil)
 				if err != nil {
 					return err
 				}
 
 			}
-		case 51:
+		case 70:
 			if field.Value.Type() == wire.TI64 {
 				var x int64
 				x, err = field.Value.GetI64(), error(nil)
-				v.Attempt = &x
+				v.ScheduledTimestamp = &x
 				if err != nil {
 					return err
 				}
 
 			}
-		case 54:
+		case 80:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.ScheduleToCloseTimeoutSeconds = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 90:
 			if field.Value.Type() == wire.TI64 {
 				var x int64
 				x, err = field.Value.GetI64(), error(nil)
-				v.BacklogCountHint = &x
+				v.StartedTimestamp = &x
 				if err != nil {
 					return err
 				}
 
 			}
-		case 60:
-			if field.Value.Type() == wire.TStruct {
-				v.History, err = _History_Read(field.Value)
+		case 100:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.StartToCloseTimeoutSeconds = &x
 				if err != nil {
 					return err
 				}
 
 			}
-		case 70:
+		case 110:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.HeartbeatTimeoutSeconds = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 120:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.Attempt = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 130:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.ScheduledTimestampOfThisAttempt = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 140:
 			if field.Value.Type() == wire.TBinary {
-				v.NextPageToken, err = field.Value.GetBinary(), error(nil)
+				v.HeartbeatDetails, err = field.Value.GetBinary(), error(nil)
 				if err != nil {
 					return err
 				}
 
 			}
-		case 80:
+		case 150:
 			if field.Value.Type() == wire.TStruct {
-				v.Query, err = _WorkflowQuery_Read(field.Value)
+				v.WorkflowType, err = _WorkflowType_Read(field.Value)
 				if err != nil {
 					return err
 				}
 
 			}
-		case 90:
-			if field.Value.Type() == wire.TStruct {
-				v.WorkflowExecutionTaskList, err = _TaskList_Read(field.Value)
+		case 160:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.WorkflowDomain = &x
 				if err != nil {
 					return err
 				}
@@ -24566,14 +27129,14 @@ func (v *PollForDecisionTaskResponse) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a PollForDecisionTaskResponse
+// String returns a readable string representation of a PollForActivityTaskResponse
 // struct.
-func (v *PollForDecisionTaskResponse) String() string {
+func (v *PollForActivityTaskResponse) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [11]string
+	var fields [15]string
 	i := 0
 	if v.TaskToken != nil {
 		fields[i] = fmt.Sprintf("TaskToken: %v", v.TaskToken)
@@ -24583,51 +27146,67 @@ func (v *PollForDecisionTaskResponse) String() string {
 		fields[i] = fmt.Sprintf("WorkflowExecution: %v", v.WorkflowExecution)
 		i++
 	}
-	if v.WorkflowType != nil {
-		fields[i] = fmt.Sprintf("WorkflowType: %v", v.WorkflowType)
+	if v.ActivityId != nil {
+		fields[i] = fmt.Sprintf("ActivityId: %v", *(v.ActivityId))
 		i++
 	}
-	if v.PreviousStartedEventId != nil {
-		fields[i] = fmt.Sprintf("PreviousStartedEventId: %v", *(v.PreviousStartedEventId))
+	if v.ActivityType != nil {
+		fields[i] = fmt.Sprintf("ActivityType: %v", v.ActivityType)
 		i++
 	}
-	if v.StartedEventId != nil {
-		fields[i] = fmt.Sprintf("StartedEventId: %v", *(v.StartedEventId))
+	if v.Input != nil {
+		fields[i] = fmt.Sprintf("Input: %v", v.Input)
 		i++
 	}
-	if v.Attempt != nil {
-		fields[i] = fmt.Sprintf("Attempt: %v", *(v.Attempt))
+	if v.ScheduledTimestamp != nil {
+		fields[i] = fmt.Sprintf("ScheduledTimestamp: %v", *(v.ScheduledTimestamp))
 		i++
 	}
-	if v.BacklogCountHint != nil {
-		fields[i] = fmt.Sprintf("BacklogCountHint: %v", *(v.BacklogCountHint))
+	if v.ScheduleToCloseTimeoutSeconds != nil {
+		fields[i] = fmt.Sprintf("ScheduleToCloseTimeoutSeconds: %v", *(v.ScheduleToCloseTimeoutSeconds))
 		i++
 	}
-	if v.History != nil {
-		fields[i] = fmt.Sprintf("History: %v", v.History)
+	if v.StartedTimestamp != nil {
+		fields[i] = fmt.Sprintf("StartedTimestamp: %v", *(v.StartedTimestamp))
 		i++
 	}
-	if v.NextPageToken != nil {
-		fields[i] = fmt.Sprintf("NextPageToken: %v", v.NextPageToken)
+	if v.StartToCloseTimeoutSeconds != nil {
+		fields[i] = fmt.Sprintf("StartToCloseTimeoutSeconds: %v", *(v.StartToCloseTimeoutSeconds))
 		i++
 	}
-	if v.Query != nil {
-		fields[i] = fmt.Sprintf("Query: %v", v.Query)
+	if v.HeartbeatTimeoutSeconds != nil {
+		fields[i] = fmt.Sprintf("HeartbeatTimeoutSeconds: %v", *(v.HeartbeatTimeoutSeconds))
 		i++
 	}
-	if v.WorkflowExecutionTaskList != nil {
-		fields[i] = fmt.Sprintf("WorkflowExecutionTaskList: %v", v.WorkflowExecutionTaskList)
+	if v.Attempt != nil {
+		fields[i] = fmt.Sprintf("Attempt: %v", *(v.Attempt))
+		i++
+	}
+	if v.ScheduledTimestampOfThisAttempt != nil {
+		fields[i] = fmt.Sprintf("ScheduledTimestampOfThisAttempt: %v", *(v.ScheduledTimestampOfThisAttempt))
+		i++
+	}
+	if v.HeartbeatDetails != nil {
+		fields[i] = fmt.Sprintf("HeartbeatDetails: %v", v.HeartbeatDetails)
+		i++
+	}
+	if v.WorkflowType != nil {
+		fields[i] = fmt.Sprintf("WorkflowType: %v", v.WorkflowType)
+		i++
+	}
+	if v.WorkflowDomain != nil {
+		fields[i] = fmt.Sprintf("WorkflowDomain: %v", *(v.WorkflowDomain))
 		i++
 	}
 
-	return fmt.Sprintf("PollForDecisionTaskResponse{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("PollForActivityTaskResponse{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this PollForDecisionTaskResponse match the
-// provided PollForDecisionTaskResponse.
+// Equals returns true if all the fields of this PollForActivityTaskResponse match the
+// provided PollForActivityTaskResponse.
 //
 // This function performs a deep comparison.
-func (v *PollForDecisionTaskResponse) Equals(rhs *PollForDecisionTaskResponse) bool {
+func (v *PollForActivityTaskResponse) Equals(rhs *PollForActivityTaskResponse) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
@@ -24639,31 +27218,43 @@ func (v *PollForDecisionTaskResponse) Equals(rhs *PollForDecisionTaskResponse) b
 	if !((v.WorkflowExecution == nil && rhs.WorkflowExecution == nil) || (v.WorkflowExecution != nil && rhs.WorkflowExecution != nil && v.WorkflowExecution.Equals(rhs.WorkflowExecution))) {
 		return false
 	}
-	if !((v.WorkflowType == nil && rhs.WorkflowType == nil) || (v.WorkflowType != nil && rhs.WorkflowType != nil && v.WorkflowType.Equals(rhs.WorkflowType))) {
+	if !_String_EqualsPtr(v.ActivityId, rhs.ActivityId) {
 		return false
 	}
-	if !_I64_EqualsPtr(v.PreviousStartedEventId, rhs.PreviousStartedEventId) {
+	if !((v.ActivityType == nil && rhs.ActivityType == nil) || (v.ActivityType != nil && rhs.ActivityType != nil && v.ActivityType.Equals(rhs.ActivityType))) {
 		return false
 	}
-	if !_I64_EqualsPtr(v.StartedEventId, rhs.StartedEventId) {
+	if !((v.Input == nil && rhs.Input == nil) || (v.Input != nil && rhs.Input != nil && bytes.Equal(v.Input, rhs.Input))) {
 		return false
 	}
-	if !_I64_EqualsPtr(v.Attempt, rhs.Attempt) {
+	if !_I64_EqualsPtr(v.ScheduledTimestamp, rhs.ScheduledTimestamp) {
 		return false
 	}
-	if !_I64_EqualsPtr(v.BacklogCountHint, rhs.BacklogCountHint) {
+	if !_I32_EqualsPtr(v.ScheduleToCloseTimeoutSeconds, rhs.ScheduleToCloseTimeoutSeconds) {
 		return false
 	}
-	if !((v.History == nil && rhs.History == nil) || (v.History != nil && rhs.History != nil && v.History.Equals(rhs.History))) {
+	if !_I64_EqualsPtr(v.StartedTimestamp, rhs.StartedTimestamp) {
 		return false
 	}
-	if !((v.NextPageToken == nil && rhs.NextPageToken == nil) || (v.NextPageToken != nil && rhs.NextPageToken != nil && bytes.Equal(v.NextPageToken, rhs.NextPageToken))) {
+	if !_I32_EqualsPtr(v.StartToCloseTimeoutSeconds, rhs.StartToCloseTimeoutSeconds) {
 		return false
 	}
-	if !((v.Query == nil && rhs.Query == nil) || (v.Query != nil && rhs.Query != nil && v.Query.Equals(rhs.Query))) {
+	if !_I32_EqualsPtr(v.HeartbeatTimeoutSeconds, rhs.HeartbeatTimeoutSeconds) {
 		return false
 	}
-	if !((v.WorkflowExecutionTaskList == nil && rhs.WorkflowExecutionTaskList == nil) || (v.WorkflowExecutionTaskList != nil && rhs.WorkflowExecutionTaskList != nil && v.WorkflowExecutionTaskList.Equals(rhs.WorkflowExecutionTaskList))) {
+	if !_I32_EqualsPtr(v.Attempt, rhs.Attempt) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.ScheduledTimestampOfThisAttempt, rhs.ScheduledTimestampOfThisAttempt) {
+		return false
+	}
+	if !((v.HeartbeatDetails == nil && rhs.HeartbeatDetails == nil) || (v.HeartbeatDetails != nil && rhs.HeartbeatDetails != nil && bytes.Equal(v.HeartbeatDetails, rhs.HeartbeatDetails))) {
+		return false
+	}
+	if !((v.WorkflowType == nil && rhs.WorkflowType == nil) || (v.WorkflowType != nil && rhs.WorkflowType != nil && v.WorkflowType.Equals(rhs.WorkflowType))) {
+		return false
+	}
+	if !_String_EqualsPtr(v.WorkflowDomain, rhs.WorkflowDomain) {
 		return false
 	}
 
@@ -24671,8 +27262,8 @@ func (v *PollForDecisionTaskResponse) Equals(rhs *PollForDecisionTaskResponse) b
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of PollForDecisionTaskResponse.
-func (v *PollForDecisionTaskResponse) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of PollForActivityTaskResponse.
+func (v *PollForActivityTaskResponse) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
@@ -24682,39 +27273,51 @@ func (v *PollForDecisionTaskResponse) MarshalLogObject(enc zapcore.ObjectEncoder
 	if v.WorkflowExecution != nil {
 		err = multierr.Append(err, enc.AddObject("workflowExecution", v.WorkflowExecution))
 	}
-	if v.WorkflowType != nil {
-		err = multierr.Append(err, enc.AddObject("workflowType", v.WorkflowType))
+	if v.ActivityId != nil {
+		enc.AddString("activityId", *v.ActivityId)
 	}
-	if v.PreviousStartedEventId != nil {
-		enc.AddInt64("previousStartedEventId", *v.PreviousStartedEventId)
+	if v.ActivityType != nil {
+		err = multierr.Append(err, enc.AddObject("activityType", v.ActivityType))
 	}
-	if v.StartedEventId != nil {
-		enc.AddInt64("startedEventId", *v.StartedEventId)
+	if v.Input != nil {
+		enc.AddString("input", base64.StdEncoding.EncodeToString(v.Input))
 	}
-	if v.Attempt != nil {
-		enc.AddInt64("attempt", *v.Attempt)
+	if v.ScheduledTimestamp != nil {
+		enc.AddInt64("scheduledTimestamp", *v.ScheduledTimestamp)
 	}
-	if v.BacklogCountHint != nil {
-		enc.AddInt64("backlogCountHint", *v.BacklogCountHint)
+	if v.ScheduleToCloseTimeoutSeconds != nil {
+		enc.AddInt32("scheduleToCloseTimeoutSeconds", *v.ScheduleToCloseTimeoutSeconds)
 	}
-	if v.History != nil {
-		err = multierr.Append(err, enc.AddObject("history", v.History))
+	if v.StartedTimestamp != nil {
+		enc.AddInt64("startedTimestamp", *v.StartedTimestamp)
 	}
-	if v.NextPageToken != nil {
-		enc.AddString("nextPageToken", base64.StdEncoding.EncodeToString(v.NextPageToken))
+	if v.StartToCloseTimeoutSeconds != nil {
+		enc.AddInt32("startToCloseTimeoutSeconds", *v.StartToCloseTimeoutSeconds)
 	}
-	if v.Query != nil {
-		err = multierr.Append(err, enc.AddObject("query", v.Query))
+	if v.HeartbeatTimeoutSeconds != nil {
+		enc.AddInt32("heartbeatTimeoutSeconds", *v.HeartbeatTimeoutSeconds)
 	}
-	if v.WorkflowExecutionTaskList != nil {
-		err = multierr.Append(err, enc.AddObject("WorkflowExecutionTaskList", v.WorkflowExecutionTaskList))
+	if v.Attempt != nil {
+		enc.AddInt32("attempt", *v.Attempt)
+	}
+	if v.ScheduledTimestampOfThisAttempt != nil {
+		enc.AddInt64("scheduledTimestampOfThisAttempt", *v.ScheduledTimestampOfThisAttempt)
+	}
+	if v.HeartbeatDetails != nil {
+		enc.AddString("heartbeatDetails", base64.StdEncoding.EncodeToString(v.HeartbeatDetails))
+	}
+	if v.WorkflowType != nil {
+		err = multierr.Append(err, enc.AddObject("workflowType", v.WorkflowType))
+	}
+	if v.WorkflowDomain != nil {
+		enc.AddString("workflowDomain", *v.WorkflowDomain)
 	}
 	return err
 }
 
 // GetTaskToken returns the value of TaskToken if it is set or its
 // zero value if it is unset.
-func (v *PollForDecisionTaskResponse) GetTaskToken() (o []byte) {
+func (v *PollForActivityTaskResponse) GetTaskToken() (o []byte) {
 	if v != nil && v.TaskToken != nil {
 		return v.TaskToken
 	}
@@ -24723,13 +27326,13 @@ func (v *PollForDecisionTaskResponse) GetTaskToken() (o []byte) {
 }
 
 // IsSetTaskToken returns true if TaskToken is not nil.
-func (v *PollForDecisionTaskResponse) IsSetTaskToken() bool {
+func (v *PollForActivityTaskResponse) IsSetTaskToken() bool {
 	return v != nil && v.TaskToken != nil
 }
 
 // GetWorkflowExecution returns the value of WorkflowExecution if it is set or its
 // zero value if it is unset.
-func (v *PollForDecisionTaskResponse) GetWorkflowExecution() (o *WorkflowExecution) {
+func (v *PollForActivityTaskResponse) GetWorkflowExecution() (o *WorkflowExecution) {
 	if v != nil && v.WorkflowExecution != nil {
 		return v.WorkflowExecution
 	}
@@ -24738,152 +27341,212 @@ func (v *PollForDecisionTaskResponse) GetWorkflowExecution() (o *WorkflowExecuti
 }
 
 // IsSetWorkflowExecution returns true if WorkflowExecution is not nil.
-func (v *PollForDecisionTaskResponse) IsSetWorkflowExecution() bool {
+func (v *PollForActivityTaskResponse) IsSetWorkflowExecution() bool {
 	return v != nil && v.WorkflowExecution != nil
 }
 
-// GetWorkflowType returns the value of WorkflowType if it is set or its
+// GetActivityId returns the value of ActivityId if it is set or its
 // zero value if it is unset.
-func (v *PollForDecisionTaskResponse) GetWorkflowType() (o *WorkflowType) {
-	if v != nil && v.WorkflowType != nil {
-		return v.WorkflowType
+func (v *PollForActivityTaskResponse) GetActivityId() (o string) {
+	if v != nil && v.ActivityId != nil {
+		return *v.ActivityId
 	}
 
 	return
 }
 
-// IsSetWorkflowType returns true if WorkflowType is not nil.
-func (v *PollForDecisionTaskResponse) IsSetWorkflowType() bool {
-	return v != nil && v.WorkflowType != nil
+// IsSetActivityId returns true if ActivityId is not nil.
+func (v *PollForActivityTaskResponse) IsSetActivityId() bool {
+	return v != nil && v.ActivityId != nil
 }
 
-// GetPreviousStartedEventId returns the value of PreviousStartedEventId if it is set or its
+// GetActivityType returns the value of ActivityType if it is set or its
 // zero value if it is unset.
-func (v *PollForDecisionTaskResponse) GetPreviousStartedEventId() (o int64) {
-	if v != nil && v.PreviousStartedEventId != nil {
-		return *v.PreviousStartedEventId
+func (v *PollForActivityTaskResponse) GetActivityType() (o *ActivityType) {
+	if v != nil && v.ActivityType != nil {
+		return v.ActivityType
 	}
 
 	return
 }
 
-// IsSetPreviousStartedEventId returns true if PreviousStartedEventId is not nil.
-func (v *PollForDecisionTaskResponse) IsSetPreviousStartedEventId() bool {
-	return v != nil && v.PreviousStartedEventId != nil
+// IsSetActivityType returns true if ActivityType is not nil.
+func (v *PollForActivityTaskResponse) IsSetActivityType() bool {
+	return v != nil && v.ActivityType != nil
 }
 
-// GetStartedEventId returns the value of StartedEventId if it is set or its
+// GetInput returns the value of Input if it is set or its
 // zero value if it is unset.
-func (v *PollForDecisionTaskResponse) GetStartedEventId() (o int64) {
-	if v != nil && v.StartedEventId != nil {
-		return *v.StartedEventId
+func (v *PollForActivityTaskResponse) GetInput() (o []byte) {
+	if v != nil && v.Input != nil {
+		return v.Input
 	}
 
 	return
 }
 
-// IsSetStartedEventId returns true if StartedEventId is not nil.
-func (v *PollForDecisionTaskResponse) IsSetStartedEventId() bool {
-	return v != nil && v.StartedEventId != nil
+// IsSetInput returns true if Input is not nil.
+func (v *PollForActivityTaskResponse) IsSetInput() bool {
+	return v != nil && v.Input != nil
 }
 
-// GetAttempt returns the value of Attempt if it is set or its
+// GetScheduledTimestamp returns the value of ScheduledTimestamp if it is set or its
 // zero value if it is unset.
-func (v *PollForDecisionTaskResponse) GetAttempt() (o int64) {
-	if v != nil && v.Attempt != nil {
-		return *v.Attempt
+func (v *PollForActivityTaskResponse) GetScheduledTimestamp() (o int64) {
+	if v != nil && v.ScheduledTimestamp != nil {
+		return *v.ScheduledTimestamp
 	}
 
 	return
 }
 
-// IsSetAttempt returns true if Attempt is not nil.
-func (v *PollForDecisionTaskResponse) IsSetAttempt() bool {
-	return v != nil && v.Attempt != nil
+// IsSetScheduledTimestamp returns true if ScheduledTimestamp is not nil.
+func (v *PollForActivityTaskResponse) IsSetScheduledTimestamp() bool {
+	return v != nil && v.ScheduledTimestamp != nil
 }
 
-// GetBacklogCountHint returns the value of BacklogCountHint if it is set or its
+// GetScheduleToCloseTimeoutSeconds returns the value of ScheduleToCloseTimeoutSeconds if it is set or its
 // zero value if it is unset.
-func (v *PollForDecisionTaskResponse) GetBacklogCountHint() (o int64) {
-	if v != nil && v.BacklogCountHint != nil {
-		return *v.BacklogCountHint
+func (v *PollForActivityTaskResponse) GetScheduleToCloseTimeoutSeconds() (o int32) {
+	if v != nil && v.ScheduleToCloseTimeoutSeconds != nil {
+		return *v.ScheduleToCloseTimeoutSeconds
 	}
 
 	return
 }
 
-// IsSetBacklogCountHint returns true if BacklogCountHint is not nil.
-func (v *PollForDecisionTaskResponse) IsSetBacklogCountHint() bool {
-	return v != nil && v.BacklogCountHint != nil
+// IsSetScheduleToCloseTimeoutSeconds returns true if ScheduleToCloseTimeoutSeconds is not nil.
+func (v *PollForActivityTaskResponse) IsSetScheduleToCloseTimeoutSeconds() bool {
+	return v != nil && v.ScheduleToCloseTimeoutSeconds != nil
 }
 
-// GetHistory returns the value of History if it is set or its
+// GetStartedTimestamp returns the value of StartedTimestamp if it is set or its
 // zero value if it is unset.
-func (v *PollForDecisionTaskResponse) GetHistory() (o *History) {
-	if v != nil && v.History != nil {
-		return v.History
+func (v *PollForActivityTaskResponse) GetStartedTimestamp() (o int64) {
+	if v != nil && v.StartedTimestamp != nil {
+		return *v.StartedTimestamp
 	}
 
 	return
 }
 
-// IsSetHistory returns true if History is not nil.
-func (v *PollForDecisionTaskResponse) IsSetHistory() bool {
-	return v != nil && v.History != nil
+// IsSetStartedTimestamp returns true if StartedTimestamp is not nil.
+func (v *PollForActivityTaskResponse) IsSetStartedTimestamp() bool {
+	return v != nil && v.StartedTimestamp != nil
 }
 
-// GetNextPageToken returns the value of NextPageToken if it is set or its
+// GetStartToCloseTimeoutSeconds returns the value of StartToCloseTimeoutSeconds if it is set or its
 // zero value if it is unset.
-func (v *PollForDecisionTaskResponse) GetNextPageToken() (o []byte) {
-	if v != nil && v.NextPageToken != nil {
-		return v.NextPageToken
+func (v *PollForActivityTaskResponse) GetStartToCloseTimeoutSeconds() (o int32) {
+	if v != nil && v.StartToCloseTimeoutSeconds != nil {
+		return *v.StartToCloseTimeoutSeconds
 	}
 
 	return
 }
 
-// IsSetNextPageToken returns true if NextPageToken is not nil.
-func (v *PollForDecisionTaskResponse) IsSetNextPageToken() bool {
-	return v != nil && v.NextPageToken != nil
+// IsSetStartToCloseTimeoutSeconds returns true if StartToCloseTimeoutSeconds is not nil.
+func (v *PollForActivityTaskResponse) IsSetStartToCloseTimeoutSeconds() bool {
+	return v != nil && v.StartToCloseTimeoutSeconds != nil
 }
 
-// GetQuery returns the value of Query if it is set or its
+// GetHeartbeatTimeoutSeconds returns the value of HeartbeatTimeoutSeconds if it is set or its
 // zero value if it is unset.
-func (v *PollForDecisionTaskResponse) GetQuery() (o *WorkflowQuery) {
-	if v != nil && v.Query != nil {
-		return v.Query
+func (v *PollForActivityTaskResponse) GetHeartbeatTimeoutSeconds() (o int32) {
+	if v != nil && v.HeartbeatTimeoutSeconds != nil {
+		return *v.HeartbeatTimeoutSeconds
 	}
 
 	return
 }
 
-// IsSetQuery returns true if Query is not nil.
-func (v *PollForDecisionTaskResponse) IsSetQuery() bool {
-	return v != nil && v.Query != nil
+// IsSetHeartbeatTimeoutSeconds returns true if HeartbeatTimeoutSeconds is not nil.
+func (v *PollForActivityTaskResponse) IsSetHeartbeatTimeoutSeconds() bool {
+	return v != nil && v.HeartbeatTimeoutSeconds != nil
 }
 
-// GetWorkflowExecutionTaskList returns the value of WorkflowExecutionTaskList if it is set or its
+// GetAttempt returns the value of Attempt if it is set or its
 // zero value if it is unset.
-func (v *PollForDecisionTaskResponse) GetWorkflowExecutionTaskList() (o *TaskList) {
-	if v != nil && v.WorkflowExecutionTaskList != nil {
-		return v.WorkflowExecutionTaskList
+func (v *PollForActivityTaskResponse) GetAttempt() (o int32) {
+	if v != nil && v.Attempt != nil {
+		return *v.Attempt
 	}
 
 	return
 }
 
-// IsSetWorkflowExecutionTaskList returns true if WorkflowExecutionTaskList is not nil.
-func (v *PollForDecisionTaskResponse) IsSetWorkflowExecutionTaskList() bool {
-	return v != nil && v.WorkflowExecutionTaskList != nil
+// IsSetAttempt returns true if Attempt is not nil.
+func (v *PollForActivityTaskResponse) IsSetAttempt() bool {
+	return v != nil && v.Attempt != nil
 }
 
-type PollerInfo struct {
-	LastAccessTime *int64   `json:"lastAccessTime,omitempty"`
-	Identity       *string  `json:"identity,omitempty"`
-	RatePerSecond  *float64 `json:"ratePerSecond,omitempty"`
+// GetScheduledTimestampOfThisAttempt returns the value of ScheduledTimestampOfThisAttempt if it is set or its
+// zero value if it is unset.
+func (v *PollForActivityTaskResponse) GetScheduledTimestampOfThisAttempt() (o int64) {
+	if v != nil && v.ScheduledTimestampOfThisAttempt != nil {
+		return *v.ScheduledTimestampOfThisAttempt
+	}
+
+	return
 }
 
-// ToWire translates a PollerInfo struct into a Thrift-level intermediate
+// IsSetScheduledTimestampOfThisAttempt returns true if ScheduledTimestampOfThisAttempt is not nil.
+func (v *PollForActivityTaskResponse) IsSetScheduledTimestampOfThisAttempt() bool {
+	return v != nil && v.ScheduledTimestampOfThisAttempt != nil
+}
+
+// GetHeartbeatDetails returns the value of HeartbeatDetails if it is set or its
+// zero value if it is unset.
+func (v *PollForActivityTaskResponse) GetHeartbeatDetails() (o []byte) {
+	if v != nil && v.HeartbeatDetails != nil {
+		return v.HeartbeatDetails
+	}
+
+	return
+}
+
+// IsSetHeartbeatDetails returns true if HeartbeatDetails is not nil.
+func (v *PollForActivityTaskResponse) IsSetHeartbeatDetails() bool {
+	return v != nil && v.HeartbeatDetails != nil
+}
+
+// GetWorkflowType returns the value of WorkflowType if it is set or its
+// zero value if it is unset.
+func (v *PollForActivityTaskResponse) GetWorkflowType() (o *WorkflowType) {
+	if v != nil && v.WorkflowType != nil {
+		return v.WorkflowType
+	}
+
+	return
+}
+
+// IsSetWorkflowType returns true if WorkflowType is not nil.
+func (v *PollForActivityTaskResponse) IsSetWorkflowType() bool {
+	return v != nil && v.WorkflowType != nil
+}
+
+// GetWorkflowDomain returns the value of WorkflowDomain if it is set or its
+// zero value if it is unset.
+func (v *PollForActivityTaskResponse) GetWorkflowDomain() (o string) {
+	if v != nil && v.WorkflowDomain != nil {
+		return *v.WorkflowDomain
+	}
+
+	return
+}
+
+// IsSetWorkflowDomain returns true if WorkflowDomain is not nil.
+func (v *PollForActivityTaskResponse) IsSetWorkflowDomain() bool {
+	return v != nil && v.WorkflowDomain != nil
+}
+
+type PollForDecisionTaskRequest struct {
+	Domain   *string   `json:"domain,omitempty"`
+	TaskList *TaskList `json:"taskList,omitempty"`
+	Identity *string   `json:"identity,omitempty"`
+}
+
+// ToWire translates a PollForDecisionTaskRequest struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -24898,7 +27561,7 @@ type PollerInfo struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *PollerInfo) ToWire() (wire.Value, error) {
+func (v *PollForDecisionTaskRequest) ToWire() (wire.Value, error) {
 	var (
 		fields [3]wire.Field
 		i      int = 0
@@ -24906,24 +27569,24 @@ func (v *PollerInfo) ToWire() (wire.Value, error) {
 		err    error
 	)
 
-	if v.LastAccessTime != nil {
-		w, err = wire.NewValueI64(*(v.LastAccessTime)), error(nil)
+	if v.Domain != nil {
+		w, err = wire.NewValueString(*(v.Domain)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.Identity != nil {
-		w, err = wire.NewValueString(*(v.Identity)), error(nil)
+	if v.TaskList != nil {
+		w, err = v.TaskList.ToWire()
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
-	if v.RatePerSecond != nil {
-		w, err = wire.NewValueDouble(*(v.RatePerSecond)), error(nil)
+	if v.Identity != nil {
+		w, err = wire.NewValueString(*(v.Identity)), error(nil)
 		if err != nil {
 			return w, err
 		}
@@ -24934,11 +27597,11 @@ func (v *PollerInfo) ToWire() (wire.Value, error) {
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-// FromWire deserializes a PollerInfo struct from its Thrift-level
+// FromWire deserializes a PollForDecisionTaskRequest struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a PollerInfo struct
+// An error is returned if we were unable to build a PollForDecisionTaskRequest struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -24946,41 +27609,39 @@ func (v *PollerInfo) ToWire() (wire.Value, error) {
 //     return nil, err
 //   }
 //
-//   var v PollerInfo
+//   var v PollForDecisionTaskRequest
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *PollerInfo) FromWire(w wire.Value) error {
+func (v *PollForDecisionTaskRequest) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
 		case 10:
-			if field.Value.Type() == wire.TI64 {
-				var x int64
-				x, err = field.Value.GetI64(), error(nil)
-				v.LastAccessTime = &x
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Domain = &x
 				if err != nil {
 					return err
 				}
 
 			}
 		case 20:
-			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.Identity = &x
+			if field.Value.Type() == wire.TStruct {
+				v.TaskList, err = _TaskList_Read(field.Value)
 				if err != nil {
 					return err
 				}
 
 			}
 		case 30:
-			if field.Value.Type() == wire.TDouble {
-				var x float64
-				x, err = field.Value.GetDouble(), error(nil)
-				v.RatePerSecond = &x
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Identity = &x
 				if err != nil {
 					return err
 				}
@@ -24992,58 +27653,48 @@ func (v *PollerInfo) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a PollerInfo
+// String returns a readable string representation of a PollForDecisionTaskRequest
 // struct.
-func (v *PollerInfo) String() string {
+func (v *PollForDecisionTaskRequest) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
 	var fields [3]string
 	i := 0
-	if v.LastAccessTime != nil {
-		fields[i] = fmt.Sprintf("LastAccessTime: %v", *(v.LastAccessTime))
+	if v.Domain != nil {
+		fields[i] = fmt.Sprintf("Domain: %v", *(v.Domain))
 		i++
 	}
-	if v.Identity != nil {
-		fields[i] = fmt.Sprintf("Identity: %v", *(v.Identity))
+	if v.TaskList != nil {
+		fields[i] = fmt.Sprintf("TaskList: %v", v.TaskList)
 		i++
 	}
-	if v.RatePerSecond != nil {
-		fields[i] = fmt.Sprintf("RatePerSecond: %v", *(v.RatePerSecond))
+	if v.Identity != nil {
+		fields[i] = fmt.Sprintf("Identity: %v", *(v.Identity))
 		i++
 	}
 
-	return fmt.Sprintf("PollerInfo{%v}", strings.Join(fields[:i], ", "))
-}
-
-func _Double_EqualsPtr(lhs, rhs *float64) bool {
-	if lhs != nil && rhs != nil {
-
-		x := *lhs
-		y := *rhs
-		return (x == y)
-	}
-	return lhs == nil && rhs == nil
+	return fmt.Sprintf("PollForDecisionTaskRequest{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this PollerInfo match the
-// provided PollerInfo.
+// Equals returns true if all the fields of this PollForDecisionTaskRequest match the
+// provided PollForDecisionTaskRequest.
 //
 // This function performs a deep comparison.
-func (v *PollerInfo) Equals(rhs *PollerInfo) bool {
+func (v *PollForDecisionTaskRequest) Equals(rhs *PollForDecisionTaskRequest) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
 		return false
 	}
-	if !_I64_EqualsPtr(v.LastAccessTime, rhs.LastAccessTime) {
+	if !_String_EqualsPtr(v.Domain, rhs.Domain) {
 		return false
 	}
-	if !_String_EqualsPtr(v.Identity, rhs.Identity) {
+	if !((v.TaskList == nil && rhs.TaskList == nil) || (v.TaskList != nil && rhs.TaskList != nil && v.TaskList.Equals(rhs.TaskList))) {
 		return false
 	}
-	if !_Double_EqualsPtr(v.RatePerSecond, rhs.RatePerSecond) {
+	if !_String_EqualsPtr(v.Identity, rhs.Identity) {
 		return false
 	}
 
@@ -25051,73 +27702,83 @@ func (v *PollerInfo) Equals(rhs *PollerInfo) bool {
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of PollerInfo.
-func (v *PollerInfo) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of PollForDecisionTaskRequest.
+func (v *PollForDecisionTaskRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
-	if v.LastAccessTime != nil {
-		enc.AddInt64("lastAccessTime", *v.LastAccessTime)
+	if v.Domain != nil {
+		enc.AddString("domain", *v.Domain)
+	}
+	if v.TaskList != nil {
+		err = multierr.Append(err, enc.AddObject("taskList", v.TaskList))
 	}
 	if v.Identity != nil {
 		enc.AddString("identity", *v.Identity)
 	}
-	if v.RatePerSecond != nil {
-		enc.AddFloat64("ratePerSecond", *v.RatePerSecond)
-	}
 	return err
 }
 
-// GetLastAccessTime returns the value of LastAccessTime if it is set or its
+// GetDomain returns the value of Domain if it is set or its
 // zero value if it is unset.
-func (v *PollerInfo) GetLastAccessTime() (o int64) {
-	if v != nil && v.LastAccessTime != nil {
-		return *v.LastAccessTime
+func (v *PollForDecisionTaskRequest) GetDomain() (o string) {
+	if v != nil && v.Domain != nil {
+		return *v.Domain
 	}
 
 	return
 }
 
-// IsSetLastAccessTime returns true if LastAccessTime is not nil.
-func (v *PollerInfo) IsSetLastAccessTime() bool {
-	return v != nil && v.LastAccessTime != nil
+// IsSetDomain returns true if Domain is not nil.
+func (v *PollForDecisionTaskRequest) IsSetDomain() bool {
+	return v != nil && v.Domain != nil
 }
 
-// GetIdentity returns the value of Identity if it is set or its
+// GetTaskList returns the value of TaskList if it is set or its
 // zero value if it is unset.
-func (v *PollerInfo) GetIdentity() (o string) {
-	if v != nil && v.Identity != nil {
-		return *v.Identity
+func (v *PollForDecisionTaskRequest) GetTaskList() (o *TaskList) {
+	if v != nil && v.TaskList != nil {
+		return v.TaskList
 	}
 
 	return
 }
 
-// IsSetIdentity returns true if Identity is not nil.
-func (v *PollerInfo) IsSetIdentity() bool {
-	return v != nil && v.Identity != nil
+// IsSetTaskList returns true if TaskList is not nil.
+func (v *PollForDecisionTaskRequest) IsSetTaskList() bool {
+	return v != nil && v.TaskList != nil
 }
 
-// GetRatePerSecond returns the value of RatePerSecond if it is set or its
+// GetIdentity returns the value of Identity if it is set or its
 // zero value if it is unset.
-func (v *PollerInfo) GetRatePerSecond() (o float64) {
-	if v != nil && v.RatePerSecond != nil {
-		return *v.RatePerSecond
+func (v *PollForDecisionTaskRequest) GetIdentity() (o string) {
+	if v != nil && v.Identity != nil {
+		return *v.Identity
 	}
 
 	return
 }
 
-// IsSetRatePerSecond returns true if RatePerSecond is not nil.
-func (v *PollerInfo) IsSetRatePerSecond() bool {
-	return v != nil && v.RatePerSecond != nil
+// IsSetIdentity returns true if Identity is not nil.
+func (v *PollForDecisionTaskRequest) IsSetIdentity() bool {
+	return v != nil && v.Identity != nil
 }
 
-type QueryFailedError struct {
-	Message string `json:"message,required"`
+type PollForDecisionTaskResponse struct {
+	TaskToken                 []byte             `json:"taskToken,omitempty"`
+	WorkflowExecution         *WorkflowExecution `json:"workflowExecution,omitempty"`
+	WorkflowType              *WorkflowType      `json:"workflowType,omitempty"`
+	PreviousStartedEventId    *int64             `json:"previousStartedEventId,omitempty"`
+	StartedEventId            *int64             `json:"startedEventId,omitempty"`
+	Attempt                   *int64             `json:"attempt,omitempty"`
+	BacklogCountHint          *int64             `json:"backlogCountHint,omitempty"`
+	History                   *History           `json:"history,omitempty"`
+	NextPageToken             []byte             `json:"nextPageToken,omitempty"`
+	Query                     *WorkflowQuery     `json:"query,omitempty"`
+	WorkflowExecutionTaskList *TaskList          `json:"WorkflowExecutionTaskList,omitempty"`
 }
 
-// ToWire translates a QueryFailedError struct into a Thrift-level intermediate
+// ToWire translates a PollForDecisionTaskResponse struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -25132,29 +27793,117 @@ type QueryFailedError struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *QueryFailedError) ToWire() (wire.Value, error) {
+func (v *PollForDecisionTaskResponse) ToWire() (wire.Value, error) {
 	var (
-		fields [1]wire.Field
+		fields [11]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
 	)
 
-	w, err = wire.NewValueString(v.Message), error(nil)
-	if err != nil {
-		return w, err
+	if v.TaskToken != nil {
+		w, err = wire.NewValueBinary(v.TaskToken), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.WorkflowExecution != nil {
+		w, err = v.WorkflowExecution.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.WorkflowType != nil {
+		w, err = v.WorkflowType.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+	if v.PreviousStartedEventId != nil {
+		w, err = wire.NewValueI64(*(v.PreviousStartedEventId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+	if v.StartedEventId != nil {
+		w, err = wire.NewValueI64(*(v.StartedEventId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 50, Value: w}
+		i++
+	}
+	if v.Attempt != nil {
+		w, err = wire.NewValueI64(*(v.Attempt)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 51, Value: w}
+		i++
+	}
+	if v.BacklogCountHint != nil {
+		w, err = wire.NewValueI64(*(v.BacklogCountHint)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 54, Value: w}
+		i++
+	}
+	if v.History != nil {
+		w, err = v.History.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 60, Value: w}
+		i++
+	}
+	if v.NextPageToken != nil {
+		w, err = wire.NewValueBinary(v.NextPageToken), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 70, Value: w}
+		i++
+	}
+	if v.Query != nil {
+		w, err = v.Query.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 80, Value: w}
+		i++
+	}
+	if v.WorkflowExecutionTaskList != nil {
+		w, err = v.WorkflowExecutionTaskList.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 90, Value: w}
+		i++
 	}
-	fields[i] = wire.Field{ID: 1, Value: w}
-	i++
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-// FromWire deserializes a QueryFailedError struct from its Thrift-level
+func _WorkflowQuery_Read(w wire.Value) (*WorkflowQuery, error) {
+	var v WorkflowQuery
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a PollForDecisionTaskResponse struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a QueryFailedError struct
+// An error is returned if we were unable to build a PollForDecisionTaskResponse struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -25162,269 +27911,436 @@ func (v *QueryFailedError) ToWire() (wire.Value, error) {
 //     return nil, err
 //   }
 //
-//   var v QueryFailedError
+//   var v PollForDecisionTaskResponse
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *QueryFailedError) FromWire(w wire.Value) error {
+func (v *PollForDecisionTaskResponse) FromWire(w wire.Value) error {
 	var err error
 
-	messageIsSet := false
-
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
-		case 1:
+		case 10:
 			if field.Value.Type() == wire.TBinary {
-				v.Message, err = field.Value.GetString(), error(nil)
+				v.TaskToken, err = field.Value.GetBinary(), error(nil)
 				if err != nil {
 					return err
 				}
-				messageIsSet = true
+
 			}
-		}
-	}
+		case 20:
+			if field.Value.Type() == wire.TStruct {
+				v.WorkflowExecution, err = _WorkflowExecution_Read(field.Value)
+				if err != nil {
+					return err
+				}
 
-	if !messageIsSet {
-		return errors.New("field Message of QueryFailedError is required")
+			}
+		case 30:
+			if field.Value.Type() == wire.TStruct {
+				v.WorkflowType, err = _WorkflowType_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.PreviousStartedEventId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 50:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.StartedEventId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 51:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.Attempt = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 54:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.BacklogCountHint = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 60:
+			if field.Value.Type() == wire.TStruct {
+				v.History, err = _History_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 70:
+			if field.Value.Type() == wire.TBinary {
+				v.NextPageToken, err = field.Value.GetBinary(), error(nil)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 80:
+			if field.Value.Type() == wire.TStruct {
+				v.Query, err = _WorkflowQuery_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 90:
+			if field.Value.Type() == wire.TStruct {
+				v.WorkflowExecutionTaskList, err = _TaskList_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
 	}
 
 	return nil
 }
 
-// String returns a readable string representation of a QueryFailedError
+// String returns a readable string representation of a PollForDecisionTaskResponse
 // struct.
-func (v *QueryFailedError) String() string {
+func (v *PollForDecisionTaskResponse) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [1]string
+	var fields [11]string
 	i := 0
-	fields[i] = fmt.Sprintf("Message: %v", v.Message)
-	i++
+	if v.TaskToken != nil {
+		fields[i] = fmt.Sprintf("TaskToken: %v", v.TaskToken)
+		i++
+	}
+	if v.WorkflowExecution != nil {
+		fields[i] = fmt.Sprintf("WorkflowExecution: %v", v.WorkflowExecution)
+		i++
+	}
+	if v.WorkflowType != nil {
+		fields[i] = fmt.Sprintf("WorkflowType: %v", v.WorkflowType)
+		i++
+	}
+	if v.PreviousStartedEventId != nil {
+		fields[i] = fmt.Sprintf("PreviousStartedEventId: %v", *(v.PreviousStartedEventId))
+		i++
+	}
+	if v.StartedEventId != nil {
+		fields[i] = fmt.Sprintf("StartedEventId: %v", *(v.StartedEventId))
+		i++
+	}
+	if v.Attempt != nil {
+		fields[i] = fmt.Sprintf("Attempt: %v", *(v.Attempt))
+		i++
+	}
+	if v.BacklogCountHint != nil {
+		fields[i] = fmt.Sprintf("BacklogCountHint: %v", *(v.BacklogCountHint))
+		i++
+	}
+	if v.History != nil {
+		fields[i] = fmt.Sprintf("History: %v", v.History)
+		i++
+	}
+	if v.NextPageToken != nil {
+		fields[i] = fmt.Sprintf("NextPageToken: %v", v.NextPageToken)
+		i++
+	}
+	if v.Query != nil {
+		fields[i] = fmt.Sprintf("Query: %v", v.Query)
+		i++
+	}
+	if v.WorkflowExecutionTaskList != nil {
+		fields[i] = fmt.Sprintf("WorkflowExecutionTaskList: %v", v.WorkflowExecutionTaskList)
+		i++
+	}
 
-	return fmt.Sprintf("QueryFailedError{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("PollForDecisionTaskResponse{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this QueryFailedError match the
-// provided QueryFailedError.
+// Equals returns true if all the fields of this PollForDecisionTaskResponse match the
+// provided PollForDecisionTaskResponse.
 //
 // This function performs a deep comparison.
-func (v *QueryFailedError) Equals(rhs *QueryFailedError) bool {
+func (v *PollForDecisionTaskResponse) Equals(rhs *PollForDecisionTaskResponse) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
 		return false
 	}
-	if !(v.Message == rhs.Message) {
+	if !((v.TaskToken == nil && rhs.TaskToken == nil) || (v.TaskToken != nil && rhs.TaskToken != nil && bytes.Equal(v.TaskToken, rhs.TaskToken))) {
 		return false
 	}
-
-	return true
-}
-
-// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of QueryFailedError.
-func (v *QueryFailedError) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
-	if v == nil {
-		return nil
+	if !((v.WorkflowExecution == nil && rhs.WorkflowExecution == nil) || (v.WorkflowExecution != nil && rhs.WorkflowExecution != nil && v.WorkflowExecution.Equals(rhs.WorkflowExecution))) {
+		return false
 	}
-	enc.AddString("message", v.Message)
-	return err
-}
-
-// GetMessage returns the value of Message if it is set or its
-// zero value if it is unset.
-func (v *QueryFailedError) GetMessage() (o string) {
-	if v != nil {
-		o = v.Message
+	if !((v.WorkflowType == nil && rhs.WorkflowType == nil) || (v.WorkflowType != nil && rhs.WorkflowType != nil && v.WorkflowType.Equals(rhs.WorkflowType))) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.PreviousStartedEventId, rhs.PreviousStartedEventId) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.StartedEventId, rhs.StartedEventId) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.Attempt, rhs.Attempt) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.BacklogCountHint, rhs.BacklogCountHint) {
+		return false
+	}
+	if !((v.History == nil && rhs.History == nil) || (v.History != nil && rhs.History != nil && v.History.Equals(rhs.History))) {
+		return false
+	}
+	if !((v.NextPageToken == nil && rhs.NextPageToken == nil) || (v.NextPageToken != nil && rhs.NextPageToken != nil && bytes.Equal(v.NextPageToken, rhs.NextPageToken))) {
+		return false
+	}
+	if !((v.Query == nil && rhs.Query == nil) || (v.Query != nil && rhs.Query != nil && v.Query.Equals(rhs.Query))) {
+		return false
+	}
+	if !((v.WorkflowExecutionTaskList == nil && rhs.WorkflowExecutionTaskList == nil) || (v.WorkflowExecutionTaskList != nil && rhs.WorkflowExecutionTaskList != nil && v.WorkflowExecutionTaskList.Equals(rhs.WorkflowExecutionTaskList))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of PollForDecisionTaskResponse.
+func (v *PollForDecisionTaskResponse) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.TaskToken != nil {
+		enc.AddString("taskToken", base64.StdEncoding.EncodeToString(v.TaskToken))
+	}
+	if v.WorkflowExecution != nil {
+		err = multierr.Append(err, enc.AddObject("workflowExecution", v.WorkflowExecution))
+	}
+	if v.WorkflowType != nil {
+		err = multierr.Append(err, enc.AddObject("workflowType", v.WorkflowType))
+	}
+	if v.PreviousStartedEventId != nil {
+		enc.AddInt64("previousStartedEventId", *v.PreviousStartedEventId)
+	}
+	if v.StartedEventId != nil {
+		enc.AddInt64("startedEventId", *v.StartedEventId)
+	}
+	if v.Attempt != nil {
+		enc.AddInt64("attempt", *v.Attempt)
+	}
+	if v.BacklogCountHint != nil {
+		enc.AddInt64("backlogCountHint", *v.BacklogCountHint)
+	}
+	if v.History != nil {
+		err = multierr.Append(err, enc.AddObject("history", v.History))
+	}
+	if v.NextPageToken != nil {
+		enc.AddString("nextPageToken", base64.StdEncoding.EncodeToString(v.NextPageToken))
+	}
+	if v.Query != nil {
+		err = multierr.Append(err, enc.AddObject("query", v.Query))
+	}
+	if v.WorkflowExecutionTaskList != nil {
+		err = multierr.Append(err, enc.AddObject("WorkflowExecutionTaskList", v.WorkflowExecutionTaskList))
+	}
+	return err
+}
+
+// GetTaskToken returns the value of TaskToken if it is set or its
+// zero value if it is unset.
+func (v *PollForDecisionTaskResponse) GetTaskToken() (o []byte) {
+	if v != nil && v.TaskToken != nil {
+		return v.TaskToken
 	}
+
 	return
 }
 
-func (v *QueryFailedError) Error() string {
-	return v.String()
+// IsSetTaskToken returns true if TaskToken is not nil.
+func (v *PollForDecisionTaskResponse) IsSetTaskToken() bool {
+	return v != nil && v.TaskToken != nil
 }
 
-type QueryTaskCompletedType int32
+// GetWorkflowExecution returns the value of WorkflowExecution if it is set or its
+// zero value if it is unset.
+func (v *PollForDecisionTaskResponse) GetWorkflowExecution() (o *WorkflowExecution) {
+	if v != nil && v.WorkflowExecution != nil {
+		return v.WorkflowExecution
+	}
 
-const (
-	QueryTaskCompletedTypeCompleted QueryTaskCompletedType = 0
-	QueryTaskCompletedTypeFailed    QueryTaskCompletedType = 1
-)
+	return
+}
 
-// QueryTaskCompletedType_Values returns all recognized values of QueryTaskCompletedType.
-func QueryTaskCompletedType_Values() []QueryTaskCompletedType {
-	return []QueryTaskCompletedType{
-		QueryTaskCompletedTypeCompleted,
-		QueryTaskCompletedTypeFailed,
+// IsSetWorkflowExecution returns true if WorkflowExecution is not nil.
+func (v *PollForDecisionTaskResponse) IsSetWorkflowExecution() bool {
+	return v != nil && v.WorkflowExecution != nil
+}
+
+// GetWorkflowType returns the value of WorkflowType if it is set or its
+// zero value if it is unset.
+func (v *PollForDecisionTaskResponse) GetWorkflowType() (o *WorkflowType) {
+	if v != nil && v.WorkflowType != nil {
+		return v.WorkflowType
 	}
+
+	return
 }
 
-// UnmarshalText tries to decode QueryTaskCompletedType from a byte slice
-// containing its name.
-//
-//   var v QueryTaskCompletedType
-//   err := v.UnmarshalText([]byte("COMPLETED"))
-func (v *QueryTaskCompletedType) UnmarshalText(value []byte) error {
-	switch s := string(value); s {
-	case "COMPLETED":
-		*v = QueryTaskCompletedTypeCompleted
-		return nil
-	case "FAILED":
-		*v = QueryTaskCompletedTypeFailed
-		return nil
-	default:
-		val, err := strconv.ParseInt(s, 10, 32)
-		if err != nil {
-			return fmt.Errorf("unknown enum value %q for %q: %v", s, "QueryTaskCompletedType", err)
-		}
-		*v = QueryTaskCompletedType(val)
-		return nil
+// IsSetWorkflowType returns true if WorkflowType is not nil.
+func (v *PollForDecisionTaskResponse) IsSetWorkflowType() bool {
+	return v != nil && v.WorkflowType != nil
+}
+
+// GetPreviousStartedEventId returns the value of PreviousStartedEventId if it is set or its
+// zero value if it is unset.
+func (v *PollForDecisionTaskResponse) GetPreviousStartedEventId() (o int64) {
+	if v != nil && v.PreviousStartedEventId != nil {
+		return *v.PreviousStartedEventId
 	}
+
+	return
 }
 
-// MarshalText encodes QueryTaskCompletedType to text.
-//
-// If the enum value is recognized, its name is returned. Otherwise,
-// its integer value is returned.
-//
-// This implements the TextMarshaler interface.
-func (v QueryTaskCompletedType) MarshalText() ([]byte, error) {
-	switch int32(v) {
-	case 0:
-		return []byte("COMPLETED"), nil
-	case 1:
-		return []byte("FAILED"), nil
+// IsSetPreviousStartedEventId returns true if PreviousStartedEventId is not nil.
+func (v *PollForDecisionTaskResponse) IsSetPreviousStartedEventId() bool {
+	return v != nil && v.PreviousStartedEventId != nil
+}
+
+// GetStartedEventId returns the value of StartedEventId if it is set or its
+// zero value if it is unset.
+func (v *PollForDecisionTaskResponse) GetStartedEventId() (o int64) {
+	if v != nil && v.StartedEventId != nil {
+		return *v.StartedEventId
 	}
-	return []byte(strconv.FormatInt(int64(v), 10)), nil
+
+	return
 }
 
-// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of QueryTaskCompletedType.
-// Enums are logged as objects, where the value is logged with key "value", and
-// if this value's name is known, the name is logged with key "name".
-func (v QueryTaskCompletedType) MarshalLogObject(enc zapcore.ObjectEncoder) error {
-	enc.AddInt32("value", int32(v))
-	switch int32(v) {
-	case 0:
-		enc.AddString("name", "COMPLETED")
-	case 1:
-		enc.AddString("name", "FAILED")
+// IsSetStartedEventId returns true if StartedEventId is not nil.
+func (v *PollForDecisionTaskResponse) IsSetStartedEventId() bool {
+	return v != nil && v.StartedEventId != nil
+}
+
+// GetAttempt returns the value of Attempt if it is set or its
+// zero value if it is unset.
+func (v *PollForDecisionTaskResponse) GetAttempt() (o int64) {
+	if v != nil && v.Attempt != nil {
+		return *v.Attempt
 	}
-	return nil
+
+	return
 }
 
-// Ptr returns a pointer to this enum value.
-func (v QueryTaskCompletedType) Ptr() *QueryTaskCompletedType {
-	return &v
+// IsSetAttempt returns true if Attempt is not nil.
+func (v *PollForDecisionTaskResponse) IsSetAttempt() bool {
+	return v != nil && v.Attempt != nil
 }
 
-// ToWire translates QueryTaskCompletedType into a Thrift-level intermediate
-// representation. This intermediate representation may be serialized
-// into bytes using a ThriftRW protocol implementation.
-//
-// Enums are represented as 32-bit integers over the wire.
-func (v QueryTaskCompletedType) ToWire() (wire.Value, error) {
-	return wire.NewValueI32(int32(v)), nil
+// GetBacklogCountHint returns the value of BacklogCountHint if it is set or its
+// zero value if it is unset.
+func (v *PollForDecisionTaskResponse) GetBacklogCountHint() (o int64) {
+	if v != nil && v.BacklogCountHint != nil {
+		return *v.BacklogCountHint
+	}
+
+	return
 }
 
-// FromWire deserializes QueryTaskCompletedType from its Thrift-level
-// representation.
-//
-//   x, err := binaryProtocol.Decode(reader, wire.TI32)
-//   if err != nil {
-//     return QueryTaskCompletedType(0), err
-//   }
-//
-//   var v QueryTaskCompletedType
-//   if err := v.FromWire(x); err != nil {
-//     return QueryTaskCompletedType(0), err
-//   }
-//   return v, nil
-func (v *QueryTaskCompletedType) FromWire(w wire.Value) error {
-	*v = (QueryTaskCompletedType)(w.GetI32())
-	return nil
+// IsSetBacklogCountHint returns true if BacklogCountHint is not nil.
+func (v *PollForDecisionTaskResponse) IsSetBacklogCountHint() bool {
+	return v != nil && v.BacklogCountHint != nil
 }
 
-// String returns a readable string representation of QueryTaskCompletedType.
-func (v QueryTaskCompletedType) String() string {
-	w := int32(v)
-	switch w {
-	case 0:
-		return "COMPLETED"
-	case 1:
-		return "FAILED"
+// GetHistory returns the value of History if it is set or its
+// zero value if it is unset.
+func (v *PollForDecisionTaskResponse) GetHistory() (o *History) {
+	if v != nil && v.History != nil {
+		return v.History
 	}
-	return fmt.Sprintf("QueryTaskCompletedType(%d)", w)
+
+	return
 }
 
-// Equals returns true if this QueryTaskCompletedType value matches the provided
-// value.
-func (v QueryTaskCompletedType) Equals(rhs QueryTaskCompletedType) bool {
-	return v == rhs
+// IsSetHistory returns true if History is not nil.
+func (v *PollForDecisionTaskResponse) IsSetHistory() bool {
+	return v != nil && v.History != nil
 }
 
-// MarshalJSON serializes QueryTaskCompletedType into JSON.
-//
-// If the enum value is recognized, its name is returned. Otherwise,
-// its integer value is returned.
-//
-// This implements json.Marshaler.
-func (v QueryTaskCompletedType) MarshalJSON() ([]byte, error) {
-	switch int32(v) {
-	case 0:
-		return ([]byte)("\"COMPLETED\""), nil
-	case 1:
-		return ([]byte)("\"FAILED\""), nil
+// GetNextPageToken returns the value of NextPageToken if it is set or its
+// zero value if it is unset.
+func (v *PollForDecisionTaskResponse) GetNextPageToken() (o []byte) {
+	if v != nil && v.NextPageToken != nil {
+		return v.NextPageToken
 	}
-	return ([]byte)(strconv.FormatInt(int64(v), 10)), nil
+
+	return
 }
 
-// UnmarshalJSON attempts to decode QueryTaskCompletedType from its JSON
-// representation.
-//
-// This implementation supports both, numeric and string inputs. If a
-// string is provided, it must be a known enum name.
-//
-// This implements json.Unmarshaler.
-func (v *QueryTaskCompletedType) UnmarshalJSON(text []byte) error {
-	d := json.NewDecoder(bytes.NewReader(text))
-	d.UseNumber()
-	t, err := d.Token()
-	if err != nil {
-		return err
+// IsSetNextPageToken returns true if NextPageToken is not nil.
+func (v *PollForDecisionTaskResponse) IsSetNextPageToken() bool {
+	return v != nil && v.NextPageToken != nil
+}
+
+// GetQuery returns the value of Query if it is set or its
+// zero value if it is unset.
+func (v *PollForDecisionTaskResponse) GetQuery() (o *WorkflowQuery) {
+	if v != nil && v.Query != nil {
+		return v.Query
 	}
 
-	switch w := t.(type) {
-	case json.Number:
-		x, err := w.Int64()
-		if err != nil {
-			return err
-		}
-		if x > math.MaxInt32 {
-			return fmt.Errorf("enum overflow from JSON %q for %q", text, "QueryTaskCompletedType")
-		}
-		if x < math.MinInt32 {
-			return fmt.Errorf("enum underflow from JSON %q for %q", text, "QueryTaskCompletedType")
-		}
-		*v = (QueryTaskCompletedType)(x)
-		return nil
-	case string:
-		return v.UnmarshalText([]byte(w))
-	default:
-		return fmt.Errorf("invalid JSON value %q (%T) to unmarshal into %q", t, t, "QueryTaskCompletedType")
+	return
+}
+
+// IsSetQuery returns true if Query is not nil.
+func (v *PollForDecisionTaskResponse) IsSetQuery() bool {
+	return v != nil && v.Query != nil
+}
+
+// GetWorkflowExecutionTaskList returns the value of WorkflowExecutionTaskList if it is set or its
+// zero value if it is unset.
+func (v *PollForDecisionTaskResponse) GetWorkflowExecutionTaskList() (o *TaskList) {
+	if v != nil && v.WorkflowExecutionTaskList != nil {
+		return v.WorkflowExecutionTaskList
 	}
+
+	return
 }
 
-type QueryWorkflowRequest struct {
-	Domain    *string            `json:"domain,omitempty"`
-	Execution *WorkflowExecution `json:"execution,omitempty"`
-	Query     *WorkflowQuery     `json:"query,omitempty"`
+// IsSetWorkflowExecutionTaskList returns true if WorkflowExecutionTaskList is not nil.
+func (v *PollForDecisionTaskResponse) IsSetWorkflowExecutionTaskList() bool {
+	return v != nil && v.WorkflowExecutionTaskList != nil
 }
 
-// ToWire translates a QueryWorkflowRequest struct into a Thrift-level intermediate
+type PollerInfo struct {
+	LastAccessTime *int64   `json:"lastAccessTime,omitempty"`
+	Identity       *string  `json:"identity,omitempty"`
+	RatePerSecond  *float64 `json:"ratePerSecond,omitempty"`
+}
+
+// ToWire translates a PollerInfo struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -25439,7 +28355,7 @@ type QueryWorkflowRequest struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *QueryWorkflowRequest) ToWire() (wire.Value, error) {
+func (v *PollerInfo) ToWire() (wire.Value, error) {
 	var (
 		fields [3]wire.Field
 		i      int = 0
@@ -25447,24 +28363,24 @@ func (v *QueryWorkflowRequest) ToWire() (wire.Value, error) {
 		err    error
 	)
 
-	if v.Domain != nil {
-		w, err = wire.NewValueString(*(v.Domain)), error(nil)
+	if v.LastAccessTime != nil {
+		w, err = wire.NewValueI64(*(v.LastAccessTime)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.Execution != nil {
-		w, err = v.Execution.ToWire()
+	if v.Identity != nil {
+		w, err = wire.NewValueString(*(v.Identity)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
-	if v.Query != nil {
-		w, err = v.Query.ToWire()
+	if v.RatePerSecond != nil {
+		w, err = wire.NewValueDouble(*(v.RatePerSecond)), error(nil)
 		if err != nil {
 			return w, err
 		}
@@ -25475,11 +28391,11 @@ func (v *QueryWorkflowRequest) ToWire() (wire.Value, error) {
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-// FromWire deserializes a QueryWorkflowRequest struct from its Thrift-level
+// FromWire deserializes a PollerInfo struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a QueryWorkflowRequest struct
+// An error is returned if we were unable to build a PollerInfo struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -25487,37 +28403,41 @@ func (v *QueryWorkflowRequest) ToWire() (wire.Value, error) {
 //     return nil, err
 //   }
 //
-//   var v QueryWorkflowRequest
+//   var v PollerInfo
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *QueryWorkflowRequest) FromWire(w wire.Value) error {
+func (v *PollerInfo) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
 		case 10:
-			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.Domain = &x
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.LastAccessTime = &x
 				if err != nil {
 					return err
 				}
 
 			}
 		case 20:
-			if field.Value.Type() == wire.TStruct {
-				v.Execution, err = _WorkflowExecution_Read(field.Value)
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Identity = &x
 				if err != nil {
 					return err
 				}
 
 			}
 		case 30:
-			if field.Value.Type() == wire.TStruct {
-				v.Query, err = _WorkflowQuery_Read(field.Value)
+			if field.Value.Type() == wire.TDouble {
+				var x float64
+				x, err = field.Value.GetDouble(), error(nil)
+				v.RatePerSecond = &x
 				if err != nil {
 					return err
 				}
@@ -25529,48 +28449,58 @@ func (v *QueryWorkflowRequest) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a QueryWorkflowRequest
+// String returns a readable string representation of a PollerInfo
 // struct.
-func (v *QueryWorkflowRequest) String() string {
+func (v *PollerInfo) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
 	var fields [3]string
 	i := 0
-	if v.Domain != nil {
-		fields[i] = fmt.Sprintf("Domain: %v", *(v.Domain))
+	if v.LastAccessTime != nil {
+		fields[i] = fmt.Sprintf("LastAccessTime: %v", *(v.LastAccessTime))
 		i++
 	}
-	if v.Execution != nil {
-		fields[i] = fmt.Sprintf("Execution: %v", v.Execution)
+	if v.Identity != nil {
+		fields[i] = fmt.Sprintf("Identity: %v", *(v.Identity))
 		i++
 	}
-	if v.Query != nil {
-		fields[i] = fmt.Sprintf("Query: %v", v.Query)
+	if v.RatePerSecond != nil {
+		fields[i] = fmt.Sprintf("RatePerSecond: %v", *(v.RatePerSecond))
 		i++
 	}
 
-	return fmt.Sprintf("QueryWorkflowRequest{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("PollerInfo{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this QueryWorkflowRequest match the
-// provided QueryWorkflowRequest.
+func _Double_EqualsPtr(lhs, rhs *float64) bool {
+	if lhs != nil && rhs != nil {
+
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+// Equals returns true if all the fields of this PollerInfo match the
+// provided PollerInfo.
 //
 // This function performs a deep comparison.
-func (v *QueryWorkflowRequest) Equals(rhs *QueryWorkflowRequest) bool {
+func (v *PollerInfo) Equals(rhs *PollerInfo) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
 		return false
 	}
-	if !_String_EqualsPtr(v.Domain, rhs.Domain) {
+	if !_I64_EqualsPtr(v.LastAccessTime, rhs.LastAccessTime) {
 		return false
 	}
-	if !((v.Execution == nil && rhs.Execution == nil) || (v.Execution != nil && rhs.Execution != nil && v.Execution.Equals(rhs.Execution))) {
+	if !_String_EqualsPtr(v.Identity, rhs.Identity) {
 		return false
 	}
-	if !((v.Query == nil && rhs.Query == nil) || (v.Query != nil && rhs.Query != nil && v.Query.Equals(rhs.Query))) {
+	if !_Double_EqualsPtr(v.RatePerSecond, rhs.RatePerSecond) {
 		return false
 	}
 
@@ -25578,73 +28508,73 @@ func (v *QueryWorkflowRequest) Equals(rhs *QueryWorkflowRequest) bool {
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of QueryWorkflowRequest.
-func (v *QueryWorkflowRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of PollerInfo.
+func (v *PollerInfo) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
-	if v.Domain != nil {
-		enc.AddString("domain", *v.Domain)
+	if v.LastAccessTime != nil {
+		enc.AddInt64("lastAccessTime", *v.LastAccessTime)
 	}
-	if v.Execution != nil {
-		err = multierr.Append(err, enc.AddObject("execution", v.Execution))
+	if v.Identity != nil {
+		enc.AddString("identity", *v.Identity)
 	}
-	if v.Query != nil {
-		err = multierr.Append(err, enc.AddObject("query", v.Query))
+	if v.RatePerSecond != nil {
+		enc.AddFloat64("ratePerSecond", *v.RatePerSecond)
 	}
 	return err
 }
 
-// GetDomain returns the value of Domain if it is set or its
+// GetLastAccessTime returns the value of LastAccessTime if it is set or its
 // zero value if it is unset.
-func (v *QueryWorkflowRequest) GetDomain() (o string) {
-	if v != nil && v.Domain != nil {
-		return *v.Domain
+func (v *PollerInfo) GetLastAccessTime() (o int64) {
+	if v != nil && v.LastAccessTime != nil {
+		return *v.LastAccessTime
 	}
 
 	return
 }
 
-// IsSetDomain returns true if Domain is not nil.
-func (v *QueryWorkflowRequest) IsSetDomain() bool {
-	return v != nil && v.Domain != nil
+// IsSetLastAccessTime returns true if LastAccessTime is not nil.
+func (v *PollerInfo) IsSetLastAccessTime() bool {
+	return v != nil && v.LastAccessTime != nil
 }
 
-// GetExecution returns the value of Execution if it is set or its
+// GetIdentity returns the value of Identity if it is set or its
 // zero value if it is unset.
-func (v *QueryWorkflowRequest) GetExecution() (o *WorkflowExecution) {
-	if v != nil && v.Execution != nil {
-		return v.Execution
+func (v *PollerInfo) GetIdentity() (o string) {
+	if v != nil && v.Identity != nil {
+		return *v.Identity
 	}
 
 	return
 }
 
-// IsSetExecution returns true if Execution is not nil.
-func (v *QueryWorkflowRequest) IsSetExecution() bool {
-	return v != nil && v.Execution != nil
+// IsSetIdentity returns true if Identity is not nil.
+func (v *PollerInfo) IsSetIdentity() bool {
+	return v != nil && v.Identity != nil
 }
 
-// GetQuery returns the value of Query if it is set or its
+// GetRatePerSecond returns the value of RatePerSecond if it is set or its
 // zero value if it is unset.
-func (v *QueryWorkflowRequest) GetQuery() (o *WorkflowQuery) {
-	if v != nil && v.Query != nil {
-		return v.Query
+func (v *PollerInfo) GetRatePerSecond() (o float64) {
+	if v != nil && v.RatePerSecond != nil {
+		return *v.RatePerSecond
 	}
 
 	return
 }
 
-// IsSetQuery returns true if Query is not nil.
-func (v *QueryWorkflowRequest) IsSetQuery() bool {
-	return v != nil && v.Query != nil
+// IsSetRatePerSecond returns true if RatePerSecond is not nil.
+func (v *PollerInfo) IsSetRatePerSecond() bool {
+	return v != nil && v.RatePerSecond != nil
 }
 
-type QueryWorkflowResponse struct {
-	QueryResult []byte `json:"queryResult,omitempty"`
+type QueryFailedError struct {
+	Message string `json:"message,required"`
 }
 
-// ToWire translates a QueryWorkflowResponse struct into a Thrift-level intermediate
+// ToWire translates a QueryFailedError struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -25659,7 +28589,7 @@ type QueryWorkflowResponse struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *QueryWorkflowResponse) ToWire() (wire.Value, error) {
+func (v *QueryFailedError) ToWire() (wire.Value, error) {
 	var (
 		fields [1]wire.Field
 		i      int = 0
@@ -25667,23 +28597,21 @@ func (v *QueryWorkflowResponse) ToWire() (wire.Value, error) {
 		err    error
 	)
 
-	if v.QueryResult != nil {
-		w, err = wire.NewValueBinary(v.QueryResult), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 10, Value: w}
-		i++
+	w, err = wire.NewValueString(v.Message), error(nil)
+	if err != nil {
+		return w, err
 	}
+	fields[i] = wire.Field{ID: 1, Value: w}
+	i++
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-// FromWire deserializes a QueryWorkflowResponse struct from its Thrift-level
+// FromWire deserializes a QueryFailedError struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a QueryWorkflowResponse struct
+// An error is returned if we were unable to build a QueryFailedError struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -25691,58 +28619,62 @@ func (v *QueryWorkflowResponse) ToWire() (wire.Value, error) {
 //     return nil, err
 //   }
 //
-//   var v QueryWorkflowResponse
+//   var v QueryFailedError
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *QueryWorkflowResponse) FromWire(w wire.Value) error {
+func (v *QueryFailedError) FromWire(w wire.Value) error {
 	var err error
 
+	messageIsSet := false
+
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
-		case 10:
+		case 1:
 			if field.Value.Type() == wire.TBinary {
-				v.QueryResult, err = field.Value.GetBinary(), error(nil)
+				v.Message, err = field.Value.GetString(), error(nil)
 				if err != nil {
 					return err
 				}
-
+				messageIsSet = true
 			}
 		}
 	}
 
+	if !messageIsSet {
+		return errors.New("field Message of QueryFailedError is required")
+	}
+
 	return nil
 }
 
-// String returns a readable string representation of a QueryWorkflowResponse
+// String returns a readable string representation of a QueryFailedError
 // struct.
-func (v *QueryWorkflowResponse) String() string {
+func (v *QueryFailedError) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
 	var fields [1]string
 	i := 0
-	if v.QueryResult != nil {
-		fields[i] = fmt.Sprintf("QueryResult: %v", v.QueryResult)
-		i++
-	}
+	fields[i] = fmt.Sprintf("Message: %v", v.Message)
+	i++
 
-	return fmt.Sprintf("QueryWorkflowResponse{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("QueryFailedError{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this QueryWorkflowResponse match the
-// provided QueryWorkflowResponse.
+// Equals returns true if all the fields of this QueryFailedError match the
+// provided QueryFailedError.
 //
 // This function performs a deep comparison.
-func (v *QueryWorkflowResponse) Equals(rhs *QueryWorkflowResponse) bool {
+func (v *QueryFailedError) Equals(rhs *QueryFailedError) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
 		return false
 	}
-	if !((v.QueryResult == nil && rhs.QueryResult == nil) || (v.QueryResult != nil && rhs.QueryResult != nil && bytes.Equal(v.QueryResult, rhs.QueryResult))) {
+	if !(v.Message == rhs.Message) {
 		return false
 	}
 
@@ -25750,42 +28682,206 @@ func (v *QueryWorkflowResponse) Equals(rhs *QueryWorkflowResponse) bool {
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of QueryWorkflowResponse.
-func (v *QueryWorkflowResponse) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of QueryFailedError.
+func (v *QueryFailedError) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
-	if v.QueryResult != nil {
-		enc.AddString("queryResult", base64.StdEncoding.EncodeToString(v.QueryResult))
-	}
+	enc.AddString("message", v.Message)
 	return err
 }
 
-// GetQueryResult returns the value of QueryResult if it is set or its
+// GetMessage returns the value of Message if it is set or its
 // zero value if it is unset.
-func (v *QueryWorkflowResponse) GetQueryResult() (o []byte) {
-	if v != nil && v.QueryResult != nil {
-		return v.QueryResult
+func (v *QueryFailedError) GetMessage() (o string) {
+	if v != nil {
+		o = v.Message
 	}
-
 	return
 }
 
-// IsSetQueryResult returns true if QueryResult is not nil.
-func (v *QueryWorkflowResponse) IsSetQueryResult() bool {
-	return v != nil && v.QueryResult != nil
+func (v *QueryFailedError) Error() string {
+	return v.String()
 }
 
-type RecordActivityTaskHeartbeatByIDRequest struct {
-	Domain     *string `json:"domain,omitempty"`
-	WorkflowID *string `json:"workflowID,omitempty"`
-	RunID      *string `json:"runID,omitempty"`
-	ActivityID *string `json:"activityID,omitempty"`
-	Details    []byte  `json:"details,omitempty"`
-	Identity   *string `json:"identity,omitempty"`
+type QueryTaskCompletedType int32
+
+const (
+	QueryTaskCompletedTypeCompleted QueryTaskCompletedType = 0
+	QueryTaskCompletedTypeFailed    QueryTaskCompletedType = 1
+)
+
+// QueryTaskCompletedType_Values returns all recognized values of QueryTaskCompletedType.
+func QueryTaskCompletedType_Values() []QueryTaskCompletedType {
+	return []QueryTaskCompletedType{
+		QueryTaskCompletedTypeCompleted,
+		QueryTaskCompletedTypeFailed,
+	}
 }
 
-// ToWire translates a RecordActivityTaskHeartbeatByIDRequest struct into a Thrift-level intermediate
+// UnmarshalText tries to decode QueryTaskCompletedType from a byte slice
+// containing its name.
+//
+//   var v QueryTaskCompletedType
+//   err := v.UnmarshalText([]byte("COMPLETED"))
+func (v *QueryTaskCompletedType) UnmarshalText(value []byte) error {
+	switch s := string(value); s {
+	case "COMPLETED":
+		*v = QueryTaskCompletedTypeCompleted
+		return nil
+	case "FAILED":
+		*v = QueryTaskCompletedTypeFailed
+		return nil
+	default:
+		val, err := strconv.ParseInt(s, 10, 32)
+		if err != nil {
+			return fmt.Errorf("unknown enum value %q for %q: %v", s, "QueryTaskCompletedType", err)
+		}
+		*v = QueryTaskCompletedType(val)
+		return nil
+	}
+}
+
+// MarshalText encodes QueryTaskCompletedType to text.
+//
+// If the enum value is recognized, its name is returned. Otherwise,
+// its integer value is returned.
+//
+// This implements the TextMarshaler interface.
+func (v QueryTaskCompletedType) MarshalText() ([]byte, error) {
+	switch int32(v) {
+	case 0:
+		return []byte("COMPLETED"), nil
+	case 1:
+		return []byte("FAILED"), nil
+	}
+	return []byte(strconv.FormatInt(int64(v), 10)), nil
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of QueryTaskCompletedType.
+// Enums are logged as objects, where the value is logged with key "value", and
+// if this value's name is known, the name is logged with key "name".
+func (v QueryTaskCompletedType) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddInt32("value", int32(v))
+	switch int32(v) {
+	case 0:
+		enc.AddString("name", "COMPLETED")
+	case 1:
+		enc.AddString("name", "FAILED")
+	}
+	return nil
+}
+
+// Ptr returns a pointer to this enum value.
+func (v QueryTaskCompletedType) Ptr() *QueryTaskCompletedType {
+	return &v
+}
+
+// ToWire translates QueryTaskCompletedType into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// Enums are represented as 32-bit integers over the wire.
+func (v QueryTaskCompletedType) ToWire() (wire.Value, error) {
+	return wire.NewValueI32(int32(v)), nil
+}
+
+// FromWire deserializes QueryTaskCompletedType from its Thrift-level
+// representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TI32)
+//   if err != nil {
+//     return QueryTaskCompletedType(0), err
+//   }
+//
+//   var v QueryTaskCompletedType
+//   if err := v.FromWire(x); err != nil {
+//     return QueryTaskCompletedType(0), err
+//   }
+//   return v, nil
+func (v *QueryTaskCompletedType) FromWire(w wire.Value) error {
+	*v = (QueryTaskCompletedType)(w.GetI32())
+	return nil
+}
+
+// String returns a readable string representation of QueryTaskCompletedType.
+func (v QueryTaskCompletedType) String() string {
+	w := int32(v)
+	switch w {
+	case 0:
+		return "COMPLETED"
+	case 1:
+		return "FAILED"
+	}
+	return fmt.Sprintf("QueryTaskCompletedType(%d)", w)
+}
+
+// Equals returns true if this QueryTaskCompletedType value matches the provided
+// value.
+func (v QueryTaskCompletedType) Equals(rhs QueryTaskCompletedType) bool {
+	return v == rhs
+}
+
+// MarshalJSON serializes QueryTaskCompletedType into JSON.
+//
+// If the enum value is recognized, its name is returned. Otherwise,
+// its integer value is returned.
+//
+// This implements json.Marshaler.
+func (v QueryTaskCompletedType) MarshalJSON() ([]byte, error) {
+	switch int32(v) {
+	case 0:
+		return ([]byte)("\"COMPLETED\""), nil
+	case 1:
+		return ([]byte)("\"FAILED\""), nil
+	}
+	return ([]byte)(strconv.FormatInt(int64(v), 10)), nil
+}
+
+// UnmarshalJSON attempts to decode QueryTaskCompletedType from its JSON
+// representation.
+//
+// This implementation supports both, numeric and string inputs. If a
+// string is provided, it must be a known enum name.
+//
+// This implements json.Unmarshaler.
+func (v *QueryTaskCompletedType) UnmarshalJSON(text []byte) error {
+	d := json.NewDecoder(bytes.NewReader(text))
+	d.UseNumber()
+	t, err := d.Token()
+	if err != nil {
+		return err
+	}
+
+	switch w := t.(type) {
+	case json.Number:
+		x, err := w.Int64()
+		if err != nil {
+			return err
+		}
+		if x > math.MaxInt32 {
+			return fmt.Errorf("enum overflow from JSON %q for %q", text, "QueryTaskCompletedType")
+		}
+		if x < math.MinInt32 {
+			return fmt.Errorf("enum underflow from JSON %q for %q", text, "QueryTaskCompletedType")
+		}
+		*v = (QueryTaskCompletedType)(x)
+		return nil
+	case string:
+		return v.UnmarshalText([]byte(w))
+	default:
+		return fmt.Errorf("invalid JSON value %q (%T) to unmarshal into %q", t, t, "QueryTaskCompletedType")
+	}
+}
+
+type QueryWorkflowRequest struct {
+	Domain    *string            `json:"domain,omitempty"`
+	Execution *WorkflowExecution `json:"execution,omitempty"`
+	Query     *WorkflowQuery     `json:"query,omitempty"`
+}
+
+// ToWire translates a QueryWorkflowRequest struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -25800,9 +28896,9 @@ type RecordActivityTaskHeartbeatByIDRequest struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *RecordActivityTaskHeartbeatByIDRequest) ToWire() (wire.Value, error) {
+func (v *QueryWorkflowRequest) ToWire() (wire.Value, error) {
 	var (
-		fields [6]wire.Field
+		fields [3]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -25816,55 +28912,31 @@ func (v *RecordActivityTaskHeartbeatByIDRequest) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.WorkflowID != nil {
-		w, err = wire.NewValueString(*(v.WorkflowID)), error(nil)
+	if v.Execution != nil {
+		w, err = v.Execution.ToWire()
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
-	if v.RunID != nil {
-		w, err = wire.NewValueString(*(v.RunID)), error(nil)
+	if v.Query != nil {
+		w, err = v.Query.ToWire()
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 30, Value: w}
 		i++
 	}
-	if v.ActivityID != nil {
-		w, err = wire.NewValueString(*(v.ActivityID)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 40, Value: w}
-		i++
-	}
-	if v.Details != nil {
-		w, err = wire.NewValueBinary(v.Details), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 50, Value: w}
-		i++
-	}
-	if v.Identity != nil {
-		w, err = wire.NewValueString(*(v.Identity)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 60, Value: w}
-		i++
-	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-// FromWire deserializes a RecordActivityTaskHeartbeatByIDRequest struct from its Thrift-level
+// FromWire deserializes a QueryWorkflowRequest struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a RecordActivityTaskHeartbeatByIDRequest struct
+// An error is returned if we were unable to build a QueryWorkflowRequest struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -25872,12 +28944,12 @@ func (v *RecordActivityTaskHeartbeatByIDRequest) ToWire() (wire.Value, error) {
 //     return nil, err
 //   }
 //
-//   var v RecordActivityTaskHeartbeatByIDRequest
+//   var v QueryWorkflowRequest
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *RecordActivityTaskHeartbeatByIDRequest) FromWire(w wire.Value) error {
+func (v *QueryWorkflowRequest) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
@@ -25893,48 +28965,16 @@ func (v *RecordActivityTaskHeartbeatByIDRequest) FromWire(w wire.Value) error {
 
 			}
 		case 20:
-			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.WorkflowID = &x
+			if field.Value.Type() == wire.TStruct {
+				v.Execution, err = _WorkflowExecution_Read(field.Value)
 				if err != nil {
 					return err
 				}
 
 			}
 		case 30:
-			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.RunID = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		case 40:
-			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.ActivityID = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		case 50:
-			if field.Value.Type() == wire.TBinary {
-				v.Details, err = field.Value.GetBinary(), error(nil)
-				if err != nil {
-					return err
-				}
-
-			}
-		case 60:
-			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.Identity = &x
+			if field.Value.Type() == wire.TStruct {
+				v.Query, err = _WorkflowQuery_Read(field.Value)
 				if err != nil {
 					return err
 				}
@@ -25946,48 +28986,36 @@ func (v *RecordActivityTaskHeartbeatByIDRequest) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a RecordActivityTaskHeartbeatByIDRequest
+// String returns a readable string representation of a QueryWorkflowRequest
 // struct.
-func (v *RecordActivityTaskHeartbeatByIDRequest) String() string {
+func (v *QueryWorkflowRequest) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [6]string
+	var fields [3]string
 	i := 0
 	if v.Domain != nil {
 		fields[i] = fmt.Sprintf("Domain: %v", *(v.Domain))
 		i++
 	}
-	if v.WorkflowID != nil {
-		fields[i] = fmt.Sprintf("WorkflowID: %v", *(v.WorkflowID))
-		i++
-	}
-	if v.RunID != nil {
-		fields[i] = fmt.Sprintf("RunID: %v", *(v.RunID))
-		i++
-	}
-	if v.ActivityID != nil {
-		fields[i] = fmt.Sprintf("ActivityID: %v", *(v.ActivityID))
-		i++
-	}
-	if v.Details != nil {
-		fields[i] = fmt.Sprintf("Details: %v", v.Details)
+	if v.Execution != nil {
+		fields[i] = fmt.Sprintf("Execution: %v", v.Execution)
 		i++
 	}
-	if v.Identity != nil {
-		fields[i] = fmt.Sprintf("Identity: %v", *(v.Identity))
+	if v.Query != nil {
+		fields[i] = fmt.Sprintf("Query: %v", v.Query)
 		i++
 	}
 
-	return fmt.Sprintf("RecordActivityTaskHeartbeatByIDRequest{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("QueryWorkflowRequest{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this RecordActivityTaskHeartbeatByIDRequest match the
-// provided RecordActivityTaskHeartbeatByIDRequest.
+// Equals returns true if all the fields of this QueryWorkflowRequest match the
+// provided QueryWorkflowRequest.
 //
 // This function performs a deep comparison.
-func (v *RecordActivityTaskHeartbeatByIDRequest) Equals(rhs *RecordActivityTaskHeartbeatByIDRequest) bool {
+func (v *QueryWorkflowRequest) Equals(rhs *QueryWorkflowRequest) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
@@ -25996,19 +29024,10 @@ func (v *RecordActivityTaskHeartbeatByIDRequest) Equals(rhs *RecordActivityTaskH
 	if !_String_EqualsPtr(v.Domain, rhs.Domain) {
 		return false
 	}
-	if !_String_EqualsPtr(v.WorkflowID, rhs.WorkflowID) {
-		return false
-	}
-	if !_String_EqualsPtr(v.RunID, rhs.RunID) {
-		return false
-	}
-	if !_String_EqualsPtr(v.ActivityID, rhs.ActivityID) {
-		return false
-	}
-	if !((v.Details == nil && rhs.Details == nil) || (v.Details != nil && rhs.Details != nil && bytes.Equal(v.Details, rhs.Details))) {
+	if !((v.Execution == nil && rhs.Execution == nil) || (v.Execution != nil && rhs.Execution != nil && v.Execution.Equals(rhs.Execution))) {
 		return false
 	}
-	if !_String_EqualsPtr(v.Identity, rhs.Identity) {
+	if !((v.Query == nil && rhs.Query == nil) || (v.Query != nil && rhs.Query != nil && v.Query.Equals(rhs.Query))) {
 		return false
 	}
 
@@ -26016,35 +29035,26 @@ func (v *RecordActivityTaskHeartbeatByIDRequest) Equals(rhs *RecordActivityTaskH
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of RecordActivityTaskHeartbeatByIDRequest.
-func (v *RecordActivityTaskHeartbeatByIDRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of QueryWorkflowRequest.
+func (v *QueryWorkflowRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
 	if v.Domain != nil {
 		enc.AddString("domain", *v.Domain)
 	}
-	if v.WorkflowID != nil {
-		enc.AddString("workflowID", *v.WorkflowID)
-	}
-	if v.RunID != nil {
-		enc.AddString("runID", *v.RunID)
-	}
-	if v.ActivityID != nil {
-		enc.AddString("activityID", *v.ActivityID)
-	}
-	if v.Details != nil {
-		enc.AddString("details", base64.StdEncoding.EncodeToString(v.Details))
+	if v.Execution != nil {
+		err = multierr.Append(err, enc.AddObject("execution", v.Execution))
 	}
-	if v.Identity != nil {
-		enc.AddString("identity", *v.Identity)
+	if v.Query != nil {
+		err = multierr.Append(err, enc.AddObject("query", v.Query))
 	}
 	return err
 }
 
 // GetDomain returns the value of Domain if it is set or its
 // zero value if it is unset.
-func (v *RecordActivityTaskHeartbeatByIDRequest) GetDomain() (o string) {
+func (v *QueryWorkflowRequest) GetDomain() (o string) {
 	if v != nil && v.Domain != nil {
 		return *v.Domain
 	}
@@ -26053,92 +29063,45 @@ func (v *RecordActivityTaskHeartbeatByIDRequest) GetDomain() (o string) {
 }
 
 // IsSetDomain returns true if Domain is not nil.
-func (v *RecordActivityTaskHeartbeatByIDRequest) IsSetDomain() bool {
+func (v *QueryWorkflowRequest) IsSetDomain() bool {
 	return v != nil && v.Domain != nil
 }
 
-// GetWorkflowID returns the value of WorkflowID if it is set or its
-// zero value if it is unset.
-func (v *RecordActivityTaskHeartbeatByIDRequest) GetWorkflowID() (o string) {
-	if v != nil && v.WorkflowID != nil {
-		return *v.WorkflowID
-	}
-
-	return
-}
-
-// IsSetWorkflowID returns true if WorkflowID is not nil.
-func (v *RecordActivityTaskHeartbeatByIDRequest) IsSetWorkflowID() bool {
-	return v != nil && v.WorkflowID != nil
-}
-
-// GetRunID returns the value of RunID if it is set or its
-// zero value if it is unset.
-func (v *RecordActivityTaskHeartbeatByIDRequest) GetRunID() (o string) {
-	if v != nil && v.RunID != nil {
-		return *v.RunID
-	}
-
-	return
-}
-
-// IsSetRunID returns true if RunID is not nil.
-func (v *RecordActivityTaskHeartbeatByIDRequest) IsSetRunID() bool {
-	return v != nil && v.RunID != nil
-}
-
-// GetActivityID returns the value of ActivityID if it is set or its
-// zero value if it is unset.
-func (v *RecordActivityTaskHeartbeatByIDRequest) GetActivityID() (o string) {
-	if v != nil && v.ActivityID != nil {
-		return *v.ActivityID
-	}
-
-	return
-}
-
-// IsSetActivityID returns true if ActivityID is not nil.
-func (v *RecordActivityTaskHeartbeatByIDRequest) IsSetActivityID() bool {
-	return v != nil && v.ActivityID != nil
-}
-
-// GetDetails returns the value of Details if it is set or its
+// GetExecution returns the value of Execution if it is set or its
 // zero value if it is unset.
-func (v *RecordActivityTaskHeartbeatByIDRequest) GetDetails() (o []byte) {
-	if v != nil && v.Details != nil {
-		return v.Details
+func (v *QueryWorkflowRequest) GetExecution() (o *WorkflowExecution) {
+	if v != nil && v.Execution != nil {
+		return v.Execution
 	}
 
 	return
 }
 
-// IsSetDetails returns true if Details is not nil.
-func (v *RecordActivityTaskHeartbeatByIDRequest) IsSetDetails() bool {
-	return v != nil && v.Details != nil
+// IsSetExecution returns true if Execution is not nil.
+func (v *QueryWorkflowRequest) IsSetExecution() bool {
+	return v != nil && v.Execution != nil
 }
 
-// GetIdentity returns the value of Identity if it is set or its
+// GetQuery returns the value of Query if it is set or its
 // zero value if it is unset.
-func (v *RecordActivityTaskHeartbeatByIDRequest) GetIdentity() (o string) {
-	if v != nil && v.Identity != nil {
-		return *v.Identity
+func (v *QueryWorkflowRequest) GetQuery() (o *WorkflowQuery) {
+	if v != nil && v.Query != nil {
+		return v.Query
 	}
 
 	return
 }
 
-// IsSetIdentity returns true if Identity is not nil.
-func (v *RecordActivityTaskHeartbeatByIDRequest) IsSetIdentity() bool {
-	return v != nil && v.Identity != nil
+// IsSetQuery returns true if Query is not nil.
+func (v *QueryWorkflowRequest) IsSetQuery() bool {
+	return v != nil && v.Query != nil
 }
 
-type RecordActivityTaskHeartbeatRequest struct {
-	TaskToken []byte  `json:"taskToken,omitempty"`
-	Details   []byte  `json:"details,omitempty"`
-	Identity  *string `json:"identity,omitempty"`
+type QueryWorkflowResponse struct {
+	QueryResult []byte `json:"queryResult,omitempty"`
 }
 
-// ToWire translates a RecordActivityTaskHeartbeatRequest struct into a Thrift-level intermediate
+// ToWire translates a QueryWorkflowResponse struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -26153,47 +29116,31 @@ type RecordActivityTaskHeartbeatRequest struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *RecordActivityTaskHeartbeatRequest) ToWire() (wire.Value, error) {
+func (v *QueryWorkflowResponse) ToWire() (wire.Value, error) {
 	var (
-		fields [3]wire.Field
+		fields [1]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
 	)
 
-	if v.TaskToken != nil {
-		w, err = wire.NewValueBinary(v.TaskToken), error(nil)
+	if v.QueryResult != nil {
+		w, err = wire.NewValueBinary(v.QueryResult), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.Details != nil {
-		w, err = wire.NewValueBinary(v.Details), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 20, Value: w}
-		i++
-	}
-	if v.Identity != nil {
-		w, err = wire.NewValueString(*(v.Identity)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 30, Value: w}
-		i++
-	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-// FromWire deserializes a RecordActivityTaskHeartbeatRequest struct from its Thrift-level
+// FromWire deserializes a QueryWorkflowResponse struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a RecordActivityTaskHeartbeatRequest struct
+// An error is returned if we were unable to build a QueryWorkflowResponse struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -26201,7 +29148,517 @@ func (v *RecordActivityTaskHeartbeatRequest) ToWire() (wire.Value, error) {
 //     return nil, err
 //   }
 //
-//   var v RecordActivityTaskHeartbeatRequest
+//   var v QueryWorkflowResponse
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *QueryWorkflowResponse) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				v.QueryResult, err = field.Value.GetBinary(), error(nil)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a QueryWorkflowResponse
+// struct.
+func (v *QueryWorkflowResponse) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [1]string
+	i := 0
+	if v.QueryResult != nil {
+		fields[i] = fmt.Sprintf("QueryResult: %v", v.QueryResult)
+		i++
+	}
+
+	return fmt.Sprintf("QueryWorkflowResponse{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this QueryWorkflowResponse match the
+// provided QueryWorkflowResponse.
+//
+// This function performs a deep comparison.
+func (v *QueryWorkflowResponse) Equals(rhs *QueryWorkflowResponse) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.QueryResult == nil && rhs.QueryResult == nil) || (v.QueryResult != nil && rhs.QueryResult != nil && bytes.Equal(v.QueryResult, rhs.QueryResult))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of QueryWorkflowResponse.
+func (v *QueryWorkflowResponse) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.QueryResult != nil {
+		enc.AddString("queryResult", base64.StdEncoding.EncodeToString(v.QueryResult))
+	}
+	return err
+}
+
+// GetQueryResult returns the value of QueryResult if it is set or its
+// zero value if it is unset.
+func (v *QueryWorkflowResponse) GetQueryResult() (o []byte) {
+	if v != nil && v.QueryResult != nil {
+		return v.QueryResult
+	}
+
+	return
+}
+
+// IsSetQueryResult returns true if QueryResult is not nil.
+func (v *QueryWorkflowResponse) IsSetQueryResult() bool {
+	return v != nil && v.QueryResult != nil
+}
+
+type RecordActivityTaskHeartbeatByIDRequest struct {
+	Domain     *string `json:"domain,omitempty"`
+	WorkflowID *string `json:"workflowID,omitempty"`
+	RunID      *string `json:"runID,omitempty"`
+	ActivityID *string `json:"activityID,omitempty"`
+	Details    []byte  `json:"details,omitempty"`
+	Identity   *string `json:"identity,omitempty"`
+}
+
+// ToWire translates a RecordActivityTaskHeartbeatByIDRequest struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *RecordActivityTaskHeartbeatByIDRequest) ToWire() (wire.Value, error) {
+	var (
+		fields [6]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Domain != nil {
+		w, err = wire.NewValueString(*(v.Domain)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.WorkflowID != nil {
+		w, err = wire.NewValueString(*(v.WorkflowID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.RunID != nil {
+		w, err = wire.NewValueString(*(v.RunID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+	if v.ActivityID != nil {
+		w, err = wire.NewValueString(*(v.ActivityID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+	if v.Details != nil {
+		w, err = wire.NewValueBinary(v.Details), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 50, Value: w}
+		i++
+	}
+	if v.Identity != nil {
+		w, err = wire.NewValueString(*(v.Identity)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 60, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a RecordActivityTaskHeartbeatByIDRequest struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a RecordActivityTaskHeartbeatByIDRequest struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v RecordActivityTaskHeartbeatByIDRequest
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *RecordActivityTaskHeartbeatByIDRequest) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Domain = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.WorkflowID = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.RunID = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.ActivityID = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 50:
+			if field.Value.Type() == wire.TBinary {
+				v.Details, err = field.Value.GetBinary(), error(nil)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 60:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Identity = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a RecordActivityTaskHeartbeatByIDRequest
+// struct.
+func (v *RecordActivityTaskHeartbeatByIDRequest) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [6]string
+	i := 0
+	if v.Domain != nil {
+		fields[i] = fmt.Sprintf("Domain: %v", *(v.Domain))
+		i++
+	}
+	if v.WorkflowID != nil {
+		fields[i] = fmt.Sprintf("WorkflowID: %v", *(v.WorkflowID))
+		i++
+	}
+	if v.RunID != nil {
+		fields[i] = fmt.Sprintf("RunID: %v", *(v.RunID))
+		i++
+	}
+	if v.ActivityID != nil {
+		fields[i] = fmt.Sprintf("ActivityID: %v", *(v.ActivityID))
+		i++
+	}
+	if v.Details != nil {
+		fields[i] = fmt.Sprintf("Details: %v", v.Details)
+		i++
+	}
+	if v.Identity != nil {
+		fields[i] = fmt.Sprintf("Identity: %v", *(v.Identity))
+		i++
+	}
+
+	return fmt.Sprintf("RecordActivityTaskHeartbeatByIDRequest{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this RecordActivityTaskHeartbeatByIDRequest match the
+// provided RecordActivityTaskHeartbeatByIDRequest.
+//
+// This function performs a deep comparison.
+func (v *RecordActivityTaskHeartbeatByIDRequest) Equals(rhs *RecordActivityTaskHeartbeatByIDRequest) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_String_EqualsPtr(v.Domain, rhs.Domain) {
+		return false
+	}
+	if !_String_EqualsPtr(v.WorkflowID, rhs.WorkflowID) {
+		return false
+	}
+	if !_String_EqualsPtr(v.RunID, rhs.RunID) {
+		return false
+	}
+	if !_String_EqualsPtr(v.ActivityID, rhs.ActivityID) {
+		return false
+	}
+	if !((v.Details == nil && rhs.Details == nil) || (v.Details != nil && rhs.Details != nil && bytes.Equal(v.Details, rhs.Details))) {
+		return false
+	}
+	if !_String_EqualsPtr(v.Identity, rhs.Identity) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of RecordActivityTaskHeartbeatByIDRequest.
+func (v *RecordActivityTaskHeartbeatByIDRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Domain != nil {
+		enc.AddString("domain", *v.Domain)
+	}
+	if v.WorkflowID != nil {
+		enc.AddString("workflowID", *v.WorkflowID)
+	}
+	if v.RunID != nil {
+		enc.AddString("runID", *v.RunID)
+	}
+	if v.ActivityID != nil {
+		enc.AddString("activityID", *v.ActivityID)
+	}
+	if v.Details != nil {
+		enc.AddString("details", base64.StdEncoding.EncodeToString(v.Details))
+	}
+	if v.Identity != nil {
+		enc.AddString("identity", *v.Identity)
+	}
+	return err
+}
+
+// GetDomain returns the value of Domain if it is set or its
+// zero value if it is unset.
+func (v *RecordActivityTaskHeartbeatByIDRequest) GetDomain() (o string) {
+	if v != nil && v.Domain != nil {
+		return *v.Domain
+	}
+
+	return
+}
+
+// IsSetDomain returns true if Domain is not nil.
+func (v *RecordActivityTaskHeartbeatByIDRequest) IsSetDomain() bool {
+	return v != nil && v.Domain != nil
+}
+
+// GetWorkflowID returns the value of WorkflowID if it is set or its
+// zero value if it is unset.
+func (v *RecordActivityTaskHeartbeatByIDRequest) GetWorkflowID() (o string) {
+	if v != nil && v.WorkflowID != nil {
+		return *v.WorkflowID
+	}
+
+	return
+}
+
+// IsSetWorkflowID returns true if WorkflowID is not nil.
+func (v *RecordActivityTaskHeartbeatByIDRequest) IsSetWorkflowID() bool {
+	return v != nil && v.WorkflowID != nil
+}
+
+// GetRunID returns the value of RunID if it is set or its
+// zero value if it is unset.
+func (v *RecordActivityTaskHeartbeatByIDRequest) GetRunID() (o string) {
+	if v != nil && v.RunID != nil {
+		return *v.RunID
+	}
+
+	return
+}
+
+// IsSetRunID returns true if RunID is not nil.
+func (v *RecordActivityTaskHeartbeatByIDRequest) IsSetRunID() bool {
+	return v != nil && v.RunID != nil
+}
+
+// GetActivityID returns the value of ActivityID if it is set or its
+// zero value if it is unset.
+func (v *RecordActivityTaskHeartbeatByIDRequest) GetActivityID() (o string) {
+	if v != nil && v.ActivityID != nil {
+		return *v.ActivityID
+	}
+
+	return
+}
+
+// IsSetActivityID returns true if ActivityID is not nil.
+func (v *RecordActivityTaskHeartbeatByIDRequest) IsSetActivityID() bool {
+	return v != nil && v.ActivityID != nil
+}
+
+// GetDetails returns the value of Details if it is set or its
+// zero value if it is unset.
+func (v *RecordActivityTaskHeartbeatByIDRequest) GetDetails() (o []byte) {
+	if v != nil && v.Details != nil {
+		return v.Details
+	}
+
+	return
+}
+
+// IsSetDetails returns true if Details is not nil.
+func (v *RecordActivityTaskHeartbeatByIDRequest) IsSetDetails() bool {
+	return v != nil && v.Details != nil
+}
+
+// GetIdentity returns the value of Identity if it is set or its
+// zero value if it is unset.
+func (v *RecordActivityTaskHeartbeatByIDRequest) GetIdentity() (o string) {
+	if v != nil && v.Identity != nil {
+		return *v.Identity
+	}
+
+	return
+}
+
+// IsSetIdentity returns true if Identity is not nil.
+func (v *RecordActivityTaskHeartbeatByIDRequest) IsSetIdentity() bool {
+	return v != nil && v.Identity != nil
+}
+
+type RecordActivityTaskHeartbeatRequest struct {
+	TaskToken []byte  `json:"taskToken,omitempty"`
+	Details   []byte  `json:"details,omitempty"`
+	Identity  *string `json:"identity,omitempty"`
+}
+
+// ToWire translates a RecordActivityTaskHeartbeatRequest struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *RecordActivityTaskHeartbeatRequest) ToWire() (wire.Value, error) {
+	var (
+		fields [3]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.TaskToken != nil {
+		w, err = wire.NewValueBinary(v.TaskToken), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.Details != nil {
+		w, err = wire.NewValueBinary(v.Details), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.Identity != nil {
+		w, err = wire.NewValueString(*(v.Identity)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a RecordActivityTaskHeartbeatRequest struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a RecordActivityTaskHeartbeatRequest struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v RecordActivityTaskHeartbeatRequest
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
@@ -36287,6 +39744,7 @@ type SignalWorkflowExecutionRequest struct {
 	Identity          *string            `json:"identity,omitempty"`
 	RequestId         *string            `json:"requestId,omitempty"`
 	Control           []byte             `json:"control,omitempty"`
+	DeliveryTimestamp *int64             `json:"deliveryTimestamp,omitempty"`
 }
 
 // ToWire translates a SignalWorkflowExecutionRequest struct into a Thrift-level intermediate
@@ -36306,7 +39764,7 @@ type SignalWorkflowExecutionRequest struct {
 //   }
 func (v *SignalWorkflowExecutionRequest) ToWire() (wire.Value, error) {
 	var (
-		fields [7]wire.Field
+		fields [8]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -36368,6 +39826,14 @@ func (v *SignalWorkflowExecutionRequest) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 70, Value: w}
 		i++
 	}
+	if v.DeliveryTimestamp != nil {
+		w, err = wire.NewValueI64(*(v.DeliveryTimestamp)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 80, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -36457,6 +39923,16 @@ func (v *SignalWorkflowExecutionRequest) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 80:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.DeliveryTimestamp = &x
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -36471,7 +39947,7 @@ func (v *SignalWorkflowExecutionRequest) String() string {
 		return "<nil>"
 	}
 
-	var fields [7]string
+	var fields [8]string
 	i := 0
 	if v.Domain != nil {
 		fields[i] = fmt.Sprintf("Domain: %v", *(v.Domain))
@@ -36501,6 +39977,10 @@ func (v *SignalWorkflowExecutionRequest) String() string {
 		fields[i] = fmt.Sprintf("Control: %v", v.Control)
 		i++
 	}
+	if v.DeliveryTimestamp != nil {
+		fields[i] = fmt.Sprintf("DeliveryTimestamp: %v", *(v.DeliveryTimestamp))
+		i++
+	}
 
 	return fmt.Sprintf("SignalWorkflowExecutionRequest{%v}", strings.Join(fields[:i], ", "))
 }
@@ -36536,6 +40016,9 @@ func (v *SignalWorkflowExecutionRequest) Equals(rhs *SignalWorkflowExecutionRequ
 	if !((v.Control == nil && rhs.Control == nil) || (v.Control != nil && rhs.Control != nil && bytes.Equal(v.Control, rhs.Control))) {
 		return false
 	}
+	if !_I64_EqualsPtr(v.DeliveryTimestamp, rhs.DeliveryTimestamp) {
+		return false
+	}
 
 	return true
 }
@@ -36567,6 +40050,9 @@ func (v *SignalWorkflowExecutionRequest) MarshalLogObject(enc zapcore.ObjectEnco
 	if v.Control != nil {
 		enc.AddString("control", base64.StdEncoding.EncodeToString(v.Control))
 	}
+	if v.DeliveryTimestamp != nil {
+		enc.AddInt64("deliveryTimestamp", *v.DeliveryTimestamp)
+	}
 	return err
 }
 
@@ -36675,6 +40161,21 @@ func (v *SignalWorkflowExecutionRequest) IsSetControl() bool {
 	return v != nil && v.Control != nil
 }
 
+// GetDeliveryTimestamp returns the value of DeliveryTimestamp if it is set or its
+// zero value if it is unset.
+func (v *SignalWorkflowExecutionRequest) GetDeliveryTimestamp() (o int64) {
+	if v != nil && v.DeliveryTimestamp != nil {
+		return *v.DeliveryTimestamp
+	}
+
+	return
+}
+
+// IsSetDeliveryTimestamp returns true if DeliveryTimestamp is not nil.
+func (v *SignalWorkflowExecutionRequest) IsSetDeliveryTimestamp() bool {
+	return v != nil && v.DeliveryTimestamp != nil
+}
+
 type StartChildWorkflowExecutionDecisionAttributes struct {
 	Domain                              *string                `json:"domain,omitempty"`
 	WorkflowId                          *string                `json:"workflowId,omitempty"`
@@ -38751,6 +42252,9 @@ type StartWorkflowExecutionRequest struct {
 	ChildPolicy                         *ChildPolicy           `json:"childPolicy,omitempty"`
 	RetryPolicy                         *RetryPolicy           `json:"retryPolicy,omitempty"`
 	CronSchedule                        *string                `json:"cronSchedule,omitempty"`
+	WaitForCompletion                   *bool                  `json:"waitForCompletion,omitempty"`
+	ConcurrencyGroup                    *string                `json:"concurrencyGroup,omitempty"`
+	ConcurrencyLimit                    *int32                 `json:"concurrencyLimit,omitempty"`
 }
 
 // ToWire translates a StartWorkflowExecutionRequest struct into a Thrift-level intermediate
@@ -38770,7 +42274,7 @@ type StartWorkflowExecutionRequest struct {
 //   }
 func (v *StartWorkflowExecutionRequest) ToWire() (wire.Value, error) {
 	var (
-		fields [13]wire.Field
+		fields [16]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -38880,6 +42384,30 @@ func (v *StartWorkflowExecutionRequest) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 130, Value: w}
 		i++
 	}
+	if v.WaitForCompletion != nil {
+		w, err = wire.NewValueBool(*(v.WaitForCompletion)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 140, Value: w}
+		i++
+	}
+	if v.ConcurrencyGroup != nil {
+		w, err = wire.NewValueString(*(v.ConcurrencyGroup)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 150, Value: w}
+		i++
+	}
+	if v.ConcurrencyLimit != nil {
+		w, err = wire.NewValueI32(*(v.ConcurrencyLimit)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 160, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -39027,6 +42555,36 @@ func (v *StartWorkflowExecutionRequest) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 140:
+			if field.Value.Type() == wire.TBool {
+				var x bool
+				x, err = field.Value.GetBool(), error(nil)
+				v.WaitForCompletion = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 150:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.ConcurrencyGroup = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 160:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.ConcurrencyLimit = &x
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -39041,7 +42599,7 @@ func (v *StartWorkflowExecutionRequest) String() string {
 		return "<nil>"
 	}
 
-	var fields [13]string
+	var fields [16]string
 	i := 0
 	if v.Domain != nil {
 		fields[i] = fmt.Sprintf("Domain: %v", *(v.Domain))
@@ -39095,6 +42653,18 @@ func (v *StartWorkflowExecutionRequest) String() string {
 		fields[i] = fmt.Sprintf("CronSchedule: %v", *(v.CronSchedule))
 		i++
 	}
+	if v.WaitForCompletion != nil {
+		fields[i] = fmt.Sprintf("WaitForCompletion: %v", *(v.WaitForCompletion))
+		i++
+	}
+	if v.ConcurrencyGroup != nil {
+		fields[i] = fmt.Sprintf("ConcurrencyGroup: %v", *(v.ConcurrencyGroup))
+		i++
+	}
+	if v.ConcurrencyLimit != nil {
+		fields[i] = fmt.Sprintf("ConcurrencyLimit: %v", *(v.ConcurrencyLimit))
+		i++
+	}
 
 	return fmt.Sprintf("StartWorkflowExecutionRequest{%v}", strings.Join(fields[:i], ", "))
 }
@@ -39148,6 +42718,15 @@ func (v *StartWorkflowExecutionRequest) Equals(rhs *StartWorkflowExecutionReques
 	if !_String_EqualsPtr(v.CronSchedule, rhs.CronSchedule) {
 		return false
 	}
+	if !_Bool_EqualsPtr(v.WaitForCompletion, rhs.WaitForCompletion) {
+		return false
+	}
+	if !_String_EqualsPtr(v.ConcurrencyGroup, rhs.ConcurrencyGroup) {
+		return false
+	}
+	if !_I32_EqualsPtr(v.ConcurrencyLimit, rhs.ConcurrencyLimit) {
+		return false
+	}
 
 	return true
 }
@@ -39197,6 +42776,15 @@ func (v *StartWorkflowExecutionRequest) MarshalLogObject(enc zapcore.ObjectEncod
 	if v.CronSchedule != nil {
 		enc.AddString("cronSchedule", *v.CronSchedule)
 	}
+	if v.WaitForCompletion != nil {
+		enc.AddBool("waitForCompletion", *v.WaitForCompletion)
+	}
+	if v.ConcurrencyGroup != nil {
+		enc.AddString("concurrencyGroup", *v.ConcurrencyGroup)
+	}
+	if v.ConcurrencyLimit != nil {
+		enc.AddInt32("concurrencyLimit", *v.ConcurrencyLimit)
+	}
 	return err
 }
 
@@ -39395,8 +42983,55 @@ func (v *StartWorkflowExecutionRequest) IsSetCronSchedule() bool {
 	return v != nil && v.CronSchedule != nil
 }
 
+// GetWaitForCompletion returns the value of WaitForCompletion if it is set or its
+// zero value if it is unset.
+func (v *StartWorkflowExecutionRequest) GetWaitForCompletion() (o bool) {
+	if v != nil && v.WaitForCompletion != nil {
+		return *v.WaitForCompletion
+	}
+
+	return
+}
+
+// IsSetWaitForCompletion returns true if WaitForCompletion is not nil.
+func (v *StartWorkflowExecutionRequest) IsSetWaitForCompletion() bool {
+	return v != nil && v.WaitForCompletion != nil
+}
+
+// GetConcurrencyGroup returns the value of ConcurrencyGroup if it is set or its
+// zero value if it is unset.
+func (v *StartWorkflowExecutionRequest) GetConcurrencyGroup() (o string) {
+	if v != nil && v.ConcurrencyGroup != nil {
+		return *v.ConcurrencyGroup
+	}
+
+	return
+}
+
+// IsSetConcurrencyGroup returns true if ConcurrencyGroup is not nil.
+func (v *StartWorkflowExecutionRequest) IsSetConcurrencyGroup() bool {
+	return v != nil && v.ConcurrencyGroup != nil
+}
+
+// GetConcurrencyLimit returns the value of ConcurrencyLimit if it is set or its
+// zero value if it is unset.
+func (v *StartWorkflowExecutionRequest) GetConcurrencyLimit() (o int32) {
+	if v != nil && v.ConcurrencyLimit != nil {
+		return *v.ConcurrencyLimit
+	}
+
+	return
+}
+
+// IsSetConcurrencyLimit returns true if ConcurrencyLimit is not nil.
+func (v *StartWorkflowExecutionRequest) IsSetConcurrencyLimit() bool {
+	return v != nil && v.ConcurrencyLimit != nil
+}
+
 type StartWorkflowExecutionResponse struct {
-	RunId *string `json:"runId,omitempty"`
+	RunId       *string                       `json:"runId,omitempty"`
+	CloseStatus *WorkflowExecutionCloseStatus `json:"closeStatus,omitempty"`
+	Result      []byte                        `json:"result,omitempty"`
 }
 
 // ToWire translates a StartWorkflowExecutionResponse struct into a Thrift-level intermediate
@@ -39416,7 +43051,7 @@ type StartWorkflowExecutionResponse struct {
 //   }
 func (v *StartWorkflowExecutionResponse) ToWire() (wire.Value, error) {
 	var (
-		fields [1]wire.Field
+		fields [3]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -39430,6 +43065,22 @@ func (v *StartWorkflowExecutionResponse) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
+	if v.CloseStatus != nil {
+		w, err = v.CloseStatus.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.Result != nil {
+		w, err = wire.NewValueBinary(v.Result), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -39465,6 +43116,24 @@ func (v *StartWorkflowExecutionResponse) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 20:
+			if field.Value.Type() == wire.TI32 {
+				var x WorkflowExecutionCloseStatus
+				x, err = _WorkflowExecutionCloseStatus_Read(field.Value)
+				v.CloseStatus = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TBinary {
+				v.Result, err = field.Value.GetBinary(), error(nil)
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -39479,12 +43148,20 @@ func (v *StartWorkflowExecutionResponse) String() string {
 		return "<nil>"
 	}
 
-	var fields [1]string
+	var fields [3]string
 	i := 0
 	if v.RunId != nil {
 		fields[i] = fmt.Sprintf("RunId: %v", *(v.RunId))
 		i++
 	}
+	if v.CloseStatus != nil {
+		fields[i] = fmt.Sprintf("CloseStatus: %v", *(v.CloseStatus))
+		i++
+	}
+	if v.Result != nil {
+		fields[i] = fmt.Sprintf("Result: %v", v.Result)
+		i++
+	}
 
 	return fmt.Sprintf("StartWorkflowExecutionResponse{%v}", strings.Join(fields[:i], ", "))
 }
@@ -39502,6 +43179,12 @@ func (v *StartWorkflowExecutionResponse) Equals(rhs *StartWorkflowExecutionRespo
 	if !_String_EqualsPtr(v.RunId, rhs.RunId) {
 		return false
 	}
+	if !_WorkflowExecutionCloseStatus_EqualsPtr(v.CloseStatus, rhs.CloseStatus) {
+		return false
+	}
+	if !((v.Result == nil && rhs.Result == nil) || (v.Result != nil && rhs.Result != nil && bytes.Equal(v.Result, rhs.Result))) {
+		return false
+	}
 
 	return true
 }
@@ -39515,6 +43198,12 @@ func (v *StartWorkflowExecutionResponse) MarshalLogObject(enc zapcore.ObjectEnco
 	if v.RunId != nil {
 		enc.AddString("runId", *v.RunId)
 	}
+	if v.CloseStatus != nil {
+		err = multierr.Append(err, enc.AddObject("closeStatus", *v.CloseStatus))
+	}
+	if v.Result != nil {
+		enc.AddString("result", base64.StdEncoding.EncodeToString(v.Result))
+	}
 	return err
 }
 
@@ -39533,6 +43222,36 @@ func (v *StartWorkflowExecutionResponse) IsSetRunId() bool {
 	return v != nil && v.RunId != nil
 }
 
+// GetCloseStatus returns the value of CloseStatus if it is set or its
+// zero value if it is unset.
+func (v *StartWorkflowExecutionResponse) GetCloseStatus() (o WorkflowExecutionCloseStatus) {
+	if v != nil && v.CloseStatus != nil {
+		return *v.CloseStatus
+	}
+
+	return
+}
+
+// IsSetCloseStatus returns true if CloseStatus is not nil.
+func (v *StartWorkflowExecutionResponse) IsSetCloseStatus() bool {
+	return v != nil && v.CloseStatus != nil
+}
+
+// GetResult returns the value of Result if it is set or its
+// zero value if it is unset.
+func (v *StartWorkflowExecutionResponse) GetResult() (o []byte) {
+	if v != nil && v.Result != nil {
+		return v.Result
+	}
+
+	return
+}
+
+// IsSetResult returns true if Result is not nil.
+func (v *StartWorkflowExecutionResponse) IsSetResult() bool {
+	return v != nil && v.Result != nil
+}
+
 type StickyExecutionAttributes struct {
 	WorkerTaskList                *TaskList `json:"workerTaskList,omitempty"`
 	ScheduleToStartTimeoutSeconds *int32    `json:"scheduleToStartTimeoutSeconds,omitempty"`
@@ -40408,6 +44127,7 @@ type TaskListStatus struct {
 	AckLevel         *int64       `json:"ackLevel,omitempty"`
 	RatePerSecond    *float64     `json:"ratePerSecond,omitempty"`
 	TaskIDBlock      *TaskIDBlock `json:"taskIDBlock,omitempty"`
+	Burst            *int32       `json:"burst,omitempty"`
 }
 
 // ToWire translates a TaskListStatus struct into a Thrift-level intermediate
@@ -40427,7 +44147,7 @@ type TaskListStatus struct {
 //   }
 func (v *TaskListStatus) ToWire() (wire.Value, error) {
 	var (
-		fields [5]wire.Field
+		fields [6]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -40473,6 +44193,14 @@ func (v *TaskListStatus) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 40, Value: w}
 		i++
 	}
+	if v.Burst != nil {
+		w, err = wire.NewValueI32(*(v.Burst)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 50, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -40552,6 +44280,16 @@ func (v *TaskListStatus) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 50:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.Burst = &x
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -40566,7 +44304,7 @@ func (v *TaskListStatus) String() string {
 		return "<nil>"
 	}
 
-	var fields [5]string
+	var fields [6]string
 	i := 0
 	if v.BacklogCountHint != nil {
 		fields[i] = fmt.Sprintf("BacklogCountHint: %v", *(v.BacklogCountHint))
@@ -40588,6 +44326,10 @@ func (v *TaskListStatus) String() string {
 		fields[i] = fmt.Sprintf("TaskIDBlock: %v", v.TaskIDBlock)
 		i++
 	}
+	if v.Burst != nil {
+		fields[i] = fmt.Sprintf("Burst: %v", *(v.Burst))
+		i++
+	}
 
 	return fmt.Sprintf("TaskListStatus{%v}", strings.Join(fields[:i], ", "))
 }
@@ -40617,6 +44359,9 @@ func (v *TaskListStatus) Equals(rhs *TaskListStatus) bool {
 	if !((v.TaskIDBlock == nil && rhs.TaskIDBlock == nil) || (v.TaskIDBlock != nil && rhs.TaskIDBlock != nil && v.TaskIDBlock.Equals(rhs.TaskIDBlock))) {
 		return false
 	}
+	if !_I32_EqualsPtr(v.Burst, rhs.Burst) {
+		return false
+	}
 
 	return true
 }
@@ -40642,6 +44387,9 @@ func (v *TaskListStatus) MarshalLogObject(enc zapcore.ObjectEncoder) (err error)
 	if v.TaskIDBlock != nil {
 		err = multierr.Append(err, enc.AddObject("taskIDBlock", v.TaskIDBlock))
 	}
+	if v.Burst != nil {
+		enc.AddInt32("burst", *v.Burst)
+	}
 	return err
 }
 
@@ -40720,6 +44468,21 @@ func (v *TaskListStatus) IsSetTaskIDBlock() bool {
 	return v != nil && v.TaskIDBlock != nil
 }
 
+// GetBurst returns the value of Burst if it is set or its
+// zero value if it is unset.
+func (v *TaskListStatus) GetBurst() (o int32) {
+	if v != nil && v.Burst != nil {
+		return *v.Burst
+	}
+
+	return
+}
+
+// IsSetBurst returns true if Burst is not nil.
+func (v *TaskListStatus) IsSetBurst() bool {
+	return v != nil && v.Burst != nil
+}
+
 type TaskListType int32
 
 const (
@@ -41201,6 +44964,216 @@ func (v *TerminateWorkflowExecutionRequest) IsSetIdentity() bool {
 	return v != nil && v.Identity != nil
 }
 
+type TerminationCause int32
+
+const (
+	TerminationCauseUserRequest           TerminationCause = 0
+	TerminationCauseHistoryExceedsLimit   TerminationCause = 1
+	TerminationCauseWorkflowIDReusePolicy TerminationCause = 2
+	TerminationCauseResetWorkflow         TerminationCause = 3
+	TerminationCauseVersionConflict       TerminationCause = 4
+)
+
+// TerminationCause_Values returns all recognized values of TerminationCause.
+func TerminationCause_Values() []TerminationCause {
+	return []TerminationCause{
+		TerminationCauseUserRequest,
+		TerminationCauseHistoryExceedsLimit,
+		TerminationCauseWorkflowIDReusePolicy,
+		TerminationCauseResetWorkflow,
+		TerminationCauseVersionConflict,
+	}
+}
+
+// UnmarshalText tries to decode TerminationCause from a byte slice
+// containing its name.
+//
+//   var v TerminationCause
+//   err := v.UnmarshalText([]byte("USER_REQUEST"))
+func (v *TerminationCause) UnmarshalText(value []byte) error {
+	switch s := string(value); s {
+	case "USER_REQUEST":
+		*v = TerminationCauseUserRequest
+		return nil
+	case "HISTORY_EXCEEDS_LIMIT":
+		*v = TerminationCauseHistoryExceedsLimit
+		return nil
+	case "WORKFLOW_ID_REUSE_POLICY":
+		*v = TerminationCauseWorkflowIDReusePolicy
+		return nil
+	case "RESET_WORKFLOW":
+		*v = TerminationCauseResetWorkflow
+		return nil
+	case "VERSION_CONFLICT":
+		*v = TerminationCauseVersionConflict
+		return nil
+	default:
+		val, err := strconv.ParseInt(s, 10, 32)
+		if err != nil {
+			return fmt.Errorf("unknown enum value %q for %q: %v", s, "TerminationCause", err)
+		}
+		*v = TerminationCause(val)
+		return nil
+	}
+}
+
+// MarshalText encodes TerminationCause to text.
+//
+// If the enum value is recognized, its name is returned. Otherwise,
+// its integer value is returned.
+//
+// This implements the TextMarshaler interface.
+func (v TerminationCause) MarshalText() ([]byte, error) {
+	switch int32(v) {
+	case 0:
+		return []byte("USER_REQUEST"), nil
+	case 1:
+		return []byte("HISTORY_EXCEEDS_LIMIT"), nil
+	case 2:
+		return []byte("WORKFLOW_ID_REUSE_POLICY"), nil
+	case 3:
+		return []byte("RESET_WORKFLOW"), nil
+	case 4:
+		return []byte("VERSION_CONFLICT"), nil
+	}
+	return []byte(strconv.FormatInt(int64(v), 10)), nil
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of TerminationCause.
+// Enums are logged as objects, where the value is logged with key "value", and
+// if this value's name is known, the name is logged with key "name".
+func (v TerminationCause) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddInt32("value", int32(v))
+	switch int32(v) {
+	case 0:
+		enc.AddString("name", "USER_REQUEST")
+	case 1:
+		enc.AddString("name", "HISTORY_EXCEEDS_LIMIT")
+	case 2:
+		enc.AddString("name", "WORKFLOW_ID_REUSE_POLICY")
+	case 3:
+		enc.AddString("name", "RESET_WORKFLOW")
+	case 4:
+		enc.AddString("name", "VERSION_CONFLICT")
+	}
+	return nil
+}
+
+// Ptr returns a pointer to this enum value.
+func (v TerminationCause) Ptr() *TerminationCause {
+	return &v
+}
+
+// ToWire translates TerminationCause into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// Enums are represented as 32-bit integers over the wire.
+func (v TerminationCause) ToWire() (wire.Value, error) {
+	return wire.NewValueI32(int32(v)), nil
+}
+
+// FromWire deserializes TerminationCause from its Thrift-level
+// representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TI32)
+//   if err != nil {
+//     return TerminationCause(0), err
+//   }
+//
+//   var v TerminationCause
+//   if err := v.FromWire(x); err != nil {
+//     return TerminationCause(0), err
+//   }
+//   return v, nil
+func (v *TerminationCause) FromWire(w wire.Value) error {
+	*v = (TerminationCause)(w.GetI32())
+	return nil
+}
+
+// String returns a readable string representation of TerminationCause.
+func (v TerminationCause) String() string {
+	w := int32(v)
+	switch w {
+	case 0:
+		return "USER_REQUEST"
+	case 1:
+		return "HISTORY_EXCEEDS_LIMIT"
+	case 2:
+		return "WORKFLOW_ID_REUSE_POLICY"
+	case 3:
+		return "RESET_WORKFLOW"
+	case 4:
+		return "VERSION_CONFLICT"
+	}
+	return fmt.Sprintf("TerminationCause(%d)", w)
+}
+
+// Equals returns true if this TerminationCause value matches the provided
+// value.
+func (v TerminationCause) Equals(rhs TerminationCause) bool {
+	return v == rhs
+}
+
+// MarshalJSON serializes TerminationCause into JSON.
+//
+// If the enum value is recognized, its name is returned. Otherwise,
+// its integer value is returned.
+//
+// This implements json.Marshaler.
+func (v TerminationCause) MarshalJSON() ([]byte, error) {
+	switch int32(v) {
+	case 0:
+		return ([]byte)("\"USER_REQUEST\""), nil
+	case 1:
+		return ([]byte)("\"HISTORY_EXCEEDS_LIMIT\""), nil
+	case 2:
+		return ([]byte)("\"WORKFLOW_ID_REUSE_POLICY\""), nil
+	case 3:
+		return ([]byte)("\"RESET_WORKFLOW\""), nil
+	case 4:
+		return ([]byte)("\"VERSION_CONFLICT\""), nil
+	}
+	return ([]byte)(strconv.FormatInt(int64(v), 10)), nil
+}
+
+// UnmarshalJSON attempts to decode TerminationCause from its JSON
+// representation.
+//
+// This implementation supports both, numeric and string inputs. If a
+// string is provided, it must be a known enum name.
+//
+// This implements json.Unmarshaler.
+func (v *TerminationCause) UnmarshalJSON(text []byte) error {
+	d := json.NewDecoder(bytes.NewReader(text))
+	d.UseNumber()
+	t, err := d.Token()
+	if err != nil {
+		return err
+	}
+
+	switch w := t.(type) {
+	case json.Number:
+		x, err := w.Int64()
+		if err != nil {
+			return err
+		}
+		if x > math.MaxInt32 {
+			return fmt.Errorf("enum overflow from JSON %q for %q", text, "TerminationCause")
+		}
+		if x < math.MinInt32 {
+			return fmt.Errorf("enum underflow from JSON %q for %q", text, "TerminationCause")
+		}
+		*v = (TerminationCause)(x)
+		return nil
+	case string:
+		return v.UnmarshalText([]byte(w))
+	default:
+		return fmt.Errorf("invalid JSON value %q (%T) to unmarshal into %q", t, t, "TerminationCause")
+	}
+}
+
 type TimeoutType int32
 
 const (
@@ -41320,92 +45293,947 @@ func (v *TimeoutType) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of TimeoutType.
-func (v TimeoutType) String() string {
-	w := int32(v)
-	switch w {
-	case 0:
-		return "START_TO_CLOSE"
-	case 1:
-		return "SCHEDULE_TO_START"
-	case 2:
-		return "SCHEDULE_TO_CLOSE"
-	case 3:
-		return "HEARTBEAT"
+// String returns a readable string representation of TimeoutType.
+func (v TimeoutType) String() string {
+	w := int32(v)
+	switch w {
+	case 0:
+		return "START_TO_CLOSE"
+	case 1:
+		return "SCHEDULE_TO_START"
+	case 2:
+		return "SCHEDULE_TO_CLOSE"
+	case 3:
+		return "HEARTBEAT"
+	}
+	return fmt.Sprintf("TimeoutType(%d)", w)
+}
+
+// Equals returns true if this TimeoutType value matches the provided
+// value.
+func (v TimeoutType) Equals(rhs TimeoutType) bool {
+	return v == rhs
+}
+
+// MarshalJSON serializes TimeoutType into JSON.
+//
+// If the enum value is recognized, its name is returned. Otherwise,
+// its integer value is returned.
+//
+// This implements json.Marshaler.
+func (v TimeoutType) MarshalJSON() ([]byte, error) {
+	switch int32(v) {
+	case 0:
+		return ([]byte)("\"START_TO_CLOSE\""), nil
+	case 1:
+		return ([]byte)("\"SCHEDULE_TO_START\""), nil
+	case 2:
+		return ([]byte)("\"SCHEDULE_TO_CLOSE\""), nil
+	case 3:
+		return ([]byte)("\"HEARTBEAT\""), nil
+	}
+	return ([]byte)(strconv.FormatInt(int64(v), 10)), nil
+}
+
+// UnmarshalJSON attempts to decode TimeoutType from its JSON
+// representation.
+//
+// This implementation supports both, numeric and string inputs. If a
+// string is provided, it must be a known enum name.
+//
+// This implements json.Unmarshaler.
+func (v *TimeoutType) UnmarshalJSON(text []byte) error {
+	d := json.NewDecoder(bytes.NewReader(text))
+	d.UseNumber()
+	t, err := d.Token()
+	if err != nil {
+		return err
+	}
+
+	switch w := t.(type) {
+	case json.Number:
+		x, err := w.Int64()
+		if err != nil {
+			return err
+		}
+		if x > math.MaxInt32 {
+			return fmt.Errorf("enum overflow from JSON %q for %q", text, "TimeoutType")
+		}
+		if x < math.MinInt32 {
+			return fmt.Errorf("enum underflow from JSON %q for %q", text, "TimeoutType")
+		}
+		*v = (TimeoutType)(x)
+		return nil
+	case string:
+		return v.UnmarshalText([]byte(w))
+	default:
+		return fmt.Errorf("invalid JSON value %q (%T) to unmarshal into %q", t, t, "TimeoutType")
+	}
+}
+
+type TimerCanceledEventAttributes struct {
+	TimerId                      *string `json:"timerId,omitempty"`
+	StartedEventId               *int64  `json:"startedEventId,omitempty"`
+	DecisionTaskCompletedEventId *int64  `json:"decisionTaskCompletedEventId,omitempty"`
+	Identity                     *string `json:"identity,omitempty"`
+}
+
+// ToWire translates a TimerCanceledEventAttributes struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *TimerCanceledEventAttributes) ToWire() (wire.Value, error) {
+	var (
+		fields [4]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.TimerId != nil {
+		w, err = wire.NewValueString(*(v.TimerId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.StartedEventId != nil {
+		w, err = wire.NewValueI64(*(v.StartedEventId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.DecisionTaskCompletedEventId != nil {
+		w, err = wire.NewValueI64(*(v.DecisionTaskCompletedEventId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+	if v.Identity != nil {
+		w, err = wire.NewValueString(*(v.Identity)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a TimerCanceledEventAttributes struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a TimerCanceledEventAttributes struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v TimerCanceledEventAttributes
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *TimerCanceledEventAttributes) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.TimerId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.StartedEventId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.DecisionTaskCompletedEventId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Identity = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a TimerCanceledEventAttributes
+// struct.
+func (v *TimerCanceledEventAttributes) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [4]string
+	i := 0
+	if v.TimerId != nil {
+		fields[i] = fmt.Sprintf("TimerId: %v", *(v.TimerId))
+		i++
+	}
+	if v.StartedEventId != nil {
+		fields[i] = fmt.Sprintf("StartedEventId: %v", *(v.StartedEventId))
+		i++
+	}
+	if v.DecisionTaskCompletedEventId != nil {
+		fields[i] = fmt.Sprintf("DecisionTaskCompletedEventId: %v", *(v.DecisionTaskCompletedEventId))
+		i++
+	}
+	if v.Identity != nil {
+		fields[i] = fmt.Sprintf("Identity: %v", *(v.Identity))
+		i++
+	}
+
+	return fmt.Sprintf("TimerCanceledEventAttributes{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this TimerCanceledEventAttributes match the
+// provided TimerCanceledEventAttributes.
+//
+// This function performs a deep comparison.
+func (v *TimerCanceledEventAttributes) Equals(rhs *TimerCanceledEventAttributes) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_String_EqualsPtr(v.TimerId, rhs.TimerId) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.StartedEventId, rhs.StartedEventId) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.DecisionTaskCompletedEventId, rhs.DecisionTaskCompletedEventId) {
+		return false
+	}
+	if !_String_EqualsPtr(v.Identity, rhs.Identity) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of TimerCanceledEventAttributes.
+func (v *TimerCanceledEventAttributes) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.TimerId != nil {
+		enc.AddString("timerId", *v.TimerId)
+	}
+	if v.StartedEventId != nil {
+		enc.AddInt64("startedEventId", *v.StartedEventId)
+	}
+	if v.DecisionTaskCompletedEventId != nil {
+		enc.AddInt64("decisionTaskCompletedEventId", *v.DecisionTaskCompletedEventId)
+	}
+	if v.Identity != nil {
+		enc.AddString("identity", *v.Identity)
+	}
+	return err
+}
+
+// GetTimerId returns the value of TimerId if it is set or its
+// zero value if it is unset.
+func (v *TimerCanceledEventAttributes) GetTimerId() (o string) {
+	if v != nil && v.TimerId != nil {
+		return *v.TimerId
+	}
+
+	return
+}
+
+// IsSetTimerId returns true if TimerId is not nil.
+func (v *TimerCanceledEventAttributes) IsSetTimerId() bool {
+	return v != nil && v.TimerId != nil
+}
+
+// GetStartedEventId returns the value of StartedEventId if it is set or its
+// zero value if it is unset.
+func (v *TimerCanceledEventAttributes) GetStartedEventId() (o int64) {
+	if v != nil && v.StartedEventId != nil {
+		return *v.StartedEventId
+	}
+
+	return
+}
+
+// IsSetStartedEventId returns true if StartedEventId is not nil.
+func (v *TimerCanceledEventAttributes) IsSetStartedEventId() bool {
+	return v != nil && v.StartedEventId != nil
+}
+
+// GetDecisionTaskCompletedEventId returns the value of DecisionTaskCompletedEventId if it is set or its
+// zero value if it is unset.
+func (v *TimerCanceledEventAttributes) GetDecisionTaskCompletedEventId() (o int64) {
+	if v != nil && v.DecisionTaskCompletedEventId != nil {
+		return *v.DecisionTaskCompletedEventId
+	}
+
+	return
+}
+
+// IsSetDecisionTaskCompletedEventId returns true if DecisionTaskCompletedEventId is not nil.
+func (v *TimerCanceledEventAttributes) IsSetDecisionTaskCompletedEventId() bool {
+	return v != nil && v.DecisionTaskCompletedEventId != nil
+}
+
+// GetIdentity returns the value of Identity if it is set or its
+// zero value if it is unset.
+func (v *TimerCanceledEventAttributes) GetIdentity() (o string) {
+	if v != nil && v.Identity != nil {
+		return *v.Identity
+	}
+
+	return
+}
+
+// IsSetIdentity returns true if Identity is not nil.
+func (v *TimerCanceledEventAttributes) IsSetIdentity() bool {
+	return v != nil && v.Identity != nil
+}
+
+type TimerFiredEventAttributes struct {
+	TimerId        *string `json:"timerId,omitempty"`
+	StartedEventId *int64  `json:"startedEventId,omitempty"`
+}
+
+// ToWire translates a TimerFiredEventAttributes struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *TimerFiredEventAttributes) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.TimerId != nil {
+		w, err = wire.NewValueString(*(v.TimerId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.StartedEventId != nil {
+		w, err = wire.NewValueI64(*(v.StartedEventId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a TimerFiredEventAttributes struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a TimerFiredEventAttributes struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v TimerFiredEventAttributes
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *TimerFiredEventAttributes) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.TimerId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.StartedEventId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a TimerFiredEventAttributes
+// struct.
+func (v *TimerFiredEventAttributes) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [2]string
+	i := 0
+	if v.TimerId != nil {
+		fields[i] = fmt.Sprintf("TimerId: %v", *(v.TimerId))
+		i++
+	}
+	if v.StartedEventId != nil {
+		fields[i] = fmt.Sprintf("StartedEventId: %v", *(v.StartedEventId))
+		i++
+	}
+
+	return fmt.Sprintf("TimerFiredEventAttributes{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this TimerFiredEventAttributes match the
+// provided TimerFiredEventAttributes.
+//
+// This function performs a deep comparison.
+func (v *TimerFiredEventAttributes) Equals(rhs *TimerFiredEventAttributes) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_String_EqualsPtr(v.TimerId, rhs.TimerId) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.StartedEventId, rhs.StartedEventId) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of TimerFiredEventAttributes.
+func (v *TimerFiredEventAttributes) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.TimerId != nil {
+		enc.AddString("timerId", *v.TimerId)
+	}
+	if v.StartedEventId != nil {
+		enc.AddInt64("startedEventId", *v.StartedEventId)
+	}
+	return err
+}
+
+// GetTimerId returns the value of TimerId if it is set or its
+// zero value if it is unset.
+func (v *TimerFiredEventAttributes) GetTimerId() (o string) {
+	if v != nil && v.TimerId != nil {
+		return *v.TimerId
+	}
+
+	return
+}
+
+// IsSetTimerId returns true if TimerId is not nil.
+func (v *TimerFiredEventAttributes) IsSetTimerId() bool {
+	return v != nil && v.TimerId != nil
+}
+
+// GetStartedEventId returns the value of StartedEventId if it is set or its
+// zero value if it is unset.
+func (v *TimerFiredEventAttributes) GetStartedEventId() (o int64) {
+	if v != nil && v.StartedEventId != nil {
+		return *v.StartedEventId
+	}
+
+	return
+}
+
+// IsSetStartedEventId returns true if StartedEventId is not nil.
+func (v *TimerFiredEventAttributes) IsSetStartedEventId() bool {
+	return v != nil && v.StartedEventId != nil
+}
+
+type TimerStartedEventAttributes struct {
+	TimerId                      *string `json:"timerId,omitempty"`
+	StartToFireTimeoutSeconds    *int64  `json:"startToFireTimeoutSeconds,omitempty"`
+	DecisionTaskCompletedEventId *int64  `json:"decisionTaskCompletedEventId,omitempty"`
+}
+
+// ToWire translates a TimerStartedEventAttributes struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *TimerStartedEventAttributes) ToWire() (wire.Value, error) {
+	var (
+		fields [3]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.TimerId != nil {
+		w, err = wire.NewValueString(*(v.TimerId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.StartToFireTimeoutSeconds != nil {
+		w, err = wire.NewValueI64(*(v.StartToFireTimeoutSeconds)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.DecisionTaskCompletedEventId != nil {
+		w, err = wire.NewValueI64(*(v.DecisionTaskCompletedEventId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a TimerStartedEventAttributes struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a TimerStartedEventAttributes struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v TimerStartedEventAttributes
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *TimerStartedEventAttributes) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.TimerId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.StartToFireTimeoutSeconds = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.DecisionTaskCompletedEventId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a TimerStartedEventAttributes
+// struct.
+func (v *TimerStartedEventAttributes) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [3]string
+	i := 0
+	if v.TimerId != nil {
+		fields[i] = fmt.Sprintf("TimerId: %v", *(v.TimerId))
+		i++
+	}
+	if v.StartToFireTimeoutSeconds != nil {
+		fields[i] = fmt.Sprintf("StartToFireTimeoutSeconds: %v", *(v.StartToFireTimeoutSeconds))
+		i++
+	}
+	if v.DecisionTaskCompletedEventId != nil {
+		fields[i] = fmt.Sprintf("DecisionTaskCompletedEventId: %v", *(v.DecisionTaskCompletedEventId))
+		i++
+	}
+
+	return fmt.Sprintf("TimerStartedEventAttributes{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this TimerStartedEventAttributes match the
+// provided TimerStartedEventAttributes.
+//
+// This function performs a deep comparison.
+func (v *TimerStartedEventAttributes) Equals(rhs *TimerStartedEventAttributes) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_String_EqualsPtr(v.TimerId, rhs.TimerId) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.StartToFireTimeoutSeconds, rhs.StartToFireTimeoutSeconds) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.DecisionTaskCompletedEventId, rhs.DecisionTaskCompletedEventId) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of TimerStartedEventAttributes.
+func (v *TimerStartedEventAttributes) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.TimerId != nil {
+		enc.AddString("timerId", *v.TimerId)
+	}
+	if v.StartToFireTimeoutSeconds != nil {
+		enc.AddInt64("startToFireTimeoutSeconds", *v.StartToFireTimeoutSeconds)
+	}
+	if v.DecisionTaskCompletedEventId != nil {
+		enc.AddInt64("decisionTaskCompletedEventId", *v.DecisionTaskCompletedEventId)
+	}
+	return err
+}
+
+// GetTimerId returns the value of TimerId if it is set or its
+// zero value if it is unset.
+func (v *TimerStartedEventAttributes) GetTimerId() (o string) {
+	if v != nil && v.TimerId != nil {
+		return *v.TimerId
+	}
+
+	return
+}
+
+// IsSetTimerId returns true if TimerId is not nil.
+func (v *TimerStartedEventAttributes) IsSetTimerId() bool {
+	return v != nil && v.TimerId != nil
+}
+
+// GetStartToFireTimeoutSeconds returns the value of StartToFireTimeoutSeconds if it is set or its
+// zero value if it is unset.
+func (v *TimerStartedEventAttributes) GetStartToFireTimeoutSeconds() (o int64) {
+	if v != nil && v.StartToFireTimeoutSeconds != nil {
+		return *v.StartToFireTimeoutSeconds
+	}
+
+	return
+}
+
+// IsSetStartToFireTimeoutSeconds returns true if StartToFireTimeoutSeconds is not nil.
+func (v *TimerStartedEventAttributes) IsSetStartToFireTimeoutSeconds() bool {
+	return v != nil && v.StartToFireTimeoutSeconds != nil
+}
+
+// GetDecisionTaskCompletedEventId returns the value of DecisionTaskCompletedEventId if it is set or its
+// zero value if it is unset.
+func (v *TimerStartedEventAttributes) GetDecisionTaskCompletedEventId() (o int64) {
+	if v != nil && v.DecisionTaskCompletedEventId != nil {
+		return *v.DecisionTaskCompletedEventId
+	}
+
+	return
+}
+
+// IsSetDecisionTaskCompletedEventId returns true if DecisionTaskCompletedEventId is not nil.
+func (v *TimerStartedEventAttributes) IsSetDecisionTaskCompletedEventId() bool {
+	return v != nil && v.DecisionTaskCompletedEventId != nil
+}
+
+type TransientDecisionInfo struct {
+	ScheduledEvent *HistoryEvent `json:"scheduledEvent,omitempty"`
+	StartedEvent   *HistoryEvent `json:"startedEvent,omitempty"`
+}
+
+// ToWire translates a TransientDecisionInfo struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *TransientDecisionInfo) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.ScheduledEvent != nil {
+		w, err = v.ScheduledEvent.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.StartedEvent != nil {
+		w, err = v.StartedEvent.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a TransientDecisionInfo struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a TransientDecisionInfo struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v TransientDecisionInfo
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *TransientDecisionInfo) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TStruct {
+				v.ScheduledEvent, err = _HistoryEvent_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TStruct {
+				v.StartedEvent, err = _HistoryEvent_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a TransientDecisionInfo
+// struct.
+func (v *TransientDecisionInfo) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [2]string
+	i := 0
+	if v.ScheduledEvent != nil {
+		fields[i] = fmt.Sprintf("ScheduledEvent: %v", v.ScheduledEvent)
+		i++
+	}
+	if v.StartedEvent != nil {
+		fields[i] = fmt.Sprintf("StartedEvent: %v", v.StartedEvent)
+		i++
+	}
+
+	return fmt.Sprintf("TransientDecisionInfo{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this TransientDecisionInfo match the
+// provided TransientDecisionInfo.
+//
+// This function performs a deep comparison.
+func (v *TransientDecisionInfo) Equals(rhs *TransientDecisionInfo) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.ScheduledEvent == nil && rhs.ScheduledEvent == nil) || (v.ScheduledEvent != nil && rhs.ScheduledEvent != nil && v.ScheduledEvent.Equals(rhs.ScheduledEvent))) {
+		return false
+	}
+	if !((v.StartedEvent == nil && rhs.StartedEvent == nil) || (v.StartedEvent != nil && rhs.StartedEvent != nil && v.StartedEvent.Equals(rhs.StartedEvent))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of TransientDecisionInfo.
+func (v *TransientDecisionInfo) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
 	}
-	return fmt.Sprintf("TimeoutType(%d)", w)
+	if v.ScheduledEvent != nil {
+		err = multierr.Append(err, enc.AddObject("scheduledEvent", v.ScheduledEvent))
+	}
+	if v.StartedEvent != nil {
+		err = multierr.Append(err, enc.AddObject("startedEvent", v.StartedEvent))
+	}
+	return err
 }
 
-// Equals returns true if this TimeoutType value matches the provided
-// value.
-func (v TimeoutType) Equals(rhs TimeoutType) bool {
-	return v == rhs
+// GetScheduledEvent returns the value of ScheduledEvent if it is set or its
+// zero value if it is unset.
+func (v *TransientDecisionInfo) GetScheduledEvent() (o *HistoryEvent) {
+	if v != nil && v.ScheduledEvent != nil {
+		return v.ScheduledEvent
+	}
+
+	return
 }
 
-// MarshalJSON serializes TimeoutType into JSON.
-//
-// If the enum value is recognized, its name is returned. Otherwise,
-// its integer value is returned.
-//
-// This implements json.Marshaler.
-func (v TimeoutType) MarshalJSON() ([]byte, error) {
-	switch int32(v) {
-	case 0:
-		return ([]byte)("\"START_TO_CLOSE\""), nil
-	case 1:
-		return ([]byte)("\"SCHEDULE_TO_START\""), nil
-	case 2:
-		return ([]byte)("\"SCHEDULE_TO_CLOSE\""), nil
-	case 3:
-		return ([]byte)("\"HEARTBEAT\""), nil
-	}
-	return ([]byte)(strconv.FormatInt(int64(v), 10)), nil
+// IsSetScheduledEvent returns true if ScheduledEvent is not nil.
+func (v *TransientDecisionInfo) IsSetScheduledEvent() bool {
+	return v != nil && v.ScheduledEvent != nil
 }
 
-// UnmarshalJSON attempts to decode TimeoutType from its JSON
-// representation.
-//
-// This implementation supports both, numeric and string inputs. If a
-// string is provided, it must be a known enum name.
-//
-// This implements json.Unmarshaler.
-func (v *TimeoutType) UnmarshalJSON(text []byte) error {
-	d := json.NewDecoder(bytes.NewReader(text))
-	d.UseNumber()
-	t, err := d.Token()
-	if err != nil {
-		return err
+// GetStartedEvent returns the value of StartedEvent if it is set or its
+// zero value if it is unset.
+func (v *TransientDecisionInfo) GetStartedEvent() (o *HistoryEvent) {
+	if v != nil && v.StartedEvent != nil {
+		return v.StartedEvent
 	}
 
-	switch w := t.(type) {
-	case json.Number:
-		x, err := w.Int64()
-		if err != nil {
-			return err
-		}
-		if x > math.MaxInt32 {
-			return fmt.Errorf("enum overflow from JSON %q for %q", text, "TimeoutType")
-		}
-		if x < math.MinInt32 {
-			return fmt.Errorf("enum underflow from JSON %q for %q", text, "TimeoutType")
-		}
-		*v = (TimeoutType)(x)
-		return nil
-	case string:
-		return v.UnmarshalText([]byte(w))
-	default:
-		return fmt.Errorf("invalid JSON value %q (%T) to unmarshal into %q", t, t, "TimeoutType")
-	}
+	return
 }
 
-type TimerCanceledEventAttributes struct {
-	TimerId                      *string `json:"timerId,omitempty"`
-	StartedEventId               *int64  `json:"startedEventId,omitempty"`
-	DecisionTaskCompletedEventId *int64  `json:"decisionTaskCompletedEventId,omitempty"`
-	Identity                     *string `json:"identity,omitempty"`
+// IsSetStartedEvent returns true if StartedEvent is not nil.
+func (v *TransientDecisionInfo) IsSetStartedEvent() bool {
+	return v != nil && v.StartedEvent != nil
 }
 
-// ToWire translates a TimerCanceledEventAttributes struct into a Thrift-level intermediate
+type UpdateDomainInfo struct {
+	Description *string           `json:"description,omitempty"`
+	OwnerEmail  *string           `json:"ownerEmail,omitempty"`
+	Data        map[string]string `json:"data,omitempty"`
+}
+
+// ToWire translates a UpdateDomainInfo struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -41420,55 +46248,47 @@ type TimerCanceledEventAttributes struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *TimerCanceledEventAttributes) ToWire() (wire.Value, error) {
+func (v *UpdateDomainInfo) ToWire() (wire.Value, error) {
 	var (
-		fields [4]wire.Field
+		fields [3]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
 	)
 
-	if v.TimerId != nil {
-		w, err = wire.NewValueString(*(v.TimerId)), error(nil)
+	if v.Description != nil {
+		w, err = wire.NewValueString(*(v.Description)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.StartedEventId != nil {
-		w, err = wire.NewValueI64(*(v.StartedEventId)), error(nil)
+	if v.OwnerEmail != nil {
+		w, err = wire.NewValueString(*(v.OwnerEmail)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
-	if v.DecisionTaskCompletedEventId != nil {
-		w, err = wire.NewValueI64(*(v.DecisionTaskCompletedEventId)), error(nil)
+	if v.Data != nil {
+		w, err = wire.NewValueMap(_Map_String_String_MapItemList(v.Data)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 30, Value: w}
 		i++
 	}
-	if v.Identity != nil {
-		w, err = wire.NewValueString(*(v.Identity)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 40, Value: w}
-		i++
-	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-// FromWire deserializes a TimerCanceledEventAttributes struct from its Thrift-level
+// FromWire deserializes a UpdateDomainInfo struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a TimerCanceledEventAttributes struct
+// An error is returned if we were unable to build a UpdateDomainInfo struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -41476,12 +46296,12 @@ func (v *TimerCanceledEventAttributes) ToWire() (wire.Value, error) {
 //     return nil, err
 //   }
 //
-//   var v TimerCanceledEventAttributes
+//   var v UpdateDomainInfo
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *TimerCanceledEventAttributes) FromWire(w wire.Value) error {
+func (v *UpdateDomainInfo) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
@@ -41490,37 +46310,25 @@ func (v *TimerCanceledEventAttributes) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TBinary {
 				var x string
 				x, err = field.Value.GetString(), error(nil)
-				v.TimerId = &x
+				v.Description = &x
 				if err != nil {
 					return err
 				}
 
 			}
 		case 20:
-			if field.Value.Type() == wire.TI64 {
-				var x int64
-				x, err = field.Value.GetI64(), error(nil)
-				v.StartedEventId = &x
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.OwnerEmail = &x
 				if err != nil {
 					return err
 				}
 
 			}
 		case 30:
-			if field.Value.Type() == wire.TI64 {
-				var x int64
-				x, err = field.Value.GetI64(), error(nil)
-				v.DecisionTaskCompletedEventId = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		case 40:
-			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.Identity = &x
+			if field.Value.Type() == wire.TMap {
+				v.Data, err = _Map_String_String_Read(field.Value.GetMap())
 				if err != nil {
 					return err
 				}
@@ -41532,55 +46340,48 @@ func (v *TimerCanceledEventAttributes) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a TimerCanceledEventAttributes
+// String returns a readable string representation of a UpdateDomainInfo
 // struct.
-func (v *TimerCanceledEventAttributes) String() string {
+func (v *UpdateDomainInfo) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [4]string
+	var fields [3]string
 	i := 0
-	if v.TimerId != nil {
-		fields[i] = fmt.Sprintf("TimerId: %v", *(v.TimerId))
-		i++
-	}
-	if v.StartedEventId != nil {
-		fields[i] = fmt.Sprintf("StartedEventId: %v", *(v.StartedEventId))
+	if v.Description != nil {
+		fields[i] = fmt.Sprintf("Description: %v", *(v.Description))
 		i++
 	}
-	if v.DecisionTaskCompletedEventId != nil {
-		fields[i] = fmt.Sprintf("DecisionTaskCompletedEventId: %v", *(v.DecisionTaskCompletedEventId))
+	if v.OwnerEmail != nil {
+		fields[i] = fmt.Sprintf("OwnerEmail: %v", *(v.OwnerEmail))
 		i++
 	}
-	if v.Identity != nil {
-		fields[i] = fmt.Sprintf("Identity: %v", *(v.Identity))
+	if v.Data != nil {
+		fields[i] = fmt.Sprintf("Data: %v", v.Data)
 		i++
 	}
 
-	return fmt.Sprintf("TimerCanceledEventAttributes{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("UpdateDomainInfo{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this TimerCanceledEventAttributes match the
-// provided TimerCanceledEventAttributes.
+// Equals returns true if all the fields of this UpdateDomainInfo match the
+// provided UpdateDomainInfo.
 //
 // This function performs a deep comparison.
-func (v *TimerCanceledEventAttributes) Equals(rhs *TimerCanceledEventAttributes) bool {
+func (v *UpdateDomainInfo) Equals(rhs *UpdateDomainInfo) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
 		return false
 	}
-	if !_String_EqualsPtr(v.TimerId, rhs.TimerId) {
-		return false
-	}
-	if !_I64_EqualsPtr(v.StartedEventId, rhs.StartedEventId) {
+	if !_String_EqualsPtr(v.Description, rhs.Description) {
 		return false
 	}
-	if !_I64_EqualsPtr(v.DecisionTaskCompletedEventId, rhs.DecisionTaskCompletedEventId) {
+	if !_String_EqualsPtr(v.OwnerEmail, rhs.OwnerEmail) {
 		return false
 	}
-	if !_String_EqualsPtr(v.Identity, rhs.Identity) {
+	if !((v.Data == nil && rhs.Data == nil) || (v.Data != nil && rhs.Data != nil && _Map_String_String_Equals(v.Data, rhs.Data))) {
 		return false
 	}
 
@@ -41588,92 +46389,77 @@ func (v *TimerCanceledEventAttributes) Equals(rhs *TimerCanceledEventAttributes)
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of TimerCanceledEventAttributes.
-func (v *TimerCanceledEventAttributes) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of UpdateDomainInfo.
+func (v *UpdateDomainInfo) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
-	if v.TimerId != nil {
-		enc.AddString("timerId", *v.TimerId)
-	}
-	if v.StartedEventId != nil {
-		enc.AddInt64("startedEventId", *v.StartedEventId)
+	if v.Description != nil {
+		enc.AddString("description", *v.Description)
 	}
-	if v.DecisionTaskCompletedEventId != nil {
-		enc.AddInt64("decisionTaskCompletedEventId", *v.DecisionTaskCompletedEventId)
+	if v.OwnerEmail != nil {
+		enc.AddString("ownerEmail", *v.OwnerEmail)
 	}
-	if v.Identity != nil {
-		enc.AddString("identity", *v.Identity)
+	if v.Data != nil {
+		err = multierr.Append(err, enc.AddObject("data", (_Map_String_String_Zapper)(v.Data)))
 	}
 	return err
 }
 
-// GetTimerId returns the value of TimerId if it is set or its
-// zero value if it is unset.
-func (v *TimerCanceledEventAttributes) GetTimerId() (o string) {
-	if v != nil && v.TimerId != nil {
-		return *v.TimerId
-	}
-
-	return
-}
-
-// IsSetTimerId returns true if TimerId is not nil.
-func (v *TimerCanceledEventAttributes) IsSetTimerId() bool {
-	return v != nil && v.TimerId != nil
-}
-
-// GetStartedEventId returns the value of StartedEventId if it is set or its
+// GetDescription returns the value of Description if it is set or its
 // zero value if it is unset.
-func (v *TimerCanceledEventAttributes) GetStartedEventId() (o int64) {
-	if v != nil && v.StartedEventId != nil {
-		return *v.StartedEventId
+func (v *UpdateDomainInfo) GetDescription() (o string) {
+	if v != nil && v.Description != nil {
+		return *v.Description
 	}
 
 	return
 }
 
-// IsSetStartedEventId returns true if StartedEventId is not nil.
-func (v *TimerCanceledEventAttributes) IsSetStartedEventId() bool {
-	return v != nil && v.StartedEventId != nil
+// IsSetDescription returns true if Description is not nil.
+func (v *UpdateDomainInfo) IsSetDescription() bool {
+	return v != nil && v.Description != nil
 }
 
-// GetDecisionTaskCompletedEventId returns the value of DecisionTaskCompletedEventId if it is set or its
+// GetOwnerEmail returns the value of OwnerEmail if it is set or its
 // zero value if it is unset.
-func (v *TimerCanceledEventAttributes) GetDecisionTaskCompletedEventId() (o int64) {
-	if v != nil && v.DecisionTaskCompletedEventId != nil {
-		return *v.DecisionTaskCompletedEventId
+func (v *UpdateDomainInfo) GetOwnerEmail() (o string) {
+	if v != nil && v.OwnerEmail != nil {
+		return *v.OwnerEmail
 	}
 
 	return
 }
 
-// IsSetDecisionTaskCompletedEventId returns true if DecisionTaskCompletedEventId is not nil.
-func (v *TimerCanceledEventAttributes) IsSetDecisionTaskCompletedEventId() bool {
-	return v != nil && v.DecisionTaskCompletedEventId != nil
+// IsSetOwnerEmail returns true if OwnerEmail is not nil.
+func (v *UpdateDomainInfo) IsSetOwnerEmail() bool {
+	return v != nil && v.OwnerEmail != nil
 }
 
-// GetIdentity returns the value of Identity if it is set or its
+// GetData returns the value of Data if it is set or its
 // zero value if it is unset.
-func (v *TimerCanceledEventAttributes) GetIdentity() (o string) {
-	if v != nil && v.Identity != nil {
-		return *v.Identity
+func (v *UpdateDomainInfo) GetData() (o map[string]string) {
+	if v != nil && v.Data != nil {
+		return v.Data
 	}
 
 	return
 }
 
-// IsSetIdentity returns true if Identity is not nil.
-func (v *TimerCanceledEventAttributes) IsSetIdentity() bool {
-	return v != nil && v.Identity != nil
+// IsSetData returns true if Data is not nil.
+func (v *UpdateDomainInfo) IsSetData() bool {
+	return v != nil && v.Data != nil
 }
 
-type TimerFiredEventAttributes struct {
-	TimerId        *string `json:"timerId,omitempty"`
-	StartedEventId *int64  `json:"startedEventId,omitempty"`
+type UpdateDomainRequest struct {
+	Name                     *string                         `json:"name,omitempty"`
+	UpdatedInfo              *UpdateDomainInfo               `json:"updatedInfo,omitempty"`
+	Configuration            *DomainConfiguration            `json:"configuration,omitempty"`
+	ReplicationConfiguration *DomainReplicationConfiguration `json:"replicationConfiguration,omitempty"`
+	SecurityToken            *string                         `json:"securityToken,omitempty"`
 }
 
-// ToWire translates a TimerFiredEventAttributes struct into a Thrift-level intermediate
+// ToWire translates a UpdateDomainRequest struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -41688,39 +46474,69 @@ type TimerFiredEventAttributes struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *TimerFiredEventAttributes) ToWire() (wire.Value, error) {
+func (v *UpdateDomainRequest) ToWire() (wire.Value, error) {
 	var (
-		fields [2]wire.Field
+		fields [5]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
 	)
 
-	if v.TimerId != nil {
-		w, err = wire.NewValueString(*(v.TimerId)), error(nil)
+	if v.Name != nil {
+		w, err = wire.NewValueString(*(v.Name)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.StartedEventId != nil {
-		w, err = wire.NewValueI64(*(v.StartedEventId)), error(nil)
+	if v.UpdatedInfo != nil {
+		w, err = v.UpdatedInfo.ToWire()
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
+	if v.Configuration != nil {
+		w, err = v.Configuration.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+	if v.ReplicationConfiguration != nil {
+		w, err = v.ReplicationConfiguration.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+	if v.SecurityToken != nil {
+		w, err = wire.NewValueString(*(v.SecurityToken)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 50, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-// FromWire deserializes a TimerFiredEventAttributes struct from its Thrift-level
+func _UpdateDomainInfo_Read(w wire.Value) (*UpdateDomainInfo, error) {
+	var v UpdateDomainInfo
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a UpdateDomainRequest struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a TimerFiredEventAttributes struct
+// An error is returned if we were unable to build a UpdateDomainRequest struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -41728,12 +46544,12 @@ func (v *TimerFiredEventAttributes) ToWire() (wire.Value, error) {
 //     return nil, err
 //   }
 //
-//   var v TimerFiredEventAttributes
+//   var v UpdateDomainRequest
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *TimerFiredEventAttributes) FromWire(w wire.Value) error {
+func (v *UpdateDomainRequest) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
@@ -41742,17 +46558,41 @@ func (v *TimerFiredEventAttributes) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TBinary {
 				var x string
 				x, err = field.Value.GetString(), error(nil)
-				v.TimerId = &x
+				v.Name = &x
 				if err != nil {
 					return err
 				}
 
 			}
 		case 20:
-			if field.Value.Type() == wire.TI64 {
-				var x int64
-				x, err = field.Value.GetI64(), error(nil)
-				v.StartedEventId = &x
+			if field.Value.Type() == wire.TStruct {
+				v.UpdatedInfo, err = _UpdateDomainInfo_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TStruct {
+				v.Configuration, err = _DomainConfiguration_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TStruct {
+				v.ReplicationConfiguration, err = _DomainReplicationConfiguration_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 50:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.SecurityToken = &x
 				if err != nil {
 					return err
 				}
@@ -41764,41 +46604,62 @@ func (v *TimerFiredEventAttributes) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a TimerFiredEventAttributes
+// String returns a readable string representation of a UpdateDomainRequest
 // struct.
-func (v *TimerFiredEventAttributes) String() string {
+func (v *UpdateDomainRequest) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [2]string
+	var fields [5]string
 	i := 0
-	if v.TimerId != nil {
-		fields[i] = fmt.Sprintf("TimerId: %v", *(v.TimerId))
+	if v.Name != nil {
+		fields[i] = fmt.Sprintf("Name: %v", *(v.Name))
 		i++
 	}
-	if v.StartedEventId != nil {
-		fields[i] = fmt.Sprintf("StartedEventId: %v", *(v.StartedEventId))
+	if v.UpdatedInfo != nil {
+		fields[i] = fmt.Sprintf("UpdatedInfo: %v", v.UpdatedInfo)
+		i++
+	}
+	if v.Configuration != nil {
+		fields[i] = fmt.Sprintf("Configuration: %v", v.Configuration)
+		i++
+	}
+	if v.ReplicationConfiguration != nil {
+		fields[i] = fmt.Sprintf("ReplicationConfiguration: %v", v.ReplicationConfiguration)
+		i++
+	}
+	if v.SecurityToken != nil {
+		fields[i] = fmt.Sprintf("SecurityToken: %v", *(v.SecurityToken))
 		i++
 	}
 
-	return fmt.Sprintf("TimerFiredEventAttributes{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("UpdateDomainRequest{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this TimerFiredEventAttributes match the
-// provided TimerFiredEventAttributes.
+// Equals returns true if all the fields of this UpdateDomainRequest match the
+// provided UpdateDomainRequest.
 //
 // This function performs a deep comparison.
-func (v *TimerFiredEventAttributes) Equals(rhs *TimerFiredEventAttributes) bool {
+func (v *UpdateDomainRequest) Equals(rhs *UpdateDomainRequest) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
 		return false
 	}
-	if !_String_EqualsPtr(v.TimerId, rhs.TimerId) {
+	if !_String_EqualsPtr(v.Name, rhs.Name) {
 		return false
 	}
-	if !_I64_EqualsPtr(v.StartedEventId, rhs.StartedEventId) {
+	if !((v.UpdatedInfo == nil && rhs.UpdatedInfo == nil) || (v.UpdatedInfo != nil && rhs.UpdatedInfo != nil && v.UpdatedInfo.Equals(rhs.UpdatedInfo))) {
+		return false
+	}
+	if !((v.Configuration == nil && rhs.Configuration == nil) || (v.Configuration != nil && rhs.Configuration != nil && v.Configuration.Equals(rhs.Configuration))) {
+		return false
+	}
+	if !((v.ReplicationConfiguration == nil && rhs.ReplicationConfiguration == nil) || (v.ReplicationConfiguration != nil && rhs.ReplicationConfiguration != nil && v.ReplicationConfiguration.Equals(rhs.ReplicationConfiguration))) {
+		return false
+	}
+	if !_String_EqualsPtr(v.SecurityToken, rhs.SecurityToken) {
 		return false
 	}
 
@@ -41806,57 +46667,113 @@ func (v *TimerFiredEventAttributes) Equals(rhs *TimerFiredEventAttributes) bool
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of TimerFiredEventAttributes.
-func (v *TimerFiredEventAttributes) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of UpdateDomainRequest.
+func (v *UpdateDomainRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
-	if v.TimerId != nil {
-		enc.AddString("timerId", *v.TimerId)
+	if v.Name != nil {
+		enc.AddString("name", *v.Name)
 	}
-	if v.StartedEventId != nil {
-		enc.AddInt64("startedEventId", *v.StartedEventId)
+	if v.UpdatedInfo != nil {
+		err = multierr.Append(err, enc.AddObject("updatedInfo", v.UpdatedInfo))
+	}
+	if v.Configuration != nil {
+		err = multierr.Append(err, enc.AddObject("configuration", v.Configuration))
+	}
+	if v.ReplicationConfiguration != nil {
+		err = multierr.Append(err, enc.AddObject("replicationConfiguration", v.ReplicationConfiguration))
+	}
+	if v.SecurityToken != nil {
+		enc.AddString("securityToken", *v.SecurityToken)
 	}
 	return err
 }
 
-// GetTimerId returns the value of TimerId if it is set or its
+// GetName returns the value of Name if it is set or its
 // zero value if it is unset.
-func (v *TimerFiredEventAttributes) GetTimerId() (o string) {
-	if v != nil && v.TimerId != nil {
-		return *v.TimerId
+func (v *UpdateDomainRequest) GetName() (o string) {
+	if v != nil && v.Name != nil {
+		return *v.Name
 	}
 
 	return
 }
 
-// IsSetTimerId returns true if TimerId is not nil.
-func (v *TimerFiredEventAttributes) IsSetTimerId() bool {
-	return v != nil && v.TimerId != nil
+// IsSetName returns true if Name is not nil.
+func (v *UpdateDomainRequest) IsSetName() bool {
+	return v != nil && v.Name != nil
+}
+
+// GetUpdatedInfo returns the value of UpdatedInfo if it is set or its
+// zero value if it is unset.
+func (v *UpdateDomainRequest) GetUpdatedInfo() (o *UpdateDomainInfo) {
+	if v != nil && v.UpdatedInfo != nil {
+		return v.UpdatedInfo
+	}
+
+	return
+}
+
+// IsSetUpdatedInfo returns true if UpdatedInfo is not nil.
+func (v *UpdateDomainRequest) IsSetUpdatedInfo() bool {
+	return v != nil && v.UpdatedInfo != nil
+}
+
+// GetConfiguration returns the value of Configuration if it is set or its
+// zero value if it is unset.
+func (v *UpdateDomainRequest) GetConfiguration() (o *DomainConfiguration) {
+	if v != nil && v.Configuration != nil {
+		return v.Configuration
+	}
+
+	return
+}
+
+// IsSetConfiguration returns true if Configuration is not nil.
+func (v *UpdateDomainRequest) IsSetConfiguration() bool {
+	return v != nil && v.Configuration != nil
+}
+
+// GetReplicationConfiguration returns the value of ReplicationConfiguration if it is set or its
+// zero value if it is unset.
+func (v *UpdateDomainRequest) GetReplicationConfiguration() (o *DomainReplicationConfiguration) {
+	if v != nil && v.ReplicationConfiguration != nil {
+		return v.ReplicationConfiguration
+	}
+
+	return
+}
+
+// IsSetReplicationConfiguration returns true if ReplicationConfiguration is not nil.
+func (v *UpdateDomainRequest) IsSetReplicationConfiguration() bool {
+	return v != nil && v.ReplicationConfiguration != nil
 }
 
-// GetStartedEventId returns the value of StartedEventId if it is set or its
+// GetSecurityToken returns the value of SecurityToken if it is set or its
 // zero value if it is unset.
-func (v *TimerFiredEventAttributes) GetStartedEventId() (o int64) {
-	if v != nil && v.StartedEventId != nil {
-		return *v.StartedEventId
+func (v *UpdateDomainRequest) GetSecurityToken() (o string) {
+	if v != nil && v.SecurityToken != nil {
+		return *v.SecurityToken
 	}
 
 	return
 }
 
-// IsSetStartedEventId returns true if StartedEventId is not nil.
-func (v *TimerFiredEventAttributes) IsSetStartedEventId() bool {
-	return v != nil && v.StartedEventId != nil
+// IsSetSecurityToken returns true if SecurityToken is not nil.
+func (v *UpdateDomainRequest) IsSetSecurityToken() bool {
+	return v != nil && v.SecurityToken != nil
 }
 
-type TimerStartedEventAttributes struct {
-	TimerId                      *string `json:"timerId,omitempty"`
-	StartToFireTimeoutSeconds    *int64  `json:"startToFireTimeoutSeconds,omitempty"`
-	DecisionTaskCompletedEventId *int64  `json:"decisionTaskCompletedEventId,omitempty"`
+type UpdateDomainResponse struct {
+	DomainInfo               *DomainInfo                     `json:"domainInfo,omitempty"`
+	Configuration            *DomainConfiguration            `json:"configuration,omitempty"`
+	ReplicationConfiguration *DomainReplicationConfiguration `json:"replicationConfiguration,omitempty"`
+	FailoverVersion          *int64                          `json:"failoverVersion,omitempty"`
+	IsGlobalDomain           *bool                           `json:"isGlobalDomain,omitempty"`
 }
 
-// ToWire translates a TimerStartedEventAttributes struct into a Thrift-level intermediate
+// ToWire translates a UpdateDomainResponse struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -41871,47 +46788,63 @@ type TimerStartedEventAttributes struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *TimerStartedEventAttributes) ToWire() (wire.Value, error) {
+func (v *UpdateDomainResponse) ToWire() (wire.Value, error) {
 	var (
-		fields [3]wire.Field
+		fields [5]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
 	)
 
-	if v.TimerId != nil {
-		w, err = wire.NewValueString(*(v.TimerId)), error(nil)
+	if v.DomainInfo != nil {
+		w, err = v.DomainInfo.ToWire()
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.StartToFireTimeoutSeconds != nil {
-		w, err = wire.NewValueI64(*(v.StartToFireTimeoutSeconds)), error(nil)
+	if v.Configuration != nil {
+		w, err = v.Configuration.ToWire()
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
-	if v.DecisionTaskCompletedEventId != nil {
-		w, err = wire.NewValueI64(*(v.DecisionTaskCompletedEventId)), error(nil)
+	if v.ReplicationConfiguration != nil {
+		w, err = v.ReplicationConfiguration.ToWire()
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 30, Value: w}
 		i++
 	}
+	if v.FailoverVersion != nil {
+		w, err = wire.NewValueI64(*(v.FailoverVersion)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+	if v.IsGlobalDomain != nil {
+		w, err = wire.NewValueBool(*(v.IsGlobalDomain)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 50, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-// FromWire deserializes a TimerStartedEventAttributes struct from its Thrift-level
+// FromWire deserializes a UpdateDomainResponse struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a TimerStartedEventAttributes struct
+// An error is returned if we were unable to build a UpdateDomainResponse struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -41919,41 +46852,55 @@ func (v *TimerStartedEventAttributes) ToWire() (wire.Value, error) {
 //     return nil, err
 //   }
 //
-//   var v TimerStartedEventAttributes
+//   var v UpdateDomainResponse
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *TimerStartedEventAttributes) FromWire(w wire.Value) error {
+func (v *UpdateDomainResponse) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
 		case 10:
-			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.TimerId = &x
+			if field.Value.Type() == wire.TStruct {
+				v.DomainInfo, err = _DomainInfo_Read(field.Value)
 				if err != nil {
 					return err
 				}
 
 			}
 		case 20:
-			if field.Value.Type() == wire.TI64 {
-				var x int64
-				x, err = field.Value.GetI64(), error(nil)
-				v.StartToFireTimeoutSeconds = &x
+			if field.Value.Type() == wire.TStruct {
+				v.Configuration, err = _DomainConfiguration_Read(field.Value)
 				if err != nil {
 					return err
 				}
 
 			}
 		case 30:
+			if field.Value.Type() == wire.TStruct {
+				v.ReplicationConfiguration, err = _DomainReplicationConfiguration_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
 			if field.Value.Type() == wire.TI64 {
 				var x int64
 				x, err = field.Value.GetI64(), error(nil)
-				v.DecisionTaskCompletedEventId = &x
+				v.FailoverVersion = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 50:
+			if field.Value.Type() == wire.TBool {
+				var x bool
+				x, err = field.Value.GetBool(), error(nil)
+				v.IsGlobalDomain = &x
 				if err != nil {
 					return err
 				}
@@ -41965,48 +46912,62 @@ func (v *TimerStartedEventAttributes) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a TimerStartedEventAttributes
+// String returns a readable string representation of a UpdateDomainResponse
 // struct.
-func (v *TimerStartedEventAttributes) String() string {
+func (v *UpdateDomainResponse) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [3]string
+	var fields [5]string
 	i := 0
-	if v.TimerId != nil {
-		fields[i] = fmt.Sprintf("TimerId: %v", *(v.TimerId))
+	if v.DomainInfo != nil {
+		fields[i] = fmt.Sprintf("DomainInfo: %v", v.DomainInfo)
 		i++
 	}
-	if v.StartToFireTimeoutSeconds != nil {
-		fields[i] = fmt.Sprintf("StartToFireTimeoutSeconds: %v", *(v.StartToFireTimeoutSeconds))
+	if v.Configuration != nil {
+		fields[i] = fmt.Sprintf("Configuration: %v", v.Configuration)
 		i++
 	}
-	if v.DecisionTaskCompletedEventId != nil {
-		fields[i] = fmt.Sprintf("DecisionTaskCompletedEventId: %v", *(v.DecisionTaskCompletedEventId))
+	if v.ReplicationConfiguration != nil {
+		fields[i] = fmt.Sprintf("ReplicationConfiguration: %v", v.ReplicationConfiguration)
+		i++
+	}
+	if v.FailoverVersion != nil {
+		fields[i] = fmt.Sprintf("FailoverVersion: %v", *(v.FailoverVersion))
+		i++
+	}
+	if v.IsGlobalDomain != nil {
+		fields[i] = fmt.Sprintf("IsGlobalDomain: %v", *(v.IsGlobalDomain))
 		i++
 	}
 
-	return fmt.Sprintf("TimerStartedEventAttributes{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("UpdateDomainResponse{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this TimerStartedEventAttributes match the
-// provided TimerStartedEventAttributes.
+// Equals returns true if all the fields of this UpdateDomainResponse match the
+// provided UpdateDomainResponse.
 //
 // This function performs a deep comparison.
-func (v *TimerStartedEventAttributes) Equals(rhs *TimerStartedEventAttributes) bool {
+func (v *UpdateDomainResponse) Equals(rhs *UpdateDomainResponse) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
 		return false
 	}
-	if !_String_EqualsPtr(v.TimerId, rhs.TimerId) {
+	if !((v.DomainInfo == nil && rhs.DomainInfo == nil) || (v.DomainInfo != nil && rhs.DomainInfo != nil && v.DomainInfo.Equals(rhs.DomainInfo))) {
 		return false
 	}
-	if !_I64_EqualsPtr(v.StartToFireTimeoutSeconds, rhs.StartToFireTimeoutSeconds) {
+	if !((v.Configuration == nil && rhs.Configuration == nil) || (v.Configuration != nil && rhs.Configuration != nil && v.Configuration.Equals(rhs.Configuration))) {
 		return false
 	}
-	if !_I64_EqualsPtr(v.DecisionTaskCompletedEventId, rhs.DecisionTaskCompletedEventId) {
+	if !((v.ReplicationConfiguration == nil && rhs.ReplicationConfiguration == nil) || (v.ReplicationConfiguration != nil && rhs.ReplicationConfiguration != nil && v.ReplicationConfiguration.Equals(rhs.ReplicationConfiguration))) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.FailoverVersion, rhs.FailoverVersion) {
+		return false
+	}
+	if !_Bool_EqualsPtr(v.IsGlobalDomain, rhs.IsGlobalDomain) {
 		return false
 	}
 
@@ -42014,74 +46975,113 @@ func (v *TimerStartedEventAttributes) Equals(rhs *TimerStartedEventAttributes) b
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of TimerStartedEventAttributes.
-func (v *TimerStartedEventAttributes) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of UpdateDomainResponse.
+func (v *UpdateDomainResponse) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
-	if v.TimerId != nil {
-		enc.AddString("timerId", *v.TimerId)
+	if v.DomainInfo != nil {
+		err = multierr.Append(err, enc.AddObject("domainInfo", v.DomainInfo))
 	}
-	if v.StartToFireTimeoutSeconds != nil {
-		enc.AddInt64("startToFireTimeoutSeconds", *v.StartToFireTimeoutSeconds)
+	if v.Configuration != nil {
+		err = multierr.Append(err, enc.AddObject("configuration", v.Configuration))
 	}
-	if v.DecisionTaskCompletedEventId != nil {
-		enc.AddInt64("decisionTaskCompletedEventId", *v.DecisionTaskCompletedEventId)
+	if v.ReplicationConfiguration != nil {
+		err = multierr.Append(err, enc.AddObject("replicationConfiguration", v.ReplicationConfiguration))
+	}
+	if v.FailoverVersion != nil {
+		enc.AddInt64("failoverVersion", *v.FailoverVersion)
+	}
+	if v.IsGlobalDomain != nil {
+		enc.AddBool("isGlobalDomain", *v.IsGlobalDomain)
 	}
 	return err
 }
 
-// GetTimerId returns the value of TimerId if it is set or its
+// GetDomainInfo returns the value of DomainInfo if it is set or its
 // zero value if it is unset.
-func (v *TimerStartedEventAttributes) GetTimerId() (o string) {
-	if v != nil && v.TimerId != nil {
-		return *v.TimerId
+func (v *UpdateDomainResponse) GetDomainInfo() (o *DomainInfo) {
+	if v != nil && v.DomainInfo != nil {
+		return v.DomainInfo
 	}
 
 	return
 }
 
-// IsSetTimerId returns true if TimerId is not nil.
-func (v *TimerStartedEventAttributes) IsSetTimerId() bool {
-	return v != nil && v.TimerId != nil
+// IsSetDomainInfo returns true if DomainInfo is not nil.
+func (v *UpdateDomainResponse) IsSetDomainInfo() bool {
+	return v != nil && v.DomainInfo != nil
 }
 
-// GetStartToFireTimeoutSeconds returns the value of StartToFireTimeoutSeconds if it is set or its
+// GetConfiguration returns the value of Configuration if it is set or its
 // zero value if it is unset.
-func (v *TimerStartedEventAttributes) GetStartToFireTimeoutSeconds() (o int64) {
-	if v != nil && v.StartToFireTimeoutSeconds != nil {
-		return *v.StartToFireTimeoutSeconds
+func (v *UpdateDomainResponse) GetConfiguration() (o *DomainConfiguration) {
+	if v != nil && v.Configuration != nil {
+		return v.Configuration
 	}
 
 	return
 }
 
-// IsSetStartToFireTimeoutSeconds returns true if StartToFireTimeoutSeconds is not nil.
-func (v *TimerStartedEventAttributes) IsSetStartToFireTimeoutSeconds() bool {
-	return v != nil && v.StartToFireTimeoutSeconds != nil
+// IsSetConfiguration returns true if Configuration is not nil.
+func (v *UpdateDomainResponse) IsSetConfiguration() bool {
+	return v != nil && v.Configuration != nil
 }
 
-// GetDecisionTaskCompletedEventId returns the value of DecisionTaskCompletedEventId if it is set or its
+// GetReplicationConfiguration returns the value of ReplicationConfiguration if it is set or its
 // zero value if it is unset.
-func (v *TimerStartedEventAttributes) GetDecisionTaskCompletedEventId() (o int64) {
-	if v != nil && v.DecisionTaskCompletedEventId != nil {
-		return *v.DecisionTaskCompletedEventId
+func (v *UpdateDomainResponse) GetReplicationConfiguration() (o *DomainReplicationConfiguration) {
+	if v != nil && v.ReplicationConfiguration != nil {
+		return v.ReplicationConfiguration
 	}
 
 	return
 }
 
-// IsSetDecisionTaskCompletedEventId returns true if DecisionTaskCompletedEventId is not nil.
-func (v *TimerStartedEventAttributes) IsSetDecisionTaskCompletedEventId() bool {
-	return v != nil && v.DecisionTaskCompletedEventId != nil
+// IsSetReplicationConfiguration returns true if ReplicationConfiguration is not nil.
+func (v *UpdateDomainResponse) IsSetReplicationConfiguration() bool {
+	return v != nil && v.ReplicationConfiguration != nil
 }
 
-type TransientDecisionInfo struct {
-	ScheduledEvent *HistoryEvent `json:"scheduledEvent,omitempty"`
-	StartedEvent   *HistoryEvent `json:"startedEvent,omitempty"`
+// GetFailoverVersion returns the value of FailoverVersion if it is set or its
+// zero value if it is unset.
+func (v *UpdateDomainResponse) GetFailoverVersion() (o int64) {
+	if v != nil && v.FailoverVersion != nil {
+		return *v.FailoverVersion
+	}
+
+	return
 }
 
-// ToWire translates a TransientDecisionInfo struct into a Thrift-level intermediate
+// IsSetFailoverVersion returns true if FailoverVersion is not nil.
+func (v *UpdateDomainResponse) IsSetFailoverVersion() bool {
+	return v != nil && v.FailoverVersion != nil
+}
+
+// GetIsGlobalDomain returns the value of IsGlobalDomain if it is set or its
+// zero value if it is unset.
+func (v *UpdateDomainResponse) GetIsGlobalDomain() (o bool) {
+	if v != nil && v.IsGlobalDomain != nil {
+		return *v.IsGlobalDomain
+	}
+
+	return
+}
+
+// IsSetIsGlobalDomain returns true if IsGlobalDomain is not nil.
+func (v *UpdateDomainResponse) IsSetIsGlobalDomain() bool {
+	return v != nil && v.IsGlobalDomain != nil
+}
+
+type UpdateWorkflowExecutionLabelsRequest struct {
+	Domain            *string            `json:"domain,omitempty"`
+	WorkflowExecution *WorkflowExecution `json:"workflowExecution,omitempty"`
+	UpsertLabels      map[string]string  `json:"upsertLabels,omitempty"`
+	RemoveLabels      []string           `json:"removeLabels,omitempty"`
+	Identity          *string            `json:"identity,omitempty"`
+}
+
+// ToWire translates a UpdateWorkflowExecutionLabelsRequest struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -42096,39 +47096,63 @@ type TransientDecisionInfo struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *TransientDecisionInfo) ToWire() (wire.Value, error) {
+func (v *UpdateWorkflowExecutionLabelsRequest) ToWire() (wire.Value, error) {
 	var (
-		fields [2]wire.Field
+		fields [5]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
 	)
 
-	if v.ScheduledEvent != nil {
-		w, err = v.ScheduledEvent.ToWire()
+	if v.Domain != nil {
+		w, err = wire.NewValueString(*(v.Domain)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.StartedEvent != nil {
-		w, err = v.StartedEvent.ToWire()
+	if v.WorkflowExecution != nil {
+		w, err = v.WorkflowExecution.ToWire()
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
+	if v.UpsertLabels != nil {
+		w, err = wire.NewValueMap(_Map_String_String_MapItemList(v.UpsertLabels)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+	if v.RemoveLabels != nil {
+		w, err = wire.NewValueList(_List_String_ValueList(v.RemoveLabels)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+	if v.Identity != nil {
+		w, err = wire.NewValueString(*(v.Identity)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 50, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-// FromWire deserializes a TransientDecisionInfo struct from its Thrift-level
+// FromWire deserializes a UpdateWorkflowExecutionLabelsRequest struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a TransientDecisionInfo struct
+// An error is returned if we were unable to build a UpdateWorkflowExecutionLabelsRequest struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -42136,19 +47160,21 @@ func (v *TransientDecisionInfo) ToWire() (wire.Value, error) {
 //     return nil, err
 //   }
 //
-//   var v TransientDecisionInfo
+//   var v UpdateWorkflowExecutionLabelsRequest
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *TransientDecisionInfo) FromWire(w wire.Value) error {
+func (v *UpdateWorkflowExecutionLabelsRequest) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
 		case 10:
-			if field.Value.Type() == wire.TStruct {
-				v.ScheduledEvent, err = _HistoryEvent_Read(field.Value)
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Domain = &x
 				if err != nil {
 					return err
 				}
@@ -42156,7 +47182,33 @@ func (v *TransientDecisionInfo) FromWire(w wire.Value) error {
 			}
 		case 20:
 			if field.Value.Type() == wire.TStruct {
-				v.StartedEvent, err = _HistoryEvent_Read(field.Value)
+				v.WorkflowExecution, err = _WorkflowExecution_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TMap {
+				v.UpsertLabels, err = _Map_String_String_Read(field.Value.GetMap())
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TList {
+				v.RemoveLabels, err = _List_String_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
+			}
+		case 50:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Identity = &x
 				if err != nil {
 					return err
 				}
@@ -42168,41 +47220,62 @@ func (v *TransientDecisionInfo) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a TransientDecisionInfo
+// String returns a readable string representation of a UpdateWorkflowExecutionLabelsRequest
 // struct.
-func (v *TransientDecisionInfo) String() string {
+func (v *UpdateWorkflowExecutionLabelsRequest) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [2]string
+	var fields [5]string
 	i := 0
-	if v.ScheduledEvent != nil {
-		fields[i] = fmt.Sprintf("ScheduledEvent: %v", v.ScheduledEvent)
+	if v.Domain != nil {
+		fields[i] = fmt.Sprintf("Domain: %v", *(v.Domain))
 		i++
 	}
-	if v.StartedEvent != nil {
-		fields[i] = fmt.Sprintf("StartedEvent: %v", v.StartedEvent)
+	if v.WorkflowExecution != nil {
+		fields[i] = fmt.Sprintf("WorkflowExecution: %v", v.WorkflowExecution)
+		i++
+	}
+	if v.UpsertLabels != nil {
+		fields[i] = fmt.Sprintf("UpsertLabels: %v", v.UpsertLabels)
+		i++
+	}
+	if v.RemoveLabels != nil {
+		fields[i] = fmt.Sprintf("RemoveLabels: %v", v.RemoveLabels)
+		i++
+	}
+	if v.Identity != nil {
+		fields[i] = fmt.Sprintf("Identity: %v", *(v.Identity))
 		i++
 	}
 
-	return fmt.Sprintf("TransientDecisionInfo{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("UpdateWorkflowExecutionLabelsRequest{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this TransientDecisionInfo match the
-// provided TransientDecisionInfo.
+// Equals returns true if all the fields of this UpdateWorkflowExecutionLabelsRequest match the
+// provided UpdateWorkflowExecutionLabelsRequest.
 //
 // This function performs a deep comparison.
-func (v *TransientDecisionInfo) Equals(rhs *TransientDecisionInfo) bool {
+func (v *UpdateWorkflowExecutionLabelsRequest) Equals(rhs *UpdateWorkflowExecutionLabelsRequest) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
 		return false
 	}
-	if !((v.ScheduledEvent == nil && rhs.ScheduledEvent == nil) || (v.ScheduledEvent != nil && rhs.ScheduledEvent != nil && v.ScheduledEvent.Equals(rhs.ScheduledEvent))) {
+	if !_String_EqualsPtr(v.Domain, rhs.Domain) {
 		return false
 	}
-	if !((v.StartedEvent == nil && rhs.StartedEvent == nil) || (v.StartedEvent != nil && rhs.StartedEvent != nil && v.StartedEvent.Equals(rhs.StartedEvent))) {
+	if !((v.WorkflowExecution == nil && rhs.WorkflowExecution == nil) || (v.WorkflowExecution != nil && rhs.WorkflowExecution != nil && v.WorkflowExecution.Equals(rhs.WorkflowExecution))) {
+		return false
+	}
+	if !((v.UpsertLabels == nil && rhs.UpsertLabels == nil) || (v.UpsertLabels != nil && rhs.UpsertLabels != nil && _Map_String_String_Equals(v.UpsertLabels, rhs.UpsertLabels))) {
+		return false
+	}
+	if !((v.RemoveLabels == nil && rhs.RemoveLabels == nil) || (v.RemoveLabels != nil && rhs.RemoveLabels != nil && _List_String_Equals(v.RemoveLabels, rhs.RemoveLabels))) {
+		return false
+	}
+	if !_String_EqualsPtr(v.Identity, rhs.Identity) {
 		return false
 	}
 
@@ -42210,57 +47283,139 @@ func (v *TransientDecisionInfo) Equals(rhs *TransientDecisionInfo) bool {
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of TransientDecisionInfo.
-func (v *TransientDecisionInfo) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of UpdateWorkflowExecutionLabelsRequest.
+func (v *UpdateWorkflowExecutionLabelsRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
-	if v.ScheduledEvent != nil {
-		err = multierr.Append(err, enc.AddObject("scheduledEvent", v.ScheduledEvent))
+	if v.Domain != nil {
+		enc.AddString("domain", *v.Domain)
 	}
-	if v.StartedEvent != nil {
-		err = multierr.Append(err, enc.AddObject("startedEvent", v.StartedEvent))
+	if v.WorkflowExecution != nil {
+		err = multierr.Append(err, enc.AddObject("workflowExecution", v.WorkflowExecution))
+	}
+	if v.UpsertLabels != nil {
+		err = multierr.Append(err, enc.AddObject("upsertLabels", (_Map_String_String_Zapper)(v.UpsertLabels)))
+	}
+	if v.RemoveLabels != nil {
+		err = multierr.Append(err, enc.AddArray("removeLabels", (_List_String_Zapper)(v.RemoveLabels)))
+	}
+	if v.Identity != nil {
+		enc.AddString("identity", *v.Identity)
 	}
 	return err
 }
 
-// GetScheduledEvent returns the value of ScheduledEvent if it is set or its
+// GetDomain returns the value of Domain if it is set or its
 // zero value if it is unset.
-func (v *TransientDecisionInfo) GetScheduledEvent() (o *HistoryEvent) {
-	if v != nil && v.ScheduledEvent != nil {
-		return v.ScheduledEvent
+func (v *UpdateWorkflowExecutionLabelsRequest) GetDomain() (o string) {
+	if v != nil && v.Domain != nil {
+		return *v.Domain
 	}
 
 	return
 }
 
-// IsSetScheduledEvent returns true if ScheduledEvent is not nil.
-func (v *TransientDecisionInfo) IsSetScheduledEvent() bool {
-	return v != nil && v.ScheduledEvent != nil
+// IsSetDomain returns true if Domain is not nil.
+func (v *UpdateWorkflowExecutionLabelsRequest) IsSetDomain() bool {
+	return v != nil && v.Domain != nil
 }
 
-// GetStartedEvent returns the value of StartedEvent if it is set or its
+// GetWorkflowExecution returns the value of WorkflowExecution if it is set or its
 // zero value if it is unset.
-func (v *TransientDecisionInfo) GetStartedEvent() (o *HistoryEvent) {
-	if v != nil && v.StartedEvent != nil {
-		return v.StartedEvent
+func (v *UpdateWorkflowExecutionLabelsRequest) GetWorkflowExecution() (o *WorkflowExecution) {
+	if v != nil && v.WorkflowExecution != nil {
+		return v.WorkflowExecution
 	}
 
 	return
 }
 
-// IsSetStartedEvent returns true if StartedEvent is not nil.
-func (v *TransientDecisionInfo) IsSetStartedEvent() bool {
-	return v != nil && v.StartedEvent != nil
+// IsSetWorkflowExecution returns true if WorkflowExecution is not nil.
+func (v *UpdateWorkflowExecutionLabelsRequest) IsSetWorkflowExecution() bool {
+	return v != nil && v.WorkflowExecution != nil
 }
 
-type UpdateDomainInfo struct {
-	Description *string           `json:"description,omitempty"`
-	OwnerEmail  *string           `json:"ownerEmail,omitempty"`
-	Data        map[string]string `json:"data,omitempty"`
+// GetUpsertLabels returns the value of UpsertLabels if it is set or its
+// zero value if it is unset.
+func (v *UpdateWorkflowExecutionLabelsRequest) GetUpsertLabels() (o map[string]string) {
+	if v != nil && v.UpsertLabels != nil {
+		return v.UpsertLabels
+	}
+
+	return
 }
 
-// ToWire translates a UpdateDomainInfo struct into a Thrift-level intermediate
+// IsSetUpsertLabels returns true if UpsertLabels is not nil.
+func (v *UpdateWorkflowExecutionLabelsRequest) IsSetUpsertLabels() bool {
+	return v != nil && v.UpsertLabels != nil
+}
+
+// GetRemoveLabels returns the value of RemoveLabels if it is set or its
+// zero value if it is unset.
+func (v *UpdateWorkflowExecutionLabelsRequest) GetRemoveLabels() (o []string) {
+	if v != nil && v.RemoveLabels != nil {
+		return v.RemoveLabels
+	}
+
+	return
+}
+
+// IsSetRemoveLabels returns true if RemoveLabels is not nil.
+func (v *UpdateWorkflowExecutionLabelsRequest) IsSetRemoveLabels() bool {
+	return v != nil && v.RemoveLabels != nil
+}
+
+// GetIdentity returns the value of Identity if it is set or its
+// zero value if it is unset.
+func (v *UpdateWorkflowExecutionLabelsRequest) GetIdentity() (o string) {
+	if v != nil && v.Identity != nil {
+		return *v.Identity
+	}
+
+	return
+}
+
+// IsSetIdentity returns true if Identity is not nil.
+func (v *UpdateWorkflowExecutionLabelsRequest) IsSetIdentity() bool {
+	return v != nil && v.Identity != nil
+}
+
+type VersionHistories struct {
+	CurrentVersionHistoryIndex *int32            `json:"currentVersionHistoryIndex,omitempty"`
+	Histories                  []*VersionHistory `json:"histories,omitempty"`
+}
+
+type _List_VersionHistory_ValueList []*VersionHistory
+
+func (v _List_VersionHistory_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_VersionHistory_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_VersionHistory_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_VersionHistory_ValueList) Close() {}
+
+// ToWire translates a VersionHistories struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -42275,47 +47430,63 @@ type UpdateDomainInfo struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *UpdateDomainInfo) ToWire() (wire.Value, error) {
+func (v *VersionHistories) ToWire() (wire.Value, error) {
 	var (
-		fields [3]wire.Field
+		fields [2]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
 	)
 
-	if v.Description != nil {
-		w, err = wire.NewValueString(*(v.Description)), error(nil)
+	if v.CurrentVersionHistoryIndex != nil {
+		w, err = wire.NewValueI32(*(v.CurrentVersionHistoryIndex)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.OwnerEmail != nil {
-		w, err = wire.NewValueString(*(v.OwnerEmail)), error(nil)
+	if v.Histories != nil {
+		w, err = wire.NewValueList(_List_VersionHistory_ValueList(v.Histories)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
-	if v.Data != nil {
-		w, err = wire.NewValueMap(_Map_String_String_MapItemList(v.Data)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 30, Value: w}
-		i++
-	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-// FromWire deserializes a UpdateDomainInfo struct from its Thrift-level
+func _VersionHistory_Read(w wire.Value) (*VersionHistory, error) {
+	var v VersionHistory
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _List_VersionHistory_Read(l wire.ValueList) ([]*VersionHistory, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make([]*VersionHistory, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _VersionHistory_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+// FromWire deserializes a VersionHistories struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a UpdateDomainInfo struct
+// An error is returned if we were unable to build a VersionHistories struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -42323,39 +47494,29 @@ func (v *UpdateDomainInfo) ToWire() (wire.Value, error) {
 //     return nil, err
 //   }
 //
-//   var v UpdateDomainInfo
+//   var v VersionHistories
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *UpdateDomainInfo) FromWire(w wire.Value) error {
+func (v *VersionHistories) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
 		case 10:
-			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.Description = &x
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.CurrentVersionHistoryIndex = &x
 				if err != nil {
 					return err
 				}
 
 			}
 		case 20:
-			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.OwnerEmail = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		case 30:
-			if field.Value.Type() == wire.TMap {
-				v.Data, err = _Map_String_String_Read(field.Value.GetMap())
+			if field.Value.Type() == wire.TList {
+				v.Histories, err = _List_VersionHistory_Read(field.Value.GetList())
 				if err != nil {
 					return err
 				}
@@ -42367,126 +47528,153 @@ func (v *UpdateDomainInfo) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a UpdateDomainInfo
+// String returns a readable string representation of a VersionHistories
 // struct.
-func (v *UpdateDomainInfo) String() string {
+func (v *VersionHistories) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [3]string
-	i := 0
-	if v.Description != nil {
-		fields[i] = fmt.Sprintf("Description: %v", *(v.Description))
+	var fields [2]string
+	i := 0
+	if v.CurrentVersionHistoryIndex != nil {
+		fields[i] = fmt.Sprintf("CurrentVersionHistoryIndex: %v", *(v.CurrentVersionHistoryIndex))
 		i++
 	}
-	if v.OwnerEmail != nil {
-		fields[i] = fmt.Sprintf("OwnerEmail: %v", *(v.OwnerEmail))
+	if v.Histories != nil {
+		fields[i] = fmt.Sprintf("Histories: %v", v.Histories)
 		i++
 	}
-	if v.Data != nil {
-		fields[i] = fmt.Sprintf("Data: %v", v.Data)
-		i++
+
+	return fmt.Sprintf("VersionHistories{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _List_VersionHistory_Equals(lhs, rhs []*VersionHistory) bool {
+	if len(lhs) != len(rhs) {
+		return false
 	}
 
-	return fmt.Sprintf("UpdateDomainInfo{%v}", strings.Join(fields[:i], ", "))
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+
+	return true
 }
 
-// Equals returns true if all the fields of this UpdateDomainInfo match the
-// provided UpdateDomainInfo.
+// Equals returns true if all the fields of this VersionHistories match the
+// provided VersionHistories.
 //
 // This function performs a deep comparison.
-func (v *UpdateDomainInfo) Equals(rhs *UpdateDomainInfo) bool {
+func (v *VersionHistories) Equals(rhs *VersionHistories) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
 		return false
 	}
-	if !_String_EqualsPtr(v.Description, rhs.Description) {
-		return false
-	}
-	if !_String_EqualsPtr(v.OwnerEmail, rhs.OwnerEmail) {
+	if !_I32_EqualsPtr(v.CurrentVersionHistoryIndex, rhs.CurrentVersionHistoryIndex) {
 		return false
 	}
-	if !((v.Data == nil && rhs.Data == nil) || (v.Data != nil && rhs.Data != nil && _Map_String_String_Equals(v.Data, rhs.Data))) {
+	if !((v.Histories == nil && rhs.Histories == nil) || (v.Histories != nil && rhs.Histories != nil && _List_VersionHistory_Equals(v.Histories, rhs.Histories))) {
 		return false
 	}
 
 	return true
 }
 
+type _List_VersionHistory_Zapper []*VersionHistory
+
+// MarshalLogArray implements zapcore.ArrayMarshaler, enabling
+// fast logging of _List_VersionHistory_Zapper.
+func (l _List_VersionHistory_Zapper) MarshalLogArray(enc zapcore.ArrayEncoder) (err error) {
+	for _, v := range l {
+		err = multierr.Append(err, enc.AppendObject(v))
+	}
+	return err
+}
+
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of UpdateDomainInfo.
-func (v *UpdateDomainInfo) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of VersionHistories.
+func (v *VersionHistories) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
-	if v.Description != nil {
-		enc.AddString("description", *v.Description)
-	}
-	if v.OwnerEmail != nil {
-		enc.AddString("ownerEmail", *v.OwnerEmail)
+	if v.CurrentVersionHistoryIndex != nil {
+		enc.AddInt32("currentVersionHistoryIndex", *v.CurrentVersionHistoryIndex)
 	}
-	if v.Data != nil {
-		err = multierr.Append(err, enc.AddObject("data", (_Map_String_String_Zapper)(v.Data)))
+	if v.Histories != nil {
+		err = multierr.Append(err, enc.AddArray("histories", (_List_VersionHistory_Zapper)(v.Histories)))
 	}
 	return err
 }
 
-// GetDescription returns the value of Description if it is set or its
+// GetCurrentVersionHistoryIndex returns the value of CurrentVersionHistoryIndex if it is set or its
 // zero value if it is unset.
-func (v *UpdateDomainInfo) GetDescription() (o string) {
-	if v != nil && v.Description != nil {
-		return *v.Description
+func (v *VersionHistories) GetCurrentVersionHistoryIndex() (o int32) {
+	if v != nil && v.CurrentVersionHistoryIndex != nil {
+		return *v.CurrentVersionHistoryIndex
 	}
 
 	return
 }
 
-// IsSetDescription returns true if Description is not nil.
-func (v *UpdateDomainInfo) IsSetDescription() bool {
-	return v != nil && v.Description != nil
+// IsSetCurrentVersionHistoryIndex returns true if CurrentVersionHistoryIndex is not nil.
+func (v *VersionHistories) IsSetCurrentVersionHistoryIndex() bool {
+	return v != nil && v.CurrentVersionHistoryIndex != nil
 }
 
-// GetOwnerEmail returns the value of OwnerEmail if it is set or its
+// GetHistories returns the value of Histories if it is set or its
 // zero value if it is unset.
-func (v *UpdateDomainInfo) GetOwnerEmail() (o string) {
-	if v != nil && v.OwnerEmail != nil {
-		return *v.OwnerEmail
+func (v *VersionHistories) GetHistories() (o []*VersionHistory) {
+	if v != nil && v.Histories != nil {
+		return v.Histories
 	}
 
 	return
 }
 
-// IsSetOwnerEmail returns true if OwnerEmail is not nil.
-func (v *UpdateDomainInfo) IsSetOwnerEmail() bool {
-	return v != nil && v.OwnerEmail != nil
+// IsSetHistories returns true if Histories is not nil.
+func (v *VersionHistories) IsSetHistories() bool {
+	return v != nil && v.Histories != nil
 }
 
-// GetData returns the value of Data if it is set or its
-// zero value if it is unset.
-func (v *UpdateDomainInfo) GetData() (o map[string]string) {
-	if v != nil && v.Data != nil {
-		return v.Data
-	}
+type VersionHistory struct {
+	BranchToken []byte                `json:"branchToken,omitempty"`
+	Items       []*VersionHistoryItem `json:"items,omitempty"`
+}
 
-	return
+type _List_VersionHistoryItem_ValueList []*VersionHistoryItem
+
+func (v _List_VersionHistoryItem_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-// IsSetData returns true if Data is not nil.
-func (v *UpdateDomainInfo) IsSetData() bool {
-	return v != nil && v.Data != nil
+func (v _List_VersionHistoryItem_ValueList) Size() int {
+	return len(v)
 }
 
-type UpdateDomainRequest struct {
-	Name                     *string                         `json:"name,omitempty"`
-	UpdatedInfo              *UpdateDomainInfo               `json:"updatedInfo,omitempty"`
-	Configuration            *DomainConfiguration            `json:"configuration,omitempty"`
-	ReplicationConfiguration *DomainReplicationConfiguration `json:"replicationConfiguration,omitempty"`
-	SecurityToken            *string                         `json:"securityToken,omitempty"`
+func (_List_VersionHistoryItem_ValueList) ValueType() wire.Type {
+	return wire.TStruct
 }
 
-// ToWire translates a UpdateDomainRequest struct into a Thrift-level intermediate
+func (_List_VersionHistoryItem_ValueList) Close() {}
+
+// ToWire translates a VersionHistory struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -42501,69 +47689,63 @@ type UpdateDomainRequest struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *UpdateDomainRequest) ToWire() (wire.Value, error) {
+func (v *VersionHistory) ToWire() (wire.Value, error) {
 	var (
-		fields [5]wire.Field
+		fields [2]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
 	)
 
-	if v.Name != nil {
-		w, err = wire.NewValueString(*(v.Name)), error(nil)
+	if v.BranchToken != nil {
+		w, err = wire.NewValueBinary(v.BranchToken), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.UpdatedInfo != nil {
-		w, err = v.UpdatedInfo.ToWire()
+	if v.Items != nil {
+		w, err = wire.NewValueList(_List_VersionHistoryItem_ValueList(v.Items)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
-	if v.Configuration != nil {
-		w, err = v.Configuration.ToWire()
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 30, Value: w}
-		i++
-	}
-	if v.ReplicationConfiguration != nil {
-		w, err = v.ReplicationConfiguration.ToWire()
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 40, Value: w}
-		i++
-	}
-	if v.SecurityToken != nil {
-		w, err = wire.NewValueString(*(v.SecurityToken)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 50, Value: w}
-		i++
-	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _UpdateDomainInfo_Read(w wire.Value) (*UpdateDomainInfo, error) {
-	var v UpdateDomainInfo
+func _VersionHistoryItem_Read(w wire.Value) (*VersionHistoryItem, error) {
+	var v VersionHistoryItem
 	err := v.FromWire(w)
 	return &v, err
 }
 
-// FromWire deserializes a UpdateDomainRequest struct from its Thrift-level
+func _List_VersionHistoryItem_Read(l wire.ValueList) ([]*VersionHistoryItem, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make([]*VersionHistoryItem, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _VersionHistoryItem_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+// FromWire deserializes a VersionHistory struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a UpdateDomainRequest struct
+// An error is returned if we were unable to build a VersionHistory struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -42571,55 +47753,27 @@ func _UpdateDomainInfo_Read(w wire.Value) (*UpdateDomainInfo, error) {
 //     return nil, err
 //   }
 //
-//   var v UpdateDomainRequest
+//   var v VersionHistory
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *UpdateDomainRequest) FromWire(w wire.Value) error {
+func (v *VersionHistory) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
 		case 10:
 			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.Name = &x
+				v.BranchToken, err = field.Value.GetBinary(), error(nil)
 				if err != nil {
 					return err
 				}
 
 			}
 		case 20:
-			if field.Value.Type() == wire.TStruct {
-				v.UpdatedInfo, err = _UpdateDomainInfo_Read(field.Value)
-				if err != nil {
-					return err
-				}
-
-			}
-		case 30:
-			if field.Value.Type() == wire.TStruct {
-				v.Configuration, err = _DomainConfiguration_Read(field.Value)
-				if err != nil {
-					return err
-				}
-
-			}
-		case 40:
-			if field.Value.Type() == wire.TStruct {
-				v.ReplicationConfiguration, err = _DomainReplicationConfiguration_Read(field.Value)
-				if err != nil {
-					return err
-				}
-
-			}
-		case 50:
-			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.SecurityToken = &x
+			if field.Value.Type() == wire.TList {
+				v.Items, err = _List_VersionHistoryItem_Read(field.Value.GetList())
 				if err != nil {
 					return err
 				}
@@ -42631,176 +47785,124 @@ func (v *UpdateDomainRequest) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a UpdateDomainRequest
+// String returns a readable string representation of a VersionHistory
 // struct.
-func (v *UpdateDomainRequest) String() string {
+func (v *VersionHistory) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [5]string
+	var fields [2]string
 	i := 0
-	if v.Name != nil {
-		fields[i] = fmt.Sprintf("Name: %v", *(v.Name))
-		i++
-	}
-	if v.UpdatedInfo != nil {
-		fields[i] = fmt.Sprintf("UpdatedInfo: %v", v.UpdatedInfo)
+	if v.BranchToken != nil {
+		fields[i] = fmt.Sprintf("BranchToken: %v", v.BranchToken)
 		i++
 	}
-	if v.Configuration != nil {
-		fields[i] = fmt.Sprintf("Configuration: %v", v.Configuration)
+	if v.Items != nil {
+		fields[i] = fmt.Sprintf("Items: %v", v.Items)
 		i++
 	}
-	if v.ReplicationConfiguration != nil {
-		fields[i] = fmt.Sprintf("ReplicationConfiguration: %v", v.ReplicationConfiguration)
-		i++
+
+	return fmt.Sprintf("VersionHistory{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _List_VersionHistoryItem_Equals(lhs, rhs []*VersionHistoryItem) bool {
+	if len(lhs) != len(rhs) {
+		return false
 	}
-	if v.SecurityToken != nil {
-		fields[i] = fmt.Sprintf("SecurityToken: %v", *(v.SecurityToken))
-		i++
+
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
 	}
 
-	return fmt.Sprintf("UpdateDomainRequest{%v}", strings.Join(fields[:i], ", "))
+	return true
 }
 
-// Equals returns true if all the fields of this UpdateDomainRequest match the
-// provided UpdateDomainRequest.
+// Equals returns true if all the fields of this VersionHistory match the
+// provided VersionHistory.
 //
 // This function performs a deep comparison.
-func (v *UpdateDomainRequest) Equals(rhs *UpdateDomainRequest) bool {
+func (v *VersionHistory) Equals(rhs *VersionHistory) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
 		return false
 	}
-	if !_String_EqualsPtr(v.Name, rhs.Name) {
-		return false
-	}
-	if !((v.UpdatedInfo == nil && rhs.UpdatedInfo == nil) || (v.UpdatedInfo != nil && rhs.UpdatedInfo != nil && v.UpdatedInfo.Equals(rhs.UpdatedInfo))) {
-		return false
-	}
-	if !((v.Configuration == nil && rhs.Configuration == nil) || (v.Configuration != nil && rhs.Configuration != nil && v.Configuration.Equals(rhs.Configuration))) {
+	if !((v.BranchToken == nil && rhs.BranchToken == nil) || (v.BranchToken != nil && rhs.BranchToken != nil && bytes.Equal(v.BranchToken, rhs.BranchToken))) {
 		return false
 	}
-	if !((v.ReplicationConfiguration == nil && rhs.ReplicationConfiguration == nil) || (v.ReplicationConfiguration != nil && rhs.ReplicationConfiguration != nil && v.ReplicationConfiguration.Equals(rhs.ReplicationConfiguration))) {
-		return false
-	}
-	if !_String_EqualsPtr(v.SecurityToken, rhs.SecurityToken) {
+	if !((v.Items == nil && rhs.Items == nil) || (v.Items != nil && rhs.Items != nil && _List_VersionHistoryItem_Equals(v.Items, rhs.Items))) {
 		return false
 	}
 
 	return true
 }
 
-// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of UpdateDomainRequest.
-func (v *UpdateDomainRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
-	if v == nil {
-		return nil
-	}
-	if v.Name != nil {
-		enc.AddString("name", *v.Name)
-	}
-	if v.UpdatedInfo != nil {
-		err = multierr.Append(err, enc.AddObject("updatedInfo", v.UpdatedInfo))
-	}
-	if v.Configuration != nil {
-		err = multierr.Append(err, enc.AddObject("configuration", v.Configuration))
-	}
-	if v.ReplicationConfiguration != nil {
-		err = multierr.Append(err, enc.AddObject("replicationConfiguration", v.ReplicationConfiguration))
-	}
-	if v.SecurityToken != nil {
-		enc.AddString("securityToken", *v.SecurityToken)
+type _List_VersionHistoryItem_Zapper []*VersionHistoryItem
+
+// MarshalLogArray implements zapcore.ArrayMarshaler, enabling
+// fast logging of _List_VersionHistoryItem_Zapper.
+func (l _List_VersionHistoryItem_Zapper) MarshalLogArray(enc zapcore.ArrayEncoder) (err error) {
+	for _, v := range l {
+		err = multierr.Append(err, enc.AppendObject(v))
 	}
 	return err
 }
 
-// GetName returns the value of Name if it is set or its
-// zero value if it is unset.
-func (v *UpdateDomainRequest) GetName() (o string) {
-	if v != nil && v.Name != nil {
-		return *v.Name
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of VersionHistory.
+func (v *VersionHistory) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
 	}
-
-	return
-}
-
-// IsSetName returns true if Name is not nil.
-func (v *UpdateDomainRequest) IsSetName() bool {
-	return v != nil && v.Name != nil
-}
-
-// GetUpdatedInfo returns the value of UpdatedInfo if it is set or its
-// zero value if it is unset.
-func (v *UpdateDomainRequest) GetUpdatedInfo() (o *UpdateDomainInfo) {
-	if v != nil && v.UpdatedInfo != nil {
-		return v.UpdatedInfo
+	if v.BranchToken != nil {
+		enc.AddString("branchToken", base64.StdEncoding.EncodeToString(v.BranchToken))
 	}
-
-	return
-}
-
-// IsSetUpdatedInfo returns true if UpdatedInfo is not nil.
-func (v *UpdateDomainRequest) IsSetUpdatedInfo() bool {
-	return v != nil && v.UpdatedInfo != nil
-}
-
-// GetConfiguration returns the value of Configuration if it is set or its
-// zero value if it is unset.
-func (v *UpdateDomainRequest) GetConfiguration() (o *DomainConfiguration) {
-	if v != nil && v.Configuration != nil {
-		return v.Configuration
+	if v.Items != nil {
+		err = multierr.Append(err, enc.AddArray("items", (_List_VersionHistoryItem_Zapper)(v.Items)))
 	}
-
-	return
-}
-
-// IsSetConfiguration returns true if Configuration is not nil.
-func (v *UpdateDomainRequest) IsSetConfiguration() bool {
-	return v != nil && v.Configuration != nil
+	return err
 }
 
-// GetReplicationConfiguration returns the value of ReplicationConfiguration if it is set or its
+// GetBranchToken returns the value of BranchToken if it is set or its
 // zero value if it is unset.
-func (v *UpdateDomainRequest) GetReplicationConfiguration() (o *DomainReplicationConfiguration) {
-	if v != nil && v.ReplicationConfiguration != nil {
-		return v.ReplicationConfiguration
+func (v *VersionHistory) GetBranchToken() (o []byte) {
+	if v != nil && v.BranchToken != nil {
+		return v.BranchToken
 	}
 
 	return
 }
 
-// IsSetReplicationConfiguration returns true if ReplicationConfiguration is not nil.
-func (v *UpdateDomainRequest) IsSetReplicationConfiguration() bool {
-	return v != nil && v.ReplicationConfiguration != nil
+// IsSetBranchToken returns true if BranchToken is not nil.
+func (v *VersionHistory) IsSetBranchToken() bool {
+	return v != nil && v.BranchToken != nil
 }
 
-// GetSecurityToken returns the value of SecurityToken if it is set or its
+// GetItems returns the value of Items if it is set or its
 // zero value if it is unset.
-func (v *UpdateDomainRequest) GetSecurityToken() (o string) {
-	if v != nil && v.SecurityToken != nil {
-		return *v.SecurityToken
+func (v *VersionHistory) GetItems() (o []*VersionHistoryItem) {
+	if v != nil && v.Items != nil {
+		return v.Items
 	}
 
 	return
 }
 
-// IsSetSecurityToken returns true if SecurityToken is not nil.
-func (v *UpdateDomainRequest) IsSetSecurityToken() bool {
-	return v != nil && v.SecurityToken != nil
+// IsSetItems returns true if Items is not nil.
+func (v *VersionHistory) IsSetItems() bool {
+	return v != nil && v.Items != nil
 }
 
-type UpdateDomainResponse struct {
-	DomainInfo               *DomainInfo                     `json:"domainInfo,omitempty"`
-	Configuration            *DomainConfiguration            `json:"configuration,omitempty"`
-	ReplicationConfiguration *DomainReplicationConfiguration `json:"replicationConfiguration,omitempty"`
-	FailoverVersion          *int64                          `json:"failoverVersion,omitempty"`
-	IsGlobalDomain           *bool                           `json:"isGlobalDomain,omitempty"`
+type VersionHistoryItem struct {
+	EventID *int64 `json:"eventID,omitempty"`
+	Version *int64 `json:"version,omitempty"`
 }
 
-// ToWire translates a UpdateDomainResponse struct into a Thrift-level intermediate
+// ToWire translates a VersionHistoryItem struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -42815,63 +47917,39 @@ type UpdateDomainResponse struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *UpdateDomainResponse) ToWire() (wire.Value, error) {
+func (v *VersionHistoryItem) ToWire() (wire.Value, error) {
 	var (
-		fields [5]wire.Field
+		fields [2]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
 	)
 
-	if v.DomainInfo != nil {
-		w, err = v.DomainInfo.ToWire()
+	if v.EventID != nil {
+		w, err = wire.NewValueI64(*(v.EventID)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.Configuration != nil {
-		w, err = v.Configuration.ToWire()
+	if v.Version != nil {
+		w, err = wire.NewValueI64(*(v.Version)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
-	if v.ReplicationConfiguration != nil {
-		w, err = v.ReplicationConfiguration.ToWire()
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 30, Value: w}
-		i++
-	}
-	if v.FailoverVersion != nil {
-		w, err = wire.NewValueI64(*(v.FailoverVersion)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 40, Value: w}
-		i++
-	}
-	if v.IsGlobalDomain != nil {
-		w, err = wire.NewValueBool(*(v.IsGlobalDomain)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 50, Value: w}
-		i++
-	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-// FromWire deserializes a UpdateDomainResponse struct from its Thrift-level
+// FromWire deserializes a VersionHistoryItem struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a UpdateDomainResponse struct
+// An error is returned if we were unable to build a VersionHistoryItem struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -42879,55 +47957,31 @@ func (v *UpdateDomainResponse) ToWire() (wire.Value, error) {
 //     return nil, err
 //   }
 //
-//   var v UpdateDomainResponse
+//   var v VersionHistoryItem
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *UpdateDomainResponse) FromWire(w wire.Value) error {
+func (v *VersionHistoryItem) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
 		case 10:
-			if field.Value.Type() == wire.TStruct {
-				v.DomainInfo, err = _DomainInfo_Read(field.Value)
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.EventID = &x
 				if err != nil {
 					return err
 				}
 
 			}
 		case 20:
-			if field.Value.Type() == wire.TStruct {
-				v.Configuration, err = _DomainConfiguration_Read(field.Value)
-				if err != nil {
-					return err
-				}
-
-			}
-		case 30:
-			if field.Value.Type() == wire.TStruct {
-				v.ReplicationConfiguration, err = _DomainReplicationConfiguration_Read(field.Value)
-				if err != nil {
-					return err
-				}
-
-			}
-		case 40:
 			if field.Value.Type() == wire.TI64 {
 				var x int64
 				x, err = field.Value.GetI64(), error(nil)
-				v.FailoverVersion = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		case 50:
-			if field.Value.Type() == wire.TBool {
-				var x bool
-				x, err = field.Value.GetBool(), error(nil)
-				v.IsGlobalDomain = &x
+				v.Version = &x
 				if err != nil {
 					return err
 				}
@@ -42939,62 +47993,41 @@ func (v *UpdateDomainResponse) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a UpdateDomainResponse
+// String returns a readable string representation of a VersionHistoryItem
 // struct.
-func (v *UpdateDomainResponse) String() string {
+func (v *VersionHistoryItem) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [5]string
+	var fields [2]string
 	i := 0
-	if v.DomainInfo != nil {
-		fields[i] = fmt.Sprintf("DomainInfo: %v", v.DomainInfo)
-		i++
-	}
-	if v.Configuration != nil {
-		fields[i] = fmt.Sprintf("Configuration: %v", v.Configuration)
-		i++
-	}
-	if v.ReplicationConfiguration != nil {
-		fields[i] = fmt.Sprintf("ReplicationConfiguration: %v", v.ReplicationConfiguration)
-		i++
-	}
-	if v.FailoverVersion != nil {
-		fields[i] = fmt.Sprintf("FailoverVersion: %v", *(v.FailoverVersion))
+	if v.EventID != nil {
+		fields[i] = fmt.Sprintf("EventID: %v", *(v.EventID))
 		i++
 	}
-	if v.IsGlobalDomain != nil {
-		fields[i] = fmt.Sprintf("IsGlobalDomain: %v", *(v.IsGlobalDomain))
+	if v.Version != nil {
+		fields[i] = fmt.Sprintf("Version: %v", *(v.Version))
 		i++
 	}
 
-	return fmt.Sprintf("UpdateDomainResponse{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("VersionHistoryItem{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this UpdateDomainResponse match the
-// provided UpdateDomainResponse.
+// Equals returns true if all the fields of this VersionHistoryItem match the
+// provided VersionHistoryItem.
 //
 // This function performs a deep comparison.
-func (v *UpdateDomainResponse) Equals(rhs *UpdateDomainResponse) bool {
+func (v *VersionHistoryItem) Equals(rhs *VersionHistoryItem) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
 		return false
 	}
-	if !((v.DomainInfo == nil && rhs.DomainInfo == nil) || (v.DomainInfo != nil && rhs.DomainInfo != nil && v.DomainInfo.Equals(rhs.DomainInfo))) {
-		return false
-	}
-	if !((v.Configuration == nil && rhs.Configuration == nil) || (v.Configuration != nil && rhs.Configuration != nil && v.Configuration.Equals(rhs.Configuration))) {
-		return false
-	}
-	if !((v.ReplicationConfiguration == nil && rhs.ReplicationConfiguration == nil) || (v.ReplicationConfiguration != nil && rhs.ReplicationConfiguration != nil && v.ReplicationConfiguration.Equals(rhs.ReplicationConfiguration))) {
-		return false
-	}
-	if !_I64_EqualsPtr(v.FailoverVersion, rhs.FailoverVersion) {
+	if !_I64_EqualsPtr(v.EventID, rhs.EventID) {
 		return false
 	}
-	if !_Bool_EqualsPtr(v.IsGlobalDomain, rhs.IsGlobalDomain) {
+	if !_I64_EqualsPtr(v.Version, rhs.Version) {
 		return false
 	}
 
@@ -43002,102 +48035,48 @@ func (v *UpdateDomainResponse) Equals(rhs *UpdateDomainResponse) bool {
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of UpdateDomainResponse.
-func (v *UpdateDomainResponse) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of VersionHistoryItem.
+func (v *VersionHistoryItem) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
-	if v.DomainInfo != nil {
-		err = multierr.Append(err, enc.AddObject("domainInfo", v.DomainInfo))
-	}
-	if v.Configuration != nil {
-		err = multierr.Append(err, enc.AddObject("configuration", v.Configuration))
-	}
-	if v.ReplicationConfiguration != nil {
-		err = multierr.Append(err, enc.AddObject("replicationConfiguration", v.ReplicationConfiguration))
-	}
-	if v.FailoverVersion != nil {
-		enc.AddInt64("failoverVersion", *v.FailoverVersion)
+	if v.EventID != nil {
+		enc.AddInt64("eventID", *v.EventID)
 	}
-	if v.IsGlobalDomain != nil {
-		enc.AddBool("isGlobalDomain", *v.IsGlobalDomain)
+	if v.Version != nil {
+		enc.AddInt64("version", *v.Version)
 	}
 	return err
 }
 
-// GetDomainInfo returns the value of DomainInfo if it is set or its
-// zero value if it is unset.
-func (v *UpdateDomainResponse) GetDomainInfo() (o *DomainInfo) {
-	if v != nil && v.DomainInfo != nil {
-		return v.DomainInfo
-	}
-
-	return
-}
-
-// IsSetDomainInfo returns true if DomainInfo is not nil.
-func (v *UpdateDomainResponse) IsSetDomainInfo() bool {
-	return v != nil && v.DomainInfo != nil
-}
-
-// GetConfiguration returns the value of Configuration if it is set or its
-// zero value if it is unset.
-func (v *UpdateDomainResponse) GetConfiguration() (o *DomainConfiguration) {
-	if v != nil && v.Configuration != nil {
-		return v.Configuration
-	}
-
-	return
-}
-
-// IsSetConfiguration returns true if Configuration is not nil.
-func (v *UpdateDomainResponse) IsSetConfiguration() bool {
-	return v != nil && v.Configuration != nil
-}
-
-// GetReplicationConfiguration returns the value of ReplicationConfiguration if it is set or its
-// zero value if it is unset.
-func (v *UpdateDomainResponse) GetReplicationConfiguration() (o *DomainReplicationConfiguration) {
-	if v != nil && v.ReplicationConfiguration != nil {
-		return v.ReplicationConfiguration
-	}
-
-	return
-}
-
-// IsSetReplicationConfiguration returns true if ReplicationConfiguration is not nil.
-func (v *UpdateDomainResponse) IsSetReplicationConfiguration() bool {
-	return v != nil && v.ReplicationConfiguration != nil
-}
-
-// GetFailoverVersion returns the value of FailoverVersion if it is set or its
+// GetEventID returns the value of EventID if it is set or its
 // zero value if it is unset.
-func (v *UpdateDomainResponse) GetFailoverVersion() (o int64) {
-	if v != nil && v.FailoverVersion != nil {
-		return *v.FailoverVersion
+func (v *VersionHistoryItem) GetEventID() (o int64) {
+	if v != nil && v.EventID != nil {
+		return *v.EventID
 	}
 
 	return
 }
 
-// IsSetFailoverVersion returns true if FailoverVersion is not nil.
-func (v *UpdateDomainResponse) IsSetFailoverVersion() bool {
-	return v != nil && v.FailoverVersion != nil
+// IsSetEventID returns true if EventID is not nil.
+func (v *VersionHistoryItem) IsSetEventID() bool {
+	return v != nil && v.EventID != nil
 }
 
-// GetIsGlobalDomain returns the value of IsGlobalDomain if it is set or its
+// GetVersion returns the value of Version if it is set or its
 // zero value if it is unset.
-func (v *UpdateDomainResponse) GetIsGlobalDomain() (o bool) {
-	if v != nil && v.IsGlobalDomain != nil {
-		return *v.IsGlobalDomain
+func (v *VersionHistoryItem) GetVersion() (o int64) {
+	if v != nil && v.Version != nil {
+		return *v.Version
 	}
 
 	return
 }
 
-// IsSetIsGlobalDomain returns true if IsGlobalDomain is not nil.
-func (v *UpdateDomainResponse) IsSetIsGlobalDomain() bool {
-	return v != nil && v.IsGlobalDomain != nil
+// IsSetVersion returns true if Version is not nil.
+func (v *VersionHistoryItem) IsSetVersion() bool {
+	return v != nil && v.Version != nil
 }
 
 type WorkflowExecution struct {
@@ -43969,6 +48948,7 @@ const (
 	WorkflowExecutionCloseStatusTerminated     WorkflowExecutionCloseStatus = 3
 	WorkflowExecutionCloseStatusContinuedAsNew WorkflowExecutionCloseStatus = 4
 	WorkflowExecutionCloseStatusTimedOut       WorkflowExecutionCloseStatus = 5
+	WorkflowExecutionCloseStatusDeleted        WorkflowExecutionCloseStatus = 6
 )
 
 // WorkflowExecutionCloseStatus_Values returns all recognized values of WorkflowExecutionCloseStatus.
@@ -43980,6 +48960,7 @@ func WorkflowExecutionCloseStatus_Values() []WorkflowExecutionCloseStatus {
 		WorkflowExecutionCloseStatusTerminated,
 		WorkflowExecutionCloseStatusContinuedAsNew,
 		WorkflowExecutionCloseStatusTimedOut,
+		WorkflowExecutionCloseStatusDeleted,
 	}
 }
 
@@ -44008,6 +48989,9 @@ func (v *WorkflowExecutionCloseStatus) UnmarshalText(value []byte) error {
 	case "TIMED_OUT":
 		*v = WorkflowExecutionCloseStatusTimedOut
 		return nil
+	case "DELETED":
+		*v = WorkflowExecutionCloseStatusDeleted
+		return nil
 	default:
 		val, err := strconv.ParseInt(s, 10, 32)
 		if err != nil {
@@ -44038,6 +49022,8 @@ func (v WorkflowExecutionCloseStatus) MarshalText() ([]byte, error) {
 		return []byte("CONTINUED_AS_NEW"), nil
 	case 5:
 		return []byte("TIMED_OUT"), nil
+	case 6:
+		return []byte("DELETED"), nil
 	}
 	return []byte(strconv.FormatInt(int64(v), 10)), nil
 }
@@ -44061,6 +49047,8 @@ func (v WorkflowExecutionCloseStatus) MarshalLogObject(enc zapcore.ObjectEncoder
 		enc.AddString("name", "CONTINUED_AS_NEW")
 	case 5:
 		enc.AddString("name", "TIMED_OUT")
+	case 6:
+		enc.AddString("name", "DELETED")
 	}
 	return nil
 }
@@ -44113,6 +49101,8 @@ func (v WorkflowExecutionCloseStatus) String() string {
 		return "CONTINUED_AS_NEW"
 	case 5:
 		return "TIMED_OUT"
+	case 6:
+		return "DELETED"
 	}
 	return fmt.Sprintf("WorkflowExecutionCloseStatus(%d)", w)
 }
@@ -44143,6 +49133,8 @@ func (v WorkflowExecutionCloseStatus) MarshalJSON() ([]byte, error) {
 		return ([]byte)("\"CONTINUED_AS_NEW\""), nil
 	case 5:
 		return ([]byte)("\"TIMED_OUT\""), nil
+	case 6:
+		return ([]byte)("\"DELETED\""), nil
 	}
 	return ([]byte)(strconv.FormatInt(int64(v), 10)), nil
 }
@@ -45243,6 +50235,188 @@ func (v *WorkflowExecutionContinuedAsNewEventAttributes) IsSetLastCompletionResu
 	return v != nil && v.LastCompletionResult != nil
 }
 
+type WorkflowExecutionCountBucket struct {
+	Key   *string `json:"key,omitempty"`
+	Count *int64  `json:"count,omitempty"`
+}
+
+// ToWire translates a WorkflowExecutionCountBucket struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *WorkflowExecutionCountBucket) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Key != nil {
+		w, err = wire.NewValueString(*(v.Key)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.Count != nil {
+		w, err = wire.NewValueI64(*(v.Count)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a WorkflowExecutionCountBucket struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a WorkflowExecutionCountBucket struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v WorkflowExecutionCountBucket
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *WorkflowExecutionCountBucket) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Key = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.Count = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a WorkflowExecutionCountBucket
+// struct.
+func (v *WorkflowExecutionCountBucket) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [2]string
+	i := 0
+	if v.Key != nil {
+		fields[i] = fmt.Sprintf("Key: %v", *(v.Key))
+		i++
+	}
+	if v.Count != nil {
+		fields[i] = fmt.Sprintf("Count: %v", *(v.Count))
+		i++
+	}
+
+	return fmt.Sprintf("WorkflowExecutionCountBucket{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this WorkflowExecutionCountBucket match the
+// provided WorkflowExecutionCountBucket.
+//
+// This function performs a deep comparison.
+func (v *WorkflowExecutionCountBucket) Equals(rhs *WorkflowExecutionCountBucket) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_String_EqualsPtr(v.Key, rhs.Key) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.Count, rhs.Count) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of WorkflowExecutionCountBucket.
+func (v *WorkflowExecutionCountBucket) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Key != nil {
+		enc.AddString("key", *v.Key)
+	}
+	if v.Count != nil {
+		enc.AddInt64("count", *v.Count)
+	}
+	return err
+}
+
+// GetKey returns the value of Key if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionCountBucket) GetKey() (o string) {
+	if v != nil && v.Key != nil {
+		return *v.Key
+	}
+
+	return
+}
+
+// IsSetKey returns true if Key is not nil.
+func (v *WorkflowExecutionCountBucket) IsSetKey() bool {
+	return v != nil && v.Key != nil
+}
+
+// GetCount returns the value of Count if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionCountBucket) GetCount() (o int64) {
+	if v != nil && v.Count != nil {
+		return *v.Count
+	}
+
+	return
+}
+
+// IsSetCount returns true if Count is not nil.
+func (v *WorkflowExecutionCountBucket) IsSetCount() bool {
+	return v != nil && v.Count != nil
+}
+
 type WorkflowExecutionFailedEventAttributes struct {
 	Reason                       *string `json:"reason,omitempty"`
 	Details                      []byte  `json:"details,omitempty"`
@@ -45606,15 +50780,18 @@ func (v *WorkflowExecutionFilter) IsSetWorkflowId() bool {
 }
 
 type WorkflowExecutionInfo struct {
-	Execution       *WorkflowExecution            `json:"execution,omitempty"`
-	Type            *WorkflowType                 `json:"type,omitempty"`
-	StartTime       *int64                        `json:"startTime,omitempty"`
-	CloseTime       *int64                        `json:"closeTime,omitempty"`
-	CloseStatus     *WorkflowExecutionCloseStatus `json:"closeStatus,omitempty"`
-	HistoryLength   *int64                        `json:"historyLength,omitempty"`
-	ParentDomainId  *string                       `json:"parentDomainId,omitempty"`
-	ParentExecution *WorkflowExecution            `json:"parentExecution,omitempty"`
-	ExecutionTime   *int64                        `json:"executionTime,omitempty"`
+	Execution       *WorkflowExecution                `json:"execution,omitempty"`
+	Type            *WorkflowType                     `json:"type,omitempty"`
+	StartTime       *int64                            `json:"startTime,omitempty"`
+	CloseTime       *int64                            `json:"closeTime,omitempty"`
+	CloseStatus     *WorkflowExecutionCloseStatus     `json:"closeStatus,omitempty"`
+	HistoryLength   *int64                            `json:"historyLength,omitempty"`
+	ParentDomainId  *string                           `json:"parentDomainId,omitempty"`
+	ParentExecution *WorkflowExecution                `json:"parentExecution,omitempty"`
+	ExecutionTime   *int64                            `json:"executionTime,omitempty"`
+	TerminationInfo *WorkflowExecutionTerminationInfo `json:"terminationInfo,omitempty"`
+	TaskList        *string                           `json:"taskList,omitempty"`
+	Labels          map[string]string                 `json:"labels,omitempty"`
 }
 
 // ToWire translates a WorkflowExecutionInfo struct into a Thrift-level intermediate
@@ -45634,7 +50811,7 @@ type WorkflowExecutionInfo struct {
 //   }
 func (v *WorkflowExecutionInfo) ToWire() (wire.Value, error) {
 	var (
-		fields [9]wire.Field
+		fields [12]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -45712,10 +50889,40 @@ func (v *WorkflowExecutionInfo) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 90, Value: w}
 		i++
 	}
+	if v.TerminationInfo != nil {
+		w, err = v.TerminationInfo.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 100, Value: w}
+		i++
+	}
+	if v.TaskList != nil {
+		w, err = wire.NewValueString(*(v.TaskList)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 110, Value: w}
+		i++
+	}
+	if v.Labels != nil {
+		w, err = wire.NewValueMap(_Map_String_String_MapItemList(v.Labels)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 120, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
+func _WorkflowExecutionTerminationInfo_Read(w wire.Value) (*WorkflowExecutionTerminationInfo, error) {
+	var v WorkflowExecutionTerminationInfo
+	err := v.FromWire(w)
+	return &v, err
+}
+
 // FromWire deserializes a WorkflowExecutionInfo struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
@@ -45821,6 +51028,32 @@ func (v *WorkflowExecutionInfo) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 100:
+			if field.Value.Type() == wire.TStruct {
+				v.TerminationInfo, err = _WorkflowExecutionTerminationInfo_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 110:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.TaskList = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 120:
+			if field.Value.Type() == wire.TMap {
+				v.Labels, err = _Map_String_String_Read(field.Value.GetMap())
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -45835,7 +51068,7 @@ func (v *WorkflowExecutionInfo) String() string {
 		return "<nil>"
 	}
 
-	var fields [9]string
+	var fields [12]string
 	i := 0
 	if v.Execution != nil {
 		fields[i] = fmt.Sprintf("Execution: %v", v.Execution)
@@ -45873,6 +51106,18 @@ func (v *WorkflowExecutionInfo) String() string {
 		fields[i] = fmt.Sprintf("ExecutionTime: %v", *(v.ExecutionTime))
 		i++
 	}
+	if v.TerminationInfo != nil {
+		fields[i] = fmt.Sprintf("TerminationInfo: %v", v.TerminationInfo)
+		i++
+	}
+	if v.TaskList != nil {
+		fields[i] = fmt.Sprintf("TaskList: %v", *(v.TaskList))
+		i++
+	}
+	if v.Labels != nil {
+		fields[i] = fmt.Sprintf("Labels: %v", v.Labels)
+		i++
+	}
 
 	return fmt.Sprintf("WorkflowExecutionInfo{%v}", strings.Join(fields[:i], ", "))
 }
@@ -45914,6 +51159,15 @@ func (v *WorkflowExecutionInfo) Equals(rhs *WorkflowExecutionInfo) bool {
 	if !_I64_EqualsPtr(v.ExecutionTime, rhs.ExecutionTime) {
 		return false
 	}
+	if !((v.TerminationInfo == nil && rhs.TerminationInfo == nil) || (v.TerminationInfo != nil && rhs.TerminationInfo != nil && v.TerminationInfo.Equals(rhs.TerminationInfo))) {
+		return false
+	}
+	if !_String_EqualsPtr(v.TaskList, rhs.TaskList) {
+		return false
+	}
+	if !((v.Labels == nil && rhs.Labels == nil) || (v.Labels != nil && rhs.Labels != nil && _Map_String_String_Equals(v.Labels, rhs.Labels))) {
+		return false
+	}
 
 	return true
 }
@@ -45951,6 +51205,15 @@ func (v *WorkflowExecutionInfo) MarshalLogObject(enc zapcore.ObjectEncoder) (err
 	if v.ExecutionTime != nil {
 		enc.AddInt64("executionTime", *v.ExecutionTime)
 	}
+	if v.TerminationInfo != nil {
+		err = multierr.Append(err, enc.AddObject("terminationInfo", v.TerminationInfo))
+	}
+	if v.TaskList != nil {
+		enc.AddString("taskList", *v.TaskList)
+	}
+	if v.Labels != nil {
+		err = multierr.Append(err, enc.AddObject("labels", (_Map_String_String_Zapper)(v.Labels)))
+	}
 	return err
 }
 
@@ -46089,6 +51352,51 @@ func (v *WorkflowExecutionInfo) IsSetExecutionTime() bool {
 	return v != nil && v.ExecutionTime != nil
 }
 
+// GetTerminationInfo returns the value of TerminationInfo if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionInfo) GetTerminationInfo() (o *WorkflowExecutionTerminationInfo) {
+	if v != nil && v.TerminationInfo != nil {
+		return v.TerminationInfo
+	}
+
+	return
+}
+
+// IsSetTerminationInfo returns true if TerminationInfo is not nil.
+func (v *WorkflowExecutionInfo) IsSetTerminationInfo() bool {
+	return v != nil && v.TerminationInfo != nil
+}
+
+// GetTaskList returns the value of TaskList if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionInfo) GetTaskList() (o string) {
+	if v != nil && v.TaskList != nil {
+		return *v.TaskList
+	}
+
+	return
+}
+
+// IsSetTaskList returns true if TaskList is not nil.
+func (v *WorkflowExecutionInfo) IsSetTaskList() bool {
+	return v != nil && v.TaskList != nil
+}
+
+// GetLabels returns the value of Labels if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionInfo) GetLabels() (o map[string]string) {
+	if v != nil && v.Labels != nil {
+		return v.Labels
+	}
+
+	return
+}
+
+// IsSetLabels returns true if Labels is not nil.
+func (v *WorkflowExecutionInfo) IsSetLabels() bool {
+	return v != nil && v.Labels != nil
+}
+
 type WorkflowExecutionSignaledEventAttributes struct {
 	SignalName *string `json:"signalName,omitempty"`
 	Input      []byte  `json:"input,omitempty"`
@@ -46334,6 +51642,8 @@ type WorkflowExecutionStartedEventAttributes struct {
 	ExpirationTimestamp                 *int64                  `json:"expirationTimestamp,omitempty"`
 	CronSchedule                        *string                 `json:"cronSchedule,omitempty"`
 	FirstDecisionTaskBackoffSeconds     *int32                  `json:"firstDecisionTaskBackoffSeconds,omitempty"`
+	ConcurrencyGroup                    *string                 `json:"concurrencyGroup,omitempty"`
+	ConcurrencyLimit                    *int32                  `json:"concurrencyLimit,omitempty"`
 }
 
 // ToWire translates a WorkflowExecutionStartedEventAttributes struct into a Thrift-level intermediate
@@ -46353,7 +51663,7 @@ type WorkflowExecutionStartedEventAttributes struct {
 //   }
 func (v *WorkflowExecutionStartedEventAttributes) ToWire() (wire.Value, error) {
 	var (
-		fields [20]wire.Field
+		fields [22]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -46519,6 +51829,22 @@ func (v *WorkflowExecutionStartedEventAttributes) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 110, Value: w}
 		i++
 	}
+	if v.ConcurrencyGroup != nil {
+		w, err = wire.NewValueString(*(v.ConcurrencyGroup)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 120, Value: w}
+		i++
+	}
+	if v.ConcurrencyLimit != nil {
+		w, err = wire.NewValueI32(*(v.ConcurrencyLimit)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 130, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -46730,6 +52056,26 @@ func (v *WorkflowExecutionStartedEventAttributes) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 120:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.ConcurrencyGroup = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 130:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.ConcurrencyLimit = &x
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -46744,7 +52090,7 @@ func (v *WorkflowExecutionStartedEventAttributes) String() string {
 		return "<nil>"
 	}
 
-	var fields [20]string
+	var fields [22]string
 	i := 0
 	if v.WorkflowType != nil {
 		fields[i] = fmt.Sprintf("WorkflowType: %v", v.WorkflowType)
@@ -46826,6 +52172,14 @@ func (v *WorkflowExecutionStartedEventAttributes) String() string {
 		fields[i] = fmt.Sprintf("FirstDecisionTaskBackoffSeconds: %v", *(v.FirstDecisionTaskBackoffSeconds))
 		i++
 	}
+	if v.ConcurrencyGroup != nil {
+		fields[i] = fmt.Sprintf("ConcurrencyGroup: %v", *(v.ConcurrencyGroup))
+		i++
+	}
+	if v.ConcurrencyLimit != nil {
+		fields[i] = fmt.Sprintf("ConcurrencyLimit: %v", *(v.ConcurrencyLimit))
+		i++
+	}
 
 	return fmt.Sprintf("WorkflowExecutionStartedEventAttributes{%v}", strings.Join(fields[:i], ", "))
 }
@@ -46900,6 +52254,12 @@ func (v *WorkflowExecutionStartedEventAttributes) Equals(rhs *WorkflowExecutionS
 	if !_I32_EqualsPtr(v.FirstDecisionTaskBackoffSeconds, rhs.FirstDecisionTaskBackoffSeconds) {
 		return false
 	}
+	if !_String_EqualsPtr(v.ConcurrencyGroup, rhs.ConcurrencyGroup) {
+		return false
+	}
+	if !_I32_EqualsPtr(v.ConcurrencyLimit, rhs.ConcurrencyLimit) {
+		return false
+	}
 
 	return true
 }
@@ -46970,6 +52330,12 @@ func (v *WorkflowExecutionStartedEventAttributes) MarshalLogObject(enc zapcore.O
 	if v.FirstDecisionTaskBackoffSeconds != nil {
 		enc.AddInt32("firstDecisionTaskBackoffSeconds", *v.FirstDecisionTaskBackoffSeconds)
 	}
+	if v.ConcurrencyGroup != nil {
+		enc.AddString("concurrencyGroup", *v.ConcurrencyGroup)
+	}
+	if v.ConcurrencyLimit != nil {
+		enc.AddInt32("concurrencyLimit", *v.ConcurrencyLimit)
+	}
 	return err
 }
 
@@ -47273,10 +52639,41 @@ func (v *WorkflowExecutionStartedEventAttributes) IsSetFirstDecisionTaskBackoffS
 	return v != nil && v.FirstDecisionTaskBackoffSeconds != nil
 }
 
+// GetConcurrencyGroup returns the value of ConcurrencyGroup if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionStartedEventAttributes) GetConcurrencyGroup() (o string) {
+	if v != nil && v.ConcurrencyGroup != nil {
+		return *v.ConcurrencyGroup
+	}
+
+	return
+}
+
+// IsSetConcurrencyGroup returns true if ConcurrencyGroup is not nil.
+func (v *WorkflowExecutionStartedEventAttributes) IsSetConcurrencyGroup() bool {
+	return v != nil && v.ConcurrencyGroup != nil
+}
+
+// GetConcurrencyLimit returns the value of ConcurrencyLimit if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionStartedEventAttributes) GetConcurrencyLimit() (o int32) {
+	if v != nil && v.ConcurrencyLimit != nil {
+		return *v.ConcurrencyLimit
+	}
+
+	return
+}
+
+// IsSetConcurrencyLimit returns true if ConcurrencyLimit is not nil.
+func (v *WorkflowExecutionStartedEventAttributes) IsSetConcurrencyLimit() bool {
+	return v != nil && v.ConcurrencyLimit != nil
+}
+
 type WorkflowExecutionTerminatedEventAttributes struct {
-	Reason   *string `json:"reason,omitempty"`
-	Details  []byte  `json:"details,omitempty"`
-	Identity *string `json:"identity,omitempty"`
+	Reason   *string           `json:"reason,omitempty"`
+	Details  []byte            `json:"details,omitempty"`
+	Identity *string           `json:"identity,omitempty"`
+	Cause    *TerminationCause `json:"cause,omitempty"`
 }
 
 // ToWire translates a WorkflowExecutionTerminatedEventAttributes struct into a Thrift-level intermediate
@@ -47296,7 +52693,7 @@ type WorkflowExecutionTerminatedEventAttributes struct {
 //   }
 func (v *WorkflowExecutionTerminatedEventAttributes) ToWire() (wire.Value, error) {
 	var (
-		fields [3]wire.Field
+		fields [4]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -47326,10 +52723,24 @@ func (v *WorkflowExecutionTerminatedEventAttributes) ToWire() (wire.Value, error
 		fields[i] = wire.Field{ID: 30, Value: w}
 		i++
 	}
+	if v.Cause != nil {
+		w, err = v.Cause.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
+func _TerminationCause_Read(w wire.Value) (TerminationCause, error) {
+	var v TerminationCause
+	err := v.FromWire(w)
+	return v, err
+}
+
 // FromWire deserializes a WorkflowExecutionTerminatedEventAttributes struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
@@ -47379,6 +52790,16 @@ func (v *WorkflowExecutionTerminatedEventAttributes) FromWire(w wire.Value) erro
 					return err
 				}
 
+			}
+		case 40:
+			if field.Value.Type() == wire.TI32 {
+				var x TerminationCause
+				x, err = _TerminationCause_Read(field.Value)
+				v.Cause = &x
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -47393,7 +52814,7 @@ func (v *WorkflowExecutionTerminatedEventAttributes) String() string {
 		return "<nil>"
 	}
 
-	var fields [3]string
+	var fields [4]string
 	i := 0
 	if v.Reason != nil {
 		fields[i] = fmt.Sprintf("Reason: %v", *(v.Reason))
@@ -47407,10 +52828,24 @@ func (v *WorkflowExecutionTerminatedEventAttributes) String() string {
 		fields[i] = fmt.Sprintf("Identity: %v", *(v.Identity))
 		i++
 	}
+	if v.Cause != nil {
+		fields[i] = fmt.Sprintf("Cause: %v", *(v.Cause))
+		i++
+	}
 
 	return fmt.Sprintf("WorkflowExecutionTerminatedEventAttributes{%v}", strings.Join(fields[:i], ", "))
 }
 
+func _TerminationCause_EqualsPtr(lhs, rhs *TerminationCause) bool {
+	if lhs != nil && rhs != nil {
+
+		x := *lhs
+		y := *rhs
+		return x.Equals(y)
+	}
+	return lhs == nil && rhs == nil
+}
+
 // Equals returns true if all the fields of this WorkflowExecutionTerminatedEventAttributes match the
 // provided WorkflowExecutionTerminatedEventAttributes.
 //
@@ -47430,6 +52865,9 @@ func (v *WorkflowExecutionTerminatedEventAttributes) Equals(rhs *WorkflowExecuti
 	if !_String_EqualsPtr(v.Identity, rhs.Identity) {
 		return false
 	}
+	if !_TerminationCause_EqualsPtr(v.Cause, rhs.Cause) {
+		return false
+	}
 
 	return true
 }
@@ -47449,6 +52887,9 @@ func (v *WorkflowExecutionTerminatedEventAttributes) MarshalLogObject(enc zapcor
 	if v.Identity != nil {
 		enc.AddString("identity", *v.Identity)
 	}
+	if v.Cause != nil {
+		err = multierr.Append(err, enc.AddObject("cause", *v.Cause))
+	}
 	return err
 }
 
@@ -47497,6 +52938,471 @@ func (v *WorkflowExecutionTerminatedEventAttributes) IsSetIdentity() bool {
 	return v != nil && v.Identity != nil
 }
 
+// GetCause returns the value of Cause if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionTerminatedEventAttributes) GetCause() (o TerminationCause) {
+	if v != nil && v.Cause != nil {
+		return *v.Cause
+	}
+
+	return
+}
+
+// IsSetCause returns true if Cause is not nil.
+func (v *WorkflowExecutionTerminatedEventAttributes) IsSetCause() bool {
+	return v != nil && v.Cause != nil
+}
+
+type WorkflowExecutionTerminationInfo struct {
+	Cause    *TerminationCause `json:"cause,omitempty"`
+	Reason   *string           `json:"reason,omitempty"`
+	Details  []byte            `json:"details,omitempty"`
+	Identity *string           `json:"identity,omitempty"`
+}
+
+// ToWire translates a WorkflowExecutionTerminationInfo struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *WorkflowExecutionTerminationInfo) ToWire() (wire.Value, error) {
+	var (
+		fields [4]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Cause != nil {
+		w, err = v.Cause.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.Reason != nil {
+		w, err = wire.NewValueString(*(v.Reason)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.Details != nil {
+		w, err = wire.NewValueBinary(v.Details), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+	if v.Identity != nil {
+		w, err = wire.NewValueString(*(v.Identity)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a WorkflowExecutionTerminationInfo struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a WorkflowExecutionTerminationInfo struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v WorkflowExecutionTerminationInfo
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *WorkflowExecutionTerminationInfo) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TI32 {
+				var x TerminationCause
+				x, err = _TerminationCause_Read(field.Value)
+				v.Cause = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Reason = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TBinary {
+				v.Details, err = field.Value.GetBinary(), error(nil)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Identity = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a WorkflowExecutionTerminationInfo
+// struct.
+func (v *WorkflowExecutionTerminationInfo) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [4]string
+	i := 0
+	if v.Cause != nil {
+		fields[i] = fmt.Sprintf("Cause: %v", *(v.Cause))
+		i++
+	}
+	if v.Reason != nil {
+		fields[i] = fmt.Sprintf("Reason: %v", *(v.Reason))
+		i++
+	}
+	if v.Details != nil {
+		fields[i] = fmt.Sprintf("Details: %v", v.Details)
+		i++
+	}
+	if v.Identity != nil {
+		fields[i] = fmt.Sprintf("Identity: %v", *(v.Identity))
+		i++
+	}
+
+	return fmt.Sprintf("WorkflowExecutionTerminationInfo{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this WorkflowExecutionTerminationInfo match the
+// provided WorkflowExecutionTerminationInfo.
+//
+// This function performs a deep comparison.
+func (v *WorkflowExecutionTerminationInfo) Equals(rhs *WorkflowExecutionTerminationInfo) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_TerminationCause_EqualsPtr(v.Cause, rhs.Cause) {
+		return false
+	}
+	if !_String_EqualsPtr(v.Reason, rhs.Reason) {
+		return false
+	}
+	if !((v.Details == nil && rhs.Details == nil) || (v.Details != nil && rhs.Details != nil && bytes.Equal(v.Details, rhs.Details))) {
+		return false
+	}
+	if !_String_EqualsPtr(v.Identity, rhs.Identity) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of WorkflowExecutionTerminationInfo.
+func (v *WorkflowExecutionTerminationInfo) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Cause != nil {
+		err = multierr.Append(err, enc.AddObject("cause", *v.Cause))
+	}
+	if v.Reason != nil {
+		enc.AddString("reason", *v.Reason)
+	}
+	if v.Details != nil {
+		enc.AddString("details", base64.StdEncoding.EncodeToString(v.Details))
+	}
+	if v.Identity != nil {
+		enc.AddString("identity", *v.Identity)
+	}
+	return err
+}
+
+// GetCause returns the value of Cause if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionTerminationInfo) GetCause() (o TerminationCause) {
+	if v != nil && v.Cause != nil {
+		return *v.Cause
+	}
+
+	return
+}
+
+// IsSetCause returns true if Cause is not nil.
+func (v *WorkflowExecutionTerminationInfo) IsSetCause() bool {
+	return v != nil && v.Cause != nil
+}
+
+// GetReason returns the value of Reason if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionTerminationInfo) GetReason() (o string) {
+	if v != nil && v.Reason != nil {
+		return *v.Reason
+	}
+
+	return
+}
+
+// IsSetReason returns true if Reason is not nil.
+func (v *WorkflowExecutionTerminationInfo) IsSetReason() bool {
+	return v != nil && v.Reason != nil
+}
+
+// GetDetails returns the value of Details if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionTerminationInfo) GetDetails() (o []byte) {
+	if v != nil && v.Details != nil {
+		return v.Details
+	}
+
+	return
+}
+
+// IsSetDetails returns true if Details is not nil.
+func (v *WorkflowExecutionTerminationInfo) IsSetDetails() bool {
+	return v != nil && v.Details != nil
+}
+
+// GetIdentity returns the value of Identity if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionTerminationInfo) GetIdentity() (o string) {
+	if v != nil && v.Identity != nil {
+		return *v.Identity
+	}
+
+	return
+}
+
+// IsSetIdentity returns true if Identity is not nil.
+func (v *WorkflowExecutionTerminationInfo) IsSetIdentity() bool {
+	return v != nil && v.Identity != nil
+}
+
+type WorkflowExecutionTimeBucket struct {
+	StartTime *int64 `json:"startTime,omitempty"`
+	Count     *int64 `json:"count,omitempty"`
+}
+
+// ToWire translates a WorkflowExecutionTimeBucket struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *WorkflowExecutionTimeBucket) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.StartTime != nil {
+		w, err = wire.NewValueI64(*(v.StartTime)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.Count != nil {
+		w, err = wire.NewValueI64(*(v.Count)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a WorkflowExecutionTimeBucket struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a WorkflowExecutionTimeBucket struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v WorkflowExecutionTimeBucket
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *WorkflowExecutionTimeBucket) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.StartTime = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.Count = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a WorkflowExecutionTimeBucket
+// struct.
+func (v *WorkflowExecutionTimeBucket) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [2]string
+	i := 0
+	if v.StartTime != nil {
+		fields[i] = fmt.Sprintf("StartTime: %v", *(v.StartTime))
+		i++
+	}
+	if v.Count != nil {
+		fields[i] = fmt.Sprintf("Count: %v", *(v.Count))
+		i++
+	}
+
+	return fmt.Sprintf("WorkflowExecutionTimeBucket{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this WorkflowExecutionTimeBucket match the
+// provided WorkflowExecutionTimeBucket.
+//
+// This function performs a deep comparison.
+func (v *WorkflowExecutionTimeBucket) Equals(rhs *WorkflowExecutionTimeBucket) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_I64_EqualsPtr(v.StartTime, rhs.StartTime) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.Count, rhs.Count) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of WorkflowExecutionTimeBucket.
+func (v *WorkflowExecutionTimeBucket) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.StartTime != nil {
+		enc.AddInt64("startTime", *v.StartTime)
+	}
+	if v.Count != nil {
+		enc.AddInt64("count", *v.Count)
+	}
+	return err
+}
+
+// GetStartTime returns the value of StartTime if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionTimeBucket) GetStartTime() (o int64) {
+	if v != nil && v.StartTime != nil {
+		return *v.StartTime
+	}
+
+	return
+}
+
+// IsSetStartTime returns true if StartTime is not nil.
+func (v *WorkflowExecutionTimeBucket) IsSetStartTime() bool {
+	return v != nil && v.StartTime != nil
+}
+
+// GetCount returns the value of Count if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionTimeBucket) GetCount() (o int64) {
+	if v != nil && v.Count != nil {
+		return *v.Count
+	}
+
+	return
+}
+
+// IsSetCount returns true if Count is not nil.
+func (v *WorkflowExecutionTimeBucket) IsSetCount() bool {
+	return v != nil && v.Count != nil
+}
+
 type WorkflowExecutionTimedOutEventAttributes struct {
 	TimeoutType *TimeoutType `json:"timeoutType,omitempty"`
 }
@@ -47641,6 +53547,7 @@ const (
 	WorkflowIdReusePolicyAllowDuplicateFailedOnly WorkflowIdReusePolicy = 0
 	WorkflowIdReusePolicyAllowDuplicate           WorkflowIdReusePolicy = 1
 	WorkflowIdReusePolicyRejectDuplicate          WorkflowIdReusePolicy = 2
+	WorkflowIdReusePolicyTerminateIfRunning       WorkflowIdReusePolicy = 3
 )
 
 // WorkflowIdReusePolicy_Values returns all recognized values of WorkflowIdReusePolicy.
@@ -47649,6 +53556,7 @@ func WorkflowIdReusePolicy_Values() []WorkflowIdReusePolicy {
 		WorkflowIdReusePolicyAllowDuplicateFailedOnly,
 		WorkflowIdReusePolicyAllowDuplicate,
 		WorkflowIdReusePolicyRejectDuplicate,
+		WorkflowIdReusePolicyTerminateIfRunning,
 	}
 }
 
@@ -47668,6 +53576,9 @@ func (v *WorkflowIdReusePolicy) UnmarshalText(value []byte) error {
 	case "RejectDuplicate":
 		*v = WorkflowIdReusePolicyRejectDuplicate
 		return nil
+	case "TerminateIfRunning":
+		*v = WorkflowIdReusePolicyTerminateIfRunning
+		return nil
 	default:
 		val, err := strconv.ParseInt(s, 10, 32)
 		if err != nil {
@@ -47692,6 +53603,8 @@ func (v WorkflowIdReusePolicy) MarshalText() ([]byte, error) {
 		return []byte("AllowDuplicate"), nil
 	case 2:
 		return []byte("RejectDuplicate"), nil
+	case 3:
+		return []byte("TerminateIfRunning"), nil
 	}
 	return []byte(strconv.FormatInt(int64(v), 10)), nil
 }
@@ -47709,6 +53622,8 @@ func (v WorkflowIdReusePolicy) MarshalLogObject(enc zapcore.ObjectEncoder) error
 		enc.AddString("name", "AllowDuplicate")
 	case 2:
 		enc.AddString("name", "RejectDuplicate")
+	case 3:
+		enc.AddString("name", "TerminateIfRunning")
 	}
 	return nil
 }
@@ -47755,6 +53670,8 @@ func (v WorkflowIdReusePolicy) String() string {
 		return "AllowDuplicate"
 	case 2:
 		return "RejectDuplicate"
+	case 3:
+		return "TerminateIfRunning"
 	}
 	return fmt.Sprintf("WorkflowIdReusePolicy(%d)", w)
 }
@@ -47779,6 +53696,8 @@ func (v WorkflowIdReusePolicy) MarshalJSON() ([]byte, error) {
 		return ([]byte)("\"AllowDuplicate\""), nil
 	case 2:
 		return ([]byte)("\"RejectDuplicate\""), nil
+	case 3:
+		return ([]byte)("\"TerminateIfRunning\""), nil
 	}
 	return ([]byte)(strconv.FormatInt(int64(v), 10)), nil
 }
@@ -103,6 +103,134 @@ func (c *clientImpl) GetWorkflowExecutionRawHistory(
 	return client.GetWorkflowExecutionRawHistory(ctx, request, opts...)
 }
 
+func (c *clientImpl) ExportWorkflowExecution(
+	ctx context.Context,
+	request *admin.ExportWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (*admin.ExportWorkflowExecutionResponse, error) {
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.ExportWorkflowExecution(ctx, request, opts...)
+}
+
+func (c *clientImpl) ImportWorkflowExecution(
+	ctx context.Context,
+	request *admin.ImportWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (*admin.ImportWorkflowExecutionResponse, error) {
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.ImportWorkflowExecution(ctx, request, opts...)
+}
+
+func (c *clientImpl) MigrateWorkflowExecution(
+	ctx context.Context,
+	request *admin.MigrateWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (*admin.MigrateWorkflowExecutionResponse, error) {
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.MigrateWorkflowExecution(ctx, request, opts...)
+}
+
+func (c *clientImpl) AddRemoteCluster(
+	ctx context.Context,
+	request *admin.AddRemoteClusterRequest,
+	opts ...yarpc.CallOption,
+) (*admin.AddRemoteClusterResponse, error) {
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.AddRemoteCluster(ctx, request, opts...)
+}
+
+func (c *clientImpl) AddSearchAttribute(
+	ctx context.Context,
+	request *admin.AddSearchAttributeRequest,
+	opts ...yarpc.CallOption,
+) (*admin.AddSearchAttributeResponse, error) {
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.AddSearchAttribute(ctx, request, opts...)
+}
+
+func (c *clientImpl) GetReplicationTasks(
+	ctx context.Context,
+	request *admin.GetReplicationTasksRequest,
+	opts ...yarpc.CallOption,
+) (*admin.GetReplicationTasksResponse, error) {
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.GetReplicationTasks(ctx, request, opts...)
+}
+
+func (c *clientImpl) GetDLQReplicationTasks(
+	ctx context.Context,
+	request *admin.GetDLQReplicationTasksRequest,
+	opts ...yarpc.CallOption,
+) (*admin.GetDLQReplicationTasksResponse, error) {
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.GetDLQReplicationTasks(ctx, request, opts...)
+}
+
+func (c *clientImpl) GetReplicationConsumerLag(
+	ctx context.Context,
+	request *admin.GetReplicationConsumerLagRequest,
+	opts ...yarpc.CallOption,
+) (*admin.GetReplicationConsumerLagResponse, error) {
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.GetReplicationConsumerLag(ctx, request, opts...)
+}
+
 func (c *clientImpl) createContext(parent context.Context) (context.Context, context.CancelFunc) {
 	if parent == nil {
 		return context.WithTimeout(context.Background(), c.timeout)
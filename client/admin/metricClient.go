@@ -97,3 +97,147 @@ func (c *metricClient) GetWorkflowExecutionRawHistory(
 	}
 	return resp, err
 }
+
+func (c *metricClient) ExportWorkflowExecution(
+	ctx context.Context,
+	request *admin.ExportWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (*admin.ExportWorkflowExecutionResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientExportWorkflowExecutionScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientExportWorkflowExecutionScope, metrics.CadenceClientLatency)
+	resp, err := c.client.ExportWorkflowExecution(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientExportWorkflowExecutionScope, metrics.CadenceClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) ImportWorkflowExecution(
+	ctx context.Context,
+	request *admin.ImportWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (*admin.ImportWorkflowExecutionResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientImportWorkflowExecutionScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientImportWorkflowExecutionScope, metrics.CadenceClientLatency)
+	resp, err := c.client.ImportWorkflowExecution(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientImportWorkflowExecutionScope, metrics.CadenceClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) MigrateWorkflowExecution(
+	ctx context.Context,
+	request *admin.MigrateWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (*admin.MigrateWorkflowExecutionResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientMigrateWorkflowExecutionScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientMigrateWorkflowExecutionScope, metrics.CadenceClientLatency)
+	resp, err := c.client.MigrateWorkflowExecution(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientMigrateWorkflowExecutionScope, metrics.CadenceClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) AddRemoteCluster(
+	ctx context.Context,
+	request *admin.AddRemoteClusterRequest,
+	opts ...yarpc.CallOption,
+) (*admin.AddRemoteClusterResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientAddRemoteClusterScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientAddRemoteClusterScope, metrics.CadenceClientLatency)
+	resp, err := c.client.AddRemoteCluster(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientAddRemoteClusterScope, metrics.CadenceClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) AddSearchAttribute(
+	ctx context.Context,
+	request *admin.AddSearchAttributeRequest,
+	opts ...yarpc.CallOption,
+) (*admin.AddSearchAttributeResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientAddSearchAttributeScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientAddSearchAttributeScope, metrics.CadenceClientLatency)
+	resp, err := c.client.AddSearchAttribute(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientAddSearchAttributeScope, metrics.CadenceClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) GetReplicationTasks(
+	ctx context.Context,
+	request *admin.GetReplicationTasksRequest,
+	opts ...yarpc.CallOption,
+) (*admin.GetReplicationTasksResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientGetReplicationTasksScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientGetReplicationTasksScope, metrics.CadenceClientLatency)
+	resp, err := c.client.GetReplicationTasks(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientGetReplicationTasksScope, metrics.CadenceClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) GetDLQReplicationTasks(
+	ctx context.Context,
+	request *admin.GetDLQReplicationTasksRequest,
+	opts ...yarpc.CallOption,
+) (*admin.GetDLQReplicationTasksResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientGetDLQReplicationTasksScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientGetDLQReplicationTasksScope, metrics.CadenceClientLatency)
+	resp, err := c.client.GetDLQReplicationTasks(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientGetDLQReplicationTasksScope, metrics.CadenceClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) GetReplicationConsumerLag(
+	ctx context.Context,
+	request *admin.GetReplicationConsumerLagRequest,
+	opts ...yarpc.CallOption,
+) (*admin.GetReplicationConsumerLagResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientGetReplicationConsumerLagScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientGetReplicationConsumerLagScope, metrics.CadenceClientLatency)
+	resp, err := c.client.GetReplicationConsumerLag(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientGetReplicationConsumerLagScope, metrics.CadenceClientFailures)
+	}
+	return resp, err
+}
@@ -93,3 +93,131 @@ func (c *retryableClient) GetWorkflowExecutionRawHistory(
 	err := backoff.Retry(op, c.policy, c.isRetryable)
 	return resp, err
 }
+
+func (c *retryableClient) ExportWorkflowExecution(
+	ctx context.Context,
+	request *admin.ExportWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (*admin.ExportWorkflowExecutionResponse, error) {
+
+	var resp *admin.ExportWorkflowExecutionResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.ExportWorkflowExecution(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) ImportWorkflowExecution(
+	ctx context.Context,
+	request *admin.ImportWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (*admin.ImportWorkflowExecutionResponse, error) {
+
+	var resp *admin.ImportWorkflowExecutionResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.ImportWorkflowExecution(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) MigrateWorkflowExecution(
+	ctx context.Context,
+	request *admin.MigrateWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (*admin.MigrateWorkflowExecutionResponse, error) {
+
+	var resp *admin.MigrateWorkflowExecutionResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.MigrateWorkflowExecution(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) AddRemoteCluster(
+	ctx context.Context,
+	request *admin.AddRemoteClusterRequest,
+	opts ...yarpc.CallOption,
+) (*admin.AddRemoteClusterResponse, error) {
+
+	var resp *admin.AddRemoteClusterResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.AddRemoteCluster(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) AddSearchAttribute(
+	ctx context.Context,
+	request *admin.AddSearchAttributeRequest,
+	opts ...yarpc.CallOption,
+) (*admin.AddSearchAttributeResponse, error) {
+
+	var resp *admin.AddSearchAttributeResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.AddSearchAttribute(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) GetReplicationTasks(
+	ctx context.Context,
+	request *admin.GetReplicationTasksRequest,
+	opts ...yarpc.CallOption,
+) (*admin.GetReplicationTasksResponse, error) {
+
+	var resp *admin.GetReplicationTasksResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.GetReplicationTasks(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) GetDLQReplicationTasks(
+	ctx context.Context,
+	request *admin.GetDLQReplicationTasksRequest,
+	opts ...yarpc.CallOption,
+) (*admin.GetDLQReplicationTasksResponse, error) {
+
+	var resp *admin.GetDLQReplicationTasksResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.GetDLQReplicationTasks(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) GetReplicationConsumerLag(
+	ctx context.Context,
+	request *admin.GetReplicationConsumerLagRequest,
+	opts ...yarpc.CallOption,
+) (*admin.GetReplicationConsumerLagResponse, error) {
+
+	var resp *admin.GetReplicationConsumerLagResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.GetReplicationConsumerLag(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
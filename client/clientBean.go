@@ -24,6 +24,7 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"sync"
 
 	"go.uber.org/yarpc"
 	"go.uber.org/yarpc/transport/tchannel"
@@ -33,6 +34,7 @@ import (
 	"github.com/uber/cadence/client/history"
 	"github.com/uber/cadence/client/matching"
 	"github.com/uber/cadence/common/cluster"
+	"github.com/uber/cadence/common/service/config"
 )
 
 const (
@@ -55,6 +57,10 @@ type (
 	}
 
 	clientBeanImpl struct {
+		sync.RWMutex
+		factory               Factory
+		dispatcherProvider    DispatcherProvider
+		clusterMetadata       cluster.Metadata
 		historyClient         history.Client
 		matchingClient        matching.Client
 		frontendClient        frontend.Client
@@ -84,44 +90,22 @@ func NewClientBean(factory Factory, dispatcherProvider DispatcherProvider, clust
 		return nil, err
 	}
 
-	remoteAdminClients := map[string]admin.Client{}
-	remoteFrontendClients := map[string]frontend.Client{}
+	bean := &clientBeanImpl{
+		factory:               factory,
+		dispatcherProvider:    dispatcherProvider,
+		clusterMetadata:       clusterMetadata,
+		historyClient:         historyClient,
+		matchingClient:        matchingClient,
+		frontendClient:        frontendClient,
+		remoteAdminClients:    map[string]admin.Client{},
+		remoteFrontendClients: map[string]frontend.Client{},
+	}
 	for cluster, address := range clusterMetadata.GetAllClientAddress() {
-		dispatcher, err := dispatcherProvider.Get(address.RPCName, address.RPCAddress)
-		if err != nil {
+		if err := bean.createRemoteClients(cluster, address); err != nil {
 			return nil, err
 		}
-
-		adminClient, err := factory.NewAdminClientWithTimeoutAndDispatcher(
-			address.RPCName,
-			admin.DefaultTimeout,
-			dispatcher,
-		)
-		if err != nil {
-			return nil, err
-		}
-
-		frontendclient, err := factory.NewFrontendClientWithTimeoutAndDispatcher(
-			address.RPCName,
-			frontend.DefaultTimeout,
-			frontend.DefaultLongPollTimeout,
-			dispatcher,
-		)
-		if err != nil {
-			return nil, err
-		}
-
-		remoteAdminClients[cluster] = adminClient
-		remoteFrontendClients[cluster] = frontendclient
 	}
-
-	return &clientBeanImpl{
-		historyClient:         historyClient,
-		matchingClient:        matchingClient,
-		frontendClient:        frontendClient,
-		remoteAdminClients:    remoteAdminClients,
-		remoteFrontendClients: remoteFrontendClients,
-	}, nil
+	return bean, nil
 }
 
 func (h *clientBeanImpl) GetHistoryClient() history.Client {
@@ -137,27 +121,85 @@ func (h *clientBeanImpl) GetFrontendClient() frontend.Client {
 }
 
 func (h *clientBeanImpl) GetRemoteAdminClient(cluster string) admin.Client {
+	h.RLock()
 	client, ok := h.remoteAdminClients[cluster]
+	h.RUnlock()
 	if !ok {
-		panic(fmt.Sprintf(
-			"Unknown cluster name: %v with given cluster client map: %v.",
-			cluster,
-			h.remoteAdminClients,
-		))
+		// the cluster can be a remote cluster added at runtime
+		h.ensureRemoteClients(cluster)
+		h.RLock()
+		client = h.remoteAdminClients[cluster]
+		h.RUnlock()
 	}
 	return client
 }
 
 func (h *clientBeanImpl) GetRemoteFrontendClient(cluster string) frontend.Client {
+	h.RLock()
 	client, ok := h.remoteFrontendClients[cluster]
+	h.RUnlock()
 	if !ok {
+		// the cluster can be a remote cluster added at runtime
+		h.ensureRemoteClients(cluster)
+		h.RLock()
+		client = h.remoteFrontendClients[cluster]
+		h.RUnlock()
+	}
+	return client
+}
+
+// ensureRemoteClients creates the clients of a cluster which was not known when the bean was created,
+// it panics if the cluster is unknown to the cluster metadata as well
+func (h *clientBeanImpl) ensureRemoteClients(cluster string) {
+	address, ok := h.clusterMetadata.GetAllClientAddress()[cluster]
+	if !ok {
+		h.RLock()
+		defer h.RUnlock()
 		panic(fmt.Sprintf(
 			"Unknown cluster name: %v with given cluster client map: %v.",
 			cluster,
-			h.remoteFrontendClients,
+			h.remoteAdminClients,
 		))
 	}
-	return client
+	if err := h.createRemoteClients(cluster, address); err != nil {
+		panic(fmt.Sprintf("Failed to create clients for cluster %v: %v.", cluster, err))
+	}
+}
+
+func (h *clientBeanImpl) createRemoteClients(cluster string, address config.Address) error {
+	h.Lock()
+	defer h.Unlock()
+
+	if _, ok := h.remoteAdminClients[cluster]; ok {
+		return nil
+	}
+	dispatcher, err := h.dispatcherProvider.Get(address.RPCName, address.RPCAddress)
+	if err != nil {
+		return err
+	}
+
+	adminClient, err := h.factory.NewAdminClientWithTimeoutAndDispatcher(
+		address.RPCName,
+		admin.DefaultTimeout,
+		dispatcher,
+	)
+	if err != nil {
+		return err
+	}
+
+	frontendclient, err := h.factory.NewFrontendClientWithTimeoutAndDispatcher(
+		address.RPCName,
+		frontend.DefaultTimeout,
+		frontend.DefaultLongPollTimeout,
+		dispatcher,
+	)
+	if err != nil {
+		return err
+	}
+
+	h.remoteAdminClients[cluster] = adminClient
+	h.remoteFrontendClients[cluster] = frontendclient
+	return nil
 }
 
 // NewIPYarpcDispatcherProvider create a dispatcher provider which handles with IP address
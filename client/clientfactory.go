@@ -36,6 +36,7 @@ import (
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/membership"
 	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/service/dynamicconfig"
 )
 
 const (
@@ -67,16 +68,18 @@ type rpcClientFactory struct {
 	rpcFactory            common.RPCFactory
 	monitor               membership.Monitor
 	metricsClient         metrics.Client
+	dynamicCollection     *dynamicconfig.Collection
 	numberOfHistoryShards int
 }
 
 // NewRPCClientFactory creates an instance of client factory that knows how to dispatch RPC calls.
 func NewRPCClientFactory(rpcFactory common.RPCFactory, monitor membership.Monitor,
-	metricsClient metrics.Client, numberOfHistoryShards int) Factory {
+	metricsClient metrics.Client, dc *dynamicconfig.Collection, numberOfHistoryShards int) Factory {
 	return &rpcClientFactory{
 		rpcFactory:            rpcFactory,
 		monitor:               monitor,
 		metricsClient:         metricsClient,
+		dynamicCollection:     dc,
 		numberOfHistoryShards: numberOfHistoryShards,
 	}
 }
@@ -112,7 +115,12 @@ func (cf *rpcClientFactory) NewHistoryClientWithTimeout(timeout time.Duration) (
 		return historyserviceclient.New(dispatcher.ClientConfig(common.HistoryServiceName)), nil
 	}
 
-	client := history.NewClient(cf.numberOfHistoryShards, timeout, common.NewClientCache(keyResolver, clientProvider))
+	client := history.NewClient(
+		cf.numberOfHistoryShards,
+		cf.dynamicCollection.GetDurationProperty(dynamicconfig.HistoryClientTimeout, timeout),
+		cf.dynamicCollection.GetDurationProperty(dynamicconfig.HistoryClientHedgeDelay, 0),
+		common.NewClientCache(keyResolver, clientProvider),
+	)
 	if cf.metricsClient != nil {
 		client = history.NewMetricClient(client, cf.metricsClient)
 	}
@@ -141,7 +149,12 @@ func (cf *rpcClientFactory) NewMatchingClientWithTimeout(
 		return matchingserviceclient.New(dispatcher.ClientConfig(common.MatchingServiceName)), nil
 	}
 
-	client := matching.NewClient(timeout, longPollTimeout, common.NewClientCache(keyResolver, clientProvider))
+	client := matching.NewClient(
+		cf.dynamicCollection.GetDurationProperty(dynamicconfig.MatchingClientTimeout, timeout),
+		cf.dynamicCollection.GetDurationProperty(dynamicconfig.MatchingClientHedgeDelay, 0),
+		longPollTimeout,
+		common.NewClientCache(keyResolver, clientProvider),
+	)
 	if cf.metricsClient != nil {
 		client = matching.NewMetricClient(client, cf.metricsClient)
 	}
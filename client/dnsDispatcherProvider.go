@@ -0,0 +1,234 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"net"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/uber-common/bark"
+	"go.uber.org/yarpc"
+	"go.uber.org/yarpc/api/peer"
+	"go.uber.org/yarpc/api/transport"
+	ypeer "go.uber.org/yarpc/peer"
+	"go.uber.org/yarpc/peer/hostport"
+	"go.uber.org/yarpc/peer/pendingheap"
+	"go.uber.org/yarpc/peer/roundrobin"
+	"go.uber.org/yarpc/transport/tchannel"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/service/config"
+)
+
+const (
+	defaultPeerRefreshInterval = 30 * time.Second
+)
+
+type (
+	dnsDispatcherProvider struct {
+		config config.PeerDiscovery
+		logger bark.Logger
+	}
+
+	// dnsPeerUpdater keeps the peer list in sync with the addresses a host name resolves to
+	dnsPeerUpdater struct {
+		status     int32
+		list       peer.List
+		host       string
+		port       string
+		interval   time.Duration
+		lookupHost func(host string) ([]string, error)
+		logger     bark.Logger
+		peers      map[string]struct{}
+		shutdownCh chan struct{}
+		shutdownWG sync.WaitGroup
+	}
+)
+
+// NewDNSYarpcDispatcherProvider creates a dispatcher provider which resolves the host of the address
+// with dns, balances the calls across all the resolved peers with the configured chooser and skips
+// the peers whose connection is unavailable until the tchannel transport reconnects them
+func NewDNSYarpcDispatcherProvider(cfg config.PeerDiscovery, logger bark.Logger) DispatcherProvider {
+	if cfg.RefreshInterval <= 0 {
+		cfg.RefreshInterval = defaultPeerRefreshInterval
+	}
+	return &dnsDispatcherProvider{
+		config: cfg,
+		logger: logger,
+	}
+}
+
+func (p *dnsDispatcherProvider) Get(name string, address string) (*yarpc.Dispatcher, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+
+	ch, err := tchannel.NewTransport(
+		tchannel.ServiceName(crossDCCaller),
+		// this aim to get rid of the annoying popup about accepting incoming network connections
+		tchannel.ListenAddr("127.0.0.1:0"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var list peer.ChooserList
+	switch p.config.Chooser {
+	case config.PeerChooserLeastPending:
+		list = pendingheap.New(ch)
+	default:
+		list = roundrobin.New(ch)
+	}
+	logger := p.logger.WithFields(bark.Fields{
+		logging.TagHostname: address,
+	})
+	chooser := ypeer.Bind(list, func(pl peer.List) transport.Lifecycle {
+		return newDNSPeerUpdater(pl, host, port, p.config.RefreshInterval, net.LookupHost, logger)
+	})
+
+	dispatcher := yarpc.NewDispatcher(yarpc.Config{
+		Name: crossDCCaller,
+		Outbounds: yarpc.Outbounds{
+			name: {Unary: ch.NewOutbound(chooser)},
+		},
+	})
+	err = dispatcher.Start()
+	if err != nil {
+		return nil, err
+	}
+	return dispatcher, nil
+}
+
+func newDNSPeerUpdater(
+	list peer.List,
+	host string,
+	port string,
+	interval time.Duration,
+	lookupHost func(host string) ([]string, error),
+	logger bark.Logger,
+) *dnsPeerUpdater {
+	return &dnsPeerUpdater{
+		status:     common.DaemonStatusInitialized,
+		list:       list,
+		host:       host,
+		port:       port,
+		interval:   interval,
+		lookupHost: lookupHost,
+		logger:     logger,
+		peers:      map[string]struct{}{},
+		shutdownCh: make(chan struct{}),
+	}
+}
+
+// Start resolves the peers once, so the outbound has peers when the dispatcher starts, then keeps
+// resolving them in the background
+func (u *dnsPeerUpdater) Start() error {
+	if !atomic.CompareAndSwapInt32(&u.status, common.DaemonStatusInitialized, common.DaemonStatusStarted) {
+		return nil
+	}
+	if err := u.refresh(); err != nil {
+		return err
+	}
+
+	u.shutdownWG.Add(1)
+	go u.refreshLoop()
+	return nil
+}
+
+// Stop stops resolving the peers
+func (u *dnsPeerUpdater) Stop() error {
+	if !atomic.CompareAndSwapInt32(&u.status, common.DaemonStatusStarted, common.DaemonStatusStopped) {
+		return nil
+	}
+	close(u.shutdownCh)
+	u.shutdownWG.Wait()
+	return nil
+}
+
+// IsRunning returns true if the peers are being resolved
+func (u *dnsPeerUpdater) IsRunning() bool {
+	return atomic.LoadInt32(&u.status) == common.DaemonStatusStarted
+}
+
+func (u *dnsPeerUpdater) refreshLoop() {
+	defer u.shutdownWG.Done()
+
+	ticker := time.NewTicker(u.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-u.shutdownCh:
+			return
+		case <-ticker.C:
+			if err := u.refresh(); err != nil {
+				// keep the current peers, a failed resolution is not a reason to drop hosts which may still be healthy
+				u.logger.WithField(logging.TagErr, err).Warn("Failed to resolve peers.")
+			}
+		}
+	}
+}
+
+// refresh adds the peers the host now resolves to and removes the peers it no longer resolves to
+func (u *dnsPeerUpdater) refresh() error {
+	addrs, err := u.lookupHost(u.host)
+	if err != nil {
+		return err
+	}
+
+	resolved := make(map[string]struct{}, len(addrs))
+	for _, addr := range addrs {
+		resolved[net.JoinHostPort(addr, u.port)] = struct{}{}
+	}
+
+	var updates peer.ListUpdates
+	for hostPort := range resolved {
+		if _, ok := u.peers[hostPort]; !ok {
+			updates.Additions = append(updates.Additions, hostport.PeerIdentifier(hostPort))
+		}
+	}
+	for hostPort := range u.peers {
+		if _, ok := resolved[hostPort]; !ok {
+			updates.Removals = append(updates.Removals, hostport.PeerIdentifier(hostPort))
+		}
+	}
+	if len(updates.Additions) == 0 && len(updates.Removals) == 0 {
+		return nil
+	}
+	sortPeerIdentifiers(updates.Additions)
+	sortPeerIdentifiers(updates.Removals)
+
+	if err := u.list.Update(updates); err != nil {
+		return err
+	}
+	u.peers = resolved
+	return nil
+}
+
+func sortPeerIdentifiers(ids []peer.Identifier) {
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i].Identifier() < ids[j].Identifier()
+	})
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	"go.uber.org/yarpc/api/peer"
+)
+
+type (
+	dnsPeerUpdaterSuite struct {
+		suite.Suite
+		*require.Assertions
+		list    *fakePeerList
+		addrs   []string
+		lookErr error
+		updater *dnsPeerUpdater
+	}
+
+	fakePeerList struct {
+		updates []peer.ListUpdates
+	}
+)
+
+func TestDNSPeerUpdaterSuite(t *testing.T) {
+	suite.Run(t, new(dnsPeerUpdaterSuite))
+}
+
+func (s *dnsPeerUpdaterSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.list = &fakePeerList{}
+	s.addrs = nil
+	s.lookErr = nil
+	lookupHost := func(host string) ([]string, error) {
+		s.Equal("frontend.cadence", host)
+		return s.addrs, s.lookErr
+	}
+	s.updater = newDNSPeerUpdater(s.list, "frontend.cadence", "7933", time.Hour, lookupHost, bark.NewNopLogger())
+}
+
+func (s *dnsPeerUpdaterSuite) TestRefresh() {
+	s.addrs = []string{"10.0.0.2", "10.0.0.1"}
+	s.NoError(s.updater.refresh())
+	s.Equal(1, len(s.list.updates))
+	s.Equal([]string{"10.0.0.1:7933", "10.0.0.2:7933"}, identifiers(s.list.updates[0].Additions))
+	s.Empty(s.list.updates[0].Removals)
+
+	// unchanged resolution does not update the list
+	s.NoError(s.updater.refresh())
+	s.Equal(1, len(s.list.updates))
+
+	s.addrs = []string{"10.0.0.2", "10.0.0.3"}
+	s.NoError(s.updater.refresh())
+	s.Equal(2, len(s.list.updates))
+	s.Equal([]string{"10.0.0.3:7933"}, identifiers(s.list.updates[1].Additions))
+	s.Equal([]string{"10.0.0.1:7933"}, identifiers(s.list.updates[1].Removals))
+}
+
+func (s *dnsPeerUpdaterSuite) TestRefresh_LookupFailureKeepsPeers() {
+	s.addrs = []string{"10.0.0.1"}
+	s.NoError(s.updater.refresh())
+
+	s.lookErr = errors.New("no such host")
+	s.Error(s.updater.refresh())
+	s.Equal(1, len(s.list.updates))
+	s.Equal(map[string]struct{}{"10.0.0.1:7933": {}}, s.updater.peers)
+}
+
+func (s *dnsPeerUpdaterSuite) TestStartStop() {
+	s.lookErr = errors.New("no such host")
+	s.Error(s.updater.Start())
+
+	s.SetupTest()
+	s.addrs = []string{"10.0.0.1"}
+	s.NoError(s.updater.Start())
+	s.True(s.updater.IsRunning())
+	s.Equal(1, len(s.list.updates))
+	s.NoError(s.updater.Stop())
+	s.False(s.updater.IsRunning())
+}
+
+func (l *fakePeerList) Update(updates peer.ListUpdates) error {
+	l.updates = append(l.updates, updates)
+	return nil
+}
+
+func identifiers(ids []peer.Identifier) []string {
+	var result []string
+	for _, id := range ids {
+		result = append(result, id.Identifier())
+	}
+	return result
+}
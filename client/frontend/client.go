@@ -60,6 +60,22 @@ func NewClient(
 	}
 }
 
+func (c *clientImpl) CountOpenWorkflowExecutions(
+	ctx context.Context,
+	request *shared.CountOpenWorkflowExecutionsRequest,
+	opts ...yarpc.CallOption,
+) (*shared.CountOpenWorkflowExecutionsResponse, error) {
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.CountOpenWorkflowExecutions(ctx, request, opts...)
+}
+
 func (c *clientImpl) DeprecateDomain(
 	ctx context.Context,
 	request *shared.DeprecateDomainRequest,
@@ -124,6 +140,37 @@ func (c *clientImpl) DescribeWorkflowExecution(
 	return client.DescribeWorkflowExecution(ctx, request, opts...)
 }
 
+func (c *clientImpl) DescribeWorkflowExecutions(
+	ctx context.Context,
+	request *shared.DescribeWorkflowExecutionsRequest,
+	opts ...yarpc.CallOption,
+) (*shared.DescribeWorkflowExecutionsResponse, error) {
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.DescribeWorkflowExecutions(ctx, request, opts...)
+}
+
+func (c *clientImpl) GetSearchAttributes(
+	ctx context.Context,
+	opts ...yarpc.CallOption,
+) (*shared.GetSearchAttributesResponse, error) {
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.GetSearchAttributes(ctx, opts...)
+}
+
 func (c *clientImpl) GetWorkflowExecutionHistory(
 	ctx context.Context,
 	request *shared.GetWorkflowExecutionHistoryRequest,
@@ -140,6 +187,22 @@ func (c *clientImpl) GetWorkflowExecutionHistory(
 	return client.GetWorkflowExecutionHistory(ctx, request, opts...)
 }
 
+func (c *clientImpl) GetWorkflowExecutionStatistics(
+	ctx context.Context,
+	request *shared.GetWorkflowExecutionStatisticsRequest,
+	opts ...yarpc.CallOption,
+) (*shared.GetWorkflowExecutionStatisticsResponse, error) {
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.GetWorkflowExecutionStatistics(ctx, request, opts...)
+}
+
 func (c *clientImpl) ListClosedWorkflowExecutions(
 	ctx context.Context,
 	request *shared.ListClosedWorkflowExecutionsRequest,
@@ -540,6 +603,22 @@ func (c *clientImpl) TerminateWorkflowExecution(
 	return client.TerminateWorkflowExecution(ctx, request, opts...)
 }
 
+func (c *clientImpl) UpdateWorkflowExecutionLabels(
+	ctx context.Context,
+	request *shared.UpdateWorkflowExecutionLabelsRequest,
+	opts ...yarpc.CallOption,
+) error {
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getRandomClient()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.UpdateWorkflowExecutionLabels(ctx, request, opts...)
+}
+
 func (c *clientImpl) UpdateDomain(
 	ctx context.Context,
 	request *shared.UpdateDomainRequest,
@@ -42,6 +42,24 @@ func NewMetricClient(client Client, metricsClient metrics.Client) Client {
 	}
 }
 
+func (c *metricClient) CountOpenWorkflowExecutions(
+	ctx context.Context,
+	request *shared.CountOpenWorkflowExecutionsRequest,
+	opts ...yarpc.CallOption,
+) (*shared.CountOpenWorkflowExecutionsResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.FrontendClientCountOpenWorkflowExecutionsScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.FrontendClientCountOpenWorkflowExecutionsScope, metrics.CadenceClientLatency)
+	resp, err := c.client.CountOpenWorkflowExecutions(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.FrontendClientCountOpenWorkflowExecutionsScope, metrics.CadenceClientFailures)
+	}
+	return resp, err
+}
+
 func (c *metricClient) DeprecateDomain(
 	ctx context.Context,
 	request *shared.DeprecateDomainRequest,
@@ -114,6 +132,41 @@ func (c *metricClient) DescribeWorkflowExecution(
 	return resp, err
 }
 
+func (c *metricClient) DescribeWorkflowExecutions(
+	ctx context.Context,
+	request *shared.DescribeWorkflowExecutionsRequest,
+	opts ...yarpc.CallOption,
+) (*shared.DescribeWorkflowExecutionsResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.FrontendClientDescribeWorkflowExecutionsScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.FrontendClientDescribeWorkflowExecutionsScope, metrics.CadenceClientLatency)
+	resp, err := c.client.DescribeWorkflowExecutions(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.FrontendClientDescribeWorkflowExecutionsScope, metrics.CadenceClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) GetSearchAttributes(
+	ctx context.Context,
+	opts ...yarpc.CallOption,
+) (*shared.GetSearchAttributesResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.FrontendClientGetSearchAttributesScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.FrontendClientGetSearchAttributesScope, metrics.CadenceClientLatency)
+	resp, err := c.client.GetSearchAttributes(ctx, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.FrontendClientGetSearchAttributesScope, metrics.CadenceClientFailures)
+	}
+	return resp, err
+}
+
 func (c *metricClient) GetWorkflowExecutionHistory(
 	ctx context.Context,
 	request *shared.GetWorkflowExecutionHistoryRequest,
@@ -132,6 +185,24 @@ func (c *metricClient) GetWorkflowExecutionHistory(
 	return resp, err
 }
 
+func (c *metricClient) GetWorkflowExecutionStatistics(
+	ctx context.Context,
+	request *shared.GetWorkflowExecutionStatisticsRequest,
+	opts ...yarpc.CallOption,
+) (*shared.GetWorkflowExecutionStatisticsResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.FrontendClientGetWorkflowExecutionStatisticsScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.FrontendClientGetWorkflowExecutionStatisticsScope, metrics.CadenceClientLatency)
+	resp, err := c.client.GetWorkflowExecutionStatistics(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.FrontendClientGetWorkflowExecutionStatisticsScope, metrics.CadenceClientFailures)
+	}
+	return resp, err
+}
+
 func (c *metricClient) ListClosedWorkflowExecutions(
 	ctx context.Context,
 	request *shared.ListClosedWorkflowExecutionsRequest,
@@ -582,6 +653,24 @@ func (c *metricClient) TerminateWorkflowExecution(
 	return err
 }
 
+func (c *metricClient) UpdateWorkflowExecutionLabels(
+	ctx context.Context,
+	request *shared.UpdateWorkflowExecutionLabelsRequest,
+	opts ...yarpc.CallOption,
+) error {
+
+	c.metricsClient.IncCounter(metrics.FrontendClientUpdateWorkflowExecutionLabelsScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.FrontendClientUpdateWorkflowExecutionLabelsScope, metrics.CadenceClientLatency)
+	err := c.client.UpdateWorkflowExecutionLabels(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.FrontendClientUpdateWorkflowExecutionLabelsScope, metrics.CadenceClientFailures)
+	}
+	return err
+}
+
 func (c *metricClient) UpdateDomain(
 	ctx context.Context,
 	request *shared.UpdateDomainRequest,
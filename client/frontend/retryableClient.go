@@ -46,6 +46,22 @@ func NewRetryableClient(client Client, policy backoff.RetryPolicy, isRetryable b
 	}
 }
 
+func (c *retryableClient) CountOpenWorkflowExecutions(
+	ctx context.Context,
+	request *shared.CountOpenWorkflowExecutionsRequest,
+	opts ...yarpc.CallOption,
+) (*shared.CountOpenWorkflowExecutionsResponse, error) {
+
+	var resp *shared.CountOpenWorkflowExecutionsResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.CountOpenWorkflowExecutions(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
 func (c *retryableClient) DeprecateDomain(
 	ctx context.Context,
 	request *shared.DeprecateDomainRequest,
@@ -106,6 +122,37 @@ func (c *retryableClient) DescribeWorkflowExecution(
 	return resp, err
 }
 
+func (c *retryableClient) DescribeWorkflowExecutions(
+	ctx context.Context,
+	request *shared.DescribeWorkflowExecutionsRequest,
+	opts ...yarpc.CallOption,
+) (*shared.DescribeWorkflowExecutionsResponse, error) {
+
+	var resp *shared.DescribeWorkflowExecutionsResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.DescribeWorkflowExecutions(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) GetSearchAttributes(
+	ctx context.Context,
+	opts ...yarpc.CallOption,
+) (*shared.GetSearchAttributesResponse, error) {
+
+	var resp *shared.GetSearchAttributesResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.GetSearchAttributes(ctx, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
 func (c *retryableClient) GetWorkflowExecutionHistory(
 	ctx context.Context,
 	request *shared.GetWorkflowExecutionHistoryRequest,
@@ -122,6 +169,22 @@ func (c *retryableClient) GetWorkflowExecutionHistory(
 	return resp, err
 }
 
+func (c *retryableClient) GetWorkflowExecutionStatistics(
+	ctx context.Context,
+	request *shared.GetWorkflowExecutionStatisticsRequest,
+	opts ...yarpc.CallOption,
+) (*shared.GetWorkflowExecutionStatisticsResponse, error) {
+
+	var resp *shared.GetWorkflowExecutionStatisticsResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.GetWorkflowExecutionStatistics(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
 func (c *retryableClient) ListClosedWorkflowExecutions(
 	ctx context.Context,
 	request *shared.ListClosedWorkflowExecutionsRequest,
@@ -474,6 +537,18 @@ func (c *retryableClient) TerminateWorkflowExecution(
 	return backoff.Retry(op, c.policy, c.isRetryable)
 }
 
+func (c *retryableClient) UpdateWorkflowExecutionLabels(
+	ctx context.Context,
+	request *shared.UpdateWorkflowExecutionLabelsRequest,
+	opts ...yarpc.CallOption,
+) error {
+
+	op := func() error {
+		return c.client.UpdateWorkflowExecutionLabels(ctx, request, opts...)
+	}
+	return backoff.Retry(op, c.policy, c.isRetryable)
+}
+
 func (c *retryableClient) UpdateDomain(
 	ctx context.Context,
 	request *shared.UpdateDomainRequest,
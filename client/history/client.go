@@ -22,12 +22,14 @@ package history
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	h "github.com/uber/cadence/.gen/go/history"
 	"github.com/uber/cadence/.gen/go/history/historyserviceclient"
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/service/dynamicconfig"
 	"go.uber.org/yarpc"
 )
 
@@ -41,20 +43,24 @@ const (
 type clientImpl struct {
 	numberOfShards  int
 	tokenSerializer common.TaskTokenSerializer
-	timeout         time.Duration
+	timeout         dynamicconfig.DurationPropertyFn
+	hedgeDelay      dynamicconfig.DurationPropertyFn
 	clients         common.ClientCache
 }
 
-// NewClient creates a new history service TChannel client
+// NewClient creates a new history service TChannel client, the timeout and the hedge delay
+// are filtered by API name
 func NewClient(
 	numberOfShards int,
-	timeout time.Duration,
+	timeout dynamicconfig.DurationPropertyFn,
+	hedgeDelay dynamicconfig.DurationPropertyFn,
 	clients common.ClientCache,
 ) Client {
 	return &clientImpl{
 		numberOfShards:  numberOfShards,
 		tokenSerializer: common.NewJSONTaskTokenSerializer(),
 		timeout:         timeout,
+		hedgeDelay:      hedgeDelay,
 		clients:         clients,
 	}
 }
@@ -71,7 +77,7 @@ func (c *clientImpl) StartWorkflowExecution(
 	var response *workflow.StartWorkflowExecutionResponse
 	op := func(ctx context.Context, client historyserviceclient.Interface) error {
 		var err error
-		ctx, cancel := c.createContext(ctx)
+		ctx, cancel := c.createContext(ctx, "StartWorkflowExecution")
 		defer cancel()
 		response, err = client.StartWorkflowExecution(ctx, request, opts...)
 		return err
@@ -92,19 +98,21 @@ func (c *clientImpl) GetMutableState(
 		return nil, err
 	}
 	opts = common.AggregateYarpcOptions(ctx, opts...)
-	var response *h.GetMutableStateResponse
-	op := func(ctx context.Context, client historyserviceclient.Interface) error {
-		var err error
-		ctx, cancel := c.createContext(ctx)
+	op := func(ctx context.Context, client historyserviceclient.Interface) (interface{}, error) {
+		ctx, cancel := c.createContext(ctx, "GetMutableState")
 		defer cancel()
-		response, err = client.GetMutableState(ctx, request, opts...)
-		return err
+		return client.GetMutableState(ctx, request, opts...)
 	}
-	err = c.executeWithRedirect(ctx, client, op)
+	// only the reads which do not wait for new events are hedged
+	hedgeAPI := "GetMutableState"
+	if request.GetExpectedNextEventId() > common.FirstEventID {
+		hedgeAPI = ""
+	}
+	response, err := c.executeHedgedWithRedirect(ctx, hedgeAPI, client, op)
 	if err != nil {
 		return nil, err
 	}
-	return response, nil
+	return response.(*h.GetMutableStateResponse), nil
 }
 
 func (c *clientImpl) DescribeHistoryHost(
@@ -134,7 +142,7 @@ func (c *clientImpl) DescribeHistoryHost(
 	var response *workflow.DescribeHistoryHostResponse
 	op := func(ctx context.Context, client historyserviceclient.Interface) error {
 		var err error
-		ctx, cancel := c.createContext(ctx)
+		ctx, cancel := c.createContext(ctx, "DescribeHistoryHost")
 		defer cancel()
 		response, err = client.DescribeHistoryHost(ctx, request, opts...)
 		return err
@@ -155,19 +163,16 @@ func (c *clientImpl) DescribeMutableState(
 		return nil, err
 	}
 	opts = common.AggregateYarpcOptions(ctx, opts...)
-	var response *h.DescribeMutableStateResponse
-	op := func(ctx context.Context, client historyserviceclient.Interface) error {
-		var err error
-		ctx, cancel := c.createContext(ctx)
+	op := func(ctx context.Context, client historyserviceclient.Interface) (interface{}, error) {
+		ctx, cancel := c.createContext(ctx, "DescribeMutableState")
 		defer cancel()
-		response, err = client.DescribeMutableState(ctx, request, opts...)
-		return err
+		return client.DescribeMutableState(ctx, request, opts...)
 	}
-	err = c.executeWithRedirect(ctx, client, op)
+	response, err := c.executeHedgedWithRedirect(ctx, "DescribeMutableState", client, op)
 	if err != nil {
 		return nil, err
 	}
-	return response, nil
+	return response.(*h.DescribeMutableStateResponse), nil
 }
 
 func (c *clientImpl) ResetStickyTaskList(
@@ -182,7 +187,7 @@ func (c *clientImpl) ResetStickyTaskList(
 	var response *h.ResetStickyTaskListResponse
 	op := func(ctx context.Context, client historyserviceclient.Interface) error {
 		var err error
-		ctx, cancel := c.createContext(ctx)
+		ctx, cancel := c.createContext(ctx, "ResetStickyTaskList")
 		defer cancel()
 		response, err = client.ResetStickyTaskList(ctx, request, opts...)
 		return err
@@ -203,18 +208,75 @@ func (c *clientImpl) DescribeWorkflowExecution(
 		return nil, err
 	}
 	opts = common.AggregateYarpcOptions(ctx, opts...)
-	var response *workflow.DescribeWorkflowExecutionResponse
-	op := func(ctx context.Context, client historyserviceclient.Interface) error {
-		var err error
-		ctx, cancel := c.createContext(ctx)
+	op := func(ctx context.Context, client historyserviceclient.Interface) (interface{}, error) {
+		ctx, cancel := c.createContext(ctx, "DescribeWorkflowExecution")
 		defer cancel()
-		response, err = client.DescribeWorkflowExecution(ctx, request, opts...)
-		return err
+		return client.DescribeWorkflowExecution(ctx, request, opts...)
 	}
-	err = c.executeWithRedirect(ctx, client, op)
+	response, err := c.executeHedgedWithRedirect(ctx, "DescribeWorkflowExecution", client, op)
 	if err != nil {
 		return nil, err
 	}
+	return response.(*workflow.DescribeWorkflowExecutionResponse), nil
+}
+
+// DescribeWorkflowExecutions groups the executions by the shard owning them and sends one request per shard,
+// every history host loads the executions of a shard in one persistence round trip
+func (c *clientImpl) DescribeWorkflowExecutions(
+	ctx context.Context,
+	request *h.DescribeWorkflowExecutionsRequest,
+	opts ...yarpc.CallOption) (*workflow.DescribeWorkflowExecutionsResponse, error) {
+	executionsByShard := make(map[int][]*workflow.WorkflowExecution)
+	for _, execution := range request.Request.GetExecutions() {
+		shardID := common.WorkflowIDToHistoryShard(execution.GetWorkflowId(), c.numberOfShards)
+		executionsByShard[shardID] = append(executionsByShard[shardID], execution)
+	}
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	var retError error
+	response := &workflow.DescribeWorkflowExecutionsResponse{}
+	for shardID, executions := range executionsByShard {
+		client, err := c.getClientForShardID(shardID)
+		if err != nil {
+			return nil, err
+		}
+		shardRequest := &h.DescribeWorkflowExecutionsRequest{
+			DomainUUID: request.DomainUUID,
+			Request: &workflow.DescribeWorkflowExecutionsRequest{
+				Domain:     request.Request.Domain,
+				Executions: executions,
+			},
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var shardResponse *workflow.DescribeWorkflowExecutionsResponse
+			op := func(ctx context.Context, client historyserviceclient.Interface) error {
+				var err error
+				ctx, cancel := c.createContext(ctx, "DescribeWorkflowExecutions")
+				defer cancel()
+				shardResponse, err = client.DescribeWorkflowExecutions(ctx, shardRequest, opts...)
+				return err
+			}
+			err := c.executeWithRedirect(ctx, client, op)
+
+			lock.Lock()
+			defer lock.Unlock()
+			if err != nil {
+				retError = err
+				return
+			}
+			response.Executions = append(response.Executions, shardResponse.Executions...)
+		}()
+	}
+	wg.Wait()
+
+	if retError != nil {
+		return nil, retError
+	}
 	return response, nil
 }
 
@@ -230,7 +292,7 @@ func (c *clientImpl) RecordDecisionTaskStarted(
 	var response *h.RecordDecisionTaskStartedResponse
 	op := func(ctx context.Context, client historyserviceclient.Interface) error {
 		var err error
-		ctx, cancel := c.createContext(ctx)
+		ctx, cancel := c.createContext(ctx, "RecordDecisionTaskStarted")
 		defer cancel()
 		response, err = client.RecordDecisionTaskStarted(ctx, request, opts...)
 		return err
@@ -254,7 +316,7 @@ func (c *clientImpl) RecordActivityTaskStarted(
 	var response *h.RecordActivityTaskStartedResponse
 	op := func(ctx context.Context, client historyserviceclient.Interface) error {
 		var err error
-		ctx, cancel := c.createContext(ctx)
+		ctx, cancel := c.createContext(ctx, "RecordActivityTaskStarted")
 		defer cancel()
 		response, err = client.RecordActivityTaskStarted(ctx, request, opts...)
 		return err
@@ -281,7 +343,7 @@ func (c *clientImpl) RespondDecisionTaskCompleted(
 	opts = common.AggregateYarpcOptions(ctx, opts...)
 	var response *h.RespondDecisionTaskCompletedResponse
 	op := func(ctx context.Context, client historyserviceclient.Interface) error {
-		ctx, cancel := c.createContext(ctx)
+		ctx, cancel := c.createContext(ctx, "RespondDecisionTaskCompleted")
 		defer cancel()
 		response, err = client.RespondDecisionTaskCompleted(ctx, request, opts...)
 		return err
@@ -304,7 +366,7 @@ func (c *clientImpl) RespondDecisionTaskFailed(
 	}
 	opts = common.AggregateYarpcOptions(ctx, opts...)
 	op := func(ctx context.Context, client historyserviceclient.Interface) error {
-		ctx, cancel := c.createContext(ctx)
+		ctx, cancel := c.createContext(ctx, "RespondDecisionTaskFailed")
 		defer cancel()
 		return client.RespondDecisionTaskFailed(ctx, request, opts...)
 	}
@@ -326,7 +388,7 @@ func (c *clientImpl) RespondActivityTaskCompleted(
 	}
 	opts = common.AggregateYarpcOptions(ctx, opts...)
 	op := func(ctx context.Context, client historyserviceclient.Interface) error {
-		ctx, cancel := c.createContext(ctx)
+		ctx, cancel := c.createContext(ctx, "RespondActivityTaskCompleted")
 		defer cancel()
 		return client.RespondActivityTaskCompleted(ctx, request, opts...)
 	}
@@ -348,7 +410,7 @@ func (c *clientImpl) RespondActivityTaskFailed(
 	}
 	opts = common.AggregateYarpcOptions(ctx, opts...)
 	op := func(ctx context.Context, client historyserviceclient.Interface) error {
-		ctx, cancel := c.createContext(ctx)
+		ctx, cancel := c.createContext(ctx, "RespondActivityTaskFailed")
 		defer cancel()
 		return client.RespondActivityTaskFailed(ctx, request, opts...)
 	}
@@ -370,7 +432,7 @@ func (c *clientImpl) RespondActivityTaskCanceled(
 	}
 	opts = common.AggregateYarpcOptions(ctx, opts...)
 	op := func(ctx context.Context, client historyserviceclient.Interface) error {
-		ctx, cancel := c.createContext(ctx)
+		ctx, cancel := c.createContext(ctx, "RespondActivityTaskCanceled")
 		defer cancel()
 		return client.RespondActivityTaskCanceled(ctx, request, opts...)
 	}
@@ -394,7 +456,7 @@ func (c *clientImpl) RecordActivityTaskHeartbeat(
 	var response *workflow.RecordActivityTaskHeartbeatResponse
 	op := func(ctx context.Context, client historyserviceclient.Interface) error {
 		var err error
-		ctx, cancel := c.createContext(ctx)
+		ctx, cancel := c.createContext(ctx, "RecordActivityTaskHeartbeat")
 		defer cancel()
 		response, err = client.RecordActivityTaskHeartbeat(ctx, request, opts...)
 		return err
@@ -416,7 +478,7 @@ func (c *clientImpl) RequestCancelWorkflowExecution(
 	}
 	opts = common.AggregateYarpcOptions(ctx, opts...)
 	op := func(ctx context.Context, client historyserviceclient.Interface) error {
-		ctx, cancel := c.createContext(ctx)
+		ctx, cancel := c.createContext(ctx, "RequestCancelWorkflowExecution")
 		defer cancel()
 		return client.RequestCancelWorkflowExecution(ctx, request, opts...)
 	}
@@ -433,7 +495,7 @@ func (c *clientImpl) SignalWorkflowExecution(
 	}
 	opts = common.AggregateYarpcOptions(ctx, opts...)
 	op := func(ctx context.Context, client historyserviceclient.Interface) error {
-		ctx, cancel := c.createContext(ctx)
+		ctx, cancel := c.createContext(ctx, "SignalWorkflowExecution")
 		defer cancel()
 		return client.SignalWorkflowExecution(ctx, request, opts...)
 	}
@@ -454,7 +516,7 @@ func (c *clientImpl) SignalWithStartWorkflowExecution(
 	var response *workflow.StartWorkflowExecutionResponse
 	op := func(ctx context.Context, client historyserviceclient.Interface) error {
 		var err error
-		ctx, cancel := c.createContext(ctx)
+		ctx, cancel := c.createContext(ctx, "SignalWithStartWorkflowExecution")
 		defer cancel()
 		response, err = client.SignalWithStartWorkflowExecution(ctx, request, opts...)
 		return err
@@ -476,7 +538,7 @@ func (c *clientImpl) RemoveSignalMutableState(
 		return err
 	}
 	op := func(ctx context.Context, client historyserviceclient.Interface) error {
-		ctx, cancel := c.createContext(ctx)
+		ctx, cancel := c.createContext(ctx, "RemoveSignalMutableState")
 		defer cancel()
 		return client.RemoveSignalMutableState(ctx, request)
 	}
@@ -495,7 +557,7 @@ func (c *clientImpl) TerminateWorkflowExecution(
 	}
 	opts = common.AggregateYarpcOptions(ctx, opts...)
 	op := func(ctx context.Context, client historyserviceclient.Interface) error {
-		ctx, cancel := c.createContext(ctx)
+		ctx, cancel := c.createContext(ctx, "TerminateWorkflowExecution")
 		defer cancel()
 		return client.TerminateWorkflowExecution(ctx, request, opts...)
 	}
@@ -503,6 +565,24 @@ func (c *clientImpl) TerminateWorkflowExecution(
 	return err
 }
 
+func (c *clientImpl) UpdateWorkflowExecutionLabels(
+	ctx context.Context,
+	request *h.UpdateWorkflowExecutionLabelsRequest,
+	opts ...yarpc.CallOption) error {
+	client, err := c.getClientForWorkflowID(*request.UpdateRequest.WorkflowExecution.WorkflowId)
+	if err != nil {
+		return err
+	}
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	op := func(ctx context.Context, client historyserviceclient.Interface) error {
+		ctx, cancel := c.createContext(ctx, "UpdateWorkflowExecutionLabels")
+		defer cancel()
+		return client.UpdateWorkflowExecutionLabels(ctx, request, opts...)
+	}
+	err = c.executeWithRedirect(ctx, client, op)
+	return err
+}
+
 func (c *clientImpl) ResetWorkflowExecution(
 	ctx context.Context,
 	request *h.ResetWorkflowExecutionRequest,
@@ -514,7 +594,7 @@ func (c *clientImpl) ResetWorkflowExecution(
 	opts = common.AggregateYarpcOptions(ctx, opts...)
 	var response *workflow.ResetWorkflowExecutionResponse
 	op := func(ctx context.Context, client historyserviceclient.Interface) error {
-		ctx, cancel := c.createContext(ctx)
+		ctx, cancel := c.createContext(ctx, "ResetWorkflowExecution")
 		defer cancel()
 		response, err = client.ResetWorkflowExecution(ctx, request, opts...)
 		return err
@@ -536,7 +616,7 @@ func (c *clientImpl) ScheduleDecisionTask(
 	}
 	opts = common.AggregateYarpcOptions(ctx, opts...)
 	op := func(ctx context.Context, client historyserviceclient.Interface) error {
-		ctx, cancel := c.createContext(ctx)
+		ctx, cancel := c.createContext(ctx, "ScheduleDecisionTask")
 		defer cancel()
 		return client.ScheduleDecisionTask(ctx, request, opts...)
 	}
@@ -554,7 +634,7 @@ func (c *clientImpl) RecordChildExecutionCompleted(
 	}
 	opts = common.AggregateYarpcOptions(ctx, opts...)
 	op := func(ctx context.Context, client historyserviceclient.Interface) error {
-		ctx, cancel := c.createContext(ctx)
+		ctx, cancel := c.createContext(ctx, "RecordChildExecutionCompleted")
 		defer cancel()
 		return client.RecordChildExecutionCompleted(ctx, request, opts...)
 	}
@@ -572,7 +652,7 @@ func (c *clientImpl) ReplicateEvents(
 	}
 	opts = common.AggregateYarpcOptions(ctx, opts...)
 	op := func(ctx context.Context, client historyserviceclient.Interface) error {
-		ctx, cancel := c.createContext(ctx)
+		ctx, cancel := c.createContext(ctx, "ReplicateEvents")
 		defer cancel()
 		return client.ReplicateEvents(ctx, request, opts...)
 	}
@@ -590,7 +670,7 @@ func (c *clientImpl) ReplicateRawEvents(
 	}
 	opts = common.AggregateYarpcOptions(ctx, opts...)
 	op := func(ctx context.Context, client historyserviceclient.Interface) error {
-		ctx, cancel := c.createContext(ctx)
+		ctx, cancel := c.createContext(ctx, "ReplicateRawEvents")
 		defer cancel()
 		return client.ReplicateRawEvents(ctx, request, opts...)
 	}
@@ -598,6 +678,24 @@ func (c *clientImpl) ReplicateRawEvents(
 	return err
 }
 
+func (c *clientImpl) ReplicateEventsV2(
+	ctx context.Context,
+	request *h.ReplicateEventsV2Request,
+	opts ...yarpc.CallOption) error {
+	client, err := c.getClientForWorkflowID(request.WorkflowExecution.GetWorkflowId())
+	if err != nil {
+		return err
+	}
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	op := func(ctx context.Context, client historyserviceclient.Interface) error {
+		ctx, cancel := c.createContext(ctx, "ReplicateEventsV2")
+		defer cancel()
+		return client.ReplicateEventsV2(ctx, request, opts...)
+	}
+	err = c.executeWithRedirect(ctx, client, op)
+	return err
+}
+
 func (c *clientImpl) SyncShardStatus(
 	ctx context.Context,
 	request *h.SyncShardStatusRequest,
@@ -611,7 +709,7 @@ func (c *clientImpl) SyncShardStatus(
 
 	opts = common.AggregateYarpcOptions(ctx, opts...)
 	op := func(ctx context.Context, client historyserviceclient.Interface) error {
-		ctx, cancel := c.createContext(ctx)
+		ctx, cancel := c.createContext(ctx, "SyncShardStatus")
 		defer cancel()
 		return client.SyncShardStatus(ctx, request, opts...)
 	}
@@ -630,7 +728,7 @@ func (c *clientImpl) SyncActivity(
 	}
 	opts = common.AggregateYarpcOptions(ctx, opts...)
 	op := func(ctx context.Context, client historyserviceclient.Interface) error {
-		ctx, cancel := c.createContext(ctx)
+		ctx, cancel := c.createContext(ctx, "SyncActivity")
 		defer cancel()
 		return client.SyncActivity(ctx, request, opts...)
 	}
@@ -638,11 +736,38 @@ func (c *clientImpl) SyncActivity(
 	return err
 }
 
-func (c *clientImpl) createContext(parent context.Context) (context.Context, context.CancelFunc) {
+func (c *clientImpl) GetReplicationTasks(
+	ctx context.Context,
+	request *h.GetReplicationTasksRequest,
+	opts ...yarpc.CallOption) (*h.GetReplicationTasksResponse, error) {
+
+	client, err := c.getClientForShardID(int(request.GetShardId()))
+	if err != nil {
+		return nil, err
+	}
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	var response *h.GetReplicationTasksResponse
+	op := func(ctx context.Context, client historyserviceclient.Interface) error {
+		var err error
+		ctx, cancel := c.createContext(ctx, "GetReplicationTasks")
+		defer cancel()
+		response, err = client.GetReplicationTasks(ctx, request, opts...)
+		return err
+	}
+	err = c.executeWithRedirect(ctx, client, op)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (c *clientImpl) createContext(parent context.Context, api string) (context.Context, context.CancelFunc) {
+	timeout := c.timeout(dynamicconfig.APINameFilter(api))
 	if parent == nil {
-		return context.WithTimeout(context.Background(), c.timeout)
+		return context.WithTimeout(context.Background(), timeout)
 	}
-	return context.WithTimeout(parent, c.timeout)
+	return context.WithTimeout(parent, timeout)
 }
 
 func (c *clientImpl) getClientForWorkflowID(workflowID string) (historyserviceclient.Interface, error) {
@@ -658,6 +783,25 @@ func (c *clientImpl) getClientForShardID(shardID int) (historyserviceclient.Inte
 	return client.(historyserviceclient.Interface), nil
 }
 
+// executeHedgedWithRedirect runs an idempotent read with executeWithRedirect, a second attempt is sent
+// if the first one has not returned after the hedge delay of the api, the read is not hedged if api is empty
+func (c *clientImpl) executeHedgedWithRedirect(ctx context.Context, api string, client historyserviceclient.Interface,
+	op func(ctx context.Context, client historyserviceclient.Interface) (interface{}, error)) (interface{}, error) {
+	var hedgeDelay time.Duration
+	if api != "" {
+		hedgeDelay = c.hedgeDelay(dynamicconfig.APINameFilter(api))
+	}
+	return common.HedgedCall(ctx, hedgeDelay, func(ctx context.Context) (interface{}, error) {
+		var response interface{}
+		err := c.executeWithRedirect(ctx, client, func(ctx context.Context, client historyserviceclient.Interface) error {
+			var err error
+			response, err = op(ctx, client)
+			return err
+		})
+		return response, err
+	})
+}
+
 func (c *clientImpl) executeWithRedirect(ctx context.Context, client historyserviceclient.Interface,
 	op func(ctx context.Context, client historyserviceclient.Interface) error) error {
 	var err error
@@ -130,6 +130,23 @@ func (c *metricClient) DescribeWorkflowExecution(
 	return resp, err
 }
 
+func (c *metricClient) DescribeWorkflowExecutions(
+	context context.Context,
+	request *h.DescribeWorkflowExecutionsRequest,
+	opts ...yarpc.CallOption) (*shared.DescribeWorkflowExecutionsResponse, error) {
+	c.metricsClient.IncCounter(metrics.HistoryClientDescribeWorkflowExecutionsScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientDescribeWorkflowExecutionsScope, metrics.CadenceClientLatency)
+	resp, err := c.client.DescribeWorkflowExecutions(context, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientDescribeWorkflowExecutionsScope, metrics.CadenceClientFailures)
+	}
+
+	return resp, err
+}
+
 func (c *metricClient) RecordDecisionTaskStarted(
 	context context.Context,
 	request *h.RecordDecisionTaskStartedRequest,
@@ -351,6 +368,23 @@ func (c *metricClient) TerminateWorkflowExecution(
 	return err
 }
 
+func (c *metricClient) UpdateWorkflowExecutionLabels(
+	context context.Context,
+	request *h.UpdateWorkflowExecutionLabelsRequest,
+	opts ...yarpc.CallOption) error {
+	c.metricsClient.IncCounter(metrics.HistoryClientUpdateWorkflowExecutionLabelsScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientUpdateWorkflowExecutionLabelsScope, metrics.CadenceClientLatency)
+	err := c.client.UpdateWorkflowExecutionLabels(context, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientUpdateWorkflowExecutionLabelsScope, metrics.CadenceClientFailures)
+	}
+
+	return err
+}
+
 func (c *metricClient) ResetWorkflowExecution(
 	context context.Context,
 	request *h.ResetWorkflowExecutionRequest,
@@ -436,6 +470,23 @@ func (c *metricClient) ReplicateRawEvents(
 	return err
 }
 
+func (c *metricClient) ReplicateEventsV2(
+	context context.Context,
+	request *h.ReplicateEventsV2Request,
+	opts ...yarpc.CallOption) error {
+	c.metricsClient.IncCounter(metrics.HistoryClientReplicateEventsV2Scope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientReplicateEventsV2Scope, metrics.CadenceClientLatency)
+	err := c.client.ReplicateEventsV2(context, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientReplicateEventsV2Scope, metrics.CadenceClientFailures)
+	}
+
+	return err
+}
+
 func (c *metricClient) SyncShardStatus(
 	context context.Context,
 	request *h.SyncShardStatusRequest,
@@ -469,3 +520,20 @@ func (c *metricClient) SyncActivity(
 
 	return err
 }
+
+func (c *metricClient) GetReplicationTasks(
+	context context.Context,
+	request *h.GetReplicationTasksRequest,
+	opts ...yarpc.CallOption) (*h.GetReplicationTasksResponse, error) {
+	c.metricsClient.IncCounter(metrics.HistoryClientGetReplicationTasksScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientGetReplicationTasksScope, metrics.CadenceClientLatency)
+	resp, err := c.client.GetReplicationTasks(context, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientGetReplicationTasksScope, metrics.CadenceClientFailures)
+	}
+
+	return resp, err
+}
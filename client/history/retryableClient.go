@@ -142,6 +142,22 @@ func (c *retryableClient) DescribeWorkflowExecution(
 	return resp, err
 }
 
+func (c *retryableClient) DescribeWorkflowExecutions(
+	ctx context.Context,
+	request *h.DescribeWorkflowExecutionsRequest,
+	opts ...yarpc.CallOption) (*shared.DescribeWorkflowExecutionsResponse, error) {
+
+	var resp *shared.DescribeWorkflowExecutionsResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.DescribeWorkflowExecutions(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
 func (c *retryableClient) RecordDecisionTaskStarted(
 	ctx context.Context,
 	request *h.RecordDecisionTaskStartedRequest,
@@ -318,6 +334,18 @@ func (c *retryableClient) TerminateWorkflowExecution(
 	return backoff.Retry(op, c.policy, c.isRetryable)
 }
 
+func (c *retryableClient) UpdateWorkflowExecutionLabels(
+	ctx context.Context,
+	request *h.UpdateWorkflowExecutionLabelsRequest,
+	opts ...yarpc.CallOption) error {
+
+	op := func() error {
+		return c.client.UpdateWorkflowExecutionLabels(ctx, request, opts...)
+	}
+
+	return backoff.Retry(op, c.policy, c.isRetryable)
+}
+
 func (c *retryableClient) ResetWorkflowExecution(
 	ctx context.Context,
 	request *h.ResetWorkflowExecutionRequest,
@@ -382,6 +410,18 @@ func (c *retryableClient) ReplicateRawEvents(
 	return backoff.Retry(op, c.policy, c.isRetryable)
 }
 
+func (c *retryableClient) ReplicateEventsV2(
+	ctx context.Context,
+	request *h.ReplicateEventsV2Request,
+	opts ...yarpc.CallOption) error {
+
+	op := func() error {
+		return c.client.ReplicateEventsV2(ctx, request, opts...)
+	}
+
+	return backoff.Retry(op, c.policy, c.isRetryable)
+}
+
 func (c *retryableClient) SyncShardStatus(
 	ctx context.Context,
 	request *h.SyncShardStatusRequest,
@@ -405,3 +445,19 @@ func (c *retryableClient) SyncActivity(
 
 	return backoff.Retry(op, c.policy, c.isRetryable)
 }
+
+func (c *retryableClient) GetReplicationTasks(
+	ctx context.Context,
+	request *h.GetReplicationTasksRequest,
+	opts ...yarpc.CallOption) (*h.GetReplicationTasksResponse, error) {
+
+	var resp *h.GetReplicationTasksResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.GetReplicationTasks(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/uber/cadence/.gen/go/matching/matchingserviceclient"
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/service/dynamicconfig"
 	"go.uber.org/yarpc"
 )
 
@@ -41,19 +42,23 @@ const (
 )
 
 type clientImpl struct {
-	timeout         time.Duration
+	timeout         dynamicconfig.DurationPropertyFn
+	hedgeDelay      dynamicconfig.DurationPropertyFn
 	longPollTimeout time.Duration
 	clients         common.ClientCache
 }
 
-// NewClient creates a new history service TChannel client
+// NewClient creates a new history service TChannel client, the timeout and the hedge delay
+// are filtered by API name
 func NewClient(
-	timeout time.Duration,
+	timeout dynamicconfig.DurationPropertyFn,
+	hedgeDelay dynamicconfig.DurationPropertyFn,
 	longPollTimeout time.Duration,
 	clients common.ClientCache,
 ) Client {
 	return &clientImpl{
 		timeout:         timeout,
+		hedgeDelay:      hedgeDelay,
 		longPollTimeout: longPollTimeout,
 		clients:         clients,
 	}
@@ -68,7 +73,7 @@ func (c *clientImpl) AddActivityTask(
 	if err != nil {
 		return err
 	}
-	ctx, cancel := c.createContext(ctx)
+	ctx, cancel := c.createContext(ctx, "AddActivityTask")
 	defer cancel()
 	return client.AddActivityTask(ctx, addRequest, opts...)
 }
@@ -82,7 +87,7 @@ func (c *clientImpl) AddDecisionTask(
 	if err != nil {
 		return err
 	}
-	ctx, cancel := c.createContext(ctx)
+	ctx, cancel := c.createContext(ctx, "AddDecisionTask")
 	defer cancel()
 	return client.AddDecisionTask(ctx, addRequest, opts...)
 }
@@ -121,7 +126,7 @@ func (c *clientImpl) QueryWorkflow(ctx context.Context, queryRequest *m.QueryWor
 	if err != nil {
 		return nil, err
 	}
-	ctx, cancel := c.createContext(ctx)
+	ctx, cancel := c.createContext(ctx, "QueryWorkflow")
 	defer cancel()
 	return client.QueryWorkflow(ctx, queryRequest, opts...)
 }
@@ -132,7 +137,7 @@ func (c *clientImpl) RespondQueryTaskCompleted(ctx context.Context, request *m.R
 	if err != nil {
 		return err
 	}
-	ctx, cancel := c.createContext(ctx)
+	ctx, cancel := c.createContext(ctx, "RespondQueryTaskCompleted")
 	defer cancel()
 	return client.RespondQueryTaskCompleted(ctx, request, opts...)
 }
@@ -143,7 +148,7 @@ func (c *clientImpl) CancelOutstandingPoll(ctx context.Context, request *m.Cance
 	if err != nil {
 		return err
 	}
-	ctx, cancel := c.createContext(ctx)
+	ctx, cancel := c.createContext(ctx, "CancelOutstandingPoll")
 	defer cancel()
 	return client.CancelOutstandingPoll(ctx, request, opts...)
 }
@@ -154,16 +159,24 @@ func (c *clientImpl) DescribeTaskList(ctx context.Context, request *m.DescribeTa
 	if err != nil {
 		return nil, err
 	}
-	ctx, cancel := c.createContext(ctx)
-	defer cancel()
-	return client.DescribeTaskList(ctx, request, opts...)
+	response, err := common.HedgedCall(ctx, c.hedgeDelay(dynamicconfig.APINameFilter("DescribeTaskList")),
+		func(ctx context.Context) (interface{}, error) {
+			ctx, cancel := c.createContext(ctx, "DescribeTaskList")
+			defer cancel()
+			return client.DescribeTaskList(ctx, request, opts...)
+		})
+	if err != nil {
+		return nil, err
+	}
+	return response.(*workflow.DescribeTaskListResponse), nil
 }
 
-func (c *clientImpl) createContext(parent context.Context) (context.Context, context.CancelFunc) {
+func (c *clientImpl) createContext(parent context.Context, api string) (context.Context, context.CancelFunc) {
+	timeout := c.timeout(dynamicconfig.APINameFilter(api))
 	if parent == nil {
-		return context.WithTimeout(context.Background(), c.timeout)
+		return context.WithTimeout(context.Background(), timeout)
 	}
-	return context.WithTimeout(parent, c.timeout)
+	return context.WithTimeout(parent, timeout)
 }
 
 func (c *clientImpl) createLongPollContext(parent context.Context) (context.Context, context.CancelFunc) {
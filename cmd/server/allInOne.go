@@ -0,0 +1,59 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+
+package main
+
+import (
+	"github.com/uber/cadence/common/metrics"
+	persistencefactory "github.com/uber/cadence/common/persistence/persistence-factory"
+	"github.com/uber/cadence/common/service/config"
+)
+
+type (
+	// allInOneResources holds the resources shared by the services of an all-in-one
+	// server, which runs frontend, history, matching and worker in a single process
+	allInOneResources struct {
+		persistenceFactory persistencefactory.Factory
+	}
+)
+
+// newAllInOneResources builds the resources shared by the services of an all-in-one
+// server. The persistence factory is built once from the static persistence config,
+// the per service persistence qps and connection overrides don't apply to it
+func newAllInOneResources(cfg *config.Config) *allInOneResources {
+	svcCfg := cfg.Services[frontendService]
+	metricsClient := metrics.NewClient(svcCfg.Metrics.NewScope(), metrics.Common)
+	pConfig := cfg.Persistence
+	return &allInOneResources{
+		persistenceFactory: persistencefactory.New(
+			&pConfig,
+			cfg.ClustersInfo.CurrentClusterName,
+			metricsClient,
+			cfg.Log.NewBarkLogger(),
+		),
+	}
+}
+
+// membershipFactory returns the in memory membership factory of the given service,
+// the rings are built from the rpc config of all the services of the process
+func (r *allInOneResources) membershipFactory(cfg *config.Config, serviceName string) config.MembershipFactory {
+	return config.NewInMemoryMembershipFactory(cfg.Services, cfg.Log.NewBarkLogger(), serviceName)
+}
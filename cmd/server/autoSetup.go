@@ -0,0 +1,137 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"path"
+	"time"
+
+	"github.com/olivere/elastic"
+	"github.com/uber/cadence/client"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/backoff"
+	"github.com/uber/cadence/common/persistence/sql"
+	"github.com/uber/cadence/common/service/config"
+	"github.com/uber/cadence/tools/cassandra"
+	"go.uber.org/cadence/.gen/go/cadence/workflowserviceclient"
+	"go.uber.org/cadence/.gen/go/shared"
+)
+
+const (
+	// defaultDomainName is the domain registered by the auto-setup mode
+	defaultDomainName = "default"
+	// defaultDomainRetentionDays is the retention of the domain registered by the auto-setup mode
+	defaultDomainRetentionDays = 1
+
+	visibilityIndexTemplateName = "cadence-visibility-template"
+	autoSetupTimeout            = 10 * time.Second
+)
+
+// autoSetup creates the keyspaces / databases of the configured persistence
+// stores and brings them up to the latest schema. When elastic search is
+// enabled, the visibility index template and index are created as well.
+// This is meant for development servers started against empty backing stores
+func autoSetup(cfg *config.Config, rootDir string) {
+	if err := cassandra.AutoSetupSchema(cfg.Persistence, rootDir); err != nil {
+		log.Fatalf("cassandra schema auto-setup failed: %v", err)
+	}
+	if err := sql.AutoSetupSchema(cfg.Persistence, rootDir); err != nil {
+		log.Fatalf("sql schema auto-setup failed: %v", err)
+	}
+	if cfg.ElasticSearch.Enable {
+		templateFile := path.Join(rootDir, "schema/elasticsearch/visibility/index_template.json")
+		if err := setupElasticSearch(&cfg.ElasticSearch.URL, cfg.ElasticSearch.Indices[common.VisibilityAppName], templateFile); err != nil {
+			log.Fatalf("elastic search auto-setup failed: %v", err)
+		}
+	}
+}
+
+// setupElasticSearch puts the visibility index template and creates
+// the visibility index, if it doesn't exist
+func setupElasticSearch(url fmt.Stringer, indexName string, templateFile string) error {
+	if len(indexName) == 0 {
+		return fmt.Errorf("elastic search config missing visibility index")
+	}
+	template, err := ioutil.ReadFile(templateFile)
+	if err != nil {
+		return fmt.Errorf("error reading index template %v: %v", templateFile, err)
+	}
+	esClient, err := elastic.NewClient(elastic.SetURL(url.String()), elastic.SetSniff(false))
+	if err != nil {
+		return fmt.Errorf("error creating elastic search client: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), autoSetupTimeout)
+	defer cancel()
+	if _, err := esClient.IndexPutTemplate(visibilityIndexTemplateName).BodyString(string(template)).Do(ctx); err != nil {
+		return fmt.Errorf("error putting index template: %v", err)
+	}
+	exists, err := esClient.IndexExists(indexName).Do(ctx)
+	if err != nil {
+		return fmt.Errorf("error checking index %v: %v", indexName, err)
+	}
+	if exists {
+		return nil
+	}
+	if _, err := esClient.CreateIndex(indexName).Do(ctx); err != nil {
+		return fmt.Errorf("error creating index %v: %v", indexName, err)
+	}
+	return nil
+}
+
+// registerDefaultDomain registers the default domain through the frontend,
+// retrying until the frontend is up. An already registered domain is not an error
+func registerDefaultDomain(cfg *config.Config) {
+	dispatcher, err := client.NewDNSYarpcDispatcherProvider(cfg.PeerDiscovery, cfg.Log.NewBarkLogger()).
+		Get(common.FrontendServiceName, cfg.PublicClient.HostPort)
+	if err != nil {
+		log.Fatalf("failed to construct dispatcher: %v", err)
+	}
+	frontendClient := workflowserviceclient.New(dispatcher.ClientConfig(common.FrontendServiceName))
+
+	request := &shared.RegisterDomainRequest{
+		Name:                                   common.StringPtr(defaultDomainName),
+		Description:                            common.StringPtr("default domain registered by auto-setup"),
+		WorkflowExecutionRetentionPeriodInDays: common.Int32Ptr(defaultDomainRetentionDays),
+	}
+	op := func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), autoSetupTimeout)
+		defer cancel()
+		err := frontendClient.RegisterDomain(ctx, request)
+		if _, ok := err.(*shared.DomainAlreadyExistsError); ok {
+			return nil
+		}
+		return err
+	}
+	policy := backoff.NewExponentialRetryPolicy(time.Second)
+	policy.SetMaximumInterval(10 * time.Second)
+	policy.SetExpirationInterval(5 * time.Minute)
+	if err := backoff.Retry(op, policy, func(error) bool { return true }); err != nil {
+		log.Printf("failed to register domain %v: %v\n", defaultDomainName, err)
+		return
+	}
+	log.Printf("domain %v is registered\n", defaultDomainName)
+}
@@ -63,19 +63,33 @@ func startHandler(c *cli.Context) {
 	if err != nil {
 		log.Fatal("Unable to get current directory")
 	}
+	autoSetupEnabled := c.Bool("auto-setup")
+	if autoSetupEnabled {
+		autoSetup(&cfg, dir)
+	}
 	if err := cassandra.VerifyCompatibleVersion(cfg.Persistence, dir); err != nil {
 		log.Fatal("Incompatible versions", err)
 	}
+	verifyClusterMetadata(&cfg)
 
 	services := getServices(c)
+	var allInOne *allInOneResources
+	if c.Bool("all-in-one") {
+		services = validServices
+		allInOne = newAllInOneResources(&cfg)
+	}
 	for _, svc := range services {
 		if _, ok := cfg.Services[svc]; !ok {
 			log.Fatalf("`%v` service missing config", svc)
 		}
-		server := newServer(svc, &cfg)
+		server := newServer(svc, &cfg, allInOne)
 		server.Start()
 	}
 
+	if autoSetupEnabled {
+		go registerDefaultDomain(&cfg)
+	}
+
 	select {}
 }
 
@@ -181,6 +195,14 @@ func buildCLI() *cli.App {
 					Value: strings.Join(validServices, ","),
 					Usage: "list of services to start",
 				},
+				cli.BoolFlag{
+					Name:  "all-in-one",
+					Usage: "run all the services in this process with in memory membership and shared persistence",
+				},
+				cli.BoolFlag{
+					Name:  "auto-setup, dev",
+					Usage: "create keyspaces / databases, apply the latest schema and register the default domain on start",
+				},
 			},
 			Action: func(c *cli.Context) {
 				startHandler(c)
@@ -0,0 +1,102 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/uber/cadence/common/persistence"
+	persistencefactory "github.com/uber/cadence/common/persistence/persistence-factory"
+	"github.com/uber/cadence/common/service/config"
+)
+
+// verifyClusterMetadata persists the cluster metadata of the config on the first start of the
+// cluster and refuses to start when the config doesn't match the persisted metadata afterwards.
+// Clusters added to the config are persisted, remote clusters added at runtime are kept
+func verifyClusterMetadata(cfg *config.Config) {
+	pConfig := cfg.Persistence
+	factory := persistencefactory.New(&pConfig, cfg.ClustersInfo.CurrentClusterName, nil, cfg.Log.NewBarkLogger())
+	defer factory.Close()
+
+	manager, err := factory.NewClusterMetadataManager()
+	if err != nil {
+		log.Fatalf("failed to create cluster metadata manager: %v", err)
+	}
+	defer manager.Close()
+
+	clusterAddresses := make(map[string]persistence.ClusterAddress)
+	for clusterName, address := range cfg.ClustersInfo.ClusterAddress {
+		clusterAddresses[clusterName] = persistence.ClusterAddress{
+			RPCName:    address.RPCName,
+			RPCAddress: address.RPCAddress,
+		}
+	}
+	configured := persistence.ClusterMetadata{
+		ClusterName:              cfg.ClustersInfo.CurrentClusterName,
+		FailoverVersionIncrement: cfg.ClustersInfo.FailoverVersionIncrement,
+		InitialFailoverVersions:  cfg.ClustersInfo.ClusterInitialFailoverVersions,
+		ClusterAddresses:         clusterAddresses,
+		NumHistoryShards:         cfg.Persistence.NumHistoryShards,
+	}
+	resp, err := manager.InitializeClusterMetadata(context.Background(), &persistence.InitializeClusterMetadataRequest{
+		ClusterMetadata: configured,
+	})
+	if err != nil {
+		log.Fatalf("failed to initialize cluster metadata: %v", err)
+	}
+	if resp.Created {
+		return
+	}
+
+	persisted := resp.ClusterMetadata
+	if err := persistence.ValidateClusterMetadata(&persisted, &configured); err != nil {
+		log.Fatalf("cluster metadata validation failed: %v", err)
+	}
+
+	updated := persisted
+	updated.InitialFailoverVersions = make(map[string]int64)
+	updated.ClusterAddresses = make(map[string]persistence.ClusterAddress)
+	for clusterName, version := range persisted.InitialFailoverVersions {
+		updated.InitialFailoverVersions[clusterName] = version
+		updated.ClusterAddresses[clusterName] = persisted.ClusterAddresses[clusterName]
+	}
+	for clusterName, version := range configured.InitialFailoverVersions {
+		if _, ok := updated.InitialFailoverVersions[clusterName]; !ok {
+			updated.InitialFailoverVersions[clusterName] = version
+			updated.ClusterAddresses[clusterName] = configured.ClusterAddresses[clusterName]
+		}
+	}
+	if len(updated.InitialFailoverVersions) == len(persisted.InitialFailoverVersions) {
+		return
+	}
+
+	updated.Version = persisted.Version + 1
+	if err := manager.UpdateClusterMetadata(context.Background(), &persistence.UpdateClusterMetadataRequest{
+		ClusterMetadata: updated,
+		PreviousVersion: persisted.Version,
+	}); err != nil {
+		if _, ok := err.(*persistence.ConditionFailedError); !ok {
+			log.Fatalf("failed to update cluster metadata: %v", err)
+		}
+		// another host updated the metadata concurrently, it is validated on its next start
+	}
+}
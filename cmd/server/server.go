@@ -26,7 +26,9 @@ import (
 
 	"github.com/uber/cadence/client"
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/blobstore"
 	"github.com/uber/cadence/common/blobstore/filestore"
+	"github.com/uber/cadence/common/clock"
 	"github.com/uber/cadence/common/cluster"
 	"github.com/uber/cadence/common/elasticsearch"
 	cadenceLog "github.com/uber/cadence/common/log"
@@ -35,6 +37,7 @@ import (
 	"github.com/uber/cadence/common/service"
 	"github.com/uber/cadence/common/service/config"
 	"github.com/uber/cadence/common/service/dynamicconfig"
+	"github.com/uber/cadence/common/tokenbucket"
 	"github.com/uber/cadence/service/frontend"
 	"github.com/uber/cadence/service/history"
 	"github.com/uber/cadence/service/matching"
@@ -49,6 +52,8 @@ type (
 		cfg    *config.Config
 		doneC  chan struct{}
 		daemon common.Daemon
+		// allInOne is set when all the services run in this process
+		allInOne *allInOneResources
 	}
 )
 
@@ -61,11 +66,12 @@ const (
 
 // newServer returns a new instance of a daemon
 // that represents a cadence service
-func newServer(service string, cfg *config.Config) common.Daemon {
+func newServer(service string, cfg *config.Config, allInOne *allInOneResources) common.Daemon {
 	return &server{
-		cfg:   cfg,
-		name:  service,
-		doneC: make(chan struct{}),
+		cfg:      cfg,
+		name:     service,
+		doneC:    make(chan struct{}),
+		allInOne: allInOne,
 	}
 }
 
@@ -107,9 +113,14 @@ func (s *server) startService() common.Daemon {
 	params.Logger = cadenceLog.NewLogger(s.cfg.Log.NewZapLogger())
 	params.PersistenceConfig = s.cfg.Persistence
 
-	params.MembershipFactory, err = s.cfg.Ringpop.NewFactory(params.BarkLogger, params.Name)
-	if err != nil {
-		log.Fatalf("error creating ringpop factory: %v", err)
+	if s.allInOne != nil {
+		params.MembershipFactory = s.allInOne.membershipFactory(s.cfg, params.Name)
+		params.PersistenceFactory = s.allInOne.persistenceFactory
+	} else {
+		params.MembershipFactory, err = config.NewMembershipFactory(&s.cfg.Membership, &s.cfg.Ringpop, params.BarkLogger, params.Name)
+		if err != nil {
+			log.Fatalf("error creating membership factory: %v", err)
+		}
 	}
 
 	params.DynamicConfig = dynamicconfig.NewNopClient()
@@ -119,6 +130,13 @@ func (s *server) startService() common.Daemon {
 	params.MetricScope = svcCfg.Metrics.NewScope()
 	params.RPCFactory = svcCfg.RPC.NewFactory(params.Name, params.BarkLogger)
 	params.PProfInitializer = svcCfg.PProf.NewInitializer(params.BarkLogger)
+	params.DebugInitializer = svcCfg.Debug.NewInitializer(
+		params.Name,
+		s.cfg,
+		params.DynamicConfig,
+		dc.GetStringProperty(dynamicconfig.AdminOperationToken, "CadenceTeamONLY"),
+		params.BarkLogger,
+	)
 	enableGlobalDomain := dc.GetBoolProperty(dynamicconfig.EnableGlobalDomain, s.cfg.ClustersInfo.EnableGlobalDomain)
 	archivalStatus := dc.GetStringProperty(dynamicconfig.ArchivalStatus, s.cfg.Archival.Status)
 	enableReadFromArchival := dc.GetBoolProperty(dynamicconfig.EnableReadFromArchival, s.cfg.Archival.EnableReadFromArchival)
@@ -139,7 +157,7 @@ func (s *server) startService() common.Daemon {
 		s.cfg.Archival.DefaultBucket,
 		enableReadFromArchival,
 	)
-	params.DispatcherProvider = client.NewIPYarpcDispatcherProvider()
+	params.DispatcherProvider = client.NewDNSYarpcDispatcherProvider(s.cfg.PeerDiscovery, params.BarkLogger)
 	params.ESConfig = &s.cfg.ElasticSearch
 	params.ESConfig.Enable = dc.GetBoolProperty(dynamicconfig.EnableVisibilityToKafka, params.ESConfig.Enable)() // force override with dynamic config
 	if params.ClusterMetadata.IsGlobalDomainEnabled() {
@@ -156,7 +174,7 @@ func (s *server) startService() common.Daemon {
 		if err != nil {
 			log.Fatalf("error creating elastic search client: %v", err)
 		}
-		params.ESClient = esClient
+		params.ESClient = elasticsearch.NewCircuitBreakerClient(esClient, s.cfg.ElasticSearch.CircuitBreaker, params.MetricsClient)
 
 		indexName, ok := params.ESConfig.Indices[common.VisibilityAppName]
 		if !ok || len(indexName) == 0 {
@@ -175,6 +193,9 @@ func (s *server) startService() common.Daemon {
 		if err != nil {
 			log.Fatalf("error creating blobstore: %v", err)
 		}
+		if qps := s.cfg.Archival.Filestore.MaxQPS; qps > 0 {
+			params.BlobstoreClient = blobstore.NewRateLimitedClient(params.BlobstoreClient, tokenbucket.New(qps, clock.NewRealTimeSource()))
+		}
 	}
 
 	params.Logger.Info("Starting service " + s.name)
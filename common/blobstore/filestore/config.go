@@ -30,6 +30,8 @@ type (
 		StoreDirectory string         `yaml:"storeDirectory"`
 		DefaultBucket  BucketConfig   `yaml:"defaultBucket"`
 		CustomBuckets  []BucketConfig `yaml:"customBuckets"`
+		// MaxQPS is the max request rate to this blobstore, it is not limited when zero
+		MaxQPS int `yaml:"maxQPS"`
 	}
 
 	// BucketConfig describes the config for a bucket
@@ -58,6 +60,9 @@ func (c *Config) Validate() error {
 	if len(c.StoreDirectory) == 0 {
 		return errors.New("empty store directory")
 	}
+	if c.MaxQPS < 0 {
+		return errors.New("negative max qps")
+	}
 	if err := validateBucketConfig(c.DefaultBucket); err != nil {
 		return err
 	}
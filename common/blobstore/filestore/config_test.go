@@ -130,6 +130,17 @@ func (s *ConfigSuite) TestValidate() {
 			},
 			isValid: true,
 		},
+		{
+			config: &Config{
+				StoreDirectory: "test-store-directory",
+				DefaultBucket: BucketConfig{
+					Name:  "test-default-bucket-name",
+					Owner: "test-default-bucket-owner",
+				},
+				MaxQPS: -1,
+			},
+			isValid: false,
+		},
 	}
 
 	for _, tc := range testCases {
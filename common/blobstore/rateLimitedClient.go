@@ -0,0 +1,115 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package blobstore
+
+import (
+	"context"
+
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common/backoff"
+	"github.com/uber/cadence/common/blobstore/blob"
+	"github.com/uber/cadence/common/tokenbucket"
+)
+
+var (
+	// ErrBlobstoreLimitExceeded is the error indicating the max qps of the blobstore is reached
+	ErrBlobstoreLimitExceeded = &shared.ServiceBusyError{Message: "Blobstore Max QPS Reached."}
+)
+
+var _ Client = (*rateLimitedClient)(nil)
+
+type rateLimitedClient struct {
+	client      Client
+	rateLimiter tokenbucket.TokenBucket
+}
+
+// NewRateLimitedClient creates a new instance of Client which fails the requests exceeding the rate limit
+// with ErrBlobstoreLimitExceeded, the error is retryable
+func NewRateLimitedClient(client Client, rateLimiter tokenbucket.TokenBucket) Client {
+	return &rateLimitedClient{
+		client:      client,
+		rateLimiter: rateLimiter,
+	}
+}
+
+func (c *rateLimitedClient) Upload(ctx context.Context, bucket string, key blob.Key, blob *blob.Blob) error {
+	if ok, _ := c.rateLimiter.TryConsume(1); !ok {
+		return ErrBlobstoreLimitExceeded
+	}
+	return c.client.Upload(ctx, bucket, key, blob)
+}
+
+func (c *rateLimitedClient) Download(ctx context.Context, bucket string, key blob.Key) (*blob.Blob, error) {
+	if ok, _ := c.rateLimiter.TryConsume(1); !ok {
+		return nil, ErrBlobstoreLimitExceeded
+	}
+	return c.client.Download(ctx, bucket, key)
+}
+
+func (c *rateLimitedClient) GetTags(ctx context.Context, bucket string, key blob.Key) (map[string]string, error) {
+	if ok, _ := c.rateLimiter.TryConsume(1); !ok {
+		return nil, ErrBlobstoreLimitExceeded
+	}
+	return c.client.GetTags(ctx, bucket, key)
+}
+
+func (c *rateLimitedClient) Exists(ctx context.Context, bucket string, key blob.Key) (bool, error) {
+	if ok, _ := c.rateLimiter.TryConsume(1); !ok {
+		return false, ErrBlobstoreLimitExceeded
+	}
+	return c.client.Exists(ctx, bucket, key)
+}
+
+func (c *rateLimitedClient) Delete(ctx context.Context, bucket string, key blob.Key) (bool, error) {
+	if ok, _ := c.rateLimiter.TryConsume(1); !ok {
+		return false, ErrBlobstoreLimitExceeded
+	}
+	return c.client.Delete(ctx, bucket, key)
+}
+
+func (c *rateLimitedClient) ListByPrefix(ctx context.Context, bucket string, prefix string) ([]blob.Key, error) {
+	if ok, _ := c.rateLimiter.TryConsume(1); !ok {
+		return nil, ErrBlobstoreLimitExceeded
+	}
+	return c.client.ListByPrefix(ctx, bucket, prefix)
+}
+
+func (c *rateLimitedClient) BucketMetadata(ctx context.Context, bucket string) (*BucketMetadataResponse, error) {
+	if ok, _ := c.rateLimiter.TryConsume(1); !ok {
+		return nil, ErrBlobstoreLimitExceeded
+	}
+	return c.client.BucketMetadata(ctx, bucket)
+}
+
+func (c *rateLimitedClient) BucketExists(ctx context.Context, bucket string) (bool, error) {
+	if ok, _ := c.rateLimiter.TryConsume(1); !ok {
+		return false, ErrBlobstoreLimitExceeded
+	}
+	return c.client.BucketExists(ctx, bucket)
+}
+
+func (c *rateLimitedClient) IsRetryableError(err error) bool {
+	return err == ErrBlobstoreLimitExceeded || c.client.IsRetryableError(err)
+}
+
+func (c *rateLimitedClient) GetRetryPolicy() backoff.RetryPolicy {
+	return c.client.GetRetryPolicy()
+}
@@ -66,6 +66,22 @@ type Options struct {
 	// RemovedFunc is an optional function called when an element
 	// is scheduled for deletion
 	RemovedFunc RemovedFunc
+
+	// MaxBytes optionally bounds the total size of the values implementing
+	// Sizeable, least recently used elements are evicted when it is exceeded.
+	// It is read on every insert and release so it can change dynamically
+	MaxBytes func() int
+
+	// EvictedFunc is an optional function called when an element is evicted
+	// to make room for other elements. It is called with the cache lock held,
+	// so it must be fast and must not access the cache
+	EvictedFunc RemovedFunc
+}
+
+// Sizeable is implemented by values which can report their size in bytes,
+// the size is refreshed whenever the element is inserted or released
+type Sizeable interface {
+	ByteSize() int
 }
 
 // RemovedFunc is a type for notifying applications when an item is
@@ -21,6 +21,7 @@
 package cache
 
 import (
+	"context"
 	"fmt"
 	"hash/fnv"
 	"sort"
@@ -95,6 +96,11 @@ type (
 		callbackLock     sync.Mutex
 		prepareCallbacks map[int]PrepareCallbackFn
 		callbacks        map[int]CallbackFn
+
+		// notification version of the metadata record as of the last refresh, all domain changes
+		// with a lower notification version are already loaded into the cache
+		refreshedNotificationVersion int64
+		changeNotifications          <-chan int64
 	}
 
 	// DomainCacheEntries is DomainCacheEntry slice
@@ -118,6 +124,13 @@ type (
 
 // NewDomainCache creates a new instance of cache for holding onto domain information to reduce the load on persistence
 func NewDomainCache(metadataMgr persistence.MetadataManager, clusterMetadata cluster.Metadata, metricsClient metrics.Client, logger bark.Logger) DomainCache {
+	return NewDomainCacheWithChangeNotifier(metadataMgr, clusterMetadata, metricsClient, logger, nil)
+}
+
+// NewDomainCacheWithChangeNotifier creates a new instance of domain cache which, on top of the periodic refresh,
+// refreshes as soon as the notifier delivers a domain change. A nil notifier falls back to the periodic refresh only.
+func NewDomainCacheWithChangeNotifier(metadataMgr persistence.MetadataManager, clusterMetadata cluster.Metadata,
+	metricsClient metrics.Client, logger bark.Logger, notifier DomainChangeNotifier) DomainCache {
 	cache := &domainCache{
 		status:           domainCacheInitialized,
 		shutdownChan:     make(chan struct{}),
@@ -133,6 +146,9 @@ func NewDomainCache(metadataMgr persistence.MetadataManager, clusterMetadata clu
 	}
 	cache.cacheNameToID.Store(newDomainCache())
 	cache.cacheByID.Store(newDomainCache())
+	if notifier != nil {
+		cache.changeNotifications = notifier.Notifications()
+	}
 
 	return cache
 }
@@ -291,6 +307,15 @@ func (c *domainCache) refreshLoop() {
 			if err != nil {
 				c.logger.Errorf("Error refreshing domain cache: %v", err)
 			}
+		case notificationVersion := <-c.changeNotifications:
+			if notificationVersion < atomic.LoadInt64(&c.refreshedNotificationVersion) {
+				// change is already loaded
+				continue
+			}
+			err := c.refreshDomains()
+			if err != nil {
+				c.logger.Errorf("Error refreshing domain cache on domain change: %v", err)
+			}
 		}
 	}
 }
@@ -300,7 +325,7 @@ func (c *domainCache) refreshLoop() {
 func (c *domainCache) refreshDomains() error {
 	// first load the metadata record, then load domains
 	// this can guarantee that domains in the cache are not updated more than metadata record
-	metadata, err := c.metadataMgr.GetMetadata()
+	metadata, err := c.metadataMgr.GetMetadata(context.TODO())
 	if err != nil {
 		return err
 	}
@@ -313,7 +338,7 @@ func (c *domainCache) refreshDomains() error {
 
 	for continuePage {
 		request.NextPageToken = token
-		response, err := c.metadataMgr.ListDomains(request)
+		response, err := c.metadataMgr.ListDomains(context.TODO(), request)
 		if err != nil {
 			return err
 		}
@@ -370,11 +395,12 @@ UpdateLoop:
 	c.cacheByID.Store(newCacheByID)
 	c.cacheNameToID.Store(newCacheNameToID)
 	c.triggerDomainChangeCallbackLocked(prevEntries, nextEntries)
+	atomic.StoreInt64(&c.refreshedNotificationVersion, domainNotificationVersion)
 	return nil
 }
 
 func (c *domainCache) loadDomain(name string, id string) (*persistence.GetDomainResponse, error) {
-	resp, err := c.metadataMgr.GetDomain(&persistence.GetDomainRequest{Name: name, ID: id})
+	resp, err := c.metadataMgr.GetDomain(context.TODO(), &persistence.GetDomainRequest{Name: name, ID: id})
 	if err == nil {
 		if resp.TableVersion == persistence.DomainTableVersionV1 {
 			// if loaded from V1 table
@@ -554,10 +580,11 @@ func (entry *DomainCacheEntry) duplicate() *DomainCacheEntry {
 		result.info.Data[k] = v
 	}
 	result.config = &persistence.DomainConfig{
-		Retention:      entry.config.Retention,
-		EmitMetric:     entry.config.EmitMetric,
-		ArchivalBucket: entry.config.ArchivalBucket,
-		ArchivalStatus: entry.config.ArchivalStatus,
+		Retention:            entry.config.Retention,
+		EmitMetric:           entry.config.EmitMetric,
+		ArchivalBucket:       entry.config.ArchivalBucket,
+		ArchivalStatus:       entry.config.ArchivalStatus,
+		ArchivalTargetBucket: entry.config.ArchivalTargetBucket,
 	}
 	result.replicationConfig = &persistence.DomainReplicationConfig{
 		ActiveClusterName: entry.replicationConfig.ActiveClusterName,
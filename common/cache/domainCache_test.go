@@ -28,6 +28,7 @@ import (
 
 	"github.com/pborman/uuid"
 	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"github.com/uber-common/bark"
@@ -47,6 +48,10 @@ type (
 		metadataMgr     *mocks.MetadataManager
 		domainCache     *domainCache
 	}
+
+	testDomainChangeNotifier struct {
+		notifications chan int64
+	}
 )
 
 func TestDomainCacheSuite(t *testing.T) {
@@ -134,9 +139,9 @@ func (s *domainCacheSuite) TestListDomain() {
 
 	pageToken := []byte("some random page token")
 
-	s.metadataMgr.On("GetMetadata").Return(&persistence.GetMetadataResponse{NotificationVersion: domainNotificationVersion}, nil)
+	s.metadataMgr.On("GetMetadata", mock.Anything).Return(&persistence.GetMetadataResponse{NotificationVersion: domainNotificationVersion}, nil)
 	s.clusterMetadata.On("IsGlobalDomainEnabled").Return(true)
-	s.metadataMgr.On("ListDomains", &persistence.ListDomainsRequest{
+	s.metadataMgr.On("ListDomains", mock.Anything, &persistence.ListDomainsRequest{
 		PageSize:      domainCacheRefreshPageSize,
 		NextPageToken: nil,
 	}).Return(&persistence.ListDomainsResponse{
@@ -144,7 +149,7 @@ func (s *domainCacheSuite) TestListDomain() {
 		NextPageToken: pageToken,
 	}, nil).Once()
 
-	s.metadataMgr.On("ListDomains", &persistence.ListDomainsRequest{
+	s.metadataMgr.On("ListDomains", mock.Anything, &persistence.ListDomainsRequest{
 		PageSize:      domainCacheRefreshPageSize,
 		NextPageToken: pageToken,
 	}).Return(&persistence.ListDomainsResponse{
@@ -196,7 +201,7 @@ func (s *domainCacheSuite) TestGetDomain_NonLoaded_GetByName() {
 	}
 	entry := s.buildEntryFromRecord(domainRecord)
 
-	s.metadataMgr.On("GetDomain", &persistence.GetDomainRequest{Name: entry.info.Name}).Return(domainRecord, nil).Once()
+	s.metadataMgr.On("GetDomain", mock.Anything, &persistence.GetDomainRequest{Name: entry.info.Name}).Return(domainRecord, nil).Once()
 
 	entryByName, err := s.domainCache.GetDomain(domainRecord.Info.Name)
 	s.Nil(err)
@@ -222,7 +227,7 @@ func (s *domainCacheSuite) TestGetDomain_NonLoaded_GetByID() {
 	}
 	entry := s.buildEntryFromRecord(domainRecord)
 
-	s.metadataMgr.On("GetDomain", &persistence.GetDomainRequest{ID: entry.info.ID}).Return(domainRecord, nil).Once()
+	s.metadataMgr.On("GetDomain", mock.Anything, &persistence.GetDomainRequest{ID: entry.info.ID}).Return(domainRecord, nil).Once()
 
 	entryByID, err := s.domainCache.GetDomainByID(domainRecord.Info.ID)
 	s.Nil(err)
@@ -270,9 +275,9 @@ func (s *domainCacheSuite) TestRegisterCallback_CatchUp() {
 	entry2 := s.buildEntryFromRecord(domainRecord2)
 	domainNotificationVersion++
 
-	s.metadataMgr.On("GetMetadata").Return(&persistence.GetMetadataResponse{NotificationVersion: domainNotificationVersion}, nil).Once()
+	s.metadataMgr.On("GetMetadata", mock.Anything).Return(&persistence.GetMetadataResponse{NotificationVersion: domainNotificationVersion}, nil).Once()
 	s.clusterMetadata.On("IsGlobalDomainEnabled").Return(true)
-	s.metadataMgr.On("ListDomains", &persistence.ListDomainsRequest{
+	s.metadataMgr.On("ListDomains", mock.Anything, &persistence.ListDomainsRequest{
 		PageSize:      domainCacheRefreshPageSize,
 		NextPageToken: nil,
 	}).Return(&persistence.ListDomainsResponse{
@@ -348,9 +353,9 @@ func (s *domainCacheSuite) TestUpdateCache_ListTrigger() {
 	entry2Old := s.buildEntryFromRecord(domainRecord2Old)
 	domainNotificationVersion++
 
-	s.metadataMgr.On("GetMetadata").Return(&persistence.GetMetadataResponse{NotificationVersion: domainNotificationVersion}, nil).Once()
+	s.metadataMgr.On("GetMetadata", mock.Anything).Return(&persistence.GetMetadataResponse{NotificationVersion: domainNotificationVersion}, nil).Once()
 	s.clusterMetadata.On("IsGlobalDomainEnabled").Return(true)
-	s.metadataMgr.On("ListDomains", &persistence.ListDomainsRequest{
+	s.metadataMgr.On("ListDomains", mock.Anything, &persistence.ListDomainsRequest{
 		PageSize:      domainCacheRefreshPageSize,
 		NextPageToken: nil,
 	}).Return(&persistence.ListDomainsResponse{
@@ -417,8 +422,8 @@ func (s *domainCacheSuite) TestUpdateCache_ListTrigger() {
 	s.Empty(entriesOld)
 	s.Empty(entriesNew)
 
-	s.metadataMgr.On("GetMetadata").Return(&persistence.GetMetadataResponse{NotificationVersion: domainNotificationVersion}, nil).Once()
-	s.metadataMgr.On("ListDomains", &persistence.ListDomainsRequest{
+	s.metadataMgr.On("GetMetadata", mock.Anything).Return(&persistence.GetMetadataResponse{NotificationVersion: domainNotificationVersion}, nil).Once()
+	s.metadataMgr.On("ListDomains", mock.Anything, &persistence.ListDomainsRequest{
 		PageSize:      domainCacheRefreshPageSize,
 		NextPageToken: nil,
 	}).Return(&persistence.ListDomainsResponse{
@@ -473,7 +478,7 @@ func (s *domainCacheSuite) TestUpdateCache_GetNotTrigger() {
 	}
 	entryNew := s.buildEntryFromRecord(domainRecordNew)
 
-	s.metadataMgr.On("GetDomain", &persistence.GetDomainRequest{ID: entryOld.info.ID}).Return(domainRecordOld, nil).Once()
+	s.metadataMgr.On("GetDomain", mock.Anything, &persistence.GetDomainRequest{ID: entryOld.info.ID}).Return(domainRecordOld, nil).Once()
 	entry, err := s.domainCache.GetDomainByID(entryOld.info.ID)
 	s.Nil(err)
 	s.Equal(entryOld, s.clearExpiry(entry))
@@ -501,6 +506,101 @@ func (s *domainCacheSuite) TestUpdateCache_GetNotTrigger() {
 	s.False(callbackInvoked)
 }
 
+func (s *domainCacheSuite) TestUpdateCache_ChangeNotificationTrigger() {
+	notifier := &testDomainChangeNotifier{notifications: make(chan int64)}
+	metricsClient := metrics.NewClient(tally.NoopScope, metrics.History)
+	s.domainCache = NewDomainCacheWithChangeNotifier(s.metadataMgr, s.clusterMetadata, metricsClient, s.logger, notifier).(*domainCache)
+
+	domainNotificationVersion := int64(0)
+	domainRecordOld := &persistence.GetDomainResponse{
+		Info:   &persistence.DomainInfo{ID: uuid.New(), Name: "some random domain name", Data: make(map[string]string)},
+		Config: &persistence.DomainConfig{Retention: 1},
+		ReplicationConfig: &persistence.DomainReplicationConfig{
+			ActiveClusterName: cluster.TestCurrentClusterName,
+			Clusters: []*persistence.ClusterReplicationConfig{
+				&persistence.ClusterReplicationConfig{ClusterName: cluster.TestCurrentClusterName},
+				&persistence.ClusterReplicationConfig{ClusterName: cluster.TestAlternativeClusterName},
+			},
+		},
+		ConfigVersion:               10,
+		FailoverVersion:             11,
+		FailoverNotificationVersion: 0,
+		NotificationVersion:         domainNotificationVersion,
+	}
+	domainNotificationVersion++
+
+	s.metadataMgr.On("GetMetadata", mock.Anything).Return(&persistence.GetMetadataResponse{NotificationVersion: domainNotificationVersion}, nil).Once()
+	s.clusterMetadata.On("IsGlobalDomainEnabled").Return(true)
+	s.metadataMgr.On("ListDomains", mock.Anything, &persistence.ListDomainsRequest{
+		PageSize:      domainCacheRefreshPageSize,
+		NextPageToken: nil,
+	}).Return(&persistence.ListDomainsResponse{
+		Domains:       []*persistence.GetDomainResponse{domainRecordOld},
+		NextPageToken: nil,
+	}, nil).Once()
+
+	// load domains
+	s.domainCache.Start()
+	defer s.domainCache.Stop()
+
+	domainRecordNew := &persistence.GetDomainResponse{
+		Info:   &*domainRecordOld.Info,
+		Config: &*domainRecordOld.Config,
+		ReplicationConfig: &persistence.DomainReplicationConfig{
+			ActiveClusterName: cluster.TestAlternativeClusterName,
+			Clusters: []*persistence.ClusterReplicationConfig{
+				&persistence.ClusterReplicationConfig{ClusterName: cluster.TestCurrentClusterName},
+				&persistence.ClusterReplicationConfig{ClusterName: cluster.TestAlternativeClusterName},
+			},
+		},
+		ConfigVersion:               domainRecordOld.ConfigVersion,
+		FailoverVersion:             domainRecordOld.FailoverVersion + 1,
+		FailoverNotificationVersion: domainNotificationVersion,
+		NotificationVersion:         domainNotificationVersion,
+	}
+	entryNew := s.buildEntryFromRecord(domainRecordNew)
+	domainNotificationVersion++
+
+	s.metadataMgr.On("GetMetadata", mock.Anything).Return(&persistence.GetMetadataResponse{NotificationVersion: domainNotificationVersion}, nil).Once()
+	s.metadataMgr.On("ListDomains", mock.Anything, &persistence.ListDomainsRequest{
+		PageSize:      domainCacheRefreshPageSize,
+		NextPageToken: nil,
+	}).Return(&persistence.ListDomainsResponse{
+		Domains:       []*persistence.GetDomainResponse{domainRecordNew},
+		NextPageToken: nil,
+	}, nil).Once()
+
+	entriesNotification := make(chan []*DomainCacheEntry, 1)
+	// we are not testing catching up, so make this really large
+	currentDomainNotificationVersion := int64(9999999)
+	s.domainCache.RegisterDomainChangeCallback(
+		0,
+		currentDomainNotificationVersion,
+		func() {},
+		func(prevDomains []*DomainCacheEntry, nextDomains []*DomainCacheEntry) {
+			entriesNotification <- nextDomains
+		},
+	)
+
+	// change which is already loaded into the cache should not trigger a refresh
+	notifier.notifications <- domainRecordOld.NotificationVersion
+	notifier.notifications <- domainRecordNew.NotificationVersion
+
+	select {
+	case nextDomains := <-entriesNotification:
+		for _, domain := range nextDomains {
+			s.clearExpiry(domain)
+		}
+		s.Equal([]*DomainCacheEntry{entryNew}, nextDomains)
+	case <-time.After(DomainCacheRefreshInterval / 2):
+		s.Fail("domain change notification should trigger domain cache refresh")
+	}
+
+	entry, err := s.domainCache.GetDomainByID(domainRecordNew.Info.ID)
+	s.Nil(err)
+	s.Equal(entryNew, s.clearExpiry(entry))
+}
+
 func (s *domainCacheSuite) TestGetUpdateCache_ConcurrentAccess() {
 	s.clusterMetadata.On("IsGlobalDomainEnabled").Return(true)
 	id := uuid.New()
@@ -520,7 +620,7 @@ func (s *domainCacheSuite) TestGetUpdateCache_ConcurrentAccess() {
 	}
 	entryOld := s.buildEntryFromRecord(domainRecordOld)
 
-	s.metadataMgr.On("GetDomain", &persistence.GetDomainRequest{ID: id}).Return(domainRecordOld, nil).Once()
+	s.metadataMgr.On("GetDomain", mock.Anything, &persistence.GetDomainRequest{ID: id}).Return(domainRecordOld, nil).Once()
 	s.domainCache.GetDomainByID(id)
 
 	coroutineCountGet := 100
@@ -583,6 +683,18 @@ func (s *domainCacheSuite) clearExpiry(entry *DomainCacheEntry) *DomainCacheEntr
 	return entry
 }
 
+func (n *testDomainChangeNotifier) Start() {}
+
+func (n *testDomainChangeNotifier) Stop() {}
+
+func (n *testDomainChangeNotifier) Notify(domainID string, notificationVersion int64) error {
+	return nil
+}
+
+func (n *testDomainChangeNotifier) Notifications() <-chan int64 {
+	return n.notifications
+}
+
 func Test_GetRetentionDays(t *testing.T) {
 	d := &DomainCacheEntry{
 		info: &persistence.DomainInfo{
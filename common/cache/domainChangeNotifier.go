@@ -0,0 +1,173 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cache
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/codec/gob"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/messaging"
+)
+
+const (
+	domainChangeNotifierInitialized int32 = 0
+	domainChangeNotifierStarted     int32 = 1
+	domainChangeNotifierStopped     int32 = 2
+)
+
+type (
+	// DomainChangeNotifier broadcasts domain changes to all hosts, so domain caches can pick up
+	// a change, e.g. a domain failover, right away instead of waiting for the next refresh interval
+	DomainChangeNotifier interface {
+		common.Daemon
+		// Notify publishes that the domain was changed with the given notification version
+		Notify(domainID string, notificationVersion int64) error
+		// Notifications returns the channel on which the notification versions of changes published by any host are delivered
+		Notifications() <-chan int64
+	}
+
+	kafkaDomainChangeNotifier struct {
+		status        int32
+		producer      messaging.Producer
+		consumer      messaging.Consumer
+		encoder       *gob.Encoder
+		notifications chan int64
+		shutdownChan  chan struct{}
+		shutdownWG    sync.WaitGroup
+		logger        bark.Logger
+	}
+)
+
+var _ DomainChangeNotifier = (*kafkaDomainChangeNotifier)(nil)
+
+var errMessagingClientNotSet = errors.New("messaging client is not configured")
+
+// NewKafkaDomainChangeNotifier creates a domain change notifier on top of the kafka topic of the domain change application,
+// consumerName must be unique per host so every host receives all the notifications
+func NewKafkaDomainChangeNotifier(client messaging.Client, consumerName string, logger bark.Logger) (DomainChangeNotifier, error) {
+	if client == nil {
+		return nil, errMessagingClientNotSet
+	}
+
+	producer, err := client.NewProducer(common.DomainChangeAppName)
+	if err != nil {
+		return nil, err
+	}
+	consumer, err := client.NewConsumer(common.DomainChangeAppName, consumerName, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return &kafkaDomainChangeNotifier{
+		status:   domainChangeNotifierInitialized,
+		producer: producer,
+		consumer: consumer,
+		encoder:  gob.NewGobEncoder(),
+		// a single pending notification is enough since every refresh loads all the changes
+		notifications: make(chan int64, 1),
+		shutdownChan:  make(chan struct{}),
+		logger: logger.WithFields(bark.Fields{
+			logging.TagWorkflowComponent: logging.TagValueDomainChangeNotifierComponent,
+		}),
+	}, nil
+}
+
+// Start starts consuming the domain change notifications
+func (n *kafkaDomainChangeNotifier) Start() {
+	if !atomic.CompareAndSwapInt32(&n.status, domainChangeNotifierInitialized, domainChangeNotifierStarted) {
+		return
+	}
+
+	if err := n.consumer.Start(); err != nil {
+		// domain caches still pick up the changes on their periodic refresh
+		n.logger.WithField(logging.TagErr, err).Error("Failed to start domain change consumer")
+		return
+	}
+
+	n.shutdownWG.Add(1)
+	go n.processLoop()
+	n.logger.Info("Domain change notifier started")
+}
+
+// Stop stops the domain change notifier
+func (n *kafkaDomainChangeNotifier) Stop() {
+	if !atomic.CompareAndSwapInt32(&n.status, domainChangeNotifierStarted, domainChangeNotifierStopped) {
+		return
+	}
+
+	close(n.shutdownChan)
+	n.consumer.Stop()
+	n.shutdownWG.Wait()
+	if err := n.producer.Close(); err != nil {
+		n.logger.WithField(logging.TagErr, err).Warn("Failed to close domain change producer")
+	}
+	n.logger.Info("Domain change notifier stopped")
+}
+
+// Notify publishes the domain change to all hosts
+func (n *kafkaDomainChangeNotifier) Notify(domainID string, notificationVersion int64) error {
+	return n.producer.Publish(&messaging.DomainChangeNotification{
+		DomainID:            domainID,
+		NotificationVersion: notificationVersion,
+	})
+}
+
+// Notifications returns the channel of the notification versions of published domain changes
+func (n *kafkaDomainChangeNotifier) Notifications() <-chan int64 {
+	return n.notifications
+}
+
+func (n *kafkaDomainChangeNotifier) processLoop() {
+	defer n.shutdownWG.Done()
+
+	for {
+		select {
+		case <-n.shutdownChan:
+			return
+		case msg, ok := <-n.consumer.Messages():
+			if !ok {
+				return
+			}
+			n.process(msg)
+		}
+	}
+}
+
+func (n *kafkaDomainChangeNotifier) process(msg messaging.Message) {
+	var notification messaging.DomainChangeNotification
+	if err := n.encoder.Decode(msg.Value(), &notification); err != nil {
+		n.logger.WithField(logging.TagErr, err).Error("Failed to decode domain change notification")
+		msg.Nack()
+		return
+	}
+	msg.Ack()
+
+	select {
+	case n.notifications <- notification.NotificationVersion:
+	default:
+		// a refresh is already pending, which will load this change as well
+	}
+}
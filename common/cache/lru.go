@@ -42,6 +42,9 @@ type (
 		ttl      time.Duration
 		pin      bool
 		rmFunc   RemovedFunc
+		maxBytes func() int
+		bytes    int
+		evicted  RemovedFunc
 	}
 
 	iteratorImpl struct {
@@ -55,6 +58,7 @@ type (
 		createTime time.Time
 		value      interface{}
 		refCount   int
+		size       int
 	}
 )
 
@@ -138,6 +142,8 @@ func New(maxSize int, opts *Options) Cache {
 		maxSize:  maxSize,
 		pin:      opts.Pin,
 		rmFunc:   opts.RemovedFunc,
+		maxBytes: opts.MaxBytes,
+		evicted:  opts.EvictedFunc,
 	}
 }
 
@@ -226,6 +232,9 @@ func (c *lru) Release(key interface{}) {
 	}
 	entry := elt.Value.(*entryImpl)
 	entry.refCount--
+	// the value may have grown while it was in use
+	c.updateSize(entry)
+	c.evictOverBytes()
 }
 
 // Size returns the number of entries currently in the lru, useful if cache is not full
@@ -279,6 +288,7 @@ func (c *lru) putInternal(key interface{}, value interface{}, allowUpdate bool)
 	}
 
 	c.byKey[key] = c.byAccess.PushFront(entry)
+	c.updateSize(entry)
 	if len(c.byKey) == c.maxSize {
 		oldest := c.byAccess.Back().Value.(*entryImpl)
 
@@ -289,8 +299,9 @@ func (c *lru) putInternal(key interface{}, value interface{}, allowUpdate bool)
 			return nil, ErrCacheFull
 		}
 
-		c.deleteInternal(c.byAccess.Back())
+		c.evictInternal(c.byAccess.Back())
 	}
+	c.evictOverBytes()
 
 	return nil, nil
 }
@@ -300,9 +311,46 @@ func (c *lru) deleteInternal(element *list.Element) {
 	if c.rmFunc != nil {
 		go c.rmFunc(entry.value)
 	}
+	c.bytes -= entry.size
 	delete(c.byKey, entry.key)
 }
 
+func (c *lru) evictInternal(element *list.Element) {
+	entry := element.Value.(*entryImpl)
+	c.deleteInternal(element)
+	if c.evicted != nil {
+		c.evicted(entry.value)
+	}
+}
+
+func (c *lru) updateSize(entry *entryImpl) {
+	if c.maxBytes == nil {
+		return
+	}
+	if sizeable, ok := entry.value.(Sizeable); ok {
+		size := sizeable.ByteSize()
+		c.bytes += size - entry.size
+		entry.size = size
+	}
+}
+
+// evictOverBytes evicts the least recently used elements which are not pinned
+// until the total size is within the max bytes
+func (c *lru) evictOverBytes() {
+	if c.maxBytes == nil {
+		return
+	}
+	maxBytes := c.maxBytes()
+	element := c.byAccess.Back()
+	for c.bytes > maxBytes && element != nil {
+		prev := element.Prev()
+		if element.Value.(*entryImpl).refCount == 0 {
+			c.evictInternal(element)
+		}
+		element = prev
+	}
+}
+
 func (c *lru) isEntryExpired(entry *entryImpl, currentTime time.Time) bool {
 	return entry.refCount == 0 && !entry.createTime.IsZero() && currentTime.After(entry.createTime.Add(c.ttl))
 }
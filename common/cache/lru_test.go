@@ -263,3 +263,60 @@ func TestIterator(t *testing.T) {
 	it.Close()
 	assert.Equal(t, expected, actual)
 }
+
+type sizeableValue struct {
+	size int
+}
+
+func (v *sizeableValue) ByteSize() int {
+	return v.size
+}
+
+func TestLRUWithMaxBytes(t *testing.T) {
+	var evicted []interface{}
+	cache := New(0, &Options{
+		MaxBytes: func() int { return 10 },
+		EvictedFunc: func(i interface{}) {
+			evicted = append(evicted, i)
+		},
+	})
+
+	a := &sizeableValue{size: 4}
+	b := &sizeableValue{size: 4}
+	cache.Put("A", a)
+	cache.Put("B", b)
+	assert.Equal(t, 2, cache.Size())
+	assert.Empty(t, evicted)
+
+	// A is the least recently used and gets evicted
+	cache.Put("C", &sizeableValue{size: 4})
+	assert.Nil(t, cache.Get("A"))
+	assert.Equal(t, b, cache.Get("B"))
+	assert.Equal(t, []interface{}{a}, evicted)
+
+	// values which do not report a size are not counted
+	cache.Put("D", "Delt")
+	assert.Equal(t, 3, cache.Size())
+}
+
+func TestLRUWithMaxBytes_Pin(t *testing.T) {
+	cache := New(0, &Options{
+		Pin:      true,
+		MaxBytes: func() int { return 10 },
+	})
+
+	a := &sizeableValue{size: 4}
+	b := &sizeableValue{size: 4}
+	_, err := cache.PutIfNotExist("B", b)
+	assert.NoError(t, err)
+	cache.Release("B")
+	_, err = cache.PutIfNotExist("A", a)
+	assert.NoError(t, err)
+
+	// A grows while pinned, B is evicted once A is released
+	a.size = 8
+	assert.Equal(t, 2, cache.Size())
+	cache.Release("A")
+	assert.Nil(t, cache.Get("B"))
+	assert.Equal(t, a, cache.Get("A"))
+}
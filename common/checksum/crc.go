@@ -0,0 +1,82 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package checksum
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+)
+
+type (
+	// Flavor is the algorithm a checksum was computed with
+	Flavor int
+
+	// Checksum is the checksum of a payload, along with the version of the payload layout
+	// and the algorithm it was computed with
+	Checksum struct {
+		Version int
+		Flavor  Flavor
+		Value   []byte
+	}
+)
+
+const (
+	// FlavorUnknown means no checksum was computed
+	FlavorUnknown Flavor = iota
+	// FlavorIEEECRC32OverBinary is the IEEE CRC32 of the binary payload
+	FlavorIEEECRC32OverBinary
+)
+
+// ErrMismatch is returned when the checksum of the payload doesn't match the expected one
+var ErrMismatch = errors.New("checksum mismatch")
+
+// IsEmpty returns true if no checksum was computed
+func (c Checksum) IsEmpty() bool {
+	return c.Flavor == FlavorUnknown
+}
+
+// GenerateCRC32 returns the IEEE CRC32 checksum of the payload, version is the version of the payload layout
+func GenerateCRC32(payload []byte, version int) Checksum {
+	value := make([]byte, 4)
+	binary.BigEndian.PutUint32(value, crc32.ChecksumIEEE(payload))
+	return Checksum{
+		Version: version,
+		Flavor:  FlavorIEEECRC32OverBinary,
+		Value:   value,
+	}
+}
+
+// Verify checks the payload against the expected checksum, the checksum must be of the version of the payload layout
+func Verify(payload []byte, expected Checksum) error {
+	switch expected.Flavor {
+	case FlavorIEEECRC32OverBinary:
+		actual := GenerateCRC32(payload, expected.Version)
+		if !bytes.Equal(actual.Value, expected.Value) {
+			return ErrMismatch
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown checksum flavor %v", expected.Flavor)
+	}
+}
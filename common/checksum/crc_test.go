@@ -0,0 +1,57 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package checksum
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type (
+	crcSuite struct {
+		suite.Suite
+	}
+)
+
+func TestCRCSuite(t *testing.T) {
+	s := new(crcSuite)
+	suite.Run(t, s)
+}
+
+func (s *crcSuite) TestGenerateAndVerify() {
+	payload := []byte("mutable state payload")
+	csum := GenerateCRC32(payload, 1)
+	s.Equal(1, csum.Version)
+	s.Equal(FlavorIEEECRC32OverBinary, csum.Flavor)
+	s.Len(csum.Value, 4)
+	s.False(csum.IsEmpty())
+	s.NoError(Verify(payload, csum))
+
+	corrupted := append([]byte{}, payload...)
+	corrupted[0] ^= 0xFF
+	s.Equal(ErrMismatch, Verify(corrupted, csum))
+}
+
+func (s *crcSuite) TestVerify_UnknownFlavor() {
+	s.True(Checksum{}.IsEmpty())
+	s.Error(Verify([]byte("payload"), Checksum{}))
+}
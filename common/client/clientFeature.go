@@ -21,6 +21,7 @@
 package client
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 )
@@ -71,7 +72,21 @@ func NewFeatureImpl(libVersion string, featureVersion string, lang string) *Feat
 
 // SupportStickyQuery whether a client support sticky query
 func (feature *FeatureImpl) SupportStickyQuery() bool {
-	return feature.featureVersion.major > 0
+	return feature.featureVersion.atLeast(featureVersions[FeatureStickyQuery])
+}
+
+func (v version) atLeast(other version) bool {
+	if v.major != other.major {
+		return v.major > other.major
+	}
+	if v.minor != other.minor {
+		return v.minor > other.minor
+	}
+	return v.patch >= other.patch
+}
+
+func (v version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.major, v.minor, v.patch)
 }
 
 func parseVersion(versionStr string) version {
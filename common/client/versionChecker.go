@@ -0,0 +1,124 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+	"go.uber.org/yarpc"
+)
+
+const (
+	// GoSDK is the value of the client impl header sent by the go client
+	GoSDK = "uber-go"
+	// JavaSDK is the value of the client impl header sent by the java client
+	JavaSDK = "uber-java"
+
+	// FeatureStickyQuery is the capability of answering queries from the sticky task list
+	FeatureStickyQuery = "stickyQuery"
+)
+
+var (
+	// defaultMinSupportedFeatureVersions is the lowest feature version of each known client the server works
+	// with, it is used when no minimum is configured in dynamic config
+	defaultMinSupportedFeatureVersions = map[string]version{
+		GoSDK:   {1, 0, 0},
+		JavaSDK: {1, 0, 0},
+	}
+
+	// featureVersions is the client feature version each feature was introduced in
+	featureVersions = map[string]version{
+		FeatureStickyQuery: {1, 0, 0},
+	}
+)
+
+type (
+	// VersionChecker negotiates the capabilities of the client making a call from the
+	// client impl and feature version headers it sends
+	VersionChecker interface {
+		// ClientSupported returns an error if the client making the call is too old for this server
+		ClientSupported(ctx context.Context) error
+		// SupportsFeature returns an error if a client with the given impl and feature version does not support the feature
+		SupportsFeature(clientImpl string, featureVersion string, feature string) error
+	}
+
+	versionChecker struct {
+		enabled                    dynamicconfig.BoolPropertyFn
+		minSupportedFeatureVersion dynamicconfig.StringPropertyFn
+	}
+)
+
+var _ VersionChecker = (*versionChecker)(nil)
+
+// NewVersionChecker creates a VersionChecker, clients are only rejected when enabled returns true and
+// minSupportedFeatureVersion, filtered by client impl, overrides the built-in minimum of known clients
+func NewVersionChecker(enabled dynamicconfig.BoolPropertyFn, minSupportedFeatureVersion dynamicconfig.StringPropertyFn) VersionChecker {
+	return &versionChecker{
+		enabled:                    enabled,
+		minSupportedFeatureVersion: minSupportedFeatureVersion,
+	}
+}
+
+func (vc *versionChecker) ClientSupported(ctx context.Context) error {
+	if !vc.enabled() {
+		return nil
+	}
+
+	call := yarpc.CallFromContext(ctx)
+	clientImpl := call.Header(common.ClientImplHeaderName)
+	if clientImpl == "" {
+		// calls from other cadence services and raw thrift clients do not identify themselves
+		return nil
+	}
+
+	minVersion, ok := defaultMinSupportedFeatureVersions[clientImpl]
+	if override := vc.minSupportedFeatureVersion(dynamicconfig.ClientImplFilter(clientImpl)); override != "" {
+		minVersion, ok = parseVersion(override), true
+	}
+	if !ok {
+		return nil
+	}
+
+	featureVersion := call.Header(common.FeatureVersionHeaderName)
+	if !parseVersion(featureVersion).atLeast(minVersion) {
+		return &shared.BadRequestError{Message: fmt.Sprintf(
+			"Client %v with feature version %q is not supported, the minimum supported feature version is %v, please upgrade the client library.",
+			clientImpl, featureVersion, minVersion)}
+	}
+	return nil
+}
+
+func (vc *versionChecker) SupportsFeature(clientImpl string, featureVersion string, feature string) error {
+	required, ok := featureVersions[feature]
+	if !ok {
+		return &shared.BadRequestError{Message: fmt.Sprintf("Unknown client feature %v.", feature)}
+	}
+	if !parseVersion(featureVersion).atLeast(required) {
+		return &shared.BadRequestError{Message: fmt.Sprintf(
+			"Client %v with feature version %q does not support %v, which requires feature version %v.",
+			clientImpl, featureVersion, feature, required)}
+	}
+	return nil
+}
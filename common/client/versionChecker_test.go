@@ -0,0 +1,113 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+	"go.uber.org/yarpc/api/encoding"
+	"go.uber.org/yarpc/api/transport"
+)
+
+type (
+	versionCheckerSuite struct {
+		*require.Assertions
+		suite.Suite
+
+		enabled     bool
+		minVersions map[string]string
+		checker     VersionChecker
+	}
+)
+
+func TestVersionCheckerSuite(t *testing.T) {
+	suite.Run(t, new(versionCheckerSuite))
+}
+
+func (s *versionCheckerSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.enabled = true
+	s.minVersions = map[string]string{}
+	s.checker = NewVersionChecker(
+		func(opts ...dynamicconfig.FilterOption) bool {
+			return s.enabled
+		},
+		func(opts ...dynamicconfig.FilterOption) string {
+			filters := map[dynamicconfig.Filter]interface{}{}
+			for _, opt := range opts {
+				opt(filters)
+			}
+			return s.minVersions[filters[dynamicconfig.ClientImpl].(string)]
+		},
+	)
+}
+
+func (s *versionCheckerSuite) TestClientSupported() {
+	s.NoError(s.checker.ClientSupported(s.newContext(GoSDK, "1.0.0")))
+	s.NoError(s.checker.ClientSupported(s.newContext(JavaSDK, "1.2.0")))
+	s.Error(s.checker.ClientSupported(s.newContext(GoSDK, "0.9.9")))
+	s.Error(s.checker.ClientSupported(s.newContext(GoSDK, "")))
+
+	// calls which do not identify their client and unknown clients are always accepted
+	s.NoError(s.checker.ClientSupported(s.newContext("", "")))
+	s.NoError(s.checker.ClientSupported(s.newContext("unknown", "0.1.0")))
+	s.NoError(s.checker.ClientSupported(context.Background()))
+
+	s.enabled = false
+	s.NoError(s.checker.ClientSupported(s.newContext(GoSDK, "0.9.9")))
+}
+
+func (s *versionCheckerSuite) TestClientSupported_DynamicConfigOverride() {
+	s.minVersions[GoSDK] = "1.1.0"
+	s.minVersions["unknown"] = "2.0.0"
+	s.Error(s.checker.ClientSupported(s.newContext(GoSDK, "1.0.9")))
+	s.NoError(s.checker.ClientSupported(s.newContext(GoSDK, "1.1.0")))
+	s.NoError(s.checker.ClientSupported(s.newContext(GoSDK, "2.0.0")))
+	s.Error(s.checker.ClientSupported(s.newContext("unknown", "1.9.0")))
+
+	s.minVersions[GoSDK] = "0.5.0"
+	s.NoError(s.checker.ClientSupported(s.newContext(GoSDK, "0.9.9")))
+}
+
+func (s *versionCheckerSuite) TestSupportsFeature() {
+	s.NoError(s.checker.SupportsFeature(GoSDK, "1.0.0", FeatureStickyQuery))
+	s.Error(s.checker.SupportsFeature(GoSDK, "0.9.0", FeatureStickyQuery))
+	s.Error(s.checker.SupportsFeature(JavaSDK, "", FeatureStickyQuery))
+	s.Error(s.checker.SupportsFeature(GoSDK, "1.0.0", "unknownFeature"))
+}
+
+func (s *versionCheckerSuite) newContext(clientImpl string, featureVersion string) context.Context {
+	headers := transport.NewHeaders()
+	if clientImpl != "" {
+		headers = headers.With(common.ClientImplHeaderName, clientImpl)
+	}
+	if featureVersion != "" {
+		headers = headers.With(common.FeatureVersionHeaderName, featureVersion)
+	}
+	ctx, call := encoding.NewInboundCall(context.Background())
+	s.NoError(call.ReadFromRequest(&transport.Request{Headers: headers}))
+	return ctx
+}
@@ -22,6 +22,8 @@ package cluster
 
 import (
 	"fmt"
+	"sync"
+
 	"github.com/uber-common/bark"
 	"github.com/uber/cadence/common/logging"
 	"github.com/uber/cadence/common/metrics"
@@ -52,12 +54,22 @@ type (
 		ClusterNameForFailoverVersion(failoverVersion int64) string
 		// GetAllClientAddress return the frontend address for each cluster name
 		GetAllClientAddress() map[string]config.Address
+		// AddRemoteCluster adds a remote cluster at runtime and notifies the registered callbacks
+		AddRemoteCluster(clusterName string, initialFailoverVersion int64, address config.Address) error
+		// RegisterClusterChangeCallback registers a callback invoked with the name of every remote cluster added at runtime
+		RegisterClusterChangeCallback(key interface{}, callback ClusterChangeCallbackFn)
+		// UnregisterClusterChangeCallback removes the callback registered with the given key
+		UnregisterClusterChangeCallback(key interface{})
 
 		// ArchivalConfig returns the archival config of the cluster
 		ArchivalConfig() *ArchivalConfig
 	}
 
+	// ClusterChangeCallbackFn is invoked with the name of a remote cluster added at runtime
+	ClusterChangeCallbackFn func(clusterName string)
+
 	metadataImpl struct {
+		sync.RWMutex
 		logger        bark.Logger
 		metricsClient metrics.Client
 		// EnableGlobalDomain whether the global domain is enabled,
@@ -83,6 +95,8 @@ type (
 		defaultBucket string
 		// enableReadFromArchival whether reading history from archival is enabled
 		enableReadFromArchival dynamicconfig.BoolPropertyFn
+		// clusterChangeCallbacks contains the callbacks invoked when a remote cluster is added
+		clusterChangeCallbacks map[interface{}]ClusterChangeCallbackFn
 	}
 )
 
@@ -162,6 +176,7 @@ func NewMetadata(
 		archivalStatus:                 archivalStatus,
 		defaultBucket:                  defaultBucket,
 		enableReadFromArchival:         enableReadFromArchival,
+		clusterChangeCallbacks:         make(map[interface{}]ClusterChangeCallbackFn),
 	}
 }
 
@@ -173,6 +188,9 @@ func (metadata *metadataImpl) IsGlobalDomainEnabled() bool {
 
 // GetNextFailoverVersion return the next failover version based on input
 func (metadata *metadataImpl) GetNextFailoverVersion(cluster string, currentFailoverVersion int64) int64 {
+	metadata.RLock()
+	defer metadata.RUnlock()
+
 	initialFailoverVersion, ok := metadata.clusterInitialFailoverVersions[cluster]
 	if !ok {
 		panic(fmt.Sprintf(
@@ -209,11 +227,21 @@ func (metadata *metadataImpl) GetCurrentClusterName() string {
 
 // GetAllClusterFailoverVersions return the all cluster name -> corresponding initial failover version
 func (metadata *metadataImpl) GetAllClusterFailoverVersions() map[string]int64 {
-	return metadata.clusterInitialFailoverVersions
+	metadata.RLock()
+	defer metadata.RUnlock()
+
+	result := make(map[string]int64, len(metadata.clusterInitialFailoverVersions))
+	for clusterName, initialFailoverVersion := range metadata.clusterInitialFailoverVersions {
+		result[clusterName] = initialFailoverVersion
+	}
+	return result
 }
 
 // ClusterNameForFailoverVersion return the corresponding cluster name for a given failover version
 func (metadata *metadataImpl) ClusterNameForFailoverVersion(failoverVersion int64) string {
+	metadata.RLock()
+	defer metadata.RUnlock()
+
 	initialFailoverVersion := failoverVersion % metadata.failoverVersionIncrement
 	clusterName, ok := metadata.initialFailoverVersionClusters[initialFailoverVersion]
 	if !ok {
@@ -229,7 +257,90 @@ func (metadata *metadataImpl) ClusterNameForFailoverVersion(failoverVersion int6
 
 // GetAllClientAddress return the frontend address for each cluster name
 func (metadata *metadataImpl) GetAllClientAddress() map[string]config.Address {
-	return metadata.clusterToAddress
+	metadata.RLock()
+	defer metadata.RUnlock()
+
+	result := make(map[string]config.Address, len(metadata.clusterToAddress))
+	for clusterName, address := range metadata.clusterToAddress {
+		result[clusterName] = address
+	}
+	return result
+}
+
+// AddRemoteCluster adds a remote cluster at runtime and notifies the registered callbacks
+func (metadata *metadataImpl) AddRemoteCluster(clusterName string, initialFailoverVersion int64, address config.Address) error {
+	metadata.Lock()
+	if len(clusterName) == 0 {
+		metadata.Unlock()
+		return fmt.Errorf("cluster name is empty")
+	}
+	if _, ok := metadata.clusterInitialFailoverVersions[clusterName]; ok {
+		metadata.Unlock()
+		return fmt.Errorf("cluster %v already exists", clusterName)
+	}
+	if initialFailoverVersion < 0 || initialFailoverVersion >= metadata.failoverVersionIncrement {
+		metadata.Unlock()
+		return fmt.Errorf("initial failover version %v must be non negative and smaller than failover version increment %v",
+			initialFailoverVersion, metadata.failoverVersionIncrement)
+	}
+	if existing, ok := metadata.initialFailoverVersionClusters[initialFailoverVersion]; ok {
+		metadata.Unlock()
+		return fmt.Errorf("initial failover version %v is already used by cluster %v", initialFailoverVersion, existing)
+	}
+	if len(address.RPCName) == 0 || len(address.RPCAddress) == 0 {
+		metadata.Unlock()
+		return fmt.Errorf("address of cluster %v is empty", clusterName)
+	}
+
+	// copy on write, so the maps handed out before the change are not mutated
+	clusterInitialFailoverVersions := make(map[string]int64, len(metadata.clusterInitialFailoverVersions)+1)
+	for name, version := range metadata.clusterInitialFailoverVersions {
+		clusterInitialFailoverVersions[name] = version
+	}
+	clusterInitialFailoverVersions[clusterName] = initialFailoverVersion
+	initialFailoverVersionClusters := make(map[int64]string, len(metadata.initialFailoverVersionClusters)+1)
+	for version, name := range metadata.initialFailoverVersionClusters {
+		initialFailoverVersionClusters[version] = name
+	}
+	initialFailoverVersionClusters[initialFailoverVersion] = clusterName
+	clusterToAddress := make(map[string]config.Address, len(metadata.clusterToAddress)+1)
+	for name, clusterAddress := range metadata.clusterToAddress {
+		clusterToAddress[name] = clusterAddress
+	}
+	clusterToAddress[clusterName] = address
+
+	metadata.clusterInitialFailoverVersions = clusterInitialFailoverVersions
+	metadata.initialFailoverVersionClusters = initialFailoverVersionClusters
+	metadata.clusterToAddress = clusterToAddress
+	callbacks := make([]ClusterChangeCallbackFn, 0, len(metadata.clusterChangeCallbacks))
+	for _, callback := range metadata.clusterChangeCallbacks {
+		callbacks = append(callbacks, callback)
+	}
+	metadata.Unlock()
+
+	metadata.logger.WithFields(bark.Fields{
+		logging.TagClusterName: clusterName,
+	}).Info("Remote cluster added.")
+	for _, callback := range callbacks {
+		callback(clusterName)
+	}
+	return nil
+}
+
+// RegisterClusterChangeCallback registers a callback invoked with the name of every remote cluster added at runtime
+func (metadata *metadataImpl) RegisterClusterChangeCallback(key interface{}, callback ClusterChangeCallbackFn) {
+	metadata.Lock()
+	defer metadata.Unlock()
+
+	metadata.clusterChangeCallbacks[key] = callback
+}
+
+// UnregisterClusterChangeCallback removes the callback registered with the given key
+func (metadata *metadataImpl) UnregisterClusterChangeCallback(key interface{}) {
+	metadata.Lock()
+	defer metadata.Unlock()
+
+	delete(metadata.clusterChangeCallbacks, key)
 }
 
 // ArchivalConfig returns the archival config of the cluster.
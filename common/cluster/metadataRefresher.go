@@ -0,0 +1,136 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cluster
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/config"
+)
+
+const (
+	metadataRefresherInitialized int32 = 0
+	metadataRefresherStarted     int32 = 1
+	metadataRefresherStopped     int32 = 2
+)
+
+const (
+	// MetadataRefreshInterval is the interval at which remote clusters added at runtime are loaded
+	MetadataRefreshInterval = 10 * time.Second
+)
+
+type (
+	// metadataRefresher periodically loads the persisted cluster metadata and adds the remote clusters
+	// added at runtime by any host to the cluster metadata of this host
+	metadataRefresher struct {
+		status       int32
+		metadata     Metadata
+		manager      persistence.ClusterMetadataManager
+		shutdownChan chan struct{}
+		logger       bark.Logger
+	}
+)
+
+var _ common.Daemon = (*metadataRefresher)(nil)
+
+// NewMetadataRefresher creates a daemon which keeps the remote clusters of the given metadata in sync with
+// the persisted cluster metadata
+func NewMetadataRefresher(metadata Metadata, manager persistence.ClusterMetadataManager, logger bark.Logger) common.Daemon {
+	return &metadataRefresher{
+		status:       metadataRefresherInitialized,
+		metadata:     metadata,
+		manager:      manager,
+		shutdownChan: make(chan struct{}),
+		logger: logger.WithFields(bark.Fields{
+			logging.TagWorkflowComponent: logging.TagValueClusterMetadataRefresherComponent,
+		}),
+	}
+}
+
+// Start loads the persisted cluster metadata and starts the background refresh
+func (r *metadataRefresher) Start() {
+	if !atomic.CompareAndSwapInt32(&r.status, metadataRefresherInitialized, metadataRefresherStarted) {
+		return
+	}
+
+	if err := r.refresh(); err != nil {
+		r.logger.WithField(logging.TagErr, err).Error("Error refreshing cluster metadata")
+	}
+	go r.refreshLoop()
+}
+
+// Stop stops the background refresh
+func (r *metadataRefresher) Stop() {
+	if !atomic.CompareAndSwapInt32(&r.status, metadataRefresherStarted, metadataRefresherStopped) {
+		return
+	}
+	close(r.shutdownChan)
+	r.manager.Close()
+}
+
+func (r *metadataRefresher) refreshLoop() {
+	timer := time.NewTimer(MetadataRefreshInterval)
+	defer timer.Stop()
+	for {
+		select {
+		case <-r.shutdownChan:
+			return
+		case <-timer.C:
+			timer.Reset(MetadataRefreshInterval)
+			if err := r.refresh(); err != nil {
+				r.logger.WithField(logging.TagErr, err).Error("Error refreshing cluster metadata")
+			}
+		}
+	}
+}
+
+func (r *metadataRefresher) refresh() error {
+	resp, err := r.manager.GetClusterMetadata(context.Background())
+	if err != nil {
+		return err
+	}
+
+	persisted := resp.ClusterMetadata
+	known := r.metadata.GetAllClusterFailoverVersions()
+	for clusterName, initialFailoverVersion := range persisted.InitialFailoverVersions {
+		if _, ok := known[clusterName]; ok {
+			continue
+		}
+		address := persisted.ClusterAddresses[clusterName]
+		err := r.metadata.AddRemoteCluster(clusterName, initialFailoverVersion, config.Address{
+			RPCName:    address.RPCName,
+			RPCAddress: address.RPCAddress,
+		})
+		if err != nil {
+			r.logger.WithFields(bark.Fields{
+				logging.TagClusterName: clusterName,
+				logging.TagErr:         err,
+			}).Error("Failed to add remote cluster")
+		}
+	}
+	return nil
+}
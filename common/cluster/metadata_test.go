@@ -0,0 +1,87 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/service/config"
+)
+
+type (
+	metadataSuite struct {
+		suite.Suite
+		metadata Metadata
+	}
+)
+
+func TestMetadataSuite(t *testing.T) {
+	s := new(metadataSuite)
+	suite.Run(t, s)
+}
+
+func (s *metadataSuite) SetupTest() {
+	s.metadata = GetTestClusterMetadata(true, true, false)
+}
+
+func (s *metadataSuite) TestAddRemoteCluster() {
+	clusterName := "remote"
+	initialFailoverVersion := int64(2)
+	address := config.Address{RPCName: common.FrontendServiceName, RPCAddress: "127.0.0.1:9104"}
+
+	var notified []string
+	s.metadata.RegisterClusterChangeCallback(s, func(clusterName string) {
+		notified = append(notified, clusterName)
+	})
+	versionsBefore := s.metadata.GetAllClusterFailoverVersions()
+
+	err := s.metadata.AddRemoteCluster(clusterName, initialFailoverVersion, address)
+	s.NoError(err)
+	s.Equal([]string{clusterName}, notified)
+	s.Equal(initialFailoverVersion, s.metadata.GetAllClusterFailoverVersions()[clusterName])
+	s.Equal(address, s.metadata.GetAllClientAddress()[clusterName])
+	s.Equal(clusterName, s.metadata.ClusterNameForFailoverVersion(initialFailoverVersion+TestFailoverVersionIncrement))
+	s.Equal(initialFailoverVersion+TestFailoverVersionIncrement, s.metadata.GetNextFailoverVersion(clusterName, initialFailoverVersion+1))
+	// maps handed out before the change are not mutated
+	s.Equal(TestAllClusterFailoverVersions, versionsBefore)
+
+	s.metadata.UnregisterClusterChangeCallback(s)
+	err = s.metadata.AddRemoteCluster("another remote", initialFailoverVersion+1, address)
+	s.NoError(err)
+	s.Equal([]string{clusterName}, notified)
+}
+
+func (s *metadataSuite) TestAddRemoteCluster_Invalid() {
+	address := config.Address{RPCName: common.FrontendServiceName, RPCAddress: "127.0.0.1:9104"}
+	s.metadata.RegisterClusterChangeCallback(s, func(clusterName string) {
+		s.Fail("callback should not be invoked for an invalid cluster")
+	})
+
+	s.Error(s.metadata.AddRemoteCluster("", 2, address))
+	s.Error(s.metadata.AddRemoteCluster(TestAlternativeClusterName, 2, address))
+	s.Error(s.metadata.AddRemoteCluster("remote", -1, address))
+	s.Error(s.metadata.AddRemoteCluster("remote", TestFailoverVersionIncrement, address))
+	s.Error(s.metadata.AddRemoteCluster("remote", TestAlternativeClusterInitialFailoverVersion, address))
+	s.Error(s.metadata.AddRemoteCluster("remote", 2, config.Address{}))
+	s.Equal(TestAllClusterFailoverVersions, s.metadata.GetAllClusterFailoverVersions())
+}
@@ -37,11 +37,19 @@ type (
 		FromWire(w wire.Value) error
 		ToWire() (wire.Value, error)
 	}
+
+	// ProtoObject represents a protobuf message generated with marshalers
+	ProtoObject interface {
+		Marshal() ([]byte, error)
+		Unmarshal(data []byte) error
+	}
 )
 
 const (
 	// used by thriftrw binary codec
 	preambleVersion0 byte = 0x59
+	// used by protobuf binary codec
+	preambleVersion1 byte = 0x5A
 )
 
 var (
@@ -51,4 +59,6 @@ var (
 	InvalidBinaryEncodingVersion = &shared.BadRequestError{Message: "Invalid binary encoding version."}
 	// MsgPayloadNotThriftEncoded indicate message is not thrift encoded
 	MsgPayloadNotThriftEncoded = &shared.BadRequestError{Message: "Message payload is not thrift encoded."}
+	// MsgPayloadNotProtoEncoded indicate message is not protobuf encoded
+	MsgPayloadNotProtoEncoded = &shared.BadRequestError{Message: "Message payload is not protobuf encoded."}
 )
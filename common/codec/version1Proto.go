@@ -0,0 +1,65 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package codec
+
+type (
+	// ProtoEncoder is an implementation using protobuf for binary encoding / decoding, payloads are versioned
+	// with their first byte like the ones of ThriftRWEncoder so both can be told apart
+	ProtoEncoder struct {
+	}
+)
+
+// NewProtoEncoder generate a new ProtoEncoder
+func NewProtoEncoder() *ProtoEncoder {
+	return &ProtoEncoder{}
+}
+
+// Encode encode the object
+func (p *ProtoEncoder) Encode(obj ProtoObject) ([]byte, error) {
+	if obj == nil {
+		return nil, MsgPayloadNotProtoEncoded
+	}
+	payload, err := obj.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	// use the first byte to version the serialization
+	return append([]byte{preambleVersion1}, payload...), nil
+}
+
+// Decode decode the object
+func (p *ProtoEncoder) Decode(binary []byte, val ProtoObject) error {
+	if len(binary) < 1 {
+		return MissingBinaryEncodingVersion
+	}
+
+	version := binary[0]
+	if version != preambleVersion1 {
+		return InvalidBinaryEncodingVersion
+	}
+
+	return val.Unmarshal(binary[1:])
+}
+
+// IsProtoEncoded returns whether the payload was encoded by ProtoEncoder rather than ThriftRWEncoder
+func IsProtoEncoded(binary []byte) bool {
+	return len(binary) > 0 && binary[0] == preambleVersion1
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package codec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	indexerv2 "github.com/uber/cadence/.gen/proto/indexer/v2"
+)
+
+type (
+	protoEncoderSuite struct {
+		suite.Suite
+		encoder *ProtoEncoder
+	}
+)
+
+var (
+	protoObject = &indexerv2.Message{
+		MessageType: indexerv2.MessageType_MESSAGE_TYPE_INDEX,
+		DomainId:    "some random domain ID",
+		WorkflowId:  "some random workflow ID",
+		RunId:       "some random run ID",
+		Version:     1234,
+		Fields: map[string]*indexerv2.Field{
+			"StartTime": {Data: &indexerv2.Field_IntData{IntData: 112345132134}},
+		},
+	}
+)
+
+func TestProtoEncoderSuite(t *testing.T) {
+	s := new(protoEncoderSuite)
+	suite.Run(t, s)
+}
+
+func (s *protoEncoderSuite) SetupSuite() {
+	s.encoder = NewProtoEncoder()
+}
+
+func (s *protoEncoderSuite) TestEncodeDecode() {
+	binary, err := s.encoder.Encode(protoObject)
+	s.Nil(err)
+	s.True(IsProtoEncoded(binary))
+
+	var val indexerv2.Message
+	err = s.encoder.Decode(binary, &val)
+	s.Nil(err)
+	s.Equal(protoObject, &val)
+}
+
+func (s *protoEncoderSuite) TestDecode_MissingVersion() {
+	var val indexerv2.Message
+	err := s.encoder.Decode([]byte{}, &val)
+	s.Equal(MissingBinaryEncodingVersion, err)
+}
+
+func (s *protoEncoderSuite) TestDecode_ThriftEncoded() {
+	s.False(IsProtoEncoded(thriftEncodedBinary))
+
+	var val indexerv2.Message
+	err := s.encoder.Decode(thriftEncodedBinary, &val)
+	s.Equal(InvalidBinaryEncodingVersion, err)
+}
@@ -74,6 +74,12 @@ type (
 	EncodingType string
 )
 
+// LocalActivityMarkerName is the marker name used by client libraries to record local activity results
+const LocalActivityMarkerName = "LocalActivity"
+
+// QueryTypeStackTrace is the query type which client libraries answer with the current stack trace of a workflow
+const QueryTypeStackTrace = "__stack_trace"
+
 // MaxTaskTimeout is maximum task timeout allowed. 366 days in seconds
 const MaxTaskTimeout = 31622400
 
@@ -87,6 +93,8 @@ const (
 const (
 	// VisibilityAppName is used to find kafka topics and ES indexName for visibility
 	VisibilityAppName = "visibility"
+	// DomainChangeAppName is used to find kafka topics for domain change notifications
+	DomainChangeAppName = "domain-change"
 )
 
 const (
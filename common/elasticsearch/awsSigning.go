@@ -0,0 +1,232 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package elasticsearch
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	awsSigningAlgorithm = "AWS4-HMAC-SHA256"
+	awsSigningService   = "es"
+	awsDateFormat       = "20060102"
+	awsTimeFormat       = "20060102T150405Z"
+)
+
+type (
+	// awsSigningTransport signs the requests to Amazon Elasticsearch Service with AWS signature version 4
+	awsSigningTransport struct {
+		transport       http.RoundTripper
+		region          string
+		service         string
+		accessKeyID     string
+		secretAccessKey string
+		sessionToken    string
+		now             func() time.Time
+	}
+)
+
+// newAWSSigningTransport creates a transport signing the requests with the configured credentials,
+// or the credentials of the AWS environment variables if none are configured
+func newAWSSigningTransport(config AWSRequestSigningConfig, transport http.RoundTripper) (*awsSigningTransport, error) {
+	t := &awsSigningTransport{
+		transport:       transport,
+		region:          config.Region,
+		service:         awsSigningService,
+		accessKeyID:     config.AccessKeyID,
+		secretAccessKey: config.SecretAccessKey,
+		sessionToken:    config.SessionToken,
+		now:             time.Now,
+	}
+	if t.accessKeyID == "" {
+		t.accessKeyID = os.Getenv("AWS_ACCESS_KEY_ID")
+		t.secretAccessKey = os.Getenv("AWS_SECRET_ACCESS_KEY")
+		t.sessionToken = os.Getenv("AWS_SESSION_TOKEN")
+	}
+	if t.region == "" {
+		t.region = os.Getenv("AWS_REGION")
+	}
+
+	if t.region == "" {
+		return nil, errors.New("AWS request signing requires a region")
+	}
+	if t.accessKeyID == "" || t.secretAccessKey == "" {
+		return nil, errors.New("AWS request signing requires an access key ID and a secret access key")
+	}
+	return t, nil
+}
+
+func (t *awsSigningTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	signed, err := t.sign(req)
+	if err != nil {
+		return nil, err
+	}
+	return t.transport.RoundTrip(signed)
+}
+
+// sign returns a copy of the request with the signature headers set
+func (t *awsSigningTransport) sign(req *http.Request) (*http.Request, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = ioutil.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+	}
+
+	signed := req.WithContext(req.Context())
+	signed.Header = make(http.Header, len(req.Header)+3)
+	for name, values := range req.Header {
+		signed.Header[name] = values
+	}
+	if req.Body != nil {
+		signed.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	now := t.now().UTC()
+	signed.Header.Set("X-Amz-Date", now.Format(awsTimeFormat))
+	if t.sessionToken != "" {
+		signed.Header.Set("X-Amz-Security-Token", t.sessionToken)
+	}
+	host := signed.Host
+	if host == "" {
+		host = signed.URL.Host
+	}
+
+	headers := map[string]string{"host": host}
+	for name, values := range signed.Header {
+		name = strings.ToLower(name)
+		if strings.HasPrefix(name, "x-amz-") {
+			headers[name] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	payloadHash := sha256.Sum256(body)
+	canonicalRequest := strings.Join([]string{
+		signed.Method,
+		awsCanonicalURI(signed.URL),
+		awsCanonicalQuery(signed.URL),
+		canonicalHeaders.String(),
+		signedHeaders,
+		hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+	canonicalRequestHash := sha256.Sum256([]byte(canonicalRequest))
+
+	scope := strings.Join([]string{now.Format(awsDateFormat), t.region, t.service, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{
+		awsSigningAlgorithm,
+		now.Format(awsTimeFormat),
+		scope,
+		hex.EncodeToString(canonicalRequestHash[:]),
+	}, "\n")
+
+	key := awsHMAC([]byte("AWS4"+t.secretAccessKey), now.Format(awsDateFormat))
+	key = awsHMAC(key, t.region)
+	key = awsHMAC(key, t.service)
+	key = awsHMAC(key, "aws4_request")
+	signature := hex.EncodeToString(awsHMAC(key, stringToSign))
+
+	signed.Header.Set("Authorization", fmt.Sprintf("%v Credential=%v/%v, SignedHeaders=%v, Signature=%v",
+		awsSigningAlgorithm, t.accessKeyID, scope, signedHeaders, signature))
+	return signed, nil
+}
+
+func awsHMAC(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+func awsCanonicalURI(u *url.URL) string {
+	path := u.EscapedPath()
+	if path == "" {
+		return "/"
+	}
+	var b strings.Builder
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		if c == '/' || awsUnreserved(c) {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func awsCanonicalQuery(u *url.URL) string {
+	query := u.Query()
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var pairs []string
+	for _, key := range keys {
+		values := query[key]
+		sort.Strings(values)
+		for _, value := range values {
+			pairs = append(pairs, awsEscape(key)+"="+awsEscape(value))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+func awsEscape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if awsUnreserved(c) {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func awsUnreserved(c byte) bool {
+	return 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' ||
+		c == '-' || c == '_' || c == '.' || c == '~'
+}
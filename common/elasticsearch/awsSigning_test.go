@@ -0,0 +1,107 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package elasticsearch
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type (
+	awsSigningSuite struct {
+		suite.Suite
+		transport *awsSigningTransport
+	}
+)
+
+func TestAWSSigningSuite(t *testing.T) {
+	s := new(awsSigningSuite)
+	suite.Run(t, s)
+}
+
+// SetupTest uses the credentials and time of the AWS signature version 4 test suite
+func (s *awsSigningSuite) SetupTest() {
+	transport, err := newAWSSigningTransport(AWSRequestSigningConfig{
+		Enable:          true,
+		Region:          "us-east-1",
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+	}, http.DefaultTransport)
+	s.NoError(err)
+	transport.service = "service"
+	transport.now = func() time.Time { return time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC) }
+	s.transport = transport
+}
+
+func (s *awsSigningSuite) TestMissingRegion() {
+	_, err := newAWSSigningTransport(AWSRequestSigningConfig{
+		Enable:          true,
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+	}, http.DefaultTransport)
+	s.Error(err)
+}
+
+func (s *awsSigningSuite) TestSign() {
+	req, err := http.NewRequest("GET", "https://example.amazonaws.com/", nil)
+	s.NoError(err)
+	signed, err := s.transport.sign(req)
+	s.NoError(err)
+	s.Equal("20150830T123600Z", signed.Header.Get("X-Amz-Date"))
+	s.Equal("AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, "+
+		"SignedHeaders=host;x-amz-date, Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31",
+		signed.Header.Get("Authorization"))
+	s.Empty(req.Header.Get("Authorization"))
+}
+
+func (s *awsSigningSuite) TestSign_QueryOrder() {
+	req, err := http.NewRequest("GET", "https://example.amazonaws.com/?Param2=value2&Param1=value1", nil)
+	s.NoError(err)
+	signed, err := s.transport.sign(req)
+	s.NoError(err)
+	s.True(strings.HasSuffix(signed.Header.Get("Authorization"),
+		"Signature=b97d918cfa904a5beff61c982a1b6f458b799221646efd99d3219ec94cdf2500"))
+}
+
+func (s *awsSigningSuite) TestSign_Body() {
+	req, err := http.NewRequest("POST", "https://example.amazonaws.com/visibility/_search", strings.NewReader(`{"query":{}}`))
+	s.NoError(err)
+	signed, err := s.transport.sign(req)
+	s.NoError(err)
+	body, err := ioutil.ReadAll(signed.Body)
+	s.NoError(err)
+	s.Equal(`{"query":{}}`, string(body))
+}
+
+func (s *awsSigningSuite) TestSign_SessionToken() {
+	s.transport.sessionToken = "token"
+	req, err := http.NewRequest("GET", "https://example.amazonaws.com/", nil)
+	s.NoError(err)
+	signed, err := s.transport.sign(req)
+	s.NoError(err)
+	s.Equal("token", signed.Header.Get("X-Amz-Security-Token"))
+	s.Contains(signed.Header.Get("Authorization"), "SignedHeaders=host;x-amz-date;x-amz-security-token,")
+}
@@ -0,0 +1,227 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package elasticsearch
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/olivere/elastic"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/metrics"
+)
+
+const (
+	defaultCircuitBreakerOpenTimeout    = time.Second
+	defaultCircuitBreakerMaxOpenTimeout = time.Minute
+)
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	// circuitHalfOpen lets a single probe request through to find out whether ElasticSearch recovered
+	circuitHalfOpen
+)
+
+type (
+	circuitState int
+
+	// circuitBreakerClient fails requests fast with ServiceBusyError while ElasticSearch is failing,
+	// instead of letting callers pile up waiting on an unresponsive cluster
+	circuitBreakerClient struct {
+		sync.Mutex
+		client        Client
+		config        CircuitBreakerConfig
+		metricsClient metrics.Client
+		timeSource    clock.TimeSource
+
+		state               circuitState
+		consecutiveFailures int
+		openedAt            time.Time
+		openTimeout         time.Duration
+	}
+)
+
+var _ Client = (*circuitBreakerClient)(nil)
+
+var errCircuitOpen = &workflow.ServiceBusyError{Message: "ElasticSearch is unavailable, please retry later."}
+
+// NewCircuitBreakerClient wraps the client with a circuit breaker, the client is returned as is
+// if the circuit breaker is not enabled
+func NewCircuitBreakerClient(client Client, config CircuitBreakerConfig, metricsClient metrics.Client) Client {
+	if config.MaxConsecutiveFailures <= 0 {
+		return client
+	}
+	if config.OpenTimeout <= 0 {
+		config.OpenTimeout = defaultCircuitBreakerOpenTimeout
+	}
+	if config.MaxOpenTimeout < config.OpenTimeout {
+		config.MaxOpenTimeout = defaultCircuitBreakerMaxOpenTimeout
+	}
+	return &circuitBreakerClient{
+		client:        client,
+		config:        config,
+		metricsClient: metricsClient,
+		timeSource:    clock.NewRealTimeSource(),
+		state:         circuitClosed,
+		openTimeout:   config.OpenTimeout,
+	}
+}
+
+func (c *circuitBreakerClient) Search(ctx context.Context, p *SearchParameters) (*elastic.SearchResult, error) {
+	ctx, cancel, err := c.before(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+	result, err := c.client.Search(ctx, p)
+	c.after(err)
+	return result, err
+}
+
+func (c *circuitBreakerClient) Count(ctx context.Context, index string, query elastic.Query) (int64, error) {
+	ctx, cancel, err := c.before(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer cancel()
+	count, err := c.client.Count(ctx, index, query)
+	c.after(err)
+	return count, err
+}
+
+// RunBulkProcessor is not guarded, the bulk processor retries and backs off on its own
+func (c *circuitBreakerClient) RunBulkProcessor(ctx context.Context, p *BulkProcessorParameters) (*elastic.BulkProcessor, error) {
+	return c.client.RunBulkProcessor(ctx, p)
+}
+
+func (c *circuitBreakerClient) DeleteByQuery(ctx context.Context, p *DeleteByQueryParameters) (*elastic.BulkIndexByScrollResponse, error) {
+	ctx, cancel, err := c.before(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+	response, err := c.client.DeleteByQuery(ctx, p)
+	c.after(err)
+	return response, err
+}
+
+func (c *circuitBreakerClient) Bulk(ctx context.Context, requests []elastic.BulkableRequest) (*elastic.BulkResponse, error) {
+	ctx, cancel, err := c.before(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+	response, err := c.client.Bulk(ctx, requests)
+	c.after(err)
+	return response, err
+}
+
+func (c *circuitBreakerClient) PutMapping(ctx context.Context, index, key, valueType string) error {
+	ctx, cancel, err := c.before(ctx)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+	err = c.client.PutMapping(ctx, index, key, valueType)
+	c.after(err)
+	return err
+}
+
+// before rejects the request if the circuit is open, otherwise it bounds the context of the request
+func (c *circuitBreakerClient) before(ctx context.Context) (context.Context, context.CancelFunc, error) {
+	if err := c.allow(); err != nil {
+		c.metricsClient.IncCounter(metrics.ElasticsearchClientScope, metrics.ElasticsearchCircuitBreakerRejectedCounter)
+		return nil, nil, err
+	}
+	if _, ok := ctx.Deadline(); !ok && c.config.RequestTimeout > 0 {
+		ctx, cancel := context.WithTimeout(ctx, c.config.RequestTimeout)
+		return ctx, cancel, nil
+	}
+	return ctx, func() {}, nil
+}
+
+func (c *circuitBreakerClient) allow() error {
+	c.Lock()
+	defer c.Unlock()
+
+	switch c.state {
+	case circuitClosed:
+		return nil
+	case circuitOpen:
+		if c.timeSource.Now().Sub(c.openedAt) < c.openTimeout {
+			return errCircuitOpen
+		}
+		// this request probes whether ElasticSearch recovered
+		c.state = circuitHalfOpen
+		return nil
+	default:
+		// a probe request is in flight
+		return errCircuitOpen
+	}
+}
+
+func (c *circuitBreakerClient) after(err error) {
+	c.Lock()
+	defer c.Unlock()
+
+	if !isElasticSearchFailure(err) {
+		c.state = circuitClosed
+		c.consecutiveFailures = 0
+		c.openTimeout = c.config.OpenTimeout
+		return
+	}
+
+	c.consecutiveFailures++
+	switch c.state {
+	case circuitClosed:
+		if c.consecutiveFailures >= c.config.MaxConsecutiveFailures {
+			c.open()
+		}
+	case circuitHalfOpen:
+		// the probe failed, back off before probing again
+		c.openTimeout *= 2
+		if c.openTimeout > c.config.MaxOpenTimeout {
+			c.openTimeout = c.config.MaxOpenTimeout
+		}
+		c.open()
+	}
+}
+
+func (c *circuitBreakerClient) open() {
+	c.state = circuitOpen
+	c.openedAt = c.timeSource.Now()
+	c.metricsClient.IncCounter(metrics.ElasticsearchClientScope, metrics.ElasticsearchCircuitBreakerOpenCounter)
+}
+
+// isElasticSearchFailure returns true for errors which indicate ElasticSearch is unhealthy, as opposed to
+// bad requests and requests canceled by the caller
+func isElasticSearchFailure(err error) bool {
+	switch err := err.(type) {
+	case nil:
+		return false
+	case *elastic.Error:
+		return err.Status >= 500 || err.Status == 429
+	}
+	return err != context.Canceled
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package elasticsearch
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/olivere/elastic"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-go/tally"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/metrics"
+)
+
+type (
+	circuitBreakerClientSuite struct {
+		suite.Suite
+		esClient   *countingClient
+		timeSource *clock.EventTimeSource
+		client     *circuitBreakerClient
+	}
+
+	// countingClient counts the requests it receives and fails them with err
+	countingClient struct {
+		Client
+		requests int
+		err      error
+	}
+)
+
+func TestCircuitBreakerClientSuite(t *testing.T) {
+	s := new(circuitBreakerClientSuite)
+	suite.Run(t, s)
+}
+
+func (s *circuitBreakerClientSuite) SetupTest() {
+	s.esClient = &countingClient{}
+	s.timeSource = clock.NewEventTimeSource()
+	s.timeSource.Update(time.Now())
+	s.client = NewCircuitBreakerClient(s.esClient, CircuitBreakerConfig{
+		MaxConsecutiveFailures: 2,
+		OpenTimeout:            time.Second,
+		MaxOpenTimeout:         3 * time.Second,
+	}, metrics.NewClient(tally.NoopScope, metrics.Frontend)).(*circuitBreakerClient)
+	s.client.timeSource = s.timeSource
+}
+
+func (s *circuitBreakerClientSuite) TestDisabled() {
+	client := NewCircuitBreakerClient(s.esClient, CircuitBreakerConfig{}, metrics.NewClient(tally.NoopScope, metrics.Frontend))
+	s.Equal(s.esClient, client)
+}
+
+func (s *circuitBreakerClientSuite) TestOpenAndRecover() {
+	s.esClient.err = errors.New("connection refused")
+	for i := 0; i < 2; i++ {
+		_, err := s.client.Count(context.Background(), "index", nil)
+		s.Equal(s.esClient.err, err)
+	}
+
+	// the circuit is open
+	_, err := s.client.Count(context.Background(), "index", nil)
+	s.IsType(&workflow.ServiceBusyError{}, err)
+	s.Equal(2, s.esClient.requests)
+
+	// the probe fails, the circuit stays open twice as long
+	s.timeSource.Update(s.timeSource.Now().Add(time.Second))
+	_, err = s.client.Count(context.Background(), "index", nil)
+	s.Equal(s.esClient.err, err)
+	s.Equal(3, s.esClient.requests)
+	s.timeSource.Update(s.timeSource.Now().Add(time.Second))
+	_, err = s.client.Count(context.Background(), "index", nil)
+	s.IsType(&workflow.ServiceBusyError{}, err)
+
+	// the probe succeeds, the circuit is closed
+	s.esClient.err = nil
+	s.timeSource.Update(s.timeSource.Now().Add(time.Second))
+	_, err = s.client.Count(context.Background(), "index", nil)
+	s.NoError(err)
+	_, err = s.client.Count(context.Background(), "index", nil)
+	s.NoError(err)
+	s.Equal(5, s.esClient.requests)
+}
+
+func (s *circuitBreakerClientSuite) TestBadRequestsAreNotFailures() {
+	s.esClient.err = &elastic.Error{Status: 400}
+	for i := 0; i < 3; i++ {
+		_, err := s.client.Count(context.Background(), "index", nil)
+		s.Equal(s.esClient.err, err)
+	}
+	s.Equal(3, s.esClient.requests)
+}
+
+func (s *circuitBreakerClientSuite) TestRequestTimeout() {
+	s.client.config.RequestTimeout = time.Minute
+	ctx, cancel, err := s.client.before(context.Background())
+	s.NoError(err)
+	defer cancel()
+	_, ok := ctx.Deadline()
+	s.True(ok)
+}
+
+func (c *countingClient) Count(ctx context.Context, index string, query elastic.Query) (int64, error) {
+	c.requests++
+	return 0, c.err
+}
@@ -22,17 +22,26 @@ package elasticsearch
 
 import (
 	"context"
-	"github.com/olivere/elastic"
+	"fmt"
+	"net/http"
 	"time"
+
+	"github.com/olivere/elastic"
 )
 
+const docType = "_doc"
+
 type (
 	// Client is a wrapper around ElasticSearch client library.
 	// It simplifies the interface and enables mocking. We intentionally let implementation details of the elastic library
 	// bleed through, as the main purpose is testability not abstraction.
 	Client interface {
 		Search(ctx context.Context, p *SearchParameters) (*elastic.SearchResult, error)
+		Count(ctx context.Context, index string, query elastic.Query) (int64, error)
 		RunBulkProcessor(ctx context.Context, p *BulkProcessorParameters) (*elastic.BulkProcessor, error)
+		DeleteByQuery(ctx context.Context, p *DeleteByQueryParameters) (*elastic.BulkIndexByScrollResponse, error)
+		Bulk(ctx context.Context, requests []elastic.BulkableRequest) (*elastic.BulkResponse, error)
+		PutMapping(ctx context.Context, index, key, valueType string) error
 	}
 
 	// SearchParameters holds all required and optional parameters for executing a search
@@ -43,6 +52,8 @@ type (
 		PageSize    int
 		Sorter      []elastic.Sorter
 		SearchAfter []interface{}
+		// Aggregations are keyed by name; a search with aggregations and no PageSize returns no hits
+		Aggregations map[string]elastic.Aggregation
 	}
 
 	// BulkProcessorParameters holds all required and optional parameters for executing bulk service
@@ -57,6 +68,14 @@ type (
 		AfterFunc     elastic.BulkAfterFunc
 	}
 
+	// DeleteByQueryParameters holds all required and optional parameters for executing delete by query
+	DeleteByQueryParameters struct {
+		Index             string
+		Query             elastic.Query
+		MaxDocs           int
+		RequestsPerSecond int
+	}
+
 	// elasticWrapper implements Client
 	elasticWrapper struct {
 		client *elastic.Client
@@ -67,10 +86,27 @@ var _ Client = (*elasticWrapper)(nil)
 
 // NewClient create a ES client
 func NewClient(config *Config) (Client, error) {
-	client, err := elastic.NewClient(
+	options := []elastic.ClientOptionFunc{
 		elastic.SetURL(config.URL.String()),
 		elastic.SetRetrier(elastic.NewBackoffRetrier(elastic.NewExponentialBackoff(128*time.Millisecond, 513*time.Millisecond))),
-	)
+		elastic.SetSniff(!config.DisableSniff),
+		elastic.SetHealthcheck(!config.DisableHealthCheck),
+	}
+	if config.HealthCheckInterval > 0 {
+		options = append(options, elastic.SetHealthcheckInterval(config.HealthCheckInterval))
+	}
+	if config.Username != "" {
+		options = append(options, elastic.SetBasicAuth(config.Username, config.Password))
+	}
+	if config.AWSRequestSigning.Enable {
+		transport, err := newAWSSigningTransport(config.AWSRequestSigning, http.DefaultTransport)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, elastic.SetHttpClient(&http.Client{Transport: transport}))
+	}
+
+	client, err := elastic.NewClient(options...)
 	if err != nil {
 		return nil, err
 	}
@@ -90,6 +126,12 @@ func (c *elasticWrapper) Search(ctx context.Context, p *SearchParameters) (*elas
 
 	if p.PageSize != 0 {
 		searchService.Size(p.PageSize)
+	} else if len(p.Aggregations) != 0 {
+		searchService.Size(0)
+	}
+
+	for name, aggregation := range p.Aggregations {
+		searchService.Aggregation(name, aggregation)
 	}
 
 	if len(p.SearchAfter) != 0 {
@@ -99,6 +141,10 @@ func (c *elasticWrapper) Search(ctx context.Context, p *SearchParameters) (*elas
 	return searchService.Do(ctx)
 }
 
+func (c *elasticWrapper) Count(ctx context.Context, index string, query elastic.Query) (int64, error) {
+	return c.client.Count(index).Query(query).Do(ctx)
+}
+
 func (c *elasticWrapper) RunBulkProcessor(ctx context.Context, p *BulkProcessorParameters) (*elastic.BulkProcessor, error) {
 	return c.client.BulkProcessor().
 		Name(p.Name).
@@ -110,3 +156,40 @@ func (c *elasticWrapper) RunBulkProcessor(ctx context.Context, p *BulkProcessorP
 		After(p.AfterFunc).
 		Do(ctx)
 }
+
+func (c *elasticWrapper) DeleteByQuery(ctx context.Context, p *DeleteByQueryParameters) (*elastic.BulkIndexByScrollResponse, error) {
+	deleteService := c.client.DeleteByQuery(p.Index).
+		Query(p.Query).
+		ProceedOnVersionConflict()
+
+	if p.MaxDocs != 0 {
+		deleteService.Size(p.MaxDocs)
+	}
+
+	if p.RequestsPerSecond != 0 {
+		deleteService.RequestsPerSecond(p.RequestsPerSecond)
+	}
+
+	return deleteService.Do(ctx)
+}
+
+func (c *elasticWrapper) Bulk(ctx context.Context, requests []elastic.BulkableRequest) (*elastic.BulkResponse, error) {
+	return c.client.Bulk().Add(requests...).Do(ctx)
+}
+
+// PutMapping adds the key as a field of the given ElasticSearch type to the mapping of the index
+func (c *elasticWrapper) PutMapping(ctx context.Context, index, key, valueType string) error {
+	body := map[string]interface{}{
+		"properties": map[string]interface{}{
+			key: map[string]interface{}{"type": valueType},
+		},
+	}
+	response, err := c.client.PutMapping().Index(index).Type(docType).BodyJson(body).Do(ctx)
+	if err != nil {
+		return err
+	}
+	if !response.Acknowledged {
+		return fmt.Errorf("put mapping of %v to index %v is not acknowledged", key, index)
+	}
+	return nil
+}
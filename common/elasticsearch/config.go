@@ -22,6 +22,7 @@ package elasticsearch
 
 import (
 	"net/url"
+	"time"
 )
 
 // Config for connecting to ElasticSearch
@@ -30,5 +31,43 @@ type (
 		Enable  bool              `yaml:enable`
 		URL     url.URL           `yaml:url`
 		Indices map[string]string `yaml:indices`
+		// DisableSniff disables the discovery of the cluster nodes, managed ElasticSearch services
+		// only expose the URL of their endpoint
+		DisableSniff bool `yaml:"disableSniff"`
+		// DisableHealthCheck disables the periodic health checks of the cluster nodes
+		DisableHealthCheck bool `yaml:"disableHealthCheck"`
+		// HealthCheckInterval is the interval of the health checks, 0 keeps the default of the client library
+		HealthCheckInterval time.Duration `yaml:"healthCheckInterval"`
+		// Username and Password are the basic auth credentials, none are sent if Username is empty
+		Username          string                  `yaml:"username"`
+		Password          string                  `yaml:"password"`
+		AWSRequestSigning AWSRequestSigningConfig `yaml:"awsRequestSigning"`
+		CircuitBreaker    CircuitBreakerConfig    `yaml:"circuitBreaker"`
+	}
+
+	// AWSRequestSigningConfig configures the signing of requests to Amazon Elasticsearch Service
+	AWSRequestSigningConfig struct {
+		Enable bool `yaml:"enable"`
+		// Region of the domain, AWS_REGION is used if empty
+		Region string `yaml:"region"`
+		// AccessKeyID, SecretAccessKey and SessionToken are the credentials requests are signed with,
+		// AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN are used if AccessKeyID is empty
+		AccessKeyID     string `yaml:"accessKeyID"`
+		SecretAccessKey string `yaml:"secretAccessKey"`
+		SessionToken    string `yaml:"sessionToken"`
+	}
+
+	// CircuitBreakerConfig configures the circuit breaker around the ElasticSearch client
+	CircuitBreakerConfig struct {
+		// MaxConsecutiveFailures is the number of consecutive failed requests which opens the circuit,
+		// 0 disables the circuit breaker
+		MaxConsecutiveFailures int `yaml:"maxConsecutiveFailures"`
+		// OpenTimeout is how long the circuit stays open before a probe request is let through,
+		// it doubles on every failed probe up to MaxOpenTimeout
+		OpenTimeout time.Duration `yaml:"openTimeout"`
+		// MaxOpenTimeout is the max time the circuit stays open before a probe request is let through
+		MaxOpenTimeout time.Duration `yaml:"maxOpenTimeout"`
+		// RequestTimeout is the timeout of requests whose context has no deadline, 0 means no timeout
+		RequestTimeout time.Duration `yaml:"requestTimeout"`
 	}
 )
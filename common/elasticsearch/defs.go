@@ -20,7 +20,13 @@
 
 package elasticsearch
 
-import "github.com/uber/cadence/.gen/go/indexer"
+import (
+	"sort"
+	"strings"
+
+	"github.com/uber/cadence/.gen/go/indexer"
+	"github.com/uber/cadence/.gen/go/shared"
+)
 
 // All legal fields allowed in elastic search index
 const (
@@ -28,12 +34,23 @@ const (
 	WorkflowID    = "WorkflowID"
 	RunID         = "RunID"
 	WorkflowType  = "WorkflowType"
+	TaskList      = "TaskList"
 	StartTime     = "StartTime"
 	ExecutionTime = "ExecutionTime"
 	CloseTime     = "CloseTime"
 	CloseStatus   = "CloseStatus"
 	HistoryLength = "HistoryLength"
 
+	ParentWorkflowID = "ParentWorkflowID"
+	ParentRunID      = "ParentRunID"
+
+	TerminateCause    = "TerminateCause"
+	TerminateReason   = "TerminateReason"
+	TerminateIdentity = "TerminateIdentity"
+	TerminateDetails  = "TerminateDetails"
+
+	Labels = "Labels"
+
 	KafkaKey = "KafkaKey"
 )
 
@@ -42,20 +59,39 @@ var (
 	FieldTypeString = indexer.FieldTypeString
 	FieldTypeInt    = indexer.FieldTypeInt
 	FieldTypeBool   = indexer.FieldTypeBool
+
+	FieldTypeStringList = indexer.FieldTypeStringList
 )
 
 var (
 	validFieldName = map[string]interface{}{
-		DomainID:      struct{}{},
-		WorkflowID:    struct{}{},
-		RunID:         struct{}{},
-		WorkflowType:  struct{}{},
-		StartTime:     struct{}{},
-		ExecutionTime: struct{}{},
-		CloseTime:     struct{}{},
-		CloseStatus:   struct{}{},
-		HistoryLength: struct{}{},
-		KafkaKey:      struct{}{},
+		DomainID:          struct{}{},
+		WorkflowID:        struct{}{},
+		RunID:             struct{}{},
+		WorkflowType:      struct{}{},
+		TaskList:          struct{}{},
+		StartTime:         struct{}{},
+		ExecutionTime:     struct{}{},
+		CloseTime:         struct{}{},
+		CloseStatus:       struct{}{},
+		HistoryLength:     struct{}{},
+		ParentWorkflowID:  struct{}{},
+		ParentRunID:       struct{}{},
+		TerminateCause:    struct{}{},
+		TerminateReason:   struct{}{},
+		TerminateIdentity: struct{}{},
+		TerminateDetails:  struct{}{},
+		Labels:            struct{}{},
+		KafkaKey:          struct{}{},
+	}
+
+	esTypeOfIndexedValueType = map[shared.IndexedValueType]string{
+		shared.IndexedValueTypeString:   "text",
+		shared.IndexedValueTypeKeyword:  "keyword",
+		shared.IndexedValueTypeInt:      "long",
+		shared.IndexedValueTypeDouble:   "double",
+		shared.IndexedValueTypeBool:     "boolean",
+		shared.IndexedValueTypeDatetime: "date",
 	}
 )
 
@@ -64,3 +100,54 @@ func IsFieldNameValid(name string) bool {
 	_, ok := validFieldName[name]
 	return ok
 }
+
+// GetDefaultValidSearchAttributes returns the search attribute keys visibility queries can use by default,
+// mapped to the int value of their shared.IndexedValueType as expected by the dynamic config
+func GetDefaultValidSearchAttributes() map[string]interface{} {
+	return map[string]interface{}{
+		WorkflowID:    int(shared.IndexedValueTypeKeyword),
+		RunID:         int(shared.IndexedValueTypeKeyword),
+		WorkflowType:  int(shared.IndexedValueTypeKeyword),
+		TaskList:      int(shared.IndexedValueTypeKeyword),
+		StartTime:     int(shared.IndexedValueTypeInt),
+		ExecutionTime: int(shared.IndexedValueTypeInt),
+		CloseTime:     int(shared.IndexedValueTypeInt),
+		CloseStatus:   int(shared.IndexedValueTypeInt),
+		HistoryLength: int(shared.IndexedValueTypeInt),
+		Labels:        int(shared.IndexedValueTypeKeyword),
+	}
+}
+
+// GetESTypeOfIndexedValueType returns the ElasticSearch field type a search attribute of the given type is indexed as
+func GetESTypeOfIndexedValueType(valueType shared.IndexedValueType) (string, bool) {
+	esType, ok := esTypeOfIndexedValueType[valueType]
+	return esType, ok
+}
+
+// LabelToKeyword returns the keyword a workflow label is indexed as
+func LabelToKeyword(key, value string) string {
+	return key + "=" + value
+}
+
+// LabelsToKeywords returns the sorted keywords the labels of a workflow are indexed as
+func LabelsToKeywords(labels map[string]string) []string {
+	keywords := make([]string, 0, len(labels))
+	for key, value := range labels {
+		keywords = append(keywords, LabelToKeyword(key, value))
+	}
+	sort.Strings(keywords)
+	return keywords
+}
+
+// KeywordsToLabels returns the labels of a workflow from the keywords they are indexed as
+func KeywordsToLabels(keywords []string) map[string]string {
+	labels := make(map[string]string, len(keywords))
+	for _, keyword := range keywords {
+		// label keys cannot contain "=", values can
+		parts := strings.SplitN(keyword, "=", 2)
+		if len(parts) == 2 {
+			labels[parts[0]] = parts[1]
+		}
+	}
+	return labels
+}
@@ -31,6 +31,87 @@ type Client struct {
 	mock.Mock
 }
 
+// Bulk provides a mock function with given fields: ctx, requests
+func (_m *Client) Bulk(ctx context.Context, requests []elastic.BulkableRequest) (*elastic.BulkResponse, error) {
+	ret := _m.Called(ctx, requests)
+
+	var r0 *elastic.BulkResponse
+	if rf, ok := ret.Get(0).(func(context.Context, []elastic.BulkableRequest) *elastic.BulkResponse); ok {
+		r0 = rf(ctx, requests)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*elastic.BulkResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []elastic.BulkableRequest) error); ok {
+		r1 = rf(ctx, requests)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Count provides a mock function with given fields: ctx, index, query
+func (_m *Client) Count(ctx context.Context, index string, query elastic.Query) (int64, error) {
+	ret := _m.Called(ctx, index, query)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, string, elastic.Query) int64); ok {
+		r0 = rf(ctx, index, query)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, elastic.Query) error); ok {
+		r1 = rf(ctx, index, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteByQuery provides a mock function with given fields: ctx, p
+func (_m *Client) DeleteByQuery(ctx context.Context, p *elasticsearch.DeleteByQueryParameters) (*elastic.BulkIndexByScrollResponse, error) {
+	ret := _m.Called(ctx, p)
+
+	var r0 *elastic.BulkIndexByScrollResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *elasticsearch.DeleteByQueryParameters) *elastic.BulkIndexByScrollResponse); ok {
+		r0 = rf(ctx, p)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*elastic.BulkIndexByScrollResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *elasticsearch.DeleteByQueryParameters) error); ok {
+		r1 = rf(ctx, p)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PutMapping provides a mock function with given fields: ctx, index, key, valueType
+func (_m *Client) PutMapping(ctx context.Context, index string, key string, valueType string) error {
+	ret := _m.Called(ctx, index, key, valueType)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, index, key, valueType)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RunBulkProcessor provides a mock function with given fields: ctx, p
 func (_m *Client) RunBulkProcessor(ctx context.Context, p *elasticsearch.BulkProcessorParameters) (*elastic.BulkProcessor, error) {
 	ret := _m.Called(ctx, p)
@@ -0,0 +1,70 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package common
+
+import (
+	"context"
+	"time"
+)
+
+type hedgedResult struct {
+	value interface{}
+	err   error
+}
+
+// HedgedCall invokes op and, if it has neither succeeded nor failed after delay, invokes it a second time
+// concurrently. The value of the first attempt to succeed is returned and the other attempt is canceled,
+// the error of the last attempt is returned if both fail. A first attempt failing before the delay is not
+// hedged, retries are left to the retryable clients. op must be idempotent, a delay <= 0 disables hedging.
+func HedgedCall(ctx context.Context, delay time.Duration, op func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	if delay <= 0 {
+		return op(ctx)
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan hedgedResult, 2)
+	call := func() {
+		value, err := op(ctx)
+		results <- hedgedResult{value: value, err: err}
+	}
+	go call()
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	pending := 1
+	for {
+		select {
+		case result := <-results:
+			pending--
+			if result.err == nil || pending == 0 {
+				return result.value, result.err
+			}
+		case <-timer.C:
+			pending++
+			go call()
+		}
+	}
+}
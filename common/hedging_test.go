@@ -0,0 +1,80 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package common
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHedgedCall_Disabled(t *testing.T) {
+	var calls int32
+	value, err := HedgedCall(context.Background(), 0, func(ctx context.Context) (interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		return "value", nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, "value", value)
+	require.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestHedgedCall_SecondAttemptWins(t *testing.T) {
+	var calls int32
+	value, err := HedgedCall(context.Background(), 10*time.Millisecond, func(ctx context.Context) (interface{}, error) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			// the first attempt is stuck until it is canceled
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		return "hedged", nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, "hedged", value)
+	require.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestHedgedCall_FastFailureIsNotHedged(t *testing.T) {
+	var calls int32
+	_, err := HedgedCall(context.Background(), time.Second, func(ctx context.Context) (interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		return nil, errors.New("failed")
+	})
+	require.Error(t, err)
+	require.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestHedgedCall_BothFail(t *testing.T) {
+	var calls int32
+	_, err := HedgedCall(context.Background(), 10*time.Millisecond, func(ctx context.Context) (interface{}, error) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			time.Sleep(50 * time.Millisecond)
+			return nil, errors.New("first")
+		}
+		return nil, errors.New("second")
+	})
+	require.EqualError(t, err, "first")
+	require.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
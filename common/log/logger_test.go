@@ -33,6 +33,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/uber-common/bark"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 
 	"github.com/uber/cadence/common/log/tag"
 	"github.com/uber/cadence/common/service/dynamicconfig"
@@ -102,3 +103,23 @@ func TestThrottleLogger(t *testing.T) {
 	fmt.Println(out, lineNum)
 	assert.Equal(t, out, `{"level":"info","msg":"test info","error":"test error","wf-action":"add-workflowexecution-started-event","logging-call-at":"logger_test.go:`+lineNum+`"}`+"\n")
 }
+
+func TestThrottleLoggerWithKeys(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	zapLogger := zap.New(core)
+
+	logger := NewThrottledLoggerWithKeys(zapLogger, dynamicconfig.GetIntPropertyFn(10), "wf-domain-id")
+	domainLogger := logger.WithTags(tag.WorkflowDomainID("noisy-domain"))
+	for i := 0; i < 5; i++ {
+		domainLogger.Error("noisy error")
+	}
+	logger.Error("other domain error", tag.WorkflowDomainID("other-domain"))
+	logger.Error("error without domain")
+	logger.Error("another error without domain")
+
+	entries := logs.All()
+	assert.Equal(t, 3, len(entries))
+	assert.Equal(t, "noisy error", entries[0].Message)
+	assert.Equal(t, "other domain error", entries[1].Message)
+	assert.Equal(t, "error without domain", entries[2].Message)
+}
@@ -21,9 +21,13 @@
 package log
 
 import (
+	"fmt"
+	"sort"
+	"strings"
 	"sync/atomic"
 
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 
 	"github.com/uber/cadence/common/clock"
 	"github.com/uber/cadence/common/log/tag"
@@ -38,12 +42,20 @@ type throttledLogger struct {
 	cfg struct {
 		rps dynamicconfig.IntPropertyFn
 	}
+	// keyTags are the tag keys whose values select a separate token bucket
+	keyTags []string
+	// keyValues are the values of key tags set through WithTags
+	keyValues map[string]string
+	keyedTB   tokenbucket.KeyedTokenBucket
 }
 
 var _ Logger = (*throttledLogger)(nil)
 
 const skipForThrottleLogger = 6
 
+// maxThrottledLoggerKeys is the max number of separately throttled keys
+const maxThrottledLoggerKeys = 1000
+
 // NewThrottledLogger returns an implementation of logger that throttles the
 // log messages being emitted. The underlying implementation uses a token bucket
 // ratelimiter and stops emitting logs once the bucket runs out of tokens
@@ -65,32 +77,44 @@ func NewThrottledLogger(zapLogger *zap.Logger, rps dynamicconfig.IntPropertyFn)
 	return tl
 }
 
+// NewThrottledLoggerWithKeys returns a throttled logger which throttles the log
+// messages separately for every distinct combination of values of the given tag
+// keys (e.g. wf-domain-id or error), so that one noisy key does not suppress the
+// logs of other keys. Messages without any of the tags share a single bucket
+func NewThrottledLoggerWithKeys(zapLogger *zap.Logger, rps dynamicconfig.IntPropertyFn, keyTags ...string) Logger {
+	tl := NewThrottledLogger(zapLogger, rps).(*throttledLogger)
+	tl.keyTags = keyTags
+	tl.keyValues = make(map[string]string)
+	tl.keyedTB = tokenbucket.NewKeyedTokenBucket(rps, maxThrottledLoggerKeys, clock.NewRealTimeSource())
+	return tl
+}
+
 func (tl *throttledLogger) Debug(msg string, tags ...tag.Tag) {
-	tl.rateLimit(func() {
+	tl.rateLimit(tags, func() {
 		tl.log.Debug(msg, tags...)
 	})
 }
 
 func (tl *throttledLogger) Info(msg string, tags ...tag.Tag) {
-	tl.rateLimit(func() {
+	tl.rateLimit(tags, func() {
 		tl.log.Info(msg, tags...)
 	})
 }
 
 func (tl *throttledLogger) Warn(msg string, tags ...tag.Tag) {
-	tl.rateLimit(func() {
+	tl.rateLimit(tags, func() {
 		tl.log.Warn(msg, tags...)
 	})
 }
 
 func (tl *throttledLogger) Error(msg string, tags ...tag.Tag) {
-	tl.rateLimit(func() {
+	tl.rateLimit(tags, func() {
 		tl.log.Error(msg, tags...)
 	})
 }
 
 func (tl *throttledLogger) Fatal(msg string, tags ...tag.Tag) {
-	tl.rateLimit(func() {
+	tl.rateLimit(tags, func() {
 		tl.log.Fatal(msg, tags...)
 	})
 }
@@ -109,17 +133,76 @@ func (tl *throttledLogger) WithTags(tags ...tag.Tag) Logger {
 		},
 	}
 	result.cfg.rps = tl.cfg.rps
+	if len(tl.keyTags) > 0 {
+		result.keyTags = tl.keyTags
+		result.keyedTB = tl.keyedTB
+		result.keyValues = tl.mergeKeyValues(tags)
+	}
 	return result
 }
 
-func (tl *throttledLogger) rateLimit(f func()) {
-	tl.resetRateIfChanged()
-	ok, _ := tl.tb.TryConsume(1)
+func (tl *throttledLogger) rateLimit(tags []tag.Tag, f func()) {
+	var ok bool
+	if key := tl.throttleKey(tags); len(key) > 0 {
+		ok, _ = tl.keyedTB.TryConsume(key, 1)
+	} else {
+		tl.resetRateIfChanged()
+		ok, _ = tl.tb.TryConsume(1)
+	}
 	if ok {
 		f()
 	}
 }
 
+// throttleKey returns the values of the key tags set on this logger or passed in
+func (tl *throttledLogger) throttleKey(tags []tag.Tag) string {
+	if len(tl.keyTags) == 0 {
+		return ""
+	}
+	keyValues := tl.mergeKeyValues(tags)
+	if len(keyValues) == 0 {
+		return ""
+	}
+
+	values := make([]string, 0, len(keyValues))
+	for key, value := range keyValues {
+		values = append(values, key+"="+value)
+	}
+	sort.Strings(values)
+	return strings.Join(values, ",")
+}
+
+// mergeKeyValues returns the key tag values of this logger overridden by the given tags
+func (tl *throttledLogger) mergeKeyValues(tags []tag.Tag) map[string]string {
+	result := make(map[string]string, len(tl.keyValues))
+	for key, value := range tl.keyValues {
+		result[key] = value
+	}
+	for i := range tags {
+		field := tags[i].Field()
+		for _, key := range tl.keyTags {
+			if field.Key == key {
+				result[key] = fieldValue(field)
+			}
+		}
+	}
+	return result
+}
+
+// fieldValue returns the string value of a field, errors are keyed by their type
+func fieldValue(field zap.Field) string {
+	switch {
+	case field.Type == zapcore.ErrorType:
+		return fmt.Sprintf("%T", field.Interface)
+	case field.Interface != nil:
+		return fmt.Sprintf("%v", field.Interface)
+	case len(field.String) > 0:
+		return field.String
+	default:
+		return fmt.Sprintf("%v", field.Integer)
+	}
+}
+
 // resetLimitIfChanged resets the underlying token bucket if the
 // current rps quota is different from the actual rps quota obtained
 // from dynamic config
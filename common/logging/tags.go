@@ -58,6 +58,7 @@ const (
 	TagTaskType                   = "task-type"
 	TagSourceCluster              = "source-cluster"
 	TagPrevActiveCluster          = "prev-active-cluster"
+	TagClusterName                = "cluster-name"
 	TagTopicName                  = "topic-name"
 	TagConsumerName               = "consumer-name"
 	TagPartition                  = "partition"
@@ -91,11 +92,17 @@ const (
 	TagHistorySize                = "history-size"
 	TagHistorySizeBytes           = "history-size-bytes"
 	TagEventCount                 = "event-count"
+	TagMigratedCount              = "migrated-count"
+	TagVerifiedCount              = "verified-count"
+	TagFailedCount                = "failed-count"
 	TagESRequest                  = "es-request"
 	TagESKey                      = "es-mapping-key"
 	TagESField                    = "es-field"
 	TagContextTimeout             = "context-timeout"
 	TagHandlerName                = "handler-name"
+	TagRequestID                  = "request-id"
+	TagCallerIdentity             = "caller-identity"
+	TagConcurrencyGroup           = "concurrency-group"
 
 	// workflow logging tag values
 	// TagWorkflowComponent Values
@@ -116,6 +123,13 @@ const (
 	TagValueIndexerESProcessorComponent       = "indexer-es-processor"
 	TagValueESVisibilityManager               = "es-visibility-manager"
 	TagValueArchiverComponent                 = "archiver"
+	TagValueOpenWorkflowCounterComponent      = "open-workflow-counter"
+	TagValueDomainChangeNotifierComponent     = "domain-change-notifier"
+	TagValueClusterMetadataRefresherComponent = "cluster-metadata-refresher"
+	TagValueArchivalMigratorComponent         = "archival-migrator"
+	TagValueWorkflowMigratorComponent         = "workflow-migrator"
+	TagValueConcurrencyGroupComponent         = "concurrency-group"
+	TagValueCanaryComponent                   = "canary"
 
 	// TagHistoryBuilderAction values
 	TagValueActionWorkflowStarted                 = "add-workflowexecution-started-event"
@@ -188,8 +202,9 @@ const (
 	TagArchiveRequestCloseFailoverVersion = "archive-request-close-failover-version"
 
 	// archival tags (blob tags)
-	TagBucket  = "bucket"
-	TagBlobKey = "blob-key"
+	TagBucket       = "bucket"
+	TagTargetBucket = "target-bucket"
+	TagBlobKey      = "blob-key"
 
 	// archival tags (other tags)
 	TagClusterArchivalStatus    = "cluster-archival-status"
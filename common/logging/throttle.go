@@ -21,6 +21,8 @@
 package logging
 
 import (
+	"fmt"
+	"strings"
 	"sync/atomic"
 
 	"github.com/uber-common/bark"
@@ -36,10 +38,16 @@ type throttledLogger struct {
 	cfg struct {
 		rps dynamicconfig.IntPropertyFn
 	}
+	// keyFields are the fields whose values select a separate token bucket
+	keyFields []string
+	keyedTB   tokenbucket.KeyedTokenBucket
 }
 
 var _ bark.Logger = (*throttledLogger)(nil)
 
+// maxThrottledLoggerKeys is the max number of separately throttled keys
+const maxThrottledLoggerKeys = 1000
+
 // NewThrottledLogger returns an implementation of bark logger that throttles the
 // log messages being emitted. The underlying implementation uses a token bucket
 // ratelimiter and stops emitting logs once the bucket runs out of tokens
@@ -57,6 +65,19 @@ func NewThrottledLogger(log bark.Logger, rps dynamicconfig.IntPropertyFn) bark.L
 	return tl
 }
 
+// NewThrottledLoggerWithKeys returns a throttled bark logger which throttles the
+// log messages separately for every distinct combination of values of the given
+// fields (e.g. domain ID or error), so that one noisy key does not suppress the
+// logs of other keys. Messages without any of the fields share a single bucket
+//
+// Fatal/Panic logs are always emitted without any throttling
+func NewThrottledLoggerWithKeys(log bark.Logger, rps dynamicconfig.IntPropertyFn, keyFields ...string) bark.Logger {
+	tl := NewThrottledLogger(log, rps).(*throttledLogger)
+	tl.keyFields = keyFields
+	tl.keyedTB = tokenbucket.NewKeyedTokenBucket(rps, maxThrottledLoggerKeys, clock.NewRealTimeSource())
+	return tl
+}
+
 func (tl *throttledLogger) Debug(args ...interface{}) {
 	tl.rateLimit(func() {
 		tl.log.Debug(args)
@@ -160,17 +181,50 @@ func (tl *throttledLogger) clone(log bark.Logger) bark.Logger {
 		log: log,
 	}
 	result.cfg.rps = tl.cfg.rps
+	result.keyFields = tl.keyFields
+	result.keyedTB = tl.keyedTB
 	return result
 }
 
 func (tl *throttledLogger) rateLimit(f func()) {
-	tl.resetRateIfChanged()
-	ok, _ := tl.tb.TryConsume(1)
+	var ok bool
+	if key := tl.throttleKey(); len(key) > 0 {
+		ok, _ = tl.keyedTB.TryConsume(key, 1)
+	} else {
+		tl.resetRateIfChanged()
+		ok, _ = tl.tb.TryConsume(1)
+	}
 	if ok {
 		f()
 	}
 }
 
+// throttleKey returns the values of the key fields set on this logger,
+// errors are keyed by their type rather than their message
+func (tl *throttledLogger) throttleKey() string {
+	if len(tl.keyFields) == 0 {
+		return ""
+	}
+	fields := tl.log.Fields()
+	if len(fields) == 0 {
+		return ""
+	}
+
+	var values []string
+	for _, key := range tl.keyFields {
+		value, ok := fields[key]
+		if !ok {
+			continue
+		}
+		if err, ok := value.(error); ok {
+			values = append(values, fmt.Sprintf("%v=%T", key, err))
+		} else {
+			values = append(values, fmt.Sprintf("%v=%v", key, value))
+		}
+	}
+	return strings.Join(values, ",")
+}
+
 // resetLimitIfChanged resets the underlying token bucket if the
 // current rps quota is different from the actual rps quota obtained
 // from dynamic config
@@ -0,0 +1,74 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package membership
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+type (
+	dnsPeerProvider struct {
+		records map[string]string
+		lookup  func(name string) ([]*net.SRV, error)
+	}
+)
+
+var _ PeerProvider = (*dnsPeerProvider)(nil)
+
+// NewDNSPeerProvider returns a peer provider which resolves the hosts of each
+// service from DNS SRV records. records maps a cadence service name to the fully
+// qualified SRV record name, e.g. _tchannel._tcp.cadence-history.example.com
+func NewDNSPeerProvider(records map[string]string) PeerProvider {
+	return &dnsPeerProvider{
+		records: records,
+		lookup: func(name string) ([]*net.SRV, error) {
+			_, srvs, err := net.LookupSRV("", "", name)
+			return srvs, err
+		},
+	}
+}
+
+func (p *dnsPeerProvider) GetMembers(service string) ([]string, error) {
+	record, ok := p.records[service]
+	if !ok {
+		return nil, ErrUnknownService
+	}
+
+	srvs, err := p.lookup(record)
+	if err != nil {
+		return nil, fmt.Errorf("lookup of SRV record %v failed: %v", record, err)
+	}
+
+	addrs := make([]string, 0, len(srvs))
+	for _, srv := range srvs {
+		ips, err := net.LookupHost(strings.TrimSuffix(srv.Target, "."))
+		if err != nil {
+			return nil, fmt.Errorf("lookup of SRV target %v failed: %v", srv.Target, err)
+		}
+		for _, ip := range ips {
+			addrs = append(addrs, net.JoinHostPort(ip, strconv.Itoa(int(srv.Port))))
+		}
+	}
+	return addrs, nil
+}
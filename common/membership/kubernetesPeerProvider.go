@@ -0,0 +1,173 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package membership
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	kubernetesServiceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+	kubernetesRequestTimeout    = 10 * time.Second
+)
+
+type (
+	// KubernetesPeerProviderConfig contains the config to look up the hosts of
+	// cadence services from Kubernetes endpoints
+	KubernetesPeerProviderConfig struct {
+		// Namespace of the endpoints, defaults to the namespace of the pod
+		Namespace string
+		// Endpoints maps a cadence service name to the name of its Kubernetes endpoints object
+		Endpoints map[string]string
+		// PortName is the name of the endpoint port serving tchannel, first port is used if empty
+		PortName string
+	}
+
+	kubernetesPeerProvider struct {
+		apiAddress string
+		token      string
+		namespace  string
+		endpoints  map[string]string
+		portName   string
+		client     *http.Client
+	}
+
+	kubernetesEndpoints struct {
+		Subsets []kubernetesEndpointSubset `json:"subsets"`
+	}
+
+	kubernetesEndpointSubset struct {
+		Addresses []struct {
+			IP string `json:"ip"`
+		} `json:"addresses"`
+		Ports []struct {
+			Name string `json:"name"`
+			Port int    `json:"port"`
+		} `json:"ports"`
+	}
+)
+
+var _ PeerProvider = (*kubernetesPeerProvider)(nil)
+
+// NewKubernetesPeerProvider returns a peer provider which reads the ready addresses of each
+// service from the Kubernetes API, using the service account the pod is running with
+func NewKubernetesPeerProvider(cfg KubernetesPeerProviderConfig) (PeerProvider, error) {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if len(host) == 0 || len(port) == 0 {
+		return nil, errors.New("kubernetes peer provider must run inside a kubernetes cluster")
+	}
+
+	token, err := ioutil.ReadFile(kubernetesServiceAccountDir + "/token")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read service account token: %v", err)
+	}
+	ca, err := ioutil.ReadFile(kubernetesServiceAccountDir + "/ca.crt")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read service account ca: %v", err)
+	}
+	certPool := x509.NewCertPool()
+	if !certPool.AppendCertsFromPEM(ca) {
+		return nil, errors.New("failed to parse service account ca")
+	}
+
+	namespace := cfg.Namespace
+	if len(namespace) == 0 {
+		ns, err := ioutil.ReadFile(kubernetesServiceAccountDir + "/namespace")
+		if err != nil {
+			return nil, fmt.Errorf("failed to read pod namespace: %v", err)
+		}
+		namespace = strings.TrimSpace(string(ns))
+	}
+
+	return &kubernetesPeerProvider{
+		apiAddress: "https://" + net.JoinHostPort(host, port),
+		token:      strings.TrimSpace(string(token)),
+		namespace:  namespace,
+		endpoints:  cfg.Endpoints,
+		portName:   cfg.PortName,
+		client: &http.Client{
+			Timeout:   kubernetesRequestTimeout,
+			Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: certPool}},
+		},
+	}, nil
+}
+
+func (p *kubernetesPeerProvider) GetMembers(service string) ([]string, error) {
+	name, ok := p.endpoints[service]
+	if !ok {
+		return nil, ErrUnknownService
+	}
+
+	url := fmt.Sprintf("%v/api/v1/namespaces/%v/endpoints/%v", p.apiAddress, p.namespace, name)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+p.token)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get endpoints %v: %v", name, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get endpoints %v: status %v", name, resp.Status)
+	}
+
+	var endpoints kubernetesEndpoints
+	if err := json.NewDecoder(resp.Body).Decode(&endpoints); err != nil {
+		return nil, fmt.Errorf("failed to decode endpoints %v: %v", name, err)
+	}
+	return endpoints.addresses(p.portName), nil
+}
+
+// addresses returns the ip:port of every ready address serving the given port
+func (e *kubernetesEndpoints) addresses(portName string) []string {
+	var addrs []string
+	for _, subset := range e.Subsets {
+		port := 0
+		for _, p := range subset.Ports {
+			if len(portName) == 0 || p.Name == portName {
+				port = p.Port
+				break
+			}
+		}
+		if port == 0 {
+			continue
+		}
+		for _, addr := range subset.Addresses {
+			addrs = append(addrs, net.JoinHostPort(addr.IP, strconv.Itoa(port)))
+		}
+	}
+	return addrs
+}
@@ -0,0 +1,311 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package membership
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dgryski/go-farm"
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/common"
+	"github.com/uber/ringpop-go/hashring"
+)
+
+type (
+	// PeerProvider returns the addresses of all the hosts of a cadence service. It is
+	// used by membership monitors which build the hashring from an external source of
+	// truth (e.g. DNS or Kubernetes) instead of ringpop gossip
+	PeerProvider interface {
+		// GetMembers returns the ip:port addresses of the hosts of the given service
+		GetMembers(service string) ([]string, error)
+	}
+
+	peerProviderMonitor struct {
+		sync.Mutex
+		started  bool
+		stopped  bool
+		self     *HostInfo
+		provider PeerProvider
+		rings    map[string]*peerProviderServiceResolver
+		logger   bark.Logger
+	}
+
+	peerProviderServiceResolver struct {
+		service         string
+		provider        PeerProvider
+		refreshInterval time.Duration
+		shutdownCh      chan struct{}
+		shutdownWG      sync.WaitGroup
+		logger          bark.Logger
+
+		ringLock sync.RWMutex
+		ring     *hashring.HashRing
+		members  map[string]struct{}
+
+		listenerLock sync.RWMutex
+		listeners    map[string]chan<- *ChangedEvent
+	}
+)
+
+var _ Monitor = (*peerProviderMonitor)(nil)
+var _ ServiceResolver = (*peerProviderServiceResolver)(nil)
+
+// NewPeerProviderMonitor returns a membership monitor whose rings are periodically rebuilt
+// from the hosts returned by the given peer provider. selfAddress is the ip:port this
+// host is reachable at by the other hosts of the cluster
+func NewPeerProviderMonitor(
+	selfService string,
+	selfAddress string,
+	services []string,
+	provider PeerProvider,
+	refreshInterval time.Duration,
+	logger bark.Logger,
+) Monitor {
+	if refreshInterval <= 0 {
+		refreshInterval = defaultRefreshInterval
+	}
+	monitor := &peerProviderMonitor{
+		self:     NewHostInfo(selfAddress, map[string]string{RoleKey: selfService}),
+		provider: provider,
+		rings:    make(map[string]*peerProviderServiceResolver),
+		logger:   logger,
+	}
+	for _, service := range services {
+		monitor.rings[service] = newPeerProviderServiceResolver(service, provider, refreshInterval, logger)
+	}
+	return monitor
+}
+
+func (m *peerProviderMonitor) Start() error {
+	m.Lock()
+	defer m.Unlock()
+
+	if m.started {
+		return nil
+	}
+
+	for service, ring := range m.rings {
+		if err := ring.Start(); err != nil {
+			m.logger.WithField("service", service).Error("Failed to initialize ring.")
+			return err
+		}
+	}
+
+	m.started = true
+	return nil
+}
+
+func (m *peerProviderMonitor) Stop() {
+	m.Lock()
+	defer m.Unlock()
+
+	if m.stopped {
+		return
+	}
+
+	for _, ring := range m.rings {
+		ring.Stop()
+	}
+	m.stopped = true
+}
+
+func (m *peerProviderMonitor) WhoAmI() (*HostInfo, error) {
+	return m.self, nil
+}
+
+func (m *peerProviderMonitor) GetResolver(service string) (ServiceResolver, error) {
+	ring, found := m.rings[service]
+	if !found {
+		return nil, ErrUnknownService
+	}
+	return ring, nil
+}
+
+func (m *peerProviderMonitor) Lookup(service string, key string) (*HostInfo, error) {
+	ring, err := m.GetResolver(service)
+	if err != nil {
+		return nil, err
+	}
+	return ring.Lookup(key)
+}
+
+func (m *peerProviderMonitor) AddListener(service string, name string, notifyChannel chan<- *ChangedEvent) error {
+	ring, err := m.GetResolver(service)
+	if err != nil {
+		return err
+	}
+	return ring.AddListener(name, notifyChannel)
+}
+
+func (m *peerProviderMonitor) RemoveListener(service string, name string) error {
+	ring, err := m.GetResolver(service)
+	if err != nil {
+		return err
+	}
+	return ring.RemoveListener(name)
+}
+
+func newPeerProviderServiceResolver(
+	service string,
+	provider PeerProvider,
+	refreshInterval time.Duration,
+	logger bark.Logger,
+) *peerProviderServiceResolver {
+	return &peerProviderServiceResolver{
+		service:         service,
+		provider:        provider,
+		refreshInterval: refreshInterval,
+		logger:          logger.WithFields(bark.Fields{"component": "ServiceResolver", RoleKey: service}),
+		ring:            hashring.New(farm.Fingerprint32, replicaPoints),
+		members:         make(map[string]struct{}),
+		listeners:       make(map[string]chan<- *ChangedEvent),
+		shutdownCh:      make(chan struct{}),
+	}
+}
+
+// Start loads the initial members and starts refreshing them in the background
+func (r *peerProviderServiceResolver) Start() error {
+	if err := r.refresh(); err != nil {
+		return err
+	}
+	r.shutdownWG.Add(1)
+	go r.refreshRingWorker()
+	return nil
+}
+
+// Stop stops the background refresh
+func (r *peerProviderServiceResolver) Stop() {
+	close(r.shutdownCh)
+	if success := common.AwaitWaitGroup(&r.shutdownWG, time.Minute); !success {
+		r.logger.Warn("service resolver timed out on shutdown.")
+	}
+}
+
+// Lookup finds the host in the ring responsible for serving the given key
+func (r *peerProviderServiceResolver) Lookup(key string) (*HostInfo, error) {
+	r.ringLock.RLock()
+	defer r.ringLock.RUnlock()
+	addr, found := r.ring.Lookup(key)
+	if !found {
+		return nil, ErrInsufficientHosts
+	}
+	return NewHostInfo(addr, r.getLabelsMap()), nil
+}
+
+func (r *peerProviderServiceResolver) AddListener(name string, notifyChannel chan<- *ChangedEvent) error {
+	r.listenerLock.Lock()
+	defer r.listenerLock.Unlock()
+	_, ok := r.listeners[name]
+	if ok {
+		return ErrListenerAlreadyExist
+	}
+	r.listeners[name] = notifyChannel
+	return nil
+}
+
+func (r *peerProviderServiceResolver) RemoveListener(name string) error {
+	r.listenerLock.Lock()
+	defer r.listenerLock.Unlock()
+	delete(r.listeners, name)
+	return nil
+}
+
+// refresh rebuilds the ring if the members returned by the provider changed
+// and notifies the listeners about the difference
+func (r *peerProviderServiceResolver) refresh() error {
+	addrs, err := r.provider.GetMembers(r.service)
+	if err != nil {
+		r.logger.Warnf("Error during peer provider refresh. Error: %v", err)
+		return err
+	}
+	sort.Strings(addrs)
+
+	members := make(map[string]struct{}, len(addrs))
+	for _, addr := range addrs {
+		members[addr] = struct{}{}
+	}
+
+	r.ringLock.Lock()
+	event := &ChangedEvent{}
+	for addr := range members {
+		if _, ok := r.members[addr]; !ok {
+			event.HostsAdded = append(event.HostsAdded, NewHostInfo(addr, r.getLabelsMap()))
+		}
+	}
+	for addr := range r.members {
+		if _, ok := members[addr]; !ok {
+			event.HostsRemoved = append(event.HostsRemoved, NewHostInfo(addr, r.getLabelsMap()))
+		}
+	}
+	if len(event.HostsAdded) == 0 && len(event.HostsRemoved) == 0 {
+		r.ringLock.Unlock()
+		return nil
+	}
+
+	ring := hashring.New(farm.Fingerprint32, replicaPoints)
+	for _, addr := range addrs {
+		ring.AddMembers(NewHostInfo(addr, r.getLabelsMap()))
+	}
+	r.ring = ring
+	r.members = members
+	r.ringLock.Unlock()
+
+	r.logger.Debugf("Current members: %v", addrs)
+	r.emitEvent(event)
+	return nil
+}
+
+func (r *peerProviderServiceResolver) emitEvent(event *ChangedEvent) {
+	r.listenerLock.RLock()
+	defer r.listenerLock.RUnlock()
+
+	for name, ch := range r.listeners {
+		select {
+		case ch <- event:
+		default:
+			r.logger.WithFields(bark.Fields{`listenerName`: name}).Error("Failed to send listener notification, channel full")
+		}
+	}
+}
+
+func (r *peerProviderServiceResolver) refreshRingWorker() {
+	defer r.shutdownWG.Done()
+
+	refreshTicker := time.NewTicker(r.refreshInterval)
+	defer refreshTicker.Stop()
+
+	for {
+		select {
+		case <-r.shutdownCh:
+			return
+		case <-refreshTicker.C:
+			r.refresh()
+		}
+	}
+}
+
+func (r *peerProviderServiceResolver) getLabelsMap() map[string]string {
+	labels := make(map[string]string)
+	labels[RoleKey] = r.service
+	return labels
+}
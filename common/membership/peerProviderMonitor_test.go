@@ -0,0 +1,140 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package membership
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+)
+
+type (
+	PeerProviderMonitorSuite struct {
+		*require.Assertions // override suite.Suite.Assertions with require.Assertions; this means that s.NotNil(nil) will stop the test, not merely log an error
+		suite.Suite
+	}
+
+	testPeerProvider struct {
+		sync.Mutex
+		members map[string][]string
+	}
+)
+
+func TestPeerProviderMonitorSuite(t *testing.T) {
+	suite.Run(t, new(PeerProviderMonitorSuite))
+}
+
+func (s *PeerProviderMonitorSuite) SetupTest() {
+	s.Assertions = require.New(s.T()) // Have to define our overridden assertions in the test setup. If we did it earlier, s.T() will return nil
+}
+
+func (p *testPeerProvider) GetMembers(service string) ([]string, error) {
+	p.Lock()
+	defer p.Unlock()
+	members, ok := p.members[service]
+	if !ok {
+		return nil, ErrUnknownService
+	}
+	return append([]string(nil), members...), nil
+}
+
+func (p *testPeerProvider) setMembers(service string, members []string) {
+	p.Lock()
+	defer p.Unlock()
+	p.members[service] = members
+}
+
+func (s *PeerProviderMonitorSuite) TestPeerProviderMonitor() {
+	provider := &testPeerProvider{members: map[string][]string{
+		"ppm-test": {"127.0.0.1:7933", "127.0.0.1:7934", "127.0.0.1:7935"},
+	}}
+	logger := bark.NewLoggerFromLogrus(log.New())
+	monitor := NewPeerProviderMonitor("ppm-test", "127.0.0.1:7933", []string{"ppm-test"}, provider, 10*time.Millisecond, logger)
+	s.Nil(monitor.Start())
+	defer monitor.Stop()
+
+	self, err := monitor.WhoAmI()
+	s.Nil(err)
+	s.Equal("127.0.0.1:7933", self.GetAddress())
+
+	_, err = monitor.Lookup("unknown", "key")
+	s.Equal(ErrUnknownService, err)
+
+	listenCh := make(chan *ChangedEvent, 5)
+	s.Nil(monitor.AddListener("ppm-test", "test-listener", listenCh))
+	s.Equal(ErrListenerAlreadyExist, monitor.AddListener("ppm-test", "test-listener", listenCh))
+
+	host, err := monitor.Lookup("ppm-test", "key")
+	s.Nil(err)
+	s.NotNil(host)
+
+	provider.setMembers("ppm-test", []string{"127.0.0.1:7933", "127.0.0.1:7935", "127.0.0.1:7936"})
+	select {
+	case e := <-listenCh:
+		s.Equal(1, len(e.HostsAdded))
+		s.Equal("127.0.0.1:7936", e.HostsAdded[0].GetAddress())
+		s.Equal(1, len(e.HostsRemoved))
+		s.Equal("127.0.0.1:7934", e.HostsRemoved[0].GetAddress())
+		s.Nil(e.HostsUpdated)
+	case <-time.After(time.Second):
+		s.Fail("Timed out waiting for membership change")
+	}
+
+	for i := 0; i < 100; i++ {
+		host, err := monitor.Lookup("ppm-test", string(rune('a'+i%26))+"key")
+		s.Nil(err)
+		s.NotEqual("127.0.0.1:7934", host.GetAddress())
+	}
+
+	provider.setMembers("ppm-test", nil)
+	select {
+	case e := <-listenCh:
+		s.Equal(3, len(e.HostsRemoved))
+	case <-time.After(time.Second):
+		s.Fail("Timed out waiting for membership change")
+	}
+	_, err = monitor.Lookup("ppm-test", "key")
+	s.Equal(ErrInsufficientHosts, err)
+
+	s.Nil(monitor.RemoveListener("ppm-test", "test-listener"))
+}
+
+func (s *PeerProviderMonitorSuite) TestStaticPeerProvider() {
+	provider := NewStaticPeerProvider(map[string][]string{
+		"static-test": {"127.0.0.1:7933"},
+	})
+	logger := bark.NewLoggerFromLogrus(log.New())
+	monitor := NewPeerProviderMonitor("static-test", "127.0.0.1:7933", []string{"static-test"}, provider, 10*time.Millisecond, logger)
+	s.Nil(monitor.Start())
+	defer monitor.Stop()
+
+	host, err := monitor.Lookup("static-test", "key")
+	s.Nil(err)
+	s.Equal("127.0.0.1:7933", host.GetAddress())
+
+	_, err = provider.GetMembers("unknown")
+	s.Equal(ErrUnknownService, err)
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package messaging
+
+import (
+	"fmt"
+	"time"
+)
+
+type (
+	// PartitionLag is the lag of a consumer group on a topic partition
+	PartitionLag struct {
+		Partition int32
+		// CommittedOffset is the offset the consumer group resumes from, it is the oldest retained offset when the
+		// group did not commit anything yet or the messages after its last commit are no longer retained
+		CommittedOffset int64
+		// HighWatermark is the offset the next message published to the partition gets
+		HighWatermark int64
+		// Lag is the number of messages published to the partition and not committed by the consumer group
+		Lag int64
+	}
+
+	// ConsumerLag is a sample of the lag of a consumer group on a topic
+	ConsumerLag struct {
+		Topic        string
+		ConsumerName string
+		Partitions   []PartitionLag
+		// Lag is the sum of the lag of the partitions
+		Lag       int64
+		SampledAt time.Time
+	}
+
+	// ConsumerLagStats is the lag of a consumer group along with its rates between two samples of the lag
+	ConsumerLagStats struct {
+		*ConsumerLag
+		// SourceCluster is the cadence cluster which publishes the replication tasks of the topic
+		SourceCluster string
+		// ProcessingRate is the number of messages committed by the consumer group per second
+		ProcessingRate float64
+		// ProduceRate is the number of messages published to the topic per second
+		ProduceRate float64
+	}
+)
+
+// GetReplicationConsumerName returns the name of the consumer group the replicator of the current cluster consumes
+// the replication tasks of the source cluster with
+func GetReplicationConsumerName(currentCluster, sourceCluster string) string {
+	return fmt.Sprintf("%v_consumer_for_%v", currentCluster, sourceCluster)
+}
+
+// NewConsumerLagStats computes the rates of a consumer group from the offsets of two samples of its lag, rates are
+// zero without a previous sample. Partitions missing from the previous sample do not count towards the rates.
+func NewConsumerLagStats(sourceCluster string, previous *ConsumerLag, current *ConsumerLag) *ConsumerLagStats {
+	stats := &ConsumerLagStats{
+		ConsumerLag:   current,
+		SourceCluster: sourceCluster,
+	}
+	if previous == nil {
+		return stats
+	}
+	elapsed := current.SampledAt.Sub(previous.SampledAt).Seconds()
+	if elapsed <= 0 {
+		return stats
+	}
+
+	previousPartitions := make(map[int32]PartitionLag, len(previous.Partitions))
+	for _, partition := range previous.Partitions {
+		previousPartitions[partition.Partition] = partition
+	}
+	var committed, produced int64
+	for _, partition := range current.Partitions {
+		previousPartition, ok := previousPartitions[partition.Partition]
+		if !ok {
+			continue
+		}
+		// offsets only move backwards when the consumer group is reset, such partitions count as not progressing
+		if delta := partition.CommittedOffset - previousPartition.CommittedOffset; delta > 0 {
+			committed += delta
+		}
+		if delta := partition.HighWatermark - previousPartition.HighWatermark; delta > 0 {
+			produced += delta
+		}
+	}
+	stats.ProcessingRate = float64(committed) / elapsed
+	stats.ProduceRate = float64(produced) / elapsed
+	return stats
+}
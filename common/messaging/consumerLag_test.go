@@ -0,0 +1,86 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package messaging
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewConsumerLagStats(t *testing.T) {
+	now := time.Now()
+	previous := &ConsumerLag{
+		Partitions: []PartitionLag{
+			{Partition: 0, CommittedOffset: 100, HighWatermark: 150, Lag: 50},
+			{Partition: 1, CommittedOffset: 200, HighWatermark: 200},
+		},
+		Lag:       50,
+		SampledAt: now,
+	}
+	current := &ConsumerLag{
+		Partitions: []PartitionLag{
+			{Partition: 0, CommittedOffset: 140, HighWatermark: 160, Lag: 20},
+			{Partition: 1, CommittedOffset: 220, HighWatermark: 250, Lag: 30},
+			// added after the previous sample
+			{Partition: 2, CommittedOffset: 10, HighWatermark: 20, Lag: 10},
+		},
+		Lag:       60,
+		SampledAt: now.Add(10 * time.Second),
+	}
+
+	stats := NewConsumerLagStats("standby", previous, current)
+	require.Equal(t, "standby", stats.SourceCluster)
+	require.Equal(t, int64(60), stats.Lag)
+	require.Equal(t, 6.0, stats.ProcessingRate)
+	require.Equal(t, 6.0, stats.ProduceRate)
+}
+
+func TestNewConsumerLagStats_NoPreviousSample(t *testing.T) {
+	current := &ConsumerLag{
+		Partitions: []PartitionLag{{Partition: 0, CommittedOffset: 140, HighWatermark: 160, Lag: 20}},
+		Lag:        20,
+		SampledAt:  time.Now(),
+	}
+
+	stats := NewConsumerLagStats("standby", nil, current)
+	require.Equal(t, int64(20), stats.Lag)
+	require.Zero(t, stats.ProcessingRate)
+	require.Zero(t, stats.ProduceRate)
+}
+
+func TestNewConsumerLagStats_ConsumerReset(t *testing.T) {
+	now := time.Now()
+	previous := &ConsumerLag{
+		Partitions: []PartitionLag{{Partition: 0, CommittedOffset: 100, HighWatermark: 100}},
+		SampledAt:  now,
+	}
+	current := &ConsumerLag{
+		Partitions: []PartitionLag{{Partition: 0, CommittedOffset: 50, HighWatermark: 100, Lag: 50}},
+		Lag:        50,
+		SampledAt:  now.Add(time.Second),
+	}
+
+	stats := NewConsumerLagStats("standby", previous, current)
+	require.Zero(t, stats.ProcessingRate)
+	require.Zero(t, stats.ProduceRate)
+}
@@ -27,6 +27,8 @@ type (
 		NewConsumerWithClusterName(currentCluster, sourceCluster, consumerName string, concurrency int) (Consumer, error)
 		NewProducer(appName string) (Producer, error)
 		NewProducerWithClusterName(sourceCluster string) (Producer, error)
+		NewDLQReader(currentCluster string) (DLQReader, error)
+		NewConsumerLagReader(sourceCluster string) (ConsumerLagReader, error)
 	}
 
 	// Consumer is the unified interface for both internal and external kafka clients
@@ -53,6 +55,33 @@ type (
 		Nack() error
 	}
 
+	// DLQReader reads the messages of the DLQ topic of a cadence cluster without consuming them
+	DLQReader interface {
+		// Partitions returns the partitions of the DLQ topic
+		Partitions() ([]int32, error)
+		// Read returns the values of up to count messages of the partition starting at offset, the offset to continue
+		// reading from and whether the end of the partition is reached. Offsets of messages which are no longer
+		// retained move to the oldest retained message.
+		Read(partition int32, offset int64, count int) (values [][]byte, nextOffset int64, endOfPartition bool, err error)
+		// Close releases the connections to kafka
+		Close() error
+	}
+
+	// ConsumerLagReader reads how far the consumer groups of the replication topic of a cadence cluster are behind,
+	// from the offsets they committed, without joining the groups
+	ConsumerLagReader interface {
+		// GetLag returns the lag of the consumer group on each partition of the topic
+		GetLag(consumerName string) (*ConsumerLag, error)
+		// Close releases the connections to kafka
+		Close() error
+	}
+
+	// ConsumerLagHook is notified of the lag of the replicator consumers each time it is sampled,
+	// deployments implement it to scale the worker service with the lag
+	ConsumerLagHook interface {
+		OnConsumerLag(stats []*ConsumerLagStats)
+	}
+
 	// Producer is the interface used to send replication tasks to other clusters through replicator
 	Producer interface {
 		//PublishBatch(msgs []*replicator.ReplicationTask) error
@@ -60,4 +89,16 @@ type (
 		Publish(msgs interface{}) error
 		Close() error
 	}
+
+	// DomainChangeNotification is published to all hosts when the metadata of a domain is changed
+	DomainChangeNotification struct {
+		DomainID            string
+		NotificationVersion int64
+	}
+
+	// HeaderedMessage wraps a message published through a Producer with headers to attach to it
+	HeaderedMessage struct {
+		Message interface{}
+		Headers map[string]string
+	}
 )
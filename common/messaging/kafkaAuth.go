@@ -0,0 +1,249 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package messaging
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/Shopify/sarama"
+)
+
+type (
+	// scramClient implements the client side of SCRAM authentication (RFC 5802) for sarama
+	scramClient struct {
+		hashFn func() hash.Hash
+
+		user            string
+		password        string
+		authzID         string
+		clientNonce     string
+		clientFirstBare string
+		serverSignature []byte
+		done            bool
+	}
+)
+
+var _ sarama.SCRAMClient = (*scramClient)(nil)
+
+var (
+	errSCRAMServerNonce     = errors.New("scram: server nonce does not extend client nonce")
+	errSCRAMServerSignature = errors.New("scram: invalid server signature")
+	errSCRAMUnexpectedStep  = errors.New("scram: unexpected challenge after authentication is done")
+)
+
+// applyAuth sets the TLS and SASL settings of the cluster on the sarama config
+func (c *ClusterConfig) applyAuth(config *sarama.Config) error {
+	if c.TLS.Enabled {
+		tlsConfig, err := c.TLS.newTLSConfig()
+		if err != nil {
+			return err
+		}
+		config.Net.TLS.Enable = true
+		config.Net.TLS.Config = tlsConfig
+	}
+
+	if c.SASL.Enabled {
+		config.Net.SASL.Enable = true
+		config.Net.SASL.Handshake = true
+		config.Net.SASL.User = c.SASL.User
+		config.Net.SASL.Password = c.SASL.Password
+		switch c.SASL.Mechanism {
+		case SASLMechanismSCRAMSHA256:
+			config.Net.SASL.Mechanism = sarama.SASLTypeSCRAMSHA256
+			config.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient {
+				return &scramClient{hashFn: sha256.New}
+			}
+		case SASLMechanismSCRAMSHA512:
+			config.Net.SASL.Mechanism = sarama.SASLTypeSCRAMSHA512
+			config.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient {
+				return &scramClient{hashFn: sha512.New}
+			}
+		default:
+			config.Net.SASL.Mechanism = sarama.SASLTypePlaintext
+		}
+		// SCRAM requires the v1 SASL handshake
+		if c.SASL.Mechanism != SASLMechanismPlain && !config.Version.IsAtLeast(sarama.V1_0_0_0) {
+			config.Version = sarama.V1_0_0_0
+		}
+	}
+	return nil
+}
+
+func (t *TLSConfig) newTLSConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: t.SkipHostVerification,
+	}
+
+	if t.CaFile != "" {
+		caCert, err := ioutil.ReadFile(t.CaFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read kafka CA file: %v", err)
+		}
+		caPool := x509.NewCertPool()
+		if !caPool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("failed to parse kafka CA file %v", t.CaFile)
+		}
+		tlsConfig.RootCAs = caPool
+	}
+
+	if t.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(t.CertFile, t.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load kafka client certificate: %v", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
+// Begin prepares the client for the SCRAM exchange
+func (c *scramClient) Begin(user, password, authzID string) error {
+	nonce := make([]byte, 24)
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	c.user = user
+	c.password = password
+	c.authzID = authzID
+	c.clientNonce = base64.RawStdEncoding.EncodeToString(nonce)
+	c.clientFirstBare = ""
+	c.serverSignature = nil
+	c.done = false
+	return nil
+}
+
+// Step returns the response to the challenge of the server
+func (c *scramClient) Step(challenge string) (string, error) {
+	switch {
+	case c.done:
+		return "", errSCRAMUnexpectedStep
+	case c.clientFirstBare == "":
+		c.clientFirstBare = "n=" + escapeSCRAMName(c.user) + ",r=" + c.clientNonce
+		return c.gs2Header() + c.clientFirstBare, nil
+	case c.serverSignature == nil:
+		return c.clientFinal(challenge)
+	default:
+		c.done = true
+		attributes := parseSCRAMAttributes(challenge)
+		if e, ok := attributes["e"]; ok {
+			return "", fmt.Errorf("scram: server error %v", e)
+		}
+		signature, err := base64.StdEncoding.DecodeString(attributes["v"])
+		if err != nil || !hmac.Equal(signature, c.serverSignature) {
+			return "", errSCRAMServerSignature
+		}
+		return "", nil
+	}
+}
+
+// Done returns true when the server signature is verified
+func (c *scramClient) Done() bool {
+	return c.done
+}
+
+func (c *scramClient) clientFinal(serverFirst string) (string, error) {
+	attributes := parseSCRAMAttributes(serverFirst)
+	serverNonce := attributes["r"]
+	if !strings.HasPrefix(serverNonce, c.clientNonce) {
+		return "", errSCRAMServerNonce
+	}
+	salt, err := base64.StdEncoding.DecodeString(attributes["s"])
+	if err != nil {
+		return "", fmt.Errorf("scram: invalid salt: %v", err)
+	}
+	iterations, err := strconv.Atoi(attributes["i"])
+	if err != nil || iterations <= 0 {
+		return "", fmt.Errorf("scram: invalid iteration count %v", attributes["i"])
+	}
+
+	saltedPassword := c.pbkdf2([]byte(c.password), salt, iterations)
+	clientKey := c.hmac(saltedPassword, []byte("Client Key"))
+	storedKey := c.hash(clientKey)
+	clientFinalWithoutProof := "c=" + base64.StdEncoding.EncodeToString([]byte(c.gs2Header())) + ",r=" + serverNonce
+	authMessage := []byte(c.clientFirstBare + "," + serverFirst + "," + clientFinalWithoutProof)
+
+	clientSignature := c.hmac(storedKey, authMessage)
+	proof := make([]byte, len(clientKey))
+	for i := range clientKey {
+		proof[i] = clientKey[i] ^ clientSignature[i]
+	}
+	serverKey := c.hmac(saltedPassword, []byte("Server Key"))
+	c.serverSignature = c.hmac(serverKey, authMessage)
+	return clientFinalWithoutProof + ",p=" + base64.StdEncoding.EncodeToString(proof), nil
+}
+
+func (c *scramClient) gs2Header() string {
+	if c.authzID == "" {
+		return "n,,"
+	}
+	return "n,a=" + escapeSCRAMName(c.authzID) + ","
+}
+
+func (c *scramClient) hmac(key []byte, data []byte) []byte {
+	mac := hmac.New(c.hashFn, key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+func (c *scramClient) hash(data []byte) []byte {
+	h := c.hashFn()
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+// pbkdf2 derives the salted password, with the output length of the hash as key length
+func (c *scramClient) pbkdf2(password []byte, salt []byte, iterations int) []byte {
+	u := c.hmac(password, append(append([]byte{}, salt...), 0, 0, 0, 1))
+	result := append([]byte{}, u...)
+	for i := 1; i < iterations; i++ {
+		u = c.hmac(password, u)
+		for j := range result {
+			result[j] ^= u[j]
+		}
+	}
+	return result
+}
+
+func escapeSCRAMName(name string) string {
+	return strings.NewReplacer("=", "=3D", ",", "=2C").Replace(name)
+}
+
+func parseSCRAMAttributes(message string) map[string]string {
+	attributes := make(map[string]string)
+	for _, field := range strings.Split(message, ",") {
+		if parts := strings.SplitN(field, "=", 2); len(parts) == 2 {
+			attributes[parts[0]] = parts[1]
+		}
+	}
+	return attributes
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package messaging
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/require"
+)
+
+// test vector from RFC 7677
+func TestSCRAMClient_SHA256(t *testing.T) {
+	client := &scramClient{hashFn: sha256.New}
+	require.NoError(t, client.Begin("user", "pencil", ""))
+	client.clientNonce = "rOprNGfwEbeRWgbNEkqO"
+
+	clientFirst, err := client.Step("")
+	require.NoError(t, err)
+	require.Equal(t, "n,,n=user,r=rOprNGfwEbeRWgbNEkqO", clientFirst)
+
+	clientFinal, err := client.Step("r=rOprNGfwEbeRWgbNEkqO%hvYDpWUa2RaTCAfuxFIlj)hNlF$k0,s=W22ZaJ0SNY7soEsUEjb6gQ==,i=4096")
+	require.NoError(t, err)
+	require.Equal(t, "c=biws,r=rOprNGfwEbeRWgbNEkqO%hvYDpWUa2RaTCAfuxFIlj)hNlF$k0,p=dHzbZapWIk4jUhN+Ute9ytag9zjfMHgsqmmiz7AndVQ=", clientFinal)
+	require.False(t, client.Done())
+
+	_, err = client.Step("v=6rriTRBi23WpRR/wtup+mMhUZUn/dB5nLTJRsjl95G4=")
+	require.NoError(t, err)
+	require.True(t, client.Done())
+}
+
+func TestSCRAMClient_InvalidServer(t *testing.T) {
+	client := &scramClient{hashFn: sha256.New}
+	require.NoError(t, client.Begin("user", "pencil", ""))
+	client.clientNonce = "rOprNGfwEbeRWgbNEkqO"
+	_, err := client.Step("")
+	require.NoError(t, err)
+
+	_, err = client.Step("r=someOtherNonce,s=W22ZaJ0SNY7soEsUEjb6gQ==,i=4096")
+	require.Equal(t, errSCRAMServerNonce, err)
+
+	_, err = client.Step("r=rOprNGfwEbeRWgbNEkqO%hvYDpWUa2RaTCAfuxFIlj)hNlF$k0,s=W22ZaJ0SNY7soEsUEjb6gQ==,i=4096")
+	require.NoError(t, err)
+	_, err = client.Step("v=c29tZSBzaWduYXR1cmU=")
+	require.Equal(t, errSCRAMServerSignature, err)
+}
+
+func TestClusterConfig_ApplyAuth(t *testing.T) {
+	cluster := &ClusterConfig{
+		TLS: TLSConfig{
+			Enabled:              true,
+			SkipHostVerification: true,
+		},
+		SASL: SASLConfig{
+			Enabled:   true,
+			Mechanism: SASLMechanismSCRAMSHA512,
+			User:      "user",
+			Password:  "password",
+		},
+	}
+	config := sarama.NewConfig()
+	require.NoError(t, cluster.applyAuth(config))
+	require.True(t, config.Net.TLS.Enable)
+	require.True(t, config.Net.TLS.Config.InsecureSkipVerify)
+	require.True(t, config.Net.SASL.Enable)
+	require.Equal(t, sarama.SASLMechanism(sarama.SASLTypeSCRAMSHA512), config.Net.SASL.Mechanism)
+	require.Equal(t, "user", config.Net.SASL.User)
+	require.NotNil(t, config.Net.SASL.SCRAMClientGeneratorFunc)
+	require.True(t, config.Version.IsAtLeast(sarama.V1_0_0_0))
+}
+
+func TestClusterConfig_ApplyAuthDisabled(t *testing.T) {
+	config := sarama.NewConfig()
+	require.NoError(t, (&ClusterConfig{}).applyAuth(config))
+	require.False(t, config.Net.TLS.Enable)
+	require.False(t, config.Net.SASL.Enable)
+}
+
+func TestSASLConfig_ValidateInvalid(t *testing.T) {
+	require.Panics(t, func() { (&SASLConfig{Enabled: true, Mechanism: "GSSAPI", User: "user"}).validate("test") })
+	require.Panics(t, func() { (&SASLConfig{Enabled: true, Mechanism: SASLMechanismPlain}).validate("test") })
+	require.NotPanics(t, func() { (&SASLConfig{Mechanism: "GSSAPI"}).validate("test") })
+}
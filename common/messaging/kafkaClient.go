@@ -21,6 +21,9 @@
 package messaging
 
 import (
+	"crypto/tls"
+	"errors"
+	"fmt"
 	"strings"
 
 	"github.com/Shopify/sarama"
@@ -44,6 +47,8 @@ type (
 
 var _ Client = (*kafkaClient)(nil)
 
+var errConsumerSASLNotSupported = errors.New("SASL authentication is not supported by kafka consumers")
+
 // NewKafkaClient is used to create an instance of KafkaClient
 func NewKafkaClient(kc *KafkaConfig, metricsClient metrics.Client, zLogger *zap.Logger, logger bark.Logger, metricScope tally.Scope,
 	checkCluster, checkApp bool) Client {
@@ -118,6 +123,11 @@ func (c *kafkaClient) newConsumerHelper(topic, dlq *uberKafka.Topic, consumerNam
 	consumerConfig := uberKafka.NewConsumerConfig(consumerName, topicList)
 	consumerConfig.Concurrency = concurrency
 	consumerConfig.Offsets.Initial.Offset = uberKafka.OffsetOldest
+	tlsConfig, err := c.getConsumerTLSConfig(topic.Cluster, dlq.Cluster)
+	if err != nil {
+		return nil, err
+	}
+	consumerConfig.TLSConfig = tlsConfig
 
 	uConsumer, err := c.client.NewConsumer(consumerConfig)
 	if err != nil {
@@ -126,6 +136,23 @@ func (c *kafkaClient) newConsumerHelper(topic, dlq *uberKafka.Topic, consumerNam
 	return newKafkaConsumer(uConsumer, c.logger), nil
 }
 
+// getConsumerTLSConfig returns the TLS config shared by the topic and DLQ clusters of a consumer,
+// as kafka-client connects to all clusters of a consumer with the same TLS config and without SASL
+func (c *kafkaClient) getConsumerTLSConfig(topicCluster, dlqCluster string) (*tls.Config, error) {
+	topicClusterConfig := c.config.Clusters[topicCluster]
+	dlqClusterConfig := c.config.Clusters[dlqCluster]
+	if topicClusterConfig.SASL.Enabled || dlqClusterConfig.SASL.Enabled {
+		return nil, errConsumerSASLNotSupported
+	}
+	if topicClusterConfig.TLS != dlqClusterConfig.TLS {
+		return nil, fmt.Errorf("kafka clusters %v and %v of consumer must have the same TLS config", topicCluster, dlqCluster)
+	}
+	if !topicClusterConfig.TLS.Enabled {
+		return nil, nil
+	}
+	return topicClusterConfig.TLS.newTLSConfig()
+}
+
 // NewProducer is used to create a Kafka producer
 func (c *kafkaClient) NewProducer(app string) (Producer, error) {
 	topics := c.config.getTopicsForApplication(app)
@@ -138,18 +165,62 @@ func (c *kafkaClient) NewProducerWithClusterName(sourceCluster string) (Producer
 	return c.newProducerHelper(topics.Topic)
 }
 
+// NewDLQReader is used to create a reader of the DLQ topic the replicator of the current cluster moves the
+// replication tasks it failed to apply to
+func (c *kafkaClient) NewDLQReader(currentCluster string) (DLQReader, error) {
+	topics := c.config.getTopicsForCadenceCluster(currentCluster)
+	kafkaClusterName := c.config.getKafkaClusterForTopic(topics.DLQTopic)
+	brokers := c.config.getBrokersForKafkaCluster(kafkaClusterName)
+
+	config := sarama.NewConfig()
+	config.Version = sarama.V0_11_0_0
+	config.Consumer.Return.Errors = true
+	cluster := c.config.Clusters[kafkaClusterName]
+	if err := cluster.applyAuth(config); err != nil {
+		return nil, err
+	}
+	return newKafkaDLQReader(topics.DLQTopic, brokers, config)
+}
+
+// NewConsumerLagReader is used to create a reader of the lag of the consumers of the replication topic of the
+// source cluster
+func (c *kafkaClient) NewConsumerLagReader(sourceCluster string) (ConsumerLagReader, error) {
+	topics := c.config.getTopicsForCadenceCluster(sourceCluster)
+	kafkaClusterName := c.config.getKafkaClusterForTopic(topics.Topic)
+	brokers := c.config.getBrokersForKafkaCluster(kafkaClusterName)
+
+	config := sarama.NewConfig()
+	config.Version = sarama.V0_11_0_0
+	cluster := c.config.Clusters[kafkaClusterName]
+	if err := cluster.applyAuth(config); err != nil {
+		return nil, err
+	}
+	return newKafkaConsumerLagReader(topics.Topic, brokers, config)
+}
+
 func (c *kafkaClient) newProducerHelper(topic string) (Producer, error) {
 	kafkaClusterName := c.config.getKafkaClusterForTopic(topic)
 	brokers := c.config.getBrokersForKafkaCluster(kafkaClusterName)
 
-	producer, err := sarama.NewSyncProducer(brokers, nil)
+	config := sarama.NewConfig()
+	// record headers, used to carry the request ID and caller identity, require kafka 0.11 message format
+	config.Version = sarama.V0_11_0_0
+	// sync producer requires successes to be returned
+	config.Producer.Return.Successes = true
+	c.config.Producer.apply(config)
+	cluster := c.config.Clusters[kafkaClusterName]
+	if err := cluster.applyAuth(config); err != nil {
+		return nil, err
+	}
+	producer, err := sarama.NewSyncProducer(brokers, config)
 	if err != nil {
 		return nil, err
 	}
 
+	kafkaProducer := NewKafkaProducerWithConfig(topic, producer, &c.config.Producer, c.logger)
 	if c.metricsClient != nil {
 		c.logger.Info("Create producer with metricsClient")
-		return NewMetricProducer(NewKafkaProducer(topic, producer, c.logger), c.metricsClient), nil
+		return NewMetricProducer(kafkaProducer, c.metricsClient), nil
 	}
-	return NewKafkaProducer(topic, producer, c.logger), nil
+	return kafkaProducer, nil
 }
@@ -22,6 +22,8 @@ package messaging
 
 import (
 	"fmt"
+
+	"github.com/Shopify/sarama"
 )
 
 type (
@@ -31,11 +33,53 @@ type (
 		Topics         map[string]TopicConfig   `yaml:"topics"`
 		ClusterToTopic map[string]TopicList     `yaml:"cadence-cluster-topics"`
 		Applications   map[string]TopicList     `yaml:"applications"`
+		Producer       ProducerConfig           `yaml:"producer"`
+	}
+
+	// ProducerConfig describes the tuning of kafka producers, empty values keep the sarama defaults
+	ProducerConfig struct {
+		// RequiredAcks is the acks required from brokers before a message is considered published,
+		// one of none, local or all
+		RequiredAcks string `yaml:"requiredAcks"`
+		// Compression is the codec to compress messages with, one of none, gzip, snappy or lz4
+		Compression string `yaml:"compression"`
+		// MaxMessageBytes is the max size of a message accepted by the producer
+		MaxMessageBytes int `yaml:"maxMessageBytes"`
+		// PartitionKey is the field of workflow messages used to pick a partition, one of workflowID or runID,
+		// runID spreads the messages of workflows with long chains of runs across partitions but only keeps
+		// messages of the same run in order. Replication tasks carrying a shardID are always partitioned by shard
+		PartitionKey string `yaml:"partitionKey"`
+		// VisibilityMessageVersion is the format visibility messages are published in, 1 for thrift (the default)
+		// or 2 for protobuf. Indexers decode both, upgrade them before switching producers to 2
+		VisibilityMessageVersion int `yaml:"visibilityMessageVersion"`
 	}
 
 	// ClusterConfig describes the configuration for a single Kafka cluster
 	ClusterConfig struct {
-		Brokers []string `yaml:"brokers"`
+		Brokers []string   `yaml:"brokers"`
+		TLS     TLSConfig  `yaml:"tls"`
+		SASL    SASLConfig `yaml:"sasl"`
+	}
+
+	// TLSConfig describes the TLS connection to a Kafka cluster
+	TLSConfig struct {
+		Enabled bool `yaml:"enabled"`
+		// CertFile and KeyFile are the client certificate for mTLS, they are optional
+		CertFile string `yaml:"certFile"`
+		KeyFile  string `yaml:"keyFile"`
+		// CaFile is the CA bundle to verify brokers with, the system roots are used when empty
+		CaFile string `yaml:"caFile"`
+		// SkipHostVerification disables verification of the broker certificates
+		SkipHostVerification bool `yaml:"skipHostVerification"`
+	}
+
+	// SASLConfig describes the SASL authentication to a Kafka cluster
+	SASLConfig struct {
+		Enabled bool `yaml:"enabled"`
+		// Mechanism is one of PLAIN, SCRAM-SHA-256 or SCRAM-SHA-512
+		Mechanism string `yaml:"mechanism"`
+		User      string `yaml:"user"`
+		Password  string `yaml:"password"`
 	}
 
 	// TopicConfig describes the mapping from topic to Kafka cluster
@@ -51,6 +95,42 @@ type (
 	}
 )
 
+const (
+	// ProducerAcksNone does not wait for brokers to acknowledge messages
+	ProducerAcksNone = "none"
+	// ProducerAcksLocal waits for the leader broker to acknowledge messages
+	ProducerAcksLocal = "local"
+	// ProducerAcksAll waits for all in sync replicas to acknowledge messages
+	ProducerAcksAll = "all"
+
+	// PartitionKeyWorkflowID partitions workflow messages by workflowID
+	PartitionKeyWorkflowID = "workflowID"
+	// PartitionKeyRunID partitions workflow messages by runID
+	PartitionKeyRunID = "runID"
+
+	// SASLMechanismPlain authenticates with plain user and password
+	SASLMechanismPlain = "PLAIN"
+	// SASLMechanismSCRAMSHA256 authenticates with SCRAM using SHA-256
+	SASLMechanismSCRAMSHA256 = "SCRAM-SHA-256"
+	// SASLMechanismSCRAMSHA512 authenticates with SCRAM using SHA-512
+	SASLMechanismSCRAMSHA512 = "SCRAM-SHA-512"
+)
+
+var (
+	producerAcks = map[string]sarama.RequiredAcks{
+		ProducerAcksNone:  sarama.NoResponse,
+		ProducerAcksLocal: sarama.WaitForLocal,
+		ProducerAcksAll:   sarama.WaitForAll,
+	}
+
+	producerCompressions = map[string]sarama.CompressionCodec{
+		"none":   sarama.CompressionNone,
+		"gzip":   sarama.CompressionGZIP,
+		"snappy": sarama.CompressionSnappy,
+		"lz4":    sarama.CompressionLZ4,
+	}
+)
+
 // Validate will validate config for kafka
 func (k *KafkaConfig) Validate(checkCluster bool, checkApp bool) {
 	if len(k.Clusters) == 0 {
@@ -91,6 +171,77 @@ func (k *KafkaConfig) Validate(checkCluster bool, checkApp bool) {
 			validateTopicsFn(topics.DLQTopic)
 		}
 	}
+	for name, cluster := range k.Clusters {
+		cluster.TLS.validate(name)
+		cluster.SASL.validate(name)
+	}
+	k.Producer.validate()
+}
+
+func (t *TLSConfig) validate(cluster string) {
+	if !t.Enabled {
+		return
+	}
+	if (t.CertFile == "") != (t.KeyFile == "") {
+		panic(fmt.Sprintf("Kafka Cluster %v TLS Config requires both Cert File and Key File", cluster))
+	}
+}
+
+func (s *SASLConfig) validate(cluster string) {
+	if !s.Enabled {
+		return
+	}
+	switch s.Mechanism {
+	case SASLMechanismPlain, SASLMechanismSCRAMSHA256, SASLMechanismSCRAMSHA512:
+	default:
+		panic(fmt.Sprintf("Invalid Kafka Cluster %v SASL Mechanism %v", cluster, s.Mechanism))
+	}
+	if s.User == "" {
+		panic(fmt.Sprintf("Empty Kafka Cluster %v SASL User", cluster))
+	}
+}
+
+func (p *ProducerConfig) validate() {
+	if _, ok := producerAcks[p.RequiredAcks]; p.RequiredAcks != "" && !ok {
+		panic(fmt.Sprintf("Invalid Producer Required Acks %v", p.RequiredAcks))
+	}
+	if _, ok := producerCompressions[p.Compression]; p.Compression != "" && !ok {
+		panic(fmt.Sprintf("Invalid Producer Compression %v", p.Compression))
+	}
+	if p.MaxMessageBytes < 0 {
+		panic(fmt.Sprintf("Invalid Producer Max Message Bytes %v", p.MaxMessageBytes))
+	}
+	switch p.PartitionKey {
+	case "", PartitionKeyWorkflowID, PartitionKeyRunID:
+	default:
+		panic(fmt.Sprintf("Invalid Producer Partition Key %v", p.PartitionKey))
+	}
+	switch p.VisibilityMessageVersion {
+	case 0, VisibilityMessageVersionThrift, VisibilityMessageVersionProto:
+	default:
+		panic(fmt.Sprintf("Invalid Producer Visibility Message Version %v", p.VisibilityMessageVersion))
+	}
+}
+
+// apply sets the producer tuning on the sarama config
+func (p *ProducerConfig) apply(config *sarama.Config) {
+	if acks, ok := producerAcks[p.RequiredAcks]; ok {
+		config.Producer.RequiredAcks = acks
+	}
+	if compression, ok := producerCompressions[p.Compression]; ok {
+		config.Producer.Compression = compression
+	}
+	if p.MaxMessageBytes > 0 {
+		config.Producer.MaxMessageBytes = p.MaxMessageBytes
+	}
+}
+
+func (p *ProducerConfig) partitionByRunID() bool {
+	return p.PartitionKey == PartitionKeyRunID
+}
+
+func (p *ProducerConfig) publishVisibilityAsProto() bool {
+	return p.VisibilityMessageVersion == VisibilityMessageVersionProto
 }
 
 func (k *KafkaConfig) getTopicsForCadenceCluster(cadenceCluster string) TopicList {
@@ -0,0 +1,66 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package messaging
+
+import (
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProducerConfig_Apply(t *testing.T) {
+	config := &ProducerConfig{
+		RequiredAcks:    ProducerAcksAll,
+		Compression:     "snappy",
+		MaxMessageBytes: 4 * 1024 * 1024,
+		PartitionKey:    PartitionKeyRunID,
+	}
+	require.NotPanics(t, config.validate)
+
+	saramaConfig := sarama.NewConfig()
+	config.apply(saramaConfig)
+	require.Equal(t, sarama.WaitForAll, saramaConfig.Producer.RequiredAcks)
+	require.Equal(t, sarama.CompressionSnappy, saramaConfig.Producer.Compression)
+	require.Equal(t, 4*1024*1024, saramaConfig.Producer.MaxMessageBytes)
+	require.True(t, config.partitionByRunID())
+}
+
+func TestProducerConfig_ApplyDefaults(t *testing.T) {
+	config := &ProducerConfig{}
+	require.NotPanics(t, config.validate)
+
+	saramaConfig := sarama.NewConfig()
+	defaultConfig := sarama.NewConfig()
+	config.apply(saramaConfig)
+	require.Equal(t, defaultConfig.Producer.RequiredAcks, saramaConfig.Producer.RequiredAcks)
+	require.Equal(t, defaultConfig.Producer.Compression, saramaConfig.Producer.Compression)
+	require.Equal(t, defaultConfig.Producer.MaxMessageBytes, saramaConfig.Producer.MaxMessageBytes)
+	require.False(t, config.partitionByRunID())
+}
+
+func TestProducerConfig_ValidateInvalid(t *testing.T) {
+	require.Panics(t, (&ProducerConfig{RequiredAcks: "some"}).validate)
+	require.Panics(t, (&ProducerConfig{Compression: "zip"}).validate)
+	require.Panics(t, (&ProducerConfig{MaxMessageBytes: -1}).validate)
+	require.Panics(t, (&ProducerConfig{PartitionKey: "domainID"}).validate)
+	require.Panics(t, (&ProducerConfig{VisibilityMessageVersion: 3}).validate)
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package messaging
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Shopify/sarama"
+)
+
+type (
+	// kafkaConsumerLagReader reads the committed offsets of consumer groups from their group coordinator, it does
+	// not join the groups so reading never triggers a rebalance of the consumers
+	kafkaConsumerLagReader struct {
+		topic  string
+		client sarama.Client
+	}
+)
+
+var _ ConsumerLagReader = (*kafkaConsumerLagReader)(nil)
+
+func newKafkaConsumerLagReader(topic string, brokers []string, config *sarama.Config) (ConsumerLagReader, error) {
+	client, err := sarama.NewClient(brokers, config)
+	if err != nil {
+		return nil, err
+	}
+	return &kafkaConsumerLagReader{
+		topic:  topic,
+		client: client,
+	}, nil
+}
+
+// GetLag returns the lag of the consumer group on each partition of the topic
+func (r *kafkaConsumerLagReader) GetLag(consumerName string) (*ConsumerLag, error) {
+	partitions, err := r.client.Partitions(r.topic)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(partitions, func(i, j int) bool { return partitions[i] < partitions[j] })
+
+	committedOffsets, err := r.getCommittedOffsets(consumerName, partitions)
+	if err != nil {
+		return nil, err
+	}
+
+	lag := &ConsumerLag{
+		Topic:        r.topic,
+		ConsumerName: consumerName,
+		SampledAt:    time.Now(),
+	}
+	for _, partition := range partitions {
+		oldest, err := r.client.GetOffset(r.topic, partition, sarama.OffsetOldest)
+		if err != nil {
+			return nil, err
+		}
+		newest, err := r.client.GetOffset(r.topic, partition, sarama.OffsetNewest)
+		if err != nil {
+			return nil, err
+		}
+		// consumers start from the oldest retained message, see newConsumerHelper
+		committed := committedOffsets[partition]
+		if committed < oldest {
+			committed = oldest
+		}
+		partitionLag := PartitionLag{
+			Partition:       partition,
+			CommittedOffset: committed,
+			HighWatermark:   newest,
+		}
+		if newest > committed {
+			partitionLag.Lag = newest - committed
+		}
+		lag.Partitions = append(lag.Partitions, partitionLag)
+		lag.Lag += partitionLag.Lag
+	}
+	return lag, nil
+}
+
+// getCommittedOffsets returns the offset committed by the consumer group for each partition,
+// which is -1 for partitions the group did not commit yet
+func (r *kafkaConsumerLagReader) getCommittedOffsets(consumerName string, partitions []int32) (map[int32]int64, error) {
+	coordinator, err := r.client.Coordinator(consumerName)
+	if err != nil {
+		return nil, err
+	}
+	// version 1 reads the offsets committed to kafka rather than to zookeeper
+	request := &sarama.OffsetFetchRequest{ConsumerGroup: consumerName, Version: 1}
+	for _, partition := range partitions {
+		request.AddPartition(r.topic, partition)
+	}
+	response, err := coordinator.FetchOffset(request)
+	if err != nil {
+		return nil, err
+	}
+
+	offsets := make(map[int32]int64, len(partitions))
+	for _, partition := range partitions {
+		offsets[partition] = -1
+		block := response.GetBlock(r.topic, partition)
+		if block == nil {
+			continue
+		}
+		if block.Err != sarama.ErrNoError {
+			return nil, block.Err
+		}
+		offsets[partition] = block.Offset
+	}
+	return offsets, nil
+}
+
+// Close releases the connections to kafka
+func (r *kafkaConsumerLagReader) Close() error {
+	return r.client.Close()
+}
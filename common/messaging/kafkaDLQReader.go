@@ -0,0 +1,117 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package messaging
+
+import (
+	"time"
+
+	"github.com/Shopify/sarama"
+)
+
+type (
+	// kafkaDLQReader reads a DLQ topic partition by partition with a plain sarama consumer, it does not join a
+	// consumer group so reading never moves the offsets committed by the replicator
+	kafkaDLQReader struct {
+		topic    string
+		client   sarama.Client
+		consumer sarama.Consumer
+	}
+)
+
+// dlqReadTimeout bounds the time spent waiting for the messages of one Read call
+const dlqReadTimeout = 5 * time.Second
+
+var _ DLQReader = (*kafkaDLQReader)(nil)
+
+func newKafkaDLQReader(topic string, brokers []string, config *sarama.Config) (DLQReader, error) {
+	client, err := sarama.NewClient(brokers, config)
+	if err != nil {
+		return nil, err
+	}
+	consumer, err := sarama.NewConsumerFromClient(client)
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+	return &kafkaDLQReader{
+		topic:    topic,
+		client:   client,
+		consumer: consumer,
+	}, nil
+}
+
+// Partitions returns the partitions of the DLQ topic
+func (r *kafkaDLQReader) Partitions() ([]int32, error) {
+	return r.client.Partitions(r.topic)
+}
+
+// Read returns the values of up to count messages of the partition starting at offset
+func (r *kafkaDLQReader) Read(partition int32, offset int64, count int) ([][]byte, int64, bool, error) {
+	oldest, err := r.client.GetOffset(r.topic, partition, sarama.OffsetOldest)
+	if err != nil {
+		return nil, offset, false, err
+	}
+	newest, err := r.client.GetOffset(r.topic, partition, sarama.OffsetNewest)
+	if err != nil {
+		return nil, offset, false, err
+	}
+	if offset < oldest {
+		offset = oldest
+	}
+	if offset >= newest {
+		return nil, offset, true, nil
+	}
+	if count <= 0 {
+		return nil, offset, false, nil
+	}
+
+	partitionConsumer, err := r.consumer.ConsumePartition(r.topic, partition, offset)
+	if err != nil {
+		return nil, offset, false, err
+	}
+	defer partitionConsumer.Close()
+
+	timer := time.NewTimer(dlqReadTimeout)
+	defer timer.Stop()
+
+	var values [][]byte
+	for len(values) < count && offset < newest {
+		select {
+		case msg := <-partitionConsumer.Messages():
+			values = append(values, msg.Value)
+			offset = msg.Offset + 1
+		case err := <-partitionConsumer.Errors():
+			return nil, offset, false, err
+		case <-timer.C:
+			return values, offset, false, nil
+		}
+	}
+	return values, offset, offset >= newest, nil
+}
+
+// Close releases the connections to kafka
+func (r *kafkaDLQReader) Close() error {
+	if err := r.consumer.Close(); err != nil {
+		r.client.Close()
+		return err
+	}
+	return r.client.Close()
+}
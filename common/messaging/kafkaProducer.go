@@ -22,6 +22,7 @@ package messaging
 
 import (
 	"errors"
+	"strconv"
 
 	"github.com/Shopify/sarama"
 	"github.com/uber-common/bark"
@@ -34,11 +35,13 @@ import (
 
 type (
 	kafkaProducer struct {
-		topic      string
-		producer   sarama.SyncProducer
-		msgEncoder codec.BinaryEncoder
-		gobEncoder *gob.Encoder
-		logger     bark.Logger
+		topic        string
+		producer     sarama.SyncProducer
+		config       *ProducerConfig
+		msgEncoder   codec.BinaryEncoder
+		protoEncoder *codec.ProtoEncoder
+		gobEncoder   *gob.Encoder
+		logger       bark.Logger
 	}
 )
 
@@ -46,11 +49,19 @@ var _ Producer = (*kafkaProducer)(nil)
 
 // NewKafkaProducer is used to create the Kafka based producer implementation
 func NewKafkaProducer(topic string, producer sarama.SyncProducer, logger bark.Logger) Producer {
+	return NewKafkaProducerWithConfig(topic, producer, &ProducerConfig{}, logger)
+}
+
+// NewKafkaProducerWithConfig is used to create the Kafka based producer implementation,
+// which partitions messages as configured
+func NewKafkaProducerWithConfig(topic string, producer sarama.SyncProducer, config *ProducerConfig, logger bark.Logger) Producer {
 	return &kafkaProducer{
-		topic:      topic,
-		producer:   producer,
-		msgEncoder: codec.NewThriftRWEncoder(),
-		gobEncoder: gob.NewGobEncoder(),
+		topic:        topic,
+		producer:     producer,
+		config:       config,
+		msgEncoder:   codec.NewThriftRWEncoder(),
+		protoEncoder: codec.NewProtoEncoder(),
+		gobEncoder:   gob.NewGobEncoder(),
 		logger: logger.WithFields(bark.Fields{
 			logging.TagTopicName: topic,
 		}),
@@ -118,31 +129,74 @@ func (p *kafkaProducer) serializeThrift(input codec.ThriftObject) ([]byte, error
 	return payload, nil
 }
 
+func (p *kafkaProducer) serializeVisibilityMessage(msg *indexer.Message) ([]byte, error) {
+	if !p.config.publishVisibilityAsProto() {
+		return p.serializeThrift(msg)
+	}
+	payload, err := p.protoEncoder.Encode(toVisibilityMessageV2(msg))
+	if err != nil {
+		p.logger.WithFields(bark.Fields{
+			logging.TagErr: err,
+		}).Error("Failed to serialize proto object")
+
+		return nil, err
+	}
+
+	return payload, nil
+}
+
 func (p *kafkaProducer) getKeyForReplicationTask(task *replicator.ReplicationTask) sarama.Encoder {
 	if task == nil {
 		return nil
 	}
 
+	if task.IsSetShardId() {
+		// Use shardID as the partition key so all replication tasks generated by a shard are dispatched to the same
+		// Kafka partition in the order the shard generated them.  The consumer applies tasks of a shard in order,
+		// so tasks of a workflow never arrive before the tasks they depend on
+		return sarama.StringEncoder(strconv.Itoa(int(task.GetShardId())))
+	}
+
 	switch task.GetTaskType() {
 	case replicator.ReplicationTaskTypeHistory:
-		// Use workflowID as the partition key so all replication tasks for a workflow are dispatched to the same
-		// Kafka partition.  This will give us some ordering guarantee for workflow replication tasks atleast at
-		// the messaging layer perspective
+		// Tasks without shardID are published by older hosts or tools, use workflowID (or runID if configured) as
+		// the partition key so all replication tasks for a workflow are dispatched to the same Kafka partition.
+		// This will give us some ordering guarantee for workflow replication tasks atleast at the messaging layer perspective
 		attributes := task.HistoryTaskAttributes
-		return sarama.StringEncoder(attributes.GetWorkflowId())
+		return p.getKeyForWorkflow(attributes.GetWorkflowId(), attributes.GetRunId())
 	case replicator.ReplicationTaskTypeSyncActivity:
-		// Use workflowID as the partition key so all sync activity tasks for a workflow are dispatched to the same
-		// Kafka partition.  This will give us some ordering guarantee for workflow replication tasks atleast at
-		// the messaging layer perspective
+		// Use workflowID (or runID if configured) as the partition key so all sync activity tasks for a workflow are
+		// dispatched to the same Kafka partition.  This will give us some ordering guarantee for workflow replication
+		// tasks atleast at the messaging layer perspective
 		attributes := task.SyncActicvityTaskAttributes
-		return sarama.StringEncoder(attributes.GetWorkflowId())
+		return p.getKeyForWorkflow(attributes.GetWorkflowId(), attributes.GetRunId())
 	}
 
 	return nil
 }
 
+func (p *kafkaProducer) getKeyForWorkflow(workflowID string, runID string) sarama.Encoder {
+	if p.config.partitionByRunID() {
+		return sarama.StringEncoder(runID)
+	}
+	return sarama.StringEncoder(workflowID)
+}
+
 func (p *kafkaProducer) getProducerMessage(message interface{}) (*sarama.ProducerMessage, error) {
 	switch message.(type) {
+	case *HeaderedMessage:
+		headered := message.(*HeaderedMessage)
+		msg, err := p.getProducerMessage(headered.Message)
+		if err != nil {
+			return nil, err
+		}
+		for key, value := range headered.Headers {
+			msg.Headers = append(msg.Headers, sarama.RecordHeader{
+				Key:   []byte(key),
+				Value: []byte(value),
+			})
+		}
+		return msg, nil
 	case *replicator.ReplicationTask:
 		task := message.(*replicator.ReplicationTask)
 		payload, err := p.serializeThrift(task)
@@ -158,13 +212,25 @@ func (p *kafkaProducer) getProducerMessage(message interface{}) (*sarama.Produce
 		return msg, nil
 	case *indexer.Message:
 		indexMsg := message.(*indexer.Message)
-		payload, err := p.serializeThrift(indexMsg)
+		payload, err := p.serializeVisibilityMessage(indexMsg)
+		if err != nil {
+			return nil, err
+		}
+		msg := &sarama.ProducerMessage{
+			Topic: p.topic,
+			Key:   p.getKeyForWorkflow(indexMsg.GetWorkflowID(), indexMsg.GetRunID()),
+			Value: sarama.ByteEncoder(payload),
+		}
+		return msg, nil
+	case *DomainChangeNotification:
+		notification := message.(*DomainChangeNotification)
+		payload, err := p.gobEncoder.Encode(notification)
 		if err != nil {
 			return nil, err
 		}
 		msg := &sarama.ProducerMessage{
 			Topic: p.topic,
-			Key:   sarama.StringEncoder(indexMsg.GetWorkflowID()),
+			Key:   sarama.StringEncoder(notification.DomainID),
 			Value: sarama.ByteEncoder(payload),
 		}
 		return msg, nil
@@ -0,0 +1,370 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package messaging
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/.gen/go/indexer"
+	"github.com/uber/cadence/common/codec"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	// SpillBufferConfig describes the local buffer messages are spilled to when they cannot be published
+	SpillBufferConfig struct {
+		// Dir is the directory the buffer file is kept in, it must survive restarts of the host
+		Dir string
+		// MaxMessages is the max number of messages kept in the buffer, messages beyond it are dropped
+		MaxMessages dynamicconfig.IntPropertyFn
+		// RetryInterval is the interval to retry delivering buffered messages
+		RetryInterval dynamicconfig.DurationPropertyFn
+	}
+
+	// spillBufferProducer spills the messages it fails to publish to a file, and publishes them again in
+	// the background once the underlying producer recovers, which makes delivery at least once.
+	// Buffered messages can be delivered after messages published later, so it is only suitable
+	// for consumers which do not rely on ordering, e.g. the ES indexer using versioned documents.
+	spillBufferProducer struct {
+		sync.Mutex
+		producer      Producer
+		config        *SpillBufferConfig
+		filePath      string
+		numBuffered   int
+		msgEncoder    codec.BinaryEncoder
+		metricsClient metrics.Client
+		logger        bark.Logger
+		shutdownCh    chan struct{}
+		shutdownWG    sync.WaitGroup
+	}
+
+	// spillRecord is a message serialized as a line in the buffer file
+	spillRecord struct {
+		Headers map[string]string `json:"headers,omitempty"`
+		Payload []byte            `json:"payload"`
+	}
+)
+
+const (
+	spillBufferFileSuffix = ".spill"
+)
+
+var (
+	errSpillBufferFull         = errors.New("spill buffer is full")
+	errSpillUnsupportedMessage = errors.New("message type cannot be spilled")
+)
+
+var _ Producer = (*spillBufferProducer)(nil)
+
+// NewSpillBufferProducer creates a producer which spills messages failed to be published to a local buffer,
+// messages left in the buffer by a previous process are delivered as well
+func NewSpillBufferProducer(producer Producer, name string, config *SpillBufferConfig,
+	metricsClient metrics.Client, logger bark.Logger) (Producer, error) {
+	if err := os.MkdirAll(config.Dir, 0755); err != nil {
+		return nil, err
+	}
+
+	p := &spillBufferProducer{
+		producer:      producer,
+		config:        config,
+		filePath:      filepath.Join(config.Dir, name+spillBufferFileSuffix),
+		msgEncoder:    codec.NewThriftRWEncoder(),
+		metricsClient: metricsClient,
+		logger: logger.WithFields(bark.Fields{
+			logging.TagTopicName: name,
+		}),
+		shutdownCh: make(chan struct{}),
+	}
+
+	records, err := p.readRecords()
+	if err != nil {
+		return nil, err
+	}
+	p.numBuffered = len(records)
+	if p.numBuffered > 0 {
+		p.logger.Infof("Found %v messages in spill buffer from previous run.", p.numBuffered)
+	}
+
+	p.shutdownWG.Add(1)
+	go p.deliveryLoop()
+	return p, nil
+}
+
+// Publish publishes the message, and spills it to the buffer if publishing fails,
+// the publishing error is only returned when the message cannot be buffered
+func (p *spillBufferProducer) Publish(msg interface{}) error {
+	err := p.producer.Publish(msg)
+	if err == nil {
+		return nil
+	}
+
+	if spillErr := p.spill([]interface{}{msg}); spillErr != nil {
+		p.logger.WithFields(bark.Fields{
+			logging.TagErr: spillErr,
+		}).Error("Failed to spill message to buffer.")
+		p.metricsClient.IncCounter(metrics.MessagingClientSpillBufferScope, metrics.MessagingSpillDroppedCounter)
+		return err
+	}
+	return nil
+}
+
+// PublishBatch publishes the messages, and spills all of them to the buffer if publishing fails,
+// as it is unknown which of the messages were published
+func (p *spillBufferProducer) PublishBatch(msgs []interface{}) error {
+	err := p.producer.PublishBatch(msgs)
+	if err == nil {
+		return nil
+	}
+
+	if spillErr := p.spill(msgs); spillErr != nil {
+		p.logger.WithFields(bark.Fields{
+			logging.TagErr: spillErr,
+		}).Error("Failed to spill messages to buffer.")
+		p.metricsClient.AddCounter(metrics.MessagingClientSpillBufferScope, metrics.MessagingSpillDroppedCounter, int64(len(msgs)))
+		return err
+	}
+	return nil
+}
+
+// Close stops delivering buffered messages and closes the underlying producer,
+// messages remaining in the buffer are delivered by the next process
+func (p *spillBufferProducer) Close() error {
+	close(p.shutdownCh)
+	p.shutdownWG.Wait()
+	return p.producer.Close()
+}
+
+func (p *spillBufferProducer) spill(msgs []interface{}) error {
+	records := make([]*spillRecord, 0, len(msgs))
+	for _, msg := range msgs {
+		record, err := p.toRecord(msg)
+		if err != nil {
+			return err
+		}
+		records = append(records, record)
+	}
+
+	p.Lock()
+	defer p.Unlock()
+
+	if p.numBuffered+len(records) > p.config.MaxMessages() {
+		return errSpillBufferFull
+	}
+	if err := p.appendRecords(records); err != nil {
+		return err
+	}
+	p.numBuffered += len(records)
+	p.metricsClient.AddCounter(metrics.MessagingClientSpillBufferScope, metrics.MessagingSpillBufferedCounter, int64(len(records)))
+	p.metricsClient.UpdateGauge(metrics.MessagingClientSpillBufferScope, metrics.MessagingSpillBufferSizeGauge, float64(p.numBuffered))
+	return nil
+}
+
+func (p *spillBufferProducer) deliveryLoop() {
+	defer p.shutdownWG.Done()
+
+	timer := time.NewTimer(p.config.RetryInterval())
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+			if err := p.deliverBuffered(); err != nil {
+				p.logger.WithFields(bark.Fields{
+					logging.TagErr: err,
+				}).Warn("Failed to deliver messages from spill buffer.")
+			}
+			timer.Reset(p.config.RetryInterval())
+		case <-p.shutdownCh:
+			return
+		}
+	}
+}
+
+// deliverBuffered publishes the buffered messages in order until one fails,
+// and rewrites the buffer with the messages not yet delivered
+func (p *spillBufferProducer) deliverBuffered() error {
+	p.Lock()
+	defer p.Unlock()
+
+	if p.numBuffered == 0 {
+		return nil
+	}
+
+	records, err := p.readRecords()
+	if err != nil {
+		return err
+	}
+
+	delivered := 0
+	var publishErr error
+	for _, record := range records {
+		msg, err := p.fromRecord(record)
+		if err != nil {
+			// cannot be fixed by retrying, skip the corrupted message
+			p.logger.WithFields(bark.Fields{
+				logging.TagErr: err,
+			}).Error("Dropping corrupted message from spill buffer.")
+			p.metricsClient.IncCounter(metrics.MessagingClientSpillBufferScope, metrics.MessagingSpillDroppedCounter)
+			delivered++
+			continue
+		}
+		if publishErr = p.producer.Publish(msg); publishErr != nil {
+			break
+		}
+		p.metricsClient.IncCounter(metrics.MessagingClientSpillBufferScope, metrics.MessagingSpillDeliveredCounter)
+		delivered++
+	}
+
+	if err := p.rewriteRecords(records[delivered:]); err != nil {
+		return err
+	}
+	p.numBuffered = len(records) - delivered
+	p.metricsClient.UpdateGauge(metrics.MessagingClientSpillBufferScope, metrics.MessagingSpillBufferSizeGauge, float64(p.numBuffered))
+	return publishErr
+}
+
+func (p *spillBufferProducer) toRecord(msg interface{}) (*spillRecord, error) {
+	record := &spillRecord{}
+	if headered, ok := msg.(*HeaderedMessage); ok {
+		record.Headers = headered.Headers
+		msg = headered.Message
+	}
+
+	indexMsg, ok := msg.(*indexer.Message)
+	if !ok {
+		return nil, errSpillUnsupportedMessage
+	}
+	payload, err := p.msgEncoder.Encode(indexMsg)
+	if err != nil {
+		return nil, err
+	}
+	record.Payload = payload
+	return record, nil
+}
+
+func (p *spillBufferProducer) fromRecord(record *spillRecord) (interface{}, error) {
+	var indexMsg indexer.Message
+	if err := p.msgEncoder.Decode(record.Payload, &indexMsg); err != nil {
+		return nil, err
+	}
+	if len(record.Headers) == 0 {
+		return &indexMsg, nil
+	}
+	return &HeaderedMessage{
+		Message: &indexMsg,
+		Headers: record.Headers,
+	}, nil
+}
+
+func (p *spillBufferProducer) readRecords() ([]*spillRecord, error) {
+	file, err := os.Open(p.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	var records []*spillRecord
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			// a partial last line is left by a crash in the middle of a write
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		record := &spillRecord{}
+		if err := json.Unmarshal(line, record); err != nil {
+			return nil, fmt.Errorf("corrupted spill buffer %v: %v", p.filePath, err)
+		}
+		records = append(records, record)
+	}
+}
+
+func (p *spillBufferProducer) appendRecords(records []*spillRecord) error {
+	file, err := os.OpenFile(p.filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err := writeRecords(file, records); err != nil {
+		return err
+	}
+	return file.Sync()
+}
+
+func (p *spillBufferProducer) rewriteRecords(records []*spillRecord) error {
+	if len(records) == 0 {
+		if err := os.Remove(p.filePath); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	// write to a temp file and rename, so a crash never leaves a partially rewritten buffer
+	tmpPath := p.filePath + ".tmp"
+	file, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if err := writeRecords(file, records); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, p.filePath)
+}
+
+func writeRecords(writer io.Writer, records []*spillRecord) error {
+	for _, record := range records {
+		line, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		if _, err := writer.Write(append(line, '\n')); err != nil {
+			return err
+		}
+	}
+	return nil
+}
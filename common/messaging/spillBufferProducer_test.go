@@ -0,0 +1,189 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package messaging
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	"github.com/uber-go/tally"
+	"github.com/uber/cadence/.gen/go/indexer"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	spillBufferProducerSuite struct {
+		suite.Suite
+		// override suite.Suite.Assertions with require.Assertions; this means that s.NotNil(nil) will stop the test,
+		// not merely log an error
+		*require.Assertions
+		dir      string
+		config   *SpillBufferConfig
+		producer *fakeProducer
+	}
+
+	// fakeProducer records published messages, or fails them when err is set
+	fakeProducer struct {
+		err       error
+		published []interface{}
+	}
+)
+
+func TestSpillBufferProducerSuite(t *testing.T) {
+	s := new(spillBufferProducerSuite)
+	suite.Run(t, s)
+}
+
+func (s *spillBufferProducerSuite) SetupTest() {
+	// Have to define our overridden assertions in the test setup. If we did it earlier, s.T() will return nil
+	s.Assertions = require.New(s.T())
+	dir, err := ioutil.TempDir("", "TestSpillBufferProducer")
+	s.NoError(err)
+	s.dir = dir
+	s.config = &SpillBufferConfig{
+		Dir:           dir,
+		MaxMessages:   dynamicconfig.GetIntPropertyFn(3),
+		RetryInterval: dynamicconfig.GetDurationPropertyFn(time.Hour),
+	}
+	s.producer = &fakeProducer{}
+}
+
+func (s *spillBufferProducerSuite) TearDownTest() {
+	os.RemoveAll(s.dir)
+}
+
+func (s *spillBufferProducerSuite) newProducer() *spillBufferProducer {
+	p, err := NewSpillBufferProducer(s.producer, "test", s.config, metrics.NewClient(tally.NoopScope, metrics.History), bark.NewNopLogger())
+	s.NoError(err)
+	return p.(*spillBufferProducer)
+}
+
+func (s *spillBufferProducerSuite) TestPublish_Success() {
+	p := s.newProducer()
+	defer p.Close()
+
+	s.NoError(p.Publish(newTestIndexMessage("wid1")))
+	s.Equal(1, len(s.producer.published))
+	s.Equal(0, p.numBuffered)
+}
+
+func (s *spillBufferProducerSuite) TestPublish_SpillAndDeliver() {
+	p := s.newProducer()
+	defer p.Close()
+
+	s.producer.err = errors.New("kafka is down")
+	s.NoError(p.Publish(newTestIndexMessage("wid1")))
+	s.NoError(p.Publish(&HeaderedMessage{
+		Message: newTestIndexMessage("wid2"),
+		Headers: map[string]string{common.RequestIDHeaderName: "request-id"},
+	}))
+	s.Equal(2, p.numBuffered)
+
+	// still down, messages are kept
+	s.Error(p.deliverBuffered())
+	s.Equal(2, p.numBuffered)
+
+	s.producer.err = nil
+	s.NoError(p.deliverBuffered())
+	s.Equal(0, p.numBuffered)
+	s.Equal(2, len(s.producer.published))
+	s.Equal("wid1", s.producer.published[0].(*indexer.Message).GetWorkflowID())
+	headered := s.producer.published[1].(*HeaderedMessage)
+	s.Equal("wid2", headered.Message.(*indexer.Message).GetWorkflowID())
+	s.Equal("request-id", headered.Headers[common.RequestIDHeaderName])
+
+	_, err := os.Stat(p.filePath)
+	s.True(os.IsNotExist(err))
+}
+
+func (s *spillBufferProducerSuite) TestPublish_BufferFull() {
+	p := s.newProducer()
+	defer p.Close()
+
+	s.producer.err = errors.New("kafka is down")
+	for i := 0; i < 3; i++ {
+		s.NoError(p.Publish(newTestIndexMessage("wid")))
+	}
+	s.Equal(s.producer.err, p.Publish(newTestIndexMessage("wid")))
+	s.Equal(3, p.numBuffered)
+}
+
+func (s *spillBufferProducerSuite) TestPublish_UnsupportedMessage() {
+	p := s.newProducer()
+	defer p.Close()
+
+	s.producer.err = errors.New("kafka is down")
+	s.Equal(s.producer.err, p.Publish(&DomainChangeNotification{DomainID: "domain"}))
+	s.Equal(0, p.numBuffered)
+}
+
+func (s *spillBufferProducerSuite) TestNewSpillBufferProducer_ResumesBuffer() {
+	s.producer.err = errors.New("kafka is down")
+	p := s.newProducer()
+	s.NoError(p.Publish(newTestIndexMessage("wid1")))
+	s.NoError(p.Close())
+
+	s.producer.err = nil
+	p = s.newProducer()
+	defer p.Close()
+	s.Equal(1, p.numBuffered)
+	s.NoError(p.deliverBuffered())
+	s.Equal(1, len(s.producer.published))
+}
+
+func newTestIndexMessage(workflowID string) *indexer.Message {
+	msgType := indexer.MessageTypeIndex
+	return &indexer.Message{
+		MessageType: &msgType,
+		DomainID:    common.StringPtr("domain-id"),
+		WorkflowID:  common.StringPtr(workflowID),
+		RunID:       common.StringPtr("run-id"),
+		Version:     common.Int64Ptr(1),
+	}
+}
+
+func (p *fakeProducer) Publish(msg interface{}) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.published = append(p.published, msg)
+	return nil
+}
+
+func (p *fakeProducer) PublishBatch(msgs []interface{}) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.published = append(p.published, msgs...)
+	return nil
+}
+
+func (p *fakeProducer) Close() error {
+	return nil
+}
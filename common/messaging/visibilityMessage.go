@@ -0,0 +1,136 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package messaging
+
+import (
+	"github.com/uber/cadence/.gen/go/indexer"
+	indexerv2 "github.com/uber/cadence/.gen/proto/indexer/v2"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/codec"
+)
+
+const (
+	// VisibilityMessageVersionThrift publishes visibility messages as thrift encoded indexer.Message
+	VisibilityMessageVersionThrift = 1
+	// VisibilityMessageVersionProto publishes visibility messages as protobuf encoded indexer v2 Message
+	VisibilityMessageVersionProto = 2
+)
+
+// DecodeVisibilityMessage decodes a message of the visibility topic of either version, so consumers keep working
+// while producers migrate from one version to the other
+func DecodeVisibilityMessage(payload []byte) (*indexer.Message, error) {
+	if codec.IsProtoEncoded(payload) {
+		var msg indexerv2.Message
+		if err := codec.NewProtoEncoder().Decode(payload, &msg); err != nil {
+			return nil, err
+		}
+		return fromVisibilityMessageV2(&msg), nil
+	}
+
+	var msg indexer.Message
+	if err := codec.NewThriftRWEncoder().Decode(payload, &msg); err != nil {
+		return nil, err
+	}
+	return &msg, nil
+}
+
+func toVisibilityMessageV2(msg *indexer.Message) *indexerv2.Message {
+	result := &indexerv2.Message{
+		DomainId:   msg.GetDomainID(),
+		WorkflowId: msg.GetWorkflowID(),
+		RunId:      msg.GetRunID(),
+		Version:    msg.GetVersion(),
+	}
+	if msg.MessageType != nil {
+		switch msg.GetMessageType() {
+		case indexer.MessageTypeIndex:
+			result.MessageType = indexerv2.MessageType_MESSAGE_TYPE_INDEX
+		case indexer.MessageTypeDelete:
+			result.MessageType = indexerv2.MessageType_MESSAGE_TYPE_DELETE
+		}
+	}
+	if msg.IndexAttributes != nil {
+		result.Fields = make(map[string]*indexerv2.Field, len(msg.IndexAttributes.Fields))
+		for key, field := range msg.IndexAttributes.Fields {
+			result.Fields[key] = toVisibilityFieldV2(field)
+		}
+	}
+	return result
+}
+
+func toVisibilityFieldV2(field *indexer.Field) *indexerv2.Field {
+	result := &indexerv2.Field{}
+	switch field.GetType() {
+	case indexer.FieldTypeString:
+		result.Data = &indexerv2.Field_StringData{StringData: field.GetStringData()}
+	case indexer.FieldTypeInt:
+		result.Data = &indexerv2.Field_IntData{IntData: field.GetIntData()}
+	case indexer.FieldTypeBool:
+		result.Data = &indexerv2.Field_BoolData{BoolData: field.GetBoolData()}
+	case indexer.FieldTypeStringList:
+		result.Data = &indexerv2.Field_StringListData{
+			StringListData: &indexerv2.StringList{Values: field.GetStringListData()},
+		}
+	}
+	return result
+}
+
+func fromVisibilityMessageV2(msg *indexerv2.Message) *indexer.Message {
+	result := &indexer.Message{
+		DomainID:   common.StringPtr(msg.GetDomainId()),
+		WorkflowID: common.StringPtr(msg.GetWorkflowId()),
+		RunID:      common.StringPtr(msg.GetRunId()),
+		Version:    common.Int64Ptr(msg.GetVersion()),
+	}
+	// an unknown message type is left unset, the indexer rejects such messages
+	switch msg.GetMessageType() {
+	case indexerv2.MessageType_MESSAGE_TYPE_INDEX:
+		result.MessageType = indexer.MessageTypeIndex.Ptr()
+	case indexerv2.MessageType_MESSAGE_TYPE_DELETE:
+		result.MessageType = indexer.MessageTypeDelete.Ptr()
+	}
+	if msg.Fields != nil {
+		result.IndexAttributes = &indexer.IndexAttributes{
+			Fields: make(map[string]*indexer.Field, len(msg.Fields)),
+		}
+		for key, field := range msg.Fields {
+			// fields of a data type added after this consumer was built are skipped
+			if converted, ok := fromVisibilityFieldV2(field); ok {
+				result.IndexAttributes.Fields[key] = converted
+			}
+		}
+	}
+	return result
+}
+
+func fromVisibilityFieldV2(field *indexerv2.Field) (*indexer.Field, bool) {
+	switch data := field.GetData().(type) {
+	case *indexerv2.Field_StringData:
+		return &indexer.Field{Type: indexer.FieldTypeString.Ptr(), StringData: common.StringPtr(data.StringData)}, true
+	case *indexerv2.Field_IntData:
+		return &indexer.Field{Type: indexer.FieldTypeInt.Ptr(), IntData: common.Int64Ptr(data.IntData)}, true
+	case *indexerv2.Field_BoolData:
+		return &indexer.Field{Type: indexer.FieldTypeBool.Ptr(), BoolData: common.BoolPtr(data.BoolData)}, true
+	case *indexerv2.Field_StringListData:
+		return &indexer.Field{Type: indexer.FieldTypeStringList.Ptr(), StringListData: data.StringListData.GetValues()}, true
+	}
+	return nil, false
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package messaging
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/uber/cadence/.gen/go/indexer"
+	indexerv2 "github.com/uber/cadence/.gen/proto/indexer/v2"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/codec"
+)
+
+func TestDecodeVisibilityMessage_Thrift(t *testing.T) {
+	msg := newTestVisibilityMessage()
+	payload, err := codec.NewThriftRWEncoder().Encode(msg)
+	require.NoError(t, err)
+
+	decoded, err := DecodeVisibilityMessage(payload)
+	require.NoError(t, err)
+	require.Equal(t, msg, decoded)
+}
+
+func TestDecodeVisibilityMessage_Proto(t *testing.T) {
+	msg := newTestVisibilityMessage()
+	payload, err := codec.NewProtoEncoder().Encode(toVisibilityMessageV2(msg))
+	require.NoError(t, err)
+
+	decoded, err := DecodeVisibilityMessage(payload)
+	require.NoError(t, err)
+	require.Equal(t, msg, decoded)
+}
+
+func TestDecodeVisibilityMessage_ProtoUnknownField(t *testing.T) {
+	msg := &indexerv2.Message{
+		MessageType: indexerv2.MessageType_MESSAGE_TYPE_INDEX,
+		WorkflowId:  "wid",
+		Fields: map[string]*indexerv2.Field{
+			"CustomIntField": {Data: &indexerv2.Field_IntData{IntData: 1}},
+			"NewTypeField":   {},
+		},
+	}
+	payload, err := codec.NewProtoEncoder().Encode(msg)
+	require.NoError(t, err)
+
+	decoded, err := DecodeVisibilityMessage(payload)
+	require.NoError(t, err)
+	require.Equal(t, indexer.MessageTypeIndex, decoded.GetMessageType())
+	require.Len(t, decoded.IndexAttributes.Fields, 1)
+	require.Equal(t, int64(1), decoded.IndexAttributes.Fields["CustomIntField"].GetIntData())
+}
+
+func TestDecodeVisibilityMessage_Malformed(t *testing.T) {
+	_, err := DecodeVisibilityMessage(nil)
+	require.Error(t, err)
+	_, err = DecodeVisibilityMessage([]byte{0x5A, 0xFF, 0xFF})
+	require.Error(t, err)
+}
+
+func newTestVisibilityMessage() *indexer.Message {
+	return &indexer.Message{
+		MessageType: indexer.MessageTypeIndex.Ptr(),
+		DomainID:    common.StringPtr("domain-id"),
+		WorkflowID:  common.StringPtr("wid"),
+		RunID:       common.StringPtr("rid"),
+		Version:     common.Int64Ptr(2),
+		IndexAttributes: &indexer.IndexAttributes{
+			Fields: map[string]*indexer.Field{
+				"WorkflowType": {Type: indexer.FieldTypeString.Ptr(), StringData: common.StringPtr("type")},
+				"StartTime":    {Type: indexer.FieldTypeInt.Ptr(), IntData: common.Int64Ptr(100)},
+				"IsCron":       {Type: indexer.FieldTypeBool.Ptr(), BoolData: common.BoolPtr(true)},
+				"Tags":         {Type: indexer.FieldTypeStringList.Ptr(), StringListData: []string{"a", "b"}},
+			},
+		},
+	}
+}
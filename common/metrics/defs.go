@@ -139,6 +139,8 @@ const (
 	PersistenceCreateWorkflowExecutionScope
 	// PersistenceGetWorkflowExecutionScope tracks GetWorkflowExecution calls made by service to persistence layer
 	PersistenceGetWorkflowExecutionScope
+	// PersistenceGetWorkflowExecutionsScope tracks GetWorkflowExecutions calls made by service to persistence layer
+	PersistenceGetWorkflowExecutionsScope
 	// PersistenceUpdateWorkflowExecutionScope tracks UpdateWorkflowExecution calls made by service to persistence layer
 	PersistenceUpdateWorkflowExecutionScope
 	// PersistenceResetMutableStateScope tracks ResetMutableState calls made by service to persistence layer
@@ -223,6 +225,10 @@ const (
 	PersistenceGetClosedWorkflowExecutionScope
 	// PersistenceVisibilityDeleteWorkflowExecutionScope is the metrics scope for persistence.VisibilityManager.DeleteWorkflowExecution
 	PersistenceVisibilityDeleteWorkflowExecutionScope
+	// PersistenceGetWorkflowExecutionStatisticsScope tracks GetWorkflowExecutionStatistics calls made by service to persistence layer
+	PersistenceGetWorkflowExecutionStatisticsScope
+	// PersistenceCountOpenWorkflowExecutionsScope tracks CountOpenWorkflowExecutions calls made by service to persistence layer
+	PersistenceCountOpenWorkflowExecutionsScope
 	// HistoryClientStartWorkflowExecutionScope tracks RPC calls to history service
 	HistoryClientStartWorkflowExecutionScope
 	// HistoryClientRecordActivityTaskHeartbeatScope tracks RPC calls to history service
@@ -243,6 +249,10 @@ const (
 	HistoryClientResetStickyTaskListScope
 	// HistoryClientDescribeWorkflowExecutionScope tracks RPC calls to history service
 	HistoryClientDescribeWorkflowExecutionScope
+	// HistoryClientDescribeWorkflowExecutionsScope tracks RPC calls to history service
+	HistoryClientDescribeWorkflowExecutionsScope
+	// HistoryClientGetReplicationTasksScope tracks RPC calls to history service
+	HistoryClientGetReplicationTasksScope
 	// HistoryClientRecordDecisionTaskStartedScope tracks RPC calls to history service
 	HistoryClientRecordDecisionTaskStartedScope
 	// HistoryClientRecordActivityTaskStartedScope tracks RPC calls to history service
@@ -257,6 +267,8 @@ const (
 	HistoryClientRemoveSignalMutableStateScope
 	// HistoryClientTerminateWorkflowExecutionScope tracks RPC calls to history service
 	HistoryClientTerminateWorkflowExecutionScope
+	// HistoryClientUpdateWorkflowExecutionLabelsScope tracks RPC calls to history service
+	HistoryClientUpdateWorkflowExecutionLabelsScope
 	// HistoryClientResetWorkflowExecutionScope tracks RPC calls to history service
 	HistoryClientResetWorkflowExecutionScope
 	// HistoryClientScheduleDecisionTaskScope tracks RPC calls to history service
@@ -267,6 +279,8 @@ const (
 	HistoryClientReplicateEventsScope
 	// HistoryClientReplicateRawEventsScope tracks RPC calls to history service
 	HistoryClientReplicateRawEventsScope
+	// HistoryClientReplicateEventsV2Scope tracks RPC calls to history service
+	HistoryClientReplicateEventsV2Scope
 	// HistoryClientSyncShardStatusScope tracks RPC calls to history service
 	HistoryClientSyncShardStatusScope
 	// HistoryClientSyncActivityScope tracks RPC calls to history service
@@ -295,10 +309,18 @@ const (
 	FrontendClientDescribeTaskListScope
 	// FrontendClientDescribeWorkflowExecutionScope tracks RPC calls to frontend service
 	FrontendClientDescribeWorkflowExecutionScope
+	// FrontendClientDescribeWorkflowExecutionsScope tracks RPC calls to frontend service
+	FrontendClientDescribeWorkflowExecutionsScope
+	// FrontendClientGetSearchAttributesScope tracks RPC calls to frontend service
+	FrontendClientGetSearchAttributesScope
 	// FrontendClientGetWorkflowExecutionHistoryScope tracks RPC calls to frontend service
 	FrontendClientGetWorkflowExecutionHistoryScope
 	// FrontendClientListClosedWorkflowExecutionsScope tracks RPC calls to frontend service
 	FrontendClientListClosedWorkflowExecutionsScope
+	// FrontendClientGetWorkflowExecutionStatisticsScope tracks RPC calls to frontend service
+	FrontendClientGetWorkflowExecutionStatisticsScope
+	// FrontendClientCountOpenWorkflowExecutionsScope tracks RPC calls to frontend service
+	FrontendClientCountOpenWorkflowExecutionsScope
 	// FrontendClientListDomainsScope tracks RPC calls to frontend service
 	FrontendClientListDomainsScope
 	// FrontendClientListOpenWorkflowExecutionsScope tracks RPC calls to frontend service
@@ -347,6 +369,8 @@ const (
 	FrontendClientStartWorkflowExecutionScope
 	// FrontendClientTerminateWorkflowExecutionScope tracks RPC calls to frontend service
 	FrontendClientTerminateWorkflowExecutionScope
+	// FrontendClientUpdateWorkflowExecutionLabelsScope tracks RPC calls to frontend service
+	FrontendClientUpdateWorkflowExecutionLabelsScope
 	// FrontendClientUpdateDomainScope tracks RPC calls to frontend service
 	FrontendClientUpdateDomainScope
 	// AdminClientDescribeHistoryHostScope tracks RPC calls to admin service
@@ -355,11 +379,29 @@ const (
 	AdminClientDescribeWorkflowExecutionScope
 	// AdminClientGetWorkflowExecutionRawHistoryScope tracks RPC calls to admin service
 	AdminClientGetWorkflowExecutionRawHistoryScope
+	// AdminClientExportWorkflowExecutionScope tracks RPC calls to admin service
+	AdminClientExportWorkflowExecutionScope
+	// AdminClientImportWorkflowExecutionScope tracks RPC calls to admin service
+	AdminClientImportWorkflowExecutionScope
+	// AdminClientMigrateWorkflowExecutionScope tracks RPC calls to admin service
+	AdminClientMigrateWorkflowExecutionScope
+	// AdminClientAddRemoteClusterScope tracks RPC calls to admin service
+	AdminClientAddRemoteClusterScope
+	// AdminClientAddSearchAttributeScope tracks RPC calls to admin service
+	AdminClientAddSearchAttributeScope
+	// AdminClientGetReplicationTasksScope tracks RPC calls to admin service
+	AdminClientGetReplicationTasksScope
+	// AdminClientGetDLQReplicationTasksScope tracks RPC calls to admin service
+	AdminClientGetDLQReplicationTasksScope
+	// AdminClientGetReplicationConsumerLagScope tracks RPC calls to admin service
+	AdminClientGetReplicationConsumerLagScope
 
 	// MessagingPublishScope tracks Publish calls made by service to messaging layer
 	MessagingClientPublishScope
 	// MessagingPublishBatchScope tracks Publish calls made by service to messaging layer
 	MessagingClientPublishBatchScope
+	// MessagingClientSpillBufferScope tracks messages spilled to local buffer when messaging layer is unavailable
+	MessagingClientSpillBufferScope
 
 	// DomainCacheScope tracks domain cache callbacks
 	DomainCacheScope
@@ -425,6 +467,12 @@ const (
 	ElasticsearchListClosedWorkflowExecutionsByStatusScope
 	// ElasticsearchGetClosedWorkflowExecutionScope tracks GetClosedWorkflowExecution calls made by service to persistence layer
 	ElasticsearchGetClosedWorkflowExecutionScope
+	// ElasticsearchGetWorkflowExecutionStatisticsScope tracks GetWorkflowExecutionStatistics calls made by service to persistence layer
+	ElasticsearchGetWorkflowExecutionStatisticsScope
+	// ElasticsearchCountOpenWorkflowExecutionsScope tracks CountOpenWorkflowExecutions calls made by service to persistence layer
+	ElasticsearchCountOpenWorkflowExecutionsScope
+	// ElasticsearchClientScope tracks the requests of the ElasticSearch client
+	ElasticsearchClientScope
 
 	NumCommonScopes
 )
@@ -437,6 +485,22 @@ const (
 	AdminDescribeWorkflowExecutionScope
 	// AdminGetWorkflowExecutionRawHistoryScope is the metric scope for admin.GetWorkflowExecutionRawHistoryScope
 	AdminGetWorkflowExecutionRawHistoryScope
+	// AdminExportWorkflowExecutionScope is the metric scope for admin.ExportWorkflowExecutionScope
+	AdminExportWorkflowExecutionScope
+	// AdminImportWorkflowExecutionScope is the metric scope for admin.ImportWorkflowExecutionScope
+	AdminImportWorkflowExecutionScope
+	// AdminMigrateWorkflowExecutionScope is the metric scope for admin.MigrateWorkflowExecutionScope
+	AdminMigrateWorkflowExecutionScope
+	// AdminAddRemoteClusterScope is the metric scope for admin.AddRemoteClusterScope
+	AdminAddRemoteClusterScope
+	// AdminAddSearchAttributeScope is the metric scope for admin.AddSearchAttributeScope
+	AdminAddSearchAttributeScope
+	// AdminGetReplicationTasksScope is the metric scope for admin.GetReplicationTasksScope
+	AdminGetReplicationTasksScope
+	// AdminGetDLQReplicationTasksScope is the metric scope for admin.GetDLQReplicationTasksScope
+	AdminGetDLQReplicationTasksScope
+	// AdminGetReplicationConsumerLagScope is the metric scope for admin.GetReplicationConsumerLagScope
+	AdminGetReplicationConsumerLagScope
 
 	NumAdminScopes
 )
@@ -479,6 +543,8 @@ const (
 	FrontendSignalWithStartWorkflowExecutionScope
 	// FrontendTerminateWorkflowExecutionScope is the metric scope for frontend.TerminateWorkflowExecution
 	FrontendTerminateWorkflowExecutionScope
+	// FrontendUpdateWorkflowExecutionLabelsScope is the metric scope for frontend.UpdateWorkflowExecutionLabels
+	FrontendUpdateWorkflowExecutionLabelsScope
 	// FrontendRequestCancelWorkflowExecutionScope is the metric scope for frontend.RequestCancelWorkflowExecution
 	FrontendRequestCancelWorkflowExecutionScope
 	// FrontendListOpenWorkflowExecutionsScope is the metric scope for frontend.ListOpenWorkflowExecutions
@@ -497,6 +563,8 @@ const (
 	FrontendQueryWorkflowScope
 	// FrontendDescribeWorkflowExecutionScope is the metric scope for frontend.DescribeWorkflowExecution
 	FrontendDescribeWorkflowExecutionScope
+	// FrontendDescribeWorkflowExecutionsScope is the metric scope for frontend.DescribeWorkflowExecutions
+	FrontendDescribeWorkflowExecutionsScope
 	// FrontendDescribeTaskListScope is the metric scope for frontend.DescribeTaskList
 	FrontendDescribeTaskListScope
 	// FrontendResetStickyTaskListScope is the metric scope for frontend.ResetStickyTaskList
@@ -505,6 +573,12 @@ const (
 	FrontendListDomainsScope
 	// FrontendResetWorkflowExecutionScope is the metric scope for frontend.ResetWorkflowExecution
 	FrontendResetWorkflowExecutionScope
+	// FrontendGetWorkflowExecutionStatisticsScope is the metric scope for frontend.GetWorkflowExecutionStatistics
+	FrontendGetWorkflowExecutionStatisticsScope
+	// FrontendCountOpenWorkflowExecutionsScope is the metric scope for frontend.CountOpenWorkflowExecutions
+	FrontendCountOpenWorkflowExecutionsScope
+	// FrontendGetSearchAttributesScope is the metric scope for frontend.GetSearchAttributes
+	FrontendGetSearchAttributesScope
 
 	NumFrontendScopes
 )
@@ -531,6 +605,10 @@ const (
 	HistoryResetStickyTaskListScope
 	// HistoryDescribeWorkflowExecutionScope tracks DescribeWorkflowExecution API calls received by service
 	HistoryDescribeWorkflowExecutionScope
+	// HistoryDescribeWorkflowExecutionsScope tracks DescribeWorkflowExecutions API calls received by service
+	HistoryDescribeWorkflowExecutionsScope
+	// HistoryGetReplicationTasksScope tracks GetReplicationTasks API calls received by service
+	HistoryGetReplicationTasksScope
 	// HistoryRecordDecisionTaskStartedScope tracks RecordDecisionTaskStarted API calls received by service
 	HistoryRecordDecisionTaskStartedScope
 	// HistoryRecordActivityTaskStartedScope tracks RecordActivityTaskStarted API calls received by service
@@ -543,6 +621,8 @@ const (
 	HistoryRemoveSignalMutableStateScope
 	// HistoryTerminateWorkflowExecutionScope tracks TerminateWorkflowExecution API calls received by service
 	HistoryTerminateWorkflowExecutionScope
+	// HistoryUpdateWorkflowExecutionLabelsScope tracks UpdateWorkflowExecutionLabels API calls received by service
+	HistoryUpdateWorkflowExecutionLabelsScope
 	// HistoryScheduleDecisionTaskScope tracks ScheduleDecisionTask API calls received by service
 	HistoryScheduleDecisionTaskScope
 	// HistoryRecordChildExecutionCompletedScope tracks CompleteChildExecution API calls received by service
@@ -553,6 +633,8 @@ const (
 	HistoryReplicateEventsScope
 	// HistoryReplicateRawEventsScope tracks ReplicateEvents API calls received by service
 	HistoryReplicateRawEventsScope
+	// HistoryReplicateEventsV2Scope tracks ReplicateEventsV2 API calls received by service
+	HistoryReplicateEventsV2Scope
 	// HistorySyncShardStatusScope tracks HistorySyncShardStatus API calls received by service
 	HistorySyncShardStatusScope
 	// HistorySyncActivityScope tracks HistoryActivity API calls received by service
@@ -581,6 +663,8 @@ const (
 	TransferActiveTaskStartChildExecutionScope
 	// TransferActiveTaskRecordWorkflowStartedScope is the scope used for record workflow started task processing by transfer queue processor
 	TransferActiveTaskRecordWorkflowStartedScope
+	// TransferActiveTaskUpsertWorkflowLabelsScope is the scope used for upsert workflow labels task processing by transfer queue processor
+	TransferActiveTaskUpsertWorkflowLabelsScope
 	// TransferStandbyTaskActivityScope is the scope used for activity task processing by transfer queue processor
 	TransferStandbyTaskActivityScope
 	// TransferStandbyTaskDecisionScope is the scope used for decision task processing by transfer queue processor
@@ -595,6 +679,8 @@ const (
 	TransferStandbyTaskStartChildExecutionScope
 	// TransferStandbyTaskRecordWorkflowStartedScope is the scope used for record workflow started task processing by transfer queue processor
 	TransferStandbyTaskRecordWorkflowStartedScope
+	// TransferStandbyTaskUpsertWorkflowLabelsScope is the scope used for upsert workflow labels task processing by transfer queue processor
+	TransferStandbyTaskUpsertWorkflowLabelsScope
 	// TimerQueueProcessorScope is the scope used by all metric emitted by timer queue processor
 	TimerQueueProcessorScope
 	// TimerActiveQueueProcessorScope is the scope used by all metric emitted by timer queue processor
@@ -613,6 +699,8 @@ const (
 	TimerActiveTaskActivityRetryTimerScope
 	// TimerActiveTaskWorkflowBackoffTimerScope is the scope used by metric emitted by timer queue processor for processing retry task.
 	TimerActiveTaskWorkflowBackoffTimerScope
+	// TimerActiveTaskDelayedSignalScope is the scope used by metric emitted by timer queue processor for processing delayed signals.
+	TimerActiveTaskDelayedSignalScope
 	// TimerActiveTaskDeleteHistoryEventScope is the scope used by metric emitted by timer queue processor for processing history event cleanup
 	TimerActiveTaskDeleteHistoryEventScope
 	// TimerStandbyTaskActivityTimeoutScope is the scope used by metric emitted by timer queue processor for processing activity timeouts
@@ -629,6 +717,8 @@ const (
 	TimerStandbyTaskDeleteHistoryEventScope
 	// TimerStandbyTaskWorkflowBackoffTimerScope is the scope used by metric emitted by timer queue processor for processing retry task.
 	TimerStandbyTaskWorkflowBackoffTimerScope
+	// TimerStandbyTaskDelayedSignalScope is the scope used by metric emitted by timer queue processor for processing delayed signals.
+	TimerStandbyTaskDelayedSignalScope
 	// HistoryEventNotificationScope is the scope used by shard history event nitification
 	HistoryEventNotificationScope
 	// ReplicatorQueueProcessorScope is the scope used by all metric emitted by replicator queue processor
@@ -649,6 +739,8 @@ const (
 	HistoryCacheGetOrCreateScope
 	// HistoryCacheGetCurrentExecutionScope is the scope used by history cache for getting current execution
 	HistoryCacheGetCurrentExecutionScope
+	// HistoryCacheScope is the scope used by the host level history cache
+	HistoryCacheScope
 	// EventsCacheGetEventScope is the scope used by events cache
 	EventsCacheGetEventScope
 	// EventsCachePutEventScope is the scope used by events cache
@@ -707,10 +799,14 @@ const (
 	DomainReplicationTaskScope
 	// HistoryReplicationTaskScope is the scope used by history task replication processing
 	HistoryReplicationTaskScope
+	// HistoryReplicationV2TaskScope is the scope used by history task with version histories replication processing
+	HistoryReplicationV2TaskScope
 	// SyncShardTaskScope is the scope used by sync shrad information processing
 	SyncShardTaskScope
 	// SyncActivityTaskScope is the scope used by sync activity information processing
 	SyncActivityTaskScope
+	// ReplicatorConsumerLagScope is the scope used by the lag of the replicator consumers
+	ReplicatorConsumerLagScope
 	// ESProcessorScope is scope used by all metric emitted by esProcessor
 	ESProcessorScope
 	// IndexProcessorScope is scope used by all metric emitted by index processor
@@ -727,8 +823,28 @@ const (
 	ArchiverArchivalWorkflowScope
 	// ArchiverClientScope is scope used by all metrics emitted by archiver.Client
 	ArchiverClientScope
+	// ArchiverDLQWorkflowScope is scope used by all metrics emitted by archiver.ArchivalDLQWorkflow
+	ArchiverDLQWorkflowScope
 	// TaskListScavengerScope is scope used by all metrics emitted by worker.tasklist.Scavenger module
 	TaskListScavengerScope
+	// ESRetentionScavengerScope is scope used by all metrics emitted by worker.esretention.Scavenger module
+	ESRetentionScavengerScope
+	// ESBackfillScope is scope used by all metrics emitted by worker.esbackfill.Backfiller module
+	ESBackfillScope
+	// VisibilityReconcilerScope is scope used by all metrics emitted by worker.visibility.Reconciler module
+	VisibilityReconcilerScope
+	// OpenWorkflowCounterScope is scope used by all metrics emitted by worker.counter.OpenWorkflowCounter module
+	OpenWorkflowCounterScope
+	// ArchivalMigratorScope is scope used by all metrics emitted by worker.migrator.ArchivalMigrator module
+	ArchivalMigratorScope
+	// WorkflowMigratorScope is scope used by all metrics emitted by worker.migrator.WorkflowMigrator module
+	WorkflowMigratorScope
+	// ConcurrencyGroupScope is scope used by all metrics emitted by worker.concurrency module
+	ConcurrencyGroupScope
+	// CanaryScope is scope used by the metrics of the canary workflow suite emitted by worker.canary module
+	CanaryScope
+	// BenchScope is scope used by the metrics of the bench load emitted by worker.canary module
+	BenchScope
 
 	NumWorkerScopes
 )
@@ -762,6 +878,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		PersistenceUpdateShardScope:                              {operation: "UpdateShard", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
 		PersistenceCreateWorkflowExecutionScope:                  {operation: "CreateWorkflowExecution"},
 		PersistenceGetWorkflowExecutionScope:                     {operation: "GetWorkflowExecution"},
+		PersistenceGetWorkflowExecutionsScope:                    {operation: "GetWorkflowExecutions"},
 		PersistenceUpdateWorkflowExecutionScope:                  {operation: "UpdateWorkflowExecution"},
 		PersistenceResetMutableStateScope:                        {operation: "ResetMutableState"},
 		PersistenceResetWorkflowExecutionScope:                   {operation: "ResetWorkflowExecution"},
@@ -804,6 +921,8 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		PersistenceListClosedWorkflowExecutionsByStatusScope:     {operation: "ListClosedWorkflowExecutionsByStatus"},
 		PersistenceGetClosedWorkflowExecutionScope:               {operation: "GetClosedWorkflowExecution"},
 		PersistenceVisibilityDeleteWorkflowExecutionScope:        {operation: "VisibilityDeleteWorkflowExecution"},
+		PersistenceGetWorkflowExecutionStatisticsScope:           {operation: "GetWorkflowExecutionStatistics"},
+		PersistenceCountOpenWorkflowExecutionsScope:              {operation: "CountOpenWorkflowExecutions"},
 		PersistenceAppendHistoryNodesScope:                       {operation: "AppendHistoryNodes", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
 		PersistenceReadHistoryBranchScope:                        {operation: "ReadHistoryBranch", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
 		PersistenceForkHistoryBranchScope:                        {operation: "ForkHistoryBranch", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
@@ -832,6 +951,8 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		HistoryClientGetMutableStateScope:                   {operation: "HistoryClientGetMutableState", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientResetStickyTaskListScope:               {operation: "HistoryClientResetStickyTaskListScope", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientDescribeWorkflowExecutionScope:         {operation: "HistoryClientDescribeWorkflowExecution", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientDescribeWorkflowExecutionsScope:        {operation: "HistoryClientDescribeWorkflowExecutions", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientGetReplicationTasksScope:               {operation: "HistoryClientGetReplicationTasks", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientRecordDecisionTaskStartedScope:         {operation: "HistoryClientRecordDecisionTaskStarted", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientRecordActivityTaskStartedScope:         {operation: "HistoryClientRecordActivityTaskStarted", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientRequestCancelWorkflowExecutionScope:    {operation: "HistoryClientRequestCancelWorkflowExecution", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
@@ -839,11 +960,13 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		HistoryClientSignalWithStartWorkflowExecutionScope:  {operation: "HistoryClientSignalWithStartWorkflowExecution", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientRemoveSignalMutableStateScope:          {operation: "HistoryClientRemoveSignalMutableStateScope", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientTerminateWorkflowExecutionScope:        {operation: "HistoryClientTerminateWorkflowExecution", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientUpdateWorkflowExecutionLabelsScope:     {operation: "HistoryClientUpdateWorkflowExecutionLabels", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientResetWorkflowExecutionScope:            {operation: "HistoryClientResetWorkflowExecution", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientScheduleDecisionTaskScope:              {operation: "HistoryClientScheduleDecisionTask", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientRecordChildExecutionCompletedScope:     {operation: "HistoryClientRecordChildExecutionCompleted", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientReplicateEventsScope:                   {operation: "HistoryClientReplicateEvents", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientReplicateRawEventsScope:                {operation: "HistoryClientReplicateRawEvents", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientReplicateEventsV2Scope:                 {operation: "HistoryClientReplicateEventsV2", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientSyncShardStatusScope:                   {operation: "HistoryClientSyncShardStatusScope", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientSyncActivityScope:                      {operation: "HistoryClientSyncActivityScope", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
 		MatchingClientPollForDecisionTaskScope:              {operation: "MatchingClientPollForDecisionTask", tags: map[string]string{CadenceRoleTagName: MatchingRoleTagValue}},
@@ -858,8 +981,12 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		FrontendClientDescribeDomainScope:                   {operation: "FrontendClientDescribeDomain", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
 		FrontendClientDescribeTaskListScope:                 {operation: "FrontendClientDescribeTaskList", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
 		FrontendClientDescribeWorkflowExecutionScope:        {operation: "FrontendClientDescribeWorkflowExecution", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
+		FrontendClientDescribeWorkflowExecutionsScope:       {operation: "FrontendClientDescribeWorkflowExecutions", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
+		FrontendClientGetSearchAttributesScope:              {operation: "FrontendClientGetSearchAttributes", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
 		FrontendClientGetWorkflowExecutionHistoryScope:      {operation: "FrontendClientGetWorkflowExecutionHistory", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
 		FrontendClientListClosedWorkflowExecutionsScope:     {operation: "FrontendClientListClosedWorkflowExecutions", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
+		FrontendClientGetWorkflowExecutionStatisticsScope:   {operation: "FrontendClientGetWorkflowExecutionStatistics", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
+		FrontendClientCountOpenWorkflowExecutionsScope:      {operation: "FrontendClientCountOpenWorkflowExecutions", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
 		FrontendClientListDomainsScope:                      {operation: "FrontendClientListDomains", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
 		FrontendClientListOpenWorkflowExecutionsScope:       {operation: "FrontendClientListOpenWorkflowExecutions", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
 		FrontendClientPollForActivityTaskScope:              {operation: "FrontendClientPollForActivityTask", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
@@ -884,13 +1011,23 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		FrontendClientSignalWorkflowExecutionScope:          {operation: "FrontendClientSignalWorkflowExecution", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
 		FrontendClientStartWorkflowExecutionScope:           {operation: "FrontendClientStartWorkflowExecution", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
 		FrontendClientTerminateWorkflowExecutionScope:       {operation: "FrontendClientTerminateWorkflowExecution", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
+		FrontendClientUpdateWorkflowExecutionLabelsScope:    {operation: "FrontendClientUpdateWorkflowExecutionLabels", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
 		FrontendClientUpdateDomainScope:                     {operation: "FrontendClientUpdateDomain", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
 		AdminClientDescribeHistoryHostScope:                 {operation: "AdminClientDescribeHistoryHost", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
 		AdminClientDescribeWorkflowExecutionScope:           {operation: "AdminClientDescribeWorkflowExecution", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
 		AdminClientGetWorkflowExecutionRawHistoryScope:      {operation: "AdminClientGetWorkflowExecutionRawHistory", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientExportWorkflowExecutionScope:             {operation: "AdminClientExportWorkflowExecution", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientImportWorkflowExecutionScope:             {operation: "AdminClientImportWorkflowExecution", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientMigrateWorkflowExecutionScope:            {operation: "AdminClientMigrateWorkflowExecution", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientAddRemoteClusterScope:                    {operation: "AdminClientAddRemoteCluster", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientAddSearchAttributeScope:                  {operation: "AdminClientAddSearchAttribute", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientGetReplicationTasksScope:                 {operation: "AdminClientGetReplicationTasks", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientGetDLQReplicationTasksScope:              {operation: "AdminClientGetDLQReplicationTasks", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientGetReplicationConsumerLagScope:           {operation: "AdminClientGetReplicationConsumerLag", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
 
 		MessagingClientPublishScope:      {operation: "MessagingClientPublish"},
 		MessagingClientPublishBatchScope: {operation: "MessagingClientPublishBatch"},
+		MessagingClientSpillBufferScope:  {operation: "MessagingClientSpillBuffer"},
 
 		DomainCacheScope:                               {operation: "DomainCache"},
 		HistoryRereplicationByTransferTaskScope:        {operation: "HistoryRereplicationByTransferTask"},
@@ -908,6 +1045,9 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		ElasticsearchListClosedWorkflowExecutionsByWorkflowIDScope: {operation: "ListClosedWorkflowExecutionsByWorkflowID"},
 		ElasticsearchListClosedWorkflowExecutionsByStatusScope:     {operation: "ListClosedWorkflowExecutionsByStatus"},
 		ElasticsearchGetClosedWorkflowExecutionScope:               {operation: "GetClosedWorkflowExecution"},
+		ElasticsearchGetWorkflowExecutionStatisticsScope:           {operation: "GetWorkflowExecutionStatistics"},
+		ElasticsearchCountOpenWorkflowExecutionsScope:              {operation: "CountOpenWorkflowExecutions"},
+		ElasticsearchClientScope:                                   {operation: "ElasticsearchClient"},
 	},
 	// Frontend Scope Names
 	Frontend: {
@@ -915,6 +1055,14 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		AdminDescribeHistoryHostScope:            {operation: "DescribeHistoryHost"},
 		AdminDescribeWorkflowExecutionScope:      {operation: "DescribeWorkflowExecution"},
 		AdminGetWorkflowExecutionRawHistoryScope: {operation: "GetWorkflowExecutionRawHistory"},
+		AdminExportWorkflowExecutionScope:        {operation: "ExportWorkflowExecution"},
+		AdminImportWorkflowExecutionScope:        {operation: "ImportWorkflowExecution"},
+		AdminMigrateWorkflowExecutionScope:       {operation: "MigrateWorkflowExecution"},
+		AdminAddRemoteClusterScope:               {operation: "AddRemoteCluster"},
+		AdminAddSearchAttributeScope:             {operation: "AddSearchAttribute"},
+		AdminGetReplicationTasksScope:            {operation: "GetReplicationTasks"},
+		AdminGetDLQReplicationTasksScope:         {operation: "GetDLQReplicationTasks"},
+		AdminGetReplicationConsumerLagScope:      {operation: "GetReplicationConsumerLag"},
 
 		FrontendStartWorkflowExecutionScope:           {operation: "StartWorkflowExecution"},
 		FrontendPollForDecisionTaskScope:              {operation: "PollForDecisionTask"},
@@ -934,6 +1082,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		FrontendSignalWorkflowExecutionScope:          {operation: "SignalWorkflowExecution"},
 		FrontendSignalWithStartWorkflowExecutionScope: {operation: "SignalWithStartWorkflowExecution"},
 		FrontendTerminateWorkflowExecutionScope:       {operation: "TerminateWorkflowExecution"},
+		FrontendUpdateWorkflowExecutionLabelsScope:    {operation: "UpdateWorkflowExecutionLabels"},
 		FrontendResetWorkflowExecutionScope:           {operation: "ResetWorkflowExecution"},
 		FrontendRequestCancelWorkflowExecutionScope:   {operation: "RequestCancelWorkflowExecution"},
 		FrontendListOpenWorkflowExecutionsScope:       {operation: "ListOpenWorkflowExecutions"},
@@ -945,8 +1094,12 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		FrontendDeprecateDomainScope:                  {operation: "DeprecateDomain"},
 		FrontendQueryWorkflowScope:                    {operation: "QueryWorkflow"},
 		FrontendDescribeWorkflowExecutionScope:        {operation: "DescribeWorkflowExecution"},
+		FrontendDescribeWorkflowExecutionsScope:       {operation: "DescribeWorkflowExecutions"},
 		FrontendDescribeTaskListScope:                 {operation: "DescribeTaskList"},
 		FrontendResetStickyTaskListScope:              {operation: "ResetStickyTaskList"},
+		FrontendGetWorkflowExecutionStatisticsScope:   {operation: "GetWorkflowExecutionStatistics"},
+		FrontendCountOpenWorkflowExecutionsScope:      {operation: "CountOpenWorkflowExecutions"},
+		FrontendGetSearchAttributesScope:              {operation: "GetSearchAttributes"},
 	},
 	// History Scope Names
 	History: {
@@ -960,12 +1113,15 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		HistoryGetMutableStateScope:                   {operation: "GetMutableState"},
 		HistoryResetStickyTaskListScope:               {operation: "ResetStickyTaskListScope"},
 		HistoryDescribeWorkflowExecutionScope:         {operation: "DescribeWorkflowExecution"},
+		HistoryDescribeWorkflowExecutionsScope:        {operation: "DescribeWorkflowExecutions"},
+		HistoryGetReplicationTasksScope:               {operation: "GetReplicationTasks"},
 		HistoryRecordDecisionTaskStartedScope:         {operation: "RecordDecisionTaskStarted"},
 		HistoryRecordActivityTaskStartedScope:         {operation: "RecordActivityTaskStarted"},
 		HistorySignalWorkflowExecutionScope:           {operation: "SignalWorkflowExecution"},
 		HistorySignalWithStartWorkflowExecutionScope:  {operation: "SignalWithStartWorkflowExecution"},
 		HistoryRemoveSignalMutableStateScope:          {operation: "RemoveSignalMutableState"},
 		HistoryTerminateWorkflowExecutionScope:        {operation: "TerminateWorkflowExecution"},
+		HistoryUpdateWorkflowExecutionLabelsScope:     {operation: "UpdateWorkflowExecutionLabels"},
 		HistoryResetWorkflowExecutionScope:            {operation: "ResetWorkflowExecution"},
 		HistoryProcessDeleteHistoryEventScope:         {operation: "ProcessDeleteHistoryEvent"},
 		HistoryScheduleDecisionTaskScope:              {operation: "ScheduleDecisionTask"},
@@ -973,6 +1129,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		HistoryRequestCancelWorkflowExecutionScope:    {operation: "RequestCancelWorkflowExecution"},
 		HistoryReplicateEventsScope:                   {operation: "ReplicateEvents"},
 		HistoryReplicateRawEventsScope:                {operation: "ReplicateRawEvents"},
+		HistoryReplicateEventsV2Scope:                 {operation: "ReplicateEventsV2"},
 		HistorySyncShardStatusScope:                   {operation: "SyncShardStatus"},
 		HistorySyncActivityScope:                      {operation: "SyncActivity"},
 		HistoryDescribeMutableStateScope:              {operation: "DescribeMutableState"},
@@ -987,6 +1144,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		TransferActiveTaskSignalExecutionScope:        {operation: "TransferActiveTaskSignalExecution"},
 		TransferActiveTaskStartChildExecutionScope:    {operation: "TransferActiveTaskStartChildExecution"},
 		TransferActiveTaskRecordWorkflowStartedScope:  {operation: "TransferActiveTaskRecordWorkflowStarted"},
+		TransferActiveTaskUpsertWorkflowLabelsScope:   {operation: "TransferActiveTaskUpsertWorkflowLabels"},
 		TransferStandbyTaskActivityScope:              {operation: "TransferStandbyTaskActivity"},
 		TransferStandbyTaskDecisionScope:              {operation: "TransferStandbyTaskDecision"},
 		TransferStandbyTaskCloseExecutionScope:        {operation: "TransferStandbyTaskCloseExecution"},
@@ -994,6 +1152,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		TransferStandbyTaskSignalExecutionScope:       {operation: "TransferStandbyTaskSignalExecution"},
 		TransferStandbyTaskStartChildExecutionScope:   {operation: "TransferStandbyTaskStartChildExecution"},
 		TransferStandbyTaskRecordWorkflowStartedScope: {operation: "TransferStandbyTaskRecordWorkflowStarted"},
+		TransferStandbyTaskUpsertWorkflowLabelsScope:  {operation: "TransferStandbyTaskUpsertWorkflowLabels"},
 		TimerQueueProcessorScope:                      {operation: "TimerQueueProcessor"},
 		TimerActiveQueueProcessorScope:                {operation: "TimerActiveQueueProcessor"},
 		TimerStandbyQueueProcessorScope:               {operation: "TimerStandbyQueueProcessor"},
@@ -1003,6 +1162,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		TimerActiveTaskWorkflowTimeoutScope:           {operation: "TimerActiveTaskWorkflowTimeout"},
 		TimerActiveTaskActivityRetryTimerScope:        {operation: "TimerActiveTaskActivityRetryTimer"},
 		TimerActiveTaskWorkflowBackoffTimerScope:      {operation: "TimerActiveTaskWorkflowBackoffTimer"},
+		TimerActiveTaskDelayedSignalScope:             {operation: "TimerActiveTaskDelayedSignal"},
 		TimerActiveTaskDeleteHistoryEventScope:        {operation: "TimerActiveTaskDeleteHistoryEvent"},
 		TimerStandbyTaskActivityTimeoutScope:          {operation: "TimerStandbyTaskActivityTimeout"},
 		TimerStandbyTaskDecisionTimeoutScope:          {operation: "TimerStandbyTaskDecisionTimeout"},
@@ -1010,6 +1170,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		TimerStandbyTaskWorkflowTimeoutScope:          {operation: "TimerStandbyTaskWorkflowTimeout"},
 		TimerStandbyTaskActivityRetryTimerScope:       {operation: "TimerStandbyTaskActivityRetryTimer"},
 		TimerStandbyTaskWorkflowBackoffTimerScope:     {operation: "TimerStandbyTaskWorkflowBackoffTimer"},
+		TimerStandbyTaskDelayedSignalScope:            {operation: "TimerStandbyTaskDelayedSignal"},
 		TimerStandbyTaskDeleteHistoryEventScope:       {operation: "TimerStandbyTaskDeleteHistoryEvent"},
 		HistoryEventNotificationScope:                 {operation: "HistoryEventNotification"},
 		ReplicatorQueueProcessorScope:                 {operation: "ReplicatorQueueProcessor"},
@@ -1021,6 +1182,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		HistoryCacheGetAndCreateScope:                 {operation: "HistoryCacheGetAndCreate", tags: map[string]string{CacheTypeTagName: MutableStateCacheTypeTagValue}},
 		HistoryCacheGetOrCreateScope:                  {operation: "HistoryCacheGetOrCreate", tags: map[string]string{CacheTypeTagName: MutableStateCacheTypeTagValue}},
 		HistoryCacheGetCurrentExecutionScope:          {operation: "HistoryCacheGetCurrentExecution", tags: map[string]string{CacheTypeTagName: MutableStateCacheTypeTagValue}},
+		HistoryCacheScope:                             {operation: "HistoryCache", tags: map[string]string{CacheTypeTagName: MutableStateCacheTypeTagValue}},
 		EventsCacheGetEventScope:                      {operation: "EventsCacheGetEvent", tags: map[string]string{CacheTypeTagName: EventsCacheTypeTagValue}},
 		EventsCachePutEventScope:                      {operation: "EventsCachePutEvent", tags: map[string]string{CacheTypeTagName: EventsCacheTypeTagValue}},
 		EventsCacheDeleteEventScope:                   {operation: "EventsCacheDeleteEvent", tags: map[string]string{CacheTypeTagName: EventsCacheTypeTagValue}},
@@ -1048,8 +1210,10 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		ReplicatorScope:                    {operation: "Replicator"},
 		DomainReplicationTaskScope:         {operation: "DomainReplicationTask"},
 		HistoryReplicationTaskScope:        {operation: "HistoryReplicationTask"},
+		HistoryReplicationV2TaskScope:      {operation: "HistoryReplicationV2Task"},
 		SyncShardTaskScope:                 {operation: "SyncShardTask"},
 		SyncActivityTaskScope:              {operation: "SyncActivityTask"},
+		ReplicatorConsumerLagScope:         {operation: "ReplicatorConsumerLag"},
 		ESProcessorScope:                   {operation: "ESProcessor"},
 		IndexProcessorScope:                {operation: "IndexProcessor"},
 		ArchiverUploadHistoryActivityScope: {operation: "ArchiverUploadHistoryActivity"},
@@ -1058,7 +1222,17 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		ArchiverPumpScope:                  {operation: "ArchiverPump"},
 		ArchiverArchivalWorkflowScope:      {operation: "ArchiverArchivalWorkflow"},
 		ArchiverClientScope:                {operation: "ArchiverClient"},
+		ArchiverDLQWorkflowScope:           {operation: "ArchiverDLQWorkflow"},
 		TaskListScavengerScope:             {operation: "tasklistscavenger"},
+		ESRetentionScavengerScope:          {operation: "esretentionscavenger"},
+		ESBackfillScope:                    {operation: "esbackfill"},
+		VisibilityReconcilerScope:          {operation: "visibilityreconciler"},
+		OpenWorkflowCounterScope:           {operation: "openworkflowcounter"},
+		ArchivalMigratorScope:              {operation: "archivalmigrator"},
+		WorkflowMigratorScope:              {operation: "workflowmigrator"},
+		ConcurrencyGroupScope:              {operation: "concurrencygroup"},
+		CanaryScope:                        {operation: "canary"},
+		BenchScope:                         {operation: "bench"},
 	},
 	// Blobstore Scope Names
 	Blobstore: {
@@ -1089,6 +1263,9 @@ const (
 	CadenceErrLimitExceededCounter
 	CadenceErrContextTimeoutCounter
 	CadenceErrRetryTaskCounter
+	CadenceLatencySLORequests
+	CadenceLatencySLOBreaches
+	CadenceClientVersionRejected
 	PersistenceRequests
 	PersistenceFailures
 	PersistenceLatency
@@ -1103,17 +1280,28 @@ const (
 	PersistenceErrDomainAlreadyExistsCounter
 	PersistenceErrBadRequestCounter
 	PersistenceSampledCounter
+	PersistenceAsyncWriteFailures
 
 	CadenceClientRequests
 	CadenceClientFailures
 	CadenceClientLatency
 
+	MessagingSpillBufferedCounter
+	MessagingSpillDeliveredCounter
+	MessagingSpillDroppedCounter
+	MessagingSpillBufferSizeGauge
+
 	DomainCachePrepareCallbacksLatency
 	DomainCacheCallbacksLatency
+	DomainCacheNotFoundHitsCounter
+
+	StackTraceQueryCacheHitsCounter
 
 	HistorySize
 	HistoryCount
 	EventBlobSize
+	BlobSizeWarnCount
+	BlobSizeExceedsLimitCount
 
 	ArchivalConfigFailures
 
@@ -1122,6 +1310,9 @@ const (
 	ElasticsearchLatency
 	ElasticsearchErrBadRequestCounter
 	ElasticsearchErrBusyCounter
+	ElasticsearchErrEntityNotExistsCounter
+	ElasticsearchCircuitBreakerOpenCounter
+	ElasticsearchCircuitBreakerRejectedCounter
 
 	NumCommonMetrics // Needs to be last on this list for iota numbering
 )
@@ -1137,6 +1328,7 @@ const (
 	TaskNotActiveCounter
 	TaskLimitExceededCounter
 	TaskBatchCompleteCounter
+	TaskBatchHostThrottledCounter
 	TaskProcessingLatency
 	TaskQueueLatency
 
@@ -1150,6 +1342,8 @@ const (
 	DecisionTypeCancelActivityCounter
 	DecisionTypeCancelTimerCounter
 	DecisionTypeRecordMarkerCounter
+	DecisionTypeLocalActivityMarkerCounter
+	LocalActivityMarkersSize
 	DecisionTypeCancelExternalWorkflowCounter
 	DecisionTypeChildWorkflowCounter
 	DecisionTypeContinueAsNewCounter
@@ -1157,15 +1351,20 @@ const (
 	MultipleCompletionDecisionsCounter
 	FailedDecisionsCounter
 	StaleMutableStateCounter
+	ActivityHeartbeatCoalescedCounter
+	PendingChildWorkflowsLimitExceededCounter
+	PendingActivitiesLimitExceededCounter
 	ConcurrencyUpdateFailureCounter
 	CadenceErrEventAlreadyStartedCounter
 	CadenceErrShardOwnershipLostCounter
 	HeartbeatTimeoutCounter
 	ScheduleToStartTimeoutCounter
 	StartToCloseTimeoutCounter
+	StuckDecisionCounter
 	ScheduleToCloseTimeoutCounter
 	NewTimerCounter
 	NewTimerNotifyCounter
+	TimerWheelSizeTimer
 	AcquireShardsCounter
 	AcquireShardsLatency
 	ShardClosedCounter
@@ -1208,8 +1407,12 @@ const (
 	CacheFailures
 	CacheLatency
 	CacheMissCounter
+	CacheHitCounter
+	CacheEvictionCounter
 	AcquireLockFailedCounter
 	WorkflowContextCleared
+	BufferedEventsLimitExceededCounter
+	MutableStateChecksumMismatchCounter
 	MutableStateSize
 	ExecutionInfoSize
 	ActivityInfoSize
@@ -1232,6 +1435,8 @@ const (
 	DeleteRequestCancelInfoCount
 	WorkflowRetryBackoffTimerCount
 	WorkflowCronBackoffTimerCount
+	DelayedSignalDeliveredCount
+	DelayedSignalDroppedCount
 	WorkflowCleanupDeleteCount
 	WorkflowCleanupArchiveCount
 	WorkflowCleanupNopCount
@@ -1257,6 +1462,10 @@ const (
 	BufferThrottleCounter
 	SyncMatchLatency
 	ExpiredTasksCounter
+	TasksAddedWithoutPollersCounter
+	TasksSyncMatchedCounter
+	TasksPersistedCounter
+	SyncMatchFailedCounter
 
 	NumMatchingMetrics
 )
@@ -1267,8 +1476,16 @@ const (
 	ReplicatorFailures
 	ReplicatorMessagesDropped
 	ReplicatorLatency
+	ReplicatorConsumerLag
+	ReplicatorConsumerPartitionLag
+	ReplicatorProcessingRate
+	ReplicatorProduceRate
+	ReplicatorConsumerLagFailures
 	ESProcessorFailures
 	ESProcessorCorruptedData
+	ESProcessorThrottledBulks
+	ESProcessorBulkActionsGauge
+	ESProcessorBackpressureLatency
 	IndexProcessorCorruptedData
 	ArchiverNonRetryableErrorCount
 	ArchiverSkipUploadCount
@@ -1300,6 +1517,11 @@ const (
 	ArchiverHandleAllRequestsLatency
 	ArchiverWorkflowStoppingCount
 	ArchiverClientSendSignalFailureCount
+	ArchiverSendToDLQCount
+	ArchiverSendToDLQFailedCount
+	ArchiverDLQDroppedCount
+	ArchiverDLQRedriveSuccessCount
+	ArchiverDLQRedriveFailedCount
 	TaskProcessedCount
 	TaskDeletedCount
 	TaskListProcessedCount
@@ -1309,6 +1531,38 @@ const (
 	StoppedCount
 	ExecutorTasksDeferredCount
 	ExecutorTasksDroppedCount
+	ESRetentionDeletedCount
+	ESRetentionDomainProcessedCount
+	ESRetentionFailures
+	ESBackfillIndexedCount
+	ESBackfillFailures
+	VisibilityReconcilerCheckedCount
+	VisibilityReconcilerStaleOpenCount
+	VisibilityReconcilerStaleClosedCount
+	VisibilityReconcilerMissingExecutionCount
+	VisibilityReconcilerESMismatchCount
+	VisibilityReconcilerRepairedCount
+	VisibilityReconcilerFailures
+	OpenWorkflowExecutionsGauge
+	OpenWorkflowCounterFailures
+	ArchivalMigratorBlobsCopiedCount
+	ArchivalMigratorMigrationsCompletedCount
+	ArchivalMigratorFailures
+	WorkflowMigratorExecutionsMigratedCount
+	WorkflowMigratorExecutionsVerifiedCount
+	WorkflowMigratorFailures
+	ConcurrencyGroupRunsDispatchedCount
+	ConcurrencyGroupFailures
+	CanarySuccessCount
+	CanaryFailures
+	CanaryLatency
+	BenchWorkflowsStartedCount
+	BenchWorkflowStartLatency
+	BenchSignalsSentCount
+	BenchSignalLatency
+	BenchActivityScheduleToStartLatency
+	BenchActivityLatency
+	BenchFailures
 	NumWorkerMetrics
 )
 
@@ -1330,6 +1584,9 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		CadenceErrLimitExceededCounter:                      {metricName: "cadence_errors_limit_exceeded", oldMetricName: "cadence.errors.limit-exceeded", metricType: Counter},
 		CadenceErrContextTimeoutCounter:                     {metricName: "cadence_errors_context_timeout", oldMetricName: "cadence.errors.context-timeout", metricType: Counter},
 		CadenceErrRetryTaskCounter:                          {metricName: "cadence_errors_retry_task", oldMetricName: "cadence.errors.retry-task", metricType: Counter},
+		CadenceLatencySLORequests:                           {metricName: "cadence_latency_slo_requests", oldMetricName: "cadence.latency-slo.requests", metricType: Counter},
+		CadenceLatencySLOBreaches:                           {metricName: "cadence_latency_slo_breaches", oldMetricName: "cadence.latency-slo.breaches", metricType: Counter},
+		CadenceClientVersionRejected:                        {metricName: "cadence_client_version_rejected", oldMetricName: "cadence.client-version-rejected", metricType: Counter},
 		PersistenceRequests:                                 {metricName: "persistence_requests", oldMetricName: "persistence.requests", metricType: Counter},
 		PersistenceFailures:                                 {metricName: "persistence_errors", oldMetricName: "persistence.errors", metricType: Counter},
 		PersistenceLatency:                                  {metricName: "persistence_latency", oldMetricName: "persistence.latency", metricType: Timer},
@@ -1344,20 +1601,32 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		PersistenceErrDomainAlreadyExistsCounter:            {metricName: "persistence_errors_domain_already_exists", oldMetricName: "persistence.errors.domain-already-exists", metricType: Counter},
 		PersistenceErrBadRequestCounter:                     {metricName: "persistence_errors_bad_request", oldMetricName: "persistence.errors.bad-request", metricType: Counter},
 		PersistenceSampledCounter:                           {metricName: "persistence_sampled", oldMetricName: "persistence.sampled", metricType: Counter},
+		PersistenceAsyncWriteFailures:                       {metricName: "persistence_async_write_failures", metricType: Counter},
 		CadenceClientRequests:                               {metricName: "cadence_client_requests", oldMetricName: "cadence.client.requests", metricType: Counter},
 		CadenceClientFailures:                               {metricName: "cadence_client_errors", oldMetricName: "cadence.client.errors", metricType: Counter},
 		CadenceClientLatency:                                {metricName: "cadence_client_latency", oldMetricName: "cadence.client.latency", metricType: Timer},
+		MessagingSpillBufferedCounter:                       {metricName: "messaging_spill_buffered", metricType: Counter},
+		MessagingSpillDeliveredCounter:                      {metricName: "messaging_spill_delivered", metricType: Counter},
+		MessagingSpillDroppedCounter:                        {metricName: "messaging_spill_dropped", metricType: Counter},
+		MessagingSpillBufferSizeGauge:                       {metricName: "messaging_spill_buffer_size", metricType: Gauge},
 		DomainCachePrepareCallbacksLatency:                  {metricName: "domain_cache_prepare_callbacks_latency", oldMetricName: "domain-cache.prepare-callbacks.latency", metricType: Timer},
 		DomainCacheCallbacksLatency:                         {metricName: "domain_cache_callbacks_latency", oldMetricName: "domain-cache.callbacks.latency", metricType: Timer},
+		DomainCacheNotFoundHitsCounter:                      {metricName: "domain_cache_not_found_hits", metricType: Counter},
+		StackTraceQueryCacheHitsCounter:                     {metricName: "stack_trace_query_cache_hits", metricType: Counter},
 		HistorySize:                                         {metricName: "history_size", oldMetricName: "history-size", metricType: Timer},
 		HistoryCount:                                        {metricName: "history_count", oldMetricName: "history-count", metricType: Timer},
 		EventBlobSize:                                       {metricName: "event_blob_size", oldMetricName: "event-blob-size", metricType: Timer},
+		BlobSizeWarnCount:                                   {metricName: "blob_size_warn", oldMetricName: "blob-size-warn", metricType: Counter},
+		BlobSizeExceedsLimitCount:                           {metricName: "blob_size_exceeds_limit", oldMetricName: "blob-size-exceeds-limit", metricType: Counter},
 		ArchivalConfigFailures:                              {metricName: "archivalconfig_failures", oldMetricName: "archivalconfig.failures", metricType: Counter},
 		ElasticsearchRequests:                               {metricName: "elasticsearch_requests", oldMetricName: "elasticsearch.requests", metricType: Counter},
 		ElasticsearchFailures:                               {metricName: "elasticsearch_errors", oldMetricName: "elasticsearch.errors", metricType: Counter},
 		ElasticsearchLatency:                                {metricName: "elasticsearch_latency", oldMetricName: "elasticsearch.latency", metricType: Timer},
 		ElasticsearchErrBadRequestCounter:                   {metricName: "elasticsearch_errors_bad_request", oldMetricName: "elasticsearch.errors.bad-request", metricType: Counter},
 		ElasticsearchErrBusyCounter:                         {metricName: "elasticsearch_errors_busy", oldMetricName: "elasticsearch.errors.busy", metricType: Counter},
+		ElasticsearchErrEntityNotExistsCounter:              {metricName: "elasticsearch_errors_entity_not_exists", oldMetricName: "elasticsearch.errors.entity-not-exists", metricType: Counter},
+		ElasticsearchCircuitBreakerOpenCounter:              {metricName: "elasticsearch_circuit_breaker_open", metricType: Counter},
+		ElasticsearchCircuitBreakerRejectedCounter:          {metricName: "elasticsearch_circuit_breaker_rejected", metricType: Counter},
 	},
 	Frontend: {},
 	History: {
@@ -1372,6 +1641,7 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		TaskProcessingLatency:                        {metricName: "task_latency_processing", oldMetricName: "task.latency.processing", metricType: Timer},
 		TaskQueueLatency:                             {metricName: "task_latency_queue", oldMetricName: "task.latency.queue", metricType: Timer},
 		TaskBatchCompleteCounter:                     {metricName: "task_batch_complete_counter", oldMetricName: "task.batch-complete-counter", metricType: Counter},
+		TaskBatchHostThrottledCounter:                {metricName: "task_batch_host_throttled_counter", oldMetricName: "task.batch-host-throttled-counter", metricType: Counter},
 		AckLevelUpdateCounter:                        {metricName: "ack_level_update", oldMetricName: "ack-level-update", metricType: Counter},
 		AckLevelUpdateFailedCounter:                  {metricName: "ack_level_update_failed", oldMetricName: "ack-level-update-failed", metricType: Counter},
 		DecisionTypeScheduleActivityCounter:          {metricName: "schedule_activity_decision", oldMetricName: "schedule-activity-decision", metricType: Counter},
@@ -1382,6 +1652,8 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		DecisionTypeCancelActivityCounter:            {metricName: "cancel_activity_decision", oldMetricName: "cancel-activity-decision", metricType: Counter},
 		DecisionTypeCancelTimerCounter:               {metricName: "cancel_timer_decision", oldMetricName: "cancel-timer-decision", metricType: Counter},
 		DecisionTypeRecordMarkerCounter:              {metricName: "record_marker_decision", oldMetricName: "record-marker-decision", metricType: Counter},
+		DecisionTypeLocalActivityMarkerCounter:       {metricName: "local_activity_marker_decision", metricType: Counter},
+		LocalActivityMarkersSize:                     {metricName: "local_activity_markers_size", metricType: Timer},
 		DecisionTypeCancelExternalWorkflowCounter:    {metricName: "cancel_external_workflow_decision", oldMetricName: "cancel-external-workflow-decision", metricType: Counter},
 		DecisionTypeContinueAsNewCounter:             {metricName: "continue_as_new_decision", oldMetricName: "continue-as-new-decision", metricType: Counter},
 		DecisionTypeSignalExternalWorkflowCounter:    {metricName: "signal_external_workflow_decision", oldMetricName: "signal-external-workflow-decision", metricType: Counter},
@@ -1389,15 +1661,20 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		MultipleCompletionDecisionsCounter:           {metricName: "multiple_completion_decisions", oldMetricName: "multiple-completion-decisions", metricType: Counter},
 		FailedDecisionsCounter:                       {metricName: "failed_decisions", oldMetricName: "failed-decisions", metricType: Counter},
 		StaleMutableStateCounter:                     {metricName: "stale_mutable_state", oldMetricName: "stale-mutable-state", metricType: Counter},
+		ActivityHeartbeatCoalescedCounter:            {metricName: "activity_heartbeat_coalesced", metricType: Counter},
+		PendingChildWorkflowsLimitExceededCounter:    {metricName: "pending_child_workflows_limit_exceeded", metricType: Counter},
+		PendingActivitiesLimitExceededCounter:        {metricName: "pending_activities_limit_exceeded", metricType: Counter},
 		ConcurrencyUpdateFailureCounter:              {metricName: "concurrency_update_failure", oldMetricName: "concurrency-update-failure", metricType: Counter},
 		CadenceErrShardOwnershipLostCounter:          {metricName: "cadence_errors_shard_ownership_lost", oldMetricName: "cadence.errors.shard-ownership-lost", metricType: Counter},
 		CadenceErrEventAlreadyStartedCounter:         {metricName: "cadence_errors_event_already_started", oldMetricName: "cadence.errors.event-already-started", metricType: Counter},
 		HeartbeatTimeoutCounter:                      {metricName: "heartbeat_timeout", oldMetricName: "heartbeat-timeout", metricType: Counter},
 		ScheduleToStartTimeoutCounter:                {metricName: "schedule_to_start_timeout", oldMetricName: "schedule-to-start-timeout", metricType: Counter},
 		StartToCloseTimeoutCounter:                   {metricName: "start_to_close_timeout", oldMetricName: "start-to-close-timeout", metricType: Counter},
+		StuckDecisionCounter:                         {metricName: "stuck_decision", metricType: Counter},
 		ScheduleToCloseTimeoutCounter:                {metricName: "schedule_to_close_timeout", oldMetricName: "schedule-to-close-timeout", metricType: Counter},
 		NewTimerCounter:                              {metricName: "new_timer", oldMetricName: "new-timer", metricType: Counter},
 		NewTimerNotifyCounter:                        {metricName: "new_timer_notifications", oldMetricName: "new-timer-notifications", metricType: Counter},
+		TimerWheelSizeTimer:                          {metricName: "timer_wheel_size", metricType: Timer},
 		AcquireShardsCounter:                         {metricName: "acquire_shards_count", oldMetricName: "acquire-shards-count", metricType: Counter},
 		AcquireShardsLatency:                         {metricName: "acquire_shards_latency", oldMetricName: "acquire-shards-latency", metricType: Timer},
 		ShardClosedCounter:                           {metricName: "shard_closed_count", oldMetricName: "shard-closed-count", metricType: Counter},
@@ -1440,8 +1717,12 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		CacheFailures:                                {metricName: "cache_errors", oldMetricName: "cache.errors", metricType: Counter},
 		CacheLatency:                                 {metricName: "cache_latency", oldMetricName: "cache.latency", metricType: Timer},
 		CacheMissCounter:                             {metricName: "cache_miss", oldMetricName: "cache-miss", metricType: Counter},
+		CacheHitCounter:                              {metricName: "cache_hit", metricType: Counter},
+		CacheEvictionCounter:                         {metricName: "cache_eviction", metricType: Counter},
 		AcquireLockFailedCounter:                     {metricName: "acquire_lock_failed", oldMetricName: "acquire-lock-failed", metricType: Counter},
 		WorkflowContextCleared:                       {metricName: "workflow_context_cleared", oldMetricName: "workflow-context-cleared", metricType: Counter},
+		BufferedEventsLimitExceededCounter:           {metricName: "buffered_events_limit_exceeded", metricType: Counter},
+		MutableStateChecksumMismatchCounter:          {metricName: "mutable_state_checksum_mismatch", metricType: Counter},
 		MutableStateSize:                             {metricName: "mutable_state_size", oldMetricName: "mutable-state-size", metricType: Timer},
 		ExecutionInfoSize:                            {metricName: "execution_info_size", oldMetricName: "execution-info-size", metricType: Timer},
 		ActivityInfoSize:                             {metricName: "activity_info_size", oldMetricName: "activity-info-size", metricType: Timer},
@@ -1464,6 +1745,8 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		DeleteRequestCancelInfoCount:                 {metricName: "delete_request_cancel_info", oldMetricName: "delete-request-cancel-info", metricType: Timer},
 		WorkflowRetryBackoffTimerCount:               {metricName: "workflow_retry_backoff_timer", oldMetricName: "workflow-retry-backoff-timer", metricType: Counter},
 		WorkflowCronBackoffTimerCount:                {metricName: "workflow_cron_backoff_timer", oldMetricName: "workflow-cron-backoff-timer", metricType: Counter},
+		DelayedSignalDeliveredCount:                  {metricName: "delayed_signal_delivered", metricType: Counter},
+		DelayedSignalDroppedCount:                    {metricName: "delayed_signal_dropped", metricType: Counter},
 		WorkflowCleanupDeleteCount:                   {metricName: "workflow_cleanup_delete", oldMetricName: "workflow-cleanup-delete", metricType: Counter},
 		WorkflowCleanupArchiveCount:                  {metricName: "workflow_cleanup_archive", oldMetricName: "workflow-cleanup-archive", metricType: Counter},
 		WorkflowCleanupNopCount:                      {metricName: "workflow_cleanup_nop", oldMetricName: "workflow-cleanup-nop", metricType: Counter},
@@ -1474,25 +1757,37 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		WorkflowTerminateCount:                       {metricName: "workflow_terminate", metricType: Counter},
 	},
 	Matching: {
-		PollSuccessCounter:            {metricName: "poll_success", oldMetricName: "poll.success"},
-		PollTimeoutCounter:            {metricName: "poll_timeouts", oldMetricName: "poll.timeouts"},
-		PollSuccessWithSyncCounter:    {metricName: "poll_success_sync", oldMetricName: "poll.success.sync"},
-		LeaseRequestCounter:           {metricName: "lease_requests", oldMetricName: "lease.requests"},
-		LeaseFailureCounter:           {metricName: "lease_failures", oldMetricName: "lease.failures"},
-		ConditionFailedErrorCounter:   {metricName: "condition_failed_errors", oldMetricName: "condition-failed-errors"},
-		RespondQueryTaskFailedCounter: {metricName: "respond_query_failed", oldMetricName: "respond-query-failed"},
-		SyncThrottleCounter:           {metricName: "sync_throttle_count", oldMetricName: "sync.throttle.count"},
-		BufferThrottleCounter:         {metricName: "buffer_throttle_count", oldMetricName: "buffer.throttle.count"},
-		ExpiredTasksCounter:           {metricName: "tasks_expired", oldMetricName: "tasks.expired"},
-		SyncMatchLatency:              {metricName: "syncmatch_latency", oldMetricName: "syncmatch.latency", metricType: Timer},
+		PollSuccessCounter:              {metricName: "poll_success", oldMetricName: "poll.success"},
+		PollTimeoutCounter:              {metricName: "poll_timeouts", oldMetricName: "poll.timeouts"},
+		PollSuccessWithSyncCounter:      {metricName: "poll_success_sync", oldMetricName: "poll.success.sync"},
+		LeaseRequestCounter:             {metricName: "lease_requests", oldMetricName: "lease.requests"},
+		LeaseFailureCounter:             {metricName: "lease_failures", oldMetricName: "lease.failures"},
+		ConditionFailedErrorCounter:     {metricName: "condition_failed_errors", oldMetricName: "condition-failed-errors"},
+		RespondQueryTaskFailedCounter:   {metricName: "respond_query_failed", oldMetricName: "respond-query-failed"},
+		SyncThrottleCounter:             {metricName: "sync_throttle_count", oldMetricName: "sync.throttle.count"},
+		BufferThrottleCounter:           {metricName: "buffer_throttle_count", oldMetricName: "buffer.throttle.count"},
+		ExpiredTasksCounter:             {metricName: "tasks_expired", oldMetricName: "tasks.expired"},
+		SyncMatchLatency:                {metricName: "syncmatch_latency", oldMetricName: "syncmatch.latency", metricType: Timer},
+		TasksAddedWithoutPollersCounter: {metricName: "tasks_added_without_pollers", metricType: Counter},
+		TasksSyncMatchedCounter:         {metricName: "tasks_sync_matched", metricType: Counter},
+		TasksPersistedCounter:           {metricName: "tasks_persisted", metricType: Counter},
+		SyncMatchFailedCounter:          {metricName: "sync_match_failed", metricType: Counter},
 	},
 	Worker: {
 		ReplicatorMessages:                                     {metricName: "replicator_messages", oldMetricName: "replicator.messages"},
 		ReplicatorFailures:                                     {metricName: "replicator_errors", oldMetricName: "replicator.errors"},
 		ReplicatorMessagesDropped:                              {metricName: "replicator_messages_dropped", oldMetricName: "replicator.messages.dropped"},
 		ReplicatorLatency:                                      {metricName: "replicator_latency", oldMetricName: "replicator.latency"},
+		ReplicatorConsumerLag:                                  {metricName: "replicator_consumer_lag", metricType: Gauge},
+		ReplicatorConsumerPartitionLag:                         {metricName: "replicator_consumer_partition_lag", metricType: Gauge},
+		ReplicatorProcessingRate:                               {metricName: "replicator_processing_rate", metricType: Gauge},
+		ReplicatorProduceRate:                                  {metricName: "replicator_produce_rate", metricType: Gauge},
+		ReplicatorConsumerLagFailures:                          {metricName: "replicator_consumer_lag_errors", metricType: Counter},
 		ESProcessorFailures:                                    {metricName: "es_processor_errors", oldMetricName: "es-processor.errors"},
 		ESProcessorCorruptedData:                               {metricName: "es_processor_corrupted_data", oldMetricName: "es-processor.corrupted-data"},
+		ESProcessorThrottledBulks:                              {metricName: "es_processor_throttled_bulks", metricType: Counter},
+		ESProcessorBulkActionsGauge:                            {metricName: "es_processor_bulk_actions", metricType: Gauge},
+		ESProcessorBackpressureLatency:                         {metricName: "es_processor_backpressure_latency", metricType: Timer},
 		IndexProcessorCorruptedData:                            {metricName: "index_processor_corrupted_data", oldMetricName: "index-processor.corrupted-data"},
 		ArchiverNonRetryableErrorCount:                         {metricName: "archiver_non_retryable_error", oldMetricName: "archiver.non-retryable-error"},
 		ArchiverSkipUploadCount:                                {metricName: "archiver_skip_upload", oldMetricName: "archiver.skip-upload"},
@@ -1524,6 +1819,11 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		ArchiverHandleAllRequestsLatency:                       {metricName: "archiver_handle_all_requests_latency", oldMetricName: "archiver.handle-all-requests-latency"},
 		ArchiverWorkflowStoppingCount:                          {metricName: "archiver_workflow_stopping", oldMetricName: "archiver.workflow-stopping"},
 		ArchiverClientSendSignalFailureCount:                   {metricName: "archiver_client_send_signal_error", oldMetricName: "archiver.client-send-signal-error"},
+		ArchiverSendToDLQCount:                                 {metricName: "archiver_send_to_dlq"},
+		ArchiverSendToDLQFailedCount:                           {metricName: "archiver_send_to_dlq_failed"},
+		ArchiverDLQDroppedCount:                                {metricName: "archiver_dlq_dropped"},
+		ArchiverDLQRedriveSuccessCount:                         {metricName: "archiver_dlq_redrive_success"},
+		ArchiverDLQRedriveFailedCount:                          {metricName: "archiver_dlq_redrive_failed"},
 		TaskProcessedCount:                                     {metricName: "task_processed", metricType: Gauge},
 		TaskDeletedCount:                                       {metricName: "task_deleted", metricType: Gauge},
 		TaskListProcessedCount:                                 {metricName: "tasklist_processed", metricType: Gauge},
@@ -1533,6 +1833,38 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		StoppedCount:                                           {metricName: "stopped", metricType: Counter},
 		ExecutorTasksDeferredCount:                             {metricName: "executor_deferred", metricType: Counter},
 		ExecutorTasksDroppedCount:                              {metricName: "executor_dropped", metricType: Counter},
+		ESRetentionDeletedCount:                                {metricName: "es_retention_deleted", metricType: Counter},
+		ESRetentionDomainProcessedCount:                        {metricName: "es_retention_domain_processed", metricType: Counter},
+		ESRetentionFailures:                                    {metricName: "es_retention_errors", metricType: Counter},
+		ESBackfillIndexedCount:                                 {metricName: "es_backfill_indexed", metricType: Counter},
+		ESBackfillFailures:                                     {metricName: "es_backfill_errors", metricType: Counter},
+		VisibilityReconcilerCheckedCount:                       {metricName: "visibility_reconciler_checked", metricType: Counter},
+		VisibilityReconcilerStaleOpenCount:                     {metricName: "visibility_reconciler_stale_open", metricType: Counter},
+		VisibilityReconcilerStaleClosedCount:                   {metricName: "visibility_reconciler_stale_closed", metricType: Counter},
+		VisibilityReconcilerMissingExecutionCount:              {metricName: "visibility_reconciler_missing_execution", metricType: Counter},
+		VisibilityReconcilerESMismatchCount:                    {metricName: "visibility_reconciler_es_mismatch", metricType: Counter},
+		VisibilityReconcilerRepairedCount:                      {metricName: "visibility_reconciler_repaired", metricType: Counter},
+		VisibilityReconcilerFailures:                           {metricName: "visibility_reconciler_errors", metricType: Counter},
+		OpenWorkflowExecutionsGauge:                            {metricName: "open_workflow_executions", metricType: Gauge},
+		OpenWorkflowCounterFailures:                            {metricName: "open_workflow_counter_errors", metricType: Counter},
+		ArchivalMigratorBlobsCopiedCount:                       {metricName: "archival_migrator_blobs_copied", metricType: Counter},
+		ArchivalMigratorMigrationsCompletedCount:               {metricName: "archival_migrator_migrations_completed", metricType: Counter},
+		ArchivalMigratorFailures:                               {metricName: "archival_migrator_errors", metricType: Counter},
+		WorkflowMigratorExecutionsMigratedCount:                {metricName: "workflow_migrator_executions_migrated", metricType: Counter},
+		WorkflowMigratorExecutionsVerifiedCount:                {metricName: "workflow_migrator_executions_verified", metricType: Counter},
+		WorkflowMigratorFailures:                               {metricName: "workflow_migrator_errors", metricType: Counter},
+		ConcurrencyGroupRunsDispatchedCount:                    {metricName: "concurrency_group_runs_dispatched", metricType: Counter},
+		ConcurrencyGroupFailures:                               {metricName: "concurrency_group_errors", metricType: Counter},
+		CanarySuccessCount:                                     {metricName: "canary_success", metricType: Counter},
+		CanaryFailures:                                         {metricName: "canary_errors", metricType: Counter},
+		CanaryLatency:                                          {metricName: "canary_latency", metricType: Timer},
+		BenchWorkflowsStartedCount:                             {metricName: "bench_workflows_started", metricType: Counter},
+		BenchWorkflowStartLatency:                              {metricName: "bench_workflow_start_latency", metricType: Timer},
+		BenchSignalsSentCount:                                  {metricName: "bench_signals_sent", metricType: Counter},
+		BenchSignalLatency:                                     {metricName: "bench_signal_latency", metricType: Timer},
+		BenchActivityScheduleToStartLatency:                    {metricName: "bench_activity_schedule_to_start_latency", metricType: Timer},
+		BenchActivityLatency:                                   {metricName: "bench_activity_latency", metricType: Timer},
+		BenchFailures:                                          {metricName: "bench_errors", metricType: Counter},
 	},
 }
 
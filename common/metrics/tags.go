@@ -20,9 +20,14 @@
 
 package metrics
 
+import "strconv"
+
 const (
 	domain         = "domain"
+	domainID       = "domain_id"
 	domainAllValue = "all"
+	topic          = "topic"
+	partition      = "partition"
 )
 
 // Tag is an interface to define metrics tags
@@ -37,6 +42,18 @@ type domainTag struct {
 
 type domainAllTag struct{}
 
+type domainIDTag struct {
+	value string
+}
+
+type topicTag struct {
+	value string
+}
+
+type partitionTag struct {
+	value string
+}
+
 // DomainTag returns a new domain tag
 func DomainTag(value string) Tag {
 	return domainTag{value}
@@ -66,3 +83,48 @@ func (d domainAllTag) Key() string {
 func (d domainAllTag) Value() string {
 	return domainAllValue
 }
+
+// DomainIDTag returns a new domain id tag
+func DomainIDTag(value string) Tag {
+	return domainIDTag{value}
+}
+
+// Key returns the key of the domain id tag
+func (d domainIDTag) Key() string {
+	return domainID
+}
+
+// Value returns the value of a domain id tag
+func (d domainIDTag) Value() string {
+	return d.value
+}
+
+// TopicTag returns a new kafka topic tag
+func TopicTag(value string) Tag {
+	return topicTag{value}
+}
+
+// Key returns the key of the topic tag
+func (t topicTag) Key() string {
+	return topic
+}
+
+// Value returns the value of the topic tag
+func (t topicTag) Value() string {
+	return t.value
+}
+
+// PartitionTag returns a new kafka partition tag
+func PartitionTag(value int32) Tag {
+	return partitionTag{strconv.Itoa(int(value))}
+}
+
+// Key returns the key of the partition tag
+func (p partitionTag) Key() string {
+	return partition
+}
+
+// Value returns the value of the partition tag
+func (p partitionTag) Value() string {
+	return p.value
+}
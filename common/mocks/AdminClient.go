@@ -105,3 +105,187 @@ func (_m *AdminClient) GetWorkflowExecutionRawHistory(ctx context.Context, reque
 
 	return r0, r1
 }
+
+// ExportWorkflowExecution provides a mock function with given fields: ctx, request
+func (_m *AdminClient) ExportWorkflowExecution(ctx context.Context, request *admin.ExportWorkflowExecutionRequest, opts ...yarpc.CallOption) (*admin.ExportWorkflowExecutionResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *admin.ExportWorkflowExecutionResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *admin.ExportWorkflowExecutionRequest) *admin.ExportWorkflowExecutionResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*admin.ExportWorkflowExecutionResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *admin.ExportWorkflowExecutionRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportWorkflowExecution provides a mock function with given fields: ctx, request
+func (_m *AdminClient) ImportWorkflowExecution(ctx context.Context, request *admin.ImportWorkflowExecutionRequest, opts ...yarpc.CallOption) (*admin.ImportWorkflowExecutionResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *admin.ImportWorkflowExecutionResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *admin.ImportWorkflowExecutionRequest) *admin.ImportWorkflowExecutionResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*admin.ImportWorkflowExecutionResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *admin.ImportWorkflowExecutionRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MigrateWorkflowExecution provides a mock function with given fields: ctx, request
+func (_m *AdminClient) MigrateWorkflowExecution(ctx context.Context, request *admin.MigrateWorkflowExecutionRequest, opts ...yarpc.CallOption) (*admin.MigrateWorkflowExecutionResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *admin.MigrateWorkflowExecutionResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *admin.MigrateWorkflowExecutionRequest) *admin.MigrateWorkflowExecutionResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*admin.MigrateWorkflowExecutionResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *admin.MigrateWorkflowExecutionRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AddRemoteCluster provides a mock function with given fields: ctx, request
+func (_m *AdminClient) AddRemoteCluster(ctx context.Context, request *admin.AddRemoteClusterRequest, opts ...yarpc.CallOption) (*admin.AddRemoteClusterResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *admin.AddRemoteClusterResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *admin.AddRemoteClusterRequest) *admin.AddRemoteClusterResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*admin.AddRemoteClusterResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *admin.AddRemoteClusterRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AddSearchAttribute provides a mock function with given fields: ctx, request
+func (_m *AdminClient) AddSearchAttribute(ctx context.Context, request *admin.AddSearchAttributeRequest, opts ...yarpc.CallOption) (*admin.AddSearchAttributeResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *admin.AddSearchAttributeResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *admin.AddSearchAttributeRequest) *admin.AddSearchAttributeResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*admin.AddSearchAttributeResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *admin.AddSearchAttributeRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReplicationTasks provides a mock function with given fields: ctx, request
+func (_m *AdminClient) GetReplicationTasks(ctx context.Context, request *admin.GetReplicationTasksRequest, opts ...yarpc.CallOption) (*admin.GetReplicationTasksResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *admin.GetReplicationTasksResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *admin.GetReplicationTasksRequest) *admin.GetReplicationTasksResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*admin.GetReplicationTasksResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *admin.GetReplicationTasksRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDLQReplicationTasks provides a mock function with given fields: ctx, request
+func (_m *AdminClient) GetDLQReplicationTasks(ctx context.Context, request *admin.GetDLQReplicationTasksRequest, opts ...yarpc.CallOption) (*admin.GetDLQReplicationTasksResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *admin.GetDLQReplicationTasksResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *admin.GetDLQReplicationTasksRequest) *admin.GetDLQReplicationTasksResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*admin.GetDLQReplicationTasksResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *admin.GetDLQReplicationTasksRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReplicationConsumerLag provides a mock function with given fields: ctx, request
+func (_m *AdminClient) GetReplicationConsumerLag(ctx context.Context, request *admin.GetReplicationConsumerLagRequest, opts ...yarpc.CallOption) (*admin.GetReplicationConsumerLagResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *admin.GetReplicationConsumerLagResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *admin.GetReplicationConsumerLagRequest) *admin.GetReplicationConsumerLagResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*admin.GetReplicationConsumerLagResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *admin.GetReplicationConsumerLagRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -31,6 +31,20 @@ type ClusterMetadata struct {
 	mock.Mock
 }
 
+// AddRemoteCluster provides a mock function with given fields: clusterName, initialFailoverVersion, address
+func (_m *ClusterMetadata) AddRemoteCluster(clusterName string, initialFailoverVersion int64, address config.Address) error {
+	ret := _m.Called(clusterName, initialFailoverVersion, address)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, int64, config.Address) error); ok {
+		r0 = rf(clusterName, initialFailoverVersion, address)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ClusterNameForFailoverVersion provides a mock function with given fields:
 func (_m *ClusterMetadata) ClusterNameForFailoverVersion(failoverVersion int64) string {
 	ret := _m.Called(failoverVersion)
@@ -186,3 +200,13 @@ func (_m *ClusterMetadata) ArchivalConfig() *cluster.ArchivalConfig {
 
 	return r0
 }
+
+// RegisterClusterChangeCallback provides a mock function with given fields: key, callback
+func (_m *ClusterMetadata) RegisterClusterChangeCallback(key interface{}, callback cluster.ClusterChangeCallbackFn) {
+	_m.Called(key, callback)
+}
+
+// UnregisterClusterChangeCallback provides a mock function with given fields: key
+func (_m *ClusterMetadata) UnregisterClusterChangeCallback(key interface{}) {
+	_m.Called(key)
+}
@@ -38,6 +38,36 @@ type FrontendClient struct {
 
 var _ workflowserviceclient.Interface = (*FrontendClient)(nil)
 
+// CountOpenWorkflowExecutions provides a mock function with given fields: ctx, CountRequest, opts
+func (_m *FrontendClient) CountOpenWorkflowExecutions(ctx context.Context, CountRequest *shared.CountOpenWorkflowExecutionsRequest, opts ...yarpc.CallOption) (*shared.CountOpenWorkflowExecutionsResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, CountRequest)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *shared.CountOpenWorkflowExecutionsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *shared.CountOpenWorkflowExecutionsRequest, ...yarpc.CallOption) *shared.CountOpenWorkflowExecutionsResponse); ok {
+		r0 = rf(ctx, CountRequest, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.CountOpenWorkflowExecutionsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *shared.CountOpenWorkflowExecutionsRequest, ...yarpc.CallOption) error); ok {
+		r1 = rf(ctx, CountRequest, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeprecateDomain provides a mock function with given fields: ctx, DeprecateRequest, opts
 func (_m *FrontendClient) DeprecateDomain(ctx context.Context, DeprecateRequest *shared.DeprecateDomainRequest, opts ...yarpc.CallOption) error {
 	_va := make([]interface{}, len(opts))
@@ -149,6 +179,66 @@ func (_m *FrontendClient) DescribeWorkflowExecution(ctx context.Context, Describ
 	return r0, r1
 }
 
+// DescribeWorkflowExecutions provides a mock function with given fields: ctx, DescribeRequest, opts
+func (_m *FrontendClient) DescribeWorkflowExecutions(ctx context.Context, DescribeRequest *shared.DescribeWorkflowExecutionsRequest, opts ...yarpc.CallOption) (*shared.DescribeWorkflowExecutionsResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, DescribeRequest)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *shared.DescribeWorkflowExecutionsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *shared.DescribeWorkflowExecutionsRequest, ...yarpc.CallOption) *shared.DescribeWorkflowExecutionsResponse); ok {
+		r0 = rf(ctx, DescribeRequest, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.DescribeWorkflowExecutionsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *shared.DescribeWorkflowExecutionsRequest, ...yarpc.CallOption) error); ok {
+		r1 = rf(ctx, DescribeRequest, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSearchAttributes provides a mock function with given fields: ctx, opts
+func (_m *FrontendClient) GetSearchAttributes(ctx context.Context, opts ...yarpc.CallOption) (*shared.GetSearchAttributesResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *shared.GetSearchAttributesResponse
+	if rf, ok := ret.Get(0).(func(context.Context, ...yarpc.CallOption) *shared.GetSearchAttributesResponse); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.GetSearchAttributesResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, ...yarpc.CallOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetWorkflowExecutionHistory provides a mock function with given fields: ctx, GetRequest, opts
 func (_m *FrontendClient) GetWorkflowExecutionHistory(ctx context.Context, GetRequest *shared.GetWorkflowExecutionHistoryRequest, opts ...yarpc.CallOption) (*shared.GetWorkflowExecutionHistoryResponse, error) {
 	_va := make([]interface{}, len(opts))
@@ -179,6 +269,36 @@ func (_m *FrontendClient) GetWorkflowExecutionHistory(ctx context.Context, GetRe
 	return r0, r1
 }
 
+// GetWorkflowExecutionStatistics provides a mock function with given fields: ctx, StatisticsRequest, opts
+func (_m *FrontendClient) GetWorkflowExecutionStatistics(ctx context.Context, StatisticsRequest *shared.GetWorkflowExecutionStatisticsRequest, opts ...yarpc.CallOption) (*shared.GetWorkflowExecutionStatisticsResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, StatisticsRequest)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *shared.GetWorkflowExecutionStatisticsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *shared.GetWorkflowExecutionStatisticsRequest, ...yarpc.CallOption) *shared.GetWorkflowExecutionStatisticsResponse); ok {
+		r0 = rf(ctx, StatisticsRequest, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.GetWorkflowExecutionStatisticsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *shared.GetWorkflowExecutionStatisticsRequest, ...yarpc.CallOption) error); ok {
+		r1 = rf(ctx, StatisticsRequest, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListClosedWorkflowExecutions provides a mock function with given fields: ctx, ListRequest, opts
 func (_m *FrontendClient) ListClosedWorkflowExecutions(ctx context.Context, ListRequest *shared.ListClosedWorkflowExecutionsRequest, opts ...yarpc.CallOption) (*shared.ListClosedWorkflowExecutionsResponse, error) {
 	_va := make([]interface{}, len(opts))
@@ -821,6 +941,27 @@ func (_m *FrontendClient) TerminateWorkflowExecution(ctx context.Context, Termin
 	return r0
 }
 
+// UpdateWorkflowExecutionLabels provides a mock function with given fields: ctx, UpdateRequest, opts
+func (_m *FrontendClient) UpdateWorkflowExecutionLabels(ctx context.Context, UpdateRequest *shared.UpdateWorkflowExecutionLabelsRequest, opts ...yarpc.CallOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, UpdateRequest)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *shared.UpdateWorkflowExecutionLabelsRequest, ...yarpc.CallOption) error); ok {
+		r0 = rf(ctx, UpdateRequest, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateDomain provides a mock function with given fields: ctx, UpdateRequest, opts
 func (_m *FrontendClient) UpdateDomain(ctx context.Context, UpdateRequest *shared.UpdateDomainRequest, opts ...yarpc.CallOption) (*shared.UpdateDomainResponse, error) {
 	_va := make([]interface{}, len(opts))
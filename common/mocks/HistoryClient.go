@@ -107,6 +107,29 @@ func (_m *HistoryClient) GetMutableState(ctx context.Context, getRequest *histor
 	return r0, r1
 }
 
+// GetReplicationTasks provides a mock function with given fields: ctx, request
+func (_m *HistoryClient) GetReplicationTasks(ctx context.Context, request *history.GetReplicationTasksRequest, opts ...yarpc.CallOption) (*history.GetReplicationTasksResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *history.GetReplicationTasksResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *history.GetReplicationTasksRequest) *history.GetReplicationTasksResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*history.GetReplicationTasksResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *history.GetReplicationTasksRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ResetStickyTaskList provides a mock function with given fields: ctx, getRequest
 func (_m *HistoryClient) ResetStickyTaskList(ctx context.Context, request *history.ResetStickyTaskListRequest, opts ...yarpc.CallOption) (*history.ResetStickyTaskListResponse, error) {
 	ret := _m.Called(ctx, request)
@@ -153,6 +176,29 @@ func (_m *HistoryClient) DescribeWorkflowExecution(ctx context.Context, request
 	return r0, r1
 }
 
+// DescribeWorkflowExecutions provides a mock function with given fields: ctx, request
+func (_m *HistoryClient) DescribeWorkflowExecutions(ctx context.Context, request *history.DescribeWorkflowExecutionsRequest, opts ...yarpc.CallOption) (*shared.DescribeWorkflowExecutionsResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *shared.DescribeWorkflowExecutionsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *history.DescribeWorkflowExecutionsRequest) *shared.DescribeWorkflowExecutionsResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.DescribeWorkflowExecutionsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *history.DescribeWorkflowExecutionsRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RecordActivityTaskHeartbeat provides a mock function with given fields: ctx, heartbeatRequest
 func (_m *HistoryClient) RecordActivityTaskHeartbeat(ctx context.Context, heartbeatRequest *history.RecordActivityTaskHeartbeatRequest, opts ...yarpc.CallOption) (*shared.RecordActivityTaskHeartbeatResponse, error) {
 	ret := _m.Called(ctx, heartbeatRequest)
@@ -405,6 +451,20 @@ func (_m *HistoryClient) TerminateWorkflowExecution(ctx context.Context, termina
 	return r0
 }
 
+// UpdateWorkflowExecutionLabels provides a mock function with given fields: ctx, updateRequest
+func (_m *HistoryClient) UpdateWorkflowExecutionLabels(ctx context.Context, updateRequest *history.UpdateWorkflowExecutionLabelsRequest, opts ...yarpc.CallOption) error {
+	ret := _m.Called(ctx, updateRequest)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *history.UpdateWorkflowExecutionLabelsRequest) error); ok {
+		r0 = rf(ctx, updateRequest)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ResetWorkflowExecution provides a mock function with given fields: ctx, terminateRequest
 func (_m *HistoryClient) ResetWorkflowExecution(ctx context.Context, resetRequest *history.ResetWorkflowExecutionRequest, opts ...yarpc.CallOption) (*shared.ResetWorkflowExecutionResponse, error) {
 	ret := _m.Called(ctx, resetRequest)
@@ -484,6 +544,20 @@ func (_m *HistoryClient) ReplicateRawEvents(ctx context.Context, request *histor
 	return r0
 }
 
+// ReplicateEventsV2 provides a mock function with given fields: ctx, request
+func (_m *HistoryClient) ReplicateEventsV2(ctx context.Context, request *history.ReplicateEventsV2Request, opts ...yarpc.CallOption) error {
+	ret := _m.Called(ctx, request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *history.ReplicateEventsV2Request) error); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SyncShardStatus provides a mock function with given fields: ctx, request
 func (_m *HistoryClient) SyncShardStatus(ctx context.Context, request *history.SyncShardStatusRequest, opts ...yarpc.CallOption) error {
 	ret := _m.Called(ctx, request)
@@ -21,6 +21,8 @@
 package mocks
 
 import (
+	"errors"
+
 	"github.com/uber/cadence/common/messaging"
 )
 
@@ -61,3 +63,13 @@ func (c *MessagingClient) NewProducer(appName string) (messaging.Producer, error
 func (c *MessagingClient) NewProducerWithClusterName(sourceCluster string) (messaging.Producer, error) {
 	return c.publisherMock, nil
 }
+
+// NewDLQReader is not supported by the dummy messaging client
+func (c *MessagingClient) NewDLQReader(currentCluster string) (messaging.DLQReader, error) {
+	return nil, errors.New("DLQ reader is not supported by the mock messaging client")
+}
+
+// NewConsumerLagReader is not supported by the dummy messaging client
+func (c *MessagingClient) NewConsumerLagReader(sourceCluster string) (messaging.ConsumerLagReader, error) {
+	return nil, errors.New("consumer lag reader is not supported by the mock messaging client")
+}
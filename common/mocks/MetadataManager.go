@@ -20,6 +20,7 @@
 
 package mocks
 
+import context "context"
 import mock "github.com/stretchr/testify/mock"
 import persistence "github.com/uber/cadence/common/persistence"
 
@@ -47,13 +48,13 @@ func (_m *MetadataManager) Close() {
 	_m.Called()
 }
 
-// CreateDomain provides a mock function with given fields: request
-func (_m *MetadataManager) CreateDomain(request *persistence.CreateDomainRequest) (*persistence.CreateDomainResponse, error) {
-	ret := _m.Called(request)
+// CreateDomain provides a mock function with given fields: ctx, request
+func (_m *MetadataManager) CreateDomain(ctx context.Context, request *persistence.CreateDomainRequest) (*persistence.CreateDomainResponse, error) {
+	ret := _m.Called(ctx, request)
 
 	var r0 *persistence.CreateDomainResponse
-	if rf, ok := ret.Get(0).(func(*persistence.CreateDomainRequest) *persistence.CreateDomainResponse); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.CreateDomainRequest) *persistence.CreateDomainResponse); ok {
+		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*persistence.CreateDomainResponse)
@@ -61,8 +62,8 @@ func (_m *MetadataManager) CreateDomain(request *persistence.CreateDomainRequest
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*persistence.CreateDomainRequest) error); ok {
-		r1 = rf(request)
+	if rf, ok := ret.Get(1).(func(context.Context, *persistence.CreateDomainRequest) error); ok {
+		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -70,13 +71,13 @@ func (_m *MetadataManager) CreateDomain(request *persistence.CreateDomainRequest
 	return r0, r1
 }
 
-// DeleteDomain provides a mock function with given fields: request
-func (_m *MetadataManager) DeleteDomain(request *persistence.DeleteDomainRequest) error {
-	ret := _m.Called(request)
+// DeleteDomain provides a mock function with given fields: ctx, request
+func (_m *MetadataManager) DeleteDomain(ctx context.Context, request *persistence.DeleteDomainRequest) error {
+	ret := _m.Called(ctx, request)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*persistence.DeleteDomainRequest) error); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.DeleteDomainRequest) error); ok {
+		r0 = rf(ctx, request)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -84,13 +85,13 @@ func (_m *MetadataManager) DeleteDomain(request *persistence.DeleteDomainRequest
 	return r0
 }
 
-// DeleteDomainByName provides a mock function with given fields: request
-func (_m *MetadataManager) DeleteDomainByName(request *persistence.DeleteDomainByNameRequest) error {
-	ret := _m.Called(request)
+// DeleteDomainByName provides a mock function with given fields: ctx, request
+func (_m *MetadataManager) DeleteDomainByName(ctx context.Context, request *persistence.DeleteDomainByNameRequest) error {
+	ret := _m.Called(ctx, request)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*persistence.DeleteDomainByNameRequest) error); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.DeleteDomainByNameRequest) error); ok {
+		r0 = rf(ctx, request)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -98,13 +99,13 @@ func (_m *MetadataManager) DeleteDomainByName(request *persistence.DeleteDomainB
 	return r0
 }
 
-// GetDomain provides a mock function with given fields: request
-func (_m *MetadataManager) GetDomain(request *persistence.GetDomainRequest) (*persistence.GetDomainResponse, error) {
-	ret := _m.Called(request)
+// GetDomain provides a mock function with given fields: ctx, request
+func (_m *MetadataManager) GetDomain(ctx context.Context, request *persistence.GetDomainRequest) (*persistence.GetDomainResponse, error) {
+	ret := _m.Called(ctx, request)
 
 	var r0 *persistence.GetDomainResponse
-	if rf, ok := ret.Get(0).(func(*persistence.GetDomainRequest) *persistence.GetDomainResponse); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.GetDomainRequest) *persistence.GetDomainResponse); ok {
+		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*persistence.GetDomainResponse)
@@ -112,8 +113,8 @@ func (_m *MetadataManager) GetDomain(request *persistence.GetDomainRequest) (*pe
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*persistence.GetDomainRequest) error); ok {
-		r1 = rf(request)
+	if rf, ok := ret.Get(1).(func(context.Context, *persistence.GetDomainRequest) error); ok {
+		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -121,13 +122,13 @@ func (_m *MetadataManager) GetDomain(request *persistence.GetDomainRequest) (*pe
 	return r0, r1
 }
 
-// UpdateDomain provides a mock function with given fields: request
-func (_m *MetadataManager) UpdateDomain(request *persistence.UpdateDomainRequest) error {
-	ret := _m.Called(request)
+// UpdateDomain provides a mock function with given fields: ctx, request
+func (_m *MetadataManager) UpdateDomain(ctx context.Context, request *persistence.UpdateDomainRequest) error {
+	ret := _m.Called(ctx, request)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*persistence.UpdateDomainRequest) error); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.UpdateDomainRequest) error); ok {
+		r0 = rf(ctx, request)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -135,13 +136,13 @@ func (_m *MetadataManager) UpdateDomain(request *persistence.UpdateDomainRequest
 	return r0
 }
 
-// ListDomains provides a mock function with given fields: request
-func (_m *MetadataManager) ListDomains(request *persistence.ListDomainsRequest) (*persistence.ListDomainsResponse, error) {
-	ret := _m.Called(request)
+// ListDomains provides a mock function with given fields: ctx, request
+func (_m *MetadataManager) ListDomains(ctx context.Context, request *persistence.ListDomainsRequest) (*persistence.ListDomainsResponse, error) {
+	ret := _m.Called(ctx, request)
 
 	var r0 *persistence.ListDomainsResponse
-	if rf, ok := ret.Get(0).(func(*persistence.ListDomainsRequest) *persistence.ListDomainsResponse); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.ListDomainsRequest) *persistence.ListDomainsResponse); ok {
+		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*persistence.ListDomainsResponse)
@@ -149,8 +150,8 @@ func (_m *MetadataManager) ListDomains(request *persistence.ListDomainsRequest)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*persistence.ListDomainsRequest) error); ok {
-		r1 = rf(request)
+	if rf, ok := ret.Get(1).(func(context.Context, *persistence.ListDomainsRequest) error); ok {
+		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -158,13 +159,13 @@ func (_m *MetadataManager) ListDomains(request *persistence.ListDomainsRequest)
 	return r0, r1
 }
 
-// GetMetadata provides a mock function with given fields: request
-func (_m *MetadataManager) GetMetadata() (*persistence.GetMetadataResponse, error) {
-	ret := _m.Called()
+// GetMetadata provides a mock function with given fields: ctx
+func (_m *MetadataManager) GetMetadata(ctx context.Context) (*persistence.GetMetadataResponse, error) {
+	ret := _m.Called(ctx)
 
 	var r0 *persistence.GetMetadataResponse
-	if rf, ok := ret.Get(0).(func() *persistence.GetMetadataResponse); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) *persistence.GetMetadataResponse); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*persistence.GetMetadataResponse)
@@ -172,8 +173,8 @@ func (_m *MetadataManager) GetMetadata() (*persistence.GetMetadataResponse, erro
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -22,6 +22,7 @@
 
 package mocks
 
+import context "context"
 import mock "github.com/stretchr/testify/mock"
 import persistence "github.com/uber/cadence/common/persistence"
 
@@ -35,13 +36,36 @@ func (_m *VisibilityManager) Close() {
 	_m.Called()
 }
 
-// DeleteWorkflowExecution provides a mock function with given fields: request
-func (_m *VisibilityManager) DeleteWorkflowExecution(request *persistence.VisibilityDeleteWorkflowExecutionRequest) error {
-	ret := _m.Called(request)
+// CountOpenWorkflowExecutions provides a mock function with given fields: ctx, request
+func (_m *VisibilityManager) CountOpenWorkflowExecutions(ctx context.Context, request *persistence.CountWorkflowExecutionsRequest) (*persistence.CountWorkflowExecutionsResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *persistence.CountWorkflowExecutionsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.CountWorkflowExecutionsRequest) *persistence.CountWorkflowExecutionsResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*persistence.CountWorkflowExecutionsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *persistence.CountWorkflowExecutionsRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteWorkflowExecution provides a mock function with given fields: ctx, request
+func (_m *VisibilityManager) DeleteWorkflowExecution(ctx context.Context, request *persistence.VisibilityDeleteWorkflowExecutionRequest) error {
+	ret := _m.Called(ctx, request)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*persistence.VisibilityDeleteWorkflowExecutionRequest) error); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.VisibilityDeleteWorkflowExecutionRequest) error); ok {
+		r0 = rf(ctx, request)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -49,13 +73,13 @@ func (_m *VisibilityManager) DeleteWorkflowExecution(request *persistence.Visibi
 	return r0
 }
 
-// GetClosedWorkflowExecution provides a mock function with given fields: request
-func (_m *VisibilityManager) GetClosedWorkflowExecution(request *persistence.GetClosedWorkflowExecutionRequest) (*persistence.GetClosedWorkflowExecutionResponse, error) {
-	ret := _m.Called(request)
+// GetClosedWorkflowExecution provides a mock function with given fields: ctx, request
+func (_m *VisibilityManager) GetClosedWorkflowExecution(ctx context.Context, request *persistence.GetClosedWorkflowExecutionRequest) (*persistence.GetClosedWorkflowExecutionResponse, error) {
+	ret := _m.Called(ctx, request)
 
 	var r0 *persistence.GetClosedWorkflowExecutionResponse
-	if rf, ok := ret.Get(0).(func(*persistence.GetClosedWorkflowExecutionRequest) *persistence.GetClosedWorkflowExecutionResponse); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.GetClosedWorkflowExecutionRequest) *persistence.GetClosedWorkflowExecutionResponse); ok {
+		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*persistence.GetClosedWorkflowExecutionResponse)
@@ -63,8 +87,8 @@ func (_m *VisibilityManager) GetClosedWorkflowExecution(request *persistence.Get
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*persistence.GetClosedWorkflowExecutionRequest) error); ok {
-		r1 = rf(request)
+	if rf, ok := ret.Get(1).(func(context.Context, *persistence.GetClosedWorkflowExecutionRequest) error); ok {
+		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -86,13 +110,36 @@ func (_m *VisibilityManager) GetName() string {
 	return r0
 }
 
-// ListClosedWorkflowExecutions provides a mock function with given fields: request
-func (_m *VisibilityManager) ListClosedWorkflowExecutions(request *persistence.ListWorkflowExecutionsRequest) (*persistence.ListWorkflowExecutionsResponse, error) {
-	ret := _m.Called(request)
+// GetWorkflowExecutionStatistics provides a mock function with given fields: ctx, request
+func (_m *VisibilityManager) GetWorkflowExecutionStatistics(ctx context.Context, request *persistence.GetWorkflowExecutionStatisticsRequest) (*persistence.GetWorkflowExecutionStatisticsResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *persistence.GetWorkflowExecutionStatisticsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.GetWorkflowExecutionStatisticsRequest) *persistence.GetWorkflowExecutionStatisticsResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*persistence.GetWorkflowExecutionStatisticsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *persistence.GetWorkflowExecutionStatisticsRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListClosedWorkflowExecutions provides a mock function with given fields: ctx, request
+func (_m *VisibilityManager) ListClosedWorkflowExecutions(ctx context.Context, request *persistence.ListWorkflowExecutionsRequest) (*persistence.ListWorkflowExecutionsResponse, error) {
+	ret := _m.Called(ctx, request)
 
 	var r0 *persistence.ListWorkflowExecutionsResponse
-	if rf, ok := ret.Get(0).(func(*persistence.ListWorkflowExecutionsRequest) *persistence.ListWorkflowExecutionsResponse); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.ListWorkflowExecutionsRequest) *persistence.ListWorkflowExecutionsResponse); ok {
+		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*persistence.ListWorkflowExecutionsResponse)
@@ -100,8 +147,8 @@ func (_m *VisibilityManager) ListClosedWorkflowExecutions(request *persistence.L
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*persistence.ListWorkflowExecutionsRequest) error); ok {
-		r1 = rf(request)
+	if rf, ok := ret.Get(1).(func(context.Context, *persistence.ListWorkflowExecutionsRequest) error); ok {
+		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -109,13 +156,13 @@ func (_m *VisibilityManager) ListClosedWorkflowExecutions(request *persistence.L
 	return r0, r1
 }
 
-// ListClosedWorkflowExecutionsByStatus provides a mock function with given fields: request
-func (_m *VisibilityManager) ListClosedWorkflowExecutionsByStatus(request *persistence.ListClosedWorkflowExecutionsByStatusRequest) (*persistence.ListWorkflowExecutionsResponse, error) {
-	ret := _m.Called(request)
+// ListClosedWorkflowExecutionsByStatus provides a mock function with given fields: ctx, request
+func (_m *VisibilityManager) ListClosedWorkflowExecutionsByStatus(ctx context.Context, request *persistence.ListClosedWorkflowExecutionsByStatusRequest) (*persistence.ListWorkflowExecutionsResponse, error) {
+	ret := _m.Called(ctx, request)
 
 	var r0 *persistence.ListWorkflowExecutionsResponse
-	if rf, ok := ret.Get(0).(func(*persistence.ListClosedWorkflowExecutionsByStatusRequest) *persistence.ListWorkflowExecutionsResponse); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.ListClosedWorkflowExecutionsByStatusRequest) *persistence.ListWorkflowExecutionsResponse); ok {
+		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*persistence.ListWorkflowExecutionsResponse)
@@ -123,8 +170,8 @@ func (_m *VisibilityManager) ListClosedWorkflowExecutionsByStatus(request *persi
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*persistence.ListClosedWorkflowExecutionsByStatusRequest) error); ok {
-		r1 = rf(request)
+	if rf, ok := ret.Get(1).(func(context.Context, *persistence.ListClosedWorkflowExecutionsByStatusRequest) error); ok {
+		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -132,13 +179,13 @@ func (_m *VisibilityManager) ListClosedWorkflowExecutionsByStatus(request *persi
 	return r0, r1
 }
 
-// ListClosedWorkflowExecutionsByType provides a mock function with given fields: request
-func (_m *VisibilityManager) ListClosedWorkflowExecutionsByType(request *persistence.ListWorkflowExecutionsByTypeRequest) (*persistence.ListWorkflowExecutionsResponse, error) {
-	ret := _m.Called(request)
+// ListClosedWorkflowExecutionsByType provides a mock function with given fields: ctx, request
+func (_m *VisibilityManager) ListClosedWorkflowExecutionsByType(ctx context.Context, request *persistence.ListWorkflowExecutionsByTypeRequest) (*persistence.ListWorkflowExecutionsResponse, error) {
+	ret := _m.Called(ctx, request)
 
 	var r0 *persistence.ListWorkflowExecutionsResponse
-	if rf, ok := ret.Get(0).(func(*persistence.ListWorkflowExecutionsByTypeRequest) *persistence.ListWorkflowExecutionsResponse); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.ListWorkflowExecutionsByTypeRequest) *persistence.ListWorkflowExecutionsResponse); ok {
+		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*persistence.ListWorkflowExecutionsResponse)
@@ -146,8 +193,8 @@ func (_m *VisibilityManager) ListClosedWorkflowExecutionsByType(request *persist
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*persistence.ListWorkflowExecutionsByTypeRequest) error); ok {
-		r1 = rf(request)
+	if rf, ok := ret.Get(1).(func(context.Context, *persistence.ListWorkflowExecutionsByTypeRequest) error); ok {
+		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -155,13 +202,13 @@ func (_m *VisibilityManager) ListClosedWorkflowExecutionsByType(request *persist
 	return r0, r1
 }
 
-// ListClosedWorkflowExecutionsByWorkflowID provides a mock function with given fields: request
-func (_m *VisibilityManager) ListClosedWorkflowExecutionsByWorkflowID(request *persistence.ListWorkflowExecutionsByWorkflowIDRequest) (*persistence.ListWorkflowExecutionsResponse, error) {
-	ret := _m.Called(request)
+// ListClosedWorkflowExecutionsByWorkflowID provides a mock function with given fields: ctx, request
+func (_m *VisibilityManager) ListClosedWorkflowExecutionsByWorkflowID(ctx context.Context, request *persistence.ListWorkflowExecutionsByWorkflowIDRequest) (*persistence.ListWorkflowExecutionsResponse, error) {
+	ret := _m.Called(ctx, request)
 
 	var r0 *persistence.ListWorkflowExecutionsResponse
-	if rf, ok := ret.Get(0).(func(*persistence.ListWorkflowExecutionsByWorkflowIDRequest) *persistence.ListWorkflowExecutionsResponse); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.ListWorkflowExecutionsByWorkflowIDRequest) *persistence.ListWorkflowExecutionsResponse); ok {
+		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*persistence.ListWorkflowExecutionsResponse)
@@ -169,8 +216,8 @@ func (_m *VisibilityManager) ListClosedWorkflowExecutionsByWorkflowID(request *p
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*persistence.ListWorkflowExecutionsByWorkflowIDRequest) error); ok {
-		r1 = rf(request)
+	if rf, ok := ret.Get(1).(func(context.Context, *persistence.ListWorkflowExecutionsByWorkflowIDRequest) error); ok {
+		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -178,13 +225,13 @@ func (_m *VisibilityManager) ListClosedWorkflowExecutionsByWorkflowID(request *p
 	return r0, r1
 }
 
-// ListOpenWorkflowExecutions provides a mock function with given fields: request
-func (_m *VisibilityManager) ListOpenWorkflowExecutions(request *persistence.ListWorkflowExecutionsRequest) (*persistence.ListWorkflowExecutionsResponse, error) {
-	ret := _m.Called(request)
+// ListOpenWorkflowExecutions provides a mock function with given fields: ctx, request
+func (_m *VisibilityManager) ListOpenWorkflowExecutions(ctx context.Context, request *persistence.ListWorkflowExecutionsRequest) (*persistence.ListWorkflowExecutionsResponse, error) {
+	ret := _m.Called(ctx, request)
 
 	var r0 *persistence.ListWorkflowExecutionsResponse
-	if rf, ok := ret.Get(0).(func(*persistence.ListWorkflowExecutionsRequest) *persistence.ListWorkflowExecutionsResponse); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.ListWorkflowExecutionsRequest) *persistence.ListWorkflowExecutionsResponse); ok {
+		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*persistence.ListWorkflowExecutionsResponse)
@@ -192,8 +239,8 @@ func (_m *VisibilityManager) ListOpenWorkflowExecutions(request *persistence.Lis
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*persistence.ListWorkflowExecutionsRequest) error); ok {
-		r1 = rf(request)
+	if rf, ok := ret.Get(1).(func(context.Context, *persistence.ListWorkflowExecutionsRequest) error); ok {
+		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -201,13 +248,13 @@ func (_m *VisibilityManager) ListOpenWorkflowExecutions(request *persistence.Lis
 	return r0, r1
 }
 
-// ListOpenWorkflowExecutionsByType provides a mock function with given fields: request
-func (_m *VisibilityManager) ListOpenWorkflowExecutionsByType(request *persistence.ListWorkflowExecutionsByTypeRequest) (*persistence.ListWorkflowExecutionsResponse, error) {
-	ret := _m.Called(request)
+// ListOpenWorkflowExecutionsByType provides a mock function with given fields: ctx, request
+func (_m *VisibilityManager) ListOpenWorkflowExecutionsByType(ctx context.Context, request *persistence.ListWorkflowExecutionsByTypeRequest) (*persistence.ListWorkflowExecutionsResponse, error) {
+	ret := _m.Called(ctx, request)
 
 	var r0 *persistence.ListWorkflowExecutionsResponse
-	if rf, ok := ret.Get(0).(func(*persistence.ListWorkflowExecutionsByTypeRequest) *persistence.ListWorkflowExecutionsResponse); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.ListWorkflowExecutionsByTypeRequest) *persistence.ListWorkflowExecutionsResponse); ok {
+		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*persistence.ListWorkflowExecutionsResponse)
@@ -215,8 +262,8 @@ func (_m *VisibilityManager) ListOpenWorkflowExecutionsByType(request *persisten
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*persistence.ListWorkflowExecutionsByTypeRequest) error); ok {
-		r1 = rf(request)
+	if rf, ok := ret.Get(1).(func(context.Context, *persistence.ListWorkflowExecutionsByTypeRequest) error); ok {
+		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -224,13 +271,13 @@ func (_m *VisibilityManager) ListOpenWorkflowExecutionsByType(request *persisten
 	return r0, r1
 }
 
-// ListOpenWorkflowExecutionsByWorkflowID provides a mock function with given fields: request
-func (_m *VisibilityManager) ListOpenWorkflowExecutionsByWorkflowID(request *persistence.ListWorkflowExecutionsByWorkflowIDRequest) (*persistence.ListWorkflowExecutionsResponse, error) {
-	ret := _m.Called(request)
+// ListOpenWorkflowExecutionsByWorkflowID provides a mock function with given fields: ctx, request
+func (_m *VisibilityManager) ListOpenWorkflowExecutionsByWorkflowID(ctx context.Context, request *persistence.ListWorkflowExecutionsByWorkflowIDRequest) (*persistence.ListWorkflowExecutionsResponse, error) {
+	ret := _m.Called(ctx, request)
 
 	var r0 *persistence.ListWorkflowExecutionsResponse
-	if rf, ok := ret.Get(0).(func(*persistence.ListWorkflowExecutionsByWorkflowIDRequest) *persistence.ListWorkflowExecutionsResponse); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.ListWorkflowExecutionsByWorkflowIDRequest) *persistence.ListWorkflowExecutionsResponse); ok {
+		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*persistence.ListWorkflowExecutionsResponse)
@@ -238,8 +285,8 @@ func (_m *VisibilityManager) ListOpenWorkflowExecutionsByWorkflowID(request *per
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*persistence.ListWorkflowExecutionsByWorkflowIDRequest) error); ok {
-		r1 = rf(request)
+	if rf, ok := ret.Get(1).(func(context.Context, *persistence.ListWorkflowExecutionsByWorkflowIDRequest) error); ok {
+		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -247,13 +294,13 @@ func (_m *VisibilityManager) ListOpenWorkflowExecutionsByWorkflowID(request *per
 	return r0, r1
 }
 
-// RecordWorkflowExecutionClosed provides a mock function with given fields: request
-func (_m *VisibilityManager) RecordWorkflowExecutionClosed(request *persistence.RecordWorkflowExecutionClosedRequest) error {
-	ret := _m.Called(request)
+// RecordWorkflowExecutionClosed provides a mock function with given fields: ctx, request
+func (_m *VisibilityManager) RecordWorkflowExecutionClosed(ctx context.Context, request *persistence.RecordWorkflowExecutionClosedRequest) error {
+	ret := _m.Called(ctx, request)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*persistence.RecordWorkflowExecutionClosedRequest) error); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.RecordWorkflowExecutionClosedRequest) error); ok {
+		r0 = rf(ctx, request)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -261,13 +308,13 @@ func (_m *VisibilityManager) RecordWorkflowExecutionClosed(request *persistence.
 	return r0
 }
 
-// RecordWorkflowExecutionStarted provides a mock function with given fields: request
-func (_m *VisibilityManager) RecordWorkflowExecutionStarted(request *persistence.RecordWorkflowExecutionStartedRequest) error {
-	ret := _m.Called(request)
+// RecordWorkflowExecutionStarted provides a mock function with given fields: ctx, request
+func (_m *VisibilityManager) RecordWorkflowExecutionStarted(ctx context.Context, request *persistence.RecordWorkflowExecutionStartedRequest) error {
+	ret := _m.Called(ctx, request)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*persistence.RecordWorkflowExecutionStartedRequest) error); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.RecordWorkflowExecutionStartedRequest) error); ok {
+		r0 = rf(ctx, request)
 	} else {
 		r0 = ret.Error(0)
 	}
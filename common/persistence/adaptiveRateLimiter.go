@@ -0,0 +1,168 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"sync"
+	"time"
+
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/service/config"
+	"github.com/uber/cadence/common/tokenbucket"
+)
+
+type (
+	// AdaptiveRateLimiter is a token bucket whose rate is adjusted based
+	// on the errors returned by the underlying data store
+	AdaptiveRateLimiter interface {
+		tokenbucket.TokenBucket
+		// RecordResult records the outcome of a single persistence call
+		RecordResult(err error)
+		// CurrentQPS returns the rate currently enforced by the limiter
+		CurrentQPS() int
+	}
+
+	adaptiveRateLimiterImpl struct {
+		sync.Mutex
+		maxQPS      int
+		currentQPS  int
+		config      *config.AdaptiveThrottlingConfig
+		tb          tokenbucket.TokenBucket
+		timeSource  clock.TimeSource
+		windowStart time.Time
+		numRequests int
+		numErrors   int
+	}
+)
+
+var _ AdaptiveRateLimiter = (*adaptiveRateLimiterImpl)(nil)
+
+// NewAdaptiveRateLimiter creates a rate limiter which starts at maxQPS, backs off
+// multiplicatively when the ratio of overload errors (ServiceBusy / Timeout) from the
+// data store exceeds the configured threshold and recovers additively otherwise
+func NewAdaptiveRateLimiter(maxQPS int, config *config.AdaptiveThrottlingConfig, timeSource clock.TimeSource) AdaptiveRateLimiter {
+	return &adaptiveRateLimiterImpl{
+		maxQPS:      maxQPS,
+		currentQPS:  maxQPS,
+		config:      config,
+		tb:          tokenbucket.New(maxQPS, timeSource),
+		timeSource:  timeSource,
+		windowStart: timeSource.Now(),
+	}
+}
+
+func (r *adaptiveRateLimiterImpl) TryConsume(count int) (bool, time.Duration) {
+	r.evaluate()
+	return r.tb.TryConsume(count)
+}
+
+func (r *adaptiveRateLimiterImpl) Consume(count int, timeout time.Duration) bool {
+	r.evaluate()
+	return r.tb.Consume(count, timeout)
+}
+
+func (r *adaptiveRateLimiterImpl) Reset(rps int) {
+	r.Lock()
+	defer r.Unlock()
+	r.maxQPS = rps
+	r.currentQPS = rps
+	r.tb.Reset(rps)
+}
+
+func (r *adaptiveRateLimiterImpl) RecordResult(err error) {
+	r.Lock()
+	defer r.Unlock()
+	r.numRequests++
+	if isOverloadError(err) {
+		r.numErrors++
+	}
+}
+
+func (r *adaptiveRateLimiterImpl) CurrentQPS() int {
+	r.Lock()
+	defer r.Unlock()
+	return r.currentQPS
+}
+
+// evaluate adjusts the enforced rate once every evaluation interval based on
+// the error ratio observed during the window that just ended
+func (r *adaptiveRateLimiterImpl) evaluate() {
+	r.Lock()
+	defer r.Unlock()
+
+	now := r.timeSource.Now()
+	if now.Sub(r.windowStart) < r.config.EvaluationInterval() {
+		return
+	}
+
+	newQPS := r.currentQPS
+	if !r.config.Enabled() {
+		newQPS = r.maxQPS
+	} else if r.numRequests > 0 && float64(r.numErrors)/float64(r.numRequests) >= r.config.ErrorRatioThreshold() {
+		newQPS = int(float64(r.currentQPS) * r.config.BackoffFactor())
+		minQPS := int(float64(r.maxQPS) * r.config.MinQPSRatio())
+		if minQPS < 1 {
+			minQPS = 1
+		}
+		if newQPS < minQPS {
+			newQPS = minQPS
+		}
+	} else if r.currentQPS < r.maxQPS {
+		step := int(float64(r.maxQPS) * r.config.RecoveryFactor())
+		if step < 1 {
+			step = 1
+		}
+		newQPS = r.currentQPS + step
+		if newQPS > r.maxQPS {
+			newQPS = r.maxQPS
+		}
+	}
+
+	if newQPS != r.currentQPS {
+		r.currentQPS = newQPS
+		r.tb.Reset(newQPS)
+	}
+	r.windowStart = now
+	r.numRequests = 0
+	r.numErrors = 0
+}
+
+// isOverloadError returns true if the error indicates the data store is overloaded,
+// errors generated by the rate limiter itself are not counted
+func isOverloadError(err error) bool {
+	if err == nil || err == ErrPersistenceLimitExceeded {
+		return false
+	}
+	switch err.(type) {
+	case *workflow.ServiceBusyError, *TimeoutError:
+		return true
+	}
+	return false
+}
+
+// recordRateLimiterFeedback reports the result of a persistence call to the
+// rate limiter if it adapts to errors
+func recordRateLimiterFeedback(rateLimiter tokenbucket.TokenBucket, err error) {
+	if limiter, ok := rateLimiter.(AdaptiveRateLimiter); ok {
+		limiter.RecordResult(err)
+	}
+}
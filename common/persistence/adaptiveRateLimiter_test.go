@@ -0,0 +1,125 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common/service/config"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	adaptiveRateLimiterSuite struct {
+		suite.Suite
+		// override suite.Suite.Assertions with require.Assertions; this means that s.NotNil(nil) will stop the test,
+		// not merely log an error
+		*require.Assertions
+		timeSource *mockTimeSource
+		config     *config.AdaptiveThrottlingConfig
+	}
+
+	mockTimeSource struct {
+		currTime time.Time
+	}
+)
+
+func TestAdaptiveRateLimiterSuite(t *testing.T) {
+	s := new(adaptiveRateLimiterSuite)
+	suite.Run(t, s)
+}
+
+func (s *adaptiveRateLimiterSuite) SetupTest() {
+	// Have to define our overridden assertions in the test setup. If we did it earlier, s.T() will return nil
+	s.Assertions = require.New(s.T())
+	s.timeSource = &mockTimeSource{currTime: time.Now()}
+	s.config = &config.AdaptiveThrottlingConfig{
+		Enabled:             dynamicconfig.GetBoolPropertyFn(true),
+		ErrorRatioThreshold: dynamicconfig.GetFloatPropertyFn(0.1),
+		BackoffFactor:       dynamicconfig.GetFloatPropertyFn(0.5),
+		RecoveryFactor:      dynamicconfig.GetFloatPropertyFn(0.1),
+		MinQPSRatio:         dynamicconfig.GetFloatPropertyFn(0.2),
+		EvaluationInterval:  dynamicconfig.GetDurationPropertyFn(time.Second),
+	}
+}
+
+func (ts *mockTimeSource) Now() time.Time {
+	return ts.currTime
+}
+
+func (ts *mockTimeSource) advance(d time.Duration) {
+	ts.currTime = ts.currTime.Add(d)
+}
+
+func (s *adaptiveRateLimiterSuite) TestBackoffAndRecovery() {
+	limiter := NewAdaptiveRateLimiter(100, s.config, s.timeSource)
+	s.Equal(100, limiter.CurrentQPS())
+
+	limiter.RecordResult(&workflow.ServiceBusyError{})
+	limiter.RecordResult(nil)
+	s.timeSource.advance(time.Second)
+	limiter.TryConsume(1)
+	s.Equal(50, limiter.CurrentQPS())
+
+	limiter.RecordResult(&TimeoutError{})
+	s.timeSource.advance(time.Second)
+	limiter.TryConsume(1)
+	s.Equal(25, limiter.CurrentQPS())
+
+	// never goes below the min ratio
+	limiter.RecordResult(&TimeoutError{})
+	s.timeSource.advance(time.Second)
+	limiter.TryConsume(1)
+	s.Equal(20, limiter.CurrentQPS())
+
+	for i := 0; i < 10; i++ {
+		limiter.RecordResult(nil)
+		s.timeSource.advance(time.Second)
+		limiter.TryConsume(1)
+	}
+	s.Equal(100, limiter.CurrentQPS())
+}
+
+func (s *adaptiveRateLimiterSuite) TestIgnoresNonOverloadErrors() {
+	limiter := NewAdaptiveRateLimiter(100, s.config, s.timeSource)
+
+	limiter.RecordResult(ErrPersistenceLimitExceeded)
+	limiter.RecordResult(&workflow.EntityNotExistsError{})
+	limiter.RecordResult(errors.New("some random error"))
+	s.timeSource.advance(time.Second)
+	limiter.TryConsume(1)
+	s.Equal(100, limiter.CurrentQPS())
+}
+
+func (s *adaptiveRateLimiterSuite) TestDisabled() {
+	s.config.Enabled = dynamicconfig.GetBoolPropertyFn(false)
+	limiter := NewAdaptiveRateLimiter(100, s.config, s.timeSource)
+
+	limiter.RecordResult(&workflow.ServiceBusyError{})
+	s.timeSource.advance(time.Second)
+	limiter.TryConsume(1)
+	s.Equal(100, limiter.CurrentQPS())
+}
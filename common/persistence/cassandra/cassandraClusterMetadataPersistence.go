@@ -0,0 +1,219 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cassandra
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gocql/gocql"
+	"github.com/uber-common/bark"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	p "github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/config"
+)
+
+// the metadata of the cluster is a single row
+const constClusterMetadataPartition = 0
+
+const (
+	templateCreateClusterMetadataQuery = `INSERT INTO cluster_metadata (` +
+		`metadata_partition, cluster_name, failover_version_increment, initial_failover_versions, ` +
+		`cluster_rpc_names, cluster_rpc_addresses, search_attributes, num_history_shards, version) ` +
+		`VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?) IF NOT EXISTS`
+
+	templateGetClusterMetadataQuery = `SELECT cluster_name, failover_version_increment, initial_failover_versions, ` +
+		`cluster_rpc_names, cluster_rpc_addresses, search_attributes, num_history_shards, version ` +
+		`FROM cluster_metadata ` +
+		`WHERE metadata_partition = ?`
+
+	templateUpdateClusterMetadataQuery = `UPDATE cluster_metadata ` +
+		`SET cluster_name = ?, ` +
+		`failover_version_increment = ?, ` +
+		`initial_failover_versions = ?, ` +
+		`cluster_rpc_names = ?, ` +
+		`cluster_rpc_addresses = ?, ` +
+		`search_attributes = ?, ` +
+		`num_history_shards = ?, ` +
+		`version = ? ` +
+		`WHERE metadata_partition = ? ` +
+		`IF version = ?`
+)
+
+type (
+	cassandraClusterMetadataPersistence struct {
+		cassandraStore
+	}
+)
+
+// newClusterMetadataPersistence is used to create an instance of ClusterMetadataManager implementation
+func newClusterMetadataPersistence(cfg config.Cassandra, logger bark.Logger) (p.ClusterMetadataStore, error) {
+	cluster := NewCassandraCluster(cfg.Hosts, cfg.Port, cfg.User, cfg.Password, cfg.Datacenter)
+	cluster.Keyspace = cfg.Keyspace
+	cluster.ProtoVersion = cassandraProtoVersion
+	cluster.Consistency = gocql.LocalQuorum
+	cluster.SerialConsistency = gocql.LocalSerial
+	cluster.Timeout = sessionTimeout(cfg)
+
+	session, err := cluster.CreateSession()
+	if err != nil {
+		return nil, err
+	}
+
+	return &cassandraClusterMetadataPersistence{
+		cassandraStore: cassandraStore{session: session, logger: logger},
+	}, nil
+}
+
+// Close releases the resources held by this object
+func (m *cassandraClusterMetadataPersistence) Close() {
+	if m.session != nil {
+		m.session.Close()
+	}
+}
+
+func (m *cassandraClusterMetadataPersistence) InitializeClusterMetadata(ctx context.Context,
+	request *p.InitializeClusterMetadataRequest) (*p.InitializeClusterMetadataResponse, error) {
+	metadata := request.ClusterMetadata
+	rpcNames, rpcAddresses := splitClusterAddresses(metadata.ClusterAddresses)
+	query := m.session.Query(templateCreateClusterMetadataQuery,
+		constClusterMetadataPartition,
+		metadata.ClusterName,
+		metadata.FailoverVersionIncrement,
+		metadata.InitialFailoverVersions,
+		rpcNames,
+		rpcAddresses,
+		metadata.SearchAttributes,
+		metadata.NumHistoryShards,
+		metadata.Version,
+	).WithContext(ctx)
+
+	previous := make(map[string]interface{})
+	applied, err := query.MapScanCAS(previous)
+	if err != nil {
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("InitializeClusterMetadata operation failed. Error: %v", err),
+		}
+	}
+	if applied {
+		return &p.InitializeClusterMetadataResponse{ClusterMetadata: metadata, Created: true}, nil
+	}
+	return &p.InitializeClusterMetadataResponse{ClusterMetadata: createClusterMetadata(previous)}, nil
+}
+
+func (m *cassandraClusterMetadataPersistence) GetClusterMetadata(ctx context.Context) (*p.GetClusterMetadataResponse, error) {
+	query := m.session.Query(templateGetClusterMetadataQuery, constClusterMetadataPartition).WithContext(ctx)
+
+	result := make(map[string]interface{})
+	if err := query.MapScan(result); err != nil {
+		if err == gocql.ErrNotFound {
+			return nil, &workflow.EntityNotExistsError{
+				Message: "GetClusterMetadata operation failed. Cluster metadata not found.",
+			}
+		}
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("GetClusterMetadata operation failed. Error: %v", err),
+		}
+	}
+	return &p.GetClusterMetadataResponse{ClusterMetadata: createClusterMetadata(result)}, nil
+}
+
+func (m *cassandraClusterMetadataPersistence) UpdateClusterMetadata(ctx context.Context,
+	request *p.UpdateClusterMetadataRequest) error {
+	metadata := request.ClusterMetadata
+	rpcNames, rpcAddresses := splitClusterAddresses(metadata.ClusterAddresses)
+	query := m.session.Query(templateUpdateClusterMetadataQuery,
+		metadata.ClusterName,
+		metadata.FailoverVersionIncrement,
+		metadata.InitialFailoverVersions,
+		rpcNames,
+		rpcAddresses,
+		metadata.SearchAttributes,
+		metadata.NumHistoryShards,
+		metadata.Version,
+		constClusterMetadataPartition,
+		request.PreviousVersion,
+	).WithContext(ctx)
+
+	previous := make(map[string]interface{})
+	applied, err := query.MapScanCAS(previous)
+	if err != nil {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("UpdateClusterMetadata operation failed. Error: %v", err),
+		}
+	}
+	if !applied {
+		return &p.ConditionFailedError{
+			Msg: fmt.Sprintf("UpdateClusterMetadata operation failed. Previous version: %v, persisted version: %v",
+				request.PreviousVersion, previous["version"]),
+		}
+	}
+	return nil
+}
+
+func createClusterMetadata(result map[string]interface{}) p.ClusterMetadata {
+	metadata := p.ClusterMetadata{
+		InitialFailoverVersions: make(map[string]int64),
+		ClusterAddresses:        make(map[string]p.ClusterAddress),
+		SearchAttributes:        make(map[string]int32),
+	}
+	rpcNames := make(map[string]string)
+	rpcAddresses := make(map[string]string)
+	for k, v := range result {
+		switch k {
+		case "cluster_name":
+			metadata.ClusterName = v.(string)
+		case "failover_version_increment":
+			metadata.FailoverVersionIncrement = v.(int64)
+		case "initial_failover_versions":
+			metadata.InitialFailoverVersions = v.(map[string]int64)
+		case "cluster_rpc_names":
+			rpcNames = v.(map[string]string)
+		case "cluster_rpc_addresses":
+			rpcAddresses = v.(map[string]string)
+		case "search_attributes":
+			for key, valueType := range v.(map[string]int) {
+				metadata.SearchAttributes[key] = int32(valueType)
+			}
+		case "num_history_shards":
+			metadata.NumHistoryShards = v.(int)
+		case "version":
+			metadata.Version = v.(int64)
+		}
+	}
+	for clusterName, rpcAddress := range rpcAddresses {
+		metadata.ClusterAddresses[clusterName] = p.ClusterAddress{
+			RPCName:    rpcNames[clusterName],
+			RPCAddress: rpcAddress,
+		}
+	}
+	return metadata
+}
+
+func splitClusterAddresses(addresses map[string]p.ClusterAddress) (map[string]string, map[string]string) {
+	rpcNames := make(map[string]string, len(addresses))
+	rpcAddresses := make(map[string]string, len(addresses))
+	for clusterName, address := range addresses {
+		rpcNames[clusterName] = address.RPCName
+		rpcAddresses[clusterName] = address.RPCAddress
+	}
+	return rpcNames, rpcAddresses
+}
@@ -73,7 +73,7 @@ func newHistoryPersistence(cfg config.Cassandra, logger bark.Logger) (p.HistoryS
 	cluster.ProtoVersion = cassandraProtoVersion
 	cluster.Consistency = gocql.LocalQuorum
 	cluster.SerialConsistency = gocql.LocalSerial
-	cluster.Timeout = defaultSessionTimeout
+	cluster.Timeout = sessionTimeout(cfg)
 	if cfg.MaxConns > 0 {
 		cluster.NumConns = cfg.MaxConns
 	}
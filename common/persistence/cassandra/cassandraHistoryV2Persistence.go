@@ -76,7 +76,7 @@ func newHistoryV2Persistence(cfg config.Cassandra, logger bark.Logger) (p.Histor
 	cluster.ProtoVersion = cassandraProtoVersion
 	cluster.Consistency = gocql.LocalQuorum
 	cluster.SerialConsistency = gocql.LocalSerial
-	cluster.Timeout = defaultSessionTimeout
+	cluster.Timeout = sessionTimeout(cfg)
 	if cfg.MaxConns > 0 {
 		cluster.NumConns = cfg.MaxConns
 	}
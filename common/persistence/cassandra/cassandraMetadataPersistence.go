@@ -21,6 +21,7 @@
 package cassandra
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/gocql/gocql"
@@ -44,7 +45,8 @@ const (
 		`retention: ?, ` +
 		`emit_metric: ?, ` +
 		`archival_bucket: ?, ` +
-		`archival_status: ?` +
+		`archival_status: ?, ` +
+		`archival_target_bucket: ?` +
 		`}`
 
 	templateDomainReplicationConfigType = `{` +
@@ -66,7 +68,7 @@ const (
 
 	templateGetDomainByNameQuery = `SELECT domain.id, domain.name, domain.status, domain.description, ` +
 		`domain.owner_email, domain.data, config.retention, config.emit_metric, ` +
-		`config.archival_bucket, config.archival_status, ` +
+		`config.archival_bucket, config.archival_status, config.archival_target_bucket, ` +
 		`replication_config.active_cluster_name, replication_config.clusters, ` +
 		`is_global_domain, ` +
 		`config_version, ` +
@@ -107,7 +109,7 @@ func newMetadataPersistence(cfg config.Cassandra, clusterName string, logger bar
 	cluster.ProtoVersion = cassandraProtoVersion
 	cluster.Consistency = gocql.LocalQuorum
 	cluster.SerialConsistency = gocql.LocalSerial
-	cluster.Timeout = defaultSessionTimeout
+	cluster.Timeout = sessionTimeout(cfg)
 
 	session, err := cluster.CreateSession()
 	if err != nil {
@@ -131,8 +133,8 @@ func (m *cassandraMetadataPersistence) Close() {
 // 'Domains' table and then do a conditional insert into domains_by_name table.  If the conditional write fails we
 // delete the orphaned entry from domains table.  There is a chance delete entry could fail and we never delete the
 // orphaned entry from domains table.  We might need a background job to delete those orphaned record.
-func (m *cassandraMetadataPersistence) CreateDomain(request *p.CreateDomainRequest) (*p.CreateDomainResponse, error) {
-	query := m.session.Query(templateCreateDomainQuery, request.Info.ID, request.Info.Name)
+func (m *cassandraMetadataPersistence) CreateDomain(ctx context.Context, request *p.CreateDomainRequest) (*p.CreateDomainResponse, error) {
+	query := m.session.Query(templateCreateDomainQuery, request.Info.ID, request.Info.Name).WithContext(ctx)
 	applied, err := query.ScanCAS()
 	if err != nil {
 		return nil, &workflow.InternalServiceError{
@@ -157,12 +159,13 @@ func (m *cassandraMetadataPersistence) CreateDomain(request *p.CreateDomainReque
 		request.Config.EmitMetric,
 		request.Config.ArchivalBucket,
 		request.Config.ArchivalStatus,
+		request.Config.ArchivalTargetBucket,
 		request.ReplicationConfig.ActiveClusterName,
 		p.SerializeClusterConfigs(request.ReplicationConfig.Clusters),
 		request.IsGlobalDomain,
 		request.ConfigVersion,
 		request.FailoverVersion,
-	)
+	).WithContext(ctx)
 
 	previous := make(map[string]interface{})
 	applied, err = query.MapScanCAS(previous)
@@ -175,7 +178,7 @@ func (m *cassandraMetadataPersistence) CreateDomain(request *p.CreateDomainReque
 
 	if !applied {
 		// Domain already exist.  Delete orphan domain record before returning back to user
-		if errDelete := m.session.Query(templateDeleteDomainQuery, request.Info.ID).Exec(); errDelete != nil {
+		if errDelete := m.session.Query(templateDeleteDomainQuery, request.Info.ID).WithContext(ctx).Exec(); errDelete != nil {
 			m.logger.Warnf("Unable to delete orphan domain record. Error: %v", errDelete)
 		}
 
@@ -194,7 +197,7 @@ func (m *cassandraMetadataPersistence) CreateDomain(request *p.CreateDomainReque
 	return &p.CreateDomainResponse{ID: request.Info.ID}, nil
 }
 
-func (m *cassandraMetadataPersistence) GetDomain(request *p.GetDomainRequest) (*p.GetDomainResponse, error) {
+func (m *cassandraMetadataPersistence) GetDomain(ctx context.Context, request *p.GetDomainRequest) (*p.GetDomainResponse, error) {
 	var query *gocql.Query
 	var err error
 	info := &p.DomainInfo{}
@@ -233,14 +236,14 @@ func (m *cassandraMetadataPersistence) GetDomain(request *p.GetDomainRequest) (*
 
 	domainName := request.Name
 	if len(request.ID) > 0 {
-		query = m.session.Query(templateGetDomainQuery, request.ID)
+		query = m.session.Query(templateGetDomainQuery, request.ID).WithContext(ctx)
 		err = query.Scan(&domainName)
 		if err != nil {
 			return nil, handleError(request.Name, request.ID, err)
 		}
 	}
 
-	query = m.session.Query(templateGetDomainByNameQuery, domainName)
+	query = m.session.Query(templateGetDomainByNameQuery, domainName).WithContext(ctx)
 	err = query.Scan(
 		&info.ID,
 		&info.Name,
@@ -252,6 +255,7 @@ func (m *cassandraMetadataPersistence) GetDomain(request *p.GetDomainRequest) (*
 		&config.EmitMetric,
 		&config.ArchivalBucket,
 		&config.ArchivalStatus,
+		&config.ArchivalTargetBucket,
 		&replicationConfig.ActiveClusterName,
 		&replicationClusters,
 		&isGlobalDomain,
@@ -280,7 +284,7 @@ func (m *cassandraMetadataPersistence) GetDomain(request *p.GetDomainRequest) (*
 	}, nil
 }
 
-func (m *cassandraMetadataPersistence) UpdateDomain(request *p.UpdateDomainRequest) error {
+func (m *cassandraMetadataPersistence) UpdateDomain(ctx context.Context, request *p.UpdateDomainRequest) error {
 	var nextVersion int64 = 1
 	var currentVersion *int64
 	if request.NotificationVersion > 0 {
@@ -298,6 +302,7 @@ func (m *cassandraMetadataPersistence) UpdateDomain(request *p.UpdateDomainReque
 		request.Config.EmitMetric,
 		request.Config.ArchivalBucket,
 		request.Config.ArchivalStatus,
+		request.Config.ArchivalTargetBucket,
 		request.ReplicationConfig.ActiveClusterName,
 		p.SerializeClusterConfigs(request.ReplicationConfig.Clusters),
 		request.ConfigVersion,
@@ -305,7 +310,7 @@ func (m *cassandraMetadataPersistence) UpdateDomain(request *p.UpdateDomainReque
 		nextVersion,
 		request.Info.Name,
 		currentVersion,
-	)
+	).WithContext(ctx)
 
 	applied, err := query.ScanCAS()
 	if !applied {
@@ -322,9 +327,9 @@ func (m *cassandraMetadataPersistence) UpdateDomain(request *p.UpdateDomainReque
 	return nil
 }
 
-func (m *cassandraMetadataPersistence) DeleteDomain(request *p.DeleteDomainRequest) error {
+func (m *cassandraMetadataPersistence) DeleteDomain(ctx context.Context, request *p.DeleteDomainRequest) error {
 	var name string
-	query := m.session.Query(templateGetDomainQuery, request.ID)
+	query := m.session.Query(templateGetDomainQuery, request.ID).WithContext(ctx)
 	err := query.Scan(&name)
 	if err != nil {
 		if err == gocql.ErrNotFound {
@@ -336,9 +341,9 @@ func (m *cassandraMetadataPersistence) DeleteDomain(request *p.DeleteDomainReque
 	return m.deleteDomain(name, request.ID)
 }
 
-func (m *cassandraMetadataPersistence) DeleteDomainByName(request *p.DeleteDomainByNameRequest) error {
+func (m *cassandraMetadataPersistence) DeleteDomainByName(ctx context.Context, request *p.DeleteDomainByNameRequest) error {
 	var ID string
-	query := m.session.Query(templateGetDomainByNameQuery, request.Name)
+	query := m.session.Query(templateGetDomainByNameQuery, request.Name).WithContext(ctx)
 	err := query.Scan(&ID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	if err != nil {
 		if err == gocql.ErrNotFound {
@@ -349,11 +354,11 @@ func (m *cassandraMetadataPersistence) DeleteDomainByName(request *p.DeleteDomai
 	return m.deleteDomain(request.Name, ID)
 }
 
-func (m *cassandraMetadataPersistence) ListDomains(request *p.ListDomainsRequest) (*p.ListDomainsResponse, error) {
+func (m *cassandraMetadataPersistence) ListDomains(ctx context.Context, request *p.ListDomainsRequest) (*p.ListDomainsResponse, error) {
 	panic("cassandraMetadataPersistence do not support list domain operation.")
 }
 
-func (m *cassandraMetadataPersistence) GetMetadata() (*p.GetMetadataResponse, error) {
+func (m *cassandraMetadataPersistence) GetMetadata(ctx context.Context) (*p.GetMetadataResponse, error) {
 	panic("cassandraMetadataPersistence do not support get metadata operation.")
 }
 
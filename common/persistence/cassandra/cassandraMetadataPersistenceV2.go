@@ -21,6 +21,7 @@
 package cassandra
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/gocql/gocql"
@@ -40,7 +41,7 @@ const (
 
 	templateGetDomainByNameQueryV2 = `SELECT domain.id, domain.name, domain.status, domain.description, ` +
 		`domain.owner_email, domain.data, config.retention, config.emit_metric, ` +
-		`config.archival_bucket, config.archival_status, ` +
+		`config.archival_bucket, config.archival_status, config.archival_target_bucket, ` +
 		`replication_config.active_cluster_name, replication_config.clusters, ` +
 		`is_global_domain, ` +
 		`config_version, ` +
@@ -79,7 +80,7 @@ const (
 
 	templateListDomainQueryV2 = `SELECT name, domain.id, domain.name, domain.status, domain.description, ` +
 		`domain.owner_email, domain.data, config.retention, config.emit_metric, ` +
-		`config.archival_bucket, config.archival_status, ` +
+		`config.archival_bucket, config.archival_status, config.archival_target_bucket, ` +
 		`replication_config.active_cluster_name, replication_config.clusters, ` +
 		`is_global_domain, ` +
 		`config_version, ` +
@@ -104,7 +105,7 @@ func newMetadataPersistenceV2(cfg config.Cassandra, currentClusterName string, l
 	cluster.ProtoVersion = cassandraProtoVersion
 	cluster.Consistency = gocql.LocalQuorum
 	cluster.SerialConsistency = gocql.LocalSerial
-	cluster.Timeout = defaultSessionTimeout
+	cluster.Timeout = sessionTimeout(cfg)
 
 	session, err := cluster.CreateSession()
 	if err != nil {
@@ -128,8 +129,8 @@ func (m *cassandraMetadataPersistenceV2) Close() {
 // 'Domains' table and then do a conditional insert into domains_by_name table.  If the conditional write fails we
 // delete the orphaned entry from domains table.  There is a chance delete entry could fail and we never delete the
 // orphaned entry from domains table.  We might need a background job to delete those orphaned record.
-func (m *cassandraMetadataPersistenceV2) CreateDomain(request *p.CreateDomainRequest) (*p.CreateDomainResponse, error) {
-	query := m.session.Query(templateCreateDomainQuery, request.Info.ID, request.Info.Name)
+func (m *cassandraMetadataPersistenceV2) CreateDomain(ctx context.Context, request *p.CreateDomainRequest) (*p.CreateDomainResponse, error) {
+	query := m.session.Query(templateCreateDomainQuery, request.Info.ID, request.Info.Name).WithContext(ctx)
 	applied, err := query.ScanCAS()
 	if err != nil {
 		return nil, &workflow.InternalServiceError{
@@ -142,12 +143,12 @@ func (m *cassandraMetadataPersistenceV2) CreateDomain(request *p.CreateDomainReq
 		}
 	}
 
-	metadata, err := m.GetMetadata()
+	metadata, err := m.GetMetadata(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	batch := m.session.NewBatch(gocql.LoggedBatch)
+	batch := m.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
 	batch.Query(templateCreateDomainByNameQueryWithinBatchV2,
 		constDomainPartition,
 		request.Info.Name,
@@ -161,6 +162,7 @@ func (m *cassandraMetadataPersistenceV2) CreateDomain(request *p.CreateDomainReq
 		request.Config.EmitMetric,
 		request.Config.ArchivalBucket,
 		request.Config.ArchivalStatus,
+		request.Config.ArchivalTargetBucket,
 		request.ReplicationConfig.ActiveClusterName,
 		p.SerializeClusterConfigs(request.ReplicationConfig.Clusters),
 		request.IsGlobalDomain,
@@ -187,7 +189,7 @@ func (m *cassandraMetadataPersistenceV2) CreateDomain(request *p.CreateDomainReq
 
 	if !applied {
 		// Domain already exist.  Delete orphan domain record before returning back to user
-		if errDelete := m.session.Query(templateDeleteDomainQuery, request.Info.ID).Exec(); errDelete != nil {
+		if errDelete := m.session.Query(templateDeleteDomainQuery, request.Info.ID).WithContext(ctx).Exec(); errDelete != nil {
 			m.logger.Warnf("Unable to delete orphan domain record. Error: %v", errDelete)
 		}
 
@@ -206,8 +208,8 @@ func (m *cassandraMetadataPersistenceV2) CreateDomain(request *p.CreateDomainReq
 	return &p.CreateDomainResponse{ID: request.Info.ID}, nil
 }
 
-func (m *cassandraMetadataPersistenceV2) UpdateDomain(request *p.UpdateDomainRequest) error {
-	batch := m.session.NewBatch(gocql.LoggedBatch)
+func (m *cassandraMetadataPersistenceV2) UpdateDomain(ctx context.Context, request *p.UpdateDomainRequest) error {
+	batch := m.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
 	batch.Query(templateUpdateDomainByNameQueryWithinBatchV2,
 		request.Info.ID,
 		request.Info.Name,
@@ -219,6 +221,7 @@ func (m *cassandraMetadataPersistenceV2) UpdateDomain(request *p.UpdateDomainReq
 		request.Config.EmitMetric,
 		request.Config.ArchivalBucket,
 		request.Config.ArchivalStatus,
+		request.Config.ArchivalTargetBucket,
 		request.ReplicationConfig.ActiveClusterName,
 		p.SerializeClusterConfigs(request.ReplicationConfig.Clusters),
 		request.ConfigVersion,
@@ -252,7 +255,7 @@ func (m *cassandraMetadataPersistenceV2) UpdateDomain(request *p.UpdateDomainReq
 	return nil
 }
 
-func (m *cassandraMetadataPersistenceV2) GetDomain(request *p.GetDomainRequest) (*p.GetDomainResponse, error) {
+func (m *cassandraMetadataPersistenceV2) GetDomain(ctx context.Context, request *p.GetDomainRequest) (*p.GetDomainResponse, error) {
 	var query *gocql.Query
 	var err error
 	info := &p.DomainInfo{}
@@ -292,14 +295,14 @@ func (m *cassandraMetadataPersistenceV2) GetDomain(request *p.GetDomainRequest)
 
 	domainName := request.Name
 	if len(request.ID) > 0 {
-		query = m.session.Query(templateGetDomainQuery, request.ID)
+		query = m.session.Query(templateGetDomainQuery, request.ID).WithContext(ctx)
 		err = query.Scan(&domainName)
 		if err != nil {
 			return nil, handleError(request.Name, request.ID, err)
 		}
 	}
 
-	query = m.session.Query(templateGetDomainByNameQueryV2, constDomainPartition, domainName)
+	query = m.session.Query(templateGetDomainByNameQueryV2, constDomainPartition, domainName).WithContext(ctx)
 	err = query.Scan(
 		&info.ID,
 		&info.Name,
@@ -311,6 +314,7 @@ func (m *cassandraMetadataPersistenceV2) GetDomain(request *p.GetDomainRequest)
 		&config.EmitMetric,
 		&config.ArchivalBucket,
 		&config.ArchivalStatus,
+		&config.ArchivalTargetBucket,
 		&replicationConfig.ActiveClusterName,
 		&replicationClusters,
 		&isGlobalDomain,
@@ -344,10 +348,10 @@ func (m *cassandraMetadataPersistenceV2) GetDomain(request *p.GetDomainRequest)
 	}, nil
 }
 
-func (m *cassandraMetadataPersistenceV2) ListDomains(request *p.ListDomainsRequest) (*p.ListDomainsResponse, error) {
+func (m *cassandraMetadataPersistenceV2) ListDomains(ctx context.Context, request *p.ListDomainsRequest) (*p.ListDomainsResponse, error) {
 	var query *gocql.Query
 
-	query = m.session.Query(templateListDomainQueryV2, constDomainPartition)
+	query = m.session.Query(templateListDomainQueryV2, constDomainPartition).WithContext(ctx)
 	iter := query.PageSize(request.PageSize).PageState(request.NextPageToken).Iter()
 	if iter == nil {
 		return nil, &workflow.InternalServiceError{
@@ -368,7 +372,7 @@ func (m *cassandraMetadataPersistenceV2) ListDomains(request *p.ListDomainsReque
 		&name,
 		&domain.Info.ID, &domain.Info.Name, &domain.Info.Status, &domain.Info.Description, &domain.Info.OwnerEmail, &domain.Info.Data,
 		&domain.Config.Retention, &domain.Config.EmitMetric,
-		&domain.Config.ArchivalBucket, &domain.Config.ArchivalStatus,
+		&domain.Config.ArchivalBucket, &domain.Config.ArchivalStatus, &domain.Config.ArchivalTargetBucket,
 		&domain.ReplicationConfig.ActiveClusterName, &replicationClusters,
 		&domain.IsGlobalDomain, &domain.ConfigVersion, &domain.FailoverVersion,
 		&domain.FailoverNotificationVersion, &domain.NotificationVersion,
@@ -403,9 +407,9 @@ func (m *cassandraMetadataPersistenceV2) ListDomains(request *p.ListDomainsReque
 	return response, nil
 }
 
-func (m *cassandraMetadataPersistenceV2) DeleteDomain(request *p.DeleteDomainRequest) error {
+func (m *cassandraMetadataPersistenceV2) DeleteDomain(ctx context.Context, request *p.DeleteDomainRequest) error {
 	var name string
-	query := m.session.Query(templateGetDomainQuery, request.ID)
+	query := m.session.Query(templateGetDomainQuery, request.ID).WithContext(ctx)
 	err := query.Scan(&name)
 	if err != nil {
 		if err == gocql.ErrNotFound {
@@ -417,9 +421,9 @@ func (m *cassandraMetadataPersistenceV2) DeleteDomain(request *p.DeleteDomainReq
 	return m.deleteDomain(name, request.ID)
 }
 
-func (m *cassandraMetadataPersistenceV2) DeleteDomainByName(request *p.DeleteDomainByNameRequest) error {
+func (m *cassandraMetadataPersistenceV2) DeleteDomainByName(ctx context.Context, request *p.DeleteDomainByNameRequest) error {
 	var ID string
-	query := m.session.Query(templateGetDomainByNameQueryV2, constDomainPartition, request.Name)
+	query := m.session.Query(templateGetDomainByNameQueryV2, constDomainPartition, request.Name).WithContext(ctx)
 	err := query.Scan(&ID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	if err != nil {
 		if err == gocql.ErrNotFound {
@@ -430,9 +434,9 @@ func (m *cassandraMetadataPersistenceV2) DeleteDomainByName(request *p.DeleteDom
 	return m.deleteDomain(request.Name, ID)
 }
 
-func (m *cassandraMetadataPersistenceV2) GetMetadata() (*p.GetMetadataResponse, error) {
+func (m *cassandraMetadataPersistenceV2) GetMetadata(ctx context.Context) (*p.GetMetadataResponse, error) {
 	var notificationVersion int64
-	query := m.session.Query(templateGetMetadataQueryV2, constDomainPartition, domainMetadataRecordName)
+	query := m.session.Query(templateGetMetadataQueryV2, constDomainPartition, domainMetadataRecordName).WithContext(ctx)
 	err := query.Scan(&notificationVersion)
 	if err != nil {
 		if err == gocql.ErrNotFound {
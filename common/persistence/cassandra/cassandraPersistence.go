@@ -21,6 +21,7 @@
 package cassandra
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
@@ -30,6 +31,7 @@ import (
 
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/checksum"
 	p "github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/service/config"
 )
@@ -163,7 +165,10 @@ const (
 		`event_store_version: ?, ` +
 		`branch_token: ?, ` +
 		`cron_schedule: ?, ` +
-		`expiration_seconds: ? ` +
+		`expiration_seconds: ?, ` +
+		`labels: ?, ` +
+		`version_histories: ?, ` +
+		`version_histories_encoding: ? ` +
 		`}`
 
 	templateReplicationStateType = `{` +
@@ -174,6 +179,12 @@ const (
 		`last_replication_info: ?` +
 		`}`
 
+	templateChecksumType = `{` +
+		`version: ?, ` +
+		`flavor: ?, ` +
+		`value: ? ` +
+		`}`
+
 	templateTransferTaskType = `{` +
 		`domain_id: ?, ` +
 		`workflow_id: ?, ` +
@@ -188,7 +199,9 @@ const (
 		`type: ?, ` +
 		`schedule_id: ?, ` +
 		`record_visibility: ?, ` +
-		`version: ?` +
+		`version: ?, ` +
+		`request_id: ?, ` +
+		`caller_identity: ?` +
 		`}`
 
 	templateReplicationTaskType = `{` +
@@ -219,7 +232,11 @@ const (
 		`timeout_type: ?, ` +
 		`event_id: ?, ` +
 		`schedule_attempt: ?, ` +
-		`version: ?` +
+		`version: ?, ` +
+		`signal_name: ?, ` +
+		`signal_input: ?, ` +
+		`signal_identity: ?, ` +
+		`signal_request_id: ?` +
 		`}`
 
 	templateActivityInfoType = `{` +
@@ -252,6 +269,7 @@ const (
 		`expiration_time: ?, ` +
 		`max_attempts: ?, ` +
 		`non_retriable_errors: ?, ` +
+		`last_failure_reason: ?, ` +
 		`event_data_encoding: ?` +
 		`}`
 
@@ -416,7 +434,7 @@ workflow_state = ? ` +
 		`and task_id = ? ` +
 		`IF range_id = ?`
 
-	templateGetWorkflowExecutionQuery = `SELECT execution, replication_state, activity_map, timer_map, child_executions_map, request_cancel_map, signal_map, signal_requested, buffered_events_list, buffered_replication_tasks_map ` +
+	templateGetWorkflowExecutionQuery = `SELECT execution, replication_state, activity_map, timer_map, child_executions_map, request_cancel_map, signal_map, signal_requested, buffered_events_list, buffered_replication_tasks_map, checksum ` +
 		`FROM executions ` +
 		`WHERE shard_id = ? ` +
 		`and type = ? ` +
@@ -426,6 +444,13 @@ workflow_state = ? ` +
 		`and visibility_ts = ? ` +
 		`and task_id = ?`
 
+	templateGetWorkflowExecutionsQuery = `SELECT execution, replication_state, activity_map, timer_map, child_executions_map, request_cancel_map, signal_map, signal_requested, buffered_events_list, buffered_replication_tasks_map, checksum ` +
+		`FROM executions ` +
+		`WHERE shard_id = ? ` +
+		`and (type, domain_id, workflow_id, run_id, visibility_ts, task_id) IN (%v)`
+
+	templateGetWorkflowExecutionsKey = `(?, ?, ?, ?, ?, ?)`
+
 	templateGetCurrentExecutionQuery = `SELECT current_run_id, execution, replication_state ` +
 		`FROM executions ` +
 		`WHERE shard_id = ? ` +
@@ -450,7 +475,7 @@ workflow_state = ? ` +
 	templateUpdateWorkflowExecutionConditionSuffix = ` IF next_event_id = ?`
 
 	templateUpdateWorkflowExecutionQuery = `UPDATE executions ` +
-		`SET execution = ` + templateWorkflowExecutionType + `, next_event_id = ? ` +
+		`SET execution = ` + templateWorkflowExecutionType + `, checksum = ` + templateChecksumType + `, next_event_id = ? ` +
 		`WHERE shard_id = ? ` +
 		`and type = ? ` +
 		`and domain_id = ? ` +
@@ -460,7 +485,7 @@ workflow_state = ? ` +
 		`and task_id = ? `
 
 	templateUpdateWorkflowExecutionWithReplicationQuery = `UPDATE executions ` +
-		`SET execution = ` + templateWorkflowExecutionType + `, replication_state = ` + templateReplicationStateType + `, next_event_id = ? ` +
+		`SET execution = ` + templateWorkflowExecutionType + `, replication_state = ` + templateReplicationStateType + `, checksum = ` + templateChecksumType + `, next_event_id = ? ` +
 		`WHERE shard_id = ? ` +
 		`and type = ? ` +
 		`and domain_id = ? ` +
@@ -920,7 +945,7 @@ func newShardPersistence(cfg config.Cassandra, clusterName string, logger bark.L
 	cluster.ProtoVersion = cassandraProtoVersion
 	cluster.Consistency = gocql.LocalQuorum
 	cluster.SerialConsistency = gocql.LocalSerial
-	cluster.Timeout = defaultSessionTimeout
+	cluster.Timeout = sessionTimeout(cfg)
 
 	session, err := cluster.CreateSession()
 	if err != nil {
@@ -947,7 +972,7 @@ func newTaskPersistence(cfg config.Cassandra, logger bark.Logger) (p.TaskStore,
 	cluster.ProtoVersion = cassandraProtoVersion
 	cluster.Consistency = gocql.LocalQuorum
 	cluster.SerialConsistency = gocql.LocalSerial
-	cluster.Timeout = defaultSessionTimeout
+	cluster.Timeout = sessionTimeout(cfg)
 	session, err := cluster.CreateSession()
 	if err != nil {
 		return nil, err
@@ -1106,10 +1131,10 @@ func (d *cassandraPersistence) UpdateShard(request *p.UpdateShardRequest) error
 	return nil
 }
 
-func (d *cassandraPersistence) CreateWorkflowExecution(request *p.CreateWorkflowExecutionRequest) (
+func (d *cassandraPersistence) CreateWorkflowExecution(ctx context.Context, request *p.InternalCreateWorkflowExecutionRequest) (
 	*p.CreateWorkflowExecutionResponse, error) {
 	cqlNowTimestamp := p.UnixNanoToDBTimestamp(time.Now().UnixNano())
-	batch := d.session.NewBatch(gocql.LoggedBatch)
+	batch := d.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
 
 	d.CreateWorkflowExecutionWithinBatch(request, batch, cqlNowTimestamp)
 
@@ -1243,7 +1268,7 @@ func (d *cassandraPersistence) CreateWorkflowExecution(request *p.CreateWorkflow
 	return &p.CreateWorkflowExecutionResponse{}, nil
 }
 
-func (d *cassandraPersistence) CreateWorkflowExecutionWithinBatch(request *p.CreateWorkflowExecutionRequest,
+func (d *cassandraPersistence) CreateWorkflowExecutionWithinBatch(request *p.InternalCreateWorkflowExecutionRequest,
 	batch *gocql.Batch, cqlNowTimestamp int64) {
 
 	parentDomainID := emptyDomainID
@@ -1260,6 +1285,8 @@ func (d *cassandraPersistence) CreateWorkflowExecutionWithinBatch(request *p.Cre
 		state = p.WorkflowStateCreated
 	}
 
+	versionHistoriesData, versionHistoriesEncoding := p.FromDataBlob(request.VersionHistories)
+
 	startVersion := common.EmptyVersion
 	lastWriteVersion := common.EmptyVersion
 	if request.ReplicationState != nil {
@@ -1401,6 +1428,9 @@ func (d *cassandraPersistence) CreateWorkflowExecutionWithinBatch(request *p.Cre
 			request.BranchToken,
 			request.CronSchedule,
 			request.ExpirationSeconds,
+			nil, // labels
+			versionHistoriesData,
+			versionHistoriesEncoding,
 			request.NextEventID,
 			defaultVisibilityTimestamp,
 			rowTypeExecutionTaskID)
@@ -1468,6 +1498,9 @@ func (d *cassandraPersistence) CreateWorkflowExecutionWithinBatch(request *p.Cre
 			request.BranchToken,
 			request.CronSchedule,
 			request.ExpirationSeconds,
+			nil, // labels
+			versionHistoriesData,
+			versionHistoriesEncoding,
 			request.ReplicationState.CurrentVersion,
 			request.ReplicationState.StartVersion,
 			request.ReplicationState.LastWriteVersion,
@@ -1479,7 +1512,7 @@ func (d *cassandraPersistence) CreateWorkflowExecutionWithinBatch(request *p.Cre
 	}
 }
 
-func (d *cassandraPersistence) GetWorkflowExecution(request *p.GetWorkflowExecutionRequest) (
+func (d *cassandraPersistence) GetWorkflowExecution(ctx context.Context, request *p.GetWorkflowExecutionRequest) (
 	*p.InternalGetWorkflowExecutionResponse, error) {
 	execution := request.Execution
 	query := d.session.Query(templateGetWorkflowExecutionQuery,
@@ -1489,7 +1522,7 @@ func (d *cassandraPersistence) GetWorkflowExecution(request *p.GetWorkflowExecut
 		*execution.WorkflowId,
 		*execution.RunId,
 		defaultVisibilityTimestamp,
-		rowTypeExecutionTaskID)
+		rowTypeExecutionTaskID).WithContext(ctx)
 
 	result := make(map[string]interface{})
 	if err := query.MapScan(result); err != nil {
@@ -1509,6 +1542,62 @@ func (d *cassandraPersistence) GetWorkflowExecution(request *p.GetWorkflowExecut
 		}
 	}
 
+	return &p.InternalGetWorkflowExecutionResponse{State: d.createWorkflowMutableState(request.DomainID, result)}, nil
+}
+
+func (d *cassandraPersistence) GetWorkflowExecutions(ctx context.Context, request *p.GetWorkflowExecutionsRequest) (
+	*p.InternalGetWorkflowExecutionsResponse, error) {
+	response := &p.InternalGetWorkflowExecutionsResponse{}
+	if len(request.Executions) == 0 {
+		return response, nil
+	}
+
+	keys := make([]string, 0, len(request.Executions))
+	args := []interface{}{d.shardID}
+	for _, execution := range request.Executions {
+		keys = append(keys, templateGetWorkflowExecutionsKey)
+		args = append(args,
+			rowTypeExecution,
+			request.DomainID,
+			execution.GetWorkflowId(),
+			execution.GetRunId(),
+			defaultVisibilityTimestamp,
+			rowTypeExecutionTaskID)
+	}
+	query := d.session.Query(fmt.Sprintf(templateGetWorkflowExecutionsQuery, strings.Join(keys, ", ")), args...).
+		WithContext(ctx)
+
+	iter := query.Iter()
+	if iter == nil {
+		return nil, &workflow.InternalServiceError{
+			Message: "GetWorkflowExecutions operation failed.  Not able to create query iterator.",
+		}
+	}
+
+	result := make(map[string]interface{})
+	for iter.MapScan(result) {
+		state := d.createWorkflowMutableState(request.DomainID, result)
+		response.Responses = append(response.Responses, &p.InternalGetWorkflowExecutionResponse{State: state})
+		// Reset result map to get it ready for next scan
+		result = make(map[string]interface{})
+	}
+
+	if err := iter.Close(); err != nil {
+		if isThrottlingError(err) {
+			return nil, &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("GetWorkflowExecutions operation failed. Error: %v", err),
+			}
+		}
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("GetWorkflowExecutions operation failed. Error: %v", err),
+		}
+	}
+
+	return response, nil
+}
+
+func (d *cassandraPersistence) createWorkflowMutableState(domainID string,
+	result map[string]interface{}) *p.InternalWorkflowMutableState {
 	state := &p.InternalWorkflowMutableState{}
 	info := createWorkflowExecutionInfo(result["execution"].(map[string]interface{}))
 	state.ExecutionInfo = info
@@ -1519,7 +1608,7 @@ func (d *cassandraPersistence) GetWorkflowExecution(request *p.GetWorkflowExecut
 	activityInfos := make(map[int64]*p.InternalActivityInfo)
 	aMap := result["activity_map"].(map[int64]map[string]interface{})
 	for key, value := range aMap {
-		info := createActivityInfo(request.DomainID, value)
+		info := createActivityInfo(domainID, value)
 		activityInfos[key] = info
 	}
 	state.ActivitInfos = activityInfos
@@ -1579,7 +1668,11 @@ func (d *cassandraPersistence) GetWorkflowExecution(request *p.GetWorkflowExecut
 	}
 	state.BufferedReplicationTasks = bufferedReplicationTasks
 
-	return &p.InternalGetWorkflowExecutionResponse{State: state}, nil
+	if csum, ok := result["checksum"].(map[string]interface{}); ok {
+		state.Checksum = createChecksum(csum)
+	}
+
+	return state
 }
 
 // this helper is passing dynamic number of arguments based on whether needing condition or not
@@ -1592,7 +1685,7 @@ func batchQueryHelper(batch *gocql.Batch, stmt string, useCondition bool, condit
 }
 
 func (d *cassandraPersistence) updateMutableState(batch *gocql.Batch, executionInfo *p.InternalWorkflowExecutionInfo,
-	replicationState *p.ReplicationState, cqlNowTimestamp int64, useCondition bool, condition int64) {
+	replicationState *p.ReplicationState, csum checksum.Checksum, cqlNowTimestamp int64, useCondition bool, condition int64) {
 	if executionInfo.ParentDomainID == "" {
 		executionInfo.ParentDomainID = emptyDomainID
 	}
@@ -1601,6 +1694,7 @@ func (d *cassandraPersistence) updateMutableState(batch *gocql.Batch, executionI
 	}
 
 	completionData, completionEncoding := p.FromDataBlob(executionInfo.CompletionEvent)
+	versionHistoriesData, versionHistoriesEncoding := p.FromDataBlob(executionInfo.VersionHistories)
 	if replicationState == nil {
 		// Updates will be called with null ReplicationState while the feature is disabled
 		batchQueryHelper(batch, templateUpdateWorkflowExecutionQuery, useCondition, condition,
@@ -1656,6 +1750,12 @@ func (d *cassandraPersistence) updateMutableState(batch *gocql.Batch, executionI
 			executionInfo.BranchToken,
 			executionInfo.CronSchedule,
 			executionInfo.ExpirationSeconds,
+			executionInfo.Labels,
+			versionHistoriesData,
+			versionHistoriesEncoding,
+			csum.Version,
+			int(csum.Flavor),
+			csum.Value,
 			executionInfo.NextEventID,
 			d.shardID,
 			rowTypeExecution,
@@ -1723,11 +1823,17 @@ func (d *cassandraPersistence) updateMutableState(batch *gocql.Batch, executionI
 			executionInfo.BranchToken,
 			executionInfo.CronSchedule,
 			executionInfo.ExpirationSeconds,
+			executionInfo.Labels,
+			versionHistoriesData,
+			versionHistoriesEncoding,
 			replicationState.CurrentVersion,
 			replicationState.StartVersion,
 			replicationState.LastWriteVersion,
 			replicationState.LastWriteEventID,
 			lastReplicationInfo,
+			csum.Version,
+			int(csum.Flavor),
+			csum.Value,
 			executionInfo.NextEventID,
 			d.shardID,
 			rowTypeExecution,
@@ -1739,13 +1845,13 @@ func (d *cassandraPersistence) updateMutableState(batch *gocql.Batch, executionI
 	}
 }
 
-func (d *cassandraPersistence) UpdateWorkflowExecution(request *p.InternalUpdateWorkflowExecutionRequest) error {
-	batch := d.session.NewBatch(gocql.LoggedBatch)
+func (d *cassandraPersistence) UpdateWorkflowExecution(ctx context.Context, request *p.InternalUpdateWorkflowExecutionRequest) error {
+	batch := d.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
 	cqlNowTimestamp := p.UnixNanoToDBTimestamp(time.Now().UnixNano())
 	executionInfo := request.ExecutionInfo
 	replicationState := request.ReplicationState
 
-	d.updateMutableState(batch, executionInfo, replicationState, cqlNowTimestamp, true, request.Condition)
+	d.updateMutableState(batch, executionInfo, replicationState, request.Checksum, cqlNowTimestamp, true, request.Condition)
 
 	d.createTransferTasks(batch, request.TransferTasks, executionInfo.DomainID, executionInfo.WorkflowID,
 		executionInfo.RunID)
@@ -1891,8 +1997,8 @@ func (d *cassandraPersistence) UpdateWorkflowExecution(request *p.InternalUpdate
 	return nil
 }
 
-func (d *cassandraPersistence) ResetWorkflowExecution(request *p.InternalResetWorkflowExecutionRequest) error {
-	batch := d.session.NewBatch(gocql.LoggedBatch)
+func (d *cassandraPersistence) ResetWorkflowExecution(ctx context.Context, request *p.InternalResetWorkflowExecutionRequest) error {
+	batch := d.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
 	cqlNowTimestamp := p.UnixNanoToDBTimestamp(time.Now().UnixNano())
 
 	currExecutionInfo := request.CurrExecutionInfo
@@ -1970,7 +2076,7 @@ func (d *cassandraPersistence) ResetWorkflowExecution(request *p.InternalResetWo
 	}
 
 	if request.UpdateCurr {
-		d.updateMutableState(batch, currExecutionInfo, currReplicationState, cqlNowTimestamp, true, request.Condition)
+		d.updateMutableState(batch, currExecutionInfo, currReplicationState, checksum.Checksum{}, cqlNowTimestamp, true, request.Condition)
 		d.createTimerTasks(batch, request.CurrTimerTasks, nil, currExecutionInfo.DomainID, currExecutionInfo.WorkflowID, currExecutionInfo.RunID, cqlNowTimestamp)
 		d.createTransferTasks(batch, request.CurrTransferTasks, currExecutionInfo.DomainID, currExecutionInfo.WorkflowID, currExecutionInfo.RunID)
 	} else {
@@ -1996,7 +2102,7 @@ func (d *cassandraPersistence) ResetWorkflowExecution(request *p.InternalResetWo
 	}
 
 	// we need to insert new mutableState, there is no condition to check. We use update without condition as insert
-	d.updateMutableState(batch, insertExecutionInfo, insertReplicationState, cqlNowTimestamp, false, 0)
+	d.updateMutableState(batch, insertExecutionInfo, insertReplicationState, checksum.Checksum{}, cqlNowTimestamp, false, 0)
 
 	if len(request.InsertActivityInfos) > 0 {
 		d.resetActivityInfos(batch, request.InsertActivityInfos, insertExecutionInfo.DomainID, insertExecutionInfo.WorkflowID,
@@ -2079,8 +2185,8 @@ func (d *cassandraPersistence) ResetWorkflowExecution(request *p.InternalResetWo
 	return nil
 }
 
-func (d *cassandraPersistence) ResetMutableState(request *p.InternalResetMutableStateRequest) error {
-	batch := d.session.NewBatch(gocql.LoggedBatch)
+func (d *cassandraPersistence) ResetMutableState(ctx context.Context, request *p.InternalResetMutableStateRequest) error {
+	batch := d.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
 	cqlNowTimestamp := p.UnixNanoToDBTimestamp(time.Now().UnixNano())
 	executionInfo := request.ExecutionInfo
 	replicationState := request.ReplicationState
@@ -2105,7 +2211,7 @@ func (d *cassandraPersistence) ResetMutableState(request *p.InternalResetMutable
 		request.PrevRunID,
 	)
 
-	d.updateMutableState(batch, executionInfo, replicationState, cqlNowTimestamp, true, request.Condition)
+	d.updateMutableState(batch, executionInfo, replicationState, request.Checksum, cqlNowTimestamp, true, request.Condition)
 
 	d.resetActivityInfos(batch, request.InsertActivityInfos, executionInfo.DomainID, executionInfo.WorkflowID,
 		executionInfo.RunID, true, request.Condition)
@@ -2255,7 +2361,7 @@ GetFailureReasonLoop:
 	}
 }
 
-func (d *cassandraPersistence) DeleteWorkflowExecution(request *p.DeleteWorkflowExecutionRequest) error {
+func (d *cassandraPersistence) DeleteWorkflowExecution(ctx context.Context, request *p.DeleteWorkflowExecutionRequest) error {
 	query := d.session.Query(templateDeleteWorkflowExecutionMutableStateQuery,
 		d.shardID,
 		rowTypeExecution,
@@ -2263,7 +2369,7 @@ func (d *cassandraPersistence) DeleteWorkflowExecution(request *p.DeleteWorkflow
 		request.WorkflowID,
 		request.RunID,
 		defaultVisibilityTimestamp,
-		rowTypeExecutionTaskID)
+		rowTypeExecutionTaskID).WithContext(ctx)
 
 	err := query.Exec()
 	if err != nil {
@@ -2306,7 +2412,7 @@ func (d *cassandraPersistence) DeleteWorkflowCurrentRow(request *p.DeleteWorkflo
 	return nil
 }
 
-func (d *cassandraPersistence) GetCurrentExecution(request *p.GetCurrentExecutionRequest) (*p.GetCurrentExecutionResponse,
+func (d *cassandraPersistence) GetCurrentExecution(ctx context.Context, request *p.GetCurrentExecutionRequest) (*p.GetCurrentExecutionResponse,
 	error) {
 	query := d.session.Query(templateGetCurrentExecutionQuery,
 		d.shardID,
@@ -2315,7 +2421,7 @@ func (d *cassandraPersistence) GetCurrentExecution(request *p.GetCurrentExecutio
 		request.WorkflowID,
 		permanentRunID,
 		defaultVisibilityTimestamp,
-		rowTypeExecutionTaskID)
+		rowTypeExecutionTaskID).WithContext(ctx)
 
 	result := make(map[string]interface{})
 	if err := query.MapScan(result); err != nil {
@@ -2347,7 +2453,7 @@ func (d *cassandraPersistence) GetCurrentExecution(request *p.GetCurrentExecutio
 	}, nil
 }
 
-func (d *cassandraPersistence) GetTransferTasks(request *p.GetTransferTasksRequest) (*p.GetTransferTasksResponse, error) {
+func (d *cassandraPersistence) GetTransferTasks(ctx context.Context, request *p.GetTransferTasksRequest) (*p.GetTransferTasksResponse, error) {
 
 	// Reading transfer tasks need to be quorum level consistent, otherwise we could loose task
 	query := d.session.Query(templateGetTransferTasksQuery,
@@ -2359,7 +2465,7 @@ func (d *cassandraPersistence) GetTransferTasks(request *p.GetTransferTasksReque
 		defaultVisibilityTimestamp,
 		request.ReadLevel,
 		request.MaxReadLevel,
-	).PageSize(request.BatchSize).PageState(request.NextPageToken)
+	).WithContext(ctx).PageSize(request.BatchSize).PageState(request.NextPageToken)
 
 	iter := query.Iter()
 	if iter == nil {
@@ -2390,7 +2496,7 @@ func (d *cassandraPersistence) GetTransferTasks(request *p.GetTransferTasksReque
 	return response, nil
 }
 
-func (d *cassandraPersistence) GetReplicationTasks(request *p.GetReplicationTasksRequest) (*p.GetReplicationTasksResponse,
+func (d *cassandraPersistence) GetReplicationTasks(ctx context.Context, request *p.GetReplicationTasksRequest) (*p.GetReplicationTasksResponse,
 	error) {
 
 	// Reading replication tasks need to be quorum level consistent, otherwise we could loose task
@@ -2403,7 +2509,7 @@ func (d *cassandraPersistence) GetReplicationTasks(request *p.GetReplicationTask
 		defaultVisibilityTimestamp,
 		request.ReadLevel,
 		request.MaxReadLevel,
-	).PageSize(request.BatchSize).PageState(request.NextPageToken)
+	).WithContext(ctx).PageSize(request.BatchSize).PageState(request.NextPageToken)
 
 	iter := query.Iter()
 	if iter == nil {
@@ -2434,7 +2540,7 @@ func (d *cassandraPersistence) GetReplicationTasks(request *p.GetReplicationTask
 	return response, nil
 }
 
-func (d *cassandraPersistence) CompleteTransferTask(request *p.CompleteTransferTaskRequest) error {
+func (d *cassandraPersistence) CompleteTransferTask(ctx context.Context, request *p.CompleteTransferTaskRequest) error {
 	query := d.session.Query(templateCompleteTransferTaskQuery,
 		d.shardID,
 		rowTypeTransferTask,
@@ -2442,7 +2548,7 @@ func (d *cassandraPersistence) CompleteTransferTask(request *p.CompleteTransferT
 		rowTypeTransferWorkflowID,
 		rowTypeTransferRunID,
 		defaultVisibilityTimestamp,
-		request.TaskID)
+		request.TaskID).WithContext(ctx)
 
 	err := query.Exec()
 	if err != nil {
@@ -2459,7 +2565,7 @@ func (d *cassandraPersistence) CompleteTransferTask(request *p.CompleteTransferT
 	return nil
 }
 
-func (d *cassandraPersistence) RangeCompleteTransferTask(request *p.RangeCompleteTransferTaskRequest) error {
+func (d *cassandraPersistence) RangeCompleteTransferTask(ctx context.Context, request *p.RangeCompleteTransferTaskRequest) error {
 	query := d.session.Query(templateRangeCompleteTransferTaskQuery,
 		d.shardID,
 		rowTypeTransferTask,
@@ -2469,7 +2575,7 @@ func (d *cassandraPersistence) RangeCompleteTransferTask(request *p.RangeComplet
 		defaultVisibilityTimestamp,
 		request.ExclusiveBeginTaskID,
 		request.InclusiveEndTaskID,
-	)
+	).WithContext(ctx)
 
 	err := query.Exec()
 	if err != nil {
@@ -2486,7 +2592,7 @@ func (d *cassandraPersistence) RangeCompleteTransferTask(request *p.RangeComplet
 	return nil
 }
 
-func (d *cassandraPersistence) CompleteReplicationTask(request *p.CompleteReplicationTaskRequest) error {
+func (d *cassandraPersistence) CompleteReplicationTask(ctx context.Context, request *p.CompleteReplicationTaskRequest) error {
 	query := d.session.Query(templateCompleteTransferTaskQuery,
 		d.shardID,
 		rowTypeReplicationTask,
@@ -2494,7 +2600,7 @@ func (d *cassandraPersistence) CompleteReplicationTask(request *p.CompleteReplic
 		rowTypeReplicationWorkflowID,
 		rowTypeReplicationRunID,
 		defaultVisibilityTimestamp,
-		request.TaskID)
+		request.TaskID).WithContext(ctx)
 
 	err := query.Exec()
 	if err != nil {
@@ -2511,7 +2617,7 @@ func (d *cassandraPersistence) CompleteReplicationTask(request *p.CompleteReplic
 	return nil
 }
 
-func (d *cassandraPersistence) CompleteTimerTask(request *p.CompleteTimerTaskRequest) error {
+func (d *cassandraPersistence) CompleteTimerTask(ctx context.Context, request *p.CompleteTimerTaskRequest) error {
 	ts := p.UnixNanoToDBTimestamp(request.VisibilityTimestamp.UnixNano())
 	query := d.session.Query(templateCompleteTimerTaskQuery,
 		d.shardID,
@@ -2520,7 +2626,7 @@ func (d *cassandraPersistence) CompleteTimerTask(request *p.CompleteTimerTaskReq
 		rowTypeTimerWorkflowID,
 		rowTypeTimerRunID,
 		ts,
-		request.TaskID)
+		request.TaskID).WithContext(ctx)
 
 	err := query.Exec()
 	if err != nil {
@@ -2537,7 +2643,7 @@ func (d *cassandraPersistence) CompleteTimerTask(request *p.CompleteTimerTaskReq
 	return nil
 }
 
-func (d *cassandraPersistence) RangeCompleteTimerTask(request *p.RangeCompleteTimerTaskRequest) error {
+func (d *cassandraPersistence) RangeCompleteTimerTask(ctx context.Context, request *p.RangeCompleteTimerTaskRequest) error {
 	start := p.UnixNanoToDBTimestamp(request.InclusiveBeginTimestamp.UnixNano())
 	end := p.UnixNanoToDBTimestamp(request.ExclusiveEndTimestamp.UnixNano())
 	query := d.session.Query(templateRangeCompleteTimerTaskQuery,
@@ -2548,7 +2654,7 @@ func (d *cassandraPersistence) RangeCompleteTimerTask(request *p.RangeCompleteTi
 		rowTypeTimerRunID,
 		start,
 		end,
-	)
+	).WithContext(ctx)
 
 	err := query.Exec()
 	if err != nil {
@@ -2952,7 +3058,7 @@ func (d *cassandraPersistence) CompleteTasksLessThan(request *p.CompleteTasksLes
 	return p.UnknownNumRowsAffected, nil
 }
 
-func (d *cassandraPersistence) GetTimerIndexTasks(request *p.GetTimerIndexTasksRequest) (*p.GetTimerIndexTasksResponse,
+func (d *cassandraPersistence) GetTimerIndexTasks(ctx context.Context, request *p.GetTimerIndexTasksRequest) (*p.GetTimerIndexTasksResponse,
 	error) {
 	// Reading timer tasks need to be quorum level consistent, otherwise we could loose task
 	minTimestamp := p.UnixNanoToDBTimestamp(request.MinTimestamp.UnixNano())
@@ -2965,7 +3071,7 @@ func (d *cassandraPersistence) GetTimerIndexTasks(request *p.GetTimerIndexTasksR
 		rowTypeTimerRunID,
 		minTimestamp,
 		maxTimestamp,
-	).PageSize(request.BatchSize).PageState(request.NextPageToken)
+	).WithContext(ctx).PageSize(request.BatchSize).PageState(request.NextPageToken)
 
 	iter := query.Iter()
 	if iter == nil {
@@ -3011,6 +3117,7 @@ func (d *cassandraPersistence) createTransferTasks(batch *gocql.Batch, transferT
 		targetRunID := p.TransferTaskTransferTargetRunID
 		targetChildWorkflowOnly := false
 		recordVisibility := false
+		var requestID, callerIdentity string
 
 		switch task.GetType() {
 		case p.TransferTaskTypeActivityTask:
@@ -3050,10 +3157,16 @@ func (d *cassandraPersistence) createTransferTasks(batch *gocql.Batch, transferT
 			scheduleID = task.(*p.StartChildExecutionTask).InitiatedID
 
 		case p.TransferTaskTypeCloseExecution:
-			// No explicit property needs to be set
+			requestID = task.(*p.CloseExecutionTask).RequestID
+			callerIdentity = task.(*p.CloseExecutionTask).CallerIdentity
 
 		case p.TransferTaskTypeRecordWorkflowStarted:
-			// No explicit property needs to be set
+			requestID = task.(*p.RecordWorkflowStartedTask).RequestID
+			callerIdentity = task.(*p.RecordWorkflowStartedTask).CallerIdentity
+
+		case p.TransferTaskTypeUpsertWorkflowLabels:
+			requestID = task.(*p.UpsertWorkflowLabelsTask).RequestID
+			callerIdentity = task.(*p.UpsertWorkflowLabelsTask).CallerIdentity
 
 		default:
 			d.logger.Fatal("Unknown Transfer Task.")
@@ -3079,6 +3192,8 @@ func (d *cassandraPersistence) createTransferTasks(batch *gocql.Batch, transferT
 			scheduleID,
 			recordVisibility,
 			task.GetVersion(),
+			requestID,
+			callerIdentity,
 			defaultVisibilityTimestamp,
 			task.GetTaskID())
 	}
@@ -3154,6 +3269,8 @@ func (d *cassandraPersistence) createTimerTasks(batch *gocql.Batch, timerTasks [
 	for _, task := range timerTasks {
 		var eventID int64
 		var attempt int64
+		var signalName, signalIdentity, signalRequestID string
+		var signalInput []byte
 
 		timeoutType := 0
 
@@ -3174,6 +3291,11 @@ func (d *cassandraPersistence) createTimerTasks(batch *gocql.Batch, timerTasks [
 		case *p.WorkflowBackoffTimerTask:
 			eventID = t.EventID
 			timeoutType = t.TimeoutType
+		case *p.DelayedSignalTask:
+			signalName = t.SignalName
+			signalInput = t.Input
+			signalIdentity = t.Identity
+			signalRequestID = t.RequestID
 		}
 
 		// Ignoring possible type cast errors.
@@ -3195,6 +3317,10 @@ func (d *cassandraPersistence) createTimerTasks(batch *gocql.Batch, timerTasks [
 			eventID,
 			attempt,
 			task.GetVersion(),
+			signalName,
+			signalInput,
+			signalIdentity,
+			signalRequestID,
 			ts,
 			task.GetTaskID())
 	}
@@ -3259,6 +3385,7 @@ func (d *cassandraPersistence) updateActivityInfos(batch *gocql.Batch, activityI
 			a.ExpirationTime,
 			a.MaximumAttempts,
 			a.NonRetriableErrors,
+			a.LastFailureReason,
 			encoding,
 			d.shardID,
 			rowTypeExecution,
@@ -3733,6 +3860,8 @@ func createWorkflowExecutionInfo(result map[string]interface{}) *p.InternalWorkf
 	info := &p.InternalWorkflowExecutionInfo{}
 	var completionEventData []byte
 	var completionEventEncoding common.EncodingType
+	var versionHistoriesData []byte
+	var versionHistoriesEncoding common.EncodingType
 	for k, v := range result {
 		switch k {
 		case "domain_id":
@@ -3839,9 +3968,16 @@ func createWorkflowExecutionInfo(result map[string]interface{}) *p.InternalWorkf
 			info.CronSchedule = v.(string)
 		case "expiration_seconds":
 			info.ExpirationSeconds = int32(v.(int))
+		case "labels":
+			info.Labels = v.(map[string]string)
+		case "version_histories":
+			versionHistoriesData = v.([]byte)
+		case "version_histories_encoding":
+			versionHistoriesEncoding = common.EncodingType(v.(string))
 		}
 	}
 	info.CompletionEvent = p.NewDataBlob(completionEventData, completionEventEncoding)
+	info.VersionHistories = p.NewDataBlob(versionHistoriesData, versionHistoriesEncoding)
 	return info
 }
 
@@ -3873,6 +4009,21 @@ func createReplicationState(result map[string]interface{}) *p.ReplicationState {
 	return info
 }
 
+func createChecksum(result map[string]interface{}) checksum.Checksum {
+	csum := checksum.Checksum{}
+	for k, v := range result {
+		switch k {
+		case "version":
+			csum.Version = v.(int)
+		case "flavor":
+			csum.Flavor = checksum.Flavor(v.(int))
+		case "value":
+			csum.Value = v.([]byte)
+		}
+	}
+	return csum
+}
+
 func createTransferTaskInfo(result map[string]interface{}) *p.TransferTaskInfo {
 	info := &p.TransferTaskInfo{}
 	for k, v := range result {
@@ -3908,6 +4059,10 @@ func createTransferTaskInfo(result map[string]interface{}) *p.TransferTaskInfo {
 			info.RecordVisibility = v.(bool)
 		case "version":
 			info.Version = v.(int64)
+		case "request_id":
+			info.RequestID = v.(string)
+		case "caller_identity":
+			info.CallerIdentity = v.(string)
 		}
 	}
 
@@ -4022,6 +4177,8 @@ func createActivityInfo(domainID string, result map[string]interface{}) *p.Inter
 			info.ExpirationTime = v.(time.Time)
 		case "non_retriable_errors":
 			info.NonRetriableErrors = v.([]string)
+		case "last_failure_reason":
+			info.LastFailureReason = v.(string)
 		case "event_data_encoding":
 			sharedEncoding = common.EncodingType(v.(string))
 		}
@@ -4197,6 +4354,7 @@ func resetActivityInfoMap(activityInfos []*p.InternalActivityInfo) (map[int64]ma
 		aInfo["expiration_time"] = a.ExpirationTime
 		aInfo["max_attempts"] = a.MaximumAttempts
 		aInfo["non_retriable_errors"] = a.NonRetriableErrors
+		aInfo["last_failure_reason"] = a.LastFailureReason
 
 		aMap[a.ScheduleID] = aInfo
 	}
@@ -4342,6 +4500,14 @@ func createTimerTaskInfo(result map[string]interface{}) *p.TimerTaskInfo {
 			info.ScheduleAttempt = v.(int64)
 		case "version":
 			info.Version = v.(int64)
+		case "signal_name":
+			info.SignalName = v.(string)
+		case "signal_input":
+			info.SignalInput = v.([]byte)
+		case "signal_identity":
+			info.SignalIdentity = v.(string)
+		case "signal_request_id":
+			info.SignalRequestID = v.(string)
 		}
 	}
 
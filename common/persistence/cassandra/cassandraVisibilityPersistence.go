@@ -21,6 +21,7 @@
 package cassandra
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -43,12 +44,9 @@ const (
 
 const (
 	templateCreateWorkflowExecutionStartedWithTTL = `INSERT INTO open_executions (` +
-		`domain_id, domain_partition, workflow_id, run_id, start_time, execution_time, workflow_type_name) ` +
-		`VALUES (?, ?, ?, ?, ?, ?, ?) using TTL ?`
-
-	templateCreateWorkflowExecutionStarted = `INSERT INTO open_executions (` +
-		`domain_id, domain_partition, workflow_id, run_id, start_time, execution_time, workflow_type_name) ` +
-		`VALUES (?, ?, ?, ?, ?, ?, ?)`
+		`domain_id, domain_partition, workflow_id, run_id, start_time, execution_time, workflow_type_name, ` +
+		`task_list, parent_workflow_id, parent_run_id) ` +
+		`VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?) using TTL ?`
 
 	templateDeleteWorkflowExecutionStarted = `DELETE FROM open_executions ` +
 		`WHERE domain_id = ? ` +
@@ -57,36 +55,36 @@ const (
 		`AND run_id = ?`
 
 	templateCreateWorkflowExecutionClosedWithTTL = `INSERT INTO closed_executions (` +
-		`domain_id, domain_partition, workflow_id, run_id, start_time, execution_time, close_time, workflow_type_name, status, history_length) ` +
-		`VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?) using TTL ?`
-
-	templateCreateWorkflowExecutionClosed = `INSERT INTO closed_executions (` +
-		`domain_id, domain_partition, workflow_id, run_id, start_time, execution_time, close_time, workflow_type_name, status, history_length) ` +
-		`VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+		`domain_id, domain_partition, workflow_id, run_id, start_time, execution_time, close_time, workflow_type_name, status, history_length, ` +
+		`task_list, parent_workflow_id, parent_run_id, ` +
+		`terminate_cause, terminate_reason, terminate_details, terminate_identity) ` +
+		`VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) using TTL ?`
 
 	templateCreateWorkflowExecutionClosedWithTTLV2 = `INSERT INTO closed_executions_v2 (` +
-		`domain_id, domain_partition, workflow_id, run_id, start_time, execution_time, close_time, workflow_type_name, status, history_length) ` +
-		`VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?) using TTL ?`
-
-	templateCreateWorkflowExecutionClosedV2 = `INSERT INTO closed_executions_v2 (` +
-		`domain_id, domain_partition, workflow_id, run_id, start_time, execution_time, close_time, workflow_type_name, status, history_length) ` +
-		`VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+		`domain_id, domain_partition, workflow_id, run_id, start_time, execution_time, close_time, workflow_type_name, status, history_length, ` +
+		`task_list, parent_workflow_id, parent_run_id, ` +
+		`terminate_cause, terminate_reason, terminate_details, terminate_identity) ` +
+		`VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) using TTL ?`
 
-	templateGetOpenWorkflowExecutions = `SELECT workflow_id, run_id, start_time, execution_time, workflow_type_name ` +
+	templateGetOpenWorkflowExecutions = `SELECT workflow_id, run_id, start_time, execution_time, workflow_type_name, ` +
+		`task_list, parent_workflow_id, parent_run_id ` +
 		`FROM open_executions ` +
 		`WHERE domain_id = ? ` +
 		`AND domain_partition IN (?) ` +
 		`AND start_time >= ? ` +
 		`AND start_time <= ? `
 
-	templateGetClosedWorkflowExecutions = `SELECT workflow_id, run_id, start_time, execution_time, close_time, workflow_type_name, status, history_length ` +
+	templateGetClosedWorkflowExecutions = `SELECT workflow_id, run_id, start_time, execution_time, close_time, workflow_type_name, status, history_length, ` +
+		`task_list, parent_workflow_id, parent_run_id, ` +
+		`terminate_cause, terminate_reason, terminate_details, terminate_identity ` +
 		`FROM closed_executions ` +
 		`WHERE domain_id = ? ` +
 		`AND domain_partition IN (?) ` +
 		`AND start_time >= ? ` +
 		`AND start_time <= ? `
 
-	templateGetOpenWorkflowExecutionsByType = `SELECT workflow_id, run_id, start_time, execution_time, workflow_type_name ` +
+	templateGetOpenWorkflowExecutionsByType = `SELECT workflow_id, run_id, start_time, execution_time, workflow_type_name, ` +
+		`task_list, parent_workflow_id, parent_run_id ` +
 		`FROM open_executions ` +
 		`WHERE domain_id = ? ` +
 		`AND domain_partition = ? ` +
@@ -94,7 +92,9 @@ const (
 		`AND start_time <= ? ` +
 		`AND workflow_type_name = ? `
 
-	templateGetClosedWorkflowExecutionsByType = `SELECT workflow_id, run_id, start_time, execution_time, close_time, workflow_type_name, status, history_length ` +
+	templateGetClosedWorkflowExecutionsByType = `SELECT workflow_id, run_id, start_time, execution_time, close_time, workflow_type_name, status, history_length, ` +
+		`task_list, parent_workflow_id, parent_run_id, ` +
+		`terminate_cause, terminate_reason, terminate_details, terminate_identity ` +
 		`FROM closed_executions ` +
 		`WHERE domain_id = ? ` +
 		`AND domain_partition = ? ` +
@@ -102,7 +102,8 @@ const (
 		`AND start_time <= ? ` +
 		`AND workflow_type_name = ? `
 
-	templateGetOpenWorkflowExecutionsByID = `SELECT workflow_id, run_id, start_time, execution_time, workflow_type_name ` +
+	templateGetOpenWorkflowExecutionsByID = `SELECT workflow_id, run_id, start_time, execution_time, workflow_type_name, ` +
+		`task_list, parent_workflow_id, parent_run_id ` +
 		`FROM open_executions ` +
 		`WHERE domain_id = ? ` +
 		`AND domain_partition = ? ` +
@@ -110,7 +111,9 @@ const (
 		`AND start_time <= ? ` +
 		`AND workflow_id = ? `
 
-	templateGetClosedWorkflowExecutionsByID = `SELECT workflow_id, run_id, start_time, execution_time, close_time, workflow_type_name, status, history_length ` +
+	templateGetClosedWorkflowExecutionsByID = `SELECT workflow_id, run_id, start_time, execution_time, close_time, workflow_type_name, status, history_length, ` +
+		`task_list, parent_workflow_id, parent_run_id, ` +
+		`terminate_cause, terminate_reason, terminate_details, terminate_identity ` +
 		`FROM closed_executions ` +
 		`WHERE domain_id = ? ` +
 		`AND domain_partition = ? ` +
@@ -118,7 +121,9 @@ const (
 		`AND start_time <= ? ` +
 		`AND workflow_id = ? `
 
-	templateGetClosedWorkflowExecutionsByStatus = `SELECT workflow_id, run_id, start_time, execution_time, close_time, workflow_type_name, status, history_length ` +
+	templateGetClosedWorkflowExecutionsByStatus = `SELECT workflow_id, run_id, start_time, execution_time, close_time, workflow_type_name, status, history_length, ` +
+		`task_list, parent_workflow_id, parent_run_id, ` +
+		`terminate_cause, terminate_reason, terminate_details, terminate_identity ` +
 		`FROM closed_executions ` +
 		`WHERE domain_id = ? ` +
 		`AND domain_partition = ? ` +
@@ -126,7 +131,9 @@ const (
 		`AND start_time <= ? ` +
 		`AND status = ? `
 
-	templateGetClosedWorkflowExecution = `SELECT workflow_id, run_id, start_time, execution_time, close_time, workflow_type_name, status, history_length ` +
+	templateGetClosedWorkflowExecution = `SELECT workflow_id, run_id, start_time, execution_time, close_time, workflow_type_name, status, history_length, ` +
+		`task_list, parent_workflow_id, parent_run_id, ` +
+		`terminate_cause, terminate_reason, terminate_details, terminate_identity ` +
 		`FROM closed_executions ` +
 		`WHERE domain_id = ? ` +
 		`AND domain_partition = ? ` +
@@ -134,6 +141,12 @@ const (
 		`AND run_id = ? ALLOW FILTERING `
 )
 
+// errVisibilitySortNotSupported is returned for list queries asking for an ordering other than the
+// clustering order of the visibility tables, which is newest first by start time or close time
+var errVisibilitySortNotSupported = &workflow.BadRequestError{
+	Message: "cassandra visibility store only supports listing workflow executions in the default order",
+}
+
 type (
 	cassandraVisibilityPersistence struct {
 		cassandraStore
@@ -148,7 +161,7 @@ func newVisibilityPersistence(cfg config.Cassandra, logger bark.Logger) (p.Visib
 	cluster.ProtoVersion = cassandraProtoVersion
 	cluster.Consistency = gocql.LocalQuorum
 	cluster.SerialConsistency = gocql.LocalSerial
-	cluster.Timeout = defaultSessionTimeout
+	cluster.Timeout = sessionTimeout(cfg)
 
 	session, err := cluster.CreateSession()
 	if err != nil {
@@ -168,51 +181,33 @@ func (v *cassandraVisibilityPersistence) Close() {
 	}
 }
 
-func (v *cassandraVisibilityPersistence) RecordWorkflowExecutionStarted(
+func (v *cassandraVisibilityPersistence) RecordWorkflowExecutionStarted(ctx context.Context,
 	request *p.RecordWorkflowExecutionStartedRequest) error {
-	ttl := request.WorkflowTimeout + openExecutionTTLBuffer
-	var query *gocql.Query
-	if ttl > maxCassandraTTL {
-		query = v.session.Query(templateCreateWorkflowExecutionStarted,
-			request.DomainUUID,
-			domainPartition,
-			*request.Execution.WorkflowId,
-			*request.Execution.RunId,
-			p.UnixNanoToDBTimestamp(request.StartTimestamp),
-			p.UnixNanoToDBTimestamp(request.ExecutionTimestamp),
-			request.WorkflowTypeName,
-		)
-	} else {
-		query = v.session.Query(templateCreateWorkflowExecutionStartedWithTTL,
-			request.DomainUUID,
-			domainPartition,
-			*request.Execution.WorkflowId,
-			*request.Execution.RunId,
-			p.UnixNanoToDBTimestamp(request.StartTimestamp),
-			p.UnixNanoToDBTimestamp(request.ExecutionTimestamp),
-			request.WorkflowTypeName,
-			ttl,
-		)
-	}
+	query := v.session.Query(templateCreateWorkflowExecutionStartedWithTTL,
+		request.DomainUUID,
+		domainPartition,
+		*request.Execution.WorkflowId,
+		*request.Execution.RunId,
+		p.UnixNanoToDBTimestamp(request.StartTimestamp),
+		p.UnixNanoToDBTimestamp(request.GetExecutionTimestamp()),
+		request.WorkflowTypeName,
+		request.TaskList,
+		request.ParentWorkflowID,
+		request.ParentRunID,
+		getOpenExecutionTTL(request.WorkflowTimeout, request.RetentionSeconds),
+	).WithContext(ctx)
 	query = query.WithTimestamp(p.UnixNanoToDBTimestamp(request.StartTimestamp))
 	err := query.Exec()
 	if err != nil {
-		if isThrottlingError(err) {
-			return &workflow.ServiceBusyError{
-				Message: fmt.Sprintf("RecordWorkflowExecutionStarted operation failed. Error: %v", err),
-			}
-		}
-		return &workflow.InternalServiceError{
-			Message: fmt.Sprintf("RecordWorkflowExecutionStarted operation failed. Error: %v", err),
-		}
+		return convertVisibilityError("RecordWorkflowExecutionStarted", err)
 	}
 
 	return nil
 }
 
-func (v *cassandraVisibilityPersistence) RecordWorkflowExecutionClosed(
+func (v *cassandraVisibilityPersistence) RecordWorkflowExecutionClosed(ctx context.Context,
 	request *p.RecordWorkflowExecutionClosedRequest) error {
-	batch := v.session.NewBatch(gocql.LoggedBatch)
+	batch := v.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
 
 	// First, remove execution from the open table
 	batch.Query(templateDeleteWorkflowExecutionStarted,
@@ -225,67 +220,56 @@ func (v *cassandraVisibilityPersistence) RecordWorkflowExecutionClosed(
 	// Next, add a row in the closed table.
 
 	// Find how long to keep the row
-	retention := request.RetentionSeconds
-	if retention == 0 {
-		retention = defaultCloseTTLSeconds
-	}
-
-	if retention > maxCassandraTTL {
-		batch.Query(templateCreateWorkflowExecutionClosed,
-			request.DomainUUID,
-			domainPartition,
-			*request.Execution.WorkflowId,
-			*request.Execution.RunId,
-			p.UnixNanoToDBTimestamp(request.StartTimestamp),
-			p.UnixNanoToDBTimestamp(request.ExecutionTimestamp),
-			p.UnixNanoToDBTimestamp(request.CloseTimestamp),
-			request.WorkflowTypeName,
-			request.Status,
-			request.HistoryLength,
-		)
-		// duplicate write to v2 to order by close time
-		batch.Query(templateCreateWorkflowExecutionClosedV2,
-			request.DomainUUID,
-			domainPartition,
-			*request.Execution.WorkflowId,
-			*request.Execution.RunId,
-			p.UnixNanoToDBTimestamp(request.StartTimestamp),
-			p.UnixNanoToDBTimestamp(request.ExecutionTimestamp),
-			p.UnixNanoToDBTimestamp(request.CloseTimestamp),
-			request.WorkflowTypeName,
-			request.Status,
-			request.HistoryLength,
-		)
-	} else {
-		batch.Query(templateCreateWorkflowExecutionClosedWithTTL,
-			request.DomainUUID,
-			domainPartition,
-			*request.Execution.WorkflowId,
-			*request.Execution.RunId,
-			p.UnixNanoToDBTimestamp(request.StartTimestamp),
-			p.UnixNanoToDBTimestamp(request.ExecutionTimestamp),
-			p.UnixNanoToDBTimestamp(request.CloseTimestamp),
-			request.WorkflowTypeName,
-			request.Status,
-			request.HistoryLength,
-			retention,
-		)
-		// duplicate write to v2 to order by close time
-		batch.Query(templateCreateWorkflowExecutionClosedWithTTLV2,
-			request.DomainUUID,
-			domainPartition,
-			*request.Execution.WorkflowId,
-			*request.Execution.RunId,
-			p.UnixNanoToDBTimestamp(request.StartTimestamp),
-			p.UnixNanoToDBTimestamp(request.ExecutionTimestamp),
-			p.UnixNanoToDBTimestamp(request.CloseTimestamp),
-			request.WorkflowTypeName,
-			request.Status,
-			request.HistoryLength,
-			retention,
-		)
+	retention := getClosedExecutionTTL(request.RetentionSeconds)
+
+	terminationInfo := request.TerminationInfo
+	var terminateCause *int32
+	if terminationInfo != nil {
+		terminateCause = common.Int32Ptr(int32(terminationInfo.GetCause()))
 	}
 
+	batch.Query(templateCreateWorkflowExecutionClosedWithTTL,
+		request.DomainUUID,
+		domainPartition,
+		*request.Execution.WorkflowId,
+		*request.Execution.RunId,
+		p.UnixNanoToDBTimestamp(request.StartTimestamp),
+		p.UnixNanoToDBTimestamp(request.GetExecutionTimestamp()),
+		p.UnixNanoToDBTimestamp(request.CloseTimestamp),
+		request.WorkflowTypeName,
+		request.Status,
+		request.HistoryLength,
+		request.TaskList,
+		request.ParentWorkflowID,
+		request.ParentRunID,
+		terminateCause,
+		terminationInfo.GetReason(),
+		terminationInfo.GetDetails(),
+		terminationInfo.GetIdentity(),
+		retention,
+	)
+	// duplicate write to v2 to order by close time
+	batch.Query(templateCreateWorkflowExecutionClosedWithTTLV2,
+		request.DomainUUID,
+		domainPartition,
+		*request.Execution.WorkflowId,
+		*request.Execution.RunId,
+		p.UnixNanoToDBTimestamp(request.StartTimestamp),
+		p.UnixNanoToDBTimestamp(request.GetExecutionTimestamp()),
+		p.UnixNanoToDBTimestamp(request.CloseTimestamp),
+		request.WorkflowTypeName,
+		request.Status,
+		request.HistoryLength,
+		request.TaskList,
+		request.ParentWorkflowID,
+		request.ParentRunID,
+		terminateCause,
+		terminationInfo.GetReason(),
+		terminationInfo.GetDetails(),
+		terminationInfo.GetIdentity(),
+		retention,
+	)
+
 	// RecordWorkflowExecutionStarted is using StartTimestamp as
 	// the timestamp to issue query to Cassandra
 	// due to the fact that cross DC using mutable state creation time as workflow start time
@@ -299,28 +283,30 @@ func (v *cassandraVisibilityPersistence) RecordWorkflowExecutionClosed(
 	batch = batch.WithTimestamp(p.UnixNanoToDBTimestamp(queryTimeStamp))
 	err := v.session.ExecuteBatch(batch)
 	if err != nil {
-		if isThrottlingError(err) {
-			return &workflow.ServiceBusyError{
-				Message: fmt.Sprintf("RecordWorkflowExecutionClosed operation failed. Error: %v", err),
-			}
-		}
-		return &workflow.InternalServiceError{
-			Message: fmt.Sprintf("RecordWorkflowExecutionClosed operation failed. Error: %v", err),
-		}
+		return convertVisibilityError("RecordWorkflowExecutionClosed", err)
 	}
 	return nil
 }
 
-func (v *cassandraVisibilityPersistence) ListOpenWorkflowExecutions(
+func (v *cassandraVisibilityPersistence) ListOpenWorkflowExecutions(ctx context.Context,
 	request *p.ListWorkflowExecutionsRequest) (*p.ListWorkflowExecutionsResponse, error) {
+	pageToken, err := p.DeserializeVisibilityPageToken(p.VisibilityStoreCassandra, request.NextPageToken)
+	if err != nil {
+		return nil, err
+	}
+	if !request.IsDefaultSort() {
+		return nil, errVisibilitySortNotSupported
+	}
+	if request.HasLabelFilter() {
+		return nil, p.ErrVisibilityLabelFilterNotSupported
+	}
 	query := v.session.Query(templateGetOpenWorkflowExecutions,
 		request.DomainUUID,
 		domainPartition,
 		p.UnixNanoToDBTimestamp(request.EarliestStartTime),
-		p.UnixNanoToDBTimestamp(request.LatestStartTime)).Consistency(v.lowConslevel)
-	iter := query.PageSize(request.PageSize).PageState(request.NextPageToken).Iter()
+		p.UnixNanoToDBTimestamp(request.LatestStartTime)).WithContext(ctx).Consistency(v.lowConslevel)
+	iter := query.PageSize(request.PageSize).PageState(pageToken).Iter()
 	if iter == nil {
-		// TODO: should return a bad request error if the token is invalid
 		return nil, &workflow.InternalServiceError{
 			Message: "ListOpenWorkflowExecutions operation failed.  Not able to create query iterator.",
 		}
@@ -334,33 +320,33 @@ func (v *cassandraVisibilityPersistence) ListOpenWorkflowExecutions(
 		wfexecution, has = readOpenWorkflowExecutionRecord(iter)
 	}
 
-	nextPageToken := iter.PageState()
-	response.NextPageToken = make([]byte, len(nextPageToken))
-	copy(response.NextPageToken, nextPageToken)
+	response.NextPageToken = p.SerializeVisibilityPageToken(p.VisibilityStoreCassandra, iter.PageState())
 	if err := iter.Close(); err != nil {
-		if isThrottlingError(err) {
-			return nil, &workflow.ServiceBusyError{
-				Message: fmt.Sprintf("ListOpenWorkflowExecutions operation failed. Error: %v", err),
-			}
-		}
-		return nil, &workflow.InternalServiceError{
-			Message: fmt.Sprintf("ListOpenWorkflowExecutions operation failed. Error: %v", err),
-		}
+		return nil, convertVisibilityError("ListOpenWorkflowExecutions", err)
 	}
 
 	return response, nil
 }
 
-func (v *cassandraVisibilityPersistence) ListClosedWorkflowExecutions(
+func (v *cassandraVisibilityPersistence) ListClosedWorkflowExecutions(ctx context.Context,
 	request *p.ListWorkflowExecutionsRequest) (*p.ListWorkflowExecutionsResponse, error) {
+	pageToken, err := p.DeserializeVisibilityPageToken(p.VisibilityStoreCassandra, request.NextPageToken)
+	if err != nil {
+		return nil, err
+	}
+	if !request.IsDefaultSort() {
+		return nil, errVisibilitySortNotSupported
+	}
+	if request.HasLabelFilter() {
+		return nil, p.ErrVisibilityLabelFilterNotSupported
+	}
 	query := v.session.Query(templateGetClosedWorkflowExecutions,
 		request.DomainUUID,
 		domainPartition,
 		p.UnixNanoToDBTimestamp(request.EarliestStartTime),
-		p.UnixNanoToDBTimestamp(request.LatestStartTime)).Consistency(v.lowConslevel)
-	iter := query.PageSize(request.PageSize).PageState(request.NextPageToken).Iter()
+		p.UnixNanoToDBTimestamp(request.LatestStartTime)).WithContext(ctx).Consistency(v.lowConslevel)
+	iter := query.PageSize(request.PageSize).PageState(pageToken).Iter()
 	if iter == nil {
-		// TODO: should return a bad request error if the token is invalid
 		return nil, &workflow.InternalServiceError{
 			Message: "ListClosedWorkflowExecutions operation failed.  Not able to create query iterator.",
 		}
@@ -374,34 +360,34 @@ func (v *cassandraVisibilityPersistence) ListClosedWorkflowExecutions(
 		wfexecution, has = readClosedWorkflowExecutionRecord(iter)
 	}
 
-	nextPageToken := iter.PageState()
-	response.NextPageToken = make([]byte, len(nextPageToken))
-	copy(response.NextPageToken, nextPageToken)
+	response.NextPageToken = p.SerializeVisibilityPageToken(p.VisibilityStoreCassandra, iter.PageState())
 	if err := iter.Close(); err != nil {
-		if isThrottlingError(err) {
-			return nil, &workflow.ServiceBusyError{
-				Message: fmt.Sprintf("ListClosedWorkflowExecutions operation failed. Error: %v", err),
-			}
-		}
-		return nil, &workflow.InternalServiceError{
-			Message: fmt.Sprintf("ListClosedWorkflowExecutions operation failed. Error: %v", err),
-		}
+		return nil, convertVisibilityError("ListClosedWorkflowExecutions", err)
 	}
 
 	return response, nil
 }
 
-func (v *cassandraVisibilityPersistence) ListOpenWorkflowExecutionsByType(
+func (v *cassandraVisibilityPersistence) ListOpenWorkflowExecutionsByType(ctx context.Context,
 	request *p.ListWorkflowExecutionsByTypeRequest) (*p.ListWorkflowExecutionsResponse, error) {
+	pageToken, err := p.DeserializeVisibilityPageToken(p.VisibilityStoreCassandra, request.NextPageToken)
+	if err != nil {
+		return nil, err
+	}
+	if !request.IsDefaultSort() {
+		return nil, errVisibilitySortNotSupported
+	}
+	if request.HasLabelFilter() {
+		return nil, p.ErrVisibilityLabelFilterNotSupported
+	}
 	query := v.session.Query(templateGetOpenWorkflowExecutionsByType,
 		request.DomainUUID,
 		domainPartition,
 		p.UnixNanoToDBTimestamp(request.EarliestStartTime),
 		p.UnixNanoToDBTimestamp(request.LatestStartTime),
-		request.WorkflowTypeName).Consistency(v.lowConslevel)
-	iter := query.PageSize(request.PageSize).PageState(request.NextPageToken).Iter()
+		request.WorkflowTypeName).WithContext(ctx).Consistency(v.lowConslevel)
+	iter := query.PageSize(request.PageSize).PageState(pageToken).Iter()
 	if iter == nil {
-		// TODO: should return a bad request error if the token is invalid
 		return nil, &workflow.InternalServiceError{
 			Message: "ListOpenWorkflowExecutionsByType operation failed.  Not able to create query iterator.",
 		}
@@ -415,34 +401,34 @@ func (v *cassandraVisibilityPersistence) ListOpenWorkflowExecutionsByType(
 		wfexecution, has = readOpenWorkflowExecutionRecord(iter)
 	}
 
-	nextPageToken := iter.PageState()
-	response.NextPageToken = make([]byte, len(nextPageToken))
-	copy(response.NextPageToken, nextPageToken)
+	response.NextPageToken = p.SerializeVisibilityPageToken(p.VisibilityStoreCassandra, iter.PageState())
 	if err := iter.Close(); err != nil {
-		if isThrottlingError(err) {
-			return nil, &workflow.ServiceBusyError{
-				Message: fmt.Sprintf("ListOpenWorkflowExecutionsByType operation failed. Error: %v", err),
-			}
-		}
-		return nil, &workflow.InternalServiceError{
-			Message: fmt.Sprintf("ListOpenWorkflowExecutionsByType operation failed. Error: %v", err),
-		}
+		return nil, convertVisibilityError("ListOpenWorkflowExecutionsByType", err)
 	}
 
 	return response, nil
 }
 
-func (v *cassandraVisibilityPersistence) ListClosedWorkflowExecutionsByType(
+func (v *cassandraVisibilityPersistence) ListClosedWorkflowExecutionsByType(ctx context.Context,
 	request *p.ListWorkflowExecutionsByTypeRequest) (*p.ListWorkflowExecutionsResponse, error) {
+	pageToken, err := p.DeserializeVisibilityPageToken(p.VisibilityStoreCassandra, request.NextPageToken)
+	if err != nil {
+		return nil, err
+	}
+	if !request.IsDefaultSort() {
+		return nil, errVisibilitySortNotSupported
+	}
+	if request.HasLabelFilter() {
+		return nil, p.ErrVisibilityLabelFilterNotSupported
+	}
 	query := v.session.Query(templateGetClosedWorkflowExecutionsByType,
 		request.DomainUUID,
 		domainPartition,
 		p.UnixNanoToDBTimestamp(request.EarliestStartTime),
 		p.UnixNanoToDBTimestamp(request.LatestStartTime),
-		request.WorkflowTypeName).Consistency(v.lowConslevel)
-	iter := query.PageSize(request.PageSize).PageState(request.NextPageToken).Iter()
+		request.WorkflowTypeName).WithContext(ctx).Consistency(v.lowConslevel)
+	iter := query.PageSize(request.PageSize).PageState(pageToken).Iter()
 	if iter == nil {
-		// TODO: should return a bad request error if the token is invalid
 		return nil, &workflow.InternalServiceError{
 			Message: "ListClosedWorkflowExecutionsByType operation failed.  Not able to create query iterator.",
 		}
@@ -456,34 +442,34 @@ func (v *cassandraVisibilityPersistence) ListClosedWorkflowExecutionsByType(
 		wfexecution, has = readClosedWorkflowExecutionRecord(iter)
 	}
 
-	nextPageToken := iter.PageState()
-	response.NextPageToken = make([]byte, len(nextPageToken))
-	copy(response.NextPageToken, nextPageToken)
+	response.NextPageToken = p.SerializeVisibilityPageToken(p.VisibilityStoreCassandra, iter.PageState())
 	if err := iter.Close(); err != nil {
-		if isThrottlingError(err) {
-			return nil, &workflow.ServiceBusyError{
-				Message: fmt.Sprintf("ListClosedWorkflowExecutionsByType operation failed. Error: %v", err),
-			}
-		}
-		return nil, &workflow.InternalServiceError{
-			Message: fmt.Sprintf("ListClosedWorkflowExecutionsByType operation failed. Error: %v", err),
-		}
+		return nil, convertVisibilityError("ListClosedWorkflowExecutionsByType", err)
 	}
 
 	return response, nil
 }
 
-func (v *cassandraVisibilityPersistence) ListOpenWorkflowExecutionsByWorkflowID(
+func (v *cassandraVisibilityPersistence) ListOpenWorkflowExecutionsByWorkflowID(ctx context.Context,
 	request *p.ListWorkflowExecutionsByWorkflowIDRequest) (*p.ListWorkflowExecutionsResponse, error) {
+	pageToken, err := p.DeserializeVisibilityPageToken(p.VisibilityStoreCassandra, request.NextPageToken)
+	if err != nil {
+		return nil, err
+	}
+	if !request.IsDefaultSort() {
+		return nil, errVisibilitySortNotSupported
+	}
+	if request.HasLabelFilter() {
+		return nil, p.ErrVisibilityLabelFilterNotSupported
+	}
 	query := v.session.Query(templateGetOpenWorkflowExecutionsByID,
 		request.DomainUUID,
 		domainPartition,
 		p.UnixNanoToDBTimestamp(request.EarliestStartTime),
 		p.UnixNanoToDBTimestamp(request.LatestStartTime),
-		request.WorkflowID).Consistency(v.lowConslevel)
-	iter := query.PageSize(request.PageSize).PageState(request.NextPageToken).Iter()
+		request.WorkflowID).WithContext(ctx).Consistency(v.lowConslevel)
+	iter := query.PageSize(request.PageSize).PageState(pageToken).Iter()
 	if iter == nil {
-		// TODO: should return a bad request error if the token is invalid
 		return nil, &workflow.InternalServiceError{
 			Message: "ListOpenWorkflowExecutionsByWorkflowID operation failed.  Not able to create query iterator.",
 		}
@@ -497,34 +483,34 @@ func (v *cassandraVisibilityPersistence) ListOpenWorkflowExecutionsByWorkflowID(
 		wfexecution, has = readOpenWorkflowExecutionRecord(iter)
 	}
 
-	nextPageToken := iter.PageState()
-	response.NextPageToken = make([]byte, len(nextPageToken))
-	copy(response.NextPageToken, nextPageToken)
+	response.NextPageToken = p.SerializeVisibilityPageToken(p.VisibilityStoreCassandra, iter.PageState())
 	if err := iter.Close(); err != nil {
-		if isThrottlingError(err) {
-			return nil, &workflow.ServiceBusyError{
-				Message: fmt.Sprintf("ListOpenWorkflowExecutionsByWorkflowID operation failed. Error: %v", err),
-			}
-		}
-		return nil, &workflow.InternalServiceError{
-			Message: fmt.Sprintf("ListOpenWorkflowExecutionsByWorkflowID operation failed. Error: %v", err),
-		}
+		return nil, convertVisibilityError("ListOpenWorkflowExecutionsByWorkflowID", err)
 	}
 
 	return response, nil
 }
 
-func (v *cassandraVisibilityPersistence) ListClosedWorkflowExecutionsByWorkflowID(
+func (v *cassandraVisibilityPersistence) ListClosedWorkflowExecutionsByWorkflowID(ctx context.Context,
 	request *p.ListWorkflowExecutionsByWorkflowIDRequest) (*p.ListWorkflowExecutionsResponse, error) {
+	pageToken, err := p.DeserializeVisibilityPageToken(p.VisibilityStoreCassandra, request.NextPageToken)
+	if err != nil {
+		return nil, err
+	}
+	if !request.IsDefaultSort() {
+		return nil, errVisibilitySortNotSupported
+	}
+	if request.HasLabelFilter() {
+		return nil, p.ErrVisibilityLabelFilterNotSupported
+	}
 	query := v.session.Query(templateGetClosedWorkflowExecutionsByID,
 		request.DomainUUID,
 		domainPartition,
 		p.UnixNanoToDBTimestamp(request.EarliestStartTime),
 		p.UnixNanoToDBTimestamp(request.LatestStartTime),
-		request.WorkflowID).Consistency(v.lowConslevel)
-	iter := query.PageSize(request.PageSize).PageState(request.NextPageToken).Iter()
+		request.WorkflowID).WithContext(ctx).Consistency(v.lowConslevel)
+	iter := query.PageSize(request.PageSize).PageState(pageToken).Iter()
 	if iter == nil {
-		// TODO: should return a bad request error if the token is invalid
 		return nil, &workflow.InternalServiceError{
 			Message: "ListClosedWorkflowExecutionsByWorkflowID operation failed.  Not able to create query iterator.",
 		}
@@ -538,34 +524,34 @@ func (v *cassandraVisibilityPersistence) ListClosedWorkflowExecutionsByWorkflowI
 		wfexecution, has = readClosedWorkflowExecutionRecord(iter)
 	}
 
-	nextPageToken := iter.PageState()
-	response.NextPageToken = make([]byte, len(nextPageToken))
-	copy(response.NextPageToken, nextPageToken)
+	response.NextPageToken = p.SerializeVisibilityPageToken(p.VisibilityStoreCassandra, iter.PageState())
 	if err := iter.Close(); err != nil {
-		if isThrottlingError(err) {
-			return nil, &workflow.ServiceBusyError{
-				Message: fmt.Sprintf("ListClosedWorkflowExecutionsByWorkflowID operation failed. Error: %v", err),
-			}
-		}
-		return nil, &workflow.InternalServiceError{
-			Message: fmt.Sprintf("ListClosedWorkflowExecutionsByWorkflowID operation failed. Error: %v", err),
-		}
+		return nil, convertVisibilityError("ListClosedWorkflowExecutionsByWorkflowID", err)
 	}
 
 	return response, nil
 }
 
-func (v *cassandraVisibilityPersistence) ListClosedWorkflowExecutionsByStatus(
+func (v *cassandraVisibilityPersistence) ListClosedWorkflowExecutionsByStatus(ctx context.Context,
 	request *p.ListClosedWorkflowExecutionsByStatusRequest) (*p.ListWorkflowExecutionsResponse, error) {
+	pageToken, err := p.DeserializeVisibilityPageToken(p.VisibilityStoreCassandra, request.NextPageToken)
+	if err != nil {
+		return nil, err
+	}
+	if !request.IsDefaultSort() {
+		return nil, errVisibilitySortNotSupported
+	}
+	if request.HasLabelFilter() {
+		return nil, p.ErrVisibilityLabelFilterNotSupported
+	}
 	query := v.session.Query(templateGetClosedWorkflowExecutionsByStatus,
 		request.DomainUUID,
 		domainPartition,
 		p.UnixNanoToDBTimestamp(request.EarliestStartTime),
 		p.UnixNanoToDBTimestamp(request.LatestStartTime),
-		request.Status).Consistency(v.lowConslevel)
-	iter := query.PageSize(request.PageSize).PageState(request.NextPageToken).Iter()
+		request.Status).WithContext(ctx).Consistency(v.lowConslevel)
+	iter := query.PageSize(request.PageSize).PageState(pageToken).Iter()
 	if iter == nil {
-		// TODO: should return a bad request error if the token is invalid
 		return nil, &workflow.InternalServiceError{
 			Message: "ListClosedWorkflowExecutionsByStatus operation failed.  Not able to create query iterator.",
 		}
@@ -579,31 +565,22 @@ func (v *cassandraVisibilityPersistence) ListClosedWorkflowExecutionsByStatus(
 		wfexecution, has = readClosedWorkflowExecutionRecord(iter)
 	}
 
-	nextPageToken := iter.PageState()
-	response.NextPageToken = make([]byte, len(nextPageToken))
-	copy(response.NextPageToken, nextPageToken)
+	response.NextPageToken = p.SerializeVisibilityPageToken(p.VisibilityStoreCassandra, iter.PageState())
 	if err := iter.Close(); err != nil {
-		if isThrottlingError(err) {
-			return nil, &workflow.ServiceBusyError{
-				Message: fmt.Sprintf("ListClosedWorkflowExecutionsByStatus operation failed. Error: %v", err),
-			}
-		}
-		return nil, &workflow.InternalServiceError{
-			Message: fmt.Sprintf("ListClosedWorkflowExecutionsByStatus operation failed. Error: %v", err),
-		}
+		return nil, convertVisibilityError("ListClosedWorkflowExecutionsByStatus", err)
 	}
 
 	return response, nil
 }
 
-func (v *cassandraVisibilityPersistence) GetClosedWorkflowExecution(
+func (v *cassandraVisibilityPersistence) GetClosedWorkflowExecution(ctx context.Context,
 	request *p.GetClosedWorkflowExecutionRequest) (*p.GetClosedWorkflowExecutionResponse, error) {
 	execution := request.Execution
 	query := v.session.Query(templateGetClosedWorkflowExecution,
 		request.DomainUUID,
 		domainPartition,
 		execution.GetWorkflowId(),
-		execution.GetRunId())
+		execution.GetRunId()).WithContext(ctx)
 
 	iter := query.Iter()
 	if iter == nil {
@@ -613,6 +590,11 @@ func (v *cassandraVisibilityPersistence) GetClosedWorkflowExecution(
 	}
 
 	wfexecution, has := readClosedWorkflowExecutionRecord(iter)
+	// check the iterator error first, a failed read must not be reported as a missing execution
+	if err := iter.Close(); err != nil {
+		return nil, convertVisibilityError("GetClosedWorkflowExecution", err)
+	}
+
 	if !has {
 		return nil, &workflow.EntityNotExistsError{
 			Message: fmt.Sprintf("Workflow execution not found.  WorkflowId: %v, RunId: %v",
@@ -620,34 +602,58 @@ func (v *cassandraVisibilityPersistence) GetClosedWorkflowExecution(
 		}
 	}
 
-	if err := iter.Close(); err != nil {
-		if isThrottlingError(err) {
-			return nil, &workflow.ServiceBusyError{
-				Message: fmt.Sprintf("GetClosedWorkflowExecution operation failed. Error: %v", err),
-			}
-		}
-		return nil, &workflow.InternalServiceError{
-			Message: fmt.Sprintf("GetClosedWorkflowExecution operation failed. Error: %v", err),
-		}
-	}
-
 	return &p.GetClosedWorkflowExecutionResponse{
 		Execution: wfexecution,
 	}, nil
 }
 
+// getOpenExecutionTTL returns the TTL of an open execution row. The row is normally deleted
+// when the execution closes, the TTL only cleans up rows whose close was never recorded,
+// so they are kept for the domain retention after the workflow timeout.
+func getOpenExecutionTTL(workflowTimeout int64, retentionSeconds int64) int64 {
+	ttl := workflowTimeout + openExecutionTTLBuffer + retentionSeconds
+	if ttl > maxCassandraTTL {
+		ttl = maxCassandraTTL
+	}
+	return ttl
+}
+
+// getClosedExecutionTTL returns the TTL of a closed execution row based on the domain retention
+func getClosedExecutionTTL(retentionSeconds int64) int64 {
+	if retentionSeconds <= 0 {
+		return defaultCloseTTLSeconds
+	}
+	if retentionSeconds > maxCassandraTTL {
+		return maxCassandraTTL
+	}
+	return retentionSeconds
+}
+
 // DeleteWorkflowExecution is a no-op since deletes are auto-handled by cassandra TTLs
-func (v *cassandraVisibilityPersistence) DeleteWorkflowExecution(request *p.VisibilityDeleteWorkflowExecutionRequest) error {
+func (v *cassandraVisibilityPersistence) DeleteWorkflowExecution(ctx context.Context, request *p.VisibilityDeleteWorkflowExecutionRequest) error {
 	return nil
 }
 
+// GetWorkflowExecutionStatistics is not supported since cassandra cannot aggregate visibility records
+func (v *cassandraVisibilityPersistence) GetWorkflowExecutionStatistics(ctx context.Context, request *p.GetWorkflowExecutionStatisticsRequest) (*p.GetWorkflowExecutionStatisticsResponse, error) {
+	return nil, p.ErrVisibilityStatisticsNotSupported
+}
+
+// CountOpenWorkflowExecutions is not supported since cassandra cannot count visibility records cheaply
+func (v *cassandraVisibilityPersistence) CountOpenWorkflowExecutions(ctx context.Context, request *p.CountWorkflowExecutionsRequest) (*p.CountWorkflowExecutionsResponse, error) {
+	return nil, p.ErrVisibilityCountNotSupported
+}
+
 func readOpenWorkflowExecutionRecord(iter *gocql.Iter) (*workflow.WorkflowExecutionInfo, bool) {
 	var workflowID string
 	var runID gocql.UUID
 	var typeName string
 	var startTime time.Time
 	var executionTime time.Time
-	if iter.Scan(&workflowID, &runID, &startTime, &executionTime, &typeName) {
+	var taskList string
+	var parentWorkflowID string
+	var parentRunID string
+	if iter.Scan(&workflowID, &runID, &startTime, &executionTime, &typeName, &taskList, &parentWorkflowID, &parentRunID) {
 		execution := &workflow.WorkflowExecution{}
 		execution.WorkflowId = common.StringPtr(workflowID)
 		execution.RunId = common.StringPtr(runID.String())
@@ -664,6 +670,8 @@ func readOpenWorkflowExecutionRecord(iter *gocql.Iter) (*workflow.WorkflowExecut
 		record.StartTime = common.Int64Ptr(startTime.UnixNano())
 		record.ExecutionTime = common.Int64Ptr(executionTime.UnixNano())
 		record.Type = wfType
+		record.TaskList = common.StringPtr(taskList)
+		record.ParentExecution = getParentExecution(parentWorkflowID, parentRunID)
 		return record, true
 	}
 	return nil, false
@@ -678,7 +686,15 @@ func readClosedWorkflowExecutionRecord(iter *gocql.Iter) (*workflow.WorkflowExec
 	var closeTime time.Time
 	var status workflow.WorkflowExecutionCloseStatus
 	var historyLength int64
-	if iter.Scan(&workflowID, &runID, &startTime, &executionTime, &closeTime, &typeName, &status, &historyLength) {
+	var taskList string
+	var parentWorkflowID string
+	var parentRunID string
+	var terminateCause *int32
+	var terminateReason string
+	var terminateDetails []byte
+	var terminateIdentity string
+	if iter.Scan(&workflowID, &runID, &startTime, &executionTime, &closeTime, &typeName, &status, &historyLength,
+		&taskList, &parentWorkflowID, &parentRunID, &terminateCause, &terminateReason, &terminateDetails, &terminateIdentity) {
 		execution := &workflow.WorkflowExecution{}
 		execution.WorkflowId = common.StringPtr(workflowID)
 		execution.RunId = common.StringPtr(runID.String())
@@ -698,7 +714,40 @@ func readClosedWorkflowExecutionRecord(iter *gocql.Iter) (*workflow.WorkflowExec
 		record.Type = wfType
 		record.CloseStatus = &status
 		record.HistoryLength = common.Int64Ptr(historyLength)
+		record.TaskList = common.StringPtr(taskList)
+		record.ParentExecution = getParentExecution(parentWorkflowID, parentRunID)
+		if terminateCause != nil {
+			record.TerminationInfo = &workflow.WorkflowExecutionTerminationInfo{
+				Cause:    workflow.TerminationCause(*terminateCause).Ptr(),
+				Reason:   common.StringPtr(terminateReason),
+				Details:  terminateDetails,
+				Identity: common.StringPtr(terminateIdentity),
+			}
+		}
 		return record, true
 	}
 	return nil, false
 }
+
+func getParentExecution(parentWorkflowID string, parentRunID string) *workflow.WorkflowExecution {
+	if parentWorkflowID == "" {
+		return nil
+	}
+	return &workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr(parentWorkflowID),
+		RunId:      common.StringPtr(parentRunID),
+	}
+}
+
+// convertVisibilityError classifies a failed visibility query, so callers can tell throttled and
+// timed out queries, which are worth retrying, from other failures
+func convertVisibilityError(operation string, err error) error {
+	msg := fmt.Sprintf("%v operation failed. Error: %v", operation, err)
+	if isThrottlingError(err) {
+		return &workflow.ServiceBusyError{Message: msg}
+	}
+	if isTimeoutError(err) {
+		return &p.TimeoutError{Msg: msg}
+	}
+	return &workflow.InternalServiceError{Message: msg}
+}
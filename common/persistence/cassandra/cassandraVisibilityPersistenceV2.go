@@ -21,6 +21,7 @@
 package cassandra
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/gocql/gocql"
@@ -31,14 +32,18 @@ import (
 )
 
 const (
-	templateGetClosedWorkflowExecutionsV2 = `SELECT workflow_id, run_id, start_time, execution_time, close_time, workflow_type_name, status, history_length ` +
+	templateGetClosedWorkflowExecutionsV2 = `SELECT workflow_id, run_id, start_time, execution_time, close_time, workflow_type_name, status, history_length, ` +
+		`task_list, parent_workflow_id, parent_run_id, ` +
+		`terminate_cause, terminate_reason, terminate_details, terminate_identity ` +
 		`FROM closed_executions_v2 ` +
 		`WHERE domain_id = ? ` +
 		`AND domain_partition IN (?) ` +
 		`AND close_time >= ? ` +
 		`AND close_time <= ? `
 
-	templateGetClosedWorkflowExecutionsByTypeV2 = `SELECT workflow_id, run_id, start_time, execution_time, close_time, workflow_type_name, status, history_length ` +
+	templateGetClosedWorkflowExecutionsByTypeV2 = `SELECT workflow_id, run_id, start_time, execution_time, close_time, workflow_type_name, status, history_length, ` +
+		`task_list, parent_workflow_id, parent_run_id, ` +
+		`terminate_cause, terminate_reason, terminate_details, terminate_identity ` +
 		`FROM closed_executions_v2 ` +
 		`WHERE domain_id = ? ` +
 		`AND domain_partition = ? ` +
@@ -46,7 +51,9 @@ const (
 		`AND close_time <= ? ` +
 		`AND workflow_type_name = ? `
 
-	templateGetClosedWorkflowExecutionsByIDV2 = `SELECT workflow_id, run_id, start_time, execution_time, close_time, workflow_type_name, status, history_length ` +
+	templateGetClosedWorkflowExecutionsByIDV2 = `SELECT workflow_id, run_id, start_time, execution_time, close_time, workflow_type_name, status, history_length, ` +
+		`task_list, parent_workflow_id, parent_run_id, ` +
+		`terminate_cause, terminate_reason, terminate_details, terminate_identity ` +
 		`FROM closed_executions_v2 ` +
 		`WHERE domain_id = ? ` +
 		`AND domain_partition = ? ` +
@@ -54,7 +61,9 @@ const (
 		`AND close_time <= ? ` +
 		`AND workflow_id = ? `
 
-	templateGetClosedWorkflowExecutionsByStatusV2 = `SELECT workflow_id, run_id, start_time, execution_time, close_time, workflow_type_name, status, history_length ` +
+	templateGetClosedWorkflowExecutionsByStatusV2 = `SELECT workflow_id, run_id, start_time, execution_time, close_time, workflow_type_name, status, history_length, ` +
+		`task_list, parent_workflow_id, parent_run_id, ` +
+		`terminate_cause, terminate_reason, terminate_details, terminate_identity ` +
 		`FROM closed_executions_v2 ` +
 		`WHERE domain_id = ? ` +
 		`AND domain_partition = ? ` +
@@ -78,7 +87,7 @@ func NewVisibilityPersistenceV2(persistence p.VisibilityManager, cfg *config.Cas
 	cluster.ProtoVersion = cassandraProtoVersion
 	cluster.Consistency = gocql.LocalQuorum
 	cluster.SerialConsistency = gocql.LocalSerial
-	cluster.Timeout = defaultSessionTimeout
+	cluster.Timeout = sessionTimeout(*cfg)
 
 	session, err := cluster.CreateSession()
 	if err != nil {
@@ -104,46 +113,55 @@ func (v *cassandraVisibilityPersistenceV2) GetName() string {
 	return v.persistence.GetName()
 }
 
-func (v *cassandraVisibilityPersistenceV2) RecordWorkflowExecutionStarted(
+func (v *cassandraVisibilityPersistenceV2) RecordWorkflowExecutionStarted(ctx context.Context,
 	request *p.RecordWorkflowExecutionStartedRequest) error {
-	return v.persistence.RecordWorkflowExecutionStarted(request)
+	return v.persistence.RecordWorkflowExecutionStarted(ctx, request)
 }
 
-func (v *cassandraVisibilityPersistenceV2) RecordWorkflowExecutionClosed(
+func (v *cassandraVisibilityPersistenceV2) RecordWorkflowExecutionClosed(ctx context.Context,
 	request *p.RecordWorkflowExecutionClosedRequest) error {
-	return v.persistence.RecordWorkflowExecutionClosed(request)
+	return v.persistence.RecordWorkflowExecutionClosed(ctx, request)
 }
 
-func (v *cassandraVisibilityPersistenceV2) ListOpenWorkflowExecutions(
+func (v *cassandraVisibilityPersistenceV2) ListOpenWorkflowExecutions(ctx context.Context,
 	request *p.ListWorkflowExecutionsRequest) (*p.ListWorkflowExecutionsResponse, error) {
-	return v.persistence.ListOpenWorkflowExecutions(request)
+	return v.persistence.ListOpenWorkflowExecutions(ctx, request)
 }
 
-func (v *cassandraVisibilityPersistenceV2) ListOpenWorkflowExecutionsByType(
+func (v *cassandraVisibilityPersistenceV2) ListOpenWorkflowExecutionsByType(ctx context.Context,
 	request *p.ListWorkflowExecutionsByTypeRequest) (*p.ListWorkflowExecutionsResponse, error) {
-	return v.persistence.ListOpenWorkflowExecutionsByType(request)
+	return v.persistence.ListOpenWorkflowExecutionsByType(ctx, request)
 }
 
-func (v *cassandraVisibilityPersistenceV2) ListOpenWorkflowExecutionsByWorkflowID(
+func (v *cassandraVisibilityPersistenceV2) ListOpenWorkflowExecutionsByWorkflowID(ctx context.Context,
 	request *p.ListWorkflowExecutionsByWorkflowIDRequest) (*p.ListWorkflowExecutionsResponse, error) {
-	return v.persistence.ListOpenWorkflowExecutionsByWorkflowID(request)
+	return v.persistence.ListOpenWorkflowExecutionsByWorkflowID(ctx, request)
 }
 
-func (v *cassandraVisibilityPersistenceV2) GetClosedWorkflowExecution(
+func (v *cassandraVisibilityPersistenceV2) GetClosedWorkflowExecution(ctx context.Context,
 	request *p.GetClosedWorkflowExecutionRequest) (*p.GetClosedWorkflowExecutionResponse, error) {
-	return v.persistence.GetClosedWorkflowExecution(request)
+	return v.persistence.GetClosedWorkflowExecution(ctx, request)
 }
 
-func (v *cassandraVisibilityPersistenceV2) ListClosedWorkflowExecutions(
+func (v *cassandraVisibilityPersistenceV2) ListClosedWorkflowExecutions(ctx context.Context,
 	request *p.ListWorkflowExecutionsRequest) (*p.ListWorkflowExecutionsResponse, error) {
+	pageToken, err := p.DeserializeVisibilityPageToken(p.VisibilityStoreCassandra, request.NextPageToken)
+	if err != nil {
+		return nil, err
+	}
+	if !request.IsDefaultSort() {
+		return nil, errVisibilitySortNotSupported
+	}
+	if request.HasLabelFilter() {
+		return nil, p.ErrVisibilityLabelFilterNotSupported
+	}
 	query := v.session.Query(templateGetClosedWorkflowExecutionsV2,
 		request.DomainUUID,
 		domainPartition,
 		p.UnixNanoToDBTimestamp(request.EarliestStartTime),
-		p.UnixNanoToDBTimestamp(request.LatestStartTime)).Consistency(v.lowConslevel)
-	iter := query.PageSize(request.PageSize).PageState(request.NextPageToken).Iter()
+		p.UnixNanoToDBTimestamp(request.LatestStartTime)).WithContext(ctx).Consistency(v.lowConslevel)
+	iter := query.PageSize(request.PageSize).PageState(pageToken).Iter()
 	if iter == nil {
-		// TODO: should return a bad request error if the token is invalid
 		return nil, &workflow.InternalServiceError{
 			Message: "ListClosedWorkflowExecutions operation failed.  Not able to create query iterator.",
 		}
@@ -157,9 +175,7 @@ func (v *cassandraVisibilityPersistenceV2) ListClosedWorkflowExecutions(
 		wfexecution, has = readClosedWorkflowExecutionRecord(iter)
 	}
 
-	nextPageToken := iter.PageState()
-	response.NextPageToken = make([]byte, len(nextPageToken))
-	copy(response.NextPageToken, nextPageToken)
+	response.NextPageToken = p.SerializeVisibilityPageToken(p.VisibilityStoreCassandra, iter.PageState())
 	if err := iter.Close(); err != nil {
 		if isThrottlingError(err) {
 			return nil, &workflow.ServiceBusyError{
@@ -174,17 +190,26 @@ func (v *cassandraVisibilityPersistenceV2) ListClosedWorkflowExecutions(
 	return response, nil
 }
 
-func (v *cassandraVisibilityPersistenceV2) ListClosedWorkflowExecutionsByType(
+func (v *cassandraVisibilityPersistenceV2) ListClosedWorkflowExecutionsByType(ctx context.Context,
 	request *p.ListWorkflowExecutionsByTypeRequest) (*p.ListWorkflowExecutionsResponse, error) {
+	pageToken, err := p.DeserializeVisibilityPageToken(p.VisibilityStoreCassandra, request.NextPageToken)
+	if err != nil {
+		return nil, err
+	}
+	if !request.IsDefaultSort() {
+		return nil, errVisibilitySortNotSupported
+	}
+	if request.HasLabelFilter() {
+		return nil, p.ErrVisibilityLabelFilterNotSupported
+	}
 	query := v.session.Query(templateGetClosedWorkflowExecutionsByTypeV2,
 		request.DomainUUID,
 		domainPartition,
 		p.UnixNanoToDBTimestamp(request.EarliestStartTime),
 		p.UnixNanoToDBTimestamp(request.LatestStartTime),
-		request.WorkflowTypeName).Consistency(v.lowConslevel)
-	iter := query.PageSize(request.PageSize).PageState(request.NextPageToken).Iter()
+		request.WorkflowTypeName).WithContext(ctx).Consistency(v.lowConslevel)
+	iter := query.PageSize(request.PageSize).PageState(pageToken).Iter()
 	if iter == nil {
-		// TODO: should return a bad request error if the token is invalid
 		return nil, &workflow.InternalServiceError{
 			Message: "ListClosedWorkflowExecutionsByType operation failed.  Not able to create query iterator.",
 		}
@@ -198,9 +223,7 @@ func (v *cassandraVisibilityPersistenceV2) ListClosedWorkflowExecutionsByType(
 		wfexecution, has = readClosedWorkflowExecutionRecord(iter)
 	}
 
-	nextPageToken := iter.PageState()
-	response.NextPageToken = make([]byte, len(nextPageToken))
-	copy(response.NextPageToken, nextPageToken)
+	response.NextPageToken = p.SerializeVisibilityPageToken(p.VisibilityStoreCassandra, iter.PageState())
 	if err := iter.Close(); err != nil {
 		if isThrottlingError(err) {
 			return nil, &workflow.ServiceBusyError{
@@ -215,17 +238,26 @@ func (v *cassandraVisibilityPersistenceV2) ListClosedWorkflowExecutionsByType(
 	return response, nil
 }
 
-func (v *cassandraVisibilityPersistenceV2) ListClosedWorkflowExecutionsByWorkflowID(
+func (v *cassandraVisibilityPersistenceV2) ListClosedWorkflowExecutionsByWorkflowID(ctx context.Context,
 	request *p.ListWorkflowExecutionsByWorkflowIDRequest) (*p.ListWorkflowExecutionsResponse, error) {
+	pageToken, err := p.DeserializeVisibilityPageToken(p.VisibilityStoreCassandra, request.NextPageToken)
+	if err != nil {
+		return nil, err
+	}
+	if !request.IsDefaultSort() {
+		return nil, errVisibilitySortNotSupported
+	}
+	if request.HasLabelFilter() {
+		return nil, p.ErrVisibilityLabelFilterNotSupported
+	}
 	query := v.session.Query(templateGetClosedWorkflowExecutionsByIDV2,
 		request.DomainUUID,
 		domainPartition,
 		p.UnixNanoToDBTimestamp(request.EarliestStartTime),
 		p.UnixNanoToDBTimestamp(request.LatestStartTime),
-		request.WorkflowID).Consistency(v.lowConslevel)
-	iter := query.PageSize(request.PageSize).PageState(request.NextPageToken).Iter()
+		request.WorkflowID).WithContext(ctx).Consistency(v.lowConslevel)
+	iter := query.PageSize(request.PageSize).PageState(pageToken).Iter()
 	if iter == nil {
-		// TODO: should return a bad request error if the token is invalid
 		return nil, &workflow.InternalServiceError{
 			Message: "ListClosedWorkflowExecutionsByWorkflowID operation failed.  Not able to create query iterator.",
 		}
@@ -239,9 +271,7 @@ func (v *cassandraVisibilityPersistenceV2) ListClosedWorkflowExecutionsByWorkflo
 		wfexecution, has = readClosedWorkflowExecutionRecord(iter)
 	}
 
-	nextPageToken := iter.PageState()
-	response.NextPageToken = make([]byte, len(nextPageToken))
-	copy(response.NextPageToken, nextPageToken)
+	response.NextPageToken = p.SerializeVisibilityPageToken(p.VisibilityStoreCassandra, iter.PageState())
 	if err := iter.Close(); err != nil {
 		if isThrottlingError(err) {
 			return nil, &workflow.ServiceBusyError{
@@ -256,17 +286,26 @@ func (v *cassandraVisibilityPersistenceV2) ListClosedWorkflowExecutionsByWorkflo
 	return response, nil
 }
 
-func (v *cassandraVisibilityPersistenceV2) ListClosedWorkflowExecutionsByStatus(
+func (v *cassandraVisibilityPersistenceV2) ListClosedWorkflowExecutionsByStatus(ctx context.Context,
 	request *p.ListClosedWorkflowExecutionsByStatusRequest) (*p.ListWorkflowExecutionsResponse, error) {
+	pageToken, err := p.DeserializeVisibilityPageToken(p.VisibilityStoreCassandra, request.NextPageToken)
+	if err != nil {
+		return nil, err
+	}
+	if !request.IsDefaultSort() {
+		return nil, errVisibilitySortNotSupported
+	}
+	if request.HasLabelFilter() {
+		return nil, p.ErrVisibilityLabelFilterNotSupported
+	}
 	query := v.session.Query(templateGetClosedWorkflowExecutionsByStatusV2,
 		request.DomainUUID,
 		domainPartition,
 		p.UnixNanoToDBTimestamp(request.EarliestStartTime),
 		p.UnixNanoToDBTimestamp(request.LatestStartTime),
-		request.Status).Consistency(v.lowConslevel)
-	iter := query.PageSize(request.PageSize).PageState(request.NextPageToken).Iter()
+		request.Status).WithContext(ctx).Consistency(v.lowConslevel)
+	iter := query.PageSize(request.PageSize).PageState(pageToken).Iter()
 	if iter == nil {
-		// TODO: should return a bad request error if the token is invalid
 		return nil, &workflow.InternalServiceError{
 			Message: "ListClosedWorkflowExecutionsByStatus operation failed.  Not able to create query iterator.",
 		}
@@ -280,9 +319,7 @@ func (v *cassandraVisibilityPersistenceV2) ListClosedWorkflowExecutionsByStatus(
 		wfexecution, has = readClosedWorkflowExecutionRecord(iter)
 	}
 
-	nextPageToken := iter.PageState()
-	response.NextPageToken = make([]byte, len(nextPageToken))
-	copy(response.NextPageToken, nextPageToken)
+	response.NextPageToken = p.SerializeVisibilityPageToken(p.VisibilityStoreCassandra, iter.PageState())
 	if err := iter.Close(); err != nil {
 		if isThrottlingError(err) {
 			return nil, &workflow.ServiceBusyError{
@@ -298,6 +335,16 @@ func (v *cassandraVisibilityPersistenceV2) ListClosedWorkflowExecutionsByStatus(
 }
 
 // DeleteWorkflowExecution is a no-op since deletes are auto-handled by cassandra TTLs
-func (v *cassandraVisibilityPersistenceV2) DeleteWorkflowExecution(request *p.VisibilityDeleteWorkflowExecutionRequest) error {
+func (v *cassandraVisibilityPersistenceV2) DeleteWorkflowExecution(ctx context.Context, request *p.VisibilityDeleteWorkflowExecutionRequest) error {
 	return nil
 }
+
+// GetWorkflowExecutionStatistics is not supported since cassandra cannot aggregate visibility records
+func (v *cassandraVisibilityPersistenceV2) GetWorkflowExecutionStatistics(ctx context.Context, request *p.GetWorkflowExecutionStatisticsRequest) (*p.GetWorkflowExecutionStatisticsResponse, error) {
+	return nil, p.ErrVisibilityStatisticsNotSupported
+}
+
+// CountOpenWorkflowExecutions is not supported since cassandra cannot count visibility records cheaply
+func (v *cassandraVisibilityPersistenceV2) CountOpenWorkflowExecutions(ctx context.Context, request *p.CountWorkflowExecutionsRequest) (*p.CountWorkflowExecutionsResponse, error) {
+	return nil, p.ErrVisibilityCountNotSupported
+}
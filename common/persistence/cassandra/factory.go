@@ -22,6 +22,7 @@ package cassandra
 
 import (
 	"sync"
+	"time"
 
 	"github.com/gocql/gocql"
 	"github.com/uber-common/bark"
@@ -89,6 +90,11 @@ func (f *Factory) NewMetadataStoreV2() (p.MetadataStore, error) {
 	return newMetadataPersistenceV2(f.cfg, f.clusterName, f.logger)
 }
 
+// NewClusterMetadataStore returns a new cluster metadata store
+func (f *Factory) NewClusterMetadataStore() (p.ClusterMetadataStore, error) {
+	return newClusterMetadataPersistence(f.cfg, f.logger)
+}
+
 // NewExecutionStore returns an ExecutionStore for a given shardID
 func (f *Factory) NewExecutionStore(shardID int) (p.ExecutionStore, error) {
 	factory, err := f.executionStoreFactory()
@@ -140,7 +146,7 @@ func newExecutionStoreFactory(cfg config.Cassandra, logger bark.Logger) (*execut
 	cluster.ProtoVersion = cassandraProtoVersion
 	cluster.Consistency = gocql.LocalQuorum
 	cluster.SerialConsistency = gocql.LocalSerial
-	cluster.Timeout = defaultSessionTimeout
+	cluster.Timeout = sessionTimeout(cfg)
 	if cfg.MaxConns > 0 {
 		cluster.NumConns = cfg.MaxConns
 	}
@@ -163,3 +169,12 @@ func (f *executionStoreFactory) new(shardID int) (p.ExecutionStore, error) {
 	}
 	return pmgr, nil
 }
+
+// sessionTimeout returns the client side timeout to be used for queries
+// against the cassandra datastore described by cfg
+func sessionTimeout(cfg config.Cassandra) time.Duration {
+	if cfg.Timeout > 0 {
+		return cfg.Timeout
+	}
+	return defaultSessionTimeout
+}
@@ -20,6 +20,8 @@
 
 package persistence
 
+import "fmt"
+
 // GetOrUseDefaultActiveCluster return the current cluster name or use the input if valid
 func GetOrUseDefaultActiveCluster(currentClusterName string, activeClusterName string) string {
 	if len(activeClusterName) == 0 {
@@ -39,3 +41,33 @@ func GetOrUseDefaultClusters(currentClusterName string, clusters []*ClusterRepli
 	}
 	return clusters
 }
+
+// ValidateClusterMetadata returns an error if the metadata of the cluster config doesn't match the persisted
+// metadata. Clusters can be added to the config, and persisted clusters can be missing from the config since
+// remote clusters can be added at runtime. All the other changes would attribute the failover versions of
+// existing global domains to the wrong cluster
+func ValidateClusterMetadata(persisted *ClusterMetadata, configured *ClusterMetadata) error {
+	if persisted.ClusterName != configured.ClusterName {
+		return fmt.Errorf("current cluster name %v doesn't match the persisted cluster name %v",
+			configured.ClusterName, persisted.ClusterName)
+	}
+	if persisted.FailoverVersionIncrement != configured.FailoverVersionIncrement {
+		return fmt.Errorf("failover version increment %v doesn't match the persisted failover version increment %v",
+			configured.FailoverVersionIncrement, persisted.FailoverVersionIncrement)
+	}
+	if persisted.NumHistoryShards != configured.NumHistoryShards {
+		return fmt.Errorf("number of history shards %v doesn't match the persisted number of history shards %v",
+			configured.NumHistoryShards, persisted.NumHistoryShards)
+	}
+	for clusterName, persistedVersion := range persisted.InitialFailoverVersions {
+		version, ok := configured.InitialFailoverVersions[clusterName]
+		if !ok {
+			continue
+		}
+		if version != persistedVersion {
+			return fmt.Errorf("initial failover version %v of cluster %v doesn't match the persisted initial failover version %v",
+				version, clusterName, persistedVersion)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type (
+	clusterMetadataSuite struct {
+		suite.Suite
+		*require.Assertions
+	}
+)
+
+func TestClusterMetadataSuite(t *testing.T) {
+	s := new(clusterMetadataSuite)
+	suite.Run(t, s)
+}
+
+func (s *clusterMetadataSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *clusterMetadataSuite) newClusterMetadata() *ClusterMetadata {
+	return &ClusterMetadata{
+		ClusterName:              "active",
+		FailoverVersionIncrement: 10,
+		InitialFailoverVersions:  map[string]int64{"active": 0, "standby": 1},
+		NumHistoryShards:         4,
+		Version:                  1,
+	}
+}
+
+func (s *clusterMetadataSuite) TestValidateClusterMetadata_Match() {
+	s.NoError(ValidateClusterMetadata(s.newClusterMetadata(), s.newClusterMetadata()))
+}
+
+func (s *clusterMetadataSuite) TestValidateClusterMetadata_ClusterAdded() {
+	configured := s.newClusterMetadata()
+	configured.InitialFailoverVersions["other"] = 2
+	s.NoError(ValidateClusterMetadata(s.newClusterMetadata(), configured))
+}
+
+func (s *clusterMetadataSuite) TestValidateClusterMetadata_ClusterAddedAtRuntime() {
+	configured := s.newClusterMetadata()
+	delete(configured.InitialFailoverVersions, "standby")
+	s.NoError(ValidateClusterMetadata(s.newClusterMetadata(), configured))
+}
+
+func (s *clusterMetadataSuite) TestValidateClusterMetadata_Mismatch() {
+	testCases := []func(*ClusterMetadata){
+		func(m *ClusterMetadata) { m.ClusterName = "standby" },
+		func(m *ClusterMetadata) { m.FailoverVersionIncrement = 100 },
+		func(m *ClusterMetadata) { m.NumHistoryShards = 8 },
+		func(m *ClusterMetadata) { m.InitialFailoverVersions["standby"] = 2 },
+	}
+	for _, modify := range testCases {
+		configured := s.newClusterMetadata()
+		modify(configured)
+		s.Error(ValidateClusterMetadata(s.newClusterMetadata(), configured))
+	}
+}
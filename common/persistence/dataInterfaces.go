@@ -21,12 +21,14 @@
 package persistence
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"github.com/pborman/uuid"
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/checksum"
 	"github.com/uber/cadence/common/codec"
 )
 
@@ -100,6 +102,7 @@ const (
 	TransferTaskTypeStartChildExecution
 	TransferTaskTypeSignalExecution
 	TransferTaskTypeRecordWorkflowStarted
+	TransferTaskTypeUpsertWorkflowLabels
 )
 
 // Types of replication tasks
@@ -117,6 +120,7 @@ const (
 	TaskTypeDeleteHistoryEvent
 	TaskTypeActivityRetryTimer
 	TaskTypeWorkflowBackoffTimer
+	TaskTypeDelayedSignal
 )
 
 // UnknownNumRowsAffected is returned when the number of rows that an API affected cannot be determined
@@ -272,6 +276,9 @@ type (
 		BranchToken       []byte
 		CronSchedule      string
 		ExpirationSeconds int32
+		Labels            map[string]string
+		// for NDC replication: the version history of every history branch
+		VersionHistories *VersionHistories
 	}
 
 	// ReplicationState represents mutable state information for global domains.
@@ -300,6 +307,8 @@ type (
 		ScheduleID              int64
 		Version                 int64
 		RecordVisibility        bool
+		RequestID               string
+		CallerIdentity          string
 	}
 
 	// ReplicationTaskInfo describes the replication task created for replication of history events
@@ -333,6 +342,10 @@ type (
 		EventID             int64
 		ScheduleAttempt     int64
 		Version             int64
+		SignalName          string
+		SignalInput         []byte
+		SignalIdentity      string
+		SignalRequestID     string
 	}
 
 	// TaskListInfo describes a state of a task list implementation.
@@ -395,6 +408,17 @@ type (
 		VisibilityTimestamp time.Time
 		TaskID              int64
 		Version             int64
+		RequestID           string
+		CallerIdentity      string
+	}
+
+	// UpsertWorkflowLabelsTask identifies a transfer task for indexing the updated labels of an open execution
+	UpsertWorkflowLabelsTask struct {
+		VisibilityTimestamp time.Time
+		TaskID              int64
+		Version             int64
+		RequestID           string
+		CallerIdentity      string
 	}
 
 	// CloseExecutionTask identifies a transfer task for deletion of execution
@@ -402,6 +426,8 @@ type (
 		VisibilityTimestamp time.Time
 		TaskID              int64
 		Version             int64
+		RequestID           string
+		CallerIdentity      string
 	}
 
 	// DeleteHistoryEventTask identifies a timer task for deletion of history events of completed execution.
@@ -498,6 +524,17 @@ type (
 		TimeoutType         int // 0 for retry, 1 for cron.
 	}
 
+	// DelayedSignalTask to deliver a signal to the workflow at a future time
+	DelayedSignalTask struct {
+		VisibilityTimestamp time.Time
+		TaskID              int64
+		Version             int64
+		SignalName          string
+		Input               []byte
+		Identity            string
+		RequestID           string
+	}
+
 	// HistoryReplicationTask is the replication task created for shipping history replication events to other clusters
 	HistoryReplicationTask struct {
 		VisibilityTimestamp     time.Time
@@ -539,6 +576,7 @@ type (
 		ReplicationState         *ReplicationState
 		BufferedEvents           []*workflow.HistoryEvent
 		BufferedReplicationTasks map[int64]*BufferedReplicationTask
+		Checksum                 checksum.Checksum
 	}
 
 	// ActivityInfo details.
@@ -574,6 +612,8 @@ type (
 		ExpirationTime     time.Time
 		MaximumAttempts    int32
 		NonRetriableErrors []string
+		// LastFailureReason is the failure reason of the last attempt which was retried
+		LastFailureReason string
 		// Not written to database - This is used only for deduping heartbeat timer creation
 		LastHeartbeatTimeoutVisibility int64
 	}
@@ -695,6 +735,9 @@ type (
 		BranchToken       []byte
 		CronSchedule      string
 		ExpirationSeconds int32
+		VersionHistories  *VersionHistories
+		//Optional. It is to suggest a binary encoding type to serialize version histories
+		Encoding common.EncodingType
 	}
 
 	// CreateWorkflowExecutionResponse is the response to CreateWorkflowExecutionRequest
@@ -713,6 +756,19 @@ type (
 		MutableStateStats *MutableStateStats
 	}
 
+	// GetWorkflowExecutionsRequest is used to retrieve the info of multiple workflow executions of a domain
+	// in one round trip, the run id of every execution must be set
+	GetWorkflowExecutionsRequest struct {
+		DomainID   string
+		Executions []workflow.WorkflowExecution
+	}
+
+	// GetWorkflowExecutionsResponse is the response to GetWorkflowExecutionsRequest, executions which don't
+	// exist are omitted
+	GetWorkflowExecutionsResponse struct {
+		Responses []*GetWorkflowExecutionResponse
+	}
+
 	// GetCurrentExecutionRequest is used to retrieve the current RunId for an execution
 	GetCurrentExecutionRequest struct {
 		DomainID   string
@@ -759,6 +815,8 @@ type (
 		ClearBufferedEvents           bool
 		NewBufferedReplicationTask    *BufferedReplicationTask
 		DeleteBufferedReplicationTask *int64
+		// Checksum of the mutable state after the update, it is cleared if empty
+		Checksum checksum.Checksum
 		//Optional. It is to suggest a binary encoding type to serialize history events
 		Encoding common.EncodingType
 	}
@@ -778,6 +836,8 @@ type (
 		InsertRequestCancelInfos  []*RequestCancelInfo
 		InsertSignalInfos         []*SignalInfo
 		InsertSignalRequestedIDs  []string
+		// Checksum of the mutable state after the reset, it is cleared if empty
+		Checksum checksum.Checksum
 		//Optional. It is to suggest a binary encoding type to serialize history events
 		Encoding common.EncodingType
 	}
@@ -1016,6 +1076,8 @@ type (
 		PageSize int
 		// Token to continue reading next page of history append transactions.  Pass in empty slice for first page
 		NextPageToken []byte
+		// ReverseOrder returns the history events newest first, it is ignored when reading by batch
+		ReverseOrder bool
 	}
 
 	// GetWorkflowExecutionHistoryResponse is the response to GetWorkflowExecutionHistoryRequest
@@ -1068,6 +1130,8 @@ type (
 		EmitMetric     bool
 		ArchivalBucket string
 		ArchivalStatus workflow.ArchivalStatus
+		// ArchivalTargetBucket is the bucket archived histories are being migrated to, only set while migrating
+		ArchivalTargetBucket string
 	}
 
 	// DomainReplicationConfig describes the cross DC domain replication configuration
@@ -1250,6 +1314,8 @@ type (
 		NextPageToken []byte
 		// The shard to get history branch data
 		ShardID *int
+		// ReverseOrder returns the history events newest first, it is ignored when reading by batch
+		ReverseOrder bool
 	}
 
 	// ReadHistoryBranchResponse is the response to ReadHistoryBranchRequest
@@ -1348,6 +1414,53 @@ type (
 		Size int
 	}
 
+	// ClusterAddress is the frontend address of a cluster
+	ClusterAddress struct {
+		RPCName    string
+		RPCAddress string
+	}
+
+	// ClusterMetadata is the static configuration of a cluster, persisted at the first startup of the
+	// cluster and validated at every subsequent startup. Remote clusters can be added at runtime
+	ClusterMetadata struct {
+		ClusterName              string
+		FailoverVersionIncrement int64
+		// InitialFailoverVersions contains all cluster name -> corresponding initial failover version
+		InitialFailoverVersions map[string]int64
+		// ClusterAddresses contains all cluster name -> corresponding frontend address
+		ClusterAddresses map[string]ClusterAddress
+		// SearchAttributes contains the search attribute keys added at runtime -> the int value of their IndexedValueType
+		SearchAttributes map[string]int32
+		NumHistoryShards int
+		// Version is incremented by every update of the metadata
+		Version int64
+	}
+
+	// InitializeClusterMetadataRequest is used to persist the metadata of the cluster, unless
+	// metadata is persisted already
+	InitializeClusterMetadataRequest struct {
+		ClusterMetadata ClusterMetadata
+	}
+
+	// InitializeClusterMetadataResponse is the response to InitializeClusterMetadataRequest
+	InitializeClusterMetadataResponse struct {
+		// ClusterMetadata is the persisted metadata, which is the metadata of the request if Created
+		ClusterMetadata ClusterMetadata
+		Created         bool
+	}
+
+	// GetClusterMetadataResponse is the response to GetClusterMetadata
+	GetClusterMetadataResponse struct {
+		ClusterMetadata ClusterMetadata
+	}
+
+	// UpdateClusterMetadataRequest is used to update the persisted metadata of the cluster, the
+	// update fails with ConditionFailedError if the persisted version is not PreviousVersion
+	UpdateClusterMetadataRequest struct {
+		ClusterMetadata ClusterMetadata
+		PreviousVersion int64
+	}
+
 	// Closeable is an interface for any entity that supports a close operation to release resources
 	Closeable interface {
 		Close()
@@ -1368,27 +1481,28 @@ type (
 		GetName() string
 		GetShardID() int
 
-		CreateWorkflowExecution(request *CreateWorkflowExecutionRequest) (*CreateWorkflowExecutionResponse, error)
-		GetWorkflowExecution(request *GetWorkflowExecutionRequest) (*GetWorkflowExecutionResponse, error)
-		UpdateWorkflowExecution(request *UpdateWorkflowExecutionRequest) (*UpdateWorkflowExecutionResponse, error)
-		ResetMutableState(request *ResetMutableStateRequest) error
-		ResetWorkflowExecution(request *ResetWorkflowExecutionRequest) error
-		DeleteWorkflowExecution(request *DeleteWorkflowExecutionRequest) error
-		GetCurrentExecution(request *GetCurrentExecutionRequest) (*GetCurrentExecutionResponse, error)
+		CreateWorkflowExecution(ctx context.Context, request *CreateWorkflowExecutionRequest) (*CreateWorkflowExecutionResponse, error)
+		GetWorkflowExecution(ctx context.Context, request *GetWorkflowExecutionRequest) (*GetWorkflowExecutionResponse, error)
+		GetWorkflowExecutions(ctx context.Context, request *GetWorkflowExecutionsRequest) (*GetWorkflowExecutionsResponse, error)
+		UpdateWorkflowExecution(ctx context.Context, request *UpdateWorkflowExecutionRequest) (*UpdateWorkflowExecutionResponse, error)
+		ResetMutableState(ctx context.Context, request *ResetMutableStateRequest) error
+		ResetWorkflowExecution(ctx context.Context, request *ResetWorkflowExecutionRequest) error
+		DeleteWorkflowExecution(ctx context.Context, request *DeleteWorkflowExecutionRequest) error
+		GetCurrentExecution(ctx context.Context, request *GetCurrentExecutionRequest) (*GetCurrentExecutionResponse, error)
 
 		// Transfer task related methods
-		GetTransferTasks(request *GetTransferTasksRequest) (*GetTransferTasksResponse, error)
-		CompleteTransferTask(request *CompleteTransferTaskRequest) error
-		RangeCompleteTransferTask(request *RangeCompleteTransferTaskRequest) error
+		GetTransferTasks(ctx context.Context, request *GetTransferTasksRequest) (*GetTransferTasksResponse, error)
+		CompleteTransferTask(ctx context.Context, request *CompleteTransferTaskRequest) error
+		RangeCompleteTransferTask(ctx context.Context, request *RangeCompleteTransferTaskRequest) error
 
 		// Replication task related methods
-		GetReplicationTasks(request *GetReplicationTasksRequest) (*GetReplicationTasksResponse, error)
-		CompleteReplicationTask(request *CompleteReplicationTaskRequest) error
+		GetReplicationTasks(ctx context.Context, request *GetReplicationTasksRequest) (*GetReplicationTasksResponse, error)
+		CompleteReplicationTask(ctx context.Context, request *CompleteReplicationTaskRequest) error
 
 		// Timer related methods.
-		GetTimerIndexTasks(request *GetTimerIndexTasksRequest) (*GetTimerIndexTasksResponse, error)
-		CompleteTimerTask(request *CompleteTimerTaskRequest) error
-		RangeCompleteTimerTask(request *RangeCompleteTimerTaskRequest) error
+		GetTimerIndexTasks(ctx context.Context, request *GetTimerIndexTasksRequest) (*GetTimerIndexTasksResponse, error)
+		CompleteTimerTask(ctx context.Context, request *CompleteTimerTaskRequest) error
+		RangeCompleteTimerTask(ctx context.Context, request *RangeCompleteTimerTaskRequest) error
 	}
 
 	// ExecutionManagerFactory creates an instance of ExecutionManager for a given shard
@@ -1467,13 +1581,22 @@ type (
 	MetadataManager interface {
 		Closeable
 		GetName() string
-		CreateDomain(request *CreateDomainRequest) (*CreateDomainResponse, error)
-		GetDomain(request *GetDomainRequest) (*GetDomainResponse, error)
-		UpdateDomain(request *UpdateDomainRequest) error
-		DeleteDomain(request *DeleteDomainRequest) error
-		DeleteDomainByName(request *DeleteDomainByNameRequest) error
-		ListDomains(request *ListDomainsRequest) (*ListDomainsResponse, error)
-		GetMetadata() (*GetMetadataResponse, error)
+		CreateDomain(ctx context.Context, request *CreateDomainRequest) (*CreateDomainResponse, error)
+		GetDomain(ctx context.Context, request *GetDomainRequest) (*GetDomainResponse, error)
+		UpdateDomain(ctx context.Context, request *UpdateDomainRequest) error
+		DeleteDomain(ctx context.Context, request *DeleteDomainRequest) error
+		DeleteDomainByName(ctx context.Context, request *DeleteDomainByNameRequest) error
+		ListDomains(ctx context.Context, request *ListDomainsRequest) (*ListDomainsResponse, error)
+		GetMetadata(ctx context.Context) (*GetMetadataResponse, error)
+	}
+
+	// ClusterMetadataManager is used to manage the persisted metadata of the cluster
+	ClusterMetadataManager interface {
+		Closeable
+		GetName() string
+		InitializeClusterMetadata(ctx context.Context, request *InitializeClusterMetadataRequest) (*InitializeClusterMetadataResponse, error)
+		GetClusterMetadata(ctx context.Context) (*GetClusterMetadataResponse, error)
+		UpdateClusterMetadata(ctx context.Context, request *UpdateClusterMetadataRequest) error
 	}
 )
 
@@ -1610,6 +1733,41 @@ func (a *RecordWorkflowStartedTask) SetVisibilityTimestamp(timestamp time.Time)
 	a.VisibilityTimestamp = timestamp
 }
 
+// GetType returns the type of the upsert workflow labels task
+func (u *UpsertWorkflowLabelsTask) GetType() int {
+	return TransferTaskTypeUpsertWorkflowLabels
+}
+
+// GetVersion returns the version of the upsert workflow labels task
+func (u *UpsertWorkflowLabelsTask) GetVersion() int64 {
+	return u.Version
+}
+
+// SetVersion returns the version of the upsert workflow labels task
+func (u *UpsertWorkflowLabelsTask) SetVersion(version int64) {
+	u.Version = version
+}
+
+// GetTaskID returns the sequence ID of the upsert workflow labels task
+func (u *UpsertWorkflowLabelsTask) GetTaskID() int64 {
+	return u.TaskID
+}
+
+// SetTaskID sets the sequence ID of the upsert workflow labels task
+func (u *UpsertWorkflowLabelsTask) SetTaskID(id int64) {
+	u.TaskID = id
+}
+
+// GetVisibilityTimestamp get the visibility timestamp
+func (u *UpsertWorkflowLabelsTask) GetVisibilityTimestamp() time.Time {
+	return u.VisibilityTimestamp
+}
+
+// SetVisibilityTimestamp set the visibility timestamp
+func (u *UpsertWorkflowLabelsTask) SetVisibilityTimestamp(timestamp time.Time) {
+	u.VisibilityTimestamp = timestamp
+}
+
 // GetType returns the type of the close execution task
 func (a *CloseExecutionTask) GetType() int {
 	return TransferTaskTypeCloseExecution
@@ -1855,6 +2013,41 @@ func (r *WorkflowBackoffTimerTask) SetVisibilityTimestamp(t time.Time) {
 	r.VisibilityTimestamp = t
 }
 
+// GetType returns the type of the delayed signal task
+func (r *DelayedSignalTask) GetType() int {
+	return TaskTypeDelayedSignal
+}
+
+// GetVersion returns the version of the delayed signal task
+func (r *DelayedSignalTask) GetVersion() int64 {
+	return r.Version
+}
+
+// SetVersion returns the version of the delayed signal task
+func (r *DelayedSignalTask) SetVersion(version int64) {
+	r.Version = version
+}
+
+// GetTaskID returns the sequence ID.
+func (r *DelayedSignalTask) GetTaskID() int64 {
+	return r.TaskID
+}
+
+// SetTaskID sets the sequence ID.
+func (r *DelayedSignalTask) SetTaskID(id int64) {
+	r.TaskID = id
+}
+
+// GetVisibilityTimestamp gets the visibility time stamp
+func (r *DelayedSignalTask) GetVisibilityTimestamp() time.Time {
+	return r.VisibilityTimestamp
+}
+
+// SetVisibilityTimestamp gets the visibility time stamp
+func (r *DelayedSignalTask) SetVisibilityTimestamp(t time.Time) {
+	r.VisibilityTimestamp = t
+}
+
 // GetType returns the type of the timeout task.
 func (u *WorkflowTimeoutTask) GetType() int {
 	return TaskTypeWorkflowTimeout
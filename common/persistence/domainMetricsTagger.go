@@ -0,0 +1,96 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"sync"
+
+	"github.com/uber-go/tally"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/service/config"
+)
+
+const (
+	// domainMetricsOtherValue is the domain id tag of the operations whose domain
+	// is beyond the max number of tagged domains
+	domainMetricsOtherValue = "other"
+)
+
+type (
+	// DomainMetricsTagger emits the persistence request and latency metrics tagged
+	// with the domain of the operation, so the datastore load can be attributed to
+	// domains. The number of distinct tag values is bounded by the config
+	DomainMetricsTagger struct {
+		sync.RWMutex
+		metricClient metrics.Client
+		config       *config.DomainMetricsConfig
+		domains      map[string]struct{}
+	}
+)
+
+var noopStopwatch = tally.NoopScope.Timer("noop").Start()
+
+// NewDomainMetricsTagger creates a tagger for persistence domain metrics, a nil
+// config disables the tagging
+func NewDomainMetricsTagger(metricClient metrics.Client, config *config.DomainMetricsConfig) *DomainMetricsTagger {
+	return &DomainMetricsTagger{
+		metricClient: metricClient,
+		config:       config,
+		domains:      make(map[string]struct{}),
+	}
+}
+
+// StartTimer increments the requests counter and starts the latency timer of the domain
+// tagged scope, the returned stopwatch is a noop if the tagging is disabled or the domain
+// is unknown
+func (t *DomainMetricsTagger) StartTimer(scope int, requests int, latency int, domainID string) tally.Stopwatch {
+	if t == nil || t.config == nil || !t.config.Enabled() || domainID == "" {
+		return noopStopwatch
+	}
+
+	taggedScope := t.metricClient.Scope(scope, metrics.DomainIDTag(t.tagValue(domainID)))
+	taggedScope.IncCounter(requests)
+	return taggedScope.StartTimer(latency)
+}
+
+func (t *DomainMetricsTagger) startTimer(scope int, domainID string) tally.Stopwatch {
+	return t.StartTimer(scope, metrics.PersistenceRequests, metrics.PersistenceLatency, domainID)
+}
+
+func (t *DomainMetricsTagger) tagValue(domainID string) string {
+	t.RLock()
+	_, ok := t.domains[domainID]
+	t.RUnlock()
+	if ok {
+		return domainID
+	}
+
+	t.Lock()
+	defer t.Unlock()
+	if _, ok := t.domains[domainID]; ok {
+		return domainID
+	}
+	if len(t.domains) >= t.config.MaxDomains() {
+		return domainMetricsOtherValue
+	}
+	t.domains[domainID] = struct{}{}
+	return domainID
+}
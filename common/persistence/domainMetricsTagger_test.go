@@ -0,0 +1,99 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-go/tally"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/service/config"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	domainMetricsTaggerSuite struct {
+		suite.Suite
+		// override suite.Suite.Assertions with require.Assertions; this means that s.NotNil(nil) will stop the test,
+		// not merely log an error
+		*require.Assertions
+		testScope tally.TestScope
+		enabled   bool
+		tagger    *DomainMetricsTagger
+	}
+)
+
+func TestDomainMetricsTaggerSuite(t *testing.T) {
+	s := new(domainMetricsTaggerSuite)
+	suite.Run(t, s)
+}
+
+func (s *domainMetricsTaggerSuite) SetupTest() {
+	// Have to define our overridden assertions in the test setup. If we did it earlier, s.T() will return nil
+	s.Assertions = require.New(s.T())
+	s.testScope = tally.NewTestScope("", nil)
+	s.enabled = true
+	s.tagger = NewDomainMetricsTagger(metrics.NewClient(s.testScope, metrics.History), &config.DomainMetricsConfig{
+		Enabled:    func(opts ...dynamicconfig.FilterOption) bool { return s.enabled },
+		MaxDomains: dynamicconfig.GetIntPropertyFn(2),
+	})
+}
+
+func (s *domainMetricsTaggerSuite) TestTagsDomain() {
+	s.tagger.startTimer(metrics.PersistenceGetWorkflowExecutionScope, "domain-1").Stop()
+	s.tagger.startTimer(metrics.PersistenceGetWorkflowExecutionScope, "domain-1").Stop()
+	s.Equal(map[string]int64{"domain-1": 2}, s.requests())
+}
+
+func (s *domainMetricsTaggerSuite) TestMaxDomains() {
+	s.tagger.startTimer(metrics.PersistenceGetWorkflowExecutionScope, "domain-1").Stop()
+	s.tagger.startTimer(metrics.PersistenceGetWorkflowExecutionScope, "domain-2").Stop()
+	s.tagger.startTimer(metrics.PersistenceGetWorkflowExecutionScope, "domain-3").Stop()
+	s.tagger.startTimer(metrics.PersistenceGetWorkflowExecutionScope, "domain-4").Stop()
+	s.tagger.startTimer(metrics.PersistenceGetWorkflowExecutionScope, "domain-1").Stop()
+	s.Equal(map[string]int64{"domain-1": 2, "domain-2": 1, domainMetricsOtherValue: 2}, s.requests())
+}
+
+func (s *domainMetricsTaggerSuite) TestDisabled() {
+	s.enabled = false
+	s.tagger.startTimer(metrics.PersistenceGetWorkflowExecutionScope, "domain-1").Stop()
+	s.Empty(s.requests())
+
+	s.enabled = true
+	s.tagger.startTimer(metrics.PersistenceGetWorkflowExecutionScope, "").Stop()
+	s.Empty(s.requests())
+
+	var tagger *DomainMetricsTagger
+	tagger.startTimer(metrics.PersistenceGetWorkflowExecutionScope, "domain-1").Stop()
+	NewDomainMetricsTagger(nil, nil).startTimer(metrics.PersistenceGetWorkflowExecutionScope, "domain-1").Stop()
+}
+
+func (s *domainMetricsTaggerSuite) requests() map[string]int64 {
+	result := make(map[string]int64)
+	for _, c := range s.testScope.Snapshot().Counters() {
+		if domainID, ok := c.Tags()["domain_id"]; ok && c.Name() == "persistence_requests" {
+			result[domainID] += c.Value()
+		}
+	}
+	return result
+}
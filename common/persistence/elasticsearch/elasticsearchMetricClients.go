@@ -21,6 +21,7 @@
 package elasticsearch
 
 import (
+	"context"
 	"github.com/uber-common/bark"
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common/logging"
@@ -29,19 +30,21 @@ import (
 )
 
 type visibilityMetricsClient struct {
-	metricClient metrics.Client
-	persistence  p.VisibilityManager
-	logger       bark.Logger
+	metricClient  metrics.Client
+	domainMetrics *p.DomainMetricsTagger
+	persistence   p.VisibilityManager
+	logger        bark.Logger
 }
 
 var _ p.VisibilityManager = (*visibilityMetricsClient)(nil)
 
 // NewVisibilityMetricsClient wrap visibility client with metrics
-func NewVisibilityMetricsClient(persistence p.VisibilityManager, metricClient metrics.Client, logger bark.Logger) p.VisibilityManager {
+func NewVisibilityMetricsClient(persistence p.VisibilityManager, metricClient metrics.Client, domainMetrics *p.DomainMetricsTagger, logger bark.Logger) p.VisibilityManager {
 	return &visibilityMetricsClient{
-		persistence:  persistence,
-		metricClient: metricClient,
-		logger:       logger,
+		persistence:   persistence,
+		metricClient:  metricClient,
+		domainMetrics: domainMetrics,
+		logger:        logger,
 	}
 }
 
@@ -49,12 +52,14 @@ func (p *visibilityMetricsClient) GetName() string {
 	return p.persistence.GetName()
 }
 
-func (p *visibilityMetricsClient) RecordWorkflowExecutionStarted(request *p.RecordWorkflowExecutionStartedRequest) error {
+func (p *visibilityMetricsClient) RecordWorkflowExecutionStarted(ctx context.Context, request *p.RecordWorkflowExecutionStartedRequest) error {
 	p.metricClient.IncCounter(metrics.ElasticsearchRecordWorkflowExecutionStartedScope, metrics.ElasticsearchRequests)
 
 	sw := p.metricClient.StartTimer(metrics.ElasticsearchRecordWorkflowExecutionStartedScope, metrics.ElasticsearchLatency)
-	err := p.persistence.RecordWorkflowExecutionStarted(request)
+	domainSw := p.domainMetrics.StartTimer(metrics.ElasticsearchRecordWorkflowExecutionStartedScope, metrics.ElasticsearchRequests, metrics.ElasticsearchLatency, request.DomainUUID)
+	err := p.persistence.RecordWorkflowExecutionStarted(ctx, request)
 	sw.Stop()
+	domainSw.Stop()
 
 	if err != nil {
 		p.updateErrorMetric(metrics.ElasticsearchRecordWorkflowExecutionStartedScope, err)
@@ -63,12 +68,14 @@ func (p *visibilityMetricsClient) RecordWorkflowExecutionStarted(request *p.Reco
 	return err
 }
 
-func (p *visibilityMetricsClient) RecordWorkflowExecutionClosed(request *p.RecordWorkflowExecutionClosedRequest) error {
+func (p *visibilityMetricsClient) RecordWorkflowExecutionClosed(ctx context.Context, request *p.RecordWorkflowExecutionClosedRequest) error {
 	p.metricClient.IncCounter(metrics.ElasticsearchRecordWorkflowExecutionClosedScope, metrics.ElasticsearchRequests)
 
 	sw := p.metricClient.StartTimer(metrics.ElasticsearchRecordWorkflowExecutionClosedScope, metrics.ElasticsearchLatency)
-	err := p.persistence.RecordWorkflowExecutionClosed(request)
+	domainSw := p.domainMetrics.StartTimer(metrics.ElasticsearchRecordWorkflowExecutionClosedScope, metrics.ElasticsearchRequests, metrics.ElasticsearchLatency, request.DomainUUID)
+	err := p.persistence.RecordWorkflowExecutionClosed(ctx, request)
 	sw.Stop()
+	domainSw.Stop()
 
 	if err != nil {
 		p.updateErrorMetric(metrics.ElasticsearchRecordWorkflowExecutionClosedScope, err)
@@ -77,12 +84,14 @@ func (p *visibilityMetricsClient) RecordWorkflowExecutionClosed(request *p.Recor
 	return err
 }
 
-func (p *visibilityMetricsClient) ListOpenWorkflowExecutions(request *p.ListWorkflowExecutionsRequest) (*p.ListWorkflowExecutionsResponse, error) {
+func (p *visibilityMetricsClient) ListOpenWorkflowExecutions(ctx context.Context, request *p.ListWorkflowExecutionsRequest) (*p.ListWorkflowExecutionsResponse, error) {
 	p.metricClient.IncCounter(metrics.ElasticsearchListOpenWorkflowExecutionsScope, metrics.ElasticsearchRequests)
 
 	sw := p.metricClient.StartTimer(metrics.ElasticsearchListOpenWorkflowExecutionsScope, metrics.ElasticsearchLatency)
-	response, err := p.persistence.ListOpenWorkflowExecutions(request)
+	domainSw := p.domainMetrics.StartTimer(metrics.ElasticsearchListOpenWorkflowExecutionsScope, metrics.ElasticsearchRequests, metrics.ElasticsearchLatency, request.DomainUUID)
+	response, err := p.persistence.ListOpenWorkflowExecutions(ctx, request)
 	sw.Stop()
+	domainSw.Stop()
 
 	if err != nil {
 		p.updateErrorMetric(metrics.ElasticsearchListOpenWorkflowExecutionsScope, err)
@@ -91,12 +100,14 @@ func (p *visibilityMetricsClient) ListOpenWorkflowExecutions(request *p.ListWork
 	return response, err
 }
 
-func (p *visibilityMetricsClient) ListClosedWorkflowExecutions(request *p.ListWorkflowExecutionsRequest) (*p.ListWorkflowExecutionsResponse, error) {
+func (p *visibilityMetricsClient) ListClosedWorkflowExecutions(ctx context.Context, request *p.ListWorkflowExecutionsRequest) (*p.ListWorkflowExecutionsResponse, error) {
 	p.metricClient.IncCounter(metrics.ElasticsearchListClosedWorkflowExecutionsScope, metrics.ElasticsearchRequests)
 
 	sw := p.metricClient.StartTimer(metrics.ElasticsearchListClosedWorkflowExecutionsScope, metrics.ElasticsearchLatency)
-	response, err := p.persistence.ListClosedWorkflowExecutions(request)
+	domainSw := p.domainMetrics.StartTimer(metrics.ElasticsearchListClosedWorkflowExecutionsScope, metrics.ElasticsearchRequests, metrics.ElasticsearchLatency, request.DomainUUID)
+	response, err := p.persistence.ListClosedWorkflowExecutions(ctx, request)
 	sw.Stop()
+	domainSw.Stop()
 
 	if err != nil {
 		p.updateErrorMetric(metrics.ElasticsearchListClosedWorkflowExecutionsScope, err)
@@ -105,12 +116,14 @@ func (p *visibilityMetricsClient) ListClosedWorkflowExecutions(request *p.ListWo
 	return response, err
 }
 
-func (p *visibilityMetricsClient) ListOpenWorkflowExecutionsByType(request *p.ListWorkflowExecutionsByTypeRequest) (*p.ListWorkflowExecutionsResponse, error) {
+func (p *visibilityMetricsClient) ListOpenWorkflowExecutionsByType(ctx context.Context, request *p.ListWorkflowExecutionsByTypeRequest) (*p.ListWorkflowExecutionsResponse, error) {
 	p.metricClient.IncCounter(metrics.ElasticsearchListOpenWorkflowExecutionsByTypeScope, metrics.ElasticsearchRequests)
 
 	sw := p.metricClient.StartTimer(metrics.ElasticsearchListOpenWorkflowExecutionsByTypeScope, metrics.ElasticsearchLatency)
-	response, err := p.persistence.ListOpenWorkflowExecutionsByType(request)
+	domainSw := p.domainMetrics.StartTimer(metrics.ElasticsearchListOpenWorkflowExecutionsByTypeScope, metrics.ElasticsearchRequests, metrics.ElasticsearchLatency, request.DomainUUID)
+	response, err := p.persistence.ListOpenWorkflowExecutionsByType(ctx, request)
 	sw.Stop()
+	domainSw.Stop()
 
 	if err != nil {
 		p.updateErrorMetric(metrics.ElasticsearchListOpenWorkflowExecutionsByTypeScope, err)
@@ -119,12 +132,14 @@ func (p *visibilityMetricsClient) ListOpenWorkflowExecutionsByType(request *p.Li
 	return response, err
 }
 
-func (p *visibilityMetricsClient) ListClosedWorkflowExecutionsByType(request *p.ListWorkflowExecutionsByTypeRequest) (*p.ListWorkflowExecutionsResponse, error) {
+func (p *visibilityMetricsClient) ListClosedWorkflowExecutionsByType(ctx context.Context, request *p.ListWorkflowExecutionsByTypeRequest) (*p.ListWorkflowExecutionsResponse, error) {
 	p.metricClient.IncCounter(metrics.ElasticsearchListClosedWorkflowExecutionsByTypeScope, metrics.ElasticsearchRequests)
 
 	sw := p.metricClient.StartTimer(metrics.ElasticsearchListClosedWorkflowExecutionsByTypeScope, metrics.ElasticsearchLatency)
-	response, err := p.persistence.ListClosedWorkflowExecutionsByType(request)
+	domainSw := p.domainMetrics.StartTimer(metrics.ElasticsearchListClosedWorkflowExecutionsByTypeScope, metrics.ElasticsearchRequests, metrics.ElasticsearchLatency, request.DomainUUID)
+	response, err := p.persistence.ListClosedWorkflowExecutionsByType(ctx, request)
 	sw.Stop()
+	domainSw.Stop()
 
 	if err != nil {
 		p.updateErrorMetric(metrics.ElasticsearchListClosedWorkflowExecutionsByTypeScope, err)
@@ -133,12 +148,14 @@ func (p *visibilityMetricsClient) ListClosedWorkflowExecutionsByType(request *p.
 	return response, err
 }
 
-func (p *visibilityMetricsClient) ListOpenWorkflowExecutionsByWorkflowID(request *p.ListWorkflowExecutionsByWorkflowIDRequest) (*p.ListWorkflowExecutionsResponse, error) {
+func (p *visibilityMetricsClient) ListOpenWorkflowExecutionsByWorkflowID(ctx context.Context, request *p.ListWorkflowExecutionsByWorkflowIDRequest) (*p.ListWorkflowExecutionsResponse, error) {
 	p.metricClient.IncCounter(metrics.ElasticsearchListOpenWorkflowExecutionsByWorkflowIDScope, metrics.ElasticsearchRequests)
 
 	sw := p.metricClient.StartTimer(metrics.ElasticsearchListOpenWorkflowExecutionsByWorkflowIDScope, metrics.ElasticsearchLatency)
-	response, err := p.persistence.ListOpenWorkflowExecutionsByWorkflowID(request)
+	domainSw := p.domainMetrics.StartTimer(metrics.ElasticsearchListOpenWorkflowExecutionsByWorkflowIDScope, metrics.ElasticsearchRequests, metrics.ElasticsearchLatency, request.DomainUUID)
+	response, err := p.persistence.ListOpenWorkflowExecutionsByWorkflowID(ctx, request)
 	sw.Stop()
+	domainSw.Stop()
 
 	if err != nil {
 		p.updateErrorMetric(metrics.ElasticsearchListOpenWorkflowExecutionsByWorkflowIDScope, err)
@@ -147,12 +164,14 @@ func (p *visibilityMetricsClient) ListOpenWorkflowExecutionsByWorkflowID(request
 	return response, err
 }
 
-func (p *visibilityMetricsClient) ListClosedWorkflowExecutionsByWorkflowID(request *p.ListWorkflowExecutionsByWorkflowIDRequest) (*p.ListWorkflowExecutionsResponse, error) {
+func (p *visibilityMetricsClient) ListClosedWorkflowExecutionsByWorkflowID(ctx context.Context, request *p.ListWorkflowExecutionsByWorkflowIDRequest) (*p.ListWorkflowExecutionsResponse, error) {
 	p.metricClient.IncCounter(metrics.ElasticsearchListClosedWorkflowExecutionsByWorkflowIDScope, metrics.ElasticsearchRequests)
 
 	sw := p.metricClient.StartTimer(metrics.ElasticsearchListClosedWorkflowExecutionsByWorkflowIDScope, metrics.ElasticsearchLatency)
-	response, err := p.persistence.ListClosedWorkflowExecutionsByWorkflowID(request)
+	domainSw := p.domainMetrics.StartTimer(metrics.ElasticsearchListClosedWorkflowExecutionsByWorkflowIDScope, metrics.ElasticsearchRequests, metrics.ElasticsearchLatency, request.DomainUUID)
+	response, err := p.persistence.ListClosedWorkflowExecutionsByWorkflowID(ctx, request)
 	sw.Stop()
+	domainSw.Stop()
 
 	if err != nil {
 		p.updateErrorMetric(metrics.ElasticsearchListClosedWorkflowExecutionsByWorkflowIDScope, err)
@@ -161,12 +180,14 @@ func (p *visibilityMetricsClient) ListClosedWorkflowExecutionsByWorkflowID(reque
 	return response, err
 }
 
-func (p *visibilityMetricsClient) ListClosedWorkflowExecutionsByStatus(request *p.ListClosedWorkflowExecutionsByStatusRequest) (*p.ListWorkflowExecutionsResponse, error) {
+func (p *visibilityMetricsClient) ListClosedWorkflowExecutionsByStatus(ctx context.Context, request *p.ListClosedWorkflowExecutionsByStatusRequest) (*p.ListWorkflowExecutionsResponse, error) {
 	p.metricClient.IncCounter(metrics.ElasticsearchListClosedWorkflowExecutionsByStatusScope, metrics.ElasticsearchRequests)
 
 	sw := p.metricClient.StartTimer(metrics.ElasticsearchListClosedWorkflowExecutionsByStatusScope, metrics.ElasticsearchLatency)
-	response, err := p.persistence.ListClosedWorkflowExecutionsByStatus(request)
+	domainSw := p.domainMetrics.StartTimer(metrics.ElasticsearchListClosedWorkflowExecutionsByStatusScope, metrics.ElasticsearchRequests, metrics.ElasticsearchLatency, request.DomainUUID)
+	response, err := p.persistence.ListClosedWorkflowExecutionsByStatus(ctx, request)
 	sw.Stop()
+	domainSw.Stop()
 
 	if err != nil {
 		p.updateErrorMetric(metrics.ElasticsearchListClosedWorkflowExecutionsByStatusScope, err)
@@ -175,12 +196,14 @@ func (p *visibilityMetricsClient) ListClosedWorkflowExecutionsByStatus(request *
 	return response, err
 }
 
-func (p *visibilityMetricsClient) GetClosedWorkflowExecution(request *p.GetClosedWorkflowExecutionRequest) (*p.GetClosedWorkflowExecutionResponse, error) {
+func (p *visibilityMetricsClient) GetClosedWorkflowExecution(ctx context.Context, request *p.GetClosedWorkflowExecutionRequest) (*p.GetClosedWorkflowExecutionResponse, error) {
 	p.metricClient.IncCounter(metrics.ElasticsearchGetClosedWorkflowExecutionScope, metrics.ElasticsearchRequests)
 
 	sw := p.metricClient.StartTimer(metrics.ElasticsearchGetClosedWorkflowExecutionScope, metrics.ElasticsearchLatency)
-	response, err := p.persistence.GetClosedWorkflowExecution(request)
+	domainSw := p.domainMetrics.StartTimer(metrics.ElasticsearchGetClosedWorkflowExecutionScope, metrics.ElasticsearchRequests, metrics.ElasticsearchLatency, request.DomainUUID)
+	response, err := p.persistence.GetClosedWorkflowExecution(ctx, request)
 	sw.Stop()
+	domainSw.Stop()
 
 	if err != nil {
 		p.updateErrorMetric(metrics.ElasticsearchGetClosedWorkflowExecutionScope, err)
@@ -189,7 +212,39 @@ func (p *visibilityMetricsClient) GetClosedWorkflowExecution(request *p.GetClose
 	return response, err
 }
 
-func (v *visibilityMetricsClient) DeleteWorkflowExecution(request *p.VisibilityDeleteWorkflowExecutionRequest) error {
+func (p *visibilityMetricsClient) GetWorkflowExecutionStatistics(ctx context.Context, request *p.GetWorkflowExecutionStatisticsRequest) (*p.GetWorkflowExecutionStatisticsResponse, error) {
+	p.metricClient.IncCounter(metrics.ElasticsearchGetWorkflowExecutionStatisticsScope, metrics.ElasticsearchRequests)
+
+	sw := p.metricClient.StartTimer(metrics.ElasticsearchGetWorkflowExecutionStatisticsScope, metrics.ElasticsearchLatency)
+	domainSw := p.domainMetrics.StartTimer(metrics.ElasticsearchGetWorkflowExecutionStatisticsScope, metrics.ElasticsearchRequests, metrics.ElasticsearchLatency, request.DomainUUID)
+	response, err := p.persistence.GetWorkflowExecutionStatistics(ctx, request)
+	sw.Stop()
+	domainSw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.ElasticsearchGetWorkflowExecutionStatisticsScope, err)
+	}
+
+	return response, err
+}
+
+func (p *visibilityMetricsClient) CountOpenWorkflowExecutions(ctx context.Context, request *p.CountWorkflowExecutionsRequest) (*p.CountWorkflowExecutionsResponse, error) {
+	p.metricClient.IncCounter(metrics.ElasticsearchCountOpenWorkflowExecutionsScope, metrics.ElasticsearchRequests)
+
+	sw := p.metricClient.StartTimer(metrics.ElasticsearchCountOpenWorkflowExecutionsScope, metrics.ElasticsearchLatency)
+	domainSw := p.domainMetrics.StartTimer(metrics.ElasticsearchCountOpenWorkflowExecutionsScope, metrics.ElasticsearchRequests, metrics.ElasticsearchLatency, request.DomainUUID)
+	response, err := p.persistence.CountOpenWorkflowExecutions(ctx, request)
+	sw.Stop()
+	domainSw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.ElasticsearchCountOpenWorkflowExecutionsScope, err)
+	}
+
+	return response, err
+}
+
+func (v *visibilityMetricsClient) DeleteWorkflowExecution(ctx context.Context, request *p.VisibilityDeleteWorkflowExecutionRequest) error {
 	return nil // not applicable for elastic search, which relies on retention policies for deletion
 }
 
@@ -201,6 +256,8 @@ func (p *visibilityMetricsClient) updateErrorMetric(scope int, err error) {
 	case *workflow.ServiceBusyError:
 		p.metricClient.IncCounter(scope, metrics.ElasticsearchErrBusyCounter)
 		p.metricClient.IncCounter(scope, metrics.ElasticsearchFailures)
+	case *workflow.EntityNotExistsError:
+		p.metricClient.IncCounter(scope, metrics.ElasticsearchErrEntityNotExistsCounter)
 	default:
 		p.logger.WithFields(bark.Fields{
 			logging.TagScope: scope,
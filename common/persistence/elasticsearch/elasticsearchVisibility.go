@@ -24,6 +24,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 
 	"github.com/olivere/elastic"
 	"github.com/pkg/errors"
@@ -38,6 +39,12 @@ import (
 
 const (
 	esPersistenceName = "elasticsearch"
+
+	statisticsByTypeAggregation        = "countsByType"
+	statisticsByCloseStatusAggregation = "countsByCloseStatus"
+	statisticsByCloseTimeAggregation   = "countsByCloseTime"
+	// statisticsMaxTermsBuckets caps the number of workflow types returned by a statistics query
+	statisticsMaxTermsBuckets = 1000
 )
 
 type (
@@ -60,11 +67,22 @@ type (
 		WorkflowID    string
 		RunID         string
 		WorkflowType  string
+		TaskList      string
 		StartTime     int64
 		ExecutionTime int64
 		CloseTime     int64
 		CloseStatus   workflow.WorkflowExecutionCloseStatus
 		HistoryLength int64
+		// only set for child workflows
+		ParentWorkflowID string
+		ParentRunID      string
+		// only set for terminated workflows
+		TerminateCause    *workflow.TerminationCause
+		TerminateReason   string
+		TerminateIdentity string
+		TerminateDetails  []byte
+		// indexed as key=value keywords
+		Labels []string
 	}
 )
 
@@ -92,15 +110,15 @@ func (v *esVisibilityManager) GetName() string {
 	return esPersistenceName
 }
 
-func (v *esVisibilityManager) RecordWorkflowExecutionStarted(request *p.RecordWorkflowExecutionStartedRequest) error {
+func (v *esVisibilityManager) RecordWorkflowExecutionStarted(ctx context.Context, request *p.RecordWorkflowExecutionStartedRequest) error {
 	return errOperationNotSupported
 }
 
-func (v *esVisibilityManager) RecordWorkflowExecutionClosed(request *p.RecordWorkflowExecutionClosedRequest) error {
+func (v *esVisibilityManager) RecordWorkflowExecutionClosed(ctx context.Context, request *p.RecordWorkflowExecutionClosedRequest) error {
 	return errOperationNotSupported
 }
 
-func (v *esVisibilityManager) ListOpenWorkflowExecutions(
+func (v *esVisibilityManager) ListOpenWorkflowExecutions(ctx context.Context,
 	request *p.ListWorkflowExecutionsRequest) (*p.ListWorkflowExecutionsResponse, error) {
 	token, err := v.getNextPageToken(request.NextPageToken)
 	if err != nil {
@@ -108,17 +126,18 @@ func (v *esVisibilityManager) ListOpenWorkflowExecutions(
 	}
 
 	isOpen := true
-	searchResult, err := v.getSearchResult(request, token, nil, isOpen)
+	if err := p.ValidateVisibilitySort(request, isOpen); err != nil {
+		return nil, err
+	}
+	searchResult, err := v.getSearchResult(ctx, request, token, nil, isOpen)
 	if err != nil {
-		return nil, &workflow.InternalServiceError{
-			Message: fmt.Sprintf("ListOpenWorkflowExecutions failed. Error: %v", err),
-		}
+		return nil, convertESError("ListOpenWorkflowExecutions", err)
 	}
 
-	return v.getListWorkflowExecutionsResponse(searchResult.Hits, token, isOpen, request.PageSize)
+	return v.getListWorkflowExecutionsResponse(searchResult.Hits, token, isOpen, getSortField(request, isOpen), request.PageSize)
 }
 
-func (v *esVisibilityManager) ListClosedWorkflowExecutions(
+func (v *esVisibilityManager) ListClosedWorkflowExecutions(ctx context.Context,
 	request *p.ListWorkflowExecutionsRequest) (*p.ListWorkflowExecutionsResponse, error) {
 
 	token, err := v.getNextPageToken(request.NextPageToken)
@@ -127,17 +146,18 @@ func (v *esVisibilityManager) ListClosedWorkflowExecutions(
 	}
 
 	isOpen := false
-	searchResult, err := v.getSearchResult(request, token, nil, isOpen)
+	if err := p.ValidateVisibilitySort(request, isOpen); err != nil {
+		return nil, err
+	}
+	searchResult, err := v.getSearchResult(ctx, request, token, nil, isOpen)
 	if err != nil {
-		return nil, &workflow.InternalServiceError{
-			Message: fmt.Sprintf("ListClosedWorkflowExecutions failed. Error: %v", err),
-		}
+		return nil, convertESError("ListClosedWorkflowExecutions", err)
 	}
 
-	return v.getListWorkflowExecutionsResponse(searchResult.Hits, token, isOpen, request.PageSize)
+	return v.getListWorkflowExecutionsResponse(searchResult.Hits, token, isOpen, getSortField(request, isOpen), request.PageSize)
 }
 
-func (v *esVisibilityManager) ListOpenWorkflowExecutionsByType(
+func (v *esVisibilityManager) ListOpenWorkflowExecutionsByType(ctx context.Context,
 	request *p.ListWorkflowExecutionsByTypeRequest) (*p.ListWorkflowExecutionsResponse, error) {
 
 	token, err := v.getNextPageToken(request.NextPageToken)
@@ -146,18 +166,19 @@ func (v *esVisibilityManager) ListOpenWorkflowExecutionsByType(
 	}
 
 	isOpen := true
+	if err := p.ValidateVisibilitySort(&request.ListWorkflowExecutionsRequest, isOpen); err != nil {
+		return nil, err
+	}
 	matchQuery := elastic.NewMatchQuery(es.WorkflowType, request.WorkflowTypeName)
-	searchResult, err := v.getSearchResult(&request.ListWorkflowExecutionsRequest, token, matchQuery, isOpen)
+	searchResult, err := v.getSearchResult(ctx, &request.ListWorkflowExecutionsRequest, token, matchQuery, isOpen)
 	if err != nil {
-		return nil, &workflow.InternalServiceError{
-			Message: fmt.Sprintf("ListOpenWorkflowExecutionsByType failed. Error: %v", err),
-		}
+		return nil, convertESError("ListOpenWorkflowExecutionsByType", err)
 	}
 
-	return v.getListWorkflowExecutionsResponse(searchResult.Hits, token, isOpen, request.PageSize)
+	return v.getListWorkflowExecutionsResponse(searchResult.Hits, token, isOpen, getSortField(&request.ListWorkflowExecutionsRequest, isOpen), request.PageSize)
 }
 
-func (v *esVisibilityManager) ListClosedWorkflowExecutionsByType(
+func (v *esVisibilityManager) ListClosedWorkflowExecutionsByType(ctx context.Context,
 	request *p.ListWorkflowExecutionsByTypeRequest) (*p.ListWorkflowExecutionsResponse, error) {
 
 	token, err := v.getNextPageToken(request.NextPageToken)
@@ -166,18 +187,19 @@ func (v *esVisibilityManager) ListClosedWorkflowExecutionsByType(
 	}
 
 	isOpen := false
+	if err := p.ValidateVisibilitySort(&request.ListWorkflowExecutionsRequest, isOpen); err != nil {
+		return nil, err
+	}
 	matchQuery := elastic.NewMatchQuery(es.WorkflowType, request.WorkflowTypeName)
-	searchResult, err := v.getSearchResult(&request.ListWorkflowExecutionsRequest, token, matchQuery, isOpen)
+	searchResult, err := v.getSearchResult(ctx, &request.ListWorkflowExecutionsRequest, token, matchQuery, isOpen)
 	if err != nil {
-		return nil, &workflow.InternalServiceError{
-			Message: fmt.Sprintf("ListClosedWorkflowExecutionsByType failed. Error: %v", err),
-		}
+		return nil, convertESError("ListClosedWorkflowExecutionsByType", err)
 	}
 
-	return v.getListWorkflowExecutionsResponse(searchResult.Hits, token, isOpen, request.PageSize)
+	return v.getListWorkflowExecutionsResponse(searchResult.Hits, token, isOpen, getSortField(&request.ListWorkflowExecutionsRequest, isOpen), request.PageSize)
 }
 
-func (v *esVisibilityManager) ListOpenWorkflowExecutionsByWorkflowID(
+func (v *esVisibilityManager) ListOpenWorkflowExecutionsByWorkflowID(ctx context.Context,
 	request *p.ListWorkflowExecutionsByWorkflowIDRequest) (*p.ListWorkflowExecutionsResponse, error) {
 
 	token, err := v.getNextPageToken(request.NextPageToken)
@@ -186,18 +208,19 @@ func (v *esVisibilityManager) ListOpenWorkflowExecutionsByWorkflowID(
 	}
 
 	isOpen := true
+	if err := p.ValidateVisibilitySort(&request.ListWorkflowExecutionsRequest, isOpen); err != nil {
+		return nil, err
+	}
 	matchQuery := elastic.NewMatchQuery(es.WorkflowID, request.WorkflowID)
-	searchResult, err := v.getSearchResult(&request.ListWorkflowExecutionsRequest, token, matchQuery, isOpen)
+	searchResult, err := v.getSearchResult(ctx, &request.ListWorkflowExecutionsRequest, token, matchQuery, isOpen)
 	if err != nil {
-		return nil, &workflow.InternalServiceError{
-			Message: fmt.Sprintf("ListOpenWorkflowExecutionsByWorkflowID failed. Error: %v", err),
-		}
+		return nil, convertESError("ListOpenWorkflowExecutionsByWorkflowID", err)
 	}
 
-	return v.getListWorkflowExecutionsResponse(searchResult.Hits, token, isOpen, request.PageSize)
+	return v.getListWorkflowExecutionsResponse(searchResult.Hits, token, isOpen, getSortField(&request.ListWorkflowExecutionsRequest, isOpen), request.PageSize)
 }
 
-func (v *esVisibilityManager) ListClosedWorkflowExecutionsByWorkflowID(
+func (v *esVisibilityManager) ListClosedWorkflowExecutionsByWorkflowID(ctx context.Context,
 	request *p.ListWorkflowExecutionsByWorkflowIDRequest) (*p.ListWorkflowExecutionsResponse, error) {
 
 	token, err := v.getNextPageToken(request.NextPageToken)
@@ -206,18 +229,19 @@ func (v *esVisibilityManager) ListClosedWorkflowExecutionsByWorkflowID(
 	}
 
 	isOpen := false
+	if err := p.ValidateVisibilitySort(&request.ListWorkflowExecutionsRequest, isOpen); err != nil {
+		return nil, err
+	}
 	matchQuery := elastic.NewMatchQuery(es.WorkflowID, request.WorkflowID)
-	searchResult, err := v.getSearchResult(&request.ListWorkflowExecutionsRequest, token, matchQuery, isOpen)
+	searchResult, err := v.getSearchResult(ctx, &request.ListWorkflowExecutionsRequest, token, matchQuery, isOpen)
 	if err != nil {
-		return nil, &workflow.InternalServiceError{
-			Message: fmt.Sprintf("ListClosedWorkflowExecutionsByWorkflowID failed. Error: %v", err),
-		}
+		return nil, convertESError("ListClosedWorkflowExecutionsByWorkflowID", err)
 	}
 
-	return v.getListWorkflowExecutionsResponse(searchResult.Hits, token, isOpen, request.PageSize)
+	return v.getListWorkflowExecutionsResponse(searchResult.Hits, token, isOpen, getSortField(&request.ListWorkflowExecutionsRequest, isOpen), request.PageSize)
 }
 
-func (v *esVisibilityManager) ListClosedWorkflowExecutionsByStatus(
+func (v *esVisibilityManager) ListClosedWorkflowExecutionsByStatus(ctx context.Context,
 	request *p.ListClosedWorkflowExecutionsByStatusRequest) (*p.ListWorkflowExecutionsResponse, error) {
 
 	token, err := v.getNextPageToken(request.NextPageToken)
@@ -226,18 +250,19 @@ func (v *esVisibilityManager) ListClosedWorkflowExecutionsByStatus(
 	}
 
 	isOpen := false
+	if err := p.ValidateVisibilitySort(&request.ListWorkflowExecutionsRequest, isOpen); err != nil {
+		return nil, err
+	}
 	matchQuery := elastic.NewMatchQuery(es.CloseStatus, int32(request.Status))
-	searchResult, err := v.getSearchResult(&request.ListWorkflowExecutionsRequest, token, matchQuery, isOpen)
+	searchResult, err := v.getSearchResult(ctx, &request.ListWorkflowExecutionsRequest, token, matchQuery, isOpen)
 	if err != nil {
-		return nil, &workflow.InternalServiceError{
-			Message: fmt.Sprintf("ListClosedWorkflowExecutionsByStatus failed. Error: %v", err),
-		}
+		return nil, convertESError("ListClosedWorkflowExecutionsByStatus", err)
 	}
 
-	return v.getListWorkflowExecutionsResponse(searchResult.Hits, token, isOpen, request.PageSize)
+	return v.getListWorkflowExecutionsResponse(searchResult.Hits, token, isOpen, getSortField(&request.ListWorkflowExecutionsRequest, isOpen), request.PageSize)
 }
 
-func (v *esVisibilityManager) GetClosedWorkflowExecution(
+func (v *esVisibilityManager) GetClosedWorkflowExecution(ctx context.Context,
 	request *p.GetClosedWorkflowExecutionRequest) (*p.GetClosedWorkflowExecutionResponse, error) {
 
 	matchDomainQuery := elastic.NewMatchQuery(es.DomainID, request.DomainUUID)
@@ -250,32 +275,123 @@ func (v *esVisibilityManager) GetClosedWorkflowExecution(
 		boolQuery = boolQuery.Must(matchRunIDQuery)
 	}
 
-	ctx := context.Background()
 	params := &es.SearchParameters{
 		Index: v.index,
 		Query: boolQuery,
 	}
 	searchResult, err := v.esClient.Search(ctx, params)
 	if err != nil {
-		return nil, &workflow.InternalServiceError{
-			Message: fmt.Sprintf("GetClosedWorkflowExecution failed. Error: %v", err),
-		}
+		return nil, convertESError("GetClosedWorkflowExecution", err)
 	}
 
-	response := &p.GetClosedWorkflowExecutionResponse{}
 	actualHits := searchResult.Hits.Hits
 	if len(actualHits) == 0 {
-		return response, nil
+		return nil, &workflow.EntityNotExistsError{
+			Message: fmt.Sprintf("Workflow execution not found.  WorkflowId: %v, RunId: %v",
+				request.Execution.GetWorkflowId(), request.Execution.GetRunId()),
+		}
+	}
+	execution := v.convertSearchResultToVisibilityRecord(actualHits[0], false)
+	if execution == nil {
+		return nil, &workflow.InternalServiceError{
+			Message: "GetClosedWorkflowExecution failed. Unable to decode visibility record",
+		}
 	}
-	response.Execution = v.convertSearchResultToVisibilityRecord(actualHits[0], false)
 
-	return response, nil
+	return &p.GetClosedWorkflowExecutionResponse{
+		Execution: execution,
+	}, nil
 }
 
-func (v *esVisibilityManager) DeleteWorkflowExecution(request *p.VisibilityDeleteWorkflowExecutionRequest) error {
+func (v *esVisibilityManager) DeleteWorkflowExecution(ctx context.Context, request *p.VisibilityDeleteWorkflowExecutionRequest) error {
 	return nil // not applicable for elastic search, which relies on retention policies for deletion
 }
 
+func (v *esVisibilityManager) GetWorkflowExecutionStatistics(ctx context.Context,
+	request *p.GetWorkflowExecutionStatisticsRequest) (*p.GetWorkflowExecutionStatisticsResponse, error) {
+
+	if err := v.checkQueryTimeRange(request.Domain, request.EarliestCloseTime, request.LatestCloseTime); err != nil {
+		return nil, err
+	}
+
+	matchDomainQuery := elastic.NewMatchQuery(es.DomainID, request.DomainUUID)
+	existClosedStatusQuery := elastic.NewExistsQuery(es.CloseStatus)
+	// ElasticSearch v6 is unable to precisely compare time, have to manually add resolution 1ms to time range.
+	rangeQuery := elastic.NewRangeQuery(es.CloseTime).
+		Gte(request.EarliestCloseTime - oneMilliSecondInNano).
+		Lte(request.LatestCloseTime + oneMilliSecondInNano)
+	boolQuery := elastic.NewBoolQuery().Must(matchDomainQuery).Must(existClosedStatusQuery).Filter(rangeQuery)
+
+	params := &es.SearchParameters{
+		Index: v.index,
+		Query: boolQuery,
+		Aggregations: map[string]elastic.Aggregation{
+			statisticsByTypeAggregation:        elastic.NewTermsAggregation().Field(es.WorkflowType).Size(statisticsMaxTermsBuckets),
+			statisticsByCloseStatusAggregation: elastic.NewTermsAggregation().Field(es.CloseStatus),
+		},
+	}
+	if request.CloseTimeInterval > 0 {
+		params.Aggregations[statisticsByCloseTimeAggregation] = elastic.NewHistogramAggregation().
+			Field(es.CloseTime).
+			Interval(float64(request.CloseTimeInterval.Nanoseconds())).
+			MinDocCount(0)
+	}
+	searchResult, err := v.esClient.Search(ctx, params)
+	if err != nil {
+		return nil, convertESError("GetWorkflowExecutionStatistics", err)
+	}
+
+	response := &p.GetWorkflowExecutionStatisticsResponse{
+		TotalCount: searchResult.Hits.TotalHits,
+	}
+	if items, ok := searchResult.Aggregations.Terms(statisticsByTypeAggregation); ok {
+		for _, bucket := range items.Buckets {
+			typeName, ok := bucket.Key.(string)
+			if !ok {
+				return nil, &workflow.InternalServiceError{
+					Message: fmt.Sprintf("GetWorkflowExecutionStatistics failed. Unexpected workflow type key: %v", bucket.Key),
+				}
+			}
+			response.CountsByType = append(response.CountsByType, newCountBucket(typeName, bucket.DocCount))
+		}
+	}
+	if items, ok := searchResult.Aggregations.Terms(statisticsByCloseStatusAggregation); ok {
+		for _, bucket := range items.Buckets {
+			status, err := bucket.KeyNumber.Int64()
+			if err != nil {
+				return nil, &workflow.InternalServiceError{
+					Message: fmt.Sprintf("GetWorkflowExecutionStatistics failed. Unexpected close status key: %v", bucket.Key),
+				}
+			}
+			key := workflow.WorkflowExecutionCloseStatus(status).String()
+			response.CountsByCloseStatus = append(response.CountsByCloseStatus, newCountBucket(key, bucket.DocCount))
+		}
+	}
+	if items, ok := searchResult.Aggregations.Histogram(statisticsByCloseTimeAggregation); ok {
+		for _, bucket := range items.Buckets {
+			response.CountsByCloseTime = append(response.CountsByCloseTime, &workflow.WorkflowExecutionTimeBucket{
+				StartTime: common.Int64Ptr(int64(bucket.Key)),
+				Count:     common.Int64Ptr(bucket.DocCount),
+			})
+		}
+	}
+	return response, nil
+}
+
+func (v *esVisibilityManager) CountOpenWorkflowExecutions(ctx context.Context,
+	request *p.CountWorkflowExecutionsRequest) (*p.CountWorkflowExecutionsResponse, error) {
+
+	matchDomainQuery := elastic.NewMatchQuery(es.DomainID, request.DomainUUID)
+	existClosedStatusQuery := elastic.NewExistsQuery(es.CloseStatus)
+	boolQuery := elastic.NewBoolQuery().Must(matchDomainQuery).MustNot(existClosedStatusQuery)
+
+	count, err := v.esClient.Count(ctx, v.index, boolQuery)
+	if err != nil {
+		return nil, convertESError("CountOpenWorkflowExecutions", err)
+	}
+	return &p.CountWorkflowExecutionsResponse{Count: count}, nil
+}
+
 func (v *esVisibilityManager) getNextPageToken(token []byte) (*esVisibilityPageToken, error) {
 	var result *esVisibilityPageToken
 	var err error
@@ -290,7 +406,7 @@ func (v *esVisibilityManager) getNextPageToken(token []byte) (*esVisibilityPageT
 	return result, nil
 }
 
-func (v *esVisibilityManager) getSearchResult(request *p.ListWorkflowExecutionsRequest, token *esVisibilityPageToken,
+func (v *esVisibilityManager) getSearchResult(ctx context.Context, request *p.ListWorkflowExecutionsRequest, token *esVisibilityPageToken,
 	matchQuery *elastic.MatchQuery, isOpen bool) (*elastic.SearchResult, error) {
 
 	matchDomainQuery := elastic.NewMatchQuery(es.DomainID, request.DomainUUID)
@@ -310,25 +426,33 @@ func (v *esVisibilityManager) getSearchResult(request *p.ListWorkflowExecutionsR
 	if matchQuery != nil {
 		boolQuery = boolQuery.Must(matchQuery)
 	}
+	if request.HasLabelFilter() {
+		boolQuery = boolQuery.Filter(elastic.NewTermQuery(es.Labels, es.LabelToKeyword(request.LabelKey, request.LabelValue)))
+	}
 	if isOpen {
 		boolQuery = boolQuery.MustNot(existClosedStatusQuery)
 	} else {
 		boolQuery = boolQuery.Must(existClosedStatusQuery)
 	}
 
-	ctx := context.Background()
+	if err := v.checkQueryTimeRange(request.Domain, request.EarliestStartTime, request.LatestStartTime); err != nil {
+		return nil, err
+	}
+	if token.isFirstPage() {
+		if err := v.checkQueryCost(ctx, request.Domain, boolQuery); err != nil {
+			return nil, err
+		}
+	}
+
 	params := &es.SearchParameters{
 		Index:    v.index,
 		Query:    boolQuery,
 		From:     token.From,
 		PageSize: request.PageSize,
 	}
-	if isOpen {
-		params.Sorter = append(params.Sorter, elastic.NewFieldSort(es.StartTime).Desc())
-	} else {
-		params.Sorter = append(params.Sorter, elastic.NewFieldSort(es.CloseTime).Desc())
-	}
-	params.Sorter = append(params.Sorter, elastic.NewFieldSort(es.RunID).Desc())
+	ascending := request.SortOrder == p.VisibilitySortOrderAsc
+	params.Sorter = append(params.Sorter, elastic.NewFieldSort(getSortField(request, isOpen)).Order(ascending))
+	params.Sorter = append(params.Sorter, elastic.NewFieldSort(es.RunID).Order(ascending))
 
 	if token.SortTime != 0 && token.TieBreaker != "" {
 		params.SearchAfter = []interface{}{token.SortTime, token.TieBreaker}
@@ -337,8 +461,41 @@ func (v *esVisibilityManager) getSearchResult(request *p.ListWorkflowExecutionsR
 	return v.esClient.Search(ctx, params)
 }
 
+// checkQueryTimeRange rejects queries whose time range is wider than the policy of the domain
+func (v *esVisibilityManager) checkQueryTimeRange(domain string, earliestTime, latestTime int64) error {
+	maxTimeRange := v.config.ESVisibilityMaxQueryTimeRange(domain)
+	if maxTimeRange <= 0 || latestTime-earliestTime <= maxTimeRange.Nanoseconds() {
+		return nil
+	}
+	return &workflow.BadRequestError{
+		Message: fmt.Sprintf("Query time range exceeds the limit of %v for the domain, narrow the time range.", maxTimeRange),
+	}
+}
+
+// checkQueryCost estimates the cost of a list query with the number of documents it matches,
+// which ElasticSearch has to sort, and rejects queries costlier than the policy of the domain
+func (v *esVisibilityManager) checkQueryCost(ctx context.Context, domain string, query elastic.Query) error {
+	maxCost := v.config.ESVisibilityMaxQueryCost(domain)
+	if maxCost <= 0 {
+		return nil
+	}
+	count, err := v.esClient.Count(ctx, v.index, query)
+	if err != nil {
+		return err
+	}
+	if count <= int64(maxCost) {
+		return nil
+	}
+	v.logger.WithFields(bark.Fields{
+		logging.TagDomain: domain,
+	}).Warnf("Rejected visibility query matching %v documents, limit is %v", count, maxCost)
+	return &workflow.BadRequestError{
+		Message: fmt.Sprintf("Query matches %v workflow executions, more than the limit of %v for the domain, narrow the time range or add a filter.", count, maxCost),
+	}
+}
+
 func (v *esVisibilityManager) getListWorkflowExecutionsResponse(searchHits *elastic.SearchHits,
-	token *esVisibilityPageToken, isOpen bool, pageSize int) (*p.ListWorkflowExecutionsResponse, error) {
+	token *esVisibilityPageToken, isOpen bool, sortField string, pageSize int) (*p.ListWorkflowExecutionsResponse, error) {
 
 	response := &p.ListWorkflowExecutionsResponse{}
 	actualHits := searchHits.Hits
@@ -361,10 +518,13 @@ func (v *esVisibilityManager) getListWorkflowExecutionsResponse(searchHits *elas
 		} else { // use ES Search After
 			lastExecution := response.Executions[len(response.Executions)-1]
 			var sortTime int64
-			if isOpen {
+			switch sortField {
+			case es.StartTime:
 				sortTime = lastExecution.GetStartTime()
-			} else {
+			case es.CloseTime:
 				sortTime = lastExecution.GetCloseTime()
+			case es.ExecutionTime:
+				sortTime = lastExecution.GetExecutionTime()
 			}
 			nextPageToken, err = v.serializePageToken(&esVisibilityPageToken{SortTime: sortTime, TieBreaker: lastExecution.GetExecution().GetRunId()})
 		}
@@ -379,9 +539,34 @@ func (v *esVisibilityManager) getListWorkflowExecutionsResponse(searchHits *elas
 	return response, nil
 }
 
+// getSortField returns the document field list results are ordered by, open executions
+// are ordered by StartTime and closed executions by CloseTime unless requested otherwise
+func getSortField(request *p.ListWorkflowExecutionsRequest, isOpen bool) string {
+	switch request.SortField {
+	case p.VisibilitySortFieldStartTime:
+		return es.StartTime
+	case p.VisibilitySortFieldCloseTime:
+		return es.CloseTime
+	case p.VisibilitySortFieldExecutionTime:
+		return es.ExecutionTime
+	}
+	if isOpen {
+		return es.StartTime
+	}
+	return es.CloseTime
+}
+
+func (t *esVisibilityPageToken) isFirstPage() bool {
+	return t.From == 0 && t.SortTime == 0 && t.TieBreaker == ""
+}
+
 func (v *esVisibilityManager) deserializePageToken(data []byte) (*esVisibilityPageToken, error) {
+	payload, err := p.DeserializeVisibilityPageToken(p.VisibilityStoreElasticSearch, data)
+	if err != nil {
+		return nil, err
+	}
 	var token esVisibilityPageToken
-	err := json.Unmarshal(data, &token)
+	err = json.Unmarshal(payload, &token)
 	if err != nil {
 		return nil, &workflow.BadRequestError{
 			Message: fmt.Sprintf("unable to deserialize page token. err: %v", err),
@@ -397,16 +582,17 @@ func (v *esVisibilityManager) serializePageToken(token *esVisibilityPageToken) (
 			Message: fmt.Sprintf("unable to serialize page token. err: %v", err),
 		}
 	}
-	return data, nil
+	return p.SerializeVisibilityPageToken(p.VisibilityStoreElasticSearch, data), nil
 }
 
 func (v *esVisibilityManager) convertSearchResultToVisibilityRecord(hit *elastic.SearchHit, isOpen bool) *workflow.WorkflowExecutionInfo {
 	var source *visibilityRecord
 	err := json.Unmarshal(*hit.Source, &source)
 	if err != nil { // log and skip error
+		// logger is throttled by error type, so a burst of bad documents does not suppress other errors
 		v.logger.WithFields(bark.Fields{
-			"error": err.Error(),
-			"docID": hit.Id,
+			logging.TagErr: err,
+			"docID":        hit.Id,
 		}).Error("unable to unmarshal search hit source")
 		return nil
 	}
@@ -418,6 +604,8 @@ func (v *esVisibilityManager) convertSearchResultToVisibilityRecord(hit *elastic
 	wfType := &workflow.WorkflowType{
 		Name: common.StringPtr(source.WorkflowType),
 	}
+	// documents indexed before the execution time was always written, and not yet
+	// backfilled, have no execution time for workflows without backoff
 	if source.ExecutionTime == 0 {
 		source.ExecutionTime = source.StartTime
 	}
@@ -429,6 +617,7 @@ func (v *esVisibilityManager) convertSearchResultToVisibilityRecord(hit *elastic
 			Type:          wfType,
 			StartTime:     common.Int64Ptr(source.StartTime),
 			ExecutionTime: common.Int64Ptr(source.ExecutionTime),
+			TaskList:      common.StringPtr(source.TaskList),
 		}
 	} else {
 		record = &workflow.WorkflowExecutionInfo{
@@ -439,7 +628,49 @@ func (v *esVisibilityManager) convertSearchResultToVisibilityRecord(hit *elastic
 			CloseTime:     common.Int64Ptr(source.CloseTime),
 			CloseStatus:   &source.CloseStatus,
 			HistoryLength: common.Int64Ptr(source.HistoryLength),
+			TaskList:      common.StringPtr(source.TaskList),
+		}
+		if source.TerminateCause != nil {
+			record.TerminationInfo = &workflow.WorkflowExecutionTerminationInfo{
+				Cause:    source.TerminateCause,
+				Reason:   common.StringPtr(source.TerminateReason),
+				Details:  source.TerminateDetails,
+				Identity: common.StringPtr(source.TerminateIdentity),
+			}
+		}
+	}
+	if source.ParentWorkflowID != "" {
+		record.ParentExecution = &workflow.WorkflowExecution{
+			WorkflowId: common.StringPtr(source.ParentWorkflowID),
+			RunId:      common.StringPtr(source.ParentRunID),
 		}
 	}
+	if len(source.Labels) > 0 {
+		record.Labels = es.KeywordsToLabels(source.Labels)
+	}
 	return record
 }
+
+func newCountBucket(key string, count int64) *workflow.WorkflowExecutionCountBucket {
+	return &workflow.WorkflowExecutionCountBucket{
+		Key:   common.StringPtr(key),
+		Count: common.Int64Ptr(count),
+	}
+}
+
+// convertESError classifies a failed ElasticSearch call, so callers can tell throttled and timed out
+// calls, which are worth retrying, from other failures
+func convertESError(operation string, err error) error {
+	if _, ok := err.(*workflow.BadRequestError); ok {
+		return err
+	}
+	msg := fmt.Sprintf("%v failed. Error: %v", operation, err)
+	switch {
+	case elastic.IsStatusCode(err, http.StatusTooManyRequests):
+		return &workflow.ServiceBusyError{Message: msg}
+	case elastic.IsTimeout(err), elastic.IsConnErr(err), err == context.DeadlineExceeded:
+		return &p.TimeoutError{Msg: msg}
+	default:
+		return &workflow.InternalServiceError{Message: msg}
+	}
+}
@@ -21,6 +21,7 @@
 package elasticsearch
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -36,8 +37,10 @@ import (
 	p "github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/service/config"
 	"github.com/uber/cadence/common/service/dynamicconfig"
+	"net/http"
 	"strings"
 	"testing"
+	"time"
 )
 
 type ESVisibilitySuite struct {
@@ -71,6 +74,13 @@ var (
 	testSearchResult = &elastic.SearchResult{
 		Hits: &elastic.SearchHits{},
 	}
+	testClosedSource       = json.RawMessage(fmt.Sprintf(`{"CloseStatus": 0, "CloseTime": 1547596872817380000, "HistoryLength": 29, "RunID": "%s", "StartTime": 1547596872371000000, "WorkflowID": "%s", "WorkflowType": "%s"}`, testRunID, testWorkflowID, testWorkflowType))
+	testClosedSearchResult = &elastic.SearchResult{
+		Hits: &elastic.SearchHits{
+			TotalHits: 1,
+			Hits:      []*elastic.SearchHit{{Source: &testClosedSource}},
+		},
+	}
 	errTestESSearch = errors.New("ES error")
 
 	filterOpen     = "must_not:map[exists:map[field:CloseStatus]]"
@@ -91,7 +101,9 @@ func (s *ESVisibilitySuite) SetupTest() {
 
 	s.mockESClient = &esMocks.Client{}
 	config := &config.VisibilityConfig{
-		ESIndexMaxResultWindow: dynamicconfig.GetIntPropertyFn(3),
+		ESIndexMaxResultWindow:        dynamicconfig.GetIntPropertyFn(3),
+		ESVisibilityMaxQueryTimeRange: dynamicconfig.GetDurationPropertyFnFilteredByDomain(0),
+		ESVisibilityMaxQueryCost:      dynamicconfig.GetIntPropertyFilteredByDomain(0),
 	}
 	mgr := NewElasticSearchVisibilityManager(s.mockESClient, testIndex, config, bark.NewNopLogger())
 	s.visibilityMgr = mgr.(*esVisibilityManager)
@@ -102,11 +114,11 @@ func (s *ESVisibilitySuite) TearDownTest() {
 }
 
 func (s *ESVisibilitySuite) TestRecordWorkflowExecutionStarted() {
-	s.Equal(errOperationNotSupported, s.visibilityMgr.RecordWorkflowExecutionStarted(nil))
+	s.Equal(errOperationNotSupported, s.visibilityMgr.RecordWorkflowExecutionStarted(context.Background(), nil))
 }
 
 func (s *ESVisibilitySuite) TestRecordWorkflowExecutionClosed() {
-	s.Equal(errOperationNotSupported, s.visibilityMgr.RecordWorkflowExecutionClosed(nil))
+	s.Equal(errOperationNotSupported, s.visibilityMgr.RecordWorkflowExecutionClosed(context.Background(), nil))
 }
 
 func (s *ESVisibilitySuite) TestListOpenWorkflowExecutions() {
@@ -115,28 +127,37 @@ func (s *ESVisibilitySuite) TestListOpenWorkflowExecutions() {
 		s.True(strings.Contains(fmt.Sprintf("%v", source), filterOpen))
 		return true
 	})).Return(testSearchResult, nil).Once()
-	_, err := s.visibilityMgr.ListOpenWorkflowExecutions(testRequest)
+	_, err := s.visibilityMgr.ListOpenWorkflowExecutions(context.Background(), testRequest)
 	s.NoError(err)
 
 	s.mockESClient.On("Search", mock.Anything, mock.Anything).Return(nil, errTestESSearch).Once()
-	_, err = s.visibilityMgr.ListOpenWorkflowExecutions(testRequest)
+	_, err = s.visibilityMgr.ListOpenWorkflowExecutions(context.Background(), testRequest)
 	s.Error(err)
 	_, ok := err.(*workflow.InternalServiceError)
 	s.True(ok)
 	s.True(strings.Contains(err.Error(), "ListOpenWorkflowExecutions failed"))
 }
 
+func (s *ESVisibilitySuite) TestListOpenWorkflowExecutions_SortedByCloseTime() {
+	request := *testRequest
+	request.SortField = p.VisibilitySortFieldCloseTime
+	_, err := s.visibilityMgr.ListOpenWorkflowExecutions(context.Background(), &request)
+	s.Error(err)
+	_, ok := err.(*workflow.BadRequestError)
+	s.True(ok)
+}
+
 func (s *ESVisibilitySuite) TestListClosedWorkflowExecutions() {
 	s.mockESClient.On("Search", mock.Anything, mock.MatchedBy(func(input *es.SearchParameters) bool {
 		source, _ := input.Query.Source()
 		s.True(strings.Contains(fmt.Sprintf("%v", source), filterClose))
 		return true
 	})).Return(testSearchResult, nil).Once()
-	_, err := s.visibilityMgr.ListClosedWorkflowExecutions(testRequest)
+	_, err := s.visibilityMgr.ListClosedWorkflowExecutions(context.Background(), testRequest)
 	s.NoError(err)
 
 	s.mockESClient.On("Search", mock.Anything, mock.Anything).Return(nil, errTestESSearch).Once()
-	_, err = s.visibilityMgr.ListClosedWorkflowExecutions(testRequest)
+	_, err = s.visibilityMgr.ListClosedWorkflowExecutions(context.Background(), testRequest)
 	s.Error(err)
 	_, ok := err.(*workflow.InternalServiceError)
 	s.True(ok)
@@ -155,11 +176,11 @@ func (s *ESVisibilitySuite) TestListOpenWorkflowExecutionsByType() {
 		ListWorkflowExecutionsRequest: *testRequest,
 		WorkflowTypeName:              testWorkflowType,
 	}
-	_, err := s.visibilityMgr.ListOpenWorkflowExecutionsByType(request)
+	_, err := s.visibilityMgr.ListOpenWorkflowExecutionsByType(context.Background(), request)
 	s.NoError(err)
 
 	s.mockESClient.On("Search", mock.Anything, mock.Anything).Return(nil, errTestESSearch).Once()
-	_, err = s.visibilityMgr.ListOpenWorkflowExecutionsByType(request)
+	_, err = s.visibilityMgr.ListOpenWorkflowExecutionsByType(context.Background(), request)
 	s.Error(err)
 	_, ok := err.(*workflow.InternalServiceError)
 	s.True(ok)
@@ -178,11 +199,11 @@ func (s *ESVisibilitySuite) TestListClosedWorkflowExecutionsByType() {
 		ListWorkflowExecutionsRequest: *testRequest,
 		WorkflowTypeName:              testWorkflowType,
 	}
-	_, err := s.visibilityMgr.ListClosedWorkflowExecutionsByType(request)
+	_, err := s.visibilityMgr.ListClosedWorkflowExecutionsByType(context.Background(), request)
 	s.NoError(err)
 
 	s.mockESClient.On("Search", mock.Anything, mock.Anything).Return(nil, errTestESSearch).Once()
-	_, err = s.visibilityMgr.ListClosedWorkflowExecutionsByType(request)
+	_, err = s.visibilityMgr.ListClosedWorkflowExecutionsByType(context.Background(), request)
 	s.Error(err)
 	_, ok := err.(*workflow.InternalServiceError)
 	s.True(ok)
@@ -201,11 +222,11 @@ func (s *ESVisibilitySuite) TestListOpenWorkflowExecutionsByWorkflowID() {
 		ListWorkflowExecutionsRequest: *testRequest,
 		WorkflowID:                    testWorkflowID,
 	}
-	_, err := s.visibilityMgr.ListOpenWorkflowExecutionsByWorkflowID(request)
+	_, err := s.visibilityMgr.ListOpenWorkflowExecutionsByWorkflowID(context.Background(), request)
 	s.NoError(err)
 
 	s.mockESClient.On("Search", mock.Anything, mock.Anything).Return(nil, errTestESSearch).Once()
-	_, err = s.visibilityMgr.ListOpenWorkflowExecutionsByWorkflowID(request)
+	_, err = s.visibilityMgr.ListOpenWorkflowExecutionsByWorkflowID(context.Background(), request)
 	s.Error(err)
 	_, ok := err.(*workflow.InternalServiceError)
 	s.True(ok)
@@ -224,11 +245,11 @@ func (s *ESVisibilitySuite) TestListClosedWorkflowExecutionsByWorkflowID() {
 		ListWorkflowExecutionsRequest: *testRequest,
 		WorkflowID:                    testWorkflowID,
 	}
-	_, err := s.visibilityMgr.ListClosedWorkflowExecutionsByWorkflowID(request)
+	_, err := s.visibilityMgr.ListClosedWorkflowExecutionsByWorkflowID(context.Background(), request)
 	s.NoError(err)
 
 	s.mockESClient.On("Search", mock.Anything, mock.Anything).Return(nil, errTestESSearch).Once()
-	_, err = s.visibilityMgr.ListClosedWorkflowExecutionsByWorkflowID(request)
+	_, err = s.visibilityMgr.ListClosedWorkflowExecutionsByWorkflowID(context.Background(), request)
 	s.Error(err)
 	_, ok := err.(*workflow.InternalServiceError)
 	s.True(ok)
@@ -247,11 +268,11 @@ func (s *ESVisibilitySuite) TestListClosedWorkflowExecutionsByStatus() {
 		ListWorkflowExecutionsRequest: *testRequest,
 		Status:                        workflow.WorkflowExecutionCloseStatus(testCloseStatus),
 	}
-	_, err := s.visibilityMgr.ListClosedWorkflowExecutionsByStatus(request)
+	_, err := s.visibilityMgr.ListClosedWorkflowExecutionsByStatus(context.Background(), request)
 	s.NoError(err)
 
 	s.mockESClient.On("Search", mock.Anything, mock.Anything).Return(nil, errTestESSearch).Once()
-	_, err = s.visibilityMgr.ListClosedWorkflowExecutionsByStatus(request)
+	_, err = s.visibilityMgr.ListClosedWorkflowExecutionsByStatus(context.Background(), request)
 	s.Error(err)
 	_, ok := err.(*workflow.InternalServiceError)
 	s.True(ok)
@@ -265,7 +286,7 @@ func (s *ESVisibilitySuite) TestGetClosedWorkflowExecution() {
 		s.True(strings.Contains(fmt.Sprintf("%v", source), filterByWID))
 		s.True(strings.Contains(fmt.Sprintf("%v", source), filterByRunID))
 		return true
-	})).Return(testSearchResult, nil).Once()
+	})).Return(testClosedSearchResult, nil).Once()
 	request := &p.GetClosedWorkflowExecutionRequest{
 		DomainUUID: testDomainID,
 		Execution: workflow.WorkflowExecution{
@@ -273,13 +294,23 @@ func (s *ESVisibilitySuite) TestGetClosedWorkflowExecution() {
 			RunId:      common.StringPtr(testRunID),
 		},
 	}
-	_, err := s.visibilityMgr.GetClosedWorkflowExecution(request)
+	resp, err := s.visibilityMgr.GetClosedWorkflowExecution(context.Background(), request)
 	s.NoError(err)
+	s.Equal(testWorkflowID, resp.Execution.Execution.GetWorkflowId())
+	s.Equal(testRunID, resp.Execution.Execution.GetRunId())
+
+	s.mockESClient.On("Search", mock.Anything, mock.Anything).Return(testSearchResult, nil).Once()
+	resp, err = s.visibilityMgr.GetClosedWorkflowExecution(context.Background(), request)
+	s.Nil(resp)
+	_, ok := err.(*workflow.EntityNotExistsError)
+	s.True(ok)
+	s.True(strings.Contains(err.Error(), testWorkflowID))
+	s.True(strings.Contains(err.Error(), testRunID))
 
 	s.mockESClient.On("Search", mock.Anything, mock.Anything).Return(nil, errTestESSearch).Once()
-	_, err = s.visibilityMgr.GetClosedWorkflowExecution(request)
+	_, err = s.visibilityMgr.GetClosedWorkflowExecution(context.Background(), request)
 	s.Error(err)
-	_, ok := err.(*workflow.InternalServiceError)
+	_, ok = err.(*workflow.InternalServiceError)
 	s.True(ok)
 	s.True(strings.Contains(err.Error(), "GetClosedWorkflowExecution failed"))
 }
@@ -291,15 +322,100 @@ func (s *ESVisibilitySuite) TestGetClosedWorkflowExecution_NoRunID() {
 		s.True(strings.Contains(fmt.Sprintf("%v", source), filterByWID))
 		s.False(strings.Contains(fmt.Sprintf("%v", source), filterByRunID))
 		return true
-	})).Return(testSearchResult, nil).Once()
+	})).Return(testClosedSearchResult, nil).Once()
 	request := &p.GetClosedWorkflowExecutionRequest{
 		DomainUUID: testDomainID,
 		Execution: workflow.WorkflowExecution{
 			WorkflowId: common.StringPtr(testWorkflowID),
 		},
 	}
-	_, err := s.visibilityMgr.GetClosedWorkflowExecution(request)
+	_, err := s.visibilityMgr.GetClosedWorkflowExecution(context.Background(), request)
+	s.NoError(err)
+}
+
+func (s *ESVisibilitySuite) TestGetWorkflowExecutionStatistics() {
+	var searchResult elastic.SearchResult
+	err := json.Unmarshal([]byte(fmt.Sprintf(`{
+		"hits": {"total": 3, "hits": []},
+		"aggregations": {
+			"countsByType": {"buckets": [{"key": "%s", "doc_count": 3}]},
+			"countsByCloseStatus": {"buckets": [{"key": 0, "doc_count": 2}, {"key": 1, "doc_count": 1}]},
+			"countsByCloseTime": {"buckets": [{"key": 1547596800000000000, "doc_count": 3}]}
+		}
+	}`, testWorkflowType)), &searchResult)
+	s.NoError(err)
+
+	s.mockESClient.On("Search", mock.Anything, mock.MatchedBy(func(input *es.SearchParameters) bool {
+		source, _ := input.Query.Source()
+		s.True(strings.Contains(fmt.Sprintf("%v", source), filterClose))
+		s.Equal(0, input.PageSize)
+		s.Len(input.Aggregations, 3)
+		return true
+	})).Return(&searchResult, nil).Once()
+	request := &p.GetWorkflowExecutionStatisticsRequest{
+		DomainUUID:        testDomainID,
+		Domain:            testDomain,
+		EarliestCloseTime: testEarliestTime,
+		LatestCloseTime:   testLatestTime,
+		CloseTimeInterval: time.Hour,
+	}
+	resp, err := s.visibilityMgr.GetWorkflowExecutionStatistics(context.Background(), request)
 	s.NoError(err)
+	s.Equal(int64(3), resp.TotalCount)
+	s.Equal(1, len(resp.CountsByType))
+	s.Equal(testWorkflowType, resp.CountsByType[0].GetKey())
+	s.Equal(int64(3), resp.CountsByType[0].GetCount())
+	s.Equal(2, len(resp.CountsByCloseStatus))
+	s.Equal(workflow.WorkflowExecutionCloseStatusCompleted.String(), resp.CountsByCloseStatus[0].GetKey())
+	s.Equal(workflow.WorkflowExecutionCloseStatusFailed.String(), resp.CountsByCloseStatus[1].GetKey())
+	s.Equal(1, len(resp.CountsByCloseTime))
+	s.Equal(int64(1547596800000000000), resp.CountsByCloseTime[0].GetStartTime())
+
+	s.mockESClient.On("Search", mock.Anything, mock.MatchedBy(func(input *es.SearchParameters) bool {
+		s.Len(input.Aggregations, 2)
+		return true
+	})).Return(nil, errTestESSearch).Once()
+	request.CloseTimeInterval = 0
+	_, err = s.visibilityMgr.GetWorkflowExecutionStatistics(context.Background(), request)
+	_, ok := err.(*workflow.InternalServiceError)
+	s.True(ok)
+	s.True(strings.Contains(err.Error(), "GetWorkflowExecutionStatistics failed"))
+}
+
+func (s *ESVisibilitySuite) TestCountOpenWorkflowExecutions() {
+	s.mockESClient.On("Count", mock.Anything, testIndex, mock.MatchedBy(func(input elastic.Query) bool {
+		source, _ := input.Source()
+		s.True(strings.Contains(fmt.Sprintf("%v", source), filterOpen))
+		return true
+	})).Return(int64(10), nil).Once()
+	request := &p.CountWorkflowExecutionsRequest{
+		DomainUUID: testDomainID,
+		Domain:     testDomain,
+	}
+	resp, err := s.visibilityMgr.CountOpenWorkflowExecutions(context.Background(), request)
+	s.NoError(err)
+	s.Equal(int64(10), resp.Count)
+
+	s.mockESClient.On("Count", mock.Anything, testIndex, mock.Anything).Return(int64(0), errTestESSearch).Once()
+	_, err = s.visibilityMgr.CountOpenWorkflowExecutions(context.Background(), request)
+	_, ok := err.(*workflow.InternalServiceError)
+	s.True(ok)
+	s.True(strings.Contains(err.Error(), "CountOpenWorkflowExecutions failed"))
+}
+
+func (s *ESVisibilitySuite) TestConvertESError() {
+	err := convertESError("Search", &elastic.Error{Status: http.StatusTooManyRequests})
+	s.IsType(&workflow.ServiceBusyError{}, err)
+	s.True(strings.Contains(err.Error(), "Search failed"))
+
+	err = convertESError("Search", &elastic.Error{Status: http.StatusRequestTimeout})
+	s.IsType(&p.TimeoutError{}, err)
+	err = convertESError("Search", context.DeadlineExceeded)
+	s.IsType(&p.TimeoutError{}, err)
+
+	err = convertESError("Search", errTestESSearch)
+	s.IsType(&workflow.InternalServiceError{}, err)
+	s.True(strings.Contains(err.Error(), "Search failed"))
 }
 
 func (s *ESVisibilitySuite) TestGetNextPageToken() {
@@ -344,7 +460,7 @@ func (s *ESVisibilitySuite) TestGetSearchResult() {
 		Sorter:   []elastic.Sorter{elastic.NewFieldSort(es.StartTime).Desc(), tieBreakerSorter},
 	}
 	s.mockESClient.On("Search", mock.Anything, params).Return(nil, nil).Once()
-	s.visibilityMgr.getSearchResult(request, token, nil, isOpen)
+	s.visibilityMgr.getSearchResult(context.Background(), request, token, nil, isOpen)
 
 	// test for closed
 	isOpen = false
@@ -353,14 +469,14 @@ func (s *ESVisibilitySuite) TestGetSearchResult() {
 	params.Query = boolQuery
 	params.Sorter = []elastic.Sorter{elastic.NewFieldSort(es.CloseTime).Desc(), tieBreakerSorter}
 	s.mockESClient.On("Search", mock.Anything, params).Return(nil, nil).Once()
-	s.visibilityMgr.getSearchResult(request, token, nil, isOpen)
+	s.visibilityMgr.getSearchResult(context.Background(), request, token, nil, isOpen)
 
 	// test for additional matchQuery
 	matchQuery := elastic.NewMatchQuery(es.CloseStatus, int32(0))
 	boolQuery = elastic.NewBoolQuery().Must(matchDomainQuery).Filter(rangeQuery).Must(matchQuery).Must(existClosedStatusQuery)
 	params.Query = boolQuery
 	s.mockESClient.On("Search", mock.Anything, params).Return(nil, nil).Once()
-	s.visibilityMgr.getSearchResult(request, token, matchQuery, isOpen)
+	s.visibilityMgr.getSearchResult(context.Background(), request, token, matchQuery, isOpen)
 
 	// test for search after
 	runID := "runID"
@@ -371,7 +487,76 @@ func (s *ESVisibilitySuite) TestGetSearchResult() {
 	params.From = 0
 	params.SearchAfter = []interface{}{token.SortTime, token.TieBreaker}
 	s.mockESClient.On("Search", mock.Anything, params).Return(nil, nil).Once()
-	s.visibilityMgr.getSearchResult(request, token, matchQuery, isOpen)
+	s.visibilityMgr.getSearchResult(context.Background(), request, token, matchQuery, isOpen)
+}
+
+func (s *ESVisibilitySuite) TestGetSearchResult_SortOrder() {
+	request := *testRequest
+	request.SortField = p.VisibilitySortFieldExecutionTime
+	request.SortOrder = p.VisibilitySortOrderAsc
+	token := &esVisibilityPageToken{}
+
+	s.mockESClient.On("Search", mock.Anything, mock.MatchedBy(func(input *es.SearchParameters) bool {
+		expected := []elastic.Sorter{
+			elastic.NewFieldSort(es.ExecutionTime).Asc(),
+			elastic.NewFieldSort(es.RunID).Asc(),
+		}
+		return s.Equal(expected, input.Sorter)
+	})).Return(nil, nil).Once()
+	s.visibilityMgr.getSearchResult(context.Background(), &request, token, nil, false)
+}
+
+func (s *ESVisibilitySuite) TestGetSearchResult_TimeRangeExceeded() {
+	s.visibilityMgr.config.ESVisibilityMaxQueryTimeRange = dynamicconfig.GetDurationPropertyFnFilteredByDomain(time.Hour)
+	request := *testRequest
+	request.EarliestStartTime = 0
+	request.LatestStartTime = int64(2 * time.Hour)
+
+	_, err := s.visibilityMgr.getSearchResult(context.Background(), &request, &esVisibilityPageToken{}, nil, true)
+	_, ok := err.(*workflow.BadRequestError)
+	s.True(ok)
+
+	request.LatestStartTime = int64(time.Hour)
+	s.mockESClient.On("Search", mock.Anything, mock.Anything).Return(testSearchResult, nil).Once()
+	_, err = s.visibilityMgr.getSearchResult(context.Background(), &request, &esVisibilityPageToken{}, nil, true)
+	s.NoError(err)
+}
+
+func (s *ESVisibilitySuite) TestGetSearchResult_QueryCostExceeded() {
+	s.visibilityMgr.config.ESVisibilityMaxQueryCost = dynamicconfig.GetIntPropertyFilteredByDomain(100)
+	request := *testRequest
+
+	s.mockESClient.On("Count", mock.Anything, testIndex, mock.Anything).Return(int64(101), nil).Once()
+	_, err := s.visibilityMgr.getSearchResult(context.Background(), &request, &esVisibilityPageToken{}, nil, true)
+	_, ok := err.(*workflow.BadRequestError)
+	s.True(ok)
+	s.Equal(err, convertESError("ListOpenWorkflowExecutions", err))
+
+	s.mockESClient.On("Count", mock.Anything, testIndex, mock.Anything).Return(int64(100), nil).Once()
+	s.mockESClient.On("Search", mock.Anything, mock.Anything).Return(testSearchResult, nil).Once()
+	_, err = s.visibilityMgr.getSearchResult(context.Background(), &request, &esVisibilityPageToken{}, nil, true)
+	s.NoError(err)
+
+	// the cost is only estimated for the first page
+	s.mockESClient.On("Search", mock.Anything, mock.Anything).Return(testSearchResult, nil).Once()
+	_, err = s.visibilityMgr.getSearchResult(context.Background(), &request, &esVisibilityPageToken{From: testPageSize}, nil, true)
+	s.NoError(err)
+}
+
+func (s *ESVisibilitySuite) TestGetSearchResult_LabelFilter() {
+	request := *testRequest
+	request.LabelKey = "team"
+	request.LabelValue = "payments"
+	token := &esVisibilityPageToken{}
+
+	s.mockESClient.On("Search", mock.Anything, mock.MatchedBy(func(input *es.SearchParameters) bool {
+		source, err := input.Query.Source()
+		s.NoError(err)
+		data, err := json.Marshal(source)
+		s.NoError(err)
+		return s.Contains(string(data), `{"term":{"Labels":"team=payments"}}`)
+	})).Return(nil, nil).Once()
+	s.visibilityMgr.getSearchResult(context.Background(), &request, token, nil, true)
 }
 
 func (s *ESVisibilitySuite) TestGetListWorkflowExecutionsResponse() {
@@ -380,7 +565,7 @@ func (s *ESVisibilitySuite) TestGetListWorkflowExecutionsResponse() {
 
 	// test for empty hits
 	searchHits := &elastic.SearchHits{}
-	resp, err := s.visibilityMgr.getListWorkflowExecutionsResponse(searchHits, token, isOpen, 1)
+	resp, err := s.visibilityMgr.getListWorkflowExecutionsResponse(searchHits, token, isOpen, es.StartTime, 1)
 	s.NoError(err)
 	s.Equal(0, len(resp.NextPageToken))
 	s.Equal(0, len(resp.Executions))
@@ -400,14 +585,14 @@ func (s *ESVisibilitySuite) TestGetListWorkflowExecutionsResponse() {
 		Source: source,
 	}
 	searchHits.Hits = []*elastic.SearchHit{searchHit}
-	resp, err = s.visibilityMgr.getListWorkflowExecutionsResponse(searchHits, token, isOpen, 1)
+	resp, err = s.visibilityMgr.getListWorkflowExecutionsResponse(searchHits, token, isOpen, es.StartTime, 1)
 	s.NoError(err)
 	serializedToken, _ := s.visibilityMgr.serializePageToken(&esVisibilityPageToken{From: 1})
 	s.Equal(serializedToken, resp.NextPageToken)
 	s.Equal(1, len(resp.Executions))
 
 	// test for last page hits
-	resp, err = s.visibilityMgr.getListWorkflowExecutionsResponse(searchHits, token, isOpen, 2)
+	resp, err = s.visibilityMgr.getListWorkflowExecutionsResponse(searchHits, token, isOpen, es.StartTime, 2)
 	s.NoError(err)
 	s.Equal(0, len(resp.NextPageToken))
 	s.Equal(1, len(resp.Executions))
@@ -420,7 +605,7 @@ func (s *ESVisibilitySuite) TestGetListWorkflowExecutionsResponse() {
 		searchHits.Hits = append(searchHits.Hits, searchHit)
 	}
 	numOfHits := len(searchHits.Hits)
-	resp, err = s.visibilityMgr.getListWorkflowExecutionsResponse(searchHits, token, true, numOfHits)
+	resp, err = s.visibilityMgr.getListWorkflowExecutionsResponse(searchHits, token, true, es.StartTime, numOfHits)
 	s.NoError(err)
 	s.Equal(numOfHits, len(resp.Executions))
 	nextPageToken, err := s.visibilityMgr.deserializePageToken(resp.NextPageToken)
@@ -429,7 +614,7 @@ func (s *ESVisibilitySuite) TestGetListWorkflowExecutionsResponse() {
 	s.Equal("e481009e-14b3-45ae-91af-dce6e2a88365", nextPageToken.TieBreaker)
 	s.Equal(0, nextPageToken.From)
 	// for close record
-	resp, err = s.visibilityMgr.getListWorkflowExecutionsResponse(searchHits, token, false, numOfHits)
+	resp, err = s.visibilityMgr.getListWorkflowExecutionsResponse(searchHits, token, false, es.CloseTime, numOfHits)
 	s.NoError(err)
 	s.Equal(numOfHits, len(resp.Executions))
 	nextPageToken, _ = s.visibilityMgr.deserializePageToken(resp.NextPageToken)
@@ -437,7 +622,7 @@ func (s *ESVisibilitySuite) TestGetListWorkflowExecutionsResponse() {
 	s.Equal("e481009e-14b3-45ae-91af-dce6e2a88365", nextPageToken.TieBreaker)
 	s.Equal(0, nextPageToken.From)
 	// for last page
-	resp, err = s.visibilityMgr.getListWorkflowExecutionsResponse(searchHits, token, false, numOfHits+1)
+	resp, err = s.visibilityMgr.getListWorkflowExecutionsResponse(searchHits, token, false, es.CloseTime, numOfHits+1)
 	s.NoError(err)
 	s.Equal(0, len(resp.NextPageToken))
 	s.Equal(numOfHits, len(resp.Executions))
@@ -539,3 +724,43 @@ func (s *ESVisibilitySuite) TestConvertSearchResultToVisibilityRecord() {
 	info = s.visibilityMgr.convertSearchResultToVisibilityRecord(searchHit, isOpen)
 	s.Nil(info)
 }
+
+func (s *ESVisibilitySuite) TestConvertSearchResultToVisibilityRecord_ChildWorkflow() {
+	data := []byte(`{"DomainID": "bfd5c907-f899-4baf-a7b2-2ab85e623ebd",
+          "KafkaKey": "7-620",
+          "RunID": "e481009e-14b3-45ae-91af-dce6e2a88365",
+          "StartTime": 1547596872371000000,
+          "WorkflowID": "child-workflow-id",
+          "WorkflowType": "TestWorkflowExecute",
+          "TaskList": "test-task-list",
+          "ParentWorkflowID": "parent-workflow-id",
+          "ParentRunID": "3c8ba4d4-4ed8-4e5e-8e3c-44c4c0bf7c38"}`)
+	source := (*json.RawMessage)(&data)
+	searchHit := &elastic.SearchHit{
+		Source: source,
+	}
+
+	info := s.visibilityMgr.convertSearchResultToVisibilityRecord(searchHit, true)
+	s.NotNil(info)
+	s.Equal("test-task-list", info.GetTaskList())
+	s.Equal("parent-workflow-id", info.GetParentExecution().GetWorkflowId())
+	s.Equal("3c8ba4d4-4ed8-4e5e-8e3c-44c4c0bf7c38", info.GetParentExecution().GetRunId())
+}
+
+func (s *ESVisibilitySuite) TestConvertSearchResultToVisibilityRecord_Labels() {
+	data := []byte(`{"DomainID": "bfd5c907-f899-4baf-a7b2-2ab85e623ebd",
+          "KafkaKey": "7-621",
+          "RunID": "e481009e-14b3-45ae-91af-dce6e2a88365",
+          "StartTime": 1547596872371000000,
+          "WorkflowID": "labeled-workflow-id",
+          "WorkflowType": "TestWorkflowExecute",
+          "Labels": ["env=staging", "team=payments"]}`)
+	source := (*json.RawMessage)(&data)
+	searchHit := &elastic.SearchHit{
+		Source: source,
+	}
+
+	info := s.visibilityMgr.convertSearchResultToVisibilityRecord(searchHit, true)
+	s.NotNil(info)
+	s.Equal(map[string]string{"env": "staging", "team": "payments"}, info.Labels)
+}
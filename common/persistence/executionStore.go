@@ -21,6 +21,7 @@
 package persistence
 
 import (
+	"context"
 	"github.com/uber-common/bark"
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
@@ -39,9 +40,9 @@ type (
 var _ ExecutionManager = (*executionManagerImpl)(nil)
 
 // NewExecutionManagerImpl returns new ExecutionManager
-func NewExecutionManagerImpl(persistence ExecutionStore, logger bark.Logger) ExecutionManager {
+func NewExecutionManagerImpl(persistence ExecutionStore, serializer HistorySerializer, logger bark.Logger) ExecutionManager {
 	return &executionManagerImpl{
-		serializer:    NewHistorySerializer(),
+		serializer:    serializer,
 		persistence:   persistence,
 		statsComputer: statsComputer{},
 		logger:        logger,
@@ -57,11 +58,34 @@ func (m *executionManagerImpl) GetShardID() int {
 }
 
 //The below three APIs are related to serialization/deserialization
-func (m *executionManagerImpl) GetWorkflowExecution(request *GetWorkflowExecutionRequest) (*GetWorkflowExecutionResponse, error) {
-	response, err := m.persistence.GetWorkflowExecution(request)
+func (m *executionManagerImpl) GetWorkflowExecution(ctx context.Context, request *GetWorkflowExecutionRequest) (*GetWorkflowExecutionResponse, error) {
+	response, err := m.persistence.GetWorkflowExecution(ctx, request)
 	if err != nil {
 		return nil, err
 	}
+	return m.deserializeGetWorkflowExecutionResponse(response)
+}
+
+func (m *executionManagerImpl) GetWorkflowExecutions(ctx context.Context, request *GetWorkflowExecutionsRequest) (*GetWorkflowExecutionsResponse, error) {
+	response, err := m.persistence.GetWorkflowExecutions(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	newResponse := &GetWorkflowExecutionsResponse{
+		Responses: make([]*GetWorkflowExecutionResponse, 0, len(response.Responses)),
+	}
+	for _, r := range response.Responses {
+		executionResponse, err := m.deserializeGetWorkflowExecutionResponse(r)
+		if err != nil {
+			return nil, err
+		}
+		newResponse.Responses = append(newResponse.Responses, executionResponse)
+	}
+	return newResponse, nil
+}
+
+func (m *executionManagerImpl) deserializeGetWorkflowExecutionResponse(response *InternalGetWorkflowExecutionResponse) (*GetWorkflowExecutionResponse, error) {
+	var err error
 	newResponse := &GetWorkflowExecutionResponse{
 		State: &WorkflowMutableState{
 			TimerInfos:         response.State.TimerInfos,
@@ -69,6 +93,7 @@ func (m *executionManagerImpl) GetWorkflowExecution(request *GetWorkflowExecutio
 			SignalInfos:        response.State.SignalInfos,
 			SignalRequestedIDs: response.State.SignalRequestedIDs,
 			ReplicationState:   response.State.ReplicationState,
+			Checksum:           response.State.Checksum,
 		},
 	}
 
@@ -102,9 +127,14 @@ func (m *executionManagerImpl) DeserializeExecutionInfo(info *InternalWorkflowEx
 	if err != nil {
 		return nil, err
 	}
+	versionHistories, err := m.serializer.DeserializeVersionHistories(info.VersionHistories)
+	if err != nil {
+		return nil, err
+	}
 
 	newInfo := &WorkflowExecutionInfo{
-		CompletionEvent: completionEvent,
+		CompletionEvent:  completionEvent,
+		VersionHistories: NewVersionHistoriesFromThrift(versionHistories),
 
 		DomainID:                     info.DomainID,
 		WorkflowID:                   info.WorkflowID,
@@ -156,6 +186,7 @@ func (m *executionManagerImpl) DeserializeExecutionInfo(info *InternalWorkflowEx
 		BranchToken:                  info.BranchToken,
 		CronSchedule:                 info.CronSchedule,
 		ExpirationSeconds:            info.ExpirationSeconds,
+		Labels:                       info.Labels,
 	}
 	return newInfo, nil
 }
@@ -283,6 +314,7 @@ func (m *executionManagerImpl) DeserializeActivityInfos(infos map[int64]*Interna
 			ExpirationTime:                 v.ExpirationTime,
 			MaximumAttempts:                v.MaximumAttempts,
 			NonRetriableErrors:             v.NonRetriableErrors,
+			LastFailureReason:              v.LastFailureReason,
 			LastHeartbeatTimeoutVisibility: v.LastHeartbeatTimeoutVisibility,
 		}
 		newInfos[k] = a
@@ -290,7 +322,7 @@ func (m *executionManagerImpl) DeserializeActivityInfos(infos map[int64]*Interna
 	return newInfos, nil
 }
 
-func (m *executionManagerImpl) UpdateWorkflowExecution(request *UpdateWorkflowExecutionRequest) (*UpdateWorkflowExecutionResponse, error) {
+func (m *executionManagerImpl) UpdateWorkflowExecution(ctx context.Context, request *UpdateWorkflowExecutionRequest) (*UpdateWorkflowExecutionResponse, error) {
 	executionInfo, err := m.SerializeExecutionInfo(request.ExecutionInfo, request.Encoding)
 	if err != nil {
 		return nil, err
@@ -314,6 +346,13 @@ func (m *executionManagerImpl) UpdateWorkflowExecution(request *UpdateWorkflowEx
 	if err != nil {
 		return nil, err
 	}
+	var continueAsNew *InternalCreateWorkflowExecutionRequest
+	if request.ContinueAsNew != nil {
+		continueAsNew, err = m.SerializeCreateWorkflowExecutionRequest(request.ContinueAsNew, request.Encoding)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	newRequest := &InternalUpdateWorkflowExecutionRequest{
 		ExecutionInfo:              executionInfo,
@@ -329,7 +368,7 @@ func (m *executionManagerImpl) UpdateWorkflowExecution(request *UpdateWorkflowEx
 		DeleteTimerTask:               request.DeleteTimerTask,
 		Condition:                     request.Condition,
 		RangeID:                       request.RangeID,
-		ContinueAsNew:                 request.ContinueAsNew,
+		ContinueAsNew:                 continueAsNew,
 		FinishExecution:               request.FinishExecution,
 		FinishedExecutionTTL:          request.FinishedExecutionTTL,
 		DeleteActivityInfos:           request.DeleteActivityInfos,
@@ -344,9 +383,10 @@ func (m *executionManagerImpl) UpdateWorkflowExecution(request *UpdateWorkflowEx
 		DeleteSignalRequestedID:       request.DeleteSignalRequestedID,
 		ClearBufferedEvents:           request.ClearBufferedEvents,
 		DeleteBufferedReplicationTask: request.DeleteBufferedReplicationTask,
+		Checksum:                      request.Checksum,
 	}
 	msuss := m.statsComputer.computeMutableStateUpdateStats(newRequest)
-	err1 := m.persistence.UpdateWorkflowExecution(newRequest)
+	err1 := m.persistence.UpdateWorkflowExecution(ctx, newRequest)
 	return &UpdateWorkflowExecutionResponse{MutableStateUpdateSessionStats: msuss}, err1
 }
 
@@ -454,6 +494,7 @@ func (m *executionManagerImpl) SerializeUpsertActivityInfos(infos []*ActivityInf
 			ExpirationTime:                 v.ExpirationTime,
 			MaximumAttempts:                v.MaximumAttempts,
 			NonRetriableErrors:             v.NonRetriableErrors,
+			LastFailureReason:              v.LastFailureReason,
 			LastHeartbeatTimeoutVisibility: v.LastHeartbeatTimeoutVisibility,
 		}
 		newInfos = append(newInfos, i)
@@ -469,6 +510,10 @@ func (m *executionManagerImpl) SerializeExecutionInfo(info *WorkflowExecutionInf
 	if err != nil {
 		return nil, err
 	}
+	versionHistories, err := m.SerializeVersionHistories(info.VersionHistories, encoding)
+	if err != nil {
+		return nil, err
+	}
 
 	return &InternalWorkflowExecutionInfo{
 		DomainID:                     info.DomainID,
@@ -522,10 +567,19 @@ func (m *executionManagerImpl) SerializeExecutionInfo(info *WorkflowExecutionInf
 		BranchToken:                  info.BranchToken,
 		CronSchedule:                 info.CronSchedule,
 		ExpirationSeconds:            info.ExpirationSeconds,
+		Labels:                       info.Labels,
+		VersionHistories:             versionHistories,
 	}, nil
 }
 
-func (m *executionManagerImpl) ResetMutableState(request *ResetMutableStateRequest) error {
+func (m *executionManagerImpl) SerializeVersionHistories(histories *VersionHistories, encoding common.EncodingType) (*DataBlob, error) {
+	if histories == nil {
+		return nil, nil
+	}
+	return m.serializer.SerializeVersionHistories(histories.ToThrift(), encoding)
+}
+
+func (m *executionManagerImpl) ResetMutableState(ctx context.Context, request *ResetMutableStateRequest) error {
 	executionInfo, err := m.SerializeExecutionInfo(request.ExecutionInfo, request.Encoding)
 	if err != nil {
 		return err
@@ -551,11 +605,12 @@ func (m *executionManagerImpl) ResetMutableState(request *ResetMutableStateReque
 		InsertRequestCancelInfos:  request.InsertRequestCancelInfos,
 		InsertSignalInfos:         request.InsertSignalInfos,
 		InsertSignalRequestedIDs:  request.InsertSignalRequestedIDs,
+		Checksum:                  request.Checksum,
 	}
-	return m.persistence.ResetMutableState(newRequest)
+	return m.persistence.ResetMutableState(ctx, newRequest)
 }
 
-func (m *executionManagerImpl) ResetWorkflowExecution(request *ResetWorkflowExecutionRequest) error {
+func (m *executionManagerImpl) ResetWorkflowExecution(ctx context.Context, request *ResetWorkflowExecutionRequest) error {
 
 	currExecution, err := m.SerializeExecutionInfo(request.CurrExecutionInfo, request.Encoding)
 	if err != nil {
@@ -604,48 +659,103 @@ func (m *executionManagerImpl) ResetWorkflowExecution(request *ResetWorkflowExec
 		InsertSignalInfos:         request.InsertSignalInfos,
 		InsertSignalRequestedIDs:  request.InsertSignalRequestedIDs,
 	}
-	return m.persistence.ResetWorkflowExecution(newRequest)
+	return m.persistence.ResetWorkflowExecution(ctx, newRequest)
 }
 
-func (m *executionManagerImpl) CreateWorkflowExecution(request *CreateWorkflowExecutionRequest) (*CreateWorkflowExecutionResponse, error) {
-	return m.persistence.CreateWorkflowExecution(request)
+func (m *executionManagerImpl) CreateWorkflowExecution(ctx context.Context, request *CreateWorkflowExecutionRequest) (*CreateWorkflowExecutionResponse, error) {
+	newRequest, err := m.SerializeCreateWorkflowExecutionRequest(request, request.Encoding)
+	if err != nil {
+		return nil, err
+	}
+	return m.persistence.CreateWorkflowExecution(ctx, newRequest)
+}
+
+func (m *executionManagerImpl) SerializeCreateWorkflowExecutionRequest(request *CreateWorkflowExecutionRequest, encoding common.EncodingType) (*InternalCreateWorkflowExecutionRequest, error) {
+	versionHistories, err := m.SerializeVersionHistories(request.VersionHistories, encoding)
+	if err != nil {
+		return nil, err
+	}
+
+	return &InternalCreateWorkflowExecutionRequest{
+		RequestID:                   request.RequestID,
+		DomainID:                    request.DomainID,
+		Execution:                   request.Execution,
+		ParentDomainID:              request.ParentDomainID,
+		ParentExecution:             request.ParentExecution,
+		InitiatedID:                 request.InitiatedID,
+		TaskList:                    request.TaskList,
+		WorkflowTypeName:            request.WorkflowTypeName,
+		WorkflowTimeout:             request.WorkflowTimeout,
+		DecisionTimeoutValue:        request.DecisionTimeoutValue,
+		ExecutionContext:            request.ExecutionContext,
+		LastEventTaskID:             request.LastEventTaskID,
+		NextEventID:                 request.NextEventID,
+		LastProcessedEvent:          request.LastProcessedEvent,
+		SignalCount:                 request.SignalCount,
+		HistorySize:                 request.HistorySize,
+		TransferTasks:               request.TransferTasks,
+		ReplicationTasks:            request.ReplicationTasks,
+		TimerTasks:                  request.TimerTasks,
+		RangeID:                     request.RangeID,
+		DecisionVersion:             request.DecisionVersion,
+		DecisionScheduleID:          request.DecisionScheduleID,
+		DecisionStartedID:           request.DecisionStartedID,
+		DecisionStartToCloseTimeout: request.DecisionStartToCloseTimeout,
+		CreateWorkflowMode:          request.CreateWorkflowMode,
+		PreviousRunID:               request.PreviousRunID,
+		PreviousLastWriteVersion:    request.PreviousLastWriteVersion,
+		ReplicationState:            request.ReplicationState,
+		Attempt:                     request.Attempt,
+		HasRetryPolicy:              request.HasRetryPolicy,
+		InitialInterval:             request.InitialInterval,
+		BackoffCoefficient:          request.BackoffCoefficient,
+		MaximumInterval:             request.MaximumInterval,
+		ExpirationTime:              request.ExpirationTime,
+		MaximumAttempts:             request.MaximumAttempts,
+		NonRetriableErrors:          request.NonRetriableErrors,
+		EventStoreVersion:           request.EventStoreVersion,
+		BranchToken:                 request.BranchToken,
+		CronSchedule:                request.CronSchedule,
+		ExpirationSeconds:           request.ExpirationSeconds,
+		VersionHistories:            versionHistories,
+	}, nil
 }
-func (m *executionManagerImpl) DeleteWorkflowExecution(request *DeleteWorkflowExecutionRequest) error {
-	return m.persistence.DeleteWorkflowExecution(request)
+func (m *executionManagerImpl) DeleteWorkflowExecution(ctx context.Context, request *DeleteWorkflowExecutionRequest) error {
+	return m.persistence.DeleteWorkflowExecution(ctx, request)
 }
 
-func (m *executionManagerImpl) GetCurrentExecution(request *GetCurrentExecutionRequest) (*GetCurrentExecutionResponse, error) {
-	return m.persistence.GetCurrentExecution(request)
+func (m *executionManagerImpl) GetCurrentExecution(ctx context.Context, request *GetCurrentExecutionRequest) (*GetCurrentExecutionResponse, error) {
+	return m.persistence.GetCurrentExecution(ctx, request)
 }
 
 // Transfer task related methods
-func (m *executionManagerImpl) GetTransferTasks(request *GetTransferTasksRequest) (*GetTransferTasksResponse, error) {
-	return m.persistence.GetTransferTasks(request)
+func (m *executionManagerImpl) GetTransferTasks(ctx context.Context, request *GetTransferTasksRequest) (*GetTransferTasksResponse, error) {
+	return m.persistence.GetTransferTasks(ctx, request)
 }
-func (m *executionManagerImpl) CompleteTransferTask(request *CompleteTransferTaskRequest) error {
-	return m.persistence.CompleteTransferTask(request)
+func (m *executionManagerImpl) CompleteTransferTask(ctx context.Context, request *CompleteTransferTaskRequest) error {
+	return m.persistence.CompleteTransferTask(ctx, request)
 }
-func (m *executionManagerImpl) RangeCompleteTransferTask(request *RangeCompleteTransferTaskRequest) error {
-	return m.persistence.RangeCompleteTransferTask(request)
+func (m *executionManagerImpl) RangeCompleteTransferTask(ctx context.Context, request *RangeCompleteTransferTaskRequest) error {
+	return m.persistence.RangeCompleteTransferTask(ctx, request)
 }
 
 // Replication task related methods
-func (m *executionManagerImpl) GetReplicationTasks(request *GetReplicationTasksRequest) (*GetReplicationTasksResponse, error) {
-	return m.persistence.GetReplicationTasks(request)
+func (m *executionManagerImpl) GetReplicationTasks(ctx context.Context, request *GetReplicationTasksRequest) (*GetReplicationTasksResponse, error) {
+	return m.persistence.GetReplicationTasks(ctx, request)
 }
-func (m *executionManagerImpl) CompleteReplicationTask(request *CompleteReplicationTaskRequest) error {
-	return m.persistence.CompleteReplicationTask(request)
+func (m *executionManagerImpl) CompleteReplicationTask(ctx context.Context, request *CompleteReplicationTaskRequest) error {
+	return m.persistence.CompleteReplicationTask(ctx, request)
 }
 
 // Timer related methods.
-func (m *executionManagerImpl) GetTimerIndexTasks(request *GetTimerIndexTasksRequest) (*GetTimerIndexTasksResponse, error) {
-	return m.persistence.GetTimerIndexTasks(request)
+func (m *executionManagerImpl) GetTimerIndexTasks(ctx context.Context, request *GetTimerIndexTasksRequest) (*GetTimerIndexTasksResponse, error) {
+	return m.persistence.GetTimerIndexTasks(ctx, request)
 }
-func (m *executionManagerImpl) CompleteTimerTask(request *CompleteTimerTaskRequest) error {
-	return m.persistence.CompleteTimerTask(request)
+func (m *executionManagerImpl) CompleteTimerTask(ctx context.Context, request *CompleteTimerTaskRequest) error {
+	return m.persistence.CompleteTimerTask(ctx, request)
 }
-func (m *executionManagerImpl) RangeCompleteTimerTask(request *RangeCompleteTimerTaskRequest) error {
-	return m.persistence.RangeCompleteTimerTask(request)
+func (m *executionManagerImpl) RangeCompleteTimerTask(ctx context.Context, request *RangeCompleteTimerTaskRequest) error {
+	return m.persistence.RangeCompleteTimerTask(ctx, request)
 }
 
 func (m *executionManagerImpl) Close() {
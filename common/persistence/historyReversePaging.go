@@ -0,0 +1,139 @@
+// Copyright (c) 2019 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"encoding/json"
+	"fmt"
+
+	workflow "github.com/uber/cadence/.gen/go/shared"
+)
+
+type (
+	// historyReverseToken is used to serialize/deserialize pagination token for reading
+	// history newest first, the next page ends right before UpperEventID
+	historyReverseToken struct {
+		UpperEventID int64
+	}
+
+	// historyWindowReader reads one page of the history batches whose first event ID is
+	// within [minEventID, maxEventID), in ascending order
+	historyWindowReader func(minEventID, maxEventID int64, nextPageToken []byte) ([]*workflow.History, []byte, int, error)
+)
+
+// readHistoryReverse returns one page of history events newest first along with the token of
+// the next page, the size of history read from store and the first event ID of the newest batch.
+// The store only reads forward, so each page is read forward from a window of event IDs ending
+// right before the oldest event returned so far. The window is widened until it contains the
+// beginning of a batch, which keeps the returned pages aligned with batch boundaries.
+func readHistoryReverse(
+	minEventID int64,
+	maxEventID int64,
+	pageSize int,
+	nextPageToken []byte,
+	readWindow historyWindowReader,
+) ([]*workflow.HistoryEvent, []byte, int, int64, error) {
+
+	if pageSize <= 0 || minEventID >= maxEventID {
+		return nil, nil, 0, 0, &InvalidPersistenceRequestError{
+			Msg: fmt.Sprintf("no events can be found for pageSize %v, minEventID %v, maxEventID: %v", pageSize, minEventID, maxEventID),
+		}
+	}
+
+	upperEventID := maxEventID
+	if len(nextPageToken) > 0 {
+		token := &historyReverseToken{}
+		if err := json.Unmarshal(nextPageToken, token); err != nil {
+			return nil, nil, 0, 0, &InvalidPersistenceRequestError{Msg: "invalid reverse history paging token"}
+		}
+		if token.UpperEventID <= minEventID || token.UpperEventID > maxEventID {
+			return nil, nil, 0, 0, &InvalidPersistenceRequestError{
+				Msg: fmt.Sprintf("reverse history paging token is out of range, upperEventID %v", token.UpperEventID),
+			}
+		}
+		upperEventID = token.UpperEventID
+	}
+
+	var batches []*workflow.History
+	size := 0
+	for window := int64(pageSize); ; window *= 2 {
+		lowerEventID := upperEventID - window
+		if lowerEventID < minEventID {
+			lowerEventID = minEventID
+		}
+
+		var err error
+		batches, size, err = readHistoryWindow(lowerEventID, upperEventID, readWindow)
+		if _, ok := err.(*workflow.EntityNotExistsError); ok && lowerEventID > minEventID {
+			// the window only holds the tail of a batch
+			continue
+		}
+		if err != nil {
+			return nil, nil, 0, 0, err
+		}
+		if len(batches) > 0 || lowerEventID == minEventID {
+			break
+		}
+	}
+
+	if len(batches) == 0 {
+		return nil, nil, size, 0, nil
+	}
+
+	events := make([]*workflow.HistoryEvent, 0, pageSize)
+	for i := len(batches) - 1; i >= 0; i-- {
+		batchEvents := batches[i].Events
+		for j := len(batchEvents) - 1; j >= 0; j-- {
+			events = append(events, batchEvents[j])
+		}
+	}
+
+	var token []byte
+	if oldestEventID := batches[0].Events[0].GetEventId(); oldestEventID > minEventID {
+		data, err := json.Marshal(&historyReverseToken{UpperEventID: oldestEventID})
+		if err != nil {
+			return nil, nil, 0, 0, &workflow.InternalServiceError{Message: "Error generating history event token."}
+		}
+		token = data
+	}
+
+	lastFirstEventID := batches[len(batches)-1].Events[0].GetEventId()
+	return events, token, size, lastFirstEventID, nil
+}
+
+// readHistoryWindow reads all the history batches whose first event ID is within [minEventID, maxEventID)
+func readHistoryWindow(minEventID, maxEventID int64, readWindow historyWindowReader) ([]*workflow.History, int, error) {
+	var batches []*workflow.History
+	size := 0
+	var token []byte
+	for {
+		page, nextToken, pageSize, err := readWindow(minEventID, maxEventID, token)
+		if err != nil {
+			return nil, 0, err
+		}
+		batches = append(batches, page...)
+		size += pageSize
+		if len(nextToken) == 0 {
+			return batches, size, nil
+		}
+		token = nextToken
+	}
+}
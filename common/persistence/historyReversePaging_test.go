@@ -0,0 +1,148 @@
+// Copyright (c) 2019 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+)
+
+type (
+	historyReversePagingSuite struct {
+		suite.Suite
+		// override suite.Suite.Assertions with require.Assertions; this means that s.NotNil(nil) will stop the test,
+		// not merely log an error
+		*require.Assertions
+		batches []*workflow.History
+		reads   int
+	}
+)
+
+func TestHistoryReversePagingSuite(t *testing.T) {
+	s := new(historyReversePagingSuite)
+	suite.Run(t, s)
+}
+
+func (s *historyReversePagingSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.batches = []*workflow.History{
+		s.newBatch(1, 3),
+		s.newBatch(4, 4),
+		s.newBatch(5, 8),
+		s.newBatch(9, 10),
+	}
+	s.reads = 0
+}
+
+func (s *historyReversePagingSuite) TestReadAllPages() {
+	events, token, _, lastFirstEventID, err := readHistoryReverse(1, 11, 3, nil, s.readWindow)
+	s.NoError(err)
+	s.Equal([]int64{10, 9}, s.eventIDs(events))
+	s.Equal(int64(9), lastFirstEventID)
+	s.NotEmpty(token)
+
+	// the first window only holds the tail of the batch starting at 5, so it is widened
+	events, token, _, lastFirstEventID, err = readHistoryReverse(1, 11, 3, token, s.readWindow)
+	s.NoError(err)
+	s.Equal([]int64{8, 7, 6, 5, 4}, s.eventIDs(events))
+	s.Equal(int64(5), lastFirstEventID)
+	s.NotEmpty(token)
+
+	events, token, _, lastFirstEventID, err = readHistoryReverse(1, 11, 3, token, s.readWindow)
+	s.NoError(err)
+	s.Equal([]int64{3, 2, 1}, s.eventIDs(events))
+	s.Equal(int64(1), lastFirstEventID)
+	s.Empty(token)
+}
+
+func (s *historyReversePagingSuite) TestReadFromMiddle() {
+	events, token, _, _, err := readHistoryReverse(4, 9, 100, nil, s.readWindow)
+	s.NoError(err)
+	s.Equal([]int64{8, 7, 6, 5, 4}, s.eventIDs(events))
+	s.Empty(token)
+}
+
+func (s *historyReversePagingSuite) TestNotFound() {
+	s.batches = nil
+	_, _, _, _, err := readHistoryReverse(1, 11, 3, nil, s.readWindow)
+	s.IsType(&workflow.EntityNotExistsError{}, err)
+}
+
+func (s *historyReversePagingSuite) TestInvalidToken() {
+	_, _, _, _, err := readHistoryReverse(1, 11, 3, []byte("invalid"), s.readWindow)
+	s.IsType(&InvalidPersistenceRequestError{}, err)
+
+	_, _, _, _, err = readHistoryReverse(1, 11, 3, []byte(`{"UpperEventID":20}`), s.readWindow)
+	s.IsType(&InvalidPersistenceRequestError{}, err)
+	s.Zero(s.reads)
+}
+
+// readWindow returns one batch per page, the token is the index of the next batch
+func (s *historyReversePagingSuite) readWindow(minEventID, maxEventID int64, nextPageToken []byte) ([]*workflow.History, []byte, int, error) {
+	s.reads++
+	start := 0
+	if len(nextPageToken) > 0 {
+		index, err := strconv.Atoi(string(nextPageToken))
+		s.NoError(err)
+		start = index
+	}
+
+	var matched []int
+	for i, batch := range s.batches {
+		firstEventID := batch.Events[0].GetEventId()
+		if i >= start && firstEventID >= minEventID && firstEventID < maxEventID {
+			matched = append(matched, i)
+		}
+	}
+	if len(matched) == 0 {
+		if len(nextPageToken) == 0 {
+			return nil, nil, 0, &workflow.EntityNotExistsError{Message: "Workflow execution history not found."}
+		}
+		return nil, nil, 0, nil
+	}
+
+	var token []byte
+	if len(matched) > 1 {
+		token = []byte(strconv.Itoa(matched[1]))
+	}
+	return []*workflow.History{s.batches[matched[0]]}, token, 1, nil
+}
+
+func (s *historyReversePagingSuite) newBatch(firstEventID, lastEventID int64) *workflow.History {
+	batch := &workflow.History{}
+	for id := firstEventID; id <= lastEventID; id++ {
+		batch.Events = append(batch.Events, &workflow.HistoryEvent{EventId: common.Int64Ptr(id)})
+	}
+	return batch
+}
+
+func (s *historyReversePagingSuite) eventIDs(events []*workflow.HistoryEvent) []int64 {
+	var ids []int64
+	for _, event := range events {
+		ids = append(ids, event.GetEventId())
+	}
+	return ids
+}
@@ -21,8 +21,10 @@
 package persistence
 
 import (
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
+	"hash/crc32"
 
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
@@ -40,6 +42,10 @@ type (
 		// serialize/deserialize a single history event
 		SerializeEvent(event *workflow.HistoryEvent, encodingType common.EncodingType) (*DataBlob, error)
 		DeserializeEvent(data *DataBlob) (*workflow.HistoryEvent, error)
+
+		// serialize/deserialize the version histories of a workflow execution
+		SerializeVersionHistories(histories *workflow.VersionHistories, encodingType common.EncodingType) (*DataBlob, error)
+		DeserializeVersionHistories(data *DataBlob) (*workflow.VersionHistories, error)
 	}
 
 	// HistorySerializationError is an error type that's
@@ -63,9 +69,23 @@ type (
 
 	serializerImpl struct {
 		thriftrwEncoder codec.BinaryEncoder
+		checksum        bool
 	}
 )
 
+// Blobs serialized with a checksum start with a header of blobHeaderSize bytes: the two magic bytes,
+// the header version, the encoding of the data and the CRC32 (IEEE) checksum of the data in big endian.
+// Neither JSON nor thriftrw data can start with the magic bytes, so blobs without a header are still read.
+const (
+	blobHeaderMagic0   byte = 0xCA
+	blobHeaderMagic1   byte = 0xDE
+	blobHeaderVersion1 byte = 1
+	blobHeaderSize          = 8
+
+	blobHeaderEncodingJSON     byte = 1
+	blobHeaderEncodingThriftRW byte = 2
+)
+
 // NewHistorySerializer returns a HistorySerializer
 func NewHistorySerializer() HistorySerializer {
 	return &serializerImpl{
@@ -73,6 +93,15 @@ func NewHistorySerializer() HistorySerializer {
 	}
 }
 
+// NewHistorySerializerWithChecksum returns a HistorySerializer which prefixes the blobs it serializes
+// with a versioned header carrying a checksum of the data, so corrupted blobs are detected when read
+func NewHistorySerializerWithChecksum() HistorySerializer {
+	return &serializerImpl{
+		thriftrwEncoder: codec.NewThriftRWEncoder(),
+		checksum:        true,
+	}
+}
+
 func (t *serializerImpl) SerializeBatchEvents(events []*workflow.HistoryEvent, encodingType common.EncodingType) (*DataBlob, error) {
 	batch := &workflow.History{Events: events}
 
@@ -87,7 +116,7 @@ func (t *serializerImpl) SerializeBatchEvents(events []*workflow.HistoryEvent, e
 		if err != nil {
 			return nil, NewHistorySerializationError(err.Error())
 		}
-		return NewDataBlob(data, encodingType), nil
+		return t.newDataBlob(data, encodingType), nil
 	default:
 		fallthrough
 	case common.EncodingTypeJSON:
@@ -95,7 +124,7 @@ func (t *serializerImpl) SerializeBatchEvents(events []*workflow.HistoryEvent, e
 		if err != nil {
 			return nil, NewHistorySerializationError(err.Error())
 		}
-		return NewDataBlob(data, common.EncodingTypeJSON), nil
+		return t.newDataBlob(data, common.EncodingTypeJSON), nil
 	}
 }
 
@@ -103,23 +132,27 @@ func (t *serializerImpl) DeserializeBatchEvents(data *DataBlob) ([]*workflow.His
 	if data == nil {
 		return nil, nil
 	}
+	payload, err := readBlobHeader(data)
+	if err != nil {
+		return nil, NewHistoryDeserializationError(fmt.Sprintf("DeserializeBatchEvents encoding: \"%v\", error: %v", data.Encoding, err.Error()))
+	}
 	switch data.GetEncoding() {
 	//As backward-compatibility, unknown should be json
 	case common.EncodingTypeUnknown:
 		fallthrough
 	case common.EncodingTypeJSON:
 		var events []*workflow.HistoryEvent
-		if len(data.Data) == 0 {
+		if len(payload) == 0 {
 			return events, nil
 		}
-		err := json.Unmarshal(data.Data, &events)
+		err := json.Unmarshal(payload, &events)
 		if err != nil {
 			return nil, NewHistoryDeserializationError(fmt.Sprintf("DeserializeBatchEvents encoding: \"%v\", error: %v", data.Encoding, err.Error()))
 		}
 		return events, nil
 	case common.EncodingTypeThriftRW:
 		var history workflow.History
-		err := t.thriftrwEncoder.Decode(data.Data, &history)
+		err := t.thriftrwEncoder.Decode(payload, &history)
 		if err != nil {
 			return nil, NewHistoryDeserializationError(fmt.Sprintf("DeserializeBatchEvents encoding: \"%v\", error: %v", data.Encoding, err.Error()))
 		}
@@ -141,7 +174,7 @@ func (t *serializerImpl) SerializeEvent(event *workflow.HistoryEvent, encodingTy
 		if err != nil {
 			return nil, NewHistorySerializationError(err.Error())
 		}
-		return NewDataBlob(data, encodingType), nil
+		return t.newDataBlob(data, encodingType), nil
 	default:
 		fallthrough
 	case common.EncodingTypeJSON:
@@ -149,7 +182,7 @@ func (t *serializerImpl) SerializeEvent(event *workflow.HistoryEvent, encodingTy
 		if err != nil {
 			return nil, NewHistorySerializationError(err.Error())
 		}
-		return NewDataBlob(data, common.EncodingTypeJSON), nil
+		return t.newDataBlob(data, common.EncodingTypeJSON), nil
 	}
 }
 
@@ -160,19 +193,23 @@ func (t *serializerImpl) DeserializeEvent(data *DataBlob) (*workflow.HistoryEven
 	if len(data.Data) == 0 {
 		return nil, NewHistoryDeserializationError("DeserializeEvent empty data")
 	}
+	payload, err := readBlobHeader(data)
+	if err != nil {
+		return nil, NewHistoryDeserializationError(fmt.Sprintf("DeserializeEvent encoding: \"%v\", error: %v", data.Encoding, err.Error()))
+	}
 	var event workflow.HistoryEvent
 	switch data.GetEncoding() {
 	//As backward-compatibility, unknown should be json
 	case common.EncodingTypeUnknown:
 		fallthrough
 	case common.EncodingTypeJSON:
-		err := json.Unmarshal(data.Data, &event)
+		err := json.Unmarshal(payload, &event)
 		if err != nil {
 			return nil, NewHistoryDeserializationError(fmt.Sprintf("DeserializeEvent encoding: \"%v\", error: %v", data.Encoding, err.Error()))
 		}
 		return &event, nil
 	case common.EncodingTypeThriftRW:
-		err := t.thriftrwEncoder.Decode(data.Data, &event)
+		err := t.thriftrwEncoder.Decode(payload, &event)
 		if err != nil {
 			return nil, NewHistoryDeserializationError(fmt.Sprintf("DeserializeEvent encoding: \"%v\", error: %v", data.Encoding, err.Error()))
 		}
@@ -182,6 +219,108 @@ func (t *serializerImpl) DeserializeEvent(data *DataBlob) (*workflow.HistoryEven
 	}
 }
 
+func (t *serializerImpl) SerializeVersionHistories(histories *workflow.VersionHistories, encodingType common.EncodingType) (*DataBlob, error) {
+	if histories == nil {
+		return nil, nil
+	}
+	switch encodingType {
+	case common.EncodingTypeGob:
+		return nil, NewUnknownEncodingTypeError(encodingType)
+	case common.EncodingTypeThriftRW:
+		data, err := t.thriftrwEncoder.Encode(histories)
+		if err != nil {
+			return nil, NewHistorySerializationError(err.Error())
+		}
+		return t.newDataBlob(data, encodingType), nil
+	default:
+		fallthrough
+	case common.EncodingTypeJSON:
+		data, err := json.Marshal(histories)
+		if err != nil {
+			return nil, NewHistorySerializationError(err.Error())
+		}
+		return t.newDataBlob(data, common.EncodingTypeJSON), nil
+	}
+}
+
+func (t *serializerImpl) DeserializeVersionHistories(data *DataBlob) (*workflow.VersionHistories, error) {
+	if data == nil || len(data.Data) == 0 {
+		return nil, nil
+	}
+	payload, err := readBlobHeader(data)
+	if err != nil {
+		return nil, NewHistoryDeserializationError(fmt.Sprintf("DeserializeVersionHistories encoding: \"%v\", error: %v", data.Encoding, err.Error()))
+	}
+	var histories workflow.VersionHistories
+	switch data.GetEncoding() {
+	//As backward-compatibility, unknown should be json
+	case common.EncodingTypeUnknown:
+		fallthrough
+	case common.EncodingTypeJSON:
+		err := json.Unmarshal(payload, &histories)
+		if err != nil {
+			return nil, NewHistoryDeserializationError(fmt.Sprintf("DeserializeVersionHistories encoding: \"%v\", error: %v", data.Encoding, err.Error()))
+		}
+		return &histories, nil
+	case common.EncodingTypeThriftRW:
+		err := t.thriftrwEncoder.Decode(payload, &histories)
+		if err != nil {
+			return nil, NewHistoryDeserializationError(fmt.Sprintf("DeserializeVersionHistories encoding: \"%v\", error: %v", data.Encoding, err.Error()))
+		}
+		return &histories, nil
+	default:
+		return nil, NewUnknownEncodingTypeError(data.GetEncoding())
+	}
+}
+
+// newDataBlob returns a DataBlob of the data, prefixed with a header if the serializer writes checksums
+func (t *serializerImpl) newDataBlob(data []byte, encodingType common.EncodingType) *DataBlob {
+	if !t.checksum {
+		return NewDataBlob(data, encodingType)
+	}
+	blob := make([]byte, blobHeaderSize+len(data))
+	blob[0] = blobHeaderMagic0
+	blob[1] = blobHeaderMagic1
+	blob[2] = blobHeaderVersion1
+	blob[3] = blobHeaderEncoding(encodingType)
+	binary.BigEndian.PutUint32(blob[4:blobHeaderSize], crc32.ChecksumIEEE(data))
+	copy(blob[blobHeaderSize:], data)
+	return NewDataBlob(blob, encodingType)
+}
+
+// readBlobHeader validates the header of the blob, if any, and returns the data following it
+func readBlobHeader(data *DataBlob) ([]byte, error) {
+	blob := data.Data
+	if len(blob) < 2 || blob[0] != blobHeaderMagic0 || blob[1] != blobHeaderMagic1 {
+		return blob, nil
+	}
+	if len(blob) < blobHeaderSize {
+		return nil, fmt.Errorf("truncated blob header of %v bytes", len(blob))
+	}
+	if blob[2] != blobHeaderVersion1 {
+		return nil, fmt.Errorf("unknown blob header version %v", blob[2])
+	}
+	if encoding := blobHeaderEncoding(data.GetEncoding()); blob[3] != encoding {
+		return nil, fmt.Errorf("blob header encoding %v does not match encoding %v", blob[3], encoding)
+	}
+	payload := blob[blobHeaderSize:]
+	expected := binary.BigEndian.Uint32(blob[4:blobHeaderSize])
+	if actual := crc32.ChecksumIEEE(payload); actual != expected {
+		return nil, fmt.Errorf("blob checksum mismatch, expected %08x, actual %08x", expected, actual)
+	}
+	return payload, nil
+}
+
+func blobHeaderEncoding(encodingType common.EncodingType) byte {
+	switch encodingType {
+	case common.EncodingTypeThriftRW:
+		return blobHeaderEncodingThriftRW
+	default:
+		// unknown is read as json
+		return blobHeaderEncodingJSON
+	}
+}
+
 // NewUnknownEncodingTypeError returns a new instance of encoding type error
 func NewUnknownEncodingTypeError(encodingType common.EncodingType) error {
 	return &UnknownEncodingTypeError{encodingType: encodingType}
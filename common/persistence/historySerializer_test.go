@@ -153,3 +153,116 @@ func (s *historySerializerSuite) TestSerializer() {
 	succ := common.AwaitWaitGroup(&doneWG, 10*time.Second)
 	s.True(succ, "test timed out")
 }
+
+func (s *historySerializerSuite) TestSerializeVersionHistories() {
+	serializer := NewHistorySerializer()
+
+	histories := &workflow.VersionHistories{
+		CurrentVersionHistoryIndex: common.Int32Ptr(0),
+		Histories: []*workflow.VersionHistory{
+			{
+				BranchToken: []byte("some random branch token"),
+				Items: []*workflow.VersionHistoryItem{
+					{EventID: common.Int64Ptr(3), Version: common.Int64Ptr(1)},
+					{EventID: common.Int64Ptr(6), Version: common.Int64Ptr(4)},
+				},
+			},
+		},
+	}
+
+	_, err := serializer.SerializeVersionHistories(histories, common.EncodingTypeGob)
+	s.NotNil(err)
+	_, ok := err.(*UnknownEncodingTypeError)
+	s.True(ok)
+
+	for _, encoding := range []common.EncodingType{common.EncodingTypeJSON, common.EncodingTypeThriftRW} {
+		blob, err := serializer.SerializeVersionHistories(histories, encoding)
+		s.Nil(err)
+		s.NotNil(blob)
+
+		result, err := serializer.DeserializeVersionHistories(blob)
+		s.Nil(err)
+		s.True(histories.Equals(result))
+	}
+
+	blob, err := serializer.SerializeVersionHistories(nil, common.EncodingTypeThriftRW)
+	s.Nil(err)
+	s.Nil(blob)
+	result, err := serializer.DeserializeVersionHistories(nil)
+	s.Nil(err)
+	s.Nil(result)
+}
+
+func (s *historySerializerSuite) TestSerializeWithChecksum() {
+	serializer := NewHistorySerializerWithChecksum()
+	legacySerializer := NewHistorySerializer()
+
+	event := &workflow.HistoryEvent{
+		EventId:   common.Int64Ptr(999),
+		Timestamp: common.Int64Ptr(time.Now().UnixNano()),
+		EventType: common.EventTypePtr(workflow.EventTypeActivityTaskCompleted),
+		ActivityTaskCompletedEventAttributes: &workflow.ActivityTaskCompletedEventAttributes{
+			Result:           []byte("result-1-event-1"),
+			ScheduledEventId: common.Int64Ptr(4),
+			StartedEventId:   common.Int64Ptr(5),
+			Identity:         common.StringPtr("event-1"),
+		},
+	}
+
+	for _, encoding := range []common.EncodingType{common.EncodingTypeJSON, common.EncodingTypeThriftRW} {
+		blob, err := serializer.SerializeBatchEvents([]*workflow.HistoryEvent{event}, encoding)
+		s.Nil(err)
+		s.Equal(blobHeaderMagic0, blob.Data[0])
+		s.Equal(blobHeaderMagic1, blob.Data[1])
+
+		// both serializers read blobs with and without header
+		legacyBlob, err := legacySerializer.SerializeBatchEvents([]*workflow.HistoryEvent{event}, encoding)
+		s.Nil(err)
+		s.Equal(legacyBlob.Data, blob.Data[blobHeaderSize:])
+		for _, b := range []*DataBlob{blob, legacyBlob} {
+			events, err := legacySerializer.DeserializeBatchEvents(b)
+			s.Nil(err)
+			s.Len(events, 1)
+			s.True(event.Equals(events[0]))
+			events, err = serializer.DeserializeBatchEvents(b)
+			s.Nil(err)
+			s.Len(events, 1)
+			s.True(event.Equals(events[0]))
+		}
+
+		eventBlob, err := serializer.SerializeEvent(event, encoding)
+		s.Nil(err)
+		result, err := serializer.DeserializeEvent(eventBlob)
+		s.Nil(err)
+		s.True(event.Equals(result))
+	}
+}
+
+func (s *historySerializerSuite) TestDeserializeCorruptedBlob() {
+	serializer := NewHistorySerializerWithChecksum()
+	event := &workflow.HistoryEvent{
+		EventId:   common.Int64Ptr(999),
+		EventType: common.EventTypePtr(workflow.EventTypeWorkflowExecutionStarted),
+	}
+	blob, err := serializer.SerializeEvent(event, common.EncodingTypeThriftRW)
+	s.Nil(err)
+
+	corrupted := NewDataBlob(append([]byte{}, blob.Data...), blob.Encoding)
+	corrupted.Data[len(corrupted.Data)-1] ^= 0xFF
+	_, err = serializer.DeserializeEvent(corrupted)
+	s.IsType(&HistoryDeserializationError{}, err)
+	s.Contains(err.Error(), "checksum mismatch")
+
+	truncated := NewDataBlob(blob.Data[:blobHeaderSize-1], blob.Encoding)
+	_, err = serializer.DeserializeEvent(truncated)
+	s.IsType(&HistoryDeserializationError{}, err)
+
+	wrongEncoding := NewDataBlob(blob.Data, common.EncodingTypeJSON)
+	_, err = serializer.DeserializeEvent(wrongEncoding)
+	s.IsType(&HistoryDeserializationError{}, err)
+
+	unknownVersion := NewDataBlob(append([]byte{}, blob.Data...), blob.Encoding)
+	unknownVersion.Data[2] = blobHeaderVersion1 + 1
+	_, err = serializer.DeserializeEvent(unknownVersion)
+	s.IsType(&HistoryDeserializationError{}, err)
+}
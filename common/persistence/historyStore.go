@@ -51,9 +51,9 @@ type (
 var _ HistoryManager = (*historyManagerImpl)(nil)
 
 //NewHistoryManagerImpl returns new HistoryManager
-func NewHistoryManagerImpl(persistence HistoryStore, logger bark.Logger) HistoryManager {
+func NewHistoryManagerImpl(persistence HistoryStore, serializer HistorySerializer, logger bark.Logger) HistoryManager {
 	return &historyManagerImpl{
-		serializer:  NewHistorySerializer(),
+		serializer:  serializer,
 		persistence: persistence,
 		logger:      logger,
 	}
@@ -99,6 +99,10 @@ func (m *historyManagerImpl) GetWorkflowExecutionHistoryByBatch(request *GetWork
 
 // GetWorkflowExecutionHistory retrieves the paginated list of history events for given execution
 func (m *historyManagerImpl) GetWorkflowExecutionHistory(request *GetWorkflowExecutionHistoryRequest) (*GetWorkflowExecutionHistoryResponse, error) {
+	if request.ReverseOrder {
+		return m.getWorkflowExecutionHistoryReverse(request)
+	}
+
 	resp := &GetWorkflowExecutionHistoryResponse{}
 	var err error
 	_, resp.History, resp.NextPageToken, resp.LastFirstEventID, resp.Size, err = m.getWorkflowExecutionHistory(request, false)
@@ -199,6 +203,33 @@ func (m *historyManagerImpl) getWorkflowExecutionHistory(request *GetWorkflowExe
 	return historyBatches, history, nextToken, lastFirstEventID, size, nil
 }
 
+// getWorkflowExecutionHistoryReverse retrieves the paginated list of history events for given execution, newest first
+func (m *historyManagerImpl) getWorkflowExecutionHistoryReverse(request *GetWorkflowExecutionHistoryRequest) (*GetWorkflowExecutionHistoryResponse, error) {
+	readWindow := func(minEventID, maxEventID int64, nextPageToken []byte) ([]*workflow.History, []byte, int, error) {
+		batches, _, token, _, size, err := m.getWorkflowExecutionHistory(&GetWorkflowExecutionHistoryRequest{
+			DomainID:      request.DomainID,
+			Execution:     request.Execution,
+			FirstEventID:  minEventID,
+			NextEventID:   maxEventID,
+			PageSize:      request.PageSize,
+			NextPageToken: nextPageToken,
+		}, true)
+		return batches, token, size, err
+	}
+
+	events, token, size, lastFirstEventID, err := readHistoryReverse(
+		request.FirstEventID, request.NextEventID, request.PageSize, request.NextPageToken, readWindow)
+	if err != nil {
+		return nil, err
+	}
+	return &GetWorkflowExecutionHistoryResponse{
+		History:          &workflow.History{Events: events},
+		NextPageToken:    token,
+		LastFirstEventID: lastFirstEventID,
+		Size:             size,
+	}, nil
+}
+
 func (m *historyManagerImpl) deserializeToken(request *GetWorkflowExecutionHistoryRequest, defaultLastEventID int64) (*historyToken, error) {
 	token := &historyToken{
 		LastEventBatchVersion: common.EmptyVersion,
@@ -46,9 +46,9 @@ type (
 var _ HistoryV2Manager = (*historyV2ManagerImpl)(nil)
 
 //NewHistoryV2ManagerImpl returns new HistoryManager
-func NewHistoryV2ManagerImpl(persistence HistoryV2Store, logger bark.Logger) HistoryV2Manager {
+func NewHistoryV2ManagerImpl(persistence HistoryV2Store, serializer HistorySerializer, logger bark.Logger) HistoryV2Manager {
 	return &historyV2ManagerImpl{
-		historySerializer:     NewHistorySerializer(),
+		historySerializer:     serializer,
 		persistence:           persistence,
 		logger:                logger,
 		thrifteEncoder:        codec.NewThriftRWEncoder(),
@@ -240,6 +240,10 @@ func (m *historyV2ManagerImpl) ReadHistoryBranchByBatch(request *ReadHistoryBran
 // ReadHistoryBranch returns history node data for a branch
 // Pagination is implemented here, the actual minNodeID passing to persistence layer is calculated along with token's LastNodeID
 func (m *historyV2ManagerImpl) ReadHistoryBranch(request *ReadHistoryBranchRequest) (*ReadHistoryBranchResponse, error) {
+	if request.ReverseOrder {
+		return m.readHistoryBranchReverse(request)
+	}
+
 	resp := &ReadHistoryBranchResponse{}
 	var err error
 	resp.HistoryEvents, _, resp.NextPageToken, resp.Size, resp.LastFirstEventID, err = m.readHistoryBranch(false, request)
@@ -249,6 +253,33 @@ func (m *historyV2ManagerImpl) ReadHistoryBranch(request *ReadHistoryBranchReque
 	return resp, nil
 }
 
+// readHistoryBranchReverse returns history node data for a branch, newest first
+func (m *historyV2ManagerImpl) readHistoryBranchReverse(request *ReadHistoryBranchRequest) (*ReadHistoryBranchResponse, error) {
+	readWindow := func(minEventID, maxEventID int64, nextPageToken []byte) ([]*workflow.History, []byte, int, error) {
+		_, batches, token, size, _, err := m.readHistoryBranch(true, &ReadHistoryBranchRequest{
+			BranchToken:   request.BranchToken,
+			MinEventID:    minEventID,
+			MaxEventID:    maxEventID,
+			PageSize:      request.PageSize,
+			NextPageToken: nextPageToken,
+			ShardID:       request.ShardID,
+		})
+		return batches, token, size, err
+	}
+
+	events, token, size, lastFirstEventID, err := readHistoryReverse(
+		request.MinEventID, request.MaxEventID, request.PageSize, request.NextPageToken, readWindow)
+	if err != nil {
+		return nil, err
+	}
+	return &ReadHistoryBranchResponse{
+		HistoryEvents:    events,
+		NextPageToken:    token,
+		Size:             size,
+		LastFirstEventID: lastFirstEventID,
+	}, nil
+}
+
 func (m *historyV2ManagerImpl) readHistoryBranch(byBatch bool, request *ReadHistoryBranchRequest) ([]*workflow.HistoryEvent, []*workflow.History, []byte, int, int64, error) {
 	var branch workflow.HistoryBranch
 	err := m.thrifteEncoder.Decode(request.BranchToken, &branch)
@@ -0,0 +1,100 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package inmemory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uber-common/bark"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	p "github.com/uber/cadence/common/persistence"
+)
+
+type clusterMetadataStore struct {
+	store
+}
+
+// newClusterMetadataStore creates an instance of ClusterMetadataStore
+func newClusterMetadataStore(db *database, logger bark.Logger) p.ClusterMetadataStore {
+	return &clusterMetadataStore{
+		store: store{
+			db:     db,
+			logger: logger,
+		},
+	}
+}
+
+func (m *clusterMetadataStore) InitializeClusterMetadata(_ context.Context,
+	request *p.InitializeClusterMetadataRequest) (*p.InitializeClusterMetadataResponse, error) {
+	m.db.Lock()
+	defer m.db.Unlock()
+
+	if m.db.clusterMetadata != nil {
+		return &p.InitializeClusterMetadataResponse{ClusterMetadata: copyClusterMetadata(m.db.clusterMetadata)}, nil
+	}
+	metadata := copyClusterMetadata(&request.ClusterMetadata)
+	m.db.clusterMetadata = &metadata
+	return &p.InitializeClusterMetadataResponse{ClusterMetadata: copyClusterMetadata(&metadata), Created: true}, nil
+}
+
+func (m *clusterMetadataStore) GetClusterMetadata(_ context.Context) (*p.GetClusterMetadataResponse, error) {
+	m.db.Lock()
+	defer m.db.Unlock()
+
+	if m.db.clusterMetadata == nil {
+		return nil, &workflow.EntityNotExistsError{
+			Message: "GetClusterMetadata operation failed. Cluster metadata not found.",
+		}
+	}
+	return &p.GetClusterMetadataResponse{ClusterMetadata: copyClusterMetadata(m.db.clusterMetadata)}, nil
+}
+
+func (m *clusterMetadataStore) UpdateClusterMetadata(_ context.Context, request *p.UpdateClusterMetadataRequest) error {
+	m.db.Lock()
+	defer m.db.Unlock()
+
+	if m.db.clusterMetadata == nil || m.db.clusterMetadata.Version != request.PreviousVersion {
+		return &p.ConditionFailedError{
+			Msg: fmt.Sprintf("UpdateClusterMetadata operation failed. Previous version: %v", request.PreviousVersion),
+		}
+	}
+	metadata := copyClusterMetadata(&request.ClusterMetadata)
+	m.db.clusterMetadata = &metadata
+	return nil
+}
+
+func copyClusterMetadata(metadata *p.ClusterMetadata) p.ClusterMetadata {
+	result := *metadata
+	result.InitialFailoverVersions = make(map[string]int64, len(metadata.InitialFailoverVersions))
+	for k, v := range metadata.InitialFailoverVersions {
+		result.InitialFailoverVersions[k] = v
+	}
+	result.ClusterAddresses = make(map[string]p.ClusterAddress, len(metadata.ClusterAddresses))
+	for k, v := range metadata.ClusterAddresses {
+		result.ClusterAddresses[k] = v
+	}
+	result.SearchAttributes = make(map[string]int32, len(metadata.SearchAttributes))
+	for k, v := range metadata.SearchAttributes {
+		result.SearchAttributes[k] = v
+	}
+	return result
+}
@@ -0,0 +1,163 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package inmemory
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sync"
+
+	"github.com/uber-common/bark"
+	p "github.com/uber/cadence/common/persistence"
+)
+
+const inMemoryPersistenceName = "inmemory"
+
+type (
+	// database holds all the tables of an in-memory datastore. A single lock guards
+	// all the tables, this keeps the conditional updates spanning several tables
+	// (e.g. the shard range check of execution writes) atomic
+	database struct {
+		sync.Mutex
+		shards                    map[int]*p.ShardInfo
+		taskLists                 map[taskListKey]*p.TaskListInfo
+		tasks                     map[taskListKey]map[int64]*p.TaskInfo
+		domains                   map[string]*p.GetDomainResponse
+		domainNotificationVersion int64
+		historyEvents             map[executionKey]map[int64]*historyEventBatch
+		historyBranches           map[string]map[string]*historyBranch
+		historyNodes              map[historyBranchKey]map[historyNodeKey]*p.DataBlob
+		executionShards           map[int]*executionShard
+		visibilityRecords         map[string]map[string]*visibilityRecord
+		clusterMetadata           *p.ClusterMetadata
+	}
+
+	// store contains the logic shared by all the in-memory stores
+	store struct {
+		db     *database
+		logger bark.Logger
+	}
+
+	taskListKey struct {
+		domainID string
+		name     string
+		taskType int
+	}
+
+	executionKey struct {
+		domainID   string
+		workflowID string
+		runID      string
+	}
+
+	historyBranchKey struct {
+		treeID   string
+		branchID string
+	}
+
+	historyNodeKey struct {
+		nodeID int64
+		txnID  int64
+	}
+
+	// timerTaskKey uses the unix nanos of the visibility timestamp, time.Time values
+	// are not safe to compare with ==
+	timerTaskKey struct {
+		visibilityTimestamp int64
+		taskID              int64
+	}
+)
+
+var (
+	databasesLock sync.Mutex
+	databases     = make(map[string]*database)
+)
+
+// getDatabase returns the database with the given name, creating it if it doesn't exist yet
+func getDatabase(name string) *database {
+	databasesLock.Lock()
+	defer databasesLock.Unlock()
+	db, ok := databases[name]
+	if !ok {
+		db = newDatabase()
+		databases[name] = db
+	}
+	return db
+}
+
+// DropDatabase removes all the data of the in-memory database with the given name,
+// stores created before the call keep working against the data they already hold
+func DropDatabase(name string) {
+	databasesLock.Lock()
+	defer databasesLock.Unlock()
+	delete(databases, name)
+}
+
+func newDatabase() *database {
+	return &database{
+		shards:            make(map[int]*p.ShardInfo),
+		taskLists:         make(map[taskListKey]*p.TaskListInfo),
+		tasks:             make(map[taskListKey]map[int64]*p.TaskInfo),
+		domains:           make(map[string]*p.GetDomainResponse),
+		historyEvents:     make(map[executionKey]map[int64]*historyEventBatch),
+		historyBranches:   make(map[string]map[string]*historyBranch),
+		historyNodes:      make(map[historyBranchKey]map[historyNodeKey]*p.DataBlob),
+		executionShards:   make(map[int]*executionShard),
+		visibilityRecords: make(map[string]map[string]*visibilityRecord),
+	}
+}
+
+func (s *store) GetName() string {
+	return inMemoryPersistenceName
+}
+
+// Close is a noop, the data outlives the stores and is only removed by DropDatabase
+func (s *store) Close() {
+}
+
+func copyBlob(blob *p.DataBlob) *p.DataBlob {
+	if blob == nil {
+		return nil
+	}
+	result := *blob
+	result.Data = copyBytes(blob.Data)
+	return &result
+}
+
+func copyBytes(data []byte) []byte {
+	if data == nil {
+		return nil
+	}
+	return append([]byte(nil), data...)
+}
+
+func serializePageToken(offset int64) []byte {
+	b := make([]byte, 8)
+	binary.LittleEndian.PutUint64(b, uint64(offset))
+	return b
+}
+
+func deserializePageToken(payload []byte) (int64, error) {
+	if len(payload) != 8 {
+		return 0, fmt.Errorf("Invalid token of %v length", len(payload))
+	}
+	return int64(binary.LittleEndian.Uint64(payload)), nil
+}
@@ -0,0 +1,1135 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package inmemory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/uber-common/bark"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/checksum"
+	"github.com/uber/cadence/common/collection"
+	p "github.com/uber/cadence/common/persistence"
+)
+
+type (
+	executionStore struct {
+		store
+		shardID int
+	}
+
+	// executionShard holds the workflow executions and the queues of a single history shard
+	executionShard struct {
+		currentExecutions map[currentExecutionKey]*currentExecution
+		executions        map[executionKey]*p.InternalWorkflowMutableState
+		transferTasks     map[int64]*p.TransferTaskInfo
+		replicationTasks  map[int64]*p.ReplicationTaskInfo
+		timerTasks        map[timerTaskKey]*p.TimerTaskInfo
+	}
+
+	currentExecutionKey struct {
+		domainID   string
+		workflowID string
+	}
+
+	currentExecution struct {
+		runID            string
+		createRequestID  string
+		state            int
+		closeStatus      int
+		startVersion     int64
+		lastWriteVersion int64
+	}
+
+	timerTaskPageToken struct {
+		TaskID    int64
+		Timestamp time.Time
+	}
+)
+
+// newExecutionStore creates an instance of ExecutionStore for the given shard
+func newExecutionStore(db *database, shardID int, logger bark.Logger) p.ExecutionStore {
+	return &executionStore{
+		store: store{
+			db:     db,
+			logger: logger,
+		},
+		shardID: shardID,
+	}
+}
+
+func (m *executionStore) GetShardID() int {
+	return m.shardID
+}
+
+func (m *executionStore) CreateWorkflowExecution(ctx context.Context, request *p.InternalCreateWorkflowExecutionRequest) (*p.CreateWorkflowExecutionResponse, error) {
+	if request.CreateWorkflowMode == p.CreateWorkflowModeContinueAsNew {
+		return nil, &workflow.InternalServiceError{
+			Message: "CreateWorkflowExecution operation failed. Invalid CreateWorkflowModeContinueAsNew is used",
+		}
+	}
+	workflowID := request.Execution.GetWorkflowId()
+	runID := request.Execution.GetRunId()
+	replicationTasks, err := toReplicationTaskInfos(request.ReplicationTasks, request.DomainID, workflowID, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	m.db.Lock()
+	defer m.db.Unlock()
+
+	if err := m.db.checkShardRangeID(m.shardID, request.RangeID); err != nil {
+		return nil, err
+	}
+	shard := m.db.getExecutionShard(m.shardID)
+
+	currKey := currentExecutionKey{domainID: request.DomainID, workflowID: workflowID}
+	if curr, ok := shard.currentExecutions[currKey]; ok {
+		switch request.CreateWorkflowMode {
+		case p.CreateWorkflowModeBrandNew:
+			lastWriteVersion := common.EmptyVersion
+			if request.ReplicationState != nil {
+				lastWriteVersion = curr.lastWriteVersion
+			}
+			return nil, &p.WorkflowExecutionAlreadyStartedError{
+				Msg:              fmt.Sprintf("Workflow execution already running. WorkflowId: %v", workflowID),
+				StartRequestID:   curr.createRequestID,
+				RunID:            curr.runID,
+				State:            curr.state,
+				CloseStatus:      curr.closeStatus,
+				LastWriteVersion: lastWriteVersion,
+			}
+		case p.CreateWorkflowModeWorkflowIDReuse:
+			if request.PreviousLastWriteVersion != curr.lastWriteVersion {
+				return nil, &p.CurrentWorkflowConditionFailedError{
+					Msg: fmt.Sprintf("Workflow execution creation condition failed. WorkflowId: %v, "+
+						"LastWriteVersion: %v, PreviousLastWriteVersion: %v",
+						workflowID, curr.lastWriteVersion, request.PreviousLastWriteVersion),
+				}
+			}
+			if curr.state != p.WorkflowStateCompleted {
+				return nil, &p.CurrentWorkflowConditionFailedError{
+					Msg: fmt.Sprintf("Workflow execution creation condition failed. WorkflowId: %v, "+
+						"State: %v, Expected: %v",
+						workflowID, curr.state, p.WorkflowStateCompleted),
+				}
+			}
+			if curr.runID != request.PreviousRunID {
+				return nil, &p.CurrentWorkflowConditionFailedError{
+					Msg: fmt.Sprintf("Workflow execution creation condition failed. WorkflowId: %v, "+
+						"RunID: %v, PreviousRunID: %v",
+						workflowID, curr.runID, request.PreviousRunID),
+				}
+			}
+		default:
+			return nil, fmt.Errorf("Unknown workflow creation mode: %v", request.CreateWorkflowMode)
+		}
+	} else if request.CreateWorkflowMode == p.CreateWorkflowModeWorkflowIDReuse {
+		return nil, &p.CurrentWorkflowConditionFailedError{
+			Msg: fmt.Sprintf("Workflow execution creation condition failed. WorkflowId: %v, "+
+				"current execution does not exist", workflowID),
+		}
+	}
+
+	execKey := executionKey{domainID: request.DomainID, workflowID: workflowID, runID: runID}
+	if _, ok := shard.executions[execKey]; ok {
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("CreateWorkflowExecution operation failed. Execution already exists. WorkflowId: %v, RunId: %v",
+				workflowID, runID),
+		}
+	}
+
+	shard.currentExecutions[currKey] = newCurrentExecution(request)
+	shard.executions[execKey] = newMutableState(request, time.Now())
+	shard.addTransferTasks(toTransferTaskInfos(request.TransferTasks, request.DomainID, workflowID, runID))
+	shard.addReplicationTasks(replicationTasks)
+	shard.addTimerTasks(toTimerTaskInfos(request.TimerTasks, request.DomainID, workflowID, runID))
+	return &p.CreateWorkflowExecutionResponse{}, nil
+}
+
+func (m *executionStore) GetWorkflowExecution(ctx context.Context, request *p.GetWorkflowExecutionRequest) (*p.InternalGetWorkflowExecutionResponse, error) {
+	m.db.Lock()
+	defer m.db.Unlock()
+
+	state, ok := m.db.getExecutionShard(m.shardID).executions[executionKey{
+		domainID:   request.DomainID,
+		workflowID: request.Execution.GetWorkflowId(),
+		runID:      request.Execution.GetRunId(),
+	}]
+	if !ok {
+		return nil, &workflow.EntityNotExistsError{
+			Message: fmt.Sprintf("Workflow execution not found.  WorkflowId: %v, RunId: %v",
+				request.Execution.GetWorkflowId(),
+				request.Execution.GetRunId()),
+		}
+	}
+	return &p.InternalGetWorkflowExecutionResponse{State: copyMutableState(state)}, nil
+}
+
+func (m *executionStore) GetWorkflowExecutions(ctx context.Context, request *p.GetWorkflowExecutionsRequest) (*p.InternalGetWorkflowExecutionsResponse, error) {
+	m.db.Lock()
+	defer m.db.Unlock()
+
+	response := &p.InternalGetWorkflowExecutionsResponse{}
+	for _, execution := range request.Executions {
+		state, ok := m.db.getExecutionShard(m.shardID).executions[executionKey{
+			domainID:   request.DomainID,
+			workflowID: execution.GetWorkflowId(),
+			runID:      execution.GetRunId(),
+		}]
+		if !ok {
+			continue
+		}
+		response.Responses = append(response.Responses, &p.InternalGetWorkflowExecutionResponse{State: copyMutableState(state)})
+	}
+	return response, nil
+}
+
+func (m *executionStore) UpdateWorkflowExecution(ctx context.Context, request *p.InternalUpdateWorkflowExecutionRequest) error {
+	info := request.ExecutionInfo
+	replicationTasks, err := toReplicationTaskInfos(request.ReplicationTasks, info.DomainID, info.WorkflowID, info.RunID)
+	if err != nil {
+		return err
+	}
+
+	m.db.Lock()
+	defer m.db.Unlock()
+
+	if err := m.db.checkShardRangeID(m.shardID, request.RangeID); err != nil {
+		return err
+	}
+	shard := m.db.getExecutionShard(m.shardID)
+
+	state, err := shard.getExecutionWithCondition(info.DomainID, info.WorkflowID, info.RunID, request.Condition)
+	if err != nil {
+		return err
+	}
+	currKey := currentExecutionKey{domainID: info.DomainID, workflowID: info.WorkflowID}
+	curr, err := shard.getCurrentExecutionWithRunID(currKey, info.RunID)
+	if err != nil {
+		return err
+	}
+	newRun := request.ContinueAsNew
+	if newRun != nil {
+		if _, ok := shard.executions[executionKey{
+			domainID:   newRun.DomainID,
+			workflowID: newRun.Execution.GetWorkflowId(),
+			runID:      newRun.Execution.GetRunId(),
+		}]; ok {
+			return &workflow.InternalServiceError{
+				Message: fmt.Sprintf("UpdateWorkflowExecution operation failed. Execution of new run already exists. RunId: %v",
+					newRun.Execution.GetRunId()),
+			}
+		}
+	}
+
+	state.ExecutionInfo = copyExecutionInfo(info)
+	if request.ReplicationState != nil {
+		state.ReplicationState = copyReplicationState(request.ReplicationState)
+	}
+	for _, ai := range request.UpsertActivityInfos {
+		state.ActivitInfos[ai.ScheduleID] = copyActivityInfo(ai)
+	}
+	for _, scheduleID := range request.DeleteActivityInfos {
+		delete(state.ActivitInfos, scheduleID)
+	}
+	for _, ti := range request.UpserTimerInfos {
+		state.TimerInfos[ti.TimerID] = copyTimerInfo(ti)
+	}
+	for _, timerID := range request.DeleteTimerInfos {
+		delete(state.TimerInfos, timerID)
+	}
+	for _, ci := range request.UpsertChildExecutionInfos {
+		state.ChildExecutionInfos[ci.InitiatedID] = copyChildExecutionInfo(ci)
+	}
+	if request.DeleteChildExecutionInfo != nil {
+		delete(state.ChildExecutionInfos, *request.DeleteChildExecutionInfo)
+	}
+	for _, rci := range request.UpsertRequestCancelInfos {
+		state.RequestCancelInfos[rci.InitiatedID] = copyRequestCancelInfo(rci)
+	}
+	if request.DeleteRequestCancelInfo != nil {
+		delete(state.RequestCancelInfos, *request.DeleteRequestCancelInfo)
+	}
+	for _, si := range request.UpsertSignalInfos {
+		state.SignalInfos[si.InitiatedID] = copySignalInfo(si)
+	}
+	if request.DeleteSignalInfo != nil {
+		delete(state.SignalInfos, *request.DeleteSignalInfo)
+	}
+	for _, signalRequestedID := range request.UpsertSignalRequestedIDs {
+		state.SignalRequestedIDs[signalRequestedID] = struct{}{}
+	}
+	if request.DeleteSignalRequestedID != "" {
+		delete(state.SignalRequestedIDs, request.DeleteSignalRequestedID)
+	}
+	if request.ClearBufferedEvents {
+		state.BufferedEvents = nil
+	} else if request.NewBufferedEvents != nil {
+		state.BufferedEvents = append(state.BufferedEvents, copyBlob(request.NewBufferedEvents))
+	}
+	if request.NewBufferedReplicationTask != nil {
+		task := copyBufferedReplicationTask(request.NewBufferedReplicationTask)
+		state.BufferedReplicationTasks[task.FirstEventID] = task
+	}
+	if request.DeleteBufferedReplicationTask != nil {
+		delete(state.BufferedReplicationTasks, *request.DeleteBufferedReplicationTask)
+	}
+	state.Checksum = copyChecksum(request.Checksum)
+
+	shard.addTransferTasks(toTransferTaskInfos(request.TransferTasks, info.DomainID, info.WorkflowID, info.RunID))
+	shard.addReplicationTasks(replicationTasks)
+	shard.addTimerTasks(toTimerTaskInfos(request.TimerTasks, info.DomainID, info.WorkflowID, info.RunID))
+	if request.DeleteTimerTask != nil {
+		delete(shard.timerTasks, newTimerTaskKey(request.DeleteTimerTask.GetVisibilityTimestamp(), request.DeleteTimerTask.GetTaskID()))
+	}
+
+	if newRun != nil {
+		newWorkflowID := newRun.Execution.GetWorkflowId()
+		newRunID := newRun.Execution.GetRunId()
+		shard.currentExecutions[currentExecutionKey{domainID: newRun.DomainID, workflowID: newWorkflowID}] = newCurrentExecution(newRun)
+		shard.executions[executionKey{domainID: newRun.DomainID, workflowID: newWorkflowID, runID: newRunID}] = newMutableState(newRun, time.Now())
+		shard.addTransferTasks(toTransferTaskInfos(newRun.TransferTasks, newRun.DomainID, newWorkflowID, newRunID))
+		shard.addTimerTasks(toTimerTaskInfos(newRun.TimerTasks, newRun.DomainID, newWorkflowID, newRunID))
+	} else {
+		curr.update(info, request.ReplicationState)
+	}
+	return nil
+}
+
+func (m *executionStore) ResetMutableState(ctx context.Context, request *p.InternalResetMutableStateRequest) error {
+	info := request.ExecutionInfo
+
+	m.db.Lock()
+	defer m.db.Unlock()
+
+	if err := m.db.checkShardRangeID(m.shardID, request.RangeID); err != nil {
+		return err
+	}
+	shard := m.db.getExecutionShard(m.shardID)
+
+	currKey := currentExecutionKey{domainID: info.DomainID, workflowID: info.WorkflowID}
+	curr, ok := shard.currentExecutions[currKey]
+	if !ok || curr.runID != request.PrevRunID {
+		return &p.CurrentWorkflowConditionFailedError{
+			Msg: fmt.Sprintf("ResetMutableState operation failed. Current run ID is not %v", request.PrevRunID),
+		}
+	}
+	if _, err := shard.getExecutionWithCondition(info.DomainID, info.WorkflowID, info.RunID, request.Condition); err != nil {
+		return err
+	}
+
+	curr.update(info, request.ReplicationState)
+	state := newResetMutableState(
+		info,
+		request.ReplicationState,
+		request.InsertActivityInfos,
+		request.InsertTimerInfos,
+		request.InsertChildExecutionInfos,
+		request.InsertRequestCancelInfos,
+		request.InsertSignalInfos,
+		request.InsertSignalRequestedIDs,
+	)
+	state.Checksum = copyChecksum(request.Checksum)
+	shard.executions[executionKey{domainID: info.DomainID, workflowID: info.WorkflowID, runID: info.RunID}] = state
+	return nil
+}
+
+func (m *executionStore) ResetWorkflowExecution(ctx context.Context, request *p.InternalResetWorkflowExecutionRequest) error {
+	currInfo := request.CurrExecutionInfo
+	insertInfo := request.InsertExecutionInfo
+	currReplicationTasks, err := toReplicationTaskInfos(request.CurrReplicationTasks, currInfo.DomainID, currInfo.WorkflowID, currInfo.RunID)
+	if err != nil {
+		return err
+	}
+	insertReplicationTasks, err := toReplicationTaskInfos(request.InsertReplicationTasks, insertInfo.DomainID, insertInfo.WorkflowID, insertInfo.RunID)
+	if err != nil {
+		return err
+	}
+
+	m.db.Lock()
+	defer m.db.Unlock()
+
+	if err := m.db.checkShardRangeID(m.shardID, request.RangeID); err != nil {
+		return err
+	}
+	shard := m.db.getExecutionShard(m.shardID)
+
+	// 1. check the current execution against the current run, prevRunState and prevRunVersion
+	curr, ok := shard.currentExecutions[currentExecutionKey{domainID: currInfo.DomainID, workflowID: currInfo.WorkflowID}]
+	if !ok {
+		return &workflow.InternalServiceError{
+			Message: "ResetWorkflowExecution operation failed. Current execution doesn't exist.",
+		}
+	}
+	if curr.runID != currInfo.RunID {
+		return &workflow.InternalServiceError{
+			Message: "ResetWorkflowExecution operation failed. RunID of current execution doesn't match",
+		}
+	}
+	if request.CurrReplicationState != nil {
+		// only check when with replication
+		if curr.lastWriteVersion != request.PrevRunVersion || curr.state != request.PrevRunState {
+			return &workflow.InternalServiceError{
+				Message: "ResetWorkflowExecution operation failed. Last state/version of current execution doesn't match",
+			}
+		}
+	}
+
+	// 2. make sure the base run hasn't been deleted after forking
+	if request.BaseRunID != currInfo.RunID {
+		if _, ok := shard.executions[executionKey{domainID: currInfo.DomainID, workflowID: currInfo.WorkflowID, runID: request.BaseRunID}]; !ok {
+			return &workflow.InternalServiceError{
+				Message: fmt.Sprintf("ResetWorkflowExecution operation failed. Base run %v doesn't exist", request.BaseRunID),
+			}
+		}
+	}
+
+	// 3. check the current run
+	currState, err := shard.getExecutionWithCondition(currInfo.DomainID, currInfo.WorkflowID, currInfo.RunID, request.Condition)
+	if err != nil {
+		return err
+	}
+	newKey := executionKey{domainID: insertInfo.DomainID, workflowID: insertInfo.WorkflowID, runID: insertInfo.RunID}
+	if _, ok := shard.executions[newKey]; ok {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("ResetWorkflowExecution operation failed. Execution of new run already exists. RunId: %v", insertInfo.RunID),
+		}
+	}
+
+	curr.update(insertInfo, request.InsertReplicationState)
+	if request.UpdateCurr {
+		currState.ExecutionInfo = copyExecutionInfo(currInfo)
+		currState.ReplicationState = copyReplicationState(request.CurrReplicationState)
+		currState.Checksum = checksum.Checksum{}
+		shard.addTransferTasks(toTransferTaskInfos(request.CurrTransferTasks, currInfo.DomainID, currInfo.WorkflowID, currInfo.RunID))
+		shard.addTimerTasks(toTimerTaskInfos(request.CurrTimerTasks, currInfo.DomainID, currInfo.WorkflowID, currInfo.RunID))
+	}
+	shard.addReplicationTasks(currReplicationTasks)
+
+	// 4. insert the new run
+	shard.executions[newKey] = newResetMutableState(
+		insertInfo,
+		request.InsertReplicationState,
+		request.InsertActivityInfos,
+		request.InsertTimerInfos,
+		request.InsertChildExecutionInfos,
+		request.InsertRequestCancelInfos,
+		request.InsertSignalInfos,
+		request.InsertSignalRequestedIDs,
+	)
+	shard.addTransferTasks(toTransferTaskInfos(request.InsertTransferTasks, insertInfo.DomainID, insertInfo.WorkflowID, insertInfo.RunID))
+	shard.addTimerTasks(toTimerTaskInfos(request.InsertTimerTasks, insertInfo.DomainID, insertInfo.WorkflowID, insertInfo.RunID))
+	shard.addReplicationTasks(insertReplicationTasks)
+	return nil
+}
+
+func (m *executionStore) DeleteWorkflowExecution(ctx context.Context, request *p.DeleteWorkflowExecutionRequest) error {
+	m.db.Lock()
+	defer m.db.Unlock()
+
+	shard := m.db.getExecutionShard(m.shardID)
+	delete(shard.executions, executionKey{domainID: request.DomainID, workflowID: request.WorkflowID, runID: request.RunID})
+	// a new run of the same workflow may have started after the run we are deleting here was finished,
+	// the current execution is only removed if it still points to the deleted run
+	currKey := currentExecutionKey{domainID: request.DomainID, workflowID: request.WorkflowID}
+	if curr, ok := shard.currentExecutions[currKey]; ok && curr.runID == request.RunID {
+		delete(shard.currentExecutions, currKey)
+	}
+	return nil
+}
+
+func (m *executionStore) GetCurrentExecution(ctx context.Context, request *p.GetCurrentExecutionRequest) (*p.GetCurrentExecutionResponse, error) {
+	m.db.Lock()
+	defer m.db.Unlock()
+
+	curr, ok := m.db.getExecutionShard(m.shardID).currentExecutions[currentExecutionKey{
+		domainID:   request.DomainID,
+		workflowID: request.WorkflowID,
+	}]
+	if !ok {
+		return nil, &workflow.EntityNotExistsError{
+			Message: fmt.Sprintf("Workflow execution not found.  WorkflowId: %v", request.WorkflowID),
+		}
+	}
+	return &p.GetCurrentExecutionResponse{
+		StartRequestID:   curr.createRequestID,
+		RunID:            curr.runID,
+		State:            curr.state,
+		CloseStatus:      curr.closeStatus,
+		LastWriteVersion: curr.lastWriteVersion,
+	}, nil
+}
+
+func (m *executionStore) GetTransferTasks(ctx context.Context, request *p.GetTransferTasksRequest) (*p.GetTransferTasksResponse, error) {
+	m.db.Lock()
+	defer m.db.Unlock()
+
+	resp := &p.GetTransferTasksResponse{}
+	for taskID, task := range m.db.getExecutionShard(m.shardID).transferTasks {
+		if taskID > request.ReadLevel && taskID <= request.MaxReadLevel {
+			t := *task
+			resp.Tasks = append(resp.Tasks, &t)
+		}
+	}
+	sort.Slice(resp.Tasks, func(i, j int) bool {
+		return resp.Tasks[i].TaskID < resp.Tasks[j].TaskID
+	})
+	return resp, nil
+}
+
+func (m *executionStore) CompleteTransferTask(ctx context.Context, request *p.CompleteTransferTaskRequest) error {
+	m.db.Lock()
+	defer m.db.Unlock()
+
+	delete(m.db.getExecutionShard(m.shardID).transferTasks, request.TaskID)
+	return nil
+}
+
+func (m *executionStore) RangeCompleteTransferTask(ctx context.Context, request *p.RangeCompleteTransferTaskRequest) error {
+	m.db.Lock()
+	defer m.db.Unlock()
+
+	shard := m.db.getExecutionShard(m.shardID)
+	for taskID := range shard.transferTasks {
+		if taskID > request.ExclusiveBeginTaskID && taskID <= request.InclusiveEndTaskID {
+			delete(shard.transferTasks, taskID)
+		}
+	}
+	return nil
+}
+
+func (m *executionStore) GetReplicationTasks(ctx context.Context, request *p.GetReplicationTasksRequest) (*p.GetReplicationTasksResponse, error) {
+	readLevel := request.ReadLevel
+	if len(request.NextPageToken) > 0 {
+		var err error
+		readLevel, err = deserializePageToken(request.NextPageToken)
+		if err != nil {
+			return nil, &workflow.InternalServiceError{
+				Message: fmt.Sprintf("GetReplicationTasks operation failed. Invalid next page token. Error: %v", err),
+			}
+		}
+	}
+	maxReadLevelInclusive := collection.MaxInt64(readLevel+int64(request.BatchSize), request.MaxReadLevel)
+
+	m.db.Lock()
+	defer m.db.Unlock()
+
+	var tasks []*p.ReplicationTaskInfo
+	for taskID, task := range m.db.getExecutionShard(m.shardID).replicationTasks {
+		if taskID > readLevel && taskID <= maxReadLevelInclusive {
+			tasks = append(tasks, copyReplicationTaskInfo(task))
+		}
+	}
+	if len(tasks) == 0 {
+		return &p.GetReplicationTasksResponse{}, nil
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].TaskID < tasks[j].TaskID
+	})
+	if len(tasks) > request.BatchSize {
+		tasks = tasks[:request.BatchSize]
+	}
+
+	var nextPageToken []byte
+	lastTaskID := tasks[len(tasks)-1].TaskID
+	if lastTaskID < request.MaxReadLevel {
+		nextPageToken = serializePageToken(lastTaskID)
+	}
+	return &p.GetReplicationTasksResponse{
+		Tasks:         tasks,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+func (m *executionStore) CompleteReplicationTask(ctx context.Context, request *p.CompleteReplicationTaskRequest) error {
+	m.db.Lock()
+	defer m.db.Unlock()
+
+	delete(m.db.getExecutionShard(m.shardID).replicationTasks, request.TaskID)
+	return nil
+}
+
+func (m *executionStore) GetTimerIndexTasks(ctx context.Context, request *p.GetTimerIndexTasksRequest) (*p.GetTimerIndexTasksResponse, error) {
+	pageToken := &timerTaskPageToken{TaskID: math.MinInt64, Timestamp: request.MinTimestamp}
+	if len(request.NextPageToken) > 0 {
+		if err := json.Unmarshal(request.NextPageToken, pageToken); err != nil {
+			return nil, &workflow.InternalServiceError{
+				Message: fmt.Sprintf("error deserializing timerTaskPageToken: %v", err),
+			}
+		}
+	}
+	minKey := newTimerTaskKey(pageToken.Timestamp, pageToken.TaskID)
+	maxTimestamp := request.MaxTimestamp.UnixNano()
+
+	m.db.Lock()
+	defer m.db.Unlock()
+
+	var keys []timerTaskKey
+	shard := m.db.getExecutionShard(m.shardID)
+	for key := range shard.timerTasks {
+		if !key.less(minKey) && key.visibilityTimestamp < maxTimestamp {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].less(keys[j])
+	})
+
+	resp := &p.GetTimerIndexTasksResponse{}
+	if len(keys) > request.BatchSize {
+		next := shard.timerTasks[keys[request.BatchSize]]
+		nextToken, err := json.Marshal(&timerTaskPageToken{
+			TaskID:    next.TaskID,
+			Timestamp: next.VisibilityTimestamp,
+		})
+		if err != nil {
+			return nil, &workflow.InternalServiceError{
+				Message: fmt.Sprintf("GetTimerTasks: error serializing page token: %v", err),
+			}
+		}
+		resp.NextPageToken = nextToken
+		keys = keys[:request.BatchSize]
+	}
+	for _, key := range keys {
+		t := *shard.timerTasks[key]
+		resp.Timers = append(resp.Timers, &t)
+	}
+	return resp, nil
+}
+
+func (m *executionStore) CompleteTimerTask(ctx context.Context, request *p.CompleteTimerTaskRequest) error {
+	m.db.Lock()
+	defer m.db.Unlock()
+
+	delete(m.db.getExecutionShard(m.shardID).timerTasks, newTimerTaskKey(request.VisibilityTimestamp, request.TaskID))
+	return nil
+}
+
+func (m *executionStore) RangeCompleteTimerTask(ctx context.Context, request *p.RangeCompleteTimerTaskRequest) error {
+	begin := request.InclusiveBeginTimestamp.UnixNano()
+	end := request.ExclusiveEndTimestamp.UnixNano()
+
+	m.db.Lock()
+	defer m.db.Unlock()
+
+	shard := m.db.getExecutionShard(m.shardID)
+	for key := range shard.timerTasks {
+		if key.visibilityTimestamp >= begin && key.visibilityTimestamp < end {
+			delete(shard.timerTasks, key)
+		}
+	}
+	return nil
+}
+
+// getExecutionShard returns the executions of a shard, it must be called with the database lock held
+func (db *database) getExecutionShard(shardID int) *executionShard {
+	shard, ok := db.executionShards[shardID]
+	if !ok {
+		shard = &executionShard{
+			currentExecutions: make(map[currentExecutionKey]*currentExecution),
+			executions:        make(map[executionKey]*p.InternalWorkflowMutableState),
+			transferTasks:     make(map[int64]*p.TransferTaskInfo),
+			replicationTasks:  make(map[int64]*p.ReplicationTaskInfo),
+			timerTasks:        make(map[timerTaskKey]*p.TimerTaskInfo),
+		}
+		db.executionShards[shardID] = shard
+	}
+	return shard
+}
+
+func (s *executionShard) getExecutionWithCondition(domainID, workflowID, runID string, condition int64) (*p.InternalWorkflowMutableState, error) {
+	state, ok := s.executions[executionKey{domainID: domainID, workflowID: workflowID, runID: runID}]
+	if !ok {
+		return nil, &workflow.EntityNotExistsError{
+			Message: fmt.Sprintf("Workflow execution not found.  WorkflowId: %v, RunId: %v", workflowID, runID),
+		}
+	}
+	if state.ExecutionInfo.NextEventID != condition {
+		return nil, &p.ConditionFailedError{
+			Msg: fmt.Sprintf("next_event_id was %v when it should have been %v.", state.ExecutionInfo.NextEventID, condition),
+		}
+	}
+	return state, nil
+}
+
+func (s *executionShard) getCurrentExecutionWithRunID(key currentExecutionKey, runID string) (*currentExecution, error) {
+	curr, ok := s.currentExecutions[key]
+	if !ok {
+		return nil, &p.ConditionFailedError{
+			Msg: fmt.Sprintf("Current execution doesn't exist, expected run ID %v", runID),
+		}
+	}
+	if curr.runID != runID {
+		return nil, &p.ConditionFailedError{
+			Msg: fmt.Sprintf("Current run ID was %v, expected %v", curr.runID, runID),
+		}
+	}
+	return curr, nil
+}
+
+func (s *executionShard) addTransferTasks(tasks []*p.TransferTaskInfo) {
+	for _, task := range tasks {
+		s.transferTasks[task.TaskID] = task
+	}
+}
+
+func (s *executionShard) addReplicationTasks(tasks []*p.ReplicationTaskInfo) {
+	for _, task := range tasks {
+		s.replicationTasks[task.TaskID] = task
+	}
+}
+
+func (s *executionShard) addTimerTasks(tasks []*p.TimerTaskInfo) {
+	for _, task := range tasks {
+		s.timerTasks[newTimerTaskKey(task.VisibilityTimestamp, task.TaskID)] = task
+	}
+}
+
+func (c *currentExecution) update(info *p.InternalWorkflowExecutionInfo, replicationState *p.ReplicationState) {
+	c.runID = info.RunID
+	c.createRequestID = info.CreateRequestID
+	c.state = info.State
+	c.closeStatus = info.CloseStatus
+	c.startVersion = common.EmptyVersion
+	c.lastWriteVersion = common.EmptyVersion
+	if replicationState != nil {
+		c.startVersion = replicationState.StartVersion
+		c.lastWriteVersion = replicationState.LastWriteVersion
+	}
+}
+
+func newTimerTaskKey(visibilityTimestamp time.Time, taskID int64) timerTaskKey {
+	return timerTaskKey{visibilityTimestamp: visibilityTimestamp.UnixNano(), taskID: taskID}
+}
+
+func (k timerTaskKey) less(other timerTaskKey) bool {
+	if k.visibilityTimestamp != other.visibilityTimestamp {
+		return k.visibilityTimestamp < other.visibilityTimestamp
+	}
+	return k.taskID < other.taskID
+}
+
+func newCurrentExecution(request *p.InternalCreateWorkflowExecutionRequest) *currentExecution {
+	curr := &currentExecution{
+		runID:            request.Execution.GetRunId(),
+		createRequestID:  request.RequestID,
+		state:            p.WorkflowStateRunning,
+		closeStatus:      p.WorkflowCloseStatusNone,
+		startVersion:     common.EmptyVersion,
+		lastWriteVersion: common.EmptyVersion,
+	}
+	if request.ReplicationState != nil {
+		curr.startVersion = request.ReplicationState.StartVersion
+		curr.lastWriteVersion = request.ReplicationState.LastWriteVersion
+	}
+	if request.ParentExecution != nil && request.CreateWorkflowMode == p.CreateWorkflowModeBrandNew {
+		curr.state = p.WorkflowStateCreated
+	}
+	return curr
+}
+
+func newMutableState(request *p.InternalCreateWorkflowExecutionRequest, now time.Time) *p.InternalWorkflowMutableState {
+	info := &p.InternalWorkflowExecutionInfo{
+		DomainID:               request.DomainID,
+		WorkflowID:             request.Execution.GetWorkflowId(),
+		RunID:                  request.Execution.GetRunId(),
+		InitiatedID:            common.EmptyEventID,
+		CompletionEventBatchID: common.EmptyEventID,
+		TaskList:               request.TaskList,
+		WorkflowTypeName:       request.WorkflowTypeName,
+		WorkflowTimeout:        request.WorkflowTimeout,
+		DecisionTimeoutValue:   request.DecisionTimeoutValue,
+		ExecutionContext:       copyBytes(request.ExecutionContext),
+		State:                  p.WorkflowStateCreated,
+		CloseStatus:            p.WorkflowCloseStatusNone,
+		LastFirstEventID:       common.FirstEventID,
+		LastEventTaskID:        request.LastEventTaskID,
+		NextEventID:            request.NextEventID,
+		LastProcessedEvent:     request.LastProcessedEvent,
+		StartTimestamp:         now,
+		LastUpdatedTimestamp:   now,
+		CreateRequestID:        request.RequestID,
+		SignalCount:            request.SignalCount,
+		HistorySize:            request.HistorySize,
+		DecisionVersion:        request.DecisionVersion,
+		DecisionScheduleID:     request.DecisionScheduleID,
+		DecisionStartedID:      request.DecisionStartedID,
+		DecisionTimeout:        request.DecisionStartToCloseTimeout,
+		Attempt:                request.Attempt,
+		HasRetryPolicy:         request.HasRetryPolicy,
+		InitialInterval:        request.InitialInterval,
+		BackoffCoefficient:     request.BackoffCoefficient,
+		MaximumInterval:        request.MaximumInterval,
+		ExpirationTime:         request.ExpirationTime,
+		MaximumAttempts:        request.MaximumAttempts,
+		NonRetriableErrors:     copyStrings(request.NonRetriableErrors),
+		CronSchedule:           request.CronSchedule,
+		ExpirationSeconds:      request.ExpirationSeconds,
+		VersionHistories:       copyBlob(request.VersionHistories),
+	}
+	if request.ParentExecution != nil {
+		info.ParentDomainID = request.ParentDomainID
+		info.ParentWorkflowID = request.ParentExecution.GetWorkflowId()
+		info.ParentRunID = request.ParentExecution.GetRunId()
+		info.InitiatedID = request.InitiatedID
+	}
+	if request.EventStoreVersion == p.EventStoreVersionV2 {
+		info.EventStoreVersion = p.EventStoreVersionV2
+		info.BranchToken = copyBytes(request.BranchToken)
+	}
+	return newResetMutableState(info, request.ReplicationState, nil, nil, nil, nil, nil, nil)
+}
+
+// newResetMutableState creates the state of an execution from scratch, copying all the given records
+func newResetMutableState(
+	info *p.InternalWorkflowExecutionInfo,
+	replicationState *p.ReplicationState,
+	activityInfos []*p.InternalActivityInfo,
+	timerInfos []*p.TimerInfo,
+	childExecutionInfos []*p.InternalChildExecutionInfo,
+	requestCancelInfos []*p.RequestCancelInfo,
+	signalInfos []*p.SignalInfo,
+	signalRequestedIDs []string,
+) *p.InternalWorkflowMutableState {
+	state := &p.InternalWorkflowMutableState{
+		ActivitInfos:             make(map[int64]*p.InternalActivityInfo),
+		TimerInfos:               make(map[string]*p.TimerInfo),
+		ChildExecutionInfos:      make(map[int64]*p.InternalChildExecutionInfo),
+		RequestCancelInfos:       make(map[int64]*p.RequestCancelInfo),
+		SignalInfos:              make(map[int64]*p.SignalInfo),
+		SignalRequestedIDs:       make(map[string]struct{}),
+		ExecutionInfo:            copyExecutionInfo(info),
+		ReplicationState:         copyReplicationState(replicationState),
+		BufferedReplicationTasks: make(map[int64]*p.InternalBufferedReplicationTask),
+	}
+	for _, ai := range activityInfos {
+		state.ActivitInfos[ai.ScheduleID] = copyActivityInfo(ai)
+	}
+	for _, ti := range timerInfos {
+		state.TimerInfos[ti.TimerID] = copyTimerInfo(ti)
+	}
+	for _, ci := range childExecutionInfos {
+		state.ChildExecutionInfos[ci.InitiatedID] = copyChildExecutionInfo(ci)
+	}
+	for _, rci := range requestCancelInfos {
+		state.RequestCancelInfos[rci.InitiatedID] = copyRequestCancelInfo(rci)
+	}
+	for _, si := range signalInfos {
+		state.SignalInfos[si.InitiatedID] = copySignalInfo(si)
+	}
+	for _, signalRequestedID := range signalRequestedIDs {
+		state.SignalRequestedIDs[signalRequestedID] = struct{}{}
+	}
+	return state
+}
+
+func toTransferTaskInfos(tasks []p.Task, domainID, workflowID, runID string) []*p.TransferTaskInfo {
+	result := make([]*p.TransferTaskInfo, len(tasks))
+	for i, task := range tasks {
+		info := &p.TransferTaskInfo{
+			DomainID:            domainID,
+			WorkflowID:          workflowID,
+			RunID:               runID,
+			VisibilityTimestamp: task.GetVisibilityTimestamp(),
+			TaskID:              task.GetTaskID(),
+			TargetDomainID:      domainID,
+			TargetWorkflowID:    p.TransferTaskTransferTargetWorkflowID,
+			TaskType:            task.GetType(),
+			Version:             task.GetVersion(),
+		}
+
+		switch t := task.(type) {
+		case *p.ActivityTask:
+			info.TargetDomainID = t.DomainID
+			info.TaskList = t.TaskList
+			info.ScheduleID = t.ScheduleID
+
+		case *p.DecisionTask:
+			info.TargetDomainID = t.DomainID
+			info.TaskList = t.TaskList
+			info.ScheduleID = t.ScheduleID
+			info.RecordVisibility = t.RecordVisibility
+
+		case *p.CancelExecutionTask:
+			info.TargetDomainID = t.TargetDomainID
+			info.TargetWorkflowID = t.TargetWorkflowID
+			info.TargetRunID = t.TargetRunID
+			info.TargetChildWorkflowOnly = t.TargetChildWorkflowOnly
+			info.ScheduleID = t.InitiatedID
+
+		case *p.SignalExecutionTask:
+			info.TargetDomainID = t.TargetDomainID
+			info.TargetWorkflowID = t.TargetWorkflowID
+			info.TargetRunID = t.TargetRunID
+			info.TargetChildWorkflowOnly = t.TargetChildWorkflowOnly
+			info.ScheduleID = t.InitiatedID
+
+		case *p.StartChildExecutionTask:
+			info.TargetDomainID = t.TargetDomainID
+			info.TargetWorkflowID = t.TargetWorkflowID
+			info.ScheduleID = t.InitiatedID
+
+		case *p.CloseExecutionTask:
+			info.RequestID = t.RequestID
+			info.CallerIdentity = t.CallerIdentity
+
+		case *p.RecordWorkflowStartedTask:
+			info.RequestID = t.RequestID
+			info.CallerIdentity = t.CallerIdentity
+
+		case *p.UpsertWorkflowLabelsTask:
+			info.RequestID = t.RequestID
+			info.CallerIdentity = t.CallerIdentity
+		}
+		result[i] = info
+	}
+	return result
+}
+
+func toReplicationTaskInfos(tasks []p.Task, domainID, workflowID, runID string) ([]*p.ReplicationTaskInfo, error) {
+	result := make([]*p.ReplicationTaskInfo, len(tasks))
+	for i, task := range tasks {
+		info := &p.ReplicationTaskInfo{
+			DomainID:     domainID,
+			WorkflowID:   workflowID,
+			RunID:        runID,
+			TaskID:       task.GetTaskID(),
+			TaskType:     task.GetType(),
+			FirstEventID: common.EmptyEventID,
+			NextEventID:  common.EmptyEventID,
+			Version:      task.GetVersion(),
+			ScheduledID:  common.EmptyEventID,
+		}
+
+		switch t := task.(type) {
+		case *p.HistoryReplicationTask:
+			info.FirstEventID = t.FirstEventID
+			info.NextEventID = t.NextEventID
+			info.LastReplicationInfo = copyReplicationInfos(t.LastReplicationInfo)
+			info.EventStoreVersion = t.EventStoreVersion
+			info.NewRunEventStoreVersion = t.NewRunEventStoreVersion
+			info.BranchToken = copyBytes(t.BranchToken)
+			info.NewRunBranchToken = copyBytes(t.NewRunBranchToken)
+			info.ResetWorkflow = t.ResetWorkflow
+
+		case *p.SyncActivityTask:
+			info.ScheduledID = t.ScheduledID
+
+		default:
+			return nil, &workflow.InternalServiceError{
+				Message: fmt.Sprintf("Unknown replication task: %v", task),
+			}
+		}
+		result[i] = info
+	}
+	return result, nil
+}
+
+func toTimerTaskInfos(tasks []p.Task, domainID, workflowID, runID string) []*p.TimerTaskInfo {
+	result := make([]*p.TimerTaskInfo, len(tasks))
+	for i, task := range tasks {
+		info := &p.TimerTaskInfo{
+			DomainID:            domainID,
+			WorkflowID:          workflowID,
+			RunID:               runID,
+			VisibilityTimestamp: task.GetVisibilityTimestamp(),
+			TaskID:              task.GetTaskID(),
+			TaskType:            task.GetType(),
+			Version:             task.GetVersion(),
+		}
+
+		switch t := task.(type) {
+		case *p.DecisionTimeoutTask:
+			info.EventID = t.EventID
+			info.TimeoutType = t.TimeoutType
+			info.ScheduleAttempt = t.ScheduleAttempt
+		case *p.ActivityTimeoutTask:
+			info.EventID = t.EventID
+			info.TimeoutType = t.TimeoutType
+			info.ScheduleAttempt = t.Attempt
+		case *p.UserTimerTask:
+			info.EventID = t.EventID
+		case *p.ActivityRetryTimerTask:
+			info.EventID = t.EventID
+			info.ScheduleAttempt = int64(t.Attempt)
+		case *p.WorkflowBackoffTimerTask:
+			info.EventID = t.EventID
+			info.TimeoutType = t.TimeoutType
+		case *p.DelayedSignalTask:
+			info.SignalName = t.SignalName
+			info.SignalInput = t.Input
+			info.SignalIdentity = t.Identity
+			info.SignalRequestID = t.RequestID
+		}
+		result[i] = info
+	}
+	return result
+}
+
+func copyMutableState(state *p.InternalWorkflowMutableState) *p.InternalWorkflowMutableState {
+	result := &p.InternalWorkflowMutableState{
+		ActivitInfos:             make(map[int64]*p.InternalActivityInfo, len(state.ActivitInfos)),
+		TimerInfos:               make(map[string]*p.TimerInfo, len(state.TimerInfos)),
+		ChildExecutionInfos:      make(map[int64]*p.InternalChildExecutionInfo, len(state.ChildExecutionInfos)),
+		RequestCancelInfos:       make(map[int64]*p.RequestCancelInfo, len(state.RequestCancelInfos)),
+		SignalInfos:              make(map[int64]*p.SignalInfo, len(state.SignalInfos)),
+		SignalRequestedIDs:       make(map[string]struct{}, len(state.SignalRequestedIDs)),
+		ExecutionInfo:            copyExecutionInfo(state.ExecutionInfo),
+		ReplicationState:         copyReplicationState(state.ReplicationState),
+		BufferedReplicationTasks: make(map[int64]*p.InternalBufferedReplicationTask, len(state.BufferedReplicationTasks)),
+		Checksum:                 copyChecksum(state.Checksum),
+	}
+	for k, v := range state.ActivitInfos {
+		result.ActivitInfos[k] = copyActivityInfo(v)
+	}
+	for k, v := range state.TimerInfos {
+		result.TimerInfos[k] = copyTimerInfo(v)
+	}
+	for k, v := range state.ChildExecutionInfos {
+		result.ChildExecutionInfos[k] = copyChildExecutionInfo(v)
+	}
+	for k, v := range state.RequestCancelInfos {
+		result.RequestCancelInfos[k] = copyRequestCancelInfo(v)
+	}
+	for k, v := range state.SignalInfos {
+		result.SignalInfos[k] = copySignalInfo(v)
+	}
+	for k := range state.SignalRequestedIDs {
+		result.SignalRequestedIDs[k] = struct{}{}
+	}
+	for _, v := range state.BufferedEvents {
+		result.BufferedEvents = append(result.BufferedEvents, copyBlob(v))
+	}
+	for k, v := range state.BufferedReplicationTasks {
+		result.BufferedReplicationTasks[k] = copyBufferedReplicationTask(v)
+	}
+	return result
+}
+
+func copyExecutionInfo(info *p.InternalWorkflowExecutionInfo) *p.InternalWorkflowExecutionInfo {
+	result := *info
+	result.CompletionEvent = copyBlob(info.CompletionEvent)
+	result.ExecutionContext = copyBytes(info.ExecutionContext)
+	result.NonRetriableErrors = copyStrings(info.NonRetriableErrors)
+	result.BranchToken = copyBytes(info.BranchToken)
+	result.Labels = copyStringMap(info.Labels)
+	result.VersionHistories = copyBlob(info.VersionHistories)
+	return &result
+}
+
+func copyReplicationState(state *p.ReplicationState) *p.ReplicationState {
+	if state == nil {
+		return nil
+	}
+	result := *state
+	result.LastReplicationInfo = copyReplicationInfos(state.LastReplicationInfo)
+	return &result
+}
+
+func copyReplicationInfos(infos map[string]*p.ReplicationInfo) map[string]*p.ReplicationInfo {
+	if infos == nil {
+		return nil
+	}
+	result := make(map[string]*p.ReplicationInfo, len(infos))
+	for k, v := range infos {
+		info := *v
+		result[k] = &info
+	}
+	return result
+}
+
+func copyActivityInfo(info *p.InternalActivityInfo) *p.InternalActivityInfo {
+	result := *info
+	result.ScheduledEvent = copyBlob(info.ScheduledEvent)
+	result.StartedEvent = copyBlob(info.StartedEvent)
+	result.Details = copyBytes(info.Details)
+	result.NonRetriableErrors = copyStrings(info.NonRetriableErrors)
+	return &result
+}
+
+func copyTimerInfo(info *p.TimerInfo) *p.TimerInfo {
+	result := *info
+	return &result
+}
+
+func copyChildExecutionInfo(info *p.InternalChildExecutionInfo) *p.InternalChildExecutionInfo {
+	result := *info
+	result.InitiatedEvent = copyBlob(info.InitiatedEvent)
+	result.StartedEvent = copyBlob(info.StartedEvent)
+	return &result
+}
+
+func copyRequestCancelInfo(info *p.RequestCancelInfo) *p.RequestCancelInfo {
+	result := *info
+	return &result
+}
+
+func copySignalInfo(info *p.SignalInfo) *p.SignalInfo {
+	result := *info
+	result.Input = copyBytes(info.Input)
+	result.Control = copyBytes(info.Control)
+	return &result
+}
+
+func copyBufferedReplicationTask(task *p.InternalBufferedReplicationTask) *p.InternalBufferedReplicationTask {
+	result := *task
+	result.History = copyBlob(task.History)
+	result.NewRunHistory = copyBlob(task.NewRunHistory)
+	return &result
+}
+
+func copyReplicationTaskInfo(task *p.ReplicationTaskInfo) *p.ReplicationTaskInfo {
+	result := *task
+	result.LastReplicationInfo = copyReplicationInfos(task.LastReplicationInfo)
+	result.BranchToken = copyBytes(task.BranchToken)
+	result.NewRunBranchToken = copyBytes(task.NewRunBranchToken)
+	return &result
+}
+
+func copyStrings(s []string) []string {
+	if s == nil {
+		return nil
+	}
+	return append([]string(nil), s...)
+}
+
+func copyChecksum(csum checksum.Checksum) checksum.Checksum {
+	csum.Value = copyBytes(csum.Value)
+	return csum
+}
+
+func copyStringMap(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	result := make(map[string]string, len(m))
+	for k, v := range m {
+		result[k] = v
+	}
+	return result
+}
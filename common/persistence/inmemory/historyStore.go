@@ -0,0 +1,157 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package inmemory
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/uber-common/bark"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	p "github.com/uber/cadence/common/persistence"
+)
+
+type (
+	historyStore struct {
+		store
+	}
+
+	historyEventBatch struct {
+		eventBatchVersion int64
+		rangeID           int64
+		txID              int64
+		events            *p.DataBlob
+	}
+)
+
+// newHistoryStore creates an instance of HistoryStore
+func newHistoryStore(db *database, logger bark.Logger) p.HistoryStore {
+	return &historyStore{
+		store: store{
+			db:     db,
+			logger: logger,
+		},
+	}
+}
+
+func (m *historyStore) AppendHistoryEvents(request *p.InternalAppendHistoryEventsRequest) error {
+	m.db.Lock()
+	defer m.db.Unlock()
+
+	key := executionKey{
+		domainID:   request.DomainID,
+		workflowID: request.Execution.GetWorkflowId(),
+		runID:      request.Execution.GetRunId(),
+	}
+	batches, ok := m.db.historyEvents[key]
+	if !ok {
+		batches = make(map[int64]*historyEventBatch)
+		m.db.historyEvents[key] = batches
+	}
+
+	batch, ok := batches[request.FirstEventID]
+	if request.Overwrite {
+		if !ok || batch.rangeID > request.RangeID || batch.txID >= request.TransactionID {
+			return &p.ConditionFailedError{
+				Msg: "Failed to append history events.",
+			}
+		}
+	} else if ok {
+		return &p.ConditionFailedError{
+			Msg: "Failed to append history events.",
+		}
+	}
+
+	batches[request.FirstEventID] = &historyEventBatch{
+		eventBatchVersion: request.EventBatchVersion,
+		rangeID:           request.RangeID,
+		txID:              request.TransactionID,
+		events:            copyBlob(request.Events),
+	}
+	return nil
+}
+
+func (m *historyStore) GetWorkflowExecutionHistory(request *p.InternalGetWorkflowExecutionHistoryRequest) (
+	*p.InternalGetWorkflowExecutionHistoryResponse, error) {
+	firstEventID := request.FirstEventID
+	if len(request.NextPageToken) > 0 {
+		var err error
+		if firstEventID, err = deserializePageToken(request.NextPageToken); err != nil {
+			return nil, &workflow.InternalServiceError{
+				Message: fmt.Sprintf("GetWorkflowExecutionHistory operation failed. Invalid next page token %v", request.NextPageToken),
+			}
+		}
+	}
+
+	m.db.Lock()
+	defer m.db.Unlock()
+
+	key := executionKey{
+		domainID:   request.DomainID,
+		workflowID: request.Execution.GetWorkflowId(),
+		runID:      request.Execution.GetRunId(),
+	}
+	batches := m.db.historyEvents[key]
+	var eventIDs []int64
+	for eventID := range batches {
+		if eventID >= firstEventID && eventID < request.NextEventID {
+			eventIDs = append(eventIDs, eventID)
+		}
+	}
+	sort.Slice(eventIDs, func(i, j int) bool {
+		return eventIDs[i] < eventIDs[j]
+	})
+
+	var nextPageToken []byte
+	if len(eventIDs) > request.PageSize {
+		nextPageToken = serializePageToken(eventIDs[request.PageSize])
+		eventIDs = eventIDs[:request.PageSize]
+	}
+
+	// NOTE: the batch versions must not decrease, batches of an older version are skipped
+	lastEventBatchVersion := request.LastEventBatchVersion
+	history := make([]*p.DataBlob, 0, len(eventIDs))
+	for _, eventID := range eventIDs {
+		batch := batches[eventID]
+		if batch.eventBatchVersion >= lastEventBatchVersion {
+			history = append(history, copyBlob(batch.events))
+			lastEventBatchVersion = batch.eventBatchVersion
+		}
+	}
+
+	return &p.InternalGetWorkflowExecutionHistoryResponse{
+		NextPageToken:         nextPageToken,
+		History:               history,
+		LastEventBatchVersion: lastEventBatchVersion,
+	}, nil
+}
+
+func (m *historyStore) DeleteWorkflowExecutionHistory(request *p.DeleteWorkflowExecutionHistoryRequest) error {
+	m.db.Lock()
+	defer m.db.Unlock()
+
+	delete(m.db.historyEvents, executionKey{
+		domainID:   request.DomainID,
+		workflowID: request.Execution.GetWorkflowId(),
+		runID:      request.Execution.GetRunId(),
+	})
+	return nil
+}
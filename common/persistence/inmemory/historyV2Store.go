@@ -0,0 +1,334 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package inmemory
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	p "github.com/uber/cadence/common/persistence"
+)
+
+type (
+	historyV2Store struct {
+		store
+	}
+
+	historyBranch struct {
+		ancestors  []*shared.HistoryBranchRange
+		inProgress bool
+		createdTs  time.Time
+		info       string
+	}
+)
+
+// newHistoryV2Store creates an instance of HistoryV2Store
+func newHistoryV2Store(db *database, logger bark.Logger) p.HistoryV2Store {
+	return &historyV2Store{
+		store: store{
+			db:     db,
+			logger: logger,
+		},
+	}
+}
+
+// AppendHistoryNodes add(or override) a node to a history branch
+func (m *historyV2Store) AppendHistoryNodes(request *p.InternalAppendHistoryNodesRequest) error {
+	branchInfo := request.BranchInfo
+	if request.NodeID < p.GetBeginNodeID(branchInfo) {
+		return &p.InvalidPersistenceRequestError{
+			Msg: "cannot append to ancestors' nodes",
+		}
+	}
+
+	m.db.Lock()
+	defer m.db.Unlock()
+
+	branchKey := historyBranchKey{treeID: branchInfo.GetTreeID(), branchID: branchInfo.GetBranchID()}
+	nodes, ok := m.db.historyNodes[branchKey]
+	if !ok {
+		nodes = make(map[historyNodeKey]*p.DataBlob)
+		m.db.historyNodes[branchKey] = nodes
+	}
+	nodeKey := historyNodeKey{nodeID: request.NodeID, txnID: request.TransactionID}
+	if _, ok := nodes[nodeKey]; ok {
+		return &p.ConditionFailedError{
+			Msg: fmt.Sprintf("AppendHistoryNodes: node %v of transaction %v already exist", request.NodeID, request.TransactionID),
+		}
+	}
+	nodes[nodeKey] = copyBlob(request.Events)
+
+	if request.IsNewBranch {
+		m.db.putHistoryBranch(branchKey, &historyBranch{
+			ancestors: copyBranchRanges(branchInfo.Ancestors),
+			createdTs: time.Now(),
+			info:      request.Info,
+		})
+	}
+	return nil
+}
+
+// ReadHistoryBranch returns history node data for a branch
+func (m *historyV2Store) ReadHistoryBranch(request *p.InternalReadHistoryBranchRequest) (*p.InternalReadHistoryBranchResponse, error) {
+	minNodeID := request.MinNodeID
+	if len(request.NextPageToken) > 0 {
+		lastNodeID, err := deserializePageToken(request.NextPageToken)
+		if err != nil {
+			return nil, &shared.InternalServiceError{
+				Message: fmt.Sprintf("invalid next page token %v", request.NextPageToken)}
+		}
+		minNodeID = lastNodeID + 1
+	}
+
+	m.db.Lock()
+	defer m.db.Unlock()
+
+	// for each node, only the one written by the largest transaction is returned
+	latest := make(map[int64]historyNodeKey)
+	for key := range m.db.historyNodes[historyBranchKey{treeID: request.TreeID, branchID: request.BranchID}] {
+		if key.nodeID < minNodeID || key.nodeID >= request.MaxNodeID {
+			continue
+		}
+		if curr, ok := latest[key.nodeID]; !ok || curr.txnID < key.txnID {
+			latest[key.nodeID] = key
+		}
+	}
+	nodeIDs := make([]int64, 0, len(latest))
+	for nodeID := range latest {
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+	sort.Slice(nodeIDs, func(i, j int) bool {
+		return nodeIDs[i] < nodeIDs[j]
+	})
+
+	response := &p.InternalReadHistoryBranchResponse{}
+	if len(nodeIDs) > request.PageSize {
+		nodeIDs = nodeIDs[:request.PageSize]
+		response.NextPageToken = serializePageToken(nodeIDs[len(nodeIDs)-1])
+	}
+	nodes := m.db.historyNodes[historyBranchKey{treeID: request.TreeID, branchID: request.BranchID}]
+	for _, nodeID := range nodeIDs {
+		response.History = append(response.History, copyBlob(nodes[latest[nodeID]]))
+	}
+	return response, nil
+}
+
+// ForkHistoryBranch forks a new branch from an existing branch, see the SQL store
+// for a detailed description of the valid forking nodeIDs
+func (m *historyV2Store) ForkHistoryBranch(request *p.InternalForkHistoryBranchRequest) (*p.InternalForkHistoryBranchResponse, error) {
+	forkB := request.ForkBranchInfo
+	treeID := forkB.GetTreeID()
+	newAncestors := make([]*shared.HistoryBranchRange, 0, len(forkB.Ancestors)+1)
+
+	beginNodeID := p.GetBeginNodeID(forkB)
+	if beginNodeID >= request.ForkNodeID {
+		// this is the case that new branch's ancestors doesn't include the forking branch
+		for _, br := range forkB.Ancestors {
+			if br.GetEndNodeID() >= request.ForkNodeID {
+				newAncestors = append(newAncestors, &shared.HistoryBranchRange{
+					BranchID:    br.BranchID,
+					BeginNodeID: br.BeginNodeID,
+					EndNodeID:   common.Int64Ptr(request.ForkNodeID),
+				})
+				break
+			}
+			newAncestors = append(newAncestors, br)
+		}
+	} else {
+		// this is the case the new branch will inherit all ancestors from forking branch
+		newAncestors = append(newAncestors, forkB.Ancestors...)
+		newAncestors = append(newAncestors, &shared.HistoryBranchRange{
+			BranchID:    forkB.BranchID,
+			BeginNodeID: common.Int64Ptr(beginNodeID),
+			EndNodeID:   common.Int64Ptr(request.ForkNodeID),
+		})
+	}
+
+	m.db.Lock()
+	defer m.db.Unlock()
+
+	m.db.putHistoryBranch(historyBranchKey{treeID: treeID, branchID: request.NewBranchID}, &historyBranch{
+		ancestors:  copyBranchRanges(newAncestors),
+		inProgress: true,
+		createdTs:  time.Now(),
+		info:       request.Info,
+	})
+	return &p.InternalForkHistoryBranchResponse{
+		NewBranchInfo: shared.HistoryBranch{
+			TreeID:    common.StringPtr(treeID),
+			BranchID:  common.StringPtr(request.NewBranchID),
+			Ancestors: newAncestors,
+		}}, nil
+}
+
+// DeleteHistoryBranch removes a branch
+func (m *historyV2Store) DeleteHistoryBranch(request *p.InternalDeleteHistoryBranchRequest) error {
+	branch := request.BranchInfo
+	treeID := branch.GetTreeID()
+	brsToDelete := append(copyBranchRanges(branch.Ancestors), &shared.HistoryBranchRange{
+		BranchID:    branch.BranchID,
+		BeginNodeID: common.Int64Ptr(p.GetBeginNodeID(branch)),
+	})
+
+	m.db.Lock()
+	defer m.db.Unlock()
+
+	// We won't delete the branch if there is any branch forking in progress. We will return error.
+	for _, b := range m.db.historyBranches[treeID] {
+		if b.inProgress {
+			return &p.ConditionFailedError{
+				Msg: "There are branches in progress of forking",
+			}
+		}
+	}
+
+	delete(m.db.historyBranches[treeID], branch.GetBranchID())
+	if len(m.db.historyBranches[treeID]) == 0 {
+		delete(m.db.historyBranches, treeID)
+	}
+
+	// validBRsMaxEndNode is to for each branch range that is being used, we want to know what is the max nodeID referred by other valid branch
+	validBRsMaxEndNode := map[string]int64{}
+	for _, b := range m.db.historyBranches[treeID] {
+		for _, br := range b.ancestors {
+			curr, ok := validBRsMaxEndNode[br.GetBranchID()]
+			if !ok || curr < br.GetEndNodeID() {
+				validBRsMaxEndNode[br.GetBranchID()] = br.GetEndNodeID()
+			}
+		}
+	}
+
+	// for each branch range to delete, we iterate from bottom to up, and delete up to the point according to validBRsEndNode
+	for i := len(brsToDelete) - 1; i >= 0; i-- {
+		br := brsToDelete[i]
+		minNodeID := br.GetBeginNodeID()
+		maxReferredEndNodeID, ok := validBRsMaxEndNode[br.GetBranchID()]
+		if ok {
+			// we can only delete from the maxEndNode and stop here
+			minNodeID = maxReferredEndNodeID
+		}
+		m.db.deleteHistoryNodes(historyBranchKey{treeID: treeID, branchID: br.GetBranchID()}, minNodeID)
+		if ok {
+			break
+		}
+	}
+	return nil
+}
+
+// CompleteForkBranch completes the forking of a branch, the branch is removed if the fork failed
+func (m *historyV2Store) CompleteForkBranch(request *p.InternalCompleteForkBranchRequest) error {
+	branch := request.BranchInfo
+	treeID := branch.GetTreeID()
+
+	m.db.Lock()
+	defer m.db.Unlock()
+
+	b, ok := m.db.historyBranches[treeID][branch.GetBranchID()]
+	if !ok {
+		return &shared.InternalServiceError{
+			Message: fmt.Sprintf("CompleteForkBranch: branch %v of tree %v does not exist", branch.GetBranchID(), treeID),
+		}
+	}
+	if request.Success {
+		b.inProgress = false
+		return nil
+	}
+
+	m.db.deleteHistoryNodes(historyBranchKey{treeID: treeID, branchID: branch.GetBranchID()}, common.FirstEventID)
+	delete(m.db.historyBranches[treeID], branch.GetBranchID())
+	if len(m.db.historyBranches[treeID]) == 0 {
+		delete(m.db.historyBranches, treeID)
+	}
+	return nil
+}
+
+// GetHistoryTree returns all branch information of a tree
+func (m *historyV2Store) GetHistoryTree(request *p.GetHistoryTreeRequest) (*p.GetHistoryTreeResponse, error) {
+	m.db.Lock()
+	defer m.db.Unlock()
+
+	branchIDs := make([]string, 0, len(m.db.historyBranches[request.TreeID]))
+	for branchID := range m.db.historyBranches[request.TreeID] {
+		branchIDs = append(branchIDs, branchID)
+	}
+	sort.Strings(branchIDs)
+
+	resp := &p.GetHistoryTreeResponse{}
+	for _, branchID := range branchIDs {
+		b := m.db.historyBranches[request.TreeID][branchID]
+		if b.inProgress {
+			resp.ForkingInProgressBranches = append(resp.ForkingInProgressBranches, p.ForkingInProgressBranch{
+				BranchID: branchID,
+				ForkTime: b.createdTs,
+				Info:     b.info,
+			})
+		}
+		resp.Branches = append(resp.Branches, &shared.HistoryBranch{
+			TreeID:    common.StringPtr(request.TreeID),
+			BranchID:  common.StringPtr(branchID),
+			Ancestors: copyBranchRanges(b.ancestors),
+		})
+	}
+	return resp, nil
+}
+
+// putHistoryBranch adds a branch to its tree, it must be called with the database lock held
+func (db *database) putHistoryBranch(key historyBranchKey, branch *historyBranch) {
+	branches, ok := db.historyBranches[key.treeID]
+	if !ok {
+		branches = make(map[string]*historyBranch)
+		db.historyBranches[key.treeID] = branches
+	}
+	branches[key.branchID] = branch
+}
+
+// deleteHistoryNodes removes the nodes of a branch starting at minNodeID, it must be called
+// with the database lock held
+func (db *database) deleteHistoryNodes(key historyBranchKey, minNodeID int64) {
+	nodes := db.historyNodes[key]
+	for nodeKey := range nodes {
+		if nodeKey.nodeID >= minNodeID {
+			delete(nodes, nodeKey)
+		}
+	}
+	if len(nodes) == 0 {
+		delete(db.historyNodes, key)
+	}
+}
+
+func copyBranchRanges(ranges []*shared.HistoryBranchRange) []*shared.HistoryBranchRange {
+	if ranges == nil {
+		return nil
+	}
+	result := make([]*shared.HistoryBranchRange, len(ranges))
+	for i, br := range ranges {
+		result[i] = &shared.HistoryBranchRange{
+			BranchID:    common.StringPtr(br.GetBranchID()),
+			BeginNodeID: common.Int64Ptr(br.GetBeginNodeID()),
+			EndNodeID:   common.Int64Ptr(br.GetEndNodeID()),
+		}
+	}
+	return result
+}
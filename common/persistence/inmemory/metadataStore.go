@@ -0,0 +1,237 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package inmemory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/uber-common/bark"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	p "github.com/uber/cadence/common/persistence"
+)
+
+type metadataStore struct {
+	store
+	currentClusterName string
+}
+
+// newMetadataStore creates an instance of MetadataStore speaking the V2 protocol,
+// i.e. all domains share a single notification version
+func newMetadataStore(db *database, currentClusterName string, logger bark.Logger) p.MetadataStore {
+	return &metadataStore{
+		store: store{
+			db:     db,
+			logger: logger,
+		},
+		currentClusterName: currentClusterName,
+	}
+}
+
+func (m *metadataStore) CreateDomain(ctx context.Context, request *p.CreateDomainRequest) (*p.CreateDomainResponse, error) {
+	m.db.Lock()
+	defer m.db.Unlock()
+
+	if domain, ok := m.db.getDomainByName(request.Info.Name); ok {
+		return nil, &workflow.DomainAlreadyExistsError{
+			Message: fmt.Sprintf("Domain already exists.  DomainId: %v", domain.Info.ID),
+		}
+	}
+	if _, ok := m.db.domains[request.Info.ID]; ok {
+		return nil, &workflow.DomainAlreadyExistsError{
+			Message: "CreateDomain operation failed because of conditional failure.",
+		}
+	}
+
+	m.db.domains[request.Info.ID] = copyDomain(&p.GetDomainResponse{
+		Info:                        request.Info,
+		Config:                      request.Config,
+		ReplicationConfig:           request.ReplicationConfig,
+		IsGlobalDomain:              request.IsGlobalDomain,
+		ConfigVersion:               request.ConfigVersion,
+		FailoverVersion:             request.FailoverVersion,
+		FailoverNotificationVersion: p.InitialFailoverNotificationVersion,
+		NotificationVersion:         m.db.domainNotificationVersion,
+		TableVersion:                p.DomainTableVersionV2,
+	})
+	m.db.domainNotificationVersion++
+	return &p.CreateDomainResponse{ID: request.Info.ID}, nil
+}
+
+func (m *metadataStore) GetDomain(ctx context.Context, request *p.GetDomainRequest) (*p.GetDomainResponse, error) {
+	var domain *p.GetDomainResponse
+	var ok bool
+
+	m.db.Lock()
+	defer m.db.Unlock()
+
+	switch {
+	case request.Name != "" && request.ID != "":
+		return nil, &workflow.BadRequestError{
+			Message: "GetDomain operation failed.  Both ID and Name specified in request.",
+		}
+	case request.Name != "":
+		domain, ok = m.db.getDomainByName(request.Name)
+	case request.ID != "":
+		domain, ok = m.db.domains[request.ID]
+	default:
+		return nil, &workflow.BadRequestError{
+			Message: "GetDomain operation failed.  Both ID and Name are empty.",
+		}
+	}
+
+	if !ok {
+		identity := request.Name
+		if len(request.ID) > 0 {
+			identity = request.ID
+		}
+		return nil, &workflow.EntityNotExistsError{
+			Message: fmt.Sprintf("Domain %s does not exist.", identity),
+		}
+	}
+	return m.toGetDomainResponse(domain), nil
+}
+
+func (m *metadataStore) UpdateDomain(ctx context.Context, request *p.UpdateDomainRequest) error {
+	m.db.Lock()
+	defer m.db.Unlock()
+
+	if m.db.domainNotificationVersion != request.NotificationVersion {
+		return &p.ConditionFailedError{
+			Msg: fmt.Sprintf("UpdateDomain operation failed. Notification version was %v when it should have been %v.",
+				m.db.domainNotificationVersion, request.NotificationVersion),
+		}
+	}
+	domain, ok := m.db.domains[request.Info.ID]
+	if !ok {
+		return &workflow.EntityNotExistsError{
+			Message: fmt.Sprintf("Domain %s does not exist.", request.Info.ID),
+		}
+	}
+
+	m.db.domains[request.Info.ID] = copyDomain(&p.GetDomainResponse{
+		Info:                        request.Info,
+		Config:                      request.Config,
+		ReplicationConfig:           request.ReplicationConfig,
+		IsGlobalDomain:              domain.IsGlobalDomain,
+		ConfigVersion:               request.ConfigVersion,
+		FailoverVersion:             request.FailoverVersion,
+		FailoverNotificationVersion: request.FailoverNotificationVersion,
+		NotificationVersion:         request.NotificationVersion,
+		TableVersion:                p.DomainTableVersionV2,
+	})
+	m.db.domainNotificationVersion++
+	return nil
+}
+
+func (m *metadataStore) DeleteDomain(ctx context.Context, request *p.DeleteDomainRequest) error {
+	m.db.Lock()
+	defer m.db.Unlock()
+
+	delete(m.db.domains, request.ID)
+	return nil
+}
+
+func (m *metadataStore) DeleteDomainByName(ctx context.Context, request *p.DeleteDomainByNameRequest) error {
+	m.db.Lock()
+	defer m.db.Unlock()
+
+	if domain, ok := m.db.getDomainByName(request.Name); ok {
+		delete(m.db.domains, domain.Info.ID)
+	}
+	return nil
+}
+
+func (m *metadataStore) ListDomains(ctx context.Context, request *p.ListDomainsRequest) (*p.ListDomainsResponse, error) {
+	m.db.Lock()
+	defer m.db.Unlock()
+
+	pageToken := string(request.NextPageToken)
+	var ids []string
+	for id := range m.db.domains {
+		if id > pageToken {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	resp := &p.ListDomainsResponse{}
+	if len(ids) > request.PageSize {
+		ids = ids[:request.PageSize]
+		resp.NextPageToken = []byte(ids[len(ids)-1])
+	}
+	for _, id := range ids {
+		resp.Domains = append(resp.Domains, m.toGetDomainResponse(m.db.domains[id]))
+	}
+	return resp, nil
+}
+
+func (m *metadataStore) GetMetadata(ctx context.Context) (*p.GetMetadataResponse, error) {
+	m.db.Lock()
+	defer m.db.Unlock()
+
+	return &p.GetMetadataResponse{NotificationVersion: m.db.domainNotificationVersion}, nil
+}
+
+func (m *metadataStore) toGetDomainResponse(domain *p.GetDomainResponse) *p.GetDomainResponse {
+	result := copyDomain(domain)
+	result.ReplicationConfig.ActiveClusterName = p.GetOrUseDefaultActiveCluster(
+		m.currentClusterName, result.ReplicationConfig.ActiveClusterName)
+	result.ReplicationConfig.Clusters = p.GetOrUseDefaultClusters(
+		m.currentClusterName, result.ReplicationConfig.Clusters)
+	return result
+}
+
+// getDomainByName returns the domain with the given name, it must be called with the database lock held
+func (db *database) getDomainByName(name string) (*p.GetDomainResponse, bool) {
+	for _, domain := range db.domains {
+		if domain.Info.Name == name {
+			return domain, true
+		}
+	}
+	return nil, false
+}
+
+func copyDomain(domain *p.GetDomainResponse) *p.GetDomainResponse {
+	result := *domain
+	info := *domain.Info
+	if domain.Info.Data != nil {
+		info.Data = make(map[string]string, len(domain.Info.Data))
+		for k, v := range domain.Info.Data {
+			info.Data[k] = v
+		}
+	}
+	result.Info = &info
+	config := *domain.Config
+	result.Config = &config
+	replicationConfig := &p.DomainReplicationConfig{}
+	if domain.ReplicationConfig != nil {
+		replicationConfig.ActiveClusterName = domain.ReplicationConfig.ActiveClusterName
+		for _, cluster := range domain.ReplicationConfig.Clusters {
+			replicationConfig.Clusters = append(replicationConfig.Clusters, &p.ClusterReplicationConfig{
+				ClusterName: cluster.ClusterName,
+			})
+		}
+	}
+	result.ReplicationConfig = replicationConfig
+	return &result
+}
@@ -0,0 +1,150 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package inmemory
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/uber-common/bark"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	p "github.com/uber/cadence/common/persistence"
+)
+
+type shardStore struct {
+	store
+	currentClusterName string
+}
+
+// newShardStore creates an instance of ShardStore
+func newShardStore(db *database, currentClusterName string, logger bark.Logger) p.ShardStore {
+	return &shardStore{
+		store: store{
+			db:     db,
+			logger: logger,
+		},
+		currentClusterName: currentClusterName,
+	}
+}
+
+func (m *shardStore) CreateShard(request *p.CreateShardRequest) error {
+	m.db.Lock()
+	defer m.db.Unlock()
+
+	shardID := request.ShardInfo.ShardID
+	if _, ok := m.db.shards[shardID]; ok {
+		return &p.ShardAlreadyExistError{
+			Msg: fmt.Sprintf("CreateShard operation failed. Shard with ID %v already exists.", shardID),
+		}
+	}
+	m.db.shards[shardID] = copyShardInfo(request.ShardInfo)
+	return nil
+}
+
+func (m *shardStore) GetShard(request *p.GetShardRequest) (*p.GetShardResponse, error) {
+	m.db.Lock()
+	defer m.db.Unlock()
+
+	info, ok := m.db.shards[request.ShardID]
+	if !ok {
+		return nil, &workflow.EntityNotExistsError{
+			Message: fmt.Sprintf("GetShard operation failed. Shard with ID %v not found.", request.ShardID),
+		}
+	}
+
+	result := copyShardInfo(info)
+	if len(result.ClusterTransferAckLevel) == 0 {
+		result.ClusterTransferAckLevel = map[string]int64{
+			m.currentClusterName: result.TransferAckLevel,
+		}
+	}
+	if len(result.ClusterTimerAckLevel) == 0 {
+		result.ClusterTimerAckLevel = map[string]time.Time{
+			m.currentClusterName: result.TimerAckLevel,
+		}
+	}
+	return &p.GetShardResponse{ShardInfo: result}, nil
+}
+
+func (m *shardStore) UpdateShard(request *p.UpdateShardRequest) error {
+	m.db.Lock()
+	defer m.db.Unlock()
+
+	shardID := request.ShardInfo.ShardID
+	info, ok := m.db.shards[shardID]
+	if !ok {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("UpdateShard operation failed. Shard with ID %v does not exist.", shardID),
+		}
+	}
+	if info.RangeID != request.PreviousRangeID {
+		return &p.ShardOwnershipLostError{
+			ShardID: shardID,
+			Msg:     fmt.Sprintf("Failed to update shard. Previous range ID: %v; new range ID: %v", request.PreviousRangeID, info.RangeID),
+		}
+	}
+	m.db.shards[shardID] = copyShardInfo(request.ShardInfo)
+	return nil
+}
+
+// checkShardRangeID fails with ShardOwnershipLostError unless the shard is owned at the given rangeID,
+// it must be called with the database lock held
+func (db *database) checkShardRangeID(shardID int, rangeID int64) error {
+	info, ok := db.shards[shardID]
+	if !ok {
+		return &p.ShardOwnershipLostError{
+			ShardID: shardID,
+			Msg:     fmt.Sprintf("Failed to lock shard with ID %v that does not exist.", shardID),
+		}
+	}
+	if info.RangeID != rangeID {
+		return &p.ShardOwnershipLostError{
+			ShardID: shardID,
+			Msg:     fmt.Sprintf("Failed to lock shard. Previous range ID: %v; new range ID: %v", rangeID, info.RangeID),
+		}
+	}
+	return nil
+}
+
+// copyShardInfo copies the fields of a shard which are persisted by all the stores,
+// failover levels are kept in memory by the shard owner only
+func copyShardInfo(info *p.ShardInfo) *p.ShardInfo {
+	result := &p.ShardInfo{
+		ShardID:                   info.ShardID,
+		Owner:                     info.Owner,
+		RangeID:                   info.RangeID,
+		StolenSinceRenew:          info.StolenSinceRenew,
+		UpdatedAt:                 info.UpdatedAt,
+		ReplicationAckLevel:       info.ReplicationAckLevel,
+		TransferAckLevel:          info.TransferAckLevel,
+		TimerAckLevel:             info.TimerAckLevel,
+		ClusterTransferAckLevel:   make(map[string]int64, len(info.ClusterTransferAckLevel)),
+		ClusterTimerAckLevel:      make(map[string]time.Time, len(info.ClusterTimerAckLevel)),
+		DomainNotificationVersion: info.DomainNotificationVersion,
+	}
+	for cluster, level := range info.ClusterTransferAckLevel {
+		result.ClusterTransferAckLevel[cluster] = level
+	}
+	for cluster, level := range info.ClusterTimerAckLevel {
+		result.ClusterTimerAckLevel[cluster] = level
+	}
+	return result
+}
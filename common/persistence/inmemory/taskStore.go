@@ -0,0 +1,294 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package inmemory
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/uber-common/bark"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	p "github.com/uber/cadence/common/persistence"
+)
+
+type (
+	taskStore struct {
+		store
+	}
+
+	taskListPageToken struct {
+		DomainID string
+		Name     string
+		TaskType int
+	}
+)
+
+// stickyTaskListTTL is how long a sticky task list outlives its last update
+const stickyTaskListTTL = 24 * time.Hour
+
+// newTaskStore creates an instance of TaskStore
+func newTaskStore(db *database, logger bark.Logger) p.TaskStore {
+	return &taskStore{
+		store: store{
+			db:     db,
+			logger: logger,
+		},
+	}
+}
+
+func (m *taskStore) LeaseTaskList(request *p.LeaseTaskListRequest) (*p.LeaseTaskListResponse, error) {
+	m.db.Lock()
+	defer m.db.Unlock()
+
+	key := taskListKey{domainID: request.DomainID, name: request.TaskList, taskType: request.TaskType}
+	info, ok := m.db.getTaskList(key)
+	if !ok {
+		info = &p.TaskListInfo{
+			DomainID: request.DomainID,
+			Name:     request.TaskList,
+			TaskType: request.TaskType,
+			Kind:     request.TaskListKind,
+		}
+		m.db.taskLists[key] = info
+	}
+	if request.RangeID > 0 && request.RangeID != info.RangeID {
+		return nil, &p.ConditionFailedError{
+			Msg: fmt.Sprintf("leaseTaskList:renew failed:taskList:%v, taskListType:%v, haveRangeID:%v, gotRangeID:%v",
+				request.TaskList, request.TaskType, request.RangeID, info.RangeID),
+		}
+	}
+	info.RangeID++
+	info.LastUpdated = time.Now()
+	result := *info
+	return &p.LeaseTaskListResponse{TaskListInfo: &result}, nil
+}
+
+func (m *taskStore) UpdateTaskList(request *p.UpdateTaskListRequest) (*p.UpdateTaskListResponse, error) {
+	m.db.Lock()
+	defer m.db.Unlock()
+
+	info := *request.TaskListInfo
+	key := taskListKey{domainID: info.DomainID, name: info.Name, taskType: info.TaskType}
+	info.LastUpdated = time.Now()
+	info.Expiry = time.Time{}
+	if info.Kind == p.TaskListKindSticky {
+		// sticky task lists expire, their updates are not conditioned on the rangeID
+		info.Expiry = info.LastUpdated.Add(stickyTaskListTTL)
+		m.db.taskLists[key] = &info
+		return &p.UpdateTaskListResponse{}, nil
+	}
+	if err := m.db.checkTaskListRangeID(key, info.RangeID); err != nil {
+		return nil, err
+	}
+	m.db.taskLists[key] = &info
+	return &p.UpdateTaskListResponse{}, nil
+}
+
+func (m *taskStore) ListTaskList(request *p.ListTaskListRequest) (*p.ListTaskListResponse, error) {
+	var pageToken *taskListPageToken
+	if len(request.PageToken) > 0 {
+		pageToken = &taskListPageToken{}
+		if err := json.Unmarshal(request.PageToken, pageToken); err != nil {
+			return nil, &workflow.InternalServiceError{Message: fmt.Sprintf("error deserializing page token: %v", err)}
+		}
+	}
+
+	m.db.Lock()
+	defer m.db.Unlock()
+
+	var keys []taskListKey
+	for key := range m.db.taskLists {
+		if _, ok := m.db.getTaskList(key); !ok {
+			continue
+		}
+		if pageToken != nil && !taskListKeyLess(taskListKey{
+			domainID: pageToken.DomainID,
+			name:     pageToken.Name,
+			taskType: pageToken.TaskType,
+		}, key) {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return taskListKeyLess(keys[i], keys[j])
+	})
+
+	resp := &p.ListTaskListResponse{}
+	if len(keys) > request.PageSize {
+		keys = keys[:request.PageSize]
+		last := keys[len(keys)-1]
+		token, err := json.Marshal(&taskListPageToken{DomainID: last.domainID, Name: last.name, TaskType: last.taskType})
+		if err != nil {
+			return nil, &workflow.InternalServiceError{Message: fmt.Sprintf("error serializing nextPageToken:%v", err)}
+		}
+		resp.NextPageToken = token
+	}
+	resp.Items = make([]p.TaskListInfo, len(keys))
+	for i, key := range keys {
+		resp.Items[i] = *m.db.taskLists[key]
+	}
+	return resp, nil
+}
+
+func (m *taskStore) DeleteTaskList(request *p.DeleteTaskListRequest) error {
+	m.db.Lock()
+	defer m.db.Unlock()
+
+	key := taskListKey{domainID: request.DomainID, name: request.TaskListName, taskType: request.TaskListType}
+	if err := m.db.checkTaskListRangeID(key, request.RangeID); err != nil {
+		return err
+	}
+	delete(m.db.taskLists, key)
+	return nil
+}
+
+func (m *taskStore) CreateTasks(request *p.CreateTasksRequest) (*p.CreateTasksResponse, error) {
+	m.db.Lock()
+	defer m.db.Unlock()
+
+	key := taskListKey{
+		domainID: request.TaskListInfo.DomainID,
+		name:     request.TaskListInfo.Name,
+		taskType: request.TaskListInfo.TaskType,
+	}
+	if err := m.db.checkTaskListRangeID(key, request.TaskListInfo.RangeID); err != nil {
+		return nil, err
+	}
+
+	tasks, ok := m.db.tasks[key]
+	if !ok {
+		tasks = make(map[int64]*p.TaskInfo)
+		m.db.tasks[key] = tasks
+	}
+	now := time.Now()
+	for _, t := range request.Tasks {
+		task := *t.Data
+		task.TaskID = t.TaskID
+		task.Expiry = time.Time{}
+		if task.ScheduleToStartTimeout > 0 {
+			task.Expiry = now.Add(time.Second * time.Duration(task.ScheduleToStartTimeout))
+		}
+		tasks[t.TaskID] = &task
+	}
+	return &p.CreateTasksResponse{}, nil
+}
+
+func (m *taskStore) GetTasks(request *p.GetTasksRequest) (*p.GetTasksResponse, error) {
+	m.db.Lock()
+	defer m.db.Unlock()
+
+	key := taskListKey{domainID: request.DomainID, name: request.TaskList, taskType: request.TaskType}
+	now := time.Now()
+	var result []*p.TaskInfo
+	for taskID, task := range m.db.tasks[key] {
+		if taskID <= request.ReadLevel || (request.MaxReadLevel != nil && taskID > *request.MaxReadLevel) {
+			continue
+		}
+		if !task.Expiry.IsZero() && task.Expiry.Before(now) {
+			continue
+		}
+		t := *task
+		result = append(result, &t)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].TaskID < result[j].TaskID
+	})
+	if len(result) > request.BatchSize {
+		result = result[:request.BatchSize]
+	}
+	return &p.GetTasksResponse{Tasks: result}, nil
+}
+
+func (m *taskStore) CompleteTask(request *p.CompleteTaskRequest) error {
+	m.db.Lock()
+	defer m.db.Unlock()
+
+	taskList := request.TaskList
+	key := taskListKey{domainID: taskList.DomainID, name: taskList.Name, taskType: taskList.TaskType}
+	delete(m.db.tasks[key], request.TaskID)
+	return nil
+}
+
+func (m *taskStore) CompleteTasksLessThan(request *p.CompleteTasksLessThanRequest) (int, error) {
+	m.db.Lock()
+	defer m.db.Unlock()
+
+	key := taskListKey{domainID: request.DomainID, name: request.TaskListName, taskType: request.TaskType}
+	tasks := m.db.tasks[key]
+	var taskIDs []int64
+	for taskID := range tasks {
+		if taskID <= request.TaskID {
+			taskIDs = append(taskIDs, taskID)
+		}
+	}
+	sort.Slice(taskIDs, func(i, j int) bool {
+		return taskIDs[i] < taskIDs[j]
+	})
+	if len(taskIDs) > request.Limit {
+		taskIDs = taskIDs[:request.Limit]
+	}
+	for _, taskID := range taskIDs {
+		delete(tasks, taskID)
+	}
+	return len(taskIDs), nil
+}
+
+// getTaskList returns the task list with the given key, expired sticky task lists are removed
+func (db *database) getTaskList(key taskListKey) (*p.TaskListInfo, bool) {
+	info, ok := db.taskLists[key]
+	if !ok {
+		return nil, false
+	}
+	if !info.Expiry.IsZero() && info.Expiry.Before(time.Now()) {
+		delete(db.taskLists, key)
+		return nil, false
+	}
+	return info, true
+}
+
+// checkTaskListRangeID fails with ConditionFailedError unless the task list is owned at the given rangeID
+func (db *database) checkTaskListRangeID(key taskListKey, rangeID int64) error {
+	info, ok := db.getTaskList(key)
+	if !ok {
+		return &p.ConditionFailedError{
+			Msg: fmt.Sprintf("Task list %v of type %v does not exist", key.name, key.taskType),
+		}
+	}
+	if info.RangeID != rangeID {
+		return &p.ConditionFailedError{
+			Msg: fmt.Sprintf("Task list range ID was %v when it was should have been %v", info.RangeID, rangeID),
+		}
+	}
+	return nil
+}
+
+func taskListKeyLess(a, b taskListKey) bool {
+	if a.domainID != b.domainID {
+		return a.domainID < b.domainID
+	}
+	if a.name != b.name {
+		return a.name < b.name
+	}
+	return a.taskType < b.taskType
+}
@@ -0,0 +1,336 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package inmemory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/uber-common/bark"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	p "github.com/uber/cadence/common/persistence"
+)
+
+type (
+	visibilityStore struct {
+		store
+	}
+
+	// visibilityRecord is a row of the visibility table, all the timestamps are unix nanos
+	visibilityRecord struct {
+		workflowID       string
+		runID            string
+		workflowTypeName string
+		taskList         string
+		parentWorkflowID string // empty if the execution has no parent
+		parentRunID      string
+		startTime        int64
+		executionTime    int64
+		closeTime        int64
+		closeStatus      *workflow.WorkflowExecutionCloseStatus
+		historyLength    int64
+		terminationInfo  *workflow.WorkflowExecutionTerminationInfo
+	}
+
+	visibilityPageToken struct {
+		Time  int64
+		RunID string
+	}
+)
+
+// newVisibilityStore creates an instance of VisibilityStore
+func newVisibilityStore(db *database, logger bark.Logger) p.VisibilityStore {
+	return &visibilityStore{
+		store: store{
+			db:     db,
+			logger: logger,
+		},
+	}
+}
+
+func (s *visibilityStore) RecordWorkflowExecutionStarted(ctx context.Context, request *p.RecordWorkflowExecutionStartedRequest) error {
+	s.db.Lock()
+	defer s.db.Unlock()
+
+	records := s.db.getVisibilityRecords(request.DomainUUID)
+	if _, ok := records[request.Execution.GetRunId()]; ok {
+		// the execution may already be closed, the started record never overrides it
+		return nil
+	}
+	records[request.Execution.GetRunId()] = &visibilityRecord{
+		workflowID:       request.Execution.GetWorkflowId(),
+		runID:            request.Execution.GetRunId(),
+		workflowTypeName: request.WorkflowTypeName,
+		taskList:         request.TaskList,
+		parentWorkflowID: request.ParentWorkflowID,
+		parentRunID:      request.ParentRunID,
+		startTime:        request.StartTimestamp,
+		executionTime:    request.GetExecutionTimestamp(),
+	}
+	return nil
+}
+
+func (s *visibilityStore) RecordWorkflowExecutionClosed(ctx context.Context, request *p.RecordWorkflowExecutionClosedRequest) error {
+	s.db.Lock()
+	defer s.db.Unlock()
+
+	status := request.Status
+	records := s.db.getVisibilityRecords(request.DomainUUID)
+	if status == workflow.WorkflowExecutionCloseStatusDeleted {
+		// like sql, tombstones which outlived their retention are deleted whenever a new tombstone is recorded
+		maxCloseTime := request.CloseTimestamp - int64(time.Duration(request.RetentionSeconds)*time.Second)
+		for runID, record := range records {
+			if record.closeStatus != nil && *record.closeStatus == status && record.closeTime < maxCloseTime {
+				delete(records, runID)
+			}
+		}
+	}
+	records[request.Execution.GetRunId()] = &visibilityRecord{
+		workflowID:       request.Execution.GetWorkflowId(),
+		runID:            request.Execution.GetRunId(),
+		workflowTypeName: request.WorkflowTypeName,
+		taskList:         request.TaskList,
+		parentWorkflowID: request.ParentWorkflowID,
+		parentRunID:      request.ParentRunID,
+		startTime:        request.StartTimestamp,
+		executionTime:    request.GetExecutionTimestamp(),
+		closeTime:        request.CloseTimestamp,
+		closeStatus:      &status,
+		historyLength:    request.HistoryLength,
+		terminationInfo:  request.TerminationInfo,
+	}
+	return nil
+}
+
+func (s *visibilityStore) ListOpenWorkflowExecutions(ctx context.Context, request *p.ListWorkflowExecutionsRequest) (*p.ListWorkflowExecutionsResponse, error) {
+	return s.listWorkflowExecutions(request, false, nil)
+}
+
+func (s *visibilityStore) ListClosedWorkflowExecutions(ctx context.Context, request *p.ListWorkflowExecutionsRequest) (*p.ListWorkflowExecutionsResponse, error) {
+	return s.listWorkflowExecutions(request, true, nil)
+}
+
+func (s *visibilityStore) ListOpenWorkflowExecutionsByType(ctx context.Context, request *p.ListWorkflowExecutionsByTypeRequest) (*p.ListWorkflowExecutionsResponse, error) {
+	return s.listWorkflowExecutions(&request.ListWorkflowExecutionsRequest, false, func(record *visibilityRecord) bool {
+		return record.workflowTypeName == request.WorkflowTypeName
+	})
+}
+
+func (s *visibilityStore) ListClosedWorkflowExecutionsByType(ctx context.Context, request *p.ListWorkflowExecutionsByTypeRequest) (*p.ListWorkflowExecutionsResponse, error) {
+	return s.listWorkflowExecutions(&request.ListWorkflowExecutionsRequest, true, func(record *visibilityRecord) bool {
+		return record.workflowTypeName == request.WorkflowTypeName
+	})
+}
+
+func (s *visibilityStore) ListOpenWorkflowExecutionsByWorkflowID(ctx context.Context, request *p.ListWorkflowExecutionsByWorkflowIDRequest) (*p.ListWorkflowExecutionsResponse, error) {
+	return s.listWorkflowExecutions(&request.ListWorkflowExecutionsRequest, false, func(record *visibilityRecord) bool {
+		return record.workflowID == request.WorkflowID
+	})
+}
+
+func (s *visibilityStore) ListClosedWorkflowExecutionsByWorkflowID(ctx context.Context, request *p.ListWorkflowExecutionsByWorkflowIDRequest) (*p.ListWorkflowExecutionsResponse, error) {
+	return s.listWorkflowExecutions(&request.ListWorkflowExecutionsRequest, true, func(record *visibilityRecord) bool {
+		return record.workflowID == request.WorkflowID
+	})
+}
+
+func (s *visibilityStore) ListClosedWorkflowExecutionsByStatus(ctx context.Context, request *p.ListClosedWorkflowExecutionsByStatusRequest) (*p.ListWorkflowExecutionsResponse, error) {
+	return s.listWorkflowExecutions(&request.ListWorkflowExecutionsRequest, true, func(record *visibilityRecord) bool {
+		return *record.closeStatus == request.Status
+	})
+}
+
+func (s *visibilityStore) GetClosedWorkflowExecution(ctx context.Context, request *p.GetClosedWorkflowExecutionRequest) (*p.GetClosedWorkflowExecutionResponse, error) {
+	s.db.Lock()
+	defer s.db.Unlock()
+
+	execution := request.Execution
+	record, ok := s.db.visibilityRecords[request.DomainUUID][execution.GetRunId()]
+	if !ok || record.closeStatus == nil {
+		return nil, &workflow.EntityNotExistsError{
+			Message: fmt.Sprintf("Workflow execution not found.  WorkflowId: %v, RunId: %v",
+				execution.GetWorkflowId(), execution.GetRunId()),
+		}
+	}
+	return &p.GetClosedWorkflowExecutionResponse{Execution: record.toInfo()}, nil
+}
+
+func (s *visibilityStore) DeleteWorkflowExecution(ctx context.Context, request *p.VisibilityDeleteWorkflowExecutionRequest) error {
+	s.db.Lock()
+	defer s.db.Unlock()
+
+	delete(s.db.visibilityRecords[request.DomainID], request.RunID)
+	return nil
+}
+
+func (s *visibilityStore) GetWorkflowExecutionStatistics(ctx context.Context, request *p.GetWorkflowExecutionStatisticsRequest) (*p.GetWorkflowExecutionStatisticsResponse, error) {
+	return nil, p.ErrVisibilityStatisticsNotSupported
+}
+
+func (s *visibilityStore) CountOpenWorkflowExecutions(ctx context.Context, request *p.CountWorkflowExecutionsRequest) (*p.CountWorkflowExecutionsResponse, error) {
+	return nil, p.ErrVisibilityCountNotSupported
+}
+
+func (s *visibilityStore) listWorkflowExecutions(request *p.ListWorkflowExecutionsRequest, closed bool, filter func(record *visibilityRecord) bool) (*p.ListWorkflowExecutionsResponse, error) {
+	if err := p.ValidateVisibilitySort(request, !closed); err != nil {
+		return nil, err
+	}
+	if request.HasLabelFilter() {
+		return nil, p.ErrVisibilityLabelFilterNotSupported
+	}
+	ascending := request.SortOrder == p.VisibilitySortOrderAsc
+	var pageToken *visibilityPageToken
+	if len(request.NextPageToken) > 0 {
+		var err error
+		if pageToken, err = deserializeVisibilityPageToken(request.NextPageToken); err != nil {
+			return nil, err
+		}
+	}
+
+	s.db.Lock()
+	defer s.db.Unlock()
+
+	var records []*visibilityRecord
+	for _, record := range s.db.visibilityRecords[request.DomainUUID] {
+		if (record.closeStatus != nil) != closed ||
+			record.startTime < request.EarliestStartTime || record.startTime > request.LatestStartTime {
+			continue
+		}
+		if filter != nil && !filter(record) {
+			continue
+		}
+		if pageToken != nil && !pageToken.includes(record, request.SortField, ascending) {
+			continue
+		}
+		records = append(records, record)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		ti, tj := records[i].sortValue(request.SortField), records[j].sortValue(request.SortField)
+		if ti != tj {
+			return (ti < tj) == ascending
+		}
+		return records[i].runID < records[j].runID
+	})
+	if len(records) > request.PageSize {
+		records = records[:request.PageSize]
+	}
+
+	response := &p.ListWorkflowExecutionsResponse{}
+	for _, record := range records {
+		response.Executions = append(response.Executions, record.toInfo())
+	}
+	if len(records) > 0 && len(records) == request.PageSize {
+		lastRecord := records[len(records)-1]
+		response.NextPageToken = serializeVisibilityPageToken(&visibilityPageToken{
+			Time:  lastRecord.sortValue(request.SortField),
+			RunID: lastRecord.runID,
+		})
+	}
+	return response, nil
+}
+
+// getVisibilityRecords returns the records of a domain, it must be called with the database lock held
+func (db *database) getVisibilityRecords(domainID string) map[string]*visibilityRecord {
+	records, ok := db.visibilityRecords[domainID]
+	if !ok {
+		records = make(map[string]*visibilityRecord)
+		db.visibilityRecords[domainID] = records
+	}
+	return records
+}
+
+// includes returns true if the record is ordered after the last record of the previous page
+func (t *visibilityPageToken) includes(record *visibilityRecord, field p.VisibilitySortField, ascending bool) bool {
+	value := record.sortValue(field)
+	if value == t.Time {
+		return record.runID > t.RunID
+	}
+	return (t.Time < value) == ascending
+}
+
+// sortValue returns the value of the field records are ordered by
+func (r *visibilityRecord) sortValue(field p.VisibilitySortField) int64 {
+	switch field {
+	case p.VisibilitySortFieldCloseTime:
+		return r.closeTime
+	case p.VisibilitySortFieldExecutionTime:
+		return r.getExecutionTime()
+	default:
+		return r.startTime
+	}
+}
+
+func (r *visibilityRecord) getExecutionTime() int64 {
+	if r.executionTime == 0 {
+		return r.startTime
+	}
+	return r.executionTime
+}
+
+func (r *visibilityRecord) toInfo() *workflow.WorkflowExecutionInfo {
+	info := &workflow.WorkflowExecutionInfo{
+		Execution: &workflow.WorkflowExecution{
+			WorkflowId: common.StringPtr(r.workflowID),
+			RunId:      common.StringPtr(r.runID),
+		},
+		Type:          &workflow.WorkflowType{Name: common.StringPtr(r.workflowTypeName)},
+		StartTime:     common.Int64Ptr(r.startTime),
+		ExecutionTime: common.Int64Ptr(r.getExecutionTime()),
+		TaskList:      common.StringPtr(r.taskList),
+	}
+	if r.parentWorkflowID != "" {
+		info.ParentExecution = &workflow.WorkflowExecution{
+			WorkflowId: common.StringPtr(r.parentWorkflowID),
+			RunId:      common.StringPtr(r.parentRunID),
+		}
+	}
+	if r.closeStatus != nil {
+		status := *r.closeStatus
+		info.CloseStatus = &status
+		info.CloseTime = common.Int64Ptr(r.closeTime)
+		info.HistoryLength = common.Int64Ptr(r.historyLength)
+		info.TerminationInfo = r.terminationInfo
+	}
+	return info
+}
+
+func deserializeVisibilityPageToken(data []byte) (*visibilityPageToken, error) {
+	payload, err := p.DeserializeVisibilityPageToken(p.VisibilityStoreInMemory, data)
+	if err != nil {
+		return nil, err
+	}
+	var token visibilityPageToken
+	if err := json.Unmarshal(payload, &token); err != nil {
+		return nil, &workflow.BadRequestError{
+			Message: fmt.Sprintf("invalid next page token: unable to deserialize page token. err: %v", err),
+		}
+	}
+	return &token, nil
+}
+
+func serializeVisibilityPageToken(token *visibilityPageToken) []byte {
+	data, _ := json.Marshal(token) // cannot fail, the token only has plain fields
+	return p.SerializeVisibilityPageToken(p.VisibilityStoreInMemory, data)
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/uber/cadence/common/metrics"
 	p "github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/persistence/cassandra"
+	"github.com/uber/cadence/common/persistence/inmemory"
 	"github.com/uber/cadence/common/persistence/sql"
 	"github.com/uber/cadence/common/service/config"
 	"github.com/uber/cadence/common/tokenbucket"
@@ -51,6 +52,8 @@ type (
 		// NewMetadataManager returns a new metadata manager that can speak
 		// the given version or versions
 		NewMetadataManager(version MetadataVersion) (p.MetadataManager, error)
+		// NewClusterMetadataManager returns a new cluster metadata manager
+		NewClusterMetadataManager() (p.ClusterMetadataManager, error)
 		// NewExecutionManager returns a new execution manager for a given shardID
 		NewExecutionManager(shardID int) (p.ExecutionManager, error)
 		// NewVisibilityManager returns a new visibility manager
@@ -75,6 +78,8 @@ type (
 		NewMetadataStoreV1() (p.MetadataManager, error)
 		// NewMetadataStoreV2 returns a metadata store that can talk v2
 		NewMetadataStoreV2() (p.MetadataManager, error)
+		// NewClusterMetadataStore returns a new cluster metadata store
+		NewClusterMetadataStore() (p.ClusterMetadataStore, error)
 		// NewExecutionStore returns an execution store for given shardID
 		NewExecutionStore(shardID int) (p.ExecutionStore, error)
 		// NewVisibilityStore returns a new visibility store
@@ -89,6 +94,7 @@ type (
 		sync.RWMutex
 		config        *config.Persistence
 		metricsClient metrics.Client
+		domainMetrics *p.DomainMetricsTagger
 		logger        bark.Logger
 		datastores    map[storeType]Datastore
 	}
@@ -135,18 +141,19 @@ func New(
 	factory := &factoryImpl{
 		config:        cfg,
 		metricsClient: metricsClient,
+		domainMetrics: p.NewDomainMetricsTagger(metricsClient, cfg.DomainMetricsConfig),
 		logger:        logger,
 	}
 	defaultCfg := cfg.DataStores[cfg.DefaultStore]
 	visibilityCfg := cfg.DataStores[cfg.VisibilityStore]
 	limiters := buildRatelimiters(cfg)
 	factory.datastores = map[storeType]Datastore{
-		storeTypeTask:       newStore(defaultCfg, limiters[cfg.DefaultStore], clusterName, 0, logger),
-		storeTypeShard:      newStore(defaultCfg, limiters[cfg.DefaultStore], clusterName, 0, logger),
-		storeTypeMetadata:   newStore(defaultCfg, limiters[cfg.DefaultStore], clusterName, 0, logger),
-		storeTypeExecution:  newStore(defaultCfg, limiters[cfg.DefaultStore], clusterName, 0, logger),
-		storeTypeHistory:    newStore(defaultCfg, limiters[cfg.DefaultStore], clusterName, cfg.HistoryMaxConns, logger),
-		storeTypeVisibility: newStore(visibilityCfg, limiters[cfg.VisibilityStore], clusterName, 0, logger),
+		storeTypeTask:       newStore(defaultCfg, limiters[cfg.DefaultStore], clusterName, 0, &cfg.Timeouts, logger),
+		storeTypeShard:      newStore(defaultCfg, limiters[cfg.DefaultStore], clusterName, 0, &cfg.Timeouts, logger),
+		storeTypeMetadata:   newStore(defaultCfg, limiters[cfg.DefaultStore], clusterName, 0, &cfg.Timeouts, logger),
+		storeTypeExecution:  newStore(defaultCfg, limiters[cfg.DefaultStore], clusterName, 0, &cfg.Timeouts, logger),
+		storeTypeHistory:    newStore(defaultCfg, limiters[cfg.DefaultStore], clusterName, cfg.HistoryMaxConns, &cfg.Timeouts, logger),
+		storeTypeVisibility: newStore(visibilityCfg, limiters[cfg.VisibilityStore], clusterName, 0, &cfg.Timeouts, logger),
 	}
 	return factory
 }
@@ -158,11 +165,14 @@ func (f *factoryImpl) NewTaskManager() (p.TaskManager, error) {
 	if err != nil {
 		return nil, err
 	}
+	if f.config.FaultInjectionConfig != nil {
+		result = p.NewTaskPersistenceFaultInjectionClient(result, f.config.FaultInjectionConfig, f.logger)
+	}
 	if ds.ratelimit != nil {
 		result = p.NewTaskPersistenceRateLimitedClient(result, ds.ratelimit, f.logger)
 	}
 	if f.metricsClient != nil {
-		result = p.NewTaskPersistenceMetricsClient(result, f.metricsClient, f.logger)
+		result = p.NewTaskPersistenceMetricsClient(result, f.metricsClient, f.domainMetrics, f.logger)
 	}
 	return result, nil
 }
@@ -174,6 +184,9 @@ func (f *factoryImpl) NewShardManager() (p.ShardManager, error) {
 	if err != nil {
 		return nil, err
 	}
+	if f.config.FaultInjectionConfig != nil {
+		result = p.NewShardPersistenceFaultInjectionClient(result, f.config.FaultInjectionConfig, f.logger)
+	}
 	if ds.ratelimit != nil {
 		result = p.NewShardPersistenceRateLimitedClient(result, ds.ratelimit, f.logger)
 	}
@@ -191,12 +204,15 @@ func (f *factoryImpl) NewHistoryManager() (p.HistoryManager, error) {
 	if err != nil {
 		return nil, err
 	}
-	result := p.NewHistoryManagerImpl(store, f.logger)
+	result := p.NewHistoryManagerImpl(store, f.newHistorySerializer(), f.logger)
+	if f.config.FaultInjectionConfig != nil {
+		result = p.NewHistoryPersistenceFaultInjectionClient(result, f.config.FaultInjectionConfig, f.logger)
+	}
 	if ds.ratelimit != nil {
 		result = p.NewHistoryPersistenceRateLimitedClient(result, ds.ratelimit, f.logger)
 	}
 	if f.metricsClient != nil {
-		result = p.NewHistoryPersistenceMetricsClient(result, f.metricsClient, f.logger)
+		result = p.NewHistoryPersistenceMetricsClient(result, f.metricsClient, f.domainMetrics, f.logger)
 	}
 	return result, nil
 }
@@ -208,7 +224,10 @@ func (f *factoryImpl) NewHistoryV2Manager() (p.HistoryV2Manager, error) {
 	if err != nil {
 		return nil, err
 	}
-	result := p.NewHistoryV2ManagerImpl(store, f.logger)
+	result := p.NewHistoryV2ManagerImpl(store, f.newHistorySerializer(), f.logger)
+	if f.config.FaultInjectionConfig != nil {
+		result = p.NewHistoryV2PersistenceFaultInjectionClient(result, f.config.FaultInjectionConfig, f.logger)
+	}
 	if ds.ratelimit != nil {
 		result = p.NewHistoryV2PersistenceRateLimitedClient(result, ds.ratelimit, f.logger)
 	}
@@ -236,7 +255,10 @@ func (f *factoryImpl) NewMetadataManager(version MetadataVersion) (p.MetadataMan
 		return nil, err
 	}
 
-	result := p.MetadataManager(store)
+	result := p.NewMetadataPersistenceTimeoutClient(store, &f.config.Timeouts)
+	if f.config.FaultInjectionConfig != nil {
+		result = p.NewMetadataPersistenceFaultInjectionClient(result, f.config.FaultInjectionConfig, f.logger)
+	}
 	if ds.ratelimit != nil {
 		result = p.NewMetadataPersistenceRateLimitedClient(result, ds.ratelimit, f.logger)
 	}
@@ -246,6 +268,14 @@ func (f *factoryImpl) NewMetadataManager(version MetadataVersion) (p.MetadataMan
 	return result, nil
 }
 
+// NewClusterMetadataManager returns a new cluster metadata manager. The cluster
+// metadata is only read and written at startup, so the store is not wrapped with
+// ratelimiting or metrics
+func (f *factoryImpl) NewClusterMetadataManager() (p.ClusterMetadataManager, error) {
+	ds := f.datastores[storeTypeMetadata]
+	return ds.factory.NewClusterMetadataStore()
+}
+
 // NewExecutionManager returns a new execution manager for a given shardID
 func (f *factoryImpl) NewExecutionManager(shardID int) (p.ExecutionManager, error) {
 	ds := f.datastores[storeTypeExecution]
@@ -253,12 +283,16 @@ func (f *factoryImpl) NewExecutionManager(shardID int) (p.ExecutionManager, erro
 	if err != nil {
 		return nil, err
 	}
-	result := p.NewExecutionManagerImpl(store, f.logger)
+	result := p.NewExecutionManagerImpl(store, f.newHistorySerializer(), f.logger)
+	result = p.NewWorkflowExecutionPersistenceTimeoutClient(result, &f.config.Timeouts)
+	if f.config.FaultInjectionConfig != nil {
+		result = p.NewWorkflowExecutionPersistenceFaultInjectionClient(result, f.config.FaultInjectionConfig, f.logger)
+	}
 	if ds.ratelimit != nil {
 		result = p.NewWorkflowExecutionPersistenceRateLimitedClient(result, ds.ratelimit, f.logger)
 	}
 	if f.metricsClient != nil {
-		result = p.NewWorkflowExecutionPersistenceMetricsClient(result, f.metricsClient, f.logger)
+		result = p.NewWorkflowExecutionPersistenceMetricsClient(result, f.metricsClient, f.domainMetrics, f.logger)
 	}
 	return result, nil
 }
@@ -274,14 +308,21 @@ func (f *factoryImpl) NewVisibilityManager() (p.VisibilityManager, error) {
 	if visConfig != nil && visConfig.EnableReadFromClosedExecutionV2() && f.isCassandra() {
 		result, err = cassandra.NewVisibilityPersistenceV2(result, f.getCassandraConfig(), f.logger)
 	}
+	result = p.NewVisibilityPersistenceTimeoutClient(result, &f.config.Timeouts)
+	if f.config.FaultInjectionConfig != nil {
+		result = p.NewVisibilityPersistenceFaultInjectionClient(result, f.config.FaultInjectionConfig, f.logger)
+	}
 	if ds.ratelimit != nil {
 		result = p.NewVisibilityPersistenceRateLimitedClient(result, ds.ratelimit, f.logger)
 	}
+	if visConfig != nil && visConfig.EnableAsyncClosedRecords != nil {
+		result = p.NewVisibilityBatchingClient(result, visConfig, f.metricsClient, f.logger)
+	}
 	if visConfig != nil && visConfig.EnableSampling() {
 		result = p.NewVisibilitySamplingClient(result, visConfig, f.metricsClient, f.logger)
 	}
 	if f.metricsClient != nil {
-		result = p.NewVisibilityPersistenceMetricsClient(result, f.metricsClient, f.logger)
+		result = p.NewVisibilityPersistenceMetricsClient(result, f.metricsClient, f.domainMetrics, f.logger)
 	}
 	return result, nil
 }
@@ -292,24 +333,44 @@ func (f *factoryImpl) Close() {
 	ds.factory.Close()
 }
 
+func (f *factoryImpl) newHistorySerializer() p.HistorySerializer {
+	if f.config.HistoryBlobChecksum {
+		return p.NewHistorySerializerWithChecksum()
+	}
+	return p.NewHistorySerializer()
+}
+
 func (f *factoryImpl) isCassandra() bool {
 	cfg := f.config
-	return cfg.DataStores[cfg.VisibilityStore].SQL == nil
+	return cfg.DataStores[cfg.VisibilityStore].Cassandra != nil
 }
 
 func (f *factoryImpl) getCassandraConfig() *config.Cassandra {
-	cfg := f.config
-	return cfg.DataStores[cfg.VisibilityStore].Cassandra
+	cfg := *f.config.DataStores[f.config.VisibilityStore].Cassandra
+	if cfg.Timeout == 0 {
+		cfg.Timeout = f.config.Timeouts.MaxTimeout()
+	}
+	return &cfg
 }
 
-func newStore(cfg config.DataStore, tb tokenbucket.TokenBucket, clusterName string, maxConnsOverride int, logger bark.Logger) Datastore {
+func newStore(
+	cfg config.DataStore,
+	tb tokenbucket.TokenBucket,
+	clusterName string,
+	maxConnsOverride int,
+	timeouts *config.PersistenceTimeouts,
+	logger bark.Logger) Datastore {
 	var ds Datastore
 	ds.ratelimit = tb
 	if cfg.SQL != nil {
 		ds.factory = newSQLStore(*cfg.SQL, clusterName, maxConnsOverride, logger)
 		return ds
 	}
-	ds.factory = newCassandraStore(*cfg.Cassandra, clusterName, maxConnsOverride, logger)
+	if cfg.InMemory != nil {
+		ds.factory = inmemory.NewFactory(*cfg.InMemory, clusterName, logger)
+		return ds
+	}
+	ds.factory = newCassandraStore(*cfg.Cassandra, clusterName, maxConnsOverride, timeouts, logger)
 	return ds
 }
 
@@ -320,10 +381,20 @@ func newSQLStore(cfg config.SQL, clusterName string, maxConnsOverride int, logge
 	return sql.NewFactory(cfg, clusterName, logger)
 }
 
-func newCassandraStore(cfg config.Cassandra, clusterName string, maxConnsOverride int, logger bark.Logger) DataStoreFactory {
+func newCassandraStore(
+	cfg config.Cassandra,
+	clusterName string,
+	maxConnsOverride int,
+	timeouts *config.PersistenceTimeouts,
+	logger bark.Logger) DataStoreFactory {
 	if maxConnsOverride > 0 {
 		cfg.MaxConns = maxConnsOverride
 	}
+	if cfg.Timeout == 0 {
+		// the per operation deadlines are enforced through the call context,
+		// the driver timeout only needs to stay out of their way
+		cfg.Timeout = timeouts.MaxTimeout()
+	}
 	return cassandra.NewFactory(cfg, clusterName, logger)
 }
 
@@ -338,6 +409,10 @@ func buildRatelimiters(cfg *config.Persistence) map[string]tokenbucket.TokenBuck
 			qps = ds.SQL.MaxQPS
 		}
 		if qps > 0 {
+			if cfg.AdaptiveThrottlingConfig != nil {
+				result[dsName] = p.NewAdaptiveRateLimiter(qps, cfg.AdaptiveThrottlingConfig, clock.NewRealTimeSource())
+				continue
+			}
 			result[dsName] = tokenbucket.New(qps, clock.NewRealTimeSource())
 		}
 	}
@@ -21,6 +21,7 @@
 package persistencetests
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"math/rand"
@@ -36,6 +37,7 @@ import (
 
 	gen "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/checksum"
 	"github.com/uber/cadence/common/cluster"
 	p "github.com/uber/cadence/common/persistence"
 )
@@ -97,9 +99,9 @@ func (s *ExecutionManagerSuite) TestCreateWorkflowExecutionBrandNew() {
 		CreateWorkflowMode:   p.CreateWorkflowModeBrandNew,
 	}
 
-	_, err := s.ExecutionManager.CreateWorkflowExecution(req)
+	_, err := s.ExecutionManager.CreateWorkflowExecution(context.Background(), req)
 	s.Nil(err)
-	_, err = s.ExecutionManager.CreateWorkflowExecution(req)
+	_, err = s.ExecutionManager.CreateWorkflowExecution(context.Background(), req)
 	s.NotNil(err)
 	alreadyStartedErr, ok := err.(*p.WorkflowExecutionAlreadyStartedError)
 	s.True(ok, "err is not WorkflowExecutionAlreadyStartedError")
@@ -143,7 +145,7 @@ func (s *ExecutionManagerSuite) TestCreateWorkflowExecutionRunIDReuseWithReplica
 	}
 
 	// try to create a workflow while the current workflow is still running
-	_, err := s.ExecutionManager.CreateWorkflowExecution(&p.CreateWorkflowExecutionRequest{
+	_, err := s.ExecutionManager.CreateWorkflowExecution(context.Background(), &p.CreateWorkflowExecutionRequest{
 		RequestID:                uuid.New(),
 		DomainID:                 domainID,
 		Execution:                newExecution,
@@ -176,7 +178,7 @@ func (s *ExecutionManagerSuite) TestCreateWorkflowExecutionRunIDReuseWithReplica
 		LastWriteVersion: version,
 		LastWriteEventID: updatedInfo.NextEventID - 1,
 	}
-	_, err = s.ExecutionManager.UpdateWorkflowExecution(&p.UpdateWorkflowExecutionRequest{
+	_, err = s.ExecutionManager.UpdateWorkflowExecution(context.Background(), &p.UpdateWorkflowExecutionRequest{
 		ExecutionInfo:        updatedInfo,
 		TransferTasks:        nil,
 		TimerTasks:           nil,
@@ -194,7 +196,7 @@ func (s *ExecutionManagerSuite) TestCreateWorkflowExecutionRunIDReuseWithReplica
 	s.NoError(err)
 
 	// try to create a workflow while the current workflow is complete but run ID is wrong
-	_, err = s.ExecutionManager.CreateWorkflowExecution(&p.CreateWorkflowExecutionRequest{
+	_, err = s.ExecutionManager.CreateWorkflowExecution(context.Background(), &p.CreateWorkflowExecutionRequest{
 		RequestID:                uuid.New(),
 		DomainID:                 domainID,
 		Execution:                newExecution,
@@ -214,7 +216,7 @@ func (s *ExecutionManagerSuite) TestCreateWorkflowExecutionRunIDReuseWithReplica
 	s.IsType(&p.CurrentWorkflowConditionFailedError{}, err, err.Error())
 
 	// try to create a workflow while the current workflow is complete but version is wrong
-	_, err = s.ExecutionManager.CreateWorkflowExecution(&p.CreateWorkflowExecutionRequest{
+	_, err = s.ExecutionManager.CreateWorkflowExecution(context.Background(), &p.CreateWorkflowExecutionRequest{
 		RequestID:                uuid.New(),
 		DomainID:                 domainID,
 		Execution:                newExecution,
@@ -234,7 +236,7 @@ func (s *ExecutionManagerSuite) TestCreateWorkflowExecutionRunIDReuseWithReplica
 	s.IsType(&p.CurrentWorkflowConditionFailedError{}, err, err.Error())
 
 	// try to create a workflow while the current workflow is complete with run ID & version correct
-	_, err = s.ExecutionManager.CreateWorkflowExecution(&p.CreateWorkflowExecutionRequest{
+	_, err = s.ExecutionManager.CreateWorkflowExecution(context.Background(), &p.CreateWorkflowExecutionRequest{
 		RequestID:                uuid.New(),
 		DomainID:                 domainID,
 		Execution:                newExecution,
@@ -292,7 +294,7 @@ func (s *ExecutionManagerSuite) TestCreateWorkflowExecutionRunIDReuseWithoutRepl
 	}
 	// this create should work since we are relying the business logic in history engine
 	// to check whether the existing running workflow has finished
-	_, err3 := s.ExecutionManager.CreateWorkflowExecution(&p.CreateWorkflowExecutionRequest{
+	_, err3 := s.ExecutionManager.CreateWorkflowExecution(context.Background(), &p.CreateWorkflowExecutionRequest{
 		RequestID:                uuid.New(),
 		DomainID:                 domainID,
 		Execution:                newExecution,
@@ -391,7 +393,7 @@ func (s *ExecutionManagerSuite) TestPersistenceStartWorkflow() {
 	s.Equal(common.EmptyVersion, startedErr.LastWriteVersion, startedErr.Msg)
 	s.Empty(task1, "Expected empty task identifier.")
 
-	response, err2 := s.ExecutionManager.CreateWorkflowExecution(&p.CreateWorkflowExecutionRequest{
+	response, err2 := s.ExecutionManager.CreateWorkflowExecution(context.Background(), &p.CreateWorkflowExecutionRequest{
 		RequestID:            uuid.New(),
 		DomainID:             domainID,
 		Execution:            workflowExecution,
@@ -452,7 +454,7 @@ func (s *ExecutionManagerSuite) TestPersistenceStartWorkflowWithReplicationState
 	s.Equal(common.EmptyVersion, startedErr.LastWriteVersion, startedErr.Msg)
 	s.Empty(task1, "Expected empty task identifier.")
 
-	response, err2 := s.ExecutionManager.CreateWorkflowExecution(&p.CreateWorkflowExecutionRequest{
+	response, err2 := s.ExecutionManager.CreateWorkflowExecution(context.Background(), &p.CreateWorkflowExecutionRequest{
 		RequestID:            uuid.New(),
 		DomainID:             domainID,
 		Execution:            workflowExecution,
@@ -534,7 +536,7 @@ func (s *ExecutionManagerSuite) TestGetWorkflow() {
 		ExpirationSeconds:  rand.Int31(),
 	}
 
-	createResp, err := s.ExecutionManager.CreateWorkflowExecution(createReq)
+	createResp, err := s.ExecutionManager.CreateWorkflowExecution(context.Background(), createReq)
 	s.NoError(err)
 	s.NotNil(createResp, "Expected non empty task identifier.")
 
@@ -973,7 +975,7 @@ func (s *ExecutionManagerSuite) TestGetCurrentWorkflow() {
 	s.NoError(err0)
 	s.NotNil(task0, "Expected non empty task identifier.")
 
-	response, err := s.ExecutionManager.GetCurrentExecution(&p.GetCurrentExecutionRequest{
+	response, err := s.ExecutionManager.GetCurrentExecution(context.Background(), &p.GetCurrentExecutionRequest{
 		DomainID:   domainID,
 		WorkflowID: workflowExecution.GetWorkflowId(),
 	})
@@ -1419,7 +1421,7 @@ func (s *ExecutionManagerSuite) TestTransferTasksComplete() {
 	tasks := []p.Task{
 		&p.ActivityTask{now, currentTransferID + 10001, domainID, tasklist, scheduleID, 111},
 		&p.DecisionTask{now, currentTransferID + 10002, domainID, tasklist, scheduleID, 222, false},
-		&p.CloseExecutionTask{now, currentTransferID + 10003, 333},
+		&p.CloseExecutionTask{VisibilityTimestamp: now, TaskID: currentTransferID + 10003, Version: 333},
 		&p.CancelExecutionTask{now, currentTransferID + 10004, targetDomainID, targetWorkflowID, targetRunID, true, scheduleID, 444},
 		&p.SignalExecutionTask{now, currentTransferID + 10005, targetDomainID, targetWorkflowID, targetRunID, true, scheduleID, 555},
 		&p.StartChildExecutionTask{now, currentTransferID + 10006, targetDomainID, targetWorkflowID, scheduleID, 666},
@@ -1516,7 +1518,7 @@ func (s *ExecutionManagerSuite) TestTransferTasksRangeComplete() {
 	tasks := []p.Task{
 		&p.ActivityTask{now, currentTransferID + 10001, domainID, tasklist, scheduleID, 111},
 		&p.DecisionTask{now, currentTransferID + 10002, domainID, tasklist, scheduleID, 222, false},
-		&p.CloseExecutionTask{now, currentTransferID + 10003, 333},
+		&p.CloseExecutionTask{VisibilityTimestamp: now, TaskID: currentTransferID + 10003, Version: 333},
 		&p.CancelExecutionTask{now, currentTransferID + 10004, targetDomainID, targetWorkflowID, targetRunID, true, scheduleID, 444},
 		&p.SignalExecutionTask{now, currentTransferID + 10005, targetDomainID, targetWorkflowID, targetRunID, true, scheduleID, 555},
 		&p.StartChildExecutionTask{now, currentTransferID + 10006, targetDomainID, targetWorkflowID, scheduleID, 666},
@@ -1725,6 +1727,7 @@ func (s *ExecutionManagerSuite) TestWorkflowMutableStateActivities() {
 		BackoffCoefficient:       5.55,
 		ExpirationTime:           currentTime,
 		NonRetriableErrors:       []string{"accessDenied", "badRequest"},
+		LastFailureReason:        "some random error",
 	}}
 	err2 := s.UpdateWorkflowExecution(updatedInfo, []int64{int64(4)}, nil, int64(3), nil, nil, activityInfos, nil, nil, nil)
 	s.NoError(err2)
@@ -1767,6 +1770,7 @@ func (s *ExecutionManagerSuite) TestWorkflowMutableStateActivities() {
 	s.Equal(activityInfos[0].BackoffCoefficient, ai.BackoffCoefficient)
 	s.EqualTimes(activityInfos[0].ExpirationTime, ai.ExpirationTime)
 	s.Equal(activityInfos[0].NonRetriableErrors, ai.NonRetriableErrors)
+	s.Equal(activityInfos[0].LastFailureReason, ai.LastFailureReason)
 
 	err2 = s.UpdateWorkflowExecution(updatedInfo, nil, nil, int64(5), nil, nil, nil, []int64{1}, nil, nil)
 	s.NoError(err2)
@@ -2271,6 +2275,98 @@ func (s *ExecutionManagerSuite) TestWorkflowMutableStateInfo() {
 	s.Equal(updatedInfo.State, state.ExecutionInfo.State)
 }
 
+// TestGetWorkflowExecutions test
+func (s *ExecutionManagerSuite) TestGetWorkflowExecutions() {
+	domainID := "1b5d5c8a-8b19-4bd3-9e2f-2c1c5c2e4a07"
+	workflowExecution1 := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("test-get-workflow-executions-1"),
+		RunId:      common.StringPtr(uuid.New()),
+	}
+	workflowExecution2 := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("test-get-workflow-executions-2"),
+		RunId:      common.StringPtr(uuid.New()),
+	}
+	missingExecution := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("test-get-workflow-executions-missing"),
+		RunId:      common.StringPtr(uuid.New()),
+	}
+
+	_, err0 := s.CreateWorkflowExecution(domainID, workflowExecution1, "taskList", "wType", 20, 13, nil, 3, 0, 2, nil)
+	s.NoError(err0)
+	_, err1 := s.CreateWorkflowExecution(domainID, workflowExecution2, "taskList", "wType", 20, 13, nil, 5, 0, 4, nil)
+	s.NoError(err1)
+
+	response, err2 := s.ExecutionManager.GetWorkflowExecutions(context.Background(), &p.GetWorkflowExecutionsRequest{
+		DomainID:   domainID,
+		Executions: []gen.WorkflowExecution{workflowExecution1, missingExecution, workflowExecution2},
+	})
+	s.NoError(err2)
+	s.Equal(2, len(response.Responses))
+
+	nextEventIDs := make(map[string]int64)
+	for _, r := range response.Responses {
+		s.Equal(domainID, r.State.ExecutionInfo.DomainID)
+		s.NotNil(r.MutableStateStats)
+		nextEventIDs[r.State.ExecutionInfo.RunID] = r.State.ExecutionInfo.NextEventID
+	}
+	s.Equal(map[string]int64{
+		workflowExecution1.GetRunId(): 3,
+		workflowExecution2.GetRunId(): 5,
+	}, nextEventIDs)
+
+	response, err3 := s.ExecutionManager.GetWorkflowExecutions(context.Background(), &p.GetWorkflowExecutionsRequest{
+		DomainID: domainID,
+	})
+	s.NoError(err3)
+	s.Empty(response.Responses)
+}
+
+// TestWorkflowMutableStateChecksum test
+func (s *ExecutionManagerSuite) TestWorkflowMutableStateChecksum() {
+	domainID := "7c6a8d8e-7c1a-4b33-a4e3-3b8a1d5e5f1c"
+	workflowExecution := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("test-workflow-mutable-state-checksum-test"),
+		RunId:      common.StringPtr("aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"),
+	}
+
+	task0, err0 := s.CreateWorkflowExecution(domainID, workflowExecution, "taskList", "wType", 20, 13, nil, 3, 0, 2, nil)
+	s.NoError(err0)
+	s.NotNil(task0, "Expected non empty task identifier.")
+
+	state0, err1 := s.GetWorkflowExecutionInfo(domainID, workflowExecution)
+	s.NoError(err1)
+	s.True(state0.Checksum.IsEmpty())
+
+	csum := checksum.GenerateCRC32([]byte("mutable state payload"), 1)
+	updatedInfo := copyWorkflowExecutionInfo(state0.ExecutionInfo)
+	updatedInfo.NextEventID = int64(5)
+	_, err2 := s.ExecutionManager.UpdateWorkflowExecution(context.Background(), &p.UpdateWorkflowExecutionRequest{
+		ExecutionInfo: updatedInfo,
+		Condition:     int64(3),
+		RangeID:       s.ShardInfo.RangeID,
+		Checksum:      csum,
+	})
+	s.NoError(err2)
+
+	state1, err3 := s.GetWorkflowExecutionInfo(domainID, workflowExecution)
+	s.NoError(err3)
+	s.Equal(csum, state1.Checksum)
+
+	// an update without checksum clears the persisted one
+	updatedInfo = copyWorkflowExecutionInfo(state1.ExecutionInfo)
+	updatedInfo.NextEventID = int64(6)
+	_, err4 := s.ExecutionManager.UpdateWorkflowExecution(context.Background(), &p.UpdateWorkflowExecutionRequest{
+		ExecutionInfo: updatedInfo,
+		Condition:     int64(5),
+		RangeID:       s.ShardInfo.RangeID,
+	})
+	s.NoError(err4)
+
+	state2, err5 := s.GetWorkflowExecutionInfo(domainID, workflowExecution)
+	s.NoError(err5)
+	s.True(state2.Checksum.IsEmpty())
+}
+
 // TestContinueAsNew test
 func (s *ExecutionManagerSuite) TestContinueAsNew() {
 	domainID := "c1c0bb55-04e6-4a9c-89d0-1be7b96459f8"
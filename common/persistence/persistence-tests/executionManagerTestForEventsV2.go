@@ -21,6 +21,7 @@
 package persistencetests
 
 import (
+	"context"
 	"os"
 	"testing"
 	"time"
@@ -71,7 +72,7 @@ func (s *ExecutionManagerSuiteForEventsV2) TestWorkflowCreation() {
 		RunId:      common.StringPtr("aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"),
 	}
 
-	_, err0 := s.ExecutionManager.CreateWorkflowExecution(&p.CreateWorkflowExecutionRequest{
+	_, err0 := s.ExecutionManager.CreateWorkflowExecution(context.Background(), &p.CreateWorkflowExecutionRequest{
 		RequestID:            uuid.New(),
 		DomainID:             domainID,
 		Execution:            workflowExecution,
@@ -148,6 +149,68 @@ func (s *ExecutionManagerSuiteForEventsV2) TestWorkflowCreation() {
 	s.Equal([]byte("branchToken2"), info1.BranchToken)
 }
 
+// TestWorkflowVersionHistories test
+func (s *ExecutionManagerSuiteForEventsV2) TestWorkflowVersionHistories() {
+	domainID := uuid.New()
+	workflowExecution := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("test-eventsv2-workflow-version-histories"),
+		RunId:      common.StringPtr(uuid.New()),
+	}
+	versionHistories := p.NewVersionHistories(p.NewVersionHistory([]byte("branchToken1"), []*p.VersionHistoryItem{
+		p.NewVersionHistoryItem(2, 10),
+	}))
+
+	_, err0 := s.ExecutionManager.CreateWorkflowExecution(context.Background(), &p.CreateWorkflowExecutionRequest{
+		RequestID:            uuid.New(),
+		DomainID:             domainID,
+		Execution:            workflowExecution,
+		TaskList:             "taskList",
+		WorkflowTypeName:     "wType",
+		WorkflowTimeout:      20,
+		DecisionTimeoutValue: 13,
+		ExecutionContext:     nil,
+		NextEventID:          3,
+		LastProcessedEvent:   0,
+		RangeID:              s.ShardInfo.RangeID,
+		TransferTasks: []p.Task{
+			&p.DecisionTask{
+				TaskID:              s.GetNextSequenceNumber(),
+				DomainID:            domainID,
+				TaskList:            "taskList",
+				ScheduleID:          2,
+				VisibilityTimestamp: time.Now(),
+			},
+		},
+		TimerTasks:                  nil,
+		DecisionScheduleID:          2,
+		DecisionStartedID:           common.EmptyEventID,
+		DecisionStartToCloseTimeout: 1,
+		EventStoreVersion:           p.EventStoreVersionV2,
+		BranchToken:                 []byte("branchToken1"),
+		VersionHistories:            versionHistories,
+	})
+	s.NoError(err0)
+
+	state0, err1 := s.GetWorkflowExecutionInfo(domainID, workflowExecution)
+	s.NoError(err1)
+	info0 := state0.ExecutionInfo
+	s.Equal(versionHistories, info0.VersionHistories)
+
+	updatedInfo := copyWorkflowExecutionInfo(info0)
+	updatedInfo.NextEventID = int64(5)
+	updatedInfo.LastProcessedEvent = int64(2)
+	updatedInfo.VersionHistories = info0.VersionHistories.Duplicate()
+	currentVersionHistory, err := updatedInfo.VersionHistories.GetCurrentVersionHistory()
+	s.NoError(err)
+	s.NoError(currentVersionHistory.AddOrUpdateItem(p.NewVersionHistoryItem(4, 12)))
+	err2 := s.UpdateWorkflowExecution(updatedInfo, []int64{int64(4)}, nil, int64(3), nil, nil, nil, nil, nil, nil)
+	s.NoError(err2)
+
+	state1, err1 := s.GetWorkflowExecutionInfo(domainID, workflowExecution)
+	s.NoError(err1)
+	s.Equal(updatedInfo.VersionHistories, state1.ExecutionInfo.VersionHistories)
+}
+
 //TestContinueAsNew test
 func (s *ExecutionManagerSuiteForEventsV2) TestContinueAsNew() {
 	domainID := uuid.New()
@@ -179,7 +242,7 @@ func (s *ExecutionManagerSuiteForEventsV2) TestContinueAsNew() {
 		ScheduleID: int64(2),
 	}
 
-	_, err2 := s.ExecutionManager.UpdateWorkflowExecution(&p.UpdateWorkflowExecutionRequest{
+	_, err2 := s.ExecutionManager.UpdateWorkflowExecution(context.Background(), &p.UpdateWorkflowExecutionRequest{
 		ExecutionInfo:       updatedInfo,
 		TransferTasks:       []p.Task{newdecisionTask},
 		TimerTasks:          nil,
@@ -470,7 +533,7 @@ func (s *ExecutionManagerSuiteForEventsV2) createWorkflowExecutionWithReplicatio
 	var timerTasks []p.Task
 	for _, task := range txTasks {
 		switch t := task.(type) {
-		case *p.DecisionTask, *p.ActivityTask, *p.CloseExecutionTask, *p.CancelExecutionTask, *p.StartChildExecutionTask, *p.SignalExecutionTask, *p.RecordWorkflowStartedTask:
+		case *p.DecisionTask, *p.ActivityTask, *p.CloseExecutionTask, *p.CancelExecutionTask, *p.StartChildExecutionTask, *p.SignalExecutionTask, *p.RecordWorkflowStartedTask, *p.UpsertWorkflowLabelsTask:
 			transferTasks = append(transferTasks, t)
 		case *p.HistoryReplicationTask:
 			replicationTasks = append(replicationTasks, t)
@@ -487,7 +550,7 @@ func (s *ExecutionManagerSuiteForEventsV2) createWorkflowExecutionWithReplicatio
 		TaskList:   taskList,
 		ScheduleID: decisionScheduleID,
 	})
-	response, err := s.ExecutionManager.CreateWorkflowExecution(&p.CreateWorkflowExecutionRequest{
+	response, err := s.ExecutionManager.CreateWorkflowExecution(context.Background(), &p.CreateWorkflowExecutionRequest{
 		RequestID:                   uuid.New(),
 		DomainID:                    domainID,
 		Execution:                   workflowExecution,
@@ -21,6 +21,7 @@
 package persistencetests
 
 import (
+	"context"
 	"os"
 	"testing"
 
@@ -763,7 +764,7 @@ func (m *MetadataPersistenceSuite) TestDeleteDomain() {
 // CreateDomain helper
 func (m *MetadataPersistenceSuite) CreateDomain(info *p.DomainInfo, config *p.DomainConfig,
 	replicationConfig *p.DomainReplicationConfig, isGlobaldomain bool, configVersion int64, failoverVersion int64) (*p.CreateDomainResponse, error) {
-	return m.MetadataManager.CreateDomain(&p.CreateDomainRequest{
+	return m.MetadataManager.CreateDomain(context.Background(), &p.CreateDomainRequest{
 		Info:              info,
 		Config:            config,
 		ReplicationConfig: replicationConfig,
@@ -775,7 +776,7 @@ func (m *MetadataPersistenceSuite) CreateDomain(info *p.DomainInfo, config *p.Do
 
 // GetDomain helper
 func (m *MetadataPersistenceSuite) GetDomain(id, name string) (*p.GetDomainResponse, error) {
-	return m.MetadataManager.GetDomain(&p.GetDomainRequest{
+	return m.MetadataManager.GetDomain(context.Background(), &p.GetDomainRequest{
 		ID:   id,
 		Name: name,
 	})
@@ -784,7 +785,7 @@ func (m *MetadataPersistenceSuite) GetDomain(id, name string) (*p.GetDomainRespo
 // UpdateDomain helper
 func (m *MetadataPersistenceSuite) UpdateDomain(info *p.DomainInfo, config *p.DomainConfig, replicationConfig *p.DomainReplicationConfig,
 	configVersion int64, failoverVersion int64, dbVersion int64) error {
-	return m.MetadataManager.UpdateDomain(&p.UpdateDomainRequest{
+	return m.MetadataManager.UpdateDomain(context.Background(), &p.UpdateDomainRequest{
 		Info:                info,
 		Config:              config,
 		ReplicationConfig:   replicationConfig,
@@ -797,7 +798,7 @@ func (m *MetadataPersistenceSuite) UpdateDomain(info *p.DomainInfo, config *p.Do
 // DeleteDomain helper
 func (m *MetadataPersistenceSuite) DeleteDomain(id, name string) error {
 	if len(id) > 0 {
-		return m.MetadataManager.DeleteDomain(&p.DeleteDomainRequest{ID: id})
+		return m.MetadataManager.DeleteDomain(context.Background(), &p.DeleteDomainRequest{ID: id})
 	}
-	return m.MetadataManager.DeleteDomainByName(&p.DeleteDomainByNameRequest{Name: name})
+	return m.MetadataManager.DeleteDomainByName(context.Background(), &p.DeleteDomainByNameRequest{Name: name})
 }
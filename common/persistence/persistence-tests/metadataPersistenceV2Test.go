@@ -21,6 +21,7 @@
 package persistencetests
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strconv"
@@ -438,7 +439,7 @@ func (m *MetadataPersistenceSuiteV2) TestConcurrentUpdateDomain() {
 
 	resp2, err2 := m.GetDomain(id, "")
 	m.NoError(err2)
-	metadata, err := m.MetadataManagerV2.GetMetadata()
+	metadata, err := m.MetadataManagerV2.GetMetadata(context.Background())
 	m.NoError(err)
 	notificationVersion := metadata.NotificationVersion
 
@@ -567,7 +568,7 @@ func (m *MetadataPersistenceSuiteV2) TestUpdateDomain() {
 
 	resp2, err2 := m.GetDomain(id, "")
 	m.NoError(err2)
-	metadata, err := m.MetadataManagerV2.GetMetadata()
+	metadata, err := m.MetadataManagerV2.GetMetadata(context.Background())
 	m.NoError(err)
 	notificationVersion := metadata.NotificationVersion
 
@@ -892,7 +893,7 @@ ListLoop:
 // CreateDomain helper method
 func (m *MetadataPersistenceSuiteV2) CreateDomain(info *p.DomainInfo, config *p.DomainConfig,
 	replicationConfig *p.DomainReplicationConfig, isGlobaldomain bool, configVersion int64, failoverVersion int64) (*p.CreateDomainResponse, error) {
-	return m.MetadataManagerV2.CreateDomain(&p.CreateDomainRequest{
+	return m.MetadataManagerV2.CreateDomain(context.Background(), &p.CreateDomainRequest{
 		Info:              info,
 		Config:            config,
 		ReplicationConfig: replicationConfig,
@@ -904,7 +905,7 @@ func (m *MetadataPersistenceSuiteV2) CreateDomain(info *p.DomainInfo, config *p.
 
 // GetDomain helper method
 func (m *MetadataPersistenceSuiteV2) GetDomain(id, name string) (*p.GetDomainResponse, error) {
-	return m.MetadataManagerV2.GetDomain(&p.GetDomainRequest{
+	return m.MetadataManagerV2.GetDomain(context.Background(), &p.GetDomainRequest{
 		ID:   id,
 		Name: name,
 	})
@@ -913,7 +914,7 @@ func (m *MetadataPersistenceSuiteV2) GetDomain(id, name string) (*p.GetDomainRes
 // UpdateDomain helper method
 func (m *MetadataPersistenceSuiteV2) UpdateDomain(info *p.DomainInfo, config *p.DomainConfig, replicationConfig *p.DomainReplicationConfig,
 	configVersion int64, failoverVersion int64, failoverNotificationVersion int64, notificationVersion int64) error {
-	return m.MetadataManagerV2.UpdateDomain(&p.UpdateDomainRequest{
+	return m.MetadataManagerV2.UpdateDomain(context.Background(), &p.UpdateDomainRequest{
 		Info:                        info,
 		Config:                      config,
 		ReplicationConfig:           replicationConfig,
@@ -927,14 +928,14 @@ func (m *MetadataPersistenceSuiteV2) UpdateDomain(info *p.DomainInfo, config *p.
 // DeleteDomain helper method
 func (m *MetadataPersistenceSuiteV2) DeleteDomain(id, name string) error {
 	if len(id) > 0 {
-		return m.MetadataManagerV2.DeleteDomain(&p.DeleteDomainRequest{ID: id})
+		return m.MetadataManagerV2.DeleteDomain(context.Background(), &p.DeleteDomainRequest{ID: id})
 	}
-	return m.MetadataManagerV2.DeleteDomainByName(&p.DeleteDomainByNameRequest{Name: name})
+	return m.MetadataManagerV2.DeleteDomainByName(context.Background(), &p.DeleteDomainByNameRequest{Name: name})
 }
 
 // ListDomains helper method
 func (m *MetadataPersistenceSuiteV2) ListDomains(pageSize int, pageToken []byte) (*p.ListDomainsResponse, error) {
-	return m.MetadataManagerV2.ListDomains(&p.ListDomainsRequest{
+	return m.MetadataManagerV2.ListDomains(context.Background(), &p.ListDomainsRequest{
 		PageSize:      pageSize,
 		NextPageToken: pageToken,
 	})
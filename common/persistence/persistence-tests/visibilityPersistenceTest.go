@@ -21,6 +21,7 @@
 package persistencetests
 
 import (
+	"context"
 	"os"
 	"testing"
 	"time"
@@ -77,10 +78,10 @@ func (s *VisibilityPersistenceSuite) TestBasicVisibility() {
 		WorkflowTypeName: "visibility-workflow",
 		StartTimestamp:   startTime,
 	}
-	err0 := s.VisibilityMgr.RecordWorkflowExecutionStarted(startReq)
+	err0 := s.VisibilityMgr.RecordWorkflowExecutionStarted(context.Background(), startReq)
 	s.Nil(err0)
 
-	resp, err1 := s.VisibilityMgr.ListOpenWorkflowExecutions(&p.ListWorkflowExecutionsRequest{
+	resp, err1 := s.VisibilityMgr.ListOpenWorkflowExecutions(context.Background(), &p.ListWorkflowExecutionsRequest{
 		DomainUUID:        testDomainUUID,
 		PageSize:          1,
 		EarliestStartTime: startTime,
@@ -98,10 +99,10 @@ func (s *VisibilityPersistenceSuite) TestBasicVisibility() {
 		CloseTimestamp:   time.Now().UnixNano(),
 		HistoryLength:    5,
 	}
-	err2 := s.VisibilityMgr.RecordWorkflowExecutionClosed(closeReq)
+	err2 := s.VisibilityMgr.RecordWorkflowExecutionClosed(context.Background(), closeReq)
 	s.Nil(err2)
 
-	resp, err3 := s.VisibilityMgr.ListOpenWorkflowExecutions(&p.ListWorkflowExecutionsRequest{
+	resp, err3 := s.VisibilityMgr.ListOpenWorkflowExecutions(context.Background(), &p.ListWorkflowExecutionsRequest{
 		DomainUUID:        testDomainUUID,
 		PageSize:          1,
 		EarliestStartTime: startTime,
@@ -110,7 +111,7 @@ func (s *VisibilityPersistenceSuite) TestBasicVisibility() {
 	s.Nil(err3)
 	s.Equal(0, len(resp.Executions))
 
-	resp, err4 := s.VisibilityMgr.ListClosedWorkflowExecutions(&p.ListWorkflowExecutionsRequest{
+	resp, err4 := s.VisibilityMgr.ListClosedWorkflowExecutions(context.Background(), &p.ListWorkflowExecutionsRequest{
 		DomainUUID:        testDomainUUID,
 		PageSize:          1,
 		EarliestStartTime: startTime,
@@ -131,7 +132,7 @@ func (s *VisibilityPersistenceSuite) TestBasicVisibilityTimeSkew() {
 	}
 
 	startTime := time.Now().Add(time.Second * -5).UnixNano()
-	err0 := s.VisibilityMgr.RecordWorkflowExecutionStarted(&p.RecordWorkflowExecutionStartedRequest{
+	err0 := s.VisibilityMgr.RecordWorkflowExecutionStarted(context.Background(), &p.RecordWorkflowExecutionStartedRequest{
 		DomainUUID:       testDomainUUID,
 		Execution:        workflowExecution,
 		WorkflowTypeName: "visibility-workflow",
@@ -139,7 +140,7 @@ func (s *VisibilityPersistenceSuite) TestBasicVisibilityTimeSkew() {
 	})
 	s.Nil(err0)
 
-	resp, err1 := s.VisibilityMgr.ListOpenWorkflowExecutions(&p.ListWorkflowExecutionsRequest{
+	resp, err1 := s.VisibilityMgr.ListOpenWorkflowExecutions(context.Background(), &p.ListWorkflowExecutionsRequest{
 		DomainUUID:        testDomainUUID,
 		PageSize:          1,
 		EarliestStartTime: startTime,
@@ -149,7 +150,7 @@ func (s *VisibilityPersistenceSuite) TestBasicVisibilityTimeSkew() {
 	s.Equal(1, len(resp.Executions))
 	s.Equal(workflowExecution.WorkflowId, resp.Executions[0].Execution.WorkflowId)
 
-	err2 := s.VisibilityMgr.RecordWorkflowExecutionClosed(&p.RecordWorkflowExecutionClosedRequest{
+	err2 := s.VisibilityMgr.RecordWorkflowExecutionClosed(context.Background(), &p.RecordWorkflowExecutionClosedRequest{
 		DomainUUID:       testDomainUUID,
 		Execution:        workflowExecution,
 		WorkflowTypeName: "visibility-workflow",
@@ -158,7 +159,7 @@ func (s *VisibilityPersistenceSuite) TestBasicVisibilityTimeSkew() {
 	})
 	s.Nil(err2)
 
-	resp, err3 := s.VisibilityMgr.ListOpenWorkflowExecutions(&p.ListWorkflowExecutionsRequest{
+	resp, err3 := s.VisibilityMgr.ListOpenWorkflowExecutions(context.Background(), &p.ListWorkflowExecutionsRequest{
 		DomainUUID:        testDomainUUID,
 		PageSize:          1,
 		EarliestStartTime: startTime,
@@ -167,7 +168,7 @@ func (s *VisibilityPersistenceSuite) TestBasicVisibilityTimeSkew() {
 	s.Nil(err3)
 	s.Equal(0, len(resp.Executions))
 
-	resp, err4 := s.VisibilityMgr.ListClosedWorkflowExecutions(&p.ListWorkflowExecutionsRequest{
+	resp, err4 := s.VisibilityMgr.ListClosedWorkflowExecutions(context.Background(), &p.ListWorkflowExecutionsRequest{
 		DomainUUID:        testDomainUUID,
 		PageSize:          1,
 		EarliestStartTime: startTime,
@@ -195,7 +196,7 @@ func (s *VisibilityPersistenceSuite) TestVisibilityPagination() {
 		StartTimestamp:   startTime1.UnixNano(),
 	}
 
-	err0 := s.VisibilityMgr.RecordWorkflowExecutionStarted(startReq1)
+	err0 := s.VisibilityMgr.RecordWorkflowExecutionStarted(context.Background(), startReq1)
 	s.Nil(err0)
 
 	startTime2 := startTime1.Add(time.Second)
@@ -210,11 +211,11 @@ func (s *VisibilityPersistenceSuite) TestVisibilityPagination() {
 		WorkflowTypeName: "visibility-workflow",
 		StartTimestamp:   startTime2.UnixNano(),
 	}
-	err1 := s.VisibilityMgr.RecordWorkflowExecutionStarted(startReq2)
+	err1 := s.VisibilityMgr.RecordWorkflowExecutionStarted(context.Background(), startReq2)
 	s.Nil(err1)
 
 	// Get the first one
-	resp, err2 := s.VisibilityMgr.ListOpenWorkflowExecutions(&p.ListWorkflowExecutionsRequest{
+	resp, err2 := s.VisibilityMgr.ListOpenWorkflowExecutions(context.Background(), &p.ListWorkflowExecutionsRequest{
 		DomainUUID:        testDomainUUID,
 		PageSize:          1,
 		EarliestStartTime: startTime1.UnixNano(),
@@ -225,7 +226,7 @@ func (s *VisibilityPersistenceSuite) TestVisibilityPagination() {
 	s.assertOpenExecutionEquals(startReq2, resp.Executions[0])
 
 	// Use token to get the second one
-	resp, err3 := s.VisibilityMgr.ListOpenWorkflowExecutions(&p.ListWorkflowExecutionsRequest{
+	resp, err3 := s.VisibilityMgr.ListOpenWorkflowExecutions(context.Background(), &p.ListWorkflowExecutionsRequest{
 		DomainUUID:        testDomainUUID,
 		PageSize:          1,
 		EarliestStartTime: startTime1.UnixNano(),
@@ -239,7 +240,7 @@ func (s *VisibilityPersistenceSuite) TestVisibilityPagination() {
 	// TODO: See if it is possible in Cassandra to not return non empty token which is going to return empty result
 	if s.ExecutionManager.GetName() == "cassandra" {
 		// Now should get empty result by using token
-		resp, err4 := s.VisibilityMgr.ListOpenWorkflowExecutions(&p.ListWorkflowExecutionsRequest{
+		resp, err4 := s.VisibilityMgr.ListOpenWorkflowExecutions(context.Background(), &p.ListWorkflowExecutionsRequest{
 			DomainUUID:        testDomainUUID,
 			PageSize:          1,
 			EarliestStartTime: startTime1.UnixNano(),
@@ -263,7 +264,7 @@ func (s *VisibilityPersistenceSuite) TestFilteringByType() {
 		WorkflowId: common.StringPtr("visibility-filtering-test1"),
 		RunId:      common.StringPtr("fb15e4b5-356f-466d-8c6d-a29223e5c536"),
 	}
-	err0 := s.VisibilityMgr.RecordWorkflowExecutionStarted(&p.RecordWorkflowExecutionStartedRequest{
+	err0 := s.VisibilityMgr.RecordWorkflowExecutionStarted(context.Background(), &p.RecordWorkflowExecutionStartedRequest{
 		DomainUUID:       testDomainUUID,
 		Execution:        workflowExecution1,
 		WorkflowTypeName: "visibility-workflow-1",
@@ -275,7 +276,7 @@ func (s *VisibilityPersistenceSuite) TestFilteringByType() {
 		WorkflowId: common.StringPtr("visibility-filtering-test2"),
 		RunId:      common.StringPtr("843f6fc7-102a-4c63-a2d4-7c653b01bf52"),
 	}
-	err1 := s.VisibilityMgr.RecordWorkflowExecutionStarted(&p.RecordWorkflowExecutionStartedRequest{
+	err1 := s.VisibilityMgr.RecordWorkflowExecutionStarted(context.Background(), &p.RecordWorkflowExecutionStartedRequest{
 		DomainUUID:       testDomainUUID,
 		Execution:        workflowExecution2,
 		WorkflowTypeName: "visibility-workflow-2",
@@ -284,7 +285,7 @@ func (s *VisibilityPersistenceSuite) TestFilteringByType() {
 	s.Nil(err1)
 
 	// List open with filtering
-	resp, err2 := s.VisibilityMgr.ListOpenWorkflowExecutionsByType(&p.ListWorkflowExecutionsByTypeRequest{
+	resp, err2 := s.VisibilityMgr.ListOpenWorkflowExecutionsByType(context.Background(), &p.ListWorkflowExecutionsByTypeRequest{
 		ListWorkflowExecutionsRequest: p.ListWorkflowExecutionsRequest{
 			DomainUUID:        testDomainUUID,
 			PageSize:          2,
@@ -298,7 +299,7 @@ func (s *VisibilityPersistenceSuite) TestFilteringByType() {
 	s.Equal(workflowExecution1.WorkflowId, resp.Executions[0].Execution.WorkflowId)
 
 	// Close both executions
-	err3 := s.VisibilityMgr.RecordWorkflowExecutionClosed(&p.RecordWorkflowExecutionClosedRequest{
+	err3 := s.VisibilityMgr.RecordWorkflowExecutionClosed(context.Background(), &p.RecordWorkflowExecutionClosedRequest{
 		DomainUUID:       testDomainUUID,
 		Execution:        workflowExecution1,
 		WorkflowTypeName: "visibility-workflow-1",
@@ -315,11 +316,11 @@ func (s *VisibilityPersistenceSuite) TestFilteringByType() {
 		CloseTimestamp:   time.Now().UnixNano(),
 		HistoryLength:    3,
 	}
-	err4 := s.VisibilityMgr.RecordWorkflowExecutionClosed(closeReq)
+	err4 := s.VisibilityMgr.RecordWorkflowExecutionClosed(context.Background(), closeReq)
 	s.Nil(err4)
 
 	// List closed with filtering
-	resp, err5 := s.VisibilityMgr.ListClosedWorkflowExecutionsByType(&p.ListWorkflowExecutionsByTypeRequest{
+	resp, err5 := s.VisibilityMgr.ListClosedWorkflowExecutionsByType(context.Background(), &p.ListWorkflowExecutionsByTypeRequest{
 		ListWorkflowExecutionsRequest: p.ListWorkflowExecutionsRequest{
 			DomainUUID:        testDomainUUID,
 			PageSize:          2,
@@ -343,7 +344,7 @@ func (s *VisibilityPersistenceSuite) TestFilteringByWorkflowID() {
 		WorkflowId: common.StringPtr("visibility-filtering-test1"),
 		RunId:      common.StringPtr("fb15e4b5-356f-466d-8c6d-a29223e5c536"),
 	}
-	err0 := s.VisibilityMgr.RecordWorkflowExecutionStarted(&p.RecordWorkflowExecutionStartedRequest{
+	err0 := s.VisibilityMgr.RecordWorkflowExecutionStarted(context.Background(), &p.RecordWorkflowExecutionStartedRequest{
 		DomainUUID:       testDomainUUID,
 		Execution:        workflowExecution1,
 		WorkflowTypeName: "visibility-workflow",
@@ -355,7 +356,7 @@ func (s *VisibilityPersistenceSuite) TestFilteringByWorkflowID() {
 		WorkflowId: common.StringPtr("visibility-filtering-test2"),
 		RunId:      common.StringPtr("843f6fc7-102a-4c63-a2d4-7c653b01bf52"),
 	}
-	err1 := s.VisibilityMgr.RecordWorkflowExecutionStarted(&p.RecordWorkflowExecutionStartedRequest{
+	err1 := s.VisibilityMgr.RecordWorkflowExecutionStarted(context.Background(), &p.RecordWorkflowExecutionStartedRequest{
 		DomainUUID:       testDomainUUID,
 		Execution:        workflowExecution2,
 		WorkflowTypeName: "visibility-workflow",
@@ -364,7 +365,7 @@ func (s *VisibilityPersistenceSuite) TestFilteringByWorkflowID() {
 	s.Nil(err1)
 
 	// List open with filtering
-	resp, err2 := s.VisibilityMgr.ListOpenWorkflowExecutionsByWorkflowID(&p.ListWorkflowExecutionsByWorkflowIDRequest{
+	resp, err2 := s.VisibilityMgr.ListOpenWorkflowExecutionsByWorkflowID(context.Background(), &p.ListWorkflowExecutionsByWorkflowIDRequest{
 		ListWorkflowExecutionsRequest: p.ListWorkflowExecutionsRequest{
 			DomainUUID:        testDomainUUID,
 			PageSize:          2,
@@ -378,7 +379,7 @@ func (s *VisibilityPersistenceSuite) TestFilteringByWorkflowID() {
 	s.Equal(workflowExecution1.WorkflowId, resp.Executions[0].Execution.WorkflowId)
 
 	// Close both executions
-	err3 := s.VisibilityMgr.RecordWorkflowExecutionClosed(&p.RecordWorkflowExecutionClosedRequest{
+	err3 := s.VisibilityMgr.RecordWorkflowExecutionClosed(context.Background(), &p.RecordWorkflowExecutionClosedRequest{
 		DomainUUID:       testDomainUUID,
 		Execution:        workflowExecution1,
 		WorkflowTypeName: "visibility-workflow",
@@ -395,11 +396,11 @@ func (s *VisibilityPersistenceSuite) TestFilteringByWorkflowID() {
 		CloseTimestamp:   time.Now().UnixNano(),
 		HistoryLength:    3,
 	}
-	err4 := s.VisibilityMgr.RecordWorkflowExecutionClosed(closeReq)
+	err4 := s.VisibilityMgr.RecordWorkflowExecutionClosed(context.Background(), closeReq)
 	s.Nil(err4)
 
 	// List closed with filtering
-	resp, err5 := s.VisibilityMgr.ListClosedWorkflowExecutionsByWorkflowID(&p.ListWorkflowExecutionsByWorkflowIDRequest{
+	resp, err5 := s.VisibilityMgr.ListClosedWorkflowExecutionsByWorkflowID(context.Background(), &p.ListWorkflowExecutionsByWorkflowIDRequest{
 		ListWorkflowExecutionsRequest: p.ListWorkflowExecutionsRequest{
 			DomainUUID:        testDomainUUID,
 			PageSize:          2,
@@ -423,7 +424,7 @@ func (s *VisibilityPersistenceSuite) TestFilteringByCloseStatus() {
 		WorkflowId: common.StringPtr("visibility-filtering-test1"),
 		RunId:      common.StringPtr("fb15e4b5-356f-466d-8c6d-a29223e5c536"),
 	}
-	err0 := s.VisibilityMgr.RecordWorkflowExecutionStarted(&p.RecordWorkflowExecutionStartedRequest{
+	err0 := s.VisibilityMgr.RecordWorkflowExecutionStarted(context.Background(), &p.RecordWorkflowExecutionStartedRequest{
 		DomainUUID:       testDomainUUID,
 		Execution:        workflowExecution1,
 		WorkflowTypeName: "visibility-workflow",
@@ -435,7 +436,7 @@ func (s *VisibilityPersistenceSuite) TestFilteringByCloseStatus() {
 		WorkflowId: common.StringPtr("visibility-filtering-test2"),
 		RunId:      common.StringPtr("843f6fc7-102a-4c63-a2d4-7c653b01bf52"),
 	}
-	err1 := s.VisibilityMgr.RecordWorkflowExecutionStarted(&p.RecordWorkflowExecutionStartedRequest{
+	err1 := s.VisibilityMgr.RecordWorkflowExecutionStarted(context.Background(), &p.RecordWorkflowExecutionStartedRequest{
 		DomainUUID:       testDomainUUID,
 		Execution:        workflowExecution2,
 		WorkflowTypeName: "visibility-workflow",
@@ -444,7 +445,7 @@ func (s *VisibilityPersistenceSuite) TestFilteringByCloseStatus() {
 	s.Nil(err1)
 
 	// Close both executions with different status
-	err2 := s.VisibilityMgr.RecordWorkflowExecutionClosed(&p.RecordWorkflowExecutionClosedRequest{
+	err2 := s.VisibilityMgr.RecordWorkflowExecutionClosed(context.Background(), &p.RecordWorkflowExecutionClosedRequest{
 		DomainUUID:       testDomainUUID,
 		Execution:        workflowExecution1,
 		WorkflowTypeName: "visibility-workflow",
@@ -463,11 +464,11 @@ func (s *VisibilityPersistenceSuite) TestFilteringByCloseStatus() {
 		CloseTimestamp:   time.Now().UnixNano(),
 		HistoryLength:    3,
 	}
-	err3 := s.VisibilityMgr.RecordWorkflowExecutionClosed(closeReq)
+	err3 := s.VisibilityMgr.RecordWorkflowExecutionClosed(context.Background(), closeReq)
 	s.Nil(err3)
 
 	// List closed with filtering
-	resp, err4 := s.VisibilityMgr.ListClosedWorkflowExecutionsByStatus(&p.ListClosedWorkflowExecutionsByStatusRequest{
+	resp, err4 := s.VisibilityMgr.ListClosedWorkflowExecutionsByStatus(context.Background(), &p.ListClosedWorkflowExecutionsByStatusRequest{
 		ListWorkflowExecutionsRequest: p.ListWorkflowExecutionsRequest{
 			DomainUUID:        testDomainUUID,
 			PageSize:          2,
@@ -491,7 +492,7 @@ func (s *VisibilityPersistenceSuite) TestGetClosedExecution() {
 	}
 
 	startTime := time.Now().Add(time.Second * -5).UnixNano()
-	err0 := s.VisibilityMgr.RecordWorkflowExecutionStarted(&p.RecordWorkflowExecutionStartedRequest{
+	err0 := s.VisibilityMgr.RecordWorkflowExecutionStarted(context.Background(), &p.RecordWorkflowExecutionStartedRequest{
 		DomainUUID:       testDomainUUID,
 		Execution:        workflowExecution,
 		WorkflowTypeName: "visibility-workflow",
@@ -499,7 +500,7 @@ func (s *VisibilityPersistenceSuite) TestGetClosedExecution() {
 	})
 	s.Nil(err0)
 
-	closedResp, err1 := s.VisibilityMgr.GetClosedWorkflowExecution(&p.GetClosedWorkflowExecutionRequest{
+	closedResp, err1 := s.VisibilityMgr.GetClosedWorkflowExecution(context.Background(), &p.GetClosedWorkflowExecutionRequest{
 		DomainUUID: testDomainUUID,
 		Execution:  workflowExecution,
 	})
@@ -517,10 +518,10 @@ func (s *VisibilityPersistenceSuite) TestGetClosedExecution() {
 		CloseTimestamp:   time.Now().UnixNano(),
 		HistoryLength:    3,
 	}
-	err2 := s.VisibilityMgr.RecordWorkflowExecutionClosed(closeReq)
+	err2 := s.VisibilityMgr.RecordWorkflowExecutionClosed(context.Background(), closeReq)
 	s.Nil(err2)
 
-	resp, err3 := s.VisibilityMgr.GetClosedWorkflowExecution(&p.GetClosedWorkflowExecutionRequest{
+	resp, err3 := s.VisibilityMgr.GetClosedWorkflowExecution(context.Background(), &p.GetClosedWorkflowExecutionRequest{
 		DomainUUID: testDomainUUID,
 		Execution:  workflowExecution,
 	})
@@ -528,6 +529,134 @@ func (s *VisibilityPersistenceSuite) TestGetClosedExecution() {
 	s.assertClosedExecutionEquals(closeReq, resp.Execution)
 }
 
+// TestChildExecution test
+func (s *VisibilityPersistenceSuite) TestChildExecution() {
+	testDomainUUID := uuid.New()
+	startTime := time.Now().UnixNano()
+
+	parentExecution := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("visibility-parent-test"),
+		RunId:      common.StringPtr("0d6b2ba4-b0d1-4b05-8b8b-2d6ba4d0f0a5"),
+	}
+	workflowExecution := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("visibility-child-test"),
+		RunId:      common.StringPtr("5f2b3f4e-7fa1-4b7d-9c1f-1b8c2c4a9e21"),
+	}
+	err0 := s.VisibilityMgr.RecordWorkflowExecutionStarted(context.Background(), &p.RecordWorkflowExecutionStartedRequest{
+		DomainUUID:       testDomainUUID,
+		Execution:        workflowExecution,
+		WorkflowTypeName: "visibility-workflow",
+		StartTimestamp:   startTime,
+		TaskList:         "visibility-tasklist",
+		ParentWorkflowID: parentExecution.GetWorkflowId(),
+		ParentRunID:      parentExecution.GetRunId(),
+	})
+	s.Nil(err0)
+
+	resp, err1 := s.VisibilityMgr.ListOpenWorkflowExecutionsByWorkflowID(context.Background(), &p.ListWorkflowExecutionsByWorkflowIDRequest{
+		ListWorkflowExecutionsRequest: p.ListWorkflowExecutionsRequest{
+			DomainUUID:        testDomainUUID,
+			PageSize:          1,
+			EarliestStartTime: startTime,
+			LatestStartTime:   startTime,
+		},
+		WorkflowID: workflowExecution.GetWorkflowId(),
+	})
+	s.Nil(err1)
+	s.Equal(1, len(resp.Executions))
+	s.Equal("visibility-tasklist", resp.Executions[0].GetTaskList())
+	s.Equal(parentExecution, *resp.Executions[0].ParentExecution)
+
+	err2 := s.VisibilityMgr.RecordWorkflowExecutionClosed(context.Background(), &p.RecordWorkflowExecutionClosedRequest{
+		DomainUUID:       testDomainUUID,
+		Execution:        workflowExecution,
+		WorkflowTypeName: "visibility-workflow",
+		StartTimestamp:   startTime,
+		CloseTimestamp:   time.Now().UnixNano(),
+		Status:           gen.WorkflowExecutionCloseStatusCompleted,
+		HistoryLength:    3,
+		TaskList:         "visibility-tasklist",
+		ParentWorkflowID: parentExecution.GetWorkflowId(),
+		ParentRunID:      parentExecution.GetRunId(),
+	})
+	s.Nil(err2)
+
+	closedResp, err3 := s.VisibilityMgr.GetClosedWorkflowExecution(context.Background(), &p.GetClosedWorkflowExecutionRequest{
+		DomainUUID: testDomainUUID,
+		Execution:  workflowExecution,
+	})
+	s.Nil(err3)
+	s.Equal("visibility-tasklist", closedResp.Execution.GetTaskList())
+	s.Equal(parentExecution, *closedResp.Execution.ParentExecution)
+}
+
+// TestExecutionTime test
+func (s *VisibilityPersistenceSuite) TestExecutionTime() {
+	testDomainUUID := uuid.New()
+	startTime := time.Now().UnixNano()
+	executionTime := startTime + int64(time.Hour)
+
+	delayedExecution := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("visibility-delayed-test"),
+		RunId:      common.StringPtr("b9f1b7a2-4a7e-4c8d-8f67-3b1fd2d1d6a4"),
+	}
+	err0 := s.VisibilityMgr.RecordWorkflowExecutionStarted(context.Background(), &p.RecordWorkflowExecutionStartedRequest{
+		DomainUUID:         testDomainUUID,
+		Execution:          delayedExecution,
+		WorkflowTypeName:   "visibility-workflow",
+		StartTimestamp:     startTime,
+		ExecutionTimestamp: executionTime,
+	})
+	s.Nil(err0)
+
+	// records written without execution time execute at their start time
+	immediateExecution := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("visibility-immediate-test"),
+		RunId:      common.StringPtr("6c0d3f5e-2a91-4f0b-a3c4-8e5d7b1a9c02"),
+	}
+	err1 := s.VisibilityMgr.RecordWorkflowExecutionStarted(context.Background(), &p.RecordWorkflowExecutionStartedRequest{
+		DomainUUID:       testDomainUUID,
+		Execution:        immediateExecution,
+		WorkflowTypeName: "visibility-workflow",
+		StartTimestamp:   startTime,
+	})
+	s.Nil(err1)
+
+	resp, err2 := s.VisibilityMgr.ListOpenWorkflowExecutions(context.Background(), &p.ListWorkflowExecutionsRequest{
+		DomainUUID:        testDomainUUID,
+		PageSize:          2,
+		EarliestStartTime: startTime,
+		LatestStartTime:   startTime,
+	})
+	s.Nil(err2)
+	s.Equal(2, len(resp.Executions))
+	for _, execution := range resp.Executions {
+		if execution.Execution.GetWorkflowId() == delayedExecution.GetWorkflowId() {
+			s.Equal(s.nanosToMillis(executionTime), s.nanosToMillis(execution.GetExecutionTime()))
+		} else {
+			s.Equal(s.nanosToMillis(startTime), s.nanosToMillis(execution.GetExecutionTime()))
+		}
+	}
+
+	err3 := s.VisibilityMgr.RecordWorkflowExecutionClosed(context.Background(), &p.RecordWorkflowExecutionClosedRequest{
+		DomainUUID:       testDomainUUID,
+		Execution:        immediateExecution,
+		WorkflowTypeName: "visibility-workflow",
+		StartTimestamp:   startTime,
+		CloseTimestamp:   time.Now().UnixNano(),
+		Status:           gen.WorkflowExecutionCloseStatusCompleted,
+		HistoryLength:    3,
+	})
+	s.Nil(err3)
+
+	closedResp, err4 := s.VisibilityMgr.GetClosedWorkflowExecution(context.Background(), &p.GetClosedWorkflowExecutionRequest{
+		DomainUUID: testDomainUUID,
+		Execution:  immediateExecution,
+	})
+	s.Nil(err4)
+	s.Equal(s.nanosToMillis(startTime), s.nanosToMillis(closedResp.Execution.GetExecutionTime()))
+}
+
 // TestClosedWithoutStarted test
 func (s *VisibilityPersistenceSuite) TestClosedWithoutStarted() {
 	testDomainUUID := uuid.New()
@@ -536,7 +665,7 @@ func (s *VisibilityPersistenceSuite) TestClosedWithoutStarted() {
 		RunId:      common.StringPtr("1bdb0122-e8c9-4b35-b6f8-d692ab259b09"),
 	}
 
-	closedResp, err0 := s.VisibilityMgr.GetClosedWorkflowExecution(&p.GetClosedWorkflowExecutionRequest{
+	closedResp, err0 := s.VisibilityMgr.GetClosedWorkflowExecution(context.Background(), &p.GetClosedWorkflowExecutionRequest{
 		DomainUUID: testDomainUUID,
 		Execution:  workflowExecution,
 	})
@@ -554,10 +683,10 @@ func (s *VisibilityPersistenceSuite) TestClosedWithoutStarted() {
 		CloseTimestamp:   time.Now().UnixNano(),
 		HistoryLength:    3,
 	}
-	err1 := s.VisibilityMgr.RecordWorkflowExecutionClosed(closeReq)
+	err1 := s.VisibilityMgr.RecordWorkflowExecutionClosed(context.Background(), closeReq)
 	s.Nil(err1)
 
-	resp, err2 := s.VisibilityMgr.GetClosedWorkflowExecution(&p.GetClosedWorkflowExecutionRequest{
+	resp, err2 := s.VisibilityMgr.GetClosedWorkflowExecution(context.Background(), &p.GetClosedWorkflowExecutionRequest{
 		DomainUUID: testDomainUUID,
 		Execution:  workflowExecution,
 	})
@@ -587,7 +716,7 @@ func (s *VisibilityPersistenceSuite) TestMultipleUpserts() {
 
 	count := 3
 	for i := 0; i < count; i++ {
-		err0 := s.VisibilityMgr.RecordWorkflowExecutionStarted(&p.RecordWorkflowExecutionStartedRequest{
+		err0 := s.VisibilityMgr.RecordWorkflowExecutionStarted(context.Background(), &p.RecordWorkflowExecutionStartedRequest{
 			DomainUUID:       testDomainUUID,
 			Execution:        workflowExecution,
 			WorkflowTypeName: "visibility-workflow",
@@ -595,12 +724,12 @@ func (s *VisibilityPersistenceSuite) TestMultipleUpserts() {
 		})
 		s.Nil(err0)
 		if i < count-1 {
-			err1 := s.VisibilityMgr.RecordWorkflowExecutionClosed(closeReq)
+			err1 := s.VisibilityMgr.RecordWorkflowExecutionClosed(context.Background(), closeReq)
 			s.Nil(err1)
 		}
 	}
 
-	resp, err3 := s.VisibilityMgr.GetClosedWorkflowExecution(&p.GetClosedWorkflowExecutionRequest{
+	resp, err3 := s.VisibilityMgr.GetClosedWorkflowExecution(context.Background(), &p.GetClosedWorkflowExecutionRequest{
 		DomainUUID: testDomainUUID,
 		Execution:  workflowExecution,
 	})
@@ -621,7 +750,7 @@ func (s *VisibilityPersistenceSuite) TestDelete() {
 			WorkflowId: common.StringPtr(uuid.New()),
 			RunId:      common.StringPtr(uuid.New()),
 		}
-		err0 := s.VisibilityMgr.RecordWorkflowExecutionStarted(&p.RecordWorkflowExecutionStartedRequest{
+		err0 := s.VisibilityMgr.RecordWorkflowExecutionStarted(context.Background(), &p.RecordWorkflowExecutionStartedRequest{
 			DomainUUID:       testDomainUUID,
 			Execution:        workflowExecution,
 			WorkflowTypeName: "visibility-workflow",
@@ -637,11 +766,11 @@ func (s *VisibilityPersistenceSuite) TestDelete() {
 			CloseTimestamp:   time.Now().UnixNano(),
 			HistoryLength:    3,
 		}
-		err1 := s.VisibilityMgr.RecordWorkflowExecutionClosed(closeReq)
+		err1 := s.VisibilityMgr.RecordWorkflowExecutionClosed(context.Background(), closeReq)
 		s.Nil(err1)
 	}
 
-	resp, err3 := s.VisibilityMgr.ListClosedWorkflowExecutions(&p.ListWorkflowExecutionsRequest{
+	resp, err3 := s.VisibilityMgr.ListClosedWorkflowExecutions(context.Background(), &p.ListWorkflowExecutionsRequest{
 		DomainUUID:        testDomainUUID,
 		EarliestStartTime: startTime,
 		LatestStartTime:   time.Now().UnixNano(),
@@ -652,13 +781,13 @@ func (s *VisibilityPersistenceSuite) TestDelete() {
 
 	remaining := nRows
 	for _, row := range resp.Executions {
-		err4 := s.VisibilityMgr.DeleteWorkflowExecution(&p.VisibilityDeleteWorkflowExecutionRequest{
+		err4 := s.VisibilityMgr.DeleteWorkflowExecution(context.Background(), &p.VisibilityDeleteWorkflowExecutionRequest{
 			DomainID: testDomainUUID,
 			RunID:    row.GetExecution().GetRunId(),
 		})
 		s.Nil(err4)
 		remaining--
-		resp, err5 := s.VisibilityMgr.ListClosedWorkflowExecutions(&p.ListWorkflowExecutionsRequest{
+		resp, err5 := s.VisibilityMgr.ListClosedWorkflowExecutions(context.Background(), &p.ListWorkflowExecutionsRequest{
 			DomainUUID:        testDomainUUID,
 			EarliestStartTime: startTime,
 			LatestStartTime:   time.Now().UnixNano(),
@@ -669,6 +798,54 @@ func (s *VisibilityPersistenceSuite) TestDelete() {
 	}
 }
 
+func (s *VisibilityPersistenceSuite) TestTombstone() {
+	if s.VisibilityMgr.GetName() == "cassandra" {
+		s.T().Skip("this test is not applicable for cassandra, tombstones expire through TTL")
+	}
+	testDomainUUID := uuid.New()
+	startTime := time.Now().Add(-3 * time.Hour).UnixNano()
+	recordTombstone := func(closeTime time.Time) *p.RecordWorkflowExecutionClosedRequest {
+		workflowExecution := gen.WorkflowExecution{
+			WorkflowId: common.StringPtr(uuid.New()),
+			RunId:      common.StringPtr(uuid.New()),
+		}
+		closeReq := &p.RecordWorkflowExecutionClosedRequest{
+			DomainUUID:       testDomainUUID,
+			Execution:        workflowExecution,
+			WorkflowTypeName: "visibility-workflow",
+			StartTimestamp:   startTime,
+			Status:           gen.WorkflowExecutionCloseStatusDeleted,
+			CloseTimestamp:   closeTime.UnixNano(),
+			RetentionSeconds: int64(time.Hour.Seconds()),
+		}
+		err := s.VisibilityMgr.RecordWorkflowExecutionClosed(context.Background(), closeReq)
+		s.Nil(err)
+		return closeReq
+	}
+
+	expiredReq := recordTombstone(time.Now().Add(-2 * time.Hour))
+	resp, err := s.VisibilityMgr.GetClosedWorkflowExecution(context.Background(), &p.GetClosedWorkflowExecutionRequest{
+		DomainUUID: testDomainUUID,
+		Execution:  expiredReq.Execution,
+	})
+	s.Nil(err)
+	s.assertClosedExecutionEquals(expiredReq, resp.Execution)
+
+	// recording a tombstone deletes the tombstones of the domain which outlived their retention
+	tombstoneReq := recordTombstone(time.Now())
+	_, err = s.VisibilityMgr.GetClosedWorkflowExecution(context.Background(), &p.GetClosedWorkflowExecutionRequest{
+		DomainUUID: testDomainUUID,
+		Execution:  expiredReq.Execution,
+	})
+	s.IsType(&gen.EntityNotExistsError{}, err)
+	resp, err = s.VisibilityMgr.GetClosedWorkflowExecution(context.Background(), &p.GetClosedWorkflowExecutionRequest{
+		DomainUUID: testDomainUUID,
+		Execution:  tombstoneReq.Execution,
+	})
+	s.Nil(err)
+	s.assertClosedExecutionEquals(tombstoneReq, resp.Execution)
+}
+
 func (s *VisibilityPersistenceSuite) assertClosedExecutionEquals(
 	req *p.RecordWorkflowExecutionClosedRequest, resp *gen.WorkflowExecutionInfo) {
 	s.Equal(req.Execution.RunId, resp.Execution.RunId)
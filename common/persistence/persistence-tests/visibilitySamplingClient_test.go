@@ -21,9 +21,11 @@
 package persistencetests
 
 import (
+	"context"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"github.com/uber-common/bark"
@@ -87,12 +89,12 @@ func (s *VisibilitySamplingSuite) TestRecordWorkflowExecutionStarted() {
 		WorkflowTypeName: testWorkflowTypeName,
 		StartTimestamp:   time.Now().UnixNano(),
 	}
-	s.persistence.On("RecordWorkflowExecutionStarted", request).Return(nil).Once()
-	s.NoError(s.client.RecordWorkflowExecutionStarted(request))
+	s.persistence.On("RecordWorkflowExecutionStarted", mock.Anything, request).Return(nil).Once()
+	s.NoError(s.client.RecordWorkflowExecutionStarted(context.Background(), request))
 
 	// no remaining tokens
 	s.metricClient.On("IncCounter", metrics.PersistenceRecordWorkflowExecutionStartedScope, metrics.PersistenceSampledCounter).Once()
-	s.NoError(s.client.RecordWorkflowExecutionStarted(request))
+	s.NoError(s.client.RecordWorkflowExecutionStarted(context.Background(), request))
 }
 
 func (s *VisibilitySamplingSuite) TestRecordWorkflowExecutionClosed() {
@@ -111,16 +113,16 @@ func (s *VisibilitySamplingSuite) TestRecordWorkflowExecutionClosed() {
 		Status:           gen.WorkflowExecutionCloseStatusFailed,
 	}
 
-	s.persistence.On("RecordWorkflowExecutionClosed", request).Return(nil).Once()
-	s.NoError(s.client.RecordWorkflowExecutionClosed(request))
-	s.persistence.On("RecordWorkflowExecutionClosed", request2).Return(nil).Once()
-	s.NoError(s.client.RecordWorkflowExecutionClosed(request2))
+	s.persistence.On("RecordWorkflowExecutionClosed", mock.Anything, request).Return(nil).Once()
+	s.NoError(s.client.RecordWorkflowExecutionClosed(context.Background(), request))
+	s.persistence.On("RecordWorkflowExecutionClosed", mock.Anything, request2).Return(nil).Once()
+	s.NoError(s.client.RecordWorkflowExecutionClosed(context.Background(), request2))
 
 	// no remaining tokens
 	s.metricClient.On("IncCounter", metrics.PersistenceRecordWorkflowExecutionClosedScope, metrics.PersistenceSampledCounter).Once()
-	s.NoError(s.client.RecordWorkflowExecutionClosed(request))
+	s.NoError(s.client.RecordWorkflowExecutionClosed(context.Background(), request))
 	s.metricClient.On("IncCounter", metrics.PersistenceRecordWorkflowExecutionClosedScope, metrics.PersistenceSampledCounter).Once()
-	s.NoError(s.client.RecordWorkflowExecutionClosed(request2))
+	s.NoError(s.client.RecordWorkflowExecutionClosed(context.Background(), request2))
 }
 
 func (s *VisibilitySamplingSuite) TestListOpenWorkflowExecutions() {
@@ -128,12 +130,12 @@ func (s *VisibilitySamplingSuite) TestListOpenWorkflowExecutions() {
 		DomainUUID: testDomainUUID,
 		Domain:     testDomain,
 	}
-	s.persistence.On("ListOpenWorkflowExecutions", request).Return(nil, nil).Once()
-	_, err := s.client.ListOpenWorkflowExecutions(request)
+	s.persistence.On("ListOpenWorkflowExecutions", mock.Anything, request).Return(nil, nil).Once()
+	_, err := s.client.ListOpenWorkflowExecutions(context.Background(), request)
 	s.NoError(err)
 
 	// no remaining tokens
-	_, err = s.client.ListOpenWorkflowExecutions(request)
+	_, err = s.client.ListOpenWorkflowExecutions(context.Background(), request)
 	s.Error(err)
 	errDetail, ok := err.(*gen.ServiceBusyError)
 	s.True(ok)
@@ -145,12 +147,12 @@ func (s *VisibilitySamplingSuite) TestListClosedWorkflowExecutions() {
 		DomainUUID: testDomainUUID,
 		Domain:     testDomain,
 	}
-	s.persistence.On("ListClosedWorkflowExecutions", request).Return(nil, nil).Once()
-	_, err := s.client.ListClosedWorkflowExecutions(request)
+	s.persistence.On("ListClosedWorkflowExecutions", mock.Anything, request).Return(nil, nil).Once()
+	_, err := s.client.ListClosedWorkflowExecutions(context.Background(), request)
 	s.NoError(err)
 
 	// no remaining tokens
-	_, err = s.client.ListClosedWorkflowExecutions(request)
+	_, err = s.client.ListClosedWorkflowExecutions(context.Background(), request)
 	s.Error(err)
 	errDetail, ok := err.(*gen.ServiceBusyError)
 	s.True(ok)
@@ -166,12 +168,12 @@ func (s *VisibilitySamplingSuite) TestListOpenWorkflowExecutionsByType() {
 		ListWorkflowExecutionsRequest: req,
 		WorkflowTypeName:              testWorkflowTypeName,
 	}
-	s.persistence.On("ListOpenWorkflowExecutionsByType", request).Return(nil, nil).Once()
-	_, err := s.client.ListOpenWorkflowExecutionsByType(request)
+	s.persistence.On("ListOpenWorkflowExecutionsByType", mock.Anything, request).Return(nil, nil).Once()
+	_, err := s.client.ListOpenWorkflowExecutionsByType(context.Background(), request)
 	s.NoError(err)
 
 	// no remaining tokens
-	_, err = s.client.ListOpenWorkflowExecutionsByType(request)
+	_, err = s.client.ListOpenWorkflowExecutionsByType(context.Background(), request)
 	s.Error(err)
 	errDetail, ok := err.(*gen.ServiceBusyError)
 	s.True(ok)
@@ -187,12 +189,12 @@ func (s *VisibilitySamplingSuite) TestListClosedWorkflowExecutionsByType() {
 		ListWorkflowExecutionsRequest: req,
 		WorkflowTypeName:              testWorkflowTypeName,
 	}
-	s.persistence.On("ListClosedWorkflowExecutionsByType", request).Return(nil, nil).Once()
-	_, err := s.client.ListClosedWorkflowExecutionsByType(request)
+	s.persistence.On("ListClosedWorkflowExecutionsByType", mock.Anything, request).Return(nil, nil).Once()
+	_, err := s.client.ListClosedWorkflowExecutionsByType(context.Background(), request)
 	s.NoError(err)
 
 	// no remaining tokens
-	_, err = s.client.ListClosedWorkflowExecutionsByType(request)
+	_, err = s.client.ListClosedWorkflowExecutionsByType(context.Background(), request)
 	s.Error(err)
 	errDetail, ok := err.(*gen.ServiceBusyError)
 	s.True(ok)
@@ -208,12 +210,12 @@ func (s *VisibilitySamplingSuite) TestListOpenWorkflowExecutionsByWorkflowID() {
 		ListWorkflowExecutionsRequest: req,
 		WorkflowID:                    testWorkflowExecution.GetWorkflowId(),
 	}
-	s.persistence.On("ListOpenWorkflowExecutionsByWorkflowID", request).Return(nil, nil).Once()
-	_, err := s.client.ListOpenWorkflowExecutionsByWorkflowID(request)
+	s.persistence.On("ListOpenWorkflowExecutionsByWorkflowID", mock.Anything, request).Return(nil, nil).Once()
+	_, err := s.client.ListOpenWorkflowExecutionsByWorkflowID(context.Background(), request)
 	s.NoError(err)
 
 	// no remaining tokens
-	_, err = s.client.ListOpenWorkflowExecutionsByWorkflowID(request)
+	_, err = s.client.ListOpenWorkflowExecutionsByWorkflowID(context.Background(), request)
 	s.Error(err)
 	errDetail, ok := err.(*gen.ServiceBusyError)
 	s.True(ok)
@@ -229,12 +231,12 @@ func (s *VisibilitySamplingSuite) TestListClosedWorkflowExecutionsByWorkflowID()
 		ListWorkflowExecutionsRequest: req,
 		WorkflowID:                    testWorkflowExecution.GetWorkflowId(),
 	}
-	s.persistence.On("ListClosedWorkflowExecutionsByWorkflowID", request).Return(nil, nil).Once()
-	_, err := s.client.ListClosedWorkflowExecutionsByWorkflowID(request)
+	s.persistence.On("ListClosedWorkflowExecutionsByWorkflowID", mock.Anything, request).Return(nil, nil).Once()
+	_, err := s.client.ListClosedWorkflowExecutionsByWorkflowID(context.Background(), request)
 	s.NoError(err)
 
 	// no remaining tokens
-	_, err = s.client.ListClosedWorkflowExecutionsByWorkflowID(request)
+	_, err = s.client.ListClosedWorkflowExecutionsByWorkflowID(context.Background(), request)
 	s.Error(err)
 	errDetail, ok := err.(*gen.ServiceBusyError)
 	s.True(ok)
@@ -250,12 +252,12 @@ func (s *VisibilitySamplingSuite) TestListClosedWorkflowExecutionsByStatus() {
 		ListWorkflowExecutionsRequest: req,
 		Status:                        gen.WorkflowExecutionCloseStatusFailed,
 	}
-	s.persistence.On("ListClosedWorkflowExecutionsByStatus", request).Return(nil, nil).Once()
-	_, err := s.client.ListClosedWorkflowExecutionsByStatus(request)
+	s.persistence.On("ListClosedWorkflowExecutionsByStatus", mock.Anything, request).Return(nil, nil).Once()
+	_, err := s.client.ListClosedWorkflowExecutionsByStatus(context.Background(), request)
 	s.NoError(err)
 
 	// no remaining tokens
-	_, err = s.client.ListClosedWorkflowExecutionsByStatus(request)
+	_, err = s.client.ListClosedWorkflowExecutionsByStatus(context.Background(), request)
 	s.Error(err)
 	errDetail, ok := err.(*gen.ServiceBusyError)
 	s.True(ok)
@@ -0,0 +1,107 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistencetests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber/cadence/common/mocks"
+	p "github.com/uber/cadence/common/persistence"
+)
+
+type VisibilityWrapperSuite struct {
+	*require.Assertions
+	suite.Suite
+	dbManager  *mocks.VisibilityManager
+	esManager  *mocks.VisibilityManager
+	readFromES bool
+	client     p.VisibilityManager
+}
+
+func TestVisibilityWrapperSuite(t *testing.T) {
+	suite.Run(t, new(VisibilityWrapperSuite))
+}
+
+func (s *VisibilityWrapperSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+
+	s.dbManager = &mocks.VisibilityManager{}
+	s.esManager = &mocks.VisibilityManager{}
+	s.readFromES = false
+	s.client = p.NewVisibilityManagerWrapper(s.dbManager, s.esManager, func(domain string) bool {
+		return s.readFromES
+	})
+}
+
+func (s *VisibilityWrapperSuite) TearDownTest() {
+	s.dbManager.AssertExpectations(s.T())
+	s.esManager.AssertExpectations(s.T())
+}
+
+func (s *VisibilityWrapperSuite) TestListFirstPageFollowsConfig() {
+	request := &p.ListWorkflowExecutionsRequest{Domain: testDomain}
+	s.dbManager.On("ListOpenWorkflowExecutions", mock.Anything, request).Return(&p.ListWorkflowExecutionsResponse{}, nil).Once()
+	_, err := s.client.ListOpenWorkflowExecutions(context.Background(), request)
+	s.NoError(err)
+
+	s.readFromES = true
+	s.esManager.On("ListOpenWorkflowExecutions", mock.Anything, request).Return(&p.ListWorkflowExecutionsResponse{}, nil).Once()
+	_, err = s.client.ListOpenWorkflowExecutions(context.Background(), request)
+	s.NoError(err)
+}
+
+func (s *VisibilityWrapperSuite) TestListContinuesOnDBStore() {
+	s.readFromES = true
+	request := &p.ListWorkflowExecutionsByTypeRequest{
+		ListWorkflowExecutionsRequest: p.ListWorkflowExecutionsRequest{
+			Domain:        testDomain,
+			NextPageToken: p.SerializeVisibilityPageToken(p.VisibilityStoreCassandra, []byte("paging state")),
+		},
+		WorkflowTypeName: testWorkflowTypeName,
+	}
+	s.dbManager.On("ListClosedWorkflowExecutionsByType", mock.Anything, request).Return(&p.ListWorkflowExecutionsResponse{}, nil).Once()
+	_, err := s.client.ListClosedWorkflowExecutionsByType(context.Background(), request)
+	s.NoError(err)
+}
+
+func (s *VisibilityWrapperSuite) TestListContinuesOnESStore() {
+	request := &p.ListWorkflowExecutionsRequest{
+		Domain:        testDomain,
+		NextPageToken: p.SerializeVisibilityPageToken(p.VisibilityStoreElasticSearch, []byte("paging state")),
+	}
+	s.esManager.On("ListClosedWorkflowExecutions", mock.Anything, request).Return(&p.ListWorkflowExecutionsResponse{}, nil).Once()
+	_, err := s.client.ListClosedWorkflowExecutions(context.Background(), request)
+	s.NoError(err)
+}
+
+func (s *VisibilityWrapperSuite) TestListMalformedTokenFollowsConfig() {
+	request := &p.ListWorkflowExecutionsRequest{
+		Domain:        testDomain,
+		NextPageToken: []byte("bad input"),
+	}
+	s.dbManager.On("ListOpenWorkflowExecutions", mock.Anything, request).Return(nil, &p.InvalidPersistenceRequestError{}).Once()
+	_, err := s.client.ListOpenWorkflowExecutions(context.Background(), request)
+	s.Error(err)
+}
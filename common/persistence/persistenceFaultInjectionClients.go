@@ -0,0 +1,712 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/service/config"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	// faultInjector decides which persistence calls fail, the failure rates are read from
+	// dynamic config on every call so they can be changed while a chaos test is running
+	faultInjector struct {
+		config *config.FaultInjectionConfig
+		logger bark.Logger
+
+		sync.Mutex
+		rand *rand.Rand
+	}
+
+	shardFaultInjectionPersistenceClient struct {
+		injector    *faultInjector
+		persistence ShardManager
+	}
+
+	workflowExecutionFaultInjectionPersistenceClient struct {
+		injector    *faultInjector
+		persistence ExecutionManager
+	}
+
+	taskFaultInjectionPersistenceClient struct {
+		injector    *faultInjector
+		persistence TaskManager
+	}
+
+	historyFaultInjectionPersistenceClient struct {
+		injector    *faultInjector
+		persistence HistoryManager
+	}
+
+	historyV2FaultInjectionPersistenceClient struct {
+		injector    *faultInjector
+		persistence HistoryV2Manager
+	}
+
+	metadataFaultInjectionPersistenceClient struct {
+		injector    *faultInjector
+		persistence MetadataManager
+	}
+
+	visibilityFaultInjectionPersistenceClient struct {
+		injector    *faultInjector
+		persistence VisibilityManager
+	}
+)
+
+var _ ShardManager = (*shardFaultInjectionPersistenceClient)(nil)
+var _ ExecutionManager = (*workflowExecutionFaultInjectionPersistenceClient)(nil)
+var _ TaskManager = (*taskFaultInjectionPersistenceClient)(nil)
+var _ HistoryManager = (*historyFaultInjectionPersistenceClient)(nil)
+var _ HistoryV2Manager = (*historyV2FaultInjectionPersistenceClient)(nil)
+var _ MetadataManager = (*metadataFaultInjectionPersistenceClient)(nil)
+var _ VisibilityManager = (*visibilityFaultInjectionPersistenceClient)(nil)
+
+// NewShardPersistenceFaultInjectionClient creates a client to manage shards which fails calls on purpose
+func NewShardPersistenceFaultInjectionClient(persistence ShardManager, config *config.FaultInjectionConfig, logger bark.Logger) ShardManager {
+	return &shardFaultInjectionPersistenceClient{
+		persistence: persistence,
+		injector:    newFaultInjector(config, logger),
+	}
+}
+
+// NewWorkflowExecutionPersistenceFaultInjectionClient creates a client to manage executions which fails calls on purpose
+func NewWorkflowExecutionPersistenceFaultInjectionClient(persistence ExecutionManager, config *config.FaultInjectionConfig, logger bark.Logger) ExecutionManager {
+	return &workflowExecutionFaultInjectionPersistenceClient{
+		persistence: persistence,
+		injector:    newFaultInjector(config, logger),
+	}
+}
+
+// NewTaskPersistenceFaultInjectionClient creates a client to manage tasks which fails calls on purpose
+func NewTaskPersistenceFaultInjectionClient(persistence TaskManager, config *config.FaultInjectionConfig, logger bark.Logger) TaskManager {
+	return &taskFaultInjectionPersistenceClient{
+		persistence: persistence,
+		injector:    newFaultInjector(config, logger),
+	}
+}
+
+// NewHistoryPersistenceFaultInjectionClient creates a HistoryManager client which fails calls on purpose
+func NewHistoryPersistenceFaultInjectionClient(persistence HistoryManager, config *config.FaultInjectionConfig, logger bark.Logger) HistoryManager {
+	return &historyFaultInjectionPersistenceClient{
+		persistence: persistence,
+		injector:    newFaultInjector(config, logger),
+	}
+}
+
+// NewHistoryV2PersistenceFaultInjectionClient creates a HistoryV2Manager client which fails calls on purpose
+func NewHistoryV2PersistenceFaultInjectionClient(persistence HistoryV2Manager, config *config.FaultInjectionConfig, logger bark.Logger) HistoryV2Manager {
+	return &historyV2FaultInjectionPersistenceClient{
+		persistence: persistence,
+		injector:    newFaultInjector(config, logger),
+	}
+}
+
+// NewMetadataPersistenceFaultInjectionClient creates a MetadataManager client which fails calls on purpose
+func NewMetadataPersistenceFaultInjectionClient(persistence MetadataManager, config *config.FaultInjectionConfig, logger bark.Logger) MetadataManager {
+	return &metadataFaultInjectionPersistenceClient{
+		persistence: persistence,
+		injector:    newFaultInjector(config, logger),
+	}
+}
+
+// NewVisibilityPersistenceFaultInjectionClient creates a VisibilityManager client which fails calls on purpose
+func NewVisibilityPersistenceFaultInjectionClient(persistence VisibilityManager, config *config.FaultInjectionConfig, logger bark.Logger) VisibilityManager {
+	return &visibilityFaultInjectionPersistenceClient{
+		persistence: persistence,
+		injector:    newFaultInjector(config, logger),
+	}
+}
+
+func newFaultInjector(config *config.FaultInjectionConfig, logger bark.Logger) *faultInjector {
+	return &faultInjector{
+		config: config,
+		logger: logger,
+		rand:   rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// beforeCall returns the error the operation fails with without reaching the datastore, if any.
+// Condition failures are only injected for writes
+func (f *faultInjector) beforeCall(operation string, isWrite bool) error {
+	filter := dynamicconfig.PersistenceOperationFilter(operation)
+	if !f.config.Enabled(filter) {
+		return nil
+	}
+	switch {
+	case f.roll(f.config.TimeoutRate(filter)):
+		return f.inject(operation, &TimeoutError{
+			Msg: fmt.Sprintf("%v operation failed. Injected timeout.", operation),
+		})
+	case f.roll(f.config.ThrottleRate(filter)):
+		return f.inject(operation, ErrPersistenceLimitExceeded)
+	case isWrite && f.roll(f.config.ConditionFailedRate(filter)):
+		return f.inject(operation, &ConditionFailedError{
+			Msg: fmt.Sprintf("%v operation failed. Injected condition failure.", operation),
+		})
+	}
+	return nil
+}
+
+// afterWrite fails a write which was applied by the datastore with a timeout, the same way a
+// batch which was only partially acknowledged does, so callers have to cope with not knowing
+// the outcome of the write
+func (f *faultInjector) afterWrite(operation string, err error) error {
+	if err != nil {
+		return err
+	}
+	filter := dynamicconfig.PersistenceOperationFilter(operation)
+	if !f.config.Enabled(filter) || !f.roll(f.config.PartialFailureRate(filter)) {
+		return nil
+	}
+	return f.inject(operation, &TimeoutError{
+		Msg: fmt.Sprintf("%v operation failed. Injected timeout after the write was applied.", operation),
+	})
+}
+
+func (f *faultInjector) roll(rate float64) bool {
+	if rate <= 0 {
+		return false
+	}
+	f.Lock()
+	defer f.Unlock()
+	return f.rand.Float64() < rate
+}
+
+func (f *faultInjector) inject(operation string, err error) error {
+	f.logger.WithFields(bark.Fields{
+		logging.TagStoreOperation: operation,
+		logging.TagErr:            err,
+	}).Debug("Injected persistence fault")
+	return err
+}
+
+func (p *shardFaultInjectionPersistenceClient) GetName() string {
+	return p.persistence.GetName()
+}
+
+func (p *shardFaultInjectionPersistenceClient) CreateShard(request *CreateShardRequest) error {
+	if err := p.injector.beforeCall("CreateShard", true); err != nil {
+		return err
+	}
+	err := p.persistence.CreateShard(request)
+	return p.injector.afterWrite("CreateShard", err)
+}
+
+func (p *shardFaultInjectionPersistenceClient) GetShard(request *GetShardRequest) (*GetShardResponse, error) {
+	if err := p.injector.beforeCall("GetShard", false); err != nil {
+		return nil, err
+	}
+	return p.persistence.GetShard(request)
+}
+
+func (p *shardFaultInjectionPersistenceClient) UpdateShard(request *UpdateShardRequest) error {
+	if err := p.injector.beforeCall("UpdateShard", true); err != nil {
+		return err
+	}
+	err := p.persistence.UpdateShard(request)
+	return p.injector.afterWrite("UpdateShard", err)
+}
+
+func (p *shardFaultInjectionPersistenceClient) Close() {
+	p.persistence.Close()
+}
+
+func (p *workflowExecutionFaultInjectionPersistenceClient) GetName() string {
+	return p.persistence.GetName()
+}
+
+func (p *workflowExecutionFaultInjectionPersistenceClient) GetShardID() int {
+	return p.persistence.GetShardID()
+}
+
+func (p *workflowExecutionFaultInjectionPersistenceClient) CreateWorkflowExecution(ctx context.Context, request *CreateWorkflowExecutionRequest) (*CreateWorkflowExecutionResponse, error) {
+	if err := p.injector.beforeCall("CreateWorkflowExecution", true); err != nil {
+		return nil, err
+	}
+	response, err := p.persistence.CreateWorkflowExecution(ctx, request)
+	return response, p.injector.afterWrite("CreateWorkflowExecution", err)
+}
+
+func (p *workflowExecutionFaultInjectionPersistenceClient) GetWorkflowExecution(ctx context.Context, request *GetWorkflowExecutionRequest) (*GetWorkflowExecutionResponse, error) {
+	if err := p.injector.beforeCall("GetWorkflowExecution", false); err != nil {
+		return nil, err
+	}
+	return p.persistence.GetWorkflowExecution(ctx, request)
+}
+
+func (p *workflowExecutionFaultInjectionPersistenceClient) GetWorkflowExecutions(ctx context.Context, request *GetWorkflowExecutionsRequest) (*GetWorkflowExecutionsResponse, error) {
+	if err := p.injector.beforeCall("GetWorkflowExecutions", false); err != nil {
+		return nil, err
+	}
+	return p.persistence.GetWorkflowExecutions(ctx, request)
+}
+
+func (p *workflowExecutionFaultInjectionPersistenceClient) UpdateWorkflowExecution(ctx context.Context, request *UpdateWorkflowExecutionRequest) (*UpdateWorkflowExecutionResponse, error) {
+	if err := p.injector.beforeCall("UpdateWorkflowExecution", true); err != nil {
+		return nil, err
+	}
+	response, err := p.persistence.UpdateWorkflowExecution(ctx, request)
+	return response, p.injector.afterWrite("UpdateWorkflowExecution", err)
+}
+
+func (p *workflowExecutionFaultInjectionPersistenceClient) ResetMutableState(ctx context.Context, request *ResetMutableStateRequest) error {
+	if err := p.injector.beforeCall("ResetMutableState", true); err != nil {
+		return err
+	}
+	err := p.persistence.ResetMutableState(ctx, request)
+	return p.injector.afterWrite("ResetMutableState", err)
+}
+
+func (p *workflowExecutionFaultInjectionPersistenceClient) ResetWorkflowExecution(ctx context.Context, request *ResetWorkflowExecutionRequest) error {
+	if err := p.injector.beforeCall("ResetWorkflowExecution", true); err != nil {
+		return err
+	}
+	err := p.persistence.ResetWorkflowExecution(ctx, request)
+	return p.injector.afterWrite("ResetWorkflowExecution", err)
+}
+
+// CompleteForkBranch complete forking process
+func (p *historyV2FaultInjectionPersistenceClient) CompleteForkBranch(request *CompleteForkBranchRequest) error {
+	if err := p.injector.beforeCall("CompleteForkBranch", true); err != nil {
+		return err
+	}
+	err := p.persistence.CompleteForkBranch(request)
+	return p.injector.afterWrite("CompleteForkBranch", err)
+}
+
+func (p *workflowExecutionFaultInjectionPersistenceClient) DeleteWorkflowExecution(ctx context.Context, request *DeleteWorkflowExecutionRequest) error {
+	if err := p.injector.beforeCall("DeleteWorkflowExecution", true); err != nil {
+		return err
+	}
+	err := p.persistence.DeleteWorkflowExecution(ctx, request)
+	return p.injector.afterWrite("DeleteWorkflowExecution", err)
+}
+
+func (p *workflowExecutionFaultInjectionPersistenceClient) GetCurrentExecution(ctx context.Context, request *GetCurrentExecutionRequest) (*GetCurrentExecutionResponse, error) {
+	if err := p.injector.beforeCall("GetCurrentExecution", false); err != nil {
+		return nil, err
+	}
+	return p.persistence.GetCurrentExecution(ctx, request)
+}
+
+func (p *workflowExecutionFaultInjectionPersistenceClient) GetTransferTasks(ctx context.Context, request *GetTransferTasksRequest) (*GetTransferTasksResponse, error) {
+	if err := p.injector.beforeCall("GetTransferTasks", false); err != nil {
+		return nil, err
+	}
+	return p.persistence.GetTransferTasks(ctx, request)
+}
+
+func (p *workflowExecutionFaultInjectionPersistenceClient) GetReplicationTasks(ctx context.Context, request *GetReplicationTasksRequest) (*GetReplicationTasksResponse, error) {
+	if err := p.injector.beforeCall("GetReplicationTasks", false); err != nil {
+		return nil, err
+	}
+	return p.persistence.GetReplicationTasks(ctx, request)
+}
+
+func (p *workflowExecutionFaultInjectionPersistenceClient) CompleteTransferTask(ctx context.Context, request *CompleteTransferTaskRequest) error {
+	if err := p.injector.beforeCall("CompleteTransferTask", true); err != nil {
+		return err
+	}
+	err := p.persistence.CompleteTransferTask(ctx, request)
+	return p.injector.afterWrite("CompleteTransferTask", err)
+}
+
+func (p *workflowExecutionFaultInjectionPersistenceClient) RangeCompleteTransferTask(ctx context.Context, request *RangeCompleteTransferTaskRequest) error {
+	if err := p.injector.beforeCall("RangeCompleteTransferTask", true); err != nil {
+		return err
+	}
+	err := p.persistence.RangeCompleteTransferTask(ctx, request)
+	return p.injector.afterWrite("RangeCompleteTransferTask", err)
+}
+
+func (p *workflowExecutionFaultInjectionPersistenceClient) CompleteReplicationTask(ctx context.Context, request *CompleteReplicationTaskRequest) error {
+	if err := p.injector.beforeCall("CompleteReplicationTask", true); err != nil {
+		return err
+	}
+	err := p.persistence.CompleteReplicationTask(ctx, request)
+	return p.injector.afterWrite("CompleteReplicationTask", err)
+}
+
+func (p *workflowExecutionFaultInjectionPersistenceClient) GetTimerIndexTasks(ctx context.Context, request *GetTimerIndexTasksRequest) (*GetTimerIndexTasksResponse, error) {
+	if err := p.injector.beforeCall("GetTimerIndexTasks", false); err != nil {
+		return nil, err
+	}
+	return p.persistence.GetTimerIndexTasks(ctx, request)
+}
+
+func (p *workflowExecutionFaultInjectionPersistenceClient) CompleteTimerTask(ctx context.Context, request *CompleteTimerTaskRequest) error {
+	if err := p.injector.beforeCall("CompleteTimerTask", true); err != nil {
+		return err
+	}
+	err := p.persistence.CompleteTimerTask(ctx, request)
+	return p.injector.afterWrite("CompleteTimerTask", err)
+}
+
+func (p *workflowExecutionFaultInjectionPersistenceClient) RangeCompleteTimerTask(ctx context.Context, request *RangeCompleteTimerTaskRequest) error {
+	if err := p.injector.beforeCall("RangeCompleteTimerTask", true); err != nil {
+		return err
+	}
+	err := p.persistence.RangeCompleteTimerTask(ctx, request)
+	return p.injector.afterWrite("RangeCompleteTimerTask", err)
+}
+
+func (p *workflowExecutionFaultInjectionPersistenceClient) Close() {
+	p.persistence.Close()
+}
+
+func (p *taskFaultInjectionPersistenceClient) GetName() string {
+	return p.persistence.GetName()
+}
+
+func (p *taskFaultInjectionPersistenceClient) CreateTasks(request *CreateTasksRequest) (*CreateTasksResponse, error) {
+	if err := p.injector.beforeCall("CreateTasks", true); err != nil {
+		return nil, err
+	}
+	response, err := p.persistence.CreateTasks(request)
+	return response, p.injector.afterWrite("CreateTasks", err)
+}
+
+func (p *taskFaultInjectionPersistenceClient) GetTasks(request *GetTasksRequest) (*GetTasksResponse, error) {
+	if err := p.injector.beforeCall("GetTasks", false); err != nil {
+		return nil, err
+	}
+	return p.persistence.GetTasks(request)
+}
+
+func (p *taskFaultInjectionPersistenceClient) CompleteTask(request *CompleteTaskRequest) error {
+	if err := p.injector.beforeCall("CompleteTask", true); err != nil {
+		return err
+	}
+	err := p.persistence.CompleteTask(request)
+	return p.injector.afterWrite("CompleteTask", err)
+}
+
+func (p *taskFaultInjectionPersistenceClient) CompleteTasksLessThan(request *CompleteTasksLessThanRequest) (int, error) {
+	if err := p.injector.beforeCall("CompleteTasksLessThan", true); err != nil {
+		return 0, err
+	}
+	response, err := p.persistence.CompleteTasksLessThan(request)
+	return response, p.injector.afterWrite("CompleteTasksLessThan", err)
+}
+
+func (p *taskFaultInjectionPersistenceClient) LeaseTaskList(request *LeaseTaskListRequest) (*LeaseTaskListResponse, error) {
+	if err := p.injector.beforeCall("LeaseTaskList", true); err != nil {
+		return nil, err
+	}
+	response, err := p.persistence.LeaseTaskList(request)
+	return response, p.injector.afterWrite("LeaseTaskList", err)
+}
+
+func (p *taskFaultInjectionPersistenceClient) UpdateTaskList(request *UpdateTaskListRequest) (*UpdateTaskListResponse, error) {
+	if err := p.injector.beforeCall("UpdateTaskList", true); err != nil {
+		return nil, err
+	}
+	response, err := p.persistence.UpdateTaskList(request)
+	return response, p.injector.afterWrite("UpdateTaskList", err)
+}
+
+func (p *taskFaultInjectionPersistenceClient) ListTaskList(request *ListTaskListRequest) (*ListTaskListResponse, error) {
+	if err := p.injector.beforeCall("ListTaskList", false); err != nil {
+		return nil, err
+	}
+	return p.persistence.ListTaskList(request)
+}
+
+func (p *taskFaultInjectionPersistenceClient) DeleteTaskList(request *DeleteTaskListRequest) error {
+	if err := p.injector.beforeCall("DeleteTaskList", true); err != nil {
+		return err
+	}
+	err := p.persistence.DeleteTaskList(request)
+	return p.injector.afterWrite("DeleteTaskList", err)
+}
+
+func (p *taskFaultInjectionPersistenceClient) Close() {
+	p.persistence.Close()
+}
+
+func (p *historyFaultInjectionPersistenceClient) GetName() string {
+	return p.persistence.GetName()
+}
+
+func (p *historyFaultInjectionPersistenceClient) AppendHistoryEvents(request *AppendHistoryEventsRequest) (*AppendHistoryEventsResponse, error) {
+	if err := p.injector.beforeCall("AppendHistoryEvents", true); err != nil {
+		return nil, err
+	}
+	response, err := p.persistence.AppendHistoryEvents(request)
+	return response, p.injector.afterWrite("AppendHistoryEvents", err)
+}
+
+func (p *historyFaultInjectionPersistenceClient) GetWorkflowExecutionHistory(request *GetWorkflowExecutionHistoryRequest) (*GetWorkflowExecutionHistoryResponse, error) {
+	if err := p.injector.beforeCall("GetWorkflowExecutionHistory", false); err != nil {
+		return nil, err
+	}
+	return p.persistence.GetWorkflowExecutionHistory(request)
+}
+
+func (p *historyFaultInjectionPersistenceClient) GetWorkflowExecutionHistoryByBatch(request *GetWorkflowExecutionHistoryRequest) (*GetWorkflowExecutionHistoryByBatchResponse, error) {
+	if err := p.injector.beforeCall("GetWorkflowExecutionHistoryByBatch", false); err != nil {
+		return nil, err
+	}
+	return p.persistence.GetWorkflowExecutionHistoryByBatch(request)
+}
+
+func (p *historyFaultInjectionPersistenceClient) DeleteWorkflowExecutionHistory(request *DeleteWorkflowExecutionHistoryRequest) error {
+	if err := p.injector.beforeCall("DeleteWorkflowExecutionHistory", true); err != nil {
+		return err
+	}
+	err := p.persistence.DeleteWorkflowExecutionHistory(request)
+	return p.injector.afterWrite("DeleteWorkflowExecutionHistory", err)
+}
+
+func (p *historyFaultInjectionPersistenceClient) Close() {
+	p.persistence.Close()
+}
+
+func (p *metadataFaultInjectionPersistenceClient) GetName() string {
+	return p.persistence.GetName()
+}
+
+func (p *metadataFaultInjectionPersistenceClient) CreateDomain(ctx context.Context, request *CreateDomainRequest) (*CreateDomainResponse, error) {
+	if err := p.injector.beforeCall("CreateDomain", true); err != nil {
+		return nil, err
+	}
+	response, err := p.persistence.CreateDomain(ctx, request)
+	return response, p.injector.afterWrite("CreateDomain", err)
+}
+
+func (p *metadataFaultInjectionPersistenceClient) GetDomain(ctx context.Context, request *GetDomainRequest) (*GetDomainResponse, error) {
+	if err := p.injector.beforeCall("GetDomain", false); err != nil {
+		return nil, err
+	}
+	return p.persistence.GetDomain(ctx, request)
+}
+
+func (p *metadataFaultInjectionPersistenceClient) UpdateDomain(ctx context.Context, request *UpdateDomainRequest) error {
+	if err := p.injector.beforeCall("UpdateDomain", true); err != nil {
+		return err
+	}
+	err := p.persistence.UpdateDomain(ctx, request)
+	return p.injector.afterWrite("UpdateDomain", err)
+}
+
+func (p *metadataFaultInjectionPersistenceClient) DeleteDomain(ctx context.Context, request *DeleteDomainRequest) error {
+	if err := p.injector.beforeCall("DeleteDomain", true); err != nil {
+		return err
+	}
+	err := p.persistence.DeleteDomain(ctx, request)
+	return p.injector.afterWrite("DeleteDomain", err)
+}
+
+func (p *metadataFaultInjectionPersistenceClient) DeleteDomainByName(ctx context.Context, request *DeleteDomainByNameRequest) error {
+	if err := p.injector.beforeCall("DeleteDomainByName", true); err != nil {
+		return err
+	}
+	err := p.persistence.DeleteDomainByName(ctx, request)
+	return p.injector.afterWrite("DeleteDomainByName", err)
+}
+
+func (p *metadataFaultInjectionPersistenceClient) ListDomains(ctx context.Context, request *ListDomainsRequest) (*ListDomainsResponse, error) {
+	if err := p.injector.beforeCall("ListDomains", false); err != nil {
+		return nil, err
+	}
+	return p.persistence.ListDomains(ctx, request)
+}
+
+func (p *metadataFaultInjectionPersistenceClient) GetMetadata(ctx context.Context) (*GetMetadataResponse, error) {
+	if err := p.injector.beforeCall("GetMetadata", false); err != nil {
+		return nil, err
+	}
+	return p.persistence.GetMetadata(ctx)
+}
+
+func (p *metadataFaultInjectionPersistenceClient) Close() {
+	p.persistence.Close()
+}
+
+func (p *visibilityFaultInjectionPersistenceClient) GetName() string {
+	return p.persistence.GetName()
+}
+
+func (p *visibilityFaultInjectionPersistenceClient) RecordWorkflowExecutionStarted(ctx context.Context, request *RecordWorkflowExecutionStartedRequest) error {
+	if err := p.injector.beforeCall("RecordWorkflowExecutionStarted", true); err != nil {
+		return err
+	}
+	err := p.persistence.RecordWorkflowExecutionStarted(ctx, request)
+	return p.injector.afterWrite("RecordWorkflowExecutionStarted", err)
+}
+
+func (p *visibilityFaultInjectionPersistenceClient) RecordWorkflowExecutionClosed(ctx context.Context, request *RecordWorkflowExecutionClosedRequest) error {
+	if err := p.injector.beforeCall("RecordWorkflowExecutionClosed", true); err != nil {
+		return err
+	}
+	err := p.persistence.RecordWorkflowExecutionClosed(ctx, request)
+	return p.injector.afterWrite("RecordWorkflowExecutionClosed", err)
+}
+
+func (p *visibilityFaultInjectionPersistenceClient) ListOpenWorkflowExecutions(ctx context.Context, request *ListWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error) {
+	if err := p.injector.beforeCall("ListOpenWorkflowExecutions", false); err != nil {
+		return nil, err
+	}
+	return p.persistence.ListOpenWorkflowExecutions(ctx, request)
+}
+
+func (p *visibilityFaultInjectionPersistenceClient) ListClosedWorkflowExecutions(ctx context.Context, request *ListWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error) {
+	if err := p.injector.beforeCall("ListClosedWorkflowExecutions", false); err != nil {
+		return nil, err
+	}
+	return p.persistence.ListClosedWorkflowExecutions(ctx, request)
+}
+
+func (p *visibilityFaultInjectionPersistenceClient) ListOpenWorkflowExecutionsByType(ctx context.Context, request *ListWorkflowExecutionsByTypeRequest) (*ListWorkflowExecutionsResponse, error) {
+	if err := p.injector.beforeCall("ListOpenWorkflowExecutionsByType", false); err != nil {
+		return nil, err
+	}
+	return p.persistence.ListOpenWorkflowExecutionsByType(ctx, request)
+}
+
+func (p *visibilityFaultInjectionPersistenceClient) ListClosedWorkflowExecutionsByType(ctx context.Context, request *ListWorkflowExecutionsByTypeRequest) (*ListWorkflowExecutionsResponse, error) {
+	if err := p.injector.beforeCall("ListClosedWorkflowExecutionsByType", false); err != nil {
+		return nil, err
+	}
+	return p.persistence.ListClosedWorkflowExecutionsByType(ctx, request)
+}
+
+func (p *visibilityFaultInjectionPersistenceClient) ListOpenWorkflowExecutionsByWorkflowID(ctx context.Context, request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error) {
+	if err := p.injector.beforeCall("ListOpenWorkflowExecutionsByWorkflowID", false); err != nil {
+		return nil, err
+	}
+	return p.persistence.ListOpenWorkflowExecutionsByWorkflowID(ctx, request)
+}
+
+func (p *visibilityFaultInjectionPersistenceClient) ListClosedWorkflowExecutionsByWorkflowID(ctx context.Context, request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error) {
+	if err := p.injector.beforeCall("ListClosedWorkflowExecutionsByWorkflowID", false); err != nil {
+		return nil, err
+	}
+	return p.persistence.ListClosedWorkflowExecutionsByWorkflowID(ctx, request)
+}
+
+func (p *visibilityFaultInjectionPersistenceClient) ListClosedWorkflowExecutionsByStatus(ctx context.Context, request *ListClosedWorkflowExecutionsByStatusRequest) (*ListWorkflowExecutionsResponse, error) {
+	if err := p.injector.beforeCall("ListClosedWorkflowExecutionsByStatus", false); err != nil {
+		return nil, err
+	}
+	return p.persistence.ListClosedWorkflowExecutionsByStatus(ctx, request)
+}
+
+func (p *visibilityFaultInjectionPersistenceClient) GetClosedWorkflowExecution(ctx context.Context, request *GetClosedWorkflowExecutionRequest) (*GetClosedWorkflowExecutionResponse, error) {
+	if err := p.injector.beforeCall("GetClosedWorkflowExecution", false); err != nil {
+		return nil, err
+	}
+	return p.persistence.GetClosedWorkflowExecution(ctx, request)
+}
+
+func (p *visibilityFaultInjectionPersistenceClient) DeleteWorkflowExecution(ctx context.Context, request *VisibilityDeleteWorkflowExecutionRequest) error {
+	if err := p.injector.beforeCall("VisibilityDeleteWorkflowExecution", true); err != nil {
+		return err
+	}
+	err := p.persistence.DeleteWorkflowExecution(ctx, request)
+	return p.injector.afterWrite("VisibilityDeleteWorkflowExecution", err)
+}
+
+func (p *visibilityFaultInjectionPersistenceClient) GetWorkflowExecutionStatistics(ctx context.Context, request *GetWorkflowExecutionStatisticsRequest) (*GetWorkflowExecutionStatisticsResponse, error) {
+	if err := p.injector.beforeCall("GetWorkflowExecutionStatistics", false); err != nil {
+		return nil, err
+	}
+	return p.persistence.GetWorkflowExecutionStatistics(ctx, request)
+}
+
+func (p *visibilityFaultInjectionPersistenceClient) CountOpenWorkflowExecutions(ctx context.Context, request *CountWorkflowExecutionsRequest) (*CountWorkflowExecutionsResponse, error) {
+	if err := p.injector.beforeCall("CountOpenWorkflowExecutions", false); err != nil {
+		return nil, err
+	}
+	return p.persistence.CountOpenWorkflowExecutions(ctx, request)
+}
+
+func (p *visibilityFaultInjectionPersistenceClient) Close() {
+	p.persistence.Close()
+}
+
+func (p *historyV2FaultInjectionPersistenceClient) GetName() string {
+	return p.persistence.GetName()
+}
+
+func (p *historyV2FaultInjectionPersistenceClient) Close() {
+	p.persistence.Close()
+}
+
+// AppendHistoryNodes add(or override) a node to a history branch
+func (p *historyV2FaultInjectionPersistenceClient) AppendHistoryNodes(request *AppendHistoryNodesRequest) (*AppendHistoryNodesResponse, error) {
+	if err := p.injector.beforeCall("AppendHistoryNodes", true); err != nil {
+		return nil, err
+	}
+	response, err := p.persistence.AppendHistoryNodes(request)
+	return response, p.injector.afterWrite("AppendHistoryNodes", err)
+}
+
+// ReadHistoryBranch returns history node data for a branch
+func (p *historyV2FaultInjectionPersistenceClient) ReadHistoryBranch(request *ReadHistoryBranchRequest) (*ReadHistoryBranchResponse, error) {
+	if err := p.injector.beforeCall("ReadHistoryBranch", false); err != nil {
+		return nil, err
+	}
+	return p.persistence.ReadHistoryBranch(request)
+}
+
+// ReadHistoryBranchByBatch returns history node data for a branch
+func (p *historyV2FaultInjectionPersistenceClient) ReadHistoryBranchByBatch(request *ReadHistoryBranchRequest) (*ReadHistoryBranchByBatchResponse, error) {
+	if err := p.injector.beforeCall("ReadHistoryBranchByBatch", false); err != nil {
+		return nil, err
+	}
+	return p.persistence.ReadHistoryBranchByBatch(request)
+}
+
+// ForkHistoryBranch forks a new branch from a old branch
+func (p *historyV2FaultInjectionPersistenceClient) ForkHistoryBranch(request *ForkHistoryBranchRequest) (*ForkHistoryBranchResponse, error) {
+	if err := p.injector.beforeCall("ForkHistoryBranch", true); err != nil {
+		return nil, err
+	}
+	response, err := p.persistence.ForkHistoryBranch(request)
+	return response, p.injector.afterWrite("ForkHistoryBranch", err)
+}
+
+// DeleteHistoryBranch removes a branch
+func (p *historyV2FaultInjectionPersistenceClient) DeleteHistoryBranch(request *DeleteHistoryBranchRequest) error {
+	if err := p.injector.beforeCall("DeleteHistoryBranch", true); err != nil {
+		return err
+	}
+	err := p.persistence.DeleteHistoryBranch(request)
+	return p.injector.afterWrite("DeleteHistoryBranch", err)
+}
+
+// GetHistoryTree returns all branch information of a tree
+func (p *historyV2FaultInjectionPersistenceClient) GetHistoryTree(request *GetHistoryTreeRequest) (*GetHistoryTreeResponse, error) {
+	if err := p.injector.beforeCall("GetHistoryTree", false); err != nil {
+		return nil, err
+	}
+	return p.persistence.GetHistoryTree(request)
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/common/service/config"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	faultInjectorSuite struct {
+		suite.Suite
+		// override suite.Suite.Assertions with require.Assertions; this means that s.NotNil(nil) will stop the test,
+		// not merely log an error
+		*require.Assertions
+		config *config.FaultInjectionConfig
+	}
+)
+
+func TestFaultInjectorSuite(t *testing.T) {
+	s := new(faultInjectorSuite)
+	suite.Run(t, s)
+}
+
+func (s *faultInjectorSuite) SetupTest() {
+	// Have to define our overridden assertions in the test setup. If we did it earlier, s.T() will return nil
+	s.Assertions = require.New(s.T())
+	s.config = &config.FaultInjectionConfig{
+		Enabled:             dynamicconfig.GetBoolPropertyFn(true),
+		TimeoutRate:         dynamicconfig.GetFloatPropertyFn(0),
+		ThrottleRate:        dynamicconfig.GetFloatPropertyFn(0),
+		ConditionFailedRate: dynamicconfig.GetFloatPropertyFn(0),
+		PartialFailureRate:  dynamicconfig.GetFloatPropertyFn(0),
+	}
+}
+
+func (s *faultInjectorSuite) newInjector() *faultInjector {
+	return newFaultInjector(s.config, bark.NewNopLogger())
+}
+
+func (s *faultInjectorSuite) TestDisabled() {
+	s.config.Enabled = dynamicconfig.GetBoolPropertyFn(false)
+	s.config.TimeoutRate = dynamicconfig.GetFloatPropertyFn(1)
+	s.config.PartialFailureRate = dynamicconfig.GetFloatPropertyFn(1)
+	injector := s.newInjector()
+
+	s.NoError(injector.beforeCall("UpdateWorkflowExecution", true))
+	s.NoError(injector.afterWrite("UpdateWorkflowExecution", nil))
+}
+
+func (s *faultInjectorSuite) TestNoFaults() {
+	injector := s.newInjector()
+	for i := 0; i < 100; i++ {
+		s.NoError(injector.beforeCall("UpdateWorkflowExecution", true))
+		s.NoError(injector.afterWrite("UpdateWorkflowExecution", nil))
+	}
+}
+
+func (s *faultInjectorSuite) TestTimeout() {
+	s.config.TimeoutRate = dynamicconfig.GetFloatPropertyFn(1)
+	injector := s.newInjector()
+
+	err := injector.beforeCall("GetWorkflowExecution", false)
+	s.IsType(&TimeoutError{}, err)
+}
+
+func (s *faultInjectorSuite) TestThrottle() {
+	s.config.ThrottleRate = dynamicconfig.GetFloatPropertyFn(1)
+	injector := s.newInjector()
+
+	s.Equal(ErrPersistenceLimitExceeded, injector.beforeCall("GetWorkflowExecution", false))
+}
+
+func (s *faultInjectorSuite) TestConditionFailedOnlyForWrites() {
+	s.config.ConditionFailedRate = dynamicconfig.GetFloatPropertyFn(1)
+	injector := s.newInjector()
+
+	s.NoError(injector.beforeCall("GetWorkflowExecution", false))
+	err := injector.beforeCall("UpdateWorkflowExecution", true)
+	s.IsType(&ConditionFailedError{}, err)
+}
+
+func (s *faultInjectorSuite) TestPartialFailure() {
+	s.config.PartialFailureRate = dynamicconfig.GetFloatPropertyFn(1)
+	injector := s.newInjector()
+
+	err := injector.afterWrite("AppendHistoryEvents", nil)
+	s.IsType(&TimeoutError{}, err)
+
+	// errors from the datastore are returned unchanged
+	storeErr := errors.New("store error")
+	s.Equal(storeErr, injector.afterWrite("AppendHistoryEvents", storeErr))
+}
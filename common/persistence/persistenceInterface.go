@@ -21,11 +21,13 @@
 package persistence
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/checksum"
 )
 
 type (
@@ -42,6 +44,8 @@ type (
 	TaskStore = TaskManager
 	// MetadataStore is a lower level of MetadataManager
 	MetadataStore = MetadataManager
+	// ClusterMetadataStore is a lower level of ClusterMetadataManager
+	ClusterMetadataStore = ClusterMetadataManager
 	// VisibilityStore is the store interface for visibility
 	VisibilityStore = VisibilityManager
 
@@ -51,28 +55,29 @@ type (
 		GetName() string
 		GetShardID() int
 		//The below three APIs are related to serialization/deserialization
-		GetWorkflowExecution(request *GetWorkflowExecutionRequest) (*InternalGetWorkflowExecutionResponse, error)
-		UpdateWorkflowExecution(request *InternalUpdateWorkflowExecutionRequest) error
-		ResetMutableState(request *InternalResetMutableStateRequest) error
-		ResetWorkflowExecution(request *InternalResetWorkflowExecutionRequest) error
+		GetWorkflowExecution(ctx context.Context, request *GetWorkflowExecutionRequest) (*InternalGetWorkflowExecutionResponse, error)
+		GetWorkflowExecutions(ctx context.Context, request *GetWorkflowExecutionsRequest) (*InternalGetWorkflowExecutionsResponse, error)
+		UpdateWorkflowExecution(ctx context.Context, request *InternalUpdateWorkflowExecutionRequest) error
+		ResetMutableState(ctx context.Context, request *InternalResetMutableStateRequest) error
+		ResetWorkflowExecution(ctx context.Context, request *InternalResetWorkflowExecutionRequest) error
 
-		CreateWorkflowExecution(request *CreateWorkflowExecutionRequest) (*CreateWorkflowExecutionResponse, error)
-		DeleteWorkflowExecution(request *DeleteWorkflowExecutionRequest) error
-		GetCurrentExecution(request *GetCurrentExecutionRequest) (*GetCurrentExecutionResponse, error)
+		CreateWorkflowExecution(ctx context.Context, request *InternalCreateWorkflowExecutionRequest) (*CreateWorkflowExecutionResponse, error)
+		DeleteWorkflowExecution(ctx context.Context, request *DeleteWorkflowExecutionRequest) error
+		GetCurrentExecution(ctx context.Context, request *GetCurrentExecutionRequest) (*GetCurrentExecutionResponse, error)
 
 		// Transfer task related methods
-		GetTransferTasks(request *GetTransferTasksRequest) (*GetTransferTasksResponse, error)
-		CompleteTransferTask(request *CompleteTransferTaskRequest) error
-		RangeCompleteTransferTask(request *RangeCompleteTransferTaskRequest) error
+		GetTransferTasks(ctx context.Context, request *GetTransferTasksRequest) (*GetTransferTasksResponse, error)
+		CompleteTransferTask(ctx context.Context, request *CompleteTransferTaskRequest) error
+		RangeCompleteTransferTask(ctx context.Context, request *RangeCompleteTransferTaskRequest) error
 
 		// Replication task related methods
-		GetReplicationTasks(request *GetReplicationTasksRequest) (*GetReplicationTasksResponse, error)
-		CompleteReplicationTask(request *CompleteReplicationTaskRequest) error
+		GetReplicationTasks(ctx context.Context, request *GetReplicationTasksRequest) (*GetReplicationTasksResponse, error)
+		CompleteReplicationTask(ctx context.Context, request *CompleteReplicationTaskRequest) error
 
 		// Timer related methods.
-		GetTimerIndexTasks(request *GetTimerIndexTasksRequest) (*GetTimerIndexTasksResponse, error)
-		CompleteTimerTask(request *CompleteTimerTaskRequest) error
-		RangeCompleteTimerTask(request *RangeCompleteTimerTaskRequest) error
+		GetTimerIndexTasks(ctx context.Context, request *GetTimerIndexTasksRequest) (*GetTimerIndexTasksResponse, error)
+		CompleteTimerTask(ctx context.Context, request *CompleteTimerTaskRequest) error
+		RangeCompleteTimerTask(ctx context.Context, request *RangeCompleteTimerTaskRequest) error
 	}
 
 	// HistoryStore is used to manage Workflow Execution HistoryEventBatch for Persistence layer
@@ -175,6 +180,8 @@ type (
 		BranchToken       []byte
 		CronSchedule      string
 		ExpirationSeconds int32
+		Labels            map[string]string
+		VersionHistories  *DataBlob
 	}
 
 	// InternalWorkflowMutableState indicates workflow related state for Persistence Interface
@@ -189,6 +196,7 @@ type (
 		ReplicationState         *ReplicationState
 		BufferedEvents           []*DataBlob
 		BufferedReplicationTasks map[int64]*InternalBufferedReplicationTask
+		Checksum                 checksum.Checksum
 	}
 
 	// InternalActivityInfo details  for Persistence Interface
@@ -224,6 +232,8 @@ type (
 		ExpirationTime     time.Time
 		MaximumAttempts    int32
 		NonRetriableErrors []string
+		// LastFailureReason is the failure reason of the last attempt which was retried
+		LastFailureReason string
 		// Not written to database - This is used only for deduping heartbeat timer creation
 		LastHeartbeatTimeoutVisibility int64
 	}
@@ -254,6 +264,53 @@ type (
 		NewRunEventStoreVersion int32
 	}
 
+	// InternalCreateWorkflowExecutionRequest is used to write a new workflow execution for Persistence Interface
+	InternalCreateWorkflowExecutionRequest struct {
+		RequestID                   string
+		DomainID                    string
+		Execution                   workflow.WorkflowExecution
+		ParentDomainID              string
+		ParentExecution             *workflow.WorkflowExecution
+		InitiatedID                 int64
+		TaskList                    string
+		WorkflowTypeName            string
+		WorkflowTimeout             int32
+		DecisionTimeoutValue        int32
+		ExecutionContext            []byte
+		LastEventTaskID             int64
+		NextEventID                 int64
+		LastProcessedEvent          int64
+		SignalCount                 int32
+		HistorySize                 int64
+		TransferTasks               []Task
+		ReplicationTasks            []Task
+		TimerTasks                  []Task
+		RangeID                     int64
+		DecisionVersion             int64
+		DecisionScheduleID          int64
+		DecisionStartedID           int64
+		DecisionStartToCloseTimeout int32
+		CreateWorkflowMode          int
+		PreviousRunID               string
+		PreviousLastWriteVersion    int64
+		ReplicationState            *ReplicationState
+		Attempt                     int32
+		HasRetryPolicy              bool
+		InitialInterval             int32
+		BackoffCoefficient          float64
+		MaximumInterval             int32
+		ExpirationTime              time.Time
+		MaximumAttempts             int32
+		NonRetriableErrors          []string
+		// 2 means using eventsV2, empty/0/1 means using events(V1)
+		EventStoreVersion int32
+		// for eventsV2: branchToken from historyPersistence
+		BranchToken       []byte
+		CronSchedule      string
+		ExpirationSeconds int32
+		VersionHistories  *DataBlob
+	}
+
 	// InternalUpdateWorkflowExecutionRequest is used to update a workflow execution  for Persistence Interface
 	InternalUpdateWorkflowExecutionRequest struct {
 		ExecutionInfo        *InternalWorkflowExecutionInfo
@@ -264,7 +321,7 @@ type (
 		DeleteTimerTask      Task
 		Condition            int64
 		RangeID              int64
-		ContinueAsNew        *CreateWorkflowExecutionRequest
+		ContinueAsNew        *InternalCreateWorkflowExecutionRequest
 		FinishExecution      bool
 		FinishedExecutionTTL int32
 
@@ -285,6 +342,7 @@ type (
 		ClearBufferedEvents           bool
 		NewBufferedReplicationTask    *InternalBufferedReplicationTask
 		DeleteBufferedReplicationTask *int64
+		Checksum                      checksum.Checksum
 	}
 
 	// InternalResetMutableStateRequest is used to reset workflow execution state  for Persistence Interface
@@ -302,6 +360,7 @@ type (
 		InsertRequestCancelInfos  []*RequestCancelInfo
 		InsertSignalInfos         []*SignalInfo
 		InsertSignalRequestedIDs  []string
+		Checksum                  checksum.Checksum
 	}
 
 	// InternalResetWorkflowExecutionRequest is used to reset workflow execution state  for Persistence Interface
@@ -373,6 +432,11 @@ type (
 		State *InternalWorkflowMutableState
 	}
 
+	// InternalGetWorkflowExecutionsResponse is the response to GetWorkflowExecutionsRequest for Persistence Interface
+	InternalGetWorkflowExecutionsResponse struct {
+		Responses []*InternalGetWorkflowExecutionResponse
+	}
+
 	// InternalGetWorkflowExecutionHistoryRequest is used to retrieve history of a workflow execution
 	InternalGetWorkflowExecutionHistoryRequest struct {
 		// an extra field passing from GetWorkflowExecutionHistoryRequest
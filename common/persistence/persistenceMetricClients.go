@@ -21,8 +21,10 @@
 package persistence
 
 import (
+	"context"
 	"github.com/uber-common/bark"
 	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/logging"
 	"github.com/uber/cadence/common/metrics"
 )
@@ -35,21 +37,24 @@ type (
 	}
 
 	workflowExecutionPersistenceClient struct {
-		metricClient metrics.Client
-		persistence  ExecutionManager
-		logger       bark.Logger
+		metricClient  metrics.Client
+		domainMetrics *DomainMetricsTagger
+		persistence   ExecutionManager
+		logger        bark.Logger
 	}
 
 	taskPersistenceClient struct {
-		metricClient metrics.Client
-		persistence  TaskManager
-		logger       bark.Logger
+		metricClient  metrics.Client
+		domainMetrics *DomainMetricsTagger
+		persistence   TaskManager
+		logger        bark.Logger
 	}
 
 	historyPersistenceClient struct {
-		metricClient metrics.Client
-		persistence  HistoryManager
-		logger       bark.Logger
+		metricClient  metrics.Client
+		domainMetrics *DomainMetricsTagger
+		persistence   HistoryManager
+		logger        bark.Logger
 	}
 
 	historyV2PersistenceClient struct {
@@ -65,9 +70,10 @@ type (
 	}
 
 	visibilityPersistenceClient struct {
-		metricClient metrics.Client
-		persistence  VisibilityManager
-		logger       bark.Logger
+		metricClient  metrics.Client
+		domainMetrics *DomainMetricsTagger
+		persistence   VisibilityManager
+		logger        bark.Logger
 	}
 )
 
@@ -89,29 +95,32 @@ func NewShardPersistenceMetricsClient(persistence ShardManager, metricClient met
 }
 
 // NewWorkflowExecutionPersistenceMetricsClient creates a client to manage executions
-func NewWorkflowExecutionPersistenceMetricsClient(persistence ExecutionManager, metricClient metrics.Client, logger bark.Logger) ExecutionManager {
+func NewWorkflowExecutionPersistenceMetricsClient(persistence ExecutionManager, metricClient metrics.Client, domainMetrics *DomainMetricsTagger, logger bark.Logger) ExecutionManager {
 	return &workflowExecutionPersistenceClient{
-		persistence:  persistence,
-		metricClient: metricClient,
-		logger:       logger,
+		persistence:   persistence,
+		metricClient:  metricClient,
+		domainMetrics: domainMetrics,
+		logger:        logger,
 	}
 }
 
 // NewTaskPersistenceMetricsClient creates a client to manage tasks
-func NewTaskPersistenceMetricsClient(persistence TaskManager, metricClient metrics.Client, logger bark.Logger) TaskManager {
+func NewTaskPersistenceMetricsClient(persistence TaskManager, metricClient metrics.Client, domainMetrics *DomainMetricsTagger, logger bark.Logger) TaskManager {
 	return &taskPersistenceClient{
-		persistence:  persistence,
-		metricClient: metricClient,
-		logger:       logger,
+		persistence:   persistence,
+		metricClient:  metricClient,
+		domainMetrics: domainMetrics,
+		logger:        logger,
 	}
 }
 
 // NewHistoryPersistenceMetricsClient creates a HistoryManager client to manage workflow execution history
-func NewHistoryPersistenceMetricsClient(persistence HistoryManager, metricClient metrics.Client, logger bark.Logger) HistoryManager {
+func NewHistoryPersistenceMetricsClient(persistence HistoryManager, metricClient metrics.Client, domainMetrics *DomainMetricsTagger, logger bark.Logger) HistoryManager {
 	return &historyPersistenceClient{
-		persistence:  persistence,
-		metricClient: metricClient,
-		logger:       logger,
+		persistence:   persistence,
+		metricClient:  metricClient,
+		domainMetrics: domainMetrics,
+		logger:        logger,
 	}
 }
 
@@ -134,11 +143,12 @@ func NewMetadataPersistenceMetricsClient(persistence MetadataManager, metricClie
 }
 
 // NewVisibilityPersistenceMetricsClient creates a client to manage visibility
-func NewVisibilityPersistenceMetricsClient(persistence VisibilityManager, metricClient metrics.Client, logger bark.Logger) VisibilityManager {
+func NewVisibilityPersistenceMetricsClient(persistence VisibilityManager, metricClient metrics.Client, domainMetrics *DomainMetricsTagger, logger bark.Logger) VisibilityManager {
 	return &visibilityPersistenceClient{
-		persistence:  persistence,
-		metricClient: metricClient,
-		logger:       logger,
+		persistence:   persistence,
+		metricClient:  metricClient,
+		domainMetrics: domainMetrics,
+		logger:        logger,
 	}
 }
 
@@ -221,217 +231,245 @@ func (p *workflowExecutionPersistenceClient) GetShardID() int {
 	return p.persistence.GetShardID()
 }
 
-func (p *workflowExecutionPersistenceClient) CreateWorkflowExecution(request *CreateWorkflowExecutionRequest) (*CreateWorkflowExecutionResponse, error) {
+func (p *workflowExecutionPersistenceClient) CreateWorkflowExecution(ctx context.Context, request *CreateWorkflowExecutionRequest) (*CreateWorkflowExecutionResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceCreateWorkflowExecutionScope, metrics.PersistenceRequests)
 
 	sw := p.metricClient.StartTimer(metrics.PersistenceCreateWorkflowExecutionScope, metrics.PersistenceLatency)
-	response, err := p.persistence.CreateWorkflowExecution(request)
+	domainSw := p.domainMetrics.startTimer(metrics.PersistenceCreateWorkflowExecutionScope, request.DomainID)
+	response, err := p.persistence.CreateWorkflowExecution(ctx, request)
 	sw.Stop()
+	domainSw.Stop()
 
 	if err != nil {
-		p.updateErrorMetric(metrics.PersistenceCreateWorkflowExecutionScope, err)
+		p.updateErrorMetric(ctx, metrics.PersistenceCreateWorkflowExecutionScope, err)
 	}
 
 	return response, err
 }
 
-func (p *workflowExecutionPersistenceClient) GetWorkflowExecution(request *GetWorkflowExecutionRequest) (*GetWorkflowExecutionResponse, error) {
+func (p *workflowExecutionPersistenceClient) GetWorkflowExecution(ctx context.Context, request *GetWorkflowExecutionRequest) (*GetWorkflowExecutionResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceGetWorkflowExecutionScope, metrics.PersistenceRequests)
 
 	sw := p.metricClient.StartTimer(metrics.PersistenceGetWorkflowExecutionScope, metrics.PersistenceLatency)
-	response, err := p.persistence.GetWorkflowExecution(request)
+	domainSw := p.domainMetrics.startTimer(metrics.PersistenceGetWorkflowExecutionScope, request.DomainID)
+	response, err := p.persistence.GetWorkflowExecution(ctx, request)
 	sw.Stop()
+	domainSw.Stop()
 
 	if err != nil {
-		p.updateErrorMetric(metrics.PersistenceGetWorkflowExecutionScope, err)
+		p.updateErrorMetric(ctx, metrics.PersistenceGetWorkflowExecutionScope, err)
 	}
 
 	return response, err
 }
 
-func (p *workflowExecutionPersistenceClient) UpdateWorkflowExecution(request *UpdateWorkflowExecutionRequest) (*UpdateWorkflowExecutionResponse, error) {
+func (p *workflowExecutionPersistenceClient) GetWorkflowExecutions(ctx context.Context, request *GetWorkflowExecutionsRequest) (*GetWorkflowExecutionsResponse, error) {
+	p.metricClient.IncCounter(metrics.PersistenceGetWorkflowExecutionsScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistenceGetWorkflowExecutionsScope, metrics.PersistenceLatency)
+	domainSw := p.domainMetrics.startTimer(metrics.PersistenceGetWorkflowExecutionsScope, request.DomainID)
+	response, err := p.persistence.GetWorkflowExecutions(ctx, request)
+	sw.Stop()
+	domainSw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(ctx, metrics.PersistenceGetWorkflowExecutionsScope, err)
+	}
+
+	return response, err
+}
+
+func (p *workflowExecutionPersistenceClient) UpdateWorkflowExecution(ctx context.Context, request *UpdateWorkflowExecutionRequest) (*UpdateWorkflowExecutionResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceUpdateWorkflowExecutionScope, metrics.PersistenceRequests)
 
 	sw := p.metricClient.StartTimer(metrics.PersistenceUpdateWorkflowExecutionScope, metrics.PersistenceLatency)
-	resp, err := p.persistence.UpdateWorkflowExecution(request)
+	domainSw := p.domainMetrics.startTimer(metrics.PersistenceUpdateWorkflowExecutionScope, request.ExecutionInfo.DomainID)
+	resp, err := p.persistence.UpdateWorkflowExecution(ctx, request)
 	sw.Stop()
+	domainSw.Stop()
 
 	if err != nil {
-		p.updateErrorMetric(metrics.PersistenceUpdateWorkflowExecutionScope, err)
+		p.updateErrorMetric(ctx, metrics.PersistenceUpdateWorkflowExecutionScope, err)
 	}
 
 	return resp, err
 }
 
-func (p *workflowExecutionPersistenceClient) ResetMutableState(request *ResetMutableStateRequest) error {
+func (p *workflowExecutionPersistenceClient) ResetMutableState(ctx context.Context, request *ResetMutableStateRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceResetMutableStateScope, metrics.PersistenceRequests)
 
 	sw := p.metricClient.StartTimer(metrics.PersistenceResetMutableStateScope, metrics.PersistenceLatency)
-	err := p.persistence.ResetMutableState(request)
+	domainSw := p.domainMetrics.startTimer(metrics.PersistenceResetMutableStateScope, request.ExecutionInfo.DomainID)
+	err := p.persistence.ResetMutableState(ctx, request)
 	sw.Stop()
+	domainSw.Stop()
 
 	if err != nil {
-		p.updateErrorMetric(metrics.PersistenceResetMutableStateScope, err)
+		p.updateErrorMetric(ctx, metrics.PersistenceResetMutableStateScope, err)
 	}
 
 	return err
 }
 
-func (p *workflowExecutionPersistenceClient) ResetWorkflowExecution(request *ResetWorkflowExecutionRequest) error {
+func (p *workflowExecutionPersistenceClient) ResetWorkflowExecution(ctx context.Context, request *ResetWorkflowExecutionRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceResetWorkflowExecutionScope, metrics.PersistenceRequests)
 
 	sw := p.metricClient.StartTimer(metrics.PersistenceResetWorkflowExecutionScope, metrics.PersistenceLatency)
-	err := p.persistence.ResetWorkflowExecution(request)
+	err := p.persistence.ResetWorkflowExecution(ctx, request)
 	sw.Stop()
 
 	if err != nil {
-		p.updateErrorMetric(metrics.PersistenceResetWorkflowExecutionScope, err)
+		p.updateErrorMetric(ctx, metrics.PersistenceResetWorkflowExecutionScope, err)
 	}
 
 	return err
 }
 
-func (p *workflowExecutionPersistenceClient) DeleteWorkflowExecution(request *DeleteWorkflowExecutionRequest) error {
+func (p *workflowExecutionPersistenceClient) DeleteWorkflowExecution(ctx context.Context, request *DeleteWorkflowExecutionRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceDeleteWorkflowExecutionScope, metrics.PersistenceRequests)
 
 	sw := p.metricClient.StartTimer(metrics.PersistenceDeleteWorkflowExecutionScope, metrics.PersistenceLatency)
-	err := p.persistence.DeleteWorkflowExecution(request)
+	domainSw := p.domainMetrics.startTimer(metrics.PersistenceDeleteWorkflowExecutionScope, request.DomainID)
+	err := p.persistence.DeleteWorkflowExecution(ctx, request)
 	sw.Stop()
+	domainSw.Stop()
 
 	if err != nil {
-		p.updateErrorMetric(metrics.PersistenceDeleteWorkflowExecutionScope, err)
+		p.updateErrorMetric(ctx, metrics.PersistenceDeleteWorkflowExecutionScope, err)
 	}
 
 	return err
 }
 
-func (p *workflowExecutionPersistenceClient) GetCurrentExecution(request *GetCurrentExecutionRequest) (*GetCurrentExecutionResponse, error) {
+func (p *workflowExecutionPersistenceClient) GetCurrentExecution(ctx context.Context, request *GetCurrentExecutionRequest) (*GetCurrentExecutionResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceGetCurrentExecutionScope, metrics.PersistenceRequests)
 
 	sw := p.metricClient.StartTimer(metrics.PersistenceGetCurrentExecutionScope, metrics.PersistenceLatency)
-	response, err := p.persistence.GetCurrentExecution(request)
+	domainSw := p.domainMetrics.startTimer(metrics.PersistenceGetCurrentExecutionScope, request.DomainID)
+	response, err := p.persistence.GetCurrentExecution(ctx, request)
 	sw.Stop()
+	domainSw.Stop()
 
 	if err != nil {
-		p.updateErrorMetric(metrics.PersistenceGetCurrentExecutionScope, err)
+		p.updateErrorMetric(ctx, metrics.PersistenceGetCurrentExecutionScope, err)
 	}
 
 	return response, err
 }
 
-func (p *workflowExecutionPersistenceClient) GetTransferTasks(request *GetTransferTasksRequest) (*GetTransferTasksResponse, error) {
+func (p *workflowExecutionPersistenceClient) GetTransferTasks(ctx context.Context, request *GetTransferTasksRequest) (*GetTransferTasksResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceGetTransferTasksScope, metrics.PersistenceRequests)
 
 	sw := p.metricClient.StartTimer(metrics.PersistenceGetTransferTasksScope, metrics.PersistenceLatency)
-	response, err := p.persistence.GetTransferTasks(request)
+	response, err := p.persistence.GetTransferTasks(ctx, request)
 	sw.Stop()
 
 	if err != nil {
-		p.updateErrorMetric(metrics.PersistenceGetTransferTasksScope, err)
+		p.updateErrorMetric(ctx, metrics.PersistenceGetTransferTasksScope, err)
 	}
 
 	return response, err
 }
 
-func (p *workflowExecutionPersistenceClient) GetReplicationTasks(request *GetReplicationTasksRequest) (*GetReplicationTasksResponse, error) {
+func (p *workflowExecutionPersistenceClient) GetReplicationTasks(ctx context.Context, request *GetReplicationTasksRequest) (*GetReplicationTasksResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceGetReplicationTasksScope, metrics.PersistenceRequests)
 
 	sw := p.metricClient.StartTimer(metrics.PersistenceGetReplicationTasksScope, metrics.PersistenceLatency)
-	response, err := p.persistence.GetReplicationTasks(request)
+	response, err := p.persistence.GetReplicationTasks(ctx, request)
 	sw.Stop()
 
 	if err != nil {
-		p.updateErrorMetric(metrics.PersistenceGetReplicationTasksScope, err)
+		p.updateErrorMetric(ctx, metrics.PersistenceGetReplicationTasksScope, err)
 	}
 
 	return response, err
 }
 
-func (p *workflowExecutionPersistenceClient) CompleteTransferTask(request *CompleteTransferTaskRequest) error {
+func (p *workflowExecutionPersistenceClient) CompleteTransferTask(ctx context.Context, request *CompleteTransferTaskRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceCompleteTransferTaskScope, metrics.PersistenceRequests)
 
 	sw := p.metricClient.StartTimer(metrics.PersistenceCompleteTransferTaskScope, metrics.PersistenceLatency)
-	err := p.persistence.CompleteTransferTask(request)
+	err := p.persistence.CompleteTransferTask(ctx, request)
 	sw.Stop()
 
 	if err != nil {
-		p.updateErrorMetric(metrics.PersistenceCompleteTransferTaskScope, err)
+		p.updateErrorMetric(ctx, metrics.PersistenceCompleteTransferTaskScope, err)
 	}
 
 	return err
 }
 
-func (p *workflowExecutionPersistenceClient) RangeCompleteTransferTask(request *RangeCompleteTransferTaskRequest) error {
+func (p *workflowExecutionPersistenceClient) RangeCompleteTransferTask(ctx context.Context, request *RangeCompleteTransferTaskRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceRangeCompleteTransferTaskScope, metrics.PersistenceRequests)
 
 	sw := p.metricClient.StartTimer(metrics.PersistenceRangeCompleteTransferTaskScope, metrics.PersistenceLatency)
-	err := p.persistence.RangeCompleteTransferTask(request)
+	err := p.persistence.RangeCompleteTransferTask(ctx, request)
 	sw.Stop()
 
 	if err != nil {
-		p.updateErrorMetric(metrics.PersistenceRangeCompleteTransferTaskScope, err)
+		p.updateErrorMetric(ctx, metrics.PersistenceRangeCompleteTransferTaskScope, err)
 	}
 
 	return err
 }
 
-func (p *workflowExecutionPersistenceClient) CompleteReplicationTask(request *CompleteReplicationTaskRequest) error {
+func (p *workflowExecutionPersistenceClient) CompleteReplicationTask(ctx context.Context, request *CompleteReplicationTaskRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceCompleteReplicationTaskScope, metrics.PersistenceRequests)
 
 	sw := p.metricClient.StartTimer(metrics.PersistenceCompleteReplicationTaskScope, metrics.PersistenceLatency)
-	err := p.persistence.CompleteReplicationTask(request)
+	err := p.persistence.CompleteReplicationTask(ctx, request)
 	sw.Stop()
 
 	if err != nil {
-		p.updateErrorMetric(metrics.PersistenceCompleteReplicationTaskScope, err)
+		p.updateErrorMetric(ctx, metrics.PersistenceCompleteReplicationTaskScope, err)
 	}
 
 	return err
 }
 
-func (p *workflowExecutionPersistenceClient) GetTimerIndexTasks(request *GetTimerIndexTasksRequest) (*GetTimerIndexTasksResponse, error) {
+func (p *workflowExecutionPersistenceClient) GetTimerIndexTasks(ctx context.Context, request *GetTimerIndexTasksRequest) (*GetTimerIndexTasksResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceGetTimerIndexTasksScope, metrics.PersistenceRequests)
 
 	sw := p.metricClient.StartTimer(metrics.PersistenceGetTimerIndexTasksScope, metrics.PersistenceLatency)
-	resonse, err := p.persistence.GetTimerIndexTasks(request)
+	resonse, err := p.persistence.GetTimerIndexTasks(ctx, request)
 	sw.Stop()
 
 	if err != nil {
-		p.updateErrorMetric(metrics.PersistenceGetTimerIndexTasksScope, err)
+		p.updateErrorMetric(ctx, metrics.PersistenceGetTimerIndexTasksScope, err)
 	}
 
 	return resonse, err
 }
 
-func (p *workflowExecutionPersistenceClient) CompleteTimerTask(request *CompleteTimerTaskRequest) error {
+func (p *workflowExecutionPersistenceClient) CompleteTimerTask(ctx context.Context, request *CompleteTimerTaskRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceCompleteTimerTaskScope, metrics.PersistenceRequests)
 
 	sw := p.metricClient.StartTimer(metrics.PersistenceCompleteTimerTaskScope, metrics.PersistenceLatency)
-	err := p.persistence.CompleteTimerTask(request)
+	err := p.persistence.CompleteTimerTask(ctx, request)
 	sw.Stop()
 
 	if err != nil {
-		p.updateErrorMetric(metrics.PersistenceCompleteTimerTaskScope, err)
+		p.updateErrorMetric(ctx, metrics.PersistenceCompleteTimerTaskScope, err)
 	}
 
 	return err
 }
 
-func (p *workflowExecutionPersistenceClient) RangeCompleteTimerTask(request *RangeCompleteTimerTaskRequest) error {
+func (p *workflowExecutionPersistenceClient) RangeCompleteTimerTask(ctx context.Context, request *RangeCompleteTimerTaskRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceRangeCompleteTimerTaskScope, metrics.PersistenceRequests)
 
 	sw := p.metricClient.StartTimer(metrics.PersistenceRangeCompleteTimerTaskScope, metrics.PersistenceLatency)
-	err := p.persistence.RangeCompleteTimerTask(request)
+	err := p.persistence.RangeCompleteTimerTask(ctx, request)
 	sw.Stop()
 
 	if err != nil {
-		p.updateErrorMetric(metrics.PersistenceRangeCompleteTimerTaskScope, err)
+		p.updateErrorMetric(ctx, metrics.PersistenceRangeCompleteTimerTaskScope, err)
 	}
 
 	return err
 }
 
-func (p *workflowExecutionPersistenceClient) updateErrorMetric(scope int, err error) {
+func (p *workflowExecutionPersistenceClient) updateErrorMetric(ctx context.Context, scope int, err error) {
 	switch err.(type) {
 	case *WorkflowExecutionAlreadyStartedError:
 		p.metricClient.IncCounter(scope, metrics.PersistenceErrExecutionAlreadyStartedCounter)
@@ -450,10 +488,13 @@ func (p *workflowExecutionPersistenceClient) updateErrorMetric(scope int, err er
 		p.metricClient.IncCounter(scope, metrics.PersistenceErrBusyCounter)
 		p.metricClient.IncCounter(scope, metrics.PersistenceFailures)
 	default:
+		requestID, callerIdentity := common.GetRequestInfo(ctx)
 		p.logger.WithFields(bark.Fields{
 			logging.TagScope:          scope,
 			logging.TagHistoryShardID: p.GetShardID(),
 			logging.TagErr:            err,
+			logging.TagRequestID:      requestID,
+			logging.TagCallerIdentity: callerIdentity,
 		}).Error("Operation failed with internal error.")
 		p.metricClient.IncCounter(scope, metrics.PersistenceFailures)
 	}
@@ -471,8 +512,10 @@ func (p *taskPersistenceClient) CreateTasks(request *CreateTasksRequest) (*Creat
 	p.metricClient.IncCounter(metrics.PersistenceCreateTaskScope, metrics.PersistenceRequests)
 
 	sw := p.metricClient.StartTimer(metrics.PersistenceCreateTaskScope, metrics.PersistenceLatency)
+	domainSw := p.domainMetrics.startTimer(metrics.PersistenceCreateTaskScope, request.TaskListInfo.DomainID)
 	response, err := p.persistence.CreateTasks(request)
 	sw.Stop()
+	domainSw.Stop()
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceCreateTaskScope, err)
@@ -485,8 +528,10 @@ func (p *taskPersistenceClient) GetTasks(request *GetTasksRequest) (*GetTasksRes
 	p.metricClient.IncCounter(metrics.PersistenceGetTasksScope, metrics.PersistenceRequests)
 
 	sw := p.metricClient.StartTimer(metrics.PersistenceGetTasksScope, metrics.PersistenceLatency)
+	domainSw := p.domainMetrics.startTimer(metrics.PersistenceGetTasksScope, request.DomainID)
 	response, err := p.persistence.GetTasks(request)
 	sw.Stop()
+	domainSw.Stop()
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceGetTasksScope, err)
@@ -524,8 +569,10 @@ func (p *taskPersistenceClient) LeaseTaskList(request *LeaseTaskListRequest) (*L
 	p.metricClient.IncCounter(metrics.PersistenceLeaseTaskListScope, metrics.PersistenceRequests)
 
 	sw := p.metricClient.StartTimer(metrics.PersistenceLeaseTaskListScope, metrics.PersistenceLatency)
+	domainSw := p.domainMetrics.startTimer(metrics.PersistenceLeaseTaskListScope, request.DomainID)
 	response, err := p.persistence.LeaseTaskList(request)
 	sw.Stop()
+	domainSw.Stop()
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceLeaseTaskListScope, err)
@@ -560,8 +607,10 @@ func (p *taskPersistenceClient) UpdateTaskList(request *UpdateTaskListRequest) (
 	p.metricClient.IncCounter(metrics.PersistenceUpdateTaskListScope, metrics.PersistenceRequests)
 
 	sw := p.metricClient.StartTimer(metrics.PersistenceUpdateTaskListScope, metrics.PersistenceLatency)
+	domainSw := p.domainMetrics.startTimer(metrics.PersistenceUpdateTaskListScope, request.TaskListInfo.DomainID)
 	response, err := p.persistence.UpdateTaskList(request)
 	sw.Stop()
+	domainSw.Stop()
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceUpdateTaskListScope, err)
@@ -601,8 +650,10 @@ func (p *historyPersistenceClient) AppendHistoryEvents(request *AppendHistoryEve
 	p.metricClient.IncCounter(metrics.PersistenceAppendHistoryEventsScope, metrics.PersistenceRequests)
 
 	sw := p.metricClient.StartTimer(metrics.PersistenceAppendHistoryEventsScope, metrics.PersistenceLatency)
+	domainSw := p.domainMetrics.startTimer(metrics.PersistenceAppendHistoryEventsScope, request.DomainID)
 	resp, err := p.persistence.AppendHistoryEvents(request)
 	sw.Stop()
+	domainSw.Stop()
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceAppendHistoryEventsScope, err)
@@ -616,8 +667,10 @@ func (p *historyPersistenceClient) GetWorkflowExecutionHistory(
 	p.metricClient.IncCounter(metrics.PersistenceGetWorkflowExecutionHistoryScope, metrics.PersistenceRequests)
 
 	sw := p.metricClient.StartTimer(metrics.PersistenceGetWorkflowExecutionHistoryScope, metrics.PersistenceLatency)
+	domainSw := p.domainMetrics.startTimer(metrics.PersistenceGetWorkflowExecutionHistoryScope, request.DomainID)
 	response, err := p.persistence.GetWorkflowExecutionHistory(request)
 	sw.Stop()
+	domainSw.Stop()
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceGetWorkflowExecutionHistoryScope, err)
@@ -631,8 +684,10 @@ func (p *historyPersistenceClient) GetWorkflowExecutionHistoryByBatch(
 	p.metricClient.IncCounter(metrics.PersistenceGetWorkflowExecutionHistoryScope, metrics.PersistenceRequests)
 
 	sw := p.metricClient.StartTimer(metrics.PersistenceGetWorkflowExecutionHistoryScope, metrics.PersistenceLatency)
+	domainSw := p.domainMetrics.startTimer(metrics.PersistenceGetWorkflowExecutionHistoryScope, request.DomainID)
 	response, err := p.persistence.GetWorkflowExecutionHistoryByBatch(request)
 	sw.Stop()
+	domainSw.Stop()
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceGetWorkflowExecutionHistoryScope, err)
@@ -646,8 +701,10 @@ func (p *historyPersistenceClient) DeleteWorkflowExecutionHistory(
 	p.metricClient.IncCounter(metrics.PersistenceDeleteWorkflowExecutionHistoryScope, metrics.PersistenceRequests)
 
 	sw := p.metricClient.StartTimer(metrics.PersistenceDeleteWorkflowExecutionHistoryScope, metrics.PersistenceLatency)
+	domainSw := p.domainMetrics.startTimer(metrics.PersistenceDeleteWorkflowExecutionHistoryScope, request.DomainID)
 	err := p.persistence.DeleteWorkflowExecutionHistory(request)
 	sw.Stop()
+	domainSw.Stop()
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceDeleteWorkflowExecutionHistoryScope, err)
@@ -685,99 +742,99 @@ func (p *metadataPersistenceClient) GetName() string {
 	return p.persistence.GetName()
 }
 
-func (p *metadataPersistenceClient) CreateDomain(request *CreateDomainRequest) (*CreateDomainResponse, error) {
+func (p *metadataPersistenceClient) CreateDomain(ctx context.Context, request *CreateDomainRequest) (*CreateDomainResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceCreateDomainScope, metrics.PersistenceRequests)
 
 	sw := p.metricClient.StartTimer(metrics.PersistenceCreateDomainScope, metrics.PersistenceLatency)
-	response, err := p.persistence.CreateDomain(request)
+	response, err := p.persistence.CreateDomain(ctx, request)
 	sw.Stop()
 
 	if err != nil {
-		p.updateErrorMetric(metrics.PersistenceCreateDomainScope, err)
+		p.updateErrorMetric(ctx, metrics.PersistenceCreateDomainScope, err)
 	}
 
 	return response, err
 }
 
-func (p *metadataPersistenceClient) GetDomain(request *GetDomainRequest) (*GetDomainResponse, error) {
+func (p *metadataPersistenceClient) GetDomain(ctx context.Context, request *GetDomainRequest) (*GetDomainResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceGetDomainScope, metrics.PersistenceRequests)
 
 	sw := p.metricClient.StartTimer(metrics.PersistenceGetDomainScope, metrics.PersistenceLatency)
-	response, err := p.persistence.GetDomain(request)
+	response, err := p.persistence.GetDomain(ctx, request)
 	sw.Stop()
 
 	if err != nil {
-		p.updateErrorMetric(metrics.PersistenceGetDomainScope, err)
+		p.updateErrorMetric(ctx, metrics.PersistenceGetDomainScope, err)
 	}
 
 	return response, err
 }
 
-func (p *metadataPersistenceClient) UpdateDomain(request *UpdateDomainRequest) error {
+func (p *metadataPersistenceClient) UpdateDomain(ctx context.Context, request *UpdateDomainRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceUpdateDomainScope, metrics.PersistenceRequests)
 
 	sw := p.metricClient.StartTimer(metrics.PersistenceUpdateDomainScope, metrics.PersistenceLatency)
-	err := p.persistence.UpdateDomain(request)
+	err := p.persistence.UpdateDomain(ctx, request)
 	sw.Stop()
 
 	if err != nil {
-		p.updateErrorMetric(metrics.PersistenceUpdateDomainScope, err)
+		p.updateErrorMetric(ctx, metrics.PersistenceUpdateDomainScope, err)
 	}
 
 	return err
 }
 
-func (p *metadataPersistenceClient) DeleteDomain(request *DeleteDomainRequest) error {
+func (p *metadataPersistenceClient) DeleteDomain(ctx context.Context, request *DeleteDomainRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceDeleteDomainScope, metrics.PersistenceRequests)
 
 	sw := p.metricClient.StartTimer(metrics.PersistenceDeleteDomainScope, metrics.PersistenceLatency)
-	err := p.persistence.DeleteDomain(request)
+	err := p.persistence.DeleteDomain(ctx, request)
 	sw.Stop()
 
 	if err != nil {
-		p.updateErrorMetric(metrics.PersistenceDeleteDomainScope, err)
+		p.updateErrorMetric(ctx, metrics.PersistenceDeleteDomainScope, err)
 	}
 
 	return err
 }
 
-func (p *metadataPersistenceClient) DeleteDomainByName(request *DeleteDomainByNameRequest) error {
+func (p *metadataPersistenceClient) DeleteDomainByName(ctx context.Context, request *DeleteDomainByNameRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceDeleteDomainByNameScope, metrics.PersistenceRequests)
 
 	sw := p.metricClient.StartTimer(metrics.PersistenceDeleteDomainByNameScope, metrics.PersistenceLatency)
-	err := p.persistence.DeleteDomainByName(request)
+	err := p.persistence.DeleteDomainByName(ctx, request)
 	sw.Stop()
 
 	if err != nil {
-		p.updateErrorMetric(metrics.PersistenceDeleteDomainByNameScope, err)
+		p.updateErrorMetric(ctx, metrics.PersistenceDeleteDomainByNameScope, err)
 	}
 
 	return err
 }
 
-func (p *metadataPersistenceClient) ListDomains(request *ListDomainsRequest) (*ListDomainsResponse, error) {
+func (p *metadataPersistenceClient) ListDomains(ctx context.Context, request *ListDomainsRequest) (*ListDomainsResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceListDomainScope, metrics.PersistenceRequests)
 
 	sw := p.metricClient.StartTimer(metrics.PersistenceListDomainScope, metrics.PersistenceLatency)
-	response, err := p.persistence.ListDomains(request)
+	response, err := p.persistence.ListDomains(ctx, request)
 	sw.Stop()
 
 	if err != nil {
-		p.updateErrorMetric(metrics.PersistenceListDomainScope, err)
+		p.updateErrorMetric(ctx, metrics.PersistenceListDomainScope, err)
 	}
 
 	return response, err
 }
 
-func (p *metadataPersistenceClient) GetMetadata() (*GetMetadataResponse, error) {
+func (p *metadataPersistenceClient) GetMetadata(ctx context.Context) (*GetMetadataResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceGetMetadataScope, metrics.PersistenceRequests)
 
 	sw := p.metricClient.StartTimer(metrics.PersistenceGetMetadataScope, metrics.PersistenceLatency)
-	response, err := p.persistence.GetMetadata()
+	response, err := p.persistence.GetMetadata(ctx)
 	sw.Stop()
 
 	if err != nil {
-		p.updateErrorMetric(metrics.PersistenceGetMetadataScope, err)
+		p.updateErrorMetric(ctx, metrics.PersistenceGetMetadataScope, err)
 	}
 
 	return response, err
@@ -787,7 +844,7 @@ func (p *metadataPersistenceClient) Close() {
 	p.persistence.Close()
 }
 
-func (p *metadataPersistenceClient) updateErrorMetric(scope int, err error) {
+func (p *metadataPersistenceClient) updateErrorMetric(ctx context.Context, scope int, err error) {
 	switch err.(type) {
 	case *workflow.DomainAlreadyExistsError:
 		p.metricClient.IncCounter(scope, metrics.PersistenceErrDomainAlreadyExistsCounter)
@@ -799,9 +856,12 @@ func (p *metadataPersistenceClient) updateErrorMetric(scope int, err error) {
 		p.metricClient.IncCounter(scope, metrics.PersistenceErrBusyCounter)
 		p.metricClient.IncCounter(scope, metrics.PersistenceFailures)
 	default:
+		requestID, callerIdentity := common.GetRequestInfo(ctx)
 		p.logger.WithFields(bark.Fields{
-			logging.TagScope: scope,
-			logging.TagErr:   err,
+			logging.TagScope:          scope,
+			logging.TagErr:            err,
+			logging.TagRequestID:      requestID,
+			logging.TagCallerIdentity: callerIdentity,
 		}).Error("Operation failed with internal error.")
 		p.metricClient.IncCounter(scope, metrics.PersistenceFailures)
 	}
@@ -811,161 +871,215 @@ func (p *visibilityPersistenceClient) GetName() string {
 	return p.persistence.GetName()
 }
 
-func (p *visibilityPersistenceClient) RecordWorkflowExecutionStarted(request *RecordWorkflowExecutionStartedRequest) error {
+func (p *visibilityPersistenceClient) RecordWorkflowExecutionStarted(ctx context.Context, request *RecordWorkflowExecutionStartedRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceRecordWorkflowExecutionStartedScope, metrics.PersistenceRequests)
 
 	sw := p.metricClient.StartTimer(metrics.PersistenceRecordWorkflowExecutionStartedScope, metrics.PersistenceLatency)
-	err := p.persistence.RecordWorkflowExecutionStarted(request)
+	domainSw := p.domainMetrics.startTimer(metrics.PersistenceRecordWorkflowExecutionStartedScope, request.DomainUUID)
+	err := p.persistence.RecordWorkflowExecutionStarted(ctx, request)
 	sw.Stop()
+	domainSw.Stop()
 
 	if err != nil {
-		p.updateErrorMetric(metrics.PersistenceRecordWorkflowExecutionStartedScope, err)
+		p.updateErrorMetric(ctx, metrics.PersistenceRecordWorkflowExecutionStartedScope, err)
 	}
 
 	return err
 }
 
-func (p *visibilityPersistenceClient) RecordWorkflowExecutionClosed(request *RecordWorkflowExecutionClosedRequest) error {
+func (p *visibilityPersistenceClient) RecordWorkflowExecutionClosed(ctx context.Context, request *RecordWorkflowExecutionClosedRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceRecordWorkflowExecutionClosedScope, metrics.PersistenceRequests)
 
 	sw := p.metricClient.StartTimer(metrics.PersistenceRecordWorkflowExecutionClosedScope, metrics.PersistenceLatency)
-	err := p.persistence.RecordWorkflowExecutionClosed(request)
+	domainSw := p.domainMetrics.startTimer(metrics.PersistenceRecordWorkflowExecutionClosedScope, request.DomainUUID)
+	err := p.persistence.RecordWorkflowExecutionClosed(ctx, request)
 	sw.Stop()
+	domainSw.Stop()
 
 	if err != nil {
-		p.updateErrorMetric(metrics.PersistenceRecordWorkflowExecutionClosedScope, err)
+		p.updateErrorMetric(ctx, metrics.PersistenceRecordWorkflowExecutionClosedScope, err)
 	}
 
 	return err
 }
 
-func (p *visibilityPersistenceClient) ListOpenWorkflowExecutions(request *ListWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error) {
+func (p *visibilityPersistenceClient) ListOpenWorkflowExecutions(ctx context.Context, request *ListWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceListOpenWorkflowExecutionsScope, metrics.PersistenceRequests)
 
 	sw := p.metricClient.StartTimer(metrics.PersistenceListOpenWorkflowExecutionsScope, metrics.PersistenceLatency)
-	response, err := p.persistence.ListOpenWorkflowExecutions(request)
+	domainSw := p.domainMetrics.startTimer(metrics.PersistenceListOpenWorkflowExecutionsScope, request.DomainUUID)
+	response, err := p.persistence.ListOpenWorkflowExecutions(ctx, request)
 	sw.Stop()
+	domainSw.Stop()
 
 	if err != nil {
-		p.updateErrorMetric(metrics.PersistenceListOpenWorkflowExecutionsScope, err)
+		p.updateErrorMetric(ctx, metrics.PersistenceListOpenWorkflowExecutionsScope, err)
 	}
 
 	return response, err
 }
 
-func (p *visibilityPersistenceClient) ListClosedWorkflowExecutions(request *ListWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error) {
+func (p *visibilityPersistenceClient) ListClosedWorkflowExecutions(ctx context.Context, request *ListWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceListClosedWorkflowExecutionsScope, metrics.PersistenceRequests)
 
 	sw := p.metricClient.StartTimer(metrics.PersistenceListClosedWorkflowExecutionsScope, metrics.PersistenceLatency)
-	response, err := p.persistence.ListClosedWorkflowExecutions(request)
+	domainSw := p.domainMetrics.startTimer(metrics.PersistenceListClosedWorkflowExecutionsScope, request.DomainUUID)
+	response, err := p.persistence.ListClosedWorkflowExecutions(ctx, request)
 	sw.Stop()
+	domainSw.Stop()
 
 	if err != nil {
-		p.updateErrorMetric(metrics.PersistenceListClosedWorkflowExecutionsScope, err)
+		p.updateErrorMetric(ctx, metrics.PersistenceListClosedWorkflowExecutionsScope, err)
 	}
 
 	return response, err
 }
 
-func (p *visibilityPersistenceClient) ListOpenWorkflowExecutionsByType(request *ListWorkflowExecutionsByTypeRequest) (*ListWorkflowExecutionsResponse, error) {
+func (p *visibilityPersistenceClient) ListOpenWorkflowExecutionsByType(ctx context.Context, request *ListWorkflowExecutionsByTypeRequest) (*ListWorkflowExecutionsResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceListOpenWorkflowExecutionsByTypeScope, metrics.PersistenceRequests)
 
 	sw := p.metricClient.StartTimer(metrics.PersistenceListOpenWorkflowExecutionsByTypeScope, metrics.PersistenceLatency)
-	response, err := p.persistence.ListOpenWorkflowExecutionsByType(request)
+	domainSw := p.domainMetrics.startTimer(metrics.PersistenceListOpenWorkflowExecutionsByTypeScope, request.DomainUUID)
+	response, err := p.persistence.ListOpenWorkflowExecutionsByType(ctx, request)
 	sw.Stop()
+	domainSw.Stop()
 
 	if err != nil {
-		p.updateErrorMetric(metrics.PersistenceListOpenWorkflowExecutionsByTypeScope, err)
+		p.updateErrorMetric(ctx, metrics.PersistenceListOpenWorkflowExecutionsByTypeScope, err)
 	}
 
 	return response, err
 }
 
-func (p *visibilityPersistenceClient) ListClosedWorkflowExecutionsByType(request *ListWorkflowExecutionsByTypeRequest) (*ListWorkflowExecutionsResponse, error) {
+func (p *visibilityPersistenceClient) ListClosedWorkflowExecutionsByType(ctx context.Context, request *ListWorkflowExecutionsByTypeRequest) (*ListWorkflowExecutionsResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceListClosedWorkflowExecutionsByTypeScope, metrics.PersistenceRequests)
 
 	sw := p.metricClient.StartTimer(metrics.PersistenceListClosedWorkflowExecutionsByTypeScope, metrics.PersistenceLatency)
-	response, err := p.persistence.ListClosedWorkflowExecutionsByType(request)
+	domainSw := p.domainMetrics.startTimer(metrics.PersistenceListClosedWorkflowExecutionsByTypeScope, request.DomainUUID)
+	response, err := p.persistence.ListClosedWorkflowExecutionsByType(ctx, request)
 	sw.Stop()
+	domainSw.Stop()
 
 	if err != nil {
-		p.updateErrorMetric(metrics.PersistenceListClosedWorkflowExecutionsByTypeScope, err)
+		p.updateErrorMetric(ctx, metrics.PersistenceListClosedWorkflowExecutionsByTypeScope, err)
 	}
 
 	return response, err
 }
 
-func (p *visibilityPersistenceClient) ListOpenWorkflowExecutionsByWorkflowID(request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error) {
+func (p *visibilityPersistenceClient) ListOpenWorkflowExecutionsByWorkflowID(ctx context.Context, request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceListOpenWorkflowExecutionsByWorkflowIDScope, metrics.PersistenceRequests)
 
 	sw := p.metricClient.StartTimer(metrics.PersistenceListOpenWorkflowExecutionsByWorkflowIDScope, metrics.PersistenceLatency)
-	response, err := p.persistence.ListOpenWorkflowExecutionsByWorkflowID(request)
+	domainSw := p.domainMetrics.startTimer(metrics.PersistenceListOpenWorkflowExecutionsByWorkflowIDScope, request.DomainUUID)
+	response, err := p.persistence.ListOpenWorkflowExecutionsByWorkflowID(ctx, request)
 	sw.Stop()
+	domainSw.Stop()
 
 	if err != nil {
-		p.updateErrorMetric(metrics.PersistenceListOpenWorkflowExecutionsByWorkflowIDScope, err)
+		p.updateErrorMetric(ctx, metrics.PersistenceListOpenWorkflowExecutionsByWorkflowIDScope, err)
 	}
 
 	return response, err
 }
 
-func (p *visibilityPersistenceClient) ListClosedWorkflowExecutionsByWorkflowID(request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error) {
+func (p *visibilityPersistenceClient) ListClosedWorkflowExecutionsByWorkflowID(ctx context.Context, request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceListClosedWorkflowExecutionsByWorkflowIDScope, metrics.PersistenceRequests)
 
 	sw := p.metricClient.StartTimer(metrics.PersistenceListClosedWorkflowExecutionsByWorkflowIDScope, metrics.PersistenceLatency)
-	response, err := p.persistence.ListClosedWorkflowExecutionsByWorkflowID(request)
+	domainSw := p.domainMetrics.startTimer(metrics.PersistenceListClosedWorkflowExecutionsByWorkflowIDScope, request.DomainUUID)
+	response, err := p.persistence.ListClosedWorkflowExecutionsByWorkflowID(ctx, request)
 	sw.Stop()
+	domainSw.Stop()
 
 	if err != nil {
-		p.updateErrorMetric(metrics.PersistenceListClosedWorkflowExecutionsByWorkflowIDScope, err)
+		p.updateErrorMetric(ctx, metrics.PersistenceListClosedWorkflowExecutionsByWorkflowIDScope, err)
 	}
 
 	return response, err
 }
 
-func (p *visibilityPersistenceClient) ListClosedWorkflowExecutionsByStatus(request *ListClosedWorkflowExecutionsByStatusRequest) (*ListWorkflowExecutionsResponse, error) {
+func (p *visibilityPersistenceClient) ListClosedWorkflowExecutionsByStatus(ctx context.Context, request *ListClosedWorkflowExecutionsByStatusRequest) (*ListWorkflowExecutionsResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceListClosedWorkflowExecutionsByStatusScope, metrics.PersistenceRequests)
 
 	sw := p.metricClient.StartTimer(metrics.PersistenceListClosedWorkflowExecutionsByStatusScope, metrics.PersistenceLatency)
-	response, err := p.persistence.ListClosedWorkflowExecutionsByStatus(request)
+	domainSw := p.domainMetrics.startTimer(metrics.PersistenceListClosedWorkflowExecutionsByStatusScope, request.DomainUUID)
+	response, err := p.persistence.ListClosedWorkflowExecutionsByStatus(ctx, request)
 	sw.Stop()
+	domainSw.Stop()
 
 	if err != nil {
-		p.updateErrorMetric(metrics.PersistenceListClosedWorkflowExecutionsByStatusScope, err)
+		p.updateErrorMetric(ctx, metrics.PersistenceListClosedWorkflowExecutionsByStatusScope, err)
 	}
 
 	return response, err
 }
 
-func (p *visibilityPersistenceClient) GetClosedWorkflowExecution(request *GetClosedWorkflowExecutionRequest) (*GetClosedWorkflowExecutionResponse, error) {
+func (p *visibilityPersistenceClient) GetClosedWorkflowExecution(ctx context.Context, request *GetClosedWorkflowExecutionRequest) (*GetClosedWorkflowExecutionResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceGetClosedWorkflowExecutionScope, metrics.PersistenceRequests)
 
 	sw := p.metricClient.StartTimer(metrics.PersistenceGetClosedWorkflowExecutionScope, metrics.PersistenceLatency)
-	response, err := p.persistence.GetClosedWorkflowExecution(request)
+	domainSw := p.domainMetrics.startTimer(metrics.PersistenceGetClosedWorkflowExecutionScope, request.DomainUUID)
+	response, err := p.persistence.GetClosedWorkflowExecution(ctx, request)
 	sw.Stop()
+	domainSw.Stop()
 
 	if err != nil {
-		p.updateErrorMetric(metrics.PersistenceGetClosedWorkflowExecutionScope, err)
+		p.updateErrorMetric(ctx, metrics.PersistenceGetClosedWorkflowExecutionScope, err)
 	}
 
 	return response, err
 }
 
-func (p *visibilityPersistenceClient) DeleteWorkflowExecution(request *VisibilityDeleteWorkflowExecutionRequest) error {
+func (p *visibilityPersistenceClient) DeleteWorkflowExecution(ctx context.Context, request *VisibilityDeleteWorkflowExecutionRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceVisibilityDeleteWorkflowExecutionScope, metrics.PersistenceRequests)
 
 	sw := p.metricClient.StartTimer(metrics.PersistenceVisibilityDeleteWorkflowExecutionScope, metrics.PersistenceLatency)
-	err := p.persistence.DeleteWorkflowExecution(request)
+	domainSw := p.domainMetrics.startTimer(metrics.PersistenceVisibilityDeleteWorkflowExecutionScope, request.DomainID)
+	err := p.persistence.DeleteWorkflowExecution(ctx, request)
 	sw.Stop()
+	domainSw.Stop()
 
 	if err != nil {
-		p.updateErrorMetric(metrics.PersistenceVisibilityDeleteWorkflowExecutionScope, err)
+		p.updateErrorMetric(ctx, metrics.PersistenceVisibilityDeleteWorkflowExecutionScope, err)
 	}
 
 	return err
 }
 
-func (p *visibilityPersistenceClient) updateErrorMetric(scope int, err error) {
+func (p *visibilityPersistenceClient) GetWorkflowExecutionStatistics(ctx context.Context, request *GetWorkflowExecutionStatisticsRequest) (*GetWorkflowExecutionStatisticsResponse, error) {
+	p.metricClient.IncCounter(metrics.PersistenceGetWorkflowExecutionStatisticsScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistenceGetWorkflowExecutionStatisticsScope, metrics.PersistenceLatency)
+	domainSw := p.domainMetrics.startTimer(metrics.PersistenceGetWorkflowExecutionStatisticsScope, request.DomainUUID)
+	response, err := p.persistence.GetWorkflowExecutionStatistics(ctx, request)
+	sw.Stop()
+	domainSw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(ctx, metrics.PersistenceGetWorkflowExecutionStatisticsScope, err)
+	}
+
+	return response, err
+}
+
+func (p *visibilityPersistenceClient) CountOpenWorkflowExecutions(ctx context.Context, request *CountWorkflowExecutionsRequest) (*CountWorkflowExecutionsResponse, error) {
+	p.metricClient.IncCounter(metrics.PersistenceCountOpenWorkflowExecutionsScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistenceCountOpenWorkflowExecutionsScope, metrics.PersistenceLatency)
+	domainSw := p.domainMetrics.startTimer(metrics.PersistenceCountOpenWorkflowExecutionsScope, request.DomainUUID)
+	response, err := p.persistence.CountOpenWorkflowExecutions(ctx, request)
+	sw.Stop()
+	domainSw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(ctx, metrics.PersistenceCountOpenWorkflowExecutionsScope, err)
+	}
+
+	return response, err
+}
+
+func (p *visibilityPersistenceClient) updateErrorMetric(ctx context.Context, scope int, err error) {
 	switch err.(type) {
 	case *ConditionFailedError:
 		p.metricClient.IncCounter(scope, metrics.PersistenceErrConditionFailedCounter)
@@ -978,9 +1092,12 @@ func (p *visibilityPersistenceClient) updateErrorMetric(scope int, err error) {
 		p.metricClient.IncCounter(scope, metrics.PersistenceErrBusyCounter)
 		p.metricClient.IncCounter(scope, metrics.PersistenceFailures)
 	default:
+		requestID, callerIdentity := common.GetRequestInfo(ctx)
 		p.logger.WithFields(bark.Fields{
-			logging.TagScope: scope,
-			logging.TagErr:   err,
+			logging.TagScope:          scope,
+			logging.TagErr:            err,
+			logging.TagRequestID:      requestID,
+			logging.TagCallerIdentity: callerIdentity,
 		}).Error("Operation failed with internal error.")
 		p.metricClient.IncCounter(scope, metrics.PersistenceFailures)
 	}
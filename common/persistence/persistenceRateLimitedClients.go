@@ -21,6 +21,7 @@
 package persistence
 
 import (
+	"context"
 	"github.com/uber-common/bark"
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common/tokenbucket"
@@ -158,6 +159,7 @@ func (p *shardRateLimitedPersistenceClient) CreateShard(request *CreateShardRequ
 	}
 
 	err := p.persistence.CreateShard(request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
 	return err
 }
 
@@ -167,6 +169,7 @@ func (p *shardRateLimitedPersistenceClient) GetShard(request *GetShardRequest) (
 	}
 
 	response, err := p.persistence.GetShard(request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
 	return response, err
 }
 
@@ -176,6 +179,7 @@ func (p *shardRateLimitedPersistenceClient) UpdateShard(request *UpdateShardRequ
 	}
 
 	err := p.persistence.UpdateShard(request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
 	return err
 }
 
@@ -191,48 +195,63 @@ func (p *workflowExecutionRateLimitedPersistenceClient) GetShardID() int {
 	return p.persistence.GetShardID()
 }
 
-func (p *workflowExecutionRateLimitedPersistenceClient) CreateWorkflowExecution(request *CreateWorkflowExecutionRequest) (*CreateWorkflowExecutionResponse, error) {
+func (p *workflowExecutionRateLimitedPersistenceClient) CreateWorkflowExecution(ctx context.Context, request *CreateWorkflowExecutionRequest) (*CreateWorkflowExecutionResponse, error) {
 	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
 
-	response, err := p.persistence.CreateWorkflowExecution(request)
+	response, err := p.persistence.CreateWorkflowExecution(ctx, request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
 	return response, err
 }
 
-func (p *workflowExecutionRateLimitedPersistenceClient) GetWorkflowExecution(request *GetWorkflowExecutionRequest) (*GetWorkflowExecutionResponse, error) {
+func (p *workflowExecutionRateLimitedPersistenceClient) GetWorkflowExecution(ctx context.Context, request *GetWorkflowExecutionRequest) (*GetWorkflowExecutionResponse, error) {
 	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
 
-	response, err := p.persistence.GetWorkflowExecution(request)
+	response, err := p.persistence.GetWorkflowExecution(ctx, request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
 	return response, err
 }
 
-func (p *workflowExecutionRateLimitedPersistenceClient) UpdateWorkflowExecution(request *UpdateWorkflowExecutionRequest) (*UpdateWorkflowExecutionResponse, error) {
+func (p *workflowExecutionRateLimitedPersistenceClient) GetWorkflowExecutions(ctx context.Context, request *GetWorkflowExecutionsRequest) (*GetWorkflowExecutionsResponse, error) {
 	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
 
-	resp, err := p.persistence.UpdateWorkflowExecution(request)
+	response, err := p.persistence.GetWorkflowExecutions(ctx, request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
+	return response, err
+}
+
+func (p *workflowExecutionRateLimitedPersistenceClient) UpdateWorkflowExecution(ctx context.Context, request *UpdateWorkflowExecutionRequest) (*UpdateWorkflowExecutionResponse, error) {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return nil, ErrPersistenceLimitExceeded
+	}
+
+	resp, err := p.persistence.UpdateWorkflowExecution(ctx, request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
 	return resp, err
 }
 
-func (p *workflowExecutionRateLimitedPersistenceClient) ResetMutableState(request *ResetMutableStateRequest) error {
+func (p *workflowExecutionRateLimitedPersistenceClient) ResetMutableState(ctx context.Context, request *ResetMutableStateRequest) error {
 	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
 		return ErrPersistenceLimitExceeded
 	}
 
-	err := p.persistence.ResetMutableState(request)
+	err := p.persistence.ResetMutableState(ctx, request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
 	return err
 }
 
-func (p *workflowExecutionRateLimitedPersistenceClient) ResetWorkflowExecution(request *ResetWorkflowExecutionRequest) error {
+func (p *workflowExecutionRateLimitedPersistenceClient) ResetWorkflowExecution(ctx context.Context, request *ResetWorkflowExecutionRequest) error {
 	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
 		return ErrPersistenceLimitExceeded
 	}
 
-	err := p.persistence.ResetWorkflowExecution(request)
+	err := p.persistence.ResetWorkflowExecution(ctx, request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
 	return err
 }
 
@@ -242,96 +261,107 @@ func (p *historyV2RateLimitedPersistenceClient) CompleteForkBranch(request *Comp
 		return ErrPersistenceLimitExceeded
 	}
 	err := p.persistence.CompleteForkBranch(request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
 	return err
 }
 
-func (p *workflowExecutionRateLimitedPersistenceClient) DeleteWorkflowExecution(request *DeleteWorkflowExecutionRequest) error {
+func (p *workflowExecutionRateLimitedPersistenceClient) DeleteWorkflowExecution(ctx context.Context, request *DeleteWorkflowExecutionRequest) error {
 	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
 		return ErrPersistenceLimitExceeded
 	}
 
-	err := p.persistence.DeleteWorkflowExecution(request)
+	err := p.persistence.DeleteWorkflowExecution(ctx, request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
 	return err
 }
 
-func (p *workflowExecutionRateLimitedPersistenceClient) GetCurrentExecution(request *GetCurrentExecutionRequest) (*GetCurrentExecutionResponse, error) {
+func (p *workflowExecutionRateLimitedPersistenceClient) GetCurrentExecution(ctx context.Context, request *GetCurrentExecutionRequest) (*GetCurrentExecutionResponse, error) {
 	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
 
-	response, err := p.persistence.GetCurrentExecution(request)
+	response, err := p.persistence.GetCurrentExecution(ctx, request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
 	return response, err
 }
 
-func (p *workflowExecutionRateLimitedPersistenceClient) GetTransferTasks(request *GetTransferTasksRequest) (*GetTransferTasksResponse, error) {
+func (p *workflowExecutionRateLimitedPersistenceClient) GetTransferTasks(ctx context.Context, request *GetTransferTasksRequest) (*GetTransferTasksResponse, error) {
 	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
 
-	response, err := p.persistence.GetTransferTasks(request)
+	response, err := p.persistence.GetTransferTasks(ctx, request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
 	return response, err
 }
 
-func (p *workflowExecutionRateLimitedPersistenceClient) GetReplicationTasks(request *GetReplicationTasksRequest) (*GetReplicationTasksResponse, error) {
+func (p *workflowExecutionRateLimitedPersistenceClient) GetReplicationTasks(ctx context.Context, request *GetReplicationTasksRequest) (*GetReplicationTasksResponse, error) {
 	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
 
-	response, err := p.persistence.GetReplicationTasks(request)
+	response, err := p.persistence.GetReplicationTasks(ctx, request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
 	return response, err
 }
 
-func (p *workflowExecutionRateLimitedPersistenceClient) CompleteTransferTask(request *CompleteTransferTaskRequest) error {
+func (p *workflowExecutionRateLimitedPersistenceClient) CompleteTransferTask(ctx context.Context, request *CompleteTransferTaskRequest) error {
 	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
 		return ErrPersistenceLimitExceeded
 	}
 
-	err := p.persistence.CompleteTransferTask(request)
+	err := p.persistence.CompleteTransferTask(ctx, request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
 	return err
 }
 
-func (p *workflowExecutionRateLimitedPersistenceClient) RangeCompleteTransferTask(request *RangeCompleteTransferTaskRequest) error {
+func (p *workflowExecutionRateLimitedPersistenceClient) RangeCompleteTransferTask(ctx context.Context, request *RangeCompleteTransferTaskRequest) error {
 	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
 		return ErrPersistenceLimitExceeded
 	}
 
-	err := p.persistence.RangeCompleteTransferTask(request)
+	err := p.persistence.RangeCompleteTransferTask(ctx, request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
 	return err
 }
 
-func (p *workflowExecutionRateLimitedPersistenceClient) CompleteReplicationTask(request *CompleteReplicationTaskRequest) error {
+func (p *workflowExecutionRateLimitedPersistenceClient) CompleteReplicationTask(ctx context.Context, request *CompleteReplicationTaskRequest) error {
 	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
 		return ErrPersistenceLimitExceeded
 	}
 
-	err := p.persistence.CompleteReplicationTask(request)
+	err := p.persistence.CompleteReplicationTask(ctx, request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
 	return err
 }
 
-func (p *workflowExecutionRateLimitedPersistenceClient) GetTimerIndexTasks(request *GetTimerIndexTasksRequest) (*GetTimerIndexTasksResponse, error) {
+func (p *workflowExecutionRateLimitedPersistenceClient) GetTimerIndexTasks(ctx context.Context, request *GetTimerIndexTasksRequest) (*GetTimerIndexTasksResponse, error) {
 	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
 
-	resonse, err := p.persistence.GetTimerIndexTasks(request)
+	resonse, err := p.persistence.GetTimerIndexTasks(ctx, request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
 	return resonse, err
 }
 
-func (p *workflowExecutionRateLimitedPersistenceClient) CompleteTimerTask(request *CompleteTimerTaskRequest) error {
+func (p *workflowExecutionRateLimitedPersistenceClient) CompleteTimerTask(ctx context.Context, request *CompleteTimerTaskRequest) error {
 	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
 		return ErrPersistenceLimitExceeded
 	}
 
-	err := p.persistence.CompleteTimerTask(request)
+	err := p.persistence.CompleteTimerTask(ctx, request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
 	return err
 }
 
-func (p *workflowExecutionRateLimitedPersistenceClient) RangeCompleteTimerTask(request *RangeCompleteTimerTaskRequest) error {
+func (p *workflowExecutionRateLimitedPersistenceClient) RangeCompleteTimerTask(ctx context.Context, request *RangeCompleteTimerTaskRequest) error {
 	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
 		return ErrPersistenceLimitExceeded
 	}
 
-	err := p.persistence.RangeCompleteTimerTask(request)
+	err := p.persistence.RangeCompleteTimerTask(ctx, request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
 	return err
 }
 
@@ -349,6 +379,7 @@ func (p *taskRateLimitedPersistenceClient) CreateTasks(request *CreateTasksReque
 	}
 
 	response, err := p.persistence.CreateTasks(request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
 	return response, err
 }
 
@@ -358,6 +389,7 @@ func (p *taskRateLimitedPersistenceClient) GetTasks(request *GetTasksRequest) (*
 	}
 
 	response, err := p.persistence.GetTasks(request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
 	return response, err
 }
 
@@ -367,6 +399,7 @@ func (p *taskRateLimitedPersistenceClient) CompleteTask(request *CompleteTaskReq
 	}
 
 	err := p.persistence.CompleteTask(request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
 	return err
 }
 
@@ -374,7 +407,9 @@ func (p *taskRateLimitedPersistenceClient) CompleteTasksLessThan(request *Comple
 	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
 		return 0, ErrPersistenceLimitExceeded
 	}
-	return p.persistence.CompleteTasksLessThan(request)
+	response, err := p.persistence.CompleteTasksLessThan(request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
+	return response, err
 }
 
 func (p *taskRateLimitedPersistenceClient) LeaseTaskList(request *LeaseTaskListRequest) (*LeaseTaskListResponse, error) {
@@ -383,6 +418,7 @@ func (p *taskRateLimitedPersistenceClient) LeaseTaskList(request *LeaseTaskListR
 	}
 
 	response, err := p.persistence.LeaseTaskList(request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
 	return response, err
 }
 
@@ -392,6 +428,7 @@ func (p *taskRateLimitedPersistenceClient) UpdateTaskList(request *UpdateTaskLis
 	}
 
 	response, err := p.persistence.UpdateTaskList(request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
 	return response, err
 }
 
@@ -399,14 +436,18 @@ func (p *taskRateLimitedPersistenceClient) ListTaskList(request *ListTaskListReq
 	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
-	return p.persistence.ListTaskList(request)
+	response, err := p.persistence.ListTaskList(request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
+	return response, err
 }
 
 func (p *taskRateLimitedPersistenceClient) DeleteTaskList(request *DeleteTaskListRequest) error {
 	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
 		return ErrPersistenceLimitExceeded
 	}
-	return p.persistence.DeleteTaskList(request)
+	err := p.persistence.DeleteTaskList(request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
+	return err
 }
 
 func (p *taskRateLimitedPersistenceClient) Close() {
@@ -423,6 +464,7 @@ func (p *historyRateLimitedPersistenceClient) AppendHistoryEvents(request *Appen
 	}
 
 	resp, err := p.persistence.AppendHistoryEvents(request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
 	return resp, err
 }
 
@@ -432,6 +474,7 @@ func (p *historyRateLimitedPersistenceClient) GetWorkflowExecutionHistory(reques
 	}
 
 	response, err := p.persistence.GetWorkflowExecutionHistory(request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
 	return response, err
 }
 
@@ -441,6 +484,7 @@ func (p *historyRateLimitedPersistenceClient) GetWorkflowExecutionHistoryByBatch
 	}
 
 	response, err := p.persistence.GetWorkflowExecutionHistoryByBatch(request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
 	return response, err
 }
 
@@ -450,6 +494,7 @@ func (p *historyRateLimitedPersistenceClient) DeleteWorkflowExecutionHistory(req
 	}
 
 	err := p.persistence.DeleteWorkflowExecutionHistory(request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
 	return err
 }
 
@@ -461,66 +506,73 @@ func (p *metadataRateLimitedPersistenceClient) GetName() string {
 	return p.persistence.GetName()
 }
 
-func (p *metadataRateLimitedPersistenceClient) CreateDomain(request *CreateDomainRequest) (*CreateDomainResponse, error) {
+func (p *metadataRateLimitedPersistenceClient) CreateDomain(ctx context.Context, request *CreateDomainRequest) (*CreateDomainResponse, error) {
 	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
 
-	response, err := p.persistence.CreateDomain(request)
+	response, err := p.persistence.CreateDomain(ctx, request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
 	return response, err
 }
 
-func (p *metadataRateLimitedPersistenceClient) GetDomain(request *GetDomainRequest) (*GetDomainResponse, error) {
+func (p *metadataRateLimitedPersistenceClient) GetDomain(ctx context.Context, request *GetDomainRequest) (*GetDomainResponse, error) {
 	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
 
-	response, err := p.persistence.GetDomain(request)
+	response, err := p.persistence.GetDomain(ctx, request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
 	return response, err
 }
 
-func (p *metadataRateLimitedPersistenceClient) UpdateDomain(request *UpdateDomainRequest) error {
+func (p *metadataRateLimitedPersistenceClient) UpdateDomain(ctx context.Context, request *UpdateDomainRequest) error {
 	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
 		return ErrPersistenceLimitExceeded
 	}
 
-	err := p.persistence.UpdateDomain(request)
+	err := p.persistence.UpdateDomain(ctx, request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
 	return err
 }
 
-func (p *metadataRateLimitedPersistenceClient) DeleteDomain(request *DeleteDomainRequest) error {
+func (p *metadataRateLimitedPersistenceClient) DeleteDomain(ctx context.Context, request *DeleteDomainRequest) error {
 	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
 		return ErrPersistenceLimitExceeded
 	}
 
-	err := p.persistence.DeleteDomain(request)
+	err := p.persistence.DeleteDomain(ctx, request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
 	return err
 }
 
-func (p *metadataRateLimitedPersistenceClient) DeleteDomainByName(request *DeleteDomainByNameRequest) error {
+func (p *metadataRateLimitedPersistenceClient) DeleteDomainByName(ctx context.Context, request *DeleteDomainByNameRequest) error {
 	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
 		return ErrPersistenceLimitExceeded
 	}
 
-	err := p.persistence.DeleteDomainByName(request)
+	err := p.persistence.DeleteDomainByName(ctx, request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
 	return err
 }
 
-func (p *metadataRateLimitedPersistenceClient) ListDomains(request *ListDomainsRequest) (*ListDomainsResponse, error) {
+func (p *metadataRateLimitedPersistenceClient) ListDomains(ctx context.Context, request *ListDomainsRequest) (*ListDomainsResponse, error) {
 	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
 
-	response, err := p.persistence.ListDomains(request)
+	response, err := p.persistence.ListDomains(ctx, request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
 	return response, err
 }
 
-func (p *metadataRateLimitedPersistenceClient) GetMetadata() (*GetMetadataResponse, error) {
+func (p *metadataRateLimitedPersistenceClient) GetMetadata(ctx context.Context) (*GetMetadataResponse, error) {
 	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
 
-	response, err := p.persistence.GetMetadata()
+	response, err := p.persistence.GetMetadata(ctx)
+	recordRateLimiterFeedback(p.rateLimiter, err)
 	return response, err
 }
 
@@ -532,101 +584,133 @@ func (p *visibilityRateLimitedPersistenceClient) GetName() string {
 	return p.persistence.GetName()
 }
 
-func (p *visibilityRateLimitedPersistenceClient) RecordWorkflowExecutionStarted(request *RecordWorkflowExecutionStartedRequest) error {
+func (p *visibilityRateLimitedPersistenceClient) RecordWorkflowExecutionStarted(ctx context.Context, request *RecordWorkflowExecutionStartedRequest) error {
 	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
 		return ErrPersistenceLimitExceeded
 	}
 
-	err := p.persistence.RecordWorkflowExecutionStarted(request)
+	err := p.persistence.RecordWorkflowExecutionStarted(ctx, request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
 	return err
 }
 
-func (p *visibilityRateLimitedPersistenceClient) RecordWorkflowExecutionClosed(request *RecordWorkflowExecutionClosedRequest) error {
+func (p *visibilityRateLimitedPersistenceClient) RecordWorkflowExecutionClosed(ctx context.Context, request *RecordWorkflowExecutionClosedRequest) error {
 	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
 		return ErrPersistenceLimitExceeded
 	}
 
-	err := p.persistence.RecordWorkflowExecutionClosed(request)
+	err := p.persistence.RecordWorkflowExecutionClosed(ctx, request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
 	return err
 }
 
-func (p *visibilityRateLimitedPersistenceClient) ListOpenWorkflowExecutions(request *ListWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error) {
+func (p *visibilityRateLimitedPersistenceClient) ListOpenWorkflowExecutions(ctx context.Context, request *ListWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error) {
 	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
 
-	response, err := p.persistence.ListOpenWorkflowExecutions(request)
+	response, err := p.persistence.ListOpenWorkflowExecutions(ctx, request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
 	return response, err
 }
 
-func (p *visibilityRateLimitedPersistenceClient) ListClosedWorkflowExecutions(request *ListWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error) {
+func (p *visibilityRateLimitedPersistenceClient) ListClosedWorkflowExecutions(ctx context.Context, request *ListWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error) {
 	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
 
-	response, err := p.persistence.ListClosedWorkflowExecutions(request)
+	response, err := p.persistence.ListClosedWorkflowExecutions(ctx, request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
 	return response, err
 }
 
-func (p *visibilityRateLimitedPersistenceClient) ListOpenWorkflowExecutionsByType(request *ListWorkflowExecutionsByTypeRequest) (*ListWorkflowExecutionsResponse, error) {
+func (p *visibilityRateLimitedPersistenceClient) ListOpenWorkflowExecutionsByType(ctx context.Context, request *ListWorkflowExecutionsByTypeRequest) (*ListWorkflowExecutionsResponse, error) {
 	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
 
-	response, err := p.persistence.ListOpenWorkflowExecutionsByType(request)
+	response, err := p.persistence.ListOpenWorkflowExecutionsByType(ctx, request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
 	return response, err
 }
 
-func (p *visibilityRateLimitedPersistenceClient) ListClosedWorkflowExecutionsByType(request *ListWorkflowExecutionsByTypeRequest) (*ListWorkflowExecutionsResponse, error) {
+func (p *visibilityRateLimitedPersistenceClient) ListClosedWorkflowExecutionsByType(ctx context.Context, request *ListWorkflowExecutionsByTypeRequest) (*ListWorkflowExecutionsResponse, error) {
 	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
 
-	response, err := p.persistence.ListClosedWorkflowExecutionsByType(request)
+	response, err := p.persistence.ListClosedWorkflowExecutionsByType(ctx, request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
 	return response, err
 }
 
-func (p *visibilityRateLimitedPersistenceClient) ListOpenWorkflowExecutionsByWorkflowID(request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error) {
+func (p *visibilityRateLimitedPersistenceClient) ListOpenWorkflowExecutionsByWorkflowID(ctx context.Context, request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error) {
 	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
 
-	response, err := p.persistence.ListOpenWorkflowExecutionsByWorkflowID(request)
+	response, err := p.persistence.ListOpenWorkflowExecutionsByWorkflowID(ctx, request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
 	return response, err
 }
 
-func (p *visibilityRateLimitedPersistenceClient) ListClosedWorkflowExecutionsByWorkflowID(request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error) {
+func (p *visibilityRateLimitedPersistenceClient) ListClosedWorkflowExecutionsByWorkflowID(ctx context.Context, request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error) {
 	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
 
-	response, err := p.persistence.ListClosedWorkflowExecutionsByWorkflowID(request)
+	response, err := p.persistence.ListClosedWorkflowExecutionsByWorkflowID(ctx, request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
 	return response, err
 }
 
-func (p *visibilityRateLimitedPersistenceClient) ListClosedWorkflowExecutionsByStatus(request *ListClosedWorkflowExecutionsByStatusRequest) (*ListWorkflowExecutionsResponse, error) {
+func (p *visibilityRateLimitedPersistenceClient) ListClosedWorkflowExecutionsByStatus(ctx context.Context, request *ListClosedWorkflowExecutionsByStatusRequest) (*ListWorkflowExecutionsResponse, error) {
 	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
 
-	response, err := p.persistence.ListClosedWorkflowExecutionsByStatus(request)
+	response, err := p.persistence.ListClosedWorkflowExecutionsByStatus(ctx, request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
 	return response, err
 }
 
-func (p *visibilityRateLimitedPersistenceClient) GetClosedWorkflowExecution(request *GetClosedWorkflowExecutionRequest) (*GetClosedWorkflowExecutionResponse, error) {
+func (p *visibilityRateLimitedPersistenceClient) GetClosedWorkflowExecution(ctx context.Context, request *GetClosedWorkflowExecutionRequest) (*GetClosedWorkflowExecutionResponse, error) {
 	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
 
-	response, err := p.persistence.GetClosedWorkflowExecution(request)
+	response, err := p.persistence.GetClosedWorkflowExecution(ctx, request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
 	return response, err
 }
 
-func (p *visibilityRateLimitedPersistenceClient) DeleteWorkflowExecution(request *VisibilityDeleteWorkflowExecutionRequest) error {
+func (p *visibilityRateLimitedPersistenceClient) DeleteWorkflowExecution(ctx context.Context, request *VisibilityDeleteWorkflowExecutionRequest) error {
 	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
 		return ErrPersistenceLimitExceeded
 	}
-	return p.persistence.DeleteWorkflowExecution(request)
+	err := p.persistence.DeleteWorkflowExecution(ctx, request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
+	return err
+}
+
+func (p *visibilityRateLimitedPersistenceClient) GetWorkflowExecutionStatistics(ctx context.Context, request *GetWorkflowExecutionStatisticsRequest) (*GetWorkflowExecutionStatisticsResponse, error) {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return nil, ErrPersistenceLimitExceeded
+	}
+
+	response, err := p.persistence.GetWorkflowExecutionStatistics(ctx, request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
+	return response, err
+}
+
+func (p *visibilityRateLimitedPersistenceClient) CountOpenWorkflowExecutions(ctx context.Context, request *CountWorkflowExecutionsRequest) (*CountWorkflowExecutionsResponse, error) {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return nil, ErrPersistenceLimitExceeded
+	}
+
+	response, err := p.persistence.CountOpenWorkflowExecutions(ctx, request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
+	return response, err
 }
 
 func (p *visibilityRateLimitedPersistenceClient) Close() {
@@ -646,7 +730,9 @@ func (p *historyV2RateLimitedPersistenceClient) AppendHistoryNodes(request *Appe
 	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
-	return p.persistence.AppendHistoryNodes(request)
+	response, err := p.persistence.AppendHistoryNodes(request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
+	return response, err
 }
 
 // ReadHistoryBranch returns history node data for a branch
@@ -655,6 +741,7 @@ func (p *historyV2RateLimitedPersistenceClient) ReadHistoryBranch(request *ReadH
 		return nil, ErrPersistenceLimitExceeded
 	}
 	response, err := p.persistence.ReadHistoryBranch(request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
 	return response, err
 }
 
@@ -664,6 +751,7 @@ func (p *historyV2RateLimitedPersistenceClient) ReadHistoryBranchByBatch(request
 		return nil, ErrPersistenceLimitExceeded
 	}
 	response, err := p.persistence.ReadHistoryBranchByBatch(request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
 	return response, err
 }
 
@@ -673,6 +761,7 @@ func (p *historyV2RateLimitedPersistenceClient) ForkHistoryBranch(request *ForkH
 		return nil, ErrPersistenceLimitExceeded
 	}
 	response, err := p.persistence.ForkHistoryBranch(request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
 	return response, err
 }
 
@@ -682,6 +771,7 @@ func (p *historyV2RateLimitedPersistenceClient) DeleteHistoryBranch(request *Del
 		return ErrPersistenceLimitExceeded
 	}
 	err := p.persistence.DeleteHistoryBranch(request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
 	return err
 }
 
@@ -691,5 +781,6 @@ func (p *historyV2RateLimitedPersistenceClient) GetHistoryTree(request *GetHisto
 		return nil, ErrPersistenceLimitExceeded
 	}
 	response, err := p.persistence.GetHistoryTree(request)
+	recordRateLimiterFeedback(p.rateLimiter, err)
 	return response, err
 }
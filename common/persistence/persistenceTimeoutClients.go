@@ -0,0 +1,319 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"context"
+
+	"github.com/uber/cadence/common/service/config"
+)
+
+type (
+	workflowExecutionTimeoutPersistenceClient struct {
+		timeouts    *config.PersistenceTimeouts
+		persistence ExecutionManager
+	}
+
+	metadataTimeoutPersistenceClient struct {
+		timeouts    *config.PersistenceTimeouts
+		persistence MetadataManager
+	}
+
+	visibilityTimeoutPersistenceClient struct {
+		timeouts    *config.PersistenceTimeouts
+		persistence VisibilityManager
+	}
+)
+
+var _ ExecutionManager = (*workflowExecutionTimeoutPersistenceClient)(nil)
+var _ MetadataManager = (*metadataTimeoutPersistenceClient)(nil)
+var _ VisibilityManager = (*visibilityTimeoutPersistenceClient)(nil)
+
+// NewWorkflowExecutionPersistenceTimeoutClient creates a client to manage executions which bounds
+// each call with the deadline configured for its type of operation
+func NewWorkflowExecutionPersistenceTimeoutClient(persistence ExecutionManager, timeouts *config.PersistenceTimeouts) ExecutionManager {
+	return &workflowExecutionTimeoutPersistenceClient{
+		persistence: persistence,
+		timeouts:    timeouts,
+	}
+}
+
+// NewMetadataPersistenceTimeoutClient creates a MetadataManager client which bounds
+// each call with the deadline configured for its type of operation
+func NewMetadataPersistenceTimeoutClient(persistence MetadataManager, timeouts *config.PersistenceTimeouts) MetadataManager {
+	return &metadataTimeoutPersistenceClient{
+		persistence: persistence,
+		timeouts:    timeouts,
+	}
+}
+
+// NewVisibilityPersistenceTimeoutClient creates a VisibilityManager client which bounds
+// each call with the deadline configured for its type of operation
+func NewVisibilityPersistenceTimeoutClient(persistence VisibilityManager, timeouts *config.PersistenceTimeouts) VisibilityManager {
+	return &visibilityTimeoutPersistenceClient{
+		persistence: persistence,
+		timeouts:    timeouts,
+	}
+}
+
+func (p *workflowExecutionTimeoutPersistenceClient) GetName() string {
+	return p.persistence.GetName()
+}
+
+func (p *workflowExecutionTimeoutPersistenceClient) GetShardID() int {
+	return p.persistence.GetShardID()
+}
+
+func (p *workflowExecutionTimeoutPersistenceClient) CreateWorkflowExecution(ctx context.Context, request *CreateWorkflowExecutionRequest) (*CreateWorkflowExecutionResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.WriteTimeout())
+	defer cancel()
+	return p.persistence.CreateWorkflowExecution(ctx, request)
+}
+
+func (p *workflowExecutionTimeoutPersistenceClient) GetWorkflowExecution(ctx context.Context, request *GetWorkflowExecutionRequest) (*GetWorkflowExecutionResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.ReadTimeout())
+	defer cancel()
+	return p.persistence.GetWorkflowExecution(ctx, request)
+}
+
+func (p *workflowExecutionTimeoutPersistenceClient) GetWorkflowExecutions(ctx context.Context, request *GetWorkflowExecutionsRequest) (*GetWorkflowExecutionsResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.ReadTimeout())
+	defer cancel()
+	return p.persistence.GetWorkflowExecutions(ctx, request)
+}
+
+func (p *workflowExecutionTimeoutPersistenceClient) UpdateWorkflowExecution(ctx context.Context, request *UpdateWorkflowExecutionRequest) (*UpdateWorkflowExecutionResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.WriteTimeout())
+	defer cancel()
+	return p.persistence.UpdateWorkflowExecution(ctx, request)
+}
+
+func (p *workflowExecutionTimeoutPersistenceClient) ResetMutableState(ctx context.Context, request *ResetMutableStateRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.WriteTimeout())
+	defer cancel()
+	return p.persistence.ResetMutableState(ctx, request)
+}
+
+func (p *workflowExecutionTimeoutPersistenceClient) ResetWorkflowExecution(ctx context.Context, request *ResetWorkflowExecutionRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.WriteTimeout())
+	defer cancel()
+	return p.persistence.ResetWorkflowExecution(ctx, request)
+}
+
+func (p *workflowExecutionTimeoutPersistenceClient) DeleteWorkflowExecution(ctx context.Context, request *DeleteWorkflowExecutionRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.WriteTimeout())
+	defer cancel()
+	return p.persistence.DeleteWorkflowExecution(ctx, request)
+}
+
+func (p *workflowExecutionTimeoutPersistenceClient) GetCurrentExecution(ctx context.Context, request *GetCurrentExecutionRequest) (*GetCurrentExecutionResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.ReadTimeout())
+	defer cancel()
+	return p.persistence.GetCurrentExecution(ctx, request)
+}
+
+func (p *workflowExecutionTimeoutPersistenceClient) GetTransferTasks(ctx context.Context, request *GetTransferTasksRequest) (*GetTransferTasksResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.ListTimeout())
+	defer cancel()
+	return p.persistence.GetTransferTasks(ctx, request)
+}
+
+func (p *workflowExecutionTimeoutPersistenceClient) CompleteTransferTask(ctx context.Context, request *CompleteTransferTaskRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.WriteTimeout())
+	defer cancel()
+	return p.persistence.CompleteTransferTask(ctx, request)
+}
+
+func (p *workflowExecutionTimeoutPersistenceClient) RangeCompleteTransferTask(ctx context.Context, request *RangeCompleteTransferTaskRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.WriteTimeout())
+	defer cancel()
+	return p.persistence.RangeCompleteTransferTask(ctx, request)
+}
+
+func (p *workflowExecutionTimeoutPersistenceClient) GetReplicationTasks(ctx context.Context, request *GetReplicationTasksRequest) (*GetReplicationTasksResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.ListTimeout())
+	defer cancel()
+	return p.persistence.GetReplicationTasks(ctx, request)
+}
+
+func (p *workflowExecutionTimeoutPersistenceClient) CompleteReplicationTask(ctx context.Context, request *CompleteReplicationTaskRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.WriteTimeout())
+	defer cancel()
+	return p.persistence.CompleteReplicationTask(ctx, request)
+}
+
+func (p *workflowExecutionTimeoutPersistenceClient) GetTimerIndexTasks(ctx context.Context, request *GetTimerIndexTasksRequest) (*GetTimerIndexTasksResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.ListTimeout())
+	defer cancel()
+	return p.persistence.GetTimerIndexTasks(ctx, request)
+}
+
+func (p *workflowExecutionTimeoutPersistenceClient) CompleteTimerTask(ctx context.Context, request *CompleteTimerTaskRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.WriteTimeout())
+	defer cancel()
+	return p.persistence.CompleteTimerTask(ctx, request)
+}
+
+func (p *workflowExecutionTimeoutPersistenceClient) RangeCompleteTimerTask(ctx context.Context, request *RangeCompleteTimerTaskRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.WriteTimeout())
+	defer cancel()
+	return p.persistence.RangeCompleteTimerTask(ctx, request)
+}
+
+func (p *workflowExecutionTimeoutPersistenceClient) Close() {
+	p.persistence.Close()
+}
+
+func (p *metadataTimeoutPersistenceClient) GetName() string {
+	return p.persistence.GetName()
+}
+
+func (p *metadataTimeoutPersistenceClient) CreateDomain(ctx context.Context, request *CreateDomainRequest) (*CreateDomainResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.WriteTimeout())
+	defer cancel()
+	return p.persistence.CreateDomain(ctx, request)
+}
+
+func (p *metadataTimeoutPersistenceClient) GetDomain(ctx context.Context, request *GetDomainRequest) (*GetDomainResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.ReadTimeout())
+	defer cancel()
+	return p.persistence.GetDomain(ctx, request)
+}
+
+func (p *metadataTimeoutPersistenceClient) UpdateDomain(ctx context.Context, request *UpdateDomainRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.WriteTimeout())
+	defer cancel()
+	return p.persistence.UpdateDomain(ctx, request)
+}
+
+func (p *metadataTimeoutPersistenceClient) DeleteDomain(ctx context.Context, request *DeleteDomainRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.WriteTimeout())
+	defer cancel()
+	return p.persistence.DeleteDomain(ctx, request)
+}
+
+func (p *metadataTimeoutPersistenceClient) DeleteDomainByName(ctx context.Context, request *DeleteDomainByNameRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.WriteTimeout())
+	defer cancel()
+	return p.persistence.DeleteDomainByName(ctx, request)
+}
+
+func (p *metadataTimeoutPersistenceClient) ListDomains(ctx context.Context, request *ListDomainsRequest) (*ListDomainsResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.ListTimeout())
+	defer cancel()
+	return p.persistence.ListDomains(ctx, request)
+}
+
+func (p *metadataTimeoutPersistenceClient) GetMetadata(ctx context.Context) (*GetMetadataResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.ReadTimeout())
+	defer cancel()
+	return p.persistence.GetMetadata(ctx)
+}
+
+func (p *metadataTimeoutPersistenceClient) Close() {
+	p.persistence.Close()
+}
+
+func (p *visibilityTimeoutPersistenceClient) GetName() string {
+	return p.persistence.GetName()
+}
+
+func (p *visibilityTimeoutPersistenceClient) RecordWorkflowExecutionStarted(ctx context.Context, request *RecordWorkflowExecutionStartedRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.WriteTimeout())
+	defer cancel()
+	return p.persistence.RecordWorkflowExecutionStarted(ctx, request)
+}
+
+func (p *visibilityTimeoutPersistenceClient) RecordWorkflowExecutionClosed(ctx context.Context, request *RecordWorkflowExecutionClosedRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.WriteTimeout())
+	defer cancel()
+	return p.persistence.RecordWorkflowExecutionClosed(ctx, request)
+}
+
+func (p *visibilityTimeoutPersistenceClient) ListOpenWorkflowExecutions(ctx context.Context, request *ListWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.ListTimeout())
+	defer cancel()
+	return p.persistence.ListOpenWorkflowExecutions(ctx, request)
+}
+
+func (p *visibilityTimeoutPersistenceClient) ListClosedWorkflowExecutions(ctx context.Context, request *ListWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.ListTimeout())
+	defer cancel()
+	return p.persistence.ListClosedWorkflowExecutions(ctx, request)
+}
+
+func (p *visibilityTimeoutPersistenceClient) ListOpenWorkflowExecutionsByType(ctx context.Context, request *ListWorkflowExecutionsByTypeRequest) (*ListWorkflowExecutionsResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.ListTimeout())
+	defer cancel()
+	return p.persistence.ListOpenWorkflowExecutionsByType(ctx, request)
+}
+
+func (p *visibilityTimeoutPersistenceClient) ListClosedWorkflowExecutionsByType(ctx context.Context, request *ListWorkflowExecutionsByTypeRequest) (*ListWorkflowExecutionsResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.ListTimeout())
+	defer cancel()
+	return p.persistence.ListClosedWorkflowExecutionsByType(ctx, request)
+}
+
+func (p *visibilityTimeoutPersistenceClient) ListOpenWorkflowExecutionsByWorkflowID(ctx context.Context, request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.ListTimeout())
+	defer cancel()
+	return p.persistence.ListOpenWorkflowExecutionsByWorkflowID(ctx, request)
+}
+
+func (p *visibilityTimeoutPersistenceClient) ListClosedWorkflowExecutionsByWorkflowID(ctx context.Context, request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.ListTimeout())
+	defer cancel()
+	return p.persistence.ListClosedWorkflowExecutionsByWorkflowID(ctx, request)
+}
+
+func (p *visibilityTimeoutPersistenceClient) ListClosedWorkflowExecutionsByStatus(ctx context.Context, request *ListClosedWorkflowExecutionsByStatusRequest) (*ListWorkflowExecutionsResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.ListTimeout())
+	defer cancel()
+	return p.persistence.ListClosedWorkflowExecutionsByStatus(ctx, request)
+}
+
+func (p *visibilityTimeoutPersistenceClient) GetClosedWorkflowExecution(ctx context.Context, request *GetClosedWorkflowExecutionRequest) (*GetClosedWorkflowExecutionResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.ReadTimeout())
+	defer cancel()
+	return p.persistence.GetClosedWorkflowExecution(ctx, request)
+}
+
+func (p *visibilityTimeoutPersistenceClient) DeleteWorkflowExecution(ctx context.Context, request *VisibilityDeleteWorkflowExecutionRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.WriteTimeout())
+	defer cancel()
+	return p.persistence.DeleteWorkflowExecution(ctx, request)
+}
+
+func (p *visibilityTimeoutPersistenceClient) GetWorkflowExecutionStatistics(ctx context.Context, request *GetWorkflowExecutionStatisticsRequest) (*GetWorkflowExecutionStatisticsResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.ListTimeout())
+	defer cancel()
+	return p.persistence.GetWorkflowExecutionStatistics(ctx, request)
+}
+
+func (p *visibilityTimeoutPersistenceClient) CountOpenWorkflowExecutions(ctx context.Context, request *CountWorkflowExecutionsRequest) (*CountWorkflowExecutionsResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.ListTimeout())
+	defer cancel()
+	return p.persistence.CountOpenWorkflowExecutions(ctx, request)
+}
+
+func (p *visibilityTimeoutPersistenceClient) Close() {
+	p.persistence.Close()
+}
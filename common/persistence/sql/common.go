@@ -22,6 +22,7 @@ package sql
 
 import (
 	"bytes"
+	"context"
 	"encoding/binary"
 	"encoding/gob"
 	"fmt"
@@ -50,7 +51,13 @@ func (m *sqlStore) Close() {
 }
 
 func (m *sqlStore) txExecute(operation string, f func(tx sqldb.Tx) error) error {
-	tx, err := m.db.BeginTx()
+	return m.txExecuteWithContext(context.Background(), operation, f)
+}
+
+// txExecuteWithContext executes f under a transaction bound to ctx, the transaction
+// is rolled back if ctx is done before it commits
+func (m *sqlStore) txExecuteWithContext(ctx context.Context, operation string, f func(tx sqldb.Tx) error) error {
+	tx, err := m.db.BeginTx(ctx)
 	if err != nil {
 		return &workflow.InternalServiceError{
 			Message: fmt.Sprintf("%s failed. Failed to start transaction. Error: %v", operation, err),
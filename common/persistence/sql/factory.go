@@ -38,17 +38,25 @@ type (
 		clusterName      string
 		logger           bark.Logger
 		execStoreFactory *executionStoreFactory
+		// shardRanges is shared by the shard and execution stores of this factory
+		shardRanges *shardRangeCache
 	}
 	executionStoreFactory struct {
-		db     sqldb.Interface
-		logger bark.Logger
+		db          sqldb.Interface
+		logger      bark.Logger
+		shardRanges *shardRangeCache
 	}
 )
 
 // NewFactory returns an instance of a factory object which can be used to create
 // datastores backed by any kind of SQL store
 func NewFactory(cfg config.SQL, clusterName string, logger bark.Logger) *Factory {
-	return &Factory{cfg: cfg, clusterName: clusterName, logger: logger}
+	return &Factory{
+		cfg:         cfg,
+		clusterName: clusterName,
+		logger:      logger,
+		shardRanges: newShardRangeCache(cfg.ShardRangeCacheTTL),
+	}
 }
 
 // NewTaskStore returns a new task store
@@ -58,7 +66,7 @@ func (f *Factory) NewTaskStore() (p.TaskStore, error) {
 
 // NewShardStore returns a new shard store
 func (f *Factory) NewShardStore() (p.ShardStore, error) {
-	return newShardPersistence(f.cfg, f.clusterName, f.logger)
+	return newShardPersistence(f.cfg, f.clusterName, f.shardRanges, f.logger)
 }
 
 // NewHistoryStore returns a new history store
@@ -76,6 +84,11 @@ func (f *Factory) NewMetadataStore() (p.MetadataStore, error) {
 	return newMetadataPersistenceV2(f.cfg, f.clusterName, f.logger)
 }
 
+// NewClusterMetadataStore returns a new cluster metadata store
+func (f *Factory) NewClusterMetadataStore() (p.ClusterMetadataStore, error) {
+	return newClusterMetadataPersistence(f.cfg, f.logger)
+}
+
 // NewMetadataStoreV1 returns the default metadatastore
 func (f *Factory) NewMetadataStoreV1() (p.MetadataStore, error) {
 	return f.NewMetadataStore()
@@ -123,23 +136,24 @@ func (f *Factory) newExecutionStoreFactory() (*executionStoreFactory, error) {
 	f.Lock()
 	defer f.Unlock()
 	var err error
-	f.execStoreFactory, err = newExecutionStoreFactory(f.cfg, f.logger)
+	f.execStoreFactory, err = newExecutionStoreFactory(f.cfg, f.shardRanges, f.logger)
 	return f.execStoreFactory, err
 }
 
-func newExecutionStoreFactory(cfg config.SQL, logger bark.Logger) (*executionStoreFactory, error) {
+func newExecutionStoreFactory(cfg config.SQL, shardRanges *shardRangeCache, logger bark.Logger) (*executionStoreFactory, error) {
 	db, err := storage.NewSQLDB(&cfg)
 	if err != nil {
 		return nil, err
 	}
 	return &executionStoreFactory{
-		db:     db,
-		logger: logger,
+		db:          db,
+		logger:      logger,
+		shardRanges: shardRanges,
 	}, nil
 }
 
 func (f *executionStoreFactory) new(shardID int) (p.ExecutionStore, error) {
-	return NewSQLExecutionStore(f.db, f.logger, shardID)
+	return newSQLExecutionStore(f.db, f.shardRanges, f.logger, shardID), nil
 }
 
 // close closes the factory
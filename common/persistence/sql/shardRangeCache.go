@@ -0,0 +1,108 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package sql
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/persistence"
+)
+
+type (
+	// shardRangeCache remembers the range ID of each shard last seen in the shards table, so execution stores
+	// can validate the range ID of their requests without read locking the shard row in every transaction.
+	// It is shared by the shard store, which pre-warms it whenever a shard is read or its range is renewed,
+	// and the execution stores, which invalidate a shard on conditional update failures.
+	shardRangeCache struct {
+		sync.RWMutex
+		ttl        time.Duration
+		timeSource clock.TimeSource
+		shards     map[int]shardRange
+	}
+
+	shardRange struct {
+		rangeID     int64
+		validatedAt time.Time
+	}
+)
+
+// newShardRangeCache creates a shard range cache trusting range IDs for ttl after they were read from
+// the shards table, it returns nil if ttl is not positive which disables the cache
+func newShardRangeCache(ttl time.Duration) *shardRangeCache {
+	if ttl <= 0 {
+		return nil
+	}
+	return &shardRangeCache{
+		ttl:        ttl,
+		timeSource: clock.NewRealTimeSource(),
+		shards:     make(map[int]shardRange),
+	}
+}
+
+// validate returns true if rangeID is the cached range ID of the shard and ShardOwnershipLostError if the
+// cached range ID is newer, it returns false if the range ID has to be validated against the shards table
+func (c *shardRangeCache) validate(shardID int, rangeID int64) (bool, error) {
+	if c == nil {
+		return false, nil
+	}
+
+	c.RLock()
+	cached, ok := c.shards[shardID]
+	c.RUnlock()
+	if !ok || c.timeSource.Now().Sub(cached.validatedAt) >= c.ttl {
+		return false, nil
+	}
+	if rangeID < cached.rangeID {
+		return false, &persistence.ShardOwnershipLostError{
+			ShardID: shardID,
+			Msg:     fmt.Sprintf("Failed to lock shard. Previous range ID: %v; new range ID: %v", rangeID, cached.rangeID),
+		}
+	}
+	return rangeID == cached.rangeID, nil
+}
+
+// put remembers the range ID of the shard which was just read from the shards table
+func (c *shardRangeCache) put(shardID int, rangeID int64) {
+	if c == nil {
+		return
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	if cached, ok := c.shards[shardID]; ok && cached.rangeID > rangeID {
+		return
+	}
+	c.shards[shardID] = shardRange{rangeID: rangeID, validatedAt: c.timeSource.Now()}
+}
+
+// invalidate forgets the range ID of the shard, so it is validated against the shards table on next use
+func (c *shardRangeCache) invalidate(shardID int) {
+	if c == nil {
+		return
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	delete(c.shards, shardID)
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package sql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/persistence"
+)
+
+type (
+	shardRangeCacheSuite struct {
+		suite.Suite
+		timeSource *clock.EventTimeSource
+		cache      *shardRangeCache
+	}
+)
+
+func TestShardRangeCacheSuite(t *testing.T) {
+	s := new(shardRangeCacheSuite)
+	suite.Run(t, s)
+}
+
+func (s *shardRangeCacheSuite) SetupTest() {
+	s.timeSource = clock.NewEventTimeSource()
+	s.timeSource.Update(time.Now())
+	s.cache = newShardRangeCache(time.Minute)
+	s.cache.timeSource = s.timeSource
+}
+
+func (s *shardRangeCacheSuite) TestDisabled() {
+	cache := newShardRangeCache(0)
+	s.Nil(cache)
+
+	cache.put(1, 10)
+	cached, err := cache.validate(1, 10)
+	s.NoError(err)
+	s.False(cached)
+	cache.invalidate(1)
+}
+
+func (s *shardRangeCacheSuite) TestValidate() {
+	cached, err := s.cache.validate(1, 10)
+	s.NoError(err)
+	s.False(cached)
+
+	s.cache.put(1, 10)
+	cached, err = s.cache.validate(1, 10)
+	s.NoError(err)
+	s.True(cached)
+
+	// a newer range ID is validated against the shards table
+	cached, err = s.cache.validate(1, 11)
+	s.NoError(err)
+	s.False(cached)
+
+	// an older range ID means the shard is owned by someone else
+	_, err = s.cache.validate(1, 9)
+	s.IsType(&persistence.ShardOwnershipLostError{}, err)
+
+	// an older range ID never replaces a newer one
+	s.cache.put(1, 9)
+	cached, err = s.cache.validate(1, 10)
+	s.NoError(err)
+	s.True(cached)
+
+	cached, err = s.cache.validate(2, 10)
+	s.NoError(err)
+	s.False(cached)
+}
+
+func (s *shardRangeCacheSuite) TestExpiryAndInvalidate() {
+	s.cache.put(1, 10)
+	s.timeSource.Update(s.timeSource.Now().Add(time.Minute))
+	cached, err := s.cache.validate(1, 10)
+	s.NoError(err)
+	s.False(cached)
+
+	s.cache.put(1, 10)
+	s.cache.invalidate(1)
+	cached, err = s.cache.validate(1, 9)
+	s.NoError(err)
+	s.False(cached)
+}
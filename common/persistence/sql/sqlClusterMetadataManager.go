@@ -0,0 +1,185 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/uber-common/bark"
+
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/persistence/sql/storage"
+	"github.com/uber/cadence/common/persistence/sql/storage/sqldb"
+	"github.com/uber/cadence/common/service/config"
+)
+
+type sqlClusterMetadataManager struct {
+	sqlStore
+}
+
+// newClusterMetadataPersistence creates an instance of ClusterMetadataManager
+func newClusterMetadataPersistence(cfg config.SQL, log bark.Logger) (persistence.ClusterMetadataManager, error) {
+	var db, err = storage.NewSQLDB(&cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &sqlClusterMetadataManager{
+		sqlStore: sqlStore{
+			db:     db,
+			logger: log,
+		},
+	}, nil
+}
+
+func (m *sqlClusterMetadataManager) InitializeClusterMetadata(_ context.Context,
+	request *persistence.InitializeClusterMetadataRequest) (*persistence.InitializeClusterMetadataResponse, error) {
+	row, err := clusterMetadataToRow(&request.ClusterMetadata)
+	if err != nil {
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("InitializeClusterMetadata operation failed. Error: %v", err),
+		}
+	}
+
+	if _, err := m.db.InsertIntoClusterMetadata(row); err != nil {
+		if !isDupEntry(err) {
+			return nil, &workflow.InternalServiceError{
+				Message: fmt.Sprintf("InitializeClusterMetadata operation failed. Failed to insert into cluster_metadata table. Error: %v", err),
+			}
+		}
+		persisted, err := m.getClusterMetadata()
+		if err != nil {
+			return nil, err
+		}
+		return &persistence.InitializeClusterMetadataResponse{ClusterMetadata: *persisted}, nil
+	}
+
+	return &persistence.InitializeClusterMetadataResponse{
+		ClusterMetadata: request.ClusterMetadata,
+		Created:         true,
+	}, nil
+}
+
+func (m *sqlClusterMetadataManager) GetClusterMetadata(_ context.Context) (*persistence.GetClusterMetadataResponse, error) {
+	metadata, err := m.getClusterMetadata()
+	if err != nil {
+		return nil, err
+	}
+	return &persistence.GetClusterMetadataResponse{ClusterMetadata: *metadata}, nil
+}
+
+func (m *sqlClusterMetadataManager) UpdateClusterMetadata(_ context.Context,
+	request *persistence.UpdateClusterMetadataRequest) error {
+	row, err := clusterMetadataToRow(&request.ClusterMetadata)
+	if err != nil {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("UpdateClusterMetadata operation failed. Error: %v", err),
+		}
+	}
+
+	result, err := m.db.UpdateClusterMetadata(row, request.PreviousVersion)
+	if err != nil {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("UpdateClusterMetadata operation failed. Error: %v", err),
+		}
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("UpdateClusterMetadata operation failed. rowsAffected returned error: %v", err),
+		}
+	}
+	if rowsAffected != 1 {
+		return &persistence.ConditionFailedError{
+			Msg: fmt.Sprintf("UpdateClusterMetadata operation failed. Previous version: %v", request.PreviousVersion),
+		}
+	}
+	return nil
+}
+
+func (m *sqlClusterMetadataManager) getClusterMetadata() (*persistence.ClusterMetadata, error) {
+	row, err := m.db.SelectFromClusterMetadata()
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, &workflow.EntityNotExistsError{
+				Message: fmt.Sprintf("GetClusterMetadata operation failed. Cluster metadata not found. Error: %v", err),
+			}
+		}
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("GetClusterMetadata operation failed. Error: %v", err),
+		}
+	}
+
+	initialFailoverVersions := make(map[string]int64)
+	if err := gobDeserialize(row.InitialFailoverVersions, &initialFailoverVersions); err != nil {
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("GetClusterMetadata operation failed. Failed to deserialize InitialFailoverVersions. Error: %v", err),
+		}
+	}
+	clusterAddresses := make(map[string]persistence.ClusterAddress)
+	if err := gobDeserialize(row.ClusterAddresses, &clusterAddresses); err != nil {
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("GetClusterMetadata operation failed. Failed to deserialize ClusterAddresses. Error: %v", err),
+		}
+	}
+	searchAttributes := make(map[string]int32)
+	if err := gobDeserialize(row.SearchAttributes, &searchAttributes); err != nil {
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("GetClusterMetadata operation failed. Failed to deserialize SearchAttributes. Error: %v", err),
+		}
+	}
+
+	return &persistence.ClusterMetadata{
+		ClusterName:              row.ClusterName,
+		FailoverVersionIncrement: row.FailoverVersionIncrement,
+		InitialFailoverVersions:  initialFailoverVersions,
+		ClusterAddresses:         clusterAddresses,
+		SearchAttributes:         searchAttributes,
+		NumHistoryShards:         row.NumHistoryShards,
+		Version:                  row.Version,
+	}, nil
+}
+
+func clusterMetadataToRow(metadata *persistence.ClusterMetadata) (*sqldb.ClusterMetadataRow, error) {
+	initialFailoverVersions, err := gobSerialize(metadata.InitialFailoverVersions)
+	if err != nil {
+		return nil, err
+	}
+	clusterAddresses, err := gobSerialize(metadata.ClusterAddresses)
+	if err != nil {
+		return nil, err
+	}
+	searchAttributes, err := gobSerialize(metadata.SearchAttributes)
+	if err != nil {
+		return nil, err
+	}
+	return &sqldb.ClusterMetadataRow{
+		ClusterName:              metadata.ClusterName,
+		FailoverVersionIncrement: metadata.FailoverVersionIncrement,
+		InitialFailoverVersions:  initialFailoverVersions,
+		ClusterAddresses:         clusterAddresses,
+		SearchAttributes:         searchAttributes,
+		NumHistoryShards:         metadata.NumHistoryShards,
+		Version:                  metadata.Version,
+	}, nil
+}
@@ -21,6 +21,7 @@
 package sql
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"time"
@@ -31,6 +32,7 @@ import (
 	"github.com/uber-common/bark"
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/checksum"
 	"github.com/uber/cadence/common/collection"
 	p "github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/persistence/sql/storage/sqldb"
@@ -38,14 +40,23 @@ import (
 
 type sqlExecutionManager struct {
 	sqlStore
-	shardID int
+	shardID     int
+	shardRanges *shardRangeCache
 }
 
-// txExecuteShardLocked executes f under transaction and with read lock on shard row
-func (m *sqlExecutionManager) txExecuteShardLocked(operation string, rangeID int64, f func(tx sqldb.Tx) error) error {
-	return m.txExecute(operation, func(tx sqldb.Tx) error {
-		if err := readLockShard(tx, m.shardID, rangeID); err != nil {
-			return err
+// txExecuteShardLocked executes f under transaction and with read lock on shard row,
+// the read lock is skipped if the range ID matches the cached range ID of the shard
+func (m *sqlExecutionManager) txExecuteShardLocked(ctx context.Context, operation string, rangeID int64, f func(tx sqldb.Tx) error) error {
+	cached, err := m.shardRanges.validate(m.shardID, rangeID)
+	if err != nil {
+		return err
+	}
+
+	err = m.txExecuteWithContext(ctx, operation, func(tx sqldb.Tx) error {
+		if !cached {
+			if err := readLockShard(tx, m.shardID, rangeID); err != nil {
+				return err
+			}
 		}
 		err := f(tx)
 		if err != nil {
@@ -53,21 +64,31 @@ func (m *sqlExecutionManager) txExecuteShardLocked(operation string, rangeID int
 		}
 		return nil
 	})
+	switch err.(type) {
+	case nil:
+		if !cached {
+			m.shardRanges.put(m.shardID, rangeID)
+		}
+	case *p.ConditionFailedError, *p.CurrentWorkflowConditionFailedError, *p.ShardOwnershipLostError:
+		// the shard may be owned by another host by now, read lock the shard row next time
+		m.shardRanges.invalidate(m.shardID)
+	}
+	return err
 }
 
 func (m *sqlExecutionManager) GetShardID() int {
 	return m.shardID
 }
 
-func (m *sqlExecutionManager) CreateWorkflowExecution(request *p.CreateWorkflowExecutionRequest) (response *p.CreateWorkflowExecutionResponse, err error) {
-	err = m.txExecuteShardLocked("CreateWorkflowExecution", request.RangeID, func(tx sqldb.Tx) error {
+func (m *sqlExecutionManager) CreateWorkflowExecution(ctx context.Context, request *p.InternalCreateWorkflowExecutionRequest) (response *p.CreateWorkflowExecutionResponse, err error) {
+	err = m.txExecuteShardLocked(ctx, "CreateWorkflowExecution", request.RangeID, func(tx sqldb.Tx) error {
 		response, err = m.createWorkflowExecutionTx(tx, request)
 		return err
 	})
 	return
 }
 
-func (m *sqlExecutionManager) createWorkflowExecutionTx(tx sqldb.Tx, request *p.CreateWorkflowExecutionRequest) (*p.CreateWorkflowExecutionResponse, error) {
+func (m *sqlExecutionManager) createWorkflowExecutionTx(tx sqldb.Tx, request *p.InternalCreateWorkflowExecutionRequest) (*p.CreateWorkflowExecutionResponse, error) {
 	if request.CreateWorkflowMode == p.CreateWorkflowModeContinueAsNew {
 		return nil, &workflow.InternalServiceError{
 			Message: "CreateWorkflowExecution operation failed. Invalid CreateWorkflowModeContinueAsNew is used",
@@ -164,7 +185,27 @@ func (m *sqlExecutionManager) createWorkflowExecutionTx(tx sqldb.Tx, request *p.
 	return &p.CreateWorkflowExecutionResponse{}, nil
 }
 
-func (m *sqlExecutionManager) GetWorkflowExecution(request *p.GetWorkflowExecutionRequest) (*p.InternalGetWorkflowExecutionResponse, error) {
+// GetWorkflowExecutions reads the executions one by one, the mutable state of an execution is spread across
+// several tables so there is no single query returning many of them
+func (m *sqlExecutionManager) GetWorkflowExecutions(ctx context.Context, request *p.GetWorkflowExecutionsRequest) (*p.InternalGetWorkflowExecutionsResponse, error) {
+	response := &p.InternalGetWorkflowExecutionsResponse{}
+	for _, execution := range request.Executions {
+		executionResponse, err := m.GetWorkflowExecution(ctx, &p.GetWorkflowExecutionRequest{
+			DomainID:  request.DomainID,
+			Execution: execution,
+		})
+		if err != nil {
+			if _, ok := err.(*workflow.EntityNotExistsError); ok {
+				continue
+			}
+			return nil, err
+		}
+		response.Responses = append(response.Responses, executionResponse)
+	}
+	return response, nil
+}
+
+func (m *sqlExecutionManager) GetWorkflowExecution(ctx context.Context, request *p.GetWorkflowExecutionRequest) (*p.InternalGetWorkflowExecutionResponse, error) {
 	domainID := sqldb.MustParseUUID(request.DomainID)
 	runID := sqldb.MustParseUUID(*request.Execution.RunId)
 	wfID := *request.Execution.WorkflowId
@@ -282,6 +323,29 @@ func (m *sqlExecutionManager) GetWorkflowExecution(request *p.GetWorkflowExecuti
 		}
 	}
 
+	if execution.Labels != nil {
+		err := gobDeserialize(execution.Labels, &state.ExecutionInfo.Labels)
+		if err != nil {
+			return nil, &workflow.InternalServiceError{
+				Message: fmt.Sprintf("GetWorkflowExecution: failed to deserialize labels: %v", err),
+			}
+		}
+	}
+
+	if execution.Checksum != nil {
+		err := gobDeserialize(execution.Checksum, &state.Checksum)
+		if err != nil {
+			return nil, &workflow.InternalServiceError{
+				Message: fmt.Sprintf("GetWorkflowExecution: failed to deserialize checksum: %v", err),
+			}
+		}
+	}
+
+	if execution.VersionHistories != nil && execution.VersionHistoriesEncoding != nil {
+		state.ExecutionInfo.VersionHistories = p.NewDataBlob(execution.VersionHistories,
+			common.EncodingType(*execution.VersionHistoriesEncoding))
+	}
+
 	{
 		var err error
 		state.ActivitInfos, err = getActivityInfoMap(m.db,
@@ -417,8 +481,8 @@ func getBufferedEvents(
 	return result, nil
 }
 
-func (m *sqlExecutionManager) UpdateWorkflowExecution(request *p.InternalUpdateWorkflowExecutionRequest) error {
-	return m.txExecuteShardLocked("UpdateWorkflowExecution", request.RangeID, func(tx sqldb.Tx) error {
+func (m *sqlExecutionManager) UpdateWorkflowExecution(ctx context.Context, request *p.InternalUpdateWorkflowExecutionRequest) error {
+	return m.txExecuteShardLocked(ctx, "UpdateWorkflowExecution", request.RangeID, func(tx sqldb.Tx) error {
 		return m.updateWorkflowExecutionTx(tx, request)
 	})
 }
@@ -459,7 +523,7 @@ func (m *sqlExecutionManager) updateWorkflowExecutionTx(tx sqldb.Tx, request *p.
 		}
 	}
 
-	if err := updateExecution(tx, executionInfo, request.ReplicationState, shardID); err != nil {
+	if err := updateExecution(tx, executionInfo, request.ReplicationState, request.Checksum, shardID); err != nil {
 		return &workflow.InternalServiceError{
 			Message: fmt.Sprintf("UpdateWorkflowExecution operation failed. Failed to update executions row. Erorr: %v", err),
 		}
@@ -623,8 +687,8 @@ func updateBufferedEvents(tx sqldb.Tx, batch *p.DataBlob, clear bool, shardID in
 	return nil
 }
 
-func (m *sqlExecutionManager) ResetWorkflowExecution(request *p.InternalResetWorkflowExecutionRequest) error {
-	return m.txExecuteShardLocked("ResetWorkflowExecution", request.RangeID, func(tx sqldb.Tx) error {
+func (m *sqlExecutionManager) ResetWorkflowExecution(ctx context.Context, request *p.InternalResetWorkflowExecutionRequest) error {
+	return m.txExecuteShardLocked(ctx, "ResetWorkflowExecution", request.RangeID, func(tx sqldb.Tx) error {
 		currExecutionInfo := request.CurrExecutionInfo
 		currReplicationState := request.CurrReplicationState
 		domainID := sqldb.MustParseUUID(currExecutionInfo.DomainID)
@@ -721,7 +785,7 @@ func (m *sqlExecutionManager) ResetWorkflowExecution(request *p.InternalResetWor
 				}
 			}
 
-			if err := updateExecution(tx, currExecutionInfo, currReplicationState, shardID); err != nil {
+			if err := updateExecution(tx, currExecutionInfo, currReplicationState, checksum.Checksum{}, shardID); err != nil {
 				return &workflow.InternalServiceError{
 					Message: fmt.Sprintf("ResetWorkflowExecution operation failed. Failed to update executions row. Erorr: %v", err),
 				}
@@ -864,8 +928,8 @@ func (m *sqlExecutionManager) ResetWorkflowExecution(request *p.InternalResetWor
 	})
 }
 
-func (m *sqlExecutionManager) ResetMutableState(request *p.InternalResetMutableStateRequest) error {
-	return m.txExecuteShardLocked("ResetMutableState", request.RangeID, func(tx sqldb.Tx) error {
+func (m *sqlExecutionManager) ResetMutableState(ctx context.Context, request *p.InternalResetMutableStateRequest) error {
+	return m.txExecuteShardLocked(ctx, "ResetMutableState", request.RangeID, func(tx sqldb.Tx) error {
 		return m.resetMutableStateTx(tx, request)
 	})
 }
@@ -909,7 +973,7 @@ func (m *sqlExecutionManager) resetMutableStateTx(tx sqldb.Tx, request *p.Intern
 		}
 	}
 
-	if err := updateExecution(tx, info, request.ReplicationState, m.shardID); err != nil {
+	if err := updateExecution(tx, info, request.ReplicationState, request.Checksum, m.shardID); err != nil {
 		return &workflow.InternalServiceError{
 			Message: fmt.Sprintf("UpdateWorkflowExecution operation failed. Failed to update executions row. Erorr: %v", err),
 		}
@@ -1070,10 +1134,10 @@ func (m *sqlExecutionManager) resetMutableStateTx(tx sqldb.Tx, request *p.Intern
 	return nil
 }
 
-func (m *sqlExecutionManager) DeleteWorkflowExecution(request *p.DeleteWorkflowExecutionRequest) error {
+func (m *sqlExecutionManager) DeleteWorkflowExecution(ctx context.Context, request *p.DeleteWorkflowExecutionRequest) error {
 	domainID := sqldb.MustParseUUID(request.DomainID)
 	runID := sqldb.MustParseUUID(request.RunID)
-	return m.txExecute("deleteWorkflowExecution", func(tx sqldb.Tx) error {
+	return m.txExecuteWithContext(ctx, "deleteWorkflowExecution", func(tx sqldb.Tx) error {
 		if _, err := tx.DeleteFromExecutions(&sqldb.ExecutionsFilter{
 			ShardID:    m.shardID,
 			DomainID:   domainID,
@@ -1096,7 +1160,7 @@ func (m *sqlExecutionManager) DeleteWorkflowExecution(request *p.DeleteWorkflowE
 	})
 }
 
-func (m *sqlExecutionManager) GetCurrentExecution(request *p.GetCurrentExecutionRequest) (*p.GetCurrentExecutionResponse, error) {
+func (m *sqlExecutionManager) GetCurrentExecution(ctx context.Context, request *p.GetCurrentExecutionRequest) (*p.GetCurrentExecutionResponse, error) {
 	row, err := m.db.SelectFromCurrentExecutions(&sqldb.CurrentExecutionsFilter{
 		ShardID:    int64(m.shardID),
 		DomainID:   sqldb.MustParseUUID(request.DomainID),
@@ -1119,7 +1183,7 @@ func (m *sqlExecutionManager) GetCurrentExecution(request *p.GetCurrentExecution
 	}, nil
 }
 
-func (m *sqlExecutionManager) GetTransferTasks(request *p.GetTransferTasksRequest) (*p.GetTransferTasksResponse, error) {
+func (m *sqlExecutionManager) GetTransferTasks(ctx context.Context, request *p.GetTransferTasksRequest) (*p.GetTransferTasksResponse, error) {
 	rows, err := m.db.SelectFromTransferTasks(&sqldb.TransferTasksFilter{
 		ShardID: m.shardID, MinTaskID: &request.ReadLevel, MaxTaskID: &request.MaxReadLevel})
 	if err != nil {
@@ -1150,7 +1214,7 @@ func (m *sqlExecutionManager) GetTransferTasks(request *p.GetTransferTasksReques
 	return resp, nil
 }
 
-func (m *sqlExecutionManager) CompleteTransferTask(request *p.CompleteTransferTaskRequest) error {
+func (m *sqlExecutionManager) CompleteTransferTask(ctx context.Context, request *p.CompleteTransferTaskRequest) error {
 	if _, err := m.db.DeleteFromTransferTasks(&sqldb.TransferTasksFilter{
 		ShardID: m.shardID,
 		TaskID:  &request.TaskID,
@@ -1162,7 +1226,7 @@ func (m *sqlExecutionManager) CompleteTransferTask(request *p.CompleteTransferTa
 	return nil
 }
 
-func (m *sqlExecutionManager) RangeCompleteTransferTask(request *p.RangeCompleteTransferTaskRequest) error {
+func (m *sqlExecutionManager) RangeCompleteTransferTask(ctx context.Context, request *p.RangeCompleteTransferTaskRequest) error {
 	if _, err := m.db.DeleteFromTransferTasks(&sqldb.TransferTasksFilter{
 		ShardID:   m.shardID,
 		MinTaskID: &request.ExclusiveBeginTaskID,
@@ -1174,7 +1238,7 @@ func (m *sqlExecutionManager) RangeCompleteTransferTask(request *p.RangeComplete
 	return nil
 }
 
-func (m *sqlExecutionManager) GetReplicationTasks(request *p.GetReplicationTasksRequest) (*p.GetReplicationTasksResponse, error) {
+func (m *sqlExecutionManager) GetReplicationTasks(ctx context.Context, request *p.GetReplicationTasksRequest) (*p.GetReplicationTasksResponse, error) {
 	var readLevel int64
 	var maxReadLevelInclusive int64
 	var err error
@@ -1246,7 +1310,7 @@ func (m *sqlExecutionManager) GetReplicationTasks(request *p.GetReplicationTasks
 	}, nil
 }
 
-func (m *sqlExecutionManager) CompleteReplicationTask(request *p.CompleteReplicationTaskRequest) error {
+func (m *sqlExecutionManager) CompleteReplicationTask(ctx context.Context, request *p.CompleteReplicationTaskRequest) error {
 	if _, err := m.db.DeleteFromReplicationTasks(&sqldb.ReplicationTasksFilter{
 		ShardID: m.shardID,
 		TaskID:  &request.TaskID,
@@ -1271,7 +1335,7 @@ func (t *timerTaskPageToken) deserialize(payload []byte) error {
 	return json.Unmarshal(payload, t)
 }
 
-func (m *sqlExecutionManager) GetTimerIndexTasks(request *p.GetTimerIndexTasksRequest) (*p.GetTimerIndexTasksResponse, error) {
+func (m *sqlExecutionManager) GetTimerIndexTasks(ctx context.Context, request *p.GetTimerIndexTasksRequest) (*p.GetTimerIndexTasksResponse, error) {
 	pageToken := &timerTaskPageToken{TaskID: math.MinInt64, Timestamp: request.MinTimestamp}
 	if len(request.NextPageToken) > 0 {
 		if err := pageToken.deserialize(request.NextPageToken); err != nil {
@@ -1308,6 +1372,10 @@ func (m *sqlExecutionManager) GetTimerIndexTasks(request *p.GetTimerIndexTasksRe
 			EventID:             row.EventID,
 			ScheduleAttempt:     row.ScheduleAttempt,
 			Version:             row.Version,
+			SignalName:          row.SignalName,
+			SignalInput:         row.SignalInput,
+			SignalIdentity:      row.SignalIdentity,
+			SignalRequestID:     row.SignalRequestID,
 		}
 	}
 
@@ -1329,7 +1397,7 @@ func (m *sqlExecutionManager) GetTimerIndexTasks(request *p.GetTimerIndexTasksRe
 	return resp, nil
 }
 
-func (m *sqlExecutionManager) CompleteTimerTask(request *p.CompleteTimerTaskRequest) error {
+func (m *sqlExecutionManager) CompleteTimerTask(ctx context.Context, request *p.CompleteTimerTaskRequest) error {
 	if _, err := m.db.DeleteFromTimerTasks(&sqldb.TimerTasksFilter{
 		ShardID:             m.shardID,
 		VisibilityTimestamp: &request.VisibilityTimestamp,
@@ -1342,7 +1410,7 @@ func (m *sqlExecutionManager) CompleteTimerTask(request *p.CompleteTimerTaskRequ
 	return nil
 }
 
-func (m *sqlExecutionManager) RangeCompleteTimerTask(request *p.RangeCompleteTimerTaskRequest) error {
+func (m *sqlExecutionManager) RangeCompleteTimerTask(ctx context.Context, request *p.RangeCompleteTimerTaskRequest) error {
 	start := request.InclusiveBeginTimestamp
 	end := request.ExclusiveEndTimestamp
 	if _, err := m.db.DeleteFromTimerTasks(&sqldb.TimerTasksFilter{
@@ -1359,13 +1427,18 @@ func (m *sqlExecutionManager) RangeCompleteTimerTask(request *p.RangeCompleteTim
 
 // NewSQLExecutionStore creates an instance of ExecutionStore
 func NewSQLExecutionStore(db sqldb.Interface, logger bark.Logger, shardID int) (p.ExecutionStore, error) {
+	return newSQLExecutionStore(db, nil, logger, shardID), nil
+}
+
+func newSQLExecutionStore(db sqldb.Interface, shardRanges *shardRangeCache, logger bark.Logger, shardID int) *sqlExecutionManager {
 	return &sqlExecutionManager{
-		shardID: shardID,
+		shardID:     shardID,
+		shardRanges: shardRanges,
 		sqlStore: sqlStore{
 			db:     db,
 			logger: logger,
 		},
-	}, nil
+	}
 }
 
 // lockCurrentExecutionIfExists returns current execution or nil if none is found for the workflowID
@@ -1394,7 +1467,7 @@ func lockCurrentExecutionIfExists(tx sqldb.Tx, shardID int, domainID sqldb.UUID,
 
 func createExecutionFromRequest(
 	tx sqldb.Tx,
-	request *p.CreateWorkflowExecutionRequest,
+	request *p.InternalCreateWorkflowExecutionRequest,
 	shardID int, domainID sqldb.UUID, runID sqldb.UUID, nowTimestamp time.Time) error {
 	row := &sqldb.ExecutionsRow{
 		ShardID:                      shardID,
@@ -1479,6 +1552,11 @@ func createExecutionFromRequest(
 		row.BranchToken = request.BranchToken
 	}
 
+	if request.VersionHistories != nil {
+		row.VersionHistories = request.VersionHistories.Data
+		row.VersionHistoriesEncoding = common.StringPtr(string(request.VersionHistories.Encoding))
+	}
+
 	_, err := tx.InsertIntoExecutions(row)
 	if err != nil {
 		return &workflow.InternalServiceError{
@@ -1489,7 +1567,7 @@ func createExecutionFromRequest(
 }
 
 func createOrUpdateCurrentExecution(
-	tx sqldb.Tx, request *p.CreateWorkflowExecutionRequest, shardID int, domainID sqldb.UUID, runID sqldb.UUID) error {
+	tx sqldb.Tx, request *p.InternalCreateWorkflowExecutionRequest, shardID int, domainID sqldb.UUID, runID sqldb.UUID) error {
 	row := sqldb.CurrentExecutionsRow{
 		ShardID:          int64(shardID),
 		DomainID:         domainID,
@@ -1640,6 +1718,9 @@ func createTransferTasks(tx sqldb.Tx, transferTasks []p.Task, shardID int, domai
 		case p.TransferTaskTypeRecordWorkflowStarted:
 			// No explicit property needs to be set
 
+		case p.TransferTaskTypeUpsertWorkflowLabels:
+			// No explicit property needs to be set
+
 		default:
 			// hmm what should i do here?
 			//d.logger.Fatal("Unknown Transfer Task.")
@@ -1793,6 +1874,11 @@ func createTimerTasks(
 			case *p.WorkflowBackoffTimerTask:
 				timerTasksRows[i].EventID = t.EventID
 				timerTasksRows[i].TimeoutType = t.TimeoutType
+			case *p.DelayedSignalTask:
+				timerTasksRows[i].SignalName = t.SignalName
+				timerTasksRows[i].SignalInput = t.Input
+				timerTasksRows[i].SignalIdentity = t.Identity
+				timerTasksRows[i].SignalRequestID = t.RequestID
 			}
 
 			timerTasksRows[i].ShardID = shardID
@@ -1889,6 +1975,7 @@ func updateCurrentExecution(tx sqldb.Tx, shardID int, domainID sqldb.UUID, workf
 
 func buildExecutionRow(executionInfo *p.InternalWorkflowExecutionInfo,
 	replicationState *p.ReplicationState,
+	csum checksum.Checksum,
 	shardID int) (row *sqldb.ExecutionsRow, err error) {
 	row = &sqldb.ExecutionsRow{
 		DomainID:                     sqldb.MustParseUUID(executionInfo.DomainID),
@@ -1982,6 +2069,31 @@ func buildExecutionRow(executionInfo *p.InternalWorkflowExecutionInfo,
 		}
 		row.NonRetryableErrors = blob
 	}
+
+	if executionInfo.Labels != nil {
+		blob, err := gobSerialize(executionInfo.Labels)
+		if err != nil {
+			return nil, &workflow.InternalServiceError{
+				Message: fmt.Sprintf("updateExecution: failed to serialize labels: %v", err),
+			}
+		}
+		row.Labels = blob
+	}
+
+	if !csum.IsEmpty() {
+		blob, err := gobSerialize(csum)
+		if err != nil {
+			return nil, &workflow.InternalServiceError{
+				Message: fmt.Sprintf("updateExecution: failed to serialize checksum: %v", err),
+			}
+		}
+		row.Checksum = blob
+	}
+
+	if executionInfo.VersionHistories != nil {
+		row.VersionHistories = executionInfo.VersionHistories.Data
+		row.VersionHistoriesEncoding = common.StringPtr(string(executionInfo.VersionHistories.Encoding))
+	}
 	row.EventStoreVersion = int(executionInfo.EventStoreVersion)
 	row.BranchToken = executionInfo.BranchToken
 
@@ -1991,8 +2103,9 @@ func buildExecutionRow(executionInfo *p.InternalWorkflowExecutionInfo,
 func updateExecution(tx sqldb.Tx,
 	executionInfo *p.InternalWorkflowExecutionInfo,
 	replicationState *p.ReplicationState,
+	csum checksum.Checksum,
 	shardID int) error {
-	row, err := buildExecutionRow(executionInfo, replicationState, shardID)
+	row, err := buildExecutionRow(executionInfo, replicationState, csum, shardID)
 	if err != nil {
 		return err
 	}
@@ -2021,7 +2134,7 @@ func createExecution(tx sqldb.Tx,
 	executionInfo *p.InternalWorkflowExecutionInfo,
 	replicationState *p.ReplicationState,
 	shardID int) error {
-	row, err := buildExecutionRow(executionInfo, replicationState, shardID)
+	row, err := buildExecutionRow(executionInfo, replicationState, checksum.Checksum{}, shardID)
 	if err != nil {
 		return err
 	}
@@ -23,6 +23,7 @@ package sql
 import (
 	"fmt"
 	"io/ioutil"
+	"path"
 
 	"github.com/iancoleman/strcase"
 	"github.com/jmoiron/sqlx"
@@ -85,3 +86,59 @@ func loadDatabaseSchema(dir string, fileNames []string, db *sqlx.DB, override bo
 	}
 	return nil
 }
+
+// AutoSetupSchema creates the cadence and visibility databases, if they don't
+// exist, and loads the latest mysql schema found under rootPath into the ones
+// that have no tables yet. It is meant to make a development server work against
+// an empty mysql and is a no-op for stores that are not sql.
+func AutoSetupSchema(cfg config.Persistence, rootPath string) error {
+	ds, ok := cfg.DataStores[cfg.DefaultStore]
+	if ok && ds.SQL != nil {
+		schemaFile := path.Join(rootPath, "schema/mysql/v57/cadence/schema.sql")
+		if err := autoSetupDatabase(*ds.SQL, schemaFile); err != nil {
+			return err
+		}
+	}
+	ds, ok = cfg.DataStores[cfg.VisibilityStore]
+	if ok && ds.SQL != nil {
+		schemaFile := path.Join(rootPath, "schema/mysql/v57/visibility/schema.sql")
+		return autoSetupDatabase(*ds.SQL, schemaFile)
+	}
+	return nil
+}
+
+func autoSetupDatabase(cfg config.SQL, schemaFile string) error {
+	db, err := sqlx.Connect(cfg.DriverName, fmt.Sprintf(dataSourceName, cfg.User, cfg.Password, cfg.ConnectProtocol, cfg.ConnectAddr, ""))
+	if err != nil {
+		return fmt.Errorf("failure connecting to mysql database: %v", err)
+	}
+	defer db.Close()
+
+	if _, err := db.Exec(`CREATE DATABASE IF NOT EXISTS ` + cfg.DatabaseName); err != nil {
+		return fmt.Errorf("failure creating database %v: %v", cfg.DatabaseName, err)
+	}
+
+	var numTables int
+	err = db.Get(&numTables, `SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = ?`, cfg.DatabaseName)
+	if err != nil {
+		return fmt.Errorf("failure listing tables of database %v: %v", cfg.DatabaseName, err)
+	}
+	if numTables > 0 {
+		return nil
+	}
+
+	content, err := ioutil.ReadFile(schemaFile)
+	if err != nil {
+		return fmt.Errorf("error reading contents of file %v:%v", schemaFile, err.Error())
+	}
+	schemaDB, err := newConnection(cfg)
+	if err != nil {
+		return fmt.Errorf("failure connecting to database %v: %v", cfg.DatabaseName, err)
+	}
+	defer schemaDB.Close()
+	if _, err := schemaDB.Exec(string(content)); err != nil {
+		return fmt.Errorf("error loading schema from %v: %v", schemaFile, err.Error())
+	}
+	log.WithField(`database-name`, cfg.DatabaseName).Info(`loaded database schema`)
+	return nil
+}
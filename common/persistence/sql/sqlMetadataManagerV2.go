@@ -21,6 +21,7 @@
 package sql
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 
@@ -88,7 +89,7 @@ func lockMetadata(tx sqldb.Tx) error {
 	return nil
 }
 
-func (m *sqlMetadataManagerV2) CreateDomain(request *persistence.CreateDomainRequest) (*persistence.CreateDomainResponse, error) {
+func (m *sqlMetadataManagerV2) CreateDomain(ctx context.Context, request *persistence.CreateDomainRequest) (*persistence.CreateDomainResponse, error) {
 	data, err := gobSerialize(request.Info.Data)
 	if err != nil {
 		return nil, &workflow.InternalServiceError{
@@ -103,13 +104,13 @@ func (m *sqlMetadataManagerV2) CreateDomain(request *persistence.CreateDomainReq
 		}
 	}
 
-	metadata, err := m.GetMetadata()
+	metadata, err := m.GetMetadata(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp *persistence.CreateDomainResponse
-	err = m.txExecute("CreateDomain", func(tx sqldb.Tx) error {
+	err = m.txExecuteWithContext(ctx, "CreateDomain", func(tx sqldb.Tx) error {
 		if _, err1 := tx.InsertIntoDomain(&sqldb.DomainRow{
 			Name:                        request.Info.Name,
 			ID:                          sqldb.MustParseUUID(request.Info.ID),
@@ -121,6 +122,7 @@ func (m *sqlMetadataManagerV2) CreateDomain(request *persistence.CreateDomainReq
 			EmitMetric:                  request.Config.EmitMetric,
 			ArchivalBucket:              request.Config.ArchivalBucket,
 			ArchivalStatus:              int(request.Config.ArchivalStatus),
+			ArchivalTargetBucket:        request.Config.ArchivalTargetBucket,
 			ActiveClusterName:           request.ReplicationConfig.ActiveClusterName,
 			Clusters:                    clusters,
 			ConfigVersion:               request.ConfigVersion,
@@ -148,7 +150,7 @@ func (m *sqlMetadataManagerV2) CreateDomain(request *persistence.CreateDomainReq
 	return resp, err
 }
 
-func (m *sqlMetadataManagerV2) GetDomain(request *persistence.GetDomainRequest) (*persistence.GetDomainResponse, error) {
+func (m *sqlMetadataManagerV2) GetDomain(ctx context.Context, request *persistence.GetDomainRequest) (*persistence.GetDomainResponse, error) {
 	filter := &sqldb.DomainFilter{}
 	switch {
 	case request.Name != "" && request.ID != "":
@@ -223,10 +225,11 @@ func (m *sqlMetadataManagerV2) domainRowToGetDomainResponse(row *sqldb.DomainRow
 			Data:        data,
 		},
 		Config: &persistence.DomainConfig{
-			Retention:      int32(row.Retention),
-			EmitMetric:     row.EmitMetric,
-			ArchivalBucket: row.ArchivalBucket,
-			ArchivalStatus: workflow.ArchivalStatus(row.ArchivalStatus),
+			Retention:            int32(row.Retention),
+			EmitMetric:           row.EmitMetric,
+			ArchivalBucket:       row.ArchivalBucket,
+			ArchivalStatus:       workflow.ArchivalStatus(row.ArchivalStatus),
+			ArchivalTargetBucket: row.ArchivalTargetBucket,
 		},
 		ReplicationConfig: &persistence.DomainReplicationConfig{
 			ActiveClusterName: persistence.GetOrUseDefaultActiveCluster(m.activeClusterName, row.ActiveClusterName),
@@ -240,7 +243,7 @@ func (m *sqlMetadataManagerV2) domainRowToGetDomainResponse(row *sqldb.DomainRow
 	}, nil
 }
 
-func (m *sqlMetadataManagerV2) UpdateDomain(request *persistence.UpdateDomainRequest) error {
+func (m *sqlMetadataManagerV2) UpdateDomain(ctx context.Context, request *persistence.UpdateDomainRequest) error {
 	clusters, err := gobSerialize(persistence.SerializeClusterConfigs(request.ReplicationConfig.Clusters))
 	if err != nil {
 		return &workflow.InternalServiceError{
@@ -255,7 +258,7 @@ func (m *sqlMetadataManagerV2) UpdateDomain(request *persistence.UpdateDomainReq
 		}
 	}
 
-	return m.txExecute("UpdateDomain", func(tx sqldb.Tx) error {
+	return m.txExecuteWithContext(ctx, "UpdateDomain", func(tx sqldb.Tx) error {
 		result, err := tx.UpdateDomain(&sqldb.DomainRow{
 			Name:                        request.Info.Name,
 			ID:                          sqldb.MustParseUUID(request.Info.ID),
@@ -267,6 +270,7 @@ func (m *sqlMetadataManagerV2) UpdateDomain(request *persistence.UpdateDomainReq
 			EmitMetric:                  request.Config.EmitMetric,
 			ArchivalBucket:              request.Config.ArchivalBucket,
 			ArchivalStatus:              int(request.Config.ArchivalStatus),
+			ArchivalTargetBucket:        request.Config.ArchivalTargetBucket,
 			ActiveClusterName:           request.ReplicationConfig.ActiveClusterName,
 			Clusters:                    clusters,
 			ConfigVersion:               request.ConfigVersion,
@@ -291,21 +295,21 @@ func (m *sqlMetadataManagerV2) UpdateDomain(request *persistence.UpdateDomainReq
 	})
 }
 
-func (m *sqlMetadataManagerV2) DeleteDomain(request *persistence.DeleteDomainRequest) error {
-	return m.txExecute("DeleteDomain", func(tx sqldb.Tx) error {
+func (m *sqlMetadataManagerV2) DeleteDomain(ctx context.Context, request *persistence.DeleteDomainRequest) error {
+	return m.txExecuteWithContext(ctx, "DeleteDomain", func(tx sqldb.Tx) error {
 		_, err := tx.DeleteFromDomain(&sqldb.DomainFilter{ID: sqldb.UUIDPtr(sqldb.MustParseUUID(request.ID))})
 		return err
 	})
 }
 
-func (m *sqlMetadataManagerV2) DeleteDomainByName(request *persistence.DeleteDomainByNameRequest) error {
-	return m.txExecute("DeleteDomainByName", func(tx sqldb.Tx) error {
+func (m *sqlMetadataManagerV2) DeleteDomainByName(ctx context.Context, request *persistence.DeleteDomainByNameRequest) error {
+	return m.txExecuteWithContext(ctx, "DeleteDomainByName", func(tx sqldb.Tx) error {
 		_, err := tx.DeleteFromDomain(&sqldb.DomainFilter{Name: &request.Name})
 		return err
 	})
 }
 
-func (m *sqlMetadataManagerV2) GetMetadata() (*persistence.GetMetadataResponse, error) {
+func (m *sqlMetadataManagerV2) GetMetadata(ctx context.Context) (*persistence.GetMetadataResponse, error) {
 	row, err := m.db.SelectFromDomainMetadata()
 	if err != nil {
 		return nil, &workflow.InternalServiceError{
@@ -315,7 +319,7 @@ func (m *sqlMetadataManagerV2) GetMetadata() (*persistence.GetMetadataResponse,
 	return &persistence.GetMetadataResponse{NotificationVersion: row.NotificationVersion}, nil
 }
 
-func (m *sqlMetadataManagerV2) ListDomains(request *persistence.ListDomainsRequest) (*persistence.ListDomainsResponse, error) {
+func (m *sqlMetadataManagerV2) ListDomains(ctx context.Context, request *persistence.ListDomainsRequest) (*persistence.ListDomainsResponse, error) {
 	var pageToken *sqldb.UUID
 	if request.NextPageToken != nil {
 		token := sqldb.UUID(request.NextPageToken)
@@ -37,10 +37,12 @@ import (
 type sqlShardManager struct {
 	sqlStore
 	currentClusterName string
+	shardRanges        *shardRangeCache
 }
 
 // newShardPersistence creates an instance of ShardManager
-func newShardPersistence(cfg config.SQL, currentClusterName string, log bark.Logger) (persistence.ShardManager, error) {
+func newShardPersistence(cfg config.SQL, currentClusterName string, shardRanges *shardRangeCache,
+	log bark.Logger) (persistence.ShardManager, error) {
 	var db, err = storage.NewSQLDB(&cfg)
 	if err != nil {
 		return nil, err
@@ -51,6 +53,7 @@ func newShardPersistence(cfg config.SQL, currentClusterName string, log bark.Log
 			logger: log,
 		},
 		currentClusterName: currentClusterName,
+		shardRanges:        shardRanges,
 	}, nil
 }
 
@@ -76,6 +79,7 @@ func (m *sqlShardManager) CreateShard(request *persistence.CreateShardRequest) e
 		}
 	}
 
+	m.shardRanges.put(request.ShardInfo.ShardID, request.ShardInfo.RangeID)
 	return nil
 }
 
@@ -130,6 +134,7 @@ func (m *sqlShardManager) GetShard(request *persistence.GetShardRequest) (*persi
 		DomainNotificationVersion: row.DomainNotificationVersion,
 	}}
 
+	m.shardRanges.put(request.ShardID, row.RangeID)
 	return resp, nil
 }
 
@@ -140,7 +145,7 @@ func (m *sqlShardManager) UpdateShard(request *persistence.UpdateShardRequest) e
 			Message: fmt.Sprintf("UpdateShard operation failed. Error: %v", err),
 		}
 	}
-	return m.txExecute("UpdateShard", func(tx sqldb.Tx) error {
+	err = m.txExecute("UpdateShard", func(tx sqldb.Tx) error {
 		if err := lockShard(tx, request.ShardInfo.ShardID, request.PreviousRangeID); err != nil {
 			return err
 		}
@@ -157,6 +162,13 @@ func (m *sqlShardManager) UpdateShard(request *persistence.UpdateShardRequest) e
 		}
 		return nil
 	})
+	if err != nil {
+		m.shardRanges.invalidate(request.ShardInfo.ShardID)
+		return err
+	}
+
+	m.shardRanges.put(request.ShardInfo.ShardID, request.ShardInfo.RangeID)
+	return nil
 }
 
 // initiated by the owning shard
@@ -21,6 +21,7 @@
 package sql
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
@@ -60,31 +61,46 @@ func NewSQLVisibilityStore(cfg config.SQL, logger bark.Logger) (p.VisibilityMana
 	}, nil
 }
 
-func (s *sqlVisibilityStore) RecordWorkflowExecutionStarted(request *p.RecordWorkflowExecutionStartedRequest) error {
+func (s *sqlVisibilityStore) RecordWorkflowExecutionStarted(ctx context.Context, request *p.RecordWorkflowExecutionStartedRequest) error {
+	parentWorkflowID, parentRunID := toParentColumns(request.ParentWorkflowID, request.ParentRunID)
 	_, err := s.db.InsertIntoVisibility(&sqldb.VisibilityRow{
 		DomainID:         request.DomainUUID,
 		WorkflowID:       *request.Execution.WorkflowId,
 		RunID:            *request.Execution.RunId,
 		StartTime:        time.Unix(0, request.StartTimestamp),
-		ExecutionTime:    time.Unix(0, request.ExecutionTimestamp),
+		ExecutionTime:    time.Unix(0, request.GetExecutionTimestamp()),
 		WorkflowTypeName: request.WorkflowTypeName,
+		TaskList:         request.TaskList,
+		ParentWorkflowID: parentWorkflowID,
+		ParentRunID:      parentRunID,
 	})
 	return err
 }
 
-func (s *sqlVisibilityStore) RecordWorkflowExecutionClosed(request *p.RecordWorkflowExecutionClosedRequest) error {
+func (s *sqlVisibilityStore) RecordWorkflowExecutionClosed(ctx context.Context, request *p.RecordWorkflowExecutionClosedRequest) error {
 	closeTime := time.Unix(0, request.CloseTimestamp)
-	result, err := s.db.ReplaceIntoVisibility(&sqldb.VisibilityRow{
+	parentWorkflowID, parentRunID := toParentColumns(request.ParentWorkflowID, request.ParentRunID)
+	row := &sqldb.VisibilityRow{
 		DomainID:         request.DomainUUID,
 		WorkflowID:       *request.Execution.WorkflowId,
 		RunID:            *request.Execution.RunId,
 		StartTime:        time.Unix(0, request.StartTimestamp),
-		ExecutionTime:    time.Unix(0, request.ExecutionTimestamp),
+		ExecutionTime:    time.Unix(0, request.GetExecutionTimestamp()),
 		WorkflowTypeName: request.WorkflowTypeName,
+		TaskList:         request.TaskList,
+		ParentWorkflowID: parentWorkflowID,
+		ParentRunID:      parentRunID,
 		CloseTime:        &closeTime,
 		CloseStatus:      common.Int32Ptr(int32(request.Status)),
 		HistoryLength:    &request.HistoryLength,
-	})
+	}
+	if info := request.TerminationInfo; info != nil {
+		row.TerminateCause = common.Int32Ptr(int32(info.GetCause()))
+		row.TerminateReason = info.Reason
+		row.TerminateDetails = info.Details
+		row.TerminateIdentity = info.Identity
+	}
+	result, err := s.db.ReplaceIntoVisibility(row)
 	if err != nil {
 		return err
 	}
@@ -95,141 +111,95 @@ func (s *sqlVisibilityStore) RecordWorkflowExecutionClosed(request *p.RecordWork
 	if noRowsAffected > 2 { // either adds a new row or deletes old row and adds new row
 		return fmt.Errorf("RecordWorkflowExecutionClosed unexpected numRows (%v) updated", noRowsAffected)
 	}
+	if request.Status == workflow.WorkflowExecutionCloseStatusDeleted {
+		// there is no TTL in sql, the tombstones of the domain which outlived their retention are
+		// deleted whenever a new tombstone is recorded
+		maxCloseTime := closeTime.Add(-time.Duration(request.RetentionSeconds) * time.Second)
+		_, err := s.db.DeleteFromVisibility(&sqldb.VisibilityFilter{
+			DomainID:     request.DomainUUID,
+			CloseStatus:  common.Int32Ptr(int32(workflow.WorkflowExecutionCloseStatusDeleted)),
+			MaxCloseTime: &maxCloseTime,
+		})
+		if err != nil {
+			return &workflow.InternalServiceError{Message: err.Error()}
+		}
+	}
 	return nil
 }
 
-func (s *sqlVisibilityStore) ListOpenWorkflowExecutions(request *p.ListWorkflowExecutionsRequest) (*p.ListWorkflowExecutionsResponse, error) {
-	return s.listWorkflowExecutions("ListOpenWorkflowExecutions", request.NextPageToken, request.EarliestStartTime, request.LatestStartTime,
-		func(readLevel *visibilityPageToken) ([]sqldb.VisibilityRow, error) {
-			minStartTime := time.Unix(0, request.EarliestStartTime)
-			return s.db.SelectFromVisibility(&sqldb.VisibilityFilter{
-				DomainID:     request.DomainUUID,
-				MinStartTime: &minStartTime,
-				MaxStartTime: &readLevel.Time,
-				RunID:        &readLevel.RunID,
-				PageSize:     &request.PageSize,
-			})
-		})
+func (s *sqlVisibilityStore) ListOpenWorkflowExecutions(ctx context.Context, request *p.ListWorkflowExecutionsRequest) (*p.ListWorkflowExecutionsResponse, error) {
+	return s.listWorkflowExecutions("ListOpenWorkflowExecutions", request, false, s.db.SelectFromVisibility)
 }
 
-func (s *sqlVisibilityStore) ListClosedWorkflowExecutions(request *p.ListWorkflowExecutionsRequest) (*p.ListWorkflowExecutionsResponse, error) {
-	return s.listWorkflowExecutions("ListClosedWorkflowExecutions", request.NextPageToken, request.EarliestStartTime, request.LatestStartTime,
-		func(readLevel *visibilityPageToken) ([]sqldb.VisibilityRow, error) {
-			minStartTime := time.Unix(0, request.EarliestStartTime)
-			return s.db.SelectFromVisibility(&sqldb.VisibilityFilter{
-				DomainID:     request.DomainUUID,
-				MinStartTime: &minStartTime,
-				MaxStartTime: &readLevel.Time,
-				Closed:       true,
-				RunID:        &readLevel.RunID,
-				PageSize:     &request.PageSize,
-			})
-		})
+func (s *sqlVisibilityStore) ListClosedWorkflowExecutions(ctx context.Context, request *p.ListWorkflowExecutionsRequest) (*p.ListWorkflowExecutionsResponse, error) {
+	return s.listWorkflowExecutions("ListClosedWorkflowExecutions", request, true, s.db.SelectFromVisibility)
 }
 
-func (s *sqlVisibilityStore) ListOpenWorkflowExecutionsByType(request *p.ListWorkflowExecutionsByTypeRequest) (*p.ListWorkflowExecutionsResponse, error) {
-	return s.listWorkflowExecutions("ListOpenWorkflowExecutionsByType", request.NextPageToken, request.EarliestStartTime, request.LatestStartTime,
-		func(readLevel *visibilityPageToken) ([]sqldb.VisibilityRow, error) {
-			minStartTime := time.Unix(0, request.EarliestStartTime)
-			return s.db.SelectFromVisibility(&sqldb.VisibilityFilter{
-				DomainID:         request.DomainUUID,
-				MinStartTime:     &minStartTime,
-				MaxStartTime:     &readLevel.Time,
-				RunID:            &readLevel.RunID,
-				WorkflowTypeName: &request.WorkflowTypeName,
-				PageSize:         &request.PageSize,
-			})
+func (s *sqlVisibilityStore) ListOpenWorkflowExecutionsByType(ctx context.Context, request *p.ListWorkflowExecutionsByTypeRequest) (*p.ListWorkflowExecutionsResponse, error) {
+	return s.listWorkflowExecutions("ListOpenWorkflowExecutionsByType", &request.ListWorkflowExecutionsRequest, false,
+		func(filter *sqldb.VisibilityFilter) ([]sqldb.VisibilityRow, error) {
+			filter.WorkflowTypeName = &request.WorkflowTypeName
+			return s.db.SelectFromVisibility(filter)
 		})
 }
 
-func (s *sqlVisibilityStore) ListClosedWorkflowExecutionsByType(request *p.ListWorkflowExecutionsByTypeRequest) (*p.ListWorkflowExecutionsResponse, error) {
-	return s.listWorkflowExecutions("ListClosedWorkflowExecutionsByType", request.NextPageToken, request.EarliestStartTime, request.LatestStartTime,
-		func(readLevel *visibilityPageToken) ([]sqldb.VisibilityRow, error) {
-			minStartTime := time.Unix(0, request.EarliestStartTime)
-			return s.db.SelectFromVisibility(&sqldb.VisibilityFilter{
-				DomainID:         request.DomainUUID,
-				MinStartTime:     &minStartTime,
-				MaxStartTime:     &readLevel.Time,
-				Closed:           true,
-				RunID:            &readLevel.RunID,
-				WorkflowTypeName: &request.WorkflowTypeName,
-				PageSize:         &request.PageSize,
-			})
+func (s *sqlVisibilityStore) ListClosedWorkflowExecutionsByType(ctx context.Context, request *p.ListWorkflowExecutionsByTypeRequest) (*p.ListWorkflowExecutionsResponse, error) {
+	return s.listWorkflowExecutions("ListClosedWorkflowExecutionsByType", &request.ListWorkflowExecutionsRequest, true,
+		func(filter *sqldb.VisibilityFilter) ([]sqldb.VisibilityRow, error) {
+			filter.WorkflowTypeName = &request.WorkflowTypeName
+			return s.db.SelectFromVisibility(filter)
 		})
 }
 
-func (s *sqlVisibilityStore) ListOpenWorkflowExecutionsByWorkflowID(request *p.ListWorkflowExecutionsByWorkflowIDRequest) (*p.ListWorkflowExecutionsResponse, error) {
-	return s.listWorkflowExecutions("ListOpenWorkflowExecutionsByWorkflowID", request.NextPageToken, request.EarliestStartTime, request.LatestStartTime,
-		func(readLevel *visibilityPageToken) ([]sqldb.VisibilityRow, error) {
-			minStartTime := time.Unix(0, request.EarliestStartTime)
-			return s.db.SelectFromVisibility(&sqldb.VisibilityFilter{
-				DomainID:     request.DomainUUID,
-				MinStartTime: &minStartTime,
-				MaxStartTime: &readLevel.Time,
-				RunID:        &readLevel.RunID,
-				WorkflowID:   &request.WorkflowID,
-				PageSize:     &request.PageSize,
-			})
+func (s *sqlVisibilityStore) ListOpenWorkflowExecutionsByWorkflowID(ctx context.Context, request *p.ListWorkflowExecutionsByWorkflowIDRequest) (*p.ListWorkflowExecutionsResponse, error) {
+	return s.listWorkflowExecutions("ListOpenWorkflowExecutionsByWorkflowID", &request.ListWorkflowExecutionsRequest, false,
+		func(filter *sqldb.VisibilityFilter) ([]sqldb.VisibilityRow, error) {
+			filter.WorkflowID = &request.WorkflowID
+			return s.db.SelectFromVisibility(filter)
 		})
 }
 
-func (s *sqlVisibilityStore) ListClosedWorkflowExecutionsByWorkflowID(request *p.ListWorkflowExecutionsByWorkflowIDRequest) (*p.ListWorkflowExecutionsResponse, error) {
-	return s.listWorkflowExecutions("ListClosedWorkflowExecutionsByWorkflowID", request.NextPageToken, request.EarliestStartTime, request.LatestStartTime,
-		func(readLevel *visibilityPageToken) ([]sqldb.VisibilityRow, error) {
-			minStartTime := time.Unix(0, request.EarliestStartTime)
-			return s.db.SelectFromVisibility(&sqldb.VisibilityFilter{
-				DomainID:     request.DomainUUID,
-				MinStartTime: &minStartTime,
-				MaxStartTime: &readLevel.Time,
-				Closed:       true,
-				RunID:        &readLevel.RunID,
-				WorkflowID:   &request.WorkflowID,
-				PageSize:     &request.PageSize,
-			})
+func (s *sqlVisibilityStore) ListClosedWorkflowExecutionsByWorkflowID(ctx context.Context, request *p.ListWorkflowExecutionsByWorkflowIDRequest) (*p.ListWorkflowExecutionsResponse, error) {
+	return s.listWorkflowExecutions("ListClosedWorkflowExecutionsByWorkflowID", &request.ListWorkflowExecutionsRequest, true,
+		func(filter *sqldb.VisibilityFilter) ([]sqldb.VisibilityRow, error) {
+			filter.WorkflowID = &request.WorkflowID
+			return s.db.SelectFromVisibility(filter)
 		})
 }
 
-func (s *sqlVisibilityStore) ListClosedWorkflowExecutionsByStatus(request *p.ListClosedWorkflowExecutionsByStatusRequest) (*p.ListWorkflowExecutionsResponse, error) {
-	return s.listWorkflowExecutions("ListClosedWorkflowExecutionsByStatus", request.NextPageToken, request.EarliestStartTime, request.LatestStartTime,
-		func(readLevel *visibilityPageToken) ([]sqldb.VisibilityRow, error) {
-			minStartTime := time.Unix(0, request.EarliestStartTime)
-			return s.db.SelectFromVisibility(&sqldb.VisibilityFilter{
-				DomainID:     request.DomainUUID,
-				MinStartTime: &minStartTime,
-				MaxStartTime: &readLevel.Time,
-				Closed:       true,
-				RunID:        &readLevel.RunID,
-				CloseStatus:  common.Int32Ptr(int32(request.Status)),
-				PageSize:     &request.PageSize,
-			})
+func (s *sqlVisibilityStore) ListClosedWorkflowExecutionsByStatus(ctx context.Context, request *p.ListClosedWorkflowExecutionsByStatusRequest) (*p.ListWorkflowExecutionsResponse, error) {
+	return s.listWorkflowExecutions("ListClosedWorkflowExecutionsByStatus", &request.ListWorkflowExecutionsRequest, true,
+		func(filter *sqldb.VisibilityFilter) ([]sqldb.VisibilityRow, error) {
+			filter.CloseStatus = common.Int32Ptr(int32(request.Status))
+			return s.db.SelectFromVisibility(filter)
 		})
 }
 
-func (s *sqlVisibilityStore) GetClosedWorkflowExecution(request *p.GetClosedWorkflowExecutionRequest) (*p.GetClosedWorkflowExecutionResponse, error) {
+func (s *sqlVisibilityStore) GetClosedWorkflowExecution(ctx context.Context, request *p.GetClosedWorkflowExecutionRequest) (*p.GetClosedWorkflowExecutionResponse, error) {
 	execution := request.Execution
 	rows, err := s.db.SelectFromVisibility(&sqldb.VisibilityFilter{
 		DomainID: request.DomainUUID,
 		Closed:   true,
 		RunID:    execution.RunId,
 	})
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, &workflow.EntityNotExistsError{
-				Message: fmt.Sprintf("Workflow execution not found.  WorkflowId: %v, RunId: %v",
-					execution.GetWorkflowId(), execution.GetRunId()),
-			}
-		}
+	if err != nil && err != sql.ErrNoRows {
 		return nil, &workflow.InternalServiceError{
 			Message: fmt.Sprintf("GetClosedWorkflowExecution operation failed. Select failed: %v", err),
 		}
 	}
+	if len(rows) == 0 {
+		return nil, &workflow.EntityNotExistsError{
+			Message: fmt.Sprintf("Workflow execution not found.  WorkflowId: %v, RunId: %v",
+				execution.GetWorkflowId(), execution.GetRunId()),
+		}
+	}
 	rows[0].DomainID = request.DomainUUID
 	rows[0].RunID = execution.GetRunId()
 	rows[0].WorkflowID = execution.GetWorkflowId()
 	return &p.GetClosedWorkflowExecutionResponse{Execution: rowToInfo(&rows[0])}, nil
 }
 
-func (s *sqlVisibilityStore) DeleteWorkflowExecution(request *p.VisibilityDeleteWorkflowExecutionRequest) error {
+func (s *sqlVisibilityStore) DeleteWorkflowExecution(ctx context.Context, request *p.VisibilityDeleteWorkflowExecutionRequest) error {
 	_, err := s.db.DeleteFromVisibility(&sqldb.VisibilityFilter{
 		DomainID: request.DomainID,
 		RunID:    &request.RunID,
@@ -240,6 +210,22 @@ func (s *sqlVisibilityStore) DeleteWorkflowExecution(request *p.VisibilityDelete
 	return nil
 }
 
+func (s *sqlVisibilityStore) GetWorkflowExecutionStatistics(ctx context.Context, request *p.GetWorkflowExecutionStatisticsRequest) (*p.GetWorkflowExecutionStatisticsResponse, error) {
+	return nil, p.ErrVisibilityStatisticsNotSupported
+}
+
+func (s *sqlVisibilityStore) CountOpenWorkflowExecutions(ctx context.Context, request *p.CountWorkflowExecutionsRequest) (*p.CountWorkflowExecutionsResponse, error) {
+	return nil, p.ErrVisibilityCountNotSupported
+}
+
+// toParentColumns returns the parent columns of a visibility row, which are NULL if the execution has no parent
+func toParentColumns(parentWorkflowID string, parentRunID string) (*string, *string) {
+	if parentWorkflowID == "" {
+		return nil, nil
+	}
+	return common.StringPtr(parentWorkflowID), common.StringPtr(parentRunID)
+}
+
 func rowToInfo(row *sqldb.VisibilityRow) *workflow.WorkflowExecutionInfo {
 	if row.ExecutionTime.UnixNano() == 0 {
 		row.ExecutionTime = row.StartTime
@@ -252,6 +238,13 @@ func rowToInfo(row *sqldb.VisibilityRow) *workflow.WorkflowExecutionInfo {
 		Type:          &workflow.WorkflowType{Name: common.StringPtr(row.WorkflowTypeName)},
 		StartTime:     common.Int64Ptr(row.StartTime.UnixNano()),
 		ExecutionTime: common.Int64Ptr(row.ExecutionTime.UnixNano()),
+		TaskList:      common.StringPtr(row.TaskList),
+	}
+	if row.ParentWorkflowID != nil {
+		info.ParentExecution = &workflow.WorkflowExecution{
+			WorkflowId: row.ParentWorkflowID,
+			RunId:      row.ParentRunID,
+		}
 	}
 	if row.CloseStatus != nil {
 		status := workflow.WorkflowExecutionCloseStatus(*row.CloseStatus)
@@ -260,21 +253,44 @@ func rowToInfo(row *sqldb.VisibilityRow) *workflow.WorkflowExecutionInfo {
 		info.CloseTime = &closeTime
 		info.HistoryLength = row.HistoryLength
 	}
+	if row.TerminateCause != nil {
+		info.TerminationInfo = &workflow.WorkflowExecutionTerminationInfo{
+			Cause:    workflow.TerminationCause(*row.TerminateCause).Ptr(),
+			Reason:   row.TerminateReason,
+			Details:  row.TerminateDetails,
+			Identity: row.TerminateIdentity,
+		}
+	}
 	return info
 }
 
-func (s *sqlVisibilityStore) listWorkflowExecutions(opName string, pageToken []byte, earliestTime int64, latestTime int64, selectOp func(readLevel *visibilityPageToken) ([]sqldb.VisibilityRow, error)) (*p.ListWorkflowExecutionsResponse, error) {
-	var readLevel *visibilityPageToken
-	var err error
-	if len(pageToken) > 0 {
-		readLevel, err = s.deserializePageToken(pageToken)
+func (s *sqlVisibilityStore) listWorkflowExecutions(opName string, request *p.ListWorkflowExecutionsRequest, closed bool, selectOp func(filter *sqldb.VisibilityFilter) ([]sqldb.VisibilityRow, error)) (*p.ListWorkflowExecutionsResponse, error) {
+	if err := p.ValidateVisibilitySort(request, !closed); err != nil {
+		return nil, err
+	}
+	if request.HasLabelFilter() {
+		return nil, p.ErrVisibilityLabelFilterNotSupported
+	}
+	minStartTime := time.Unix(0, request.EarliestStartTime)
+	maxStartTime := time.Unix(0, request.LatestStartTime)
+	filter := &sqldb.VisibilityFilter{
+		DomainID:      request.DomainUUID,
+		Closed:        closed,
+		MinStartTime:  &minStartTime,
+		MaxStartTime:  &maxStartTime,
+		PageSize:      &request.PageSize,
+		SortColumn:    sortColumn(request.SortField),
+		SortAscending: request.SortOrder == p.VisibilitySortOrderAsc,
+	}
+	if len(request.NextPageToken) > 0 {
+		readLevel, err := s.deserializePageToken(request.NextPageToken)
 		if err != nil {
 			return nil, err
 		}
-	} else {
-		readLevel = &visibilityPageToken{Time: time.Unix(0, latestTime), RunID: ""}
+		filter.PageTime = &readLevel.Time
+		filter.RunID = &readLevel.RunID
 	}
-	rows, err := selectOp(readLevel)
+	rows, err := selectOp(filter)
 	if err != nil {
 		return nil, &workflow.InternalServiceError{
 			Message: fmt.Sprintf("%v operation failed. Select failed: %v", opName, err),
@@ -289,11 +305,10 @@ func (s *sqlVisibilityStore) listWorkflowExecutions(opName string, pageToken []b
 		infos[i] = rowToInfo(&row)
 	}
 	var nextPageToken []byte
-	lastRow := rows[len(rows)-1]
-	lastStartTime := lastRow.StartTime
-	if lastStartTime.Sub(time.Unix(0, earliestTime)).Nanoseconds() > 0 {
+	if len(rows) == request.PageSize {
+		lastRow := rows[len(rows)-1]
 		nextPageToken, err = s.serializePageToken(&visibilityPageToken{
-			Time:  lastStartTime,
+			Time:  sortValue(&lastRow, filter.SortColumn),
 			RunID: lastRow.RunID,
 		})
 		if err != nil {
@@ -306,13 +321,48 @@ func (s *sqlVisibilityStore) listWorkflowExecutions(opName string, pageToken []b
 	}, nil
 }
 
+// sortColumn maps the requested sort field to a visibility table column
+func sortColumn(field p.VisibilitySortField) string {
+	switch field {
+	case p.VisibilitySortFieldCloseTime:
+		return "close_time"
+	case p.VisibilitySortFieldExecutionTime:
+		return "execution_time"
+	default:
+		return "start_time"
+	}
+}
+
+// sortValue returns the value of the sort column of a row
+func sortValue(row *sqldb.VisibilityRow, column string) time.Time {
+	switch column {
+	case "close_time":
+		return *row.CloseTime
+	case "execution_time":
+		return row.ExecutionTime
+	default:
+		return row.StartTime
+	}
+}
+
 func (s *sqlVisibilityStore) deserializePageToken(data []byte) (*visibilityPageToken, error) {
+	payload, err := p.DeserializeVisibilityPageToken(p.VisibilityStoreSQL, data)
+	if err != nil {
+		return nil, err
+	}
 	var token visibilityPageToken
-	err := json.Unmarshal(data, &token)
-	return &token, err
+	if err := json.Unmarshal(payload, &token); err != nil {
+		return nil, &workflow.BadRequestError{
+			Message: fmt.Sprintf("invalid next page token: unable to deserialize page token. err: %v", err),
+		}
+	}
+	return &token, nil
 }
 
 func (s *sqlVisibilityStore) serializePageToken(token *visibilityPageToken) ([]byte, error) {
 	data, err := json.Marshal(token)
-	return data, err
+	if err != nil {
+		return nil, err
+	}
+	return p.SerializeVisibilityPageToken(p.VisibilityStoreSQL, data), nil
 }
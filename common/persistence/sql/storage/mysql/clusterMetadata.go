@@ -0,0 +1,110 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package mysql
+
+import (
+	"database/sql"
+
+	"github.com/uber/cadence/common/persistence/sql/storage/sqldb"
+)
+
+// the metadata of the cluster is a single row
+const clusterMetadataPartition = 0
+
+const (
+	createClusterMetadataQry = `INSERT INTO cluster_metadata
+(metadata_partition,
+cluster_name,
+failover_version_increment,
+initial_failover_versions,
+cluster_addresses,
+search_attributes,
+num_history_shards,
+version)
+VALUES
+(:metadata_partition,
+:cluster_name,
+:failover_version_increment,
+:initial_failover_versions,
+:cluster_addresses,
+:search_attributes,
+:num_history_shards,
+:version)`
+
+	getClusterMetadataQry = `SELECT
+metadata_partition,
+cluster_name,
+failover_version_increment,
+initial_failover_versions,
+cluster_addresses,
+search_attributes,
+num_history_shards,
+version
+FROM cluster_metadata WHERE
+metadata_partition = ?
+`
+
+	updateClusterMetadataQry = `UPDATE
+cluster_metadata
+SET
+cluster_name = ?,
+failover_version_increment = ?,
+initial_failover_versions = ?,
+cluster_addresses = ?,
+search_attributes = ?,
+num_history_shards = ?,
+version = ?
+WHERE
+metadata_partition = ? AND
+version = ?
+`
+)
+
+// InsertIntoClusterMetadata inserts the single row into cluster_metadata table
+func (mdb *DB) InsertIntoClusterMetadata(row *sqldb.ClusterMetadataRow) (sql.Result, error) {
+	row.MetadataPartition = clusterMetadataPartition
+	return mdb.conn.NamedExec(createClusterMetadataQry, row)
+}
+
+// UpdateClusterMetadata updates the single row in cluster_metadata table if its version matches previousVersion
+func (mdb *DB) UpdateClusterMetadata(row *sqldb.ClusterMetadataRow, previousVersion int64) (sql.Result, error) {
+	return mdb.conn.Exec(updateClusterMetadataQry,
+		row.ClusterName,
+		row.FailoverVersionIncrement,
+		row.InitialFailoverVersions,
+		row.ClusterAddresses,
+		row.SearchAttributes,
+		row.NumHistoryShards,
+		row.Version,
+		clusterMetadataPartition,
+		previousVersion,
+	)
+}
+
+// SelectFromClusterMetadata reads the single row in cluster_metadata table
+func (mdb *DB) SelectFromClusterMetadata() (*sqldb.ClusterMetadataRow, error) {
+	var row sqldb.ClusterMetadataRow
+	err := mdb.conn.Get(&row, getClusterMetadataQry, clusterMetadataPartition)
+	if err != nil {
+		return nil, err
+	}
+	return &row, err
+}
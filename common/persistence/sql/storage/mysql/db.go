@@ -21,6 +21,8 @@
 package mysql
 
 import (
+	"context"
+
 	"github.com/jmoiron/sqlx"
 	"github.com/uber/cadence/common/persistence/sql/storage/sqldb"
 )
@@ -49,8 +51,8 @@ func NewDB(xdb *sqlx.DB, tx *sqlx.Tx) *DB {
 }
 
 // BeginTx starts a new transaction and returns a reference to the Tx object
-func (mdb *DB) BeginTx() (sqldb.Tx, error) {
-	xtx, err := mdb.db.Beginx()
+func (mdb *DB) BeginTx(ctx context.Context) (sqldb.Tx, error) {
+	xtx, err := mdb.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
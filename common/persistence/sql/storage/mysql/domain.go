@@ -37,6 +37,7 @@ const (
 		emit_metric,
 		archival_bucket,
 		archival_status,
+		archival_target_bucket,
 		config_version,
 		status, 
 		description, 
@@ -56,6 +57,7 @@ const (
 		:emit_metric,
 		:archival_bucket,
 		:archival_status,
+		:archival_target_bucket,
 		:config_version,
 		:status, 
 		:description, 
@@ -74,6 +76,7 @@ const (
 		emit_metric = :emit_metric,
 		archival_bucket = :archival_bucket,
 		archival_status = :archival_status,
+		archival_target_bucket = :archival_target_bucket,
 		config_version = :config_version,
 		status = :status, 
 		description = :description, 
@@ -92,6 +95,7 @@ const (
 		emit_metric,
 		archival_bucket,
 		archival_status,
+		archival_target_bucket,
 		config_version,
 		name, 
 		status, 
@@ -69,7 +69,11 @@ expiration_seconds,
 expiration_time,
 non_retryable_errors,
 event_store_version,
-branch_token
+branch_token,
+labels,
+version_histories,
+version_histories_encoding,
+checksum
 `
 
 	executionsColumnsTags = `:shard_id,
@@ -114,7 +118,11 @@ branch_token
 :expiration_time,
 :non_retryable_errors,
 :event_store_version,
-:branch_token`
+:branch_token,
+:labels,
+:version_histories,
+:version_histories_encoding,
+:checksum`
 
 	executionsBlobColumns = `completion_event,
 execution_context`
@@ -214,7 +222,11 @@ expiration_seconds = :expiration_seconds,
 expiration_time = :expiration_time,
 non_retryable_errors = :non_retryable_errors,
 event_store_version = :event_store_version,
-branch_token = :branch_token
+branch_token = :branch_token,
+labels = :labels,
+version_histories = :version_histories,
+version_histories_encoding = :version_histories_encoding,
+checksum = :checksum
 
 WHERE
 shard_id = :shard_id AND
@@ -386,8 +398,8 @@ ORDER BY task_id LIMIT ?`
 
 	deleteReplicationTaskQry = `DELETE FROM replication_tasks WHERE shard_id = ? AND task_id = ?`
 
-	timerTaskInfoColumns     = `visibility_timestamp, task_id, domain_id, workflow_id, run_id, task_type, timeout_type, event_id, schedule_attempt, version`
-	timerTaskInfoColumnsTags = `:visibility_timestamp, :task_id, :domain_id, :workflow_id, :run_id, :task_type, :timeout_type, :event_id, :schedule_attempt, :version`
+	timerTaskInfoColumns     = `visibility_timestamp, task_id, domain_id, workflow_id, run_id, task_type, timeout_type, event_id, schedule_attempt, version, signal_name, signal_input, signal_identity, signal_request_id`
+	timerTaskInfoColumnsTags = `:visibility_timestamp, :task_id, :domain_id, :workflow_id, :run_id, :task_type, :timeout_type, :event_id, :schedule_attempt, :version, :signal_name, :signal_input, :signal_identity, :signal_request_id`
 	timerTasksColumns        = `shard_id,` + timerTaskInfoColumns
 	timerTasksColumnsTags    = `:shard_id,` + timerTaskInfoColumnsTags
 	createTimerTasksQry      = `INSERT INTO timer_tasks (` +
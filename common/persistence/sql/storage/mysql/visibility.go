@@ -30,51 +30,60 @@ import (
 
 const (
 	templateCreateWorkflowExecutionStarted = `INSERT IGNORE INTO executions_visibility (` +
-		`domain_id, workflow_id, run_id, start_time, execution_time, workflow_type_name) ` +
-		`VALUES (?, ?, ?, ?, ?, ?)`
+		`domain_id, workflow_id, run_id, start_time, execution_time, workflow_type_name, task_list, parent_workflow_id, parent_run_id) ` +
+		`VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
 	templateCreateWorkflowExecutionClosed = `REPLACE INTO executions_visibility (` +
-		`domain_id, workflow_id, run_id, start_time, execution_time, workflow_type_name, close_time, close_status, history_length) ` +
-		`VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+		`domain_id, workflow_id, run_id, start_time, execution_time, workflow_type_name, task_list, parent_workflow_id, parent_run_id, ` +
+		`close_time, close_status, history_length, terminate_cause, terminate_reason, terminate_details, terminate_identity) ` +
+		`VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
-	// RunID condition is needed for correct pagination
 	templateConditions = ` AND domain_id = ?
 		 AND start_time >= ?
-		 AND start_time <= ?
- 		 AND (run_id > ? OR start_time < ?)
-         ORDER BY start_time DESC, run_id
+		 AND start_time <= ? `
+
+	// RunID condition is needed for correct pagination
+	templatePageConditions = ` AND (%[1]v %[2]v ? OR (%[1]v = ? AND run_id > ?)) `
+
+	templateOrderBy = ` ORDER BY %v %v, run_id
          LIMIT ?`
 
-	templateOpenFieldNames = `workflow_id, run_id, start_time, execution_time, workflow_type_name`
+	templateOpenFieldNames = `workflow_id, run_id, start_time, execution_time, workflow_type_name, task_list, parent_workflow_id, parent_run_id`
 	templateOpenSelect     = `SELECT ` + templateOpenFieldNames + ` FROM executions_visibility WHERE close_status IS NULL `
 
-	templateClosedSelect = `SELECT ` + templateOpenFieldNames + `, close_time, close_status, history_length
+	templateClosedFieldNames = `close_time, close_status, history_length, terminate_cause, terminate_reason, terminate_details, terminate_identity`
+	templateClosedSelect     = `SELECT ` + templateOpenFieldNames + `, ` + templateClosedFieldNames + `
 		 FROM executions_visibility WHERE close_status IS NOT NULL `
 
-	templateGetOpenWorkflowExecutions = templateOpenSelect + templateConditions
-
-	templateGetClosedWorkflowExecutions = templateClosedSelect + templateConditions
-
-	templateGetOpenWorkflowExecutionsByType = templateOpenSelect + `AND workflow_type_name = ?` + templateConditions
+	templateGetOpenWorkflowExecutionsByType = templateOpenSelect + `AND workflow_type_name = ?`
 
-	templateGetClosedWorkflowExecutionsByType = templateClosedSelect + `AND workflow_type_name = ?` + templateConditions
+	templateGetClosedWorkflowExecutionsByType = templateClosedSelect + `AND workflow_type_name = ?`
 
-	templateGetOpenWorkflowExecutionsByID = templateOpenSelect + `AND workflow_id = ?` + templateConditions
+	templateGetOpenWorkflowExecutionsByID = templateOpenSelect + `AND workflow_id = ?`
 
-	templateGetClosedWorkflowExecutionsByID = templateClosedSelect + `AND workflow_id = ?` + templateConditions
+	templateGetClosedWorkflowExecutionsByID = templateClosedSelect + `AND workflow_id = ?`
 
-	templateGetClosedWorkflowExecutionsByStatus = templateClosedSelect + `AND close_status = ?` + templateConditions
+	templateGetClosedWorkflowExecutionsByStatus = templateClosedSelect + `AND close_status = ?`
 
-	templateGetClosedWorkflowExecution = `SELECT workflow_id, run_id, start_time, execution_time, close_time, workflow_type_name, close_status, history_length
+	templateGetClosedWorkflowExecution = `SELECT ` + templateOpenFieldNames + `, ` + templateClosedFieldNames + `
 		 FROM executions_visibility
 		 WHERE domain_id = ? AND close_status IS NOT NULL
 		 AND run_id = ?`
 
 	templateDeleteWorkflowExecution = "DELETE FROM executions_visibility WHERE domain_id=? AND run_id=?"
+
+	templateDeleteClosedWorkflowExecutionsByStatus = "DELETE FROM executions_visibility WHERE domain_id=? AND close_status=? AND close_time<?"
 )
 
 var errCloseParams = errors.New("missing one of {closeStatus, closeTime, historyLength} params")
 
+// visibilitySortColumns are the columns list queries can be ordered by
+var visibilitySortColumns = map[string]struct{}{
+	"start_time":     {},
+	"close_time":     {},
+	"execution_time": {},
+}
+
 // InsertIntoVisibility inserts a row into visibility table. If an row already exist,
 // its left as such and no update will be made
 func (mdb *DB) InsertIntoVisibility(row *sqldb.VisibilityRow) (sql.Result, error) {
@@ -85,7 +94,10 @@ func (mdb *DB) InsertIntoVisibility(row *sqldb.VisibilityRow) (sql.Result, error
 		row.RunID,
 		row.StartTime,
 		row.ExecutionTime,
-		row.WorkflowTypeName)
+		row.WorkflowTypeName,
+		row.TaskList,
+		row.ParentWorkflowID,
+		row.ParentRunID)
 }
 
 // ReplaceIntoVisibility replaces an existing row if it exist or creates a new row in visibility table
@@ -101,17 +113,35 @@ func (mdb *DB) ReplaceIntoVisibility(row *sqldb.VisibilityRow) (sql.Result, erro
 			row.StartTime,
 			row.ExecutionTime,
 			row.WorkflowTypeName,
+			row.TaskList,
+			row.ParentWorkflowID,
+			row.ParentRunID,
 			closeTime,
 			*row.CloseStatus,
-			*row.HistoryLength)
+			*row.HistoryLength,
+			row.TerminateCause,
+			row.TerminateReason,
+			row.TerminateDetails,
+			row.TerminateIdentity)
 	default:
 		return nil, errCloseParams
 	}
 }
 
-// DeleteFromVisibility deletes a row from visibility table if it exist
+// DeleteFromVisibility deletes a row from visibility table if it exist, or all the closed rows of a domain
+// with the given close status closed before the given time
 func (mdb *DB) DeleteFromVisibility(filter *sqldb.VisibilityFilter) (sql.Result, error) {
-	return mdb.conn.Exec(templateDeleteWorkflowExecution, filter.DomainID, filter.RunID)
+	switch {
+	case filter.RunID != nil:
+		return mdb.conn.Exec(templateDeleteWorkflowExecution, filter.DomainID, *filter.RunID)
+	case filter.CloseStatus != nil && filter.MaxCloseTime != nil:
+		return mdb.conn.Exec(templateDeleteClosedWorkflowExecutionsByStatus,
+			filter.DomainID,
+			*filter.CloseStatus,
+			mdb.converter.ToMySQLDateTime(*filter.MaxCloseTime))
+	default:
+		return nil, fmt.Errorf("invalid delete filter")
+	}
 }
 
 // SelectFromVisibility reads one or more rows from visibility table
@@ -136,52 +166,21 @@ func (mdb *DB) SelectFromVisibility(filter *sqldb.VisibilityFilter) ([]sqldb.Vis
 		if filter.Closed {
 			qry = templateGetClosedWorkflowExecutionsByID
 		}
-		err = mdb.conn.Select(&rows,
-			qry,
-			*filter.WorkflowID,
-			filter.DomainID,
-			mdb.converter.ToMySQLDateTime(*filter.MinStartTime),
-			mdb.converter.ToMySQLDateTime(*filter.MaxStartTime),
-			*filter.RunID,
-			*filter.MinStartTime,
-			*filter.PageSize)
+		rows, err = mdb.listFromVisibility(qry, filter, *filter.WorkflowID)
 	case filter.MinStartTime != nil && filter.WorkflowTypeName != nil:
 		qry := templateGetOpenWorkflowExecutionsByType
 		if filter.Closed {
 			qry = templateGetClosedWorkflowExecutionsByType
 		}
-		err = mdb.conn.Select(&rows,
-			qry,
-			*filter.WorkflowTypeName,
-			filter.DomainID,
-			mdb.converter.ToMySQLDateTime(*filter.MinStartTime),
-			mdb.converter.ToMySQLDateTime(*filter.MaxStartTime),
-			*filter.RunID,
-			*filter.MaxStartTime,
-			*filter.PageSize)
+		rows, err = mdb.listFromVisibility(qry, filter, *filter.WorkflowTypeName)
 	case filter.MinStartTime != nil && filter.CloseStatus != nil:
-		err = mdb.conn.Select(&rows,
-			templateGetClosedWorkflowExecutionsByStatus,
-			*filter.CloseStatus,
-			filter.DomainID,
-			mdb.converter.ToMySQLDateTime(*filter.MinStartTime),
-			mdb.converter.ToMySQLDateTime(*filter.MaxStartTime),
-			*filter.RunID,
-			mdb.converter.ToMySQLDateTime(*filter.MaxStartTime),
-			*filter.PageSize)
+		rows, err = mdb.listFromVisibility(templateGetClosedWorkflowExecutionsByStatus, filter, *filter.CloseStatus)
 	case filter.MinStartTime != nil:
-		qry := templateGetOpenWorkflowExecutions
+		qry := templateOpenSelect
 		if filter.Closed {
-			qry = templateGetClosedWorkflowExecutions
+			qry = templateClosedSelect
 		}
-		err = mdb.conn.Select(&rows,
-			qry,
-			filter.DomainID,
-			mdb.converter.ToMySQLDateTime(*filter.MinStartTime),
-			mdb.converter.ToMySQLDateTime(*filter.MaxStartTime),
-			*filter.RunID,
-			mdb.converter.ToMySQLDateTime(*filter.MaxStartTime),
-			*filter.PageSize)
+		rows, err = mdb.listFromVisibility(qry, filter)
 	default:
 		return nil, fmt.Errorf("invalid query filter")
 	}
@@ -198,3 +197,36 @@ func (mdb *DB) SelectFromVisibility(filter *sqldb.VisibilityFilter) ([]sqldb.Vis
 	}
 	return rows, err
 }
+
+// listFromVisibility reads a page of rows matching the given select query, args are the
+// arguments of the query specific conditions
+func (mdb *DB) listFromVisibility(qry string, filter *sqldb.VisibilityFilter, args ...interface{}) ([]sqldb.VisibilityRow, error) {
+	sortColumn := "start_time"
+	if filter.SortColumn != "" {
+		sortColumn = filter.SortColumn
+	}
+	if _, ok := visibilitySortColumns[sortColumn]; !ok {
+		return nil, fmt.Errorf("invalid sort column %v", sortColumn)
+	}
+	order, comparator := "DESC", "<"
+	if filter.SortAscending {
+		order, comparator = "ASC", ">"
+	}
+
+	qry += templateConditions
+	args = append(args,
+		filter.DomainID,
+		mdb.converter.ToMySQLDateTime(*filter.MinStartTime),
+		mdb.converter.ToMySQLDateTime(*filter.MaxStartTime))
+	if filter.PageTime != nil {
+		qry += fmt.Sprintf(templatePageConditions, sortColumn, comparator)
+		pageTime := mdb.converter.ToMySQLDateTime(*filter.PageTime)
+		args = append(args, pageTime, pageTime, *filter.RunID)
+	}
+	qry += fmt.Sprintf(templateOrderBy, sortColumn, order)
+	args = append(args, *filter.PageSize)
+
+	var rows []sqldb.VisibilityRow
+	err := mdb.conn.Select(&rows, qry, args...)
+	return rows, err
+}
@@ -21,6 +21,7 @@
 package sqldb
 
 import (
+	"context"
 	"database/sql"
 	"time"
 )
@@ -38,6 +39,7 @@ type (
 		EmitMetric                  bool
 		ArchivalBucket              string
 		ArchivalStatus              int
+		ArchivalTargetBucket        string
 		ConfigVersion               int64
 		NotificationVersion         int64
 		FailoverNotificationVersion int64
@@ -64,6 +66,18 @@ type (
 		NotificationVersion int64
 	}
 
+	// ClusterMetadataRow represents the single row in cluster_metadata table
+	ClusterMetadataRow struct {
+		MetadataPartition        int
+		ClusterName              string
+		FailoverVersionIncrement int64
+		InitialFailoverVersions  []byte
+		ClusterAddresses         []byte
+		SearchAttributes         []byte
+		NumHistoryShards         int
+		Version                  int64
+	}
+
 	// ShardsRow represents a row in shards table
 	ShardsRow struct {
 		ShardID                   int64
@@ -172,6 +186,10 @@ type (
 		NonRetryableErrors           []byte
 		EventStoreVersion            int
 		BranchToken                  []byte
+		Labels                       []byte
+		VersionHistories             []byte
+		VersionHistoriesEncoding     *string
+		Checksum                     []byte
 	}
 
 	// ExecutionsFilter contains the column names within domain table that
@@ -320,6 +338,10 @@ type (
 		EventID             int64
 		ScheduleAttempt     int64
 		Version             int64
+		SignalName          string
+		SignalInput         []byte
+		SignalIdentity      string
+		SignalRequestID     string
 	}
 
 	// TimerTasksFilter contains the column names within domain table that
@@ -438,6 +460,7 @@ type (
 		ExpirationTime           time.Time
 		MaxAttempts              int64
 		NonRetriableErrors       *[]byte
+		LastFailureReason        string
 	}
 
 	// ActivityInfoMapsFilter contains the column names within domain table that
@@ -603,9 +626,17 @@ type (
 		WorkflowID       string
 		StartTime        time.Time
 		ExecutionTime    time.Time
+		TaskList         string
+		ParentWorkflowID *string // nil if the execution has no parent
+		ParentRunID      *string
 		CloseStatus      *int32
 		CloseTime        *time.Time
 		HistoryLength    *int64
+		// termination columns are only set for terminated workflows
+		TerminateCause    *int32
+		TerminateReason   *string
+		TerminateDetails  []byte
+		TerminateIdentity *string
 	}
 
 	// VisibilityFilter contains the column names within domain table that
@@ -620,6 +651,15 @@ type (
 		MinStartTime     *time.Time
 		MaxStartTime     *time.Time
 		PageSize         *int
+		// SortColumn is the time column rows are ordered by, start_time if empty
+		SortColumn string
+		// SortAscending orders rows oldest first instead of newest first
+		SortAscending bool
+		// PageTime is the SortColumn value of the last row of the previous page, with
+		// RunID breaking ties. Nil when reading the first page
+		PageTime *time.Time
+		// MaxCloseTime is the exclusive upper bound of the close time of the rows deleted along with CloseStatus
+		MaxCloseTime *time.Time
 	}
 
 	// tableCRUD defines the API for interacting with the database tables
@@ -637,6 +677,11 @@ type (
 		UpdateDomainMetadata(row *DomainMetadataRow) (sql.Result, error)
 		SelectFromDomainMetadata() (*DomainMetadataRow, error)
 
+		InsertIntoClusterMetadata(row *ClusterMetadataRow) (sql.Result, error)
+		// UpdateClusterMetadata updates the row only if its version matches previousVersion
+		UpdateClusterMetadata(row *ClusterMetadataRow, previousVersion int64) (sql.Result, error)
+		SelectFromClusterMetadata() (*ClusterMetadataRow, error)
+
 		InsertIntoShards(rows *ShardsRow) (sql.Result, error)
 		UpdateShards(row *ShardsRow) (sql.Result, error)
 		SelectFromShards(filter *ShardsFilter) (*ShardsRow, error)
@@ -820,6 +865,10 @@ type (
 		//   - OPTIONALLY specify one of following params
 		//     - workflowID, workflowTypeName, closeStatus (along with closed=true)
 		SelectFromVisibility(filter *VisibilityFilter) ([]VisibilityRow, error)
+		// DeleteFromVisibility deletes one or more rows from visibility table
+		// Required filter params
+		// - single row - {domainID, runID}
+		// - multiple rows - {domainID, closeStatus, maxCloseTime}
 		DeleteFromVisibility(filter *VisibilityFilter) (sql.Result, error)
 	}
 
@@ -833,7 +882,7 @@ type (
 	// Interface defines the API for a SQL database
 	Interface interface {
 		tableCRUD
-		BeginTx() (Tx, error)
+		BeginTx(ctx context.Context) (Tx, error)
 		DriverName() string
 		Close() error
 	}
@@ -0,0 +1,458 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"fmt"
+
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+)
+
+type (
+	// VersionHistoryItem contains the event id and the associated version
+	VersionHistoryItem struct {
+		EventID int64
+		Version int64
+	}
+
+	// VersionHistory provides operations on the version history of a history branch
+	VersionHistory struct {
+		BranchToken []byte
+		Items       []*VersionHistoryItem
+	}
+
+	// VersionHistories contains all the version histories of a workflow execution,
+	// one per history branch, together with the index of the branch mutable state is built from
+	VersionHistories struct {
+		CurrentVersionHistoryIndex int
+		Histories                  []*VersionHistory
+	}
+)
+
+// NewVersionHistoryItem create a new version history item
+func NewVersionHistoryItem(eventID int64, version int64) *VersionHistoryItem {
+	return &VersionHistoryItem{
+		EventID: eventID,
+		Version: version,
+	}
+}
+
+// NewVersionHistoryItemFromThrift create a new version history item from thrift object
+func NewVersionHistoryItemFromThrift(input *workflow.VersionHistoryItem) *VersionHistoryItem {
+	return NewVersionHistoryItem(input.GetEventID(), input.GetVersion())
+}
+
+// Duplicate duplicate VersionHistoryItem
+func (item *VersionHistoryItem) Duplicate() *VersionHistoryItem {
+	return NewVersionHistoryItem(item.EventID, item.Version)
+}
+
+// ToThrift return thrift format of version history item
+func (item *VersionHistoryItem) ToThrift() *workflow.VersionHistoryItem {
+	return &workflow.VersionHistoryItem{
+		EventID: common.Int64Ptr(item.EventID),
+		Version: common.Int64Ptr(item.Version),
+	}
+}
+
+// Equals test if this version history item and input version history item are the same
+func (item *VersionHistoryItem) Equals(input *VersionHistoryItem) bool {
+	return item.Version == input.Version && item.EventID == input.EventID
+}
+
+// NewVersionHistory create a new version history
+func NewVersionHistory(branchToken []byte, items []*VersionHistoryItem) *VersionHistory {
+	history := &VersionHistory{
+		BranchToken: branchToken,
+		Items:       nil,
+	}
+	for _, item := range items {
+		history.Items = append(history.Items, item.Duplicate())
+	}
+	return history
+}
+
+// NewVersionHistoryFromThrift create a new version history from thrift object
+func NewVersionHistoryFromThrift(input *workflow.VersionHistory) *VersionHistory {
+	items := []*VersionHistoryItem{}
+	for _, item := range input.Items {
+		items = append(items, NewVersionHistoryItemFromThrift(item))
+	}
+	return NewVersionHistory(input.BranchToken, items)
+}
+
+// NewVersionHistoryItemsFromThrift create version history items from the thrift objects
+// sent along with replicated events
+func NewVersionHistoryItemsFromThrift(input []*workflow.VersionHistoryItem) []*VersionHistoryItem {
+	items := []*VersionHistoryItem{}
+	for _, item := range input {
+		items = append(items, NewVersionHistoryItemFromThrift(item))
+	}
+	return items
+}
+
+// Duplicate duplicate VersionHistory
+func (v *VersionHistory) Duplicate() *VersionHistory {
+	return NewVersionHistory(v.BranchToken, v.Items)
+}
+
+// ToThrift return thrift format of version history
+func (v *VersionHistory) ToThrift() *workflow.VersionHistory {
+	token := make([]byte, len(v.BranchToken))
+	copy(token, v.BranchToken)
+	items := []*workflow.VersionHistoryItem{}
+	for _, item := range v.Items {
+		items = append(items, item.ToThrift())
+	}
+	return &workflow.VersionHistory{
+		BranchToken: token,
+		Items:       items,
+	}
+}
+
+// DuplicateUntilLCAItem duplicate the version history up until LCA item
+func (v *VersionHistory) DuplicateUntilLCAItem(lcaItem *VersionHistoryItem) (*VersionHistory, error) {
+	versionHistory := NewVersionHistory(nil, nil)
+	notFoundErr := &workflow.BadRequestError{
+		Message: "version history does not contains the LCA item.",
+	}
+	for _, item := range v.Items {
+		if item.Version < lcaItem.Version {
+			if err := versionHistory.AddOrUpdateItem(item); err != nil {
+				return nil, err
+			}
+		} else if item.Version == lcaItem.Version {
+			if lcaItem.EventID > item.EventID {
+				return nil, notFoundErr
+			}
+			if err := versionHistory.AddOrUpdateItem(lcaItem); err != nil {
+				return nil, err
+			}
+			return versionHistory, nil
+		} else {
+			return nil, notFoundErr
+		}
+	}
+	return nil, notFoundErr
+}
+
+// SetBranchToken the overall branch token
+func (v *VersionHistory) SetBranchToken(branchToken []byte) {
+	token := make([]byte, len(branchToken))
+	copy(token, branchToken)
+	v.BranchToken = token
+}
+
+// GetBranchToken return the branch token
+func (v *VersionHistory) GetBranchToken() []byte {
+	token := make([]byte, len(v.BranchToken))
+	copy(token, v.BranchToken)
+	return token
+}
+
+// AddOrUpdateItem updates the versionHistory slice
+func (v *VersionHistory) AddOrUpdateItem(item *VersionHistoryItem) error {
+	if len(v.Items) == 0 {
+		v.Items = []*VersionHistoryItem{item.Duplicate()}
+		return nil
+	}
+
+	lastItem := v.Items[len(v.Items)-1]
+	if item.Version < lastItem.Version {
+		return &workflow.BadRequestError{Message: fmt.Sprintf(
+			"cannot update version history with a lower version %v. Last version: %v",
+			item.Version,
+			lastItem.Version,
+		)}
+	}
+
+	if item.EventID <= lastItem.EventID {
+		return &workflow.BadRequestError{Message: fmt.Sprintf(
+			"cannot add version history with a lower event id %v. Last event id: %v",
+			item.EventID,
+			lastItem.EventID,
+		)}
+	}
+
+	if item.Version > lastItem.Version {
+		// Add a new history
+		v.Items = append(v.Items, item.Duplicate())
+	} else {
+		// item.Version == lastItem.Version && item.EventID > lastItem.EventID
+		// Update event  id
+		lastItem.EventID = item.EventID
+	}
+	return nil
+}
+
+// ContainsItem check whether given version history item is included
+func (v *VersionHistory) ContainsItem(item *VersionHistoryItem) bool {
+	prevEventID := common.FirstEventID - 1
+	for _, currentItem := range v.Items {
+		if item.Version == currentItem.Version {
+			if prevEventID < item.EventID && item.EventID <= currentItem.EventID {
+				return true
+			}
+		} else if item.Version < currentItem.Version {
+			return false
+		}
+		prevEventID = currentItem.EventID
+	}
+	return false
+}
+
+// FindLCAItem returns the lowest common ancestor version history item
+func (v *VersionHistory) FindLCAItem(remote *VersionHistory) (*VersionHistoryItem, error) {
+	localIndex := len(v.Items) - 1
+	remoteIndex := len(remote.Items) - 1
+
+	for localIndex >= 0 && remoteIndex >= 0 {
+		localVersionItem := v.Items[localIndex]
+		remoteVersionItem := remote.Items[remoteIndex]
+
+		if localVersionItem.Version == remoteVersionItem.Version {
+			if localVersionItem.EventID > remoteVersionItem.EventID {
+				return remoteVersionItem.Duplicate(), nil
+			}
+			return localVersionItem.Duplicate(), nil
+		} else if localVersionItem.Version > remoteVersionItem.Version {
+			localIndex--
+		} else {
+			// localVersionItem.Version < remoteVersionItem.Version
+			remoteIndex--
+		}
+	}
+
+	return nil, &workflow.BadRequestError{
+		Message: "version history is malformed. No joint point found.",
+	}
+}
+
+// IsLCAAppendable checks if a LCA version history item is appendable
+func (v *VersionHistory) IsLCAAppendable(item *VersionHistoryItem) bool {
+	if len(v.Items) == 0 {
+		return false
+	}
+	return v.Items[len(v.Items)-1].Equals(item)
+}
+
+// GetFirstItem return the first version history item
+func (v *VersionHistory) GetFirstItem() (*VersionHistoryItem, error) {
+	if len(v.Items) == 0 {
+		return nil, &workflow.BadRequestError{Message: "version history is empty."}
+	}
+	return v.Items[0].Duplicate(), nil
+}
+
+// GetLastItem return the last version history item
+func (v *VersionHistory) GetLastItem() (*VersionHistoryItem, error) {
+	if len(v.Items) == 0 {
+		return nil, &workflow.BadRequestError{Message: "version history is empty."}
+	}
+	return v.Items[len(v.Items)-1].Duplicate(), nil
+}
+
+// GetEventVersion return the corresponding event version of an event ID
+func (v *VersionHistory) GetEventVersion(eventID int64) (int64, error) {
+	lastItem, err := v.GetLastItem()
+	if err != nil {
+		return 0, err
+	}
+	if eventID < common.FirstEventID || eventID > lastItem.EventID {
+		return 0, &workflow.BadRequestError{Message: "input event ID is not in range."}
+	}
+
+	// items are sorted by eventID & version
+	// so the fist item with item event ID >= input event ID
+	// the item version is the result
+	for _, currentItem := range v.Items {
+		if eventID <= currentItem.EventID {
+			return currentItem.Version, nil
+		}
+	}
+	return 0, &workflow.BadRequestError{Message: "input event ID is not in range."}
+}
+
+// IsEmpty indicate whether version history is empty
+func (v *VersionHistory) IsEmpty() bool {
+	return len(v.Items) == 0
+}
+
+// Equals test if this version history and input version history are the same
+func (v *VersionHistory) Equals(input *VersionHistory) bool {
+	if string(v.BranchToken) != string(input.BranchToken) {
+		return false
+	}
+	if len(v.Items) != len(input.Items) {
+		return false
+	}
+	for index, localItem := range v.Items {
+		if !localItem.Equals(input.Items[index]) {
+			return false
+		}
+	}
+	return true
+}
+
+// NewVersionHistories create a new version histories
+func NewVersionHistories(versionHistory *VersionHistory) *VersionHistories {
+	if versionHistory == nil {
+		panic("version history cannot be null")
+	}
+
+	return &VersionHistories{
+		CurrentVersionHistoryIndex: 0,
+		Histories:                  []*VersionHistory{versionHistory},
+	}
+}
+
+// NewVersionHistoriesFromThrift create a new version histories from thrift object
+func NewVersionHistoriesFromThrift(input *workflow.VersionHistories) *VersionHistories {
+	if input == nil || len(input.Histories) == 0 {
+		return nil
+	}
+
+	histories := &VersionHistories{
+		CurrentVersionHistoryIndex: int(input.GetCurrentVersionHistoryIndex()),
+	}
+	for _, history := range input.Histories {
+		histories.Histories = append(histories.Histories, NewVersionHistoryFromThrift(history))
+	}
+	return histories
+}
+
+// Duplicate duplicate VersionHistories
+func (h *VersionHistories) Duplicate() *VersionHistories {
+	histories := []*VersionHistory{}
+	for _, history := range h.Histories {
+		histories = append(histories, history.Duplicate())
+	}
+
+	return &VersionHistories{
+		CurrentVersionHistoryIndex: h.CurrentVersionHistoryIndex,
+		Histories:                  histories,
+	}
+}
+
+// ToThrift return thrift format of version histories
+func (h *VersionHistories) ToThrift() *workflow.VersionHistories {
+	histories := []*workflow.VersionHistory{}
+	for _, history := range h.Histories {
+		histories = append(histories, history.ToThrift())
+	}
+
+	return &workflow.VersionHistories{
+		CurrentVersionHistoryIndex: common.Int32Ptr(int32(h.CurrentVersionHistoryIndex)),
+		Histories:                  histories,
+	}
+}
+
+// GetVersionHistory get the version history according to index provided
+func (h *VersionHistories) GetVersionHistory(branchIndex int) (*VersionHistory, error) {
+	if branchIndex < 0 || branchIndex >= len(h.Histories) {
+		return nil, &workflow.BadRequestError{Message: "invalid branch index."}
+	}
+
+	return h.Histories[branchIndex], nil
+}
+
+// AddVersionHistory add a version history and return the new version history index
+func (h *VersionHistories) AddVersionHistory(v *VersionHistory) (int, error) {
+	if v == nil {
+		return 0, &workflow.BadRequestError{Message: "version histories is null."}
+	}
+
+	// assuming existing version histories inside are valid
+	incomingFirstItem, err := v.GetFirstItem()
+	if err != nil {
+		return 0, err
+	}
+	currentFirstItem, err := h.Histories[h.CurrentVersionHistoryIndex].GetFirstItem()
+	if err != nil {
+		return 0, err
+	}
+	if incomingFirstItem.Version != currentFirstItem.Version {
+		return 0, &workflow.BadRequestError{Message: "version history first item does not match."}
+	}
+
+	h.Histories = append(h.Histories, v)
+	return len(h.Histories) - 1, nil
+}
+
+// FindLCAVersionHistoryIndexAndItem finds the lowest common ancestor version history index
+// along with corresponding item
+func (h *VersionHistories) FindLCAVersionHistoryIndexAndItem(incomingHistory *VersionHistory) (int, *VersionHistoryItem, error) {
+	var versionHistoryIndex int
+	var versionHistoryLength int
+	var versionHistoryItem *VersionHistoryItem
+
+	for index, localHistory := range h.Histories {
+		item, err := localHistory.FindLCAItem(incomingHistory)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		// if not set
+		if versionHistoryItem == nil ||
+			// if seeing LCA item with higher event ID
+			item.EventID > versionHistoryItem.EventID ||
+			// if seeing LCA item with equal event ID but shorter history
+			(item.EventID == versionHistoryItem.EventID && len(localHistory.Items) < versionHistoryLength) {
+
+			versionHistoryIndex = index
+			versionHistoryLength = len(localHistory.Items)
+			versionHistoryItem = item
+		}
+	}
+	return versionHistoryIndex, versionHistoryItem, nil
+}
+
+// FindFirstVersionHistoryIndexByItem find the first version history index which
+// contains the given version history item
+func (h *VersionHistories) FindFirstVersionHistoryIndexByItem(item *VersionHistoryItem) (int, error) {
+	for index, localHistory := range h.Histories {
+		if localHistory.ContainsItem(item) {
+			return index, nil
+		}
+	}
+	return 0, &workflow.BadRequestError{Message: "version histories does not contains given item."}
+}
+
+// GetCurrentVersionHistoryIndex get the current branch index
+func (h *VersionHistories) GetCurrentVersionHistoryIndex() int {
+	return h.CurrentVersionHistoryIndex
+}
+
+// SetCurrentVersionHistoryIndex set the current branch index
+func (h *VersionHistories) SetCurrentVersionHistoryIndex(index int) error {
+	if index < 0 || index >= len(h.Histories) {
+		return &workflow.BadRequestError{Message: "invalid current branch index."}
+	}
+
+	h.CurrentVersionHistoryIndex = index
+	return nil
+}
+
+// GetCurrentVersionHistory get the current version history
+func (h *VersionHistories) GetCurrentVersionHistory() (*VersionHistory, error) {
+	return h.GetVersionHistory(h.GetCurrentVersionHistoryIndex())
+}
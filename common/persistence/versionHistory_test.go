@@ -0,0 +1,346 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type (
+	versionHistorySuite struct {
+		suite.Suite
+		// override suite.Suite.Assertions with require.Assertions; this means that s.NotNil(nil) will stop the test,
+		// not merely log an error
+		*require.Assertions
+	}
+
+	versionHistoriesSuite struct {
+		suite.Suite
+		// override suite.Suite.Assertions with require.Assertions; this means that s.NotNil(nil) will stop the test,
+		// not merely log an error
+		*require.Assertions
+	}
+)
+
+func TestVersionHistorySuite(t *testing.T) {
+	s := new(versionHistorySuite)
+	suite.Run(t, s)
+}
+
+func TestVersionHistoriesSuite(t *testing.T) {
+	s := new(versionHistoriesSuite)
+	suite.Run(t, s)
+}
+
+func (s *versionHistorySuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *versionHistoriesSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *versionHistorySuite) TestConversion() {
+	branchToken := []byte("some random branch token")
+	items := []*VersionHistoryItem{
+		{EventID: 3, Version: 0},
+		{EventID: 6, Version: 4},
+	}
+
+	history := NewVersionHistory(branchToken, items)
+	s.True(history.Equals(NewVersionHistoryFromThrift(history.ToThrift())))
+}
+
+func (s *versionHistorySuite) TestDuplicateUntilLCAItem_Success() {
+	history := NewVersionHistory([]byte("some random branch token"), []*VersionHistoryItem{
+		{EventID: 3, Version: 0},
+		{EventID: 6, Version: 4},
+	})
+
+	newHistory, err := history.DuplicateUntilLCAItem(NewVersionHistoryItem(2, 0))
+	s.NoError(err)
+	s.True(NewVersionHistory(nil, []*VersionHistoryItem{{EventID: 2, Version: 0}}).Equals(newHistory))
+
+	newHistory, err = history.DuplicateUntilLCAItem(NewVersionHistoryItem(5, 4))
+	s.NoError(err)
+	s.True(NewVersionHistory(nil, []*VersionHistoryItem{
+		{EventID: 3, Version: 0},
+		{EventID: 5, Version: 4},
+	}).Equals(newHistory))
+}
+
+func (s *versionHistorySuite) TestDuplicateUntilLCAItem_Failure() {
+	history := NewVersionHistory([]byte("some random branch token"), []*VersionHistoryItem{
+		{EventID: 3, Version: 0},
+		{EventID: 6, Version: 4},
+	})
+
+	_, err := history.DuplicateUntilLCAItem(NewVersionHistoryItem(4, 0))
+	s.Error(err)
+	_, err = history.DuplicateUntilLCAItem(NewVersionHistoryItem(7, 4))
+	s.Error(err)
+	_, err = history.DuplicateUntilLCAItem(NewVersionHistoryItem(4, 5))
+	s.Error(err)
+}
+
+func (s *versionHistorySuite) TestAddOrUpdateItem_VersionIncrease() {
+	history := NewVersionHistory(nil, []*VersionHistoryItem{
+		{EventID: 3, Version: 0},
+		{EventID: 6, Version: 4},
+	})
+
+	s.NoError(history.AddOrUpdateItem(NewVersionHistoryItem(8, 5)))
+	s.Equal([]*VersionHistoryItem{
+		{EventID: 3, Version: 0},
+		{EventID: 6, Version: 4},
+		{EventID: 8, Version: 5},
+	}, history.Items)
+}
+
+func (s *versionHistorySuite) TestAddOrUpdateItem_EventIDIncrease() {
+	history := NewVersionHistory(nil, []*VersionHistoryItem{
+		{EventID: 3, Version: 0},
+		{EventID: 6, Version: 4},
+	})
+
+	s.NoError(history.AddOrUpdateItem(NewVersionHistoryItem(8, 4)))
+	s.Equal([]*VersionHistoryItem{
+		{EventID: 3, Version: 0},
+		{EventID: 8, Version: 4},
+	}, history.Items)
+}
+
+func (s *versionHistorySuite) TestAddOrUpdateItem_Failed() {
+	history := NewVersionHistory(nil, []*VersionHistoryItem{
+		{EventID: 3, Version: 0},
+		{EventID: 6, Version: 4},
+	})
+
+	s.Error(history.AddOrUpdateItem(NewVersionHistoryItem(4, 4)))
+	s.Error(history.AddOrUpdateItem(NewVersionHistoryItem(6, 4)))
+	s.Error(history.AddOrUpdateItem(NewVersionHistoryItem(8, 3)))
+}
+
+func (s *versionHistorySuite) TestContainsItem() {
+	history := NewVersionHistory(nil, []*VersionHistoryItem{
+		{EventID: 3, Version: 0},
+		{EventID: 6, Version: 4},
+	})
+
+	for eventID := int64(1); eventID <= 3; eventID++ {
+		s.True(history.ContainsItem(NewVersionHistoryItem(eventID, 0)))
+		s.False(history.ContainsItem(NewVersionHistoryItem(eventID, 4)))
+	}
+	for eventID := int64(4); eventID <= 6; eventID++ {
+		s.False(history.ContainsItem(NewVersionHistoryItem(eventID, 0)))
+		s.True(history.ContainsItem(NewVersionHistoryItem(eventID, 4)))
+	}
+	s.False(history.ContainsItem(NewVersionHistoryItem(7, 4)))
+	s.False(history.ContainsItem(NewVersionHistoryItem(4, 2)))
+}
+
+func (s *versionHistorySuite) TestFindLCAItem_ReturnLocal() {
+	localHistory := NewVersionHistory(nil, []*VersionHistoryItem{
+		{EventID: 3, Version: 0},
+		{EventID: 5, Version: 4},
+		{EventID: 7, Version: 6},
+		{EventID: 9, Version: 10},
+	})
+	remoteHistory := NewVersionHistory(nil, []*VersionHistoryItem{
+		{EventID: 3, Version: 0},
+		{EventID: 7, Version: 4},
+		{EventID: 8, Version: 8},
+		{EventID: 11, Version: 12},
+	})
+
+	item, err := localHistory.FindLCAItem(remoteHistory)
+	s.NoError(err)
+	s.Equal(NewVersionHistoryItem(5, 4), item)
+}
+
+func (s *versionHistorySuite) TestFindLCAItem_ReturnRemote() {
+	localHistory := NewVersionHistory(nil, []*VersionHistoryItem{
+		{EventID: 3, Version: 0},
+		{EventID: 5, Version: 4},
+		{EventID: 7, Version: 6},
+		{EventID: 9, Version: 10},
+	})
+	remoteHistory := NewVersionHistory(nil, []*VersionHistoryItem{
+		{EventID: 3, Version: 0},
+		{EventID: 6, Version: 6},
+		{EventID: 11, Version: 12},
+	})
+
+	item, err := localHistory.FindLCAItem(remoteHistory)
+	s.NoError(err)
+	s.Equal(NewVersionHistoryItem(6, 6), item)
+}
+
+func (s *versionHistorySuite) TestFindLCAItem_Error_NoLCA() {
+	localHistory := NewVersionHistory(nil, []*VersionHistoryItem{
+		{EventID: 3, Version: 0},
+		{EventID: 7, Version: 6},
+	})
+	remoteHistory := NewVersionHistory(nil, []*VersionHistoryItem{
+		{EventID: 3, Version: 1},
+		{EventID: 7, Version: 2},
+	})
+
+	_, err := localHistory.FindLCAItem(remoteHistory)
+	s.Error(err)
+}
+
+func (s *versionHistorySuite) TestIsLCAAppendable() {
+	history := NewVersionHistory(nil, []*VersionHistoryItem{
+		{EventID: 3, Version: 0},
+		{EventID: 6, Version: 4},
+	})
+
+	s.True(history.IsLCAAppendable(NewVersionHistoryItem(6, 4)))
+	s.False(history.IsLCAAppendable(NewVersionHistoryItem(5, 4)))
+	s.False(history.IsLCAAppendable(NewVersionHistoryItem(3, 0)))
+}
+
+func (s *versionHistorySuite) TestGetEventVersion() {
+	history := NewVersionHistory(nil, []*VersionHistoryItem{
+		{EventID: 3, Version: 0},
+		{EventID: 6, Version: 4},
+	})
+
+	for eventID := int64(1); eventID <= 3; eventID++ {
+		version, err := history.GetEventVersion(eventID)
+		s.NoError(err)
+		s.Equal(int64(0), version)
+	}
+	for eventID := int64(4); eventID <= 6; eventID++ {
+		version, err := history.GetEventVersion(eventID)
+		s.NoError(err)
+		s.Equal(int64(4), version)
+	}
+
+	_, err := history.GetEventVersion(0)
+	s.Error(err)
+	_, err = history.GetEventVersion(7)
+	s.Error(err)
+}
+
+func (s *versionHistoriesSuite) TestConversion() {
+	histories := NewVersionHistories(NewVersionHistory([]byte("some random branch token"), []*VersionHistoryItem{
+		{EventID: 3, Version: 0},
+		{EventID: 6, Version: 4},
+	}))
+
+	s.Equal(histories, NewVersionHistoriesFromThrift(histories.ToThrift()))
+	s.Nil(NewVersionHistoriesFromThrift(nil))
+}
+
+func (s *versionHistoriesSuite) TestAddGetVersionHistory() {
+	history1 := NewVersionHistory([]byte("branch token 1"), []*VersionHistoryItem{
+		{EventID: 3, Version: 0},
+		{EventID: 6, Version: 4},
+	})
+	history2 := NewVersionHistory([]byte("branch token 2"), []*VersionHistoryItem{
+		{EventID: 3, Version: 0},
+		{EventID: 5, Version: 4},
+		{EventID: 7, Version: 6},
+	})
+
+	histories := NewVersionHistories(history1)
+	s.Equal(0, histories.GetCurrentVersionHistoryIndex())
+
+	index, err := histories.AddVersionHistory(history2)
+	s.NoError(err)
+	s.Equal(1, index)
+	s.Equal(0, histories.GetCurrentVersionHistoryIndex())
+
+	s.NoError(histories.SetCurrentVersionHistoryIndex(index))
+	current, err := histories.GetCurrentVersionHistory()
+	s.NoError(err)
+	s.Equal(history2, current)
+
+	resultHistory, err := histories.GetVersionHistory(0)
+	s.NoError(err)
+	s.Equal(history1, resultHistory)
+
+	_, err = histories.GetVersionHistory(2)
+	s.Error(err)
+	s.Error(histories.SetCurrentVersionHistoryIndex(2))
+}
+
+func (s *versionHistoriesSuite) TestFindLCAVersionHistoryIndexAndItem() {
+	history1 := NewVersionHistory([]byte("branch token 1"), []*VersionHistoryItem{
+		{EventID: 3, Version: 0},
+		{EventID: 5, Version: 4},
+		{EventID: 7, Version: 6},
+		{EventID: 9, Version: 10},
+	})
+	history2 := NewVersionHistory([]byte("branch token 2"), []*VersionHistoryItem{
+		{EventID: 3, Version: 0},
+		{EventID: 10, Version: 4},
+		{EventID: 13, Version: 8},
+		{EventID: 15, Version: 12},
+	})
+
+	histories := NewVersionHistories(history1)
+	_, err := histories.AddVersionHistory(history2)
+	s.NoError(err)
+
+	incomingHistory := NewVersionHistory(nil, []*VersionHistoryItem{
+		{EventID: 3, Version: 0},
+		{EventID: 10, Version: 4},
+		{EventID: 13, Version: 8},
+		{EventID: 14, Version: 14},
+	})
+	index, item, err := histories.FindLCAVersionHistoryIndexAndItem(incomingHistory)
+	s.NoError(err)
+	s.Equal(1, index)
+	s.Equal(NewVersionHistoryItem(13, 8), item)
+}
+
+func (s *versionHistoriesSuite) TestFindFirstVersionHistoryIndexByItem() {
+	history1 := NewVersionHistory([]byte("branch token 1"), []*VersionHistoryItem{
+		{EventID: 3, Version: 0},
+		{EventID: 5, Version: 4},
+		{EventID: 7, Version: 6},
+	})
+	history2 := NewVersionHistory([]byte("branch token 2"), []*VersionHistoryItem{
+		{EventID: 3, Version: 0},
+		{EventID: 10, Version: 4},
+	})
+
+	histories := NewVersionHistories(history1)
+	_, err := histories.AddVersionHistory(history2)
+	s.NoError(err)
+
+	index, err := histories.FindFirstVersionHistoryIndexByItem(NewVersionHistoryItem(8, 4))
+	s.NoError(err)
+	s.Equal(1, index)
+
+	index, err = histories.FindFirstVersionHistoryIndexByItem(NewVersionHistoryItem(4, 4))
+	s.NoError(err)
+	s.Equal(0, index)
+
+	_, err = histories.FindFirstVersionHistoryIndexByItem(NewVersionHistoryItem(11, 4))
+	s.Error(err)
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/backoff"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/service/config"
+)
+
+// visibilityClosedRecordsBufferSize is the max number of closed records waiting to be written,
+// closed records are written synchronously while the buffer is full
+const visibilityClosedRecordsBufferSize = 4096
+
+type visibilityBatchingClient struct {
+	VisibilityManager
+	config       *config.VisibilityConfig
+	metricClient metrics.Client
+	logger       bark.Logger
+	retryPolicy  backoff.RetryPolicy
+	buffer       chan *RecordWorkflowExecutionClosedRequest
+	shutdownCh   chan struct{}
+	shutdownWG   sync.WaitGroup
+	closeOnce    sync.Once
+}
+
+var _ VisibilityManager = (*visibilityBatchingClient)(nil)
+
+// NewVisibilityBatchingClient creates a client which, when EnableAsyncClosedRecords is on, buffers closed records
+// and writes them in batches in the background, as closed records are not latency critical for the caller
+func NewVisibilityBatchingClient(persistence VisibilityManager, config *config.VisibilityConfig, metricClient metrics.Client, logger bark.Logger) VisibilityManager {
+	c := &visibilityBatchingClient{
+		VisibilityManager: persistence,
+		config:            config,
+		metricClient:      metricClient,
+		logger:            logger,
+		retryPolicy:       common.CreatePersistanceRetryPolicy(),
+		buffer:            make(chan *RecordWorkflowExecutionClosedRequest, visibilityClosedRecordsBufferSize),
+		shutdownCh:        make(chan struct{}),
+	}
+	c.shutdownWG.Add(1)
+	go c.flushLoop()
+	return c
+}
+
+func (c *visibilityBatchingClient) RecordWorkflowExecutionClosed(ctx context.Context, request *RecordWorkflowExecutionClosedRequest) error {
+	if !c.config.EnableAsyncClosedRecords() {
+		return c.VisibilityManager.RecordWorkflowExecutionClosed(ctx, request)
+	}
+
+	select {
+	case c.buffer <- request:
+		return nil
+	default:
+		// the buffer is full, push back on the caller by writing the record synchronously
+		return c.VisibilityManager.RecordWorkflowExecutionClosed(ctx, request)
+	}
+}
+
+// Close writes the buffered closed records before closing the underlying visibility manager
+func (c *visibilityBatchingClient) Close() {
+	c.closeOnce.Do(func() {
+		close(c.shutdownCh)
+		c.shutdownWG.Wait()
+	})
+	c.VisibilityManager.Close()
+}
+
+func (c *visibilityBatchingClient) flushLoop() {
+	defer c.shutdownWG.Done()
+
+	batch := make([]*RecordWorkflowExecutionClosedRequest, 0, c.config.ClosedRecordsBatchSize())
+	timer := time.NewTimer(c.config.ClosedRecordsBatchInterval())
+	defer timer.Stop()
+
+	for {
+		select {
+		case request := <-c.buffer:
+			batch = append(batch, request)
+			if len(batch) < c.config.ClosedRecordsBatchSize() {
+				continue
+			}
+		case <-timer.C:
+			timer.Reset(c.config.ClosedRecordsBatchInterval())
+		case <-c.shutdownCh:
+			for {
+				select {
+				case request := <-c.buffer:
+					batch = append(batch, request)
+				default:
+					c.flush(batch)
+					return
+				}
+			}
+		}
+		c.flush(batch)
+		batch = batch[:0]
+	}
+}
+
+// flush writes the batch of closed records concurrently, retrying transient errors
+func (c *visibilityBatchingClient) flush(batch []*RecordWorkflowExecutionClosedRequest) {
+	var wg sync.WaitGroup
+	wg.Add(len(batch))
+	for _, request := range batch {
+		go func(request *RecordWorkflowExecutionClosedRequest) {
+			defer wg.Done()
+			op := func() error {
+				return c.VisibilityManager.RecordWorkflowExecutionClosed(context.Background(), request)
+			}
+			if err := backoff.Retry(op, c.retryPolicy, common.IsPersistenceTransientError); err != nil {
+				c.metricClient.IncCounter(metrics.PersistenceRecordWorkflowExecutionClosedScope, metrics.PersistenceAsyncWriteFailures)
+				c.logger.WithFields(bark.Fields{
+					"Domain":     request.Domain,
+					"WorkflowID": request.Execution.GetWorkflowId(),
+					"RunID":      request.Execution.GetRunId(),
+					"Error":      err,
+				}).Error("Failed to write buffered closed visibility record")
+			}
+		}(request)
+	}
+	wg.Wait()
+}
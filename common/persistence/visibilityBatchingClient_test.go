@@ -0,0 +1,141 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	"github.com/uber-go/tally"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/service/config"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	visibilityBatchingClientSuite struct {
+		suite.Suite
+		persistence *closedRecordsVisibilityManager
+		config      *config.VisibilityConfig
+	}
+
+	// closedRecordsVisibilityManager remembers the closed records written to it
+	closedRecordsVisibilityManager struct {
+		VisibilityManager
+		sync.Mutex
+		closed []*RecordWorkflowExecutionClosedRequest
+	}
+)
+
+func TestVisibilityBatchingClientSuite(t *testing.T) {
+	s := new(visibilityBatchingClientSuite)
+	suite.Run(t, s)
+}
+
+func (s *visibilityBatchingClientSuite) SetupTest() {
+	s.persistence = &closedRecordsVisibilityManager{}
+	s.config = &config.VisibilityConfig{
+		EnableAsyncClosedRecords:   dynamicconfig.GetBoolPropertyFn(true),
+		ClosedRecordsBatchSize:     dynamicconfig.GetIntPropertyFn(2),
+		ClosedRecordsBatchInterval: dynamicconfig.GetDurationPropertyFn(time.Hour),
+	}
+}
+
+func (s *visibilityBatchingClientSuite) newClient() VisibilityManager {
+	return NewVisibilityBatchingClient(s.persistence, s.config, metrics.NewClient(tally.NoopScope, metrics.History), bark.NewNopLogger())
+}
+
+func (s *visibilityBatchingClientSuite) TestSynchronousWhenDisabled() {
+	s.config.EnableAsyncClosedRecords = dynamicconfig.GetBoolPropertyFn(false)
+	client := s.newClient()
+	defer client.Close()
+
+	s.NoError(client.RecordWorkflowExecutionClosed(context.Background(), newClosedRecord("wid1")))
+	s.Equal(1, s.persistence.numClosed())
+}
+
+func (s *visibilityBatchingClientSuite) TestFlushOnBatchSize() {
+	client := s.newClient()
+	defer client.Close()
+
+	s.NoError(client.RecordWorkflowExecutionClosed(context.Background(), newClosedRecord("wid1")))
+	s.NoError(client.RecordWorkflowExecutionClosed(context.Background(), newClosedRecord("wid2")))
+	s.waitForClosed(2)
+}
+
+func (s *visibilityBatchingClientSuite) TestFlushOnInterval() {
+	s.config.ClosedRecordsBatchSize = dynamicconfig.GetIntPropertyFn(100)
+	s.config.ClosedRecordsBatchInterval = dynamicconfig.GetDurationPropertyFn(10 * time.Millisecond)
+	client := s.newClient()
+	defer client.Close()
+
+	s.NoError(client.RecordWorkflowExecutionClosed(context.Background(), newClosedRecord("wid1")))
+	s.waitForClosed(1)
+}
+
+func (s *visibilityBatchingClientSuite) TestFlushOnClose() {
+	s.config.ClosedRecordsBatchSize = dynamicconfig.GetIntPropertyFn(100)
+	client := s.newClient()
+
+	s.NoError(client.RecordWorkflowExecutionClosed(context.Background(), newClosedRecord("wid1")))
+	s.NoError(client.RecordWorkflowExecutionClosed(context.Background(), newClosedRecord("wid2")))
+	s.Equal(0, s.persistence.numClosed())
+	client.Close()
+	s.Equal(2, s.persistence.numClosed())
+}
+
+func (s *visibilityBatchingClientSuite) waitForClosed(expected int) {
+	for i := 0; i < 100 && s.persistence.numClosed() < expected; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	s.Equal(expected, s.persistence.numClosed())
+}
+
+func newClosedRecord(workflowID string) *RecordWorkflowExecutionClosedRequest {
+	return &RecordWorkflowExecutionClosedRequest{
+		Domain: "test-domain",
+		Execution: workflow.WorkflowExecution{
+			WorkflowId: common.StringPtr(workflowID),
+			RunId:      common.StringPtr("run-id"),
+		},
+	}
+}
+
+func (m *closedRecordsVisibilityManager) RecordWorkflowExecutionClosed(ctx context.Context, request *RecordWorkflowExecutionClosedRequest) error {
+	m.Lock()
+	defer m.Unlock()
+	m.closed = append(m.closed, request)
+	return nil
+}
+
+func (m *closedRecordsVisibilityManager) numClosed() int {
+	m.Lock()
+	defer m.Unlock()
+	return len(m.closed)
+}
+
+func (m *closedRecordsVisibilityManager) Close() {}
@@ -20,7 +20,49 @@
 
 package persistence
 
-import s "github.com/uber/cadence/.gen/go/shared"
+import (
+	"context"
+	"fmt"
+	"time"
+
+	s "github.com/uber/cadence/.gen/go/shared"
+)
+
+// VisibilitySortField is the field list results are ordered by
+type VisibilitySortField int
+
+// VisibilitySortOrder is the direction list results are ordered in
+type VisibilitySortOrder int
+
+// Fields list results can be ordered by
+const (
+	// VisibilitySortFieldDefault lets the store pick its natural ordering field
+	VisibilitySortFieldDefault VisibilitySortField = iota
+	VisibilitySortFieldStartTime
+	VisibilitySortFieldCloseTime
+	VisibilitySortFieldExecutionTime
+)
+
+// Directions list results can be ordered in
+const (
+	VisibilitySortOrderDesc VisibilitySortOrder = iota
+	VisibilitySortOrderAsc
+)
+
+var (
+	// ErrVisibilityStatisticsNotSupported is returned by visibility stores which cannot aggregate workflow executions
+	ErrVisibilityStatisticsNotSupported = &s.BadRequestError{
+		Message: "workflow execution statistics are only supported with ElasticSearch visibility",
+	}
+	// ErrVisibilityCountNotSupported is returned by visibility stores which cannot count workflow executions cheaply
+	ErrVisibilityCountNotSupported = &s.BadRequestError{
+		Message: "counting workflow executions is only supported with ElasticSearch visibility",
+	}
+	// ErrVisibilityLabelFilterNotSupported is returned by visibility stores which do not index workflow labels
+	ErrVisibilityLabelFilterNotSupported = &s.BadRequestError{
+		Message: "filtering workflow executions by label is only supported with ElasticSearch visibility",
+	}
+)
 
 // Interfaces for the Visibility Store.
 // This is a secondary store that is eventually consistent with the main
@@ -39,6 +81,10 @@ type (
 		StartTimestamp     int64
 		ExecutionTimestamp int64
 		WorkflowTimeout    int64
+		RetentionSeconds   int64
+		TaskList           string
+		ParentWorkflowID   string // empty if the execution has no parent
+		ParentRunID        string
 	}
 
 	// RecordWorkflowExecutionClosedRequest is used to add a record of a newly
@@ -54,6 +100,11 @@ type (
 		Status             s.WorkflowExecutionCloseStatus
 		HistoryLength      int64
 		RetentionSeconds   int64
+		TaskList           string
+		ParentWorkflowID   string // empty if the execution has no parent
+		ParentRunID        string
+		// TerminationInfo records who terminated the workflow and why, only set when Status is terminated
+		TerminationInfo *s.WorkflowExecutionTerminationInfo
 	}
 
 	// ListWorkflowExecutionsRequest is used to list executions in a domain
@@ -67,6 +118,16 @@ type (
 		// Token to continue reading next page of workflow executions.
 		// Pass in empty slice for first page.
 		NextPageToken []byte
+		// SortField is the field executions are ordered by, the default is
+		// chosen by the store. Must not change between pages.
+		SortField VisibilitySortField
+		// SortOrder is the direction executions are ordered in, newest first by default.
+		// Must not change between pages.
+		SortOrder VisibilitySortOrder
+		// LabelKey and LabelValue only list the executions carrying the label, if LabelKey is set.
+		// Only supported by ElasticSearch, other stores return BadRequestError.
+		LabelKey   string
+		LabelValue string
 	}
 
 	// ListWorkflowExecutionsResponse is the response to ListWorkflowExecutionsRequest
@@ -116,20 +177,103 @@ type (
 		RunID    string
 	}
 
+	// GetWorkflowExecutionStatisticsRequest is used to count the executions of a domain closed within a time range
+	GetWorkflowExecutionStatisticsRequest struct {
+		DomainUUID        string
+		Domain            string // domain name is not persisted, but used as config filter key
+		EarliestCloseTime int64
+		LatestCloseTime   int64
+		// CloseTimeInterval is the width of the close time histogram buckets, no histogram is built if zero
+		CloseTimeInterval time.Duration
+	}
+
+	// GetWorkflowExecutionStatisticsResponse is the response to GetWorkflowExecutionStatisticsRequest
+	GetWorkflowExecutionStatisticsResponse struct {
+		TotalCount          int64
+		CountsByType        []*s.WorkflowExecutionCountBucket
+		CountsByCloseStatus []*s.WorkflowExecutionCountBucket
+		CountsByCloseTime   []*s.WorkflowExecutionTimeBucket
+	}
+
+	// CountWorkflowExecutionsRequest is used to count the executions of a domain
+	CountWorkflowExecutionsRequest struct {
+		DomainUUID string
+		Domain     string // domain name is not persisted, but used as config filter key
+	}
+
+	// CountWorkflowExecutionsResponse is the response to CountWorkflowExecutionsRequest
+	CountWorkflowExecutionsResponse struct {
+		Count int64
+	}
+
 	// VisibilityManager is used to manage the visibility store
 	VisibilityManager interface {
 		Closeable
 		GetName() string
-		RecordWorkflowExecutionStarted(request *RecordWorkflowExecutionStartedRequest) error
-		RecordWorkflowExecutionClosed(request *RecordWorkflowExecutionClosedRequest) error
-		ListOpenWorkflowExecutions(request *ListWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error)
-		ListClosedWorkflowExecutions(request *ListWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error)
-		ListOpenWorkflowExecutionsByType(request *ListWorkflowExecutionsByTypeRequest) (*ListWorkflowExecutionsResponse, error)
-		ListClosedWorkflowExecutionsByType(request *ListWorkflowExecutionsByTypeRequest) (*ListWorkflowExecutionsResponse, error)
-		ListOpenWorkflowExecutionsByWorkflowID(request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error)
-		ListClosedWorkflowExecutionsByWorkflowID(request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error)
-		ListClosedWorkflowExecutionsByStatus(request *ListClosedWorkflowExecutionsByStatusRequest) (*ListWorkflowExecutionsResponse, error)
-		GetClosedWorkflowExecution(request *GetClosedWorkflowExecutionRequest) (*GetClosedWorkflowExecutionResponse, error)
-		DeleteWorkflowExecution(request *VisibilityDeleteWorkflowExecutionRequest) error
+		RecordWorkflowExecutionStarted(ctx context.Context, request *RecordWorkflowExecutionStartedRequest) error
+		RecordWorkflowExecutionClosed(ctx context.Context, request *RecordWorkflowExecutionClosedRequest) error
+		ListOpenWorkflowExecutions(ctx context.Context, request *ListWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error)
+		ListClosedWorkflowExecutions(ctx context.Context, request *ListWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error)
+		ListOpenWorkflowExecutionsByType(ctx context.Context, request *ListWorkflowExecutionsByTypeRequest) (*ListWorkflowExecutionsResponse, error)
+		ListClosedWorkflowExecutionsByType(ctx context.Context, request *ListWorkflowExecutionsByTypeRequest) (*ListWorkflowExecutionsResponse, error)
+		ListOpenWorkflowExecutionsByWorkflowID(ctx context.Context, request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error)
+		ListClosedWorkflowExecutionsByWorkflowID(ctx context.Context, request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error)
+		ListClosedWorkflowExecutionsByStatus(ctx context.Context, request *ListClosedWorkflowExecutionsByStatusRequest) (*ListWorkflowExecutionsResponse, error)
+		// GetClosedWorkflowExecution returns EntityNotExistsError if there is no closed record for the execution
+		GetClosedWorkflowExecution(ctx context.Context, request *GetClosedWorkflowExecutionRequest) (*GetClosedWorkflowExecutionResponse, error)
+		DeleteWorkflowExecution(ctx context.Context, request *VisibilityDeleteWorkflowExecutionRequest) error
+		// GetWorkflowExecutionStatistics is only supported by ElasticSearch, other stores return BadRequestError
+		GetWorkflowExecutionStatistics(ctx context.Context, request *GetWorkflowExecutionStatisticsRequest) (*GetWorkflowExecutionStatisticsResponse, error)
+		// CountOpenWorkflowExecutions is only supported by ElasticSearch, other stores return BadRequestError
+		CountOpenWorkflowExecutions(ctx context.Context, request *CountWorkflowExecutionsRequest) (*CountWorkflowExecutionsResponse, error)
 	}
 )
+
+// IsDefaultSort returns true if the request asks for the natural ordering of the store
+func (r *ListWorkflowExecutionsRequest) IsDefaultSort() bool {
+	return r.SortField == VisibilitySortFieldDefault && r.SortOrder == VisibilitySortOrderDesc
+}
+
+// GetExecutionTimestamp returns the execution time of the started record, records written
+// without an execution time execute at their start time
+func (r *RecordWorkflowExecutionStartedRequest) GetExecutionTimestamp() int64 {
+	return getExecutionTimestamp(r.StartTimestamp, r.ExecutionTimestamp)
+}
+
+// GetExecutionTimestamp returns the execution time of the closed record, records written
+// without an execution time execute at their start time
+func (r *RecordWorkflowExecutionClosedRequest) GetExecutionTimestamp() int64 {
+	return getExecutionTimestamp(r.StartTimestamp, r.ExecutionTimestamp)
+}
+
+func getExecutionTimestamp(startTimestamp int64, executionTimestamp int64) int64 {
+	if executionTimestamp == 0 {
+		return startTimestamp
+	}
+	return executionTimestamp
+}
+
+// HasLabelFilter returns true if the request only lists executions carrying a label
+func (r *ListWorkflowExecutionsRequest) HasLabelFilter() bool {
+	return r.LabelKey != ""
+}
+
+// ValidateVisibilitySort returns BadRequestError if the ordering requested by a list query
+// of open or closed executions is unknown or doesn't apply to those executions
+func ValidateVisibilitySort(request *ListWorkflowExecutionsRequest, isOpen bool) error {
+	switch request.SortField {
+	case VisibilitySortFieldDefault, VisibilitySortFieldStartTime, VisibilitySortFieldExecutionTime:
+	case VisibilitySortFieldCloseTime:
+		if isOpen {
+			return &s.BadRequestError{Message: "open workflow executions cannot be sorted by close time"}
+		}
+	default:
+		return &s.BadRequestError{Message: fmt.Sprintf("unknown visibility sort field %v", request.SortField)}
+	}
+	switch request.SortOrder {
+	case VisibilitySortOrderDesc, VisibilitySortOrderAsc:
+	default:
+		return &s.BadRequestError{Message: fmt.Sprintf("unknown visibility sort order %v", request.SortOrder)}
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+
+	workflow "github.com/uber/cadence/.gen/go/shared"
+)
+
+// Names of the visibility stores which issue page tokens
+const (
+	VisibilityStoreCassandra     = "cassandra"
+	VisibilityStoreSQL           = "sql"
+	VisibilityStoreElasticSearch = "elasticsearch"
+	VisibilityStoreInMemory      = "inmemory"
+)
+
+const (
+	// visibilityPageTokenVersion is bumped whenever the envelope or any of the
+	// store specific payloads change in an incompatible way
+	visibilityPageTokenVersion = 1
+	visibilityPageTokenSigSize = 16
+)
+
+// visibilityPageTokenKey keys the page token signature, the signature guards
+// against truncated or hand edited tokens and is not meant to be a secret
+var visibilityPageTokenKey = []byte("cadence-visibility-page-token")
+
+type (
+	// visibilityPageTokenEnvelope wraps the store specific paging state
+	// handed out to clients as NextPageToken by all visibility stores
+	visibilityPageTokenEnvelope struct {
+		Version   int    `json:"v"`
+		Store     string `json:"s"`
+		Payload   []byte `json:"p"`
+		Signature []byte `json:"sig"`
+	}
+)
+
+// SerializeVisibilityPageToken wraps the paging state of the given visibility store
+// into a versioned and signed page token, an empty state yields an empty token
+func SerializeVisibilityPageToken(store string, payload []byte) []byte {
+	if len(payload) == 0 {
+		return nil
+	}
+	envelope := &visibilityPageTokenEnvelope{
+		Version: visibilityPageTokenVersion,
+		Store:   store,
+		Payload: payload,
+	}
+	envelope.Signature = envelope.sign()
+	data, _ := json.Marshal(envelope) // cannot fail, the envelope only has plain fields
+	return data
+}
+
+// DeserializeVisibilityPageToken validates a page token issued by SerializeVisibilityPageToken and
+// returns the paging state it carries, BadRequestError is returned if the token is malformed,
+// tampered with, was issued by another visibility store or by an incompatible version
+func DeserializeVisibilityPageToken(store string, token []byte) ([]byte, error) {
+	if len(token) == 0 {
+		return nil, nil
+	}
+	var envelope visibilityPageTokenEnvelope
+	if err := json.Unmarshal(token, &envelope); err != nil {
+		return nil, &workflow.BadRequestError{
+			Message: fmt.Sprintf("invalid next page token: unable to deserialize page token. err: %v", err),
+		}
+	}
+	if envelope.Version != visibilityPageTokenVersion {
+		return nil, &workflow.BadRequestError{
+			Message: fmt.Sprintf("invalid next page token: token version %v is not supported, "+
+				"restart the list from the first page", envelope.Version),
+		}
+	}
+	if envelope.Store != store {
+		return nil, &workflow.BadRequestError{
+			Message: fmt.Sprintf("invalid next page token: token was issued by the %v visibility store "+
+				"but the request is served by the %v visibility store, restart the list from the first page", envelope.Store, store),
+		}
+	}
+	if !hmac.Equal(envelope.Signature, envelope.sign()) {
+		return nil, &workflow.BadRequestError{
+			Message: "invalid next page token: token signature mismatch, the token is corrupted",
+		}
+	}
+	return envelope.Payload, nil
+}
+
+func (e *visibilityPageTokenEnvelope) sign() []byte {
+	mac := hmac.New(sha256.New, visibilityPageTokenKey)
+	mac.Write([]byte{byte(e.Version)})
+	mac.Write([]byte(e.Store))
+	mac.Write(e.Payload)
+	return mac.Sum(nil)[:visibilityPageTokenSigSize]
+}
+
+// visibilityPageTokenStore returns the name of the visibility store which issued the page token,
+// or an empty string if the token is empty or malformed
+func visibilityPageTokenStore(token []byte) string {
+	if len(token) == 0 {
+		return ""
+	}
+	var envelope visibilityPageTokenEnvelope
+	if err := json.Unmarshal(token, &envelope); err != nil {
+		return ""
+	}
+	return envelope.Store
+}
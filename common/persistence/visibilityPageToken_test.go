@@ -0,0 +1,108 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+)
+
+type (
+	visibilityPageTokenSuite struct {
+		suite.Suite
+		*require.Assertions
+	}
+)
+
+func TestVisibilityPageTokenSuite(t *testing.T) {
+	s := new(visibilityPageTokenSuite)
+	suite.Run(t, s)
+}
+
+func (s *visibilityPageTokenSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *visibilityPageTokenSuite) TestRoundTrip() {
+	payload := []byte("paging state")
+	token := SerializeVisibilityPageToken(VisibilityStoreCassandra, payload)
+	s.NotEmpty(token)
+
+	result, err := DeserializeVisibilityPageToken(VisibilityStoreCassandra, token)
+	s.NoError(err)
+	s.Equal(payload, result)
+}
+
+func (s *visibilityPageTokenSuite) TestEmpty() {
+	s.Nil(SerializeVisibilityPageToken(VisibilityStoreSQL, nil))
+
+	result, err := DeserializeVisibilityPageToken(VisibilityStoreSQL, nil)
+	s.NoError(err)
+	s.Nil(result)
+}
+
+func (s *visibilityPageTokenSuite) TestMalformed() {
+	_, err := DeserializeVisibilityPageToken(VisibilityStoreSQL, []byte("bad input"))
+	s.assertBadRequest(err, "unable to deserialize page token")
+}
+
+func (s *visibilityPageTokenSuite) TestWrongStore() {
+	token := SerializeVisibilityPageToken(VisibilityStoreElasticSearch, []byte("paging state"))
+	_, err := DeserializeVisibilityPageToken(VisibilityStoreCassandra, token)
+	s.assertBadRequest(err, "issued by the elasticsearch visibility store")
+}
+
+func (s *visibilityPageTokenSuite) TestOldVersion() {
+	token := s.reencode(SerializeVisibilityPageToken(VisibilityStoreSQL, []byte("paging state")), func(e *visibilityPageTokenEnvelope) {
+		e.Version = visibilityPageTokenVersion - 1
+	})
+	_, err := DeserializeVisibilityPageToken(VisibilityStoreSQL, token)
+	s.assertBadRequest(err, "is not supported")
+}
+
+func (s *visibilityPageTokenSuite) TestTampered() {
+	token := s.reencode(SerializeVisibilityPageToken(VisibilityStoreSQL, []byte("paging state")), func(e *visibilityPageTokenEnvelope) {
+		e.Payload = []byte("other paging state")
+	})
+	_, err := DeserializeVisibilityPageToken(VisibilityStoreSQL, token)
+	s.assertBadRequest(err, "signature mismatch")
+}
+
+func (s *visibilityPageTokenSuite) reencode(token []byte, update func(*visibilityPageTokenEnvelope)) []byte {
+	var envelope visibilityPageTokenEnvelope
+	s.NoError(json.Unmarshal(token, &envelope))
+	update(&envelope)
+	data, err := json.Marshal(&envelope)
+	s.NoError(err)
+	return data
+}
+
+func (s *visibilityPageTokenSuite) assertBadRequest(err error, msg string) {
+	s.Error(err)
+	_, ok := err.(*workflow.BadRequestError)
+	s.True(ok)
+	s.True(strings.Contains(err.Error(), msg), err.Error())
+}
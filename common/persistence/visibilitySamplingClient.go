@@ -21,6 +21,7 @@
 package persistence
 
 import (
+	"context"
 	"sync"
 
 	"github.com/uber-common/bark"
@@ -95,12 +96,12 @@ func (m *domainToBucketMap) getRateLimiter(domain string, numOfPriority, qps int
 	return rateLimiter
 }
 
-func (p *visibilitySamplingClient) RecordWorkflowExecutionStarted(request *RecordWorkflowExecutionStartedRequest) error {
+func (p *visibilitySamplingClient) RecordWorkflowExecutionStarted(ctx context.Context, request *RecordWorkflowExecutionStartedRequest) error {
 	domain := request.Domain
 
 	rateLimiter := p.rateLimitersForOpen.getRateLimiter(domain, numOfPriorityForOpen, p.config.VisibilityOpenMaxQPS(domain))
 	if ok, _ := rateLimiter.GetToken(0, 1); ok {
-		return p.persistence.RecordWorkflowExecutionStarted(request)
+		return p.persistence.RecordWorkflowExecutionStarted(ctx, request)
 	}
 
 	logging.LogOpenWorkflowSampled(p.logger, domain, request.Execution.GetWorkflowId(), request.Execution.GetRunId(), request.WorkflowTypeName)
@@ -108,13 +109,13 @@ func (p *visibilitySamplingClient) RecordWorkflowExecutionStarted(request *Recor
 	return nil
 }
 
-func (p *visibilitySamplingClient) RecordWorkflowExecutionClosed(request *RecordWorkflowExecutionClosedRequest) error {
+func (p *visibilitySamplingClient) RecordWorkflowExecutionClosed(ctx context.Context, request *RecordWorkflowExecutionClosedRequest) error {
 	domain := request.Domain
 	priority := getRequestPriority(request)
 
 	rateLimiter := p.rateLimitersForClosed.getRateLimiter(domain, numOfPriorityForClosed, p.config.VisibilityClosedMaxQPS(domain))
 	if ok, _ := rateLimiter.GetToken(priority, 1); ok {
-		return p.persistence.RecordWorkflowExecutionClosed(request)
+		return p.persistence.RecordWorkflowExecutionClosed(ctx, request)
 	}
 
 	logging.LogClosedWorkflowSampled(p.logger, domain, request.Execution.GetWorkflowId(), request.Execution.GetRunId(), request.WorkflowTypeName)
@@ -122,7 +123,7 @@ func (p *visibilitySamplingClient) RecordWorkflowExecutionClosed(request *Record
 	return nil
 }
 
-func (p *visibilitySamplingClient) ListOpenWorkflowExecutions(request *ListWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error) {
+func (p *visibilitySamplingClient) ListOpenWorkflowExecutions(ctx context.Context, request *ListWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error) {
 	domain := request.Domain
 
 	rateLimiter := p.rateLimitersForList.getRateLimiter(domain, numOfPriorityForList, p.config.VisibilityListMaxQPS(domain))
@@ -130,10 +131,10 @@ func (p *visibilitySamplingClient) ListOpenWorkflowExecutions(request *ListWorkf
 		return nil, ErrPersistenceLimitExceededForList
 	}
 
-	return p.persistence.ListOpenWorkflowExecutions(request)
+	return p.persistence.ListOpenWorkflowExecutions(ctx, request)
 }
 
-func (p *visibilitySamplingClient) ListClosedWorkflowExecutions(request *ListWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error) {
+func (p *visibilitySamplingClient) ListClosedWorkflowExecutions(ctx context.Context, request *ListWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error) {
 	domain := request.Domain
 
 	rateLimiter := p.rateLimitersForList.getRateLimiter(domain, numOfPriorityForList, p.config.VisibilityListMaxQPS(domain))
@@ -141,10 +142,10 @@ func (p *visibilitySamplingClient) ListClosedWorkflowExecutions(request *ListWor
 		return nil, ErrPersistenceLimitExceededForList
 	}
 
-	return p.persistence.ListClosedWorkflowExecutions(request)
+	return p.persistence.ListClosedWorkflowExecutions(ctx, request)
 }
 
-func (p *visibilitySamplingClient) ListOpenWorkflowExecutionsByType(request *ListWorkflowExecutionsByTypeRequest) (*ListWorkflowExecutionsResponse, error) {
+func (p *visibilitySamplingClient) ListOpenWorkflowExecutionsByType(ctx context.Context, request *ListWorkflowExecutionsByTypeRequest) (*ListWorkflowExecutionsResponse, error) {
 	domain := request.Domain
 
 	rateLimiter := p.rateLimitersForList.getRateLimiter(domain, numOfPriorityForList, p.config.VisibilityListMaxQPS(domain))
@@ -152,10 +153,10 @@ func (p *visibilitySamplingClient) ListOpenWorkflowExecutionsByType(request *Lis
 		return nil, ErrPersistenceLimitExceededForList
 	}
 
-	return p.persistence.ListOpenWorkflowExecutionsByType(request)
+	return p.persistence.ListOpenWorkflowExecutionsByType(ctx, request)
 }
 
-func (p *visibilitySamplingClient) ListClosedWorkflowExecutionsByType(request *ListWorkflowExecutionsByTypeRequest) (*ListWorkflowExecutionsResponse, error) {
+func (p *visibilitySamplingClient) ListClosedWorkflowExecutionsByType(ctx context.Context, request *ListWorkflowExecutionsByTypeRequest) (*ListWorkflowExecutionsResponse, error) {
 	domain := request.Domain
 
 	rateLimiter := p.rateLimitersForList.getRateLimiter(domain, numOfPriorityForList, p.config.VisibilityListMaxQPS(domain))
@@ -163,10 +164,10 @@ func (p *visibilitySamplingClient) ListClosedWorkflowExecutionsByType(request *L
 		return nil, ErrPersistenceLimitExceededForList
 	}
 
-	return p.persistence.ListClosedWorkflowExecutionsByType(request)
+	return p.persistence.ListClosedWorkflowExecutionsByType(ctx, request)
 }
 
-func (p *visibilitySamplingClient) ListOpenWorkflowExecutionsByWorkflowID(request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error) {
+func (p *visibilitySamplingClient) ListOpenWorkflowExecutionsByWorkflowID(ctx context.Context, request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error) {
 	domain := request.Domain
 
 	rateLimiter := p.rateLimitersForList.getRateLimiter(domain, numOfPriorityForList, p.config.VisibilityListMaxQPS(domain))
@@ -174,10 +175,10 @@ func (p *visibilitySamplingClient) ListOpenWorkflowExecutionsByWorkflowID(reques
 		return nil, ErrPersistenceLimitExceededForList
 	}
 
-	return p.persistence.ListOpenWorkflowExecutionsByWorkflowID(request)
+	return p.persistence.ListOpenWorkflowExecutionsByWorkflowID(ctx, request)
 }
 
-func (p *visibilitySamplingClient) ListClosedWorkflowExecutionsByWorkflowID(request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error) {
+func (p *visibilitySamplingClient) ListClosedWorkflowExecutionsByWorkflowID(ctx context.Context, request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error) {
 	domain := request.Domain
 
 	rateLimiter := p.rateLimitersForList.getRateLimiter(domain, numOfPriorityForList, p.config.VisibilityListMaxQPS(domain))
@@ -185,10 +186,10 @@ func (p *visibilitySamplingClient) ListClosedWorkflowExecutionsByWorkflowID(requ
 		return nil, ErrPersistenceLimitExceededForList
 	}
 
-	return p.persistence.ListClosedWorkflowExecutionsByWorkflowID(request)
+	return p.persistence.ListClosedWorkflowExecutionsByWorkflowID(ctx, request)
 }
 
-func (p *visibilitySamplingClient) ListClosedWorkflowExecutionsByStatus(request *ListClosedWorkflowExecutionsByStatusRequest) (*ListWorkflowExecutionsResponse, error) {
+func (p *visibilitySamplingClient) ListClosedWorkflowExecutionsByStatus(ctx context.Context, request *ListClosedWorkflowExecutionsByStatusRequest) (*ListWorkflowExecutionsResponse, error) {
 	domain := request.Domain
 
 	rateLimiter := p.rateLimitersForList.getRateLimiter(domain, numOfPriorityForList, p.config.VisibilityListMaxQPS(domain))
@@ -196,15 +197,23 @@ func (p *visibilitySamplingClient) ListClosedWorkflowExecutionsByStatus(request
 		return nil, ErrPersistenceLimitExceededForList
 	}
 
-	return p.persistence.ListClosedWorkflowExecutionsByStatus(request)
+	return p.persistence.ListClosedWorkflowExecutionsByStatus(ctx, request)
 }
 
-func (p *visibilitySamplingClient) GetClosedWorkflowExecution(request *GetClosedWorkflowExecutionRequest) (*GetClosedWorkflowExecutionResponse, error) {
-	return p.persistence.GetClosedWorkflowExecution(request)
+func (p *visibilitySamplingClient) GetClosedWorkflowExecution(ctx context.Context, request *GetClosedWorkflowExecutionRequest) (*GetClosedWorkflowExecutionResponse, error) {
+	return p.persistence.GetClosedWorkflowExecution(ctx, request)
 }
 
-func (p *visibilitySamplingClient) DeleteWorkflowExecution(request *VisibilityDeleteWorkflowExecutionRequest) error {
-	return p.persistence.DeleteWorkflowExecution(request)
+func (p *visibilitySamplingClient) DeleteWorkflowExecution(ctx context.Context, request *VisibilityDeleteWorkflowExecutionRequest) error {
+	return p.persistence.DeleteWorkflowExecution(ctx, request)
+}
+
+func (p *visibilitySamplingClient) GetWorkflowExecutionStatistics(ctx context.Context, request *GetWorkflowExecutionStatisticsRequest) (*GetWorkflowExecutionStatisticsResponse, error) {
+	return p.persistence.GetWorkflowExecutionStatistics(ctx, request)
+}
+
+func (p *visibilitySamplingClient) CountOpenWorkflowExecutions(ctx context.Context, request *CountWorkflowExecutionsRequest) (*CountWorkflowExecutionsResponse, error) {
+	return p.persistence.CountOpenWorkflowExecutions(ctx, request)
 }
 
 func (p *visibilitySamplingClient) Close() {
@@ -20,7 +20,11 @@
 
 package persistence
 
-import "github.com/uber/cadence/common/service/dynamicconfig"
+import (
+	"context"
+
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
 
 type (
 	visibilityManagerWrapper struct {
@@ -55,64 +59,80 @@ func (v *visibilityManagerWrapper) GetName() string {
 	return "visibilityManagerWrapper"
 }
 
-func (v *visibilityManagerWrapper) RecordWorkflowExecutionStarted(request *RecordWorkflowExecutionStartedRequest) error {
+func (v *visibilityManagerWrapper) RecordWorkflowExecutionStarted(ctx context.Context, request *RecordWorkflowExecutionStartedRequest) error {
 	manager := v.chooseVisibilityManagerForDomain(request.Domain)
-	return manager.RecordWorkflowExecutionStarted(request)
+	return manager.RecordWorkflowExecutionStarted(ctx, request)
 }
 
-func (v *visibilityManagerWrapper) RecordWorkflowExecutionClosed(request *RecordWorkflowExecutionClosedRequest) error {
+func (v *visibilityManagerWrapper) RecordWorkflowExecutionClosed(ctx context.Context, request *RecordWorkflowExecutionClosedRequest) error {
 	manager := v.chooseVisibilityManagerForDomain(request.Domain)
-	return manager.RecordWorkflowExecutionClosed(request)
+	return manager.RecordWorkflowExecutionClosed(ctx, request)
 }
 
-func (v *visibilityManagerWrapper) ListOpenWorkflowExecutions(request *ListWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error) {
-	manager := v.chooseVisibilityManagerForDomain(request.Domain)
-	return manager.ListOpenWorkflowExecutions(request)
+func (v *visibilityManagerWrapper) ListOpenWorkflowExecutions(ctx context.Context, request *ListWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error) {
+	manager := v.chooseVisibilityManagerForList(request.Domain, request.NextPageToken)
+	return manager.ListOpenWorkflowExecutions(ctx, request)
 }
 
-func (v *visibilityManagerWrapper) ListClosedWorkflowExecutions(request *ListWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error) {
-	manager := v.chooseVisibilityManagerForDomain(request.Domain)
-	return manager.ListClosedWorkflowExecutions(request)
+func (v *visibilityManagerWrapper) ListClosedWorkflowExecutions(ctx context.Context, request *ListWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error) {
+	manager := v.chooseVisibilityManagerForList(request.Domain, request.NextPageToken)
+	return manager.ListClosedWorkflowExecutions(ctx, request)
 }
 
-func (v *visibilityManagerWrapper) ListOpenWorkflowExecutionsByType(request *ListWorkflowExecutionsByTypeRequest) (*ListWorkflowExecutionsResponse, error) {
-	manager := v.chooseVisibilityManagerForDomain(request.Domain)
-	return manager.ListOpenWorkflowExecutionsByType(request)
+func (v *visibilityManagerWrapper) ListOpenWorkflowExecutionsByType(ctx context.Context, request *ListWorkflowExecutionsByTypeRequest) (*ListWorkflowExecutionsResponse, error) {
+	manager := v.chooseVisibilityManagerForList(request.Domain, request.NextPageToken)
+	return manager.ListOpenWorkflowExecutionsByType(ctx, request)
 }
 
-func (v *visibilityManagerWrapper) ListClosedWorkflowExecutionsByType(request *ListWorkflowExecutionsByTypeRequest) (*ListWorkflowExecutionsResponse, error) {
-	manager := v.chooseVisibilityManagerForDomain(request.Domain)
-	return manager.ListClosedWorkflowExecutionsByType(request)
+func (v *visibilityManagerWrapper) ListClosedWorkflowExecutionsByType(ctx context.Context, request *ListWorkflowExecutionsByTypeRequest) (*ListWorkflowExecutionsResponse, error) {
+	manager := v.chooseVisibilityManagerForList(request.Domain, request.NextPageToken)
+	return manager.ListClosedWorkflowExecutionsByType(ctx, request)
 }
 
-func (v *visibilityManagerWrapper) ListOpenWorkflowExecutionsByWorkflowID(request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error) {
-	manager := v.chooseVisibilityManagerForDomain(request.Domain)
-	return manager.ListOpenWorkflowExecutionsByWorkflowID(request)
+func (v *visibilityManagerWrapper) ListOpenWorkflowExecutionsByWorkflowID(ctx context.Context, request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error) {
+	manager := v.chooseVisibilityManagerForList(request.Domain, request.NextPageToken)
+	return manager.ListOpenWorkflowExecutionsByWorkflowID(ctx, request)
 }
 
-func (v *visibilityManagerWrapper) ListClosedWorkflowExecutionsByWorkflowID(request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error) {
-	manager := v.chooseVisibilityManagerForDomain(request.Domain)
-	return manager.ListClosedWorkflowExecutionsByWorkflowID(request)
+func (v *visibilityManagerWrapper) ListClosedWorkflowExecutionsByWorkflowID(ctx context.Context, request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error) {
+	manager := v.chooseVisibilityManagerForList(request.Domain, request.NextPageToken)
+	return manager.ListClosedWorkflowExecutionsByWorkflowID(ctx, request)
 }
 
-func (v *visibilityManagerWrapper) ListClosedWorkflowExecutionsByStatus(request *ListClosedWorkflowExecutionsByStatusRequest) (*ListWorkflowExecutionsResponse, error) {
-	manager := v.chooseVisibilityManagerForDomain(request.Domain)
-	return manager.ListClosedWorkflowExecutionsByStatus(request)
+func (v *visibilityManagerWrapper) ListClosedWorkflowExecutionsByStatus(ctx context.Context, request *ListClosedWorkflowExecutionsByStatusRequest) (*ListWorkflowExecutionsResponse, error) {
+	manager := v.chooseVisibilityManagerForList(request.Domain, request.NextPageToken)
+	return manager.ListClosedWorkflowExecutionsByStatus(ctx, request)
 }
 
-func (v *visibilityManagerWrapper) GetClosedWorkflowExecution(request *GetClosedWorkflowExecutionRequest) (*GetClosedWorkflowExecutionResponse, error) {
+func (v *visibilityManagerWrapper) GetClosedWorkflowExecution(ctx context.Context, request *GetClosedWorkflowExecutionRequest) (*GetClosedWorkflowExecutionResponse, error) {
 	manager := v.chooseVisibilityManagerForDomain(request.Domain)
-	return manager.GetClosedWorkflowExecution(request)
+	return manager.GetClosedWorkflowExecution(ctx, request)
 }
 
-func (v *visibilityManagerWrapper) DeleteWorkflowExecution(request *VisibilityDeleteWorkflowExecutionRequest) error {
+func (v *visibilityManagerWrapper) DeleteWorkflowExecution(ctx context.Context, request *VisibilityDeleteWorkflowExecutionRequest) error {
 	// this api is only applicable for sql based persistence as of today
 	if v.visibilityManager != nil {
-		return v.visibilityManager.DeleteWorkflowExecution(request)
+		return v.visibilityManager.DeleteWorkflowExecution(ctx, request)
 	}
 	return nil
 }
 
+func (v *visibilityManagerWrapper) GetWorkflowExecutionStatistics(ctx context.Context, request *GetWorkflowExecutionStatisticsRequest) (*GetWorkflowExecutionStatisticsResponse, error) {
+	// statistics can only be answered by ElasticSearch, so it is used regardless of EnableReadVisibilityFromES
+	if v.esVisibilityManager != nil {
+		return v.esVisibilityManager.GetWorkflowExecutionStatistics(ctx, request)
+	}
+	return v.visibilityManager.GetWorkflowExecutionStatistics(ctx, request)
+}
+
+func (v *visibilityManagerWrapper) CountOpenWorkflowExecutions(ctx context.Context, request *CountWorkflowExecutionsRequest) (*CountWorkflowExecutionsResponse, error) {
+	// counts can only be answered by ElasticSearch, so it is used regardless of EnableReadVisibilityFromES
+	if v.esVisibilityManager != nil {
+		return v.esVisibilityManager.CountOpenWorkflowExecutions(ctx, request)
+	}
+	return v.visibilityManager.CountOpenWorkflowExecutions(ctx, request)
+}
+
 func (v *visibilityManagerWrapper) chooseVisibilityManagerForDomain(domain string) VisibilityManager {
 	var visibilityMgr VisibilityManager
 	if v.enableReadVisibilityFromES(domain) && v.esVisibilityManager != nil {
@@ -122,3 +142,19 @@ func (v *visibilityManagerWrapper) chooseVisibilityManagerForDomain(domain strin
 	}
 	return visibilityMgr
 }
+
+// chooseVisibilityManagerForList keeps a pagination on the store which issued its page token until it is
+// exhausted, so that flipping EnableReadVisibilityFromES only takes effect for queries starting from the first page
+func (v *visibilityManagerWrapper) chooseVisibilityManagerForList(domain string, pageToken []byte) VisibilityManager {
+	switch visibilityPageTokenStore(pageToken) {
+	case VisibilityStoreElasticSearch:
+		if v.esVisibilityManager != nil {
+			return v.esVisibilityManager
+		}
+	case VisibilityStoreCassandra, VisibilityStoreSQL:
+		if v.visibilityManager != nil {
+			return v.visibilityManager
+		}
+	}
+	return v.chooseVisibilityManagerForDomain(domain)
+}
@@ -25,4 +25,10 @@ type (
 	PProfInitializer interface {
 		Start() error
 	}
+
+	// DebugInitializer starts the debug endpoints of a service based on config
+	DebugInitializer interface {
+		Start() error
+		Stop()
+	}
 )
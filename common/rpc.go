@@ -21,7 +21,9 @@
 package common
 
 import (
+	"github.com/pborman/uuid"
 	"go.uber.org/yarpc"
+	"go.uber.org/yarpc/api/transport"
 	"golang.org/x/net/context"
 )
 
@@ -43,6 +45,18 @@ const (
 	// ClientImplHeaderName refers to the name of the
 	// header that contains the client implementation
 	ClientImplHeaderName = "cadence-client-name"
+
+	// RequestIDHeaderName refers to the name of the header
+	// that contains the ID of the user request a call is
+	// made for, it is assigned by the first cadence service
+	// receiving the request and forwarded on every call
+	// made on behalf of the request
+	RequestIDHeaderName = "cadence-request-id"
+
+	// CallerIdentityHeaderName refers to the name of the
+	// header that contains the identity of the caller who
+	// made the user request
+	CallerIdentityHeaderName = "cadence-caller-identity"
 )
 
 type (
@@ -51,6 +65,18 @@ type (
 		CreateDispatcher() *yarpc.Dispatcher
 		CreateDispatcherForOutbound(callerName, serviceName, hostName string) *yarpc.Dispatcher
 	}
+
+	// requestInfo identifies the user request work is done for, when the work is not done
+	// in an inbound call carrying the request headers, e.g. when processing a transfer task
+	requestInfo struct {
+		requestID      string
+		callerIdentity string
+	}
+
+	requestInfoContextKey struct{}
+
+	// requestInfoInboundMiddleware assigns a request ID and caller identity to inbound calls without them
+	requestInfoInboundMiddleware struct{}
 )
 
 // AggregateYarpcOptions aggregate the header information from context to existing yarpc call options
@@ -62,7 +88,62 @@ func AggregateYarpcOptions(ctx context.Context, opts ...yarpc.CallOption) []yarp
 			value := call.Header(key)
 			result = append(result, yarpc.WithHeader(key, value))
 		}
+		if info, ok := ctx.Value(requestInfoContextKey{}).(requestInfo); ok && call.Header(RequestIDHeaderName) == "" {
+			result = append(result, yarpc.WithHeader(RequestIDHeaderName, info.requestID))
+			if info.callerIdentity != "" {
+				result = append(result, yarpc.WithHeader(CallerIdentityHeaderName, info.callerIdentity))
+			}
+		}
 	}
 	result = append(result, opts...)
 	return result
 }
+
+// NewContextWithRequestInfo returns a context carrying the request ID and caller identity of a user request,
+// outbound calls made with the context forward them to the callee
+func NewContextWithRequestInfo(ctx context.Context, requestID string, callerIdentity string) context.Context {
+	if requestID == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, requestInfoContextKey{}, requestInfo{
+		requestID:      requestID,
+		callerIdentity: callerIdentity,
+	})
+}
+
+// GetRequestInfo returns the request ID and caller identity of the user request the context belongs to,
+// both are empty if the context is not associated with a user request
+func GetRequestInfo(ctx context.Context) (requestID string, callerIdentity string) {
+	if ctx == nil {
+		return "", ""
+	}
+	if info, ok := ctx.Value(requestInfoContextKey{}).(requestInfo); ok {
+		return info.requestID, info.callerIdentity
+	}
+	call := yarpc.CallFromContext(ctx)
+	return call.Header(RequestIDHeaderName), call.Header(CallerIdentityHeaderName)
+}
+
+// NewRequestInfoInboundMiddleware creates an inbound middleware which assigns a new request ID to calls
+// without one, and uses the name of the calling service as caller identity when the caller did not set one
+func NewRequestInfoInboundMiddleware() yarpc.InboundMiddleware {
+	return yarpc.InboundMiddleware{
+		Unary: &requestInfoInboundMiddleware{},
+	}
+}
+
+// Handle implements middleware.UnaryInbound
+func (m *requestInfoInboundMiddleware) Handle(
+	ctx context.Context,
+	request *transport.Request,
+	responseWriter transport.ResponseWriter,
+	handler transport.UnaryHandler,
+) error {
+	if _, ok := request.Headers.Get(RequestIDHeaderName); !ok {
+		request.Headers = request.Headers.With(RequestIDHeaderName, uuid.New())
+	}
+	if _, ok := request.Headers.Get(CallerIdentityHeaderName); !ok {
+		request.Headers = request.Headers.With(CallerIdentityHeaderName, request.Caller)
+	}
+	return handler.Handle(ctx, request, responseWriter)
+}
@@ -22,11 +22,13 @@ package config
 
 import (
 	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/uber/cadence/common/blobstore/filestore"
 
 	"github.com/uber-go/tally/m3"
+	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/elasticsearch"
 	"github.com/uber/cadence/common/messaging"
 	"github.com/uber/cadence/common/service/dynamicconfig"
@@ -38,6 +40,8 @@ type (
 	Config struct {
 		// Ringpop is the ringpop related configuration
 		Ringpop Ringpop `yaml:"ringpop"`
+		// Membership is the config for the membership provider, ringpop is used by default
+		Membership Membership `yaml:"membership"`
 		// Persistence contains the configuration for cadence datastores
 		Persistence Persistence `yaml:"persistence"`
 		// Log is the logging config
@@ -56,6 +60,9 @@ type (
 		ElasticSearch elasticsearch.Config `yaml:"elasticsearch"`
 		// PublicClient is config for connecting to cadence frontend
 		PublicClient PublicClient `yaml:"publicClient"`
+		// PeerDiscovery is the config for resolving the frontend addresses of the public client
+		// and of the remote clusters into peers
+		PeerDiscovery PeerDiscovery `yaml:"peerDiscovery"`
 	}
 
 	// Service contains the service specific config items
@@ -66,6 +73,8 @@ type (
 		Metrics Metrics `yaml:"metrics"`
 		// PProf is the PProf configuration
 		PProf PProf `yaml:"pprof"`
+		// Debug is the debug endpoints configuration
+		Debug Debug `yaml:"debug"`
 	}
 
 	// PProf contains the rpc config items
@@ -74,10 +83,24 @@ type (
 		Port int `yaml:"port"`
 	}
 
+	// Debug contains the config items of the per service debug listener,
+	// which exposes pprof, expvar, goroutine dumps and build info
+	Debug struct {
+		// Port is the port on which the debug listener will bind to, the listener is disabled if not set
+		Port int `yaml:"port"`
+		// BindOnIP is the address the debug listener binds to, defaults to localhost
+		BindOnIP string `yaml:"bindOnIP"`
+		// AuthToken is the token callers must present, defaults to the admin operation token
+		AuthToken string `yaml:"authToken"`
+	}
+
 	// RPC contains the rpc config items
 	RPC struct {
 		// Port is the port  on which the channel will bind to
 		Port int `yaml:"port"`
+		// HTTPPort is the port on which the thrift over http inbound will bind to, in addition
+		// to the channel, the http inbound is disabled if not set and only frontend supports it
+		HTTPPort int `yaml:"httpPort"`
 		// BindOnLocalHost is true if localhost is the bind address
 		BindOnLocalHost bool `yaml:"bindOnLocalHost"`
 		// BindOnIP can be used to bind service on specific ip (eg. `0.0.0.0`) -
@@ -106,6 +129,28 @@ type (
 		DiscoveryProvider discovery.DiscoverProvider `yaml:"-"`
 	}
 
+	// Membership contains the config for the membership provider
+	Membership struct {
+		// Provider is one of ringpop, dns or kubernetes, defaults to ringpop
+		Provider string `yaml:"provider"`
+		// RefreshInterval is how often members are reloaded for dns and kubernetes providers
+		RefreshInterval time.Duration `yaml:"refreshInterval"`
+		// DNS maps a cadence service name to the SRV record of its hosts
+		DNS map[string]string `yaml:"dns"`
+		// Kubernetes contains the config for the kubernetes provider
+		Kubernetes KubernetesMembership `yaml:"kubernetes"`
+	}
+
+	// KubernetesMembership contains the config to resolve hosts from kubernetes endpoints
+	KubernetesMembership struct {
+		// Namespace of the endpoints, defaults to the namespace of the pod
+		Namespace string `yaml:"namespace"`
+		// Endpoints maps a cadence service name to the name of its kubernetes endpoints
+		Endpoints map[string]string `yaml:"endpoints"`
+		// PortName is the name of the tchannel port of the endpoints, first port is used if empty
+		PortName string `yaml:"portName"`
+	}
+
 	// Persistence contains the configuration for data store / persistence layer
 	Persistence struct {
 		// DefaultStore is the name of the default data store to use
@@ -121,7 +166,29 @@ type (
 		// DataStores contains the configuration for all datastores
 		DataStores map[string]DataStore `yaml:"datastores"`
 		// VisibilityConfig is config for visibility sampling
-		VisibilityConfig *VisibilityConfig
+		VisibilityConfig *VisibilityConfig `json:"-"`
+		// AdaptiveThrottlingConfig is config for adjusting the datastore rate limit based on errors
+		AdaptiveThrottlingConfig *AdaptiveThrottlingConfig `json:"-"`
+		// DomainMetricsConfig is config for tagging persistence metrics with the domain
+		DomainMetricsConfig *DomainMetricsConfig `json:"-"`
+		// FaultInjectionConfig is config for failing persistence calls on purpose in chaos tests
+		FaultInjectionConfig *FaultInjectionConfig `json:"-"`
+		// Timeouts contains the deadlines applied to persistence calls, per type of operation
+		Timeouts PersistenceTimeouts `yaml:"timeouts"`
+		// HistoryBlobChecksum prefixes the history blobs written with a header carrying their checksum,
+		// it must only be enabled once all hosts of the cluster can read such blobs
+		HistoryBlobChecksum bool `yaml:"historyBlobChecksum"`
+	}
+
+	// PersistenceTimeouts is the deadline applied to each type of persistence operation,
+	// a zero value means the default for that type of operation is used
+	PersistenceTimeouts struct {
+		// Read is the timeout for single record lookups
+		Read time.Duration `yaml:"read"`
+		// Write is the timeout for creates, updates and deletes
+		Write time.Duration `yaml:"write"`
+		// List is the timeout for list and scan queries
+		List time.Duration `yaml:"list"`
 	}
 
 	// DataStore is the configuration for a single datastore
@@ -130,6 +197,8 @@ type (
 		Cassandra *Cassandra `yaml:"cassandra"`
 		// SQL contains the config for a SQL based datastore
 		SQL *SQL `yaml:"sql"`
+		// InMemory contains the config for a datastore which keeps all data in process memory
+		InMemory *InMemory `yaml:"inmemory"`
 	}
 
 	// VisibilityConfig is config for visibility sampling
@@ -144,8 +213,57 @@ type (
 		VisibilityClosedMaxQPS dynamicconfig.IntPropertyFnWithDomainFilter
 		// VisibilityListMaxQPS max QPS for list workflow
 		VisibilityListMaxQPS dynamicconfig.IntPropertyFnWithDomainFilter
+		// EnableAsyncClosedRecords buffers record closed workflows and writes them in batches, nil if not supported
+		EnableAsyncClosedRecords dynamicconfig.BoolPropertyFn
+		// ClosedRecordsBatchSize is the max number of buffered record closed workflows written together
+		ClosedRecordsBatchSize dynamicconfig.IntPropertyFn
+		// ClosedRecordsBatchInterval is the max time a record closed workflow is buffered before it's written
+		ClosedRecordsBatchInterval dynamicconfig.DurationPropertyFn
 		// ESIndexMaxResultWindow ElasticSearch index setting max_result_window
 		ESIndexMaxResultWindow dynamicconfig.IntPropertyFn
+		// ESVisibilityMaxQueryTimeRange is the widest time range of ElasticSearch queries, 0 is unlimited
+		ESVisibilityMaxQueryTimeRange dynamicconfig.DurationPropertyFnWithDomainFilter
+		// ESVisibilityMaxQueryCost is the max number of documents matched by an ElasticSearch list query, 0 is unlimited
+		ESVisibilityMaxQueryCost dynamicconfig.IntPropertyFnWithDomainFilter
+	}
+
+	// AdaptiveThrottlingConfig is config for adaptive persistence throttling
+	AdaptiveThrottlingConfig struct {
+		// Enabled turns on adjusting the rate limit based on datastore errors
+		Enabled dynamicconfig.BoolPropertyFn
+		// ErrorRatioThreshold is the ratio of ServiceBusy / Timeout errors over which the rate is reduced
+		ErrorRatioThreshold dynamicconfig.FloatPropertyFn
+		// BackoffFactor is multiplied with the current rate when the threshold is exceeded
+		BackoffFactor dynamicconfig.FloatPropertyFn
+		// RecoveryFactor is the fraction of max QPS added back per healthy evaluation interval
+		RecoveryFactor dynamicconfig.FloatPropertyFn
+		// MinQPSRatio is the lowest fraction of max QPS the rate can be reduced to
+		MinQPSRatio dynamicconfig.FloatPropertyFn
+		// EvaluationInterval is the window over which errors are aggregated
+		EvaluationInterval dynamicconfig.DurationPropertyFn
+	}
+
+	// DomainMetricsConfig is config for tagging persistence metrics with the domain
+	DomainMetricsConfig struct {
+		// Enabled turns on emitting persistence metrics tagged with the domain of the operation
+		Enabled dynamicconfig.BoolPropertyFn
+		// MaxDomains is the max number of distinct domains tagged, the rest are tagged as other
+		MaxDomains dynamicconfig.IntPropertyFn
+	}
+
+	// FaultInjectionConfig is config for failing persistence calls on purpose, all the
+	// properties are read with the persistence operation as filter
+	FaultInjectionConfig struct {
+		// Enabled turns on fault injection
+		Enabled dynamicconfig.BoolPropertyFn
+		// TimeoutRate is the probability of failing a call with a timeout
+		TimeoutRate dynamicconfig.FloatPropertyFn
+		// ThrottleRate is the probability of failing a call as throttled
+		ThrottleRate dynamicconfig.FloatPropertyFn
+		// ConditionFailedRate is the probability of failing a write with a condition failure
+		ConditionFailedRate dynamicconfig.FloatPropertyFn
+		// PartialFailureRate is the probability of reporting a timeout for a write which was applied
+		PartialFailureRate dynamicconfig.FloatPropertyFn
 	}
 
 	// Cassandra contains configuration to connect to Cassandra cluster
@@ -168,6 +286,9 @@ type (
 		MaxQPS int `yaml:"maxQPS"`
 		// MaxConns is the max number of connections to this datastore for a single keyspace
 		MaxConns int `yaml:"maxConns"`
+		// Timeout is the client side timeout for a single query, defaults to
+		// the largest of the persistence operation timeouts
+		Timeout time.Duration `yaml:"timeout"`
 	}
 
 	// SQL is the configuration for connecting to a SQL backed datastore
@@ -191,6 +312,17 @@ type (
 		// NumShards is the number of storage shards to use for tables
 		// in a sharded sql database. The default value for this param is 1
 		NumShards int `yaml:"nShards"`
+		// ShardRangeCacheTTL is how long execution stores trust the cached range ID of a shard instead of
+		// read locking the shard row in every transaction. The default value of 0 disables the cache
+		ShardRangeCacheTTL time.Duration `yaml:"shardRangeCacheTTL"`
+	}
+
+	// InMemory contains the config for an in-memory datastore, it is meant for
+	// tests and development servers as nothing survives a restart of the process
+	InMemory struct {
+		// DatabaseName identifies the database, all datastores of a process
+		// configured with the same name share the same data
+		DatabaseName string `yaml:"databaseName"`
 	}
 
 	// Replicator describes the configuration of replicator
@@ -236,6 +368,8 @@ type (
 	DCRedirectionPolicy struct {
 		Policy string `yaml:"policy"`
 		ToDC   string `yaml:"toDC"`
+		// SelectedAPIs is the list of APIs forwarded by the selected-apis-forwarding policy
+		SelectedAPIs []string `yaml:"selectedAPIs"`
 	}
 
 	// Metrics contains the config items for metrics subsystem
@@ -282,17 +416,225 @@ type (
 		HostPort string `yaml:"hostPort" validate:"nonzero"`
 	}
 
+	// PeerDiscovery contains the config items for the outbounds to frontend addresses, the host of
+	// such an address can be a dns name which is resolved periodically, every address it resolves to
+	// becomes a peer the calls are balanced across, and peers whose connection fails are skipped
+	PeerDiscovery struct {
+		// Chooser is the load balancing strategy across peers, round-robin (default) or least-pending
+		Chooser string `yaml:"chooser"`
+		// RefreshInterval is the interval between dns resolutions, defaults to 30s
+		RefreshInterval time.Duration `yaml:"refreshInterval"`
+	}
+
 	// BootstrapMode is an enum type for ringpop bootstrap mode
 	BootstrapMode int
 )
 
+const (
+	// PeerChooserRoundRobin balances the calls across peers in turn
+	PeerChooserRoundRobin = "round-robin"
+	// PeerChooserLeastPending sends each call to the peer with the fewest calls in flight
+	PeerChooserLeastPending = "least-pending"
+)
+
+// redactedValue replaces the secrets of the config when it is printed
+const redactedValue = "******"
+
+// secretFields are the fields of the config, as they are marshalled to json, whose values are redacted
+var secretFields = map[string]struct{}{
+	"Password":        {},
+	"SecretAccessKey": {},
+	"SessionToken":    {},
+	"AuthToken":       {},
+}
+
 // Validate validates this config
 func (c *Config) Validate() error {
-	return c.Persistence.Validate()
+	if err := c.Persistence.Validate(); err != nil {
+		return err
+	}
+	if err := c.validateClustersInfo(); err != nil {
+		return err
+	}
+	if err := c.validateVisibility(); err != nil {
+		return err
+	}
+	if err := c.validateRPC(); err != nil {
+		return err
+	}
+	if err := c.validatePeerDiscovery(); err != nil {
+		return err
+	}
+	return c.validateKafka()
+}
+
+// validateClustersInfo verifies the current and master clusters are among the clusters
+// configured and all the clusters can be reached
+func (c *Config) validateClustersInfo() error {
+	info := c.ClustersInfo
+	if info.CurrentClusterName == "" {
+		return fmt.Errorf("clustersInfo config: current cluster name is empty")
+	}
+	if info.MasterClusterName == "" {
+		return fmt.Errorf("clustersInfo config: master cluster name is empty")
+	}
+	for _, clusterName := range []string{info.CurrentClusterName, info.MasterClusterName} {
+		if _, ok := info.ClusterInitialFailoverVersions[clusterName]; !ok {
+			return fmt.Errorf("clustersInfo config: cluster %v has no initial failover version", clusterName)
+		}
+	}
+	for clusterName := range info.ClusterInitialFailoverVersions {
+		if _, ok := info.ClusterAddress[clusterName]; !ok {
+			return fmt.Errorf("clustersInfo config: cluster %v has no address", clusterName)
+		}
+	}
+	return nil
 }
 
-// String converts the config object into a string
+// validateVisibility verifies elastic search can be used for visibility when it is enabled
+func (c *Config) validateVisibility() error {
+	es := c.ElasticSearch
+	if !es.Enable {
+		return nil
+	}
+	if es.URL.Host == "" {
+		return fmt.Errorf("elasticsearch config: url is empty")
+	}
+	if es.Indices[common.VisibilityAppName] == "" {
+		return fmt.Errorf("elasticsearch config: missing index for %v", common.VisibilityAppName)
+	}
+	if _, ok := c.Kafka.Applications[common.VisibilityAppName]; !ok {
+		return fmt.Errorf("kafka config: missing topics for application %v", common.VisibilityAppName)
+	}
+	return nil
+}
+
+// validateRPC verifies the http inbound is only enabled on frontend, the other services are reached
+// through the addresses advertised by ringpop which are always tchannel, and does not reuse the rpc port
+func (c *Config) validateRPC() error {
+	for name, svc := range c.Services {
+		if svc.RPC.HTTPPort == 0 {
+			continue
+		}
+		if name != "frontend" {
+			return fmt.Errorf("services config: http inbound is not supported by %v", name)
+		}
+		if svc.RPC.HTTPPort == svc.RPC.Port {
+			return fmt.Errorf("services config: %v httpPort %v is already used by rpc", name, svc.RPC.HTTPPort)
+		}
+	}
+	return nil
+}
+
+// validatePeerDiscovery verifies the peer chooser is known
+func (c *Config) validatePeerDiscovery() error {
+	switch c.PeerDiscovery.Chooser {
+	case "", PeerChooserRoundRobin, PeerChooserLeastPending:
+		return nil
+	default:
+		return fmt.Errorf("peerDiscovery config: unknown chooser %v", c.PeerDiscovery.Chooser)
+	}
+}
+
+// validateKafka verifies the kafka clusters and topics needed by replication, when global domains
+// are enabled, and by visibility, when elastic search is enabled, are defined
+func (c *Config) validateKafka() (err error) {
+	checkCluster := c.ClustersInfo.EnableGlobalDomain
+	checkApp := c.ElasticSearch.Enable
+	if !checkCluster && !checkApp {
+		return nil
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("kafka config: %v", r)
+		}
+	}()
+	c.Kafka.Validate(checkCluster, checkApp)
+
+	if checkCluster {
+		for clusterName := range c.ClustersInfo.ClusterAddress {
+			if _, ok := c.Kafka.ClusterToTopic[clusterName]; !ok {
+				return fmt.Errorf("kafka config: missing topics for cadence cluster %v", clusterName)
+			}
+		}
+	}
+	return nil
+}
+
+// String converts the config object into a string, the secrets it contains are redacted
 func (c *Config) String() string {
-	out, _ := json.MarshalIndent(c, "", "    ")
+	redacted, err := c.Redacted()
+	if err != nil {
+		return fmt.Sprintf("failed to convert config: %v", err)
+	}
+	out, _ := json.MarshalIndent(redacted, "", "    ")
 	return string(out)
 }
+
+// Redacted returns the config, as it is marshalled to json, with its secrets redacted
+func (c *Config) Redacted() (interface{}, error) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	var out interface{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	redactSecrets(out)
+	return out, nil
+}
+
+func redactSecrets(value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if _, ok := secretFields[key]; ok {
+				if s, ok := field.(string); ok && s != "" {
+					v[key] = redactedValue
+				}
+				continue
+			}
+			redactSecrets(field)
+		}
+	case []interface{}:
+		for _, item := range v {
+			redactSecrets(item)
+		}
+	}
+}
+
+// NewAdaptiveThrottlingConfig returns the adaptive persistence throttling config, the enable
+// switch is read from the given service specific key while tuning knobs are shared
+func NewAdaptiveThrottlingConfig(dc *dynamicconfig.Collection, enableKey dynamicconfig.Key) *AdaptiveThrottlingConfig {
+	return &AdaptiveThrottlingConfig{
+		Enabled:             dc.GetBoolProperty(enableKey, false),
+		ErrorRatioThreshold: dc.GetFloat64Property(dynamicconfig.PersistenceAdaptiveThrottlingErrorRatio, 0.1),
+		BackoffFactor:       dc.GetFloat64Property(dynamicconfig.PersistenceAdaptiveThrottlingBackoffFactor, 0.5),
+		RecoveryFactor:      dc.GetFloat64Property(dynamicconfig.PersistenceAdaptiveThrottlingRecoveryFactor, 0.1),
+		MinQPSRatio:         dc.GetFloat64Property(dynamicconfig.PersistenceAdaptiveThrottlingMinQPSRatio, 0.1),
+		EvaluationInterval:  dc.GetDurationProperty(dynamicconfig.PersistenceAdaptiveThrottlingEvaluationInterval, 10*time.Second),
+	}
+}
+
+// NewDomainMetricsConfig returns the persistence domain metrics config, the enable
+// switch is read from the given service specific key while the cardinality guard is shared
+func NewDomainMetricsConfig(dc *dynamicconfig.Collection, enableKey dynamicconfig.Key) *DomainMetricsConfig {
+	return &DomainMetricsConfig{
+		Enabled:    dc.GetBoolProperty(enableKey, false),
+		MaxDomains: dc.GetIntProperty(dynamicconfig.PersistenceMaxDomainMetricsTags, 100),
+	}
+}
+
+// NewFaultInjectionConfig returns the persistence fault injection config, the enable
+// switch is read from the given service specific key while the failure rates are shared
+func NewFaultInjectionConfig(dc *dynamicconfig.Collection, enableKey dynamicconfig.Key) *FaultInjectionConfig {
+	return &FaultInjectionConfig{
+		Enabled:             dc.GetBoolProperty(enableKey, false),
+		TimeoutRate:         dc.GetFloat64Property(dynamicconfig.PersistenceFaultInjectionTimeoutRate, 0),
+		ThrottleRate:        dc.GetFloat64Property(dynamicconfig.PersistenceFaultInjectionThrottleRate, 0),
+		ConditionFailedRate: dc.GetFloat64Property(dynamicconfig.PersistenceFaultInjectionConditionFailedRate, 0),
+		PartialFailureRate:  dc.GetFloat64Property(dynamicconfig.PersistenceFaultInjectionPartialFailureRate, 0),
+	}
+}
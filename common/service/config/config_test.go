@@ -0,0 +1,167 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber/cadence/common/elasticsearch"
+	"github.com/uber/cadence/common/messaging"
+)
+
+type ConfigSuite struct {
+	*require.Assertions
+	suite.Suite
+}
+
+func TestConfigSuite(t *testing.T) {
+	suite.Run(t, new(ConfigSuite))
+}
+
+func (s *ConfigSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *ConfigSuite) TestValidate() {
+	s.NoError(s.newConfig().Validate())
+}
+
+func (s *ConfigSuite) TestValidate_MissingDataStore() {
+	cfg := s.newConfig()
+	cfg.Persistence.VisibilityStore = "missing"
+	s.Error(cfg.Validate())
+}
+
+func (s *ConfigSuite) TestValidate_CurrentClusterNotConfigured() {
+	cfg := s.newConfig()
+	cfg.ClustersInfo.CurrentClusterName = "standby"
+	s.Error(cfg.Validate())
+}
+
+func (s *ConfigSuite) TestValidate_ClusterWithoutAddress() {
+	cfg := s.newConfig()
+	cfg.ClustersInfo.ClusterInitialFailoverVersions["standby"] = 1
+	s.Error(cfg.Validate())
+}
+
+func (s *ConfigSuite) TestValidate_ElasticSearchWithoutIndex() {
+	cfg := s.newConfig()
+	cfg.ElasticSearch = elasticsearch.Config{
+		Enable: true,
+		URL:    url.URL{Scheme: "http", Host: "127.0.0.1:9200"},
+	}
+	s.Error(cfg.Validate())
+
+	cfg.ElasticSearch.Indices = map[string]string{"visibility": "cadence-visibility"}
+	cfg.Kafka = s.newKafkaConfig()
+	s.NoError(cfg.Validate())
+}
+
+func (s *ConfigSuite) TestValidate_GlobalDomainWithoutKafka() {
+	cfg := s.newConfig()
+	cfg.ClustersInfo.EnableGlobalDomain = true
+	s.Error(cfg.Validate())
+
+	cfg.Kafka = s.newKafkaConfig()
+	s.NoError(cfg.Validate())
+
+	delete(cfg.Kafka.ClusterToTopic, "active")
+	s.Error(cfg.Validate())
+}
+
+func (s *ConfigSuite) TestValidate_HTTPPort() {
+	cfg := s.newConfig()
+	cfg.Services = map[string]Service{
+		"frontend": {RPC: RPC{Port: 7933, HTTPPort: 7941}},
+		"history":  {RPC: RPC{Port: 7934}},
+	}
+	s.NoError(cfg.Validate())
+
+	cfg.Services["frontend"] = Service{RPC: RPC{Port: 7933, HTTPPort: 7933}}
+	s.Error(cfg.Validate())
+
+	cfg.Services["frontend"] = Service{RPC: RPC{Port: 7933}}
+	cfg.Services["history"] = Service{RPC: RPC{Port: 7934, HTTPPort: 7942}}
+	s.Error(cfg.Validate())
+}
+
+func (s *ConfigSuite) TestValidate_PeerChooser() {
+	cfg := s.newConfig()
+	cfg.PeerDiscovery.Chooser = PeerChooserLeastPending
+	s.NoError(cfg.Validate())
+
+	cfg.PeerDiscovery.Chooser = "random"
+	s.Error(cfg.Validate())
+}
+
+func (s *ConfigSuite) TestString_RedactsSecrets() {
+	cfg := s.newConfig()
+	cfg.Persistence.DataStores["default"].Cassandra.Password = "secret"
+	out := cfg.String()
+	s.Contains(out, "cadence")
+	s.Contains(out, redactedValue)
+	s.NotContains(out, "secret")
+}
+
+func (s *ConfigSuite) newConfig() *Config {
+	return &Config{
+		Persistence: Persistence{
+			DefaultStore:     "default",
+			VisibilityStore:  "default",
+			NumHistoryShards: 4,
+			DataStores: map[string]DataStore{
+				"default": {Cassandra: &Cassandra{Hosts: "127.0.0.1", Keyspace: "cadence"}},
+			},
+		},
+		ClustersInfo: ClustersInfo{
+			MasterClusterName:              "active",
+			CurrentClusterName:             "active",
+			ClusterInitialFailoverVersions: map[string]int64{"active": 0},
+			ClusterAddress: map[string]Address{
+				"active": {RPCName: "cadence-frontend", RPCAddress: "127.0.0.1:7933"},
+			},
+		},
+	}
+}
+
+func (s *ConfigSuite) newKafkaConfig() messaging.KafkaConfig {
+	return messaging.KafkaConfig{
+		Clusters: map[string]messaging.ClusterConfig{
+			"test": {Brokers: []string{"127.0.0.1:9092"}},
+		},
+		Topics: map[string]messaging.TopicConfig{
+			"active":                 {Cluster: "test"},
+			"active-retry":           {Cluster: "test"},
+			"active-dlq":             {Cluster: "test"},
+			"cadence-visibility":     {Cluster: "test"},
+			"cadence-visibility-dlq": {Cluster: "test"},
+		},
+		ClusterToTopic: map[string]messaging.TopicList{
+			"active": {Topic: "active", RetryTopic: "active-retry", DLQTopic: "active-dlq"},
+		},
+		Applications: map[string]messaging.TopicList{
+			"visibility": {Topic: "cadence-visibility", DLQTopic: "cadence-visibility-dlq"},
+		},
+	}
+}
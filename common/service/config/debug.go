@@ -0,0 +1,207 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"os"
+	"runtime"
+	runtimepprof "runtime/pprof"
+	"time"
+
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	// DebugInitializerImpl runs the debug listener of a single service based on config
+	DebugInitializerImpl struct {
+		Debug         *Debug
+		ServiceName   string
+		Config        *Config
+		DynamicConfig dynamicconfig.Client
+		AdminToken    dynamicconfig.StringPropertyFn
+		Logger        bark.Logger
+
+		startTime time.Time
+		server    *http.Server
+	}
+
+	// debugBuildInfo is the payload returned by the build info endpoint
+	debugBuildInfo struct {
+		ServiceName string    `json:"serviceName"`
+		HostName    string    `json:"hostName"`
+		GoVersion   string    `json:"goVersion"`
+		GoOS        string    `json:"goOS"`
+		GoArch      string    `json:"goArch"`
+		NumCPU      int       `json:"numCPU"`
+		GoMaxProcs  int       `json:"goMaxProcs"`
+		StartTime   time.Time `json:"startTime"`
+		Uptime      string    `json:"uptime"`
+	}
+
+	// debugEffectiveConfig is the payload returned by the config endpoint
+	debugEffectiveConfig struct {
+		Static  interface{}                             `json:"static"`
+		Dynamic map[string]dynamicconfig.EffectiveValue `json:"dynamic"`
+	}
+)
+
+const (
+	// DebugTokenHeader is the http header used to pass the token to the debug endpoints
+	DebugTokenHeader = "X-Cadence-Admin-Token"
+	// debugTokenQueryParam allows passing the token by tools which cannot set headers, e.g. go tool pprof
+	debugTokenQueryParam = "token"
+)
+
+// NewInitializer create a new instance of the debug listener initializer
+func (cfg *Debug) NewInitializer(
+	serviceName string,
+	config *Config,
+	dynamicConfig dynamicconfig.Client,
+	adminToken dynamicconfig.StringPropertyFn,
+	logger bark.Logger,
+) *DebugInitializerImpl {
+	return &DebugInitializerImpl{
+		Debug:         cfg,
+		ServiceName:   serviceName,
+		Config:        config,
+		DynamicConfig: dynamicConfig,
+		AdminToken:    adminToken,
+		Logger:        logger,
+	}
+}
+
+// Start the debug listener based on config
+func (initializer *DebugInitializerImpl) Start() error {
+	port := initializer.Debug.Port
+	if port == 0 {
+		initializer.Logger.Info("Debug listener not started due to port not set")
+		return nil
+	}
+
+	ip := initializer.Debug.BindOnIP
+	if ip == "" {
+		ip = "localhost"
+	}
+	listener, err := net.Listen("tcp", fmt.Sprintf("%v:%v", ip, port))
+	if err != nil {
+		return err
+	}
+
+	initializer.startTime = time.Now()
+	initializer.server = &http.Server{Handler: initializer.newHandler()}
+	go func() {
+		initializer.Logger.Infof("Debug listener for '%v' listen on %v", initializer.ServiceName, listener.Addr())
+		if err := initializer.server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			initializer.Logger.WithField("error", err).Warn("Debug listener stopped")
+		}
+	}()
+	return nil
+}
+
+// Stop the debug listener
+func (initializer *DebugInitializerImpl) Stop() {
+	if initializer.server != nil {
+		initializer.server.Close()
+	}
+}
+
+func (initializer *DebugInitializerImpl) newHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.Handle("/debug/vars", expvar.Handler())
+	mux.HandleFunc("/debug/goroutines", initializer.goroutinesHandler)
+	mux.HandleFunc("/debug/buildinfo", initializer.buildInfoHandler)
+	mux.HandleFunc("/debug/config", initializer.configHandler)
+	return initializer.authenticate(mux)
+}
+
+// authenticate rejects every request which does not carry the expected token,
+// the endpoints are never served unauthenticated, even when no token is configured
+func (initializer *DebugInitializerImpl) authenticate(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requiredToken := initializer.Debug.AuthToken
+		if requiredToken == "" && initializer.AdminToken != nil {
+			requiredToken = initializer.AdminToken()
+		}
+		token := r.Header.Get(DebugTokenHeader)
+		if token == "" {
+			token = r.URL.Query().Get(debugTokenQueryParam)
+		}
+		if requiredToken == "" || subtle.ConstantTimeCompare([]byte(token), []byte(requiredToken)) != 1 {
+			http.Error(w, "no permission to access debug endpoints", http.StatusForbidden)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+func (initializer *DebugInitializerImpl) goroutinesHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	runtimepprof.Lookup("goroutine").WriteTo(w, 2)
+}
+
+func (initializer *DebugInitializerImpl) buildInfoHandler(w http.ResponseWriter, r *http.Request) {
+	hostName, _ := os.Hostname()
+	info := debugBuildInfo{
+		ServiceName: initializer.ServiceName,
+		HostName:    hostName,
+		GoVersion:   runtime.Version(),
+		GoOS:        runtime.GOOS,
+		GoArch:      runtime.GOARCH,
+		NumCPU:      runtime.NumCPU(),
+		GoMaxProcs:  runtime.GOMAXPROCS(0),
+		StartTime:   initializer.startTime,
+		Uptime:      time.Since(initializer.startTime).String(),
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(info)
+}
+
+// configHandler returns the effective config of the process, that is the static config with its secrets
+// redacted along with the value and default of every dynamic config key the services have registered
+func (initializer *DebugInitializerImpl) configHandler(w http.ResponseWriter, r *http.Request) {
+	var effectiveConfig debugEffectiveConfig
+	if initializer.Config != nil {
+		static, err := initializer.Config.Redacted()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		effectiveConfig.Static = static
+	}
+	if initializer.DynamicConfig != nil {
+		effectiveConfig.Dynamic = dynamicconfig.EffectiveValues(initializer.DynamicConfig)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(effectiveConfig)
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type DebugSuite struct {
+	*require.Assertions
+	suite.Suite
+}
+
+func TestDebugSuite(t *testing.T) {
+	suite.Run(t, new(DebugSuite))
+}
+
+func (s *DebugSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *DebugSuite) TestAuthentication() {
+	cfg := &Debug{}
+	initializer := cfg.NewInitializer("test-service", nil, nil, dynamicconfig.GetStringPropertyFn("admin-token"), bark.NewNopLogger())
+	handler := initializer.newHandler()
+
+	s.Equal(http.StatusForbidden, s.serve(handler, "/debug/buildinfo", ""))
+	s.Equal(http.StatusForbidden, s.serve(handler, "/debug/buildinfo", "wrong-token"))
+	s.Equal(http.StatusOK, s.serve(handler, "/debug/buildinfo", "admin-token"))
+	s.Equal(http.StatusOK, s.serve(handler, "/debug/goroutines?token=admin-token", ""))
+
+	cfg.AuthToken = "debug-token"
+	s.Equal(http.StatusForbidden, s.serve(handler, "/debug/vars", "admin-token"))
+	s.Equal(http.StatusOK, s.serve(handler, "/debug/vars", "debug-token"))
+}
+
+func (s *DebugSuite) TestNoTokenConfigured() {
+	cfg := &Debug{}
+	initializer := cfg.NewInitializer("test-service", nil, nil, dynamicconfig.GetStringPropertyFn(""), bark.NewNopLogger())
+	handler := initializer.newHandler()
+
+	s.Equal(http.StatusForbidden, s.serve(handler, "/debug/pprof/", ""))
+}
+
+func (s *DebugSuite) TestEffectiveConfig() {
+	config := &Config{
+		Persistence: Persistence{
+			DefaultStore: "default",
+			DataStores: map[string]DataStore{
+				"default": {Cassandra: &Cassandra{Hosts: "127.0.0.1", Keyspace: "cadence", Password: "secret"}},
+			},
+		},
+	}
+	cfg := &Debug{AuthToken: "debug-token"}
+	initializer := cfg.NewInitializer("test-service", config, dynamicconfig.NewNopClient(), nil, bark.NewNopLogger())
+
+	request := httptest.NewRequest(http.MethodGet, "/debug/config", nil)
+	request.Header.Set(DebugTokenHeader, "debug-token")
+	recorder := httptest.NewRecorder()
+	initializer.newHandler().ServeHTTP(recorder, request)
+	s.Equal(http.StatusOK, recorder.Code)
+	s.Contains(recorder.Body.String(), `"Keyspace":"cadence"`)
+	s.Contains(recorder.Body.String(), `"Password":"******"`)
+	s.NotContains(recorder.Body.String(), "secret")
+}
+
+func (s *DebugSuite) serve(handler http.Handler, path string, token string) int {
+	request := httptest.NewRequest(http.MethodGet, path, nil)
+	if token != "" {
+		request.Header.Set(DebugTokenHeader, token)
+	}
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	return recorder.Code
+}
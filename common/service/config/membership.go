@@ -0,0 +1,132 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/common/membership"
+	"go.uber.org/yarpc"
+)
+
+const (
+	// MembershipProviderRingpop builds the membership rings from ringpop gossip
+	MembershipProviderRingpop = "ringpop"
+	// MembershipProviderDNS builds the membership rings from DNS SRV records
+	MembershipProviderDNS = "dns"
+	// MembershipProviderKubernetes builds the membership rings from kubernetes endpoints
+	MembershipProviderKubernetes = "kubernetes"
+	// MembershipProviderInMemory builds the membership rings from the rpc config of
+	// services that all run in the current process
+	MembershipProviderInMemory = "inmemory"
+)
+
+type (
+	// MembershipFactory vends a bootstrapped membership monitor
+	MembershipFactory interface {
+		Create(dispatcher *yarpc.Dispatcher) (membership.Monitor, error)
+	}
+
+	// PeerProviderFactory creates membership monitors backed by a peer provider
+	PeerProviderFactory struct {
+		config      *Membership
+		provider    membership.PeerProvider
+		logger      bark.Logger
+		serviceName string
+	}
+)
+
+// NewMembershipFactory builds the membership factory for the configured provider,
+// ringpop config is only used if the provider is ringpop
+func NewMembershipFactory(cfg *Membership, rpConfig *Ringpop, logger bark.Logger, serviceName string) (MembershipFactory, error) {
+	var provider membership.PeerProvider
+	switch strings.ToLower(cfg.Provider) {
+	case "", MembershipProviderRingpop:
+		factory, err := rpConfig.NewFactory(logger, serviceName)
+		if err != nil {
+			return nil, err
+		}
+		return factory, nil
+	case MembershipProviderDNS:
+		if len(cfg.DNS) == 0 {
+			return nil, fmt.Errorf("membership config missing dns records")
+		}
+		provider = membership.NewDNSPeerProvider(cfg.DNS)
+	case MembershipProviderKubernetes:
+		if len(cfg.Kubernetes.Endpoints) == 0 {
+			return nil, fmt.Errorf("membership config missing kubernetes endpoints")
+		}
+		var err error
+		provider, err = membership.NewKubernetesPeerProvider(membership.KubernetesPeerProviderConfig{
+			Namespace: cfg.Kubernetes.Namespace,
+			Endpoints: cfg.Kubernetes.Endpoints,
+			PortName:  cfg.Kubernetes.PortName,
+		})
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown membership provider %v", cfg.Provider)
+	}
+	return &PeerProviderFactory{config: cfg, provider: provider, logger: logger, serviceName: serviceName}, nil
+}
+
+// NewInMemoryMembershipFactory builds the membership factory of a cluster whose services all
+// run in the current process. services is keyed by the short service name (e.g. frontend) and
+// every service is expected to be reachable at the address it binds its rpc channel to
+func NewInMemoryMembershipFactory(services map[string]Service, logger bark.Logger, serviceName string) MembershipFactory {
+	hosts := make(map[string][]string, len(services))
+	for name, svc := range services {
+		rpc := svc.RPC
+		addr := fmt.Sprintf("%v:%v", newRPCFactory(&rpc, name, logger).getListenIP(), rpc.Port)
+		hosts["cadence-"+name] = []string{addr}
+	}
+	return &PeerProviderFactory{
+		config:      &Membership{Provider: MembershipProviderInMemory},
+		provider:    membership.NewStaticPeerProvider(hosts),
+		logger:      logger,
+		serviceName: serviceName,
+	}
+}
+
+// Create is the implementation for MembershipMonitorFactory.Create
+func (factory *PeerProviderFactory) Create(dispatcher *yarpc.Dispatcher) (membership.Monitor, error) {
+	// use actual listen address (in case service is bound to :0 or 0.0.0.0:0)
+	ch, err := getTChannel(dispatcher)
+	if err != nil {
+		return nil, err
+	}
+
+	monitor := membership.NewPeerProviderMonitor(
+		factory.serviceName,
+		ch.PeerInfo().HostPort,
+		CadenceServices,
+		factory.provider,
+		factory.config.RefreshInterval,
+		factory.logger,
+	)
+	if err = monitor.Start(); err != nil {
+		return nil, err
+	}
+	return monitor, nil
+}
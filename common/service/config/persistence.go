@@ -20,13 +20,27 @@
 
 package config
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 const (
 	// StoreTypeSQL refers to sql based storage as persistence store
 	StoreTypeSQL = "sql"
 	// StoreTypeCassandra refers to cassandra as persistence store
 	StoreTypeCassandra = "cassandra"
+	// StoreTypeInMemory refers to process memory as persistence store
+	StoreTypeInMemory = "inmemory"
+)
+
+const (
+	// DefaultPersistenceReadTimeout is the default timeout for single record lookups
+	DefaultPersistenceReadTimeout = 10 * time.Second
+	// DefaultPersistenceWriteTimeout is the default timeout for creates, updates and deletes
+	DefaultPersistenceWriteTimeout = 10 * time.Second
+	// DefaultPersistenceListTimeout is the default timeout for list and scan queries
+	DefaultPersistenceListTimeout = 30 * time.Second
 )
 
 // SetMaxQPS sets the MaxQPS value for the given datastore
@@ -39,7 +53,9 @@ func (c *Persistence) SetMaxQPS(key string, qps int) {
 		ds.Cassandra.MaxQPS = qps
 		return
 	}
-	ds.SQL.MaxQPS = qps
+	if ds.SQL != nil {
+		ds.SQL.MaxQPS = qps
+	}
 }
 
 // DefaultStoreType returns the storeType for the default persistence store
@@ -47,22 +63,29 @@ func (c *Persistence) DefaultStoreType() string {
 	if c.DataStores[c.DefaultStore].SQL != nil {
 		return StoreTypeSQL
 	}
+	if c.DataStores[c.DefaultStore].InMemory != nil {
+		return StoreTypeInMemory
+	}
 	return StoreTypeCassandra
 }
 
 // Validate validates the persistence config
 func (c *Persistence) Validate() error {
+	if c.NumHistoryShards <= 0 {
+		return fmt.Errorf("persistence config: numHistoryShards must be positive, got %v", c.NumHistoryShards)
+	}
 	stores := []string{c.DefaultStore, c.VisibilityStore}
 	for _, st := range stores {
 		ds, ok := c.DataStores[st]
 		if !ok {
 			return fmt.Errorf("persistence config: missing config for datastore %v", st)
 		}
-		if ds.SQL == nil && ds.Cassandra == nil {
-			return fmt.Errorf("persistence config: datastore %v: must provide config for one of cassandra or sql stores", st)
+		numStores := ds.numStores()
+		if numStores == 0 {
+			return fmt.Errorf("persistence config: datastore %v: must provide config for one of cassandra, sql or inmemory stores", st)
 		}
-		if ds.SQL != nil && ds.Cassandra != nil {
-			return fmt.Errorf("persistence config: datastore %v: only one of SQL or cassandra can be specified", st)
+		if numStores > 1 {
+			return fmt.Errorf("persistence config: datastore %v: only one of SQL, cassandra or inmemory can be specified", st)
 		}
 		if ds.SQL != nil && ds.SQL.NumShards == 0 {
 			ds.SQL.NumShards = 1
@@ -70,3 +93,54 @@ func (c *Persistence) Validate() error {
 	}
 	return nil
 }
+
+// numStores returns the number of store configs set on the datastore
+func (ds DataStore) numStores() int {
+	result := 0
+	if ds.Cassandra != nil {
+		result++
+	}
+	if ds.SQL != nil {
+		result++
+	}
+	if ds.InMemory != nil {
+		result++
+	}
+	return result
+}
+
+// ReadTimeout returns the timeout for single record lookups
+func (t *PersistenceTimeouts) ReadTimeout() time.Duration {
+	if t.Read > 0 {
+		return t.Read
+	}
+	return DefaultPersistenceReadTimeout
+}
+
+// WriteTimeout returns the timeout for creates, updates and deletes
+func (t *PersistenceTimeouts) WriteTimeout() time.Duration {
+	if t.Write > 0 {
+		return t.Write
+	}
+	return DefaultPersistenceWriteTimeout
+}
+
+// ListTimeout returns the timeout for list and scan queries
+func (t *PersistenceTimeouts) ListTimeout() time.Duration {
+	if t.List > 0 {
+		return t.List
+	}
+	return DefaultPersistenceListTimeout
+}
+
+// MaxTimeout returns the largest of the read, write and list timeouts
+func (t *PersistenceTimeouts) MaxTimeout() time.Duration {
+	result := t.ReadTimeout()
+	if t.WriteTimeout() > result {
+		result = t.WriteTimeout()
+	}
+	if t.ListTimeout() > result {
+		result = t.ListTimeout()
+	}
+	return result
+}
@@ -192,6 +192,10 @@ func (factory *RingpopFactory) createRingpop(dispatcher *yarpc.Dispatcher) (*rin
 }
 
 func (factory *RingpopFactory) getChannel(dispatcher *yarpc.Dispatcher) (*tcg.Channel, error) {
+	return getTChannel(dispatcher)
+}
+
+func getTChannel(dispatcher *yarpc.Dispatcher) (*tcg.Channel, error) {
 	t := dispatcher.Inbounds()[0].Transports()[0].(*tchannel.ChannelTransport)
 	ty := reflect.ValueOf(t.Channel())
 	var ch *tcg.Channel
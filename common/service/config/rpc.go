@@ -25,7 +25,9 @@ import (
 	"net"
 
 	"github.com/uber-common/bark"
+	"github.com/uber/cadence/common"
 	"go.uber.org/yarpc"
+	"go.uber.org/yarpc/transport/http"
 	"go.uber.org/yarpc/transport/tchannel"
 )
 
@@ -61,9 +63,17 @@ func (d *RPCFactory) CreateDispatcher() *yarpc.Dispatcher {
 	}
 	d.logger.Infof("Created RPC dispatcher for '%v' and listening at '%v'",
 		d.serviceName, hostAddress)
+	inbounds := yarpc.Inbounds{d.ch.NewInbound()}
+	if d.config.HTTPPort > 0 {
+		// thrift over http for callers behind L7 load balancers or meshes which cannot pass tchannel
+		httpAddress := fmt.Sprintf("%v:%v", d.getListenIP(), d.config.HTTPPort)
+		inbounds = append(inbounds, http.NewTransport().NewInbound(httpAddress))
+		d.logger.Infof("Created HTTP inbound for '%v' and listening at '%v'", d.serviceName, httpAddress)
+	}
 	return yarpc.NewDispatcher(yarpc.Config{
-		Name:     d.serviceName,
-		Inbounds: yarpc.Inbounds{d.ch.NewInbound()},
+		Name:              d.serviceName,
+		Inbounds:          inbounds,
+		InboundMiddleware: common.NewRequestInfoInboundMiddleware(),
 	})
 }
 
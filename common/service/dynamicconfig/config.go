@@ -29,6 +29,16 @@ import (
 	"github.com/uber-common/bark"
 )
 
+// registeredDefaults holds the default value of every key a collection of the process has been asked for,
+// so the effective dynamic config can be dumped without listing the keys of every service up front
+var registeredDefaults = &sync.Map{}
+
+// EffectiveValue is the value of a dynamic config key resolved without any filter, along with its default
+type EffectiveValue struct {
+	Default string `json:"default"`
+	Value   string `json:"value"`
+}
+
 // NewCollection creates a new collection
 func NewCollection(client Client, logger bark.Logger) *Collection {
 	return &Collection{client, logger, &sync.Map{}}
@@ -62,6 +72,29 @@ func (c *Collection) logValue(key Key, value, defaultValue interface{}) {
 	}
 }
 
+func registerDefault(key Key, defaultValue interface{}) {
+	registeredDefaults.Store(key, defaultValue)
+}
+
+// EffectiveValues resolves with the given client every key registered by the collections of the process,
+// the value of keys which have overrides for specific filters is the one applied when no filter matches
+func EffectiveValues(client Client) map[string]EffectiveValue {
+	values := make(map[string]EffectiveValue)
+	registeredDefaults.Range(func(k, defaultValue interface{}) bool {
+		key := k.(Key)
+		value, err := client.GetValue(key, defaultValue)
+		if err != nil || value == nil {
+			value = defaultValue
+		}
+		values[key.String()] = EffectiveValue{
+			Default: fmt.Sprintf("%v", defaultValue),
+			Value:   fmt.Sprintf("%v", value),
+		}
+		return true
+	})
+	return values
+}
+
 // PropertyFn is a wrapper to get property from dynamic config
 type PropertyFn func() interface{}
 
@@ -92,6 +125,9 @@ type BoolPropertyFn func(opts ...FilterOption) bool
 // StringPropertyFn is a wrapper to get string property from dynamic config
 type StringPropertyFn func(opts ...FilterOption) string
 
+// MapPropertyFn is a wrapper to get map property from dynamic config
+type MapPropertyFn func(opts ...FilterOption) map[string]interface{}
+
 // StringPropertyFnWithDomainFilter is a wrapper to get string property from dynamic config
 type StringPropertyFnWithDomainFilter func(domain string) string
 
@@ -103,6 +139,7 @@ type BoolPropertyFnWithTaskListInfoFilters func(domain string, taskList string,
 
 // GetProperty gets a interface property and returns defaultValue if property is not found
 func (c *Collection) GetProperty(key Key, defaultValue interface{}) PropertyFn {
+	registerDefault(key, defaultValue)
 	return func() interface{} {
 		val, err := c.client.GetValue(key, defaultValue)
 		if err != nil {
@@ -124,6 +161,7 @@ func getFilterMap(opts ...FilterOption) map[Filter]interface{} {
 
 // GetIntProperty gets property and asserts that it's an integer
 func (c *Collection) GetIntProperty(key Key, defaultValue int) IntPropertyFn {
+	registerDefault(key, defaultValue)
 	return func(opts ...FilterOption) int {
 		val, err := c.client.GetIntValue(key, getFilterMap(opts...), defaultValue)
 		if err != nil {
@@ -136,6 +174,7 @@ func (c *Collection) GetIntProperty(key Key, defaultValue int) IntPropertyFn {
 
 // GetIntPropertyFilteredByDomain gets property with domain filter and asserts that it's an integer
 func (c *Collection) GetIntPropertyFilteredByDomain(key Key, defaultValue int) IntPropertyFnWithDomainFilter {
+	registerDefault(key, defaultValue)
 	return func(domain string) int {
 		val, err := c.client.GetIntValue(key, getFilterMap(DomainFilter(domain)), defaultValue)
 		if err != nil {
@@ -148,6 +187,7 @@ func (c *Collection) GetIntPropertyFilteredByDomain(key Key, defaultValue int) I
 
 // GetIntPropertyFilteredByTaskListInfo gets property with taskListInfo as filters and asserts that it's an integer
 func (c *Collection) GetIntPropertyFilteredByTaskListInfo(key Key, defaultValue int) IntPropertyFnWithTaskListInfoFilters {
+	registerDefault(key, defaultValue)
 	return func(domain string, taskList string, taskType int) int {
 		val, err := c.client.GetIntValue(
 			key,
@@ -164,6 +204,7 @@ func (c *Collection) GetIntPropertyFilteredByTaskListInfo(key Key, defaultValue
 
 // GetFloat64Property gets property and asserts that it's a float64
 func (c *Collection) GetFloat64Property(key Key, defaultValue float64) FloatPropertyFn {
+	registerDefault(key, defaultValue)
 	return func(opts ...FilterOption) float64 {
 		val, err := c.client.GetFloatValue(key, getFilterMap(opts...), defaultValue)
 		if err != nil {
@@ -176,6 +217,7 @@ func (c *Collection) GetFloat64Property(key Key, defaultValue float64) FloatProp
 
 // GetDurationProperty gets property and asserts that it's a duration
 func (c *Collection) GetDurationProperty(key Key, defaultValue time.Duration) DurationPropertyFn {
+	registerDefault(key, defaultValue)
 	return func(opts ...FilterOption) time.Duration {
 		val, err := c.client.GetDurationValue(key, getFilterMap(opts...), defaultValue)
 		if err != nil {
@@ -188,6 +230,7 @@ func (c *Collection) GetDurationProperty(key Key, defaultValue time.Duration) Du
 
 // GetDurationPropertyFilteredByDomain gets property with domain filter and asserts that it's a duration
 func (c *Collection) GetDurationPropertyFilteredByDomain(key Key, defaultValue time.Duration) DurationPropertyFnWithDomainFilter {
+	registerDefault(key, defaultValue)
 	return func(domain string) time.Duration {
 		val, err := c.client.GetDurationValue(key, getFilterMap(DomainFilter(domain)), defaultValue)
 		if err != nil {
@@ -200,6 +243,7 @@ func (c *Collection) GetDurationPropertyFilteredByDomain(key Key, defaultValue t
 
 // GetDurationPropertyFilteredByTaskListInfo gets property with taskListInfo as filters and asserts that it's a duration
 func (c *Collection) GetDurationPropertyFilteredByTaskListInfo(key Key, defaultValue time.Duration) DurationPropertyFnWithTaskListInfoFilters {
+	registerDefault(key, defaultValue)
 	return func(domain string, taskList string, taskType int) time.Duration {
 		val, err := c.client.GetDurationValue(
 			key,
@@ -216,6 +260,7 @@ func (c *Collection) GetDurationPropertyFilteredByTaskListInfo(key Key, defaultV
 
 // GetBoolProperty gets property and asserts that it's an bool
 func (c *Collection) GetBoolProperty(key Key, defaultValue bool) BoolPropertyFn {
+	registerDefault(key, defaultValue)
 	return func(opts ...FilterOption) bool {
 		val, err := c.client.GetBoolValue(key, getFilterMap(opts...), defaultValue)
 		if err != nil {
@@ -228,6 +273,7 @@ func (c *Collection) GetBoolProperty(key Key, defaultValue bool) BoolPropertyFn
 
 // GetStringProperty gets property and asserts that it's an string
 func (c *Collection) GetStringProperty(key Key, defaultValue string) StringPropertyFn {
+	registerDefault(key, defaultValue)
 	return func(opts ...FilterOption) string {
 		val, err := c.client.GetStringValue(key, getFilterMap(opts...), defaultValue)
 		if err != nil {
@@ -238,8 +284,22 @@ func (c *Collection) GetStringProperty(key Key, defaultValue string) StringPrope
 	}
 }
 
+// GetMapProperty gets property and asserts that it's a map
+func (c *Collection) GetMapProperty(key Key, defaultValue map[string]interface{}) MapPropertyFn {
+	registerDefault(key, defaultValue)
+	return func(opts ...FilterOption) map[string]interface{} {
+		val, err := c.client.GetMapValue(key, getFilterMap(opts...), defaultValue)
+		if err != nil {
+			c.logNoValue(key, err)
+		}
+		c.logValue(key, val, defaultValue)
+		return val
+	}
+}
+
 // GetStringPropertyFnWithDomainFilter gets property with domain filter and asserts that its domain
 func (c *Collection) GetStringPropertyFnWithDomainFilter(key Key, defaultValue string) StringPropertyFnWithDomainFilter {
+	registerDefault(key, defaultValue)
 	return func(domain string) string {
 		val, err := c.client.GetStringValue(key, getFilterMap(DomainFilter(domain)), defaultValue)
 		if err != nil {
@@ -252,6 +312,7 @@ func (c *Collection) GetStringPropertyFnWithDomainFilter(key Key, defaultValue s
 
 // GetBoolPropertyFnWithDomainFilter gets property with domain filter and asserts that its domain
 func (c *Collection) GetBoolPropertyFnWithDomainFilter(key Key, defaultValue bool) BoolPropertyFnWithDomainFilter {
+	registerDefault(key, defaultValue)
 	return func(domain string) bool {
 		val, err := c.client.GetBoolValue(key, getFilterMap(DomainFilter(domain)), defaultValue)
 		if err != nil {
@@ -264,6 +325,7 @@ func (c *Collection) GetBoolPropertyFnWithDomainFilter(key Key, defaultValue boo
 
 // GetBoolPropertyFilteredByTaskListInfo gets property with taskListInfo as filters and asserts that it's an bool
 func (c *Collection) GetBoolPropertyFilteredByTaskListInfo(key Key, defaultValue bool) BoolPropertyFnWithTaskListInfoFilters {
+	registerDefault(key, defaultValue)
 	return func(domain string, taskList string, taskType int) bool {
 		val, err := c.client.GetBoolValue(
 			key,
@@ -59,6 +59,11 @@ func GetDurationPropertyFn(value time.Duration) func(opts ...FilterOption) time.
 	return func(...FilterOption) time.Duration { return value }
 }
 
+// GetDurationPropertyFnFilteredByDomain returns value as DurationPropertyFnWithDomainFilter
+func GetDurationPropertyFnFilteredByDomain(value time.Duration) func(domain string) time.Duration {
+	return func(domain string) time.Duration { return value }
+}
+
 // GetDurationPropertyFnFilteredByTaskListInfo returns value as DurationPropertyFnWithTaskListInfoFilters
 func GetDurationPropertyFnFilteredByTaskListInfo(value time.Duration) func(domain string, taskList string, taskType int) time.Duration {
 	return func(domain string, taskList string, taskType int) time.Duration { return value }
@@ -68,3 +73,8 @@ func GetDurationPropertyFnFilteredByTaskListInfo(value time.Duration) func(domai
 func GetStringPropertyFn(value string) func(opts ...FilterOption) string {
 	return func(...FilterOption) string { return value }
 }
+
+// GetMapPropertyFn returns value as MapPropertyFn
+func GetMapPropertyFn(value map[string]interface{}) func(opts ...FilterOption) map[string]interface{} {
+	return func(...FilterOption) map[string]interface{} { return value }
+}
@@ -230,6 +230,28 @@ func (s *configSuite) TestGetDurationPropertyFilteredByTaskListInfo() {
 	s.Equal(time.Minute, value(domain, taskList, taskType))
 }
 
+func (s *configSuite) TestGetMapProperty() {
+	key := testGetMapPropertyKey
+	defaultVal := map[string]interface{}{"testKey": 123}
+	value := s.cln.GetMapProperty(key, defaultVal)
+	s.Equal(defaultVal, value())
+	newVal := map[string]interface{}{"testKey": "321"}
+	s.client.SetValue(key, newVal)
+	s.Equal(newVal, value())
+}
+
+func (s *configSuite) TestEffectiveValues() {
+	client := newInMemoryClient()
+	cln := NewCollection(client, bark.NewLoggerFromLogrus(logrus.New()))
+	cln.GetDurationProperty(testGetDurationPropertyKey, time.Second)
+	cln.GetIntPropertyFilteredByDomain(testGetIntPropertyFilteredByDomainKey, 10)
+	client.SetValue(testGetDurationPropertyKey, time.Minute)
+
+	values := EffectiveValues(client)
+	s.Equal(EffectiveValue{Default: "1s", Value: "1m0s"}, values[testGetDurationPropertyKey.String()])
+	s.Equal(EffectiveValue{Default: "10", Value: "10"}, values[testGetIntPropertyFilteredByDomainKey.String()])
+}
+
 func TestDynamicConfigKeyIsMapped(t *testing.T) {
 	for i := unknownKey; i < lastKeyForTest; i++ {
 		key, ok := keys[i]
@@ -46,6 +46,7 @@ var keys = map[Key]string{
 	testGetIntPropertyFilteredByTaskListInfoKey:      "testGetIntPropertyFilteredByTaskListInfoKey",
 	testGetDurationPropertyFilteredByTaskListInfoKey: "testGetDurationPropertyFilteredByTaskListInfoKey",
 	testGetBoolPropertyFilteredByTaskListInfoKey:     "testGetBoolPropertyFilteredByTaskListInfoKey",
+	testGetMapPropertyKey:                            "testGetMapPropertyKey",
 
 	// system settings
 	EnableGlobalDomain:                  "system.enableGlobalDomain",
@@ -57,6 +58,24 @@ var keys = map[Key]string{
 	ArchivalStatus:                      "system.archivalStatus",
 	EnableReadFromArchival:              "system.enableReadFromArchival",
 	EnableDomainNotActiveAutoForwarding: "system.enableDomainNotActiveAutoForwarding",
+	EnableDomainChangeNotification:      "system.enableDomainChangeNotification",
+	EnableConcurrencyGroups:             "system.enableConcurrencyGroups",
+	VisibilityTombstoneRetention:        "system.visibilityTombstoneRetention",
+
+	PersistenceAdaptiveThrottlingErrorRatio:         "system.persistenceAdaptiveThrottlingErrorRatio",
+	PersistenceAdaptiveThrottlingBackoffFactor:      "system.persistenceAdaptiveThrottlingBackoffFactor",
+	PersistenceAdaptiveThrottlingRecoveryFactor:     "system.persistenceAdaptiveThrottlingRecoveryFactor",
+	PersistenceAdaptiveThrottlingMinQPSRatio:        "system.persistenceAdaptiveThrottlingMinQPSRatio",
+	PersistenceAdaptiveThrottlingEvaluationInterval: "system.persistenceAdaptiveThrottlingEvaluationInterval",
+	PersistenceMaxDomainMetricsTags:                 "system.persistenceMaxDomainMetricsTags",
+	PersistenceFaultInjectionTimeoutRate:            "system.persistenceFaultInjectionTimeoutRate",
+	PersistenceFaultInjectionThrottleRate:           "system.persistenceFaultInjectionThrottleRate",
+	PersistenceFaultInjectionConditionFailedRate:    "system.persistenceFaultInjectionConditionFailedRate",
+	PersistenceFaultInjectionPartialFailureRate:     "system.persistenceFaultInjectionPartialFailureRate",
+	HistoryClientTimeout:                            "system.historyClientTimeout",
+	HistoryClientHedgeDelay:                         "system.historyClientHedgeDelay",
+	MatchingClientTimeout:                           "system.matchingClientTimeout",
+	MatchingClientHedgeDelay:                        "system.matchingClientHedgeDelay",
 
 	// size limit
 	BlobSizeLimitError:     "limit.blobSize.error",
@@ -65,45 +84,78 @@ var keys = map[Key]string{
 	HistorySizeLimitWarn:   "limit.historySize.warn",
 	HistoryCountLimitError: "limit.historyCount.error",
 	HistoryCountLimitWarn:  "limit.historyCount.warn",
-	MaxIDLengthLimit:       "limit.maxIDLength",
+
+	LocalActivityMarkersSizeLimit: "limit.localActivityMarkersSize",
+	MaxIDLengthLimit:              "limit.maxIDLength",
 
 	// frontend settings
-	FrontendPersistenceMaxQPS:      "frontend.persistenceMaxQPS",
-	FrontendVisibilityMaxPageSize:  "frontend.visibilityMaxPageSize",
-	FrontendVisibilityListMaxQPS:   "frontend.visibilityListMaxQPS",
-	FrontendESVisibilityListMaxQPS: "frontend.esVisibilityListMaxQPS",
-	FrontendESIndexMaxResultWindow: "frontend.esIndexMaxResultWindow",
-	FrontendHistoryMaxPageSize:     "frontend.historyMaxPageSize",
-	FrontendRPS:                    "frontend.rps",
-	FrontendHistoryMgrNumConns:     "frontend.historyMgrNumConns",
-	MaxDecisionStartToCloseTimeout: "frontend.maxDecisionStartToCloseTimeout",
-	DisableListVisibilityByFilter:  "frontend.disableListVisibilityByFilter",
-	FrontendThrottledLogRPS:        "frontend.throttledLogRPS",
+	FrontendPersistenceMaxQPS:                   "frontend.persistenceMaxQPS",
+	FrontendEnablePersistenceAdaptiveThrottling: "frontend.enablePersistenceAdaptiveThrottling",
+	FrontendEnablePersistenceDomainMetrics:      "frontend.enablePersistenceDomainMetrics",
+	FrontendVisibilityMaxPageSize:               "frontend.visibilityMaxPageSize",
+	FrontendVisibilityListMaxQPS:                "frontend.visibilityListMaxQPS",
+	FrontendESVisibilityListMaxQPS:              "frontend.esVisibilityListMaxQPS",
+	FrontendESIndexMaxResultWindow:              "frontend.esIndexMaxResultWindow",
+	FrontendESVisibilityMaxQueryTimeRange:       "frontend.esVisibilityMaxQueryTimeRange",
+	FrontendESVisibilityMaxQueryCost:            "frontend.esVisibilityMaxQueryCost",
+	FrontendHistoryMaxPageSize:                  "frontend.historyMaxPageSize",
+	FrontendMaxDescribeBatchSize:                "frontend.maxDescribeBatchSize",
+	FrontendRPS:                                 "frontend.rps",
+	FrontendHistoryMgrNumConns:                  "frontend.historyMgrNumConns",
+	MaxDecisionStartToCloseTimeout:              "frontend.maxDecisionStartToCloseTimeout",
+	DisableListVisibilityByFilter:               "frontend.disableListVisibilityByFilter",
+	FrontendThrottledLogRPS:                     "frontend.throttledLogRPS",
+	FrontendOpenWorkflowCountCacheTTL:           "frontend.openWorkflowCountCacheTTL",
+	FrontendDomainNotFoundCacheTTL:              "frontend.domainNotFoundCacheTTL",
+	FrontendStackTraceQueryCacheTTL:             "frontend.stackTraceQueryCacheTTL",
+	FrontendDomainNamePattern:                   "frontend.domainNamePattern",
+	FrontendDomainMinRetentionDays:              "frontend.domainMinRetentionDays",
+	FrontendDomainMaxRetentionDays:              "frontend.domainMaxRetentionDays",
+	FrontendDomainOwnerEmailPattern:             "frontend.domainOwnerEmailPattern",
+	FrontendDomainAllowedClusters:               "frontend.domainAllowedClusters",
+	FrontendLatencySLOTarget:                    "frontend.latencySLOTarget",
+	EnableClientVersionCheck:                    "frontend.enableClientVersionCheck",
+	FrontendMinSupportedClientFeatureVersion:    "frontend.minSupportedClientFeatureVersion",
+	ValidSearchAttributes:                       "frontend.validSearchAttributes",
+	SearchAttributesCacheTTL:                    "frontend.searchAttributesCacheTTL",
 
 	// matching settings
-	MatchingRPS:                             "matching.rps",
-	MatchingPersistenceMaxQPS:               "matching.persistenceMaxQPS",
-	MatchingMinTaskThrottlingBurstSize:      "matching.minTaskThrottlingBurstSize",
-	MatchingGetTasksBatchSize:               "matching.getTasksBatchSize",
-	MatchingLongPollExpirationInterval:      "matching.longPollExpirationInterval",
-	MatchingEnableSyncMatch:                 "matching.enableSyncMatch",
-	MatchingUpdateAckInterval:               "matching.updateAckInterval",
-	MatchingIdleTasklistCheckInterval:       "matching.idleTasklistCheckInterval",
-	MaxTasklistIdleTime:                     "matching.maxTasklistIdleTime",
-	MatchingOutstandingTaskAppendsThreshold: "matching.outstandingTaskAppendsThreshold",
-	MatchingMaxTaskBatchSize:                "matching.maxTaskBatchSize",
-	MatchingMaxTaskDeleteBatchSize:          "matching.maxTaskDeleteBatchSize",
-	MatchingThrottledLogRPS:                 "matching.throttledLogRPS",
+	MatchingRPS:               "matching.rps",
+	MatchingPersistenceMaxQPS: "matching.persistenceMaxQPS",
+	MatchingEnablePersistenceAdaptiveThrottling: "matching.enablePersistenceAdaptiveThrottling",
+	MatchingEnablePersistenceDomainMetrics:      "matching.enablePersistenceDomainMetrics",
+	MatchingMinTaskThrottlingBurstSize:          "matching.minTaskThrottlingBurstSize",
+	MatchingGetTasksBatchSize:                   "matching.getTasksBatchSize",
+	MatchingLongPollExpirationInterval:          "matching.longPollExpirationInterval",
+	MatchingEnableSyncMatch:                     "matching.enableSyncMatch",
+	MatchingUpdateAckInterval:                   "matching.updateAckInterval",
+	MatchingIdleTasklistCheckInterval:           "matching.idleTasklistCheckInterval",
+	MaxTasklistIdleTime:                         "matching.maxTasklistIdleTime",
+	MatchingOutstandingTaskAppendsThreshold:     "matching.outstandingTaskAppendsThreshold",
+	MatchingMaxTaskBatchSize:                    "matching.maxTaskBatchSize",
+	MatchingMaxTaskDeleteBatchSize:              "matching.maxTaskDeleteBatchSize",
+	MatchingThrottledLogRPS:                     "matching.throttledLogRPS",
 
 	// history settings
-	HistoryRPS:                                            "history.rps",
-	HistoryPersistenceMaxQPS:                              "history.persistenceMaxQPS",
+	HistoryRPS:               "history.rps",
+	HistoryPersistenceMaxQPS: "history.persistenceMaxQPS",
+	HistoryEnablePersistenceAdaptiveThrottling:            "history.enablePersistenceAdaptiveThrottling",
+	HistoryEnablePersistenceDomainMetrics:                 "history.enablePersistenceDomainMetrics",
+	HistoryEnablePersistenceFaultInjection:                "history.enablePersistenceFaultInjection",
 	HistoryVisibilityOpenMaxQPS:                           "history.historyVisibilityOpenMaxQPS",
 	HistoryVisibilityClosedMaxQPS:                         "history.historyVisibilityClosedMaxQPS",
+	HistoryVisibilityEnableAsyncClosedRecords:             "history.visibilityEnableAsyncClosedRecords",
+	HistoryVisibilityClosedRecordsBatchSize:               "history.visibilityClosedRecordsBatchSize",
+	HistoryVisibilityClosedRecordsBatchInterval:           "history.visibilityClosedRecordsBatchInterval",
+	HistoryVisibilitySpillBufferDir:                       "history.visibilitySpillBufferDir",
+	HistoryVisibilitySpillBufferMaxMessages:               "history.visibilitySpillBufferMaxMessages",
+	HistoryVisibilitySpillBufferRetryInterval:             "history.visibilitySpillBufferRetryInterval",
 	HistoryLongPollExpirationInterval:                     "history.longPollExpirationInterval",
 	HistoryCacheInitialSize:                               "history.cacheInitialSize",
 	HistoryCacheMaxSize:                                   "history.cacheMaxSize",
 	HistoryCacheTTL:                                       "history.cacheTTL",
+	HistoryHostLevelCacheMaxSize:                          "history.hostLevelCacheMaxSize",
+	HistoryHostLevelCacheMaxBytes:                         "history.hostLevelCacheMaxBytes",
 	EventsCacheInitialSize:                                "history.eventsCacheInitialSize",
 	EventsCacheMaxSize:                                    "history.eventsCacheMaxSize",
 	EventsCacheTTL:                                        "history.eventsCacheTTL",
@@ -122,12 +174,16 @@ var keys = map[Key]string{
 	TimerProcessorCompleteTimerInterval:                   "history.timerProcessorCompleteTimerInterval",
 	TimerProcessorFailoverMaxPollRPS:                      "history.timerProcessorFailoverMaxPollRPS",
 	TimerProcessorMaxPollRPS:                              "history.timerProcessorMaxPollRPS",
+	TimerProcessorMaxPollHostRPS:                          "history.timerProcessorMaxPollHostRPS",
 	TimerProcessorMaxPollInterval:                         "history.timerProcessorMaxPollInterval",
 	TimerProcessorMaxPollIntervalJitterCoefficient:        "history.timerProcessorMaxPollIntervalJitterCoefficient",
 	TimerProcessorMaxTimeShift:                            "history.timerProcessorMaxTimeShift",
+	TimerProcessorLookAheadBatchSize:                      "history.timerProcessorLookAheadBatchSize",
+	TimerProcessorWheelTickInterval:                       "history.timerProcessorWheelTickInterval",
 	TransferTaskBatchSize:                                 "history.transferTaskBatchSize",
 	TransferProcessorFailoverMaxPollRPS:                   "history.transferProcessorFailoverMaxPollRPS",
 	TransferProcessorMaxPollRPS:                           "history.transferProcessorMaxPollRPS",
+	TransferProcessorMaxPollHostRPS:                       "history.transferProcessorMaxPollHostRPS",
 	TransferTaskWorkerCount:                               "history.transferTaskWorkerCount",
 	TransferTaskMaxRetryCount:                             "history.transferTaskMaxRetryCount",
 	TransferProcessorStartDelay:                           "history.transferProcessorStartDelay",
@@ -144,6 +200,7 @@ var keys = map[Key]string{
 	ReplicatorTaskMaxRetryCount:                           "history.replicatorTaskMaxRetryCount",
 	ReplicatorProcessorStartDelay:                         "history.replicatorProcessorStartDelay",
 	ReplicatorProcessorMaxPollRPS:                         "history.replicatorProcessorMaxPollRPS",
+	ReplicatorProcessorMaxPollHostRPS:                     "history.replicatorProcessorMaxPollHostRPS",
 	ReplicatorProcessorUpdateShardTaskCount:               "history.replicatorProcessorUpdateShardTaskCount",
 	ReplicatorProcessorMaxPollInterval:                    "history.replicatorProcessorMaxPollInterval",
 	ReplicatorProcessorMaxPollIntervalJitterCoefficient:   "history.replicatorProcessorMaxPollIntervalJitterCoefficient",
@@ -152,36 +209,92 @@ var keys = map[Key]string{
 	ExecutionMgrNumConns:                                  "history.executionMgrNumConns",
 	HistoryMgrNumConns:                                    "history.historyMgrNumConns",
 	MaximumBufferedEventsBatch:                            "history.maximumBufferedEventsBatch",
+	MaximumBufferedEventsSize:                             "history.maximumBufferedEventsSize",
+	BufferedEventsLimitPolicy:                             "history.bufferedEventsLimitPolicy",
+	MutableStateChecksumVerifyMode:                        "history.mutableStateChecksumVerifyMode",
 	MaximumSignalsPerExecution:                            "history.maximumSignalsPerExecution",
+	MaximumSignalRequestIDsPerExecution:                   "history.maximumSignalRequestIDsPerExecution",
+	MaximumPendingChildWorkflowsPerExecution:              "history.maximumPendingChildWorkflowsPerExecution",
+	MaximumPendingActivitiesPerExecution:                  "history.maximumPendingActivitiesPerExecution",
+	ActivityHeartbeatMinInterval:                          "history.activityHeartbeatMinInterval",
+	DefaultActivityHeartbeatTimeout:                       "history.defaultActivityHeartbeatTimeout",
+	StuckDecisionTimeoutThreshold:                         "history.stuckDecisionTimeoutThreshold",
 	ShardUpdateMinInterval:                                "history.shardUpdateMinInterval",
 	ShardSyncMinInterval:                                  "history.shardSyncMinInterval",
 	DefaultEventEncoding:                                  "history.defaultEventEncoding",
 	EnableAdminProtection:                                 "history.enableAdminProtection",
 	AdminOperationToken:                                   "history.adminOperationToken",
 	EnableEventsV2:                                        "history.enableEventsV2",
+	EnableNDC:                                             "history.enableNDC",
 	NumArchiveSystemWorkflows:                             "history.numArchiveSystemWorkflows",
 	EmitShardDiffLog:                                      "history.emitShardDiffLog",
 	HistoryThrottledLogRPS:                                "history.throttledLogRPS",
 
 	WorkerPersistenceMaxQPS:                         "worker.persistenceMaxQPS",
+	WorkerEnablePersistenceAdaptiveThrottling:       "worker.enablePersistenceAdaptiveThrottling",
+	WorkerEnablePersistenceDomainMetrics:            "worker.enablePersistenceDomainMetrics",
 	WorkerReplicatorMetaTaskConcurrency:             "worker.replicatorMetaTaskConcurrency",
 	WorkerReplicatorTaskConcurrency:                 "worker.replicatorTaskConcurrency",
 	WorkerReplicatorMessageConcurrency:              "worker.replicatorMessageConcurrency",
 	WorkerReplicatorHistoryBufferRetryCount:         "worker.replicatorHistoryBufferRetryCount",
 	WorkerReplicationTaskMaxRetry:                   "worker.replicationTaskMaxRetry",
+	WorkerReplicatorEnabled:                         "worker.replicatorEnabled",
+	WorkerReplicatorLagReportInterval:               "worker.replicatorLagReportInterval",
+	WorkerIndexerEnabled:                            "worker.indexerEnabled",
 	WorkerIndexerConcurrency:                        "worker.indexerConcurrency",
 	WorkerESProcessorNumOfWorkers:                   "worker.ESProcessorNumOfWorkers",
 	WorkerESProcessorBulkActions:                    "worker.ESProcessorBulkActions",
 	WorkerESProcessorBulkSize:                       "worker.ESProcessorBulkSize",
 	WorkerESProcessorFlushInterval:                  "worker.ESProcessorFlushInterval",
+	WorkerESProcessorMinBulkActions:                 "worker.ESProcessorMinBulkActions",
+	WorkerESProcessorMinFlushInterval:               "worker.ESProcessorMinFlushInterval",
+	WorkerESProcessorTargetBulkLatency:              "worker.ESProcessorTargetBulkLatency",
+	WorkerESProcessorMaxOutstandingRequests:         "worker.ESProcessorMaxOutstandingRequests",
+	WorkerESProcessorBackpressureThreshold:          "worker.ESProcessorBackpressureThreshold",
+	WorkerESProcessorRetryInitialInterval:           "worker.ESProcessorRetryInitialInterval",
+	WorkerESProcessorRetryMaxInterval:               "worker.ESProcessorRetryMaxInterval",
 	EnableArchivalCompression:                       "worker.EnableArchivalCompression",
 	WorkerHistoryPageSize:                           "worker.WorkerHistoryPageSize",
 	WorkerTargetArchivalBlobSize:                    "worker.WorkerTargetArchivalBlobSize",
 	WorkerArchiverConcurrency:                       "worker.ArchiverConcurrency",
 	WorkerArchivalsPerIteration:                     "worker.ArchivalsPerIteration",
 	WorkerDeterministicConstructionCheckProbability: "worker.DeterministicConstructionCheckProbability",
+	WorkerArchiverUploadRetryInitialInterval:        "worker.ArchiverUploadRetryInitialInterval",
+	WorkerArchiverUploadRetryMaxInterval:            "worker.ArchiverUploadRetryMaxInterval",
+	WorkerArchiverUploadRetryBackoffCoefficient:     "worker.ArchiverUploadRetryBackoffCoefficient",
+	WorkerArchiverUploadRetryExpiration:             "worker.ArchiverUploadRetryExpiration",
+	WorkerArchiverBlobstoreRetryInitialInterval:     "worker.ArchiverBlobstoreRetryInitialInterval",
+	WorkerArchiverBlobstoreRetryMaxInterval:         "worker.ArchiverBlobstoreRetryMaxInterval",
+	WorkerEnableArchivalDLQ:                         "worker.EnableArchivalDLQ",
+	WorkerArchivalDLQMaxSize:                        "worker.ArchivalDLQMaxSize",
 	WorkerThrottledLogRPS:                           "worker.throttledLogRPS",
 	ScannerPersistenceMaxQPS:                        "worker.scannerPersistenceMaxQPS",
+	ESRetentionScannerEnabled:                       "worker.esRetentionScannerEnabled",
+	ESRetentionScannerBatchSize:                     "worker.esRetentionScannerBatchSize",
+	ESRetentionScannerRPS:                           "worker.esRetentionScannerRPS",
+	ESBackfillPageSize:                              "worker.esBackfillPageSize",
+	ESBackfillRPS:                                   "worker.esBackfillRPS",
+	VisibilityReconcilerEnabled:                     "worker.visibilityReconcilerEnabled",
+	VisibilityReconcilerSampleSize:                  "worker.visibilityReconcilerSampleSize",
+	VisibilityReconcilerMinAge:                      "worker.visibilityReconcilerMinAge",
+	VisibilityReconcilerRepairEnabled:               "worker.visibilityReconcilerRepairEnabled",
+	OpenWorkflowCounterEnabled:                      "worker.openWorkflowCounterEnabled",
+	OpenWorkflowCounterInterval:                     "worker.openWorkflowCounterInterval",
+	ArchivalMigratorEnabled:                         "worker.archivalMigratorEnabled",
+	ArchivalMigratorInterval:                        "worker.archivalMigratorInterval",
+	WorkflowMigratorEnabled:                         "worker.workflowMigratorEnabled",
+	WorkflowMigratorInterval:                        "worker.workflowMigratorInterval",
+	WorkflowMigrationSourceCluster:                  "worker.workflowMigrationSourceCluster",
+	ConcurrencyGroupWorkerEnabled:                   "worker.concurrencyGroupWorkerEnabled",
+	CanaryDomain:                                    "worker.canaryDomain",
+	CanaryEnabled:                                   "worker.canaryEnabled",
+	CanaryInterval:                                  "worker.canaryInterval",
+	BenchEnabled:                                    "worker.benchEnabled",
+	BenchStartRPS:                                   "worker.benchStartRPS",
+	BenchSignalRPS:                                  "worker.benchSignalRPS",
+	BenchActivityCount:                              "worker.benchActivityCount",
+	BenchActivityMinLatency:                         "worker.benchActivityMinLatency",
+	BenchActivityMaxLatency:                         "worker.benchActivityMaxLatency",
 }
 
 const (
@@ -198,6 +311,7 @@ const (
 	testGetIntPropertyFilteredByTaskListInfoKey
 	testGetDurationPropertyFilteredByTaskListInfoKey
 	testGetBoolPropertyFilteredByTaskListInfoKey
+	testGetMapPropertyKey
 
 	// EnableGlobalDomain is key for enable global domain
 	EnableGlobalDomain
@@ -222,6 +336,52 @@ const (
 	// EnableDomainNotActiveAutoForwarding whether enabling DC auto forwarding to active cluster
 	// for signal / start / signal with start API if domain is not active
 	EnableDomainNotActiveAutoForwarding
+	// EnableDomainChangeNotification whether domain changes are broadcast through kafka
+	// so domain caches are refreshed right away instead of on the next refresh interval
+	EnableDomainChangeNotification
+	// EnableConcurrencyGroups whether workflows of a domain can be started in a concurrency group
+	EnableConcurrencyGroups
+	// VisibilityTombstoneRetention is how long the visibility record left behind by a workflow execution
+	// deleted due to retention is kept, filtered by domain name, 0 deletes the visibility record right away
+	VisibilityTombstoneRetention
+
+	// PersistenceAdaptiveThrottlingErrorRatio is the ratio of ServiceBusy / Timeout errors from DB
+	// over which the persistence rate limit is reduced
+	PersistenceAdaptiveThrottlingErrorRatio
+	// PersistenceAdaptiveThrottlingBackoffFactor is the factor applied to the persistence rate limit on backoff
+	PersistenceAdaptiveThrottlingBackoffFactor
+	// PersistenceAdaptiveThrottlingRecoveryFactor is the fraction of max qps restored per healthy interval
+	PersistenceAdaptiveThrottlingRecoveryFactor
+	// PersistenceAdaptiveThrottlingMinQPSRatio is the lowest fraction of max qps the persistence rate limit can drop to
+	PersistenceAdaptiveThrottlingMinQPSRatio
+	// PersistenceAdaptiveThrottlingEvaluationInterval is the interval at which the persistence rate limit is adjusted
+	PersistenceAdaptiveThrottlingEvaluationInterval
+	// PersistenceMaxDomainMetricsTags is the max number of distinct domains the persistence metrics are tagged with,
+	// the operations of any other domain are tagged as other
+	PersistenceMaxDomainMetricsTags
+	// PersistenceFaultInjectionTimeoutRate is the probability of failing a persistence call with a timeout,
+	// filtered by persistence operation. It only applies where fault injection is enabled
+	PersistenceFaultInjectionTimeoutRate
+	// PersistenceFaultInjectionThrottleRate is the probability of failing a persistence call as throttled,
+	// filtered by persistence operation
+	PersistenceFaultInjectionThrottleRate
+	// PersistenceFaultInjectionConditionFailedRate is the probability of failing a persistence write with
+	// a condition failure, filtered by persistence operation
+	PersistenceFaultInjectionConditionFailedRate
+	// PersistenceFaultInjectionPartialFailureRate is the probability of reporting a timeout for a persistence
+	// write which was applied, filtered by persistence operation
+	PersistenceFaultInjectionPartialFailureRate
+	// HistoryClientTimeout is the timeout of the calls made by the history client, filtered by API name
+	HistoryClientTimeout
+	// HistoryClientHedgeDelay is the delay after which the history client sends a second attempt of an
+	// idempotent read which has not returned yet, filtered by API name, 0 disables hedging
+	HistoryClientHedgeDelay
+	// MatchingClientTimeout is the timeout of the calls, except long polls, made by the matching client,
+	// filtered by API name
+	MatchingClientTimeout
+	// MatchingClientHedgeDelay is the delay after which the matching client sends a second attempt of an
+	// idempotent read which has not returned yet, filtered by API name, 0 disables hedging
+	MatchingClientHedgeDelay
 
 	// BlobSizeLimitError is the per event blob size limit
 	BlobSizeLimitError
@@ -235,6 +395,8 @@ const (
 	HistoryCountLimitError
 	// HistoryCountLimitWarn is the per workflow execution history event count limit for warning
 	HistoryCountLimitWarn
+	// LocalActivityMarkersSizeLimit is the limit of the total size of local activity markers recorded by a single decision
+	LocalActivityMarkersSizeLimit
 
 	// MaxIDLengthLimit is the length limit for various IDs, including: Domain, TaskList, WorkflowID, ActivityID, TimerID,
 	// WorkflowType, ActivityType, SignalName, MarkerName, ErrorReason/FailureReason/CancelCause, Identity, RequestID
@@ -244,6 +406,10 @@ const (
 
 	// FrontendPersistenceMaxQPS is the max qps frontend host can query DB
 	FrontendPersistenceMaxQPS
+	// FrontendEnablePersistenceAdaptiveThrottling whether frontend adjusts its persistence qps based on DB errors
+	FrontendEnablePersistenceAdaptiveThrottling
+	// FrontendEnablePersistenceDomainMetrics whether frontend tags persistence metrics with the domain of the operation
+	FrontendEnablePersistenceDomainMetrics
 	// FrontendVisibilityMaxPageSize is default max size for ListWorkflowExecutions in one page
 	FrontendVisibilityMaxPageSize
 	// FrontendVisibilityListMaxQPS is max qps frontend can list open/close workflows
@@ -252,8 +418,16 @@ const (
 	FrontendESVisibilityListMaxQPS
 	// FrontendESIndexMaxResultWindow is ElasticSearch index setting max_result_window
 	FrontendESIndexMaxResultWindow
+	// FrontendESVisibilityMaxQueryTimeRange is the widest time range of list and statistics queries served
+	// from ElasticSearch, 0 is unlimited
+	FrontendESVisibilityMaxQueryTimeRange
+	// FrontendESVisibilityMaxQueryCost is the max number of workflow executions matched by a list query served
+	// from ElasticSearch, estimated with a count before the first page, 0 is unlimited
+	FrontendESVisibilityMaxQueryCost
 	// FrontendHistoryMaxPageSize is default max size for GetWorkflowExecutionHistory in one page
 	FrontendHistoryMaxPageSize
+	// FrontendMaxDescribeBatchSize is the max number of workflow executions of a DescribeWorkflowExecutions call
+	FrontendMaxDescribeBatchSize
 	// FrontendRPS is workflow rate limit per second
 	FrontendRPS
 	// FrontendHistoryMgrNumConns is for persistence cluster.NumConns
@@ -262,6 +436,35 @@ const (
 	FrontendThrottledLogRPS
 	// MaxDecisionStartToCloseTimeout is max decision timeout in seconds
 	MaxDecisionStartToCloseTimeout
+	// FrontendOpenWorkflowCountCacheTTL is how long frontend answers CountOpenWorkflowExecutions from its cache
+	FrontendOpenWorkflowCountCacheTTL
+	// FrontendDomainNotFoundCacheTTL is how long frontend remembers that a domain does not exist, 0 disables it
+	FrontendDomainNotFoundCacheTTL
+	// FrontendStackTraceQueryCacheTTL is how long frontend answers stack trace queries of a workflow from its cache
+	// until the workflow completes another decision, 0 disables it
+	FrontendStackTraceQueryCacheTTL
+	// FrontendDomainNamePattern is the regular expression registered domain names must match, empty means no restriction
+	FrontendDomainNamePattern
+	// FrontendDomainMinRetentionDays is the min retention days of a domain, 0 means no restriction
+	FrontendDomainMinRetentionDays
+	// FrontendDomainMaxRetentionDays is the max retention days of a domain, 0 means no restriction
+	FrontendDomainMaxRetentionDays
+	// FrontendDomainOwnerEmailPattern is the regular expression domain owner emails must match, empty means no restriction
+	FrontendDomainOwnerEmailPattern
+	// FrontendDomainAllowedClusters is the comma separated list of clusters domains can be placed in, empty means no restriction
+	FrontendDomainAllowedClusters
+	// FrontendLatencySLOTarget is the latency target of frontend API calls, filtered by API name and domain, 0 disables tracking
+	FrontendLatencySLOTarget
+	// EnableClientVersionCheck enables rejecting calls from clients older than the minimum supported feature version
+	EnableClientVersionCheck
+	// FrontendMinSupportedClientFeatureVersion is the minimum supported feature version of clients, filtered by client impl,
+	// empty means the built-in minimum of the client
+	FrontendMinSupportedClientFeatureVersion
+	// ValidSearchAttributes is the map of search attribute keys which can be used in visibility queries to the
+	// int value of their shared.IndexedValueType
+	ValidSearchAttributes
+	// SearchAttributesCacheTTL is how long frontend caches the search attributes added through the admin API
+	SearchAttributesCacheTTL
 
 	// key for matching
 
@@ -269,6 +472,10 @@ const (
 	MatchingRPS
 	// MatchingPersistenceMaxQPS is the max qps matching host can query DB
 	MatchingPersistenceMaxQPS
+	// MatchingEnablePersistenceAdaptiveThrottling whether matching adjusts its persistence qps based on DB errors
+	MatchingEnablePersistenceAdaptiveThrottling
+	// MatchingEnablePersistenceDomainMetrics whether matching tags persistence metrics with the domain of the operation
+	MatchingEnablePersistenceDomainMetrics
 	// MatchingMinTaskThrottlingBurstSize is the minimum burst size for task list throttling
 	MatchingMinTaskThrottlingBurstSize
 	// MatchingGetTasksBatchSize is the maximum batch size to fetch from the task buffer
@@ -298,10 +505,29 @@ const (
 	HistoryRPS
 	// HistoryPersistenceMaxQPS is the max qps history host can query DB
 	HistoryPersistenceMaxQPS
+	// HistoryEnablePersistenceAdaptiveThrottling whether history adjusts its persistence qps based on DB errors
+	HistoryEnablePersistenceAdaptiveThrottling
+	// HistoryEnablePersistenceDomainMetrics whether history tags persistence metrics with the domain of the operation
+	HistoryEnablePersistenceDomainMetrics
+	// HistoryEnablePersistenceFaultInjection whether history persistence calls fail at the configured
+	// fault injection rates, filtered by persistence operation. This is meant for chaos testing only
+	HistoryEnablePersistenceFaultInjection
 	// HistoryVisibilityOpenMaxQPS is max qps one history host can write visibility open_executions
 	HistoryVisibilityOpenMaxQPS
 	// HistoryVisibilityClosedMaxQPS is max qps one history host can write visibility closed_executions
 	HistoryVisibilityClosedMaxQPS
+	// HistoryVisibilityEnableAsyncClosedRecords buffers the closed_executions writes of a history host and writes them in batches
+	HistoryVisibilityEnableAsyncClosedRecords
+	// HistoryVisibilityClosedRecordsBatchSize is the max number of buffered closed_executions records written together
+	HistoryVisibilityClosedRecordsBatchSize
+	// HistoryVisibilityClosedRecordsBatchInterval is the max time a closed_executions record is buffered before it's written
+	HistoryVisibilityClosedRecordsBatchInterval
+	// HistoryVisibilitySpillBufferDir is the directory visibility messages are spilled to when kafka is down, empty disables spilling
+	HistoryVisibilitySpillBufferDir
+	// HistoryVisibilitySpillBufferMaxMessages is the max number of visibility messages spilled by one history host
+	HistoryVisibilitySpillBufferMaxMessages
+	// HistoryVisibilitySpillBufferRetryInterval is the interval to retry delivering spilled visibility messages to kafka
+	HistoryVisibilitySpillBufferRetryInterval
 	// HistoryLongPollExpirationInterval is the long poll expiration interval in the history service
 	HistoryLongPollExpirationInterval
 	// HistoryCacheInitialSize is initial size of history cache
@@ -310,6 +536,10 @@ const (
 	HistoryCacheMaxSize
 	// HistoryCacheTTL is TTL of history cache
 	HistoryCacheTTL
+	// HistoryHostLevelCacheMaxSize is max number of workflow executions in the history cache shared by all shards of a host
+	HistoryHostLevelCacheMaxSize
+	// HistoryHostLevelCacheMaxBytes is max estimated bytes of mutable state in the history cache shared by all shards of a host
+	HistoryHostLevelCacheMaxBytes
 	// EventsCacheInitialSize is initial size of events cache
 	EventsCacheInitialSize
 	// EventsCacheMaxSize is max size of events cache
@@ -346,18 +576,26 @@ const (
 	TimerProcessorFailoverMaxPollRPS
 	// TimerProcessorMaxPollRPS is max poll rate per second for timer processor
 	TimerProcessorMaxPollRPS
+	// TimerProcessorMaxPollHostRPS is max poll rate per second for all timer processors on a host, 0 means no limit
+	TimerProcessorMaxPollHostRPS
 	// TimerProcessorMaxPollInterval is max poll interval for timer processor
 	TimerProcessorMaxPollInterval
 	// TimerProcessorMaxPollIntervalJitterCoefficient is the max poll interval jitter coefficient
 	TimerProcessorMaxPollIntervalJitterCoefficient
 	// TimerProcessorMaxTimeShift is the max shift timer processor can have
 	TimerProcessorMaxTimeShift
+	// TimerProcessorLookAheadBatchSize is the max number of upcoming timers the timer processor reads ahead
+	TimerProcessorLookAheadBatchSize
+	// TimerProcessorWheelTickInterval is the granularity of the timer processor's timer wheel
+	TimerProcessorWheelTickInterval
 	// TransferTaskBatchSize is batch size for transferQueueProcessor
 	TransferTaskBatchSize
 	// TransferProcessorFailoverMaxPollRPS is max poll rate per second for transferQueueProcessor
 	TransferProcessorFailoverMaxPollRPS
 	// TransferProcessorMaxPollRPS is max poll rate per second for transferQueueProcessor
 	TransferProcessorMaxPollRPS
+	// TransferProcessorMaxPollHostRPS is max poll rate per second for all transferQueueProcessor on a host, 0 means no limit
+	TransferProcessorMaxPollHostRPS
 	// TransferTaskWorkerCount is number of worker for transferQueueProcessor
 	TransferTaskWorkerCount
 	// TransferTaskMaxRetryCount is max times of retry for transferQueueProcessor
@@ -390,6 +628,8 @@ const (
 	ReplicatorProcessorStartDelay
 	// ReplicatorProcessorMaxPollRPS is max poll rate per second for ReplicatorProcessor
 	ReplicatorProcessorMaxPollRPS
+	// ReplicatorProcessorMaxPollHostRPS is max poll rate per second for all ReplicatorProcessor on a host, 0 means no limit
+	ReplicatorProcessorMaxPollHostRPS
 	// ReplicatorProcessorUpdateShardTaskCount is update shard count for ReplicatorProcessor
 	ReplicatorProcessorUpdateShardTaskCount
 	// ReplicatorProcessorMaxPollInterval is max poll interval for ReplicatorProcessor
@@ -406,8 +646,27 @@ const (
 	HistoryMgrNumConns
 	// MaximumBufferedEventsBatch is max number of buffer event in mutable state
 	MaximumBufferedEventsBatch
+	// MaximumBufferedEventsSize is max size in bytes of buffer events in mutable state
+	MaximumBufferedEventsSize
+	// BufferedEventsLimitPolicy is how the buffered events limits are enforced, one of forceNewDecision or failDecision
+	BufferedEventsLimitPolicy
+	// MutableStateChecksumVerifyMode is how a checksum mismatch of the loaded mutable state is handled, one of disabled, log or fail
+	MutableStateChecksumVerifyMode
 	// MaximumSignalsPerExecution is max number of signals supported by single execution
 	MaximumSignalsPerExecution
+	// MaximumSignalRequestIDsPerExecution is max number of signal request ids kept for deduplication by single execution
+	MaximumSignalRequestIDsPerExecution
+	// MaximumPendingChildWorkflowsPerExecution is max number of pending child workflows of single execution
+	MaximumPendingChildWorkflowsPerExecution
+	// MaximumPendingActivitiesPerExecution is max number of pending activities of single execution
+	MaximumPendingActivitiesPerExecution
+	// ActivityHeartbeatMinInterval is the min interval between persisted activity heartbeats,
+	// more frequent heartbeats are coalesced in memory
+	ActivityHeartbeatMinInterval
+	// DefaultActivityHeartbeatTimeout is the heartbeat timeout of activities scheduled without one
+	DefaultActivityHeartbeatTimeout
+	// StuckDecisionTimeoutThreshold is the number of consecutive decision timeouts after which an execution is reported as stuck
+	StuckDecisionTimeoutThreshold
 	// ShardUpdateMinInterval is the minimal time interval which the shard info can be updated
 	ShardUpdateMinInterval
 	// ShardSyncMinInterval is the minimal time interval which the shard info should be sync to remote
@@ -424,6 +683,8 @@ const (
 
 	// EnableEventsV2 is whether to use eventsV2
 	EnableEventsV2
+	// EnableNDC is whether to track version histories and apply replicated events across diverged branches
+	EnableNDC
 	// HistoryThrottledLogRPS is the rate limit on number of log messages emitted per second for throttled logger
 	HistoryThrottledLogRPS
 
@@ -431,6 +692,10 @@ const (
 
 	// WorkerPersistenceMaxQPS is the max qps worker host can query DB
 	WorkerPersistenceMaxQPS
+	// WorkerEnablePersistenceAdaptiveThrottling whether worker adjusts its persistence qps based on DB errors
+	WorkerEnablePersistenceAdaptiveThrottling
+	// WorkerEnablePersistenceDomainMetrics whether worker tags persistence metrics with the domain of the operation
+	WorkerEnablePersistenceDomainMetrics
 	// WorkerReplicatorMetaTaskConcurrency is the number of coroutine handling metadata related tasks
 	WorkerReplicatorMetaTaskConcurrency
 	// WorkerReplicatorTaskConcurrency is the number of coroutine handling non metadata related tasks
@@ -441,6 +706,12 @@ const (
 	WorkerReplicatorHistoryBufferRetryCount
 	// WorkerReplicationTaskMaxRetry is the max retry for any task
 	WorkerReplicationTaskMaxRetry
+	// WorkerReplicatorEnabled indicates whether replication tasks are processed, processing is paused when disabled
+	WorkerReplicatorEnabled
+	// WorkerReplicatorLagReportInterval is the interval the lag of the replicator consumers is sampled and reported at
+	WorkerReplicatorLagReportInterval
+	// WorkerIndexerEnabled indicates whether visibility messages are indexed, indexing is paused when disabled
+	WorkerIndexerEnabled
 	// WorkerIndexerConcurrency is the max concurrent messages to be processed at any given time
 	WorkerIndexerConcurrency
 	// WorkerESProcessorNumOfWorkers is num of workers for esProcessor
@@ -451,6 +722,20 @@ const (
 	WorkerESProcessorBulkSize
 	// WorkerESProcessorFlushInterval is flush interval for esProcessor
 	WorkerESProcessorFlushInterval
+	// WorkerESProcessorMinBulkActions is min number of requests in bulk esProcessor shrinks bulks to when ES is throttling
+	WorkerESProcessorMinBulkActions
+	// WorkerESProcessorMinFlushInterval is min flush interval esProcessor shortens flush interval to when ES is healthy
+	WorkerESProcessorMinFlushInterval
+	// WorkerESProcessorTargetBulkLatency is the bulk latency above which esProcessor shrinks bulks
+	WorkerESProcessorTargetBulkLatency
+	// WorkerESProcessorMaxOutstandingRequests is max number of requests not yet committed to ES before esProcessor stops accepting new ones
+	WorkerESProcessorMaxOutstandingRequests
+	// WorkerESProcessorBackpressureThreshold is number of consecutive throttled bulks after which esProcessor stops accepting new requests
+	WorkerESProcessorBackpressureThreshold
+	// WorkerESProcessorRetryInitialInterval is the initial interval between retries of failed bulks
+	WorkerESProcessorRetryInitialInterval
+	// WorkerESProcessorRetryMaxInterval is the max interval between retries of failed bulks
+	WorkerESProcessorRetryMaxInterval
 	// EnableArchivalCompression indicates whether blobs are compressed before they are archived
 	EnableArchivalCompression
 	// WorkerHistoryPageSize indicates the page size of history fetched from persistence for archival
@@ -463,10 +748,79 @@ const (
 	WorkerArchivalsPerIteration
 	// WorkerDeterministicConstructionCheckProbability controls the probability of running a deterministic construction check for any given archival
 	WorkerDeterministicConstructionCheckProbability
+	// WorkerArchiverUploadRetryInitialInterval is the initial retry interval of the activity uploading a history to blobstore
+	WorkerArchiverUploadRetryInitialInterval
+	// WorkerArchiverUploadRetryMaxInterval is the maximum retry interval of the activity uploading a history to blobstore
+	WorkerArchiverUploadRetryMaxInterval
+	// WorkerArchiverUploadRetryBackoffCoefficient is the backoff coefficient of the retries of the upload activity
+	WorkerArchiverUploadRetryBackoffCoefficient
+	// WorkerArchiverUploadRetryExpiration is how long the upload activity is retried before the request is sent to the archival DLQ
+	WorkerArchiverUploadRetryExpiration
+	// WorkerArchiverBlobstoreRetryInitialInterval is the initial backoff between retries of failed blobstore calls within an upload attempt
+	WorkerArchiverBlobstoreRetryInitialInterval
+	// WorkerArchiverBlobstoreRetryMaxInterval is the maximum backoff between retries of failed blobstore calls within an upload attempt
+	WorkerArchiverBlobstoreRetryMaxInterval
+	// WorkerEnableArchivalDLQ indicates whether histories that failed to be archived are kept in the archival DLQ of their domain
+	// instead of being deleted without archiving
+	WorkerEnableArchivalDLQ
+	// WorkerArchivalDLQMaxSize is the maximum number of archival requests kept in the archival DLQ of a domain
+	WorkerArchivalDLQMaxSize
 	// WorkerThrottledLogRPS is the rate limit on number of log messages emitted per second for throttled logger
 	WorkerThrottledLogRPS
 	// ScannerPersistenceMaxQPS is the maximum rate of persistence calls from worker.Scanner
 	ScannerPersistenceMaxQPS
+	// ESRetentionScannerEnabled indicates if documents older than the domain retention are deleted from ElasticSearch
+	ESRetentionScannerEnabled
+	// ESRetentionScannerBatchSize is the maximum number of documents deleted by a single delete by query request
+	ESRetentionScannerBatchSize
+	// ESRetentionScannerRPS is the maximum rate of delete by query requests sent to ElasticSearch
+	ESRetentionScannerRPS
+	// ESBackfillPageSize is the number of closed executions re-indexed into ElasticSearch by a single bulk request of the backfill
+	ESBackfillPageSize
+	// ESBackfillRPS is the maximum rate of pages read from the visibility store by the ElasticSearch backfill
+	ESBackfillRPS
+	// VisibilityReconcilerEnabled indicates if visibility records are periodically verified against the execution store
+	VisibilityReconcilerEnabled
+	// VisibilityReconcilerSampleSize is the number of open and of closed visibility records verified per domain and run
+	VisibilityReconcilerSampleSize
+	// VisibilityReconcilerMinAge is the minimum age of the executions verified by the visibility reconciler
+	VisibilityReconcilerMinAge
+	// VisibilityReconcilerRepairEnabled indicates if the visibility reconciler closes open records of closed executions
+	VisibilityReconcilerRepairEnabled
+	// OpenWorkflowCounterEnabled indicates if worker periodically emits the number of open workflows of each domain
+	OpenWorkflowCounterEnabled
+	// OpenWorkflowCounterInterval is the interval between two runs of the open workflow counter
+	OpenWorkflowCounterInterval
+	// ArchivalMigratorEnabled indicates if worker copies archived histories of domains migrating to a new archival bucket
+	ArchivalMigratorEnabled
+	// ArchivalMigratorInterval is the interval between two runs of the archival migrator
+	ArchivalMigratorInterval
+	// WorkflowMigratorEnabled indicates if worker copies workflow executions of domains which are migrated from a remote cluster
+	WorkflowMigratorEnabled
+	// WorkflowMigratorInterval is the interval between two runs of the workflow migrator
+	WorkflowMigratorInterval
+	// WorkflowMigrationSourceCluster is the remote cluster from which executions of a domain are migrated, empty means no migration
+	WorkflowMigrationSourceCluster
+	// ConcurrencyGroupWorkerEnabled indicates if worker hosts the system workflows dispatching the runs of concurrency groups
+	ConcurrencyGroupWorkerEnabled
+	// CanaryDomain is the domain the canary and bench workflows run in
+	CanaryDomain
+	// CanaryEnabled indicates if worker periodically runs the canary workflow suite against the frontend
+	CanaryEnabled
+	// CanaryInterval is the interval between two runs of the canary workflow suite
+	CanaryInterval
+	// BenchEnabled indicates if worker generates load against the frontend with bench workflows
+	BenchEnabled
+	// BenchStartRPS is the rate at which bench workflows are started
+	BenchStartRPS
+	// BenchSignalRPS is the rate at which signals are sent to running bench workflows
+	BenchSignalRPS
+	// BenchActivityCount is the number of activities executed by each bench workflow
+	BenchActivityCount
+	// BenchActivityMinLatency is the minimum time a bench activity takes to complete
+	BenchActivityMinLatency
+	// BenchActivityMaxLatency is the maximum time a bench activity takes to complete
+	BenchActivityMaxLatency
 
 	// lastKeyForTest must be the last one in this const group for testing purpose
 	lastKeyForTest
@@ -476,7 +830,7 @@ const (
 type Filter int
 
 func (f Filter) String() string {
-	if f <= unknownFilter || f > TaskListName {
+	if f <= unknownFilter || f >= lastFilterTypeForTest {
 		return filters[unknownFilter]
 	}
 	return filters[f]
@@ -487,6 +841,9 @@ var filters = []string{
 	"domainName",
 	"taskListName",
 	"taskType",
+	"persistenceOperation",
+	"apiName",
+	"clientImpl",
 }
 
 const (
@@ -497,6 +854,12 @@ const (
 	TaskListName
 	// TaskType is the task type (0:Decision, 1:Activity)
 	TaskType
+	// PersistenceOperation is the name of a persistence API, e.g. UpdateWorkflowExecution
+	PersistenceOperation
+	// APIName is the name of a service API, e.g. StartWorkflowExecution
+	APIName
+	// ClientImpl is the client implementation sending a request, e.g. uber-go
+	ClientImpl
 
 	// lastFilterTypeForTest must be the last one in this const group for testing purpose
 	lastFilterTypeForTest
@@ -525,3 +888,24 @@ func TaskTypeFilter(taskType int) FilterOption {
 		filterMap[TaskType] = taskType
 	}
 }
+
+// PersistenceOperationFilter filters by persistence operation
+func PersistenceOperationFilter(operation string) FilterOption {
+	return func(filterMap map[Filter]interface{}) {
+		filterMap[PersistenceOperation] = operation
+	}
+}
+
+// APINameFilter filters by API name
+func APINameFilter(name string) FilterOption {
+	return func(filterMap map[Filter]interface{}) {
+		filterMap[APIName] = name
+	}
+}
+
+// ClientImplFilter filters by client implementation
+func ClientImplFilter(clientImpl string) FilterOption {
+	return func(filterMap map[Filter]interface{}) {
+		filterMap[ClientImpl] = clientImpl
+	}
+}
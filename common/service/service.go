@@ -39,6 +39,8 @@ import (
 	"github.com/uber/cadence/common/membership"
 	"github.com/uber/cadence/common/messaging"
 	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/payload"
+	persistencefactory "github.com/uber/cadence/common/persistence/persistence-factory"
 	"github.com/uber/cadence/common/service/config"
 	"github.com/uber/cadence/common/service/dynamicconfig"
 	"go.uber.org/cadence/.gen/go/cadence/workflowserviceclient"
@@ -69,6 +71,7 @@ type (
 		MembershipFactory   MembershipMonitorFactory
 		RPCFactory          common.RPCFactory
 		PProfInitializer    common.PProfInitializer
+		DebugInitializer    common.DebugInitializer
 		PersistenceConfig   config.Persistence
 		ClusterMetadata     cluster.Metadata
 		ReplicatorConfig    config.Replicator
@@ -81,6 +84,15 @@ type (
 		BlobstoreClient     blobstore.Client
 		DCRedirectionPolicy config.DCRedirectionPolicy
 		PublicClient        workflowserviceclient.Interface
+		// PersistenceFactory, when set, is shared by the services running in
+		// the same process instead of each building its own from PersistenceConfig
+		PersistenceFactory persistencefactory.Factory
+		// SchemaValidator, when set, validates the workflow start and signal
+		// inputs received by frontend against their registered schemas
+		SchemaValidator payload.SchemaValidator
+		// ConsumerLagHook, when set, is notified by the worker service of the
+		// lag of the replicator consumers, to autoscale the worker service
+		ConsumerLagHook messaging.ConsumerLagHook
 	}
 
 	// MembershipMonitorFactory provides a bootstrapped membership monitor
@@ -100,6 +112,7 @@ type (
 		membershipMonitor     membership.Monitor
 		rpcFactory            common.RPCFactory
 		pprofInitializer      common.PProfInitializer
+		debugInitializer      common.DebugInitializer
 		clientBean            client.Bean
 		numberOfHistoryShards int
 		//Deprecated
@@ -135,6 +148,7 @@ func New(params *BootstrapParams) Service {
 		rpcFactory:            params.RPCFactory,
 		membershipFactory:     params.MembershipFactory,
 		pprofInitializer:      params.PProfInitializer,
+		debugInitializer:      params.DebugInitializer,
 		metricsScope:          params.MetricScope,
 		numberOfHistoryShards: params.PersistenceConfig.NumHistoryShards,
 		clusterMetadata:       params.ClusterMetadata,
@@ -184,6 +198,12 @@ func (h *serviceImpl) Start() {
 		h.logger.WithTags(tag.Error(err)).Fatal("Failed to start pprof")
 	}
 
+	if h.debugInitializer != nil {
+		if err := h.debugInitializer.Start(); err != nil {
+			h.logger.WithTags(tag.Error(err)).Fatal("Failed to start debug listener")
+		}
+	}
+
 	if err := h.dispatcher.Start(); err != nil {
 		h.logger.WithTags(tag.Error(err)).Fatal("Failed to start yarpc dispatcher")
 	}
@@ -205,7 +225,7 @@ func (h *serviceImpl) Start() {
 	h.hostInfo = hostInfo
 
 	h.clientBean, err = client.NewClientBean(
-		client.NewRPCClientFactory(h.rpcFactory, h.membershipMonitor, h.metricsClient, h.numberOfHistoryShards),
+		client.NewRPCClientFactory(h.rpcFactory, h.membershipMonitor, h.metricsClient, h.dynamicCollection, h.numberOfHistoryShards),
 		h.dispatcherProvider,
 		h.clusterMetadata,
 	)
@@ -233,6 +253,10 @@ func (h *serviceImpl) Stop() {
 		h.dispatcher.Stop()
 	}
 
+	if h.debugInitializer != nil {
+		h.debugInitializer.Stop()
+	}
+
 	h.runtimeMetricsReporter.Stop()
 }
 
@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/service/dynamicconfig"
 )
 
 type (
@@ -48,6 +49,15 @@ type (
 		Reset(rps int)
 	}
 
+	// KeyedTokenBucket is the interface for rate limiter which
+	// keeps a separate token bucket for every key
+	KeyedTokenBucket interface {
+		// TryConsume attempts to take count tokens from the
+		// bucket of the given key. Returns true on success, false
+		// otherwise along with the duration for the next refill
+		TryConsume(key string, count int) (bool, time.Duration)
+	}
+
 	// PriorityTokenBucket is the interface for rate limiter with priority
 	PriorityTokenBucket interface {
 		// GetToken attempts to take count tokens from the
@@ -76,6 +86,22 @@ type (
 		timeSource             clock.TimeSource
 	}
 
+	dynamicTokenBucketImpl struct {
+		sync.Mutex
+		rps        dynamicconfig.IntPropertyFn
+		currentRPS int
+		tb         TokenBucket
+	}
+
+	keyedTokenBucketImpl struct {
+		sync.RWMutex
+		rps        dynamicconfig.IntPropertyFn
+		maxKeys    int
+		timeSource clock.TimeSource
+		buckets    map[string]TokenBucket
+		overflow   TokenBucket
+	}
+
 	priorityTokenBucketImpl struct {
 		sync.Mutex
 		tokens         []int
@@ -130,6 +156,35 @@ func New(rps int, timeSource clock.TimeSource) TokenBucket {
 	return tb
 }
 
+// NewDynamicTokenBucket creates and returns a
+// new token bucket rate limiter whose rps limit
+// is re-evaluated from the given dynamic config
+// property on every call, so that the limit can be
+// tuned at runtime. Thread safe.
+func NewDynamicTokenBucket(rps dynamicconfig.IntPropertyFn, timeSource clock.TimeSource) TokenBucket {
+	currentRPS := rps()
+	return &dynamicTokenBucketImpl{
+		rps:        rps,
+		currentRPS: currentRPS,
+		tb:         New(currentRPS, timeSource),
+	}
+}
+
+// NewKeyedTokenBucket creates and returns a new rate
+// limiter which enforces the given dynamic rps limit
+// separately for every key. To bound the memory used,
+// at most maxKeys buckets are created, all other keys
+// share a single overflow bucket. Thread safe.
+func NewKeyedTokenBucket(rps dynamicconfig.IntPropertyFn, maxKeys int, timeSource clock.TimeSource) KeyedTokenBucket {
+	return &keyedTokenBucketImpl{
+		rps:        rps,
+		maxKeys:    maxKeys,
+		timeSource: timeSource,
+		buckets:    make(map[string]TokenBucket),
+		overflow:   NewDynamicTokenBucket(rps, timeSource),
+	}
+}
+
 // NewFactory creates an instance of factory used for creating TokenBucket instances
 func NewFactory() Factory {
 	return &tokenBucketFactoryImpl{}
@@ -192,6 +247,31 @@ func (tb *tokenBucketImpl) Reset(rps int) {
 	tb.nextOverflowRefillTime = 0
 }
 
+func (tb *dynamicTokenBucketImpl) TryConsume(count int) (bool, time.Duration) {
+	tb.refreshRPS()
+	return tb.tb.TryConsume(count)
+}
+
+func (tb *dynamicTokenBucketImpl) Consume(count int, timeout time.Duration) bool {
+	tb.refreshRPS()
+	return tb.tb.Consume(count, timeout)
+}
+
+// Reset overrides the rps limit until the dynamic config value changes
+func (tb *dynamicTokenBucketImpl) Reset(rps int) {
+	tb.tb.Reset(rps)
+}
+
+func (tb *dynamicTokenBucketImpl) refreshRPS() {
+	rps := tb.rps()
+	tb.Lock()
+	defer tb.Unlock()
+	if rps != tb.currentRPS {
+		tb.currentRPS = rps
+		tb.tb.Reset(rps)
+	}
+}
+
 func (tb *tokenBucketImpl) refill(now int64) {
 	tb.refillOverFlow(now)
 	if tb.isRefillDue(now) {
@@ -263,6 +343,31 @@ func NewFullPriorityTokenBucket(numOfPriority, rps int, timeSource clock.TimeSou
 	return tb
 }
 
+func (tb *keyedTokenBucketImpl) TryConsume(key string, count int) (bool, time.Duration) {
+	return tb.getBucket(key).TryConsume(count)
+}
+
+func (tb *keyedTokenBucketImpl) getBucket(key string) TokenBucket {
+	tb.RLock()
+	bucket, ok := tb.buckets[key]
+	tb.RUnlock()
+	if ok {
+		return bucket
+	}
+
+	tb.Lock()
+	defer tb.Unlock()
+	if bucket, ok := tb.buckets[key]; ok { // read again to ensure no duplicate create
+		return bucket
+	}
+	if len(tb.buckets) >= tb.maxKeys {
+		return tb.overflow
+	}
+	bucket = NewDynamicTokenBucket(tb.rps, tb.timeSource)
+	tb.buckets[key] = bucket
+	return bucket
+}
+
 func (tb *priorityTokenBucketImpl) GetToken(priority, count int) (bool, time.Duration) {
 	now := tb.timeSource.Now().UnixNano()
 	tb.Lock()
@@ -26,6 +26,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"github.com/uber/cadence/common/service/dynamicconfig"
 )
 
 type (
@@ -105,6 +106,50 @@ func (s *TokenBucketSuite) TestLowRpsEnforced() {
 	s.Equal(3, attempts, "Token bucket gave out tokens too quickly")
 }
 
+func (s *TokenBucketSuite) TestDynamicRpsEnforced() {
+	ts := &mockTimeSource{currTime: time.Now()}
+	rps := 10
+	tb := NewDynamicTokenBucket(func(...dynamicconfig.FilterOption) int { return rps }, ts)
+
+	ts.advance(time.Millisecond * 101)
+	ok, _ := tb.TryConsume(1)
+	s.True(ok, "Token bucket failed to give out token")
+	ok, _ = tb.TryConsume(1)
+	s.False(ok, "Token bucket failed to enforce limit")
+
+	rps = 50
+	ts.advance(time.Millisecond * 101)
+	ok, _ = tb.TryConsume(5)
+	s.True(ok, "Token bucket failed to pick up new limit")
+	ok, _ = tb.TryConsume(1)
+	s.False(ok, "Token bucket failed to enforce new limit")
+
+	rps = 0
+	ts.advance(time.Millisecond * 101)
+	ok, _ = tb.TryConsume(1)
+	s.False(ok, "Token bucket failed to pick up new limit")
+}
+
+func (s *TokenBucketSuite) TestKeyedRpsEnforced() {
+	ts := &mockTimeSource{currTime: time.Now()}
+	tb := NewKeyedTokenBucket(dynamicconfig.GetIntPropertyFn(10), 2, ts)
+
+	ts.advance(time.Millisecond * 101)
+	ok, _ := tb.TryConsume("key1", 1)
+	s.True(ok, "Token bucket failed to give out token")
+	ok, _ = tb.TryConsume("key1", 1)
+	s.False(ok, "Token bucket failed to enforce limit")
+
+	ok, _ = tb.TryConsume("key2", 1)
+	s.True(ok, "Token bucket throttled a key because of another key")
+
+	// keys over the max share a single bucket
+	ok, _ = tb.TryConsume("key3", 1)
+	s.True(ok, "Token bucket failed to give out token")
+	ok, _ = tb.TryConsume("key4", 1)
+	s.False(ok, "Token bucket failed to enforce limit on overflow keys")
+}
+
 func (s *TokenBucketSuite) TestPriorityRpsEnforced() {
 	ts := &mockTimeSource{currTime: time.Now()}
 	tb := NewPriorityTokenBucket(1, 99, ts) // behavior same to tokenBucketImpl
@@ -74,8 +74,12 @@ const (
 	FailureReasonHeartbeatExceedsLimit = "HEARTBEAT_EXCEEDS_LIMIT"
 	// FailureReasonDecisionBlobSizeExceedsLimit is the failureReason for decision blob exceeds size limit
 	FailureReasonDecisionBlobSizeExceedsLimit = "DECISION_BLOB_SIZE_EXCEEDS_LIMIT"
+	// FailureReasonLocalActivityMarkersExceedsLimit is the failureReason for local activity markers exceed size limit
+	FailureReasonLocalActivityMarkersExceedsLimit = "LOCAL_ACTIVITY_MARKERS_EXCEEDS_LIMIT"
 	// TerminateReasonSizeExceedsLimit is reason to terminate workflow when history size or count exceed limit
 	TerminateReasonSizeExceedsLimit = "HISTORY_EXCEEDS_LIMIT"
+	// TerminateReasonWorkflowIDReusePolicy is reason to terminate workflow when a new run is started with TerminateIfRunning policy
+	TerminateReasonWorkflowIDReusePolicy = "TERMINATED_BY_WORKFLOW_ID_REUSE_POLICY"
 )
 
 var (
@@ -22,6 +22,7 @@ package host
 
 import (
 	"bytes"
+	"context"
 	"encoding/binary"
 	"encoding/json"
 	"strconv"
@@ -167,7 +168,7 @@ func (s *integrationSuite) isMutableStateDeleted(domainID string, execution *wor
 	}
 
 	for i := 0; i < retryLimit; i++ {
-		_, err := s.testCluster.testBase.ExecutionManager.GetWorkflowExecution(request)
+		_, err := s.testCluster.testBase.ExecutionManager.GetWorkflowExecution(context.Background(), request)
 		if _, ok := err.(*workflow.EntityNotExistsError); ok {
 			return true
 		}
@@ -21,6 +21,7 @@
 package host
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
@@ -211,7 +212,7 @@ func (s *integrationCrossDCSuite) TestIntegrationRegister_GlobalDomainEnabled_Lo
 	s.setupTest(true, true)
 
 	domainName := "some random domain name"
-	_, err := s.MetadataManager.CreateDomain(&persistence.CreateDomainRequest{
+	_, err := s.MetadataManager.CreateDomain(context.Background(), &persistence.CreateDomainRequest{
 		Info: &persistence.DomainInfo{
 			ID:          uuid.New(),
 			Name:        domainName,
@@ -252,7 +253,7 @@ func (s *integrationCrossDCSuite) TestIntegrationRegister_GlobalDomainEnabled_Gl
 	s.setupTest(true, true)
 
 	domainName := "some random domain name"
-	_, err := s.MetadataManagerV2.CreateDomain(&persistence.CreateDomainRequest{
+	_, err := s.MetadataManagerV2.CreateDomain(context.Background(), &persistence.CreateDomainRequest{
 		Info: &persistence.DomainInfo{
 			ID:          uuid.New(),
 			Name:        domainName,
@@ -572,7 +573,7 @@ func (s *integrationCrossDCSuite) TestIntegrationUpdateGetDomain_GlobalDomainEna
 	currentClusterName := s.ClusterMetadata.GetCurrentClusterName()
 	// bypass to create a domain, since this cluster is not the master
 	// set all attr to default
-	_, err := s.MetadataManager.CreateDomain(&persistence.CreateDomainRequest{
+	_, err := s.MetadataManager.CreateDomain(context.Background(), &persistence.CreateDomainRequest{
 		Info: &persistence.DomainInfo{
 			ID:          uuid.New(),
 			Name:        domainName,
@@ -640,7 +641,7 @@ func (s *integrationCrossDCSuite) TestIntegrationUpdateGetDomain_GlobalDomainEna
 	domainName := "some random domain name"
 	// bypass to create a domain, since this cluster is not the master
 	// set all attr to default
-	_, err := s.MetadataManagerV2.CreateDomain(&persistence.CreateDomainRequest{
+	_, err := s.MetadataManagerV2.CreateDomain(context.Background(), &persistence.CreateDomainRequest{
 		Info: &persistence.DomainInfo{
 			ID:          uuid.New(),
 			Name:        domainName,
@@ -830,7 +831,7 @@ func (s *integrationCrossDCSuite) TestIntegrationUpdateGetDomain_GlobalDomainEna
 	domainName := "some random domain name"
 	// bypass to create a domain, since this cluster is not the master
 	// set all attr to default
-	_, err := s.MetadataManagerV2.CreateDomain(&persistence.CreateDomainRequest{
+	_, err := s.MetadataManagerV2.CreateDomain(context.Background(), &persistence.CreateDomainRequest{
 		Info: &persistence.DomainInfo{
 			ID:          uuid.New(),
 			Name:        domainName,
@@ -942,7 +943,7 @@ func (s *integrationCrossDCSuite) TestIntegrationUpdateGetDomain_GlobalDomainEna
 		}
 
 		// create a domain which is not currently active
-		s.MetadataManagerV2.CreateDomain(&persistence.CreateDomainRequest{
+		s.MetadataManagerV2.CreateDomain(context.Background(), &persistence.CreateDomainRequest{
 			Info: &persistence.DomainInfo{
 				ID:          uuid.New(),
 				Name:        domainName,
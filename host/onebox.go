@@ -90,6 +90,7 @@ type (
 		messagingClient     messaging.Client
 		metadataMgr         persistence.MetadataManager
 		metadataMgrV2       persistence.MetadataManager
+		clusterMetadataMgr  persistence.ClusterMetadataManager
 		shardMgr            persistence.ShardManager
 		historyMgr          persistence.HistoryManager
 		historyV2Mgr        persistence.HistoryV2Manager
@@ -127,6 +128,7 @@ type (
 		MessagingClient               messaging.Client
 		MetadataMgr                   persistence.MetadataManager
 		MetadataMgrV2                 persistence.MetadataManager
+		ClusterMetadataMgr            persistence.ClusterMetadataManager
 		ShardMgr                      persistence.ShardManager
 		HistoryMgr                    persistence.HistoryManager
 		HistoryV2Mgr                  persistence.HistoryV2Manager
@@ -162,6 +164,7 @@ func NewCadence(params *CadenceParams) Cadence {
 		messagingClient:     params.MessagingClient,
 		metadataMgr:         params.MetadataMgr,
 		metadataMgrV2:       params.MetadataMgrV2,
+		clusterMetadataMgr:  params.ClusterMetadataMgr,
 		visibilityMgr:       params.VisibilityMgr,
 		shardMgr:            params.ShardMgr,
 		historyMgr:          params.HistoryMgr,
@@ -352,25 +355,32 @@ func (c *cadenceImpl) startFrontend(hosts map[string][]string, startWG *sync.Wai
 
 	c.initLock.Lock()
 	c.frontEndService = service.New(params)
-	c.adminHandler = frontend.NewAdminHandler(
-		c.frontEndService, c.historyConfig.NumHistoryShards, c.metadataMgr, c.historyMgr, c.historyV2Mgr)
 	dc := dynamicconfig.NewCollection(params.DynamicConfig, c.barkLogger)
 	frontendConfig := frontend.NewConfig(dc, c.historyConfig.NumHistoryShards, c.esConfig.Enable)
 	visibilityMgr := c.visibilityMgr
+	var esClient elasticsearch.Client
+	var visibilityIndexName string
 	if c.esConfig.Enable {
 		frontendConfig.EnableReadVisibilityFromES = dc.GetBoolPropertyFnWithDomainFilter(dynamicconfig.EnableReadVisibilityFromES, true)
-		visibilityIndexName := c.esConfig.Indices[common.VisibilityAppName]
+		esClient = c.esClient
+		visibilityIndexName = c.esConfig.Indices[common.VisibilityAppName]
 		visibilityConfigForES := &config.VisibilityConfig{
-			VisibilityListMaxQPS:   frontendConfig.ESVisibilityListMaxQPS,
-			ESIndexMaxResultWindow: frontendConfig.ESIndexMaxResultWindow,
+			VisibilityListMaxQPS:          frontendConfig.ESVisibilityListMaxQPS,
+			ESIndexMaxResultWindow:        frontendConfig.ESIndexMaxResultWindow,
+			ESVisibilityMaxQueryTimeRange: frontendConfig.ESVisibilityMaxQueryTimeRange,
+			ESVisibilityMaxQueryCost:      frontendConfig.ESVisibilityMaxQueryCost,
 		}
 
 		visibilityFromES := espersistence.NewElasticSearchVisibilityManager(c.esClient, visibilityIndexName, visibilityConfigForES, c.barkLogger)
 		visibilityMgr = persistence.NewVisibilityManagerWrapper(visibilityMgr, visibilityFromES, frontendConfig.EnableReadVisibilityFromES)
 	}
+	searchAttributes := frontend.NewSearchAttributesCache(frontendConfig, c.clusterMetadataMgr)
+	c.adminHandler = frontend.NewAdminHandler(
+		c.frontEndService, c.historyConfig.NumHistoryShards, c.metadataMgr, c.historyMgr, c.historyV2Mgr, c.clusterMetadataMgr,
+		esClient, visibilityIndexName, searchAttributes)
 	c.frontendHandler = frontend.NewWorkflowHandler(
 		c.frontEndService, frontendConfig, c.metadataMgr, c.historyMgr, c.historyV2Mgr,
-		visibilityMgr, kafkaProducer, params.BlobstoreClient)
+		visibilityMgr, kafkaProducer, params.BlobstoreClient, nil, nil, searchAttributes)
 	err = c.frontendHandler.Start()
 	if err != nil {
 		c.barkLogger.WithField("error", err).Fatal("Failed to start frontend")
@@ -537,7 +547,8 @@ func (c *cadenceImpl) startWorkerReplicator(params *service.BootstrapParams, ser
 		workerConfig.ReplicationCfg,
 		c.messagingClient,
 		c.barkLogger,
-		service.GetMetricsClient())
+		service.GetMetricsClient(),
+		nil)
 	if err := c.replicator.Start(); err != nil {
 		c.replicator.Stop()
 		c.barkLogger.WithField("error", err).Fatal("Fail to start replicator when start worker")
@@ -629,8 +640,9 @@ func (c *rpcFactoryImpl) CreateDispatcher() *yarpc.Dispatcher {
 		c.logger.WithField("error", err).Fatal("Failed to create transport channel")
 	}
 	return yarpc.NewDispatcher(yarpc.Config{
-		Name:     c.serviceName,
-		Inbounds: yarpc.Inbounds{c.ch.NewInbound()},
+		Name:              c.serviceName,
+		Inbounds:          yarpc.Inbounds{c.ch.NewInbound()},
+		InboundMiddleware: common.NewRequestInfoInboundMiddleware(),
 		// For integration tests to generate client out of the same outbound.
 		Outbounds: yarpc.Outbounds{
 			c.serviceName: {Unary: c.ch.NewSingleOutbound(c.hostPort)},
@@ -121,6 +121,7 @@ func NewCluster(options *TestClusterConfig, barkLogger bark.Logger, logger log.L
 		MessagingClient:     getMessagingClient(options.MessagingClientConfig, barkLogger),
 		MetadataMgr:         testBase.MetadataProxy,
 		MetadataMgrV2:       testBase.MetadataManagerV2,
+		ClusterMetadataMgr:  testBase.ClusterMetadataMgr,
 		ShardMgr:            testBase.ShardMgr,
 		HistoryMgr:          testBase.HistoryMgr,
 		HistoryV2Mgr:        testBase.HistoryV2Mgr,
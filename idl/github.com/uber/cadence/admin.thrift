@@ -21,6 +21,7 @@
 namespace java com.uber.cadence.admin
 
 include "shared.thrift"
+include "replicator.thrift"
 
 /**
 * AdminService provides advanced APIs for debugging and analysis with admin privillege
@@ -58,6 +59,109 @@ service AdminService {
       3: shared.EntityNotExistsError entityNotExistError,
       4: shared.ServiceBusyError serviceBusyError,
     )
+
+  /**
+  * ExportWorkflowExecution returns a bundle of the complete history, the mutable state snapshot and the execution info
+  * of specified workflow execution, encoded using the requested encoding type. The bundle can be imported into another
+  * cluster using ImportWorkflowExecution, or inspected locally to reproduce issues.
+  **/
+  ExportWorkflowExecutionResponse ExportWorkflowExecution(1: ExportWorkflowExecutionRequest request)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: shared.ServiceBusyError serviceBusyError,
+    )
+
+  /**
+  * ImportWorkflowExecution recreates the workflow execution contained in a bundle returned by ExportWorkflowExecution
+  * by replicating its history into specified domain. It fails with 'BadRequestError' if the domain is not global.
+  **/
+  ImportWorkflowExecutionResponse ImportWorkflowExecution(1: ImportWorkflowExecutionRequest request)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: shared.ServiceBusyError serviceBusyError,
+    )
+
+  /**
+  * MigrateWorkflowExecution copies a workflow execution of specified domain from a remote cluster into this cluster.
+  * Only history batches missing in this cluster are imported, so it can be called repeatedly while the execution is
+  * still making progress in the remote cluster. The migration is verified by comparing the next event ID of both
+  * copies of the execution. It fails with 'BadRequestError' if the domain is not global in this cluster.
+  **/
+  MigrateWorkflowExecutionResponse MigrateWorkflowExecution(1: MigrateWorkflowExecutionRequest request)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: shared.ServiceBusyError serviceBusyError,
+    )
+
+  /**
+  * AddRemoteCluster adds a remote cluster to the cluster metadata at runtime. The cluster is persisted and picked up
+  * by all the hosts of this cluster, which start replicating from it without a redeployment. It fails with
+  * 'BadRequestError' if the cluster name or the initial failover version is already used.
+  **/
+  AddRemoteClusterResponse AddRemoteCluster(1: AddRemoteClusterRequest request)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: shared.ServiceBusyError serviceBusyError,
+    )
+
+  /**
+  * GetReplicationTasks returns the replication tasks of the shards in [minShardId, maxShardId], in the form they are
+  * published to remote clusters, so external tooling can audit replication without consuming the replication topic.
+  * Shards are read in order and the next page token tracks both the shard and the position within the shard.
+  **/
+  GetReplicationTasksResponse GetReplicationTasks(1: GetReplicationTasksRequest request)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: shared.ServiceBusyError serviceBusyError,
+    )
+
+  /**
+  * GetDLQReplicationTasks returns the replication tasks of the shards in [minShardId, maxShardId] which this cluster
+  * failed to apply and moved to its replication DLQ topic. The tasks are read without being consumed.
+  **/
+  GetDLQReplicationTasksResponse GetDLQReplicationTasks(1: GetDLQReplicationTasksRequest request)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: shared.ServiceBusyError serviceBusyError,
+    )
+
+  /**
+  * AddSearchAttribute adds search attribute keys to the ones visibility queries can use. The keys are added to the
+  * mapping of the ElasticSearch visibility index and persisted in the cluster metadata. It fails with
+  * 'BadRequestError' if a key is already valid or advanced visibility is not enabled.
+  **/
+  AddSearchAttributeResponse AddSearchAttribute(1: AddSearchAttributeRequest request)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: shared.ServiceBusyError serviceBusyError,
+    )
+
+  /**
+  * GetReplicationConsumerLag returns how far the replicator of this cluster is behind on the replication topic of
+  * each remote cluster, or of 'sourceCluster' when set. The committed offsets of the replicator consumers are read
+  * twice, 'sampleIntervalInSeconds' apart, to compute the processing and produce rates of each topic.
+  **/
+  GetReplicationConsumerLagResponse GetReplicationConsumerLag(1: GetReplicationConsumerLagRequest request)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: shared.ServiceBusyError serviceBusyError,
+    )
 }
 
 struct DescribeWorkflowExecutionRequest {
@@ -86,4 +190,115 @@ struct GetWorkflowExecutionRawHistoryResponse {
   20: optional list<shared.DataBlob> historyBatches
   30: optional map<string, shared.ReplicationInfo> replicationInfo
   40: optional i32 eventStoreVersion
-}
\ No newline at end of file
+}
+
+struct ExportWorkflowExecutionRequest {
+  10: optional string domain
+  20: optional shared.WorkflowExecution execution
+  30: optional shared.EncodingType encodingType
+}
+
+struct ExportWorkflowExecutionResponse {
+  10: optional shared.DataBlob bundle
+}
+
+struct ImportWorkflowExecutionRequest {
+  10: optional string domain
+  20: optional shared.DataBlob bundle
+}
+
+struct ImportWorkflowExecutionResponse {
+  10: optional shared.WorkflowExecution execution
+}
+
+struct MigrateWorkflowExecutionRequest {
+  10: optional string domain
+  20: optional shared.WorkflowExecution execution
+  30: optional string sourceCluster
+}
+
+struct MigrateWorkflowExecutionResponse {
+  10: optional i32 importedBatchCount
+  20: optional i64 (js.type = "Long") nextEventId
+}
+
+struct AddRemoteClusterRequest {
+  10: optional string clusterName
+  20: optional i64 (js.type = "Long") initialFailoverVersion
+  30: optional string rpcName
+  40: optional string rpcAddress
+}
+
+struct AddRemoteClusterResponse {
+}
+
+struct GetReplicationTasksRequest {
+  10: optional i32 minShardId
+  20: optional i32 maxShardId
+  30: optional i64 (js.type = "Long") minTaskId
+  40: optional i64 (js.type = "Long") maxTaskId
+  50: optional i32 maximumPageSize
+  60: optional binary nextPageToken
+}
+
+struct GetReplicationTasksResponse {
+  10: optional list<replicator.ReplicationTask> tasks
+  20: optional binary nextPageToken
+}
+
+struct GetDLQReplicationTasksRequest {
+  10: optional i32 minShardId
+  20: optional i32 maxShardId
+  30: optional i32 maximumPageSize
+  40: optional binary nextPageToken
+}
+
+struct GetDLQReplicationTasksResponse {
+  10: optional list<replicator.ReplicationTask> tasks
+  20: optional binary nextPageToken
+}
+
+struct AddSearchAttributeRequest {
+  10: optional map<string, shared.IndexedValueType> searchAttribute
+}
+
+struct AddSearchAttributeResponse {
+}
+
+struct GetReplicationConsumerLagRequest {
+  10: optional string sourceCluster
+  20: optional i32 sampleIntervalInSeconds
+}
+
+struct GetReplicationConsumerLagResponse {
+  10: optional list<ReplicationConsumerLag> consumers
+}
+
+struct ReplicationConsumerLag {
+  10: optional string sourceCluster
+  20: optional string topic
+  30: optional string consumerName
+  40: optional i64 lag
+  // messages committed by the consumer per second
+  50: optional double processingRate
+  // messages published to the topic per second
+  60: optional double produceRate
+  70: optional list<ReplicationPartitionLag> partitions
+}
+
+struct ReplicationPartitionLag {
+  10: optional i32 partition
+  20: optional i64 committedOffset
+  30: optional i64 highWatermark
+  40: optional i64 lag
+}
+
+struct WorkflowExecutionBundle {
+  10: optional string domain
+  20: optional shared.WorkflowExecution execution
+  30: optional i32 eventStoreVersion
+  40: optional map<string, shared.ReplicationInfo> replicationInfo
+  50: optional list<shared.History> historyBatches
+  60: optional string mutableState
+  70: optional shared.WorkflowExecutionInfo executionInfo
+}
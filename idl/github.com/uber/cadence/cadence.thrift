@@ -405,6 +405,20 @@ service WorkflowService {
       6: shared.LimitExceededError limitExceededError,
     )
 
+  /**
+  * UpdateWorkflowExecutionLabels upserts and removes the labels of a running workflow execution. Unlike memo,
+  * labels can be changed at any time while the execution is running, and list queries can filter on them.
+  **/
+  void UpdateWorkflowExecutionLabels(1: shared.UpdateWorkflowExecutionLabelsRequest updateRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: shared.ServiceBusyError serviceBusyError,
+      5: shared.DomainNotActiveError domainNotActiveError,
+      6: shared.LimitExceededError limitExceededError,
+    )
+
   /**
   * ListOpenWorkflowExecutions is a visibility API to list the open executions in a specific domain.
   **/
@@ -428,6 +442,30 @@ service WorkflowService {
       4: shared.ServiceBusyError serviceBusyError,
     )
 
+  /**
+  * GetWorkflowExecutionStatistics is a visibility API to count the executions closed within a time range in a
+  * specific domain, grouped by workflow type, close status and close time. Requires ElasticSearch visibility.
+  **/
+  shared.GetWorkflowExecutionStatisticsResponse GetWorkflowExecutionStatistics(1: shared.GetWorkflowExecutionStatisticsRequest statisticsRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: shared.ServiceBusyError serviceBusyError,
+    )
+
+  /**
+  * CountOpenWorkflowExecutions is a visibility API to count the open executions in a specific domain. The count is
+  * cached by the frontend for a short time, so it can lag behind the actual number. Requires ElasticSearch visibility.
+  **/
+  shared.CountOpenWorkflowExecutionsResponse CountOpenWorkflowExecutions(1: shared.CountOpenWorkflowExecutionsRequest countRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: shared.ServiceBusyError serviceBusyError,
+    )
+
   /**
   * RespondQueryTaskCompleted is called by application worker to complete a QueryTask (which is a DecisionTask for query)
   * as a result of 'PollForDecisionTask' API call. Completing a QueryTask will unblock the client call to 'QueryWorkflow'
@@ -487,6 +525,19 @@ service WorkflowService {
       5: shared.ServiceBusyError serviceBusyError,
     )
 
+  /**
+  * DescribeWorkflowExecutions returns information about multiple workflow executions of a domain at once. The
+  * current run is described for executions without run id, executions which don't exist are omitted.
+  **/
+  shared.DescribeWorkflowExecutionsResponse DescribeWorkflowExecutions(1: shared.DescribeWorkflowExecutionsRequest describeRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: shared.LimitExceededError limitExceededError,
+      5: shared.ServiceBusyError serviceBusyError,
+    )
+
   /**
   * DescribeTaskList returns information about the target tasklist, right now this API returns the
   * pollers which polled this tasklist in last few minutes.
@@ -500,4 +551,15 @@ service WorkflowService {
       5: shared.ServiceBusyError serviceBusyError,
     )
 
+  /**
+  * GetSearchAttributes returns the search attribute keys which can be used in advanced visibility queries,
+  * together with the type of their values.
+  **/
+  shared.GetSearchAttributesResponse GetSearchAttributes()
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.ServiceBusyError serviceBusyError,
+    )
+
 }
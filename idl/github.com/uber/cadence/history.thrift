@@ -194,6 +194,11 @@ struct TerminateWorkflowExecutionRequest {
   20: optional shared.TerminateWorkflowExecutionRequest terminateRequest
 }
 
+struct UpdateWorkflowExecutionLabelsRequest {
+  10: optional string domainUUID
+  20: optional shared.UpdateWorkflowExecutionLabelsRequest updateRequest
+}
+
 struct ResetWorkflowExecutionRequest {
   10: optional string domainUUID
   20: optional shared.ResetWorkflowExecutionRequest resetRequest
@@ -218,6 +223,11 @@ struct DescribeWorkflowExecutionRequest {
   20: optional shared.DescribeWorkflowExecutionRequest request
 }
 
+struct DescribeWorkflowExecutionsRequest {
+  10: optional string domainUUID
+  20: optional shared.DescribeWorkflowExecutionsRequest request
+}
+
 /**
 * RecordChildExecutionCompletedRequest is used for reporting the completion of child execution to parent workflow
 * execution which started it.  When a child execution is completed it creates this request and calls the
@@ -259,6 +269,14 @@ struct ReplicateRawEventsRequest {
   70: optional i32 newRunEventStoreVersion
 }
 
+struct ReplicateEventsV2Request {
+  10: optional string domainUUID
+  20: optional shared.WorkflowExecution workflowExecution
+  30: optional list<shared.VersionHistoryItem> versionHistoryItems
+  40: optional shared.DataBlob events
+  50: optional shared.DataBlob newRunEvents
+}
+
 struct SyncShardStatusRequest {
   10: optional string sourceCluster
   20: optional i64 (js.type = "Long") shardId
@@ -279,6 +297,23 @@ struct SyncActivityRequest {
   110: optional i32 attempt
 }
 
+struct GetReplicationTasksRequest {
+  10: optional i32 shardId
+  20: optional i64 (js.type = "Long") readLevel
+  30: optional i64 (js.type = "Long") maxReadLevel
+  40: optional i32 maximumPageSize
+  50: optional binary nextPageToken
+}
+
+/**
+* GetReplicationTasksResponse carries the replication tasks as thriftrw encoded replicator.ReplicationTask blobs,
+* since replicator.thrift depends on this file and cannot be included here.
+**/
+struct GetReplicationTasksResponse {
+  10: optional list<shared.DataBlob> tasks
+  20: optional binary nextPageToken
+}
+
 /**
 * HistoryService provides API to start a new long running workflow instance, as well as query and update the history
 * of workflow instances already created.
@@ -538,6 +573,21 @@ service HistoryService {
       7: shared.ServiceBusyError serviceBusyError,
     )
 
+  /**
+  * UpdateWorkflowExecutionLabels upserts and removes the mutable labels of a running workflow execution.
+  * Labels are kept in mutable state and synced to the visibility store, they are not recorded in the history.
+  **/
+  void UpdateWorkflowExecutionLabels(1: UpdateWorkflowExecutionLabelsRequest updateRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: ShardOwnershipLostError shardOwnershipLostError,
+      5: shared.DomainNotActiveError domainNotActiveError,
+      6: shared.LimitExceededError limitExceededError,
+      7: shared.ServiceBusyError serviceBusyError,
+    )
+
   /**
   * ResetWorkflowExecution reset an existing workflow execution by a firstEventID of a existing event batch
   * in the history and immediately terminating the current execution instance.
@@ -617,6 +667,20 @@ service HistoryService {
       6: shared.ServiceBusyError serviceBusyError,
     )
 
+  /**
+  * DescribeWorkflowExecutions returns information about multiple workflow executions owned by the same shard,
+  * their mutable states are loaded in one persistence round trip.
+  **/
+  shared.DescribeWorkflowExecutionsResponse DescribeWorkflowExecutions(1: DescribeWorkflowExecutionsRequest describeRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: ShardOwnershipLostError shardOwnershipLostError,
+      5: shared.LimitExceededError limitExceededError,
+      6: shared.ServiceBusyError serviceBusyError,
+    )
+
   void ReplicateEvents(1: ReplicateEventsRequest replicateRequest)
     throws (
       1: shared.BadRequestError badRequestError,
@@ -639,6 +703,21 @@ service HistoryService {
       7: shared.ServiceBusyError serviceBusyError,
     )
 
+  /**
+  * ReplicateEventsV2 replicates a batch of history events, tagged with the version history items
+  * of the source branch, so that the receiving cluster can reconcile diverged branches.
+  **/
+  void ReplicateEventsV2(1: ReplicateEventsV2Request replicateV2Request)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: ShardOwnershipLostError shardOwnershipLostError,
+      5: shared.LimitExceededError limitExceededError,
+      6: shared.RetryTaskError retryTaskError,
+      7: shared.ServiceBusyError serviceBusyError,
+    )
+
   /**
   * SyncShardStatus sync the status between shards
   **/
@@ -686,4 +765,18 @@ service HistoryService {
       2: shared.InternalServiceError internalServiceError,
       3: shared.AccessDeniedError accessDeniedError,
     )
+
+  /**
+  * GetReplicationTasks returns the replication tasks of a shard with task IDs in (readLevel, maxReadLevel], in the
+  * form they are published to remote clusters.
+  **/
+  GetReplicationTasksResponse GetReplicationTasks(1: GetReplicationTasksRequest request)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: ShardOwnershipLostError shardOwnershipLostError,
+      5: shared.LimitExceededError limitExceededError,
+      6: shared.ServiceBusyError serviceBusyError,
+    )
 }
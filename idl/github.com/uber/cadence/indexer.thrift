@@ -31,6 +31,7 @@ enum FieldType {
   String
   Int
   Bool
+  StringList
 }
 
 struct Field {
@@ -38,6 +39,7 @@ struct Field {
   20: optional string stringData
   30: optional i64 (js.type = "Long") intData
   40: optional bool boolData
+  50: optional list<string> stringListData
 }
 
 struct IndexAttributes {
@@ -28,6 +28,7 @@ enum ReplicationTaskType {
   History
   SyncShardStatus
   SyncActivity
+  HistoryV2
 }
 
 enum DomainOperation {
@@ -81,11 +82,22 @@ struct SyncActicvityTaskAttributes {
   110: optional i32 attempt
 }
 
+struct HistoryTaskV2Attributes {
+  10: optional string domainId
+  20: optional string workflowId
+  30: optional string runId
+  40: optional list<shared.VersionHistoryItem> versionHistoryItems
+  50: optional shared.DataBlob events
+  60: optional shared.DataBlob newRunEvents
+}
+
 struct ReplicationTask {
   10: optional ReplicationTaskType taskType
   20: optional DomainTaskAttributes domainTaskAttributes
   30: optional HistoryTaskAttributes historyTaskAttributes
   40: optional SyncShardStatusTaskAttributes syncShardStatusTaskAttributes
   50: optional SyncActicvityTaskAttributes syncActicvityTaskAttributes
+  60: optional i32 shardId
+  70: optional HistoryTaskV2Attributes historyTaskV2Attributes
 }
 
@@ -93,6 +93,11 @@ enum WorkflowIdReusePolicy {
    * do not allow start a workflow execution using the same workflow ID at all
    */
   RejectDuplicate,
+  /*
+   * terminate the current running workflow execution with the same workflow ID, if any,
+   * and start the new workflow execution atomically
+   */
+  TerminateIfRunning,
 }
 
 enum DomainStatus {
@@ -192,6 +197,8 @@ enum DecisionTaskFailedCause {
   FAILOVER_CLOSE_DECISION,
   BAD_SIGNAL_INPUT_SIZE,
   RESET_WORKFLOW,
+  PENDING_CHILD_WORKFLOWS_LIMIT_EXCEEDED,
+  PENDING_ACTIVITIES_LIMIT_EXCEEDED,
 }
 
 enum CancelExternalWorkflowExecutionFailedCause {
@@ -213,6 +220,15 @@ enum WorkflowExecutionCloseStatus {
   TERMINATED,
   CONTINUED_AS_NEW,
   TIMED_OUT,
+  DELETED,
+}
+
+enum TerminationCause {
+  USER_REQUEST,
+  HISTORY_EXCEEDS_LIMIT,
+  WORKFLOW_ID_REUSE_POLICY,
+  RESET_WORKFLOW,
+  VERSION_CONFLICT,
 }
 
 enum ChildPolicy {
@@ -245,6 +261,8 @@ enum TaskListKind {
 enum ArchivalStatus {
   DISABLED,
   ENABLED,
+  PAUSED,
+  MIGRATING,
 }
 
 struct Header {
@@ -266,6 +284,7 @@ struct TaskList {
 
 enum EncodingType {
   ThriftRW,
+  JSON,
 }
 
 struct DataBlob {
@@ -297,6 +316,16 @@ struct WorkflowExecutionInfo {
   70: optional string parentDomainId
   80: optional WorkflowExecution parentExecution
   90: optional i64 (js.type = "Long") executionTime
+  100: optional WorkflowExecutionTerminationInfo terminationInfo
+  110: optional string taskList
+  120: optional map<string,string> labels
+}
+
+struct WorkflowExecutionTerminationInfo {
+  10: optional TerminationCause cause
+  20: optional string reason
+  30: optional binary details
+  40: optional string identity
 }
 
 struct WorkflowExecutionConfiguration {
@@ -440,6 +469,8 @@ struct WorkflowExecutionStartedEventAttributes {
   90: optional i64 (js.type = "Long") expirationTimestamp
   100: optional string cronSchedule
   110: optional i32 firstDecisionTaskBackoffSeconds
+  120: optional string concurrencyGroup
+  130: optional i32 concurrencyLimit
 }
 
 struct WorkflowExecutionCompletedEventAttributes {
@@ -633,6 +664,7 @@ struct WorkflowExecutionTerminatedEventAttributes {
   10: optional string reason
   20: optional binary details
   30: optional string identity
+  40: optional TerminationCause cause
 }
 
 struct RequestCancelExternalWorkflowExecutionInitiatedEventAttributes {
@@ -823,6 +855,11 @@ struct WorkflowTypeFilter {
   10: optional string name
 }
 
+struct LabelFilter {
+  10: optional string key
+  20: optional string value
+}
+
 struct StartTimeFilter {
   10: optional i64 (js.type = "Long") earliestTime
   20: optional i64 (js.type = "Long") latestTime
@@ -845,6 +882,7 @@ struct DomainConfiguration {
   40: optional i32 archivalRetentionPeriodInDays
   50: optional ArchivalStatus archivalStatus
   60: optional string archivalBucketOwner
+  70: optional string archivalTargetBucketName
 }
 
 struct UpdateDomainInfo {
@@ -936,10 +974,15 @@ struct StartWorkflowExecutionRequest {
   110: optional ChildPolicy childPolicy
   120: optional RetryPolicy retryPolicy
   130: optional string cronSchedule
+  140: optional bool waitForCompletion
+  150: optional string concurrencyGroup
+  160: optional i32 concurrencyLimit
 }
 
 struct StartWorkflowExecutionResponse {
   10: optional string runId
+  20: optional WorkflowExecutionCloseStatus closeStatus
+  30: optional binary result
 }
 
 struct PollForDecisionTaskRequest {
@@ -1094,6 +1137,8 @@ struct GetWorkflowExecutionHistoryRequest {
   40: optional binary nextPageToken
   50: optional bool waitForNewEvent
   60: optional HistoryEventFilterType HistoryEventFilterType
+  70: optional bool reverseOrder
+  80: optional list<EventType> eventTypes
 }
 
 struct GetWorkflowExecutionHistoryResponse {
@@ -1110,6 +1155,7 @@ struct SignalWorkflowExecutionRequest {
   50: optional string identity
   60: optional string requestId
   70: optional binary control
+  80: optional i64 (js.type = "Long") deliveryTimestamp
 }
 
 struct SignalWithStartWorkflowExecutionRequest {
@@ -1138,6 +1184,14 @@ struct TerminateWorkflowExecutionRequest {
   50: optional string identity
 }
 
+struct UpdateWorkflowExecutionLabelsRequest {
+  10: optional string domain
+  20: optional WorkflowExecution workflowExecution
+  30: optional map<string,string> upsertLabels
+  40: optional list<string> removeLabels
+  50: optional string identity
+}
+
 struct ResetWorkflowExecutionRequest {
   10: optional string domain
   20: optional WorkflowExecution workflowExecution
@@ -1157,6 +1211,7 @@ struct ListOpenWorkflowExecutionsRequest {
   40: optional StartTimeFilter StartTimeFilter
   50: optional WorkflowExecutionFilter executionFilter
   60: optional WorkflowTypeFilter typeFilter
+  70: optional LabelFilter labelFilter
 }
 
 struct ListOpenWorkflowExecutionsResponse {
@@ -1172,6 +1227,7 @@ struct ListClosedWorkflowExecutionsRequest {
   50: optional WorkflowExecutionFilter executionFilter
   60: optional WorkflowTypeFilter typeFilter
   70: optional WorkflowExecutionCloseStatus statusFilter
+  80: optional LabelFilter labelFilter
 }
 
 struct ListClosedWorkflowExecutionsResponse {
@@ -1179,6 +1235,38 @@ struct ListClosedWorkflowExecutionsResponse {
   20: optional binary nextPageToken
 }
 
+struct GetWorkflowExecutionStatisticsRequest {
+  10: optional string domain
+  20: optional i64 (js.type = "Long") earliestCloseTime
+  30: optional i64 (js.type = "Long") latestCloseTime
+  40: optional i32 closeTimeIntervalInSeconds
+}
+
+struct WorkflowExecutionCountBucket {
+  10: optional string key
+  20: optional i64 (js.type = "Long") count
+}
+
+struct WorkflowExecutionTimeBucket {
+  10: optional i64 (js.type = "Long") startTime
+  20: optional i64 (js.type = "Long") count
+}
+
+struct GetWorkflowExecutionStatisticsResponse {
+  10: optional i64 (js.type = "Long") totalCount
+  20: optional list<WorkflowExecutionCountBucket> countsByType
+  30: optional list<WorkflowExecutionCountBucket> countsByCloseStatus
+  40: optional list<WorkflowExecutionTimeBucket> countsByCloseTime
+}
+
+struct CountOpenWorkflowExecutionsRequest {
+  10: optional string domain
+}
+
+struct CountOpenWorkflowExecutionsResponse {
+  10: optional i64 (js.type = "Long") count
+}
+
 struct QueryWorkflowRequest {
   10: optional string domain
   20: optional WorkflowExecution execution
@@ -1227,12 +1315,46 @@ struct PendingActivityInfo {
   80: optional i32 maximumAttempts
   90: optional i64 (js.type = "Long") scheduledTimestamp
   100: optional i64 (js.type = "Long") expirationTimestamp
+  110: optional string lastFailureReason
+  120: optional string lastWorkerIdentity
+}
+
+enum PendingDecisionState {
+  SCHEDULED,
+  STARTED,
+}
+
+struct PendingDecisionInfo {
+  10: optional PendingDecisionState state
+  20: optional i64 (js.type = "Long") scheduledEventId
+  30: optional i64 (js.type = "Long") startedTimestamp
+  40: optional i64 attempt
+}
+
+struct PendingChildExecutionInfo {
+  10: optional string workflowID
+  20: optional string runID
+  30: optional string workflowTypName
+  40: optional i64 (js.type = "Long") initiatedID
+  50: optional string domain
 }
 
 struct DescribeWorkflowExecutionResponse {
   10: optional WorkflowExecutionConfiguration executionConfiguration
   20: optional WorkflowExecutionInfo workflowExecutionInfo
   30: optional list<PendingActivityInfo> pendingActivities
+  40: optional list<PendingChildExecutionInfo> pendingChildren
+  50: optional PendingDecisionInfo pendingDecision
+}
+
+struct DescribeWorkflowExecutionsRequest {
+  10: optional string domain
+  20: optional list<WorkflowExecution> executions
+}
+
+struct DescribeWorkflowExecutionsResponse {
+  // executions which don't exist are omitted
+  10: optional list<DescribeWorkflowExecutionResponse> executions
 }
 
 struct DescribeTaskListRequest {
@@ -1247,12 +1369,26 @@ struct DescribeTaskListResponse {
   20: optional TaskListStatus taskListStatus
 }
 
+enum IndexedValueType {
+  STRING,
+  KEYWORD,
+  INT,
+  DOUBLE,
+  BOOL,
+  DATETIME,
+}
+
+struct GetSearchAttributesResponse {
+  10: optional map<string, IndexedValueType> keys
+}
+
 struct TaskListStatus {
   10: optional i64 (js.type = "Long") backlogCountHint
   20: optional i64 (js.type = "Long") readLevel
   30: optional i64 (js.type = "Long") ackLevel
   35: optional double ratePerSecond
   40: optional TaskIDBlock taskIDBlock
+  50: optional i32 burst
 }
 
 struct TaskIDBlock {
@@ -1338,3 +1474,21 @@ struct HistoryBranch{
   20: optional string branchID
   30: optional list<HistoryBranchRange>  ancestors
 }
+
+// VersionHistoryItem contains the event id and the associated version
+struct VersionHistoryItem{
+  10: optional i64 (js.type = "Long") eventID
+  20: optional i64 (js.type = "Long") version
+}
+
+// VersionHistory contains the version history of a branch
+struct VersionHistory{
+  10: optional binary branchToken
+  20: optional list<VersionHistoryItem> items
+}
+
+// VersionHistories contains all version histories of a workflow execution
+struct VersionHistories{
+  10: optional i32 currentVersionHistoryIndex
+  20: optional list<VersionHistory> histories
+}
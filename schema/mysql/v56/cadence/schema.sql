@@ -12,6 +12,7 @@ CREATE TABLE domains(
   emit_metric TINYINT(1) NOT NULL,
   archival_bucket VARCHAR(255) NOT NULL,
   archival_status TINYINT NOT NULL,
+  archival_target_bucket VARCHAR(255) NOT NULL DEFAULT '',
 /* end domain_config */
   config_version BIGINT NOT NULL,
   notification_version BIGINT NOT NULL,
@@ -31,6 +32,18 @@ CREATE TABLE domain_metadata (
 
 INSERT INTO domain_metadata (notification_version) VALUES (1);
 
+CREATE TABLE cluster_metadata (
+	metadata_partition INT NOT NULL,
+	cluster_name VARCHAR(255) NOT NULL,
+	failover_version_increment BIGINT NOT NULL,
+	initial_failover_versions BLOB NOT NULL,
+	cluster_addresses BLOB NOT NULL,
+	search_attributes BLOB NOT NULL,
+	num_history_shards INT NOT NULL,
+	version BIGINT NOT NULL,
+	PRIMARY KEY (metadata_partition)
+);
+
 CREATE TABLE shards (
 	shard_id INT NOT NULL,
 	owner VARCHAR(255) NOT NULL,
@@ -126,6 +139,10 @@ CREATE TABLE executions(
   non_retryable_errors BLOB,
   event_store_version INT NOT NULL, -- indicates which version of events persistence is using
   branch_token BLOB,
+  labels BLOB, -- mutable key value labels of a running execution
+  version_histories BLOB, -- version history of every history branch, used by NDC replication
+  version_histories_encoding VARCHAR(16),
+  checksum BLOB, -- checksum of the mutable state, verified when it is loaded
 	PRIMARY KEY (shard_id, domain_id, workflow_id, run_id)
 );
 
@@ -216,6 +233,10 @@ CREATE TABLE timer_tasks (
 	event_id BIGINT NOT NULL,
 	schedule_attempt BIGINT NOT NULL,
 	version BIGINT NOT NULL,
+	signal_name VARCHAR(255),
+	signal_input BLOB,
+	signal_identity VARCHAR(255),
+	signal_request_id VARCHAR(64),
 	PRIMARY KEY (shard_id, visibility_timestamp, task_id)
 );
 
@@ -270,6 +291,7 @@ max_interval                INT NOT NULL,
 expiration_time             DATETIME(6) NOT NULL,
 max_attempts                INT NOT NULL,
 non_retriable_errors        BLOB, -- this was a list<text>. The use pattern is to replace, no modifications.
+last_failure_reason         VARCHAR(255) NOT NULL,
 	PRIMARY KEY (shard_id, domain_id, workflow_id, run_id, schedule_id)
 );
 
@@ -5,9 +5,16 @@ CREATE TABLE executions_visibility (
   execution_time       DATETIME(6) NOT NULL,
   workflow_id          VARCHAR(255) NOT NULL,
   workflow_type_name   VARCHAR(255) NOT NULL,
+  task_list            VARCHAR(255) NOT NULL,
+  parent_workflow_id   VARCHAR(255),  -- NULL if the execution has no parent
+  parent_run_id        CHAR(64),
   close_status         INT,  -- enum WorkflowExecutionCloseStatus {COMPLETED, FAILED, CANCELED, TERMINATED, CONTINUED_AS_NEW, TIMED_OUT}
   close_time           DATETIME(6) NULL,
   history_length       BIGINT,
+  terminate_cause      INT,  -- enum TerminationCause, only set when close_status is TERMINATED
+  terminate_reason     VARCHAR(255),
+  terminate_details    BLOB,
+  terminate_identity   VARCHAR(255),
 
   PRIMARY KEY  (domain_id, run_id)
 );
@@ -22,6 +22,9 @@ package frontend
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
 	"strconv"
 	"sync"
 	"sync/atomic"
@@ -34,38 +37,107 @@ import (
 	"github.com/uber/cadence/.gen/go/admin/adminserviceserver"
 	h "github.com/uber/cadence/.gen/go/history"
 	hist "github.com/uber/cadence/.gen/go/history"
+	"github.com/uber/cadence/.gen/go/replicator"
 	gen "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/client/history"
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/codec"
+	es "github.com/uber/cadence/common/elasticsearch"
 	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/messaging"
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/service"
+	"github.com/uber/cadence/common/service/config"
 	historyService "github.com/uber/cadence/service/history"
 )
 
 var _ adminserviceserver.Interface = (*AdminHandler)(nil)
 
+const (
+	// exportHistoryPageSize is the number of history batches read from persistence at a time during export
+	exportHistoryPageSize = 100
+	// replicationTasksPageSize is the default number of replication tasks returned in a page
+	replicationTasksPageSize = 100
+	// consumerLagSampleInterval is the default interval between the two samples of GetReplicationConsumerLag,
+	// maxConsumerLagSampleInterval keeps the call within the default timeout of the admin client
+	consumerLagSampleInterval    = 2 * time.Second
+	maxConsumerLagSampleInterval = 5 * time.Second
+)
+
+var (
+	errBundleNotSet           = &gen.BadRequestError{Message: "Bundle is not set on request."}
+	errInvalidBundle          = &gen.BadRequestError{Message: "Bundle cannot be decoded."}
+	errImportDomainNotGlobal  = &gen.BadRequestError{Message: "Workflow executions can only be imported into global domains."}
+	errUnsupportedBundleCodec = &gen.BadRequestError{Message: "Unsupported bundle encoding type."}
+	errSourceClusterNotSet    = &gen.BadRequestError{Message: "SourceCluster is not set on request."}
+	errInvalidSourceCluster   = &gen.BadRequestError{Message: "SourceCluster is not a remote cluster."}
+
+	errClusterNameNotSet                  = &gen.BadRequestError{Message: "ClusterName is not set on request."}
+	errClusterAddressNotSet               = &gen.BadRequestError{Message: "RpcName and RpcAddress must be set on request."}
+	errInitialFailoverVersionNotSet       = &gen.BadRequestError{Message: "InitialFailoverVersion is not set on request."}
+	errClusterAlreadyExists               = &gen.BadRequestError{Message: "Cluster already exists."}
+	errInitialFailoverVersionInUse        = &gen.BadRequestError{Message: "InitialFailoverVersion is already used by another cluster."}
+	errInvalidInitialFailoverVersion      = &gen.BadRequestError{Message: "InitialFailoverVersion must be non negative and smaller than the failover version increment."}
+	errClusterMetadataUpdatedConcurrently = &gen.ServiceBusyError{Message: "Cluster metadata was updated concurrently, please retry."}
+
+	errSearchAttributeNotSet    = &gen.BadRequestError{Message: "SearchAttribute is not set on request."}
+	errSearchAttributeKeyNotSet = &gen.BadRequestError{Message: "SearchAttribute key cannot be empty."}
+	errAdvancedVisibilityNotSet = &gen.BadRequestError{Message: "Advanced visibility is not enabled for this cluster."}
+
+	errInvalidShardRange         = &gen.BadRequestError{Message: "MinShardId and MaxShardId must be a valid range of shards."}
+	errInvalidPageSize           = &gen.BadRequestError{Message: "Invalid PageSize."}
+	errInvalidReplicationToken   = &gen.BadRequestError{Message: "Invalid pagination token."}
+	errReplicationDLQUnavailable = &gen.BadRequestError{Message: "Replication DLQ is not available, kafka is not configured for this cluster."}
+
+	errInvalidSampleInterval             = &gen.BadRequestError{Message: "SampleIntervalInSeconds must be between 1 and 5."}
+	errReplicationConsumerLagUnavailable = &gen.BadRequestError{Message: "Replication consumer lag is not available, kafka is not configured for this cluster."}
+)
+
+type (
+	// replicationTasksPageToken is the pagination token of GetReplicationTasks,
+	// it holds the shard being read and the pagination token within the shard
+	replicationTasksPageToken struct {
+		ShardID       int32
+		NextPageToken []byte
+	}
+
+	// dlqReplicationTasksPageToken is the pagination token of GetDLQReplicationTasks,
+	// it holds the partition of the DLQ topic being read and the offset within the partition
+	dlqReplicationTasksPageToken struct {
+		Partition int32
+		Offset    int64
+	}
+)
+
 type (
 	// AdminHandler - Thrift handler inteface for admin service
 	AdminHandler struct {
 		status                int32
 		numberOfHistoryShards int
 		service.Service
-		history       history.Client
-		domainCache   cache.DomainCache
-		metricsClient metrics.Client
-		historyMgr    persistence.HistoryManager
-		historyV2Mgr  persistence.HistoryV2Manager
-		startWG       sync.WaitGroup
+		history            history.Client
+		domainCache        cache.DomainCache
+		metricsClient      metrics.Client
+		historyMgr         persistence.HistoryManager
+		historyV2Mgr       persistence.HistoryV2Manager
+		clusterMetadataMgr persistence.ClusterMetadataManager
+		// esClient and visibilityIndexName are the ElasticSearch visibility index, esClient is nil if
+		// advanced visibility is not enabled
+		esClient            es.Client
+		visibilityIndexName string
+		searchAttributes    SearchAttributesCache
+		startWG             sync.WaitGroup
 	}
 )
 
 // NewAdminHandler creates a thrift handler for the cadence admin service
 func NewAdminHandler(
 	sVice service.Service, numberOfHistoryShards int, metadataMgr persistence.MetadataManager,
-	historyMgr persistence.HistoryManager, historyV2Mgr persistence.HistoryV2Manager) *AdminHandler {
+	historyMgr persistence.HistoryManager, historyV2Mgr persistence.HistoryV2Manager,
+	clusterMetadataMgr persistence.ClusterMetadataManager, esClient es.Client, visibilityIndexName string,
+	searchAttributes SearchAttributesCache) *AdminHandler {
 	handler := &AdminHandler{
 		status:                common.DaemonStatusInitialized,
 		numberOfHistoryShards: numberOfHistoryShards,
@@ -73,6 +145,10 @@ func NewAdminHandler(
 		domainCache:           cache.NewDomainCache(metadataMgr, sVice.GetClusterMetadata(), sVice.GetMetricsClient(), sVice.GetBarkLogger()),
 		historyMgr:            historyMgr,
 		historyV2Mgr:          historyV2Mgr,
+		clusterMetadataMgr:    clusterMetadataMgr,
+		esClient:              esClient,
+		visibilityIndexName:   visibilityIndexName,
+		searchAttributes:      searchAttributes,
 	}
 	// prevent us from trying to serve requests before handler's Start() is complete
 	handler.startWG.Add(1)
@@ -331,6 +407,730 @@ func (adh *AdminHandler) GetWorkflowExecutionRawHistory(
 	return result, nil
 }
 
+// ExportWorkflowExecution returns a bundle of the complete history, the mutable state snapshot and the execution info
+// of the specified workflow execution
+func (adh *AdminHandler) ExportWorkflowExecution(
+	ctx context.Context, request *admin.ExportWorkflowExecutionRequest) (resp *admin.ExportWorkflowExecutionResponse, retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+
+	scope := metrics.AdminExportWorkflowExecutionScope
+	sw := adh.startRequestProfile(scope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if err := validateExecution(request.Execution); err != nil {
+		return nil, adh.error(err, scope)
+	}
+	if request.Execution.GetRunId() == "" {
+		return nil, adh.error(errInvalidRunID, scope)
+	}
+	domainID, err := adh.domainCache.GetDomainID(request.GetDomain())
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	mutableState, err := adh.history.GetMutableState(ctx, &h.GetMutableStateRequest{
+		DomainUUID:          common.StringPtr(domainID),
+		Execution:           request.Execution,
+		ExpectedNextEventId: common.Int64Ptr(common.FirstEventID), // common.FirstEventID means no long poll
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	mutableStateSnapshot, err := adh.history.DescribeMutableState(ctx, &hist.DescribeMutableStateRequest{
+		DomainUUID: common.StringPtr(domainID),
+		Execution:  request.Execution,
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	describeResponse, err := adh.history.DescribeWorkflowExecution(ctx, &hist.DescribeWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr(domainID),
+		Request: &gen.DescribeWorkflowExecutionRequest{
+			Domain:    request.Domain,
+			Execution: request.Execution,
+		},
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	historyBatches := []*gen.History{}
+	shardID := common.WorkflowIDToHistoryShard(request.Execution.GetWorkflowId(), adh.numberOfHistoryShards)
+	var pageToken []byte
+	for {
+		var batches []*gen.History
+		_, batches, pageToken, _, err = historyService.PaginateHistory(
+			adh.historyMgr,
+			adh.historyV2Mgr,
+			adh.metricsClient,
+			adh.GetBarkLogger(),
+			true, // this means that we are getting history by batch
+			domainID,
+			request.Execution.GetWorkflowId(),
+			request.Execution.GetRunId(),
+			common.FirstEventID,
+			mutableState.GetNextEventId(),
+			pageToken,
+			mutableState.GetEventStoreVersion(),
+			mutableState.BranchToken,
+			exportHistoryPageSize,
+			common.IntPtr(shardID),
+		)
+		if err != nil {
+			return nil, adh.error(err, scope)
+		}
+		historyBatches = append(historyBatches, batches...)
+		if len(pageToken) == 0 {
+			break
+		}
+	}
+
+	bundle := &admin.WorkflowExecutionBundle{
+		Domain:            request.Domain,
+		Execution:         request.Execution,
+		EventStoreVersion: mutableState.EventStoreVersion,
+		ReplicationInfo:   mutableState.ReplicationInfo,
+		HistoryBatches:    historyBatches,
+		MutableState:      mutableStateSnapshot.MutableStateInDatabase,
+		ExecutionInfo:     describeResponse.WorkflowExecutionInfo,
+	}
+	encodingType := gen.EncodingTypeThriftRW
+	if request.EncodingType != nil {
+		encodingType = request.GetEncodingType()
+	}
+	blob, err := encodeWorkflowExecutionBundle(bundle, encodingType)
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &admin.ExportWorkflowExecutionResponse{
+		Bundle: blob,
+	}, nil
+}
+
+// ImportWorkflowExecution recreates the workflow execution contained in a bundle returned by ExportWorkflowExecution.
+// The execution is rebuilt by replicating its history batches in order, the same way history is re-sent to remote
+// clusters, so the domain is required to be a global domain.
+func (adh *AdminHandler) ImportWorkflowExecution(
+	ctx context.Context, request *admin.ImportWorkflowExecutionRequest) (resp *admin.ImportWorkflowExecutionResponse, retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+
+	scope := metrics.AdminImportWorkflowExecutionScope
+	sw := adh.startRequestProfile(scope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.Bundle == nil {
+		return nil, adh.error(errBundleNotSet, scope)
+	}
+	bundle, err := decodeWorkflowExecutionBundle(request.Bundle)
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	if err := validateExecution(bundle.Execution); err != nil {
+		return nil, adh.error(err, scope)
+	}
+	if bundle.Execution.GetRunId() == "" {
+		return nil, adh.error(errInvalidRunID, scope)
+	}
+	domainEntry, err := adh.domainCache.GetDomain(request.GetDomain())
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	if !domainEntry.IsGlobalDomain() {
+		return nil, adh.error(errImportDomainNotGlobal, scope)
+	}
+
+	if _, err := adh.importHistoryBatches(ctx, domainEntry, bundle, common.FirstEventID); err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &admin.ImportWorkflowExecutionResponse{
+		Execution: bundle.Execution,
+	}, nil
+}
+
+// MigrateWorkflowExecution copies a workflow execution from a remote cluster into this cluster, importing only the
+// history batches which are missing in this cluster and verifying the next event ID of the result
+func (adh *AdminHandler) MigrateWorkflowExecution(
+	ctx context.Context, request *admin.MigrateWorkflowExecutionRequest) (resp *admin.MigrateWorkflowExecutionResponse, retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+
+	scope := metrics.AdminMigrateWorkflowExecutionScope
+	sw := adh.startRequestProfile(scope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if err := validateExecution(request.Execution); err != nil {
+		return nil, adh.error(err, scope)
+	}
+	if request.Execution.GetRunId() == "" {
+		return nil, adh.error(errInvalidRunID, scope)
+	}
+	if request.GetSourceCluster() == "" {
+		return nil, adh.error(errSourceClusterNotSet, scope)
+	}
+	clusterMetadata := adh.GetClusterMetadata()
+	if _, ok := clusterMetadata.GetAllClientAddress()[request.GetSourceCluster()]; !ok ||
+		request.GetSourceCluster() == clusterMetadata.GetCurrentClusterName() {
+		return nil, adh.error(errInvalidSourceCluster, scope)
+	}
+	domainEntry, err := adh.domainCache.GetDomain(request.GetDomain())
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	if !domainEntry.IsGlobalDomain() {
+		return nil, adh.error(errImportDomainNotGlobal, scope)
+	}
+	domainID := domainEntry.GetInfo().ID
+
+	exportResponse, err := adh.GetClientBean().GetRemoteAdminClient(request.GetSourceCluster()).ExportWorkflowExecution(
+		ctx,
+		&admin.ExportWorkflowExecutionRequest{
+			Domain:       request.Domain,
+			Execution:    request.Execution,
+			EncodingType: gen.EncodingTypeThriftRW.Ptr(),
+		},
+	)
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	bundle, err := decodeWorkflowExecutionBundle(exportResponse.Bundle)
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	nextEventID, err := adh.getNextEventID(ctx, domainID, request.Execution)
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	importedBatchCount, err := adh.importHistoryBatches(ctx, domainEntry, bundle, nextEventID)
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	expectedNextEventID := common.FirstEventID
+	if batchCount := len(bundle.HistoryBatches); batchCount > 0 {
+		events := bundle.HistoryBatches[batchCount-1].Events
+		expectedNextEventID = events[len(events)-1].GetEventId() + 1
+	}
+	nextEventID, err = adh.getNextEventID(ctx, domainID, request.Execution)
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	if nextEventID != expectedNextEventID {
+		return nil, adh.error(&gen.InternalServiceError{
+			Message: fmt.Sprintf("Migrated workflow execution has next event ID %v, expected %v.", nextEventID, expectedNextEventID),
+		}, scope)
+	}
+	return &admin.MigrateWorkflowExecutionResponse{
+		ImportedBatchCount: common.Int32Ptr(int32(importedBatchCount)),
+		NextEventId:        common.Int64Ptr(nextEventID),
+	}, nil
+}
+
+// AddRemoteCluster persists a remote cluster in the cluster metadata and adds it to the cluster metadata of this
+// host, the other hosts pick it up on their next refresh of the cluster metadata
+func (adh *AdminHandler) AddRemoteCluster(
+	ctx context.Context, request *admin.AddRemoteClusterRequest) (resp *admin.AddRemoteClusterResponse, retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+
+	scope := metrics.AdminAddRemoteClusterScope
+	sw := adh.startRequestProfile(scope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.GetClusterName() == "" {
+		return nil, adh.error(errClusterNameNotSet, scope)
+	}
+	if request.GetRpcName() == "" || request.GetRpcAddress() == "" {
+		return nil, adh.error(errClusterAddressNotSet, scope)
+	}
+	if !request.IsSetInitialFailoverVersion() {
+		return nil, adh.error(errInitialFailoverVersionNotSet, scope)
+	}
+	clusterName := request.GetClusterName()
+	initialFailoverVersion := request.GetInitialFailoverVersion()
+
+	getResponse, err := adh.clusterMetadataMgr.GetClusterMetadata(ctx)
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	persisted := getResponse.ClusterMetadata
+	if initialFailoverVersion < 0 || initialFailoverVersion >= persisted.FailoverVersionIncrement {
+		return nil, adh.error(errInvalidInitialFailoverVersion, scope)
+	}
+	if _, ok := adh.GetClusterMetadata().GetAllClusterFailoverVersions()[clusterName]; ok {
+		return nil, adh.error(errClusterAlreadyExists, scope)
+	}
+	updated := persisted
+	updated.InitialFailoverVersions = make(map[string]int64)
+	updated.ClusterAddresses = make(map[string]persistence.ClusterAddress)
+	for name, version := range persisted.InitialFailoverVersions {
+		if name == clusterName {
+			return nil, adh.error(errClusterAlreadyExists, scope)
+		}
+		if version == initialFailoverVersion {
+			return nil, adh.error(errInitialFailoverVersionInUse, scope)
+		}
+		updated.InitialFailoverVersions[name] = version
+	}
+	for name, address := range persisted.ClusterAddresses {
+		updated.ClusterAddresses[name] = address
+	}
+	updated.InitialFailoverVersions[clusterName] = initialFailoverVersion
+	updated.ClusterAddresses[clusterName] = persistence.ClusterAddress{
+		RPCName:    request.GetRpcName(),
+		RPCAddress: request.GetRpcAddress(),
+	}
+	updated.Version = persisted.Version + 1
+
+	err = adh.clusterMetadataMgr.UpdateClusterMetadata(ctx, &persistence.UpdateClusterMetadataRequest{
+		ClusterMetadata: updated,
+		PreviousVersion: persisted.Version,
+	})
+	if err != nil {
+		if _, ok := err.(*persistence.ConditionFailedError); ok {
+			return nil, adh.error(errClusterMetadataUpdatedConcurrently, scope)
+		}
+		return nil, adh.error(err, scope)
+	}
+
+	err = adh.GetClusterMetadata().AddRemoteCluster(clusterName, initialFailoverVersion, config.Address{
+		RPCName:    request.GetRpcName(),
+		RPCAddress: request.GetRpcAddress(),
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &admin.AddRemoteClusterResponse{}, nil
+}
+
+// AddSearchAttribute adds search attribute keys to the ones visibility queries can use. Each key is added to the
+// mapping of the ElasticSearch visibility index before it is persisted in the cluster metadata, so a key is never
+// valid without a mapping. Frontend hosts pick up the new keys once their search attributes cache expired.
+func (adh *AdminHandler) AddSearchAttribute(
+	ctx context.Context, request *admin.AddSearchAttributeRequest) (resp *admin.AddSearchAttributeResponse, retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+
+	scope := metrics.AdminAddSearchAttributeScope
+	sw := adh.startRequestProfile(scope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if len(request.SearchAttribute) == 0 {
+		return nil, adh.error(errSearchAttributeNotSet, scope)
+	}
+	if adh.esClient == nil {
+		return nil, adh.error(errAdvancedVisibilityNotSet, scope)
+	}
+	validKeys, err := adh.searchAttributes.GetSearchAttributes(ctx)
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	for key, valueType := range request.SearchAttribute {
+		if key == "" {
+			return nil, adh.error(errSearchAttributeKeyNotSet, scope)
+		}
+		if _, ok := validKeys[key]; ok {
+			return nil, adh.error(&gen.BadRequestError{
+				Message: fmt.Sprintf("Search attribute %v already exists.", key)}, scope)
+		}
+		if _, ok := es.GetESTypeOfIndexedValueType(valueType); !ok {
+			return nil, adh.error(&gen.BadRequestError{
+				Message: fmt.Sprintf("Unknown type %v of search attribute %v.", valueType, key)}, scope)
+		}
+	}
+
+	for key, valueType := range request.SearchAttribute {
+		esType, _ := es.GetESTypeOfIndexedValueType(valueType)
+		if err := adh.esClient.PutMapping(ctx, adh.visibilityIndexName, key, esType); err != nil {
+			return nil, adh.error(&gen.InternalServiceError{
+				Message: fmt.Sprintf("Failed to update the ElasticSearch mapping of %v: %v", key, err)}, scope)
+		}
+	}
+
+	getResponse, err := adh.clusterMetadataMgr.GetClusterMetadata(ctx)
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	persisted := getResponse.ClusterMetadata
+	updated := persisted
+	updated.SearchAttributes = make(map[string]int32)
+	for key, valueType := range persisted.SearchAttributes {
+		updated.SearchAttributes[key] = valueType
+	}
+	for key, valueType := range request.SearchAttribute {
+		updated.SearchAttributes[key] = int32(valueType)
+	}
+	updated.Version = persisted.Version + 1
+
+	err = adh.clusterMetadataMgr.UpdateClusterMetadata(ctx, &persistence.UpdateClusterMetadataRequest{
+		ClusterMetadata: updated,
+		PreviousVersion: persisted.Version,
+	})
+	if err != nil {
+		if _, ok := err.(*persistence.ConditionFailedError); ok {
+			return nil, adh.error(errClusterMetadataUpdatedConcurrently, scope)
+		}
+		return nil, adh.error(err, scope)
+	}
+
+	adh.searchAttributes.Invalidate()
+	return &admin.AddSearchAttributeResponse{}, nil
+}
+
+// GetReplicationTasks returns the replication tasks of a range of shards in the form they are published to remote
+// clusters. Shards are read one after the other, each through the history host owning it.
+func (adh *AdminHandler) GetReplicationTasks(
+	ctx context.Context, request *admin.GetReplicationTasksRequest) (resp *admin.GetReplicationTasksResponse, retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+
+	scope := metrics.AdminGetReplicationTasksScope
+	sw := adh.startRequestProfile(scope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	minShardID, maxShardID, err := adh.getShardRange(request.MinShardId, request.MaxShardId)
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	pageSize, err := getReplicationTasksPageSize(request.MaximumPageSize)
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	token := &replicationTasksPageToken{ShardID: minShardID}
+	if len(request.NextPageToken) != 0 {
+		if err := json.Unmarshal(request.NextPageToken, token); err != nil ||
+			token.ShardID < minShardID || token.ShardID > maxShardID {
+			return nil, adh.error(errInvalidReplicationToken, scope)
+		}
+	}
+
+	response := &admin.GetReplicationTasksResponse{}
+	encoder := codec.NewThriftRWEncoder()
+	for token != nil && len(response.Tasks) < pageSize {
+		shardResponse, err := adh.history.GetReplicationTasks(ctx, &h.GetReplicationTasksRequest{
+			ShardId: common.Int32Ptr(token.ShardID),
+			// the read level is exclusive while the min task ID is inclusive
+			ReadLevel:       common.Int64Ptr(request.GetMinTaskId() - 1),
+			MaxReadLevel:    request.MaxTaskId,
+			MaximumPageSize: common.Int32Ptr(int32(pageSize - len(response.Tasks))),
+			NextPageToken:   token.NextPageToken,
+		})
+		if err != nil {
+			return nil, adh.error(err, scope)
+		}
+		for _, blob := range shardResponse.Tasks {
+			task := &replicator.ReplicationTask{}
+			if err := encoder.Decode(blob.Data, task); err != nil {
+				return nil, adh.error(err, scope)
+			}
+			response.Tasks = append(response.Tasks, task)
+		}
+
+		switch {
+		case len(shardResponse.NextPageToken) != 0:
+			token.NextPageToken = shardResponse.NextPageToken
+		case token.ShardID < maxShardID:
+			token = &replicationTasksPageToken{ShardID: token.ShardID + 1}
+		default:
+			token = nil
+		}
+	}
+
+	if token != nil {
+		response.NextPageToken, err = json.Marshal(token)
+		if err != nil {
+			return nil, adh.error(err, scope)
+		}
+	}
+	return response, nil
+}
+
+// GetDLQReplicationTasks returns the replication tasks this cluster failed to apply and moved to its DLQ topic.
+// The topic is read partition by partition outside of the consumer group of the replicator, so the tasks stay in
+// the DLQ. Domain replication tasks do not belong to a shard and are returned regardless of the shard range.
+func (adh *AdminHandler) GetDLQReplicationTasks(
+	ctx context.Context, request *admin.GetDLQReplicationTasksRequest) (resp *admin.GetDLQReplicationTasksResponse, retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+
+	scope := metrics.AdminGetDLQReplicationTasksScope
+	sw := adh.startRequestProfile(scope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	minShardID, maxShardID, err := adh.getShardRange(request.MinShardId, request.MaxShardId)
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	pageSize, err := getReplicationTasksPageSize(request.MaximumPageSize)
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	token := &dlqReplicationTasksPageToken{}
+	if len(request.NextPageToken) != 0 {
+		if err := json.Unmarshal(request.NextPageToken, token); err != nil || token.Partition < 0 {
+			return nil, adh.error(errInvalidReplicationToken, scope)
+		}
+	}
+
+	messagingClient := adh.GetMessagingClient()
+	if messagingClient == nil {
+		return nil, adh.error(errReplicationDLQUnavailable, scope)
+	}
+	reader, err := messagingClient.NewDLQReader(adh.GetClusterMetadata().GetCurrentClusterName())
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	defer reader.Close()
+	partitions, err := reader.Partitions()
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	sort.Slice(partitions, func(i, j int) bool { return partitions[i] < partitions[j] })
+
+	response := &admin.GetDLQReplicationTasksResponse{}
+	encoder := codec.NewThriftRWEncoder()
+	for int(token.Partition) < len(partitions) && len(response.Tasks) < pageSize {
+		values, nextOffset, endOfPartition, err := reader.Read(partitions[token.Partition], token.Offset, pageSize-len(response.Tasks))
+		if err != nil {
+			return nil, adh.error(err, scope)
+		}
+		for _, value := range values {
+			task := &replicator.ReplicationTask{}
+			if err := encoder.Decode(value, task); err != nil {
+				return nil, adh.error(err, scope)
+			}
+			if task.ShardId != nil && (task.GetShardId() < minShardID || task.GetShardId() > maxShardID) {
+				continue
+			}
+			response.Tasks = append(response.Tasks, task)
+		}
+
+		token.Offset = nextOffset
+		if endOfPartition {
+			token = &dlqReplicationTasksPageToken{Partition: token.Partition + 1}
+		} else if len(values) == 0 {
+			// the partition did not return anything in time, let the caller retry from the same offset
+			break
+		}
+	}
+
+	if int(token.Partition) < len(partitions) {
+		response.NextPageToken, err = json.Marshal(token)
+		if err != nil {
+			return nil, adh.error(err, scope)
+		}
+	}
+	return response, nil
+}
+
+// GetReplicationConsumerLag returns how far the replicator of this cluster is behind on the replication topics of the
+// remote clusters. The offsets committed by the replicator consumers are sampled twice to compute the rates, so the
+// call lasts at least the sample interval.
+func (adh *AdminHandler) GetReplicationConsumerLag(
+	ctx context.Context, request *admin.GetReplicationConsumerLagRequest) (resp *admin.GetReplicationConsumerLagResponse, retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+
+	scope := metrics.AdminGetReplicationConsumerLagScope
+	sw := adh.startRequestProfile(scope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	sampleInterval, err := getConsumerLagSampleInterval(request.SampleIntervalInSeconds)
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	clusterMetadata := adh.GetClusterMetadata()
+	currentCluster := clusterMetadata.GetCurrentClusterName()
+	var sourceClusters []string
+	if request.GetSourceCluster() != "" {
+		if _, ok := clusterMetadata.GetAllClusterFailoverVersions()[request.GetSourceCluster()]; !ok ||
+			request.GetSourceCluster() == currentCluster {
+			return nil, adh.error(errInvalidSourceCluster, scope)
+		}
+		sourceClusters = []string{request.GetSourceCluster()}
+	} else {
+		for cluster := range clusterMetadata.GetAllClusterFailoverVersions() {
+			if cluster != currentCluster {
+				sourceClusters = append(sourceClusters, cluster)
+			}
+		}
+		sort.Strings(sourceClusters)
+	}
+
+	messagingClient := adh.GetMessagingClient()
+	if messagingClient == nil {
+		return nil, adh.error(errReplicationConsumerLagUnavailable, scope)
+	}
+	readers := make([]messaging.ConsumerLagReader, 0, len(sourceClusters))
+	defer func() {
+		for _, reader := range readers {
+			reader.Close()
+		}
+	}()
+	samples := make([]*messaging.ConsumerLag, 0, len(sourceClusters))
+	for _, cluster := range sourceClusters {
+		reader, err := messagingClient.NewConsumerLagReader(cluster)
+		if err != nil {
+			return nil, adh.error(err, scope)
+		}
+		readers = append(readers, reader)
+		sample, err := reader.GetLag(messaging.GetReplicationConsumerName(currentCluster, cluster))
+		if err != nil {
+			return nil, adh.error(err, scope)
+		}
+		samples = append(samples, sample)
+	}
+
+	timer := time.NewTimer(sampleInterval)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+		return nil, adh.error(ctx.Err(), scope)
+	}
+
+	response := &admin.GetReplicationConsumerLagResponse{}
+	for i, cluster := range sourceClusters {
+		sample, err := readers[i].GetLag(messaging.GetReplicationConsumerName(currentCluster, cluster))
+		if err != nil {
+			return nil, adh.error(err, scope)
+		}
+		stats := messaging.NewConsumerLagStats(cluster, samples[i], sample)
+		response.Consumers = append(response.Consumers, toThriftReplicationConsumerLag(stats))
+	}
+	return response, nil
+}
+
+// getShardRange returns the shard range of a request, which defaults to all the shards of the cluster
+func (adh *AdminHandler) getShardRange(minShardID *int32, maxShardID *int32) (int32, int32, error) {
+	first := int32(0)
+	last := int32(adh.numberOfHistoryShards - 1)
+	if minShardID != nil {
+		first = *minShardID
+	}
+	if maxShardID != nil {
+		last = *maxShardID
+	}
+	if first < 0 || last >= int32(adh.numberOfHistoryShards) || first > last {
+		return 0, 0, errInvalidShardRange
+	}
+	return first, last, nil
+}
+
+func getReplicationTasksPageSize(maximumPageSize *int32) (int, error) {
+	if maximumPageSize == nil {
+		return replicationTasksPageSize, nil
+	}
+	if *maximumPageSize <= 0 {
+		return 0, errInvalidPageSize
+	}
+	return int(*maximumPageSize), nil
+}
+
+func getConsumerLagSampleInterval(sampleIntervalInSeconds *int32) (time.Duration, error) {
+	if sampleIntervalInSeconds == nil {
+		return consumerLagSampleInterval, nil
+	}
+	sampleInterval := time.Duration(*sampleIntervalInSeconds) * time.Second
+	if sampleInterval <= 0 || sampleInterval > maxConsumerLagSampleInterval {
+		return 0, errInvalidSampleInterval
+	}
+	return sampleInterval, nil
+}
+
+func toThriftReplicationConsumerLag(stats *messaging.ConsumerLagStats) *admin.ReplicationConsumerLag {
+	consumerLag := &admin.ReplicationConsumerLag{
+		SourceCluster:  common.StringPtr(stats.SourceCluster),
+		Topic:          common.StringPtr(stats.Topic),
+		ConsumerName:   common.StringPtr(stats.ConsumerName),
+		Lag:            common.Int64Ptr(stats.Lag),
+		ProcessingRate: common.Float64Ptr(stats.ProcessingRate),
+		ProduceRate:    common.Float64Ptr(stats.ProduceRate),
+	}
+	for _, partition := range stats.Partitions {
+		consumerLag.Partitions = append(consumerLag.Partitions, &admin.ReplicationPartitionLag{
+			Partition:       common.Int32Ptr(partition.Partition),
+			CommittedOffset: common.Int64Ptr(partition.CommittedOffset),
+			HighWatermark:   common.Int64Ptr(partition.HighWatermark),
+			Lag:             common.Int64Ptr(partition.Lag),
+		})
+	}
+	return consumerLag
+}
+
+// importHistoryBatches replicates the history batches of the bundle starting at firstEventID into this cluster.
+// Events written by a local domain carry no failover version, such events are imported with the failover version
+// of the domain in this cluster.
+func (adh *AdminHandler) importHistoryBatches(ctx context.Context, domainEntry *cache.DomainCacheEntry,
+	bundle *admin.WorkflowExecutionBundle, firstEventID int64) (int, error) {
+	serializer := persistence.NewHistorySerializer()
+	importedBatchCount := 0
+	for _, historyBatch := range bundle.HistoryBatches {
+		if len(historyBatch.Events) == 0 || historyBatch.Events[len(historyBatch.Events)-1].GetEventId() < firstEventID {
+			continue
+		}
+		for _, event := range historyBatch.Events {
+			if event.GetVersion() == common.EmptyVersion {
+				event.Version = common.Int64Ptr(domainEntry.GetFailoverVersion())
+			}
+		}
+		blob, err := serializer.SerializeBatchEvents(historyBatch.Events, common.EncodingTypeThriftRW)
+		if err != nil {
+			return importedBatchCount, err
+		}
+		err = adh.history.ReplicateRawEvents(ctx, &h.ReplicateRawEventsRequest{
+			DomainUUID:        common.StringPtr(domainEntry.GetInfo().ID),
+			WorkflowExecution: bundle.Execution,
+			ReplicationInfo:   bundle.ReplicationInfo,
+			History: &gen.DataBlob{
+				EncodingType: gen.EncodingTypeThriftRW.Ptr(),
+				Data:         blob.Data,
+			},
+			EventStoreVersion: bundle.EventStoreVersion,
+		})
+		if err != nil {
+			return importedBatchCount, err
+		}
+		importedBatchCount++
+	}
+	return importedBatchCount, nil
+}
+
+// getNextEventID returns the next event ID of the workflow execution in this cluster,
+// or common.FirstEventID if the execution does not exist yet
+func (adh *AdminHandler) getNextEventID(ctx context.Context, domainID string, execution *gen.WorkflowExecution) (int64, error) {
+	response, err := adh.history.GetMutableState(ctx, &h.GetMutableStateRequest{
+		DomainUUID:          common.StringPtr(domainID),
+		Execution:           execution,
+		ExpectedNextEventId: common.Int64Ptr(common.FirstEventID), // common.FirstEventID means no long poll
+	})
+	if err != nil {
+		if _, ok := err.(*gen.EntityNotExistsError); ok {
+			return common.FirstEventID, nil
+		}
+		return 0, err
+	}
+	return response.GetNextEventId(), nil
+}
+
 // startRequestProfile initiates recording of request metrics
 func (adh *AdminHandler) startRequestProfile(scope int) metrics.Stopwatch {
 	adh.startWG.Wait()
@@ -355,3 +1155,40 @@ func (adh *AdminHandler) error(err error, scope int) error {
 		return &gen.InternalServiceError{Message: err.Error()}
 	}
 }
+
+func encodeWorkflowExecutionBundle(bundle *admin.WorkflowExecutionBundle, encodingType gen.EncodingType) (*gen.DataBlob, error) {
+	var data []byte
+	var err error
+	switch encodingType {
+	case gen.EncodingTypeThriftRW:
+		data, err = codec.NewThriftRWEncoder().Encode(bundle)
+	case gen.EncodingTypeJSON:
+		data, err = json.Marshal(bundle)
+	default:
+		return nil, errUnsupportedBundleCodec
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &gen.DataBlob{
+		EncodingType: encodingType.Ptr(),
+		Data:         data,
+	}, nil
+}
+
+func decodeWorkflowExecutionBundle(blob *gen.DataBlob) (*admin.WorkflowExecutionBundle, error) {
+	bundle := &admin.WorkflowExecutionBundle{}
+	var err error
+	switch blob.GetEncodingType() {
+	case gen.EncodingTypeThriftRW:
+		err = codec.NewThriftRWEncoder().Decode(blob.Data, bundle)
+	case gen.EncodingTypeJSON:
+		err = json.Unmarshal(blob.Data, bundle)
+	default:
+		return nil, errUnsupportedBundleCodec
+	}
+	if err != nil {
+		return nil, errInvalidBundle
+	}
+	return bundle, nil
+}
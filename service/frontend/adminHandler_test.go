@@ -0,0 +1,182 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber/cadence/.gen/go/admin"
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/persistence"
+)
+
+type (
+	workflowExecutionBundleSuite struct {
+		suite.Suite
+	}
+)
+
+func TestWorkflowExecutionBundleSuite(t *testing.T) {
+	s := new(workflowExecutionBundleSuite)
+	suite.Run(t, s)
+}
+
+func (s *workflowExecutionBundleSuite) SetupSuite() {
+	if testing.Verbose() {
+		log.SetOutput(os.Stdout)
+	}
+}
+
+func (s *workflowExecutionBundleSuite) TestEncodeDecode_ThriftRW() {
+	s.assertRoundTrip(gen.EncodingTypeThriftRW)
+}
+
+func (s *workflowExecutionBundleSuite) TestEncodeDecode_JSON() {
+	s.assertRoundTrip(gen.EncodingTypeJSON)
+}
+
+func (s *workflowExecutionBundleSuite) TestEncode_UnsupportedEncoding() {
+	blob, err := encodeWorkflowExecutionBundle(s.newBundle(), gen.EncodingType(100))
+	s.Equal(errUnsupportedBundleCodec, err)
+	s.Nil(blob)
+}
+
+func (s *workflowExecutionBundleSuite) TestDecode_UnsupportedEncoding() {
+	bundle, err := decodeWorkflowExecutionBundle(&gen.DataBlob{
+		EncodingType: gen.EncodingType(100).Ptr(),
+		Data:         []byte("bundle"),
+	})
+	s.Equal(errUnsupportedBundleCodec, err)
+	s.Nil(bundle)
+}
+
+func (s *workflowExecutionBundleSuite) TestDecode_InvalidData() {
+	for _, encodingType := range gen.EncodingType_Values() {
+		bundle, err := decodeWorkflowExecutionBundle(&gen.DataBlob{
+			EncodingType: encodingType.Ptr(),
+			Data:         []byte("invalid bundle"),
+		})
+		s.Equal(errInvalidBundle, err)
+		s.Nil(bundle)
+	}
+}
+
+func (s *workflowExecutionBundleSuite) assertRoundTrip(encodingType gen.EncodingType) {
+	bundle := s.newBundle()
+	blob, err := encodeWorkflowExecutionBundle(bundle, encodingType)
+	s.NoError(err)
+	s.Equal(encodingType, blob.GetEncodingType())
+
+	decoded, err := decodeWorkflowExecutionBundle(blob)
+	s.NoError(err)
+	s.True(bundle.Equals(decoded))
+}
+
+func (s *workflowExecutionBundleSuite) newBundle() *admin.WorkflowExecutionBundle {
+	execution := &gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("some random workflow ID"),
+		RunId:      common.StringPtr("3f6a2f53-5e4a-4b0d-9a4e-5c0b0a6bb0f1"),
+	}
+	return &admin.WorkflowExecutionBundle{
+		Domain:            common.StringPtr("some random domain"),
+		Execution:         execution,
+		EventStoreVersion: common.Int32Ptr(persistence.EventStoreVersionV2),
+		ReplicationInfo: map[string]*gen.ReplicationInfo{
+			"active": {
+				Version:     common.Int64Ptr(10),
+				LastEventId: common.Int64Ptr(2),
+			},
+		},
+		HistoryBatches: []*gen.History{
+			{
+				Events: []*gen.HistoryEvent{
+					{
+						EventId:   common.Int64Ptr(common.FirstEventID),
+						EventType: gen.EventTypeWorkflowExecutionStarted.Ptr(),
+						Version:   common.Int64Ptr(10),
+						WorkflowExecutionStartedEventAttributes: &gen.WorkflowExecutionStartedEventAttributes{
+							WorkflowType: &gen.WorkflowType{Name: common.StringPtr("some random workflow type")},
+							TaskList:     &gen.TaskList{Name: common.StringPtr("some random task list")},
+						},
+					},
+				},
+			},
+			{
+				Events: []*gen.HistoryEvent{
+					{
+						EventId:   common.Int64Ptr(common.FirstEventID + 1),
+						EventType: gen.EventTypeDecisionTaskScheduled.Ptr(),
+						Version:   common.Int64Ptr(10),
+					},
+				},
+			},
+		},
+		MutableState: common.StringPtr("{}"),
+		ExecutionInfo: &gen.WorkflowExecutionInfo{
+			Execution:     execution,
+			Type:          &gen.WorkflowType{Name: common.StringPtr("some random workflow type")},
+			StartTime:     common.Int64Ptr(100),
+			HistoryLength: common.Int64Ptr(2),
+		},
+	}
+}
+
+func TestGetShardRange(t *testing.T) {
+	adh := &AdminHandler{numberOfHistoryShards: 4}
+
+	first, last, err := adh.getShardRange(nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(0), first)
+	assert.Equal(t, int32(3), last)
+
+	first, last, err = adh.getShardRange(common.Int32Ptr(1), common.Int32Ptr(1))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), first)
+	assert.Equal(t, int32(1), last)
+
+	_, _, err = adh.getShardRange(common.Int32Ptr(-1), nil)
+	assert.Equal(t, errInvalidShardRange, err)
+	_, _, err = adh.getShardRange(nil, common.Int32Ptr(4))
+	assert.Equal(t, errInvalidShardRange, err)
+	_, _, err = adh.getShardRange(common.Int32Ptr(2), common.Int32Ptr(1))
+	assert.Equal(t, errInvalidShardRange, err)
+}
+
+func TestGetConsumerLagSampleInterval(t *testing.T) {
+	interval, err := getConsumerLagSampleInterval(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, consumerLagSampleInterval, interval)
+
+	interval, err = getConsumerLagSampleInterval(common.Int32Ptr(5))
+	assert.NoError(t, err)
+	assert.Equal(t, 5*time.Second, interval)
+
+	_, err = getConsumerLagSampleInterval(common.Int32Ptr(0))
+	assert.Equal(t, errInvalidSampleInterval, err)
+	_, err = getConsumerLagSampleInterval(common.Int32Ptr(6))
+	assert.Equal(t, errInvalidSampleInterval, err)
+}
@@ -127,7 +127,7 @@ func (handler *DCRedirectionHandlerImpl) DescribeTaskList(
 	request *shared.DescribeTaskListRequest,
 ) (*shared.DescribeTaskListResponse, error) {
 
-	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByName(request.GetDomain())
+	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByName(request.GetDomain(), "DescribeTaskList")
 	if err != nil {
 		return nil, err
 	}
@@ -145,7 +145,7 @@ func (handler *DCRedirectionHandlerImpl) DescribeWorkflowExecution(
 	request *shared.DescribeWorkflowExecutionRequest,
 ) (*shared.DescribeWorkflowExecutionResponse, error) {
 
-	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByName(request.GetDomain())
+	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByName(request.GetDomain(), "DescribeWorkflowExecution")
 	if err != nil {
 		return nil, err
 	}
@@ -157,13 +157,39 @@ func (handler *DCRedirectionHandlerImpl) DescribeWorkflowExecution(
 	return handler.service.GetClientBean().GetRemoteFrontendClient(targetDC).DescribeWorkflowExecution(ctx, request)
 }
 
+// DescribeWorkflowExecutions API call
+func (handler *DCRedirectionHandlerImpl) DescribeWorkflowExecutions(
+	ctx context.Context,
+	request *shared.DescribeWorkflowExecutionsRequest,
+) (*shared.DescribeWorkflowExecutionsResponse, error) {
+
+	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByName(request.GetDomain(), "DescribeWorkflowExecutions")
+	if err != nil {
+		return nil, err
+	}
+
+	if targetDC == handler.currentClusterName {
+		return handler.frontendHandler.DescribeWorkflowExecutions(ctx, request)
+	}
+
+	return handler.service.GetClientBean().GetRemoteFrontendClient(targetDC).DescribeWorkflowExecutions(ctx, request)
+}
+
+// GetSearchAttributes API call
+func (handler *DCRedirectionHandlerImpl) GetSearchAttributes(
+	ctx context.Context,
+) (*shared.GetSearchAttributesResponse, error) {
+
+	return handler.frontendHandler.GetSearchAttributes(ctx)
+}
+
 // GetWorkflowExecutionHistory API call
 func (handler *DCRedirectionHandlerImpl) GetWorkflowExecutionHistory(
 	ctx context.Context,
 	request *shared.GetWorkflowExecutionHistoryRequest,
 ) (*shared.GetWorkflowExecutionHistoryResponse, error) {
 
-	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByName(request.GetDomain())
+	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByName(request.GetDomain(), "GetWorkflowExecutionHistory")
 	if err != nil {
 		return nil, err
 	}
@@ -175,13 +201,49 @@ func (handler *DCRedirectionHandlerImpl) GetWorkflowExecutionHistory(
 	return handler.service.GetClientBean().GetRemoteFrontendClient(targetDC).GetWorkflowExecutionHistory(ctx, request)
 }
 
+// GetWorkflowExecutionStatistics API call
+func (handler *DCRedirectionHandlerImpl) GetWorkflowExecutionStatistics(
+	ctx context.Context,
+	request *shared.GetWorkflowExecutionStatisticsRequest,
+) (*shared.GetWorkflowExecutionStatisticsResponse, error) {
+
+	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByName(request.GetDomain(), "GetWorkflowExecutionStatistics")
+	if err != nil {
+		return nil, err
+	}
+
+	if targetDC == handler.currentClusterName {
+		return handler.frontendHandler.GetWorkflowExecutionStatistics(ctx, request)
+	}
+
+	return handler.service.GetClientBean().GetRemoteFrontendClient(targetDC).GetWorkflowExecutionStatistics(ctx, request)
+}
+
+// CountOpenWorkflowExecutions API call
+func (handler *DCRedirectionHandlerImpl) CountOpenWorkflowExecutions(
+	ctx context.Context,
+	request *shared.CountOpenWorkflowExecutionsRequest,
+) (*shared.CountOpenWorkflowExecutionsResponse, error) {
+
+	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByName(request.GetDomain(), "CountOpenWorkflowExecutions")
+	if err != nil {
+		return nil, err
+	}
+
+	if targetDC == handler.currentClusterName {
+		return handler.frontendHandler.CountOpenWorkflowExecutions(ctx, request)
+	}
+
+	return handler.service.GetClientBean().GetRemoteFrontendClient(targetDC).CountOpenWorkflowExecutions(ctx, request)
+}
+
 // ListClosedWorkflowExecutions API call
 func (handler *DCRedirectionHandlerImpl) ListClosedWorkflowExecutions(
 	ctx context.Context,
 	request *shared.ListClosedWorkflowExecutionsRequest,
 ) (*shared.ListClosedWorkflowExecutionsResponse, error) {
 
-	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByName(request.GetDomain())
+	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByName(request.GetDomain(), "ListClosedWorkflowExecutions")
 	if err != nil {
 		return nil, err
 	}
@@ -199,7 +261,7 @@ func (handler *DCRedirectionHandlerImpl) ListOpenWorkflowExecutions(
 	request *shared.ListOpenWorkflowExecutionsRequest,
 ) (*shared.ListOpenWorkflowExecutionsResponse, error) {
 
-	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByName(request.GetDomain())
+	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByName(request.GetDomain(), "ListOpenWorkflowExecutions")
 	if err != nil {
 		return nil, err
 	}
@@ -217,7 +279,7 @@ func (handler *DCRedirectionHandlerImpl) PollForActivityTask(
 	request *shared.PollForActivityTaskRequest,
 ) (*shared.PollForActivityTaskResponse, error) {
 
-	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByName(request.GetDomain())
+	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByName(request.GetDomain(), "PollForActivityTask")
 	if err != nil {
 		return nil, err
 	}
@@ -235,7 +297,7 @@ func (handler *DCRedirectionHandlerImpl) PollForDecisionTask(
 	request *shared.PollForDecisionTaskRequest,
 ) (*shared.PollForDecisionTaskResponse, error) {
 
-	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByName(request.GetDomain())
+	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByName(request.GetDomain(), "PollForDecisionTask")
 	if err != nil {
 		return nil, err
 	}
@@ -253,7 +315,7 @@ func (handler *DCRedirectionHandlerImpl) QueryWorkflow(
 	request *shared.QueryWorkflowRequest,
 ) (*shared.QueryWorkflowResponse, error) {
 
-	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByName(request.GetDomain())
+	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByName(request.GetDomain(), "QueryWorkflow")
 	if err != nil {
 		return nil, err
 	}
@@ -276,7 +338,7 @@ func (handler *DCRedirectionHandlerImpl) RecordActivityTaskHeartbeat(
 		return nil, err
 	}
 
-	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByID(token.DomainID)
+	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByID(token.DomainID, "RecordActivityTaskHeartbeat")
 	if err != nil {
 		return nil, err
 	}
@@ -294,7 +356,7 @@ func (handler *DCRedirectionHandlerImpl) RecordActivityTaskHeartbeatByID(
 	request *shared.RecordActivityTaskHeartbeatByIDRequest,
 ) (*shared.RecordActivityTaskHeartbeatResponse, error) {
 
-	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByName(request.GetDomain())
+	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByName(request.GetDomain(), "RecordActivityTaskHeartbeatByID")
 	if err != nil {
 		return nil, err
 	}
@@ -312,7 +374,7 @@ func (handler *DCRedirectionHandlerImpl) RequestCancelWorkflowExecution(
 	request *shared.RequestCancelWorkflowExecutionRequest,
 ) error {
 
-	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByName(request.GetDomain())
+	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByName(request.GetDomain(), "RequestCancelWorkflowExecution")
 	if err != nil {
 		return err
 	}
@@ -330,7 +392,7 @@ func (handler *DCRedirectionHandlerImpl) ResetStickyTaskList(
 	request *shared.ResetStickyTaskListRequest,
 ) (*shared.ResetStickyTaskListResponse, error) {
 
-	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByName(request.GetDomain())
+	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByName(request.GetDomain(), "ResetStickyTaskList")
 	if err != nil {
 		return nil, err
 	}
@@ -348,7 +410,7 @@ func (handler *DCRedirectionHandlerImpl) ResetWorkflowExecution(
 	request *shared.ResetWorkflowExecutionRequest,
 ) (*shared.ResetWorkflowExecutionResponse, error) {
 
-	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByName(request.GetDomain())
+	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByName(request.GetDomain(), "ResetWorkflowExecution")
 	if err != nil {
 		return nil, err
 	}
@@ -371,7 +433,7 @@ func (handler *DCRedirectionHandlerImpl) RespondActivityTaskCanceled(
 		return err
 	}
 
-	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByID(token.DomainID)
+	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByID(token.DomainID, "RespondActivityTaskCanceled")
 	if err != nil {
 		return err
 	}
@@ -389,7 +451,7 @@ func (handler *DCRedirectionHandlerImpl) RespondActivityTaskCanceledByID(
 	request *shared.RespondActivityTaskCanceledByIDRequest,
 ) error {
 
-	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByName(request.GetDomain())
+	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByName(request.GetDomain(), "RespondActivityTaskCanceledByID")
 	if err != nil {
 		return err
 	}
@@ -412,7 +474,7 @@ func (handler *DCRedirectionHandlerImpl) RespondActivityTaskCompleted(
 		return err
 	}
 
-	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByID(token.DomainID)
+	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByID(token.DomainID, "RespondActivityTaskCompleted")
 	if err != nil {
 		return err
 	}
@@ -430,7 +492,7 @@ func (handler *DCRedirectionHandlerImpl) RespondActivityTaskCompletedByID(
 	request *shared.RespondActivityTaskCompletedByIDRequest,
 ) error {
 
-	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByName(request.GetDomain())
+	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByName(request.GetDomain(), "RespondActivityTaskCompletedByID")
 	if err != nil {
 		return err
 	}
@@ -453,7 +515,7 @@ func (handler *DCRedirectionHandlerImpl) RespondActivityTaskFailed(
 		return err
 	}
 
-	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByID(token.DomainID)
+	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByID(token.DomainID, "RespondActivityTaskFailed")
 	if err != nil {
 		return err
 	}
@@ -471,7 +533,7 @@ func (handler *DCRedirectionHandlerImpl) RespondActivityTaskFailedByID(
 	request *shared.RespondActivityTaskFailedByIDRequest,
 ) error {
 
-	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByName(request.GetDomain())
+	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByName(request.GetDomain(), "RespondActivityTaskFailedByID")
 	if err != nil {
 		return err
 	}
@@ -494,7 +556,7 @@ func (handler *DCRedirectionHandlerImpl) RespondDecisionTaskCompleted(
 		return nil, err
 	}
 
-	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByID(token.DomainID)
+	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByID(token.DomainID, "RespondDecisionTaskCompleted")
 	if err != nil {
 		return nil, err
 	}
@@ -517,7 +579,7 @@ func (handler *DCRedirectionHandlerImpl) RespondDecisionTaskFailed(
 		return err
 	}
 
-	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByID(token.DomainID)
+	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByID(token.DomainID, "RespondDecisionTaskFailed")
 	if err != nil {
 		return err
 	}
@@ -540,7 +602,7 @@ func (handler *DCRedirectionHandlerImpl) RespondQueryTaskCompleted(
 		return err
 	}
 
-	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByID(token.DomainID)
+	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByID(token.DomainID, "RespondQueryTaskCompleted")
 	if err != nil {
 		return err
 	}
@@ -558,7 +620,7 @@ func (handler *DCRedirectionHandlerImpl) SignalWithStartWorkflowExecution(
 	request *shared.SignalWithStartWorkflowExecutionRequest,
 ) (*shared.StartWorkflowExecutionResponse, error) {
 
-	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByName(request.GetDomain())
+	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByName(request.GetDomain(), "SignalWithStartWorkflowExecution")
 	if err != nil {
 		return nil, err
 	}
@@ -584,7 +646,7 @@ func (handler *DCRedirectionHandlerImpl) SignalWorkflowExecution(
 	request *shared.SignalWorkflowExecutionRequest,
 ) error {
 
-	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByName(request.GetDomain())
+	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByName(request.GetDomain(), "SignalWorkflowExecution")
 	if err != nil {
 		return err
 	}
@@ -608,7 +670,7 @@ func (handler *DCRedirectionHandlerImpl) StartWorkflowExecution(
 	request *shared.StartWorkflowExecutionRequest,
 ) (*shared.StartWorkflowExecutionResponse, error) {
 
-	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByName(request.GetDomain())
+	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByName(request.GetDomain(), "StartWorkflowExecution")
 	if err != nil {
 		return nil, err
 	}
@@ -634,7 +696,7 @@ func (handler *DCRedirectionHandlerImpl) TerminateWorkflowExecution(
 	request *shared.TerminateWorkflowExecutionRequest,
 ) error {
 
-	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByName(request.GetDomain())
+	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByName(request.GetDomain(), "TerminateWorkflowExecution")
 	if err != nil {
 		return err
 	}
@@ -646,6 +708,24 @@ func (handler *DCRedirectionHandlerImpl) TerminateWorkflowExecution(
 	return handler.service.GetClientBean().GetRemoteFrontendClient(targetDC).TerminateWorkflowExecution(ctx, request)
 }
 
+// UpdateWorkflowExecutionLabels API call
+func (handler *DCRedirectionHandlerImpl) UpdateWorkflowExecutionLabels(
+	ctx context.Context,
+	request *shared.UpdateWorkflowExecutionLabelsRequest,
+) error {
+
+	targetDC, err := handler.redirectionPolicy.GetTargetDataCenterByName(request.GetDomain(), "UpdateWorkflowExecutionLabels")
+	if err != nil {
+		return err
+	}
+
+	if targetDC == handler.currentClusterName {
+		return handler.frontendHandler.UpdateWorkflowExecutionLabels(ctx, request)
+	}
+
+	return handler.service.GetClientBean().GetRemoteFrontendClient(targetDC).UpdateWorkflowExecutionLabels(ctx, request)
+}
+
 func (handler *DCRedirectionHandlerImpl) isDomainNotActiveError(err error) (string, bool) {
 	domainNotActiveErr, ok := err.(*shared.DomainNotActiveError)
 	if !ok {
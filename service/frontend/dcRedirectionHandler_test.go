@@ -103,7 +103,7 @@ func (s *dcRedirectionHandlerSuite) SetupTest() {
 	s.mockRemoteFrontendClient = &mocks.FrontendClient{}
 	s.mockClientBean.On("GetRemoteFrontendClient", s.alternativeClusterName).Return(s.mockRemoteFrontendClient)
 	s.service = service.NewTestService(s.mockClusterMetadata, nil, metricsClient, s.mockClientBean, s.logger)
-	s.frontendHandler = NewWorkflowHandler(s.service, s.config, s.mockMetadataMgr, nil, nil, nil, nil, nil)
+	s.frontendHandler = NewWorkflowHandler(s.service, s.config, s.mockMetadataMgr, nil, nil, nil, nil, nil, nil, nil, nil)
 	s.frontendHandler.metricsClient = metricsClient
 	s.frontendHandler.history = s.mockHistoryClient
 	s.frontendHandler.startWG.Done()
@@ -586,7 +586,7 @@ func (s *dcRedirectionHandlerSuite) setupLocalDomain() {
 		TableVersion:   persistence.DomainTableVersionV1,
 	}
 
-	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(domainRecord, nil)
+	s.mockMetadataMgr.On("GetDomain", mock.Anything, mock.Anything).Return(domainRecord, nil)
 }
 
 func (s *dcRedirectionHandlerSuite) setupGlobalDomainWithOneReplicationCluster() {
@@ -605,7 +605,7 @@ func (s *dcRedirectionHandlerSuite) setupGlobalDomainWithOneReplicationCluster()
 		TableVersion:   persistence.DomainTableVersionV1,
 	}
 
-	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(domainRecord, nil)
+	s.mockMetadataMgr.On("GetDomain", mock.Anything, mock.Anything).Return(domainRecord, nil)
 }
 
 func (s *dcRedirectionHandlerSuite) setupGlobalDomainWithTwoReplicationCluster(forwardingEnabled bool, isRecordActive bool) {
@@ -629,7 +629,7 @@ func (s *dcRedirectionHandlerSuite) setupGlobalDomainWithTwoReplicationCluster(f
 		TableVersion:   persistence.DomainTableVersionV1,
 	}
 
-	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(domainRecord, nil)
+	s.mockMetadataMgr.On("GetDomain", mock.Anything, mock.Anything).Return(domainRecord, nil)
 	s.config.EnableDomainNotActiveAutoForwarding = dynamicconfig.GetBoolPropertyFnFilteredByDomain(forwardingEnabled)
 }
 
@@ -22,6 +22,7 @@ package frontend
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/cluster"
@@ -35,13 +36,33 @@ const (
 	DCRedirectionPolicyNoop = "noop"
 	// DCRedirectionPolicyForwarding means forwarding from an DC to another DC
 	DCRedirectionPolicyForwarding = "forwarding"
+	// DCRedirectionPolicySelectedAPIsForwarding means forwarding selected APIs of global domains to the active cluster
+	DCRedirectionPolicySelectedAPIsForwarding = "selected-apis-forwarding"
+
+	// DomainDataKeyForForwardedAPIs is the domain data key overriding the APIs forwarded to the
+	// active cluster by the selected APIs forwarding policy, value is a comma separated list of
+	// API names, "*" forwards all APIs and an empty value disables forwarding for the domain
+	DomainDataKeyForForwardedAPIs = "dcRedirectionForwardedAPIs"
+	// forwardAllAPIs is the wildcard value for forwarded APIs
+	forwardAllAPIs = "*"
 )
 
+// defaultSelectedAPIsForwarding is the list of APIs forwarded by the selected APIs forwarding
+// policy if neither the policy config nor the domain data specify the APIs
+var defaultSelectedAPIsForwarding = []string{
+	"SignalWorkflowExecution",
+	"SignalWithStartWorkflowExecution",
+	"RequestCancelWorkflowExecution",
+	"TerminateWorkflowExecution",
+	"UpdateWorkflowExecutionLabels",
+	"QueryWorkflow",
+}
+
 type (
 	// DCRedirectionPolicy is DC redirection policy interface
 	DCRedirectionPolicy interface {
-		GetTargetDataCenterByName(domainName string) (string, error)
-		GetTargetDataCenterByID(domainName string) (string, error)
+		GetTargetDataCenterByName(domainName string, apiName string) (string, error)
+		GetTargetDataCenterByID(domainID string, apiName string) (string, error)
 	}
 
 	// NoopRedirectionPolicy is DC redirection policy which does nothing
@@ -57,6 +78,15 @@ type (
 		toDC        string
 		domainCache cache.DomainCache
 	}
+
+	// SelectedAPIsForwardingRedirectionPolicy is DC redirection policy which forwards
+	// selected APIs of effectively global domains to the active cluster of the domain,
+	// the selected APIs can be overridden per domain through the domain data
+	SelectedAPIsForwardingRedirectionPolicy struct {
+		currentClusterName string
+		selectedAPIs       map[string]struct{}
+		domainCache        cache.DomainCache
+	}
 )
 
 // RedirectionPolicyGenerator generate corresponding redirection policy
@@ -77,6 +107,14 @@ func RedirectionPolicyGenerator(clusterMetadata cluster.Metadata,
 		return NewForwardingDCRedirectionPolicy(
 			currentClusterName, policy.ToDC, domainCache,
 		)
+	case DCRedirectionPolicySelectedAPIsForwarding:
+		selectedAPIs := policy.SelectedAPIs
+		if len(selectedAPIs) == 0 {
+			selectedAPIs = defaultSelectedAPIsForwarding
+		}
+		return NewSelectedAPIsForwardingPolicy(
+			clusterMetadata.GetCurrentClusterName(), selectedAPIs, domainCache,
+		)
 	default:
 		panic(fmt.Sprintf("Unknown DC redirection policy %v", policy.Policy))
 	}
@@ -90,12 +128,12 @@ func NewNoopRedirectionPolicy(currentClusterName string) *NoopRedirectionPolicy
 }
 
 // GetTargetDataCenterByName get target cluster name by domain Name
-func (policy *NoopRedirectionPolicy) GetTargetDataCenterByName(domainName string) (string, error) {
+func (policy *NoopRedirectionPolicy) GetTargetDataCenterByName(domainName string, apiName string) (string, error) {
 	return policy.currentClusterName, nil
 }
 
 // GetTargetDataCenterByID get target cluster name by domain ID
-func (policy *NoopRedirectionPolicy) GetTargetDataCenterByID(domainID string) (string, error) {
+func (policy *NoopRedirectionPolicy) GetTargetDataCenterByID(domainID string, apiName string) (string, error) {
 	return policy.currentClusterName, nil
 }
 
@@ -109,7 +147,7 @@ func NewForwardingDCRedirectionPolicy(fromDC string, toDC string, domainCache ca
 }
 
 // GetTargetDataCenterByName get target cluster name by domain Name
-func (policy *ForwardingDCRedirectionPolicy) GetTargetDataCenterByName(domainName string) (string, error) {
+func (policy *ForwardingDCRedirectionPolicy) GetTargetDataCenterByName(domainName string, apiName string) (string, error) {
 	domainEntry, err := policy.domainCache.GetDomain(domainName)
 	if err != nil {
 		return "", err
@@ -119,7 +157,7 @@ func (policy *ForwardingDCRedirectionPolicy) GetTargetDataCenterByName(domainNam
 }
 
 // GetTargetDataCenterByID get target cluster name by domain ID
-func (policy *ForwardingDCRedirectionPolicy) GetTargetDataCenterByID(domainID string) (string, error) {
+func (policy *ForwardingDCRedirectionPolicy) GetTargetDataCenterByID(domainID string, apiName string) (string, error) {
 	domainEntry, err := policy.domainCache.GetDomainByID(domainID)
 	if err != nil {
 		return "", err
@@ -151,3 +189,72 @@ func (policy *ForwardingDCRedirectionPolicy) getTargetDataCenter(domainEntry *ca
 	}
 	return policy.fromDC
 }
+
+// NewSelectedAPIsForwardingPolicy creates a data center redirection policy forwarding selected API calls
+func NewSelectedAPIsForwardingPolicy(currentClusterName string, selectedAPIs []string, domainCache cache.DomainCache) *SelectedAPIsForwardingRedirectionPolicy {
+	return &SelectedAPIsForwardingRedirectionPolicy{
+		currentClusterName: currentClusterName,
+		selectedAPIs:       toAPISet(selectedAPIs),
+		domainCache:        domainCache,
+	}
+}
+
+// GetTargetDataCenterByName get target cluster name by domain Name
+func (policy *SelectedAPIsForwardingRedirectionPolicy) GetTargetDataCenterByName(domainName string, apiName string) (string, error) {
+	domainEntry, err := policy.domainCache.GetDomain(domainName)
+	if err != nil {
+		return "", err
+	}
+
+	return policy.getTargetDataCenter(domainEntry, apiName), nil
+}
+
+// GetTargetDataCenterByID get target cluster name by domain ID
+func (policy *SelectedAPIsForwardingRedirectionPolicy) GetTargetDataCenterByID(domainID string, apiName string) (string, error) {
+	domainEntry, err := policy.domainCache.GetDomainByID(domainID)
+	if err != nil {
+		return "", err
+	}
+
+	return policy.getTargetDataCenter(domainEntry, apiName), nil
+}
+
+func (policy *SelectedAPIsForwardingRedirectionPolicy) getTargetDataCenter(domainEntry *cache.DomainCacheEntry, apiName string) string {
+	if !domainEntry.IsGlobalDomain() {
+		return policy.currentClusterName
+	}
+
+	if len(domainEntry.GetReplicationConfig().Clusters) == 1 {
+		// do not do dc redirection if domain is only targeting at 1 dc (effectively local domain)
+		return policy.currentClusterName
+	}
+
+	if !policy.isAPIForwarded(domainEntry, apiName) {
+		return policy.currentClusterName
+	}
+	return domainEntry.GetReplicationConfig().ActiveClusterName
+}
+
+func (policy *SelectedAPIsForwardingRedirectionPolicy) isAPIForwarded(domainEntry *cache.DomainCacheEntry, apiName string) bool {
+	selectedAPIs := policy.selectedAPIs
+	if override, ok := domainEntry.GetInfo().Data[DomainDataKeyForForwardedAPIs]; ok {
+		selectedAPIs = toAPISet(strings.Split(override, ","))
+	}
+
+	if _, ok := selectedAPIs[forwardAllAPIs]; ok {
+		return true
+	}
+	_, ok := selectedAPIs[apiName]
+	return ok
+}
+
+func toAPISet(apis []string) map[string]struct{} {
+	result := make(map[string]struct{}, len(apis))
+	for _, api := range apis {
+		api = strings.TrimSpace(api)
+		if len(api) > 0 {
+			result[api] = struct{}{}
+		}
+	}
+	return result
+}
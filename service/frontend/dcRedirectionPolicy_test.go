@@ -52,6 +52,16 @@ type (
 		mockClusterMetadata *mocks.ClusterMetadata
 		policy              *ForwardingDCRedirectionPolicy
 	}
+
+	selectedAPIsForwardingPolicySuite struct {
+		logger bark.Logger
+		suite.Suite
+		currentClusterName  string
+		activeClusterName   string
+		mockMetadataMgr     *mocks.MetadataManager
+		mockClusterMetadata *mocks.ClusterMetadata
+		policy              *SelectedAPIsForwardingRedirectionPolicy
+	}
 )
 
 func TestNoopDCRedirectionPolicySuite(t *testing.T) {
@@ -82,11 +92,11 @@ func (s *noopDCRedirectionPolicySuite) TestGetTargetDataCenter() {
 	domainName := "some random domain name"
 	domainID := "some random domain ID"
 
-	targetCluster, err := s.policy.GetTargetDataCenterByID(domainID)
+	targetCluster, err := s.policy.GetTargetDataCenterByID(domainID, "StartWorkflowExecution")
 	s.Nil(err)
 	s.Equal(s.currentClusterName, targetCluster)
 
-	targetCluster, err = s.policy.GetTargetDataCenterByName(domainName)
+	targetCluster, err = s.policy.GetTargetDataCenterByName(domainName, "StartWorkflowExecution")
 	s.Nil(err)
 	s.Equal(s.currentClusterName, targetCluster)
 }
@@ -146,13 +156,13 @@ func (s *forwardingDCRedirectionPolicySuite) TestGetTargetDataCenter_LocalDomain
 		TableVersion:   persistence.DomainTableVersionV1,
 	}
 
-	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(domainRecord, nil)
+	s.mockMetadataMgr.On("GetDomain", mock.Anything, mock.Anything).Return(domainRecord, nil)
 
-	targetCluster, err := s.policy.GetTargetDataCenterByID(domainID)
+	targetCluster, err := s.policy.GetTargetDataCenterByID(domainID, "StartWorkflowExecution")
 	s.Nil(err)
 	s.Equal(s.fromDC, targetCluster)
 
-	targetCluster, err = s.policy.GetTargetDataCenterByName(domainName)
+	targetCluster, err = s.policy.GetTargetDataCenterByName(domainName, "StartWorkflowExecution")
 	s.Nil(err)
 	s.Equal(s.fromDC, targetCluster)
 }
@@ -173,13 +183,13 @@ func (s *forwardingDCRedirectionPolicySuite) TestGetTargetDataCenter_GlobalDomai
 		TableVersion:   persistence.DomainTableVersionV1,
 	}
 
-	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(domainRecord, nil)
+	s.mockMetadataMgr.On("GetDomain", mock.Anything, mock.Anything).Return(domainRecord, nil)
 
-	targetCluster, err := s.policy.GetTargetDataCenterByID(domainID)
+	targetCluster, err := s.policy.GetTargetDataCenterByID(domainID, "StartWorkflowExecution")
 	s.Nil(err)
 	s.Equal(s.fromDC, targetCluster)
 
-	targetCluster, err = s.policy.GetTargetDataCenterByName(domainName)
+	targetCluster, err = s.policy.GetTargetDataCenterByName(domainName, "StartWorkflowExecution")
 	s.Nil(err)
 	s.Equal(s.fromDC, targetCluster)
 }
@@ -201,13 +211,13 @@ func (s *forwardingDCRedirectionPolicySuite) TestGetTargetDataCenter_GlobalDomai
 		TableVersion:   persistence.DomainTableVersionV1,
 	}
 
-	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(domainRecord, nil)
+	s.mockMetadataMgr.On("GetDomain", mock.Anything, mock.Anything).Return(domainRecord, nil)
 
-	targetCluster, err := s.policy.GetTargetDataCenterByID(domainID)
+	targetCluster, err := s.policy.GetTargetDataCenterByID(domainID, "StartWorkflowExecution")
 	s.Nil(err)
 	s.Equal(s.fromDC, targetCluster)
 
-	targetCluster, err = s.policy.GetTargetDataCenterByName(domainName)
+	targetCluster, err = s.policy.GetTargetDataCenterByName(domainName, "StartWorkflowExecution")
 	s.Nil(err)
 	s.Equal(s.fromDC, targetCluster)
 }
@@ -229,13 +239,136 @@ func (s *forwardingDCRedirectionPolicySuite) TestGetTargetDataCenter_GlobalDomai
 		TableVersion:   persistence.DomainTableVersionV1,
 	}
 
-	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(domainRecord, nil)
+	s.mockMetadataMgr.On("GetDomain", mock.Anything, mock.Anything).Return(domainRecord, nil)
 
-	targetCluster, err := s.policy.GetTargetDataCenterByID(domainID)
+	targetCluster, err := s.policy.GetTargetDataCenterByID(domainID, "StartWorkflowExecution")
 	s.Nil(err)
 	s.Equal(s.toDC, targetCluster)
 
-	targetCluster, err = s.policy.GetTargetDataCenterByName(domainName)
+	targetCluster, err = s.policy.GetTargetDataCenterByName(domainName, "StartWorkflowExecution")
 	s.Nil(err)
 	s.Equal(s.toDC, targetCluster)
 }
+
+func TestSelectedAPIsForwardingPolicySuite(t *testing.T) {
+	s := new(selectedAPIsForwardingPolicySuite)
+	suite.Run(t, s)
+}
+
+func (s *selectedAPIsForwardingPolicySuite) SetupSuite() {
+	if testing.Verbose() {
+		log.SetOutput(os.Stdout)
+	}
+}
+
+func (s *selectedAPIsForwardingPolicySuite) SetupTest() {
+	s.currentClusterName = cluster.TestCurrentClusterName
+	s.activeClusterName = cluster.TestAlternativeClusterName
+	log2 := log.New()
+	log2.Level = log.DebugLevel
+	s.logger = bark.NewLoggerFromLogrus(log2)
+	s.mockMetadataMgr = &mocks.MetadataManager{}
+	s.mockClusterMetadata = &mocks.ClusterMetadata{}
+	s.mockClusterMetadata.On("IsGlobalDomainEnabled").Return(true)
+	domainCache := cache.NewDomainCache(
+		s.mockMetadataMgr,
+		s.mockClusterMetadata,
+		metrics.NewClient(tally.NoopScope, metrics.Frontend),
+		s.logger,
+	)
+	s.policy = NewSelectedAPIsForwardingPolicy(
+		s.currentClusterName, defaultSelectedAPIsForwarding, domainCache,
+	)
+}
+
+func (s *selectedAPIsForwardingPolicySuite) setupGlobalDomain(data map[string]string) (string, string) {
+	domainName := "some random domain name"
+	domainID := "some random domain ID"
+	domainRecord := &persistence.GetDomainResponse{
+		Info:   &persistence.DomainInfo{ID: domainID, Name: domainName, Data: data},
+		Config: &persistence.DomainConfig{},
+		ReplicationConfig: &persistence.DomainReplicationConfig{
+			ActiveClusterName: s.activeClusterName,
+			Clusters: []*persistence.ClusterReplicationConfig{
+				&persistence.ClusterReplicationConfig{ClusterName: s.currentClusterName},
+				&persistence.ClusterReplicationConfig{ClusterName: s.activeClusterName},
+			},
+		},
+		IsGlobalDomain: true,
+		TableVersion:   persistence.DomainTableVersionV1,
+	}
+	s.mockMetadataMgr.On("GetDomain", mock.Anything, mock.Anything).Return(domainRecord, nil)
+	return domainName, domainID
+}
+
+func (s *selectedAPIsForwardingPolicySuite) TestGetTargetDataCenter_LocalDomain() {
+	domainName := "some random domain name"
+	domainID := "some random domain ID"
+	domainRecord := &persistence.GetDomainResponse{
+		Info:   &persistence.DomainInfo{ID: domainID, Name: domainName},
+		Config: &persistence.DomainConfig{},
+		ReplicationConfig: &persistence.DomainReplicationConfig{
+			ActiveClusterName: s.currentClusterName,
+			Clusters: []*persistence.ClusterReplicationConfig{
+				&persistence.ClusterReplicationConfig{ClusterName: s.currentClusterName},
+			},
+		},
+		IsGlobalDomain: false,
+		TableVersion:   persistence.DomainTableVersionV1,
+	}
+	s.mockMetadataMgr.On("GetDomain", mock.Anything, mock.Anything).Return(domainRecord, nil)
+
+	targetCluster, err := s.policy.GetTargetDataCenterByName(domainName, "SignalWorkflowExecution")
+	s.Nil(err)
+	s.Equal(s.currentClusterName, targetCluster)
+}
+
+func (s *selectedAPIsForwardingPolicySuite) TestGetTargetDataCenter_GlobalDomain_DefaultAPIs() {
+	domainName, domainID := s.setupGlobalDomain(nil)
+
+	targetCluster, err := s.policy.GetTargetDataCenterByName(domainName, "SignalWorkflowExecution")
+	s.Nil(err)
+	s.Equal(s.activeClusterName, targetCluster)
+
+	targetCluster, err = s.policy.GetTargetDataCenterByID(domainID, "QueryWorkflow")
+	s.Nil(err)
+	s.Equal(s.activeClusterName, targetCluster)
+
+	targetCluster, err = s.policy.GetTargetDataCenterByName(domainName, "StartWorkflowExecution")
+	s.Nil(err)
+	s.Equal(s.currentClusterName, targetCluster)
+}
+
+func (s *selectedAPIsForwardingPolicySuite) TestGetTargetDataCenter_GlobalDomain_DomainOverride() {
+	domainName, _ := s.setupGlobalDomain(map[string]string{
+		DomainDataKeyForForwardedAPIs: "StartWorkflowExecution, DescribeWorkflowExecution",
+	})
+
+	targetCluster, err := s.policy.GetTargetDataCenterByName(domainName, "StartWorkflowExecution")
+	s.Nil(err)
+	s.Equal(s.activeClusterName, targetCluster)
+
+	targetCluster, err = s.policy.GetTargetDataCenterByName(domainName, "DescribeWorkflowExecution")
+	s.Nil(err)
+	s.Equal(s.activeClusterName, targetCluster)
+
+	targetCluster, err = s.policy.GetTargetDataCenterByName(domainName, "SignalWorkflowExecution")
+	s.Nil(err)
+	s.Equal(s.currentClusterName, targetCluster)
+}
+
+func (s *selectedAPIsForwardingPolicySuite) TestGetTargetDataCenter_GlobalDomain_DomainOverrideDisabled() {
+	domainName, _ := s.setupGlobalDomain(map[string]string{DomainDataKeyForForwardedAPIs: ""})
+
+	targetCluster, err := s.policy.GetTargetDataCenterByName(domainName, "SignalWorkflowExecution")
+	s.Nil(err)
+	s.Equal(s.currentClusterName, targetCluster)
+}
+
+func (s *selectedAPIsForwardingPolicySuite) TestGetTargetDataCenter_GlobalDomain_DomainOverrideAll() {
+	domainName, _ := s.setupGlobalDomain(map[string]string{DomainDataKeyForForwardedAPIs: forwardAllAPIs})
+
+	targetCluster, err := s.policy.GetTargetDataCenterByName(domainName, "PollForDecisionTask")
+	s.Nil(err)
+	s.Equal(s.activeClusterName, targetCluster)
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	gen "github.com/uber/cadence/.gen/go/shared"
+)
+
+type (
+	// DomainAdmissionHandler is invoked by RegisterDomain and UpdateDomain before any change is persisted,
+	// so deployments can enforce their own rules on domains. A returned error rejects the request.
+	DomainAdmissionHandler interface {
+		AdmitRegisterDomain(ctx context.Context, request *gen.RegisterDomainRequest) error
+		AdmitUpdateDomain(ctx context.Context, request *gen.UpdateDomainRequest) error
+	}
+
+	// ConfigDomainAdmissionHandler is the domain admission handler enforcing the
+	// domain naming, retention, owner email and cluster placement rules of the dynamic config
+	ConfigDomainAdmissionHandler struct {
+		config *Config
+	}
+)
+
+var _ DomainAdmissionHandler = (*ConfigDomainAdmissionHandler)(nil)
+
+// NewConfigDomainAdmissionHandler creates a domain admission handler enforcing the rules of the dynamic config
+func NewConfigDomainAdmissionHandler(config *Config) *ConfigDomainAdmissionHandler {
+	return &ConfigDomainAdmissionHandler{
+		config: config,
+	}
+}
+
+// AdmitRegisterDomain validates the domain to be registered against the configured rules
+func (h *ConfigDomainAdmissionHandler) AdmitRegisterDomain(ctx context.Context, request *gen.RegisterDomainRequest) error {
+	if err := h.validatePattern("name", request.GetName(), h.config.DomainNamePattern()); err != nil {
+		return err
+	}
+	if err := h.validateRetention(request.GetWorkflowExecutionRetentionPeriodInDays()); err != nil {
+		return err
+	}
+	if err := h.validatePattern("owner email", request.GetOwnerEmail(), h.config.DomainOwnerEmailPattern()); err != nil {
+		return err
+	}
+
+	var clusters []string
+	if request.ActiveClusterName != nil {
+		clusters = append(clusters, request.GetActiveClusterName())
+	}
+	for _, cluster := range request.Clusters {
+		clusters = append(clusters, cluster.GetClusterName())
+	}
+	return h.validateClusters(clusters)
+}
+
+// AdmitUpdateDomain validates the updated attributes of the domain against the configured rules
+func (h *ConfigDomainAdmissionHandler) AdmitUpdateDomain(ctx context.Context, request *gen.UpdateDomainRequest) error {
+	if request.UpdatedInfo != nil && request.UpdatedInfo.OwnerEmail != nil {
+		if err := h.validatePattern("owner email", request.UpdatedInfo.GetOwnerEmail(), h.config.DomainOwnerEmailPattern()); err != nil {
+			return err
+		}
+	}
+	if request.Configuration != nil && request.Configuration.WorkflowExecutionRetentionPeriodInDays != nil {
+		if err := h.validateRetention(request.Configuration.GetWorkflowExecutionRetentionPeriodInDays()); err != nil {
+			return err
+		}
+	}
+
+	if request.ReplicationConfiguration == nil {
+		return nil
+	}
+	var clusters []string
+	if request.ReplicationConfiguration.ActiveClusterName != nil {
+		clusters = append(clusters, request.ReplicationConfiguration.GetActiveClusterName())
+	}
+	for _, cluster := range request.ReplicationConfiguration.Clusters {
+		clusters = append(clusters, cluster.GetClusterName())
+	}
+	return h.validateClusters(clusters)
+}
+
+func (h *ConfigDomainAdmissionHandler) validatePattern(attribute string, value string, pattern string) error {
+	if pattern == "" {
+		return nil
+	}
+
+	// the whole value has to match the pattern
+	re, err := regexp.Compile("^(?:" + pattern + ")$")
+	if err != nil {
+		return &gen.InternalServiceError{
+			Message: fmt.Sprintf("Invalid domain %v pattern %q: %v", attribute, pattern, err),
+		}
+	}
+	if !re.MatchString(value) {
+		return newDomainAdmissionError("domain %v %q does not match pattern %q", attribute, value, pattern)
+	}
+	return nil
+}
+
+func (h *ConfigDomainAdmissionHandler) validateRetention(retentionDays int32) error {
+	if minDays := h.config.DomainMinRetentionDays(); minDays > 0 && int(retentionDays) < minDays {
+		return newDomainAdmissionError("retention of %v days is less than the minimum of %v days", retentionDays, minDays)
+	}
+	if maxDays := h.config.DomainMaxRetentionDays(); maxDays > 0 && int(retentionDays) > maxDays {
+		return newDomainAdmissionError("retention of %v days is more than the maximum of %v days", retentionDays, maxDays)
+	}
+	return nil
+}
+
+func (h *ConfigDomainAdmissionHandler) validateClusters(clusters []string) error {
+	allowedClusters := h.config.DomainAllowedClusters()
+	if allowedClusters == "" {
+		return nil
+	}
+
+	allowed := make(map[string]struct{})
+	for _, cluster := range strings.Split(allowedClusters, ",") {
+		allowed[strings.TrimSpace(cluster)] = struct{}{}
+	}
+	for _, cluster := range clusters {
+		if _, ok := allowed[cluster]; !ok {
+			return newDomainAdmissionError("cluster %v is not allowed for domains", cluster)
+		}
+	}
+	return nil
+}
+
+func newDomainAdmissionError(format string, args ...interface{}) error {
+	return &gen.BadRequestError{
+		Message: "Domain rejected by admission control: " + fmt.Sprintf(format, args...),
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	configDomainAdmissionHandlerSuite struct {
+		suite.Suite
+		config  *Config
+		handler *ConfigDomainAdmissionHandler
+	}
+)
+
+func TestConfigDomainAdmissionHandlerSuite(t *testing.T) {
+	s := new(configDomainAdmissionHandlerSuite)
+	suite.Run(t, s)
+}
+
+func (s *configDomainAdmissionHandlerSuite) SetupSuite() {
+	if testing.Verbose() {
+		log.SetOutput(os.Stdout)
+	}
+}
+
+func (s *configDomainAdmissionHandlerSuite) SetupTest() {
+	s.config = NewConfig(dynamicconfig.NewCollection(dynamicconfig.NewNopClient(), bark.NewNopLogger()), 0, false)
+	s.config.DomainNamePattern = dynamicconfig.GetStringPropertyFn("team-[a-z]+")
+	s.config.DomainMinRetentionDays = dynamicconfig.GetIntPropertyFn(1)
+	s.config.DomainMaxRetentionDays = dynamicconfig.GetIntPropertyFn(30)
+	s.config.DomainOwnerEmailPattern = dynamicconfig.GetStringPropertyFn(".+@example\\.com")
+	s.config.DomainAllowedClusters = dynamicconfig.GetStringPropertyFn("active, standby")
+	s.handler = NewConfigDomainAdmissionHandler(s.config)
+}
+
+func (s *configDomainAdmissionHandlerSuite) TestAdmitRegisterDomain_Success() {
+	err := s.handler.AdmitRegisterDomain(context.Background(), s.newRegisterRequest())
+	s.NoError(err)
+}
+
+func (s *configDomainAdmissionHandlerSuite) TestAdmitRegisterDomain_NoRules() {
+	s.handler = NewConfigDomainAdmissionHandler(
+		NewConfig(dynamicconfig.NewCollection(dynamicconfig.NewNopClient(), bark.NewNopLogger()), 0, false),
+	)
+	request := s.newRegisterRequest()
+	request.Name = common.StringPtr("Any_Domain")
+	request.OwnerEmail = nil
+	request.WorkflowExecutionRetentionPeriodInDays = common.Int32Ptr(365)
+	request.ActiveClusterName = common.StringPtr("other")
+
+	err := s.handler.AdmitRegisterDomain(context.Background(), request)
+	s.NoError(err)
+}
+
+func (s *configDomainAdmissionHandlerSuite) TestAdmitRegisterDomain_Rejected() {
+	testCases := []func(request *gen.RegisterDomainRequest){
+		func(request *gen.RegisterDomainRequest) { request.Name = common.StringPtr("some-team-name") },
+		func(request *gen.RegisterDomainRequest) { request.OwnerEmail = common.StringPtr("owner@example.org") },
+		func(request *gen.RegisterDomainRequest) {
+			request.WorkflowExecutionRetentionPeriodInDays = common.Int32Ptr(0)
+		},
+		func(request *gen.RegisterDomainRequest) {
+			request.WorkflowExecutionRetentionPeriodInDays = common.Int32Ptr(31)
+		},
+		func(request *gen.RegisterDomainRequest) { request.ActiveClusterName = common.StringPtr("other") },
+		func(request *gen.RegisterDomainRequest) {
+			request.Clusters = append(request.Clusters, &gen.ClusterReplicationConfiguration{ClusterName: common.StringPtr("other")})
+		},
+	}
+
+	for _, modify := range testCases {
+		request := s.newRegisterRequest()
+		modify(request)
+		err := s.handler.AdmitRegisterDomain(context.Background(), request)
+		s.IsType(&gen.BadRequestError{}, err)
+	}
+}
+
+func (s *configDomainAdmissionHandlerSuite) TestAdmitRegisterDomain_InvalidPattern() {
+	s.config.DomainNamePattern = dynamicconfig.GetStringPropertyFn("team-[")
+
+	err := s.handler.AdmitRegisterDomain(context.Background(), s.newRegisterRequest())
+	s.IsType(&gen.InternalServiceError{}, err)
+}
+
+func (s *configDomainAdmissionHandlerSuite) TestAdmitUpdateDomain() {
+	// attributes not being updated are not validated
+	err := s.handler.AdmitUpdateDomain(context.Background(), &gen.UpdateDomainRequest{
+		Name: common.StringPtr("legacy_domain"),
+	})
+	s.NoError(err)
+
+	err = s.handler.AdmitUpdateDomain(context.Background(), &gen.UpdateDomainRequest{
+		Name: common.StringPtr("team-payments"),
+		UpdatedInfo: &gen.UpdateDomainInfo{
+			OwnerEmail: common.StringPtr("payments@example.com"),
+		},
+		Configuration: &gen.DomainConfiguration{
+			WorkflowExecutionRetentionPeriodInDays: common.Int32Ptr(7),
+		},
+		ReplicationConfiguration: &gen.DomainReplicationConfiguration{
+			ActiveClusterName: common.StringPtr("standby"),
+		},
+	})
+	s.NoError(err)
+
+	err = s.handler.AdmitUpdateDomain(context.Background(), &gen.UpdateDomainRequest{
+		Name: common.StringPtr("team-payments"),
+		Configuration: &gen.DomainConfiguration{
+			WorkflowExecutionRetentionPeriodInDays: common.Int32Ptr(90),
+		},
+	})
+	s.IsType(&gen.BadRequestError{}, err)
+
+	err = s.handler.AdmitUpdateDomain(context.Background(), &gen.UpdateDomainRequest{
+		Name: common.StringPtr("team-payments"),
+		ReplicationConfiguration: &gen.DomainReplicationConfiguration{
+			ActiveClusterName: common.StringPtr("other"),
+		},
+	})
+	s.IsType(&gen.BadRequestError{}, err)
+}
+
+func (s *configDomainAdmissionHandlerSuite) newRegisterRequest() *gen.RegisterDomainRequest {
+	return &gen.RegisterDomainRequest{
+		Name:                                   common.StringPtr("team-payments"),
+		OwnerEmail:                             common.StringPtr("payments@example.com"),
+		WorkflowExecutionRetentionPeriodInDays: common.Int32Ptr(7),
+		ActiveClusterName:                      common.StringPtr("active"),
+		Clusters: []*gen.ClusterReplicationConfiguration{
+			{ClusterName: common.StringPtr("active")},
+			{ClusterName: common.StringPtr("standby")},
+		},
+	}
+}
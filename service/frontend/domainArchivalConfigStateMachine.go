@@ -33,18 +33,21 @@ import (
 
 type (
 	// archivalState represents the state of archival config
-	// the only invalid state is {bucket="", status=enabled}
-	// once bucket is set it is immutable
+	// enabled, paused and migrating states require bucket to be set
+	// targetBucket is set if and only if status is migrating, and it must differ from bucket
+	// once bucket is set it can only be changed by migrating to a new bucket
 	// the initial state is {bucket="", status=disabled}, this is what all domains initially default to
 	archivalState struct {
-		bucket string
-		status shared.ArchivalStatus
+		bucket       string
+		targetBucket string
+		status       shared.ArchivalStatus
 	}
 
 	// archivalEvent represents a change request to archival config state
 	// the only restriction placed on events is that defaultBucket is not empty
 	// setting requestedBucket to empty string means user is not attempting to set bucket
-	// status can be nil, enabled, or disabled (nil indicates no update by user is being attempted)
+	// status can be nil, enabled, disabled or paused (nil indicates no update by user is being attempted)
+	// status can never be migrating, a migration is started by requesting a new bucket while archival is enabled
 	archivalEvent struct {
 		defaultBucket string
 		bucket        string
@@ -55,6 +58,7 @@ type (
 // the following errors represents impossible code states that should never occur
 var (
 	errInvalidState            = &shared.BadRequestError{Message: "Encountered illegal state: archival is enabled but bucket is not set (should be impossible)"}
+	errInvalidMigratingState   = &shared.BadRequestError{Message: "Encountered illegal state: archival is migrating but target bucket is not valid (should be impossible)"}
 	errInvalidEvent            = &shared.BadRequestError{Message: "Encountered illegal event: default bucket is not set (should be impossible)"}
 	errCannotHandleStateChange = &shared.BadRequestError{Message: "Encountered current state and event that cannot be handled (should be impossible)"}
 )
@@ -64,6 +68,9 @@ var (
 	errDisallowedBucketMetadata = &shared.BadRequestError{Message: "Cannot set bucket owner or bucket retention (must update bucket manually)"}
 	errBucketNameUpdate         = &shared.BadRequestError{Message: "Cannot update existing bucket name"}
 	errBucketDoesNotExist       = &shared.BadRequestError{Message: "Bucket does not exist"}
+	errMigratingStatusUpdate    = &shared.BadRequestError{Message: "Cannot set archival status to migrating (update bucket name while archival is enabled instead)"}
+	errMigrationInProgress      = &shared.BadRequestError{Message: "Cannot update archival config while bucket migration is in progress"}
+	errPauseNotEnabled          = &shared.BadRequestError{Message: "Cannot pause archival which is not enabled"}
 )
 
 func neverEnabledState() *archivalState {
@@ -107,20 +114,30 @@ func (e *archivalEvent) validate() error {
 	if len(e.defaultBucket) == 0 {
 		return errInvalidEvent
 	}
+	if e.status != nil && *e.status == shared.ArchivalStatusMigrating {
+		return errMigratingStatusUpdate
+	}
 	return nil
 }
 
 func (s *archivalState) validate() error {
-	if s.status == shared.ArchivalStatusEnabled && len(s.bucket) == 0 {
+	if s.status != shared.ArchivalStatusDisabled && len(s.bucket) == 0 {
 		return errInvalidState
 	}
+	if s.status == shared.ArchivalStatusMigrating && (len(s.targetBucket) == 0 || s.targetBucket == s.bucket) {
+		return errInvalidMigratingState
+	}
+	if s.status != shared.ArchivalStatusMigrating && len(s.targetBucket) != 0 {
+		return errInvalidMigratingState
+	}
 	return nil
 }
 
 func (s *archivalState) getNextState(ctx context.Context, blobstoreClient blobstore.Client, e *archivalEvent) (nextState *archivalState, changed bool, err error) {
 	defer func() {
-		// ensure that any existing bucket name was not mutated
-		if nextState != nil && len(s.bucket) != 0 && s.bucket != nextState.bucket {
+		// ensure that any existing bucket name was not mutated, unless a migration to the target bucket completed
+		migrationCompleted := s.status == shared.ArchivalStatusMigrating && nextState != nil && nextState.bucket == s.targetBucket
+		if nextState != nil && len(s.bucket) != 0 && s.bucket != nextState.bucket && !migrationCompleted {
 			nextState = nil
 			changed = false
 			err = errCannotHandleStateChange
@@ -135,17 +152,24 @@ func (s *archivalState) getNextState(ctx context.Context, blobstoreClient blobst
 			}
 		}
 
-		// ensure the bucket exists
-		if nextState != nil && nextState.bucket != "" {
-			exists, bucketExistsErr := blobstoreClient.BucketExists(ctx, nextState.bucket)
-			if bucketExistsErr != nil {
-				nextState = nil
-				changed = false
-				err = bucketExistsErr
-			} else if !exists {
-				nextState = nil
-				changed = false
-				err = errBucketDoesNotExist
+		// ensure the buckets exist
+		if nextState != nil {
+			for _, bucket := range []string{nextState.bucket, nextState.targetBucket} {
+				if bucket == "" {
+					continue
+				}
+				exists, bucketExistsErr := blobstoreClient.BucketExists(ctx, bucket)
+				if bucketExistsErr != nil {
+					nextState = nil
+					changed = false
+					err = bucketExistsErr
+					break
+				} else if !exists {
+					nextState = nil
+					changed = false
+					err = errBucketDoesNotExist
+					break
+				}
 			}
 		}
 	}()
@@ -164,15 +188,19 @@ func (s *archivalState) getNextState(ctx context.Context, blobstoreClient blobst
 	At this point state and event are both non-nil and valid.
 
 	State can be any one of the following:
-	{status=enabled,  bucket="foo"}
-	{status=disabled, bucket="foo"}
-	{status=disabled, bucket=""}
+	{status=enabled,   bucket="foo"}
+	{status=disabled,  bucket="foo"}
+	{status=disabled,  bucket=""}
+	{status=paused,    bucket="foo"}
+	{status=migrating, bucket="foo", targetBucket="baz"}
 
 	Event can be any one of the following:
 	{status=enabled,  bucket="foo", defaultBucket="bar"}
 	{status=enabled,  bucket="",    defaultBucket="bar"}
 	{status=disabled, bucket="foo", defaultBucket="bar"}
 	{status=disabled, bucket="",    defaultBucket="bar"}
+	{status=paused,   bucket="foo", defaultBucket="bar"}
+	{status=paused,   bucket="",    defaultBucket="bar"}
 	{status=nil,      bucket="foo", defaultBucket="bar"}
 	{status=nil,      bucket="",    defaultBucket="bar"}
 	*/
@@ -180,9 +208,9 @@ func (s *archivalState) getNextState(ctx context.Context, blobstoreClient blobst
 	stateBucketSet := len(s.bucket) != 0
 	eventBucketSet := len(e.bucket) != 0
 
-	// factor this case out to ensure that bucket name is immutable
+	// factor this case out to ensure that bucket name can only change through a migration
 	if stateBucketSet && eventBucketSet && s.bucket != e.bucket {
-		return nil, false, errBucketNameUpdate
+		return s.getNextStateForBucketChange(e)
 	}
 
 	// state 1
@@ -205,6 +233,12 @@ func (s *archivalState) getNextState(ctx context.Context, blobstoreClient blobst
 				status: shared.ArchivalStatusDisabled,
 			}, true, nil
 		}
+		if e.status != nil && *e.status == shared.ArchivalStatusPaused {
+			return &archivalState{
+				bucket: s.bucket,
+				status: shared.ArchivalStatusPaused,
+			}, true, nil
+		}
 		if e.status == nil && eventBucketSet {
 			return s, false, nil
 		}
@@ -233,6 +267,9 @@ func (s *archivalState) getNextState(ctx context.Context, blobstoreClient blobst
 		if e.status != nil && *e.status == shared.ArchivalStatusDisabled && !eventBucketSet {
 			return s, false, nil
 		}
+		if e.status != nil && *e.status == shared.ArchivalStatusPaused {
+			return nil, false, errPauseNotEnabled
+		}
 		if e.status == nil && eventBucketSet {
 			return s, false, nil
 		}
@@ -264,6 +301,9 @@ func (s *archivalState) getNextState(ctx context.Context, blobstoreClient blobst
 		if e.status != nil && *e.status == shared.ArchivalStatusDisabled && !eventBucketSet {
 			return s, false, nil
 		}
+		if e.status != nil && *e.status == shared.ArchivalStatusPaused {
+			return nil, false, errPauseNotEnabled
+		}
 		if e.status == nil && eventBucketSet {
 			return &archivalState{
 				status: shared.ArchivalStatusDisabled,
@@ -274,5 +314,76 @@ func (s *archivalState) getNextState(ctx context.Context, blobstoreClient blobst
 			return s, false, nil
 		}
 	}
+
+	// state 4
+	if s.status == shared.ArchivalStatusPaused && stateBucketSet {
+		if e.status != nil && *e.status == shared.ArchivalStatusEnabled {
+			return &archivalState{
+				status: shared.ArchivalStatusEnabled,
+				bucket: s.bucket,
+			}, true, nil
+		}
+		if e.status != nil && *e.status == shared.ArchivalStatusDisabled {
+			return &archivalState{
+				status: shared.ArchivalStatusDisabled,
+				bucket: s.bucket,
+			}, true, nil
+		}
+		if e.status != nil && *e.status == shared.ArchivalStatusPaused {
+			return s, false, nil
+		}
+		if e.status == nil {
+			return s, false, nil
+		}
+	}
+
+	// state 5
+	if s.status == shared.ArchivalStatusMigrating && stateBucketSet {
+		if e.status != nil && *e.status == shared.ArchivalStatusEnabled {
+			return s, false, nil
+		}
+		if e.status != nil && *e.status == shared.ArchivalStatusDisabled {
+			return nil, false, errMigrationInProgress
+		}
+		if e.status != nil && *e.status == shared.ArchivalStatusPaused {
+			return nil, false, errMigrationInProgress
+		}
+		if e.status == nil {
+			return s, false, nil
+		}
+	}
 	return nil, false, errCannotHandleStateChange
 }
+
+// getNextStateForBucketChange handles events which request a bucket different from the current one.
+// Requesting a new bucket while archival is enabled starts a migration to that bucket,
+// and enabling archival on the target bucket of an in progress migration completes it.
+func (s *archivalState) getNextStateForBucketChange(e *archivalEvent) (*archivalState, bool, error) {
+	switch s.status {
+	case shared.ArchivalStatusEnabled:
+		if e.status == nil || *e.status == shared.ArchivalStatusEnabled {
+			return &archivalState{
+				status:       shared.ArchivalStatusMigrating,
+				bucket:       s.bucket,
+				targetBucket: e.bucket,
+			}, true, nil
+		}
+		return nil, false, errBucketNameUpdate
+	case shared.ArchivalStatusMigrating:
+		if e.bucket != s.targetBucket {
+			return nil, false, errMigrationInProgress
+		}
+		if e.status != nil && *e.status == shared.ArchivalStatusEnabled {
+			return &archivalState{
+				status: shared.ArchivalStatusEnabled,
+				bucket: s.targetBucket,
+			}, true, nil
+		}
+		if e.status == nil {
+			return s, false, nil
+		}
+		return nil, false, errMigrationInProgress
+	default:
+		return nil, false, errBucketNameUpdate
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"time"
+
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+const (
+	domainNotFoundCacheInitialSize = 64
+	domainNotFoundCacheMaxSize     = 16 * 1024
+)
+
+type (
+	// domainNotFoundCache is a domain cache which remembers the domains which do not exist, so clients
+	// repeatedly calling with a non-existent domain are answered from memory instead of the metadata store
+	domainNotFoundCache struct {
+		cache.DomainCache
+		notFound      cache.Cache
+		ttl           dynamicconfig.DurationPropertyFn
+		timeSource    clock.TimeSource
+		metricsClient metrics.Client
+	}
+
+	domainNotFound struct {
+		err      *gen.EntityNotExistsError
+		cachedAt time.Time
+	}
+)
+
+var _ cache.DomainCache = (*domainNotFoundCache)(nil)
+
+// newDomainNotFoundCache wraps the domain cache with negative caching of domains which are not found
+func newDomainNotFoundCache(domainCache cache.DomainCache, ttl dynamicconfig.DurationPropertyFn,
+	metricsClient metrics.Client) *domainNotFoundCache {
+	return &domainNotFoundCache{
+		DomainCache: domainCache,
+		notFound: cache.New(domainNotFoundCacheMaxSize, &cache.Options{
+			InitialCapacity: domainNotFoundCacheInitialSize,
+		}),
+		ttl:           ttl,
+		timeSource:    clock.NewRealTimeSource(),
+		metricsClient: metricsClient,
+	}
+}
+
+func (c *domainNotFoundCache) GetDomain(name string) (*cache.DomainCacheEntry, error) {
+	key := "name:" + name
+	if err := c.getNotFound(key); err != nil {
+		return nil, err
+	}
+	entry, err := c.DomainCache.GetDomain(name)
+	if err != nil {
+		c.putNotFound(key, err)
+	}
+	return entry, err
+}
+
+func (c *domainNotFoundCache) GetDomainByID(id string) (*cache.DomainCacheEntry, error) {
+	key := "id:" + id
+	if err := c.getNotFound(key); err != nil {
+		return nil, err
+	}
+	entry, err := c.DomainCache.GetDomainByID(id)
+	if err != nil {
+		c.putNotFound(key, err)
+	}
+	return entry, err
+}
+
+func (c *domainNotFoundCache) GetDomainID(name string) (string, error) {
+	entry, err := c.GetDomain(name)
+	if err != nil {
+		return "", err
+	}
+	return entry.GetInfo().ID, nil
+}
+
+func (c *domainNotFoundCache) getNotFound(key string) error {
+	cached, ok := c.notFound.Get(key).(*domainNotFound)
+	if !ok {
+		return nil
+	}
+	if c.timeSource.Now().Sub(cached.cachedAt) >= c.ttl() {
+		c.notFound.Delete(key)
+		return nil
+	}
+	c.metricsClient.IncCounter(metrics.DomainCacheScope, metrics.DomainCacheNotFoundHitsCounter)
+	return &gen.EntityNotExistsError{Message: cached.err.Message}
+}
+
+func (c *domainNotFoundCache) putNotFound(key string, err error) {
+	notExistsErr, ok := err.(*gen.EntityNotExistsError)
+	if !ok || c.ttl() <= 0 {
+		return
+	}
+	c.notFound.Put(key, &domainNotFound{err: notExistsErr, cachedAt: c.timeSource.Now()})
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-go/tally"
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	domainNotFoundCacheSuite struct {
+		suite.Suite
+		mockDomainCache *cache.DomainCacheMock
+		timeSource      *clock.EventTimeSource
+		cache           *domainNotFoundCache
+	}
+)
+
+func TestDomainNotFoundCacheSuite(t *testing.T) {
+	s := new(domainNotFoundCacheSuite)
+	suite.Run(t, s)
+}
+
+func (s *domainNotFoundCacheSuite) SetupTest() {
+	s.mockDomainCache = &cache.DomainCacheMock{}
+	s.timeSource = clock.NewEventTimeSource()
+	s.timeSource.Update(time.Now())
+	s.cache = newDomainNotFoundCache(
+		s.mockDomainCache,
+		dynamicconfig.GetDurationPropertyFn(time.Minute),
+		metrics.NewClient(tally.NoopScope, metrics.Frontend),
+	)
+	s.cache.timeSource = s.timeSource
+}
+
+func (s *domainNotFoundCacheSuite) TearDownTest() {
+	s.mockDomainCache.AssertExpectations(s.T())
+}
+
+func (s *domainNotFoundCacheSuite) TestNotFoundIsCached() {
+	notExistsErr := &gen.EntityNotExistsError{Message: "domain not found"}
+	s.mockDomainCache.On("GetDomain", "some-domain").Return(nil, notExistsErr).Once()
+
+	_, err := s.cache.GetDomain("some-domain")
+	s.Equal(notExistsErr, err)
+	_, err = s.cache.GetDomainID("some-domain")
+	s.Equal(notExistsErr, err)
+}
+
+func (s *domainNotFoundCacheSuite) TestNotFoundExpires() {
+	notExistsErr := &gen.EntityNotExistsError{Message: "domain not found"}
+	entry := cache.NewDomainCacheEntryForTest(&persistence.DomainInfo{ID: "some-domain-id", Name: "some-domain"}, nil)
+	s.mockDomainCache.On("GetDomainByID", "some-domain-id").Return(nil, notExistsErr).Once()
+	s.mockDomainCache.On("GetDomainByID", "some-domain-id").Return(entry, nil).Once()
+
+	_, err := s.cache.GetDomainByID("some-domain-id")
+	s.Equal(notExistsErr, err)
+
+	s.timeSource.Update(s.timeSource.Now().Add(time.Minute))
+	result, err := s.cache.GetDomainByID("some-domain-id")
+	s.NoError(err)
+	s.Equal(entry, result)
+}
+
+func (s *domainNotFoundCacheSuite) TestOtherErrorsAreNotCached() {
+	s.mockDomainCache.On("GetDomain", "some-domain").Return(nil, errors.New("some random error")).Twice()
+
+	_, err := s.cache.GetDomain("some-domain")
+	s.Error(err)
+	_, err = s.cache.GetDomain("some-domain")
+	s.Error(err)
+}
@@ -93,6 +93,7 @@ func (domainReplicator *domainReplicatorImpl) HandleTransmissionTask(domainOpera
 			EmitMetric:                             common.BoolPtr(config.EmitMetric),
 			ArchivalBucketName:                     common.StringPtr(config.ArchivalBucket),
 			ArchivalStatus:                         common.ArchivalStatusPtr(config.ArchivalStatus),
+			ArchivalTargetBucketName:               common.StringPtr(config.ArchivalTargetBucket),
 		},
 		ReplicationConfig: &shared.DomainReplicationConfiguration{
 			ActiveClusterName: common.StringPtr(replicationConfig.ActiveClusterName),
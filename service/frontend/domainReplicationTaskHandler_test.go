@@ -135,6 +135,7 @@ func (s *domainReplicatorSuite) TestHandleTransmissionTask_RegisterDomainTask_Is
 				EmitMetric:                             common.BoolPtr(emitMetric),
 				ArchivalBucketName:                     common.StringPtr(archivalBucket),
 				ArchivalStatus:                         common.ArchivalStatusPtr(archivalStatus),
+				ArchivalTargetBucketName:               common.StringPtr(""),
 			},
 			ReplicationConfig: &shared.DomainReplicationConfiguration{
 				ActiveClusterName: common.StringPtr(clusterActive),
@@ -260,6 +261,7 @@ func (s *domainReplicatorSuite) TestHandleTransmissionTask_UpdateDomainTask_IsGl
 				EmitMetric:                             common.BoolPtr(emitMetric),
 				ArchivalBucketName:                     common.StringPtr(archivalBucket),
 				ArchivalStatus:                         common.ArchivalStatusPtr(archivalStatus),
+				ArchivalTargetBucketName:               common.StringPtr(""),
 			},
 			ReplicationConfig: &shared.DomainReplicationConfiguration{
 				ActiveClusterName: common.StringPtr(clusterActive),
@@ -0,0 +1,86 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common/persistence"
+	"go.uber.org/yarpc/yarpcerrors"
+)
+
+type (
+	// errorClass describes the errors which are not returned to clients as thrift exceptions. The yarpc status code
+	// and the error code at the start of the message are machine-readable, so clients can decide whether to retry a
+	// call without matching the free-text part of the message.
+	errorClass struct {
+		code       string
+		retryable  bool
+		statusCode yarpcerrors.Code
+	}
+)
+
+var (
+	// errorClassInternal is a failure of the server which is not expected to go away by retrying
+	errorClassInternal = errorClass{code: "Internal", retryable: false, statusCode: yarpcerrors.CodeInternal}
+	// errorClassUnknown is an error of an unknown type, it is reported the same way as before the classification
+	errorClassUnknown = errorClass{code: "Unknown", retryable: false, statusCode: yarpcerrors.CodeUnknown}
+	// errorClassPersistenceTimeout is a timed out persistence call, a write may or may not have been applied
+	errorClassPersistenceTimeout = errorClass{code: "PersistenceTimeout", retryable: true, statusCode: yarpcerrors.CodeUnavailable}
+	// errorClassConditionFailed is a write rejected because of a concurrent update of the same entity
+	errorClassConditionFailed = errorClass{code: "ConditionFailed", retryable: true, statusCode: yarpcerrors.CodeAborted}
+	// errorClassShardOwnershipLost is a call made while the shard moved to another history host
+	errorClassShardOwnershipLost = errorClass{code: "ShardOwnershipLost", retryable: true, statusCode: yarpcerrors.CodeUnavailable}
+	// errorClassUnavailable is a downstream service which is temporarily unable to serve the call
+	errorClassUnavailable = errorClass{code: "Unavailable", retryable: true, statusCode: yarpcerrors.CodeUnavailable}
+	// errorClassTimeout is a call which did not complete before its deadline
+	errorClassTimeout = errorClass{code: "Timeout", retryable: true, statusCode: yarpcerrors.CodeDeadlineExceeded}
+)
+
+// classifyError returns the class of an error which is not a thrift exception
+func classifyError(err error) errorClass {
+	switch err := err.(type) {
+	case *gen.InternalServiceError:
+		return errorClassInternal
+	case *persistence.TimeoutError:
+		return errorClassPersistenceTimeout
+	case *persistence.ConditionFailedError, *persistence.CurrentWorkflowConditionFailedError:
+		return errorClassConditionFailed
+	case *persistence.ShardOwnershipLostError:
+		return errorClassShardOwnershipLost
+	case *yarpcerrors.Status:
+		switch err.Code() {
+		case yarpcerrors.CodeUnavailable, yarpcerrors.CodeResourceExhausted:
+			return errorClassUnavailable
+		case yarpcerrors.CodeDeadlineExceeded:
+			return errorClassTimeout
+		}
+	}
+	if err == context.DeadlineExceeded {
+		return errorClassTimeout
+	}
+	return errorClassUnknown
+}
+
+func (c errorClass) newError(msg string) error {
+	return yarpcerrors.Newf(c.statusCode, "Cadence error, code: %v, retryable: %v, msg: %v", c.code, c.retryable, msg)
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common/persistence"
+	"go.uber.org/yarpc/yarpcerrors"
+)
+
+type (
+	errorsSuite struct {
+		suite.Suite
+	}
+)
+
+func TestErrorsSuite(t *testing.T) {
+	s := new(errorsSuite)
+	suite.Run(t, s)
+}
+
+func (s *errorsSuite) TestClassifyError() {
+	s.Equal(errorClassInternal, classifyError(&gen.InternalServiceError{Message: "internal"}))
+	s.Equal(errorClassPersistenceTimeout, classifyError(&persistence.TimeoutError{Msg: "timeout"}))
+	s.Equal(errorClassConditionFailed, classifyError(&persistence.ConditionFailedError{Msg: "condition"}))
+	s.Equal(errorClassConditionFailed, classifyError(&persistence.CurrentWorkflowConditionFailedError{Msg: "condition"}))
+	s.Equal(errorClassShardOwnershipLost, classifyError(&persistence.ShardOwnershipLostError{ShardID: 1}))
+	s.Equal(errorClassUnavailable, classifyError(yarpcerrors.UnavailableErrorf("unavailable")))
+	s.Equal(errorClassUnavailable, classifyError(yarpcerrors.ResourceExhaustedErrorf("exhausted")))
+	s.Equal(errorClassTimeout, classifyError(yarpcerrors.DeadlineExceededErrorf("deadline")))
+	s.Equal(errorClassTimeout, classifyError(context.DeadlineExceeded))
+	s.Equal(errorClassUnknown, classifyError(yarpcerrors.InternalErrorf("internal")))
+	s.Equal(errorClassUnknown, classifyError(errors.New("some error")))
+}
+
+func (s *errorsSuite) TestNewError() {
+	err := errorClassPersistenceTimeout.newError("write timed out")
+	s.Equal(yarpcerrors.CodeUnavailable, yarpcerrors.FromError(err).Code())
+	s.Equal("Cadence error, code: PersistenceTimeout, retryable: true, msg: write timed out", yarpcerrors.FromError(err).Message())
+
+	err = errorClassInternal.newError("broken")
+	s.Equal(yarpcerrors.CodeInternal, yarpcerrors.FromError(err).Code())
+	s.Equal("Cadence error, code: Internal, retryable: false, msg: broken", yarpcerrors.FromError(err).Message())
+}
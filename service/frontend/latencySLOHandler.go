@@ -0,0 +1,463 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+	"time"
+
+	"github.com/uber/cadence/.gen/go/cadence/workflowserviceserver"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	// LatencySLOHandlerImpl is simple wrapper over frontend service, classifying the latency of every API call
+	// against the target configured for the API and domain, and emitting SLO breach counters
+	LatencySLOHandlerImpl struct {
+		handler         workflowserviceserver.Interface
+		domainCache     cache.DomainCache
+		tokenSerializer common.TaskTokenSerializer
+		metricsClient   metrics.Client
+		target          dynamicconfig.DurationPropertyFn
+		timeSource      clock.TimeSource
+	}
+)
+
+var _ workflowserviceserver.Interface = (*LatencySLOHandlerImpl)(nil)
+
+// NewLatencySLOHandler creates a thrift handler for the cadence service, frontend
+func NewLatencySLOHandler(handler workflowserviceserver.Interface, wfHandler *WorkflowHandler) *LatencySLOHandlerImpl {
+	return &LatencySLOHandlerImpl{
+		handler:         handler,
+		domainCache:     wfHandler.domainCache,
+		tokenSerializer: common.NewJSONTaskTokenSerializer(),
+		metricsClient:   wfHandler.GetMetricsClient(),
+		target:          wfHandler.config.LatencySLOTarget,
+		timeSource:      clock.NewRealTimeSource(),
+	}
+}
+
+// DeprecateDomain API call
+func (handler *LatencySLOHandlerImpl) DeprecateDomain(
+	ctx context.Context,
+	request *shared.DeprecateDomainRequest,
+) error {
+
+	defer handler.track(metrics.FrontendDeprecateDomainScope, "DeprecateDomain", request.GetName(), handler.timeSource.Now())
+	return handler.handler.DeprecateDomain(ctx, request)
+}
+
+// DescribeDomain API call
+func (handler *LatencySLOHandlerImpl) DescribeDomain(
+	ctx context.Context,
+	request *shared.DescribeDomainRequest,
+) (*shared.DescribeDomainResponse, error) {
+
+	defer handler.track(metrics.FrontendDescribeDomainScope, "DescribeDomain", request.GetName(), handler.timeSource.Now())
+	return handler.handler.DescribeDomain(ctx, request)
+}
+
+// ListDomains API call
+func (handler *LatencySLOHandlerImpl) ListDomains(
+	ctx context.Context,
+	request *shared.ListDomainsRequest,
+) (*shared.ListDomainsResponse, error) {
+
+	defer handler.track(metrics.FrontendListDomainsScope, "ListDomains", "", handler.timeSource.Now())
+	return handler.handler.ListDomains(ctx, request)
+}
+
+// RegisterDomain API call
+func (handler *LatencySLOHandlerImpl) RegisterDomain(
+	ctx context.Context,
+	request *shared.RegisterDomainRequest,
+) error {
+
+	defer handler.track(metrics.FrontendRegisterDomainScope, "RegisterDomain", request.GetName(), handler.timeSource.Now())
+	return handler.handler.RegisterDomain(ctx, request)
+}
+
+// UpdateDomain API call
+func (handler *LatencySLOHandlerImpl) UpdateDomain(
+	ctx context.Context,
+	request *shared.UpdateDomainRequest,
+) (*shared.UpdateDomainResponse, error) {
+
+	defer handler.track(metrics.FrontendUpdateDomainScope, "UpdateDomain", request.GetName(), handler.timeSource.Now())
+	return handler.handler.UpdateDomain(ctx, request)
+}
+
+// DescribeTaskList API call
+func (handler *LatencySLOHandlerImpl) DescribeTaskList(
+	ctx context.Context,
+	request *shared.DescribeTaskListRequest,
+) (*shared.DescribeTaskListResponse, error) {
+
+	defer handler.track(metrics.FrontendDescribeTaskListScope, "DescribeTaskList", request.GetDomain(), handler.timeSource.Now())
+	return handler.handler.DescribeTaskList(ctx, request)
+}
+
+// DescribeWorkflowExecution API call
+func (handler *LatencySLOHandlerImpl) DescribeWorkflowExecution(
+	ctx context.Context,
+	request *shared.DescribeWorkflowExecutionRequest,
+) (*shared.DescribeWorkflowExecutionResponse, error) {
+
+	defer handler.track(metrics.FrontendDescribeWorkflowExecutionScope, "DescribeWorkflowExecution", request.GetDomain(), handler.timeSource.Now())
+	return handler.handler.DescribeWorkflowExecution(ctx, request)
+}
+
+// DescribeWorkflowExecutions API call
+func (handler *LatencySLOHandlerImpl) DescribeWorkflowExecutions(
+	ctx context.Context,
+	request *shared.DescribeWorkflowExecutionsRequest,
+) (*shared.DescribeWorkflowExecutionsResponse, error) {
+
+	defer handler.track(metrics.FrontendDescribeWorkflowExecutionsScope, "DescribeWorkflowExecutions", request.GetDomain(), handler.timeSource.Now())
+	return handler.handler.DescribeWorkflowExecutions(ctx, request)
+}
+
+// GetSearchAttributes API call
+func (handler *LatencySLOHandlerImpl) GetSearchAttributes(
+	ctx context.Context,
+) (*shared.GetSearchAttributesResponse, error) {
+
+	defer handler.track(metrics.FrontendGetSearchAttributesScope, "GetSearchAttributes", "", handler.timeSource.Now())
+	return handler.handler.GetSearchAttributes(ctx)
+}
+
+// GetWorkflowExecutionHistory API call
+func (handler *LatencySLOHandlerImpl) GetWorkflowExecutionHistory(
+	ctx context.Context,
+	request *shared.GetWorkflowExecutionHistoryRequest,
+) (*shared.GetWorkflowExecutionHistoryResponse, error) {
+
+	defer handler.track(metrics.FrontendGetWorkflowExecutionHistoryScope, "GetWorkflowExecutionHistory", request.GetDomain(), handler.timeSource.Now())
+	return handler.handler.GetWorkflowExecutionHistory(ctx, request)
+}
+
+// GetWorkflowExecutionStatistics API call
+func (handler *LatencySLOHandlerImpl) GetWorkflowExecutionStatistics(
+	ctx context.Context,
+	request *shared.GetWorkflowExecutionStatisticsRequest,
+) (*shared.GetWorkflowExecutionStatisticsResponse, error) {
+
+	defer handler.track(metrics.FrontendGetWorkflowExecutionStatisticsScope, "GetWorkflowExecutionStatistics", request.GetDomain(), handler.timeSource.Now())
+	return handler.handler.GetWorkflowExecutionStatistics(ctx, request)
+}
+
+// CountOpenWorkflowExecutions API call
+func (handler *LatencySLOHandlerImpl) CountOpenWorkflowExecutions(
+	ctx context.Context,
+	request *shared.CountOpenWorkflowExecutionsRequest,
+) (*shared.CountOpenWorkflowExecutionsResponse, error) {
+
+	defer handler.track(metrics.FrontendCountOpenWorkflowExecutionsScope, "CountOpenWorkflowExecutions", request.GetDomain(), handler.timeSource.Now())
+	return handler.handler.CountOpenWorkflowExecutions(ctx, request)
+}
+
+// ListClosedWorkflowExecutions API call
+func (handler *LatencySLOHandlerImpl) ListClosedWorkflowExecutions(
+	ctx context.Context,
+	request *shared.ListClosedWorkflowExecutionsRequest,
+) (*shared.ListClosedWorkflowExecutionsResponse, error) {
+
+	defer handler.track(metrics.FrontendListClosedWorkflowExecutionsScope, "ListClosedWorkflowExecutions", request.GetDomain(), handler.timeSource.Now())
+	return handler.handler.ListClosedWorkflowExecutions(ctx, request)
+}
+
+// ListOpenWorkflowExecutions API call
+func (handler *LatencySLOHandlerImpl) ListOpenWorkflowExecutions(
+	ctx context.Context,
+	request *shared.ListOpenWorkflowExecutionsRequest,
+) (*shared.ListOpenWorkflowExecutionsResponse, error) {
+
+	defer handler.track(metrics.FrontendListOpenWorkflowExecutionsScope, "ListOpenWorkflowExecutions", request.GetDomain(), handler.timeSource.Now())
+	return handler.handler.ListOpenWorkflowExecutions(ctx, request)
+}
+
+// PollForActivityTask API call
+func (handler *LatencySLOHandlerImpl) PollForActivityTask(
+	ctx context.Context,
+	request *shared.PollForActivityTaskRequest,
+) (*shared.PollForActivityTaskResponse, error) {
+
+	defer handler.track(metrics.FrontendPollForActivityTaskScope, "PollForActivityTask", request.GetDomain(), handler.timeSource.Now())
+	return handler.handler.PollForActivityTask(ctx, request)
+}
+
+// PollForDecisionTask API call
+func (handler *LatencySLOHandlerImpl) PollForDecisionTask(
+	ctx context.Context,
+	request *shared.PollForDecisionTaskRequest,
+) (*shared.PollForDecisionTaskResponse, error) {
+
+	defer handler.track(metrics.FrontendPollForDecisionTaskScope, "PollForDecisionTask", request.GetDomain(), handler.timeSource.Now())
+	return handler.handler.PollForDecisionTask(ctx, request)
+}
+
+// QueryWorkflow API call
+func (handler *LatencySLOHandlerImpl) QueryWorkflow(
+	ctx context.Context,
+	request *shared.QueryWorkflowRequest,
+) (*shared.QueryWorkflowResponse, error) {
+
+	defer handler.track(metrics.FrontendQueryWorkflowScope, "QueryWorkflow", request.GetDomain(), handler.timeSource.Now())
+	return handler.handler.QueryWorkflow(ctx, request)
+}
+
+// RecordActivityTaskHeartbeat API call
+func (handler *LatencySLOHandlerImpl) RecordActivityTaskHeartbeat(
+	ctx context.Context,
+	request *shared.RecordActivityTaskHeartbeatRequest,
+) (*shared.RecordActivityTaskHeartbeatResponse, error) {
+
+	defer handler.track(metrics.FrontendRecordActivityTaskHeartbeatScope, "RecordActivityTaskHeartbeat", handler.domainNameByTaskToken(request.TaskToken), handler.timeSource.Now())
+	return handler.handler.RecordActivityTaskHeartbeat(ctx, request)
+}
+
+// RecordActivityTaskHeartbeatByID API call
+func (handler *LatencySLOHandlerImpl) RecordActivityTaskHeartbeatByID(
+	ctx context.Context,
+	request *shared.RecordActivityTaskHeartbeatByIDRequest,
+) (*shared.RecordActivityTaskHeartbeatResponse, error) {
+
+	defer handler.track(metrics.FrontendRecordActivityTaskHeartbeatByIDScope, "RecordActivityTaskHeartbeatByID", request.GetDomain(), handler.timeSource.Now())
+	return handler.handler.RecordActivityTaskHeartbeatByID(ctx, request)
+}
+
+// RequestCancelWorkflowExecution API call
+func (handler *LatencySLOHandlerImpl) RequestCancelWorkflowExecution(
+	ctx context.Context,
+	request *shared.RequestCancelWorkflowExecutionRequest,
+) error {
+
+	defer handler.track(metrics.FrontendRequestCancelWorkflowExecutionScope, "RequestCancelWorkflowExecution", request.GetDomain(), handler.timeSource.Now())
+	return handler.handler.RequestCancelWorkflowExecution(ctx, request)
+}
+
+// ResetStickyTaskList API call
+func (handler *LatencySLOHandlerImpl) ResetStickyTaskList(
+	ctx context.Context,
+	request *shared.ResetStickyTaskListRequest,
+) (*shared.ResetStickyTaskListResponse, error) {
+
+	defer handler.track(metrics.FrontendResetStickyTaskListScope, "ResetStickyTaskList", request.GetDomain(), handler.timeSource.Now())
+	return handler.handler.ResetStickyTaskList(ctx, request)
+}
+
+// ResetWorkflowExecution API call
+func (handler *LatencySLOHandlerImpl) ResetWorkflowExecution(
+	ctx context.Context,
+	request *shared.ResetWorkflowExecutionRequest,
+) (*shared.ResetWorkflowExecutionResponse, error) {
+
+	defer handler.track(metrics.FrontendResetWorkflowExecutionScope, "ResetWorkflowExecution", request.GetDomain(), handler.timeSource.Now())
+	return handler.handler.ResetWorkflowExecution(ctx, request)
+}
+
+// RespondActivityTaskCanceled API call
+func (handler *LatencySLOHandlerImpl) RespondActivityTaskCanceled(
+	ctx context.Context,
+	request *shared.RespondActivityTaskCanceledRequest,
+) error {
+
+	defer handler.track(metrics.FrontendRespondActivityTaskCanceledScope, "RespondActivityTaskCanceled", handler.domainNameByTaskToken(request.TaskToken), handler.timeSource.Now())
+	return handler.handler.RespondActivityTaskCanceled(ctx, request)
+}
+
+// RespondActivityTaskCanceledByID API call
+func (handler *LatencySLOHandlerImpl) RespondActivityTaskCanceledByID(
+	ctx context.Context,
+	request *shared.RespondActivityTaskCanceledByIDRequest,
+) error {
+
+	defer handler.track(metrics.FrontendRespondActivityTaskCanceledByIDScope, "RespondActivityTaskCanceledByID", request.GetDomain(), handler.timeSource.Now())
+	return handler.handler.RespondActivityTaskCanceledByID(ctx, request)
+}
+
+// RespondActivityTaskCompleted API call
+func (handler *LatencySLOHandlerImpl) RespondActivityTaskCompleted(
+	ctx context.Context,
+	request *shared.RespondActivityTaskCompletedRequest,
+) error {
+
+	defer handler.track(metrics.FrontendRespondActivityTaskCompletedScope, "RespondActivityTaskCompleted", handler.domainNameByTaskToken(request.TaskToken), handler.timeSource.Now())
+	return handler.handler.RespondActivityTaskCompleted(ctx, request)
+}
+
+// RespondActivityTaskCompletedByID API call
+func (handler *LatencySLOHandlerImpl) RespondActivityTaskCompletedByID(
+	ctx context.Context,
+	request *shared.RespondActivityTaskCompletedByIDRequest,
+) error {
+
+	defer handler.track(metrics.FrontendRespondActivityTaskCompletedByIDScope, "RespondActivityTaskCompletedByID", request.GetDomain(), handler.timeSource.Now())
+	return handler.handler.RespondActivityTaskCompletedByID(ctx, request)
+}
+
+// RespondActivityTaskFailed API call
+func (handler *LatencySLOHandlerImpl) RespondActivityTaskFailed(
+	ctx context.Context,
+	request *shared.RespondActivityTaskFailedRequest,
+) error {
+
+	defer handler.track(metrics.FrontendRespondActivityTaskFailedScope, "RespondActivityTaskFailed", handler.domainNameByTaskToken(request.TaskToken), handler.timeSource.Now())
+	return handler.handler.RespondActivityTaskFailed(ctx, request)
+}
+
+// RespondActivityTaskFailedByID API call
+func (handler *LatencySLOHandlerImpl) RespondActivityTaskFailedByID(
+	ctx context.Context,
+	request *shared.RespondActivityTaskFailedByIDRequest,
+) error {
+
+	defer handler.track(metrics.FrontendRespondActivityTaskFailedByIDScope, "RespondActivityTaskFailedByID", request.GetDomain(), handler.timeSource.Now())
+	return handler.handler.RespondActivityTaskFailedByID(ctx, request)
+}
+
+// RespondDecisionTaskCompleted API call
+func (handler *LatencySLOHandlerImpl) RespondDecisionTaskCompleted(
+	ctx context.Context,
+	request *shared.RespondDecisionTaskCompletedRequest,
+) (*shared.RespondDecisionTaskCompletedResponse, error) {
+
+	defer handler.track(metrics.FrontendRespondDecisionTaskCompletedScope, "RespondDecisionTaskCompleted", handler.domainNameByTaskToken(request.TaskToken), handler.timeSource.Now())
+	return handler.handler.RespondDecisionTaskCompleted(ctx, request)
+}
+
+// RespondDecisionTaskFailed API call
+func (handler *LatencySLOHandlerImpl) RespondDecisionTaskFailed(
+	ctx context.Context,
+	request *shared.RespondDecisionTaskFailedRequest,
+) error {
+
+	defer handler.track(metrics.FrontendRespondDecisionTaskFailedScope, "RespondDecisionTaskFailed", handler.domainNameByTaskToken(request.TaskToken), handler.timeSource.Now())
+	return handler.handler.RespondDecisionTaskFailed(ctx, request)
+}
+
+// RespondQueryTaskCompleted API call
+func (handler *LatencySLOHandlerImpl) RespondQueryTaskCompleted(
+	ctx context.Context,
+	request *shared.RespondQueryTaskCompletedRequest,
+) error {
+
+	defer handler.track(metrics.FrontendRespondQueryTaskCompletedScope, "RespondQueryTaskCompleted", handler.domainNameByQueryTaskToken(request.TaskToken), handler.timeSource.Now())
+	return handler.handler.RespondQueryTaskCompleted(ctx, request)
+}
+
+// SignalWithStartWorkflowExecution API call
+func (handler *LatencySLOHandlerImpl) SignalWithStartWorkflowExecution(
+	ctx context.Context,
+	request *shared.SignalWithStartWorkflowExecutionRequest,
+) (*shared.StartWorkflowExecutionResponse, error) {
+
+	defer handler.track(metrics.FrontendSignalWithStartWorkflowExecutionScope, "SignalWithStartWorkflowExecution", request.GetDomain(), handler.timeSource.Now())
+	return handler.handler.SignalWithStartWorkflowExecution(ctx, request)
+}
+
+// SignalWorkflowExecution API call
+func (handler *LatencySLOHandlerImpl) SignalWorkflowExecution(
+	ctx context.Context,
+	request *shared.SignalWorkflowExecutionRequest,
+) error {
+
+	defer handler.track(metrics.FrontendSignalWorkflowExecutionScope, "SignalWorkflowExecution", request.GetDomain(), handler.timeSource.Now())
+	return handler.handler.SignalWorkflowExecution(ctx, request)
+}
+
+// StartWorkflowExecution API call
+func (handler *LatencySLOHandlerImpl) StartWorkflowExecution(
+	ctx context.Context,
+	request *shared.StartWorkflowExecutionRequest,
+) (*shared.StartWorkflowExecutionResponse, error) {
+
+	defer handler.track(metrics.FrontendStartWorkflowExecutionScope, "StartWorkflowExecution", request.GetDomain(), handler.timeSource.Now())
+	return handler.handler.StartWorkflowExecution(ctx, request)
+}
+
+// TerminateWorkflowExecution API call
+func (handler *LatencySLOHandlerImpl) TerminateWorkflowExecution(
+	ctx context.Context,
+	request *shared.TerminateWorkflowExecutionRequest,
+) error {
+
+	defer handler.track(metrics.FrontendTerminateWorkflowExecutionScope, "TerminateWorkflowExecution", request.GetDomain(), handler.timeSource.Now())
+	return handler.handler.TerminateWorkflowExecution(ctx, request)
+}
+
+// UpdateWorkflowExecutionLabels API call
+func (handler *LatencySLOHandlerImpl) UpdateWorkflowExecutionLabels(
+	ctx context.Context,
+	request *shared.UpdateWorkflowExecutionLabelsRequest,
+) error {
+
+	defer handler.track(metrics.FrontendUpdateWorkflowExecutionLabelsScope, "UpdateWorkflowExecutionLabels", request.GetDomain(), handler.timeSource.Now())
+	return handler.handler.UpdateWorkflowExecutionLabels(ctx, request)
+}
+
+// track emits the SLO counters of an API call which started at startTime, calls of APIs
+// without a latency target are not tracked
+func (handler *LatencySLOHandlerImpl) track(scope int, apiName string, domainName string, startTime time.Time) {
+	target := handler.target(dynamicconfig.APINameFilter(apiName), dynamicconfig.DomainFilter(domainName))
+	if target <= 0 {
+		return
+	}
+
+	domainTag := metrics.DomainAllTag()
+	if domainName != "" {
+		domainTag = metrics.DomainTag(domainName)
+	}
+	metricsScope := handler.metricsClient.Scope(scope, domainTag)
+	metricsScope.IncCounter(metrics.CadenceLatencySLORequests)
+	if handler.timeSource.Now().Sub(startTime) > target {
+		metricsScope.IncCounter(metrics.CadenceLatencySLOBreaches)
+	}
+}
+
+func (handler *LatencySLOHandlerImpl) domainNameByTaskToken(taskToken []byte) string {
+	token, err := handler.tokenSerializer.Deserialize(taskToken)
+	if err != nil {
+		return ""
+	}
+	return handler.domainNameByID(token.DomainID)
+}
+
+func (handler *LatencySLOHandlerImpl) domainNameByQueryTaskToken(taskToken []byte) string {
+	token, err := handler.tokenSerializer.DeserializeQueryTaskToken(taskToken)
+	if err != nil {
+		return ""
+	}
+	return handler.domainNameByID(token.DomainID)
+}
+
+func (handler *LatencySLOHandlerImpl) domainNameByID(domainID string) string {
+	domainEntry, err := handler.domainCache.GetDomainByID(domainID)
+	if err != nil {
+		return ""
+	}
+	return domainEntry.GetInfo().Name
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-go/tally"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	latencySLOHandlerSuite struct {
+		suite.Suite
+		testScope       tally.TestScope
+		timeSource      *clock.EventTimeSource
+		mockDomainCache *cache.DomainCacheMock
+		handler         *LatencySLOHandlerImpl
+	}
+)
+
+func TestLatencySLOHandlerSuite(t *testing.T) {
+	s := new(latencySLOHandlerSuite)
+	suite.Run(t, s)
+}
+
+func (s *latencySLOHandlerSuite) SetupTest() {
+	s.testScope = tally.NewTestScope("", nil)
+	s.timeSource = clock.NewEventTimeSource().Update(time.Now())
+	s.mockDomainCache = &cache.DomainCacheMock{}
+	s.handler = &LatencySLOHandlerImpl{
+		domainCache:     s.mockDomainCache,
+		tokenSerializer: common.NewJSONTaskTokenSerializer(),
+		metricsClient:   metrics.NewClient(s.testScope, metrics.Frontend),
+		target: func(opts ...dynamicconfig.FilterOption) time.Duration {
+			filters := make(map[dynamicconfig.Filter]interface{})
+			for _, opt := range opts {
+				opt(filters)
+			}
+			if filters[dynamicconfig.APIName] != "StartWorkflowExecution" {
+				return 0
+			}
+			if filters[dynamicconfig.DomainName] == "slow-domain" {
+				return time.Second
+			}
+			return 100 * time.Millisecond
+		},
+		timeSource: s.timeSource,
+	}
+}
+
+func (s *latencySLOHandlerSuite) TearDownTest() {
+	s.mockDomainCache.AssertExpectations(s.T())
+}
+
+func (s *latencySLOHandlerSuite) TestTrack() {
+	startTime := s.timeSource.Now()
+	s.timeSource.Update(startTime.Add(200 * time.Millisecond))
+
+	s.handler.track(metrics.FrontendStartWorkflowExecutionScope, "StartWorkflowExecution", "some-domain", startTime)
+	s.handler.track(metrics.FrontendStartWorkflowExecutionScope, "StartWorkflowExecution", "slow-domain", startTime)
+	// no target for the API
+	s.handler.track(metrics.FrontendSignalWorkflowExecutionScope, "SignalWorkflowExecution", "some-domain", startTime)
+
+	s.Equal(int64(1), s.counter("cadence_latency_slo_requests", "some-domain"))
+	s.Equal(int64(1), s.counter("cadence_latency_slo_breaches", "some-domain"))
+	s.Equal(int64(1), s.counter("cadence_latency_slo_requests", "slow-domain"))
+	s.Equal(int64(0), s.counter("cadence_latency_slo_breaches", "slow-domain"))
+}
+
+func (s *latencySLOHandlerSuite) TestDomainNameByTaskToken() {
+	taskToken, err := common.NewJSONTaskTokenSerializer().Serialize(&common.TaskToken{DomainID: "some-domain-id"})
+	s.NoError(err)
+	s.mockDomainCache.On("GetDomainByID", "some-domain-id").Return(
+		cache.NewDomainCacheEntryForTest(&persistence.DomainInfo{ID: "some-domain-id", Name: "some-domain"}, nil), nil,
+	)
+
+	s.Equal("some-domain", s.handler.domainNameByTaskToken(taskToken))
+	s.Equal("", s.handler.domainNameByTaskToken([]byte("invalid")))
+}
+
+func (s *latencySLOHandlerSuite) counter(name string, domainName string) int64 {
+	var value int64
+	for _, c := range s.testScope.Snapshot().Counters() {
+		if c.Name() == name && c.Tags()["domain"] == domainName {
+			value += c.Value()
+		}
+	}
+	return value
+}
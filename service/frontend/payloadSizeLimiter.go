@@ -0,0 +1,102 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/uber-common/bark"
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+const (
+	payloadWorkflowInput              = "Workflow input"
+	payloadSignalInput                = "Signal input"
+	payloadHeartbeatDetails           = "Heartbeat details"
+	payloadActivityResult             = "Activity result"
+	payloadActivityFailureDetails     = "Activity failure details"
+	payloadActivityCancelationDetails = "Activity cancelation details"
+	payloadDecisionFailureDetails     = "Decision failure details"
+)
+
+type (
+	// payloadSizeLimiter checks the size of the payloads carried by frontend requests against the
+	// per domain blob size limits, so every API enforces the limits the same way
+	payloadSizeLimiter struct {
+		errorLimit dynamicconfig.IntPropertyFnWithDomainFilter
+		warnLimit  dynamicconfig.IntPropertyFnWithDomainFilter
+		logger     bark.Logger
+	}
+)
+
+func newPayloadSizeLimiter(
+	errorLimit dynamicconfig.IntPropertyFnWithDomainFilter,
+	warnLimit dynamicconfig.IntPropertyFnWithDomainFilter,
+	logger bark.Logger,
+) *payloadSizeLimiter {
+	return &payloadSizeLimiter{
+		errorLimit: errorLimit,
+		warnLimit:  warnLimit,
+		logger:     logger,
+	}
+}
+
+// check returns a BadRequestError if the payload exceeds the error limit of the domain, and logs a warning
+// if it exceeds the warn limit. The scope is expected to be tagged with the domain already.
+func (l *payloadSizeLimiter) check(
+	payload string,
+	size int,
+	domainName string,
+	domainID string,
+	workflowID string,
+	runID string,
+	scope metrics.Scope,
+) error {
+	scope.RecordTimer(metrics.EventBlobSize, time.Duration(size))
+
+	warnLimit := l.warnLimit(domainName)
+	if size <= warnLimit {
+		return nil
+	}
+
+	errorLimit := l.errorLimit(domainName)
+	logger := l.logger.WithFields(bark.Fields{
+		logging.TagDomainID:            domainID,
+		logging.TagWorkflowExecutionID: workflowID,
+		logging.TagWorkflowRunID:       runID,
+		logging.TagSize:                size,
+	})
+	if size > errorLimit {
+		scope.IncCounter(metrics.BlobSizeExceedsLimitCount)
+		logger.Warnf("%v size exceeds error limit %v.", payload, errorLimit)
+		return &gen.BadRequestError{
+			Message: fmt.Sprintf("%v size of %v bytes exceeds limit of %v bytes.", payload, size, errorLimit),
+		}
+	}
+
+	scope.IncCounter(metrics.BlobSizeWarnCount)
+	logger.Warnf("%v size exceeds warn limit %v.", payload, warnLimit)
+	return nil
+}
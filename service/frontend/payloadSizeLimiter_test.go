@@ -0,0 +1,85 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	"github.com/uber-go/tally"
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	payloadSizeLimiterSuite struct {
+		suite.Suite
+		testScope tally.TestScope
+		scope     metrics.Scope
+		limiter   *payloadSizeLimiter
+	}
+)
+
+func TestPayloadSizeLimiterSuite(t *testing.T) {
+	s := new(payloadSizeLimiterSuite)
+	suite.Run(t, s)
+}
+
+func (s *payloadSizeLimiterSuite) SetupTest() {
+	s.testScope = tally.NewTestScope("", nil)
+	s.scope = metrics.NewClient(s.testScope, metrics.Frontend).Scope(metrics.FrontendSignalWorkflowExecutionScope)
+	s.limiter = newPayloadSizeLimiter(
+		dynamicconfig.GetIntPropertyFilteredByDomain(100),
+		dynamicconfig.GetIntPropertyFilteredByDomain(10),
+		bark.NewNopLogger(),
+	)
+}
+
+func (s *payloadSizeLimiterSuite) TestBelowWarnLimit() {
+	s.NoError(s.limiter.check(payloadSignalInput, 10, "domain", "domainID", "workflowID", "runID", s.scope))
+	s.Equal(int64(0), s.counter("blob_size_warn"))
+	s.Equal(int64(0), s.counter("blob_size_exceeds_limit"))
+}
+
+func (s *payloadSizeLimiterSuite) TestAboveWarnLimit() {
+	s.NoError(s.limiter.check(payloadSignalInput, 11, "domain", "domainID", "workflowID", "runID", s.scope))
+	s.Equal(int64(1), s.counter("blob_size_warn"))
+	s.Equal(int64(0), s.counter("blob_size_exceeds_limit"))
+}
+
+func (s *payloadSizeLimiterSuite) TestAboveErrorLimit() {
+	err := s.limiter.check(payloadSignalInput, 101, "domain", "domainID", "workflowID", "runID", s.scope)
+	s.Equal(&gen.BadRequestError{Message: "Signal input size of 101 bytes exceeds limit of 100 bytes."}, err)
+	s.Equal(int64(0), s.counter("blob_size_warn"))
+	s.Equal(int64(1), s.counter("blob_size_exceeds_limit"))
+}
+
+func (s *payloadSizeLimiterSuite) counter(name string) int64 {
+	var value int64
+	for _, c := range s.testScope.Snapshot().Counters() {
+		if c.Name() == name {
+			value += c.Value()
+		}
+	}
+	return value
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	// SearchAttributesCache returns the search attribute keys visibility queries can use, which are the ones of the
+	// dynamic config plus the ones added through the admin API and persisted in the cluster metadata
+	SearchAttributesCache interface {
+		GetSearchAttributes(ctx context.Context) (map[string]gen.IndexedValueType, error)
+		// Invalidate drops the cached persisted search attributes, so the next call reads them again
+		Invalidate()
+	}
+
+	searchAttributesCache struct {
+		sync.Mutex
+		validSearchAttributes dynamicconfig.MapPropertyFn
+		ttl                   dynamicconfig.DurationPropertyFn
+		clusterMetadataMgr    persistence.ClusterMetadataManager
+		timeSource            clock.TimeSource
+
+		persisted map[string]gen.IndexedValueType
+		cachedAt  time.Time
+	}
+)
+
+var _ SearchAttributesCache = (*searchAttributesCache)(nil)
+
+// NewSearchAttributesCache creates a cache of the valid search attributes, the persisted ones are read again once the
+// SearchAttributesCacheTTL expired, so search attributes added through other frontend hosts show up eventually
+func NewSearchAttributesCache(config *Config, clusterMetadataMgr persistence.ClusterMetadataManager) SearchAttributesCache {
+	return &searchAttributesCache{
+		validSearchAttributes: config.ValidSearchAttributes,
+		ttl:                   config.SearchAttributesCacheTTL,
+		clusterMetadataMgr:    clusterMetadataMgr,
+		timeSource:            clock.NewRealTimeSource(),
+	}
+}
+
+func (c *searchAttributesCache) GetSearchAttributes(ctx context.Context) (map[string]gen.IndexedValueType, error) {
+	keys, err := convertSearchAttributes(c.validSearchAttributes())
+	if err != nil {
+		return nil, err
+	}
+	persisted, err := c.getPersisted(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for key, valueType := range persisted {
+		keys[key] = valueType
+	}
+	return keys, nil
+}
+
+func (c *searchAttributesCache) Invalidate() {
+	c.Lock()
+	defer c.Unlock()
+	c.persisted = nil
+}
+
+func (c *searchAttributesCache) getPersisted(ctx context.Context) (map[string]gen.IndexedValueType, error) {
+	if c.clusterMetadataMgr == nil {
+		return nil, nil
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	if c.persisted != nil && c.timeSource.Now().Sub(c.cachedAt) < c.ttl() {
+		return c.persisted, nil
+	}
+	response, err := c.clusterMetadataMgr.GetClusterMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+	persisted := make(map[string]gen.IndexedValueType, len(response.ClusterMetadata.SearchAttributes))
+	for key, valueType := range response.ClusterMetadata.SearchAttributes {
+		persisted[key] = gen.IndexedValueType(valueType)
+	}
+	c.persisted = persisted
+	c.cachedAt = c.timeSource.Now()
+	return persisted, nil
+}
+
+// convertSearchAttributes converts the dynamic config value of the valid search attributes, which maps each key
+// to the int value of its IndexedValueType, into the value of the GetSearchAttributes response
+func convertSearchAttributes(validKeys map[string]interface{}) (map[string]gen.IndexedValueType, error) {
+	knownTypes := make(map[gen.IndexedValueType]struct{})
+	for _, t := range gen.IndexedValueType_Values() {
+		knownTypes[t] = struct{}{}
+	}
+
+	keys := make(map[string]gen.IndexedValueType, len(validKeys))
+	for key, value := range validKeys {
+		// dynamic config values read from yaml are ints while the ones read from json are float64s
+		valueType := gen.IndexedValueType(-1)
+		switch v := value.(type) {
+		case int:
+			valueType = gen.IndexedValueType(v)
+		case float64:
+			valueType = gen.IndexedValueType(v)
+		}
+		if _, ok := knownTypes[valueType]; !ok {
+			return nil, &gen.InternalServiceError{
+				Message: fmt.Sprintf("Invalid type %v of search attribute %v in dynamic config.", value, key),
+			}
+		}
+		keys[key] = valueType
+	}
+	return keys, nil
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	searchAttributesCacheSuite struct {
+		suite.Suite
+		clusterMetadataMgr *testClusterMetadataManager
+		timeSource         *clock.EventTimeSource
+		cache              *searchAttributesCache
+	}
+
+	// testClusterMetadataManager returns the search attributes it holds and counts the reads
+	testClusterMetadataManager struct {
+		persistence.ClusterMetadataManager
+		searchAttributes map[string]int32
+		reads            int
+	}
+)
+
+func TestSearchAttributesCacheSuite(t *testing.T) {
+	s := new(searchAttributesCacheSuite)
+	suite.Run(t, s)
+}
+
+func (s *searchAttributesCacheSuite) SetupTest() {
+	s.clusterMetadataMgr = &testClusterMetadataManager{
+		searchAttributes: map[string]int32{"CustomKeywordField": int32(gen.IndexedValueTypeKeyword)},
+	}
+	s.timeSource = clock.NewEventTimeSource()
+	s.timeSource.Update(time.Now())
+	config := &Config{
+		ValidSearchAttributes:    dynamicconfig.GetMapPropertyFn(map[string]interface{}{"WorkflowID": 1}),
+		SearchAttributesCacheTTL: dynamicconfig.GetDurationPropertyFn(time.Minute),
+	}
+	s.cache = NewSearchAttributesCache(config, s.clusterMetadataMgr).(*searchAttributesCache)
+	s.cache.timeSource = s.timeSource
+}
+
+func (s *searchAttributesCacheSuite) TestMergesPersistedSearchAttributes() {
+	keys, err := s.cache.GetSearchAttributes(context.Background())
+	s.NoError(err)
+	s.Equal(map[string]gen.IndexedValueType{
+		"WorkflowID":         gen.IndexedValueTypeKeyword,
+		"CustomKeywordField": gen.IndexedValueTypeKeyword,
+	}, keys)
+}
+
+func (s *searchAttributesCacheSuite) TestPersistedSearchAttributesExpire() {
+	_, err := s.cache.GetSearchAttributes(context.Background())
+	s.NoError(err)
+	s.clusterMetadataMgr.searchAttributes["CustomIntField"] = int32(gen.IndexedValueTypeInt)
+
+	keys, err := s.cache.GetSearchAttributes(context.Background())
+	s.NoError(err)
+	s.NotContains(keys, "CustomIntField")
+	s.Equal(1, s.clusterMetadataMgr.reads)
+
+	s.timeSource.Update(s.timeSource.Now().Add(time.Minute))
+	keys, err = s.cache.GetSearchAttributes(context.Background())
+	s.NoError(err)
+	s.Equal(gen.IndexedValueTypeInt, keys["CustomIntField"])
+	s.Equal(2, s.clusterMetadataMgr.reads)
+}
+
+func (s *searchAttributesCacheSuite) TestInvalidate() {
+	_, err := s.cache.GetSearchAttributes(context.Background())
+	s.NoError(err)
+	s.clusterMetadataMgr.searchAttributes["CustomIntField"] = int32(gen.IndexedValueTypeInt)
+
+	s.cache.Invalidate()
+	keys, err := s.cache.GetSearchAttributes(context.Background())
+	s.NoError(err)
+	s.Equal(gen.IndexedValueTypeInt, keys["CustomIntField"])
+	s.Equal(2, s.clusterMetadataMgr.reads)
+}
+
+func (m *testClusterMetadataManager) GetClusterMetadata(
+	ctx context.Context) (*persistence.GetClusterMetadataResponse, error) {
+	m.reads++
+	searchAttributes := make(map[string]int32, len(m.searchAttributes))
+	for key, valueType := range m.searchAttributes {
+		searchAttributes[key] = valueType
+	}
+	return &persistence.GetClusterMetadataResponse{
+		ClusterMetadata: persistence.ClusterMetadata{SearchAttributes: searchAttributes},
+	}, nil
+}
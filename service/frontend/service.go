@@ -21,9 +21,15 @@
 package frontend
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/uber/cadence/.gen/go/cadence/workflowserviceserver"
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/cluster"
+	es "github.com/uber/cadence/common/elasticsearch"
 	"github.com/uber/cadence/common/logging"
 	"github.com/uber/cadence/common/messaging"
 	"github.com/uber/cadence/common/mocks"
@@ -40,6 +46,8 @@ import (
 type Config struct {
 	NumHistoryShards                int
 	PersistenceMaxQPS               dynamicconfig.IntPropertyFn
+	PersistenceAdaptiveThrottling   *config.AdaptiveThrottlingConfig
+	PersistenceDomainMetrics        *config.DomainMetricsConfig
 	VisibilityMaxPageSize           dynamicconfig.IntPropertyFnWithDomainFilter
 	EnableVisibilitySampling        dynamicconfig.BoolPropertyFn
 	EnableReadFromClosedExecutionV2 dynamicconfig.BoolPropertyFn
@@ -48,7 +56,14 @@ type Config struct {
 	EnableReadVisibilityFromES      dynamicconfig.BoolPropertyFnWithDomainFilter
 	ESVisibilityListMaxQPS          dynamicconfig.IntPropertyFnWithDomainFilter
 	ESIndexMaxResultWindow          dynamicconfig.IntPropertyFn
+	ESVisibilityMaxQueryTimeRange   dynamicconfig.DurationPropertyFnWithDomainFilter
+	ESVisibilityMaxQueryCost        dynamicconfig.IntPropertyFnWithDomainFilter
+	OpenWorkflowCountCacheTTL       dynamicconfig.DurationPropertyFnWithDomainFilter
+	DomainNotFoundCacheTTL          dynamicconfig.DurationPropertyFn
+	StackTraceQueryCacheTTL         dynamicconfig.DurationPropertyFnWithDomainFilter
 	HistoryMaxPageSize              dynamicconfig.IntPropertyFnWithDomainFilter
+	MaxDescribeBatchSize            dynamicconfig.IntPropertyFnWithDomainFilter
+	VisibilityTombstoneRetention    dynamicconfig.DurationPropertyFnWithDomainFilter
 	RPS                             dynamicconfig.IntPropertyFn
 	MaxIDLengthLimit                dynamicconfig.IntPropertyFn
 
@@ -70,6 +85,29 @@ type Config struct {
 
 	// Domain specific config
 	EnableDomainNotActiveAutoForwarding dynamicconfig.BoolPropertyFnWithDomainFilter
+	EnableDomainChangeNotification      dynamicconfig.BoolPropertyFn
+
+	// EnableConcurrencyGroups is whether workflows of a domain can be started in a concurrency group
+	EnableConcurrencyGroups dynamicconfig.BoolPropertyFnWithDomainFilter
+
+	// domain admission control settings
+	DomainNamePattern       dynamicconfig.StringPropertyFn
+	DomainMinRetentionDays  dynamicconfig.IntPropertyFn
+	DomainMaxRetentionDays  dynamicconfig.IntPropertyFn
+	DomainOwnerEmailPattern dynamicconfig.StringPropertyFn
+	DomainAllowedClusters   dynamicconfig.StringPropertyFn
+
+	// LatencySLOTarget is the latency target of an API call, filtered by API name and domain
+	LatencySLOTarget dynamicconfig.DurationPropertyFn
+
+	// client version check settings
+	EnableClientVersionCheck         dynamicconfig.BoolPropertyFn
+	MinSupportedClientFeatureVersion dynamicconfig.StringPropertyFn
+
+	// ValidSearchAttributes is the search attribute keys visibility queries can use and the type of their values
+	ValidSearchAttributes dynamicconfig.MapPropertyFn
+	// SearchAttributesCacheTTL is how long the search attributes added through the admin API are cached
+	SearchAttributesCacheTTL dynamicconfig.DurationPropertyFn
 }
 
 // NewConfig returns new service config with default values
@@ -77,6 +115,8 @@ func NewConfig(dc *dynamicconfig.Collection, numHistoryShards int, enableVisibil
 	return &Config{
 		NumHistoryShards:                    numHistoryShards,
 		PersistenceMaxQPS:                   dc.GetIntProperty(dynamicconfig.FrontendPersistenceMaxQPS, 2000),
+		PersistenceAdaptiveThrottling:       config.NewAdaptiveThrottlingConfig(dc, dynamicconfig.FrontendEnablePersistenceAdaptiveThrottling),
+		PersistenceDomainMetrics:            config.NewDomainMetricsConfig(dc, dynamicconfig.FrontendEnablePersistenceDomainMetrics),
 		VisibilityMaxPageSize:               dc.GetIntPropertyFilteredByDomain(dynamicconfig.FrontendVisibilityMaxPageSize, 1000),
 		EnableVisibilitySampling:            dc.GetBoolProperty(dynamicconfig.EnableVisibilitySampling, true),
 		EnableReadFromClosedExecutionV2:     dc.GetBoolProperty(dynamicconfig.EnableReadFromClosedExecutionV2, false),
@@ -85,7 +125,14 @@ func NewConfig(dc *dynamicconfig.Collection, numHistoryShards int, enableVisibil
 		EnableReadVisibilityFromES:          dc.GetBoolPropertyFnWithDomainFilter(dynamicconfig.EnableReadVisibilityFromES, false),
 		ESVisibilityListMaxQPS:              dc.GetIntPropertyFilteredByDomain(dynamicconfig.FrontendESVisibilityListMaxQPS, 3),
 		ESIndexMaxResultWindow:              dc.GetIntProperty(dynamicconfig.FrontendESIndexMaxResultWindow, 10000),
+		ESVisibilityMaxQueryTimeRange:       dc.GetDurationPropertyFilteredByDomain(dynamicconfig.FrontendESVisibilityMaxQueryTimeRange, 0),
+		ESVisibilityMaxQueryCost:            dc.GetIntPropertyFilteredByDomain(dynamicconfig.FrontendESVisibilityMaxQueryCost, 0),
+		OpenWorkflowCountCacheTTL:           dc.GetDurationPropertyFilteredByDomain(dynamicconfig.FrontendOpenWorkflowCountCacheTTL, time.Minute),
+		DomainNotFoundCacheTTL:              dc.GetDurationProperty(dynamicconfig.FrontendDomainNotFoundCacheTTL, 5*time.Second),
+		VisibilityTombstoneRetention:        dc.GetDurationPropertyFilteredByDomain(dynamicconfig.VisibilityTombstoneRetention, 7*24*time.Hour),
+		StackTraceQueryCacheTTL:             dc.GetDurationPropertyFilteredByDomain(dynamicconfig.FrontendStackTraceQueryCacheTTL, 10*time.Second),
 		HistoryMaxPageSize:                  dc.GetIntPropertyFilteredByDomain(dynamicconfig.FrontendHistoryMaxPageSize, common.GetHistoryMaxPageSize),
+		MaxDescribeBatchSize:                dc.GetIntPropertyFilteredByDomain(dynamicconfig.FrontendMaxDescribeBatchSize, 100),
 		RPS:                                 dc.GetIntProperty(dynamicconfig.FrontendRPS, 1200),
 		MaxIDLengthLimit:                    dc.GetIntProperty(dynamicconfig.MaxIDLengthLimit, 1000),
 		HistoryMgrNumConns:                  dc.GetIntProperty(dynamicconfig.FrontendHistoryMgrNumConns, 10),
@@ -94,9 +141,21 @@ func NewConfig(dc *dynamicconfig.Collection, numHistoryShards int, enableVisibil
 		AdminOperationToken:                 dc.GetStringProperty(dynamicconfig.AdminOperationToken, "CadenceTeamONLY"),
 		DisableListVisibilityByFilter:       dc.GetBoolPropertyFnWithDomainFilter(dynamicconfig.DisableListVisibilityByFilter, false),
 		BlobSizeLimitError:                  dc.GetIntPropertyFilteredByDomain(dynamicconfig.BlobSizeLimitError, 2*1024*1024),
-		BlobSizeLimitWarn:                   dc.GetIntPropertyFilteredByDomain(dynamicconfig.BlobSizeLimitWarn, 256*1204),
+		BlobSizeLimitWarn:                   dc.GetIntPropertyFilteredByDomain(dynamicconfig.BlobSizeLimitWarn, 256*1024),
 		ThrottledLogRPS:                     dc.GetIntProperty(dynamicconfig.FrontendThrottledLogRPS, 20),
 		EnableDomainNotActiveAutoForwarding: dc.GetBoolPropertyFnWithDomainFilter(dynamicconfig.EnableDomainNotActiveAutoForwarding, false),
+		EnableConcurrencyGroups:             dc.GetBoolPropertyFnWithDomainFilter(dynamicconfig.EnableConcurrencyGroups, false),
+		EnableDomainChangeNotification:      dc.GetBoolProperty(dynamicconfig.EnableDomainChangeNotification, false),
+		DomainNamePattern:                   dc.GetStringProperty(dynamicconfig.FrontendDomainNamePattern, ""),
+		DomainMinRetentionDays:              dc.GetIntProperty(dynamicconfig.FrontendDomainMinRetentionDays, 0),
+		DomainMaxRetentionDays:              dc.GetIntProperty(dynamicconfig.FrontendDomainMaxRetentionDays, 0),
+		DomainOwnerEmailPattern:             dc.GetStringProperty(dynamicconfig.FrontendDomainOwnerEmailPattern, ""),
+		DomainAllowedClusters:               dc.GetStringProperty(dynamicconfig.FrontendDomainAllowedClusters, ""),
+		LatencySLOTarget:                    dc.GetDurationProperty(dynamicconfig.FrontendLatencySLOTarget, 0),
+		EnableClientVersionCheck:            dc.GetBoolProperty(dynamicconfig.EnableClientVersionCheck, false),
+		MinSupportedClientFeatureVersion:    dc.GetStringProperty(dynamicconfig.FrontendMinSupportedClientFeatureVersion, ""),
+		ValidSearchAttributes:               dc.GetMapProperty(dynamicconfig.ValidSearchAttributes, es.GetDefaultValidSearchAttributes()),
+		SearchAttributesCacheTTL:            dc.GetDurationProperty(dynamicconfig.SearchAttributesCacheTTL, 30*time.Second),
 	}
 }
 
@@ -111,7 +170,7 @@ type Service struct {
 func NewService(params *service.BootstrapParams) common.Daemon {
 	params.UpdateLoggerWithServiceName(common.FrontendServiceName)
 	config := NewConfig(dynamicconfig.NewCollection(params.DynamicConfig, params.BarkLogger), params.PersistenceConfig.NumHistoryShards, params.ESConfig.Enable)
-	params.ThrottledBarkLogger = logging.NewThrottledLogger(params.BarkLogger, config.ThrottledLogRPS)
+	params.ThrottledBarkLogger = logging.NewThrottledLoggerWithKeys(params.BarkLogger, config.ThrottledLogRPS, logging.TagDomainID, logging.TagErr)
 	return &Service{
 		params: params,
 		config: config,
@@ -129,41 +188,61 @@ func (s *Service) Start() {
 
 	base := service.New(params)
 
-	pConfig := params.PersistenceConfig
-	pConfig.HistoryMaxConns = s.config.HistoryMgrNumConns()
-	pConfig.SetMaxQPS(pConfig.DefaultStore, s.config.PersistenceMaxQPS())
-	pConfig.VisibilityConfig = &config.VisibilityConfig{
-		VisibilityListMaxQPS:            s.config.VisibilityListMaxQPS,
-		EnableSampling:                  s.config.EnableVisibilitySampling,
-		EnableReadFromClosedExecutionV2: s.config.EnableReadFromClosedExecutionV2,
+	pFactory := params.PersistenceFactory
+	if pFactory == nil {
+		pConfig := params.PersistenceConfig
+		pConfig.HistoryMaxConns = s.config.HistoryMgrNumConns()
+		pConfig.SetMaxQPS(pConfig.DefaultStore, s.config.PersistenceMaxQPS())
+		pConfig.AdaptiveThrottlingConfig = s.config.PersistenceAdaptiveThrottling
+		pConfig.DomainMetricsConfig = s.config.PersistenceDomainMetrics
+		pConfig.VisibilityConfig = &config.VisibilityConfig{
+			VisibilityListMaxQPS:            s.config.VisibilityListMaxQPS,
+			EnableSampling:                  s.config.EnableVisibilitySampling,
+			EnableReadFromClosedExecutionV2: s.config.EnableReadFromClosedExecutionV2,
+		}
+		pFactory = persistencefactory.New(&pConfig, params.ClusterMetadata.GetCurrentClusterName(), base.GetMetricsClient(), log)
 	}
-	pFactory := persistencefactory.New(&pConfig, params.ClusterMetadata.GetCurrentClusterName(), base.GetMetricsClient(), log)
 
 	metadata, err := pFactory.NewMetadataManager(persistencefactory.MetadataV1V2)
 	if err != nil {
 		log.Fatalf("failed to create metadata manager: %v", err)
 	}
 
+	clusterMetadataMgr, err := pFactory.NewClusterMetadataManager()
+	if err != nil {
+		log.Fatalf("failed to create cluster metadata manager: %v", err)
+	}
+	clusterMetadataRefresher := cluster.NewMetadataRefresher(base.GetClusterMetadata(), clusterMetadataMgr, log)
+	clusterMetadataRefresher.Start()
+
 	visibilityFromDB, err := pFactory.NewVisibilityManager()
 	if err != nil {
 		log.Fatalf("failed to create visibility manager: %v", err)
 	}
 	var visibilityFromES persistence.VisibilityManager
+	var esClient es.Client
+	var visibilityIndexName string
 	if s.config.EnableVisibilityToKafka() {
-		visibilityIndexName := params.ESConfig.Indices[common.VisibilityAppName]
+		esClient = params.ESClient
+		visibilityIndexName = params.ESConfig.Indices[common.VisibilityAppName]
 		visibilityConfigForES := &config.VisibilityConfig{
-			VisibilityListMaxQPS:   s.config.ESVisibilityListMaxQPS,
-			ESIndexMaxResultWindow: s.config.ESIndexMaxResultWindow,
+			VisibilityListMaxQPS:          s.config.ESVisibilityListMaxQPS,
+			ESIndexMaxResultWindow:        s.config.ESIndexMaxResultWindow,
+			ESVisibilityMaxQueryTimeRange: s.config.ESVisibilityMaxQueryTimeRange,
+			ESVisibilityMaxQueryCost:      s.config.ESVisibilityMaxQueryCost,
 		}
 
-		visibilityFromES = elasticsearch.NewElasticSearchVisibilityManager(params.ESClient, visibilityIndexName, visibilityConfigForES, log)
+		visibilityFromES = elasticsearch.NewElasticSearchVisibilityManager(params.ESClient, visibilityIndexName, visibilityConfigForES, base.GetThrottledBarkLogger())
+		// bound search requests with the configured persistence deadlines
+		visibilityFromES = persistence.NewVisibilityPersistenceTimeoutClient(visibilityFromES, &params.PersistenceConfig.Timeouts)
 		// wrap with rate limiter
 		esRateLimiter := tokenbucket.New(s.config.PersistenceMaxQPS(), clock.NewRealTimeSource())
 		visibilityFromES = persistence.NewVisibilityPersistenceRateLimitedClient(visibilityFromES, esRateLimiter, log)
 		// wrap with advanced rate limit for list
 		visibilityFromES = persistence.NewVisibilitySamplingClient(visibilityFromES, visibilityConfigForES, base.GetMetricsClient(), log)
 		// wrap with metrics
-		visibilityFromES = elasticsearch.NewVisibilityMetricsClient(visibilityFromES, base.GetMetricsClient(), log)
+		visibilityFromES = elasticsearch.NewVisibilityMetricsClient(visibilityFromES, base.GetMetricsClient(),
+			persistence.NewDomainMetricsTagger(base.GetMetricsClient(), s.config.PersistenceDomainMetrics), log)
 	}
 	visibility := persistence.NewVisibilityManagerWrapper(visibilityFromDB, visibilityFromES, s.config.EnableReadVisibilityFromES)
 
@@ -187,18 +266,32 @@ func (s *Service) Start() {
 		kafkaProducer = &mocks.KafkaProducer{}
 	}
 
+	var domainChangeNotifier cache.DomainChangeNotifier
+	if s.config.EnableDomainChangeNotification() {
+		consumerName := fmt.Sprintf("%v-%v", common.FrontendServiceName, base.GetHostName())
+		domainChangeNotifier, err = cache.NewKafkaDomainChangeNotifier(base.GetMessagingClient(), consumerName, log)
+		if err != nil {
+			log.Fatalf("Creating domain change notifier failed: %v", err)
+		}
+	}
+
+	searchAttributes := NewSearchAttributesCache(s.config, clusterMetadataMgr)
 	wfHandler := NewWorkflowHandler(base, s.config, metadata, history, historyV2, visibility, kafkaProducer,
-		params.BlobstoreClient)
+		params.BlobstoreClient, domainChangeNotifier, params.SchemaValidator, searchAttributes)
 	wfHandler.Start()
 	dcRedirectionHandler := NewDCRedirectionHandler(wfHandler, params.DCRedirectionPolicy)
-	base.GetDispatcher().Register(workflowserviceserver.New(dcRedirectionHandler))
-	adminHandler := NewAdminHandler(base, pConfig.NumHistoryShards, metadata, history, historyV2)
+	versionCheckHandler := NewVersionCheckHandler(dcRedirectionHandler, wfHandler)
+	latencySLOHandler := NewLatencySLOHandler(versionCheckHandler, wfHandler)
+	base.GetDispatcher().Register(workflowserviceserver.New(latencySLOHandler))
+	adminHandler := NewAdminHandler(base, params.PersistenceConfig.NumHistoryShards, metadata, history, historyV2, clusterMetadataMgr,
+		esClient, visibilityIndexName, searchAttributes)
 	adminHandler.Start()
 
 	log.Infof("%v started", common.FrontendServiceName)
 
 	<-s.stopC
 
+	clusterMetadataRefresher.Stop()
 	base.Stop()
 }
 
@@ -0,0 +1,490 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+
+	"github.com/uber/cadence/.gen/go/cadence/workflowserviceserver"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common/client"
+	"github.com/uber/cadence/common/metrics"
+)
+
+type (
+	// VersionCheckHandlerImpl is simple wrapper over frontend service, rejecting calls from
+	// client libraries older than the minimum supported feature version
+	VersionCheckHandlerImpl struct {
+		handler        workflowserviceserver.Interface
+		versionChecker client.VersionChecker
+		metricsClient  metrics.Client
+	}
+)
+
+var _ workflowserviceserver.Interface = (*VersionCheckHandlerImpl)(nil)
+
+// NewVersionCheckHandler creates a thrift handler for the cadence service, frontend
+func NewVersionCheckHandler(handler workflowserviceserver.Interface, wfHandler *WorkflowHandler) *VersionCheckHandlerImpl {
+	return &VersionCheckHandlerImpl{
+		handler:        handler,
+		versionChecker: wfHandler.versionChecker,
+		metricsClient:  wfHandler.GetMetricsClient(),
+	}
+}
+
+// DeprecateDomain API call
+func (handler *VersionCheckHandlerImpl) DeprecateDomain(
+	ctx context.Context,
+	request *shared.DeprecateDomainRequest,
+) error {
+
+	if err := handler.checkClient(ctx, metrics.FrontendDeprecateDomainScope); err != nil {
+		return err
+	}
+	return handler.handler.DeprecateDomain(ctx, request)
+}
+
+// DescribeDomain API call
+func (handler *VersionCheckHandlerImpl) DescribeDomain(
+	ctx context.Context,
+	request *shared.DescribeDomainRequest,
+) (*shared.DescribeDomainResponse, error) {
+
+	if err := handler.checkClient(ctx, metrics.FrontendDescribeDomainScope); err != nil {
+		return nil, err
+	}
+	return handler.handler.DescribeDomain(ctx, request)
+}
+
+// ListDomains API call
+func (handler *VersionCheckHandlerImpl) ListDomains(
+	ctx context.Context,
+	request *shared.ListDomainsRequest,
+) (*shared.ListDomainsResponse, error) {
+
+	if err := handler.checkClient(ctx, metrics.FrontendListDomainsScope); err != nil {
+		return nil, err
+	}
+	return handler.handler.ListDomains(ctx, request)
+}
+
+// RegisterDomain API call
+func (handler *VersionCheckHandlerImpl) RegisterDomain(
+	ctx context.Context,
+	request *shared.RegisterDomainRequest,
+) error {
+
+	if err := handler.checkClient(ctx, metrics.FrontendRegisterDomainScope); err != nil {
+		return err
+	}
+	return handler.handler.RegisterDomain(ctx, request)
+}
+
+// UpdateDomain API call
+func (handler *VersionCheckHandlerImpl) UpdateDomain(
+	ctx context.Context,
+	request *shared.UpdateDomainRequest,
+) (*shared.UpdateDomainResponse, error) {
+
+	if err := handler.checkClient(ctx, metrics.FrontendUpdateDomainScope); err != nil {
+		return nil, err
+	}
+	return handler.handler.UpdateDomain(ctx, request)
+}
+
+// DescribeTaskList API call
+func (handler *VersionCheckHandlerImpl) DescribeTaskList(
+	ctx context.Context,
+	request *shared.DescribeTaskListRequest,
+) (*shared.DescribeTaskListResponse, error) {
+
+	if err := handler.checkClient(ctx, metrics.FrontendDescribeTaskListScope); err != nil {
+		return nil, err
+	}
+	return handler.handler.DescribeTaskList(ctx, request)
+}
+
+// DescribeWorkflowExecution API call
+func (handler *VersionCheckHandlerImpl) DescribeWorkflowExecution(
+	ctx context.Context,
+	request *shared.DescribeWorkflowExecutionRequest,
+) (*shared.DescribeWorkflowExecutionResponse, error) {
+
+	if err := handler.checkClient(ctx, metrics.FrontendDescribeWorkflowExecutionScope); err != nil {
+		return nil, err
+	}
+	return handler.handler.DescribeWorkflowExecution(ctx, request)
+}
+
+// DescribeWorkflowExecutions API call
+func (handler *VersionCheckHandlerImpl) DescribeWorkflowExecutions(
+	ctx context.Context,
+	request *shared.DescribeWorkflowExecutionsRequest,
+) (*shared.DescribeWorkflowExecutionsResponse, error) {
+
+	if err := handler.checkClient(ctx, metrics.FrontendDescribeWorkflowExecutionsScope); err != nil {
+		return nil, err
+	}
+	return handler.handler.DescribeWorkflowExecutions(ctx, request)
+}
+
+// GetSearchAttributes API call
+func (handler *VersionCheckHandlerImpl) GetSearchAttributes(
+	ctx context.Context,
+) (*shared.GetSearchAttributesResponse, error) {
+
+	if err := handler.checkClient(ctx, metrics.FrontendGetSearchAttributesScope); err != nil {
+		return nil, err
+	}
+	return handler.handler.GetSearchAttributes(ctx)
+}
+
+// GetWorkflowExecutionHistory API call
+func (handler *VersionCheckHandlerImpl) GetWorkflowExecutionHistory(
+	ctx context.Context,
+	request *shared.GetWorkflowExecutionHistoryRequest,
+) (*shared.GetWorkflowExecutionHistoryResponse, error) {
+
+	if err := handler.checkClient(ctx, metrics.FrontendGetWorkflowExecutionHistoryScope); err != nil {
+		return nil, err
+	}
+	return handler.handler.GetWorkflowExecutionHistory(ctx, request)
+}
+
+// GetWorkflowExecutionStatistics API call
+func (handler *VersionCheckHandlerImpl) GetWorkflowExecutionStatistics(
+	ctx context.Context,
+	request *shared.GetWorkflowExecutionStatisticsRequest,
+) (*shared.GetWorkflowExecutionStatisticsResponse, error) {
+
+	if err := handler.checkClient(ctx, metrics.FrontendGetWorkflowExecutionStatisticsScope); err != nil {
+		return nil, err
+	}
+	return handler.handler.GetWorkflowExecutionStatistics(ctx, request)
+}
+
+// CountOpenWorkflowExecutions API call
+func (handler *VersionCheckHandlerImpl) CountOpenWorkflowExecutions(
+	ctx context.Context,
+	request *shared.CountOpenWorkflowExecutionsRequest,
+) (*shared.CountOpenWorkflowExecutionsResponse, error) {
+
+	if err := handler.checkClient(ctx, metrics.FrontendCountOpenWorkflowExecutionsScope); err != nil {
+		return nil, err
+	}
+	return handler.handler.CountOpenWorkflowExecutions(ctx, request)
+}
+
+// ListClosedWorkflowExecutions API call
+func (handler *VersionCheckHandlerImpl) ListClosedWorkflowExecutions(
+	ctx context.Context,
+	request *shared.ListClosedWorkflowExecutionsRequest,
+) (*shared.ListClosedWorkflowExecutionsResponse, error) {
+
+	if err := handler.checkClient(ctx, metrics.FrontendListClosedWorkflowExecutionsScope); err != nil {
+		return nil, err
+	}
+	return handler.handler.ListClosedWorkflowExecutions(ctx, request)
+}
+
+// ListOpenWorkflowExecutions API call
+func (handler *VersionCheckHandlerImpl) ListOpenWorkflowExecutions(
+	ctx context.Context,
+	request *shared.ListOpenWorkflowExecutionsRequest,
+) (*shared.ListOpenWorkflowExecutionsResponse, error) {
+
+	if err := handler.checkClient(ctx, metrics.FrontendListOpenWorkflowExecutionsScope); err != nil {
+		return nil, err
+	}
+	return handler.handler.ListOpenWorkflowExecutions(ctx, request)
+}
+
+// PollForActivityTask API call
+func (handler *VersionCheckHandlerImpl) PollForActivityTask(
+	ctx context.Context,
+	request *shared.PollForActivityTaskRequest,
+) (*shared.PollForActivityTaskResponse, error) {
+
+	if err := handler.checkClient(ctx, metrics.FrontendPollForActivityTaskScope); err != nil {
+		return nil, err
+	}
+	return handler.handler.PollForActivityTask(ctx, request)
+}
+
+// PollForDecisionTask API call
+func (handler *VersionCheckHandlerImpl) PollForDecisionTask(
+	ctx context.Context,
+	request *shared.PollForDecisionTaskRequest,
+) (*shared.PollForDecisionTaskResponse, error) {
+
+	if err := handler.checkClient(ctx, metrics.FrontendPollForDecisionTaskScope); err != nil {
+		return nil, err
+	}
+	return handler.handler.PollForDecisionTask(ctx, request)
+}
+
+// QueryWorkflow API call
+func (handler *VersionCheckHandlerImpl) QueryWorkflow(
+	ctx context.Context,
+	request *shared.QueryWorkflowRequest,
+) (*shared.QueryWorkflowResponse, error) {
+
+	if err := handler.checkClient(ctx, metrics.FrontendQueryWorkflowScope); err != nil {
+		return nil, err
+	}
+	return handler.handler.QueryWorkflow(ctx, request)
+}
+
+// RecordActivityTaskHeartbeat API call
+func (handler *VersionCheckHandlerImpl) RecordActivityTaskHeartbeat(
+	ctx context.Context,
+	request *shared.RecordActivityTaskHeartbeatRequest,
+) (*shared.RecordActivityTaskHeartbeatResponse, error) {
+
+	if err := handler.checkClient(ctx, metrics.FrontendRecordActivityTaskHeartbeatScope); err != nil {
+		return nil, err
+	}
+	return handler.handler.RecordActivityTaskHeartbeat(ctx, request)
+}
+
+// RecordActivityTaskHeartbeatByID API call
+func (handler *VersionCheckHandlerImpl) RecordActivityTaskHeartbeatByID(
+	ctx context.Context,
+	request *shared.RecordActivityTaskHeartbeatByIDRequest,
+) (*shared.RecordActivityTaskHeartbeatResponse, error) {
+
+	if err := handler.checkClient(ctx, metrics.FrontendRecordActivityTaskHeartbeatByIDScope); err != nil {
+		return nil, err
+	}
+	return handler.handler.RecordActivityTaskHeartbeatByID(ctx, request)
+}
+
+// RequestCancelWorkflowExecution API call
+func (handler *VersionCheckHandlerImpl) RequestCancelWorkflowExecution(
+	ctx context.Context,
+	request *shared.RequestCancelWorkflowExecutionRequest,
+) error {
+
+	if err := handler.checkClient(ctx, metrics.FrontendRequestCancelWorkflowExecutionScope); err != nil {
+		return err
+	}
+	return handler.handler.RequestCancelWorkflowExecution(ctx, request)
+}
+
+// ResetStickyTaskList API call
+func (handler *VersionCheckHandlerImpl) ResetStickyTaskList(
+	ctx context.Context,
+	request *shared.ResetStickyTaskListRequest,
+) (*shared.ResetStickyTaskListResponse, error) {
+
+	if err := handler.checkClient(ctx, metrics.FrontendResetStickyTaskListScope); err != nil {
+		return nil, err
+	}
+	return handler.handler.ResetStickyTaskList(ctx, request)
+}
+
+// ResetWorkflowExecution API call
+func (handler *VersionCheckHandlerImpl) ResetWorkflowExecution(
+	ctx context.Context,
+	request *shared.ResetWorkflowExecutionRequest,
+) (*shared.ResetWorkflowExecutionResponse, error) {
+
+	if err := handler.checkClient(ctx, metrics.FrontendResetWorkflowExecutionScope); err != nil {
+		return nil, err
+	}
+	return handler.handler.ResetWorkflowExecution(ctx, request)
+}
+
+// RespondActivityTaskCanceled API call
+func (handler *VersionCheckHandlerImpl) RespondActivityTaskCanceled(
+	ctx context.Context,
+	request *shared.RespondActivityTaskCanceledRequest,
+) error {
+
+	if err := handler.checkClient(ctx, metrics.FrontendRespondActivityTaskCanceledScope); err != nil {
+		return err
+	}
+	return handler.handler.RespondActivityTaskCanceled(ctx, request)
+}
+
+// RespondActivityTaskCanceledByID API call
+func (handler *VersionCheckHandlerImpl) RespondActivityTaskCanceledByID(
+	ctx context.Context,
+	request *shared.RespondActivityTaskCanceledByIDRequest,
+) error {
+
+	if err := handler.checkClient(ctx, metrics.FrontendRespondActivityTaskCanceledByIDScope); err != nil {
+		return err
+	}
+	return handler.handler.RespondActivityTaskCanceledByID(ctx, request)
+}
+
+// RespondActivityTaskCompleted API call
+func (handler *VersionCheckHandlerImpl) RespondActivityTaskCompleted(
+	ctx context.Context,
+	request *shared.RespondActivityTaskCompletedRequest,
+) error {
+
+	if err := handler.checkClient(ctx, metrics.FrontendRespondActivityTaskCompletedScope); err != nil {
+		return err
+	}
+	return handler.handler.RespondActivityTaskCompleted(ctx, request)
+}
+
+// RespondActivityTaskCompletedByID API call
+func (handler *VersionCheckHandlerImpl) RespondActivityTaskCompletedByID(
+	ctx context.Context,
+	request *shared.RespondActivityTaskCompletedByIDRequest,
+) error {
+
+	if err := handler.checkClient(ctx, metrics.FrontendRespondActivityTaskCompletedByIDScope); err != nil {
+		return err
+	}
+	return handler.handler.RespondActivityTaskCompletedByID(ctx, request)
+}
+
+// RespondActivityTaskFailed API call
+func (handler *VersionCheckHandlerImpl) RespondActivityTaskFailed(
+	ctx context.Context,
+	request *shared.RespondActivityTaskFailedRequest,
+) error {
+
+	if err := handler.checkClient(ctx, metrics.FrontendRespondActivityTaskFailedScope); err != nil {
+		return err
+	}
+	return handler.handler.RespondActivityTaskFailed(ctx, request)
+}
+
+// RespondActivityTaskFailedByID API call
+func (handler *VersionCheckHandlerImpl) RespondActivityTaskFailedByID(
+	ctx context.Context,
+	request *shared.RespondActivityTaskFailedByIDRequest,
+) error {
+
+	if err := handler.checkClient(ctx, metrics.FrontendRespondActivityTaskFailedByIDScope); err != nil {
+		return err
+	}
+	return handler.handler.RespondActivityTaskFailedByID(ctx, request)
+}
+
+// RespondDecisionTaskCompleted API call
+func (handler *VersionCheckHandlerImpl) RespondDecisionTaskCompleted(
+	ctx context.Context,
+	request *shared.RespondDecisionTaskCompletedRequest,
+) (*shared.RespondDecisionTaskCompletedResponse, error) {
+
+	if err := handler.checkClient(ctx, metrics.FrontendRespondDecisionTaskCompletedScope); err != nil {
+		return nil, err
+	}
+	return handler.handler.RespondDecisionTaskCompleted(ctx, request)
+}
+
+// RespondDecisionTaskFailed API call
+func (handler *VersionCheckHandlerImpl) RespondDecisionTaskFailed(
+	ctx context.Context,
+	request *shared.RespondDecisionTaskFailedRequest,
+) error {
+
+	if err := handler.checkClient(ctx, metrics.FrontendRespondDecisionTaskFailedScope); err != nil {
+		return err
+	}
+	return handler.handler.RespondDecisionTaskFailed(ctx, request)
+}
+
+// RespondQueryTaskCompleted API call
+func (handler *VersionCheckHandlerImpl) RespondQueryTaskCompleted(
+	ctx context.Context,
+	request *shared.RespondQueryTaskCompletedRequest,
+) error {
+
+	if err := handler.checkClient(ctx, metrics.FrontendRespondQueryTaskCompletedScope); err != nil {
+		return err
+	}
+	return handler.handler.RespondQueryTaskCompleted(ctx, request)
+}
+
+// SignalWithStartWorkflowExecution API call
+func (handler *VersionCheckHandlerImpl) SignalWithStartWorkflowExecution(
+	ctx context.Context,
+	request *shared.SignalWithStartWorkflowExecutionRequest,
+) (*shared.StartWorkflowExecutionResponse, error) {
+
+	if err := handler.checkClient(ctx, metrics.FrontendSignalWithStartWorkflowExecutionScope); err != nil {
+		return nil, err
+	}
+	return handler.handler.SignalWithStartWorkflowExecution(ctx, request)
+}
+
+// SignalWorkflowExecution API call
+func (handler *VersionCheckHandlerImpl) SignalWorkflowExecution(
+	ctx context.Context,
+	request *shared.SignalWorkflowExecutionRequest,
+) error {
+
+	if err := handler.checkClient(ctx, metrics.FrontendSignalWorkflowExecutionScope); err != nil {
+		return err
+	}
+	return handler.handler.SignalWorkflowExecution(ctx, request)
+}
+
+// StartWorkflowExecution API call
+func (handler *VersionCheckHandlerImpl) StartWorkflowExecution(
+	ctx context.Context,
+	request *shared.StartWorkflowExecutionRequest,
+) (*shared.StartWorkflowExecutionResponse, error) {
+
+	if err := handler.checkClient(ctx, metrics.FrontendStartWorkflowExecutionScope); err != nil {
+		return nil, err
+	}
+	return handler.handler.StartWorkflowExecution(ctx, request)
+}
+
+// TerminateWorkflowExecution API call
+func (handler *VersionCheckHandlerImpl) TerminateWorkflowExecution(
+	ctx context.Context,
+	request *shared.TerminateWorkflowExecutionRequest,
+) error {
+
+	if err := handler.checkClient(ctx, metrics.FrontendTerminateWorkflowExecutionScope); err != nil {
+		return err
+	}
+	return handler.handler.TerminateWorkflowExecution(ctx, request)
+}
+
+// UpdateWorkflowExecutionLabels API call
+func (handler *VersionCheckHandlerImpl) UpdateWorkflowExecutionLabels(
+	ctx context.Context,
+	request *shared.UpdateWorkflowExecutionLabelsRequest,
+) error {
+
+	if err := handler.checkClient(ctx, metrics.FrontendUpdateWorkflowExecutionLabelsScope); err != nil {
+		return err
+	}
+	return handler.handler.UpdateWorkflowExecutionLabels(ctx, request)
+}
+
+func (handler *VersionCheckHandlerImpl) checkClient(ctx context.Context, scope int) error {
+	err := handler.versionChecker.ClientSupported(ctx)
+	if err != nil {
+		handler.metricsClient.IncCounter(scope, metrics.CadenceClientVersionRejected)
+	}
+	return err
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-go/tally"
+	"github.com/uber/cadence/.gen/go/cadence/workflowserviceserver"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common/metrics"
+)
+
+type (
+	versionCheckHandlerSuite struct {
+		suite.Suite
+		testScope      tally.TestScope
+		innerHandler   *versionCheckTestHandler
+		versionChecker *versionCheckTestChecker
+		handler        *VersionCheckHandlerImpl
+	}
+
+	versionCheckTestHandler struct {
+		workflowserviceserver.Interface
+		calls int
+	}
+
+	versionCheckTestChecker struct {
+		err error
+	}
+)
+
+func TestVersionCheckHandlerSuite(t *testing.T) {
+	s := new(versionCheckHandlerSuite)
+	suite.Run(t, s)
+}
+
+func (s *versionCheckHandlerSuite) SetupTest() {
+	s.testScope = tally.NewTestScope("", nil)
+	s.innerHandler = &versionCheckTestHandler{}
+	s.versionChecker = &versionCheckTestChecker{}
+	s.handler = &VersionCheckHandlerImpl{
+		handler:        s.innerHandler,
+		versionChecker: s.versionChecker,
+		metricsClient:  metrics.NewClient(s.testScope, metrics.Frontend),
+	}
+}
+
+func (s *versionCheckHandlerSuite) TestSupportedClient() {
+	resp, err := s.handler.DescribeDomain(context.Background(), &shared.DescribeDomainRequest{})
+	s.NoError(err)
+	s.NotNil(resp)
+	s.Equal(1, s.innerHandler.calls)
+	s.Equal(int64(0), s.rejectedCount())
+}
+
+func (s *versionCheckHandlerSuite) TestUnsupportedClient() {
+	s.versionChecker.err = &shared.BadRequestError{Message: "unsupported client"}
+	resp, err := s.handler.DescribeDomain(context.Background(), &shared.DescribeDomainRequest{})
+	s.Equal(s.versionChecker.err, err)
+	s.Nil(resp)
+	s.Equal(0, s.innerHandler.calls)
+	s.Equal(int64(1), s.rejectedCount())
+}
+
+func (s *versionCheckHandlerSuite) rejectedCount() int64 {
+	var value int64
+	for _, c := range s.testScope.Snapshot().Counters() {
+		if c.Name() == "cadence_client_version_rejected" {
+			value += c.Value()
+		}
+	}
+	return value
+}
+
+func (h *versionCheckTestHandler) DescribeDomain(
+	ctx context.Context,
+	request *shared.DescribeDomainRequest,
+) (*shared.DescribeDomainResponse, error) {
+
+	h.calls++
+	return &shared.DescribeDomainResponse{}, nil
+}
+
+func (c *versionCheckTestChecker) ClientSupported(ctx context.Context) error {
+	return c.err
+}
+
+func (c *versionCheckTestChecker) SupportsFeature(clientImpl string, featureVersion string, feature string) error {
+	return nil
+}
@@ -25,6 +25,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -53,6 +54,7 @@ import (
 	"github.com/uber/cadence/common/logging"
 	"github.com/uber/cadence/common/messaging"
 	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/payload"
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/service"
 	"github.com/uber/cadence/common/tokenbucket"
@@ -80,9 +82,38 @@ type (
 		config            *Config
 		domainReplicator  DomainReplicator
 		blobstoreClient   blobstore.Client
+		// domainAdmissionHandler validates domain registrations and updates
+		domainAdmissionHandler DomainAdmissionHandler
+		// domainChangeNotifier broadcasts domain changes to other hosts, nil if not enabled
+		domainChangeNotifier cache.DomainChangeNotifier
+		// openWorkflowCounts caches the open workflow count of each domain by domain ID
+		openWorkflowCounts cache.Cache
+		// stackTraceQueries caches the stack trace query results of each workflow execution
+		stackTraceQueries cache.Cache
+		// payloadSizeLimiter enforces the blob size limits on request payloads
+		payloadSizeLimiter *payloadSizeLimiter
+		// versionChecker negotiates the capabilities of client libraries
+		versionChecker client.VersionChecker
+		// schemaValidator validates workflow start and signal inputs, nil if not configured
+		schemaValidator payload.SchemaValidator
+		// searchAttributes caches the search attribute keys visibility queries can use
+		searchAttributes SearchAttributesCache
 		service.Service
 	}
 
+	openWorkflowCount struct {
+		count     int64
+		updatedAt time.Time
+	}
+
+	// stackTraceQueryResult is a stack trace query result, which is valid as long as the workflow
+	// has not completed any decision after the one it was taken at
+	stackTraceQueryResult struct {
+		previousStartedEventID int64
+		result                 []byte
+		updatedAt              time.Time
+	}
+
 	getHistoryContinuationToken struct {
 		RunID             string
 		FirstEventID      int64
@@ -93,10 +124,14 @@ type (
 		EventStoreVersion int32
 		BranchToken       []byte
 		ReplicationInfo   map[string]*gen.ReplicationInfo
+		ReverseOrder      bool
 	}
 
 	getHistoryContinuationTokenArchival struct {
 		BlobstorePageToken int
+		// EventIndex is the index of the first event to return from the blob, a single blob is paginated
+		// through when it contains more events than the requested page size
+		EventIndex int
 	}
 )
 
@@ -112,8 +147,12 @@ var (
 	errRunIDNotSet                                = &gen.BadRequestError{Message: "RunId is not set on request."}
 	errActivityIDNotSet                           = &gen.BadRequestError{Message: "ActivityID is not set on request."}
 	errInvalidRunID                               = &gen.BadRequestError{Message: "Invalid RunId."}
+	errInvalidSignalRequestID                     = &gen.BadRequestError{Message: "Invalid RequestId, signal request id must be a UUID."}
+	errInvalidDeliveryTimestamp                   = &gen.BadRequestError{Message: "Invalid DeliveryTimestamp."}
 	errInvalidNextPageToken                       = &gen.BadRequestError{Message: "Invalid NextPageToken."}
 	errNextPageTokenRunIDMismatch                 = &gen.BadRequestError{Message: "RunID in the request does not match the NextPageToken."}
+	errReverseOrderWithLongPoll                   = &gen.BadRequestError{Message: "ReverseOrder cannot be used with WaitForNewEvent."}
+	errReverseOrderArchivedHistory                = &gen.BadRequestError{Message: "ReverseOrder is not supported for archived history."}
 	errQueryNotSet                                = &gen.BadRequestError{Message: "WorkflowQuery is not set on request."}
 	errQueryTypeNotSet                            = &gen.BadRequestError{Message: "QueryType is not set on request."}
 	errRequestNotSet                              = &gen.BadRequestError{Message: "Request is nil."}
@@ -126,6 +165,8 @@ var (
 	// err for archival
 	errDomainHasNeverBeenEnabledForArchival = &gen.BadRequestError{Message: "Attempted to fetch history from archival, but domain has never been enabled for archival."}
 	errInvalidNextArchivalPageToken         = &gen.BadRequestError{Message: "Invalid NextPageToken for archival."}
+	errUnknownArchivalEncoding              = &gen.InternalServiceError{Message: "Archived history blob has unknown encoding."}
+	errInvalidArchivedHistoryBlob           = &gen.InternalServiceError{Message: "Archived history blob is malformed."}
 
 	// err for string too long
 	errDomainTooLong       = &gen.BadRequestError{Message: "Domain length exceeds limit."}
@@ -136,6 +177,19 @@ var (
 	errRequestIDTooLong    = &gen.BadRequestError{Message: "RequestID length exceeds limit."}
 	errIdentityTooLong     = &gen.BadRequestError{Message: "Identity length exceeds limit."}
 
+	errConcurrencyGroupTooLong          = &gen.BadRequestError{Message: "ConcurrencyGroup length exceeds limit."}
+	errInvalidConcurrencyLimit          = &gen.BadRequestError{Message: "A valid ConcurrencyLimit is not set on request."}
+	errConcurrencyGroupWithCronSchedule = &gen.BadRequestError{Message: "ConcurrencyGroup cannot be used with CronSchedule."}
+	errConcurrencyGroupsDisabled        = &gen.BadRequestError{Message: "Concurrency groups are not enabled for the domain."}
+
+	errLabelsNotSet            = &gen.BadRequestError{Message: "Neither UpsertLabels nor RemoveLabels is set on request."}
+	errInvalidLabelKey         = &gen.BadRequestError{Message: "Label key must be non-empty and must not contain '='."}
+	errLabelTooLong            = &gen.BadRequestError{Message: "Label key or value length exceeds limit."}
+	errLabelUpsertedAndRemoved = &gen.BadRequestError{Message: "Label cannot be both upserted and removed in the same request."}
+
+	errExecutionsNotSet  = &gen.BadRequestError{Message: "Executions are not set on request."}
+	errTooManyExecutions = &gen.BadRequestError{Message: "Number of executions exceeds limit."}
+
 	// err indicating that this cluster is not the master, so cannot do domain registration or update
 	errNotMasterCluster                = &gen.BadRequestError{Message: "Cluster is not master cluster, cannot do domain registration or domain update."}
 	errCannotAddClusterToLocalDomain   = &gen.BadRequestError{Message: "Cannot add more replicated cluster to local domain."}
@@ -146,23 +200,48 @@ var (
 	frontendServiceRetryPolicy = common.CreateFrontendServiceRetryPolicy()
 )
 
+const (
+	openWorkflowCountCacheInitialSize = 16
+	openWorkflowCountCacheMaxSize     = 1024
+
+	stackTraceQueryCacheInitialSize = 256
+	stackTraceQueryCacheMaxSize     = 16 * 1024
+)
+
 // NewWorkflowHandler creates a thrift handler for the cadence service
 func NewWorkflowHandler(sVice service.Service, config *Config, metadataMgr persistence.MetadataManager,
 	historyMgr persistence.HistoryManager, historyV2Mgr persistence.HistoryV2Manager,
 	visibilityMgr persistence.VisibilityManager, kafkaProducer messaging.Producer,
-	blobstoreClient blobstore.Client) *WorkflowHandler {
+	blobstoreClient blobstore.Client, domainChangeNotifier cache.DomainChangeNotifier,
+	schemaValidator payload.SchemaValidator, searchAttributes SearchAttributesCache) *WorkflowHandler {
 	handler := &WorkflowHandler{
-		Service:          sVice,
-		config:           config,
-		metadataMgr:      metadataMgr,
-		historyMgr:       historyMgr,
-		historyV2Mgr:     historyV2Mgr,
-		visibilityMgr:    visibilityMgr,
-		tokenSerializer:  common.NewJSONTaskTokenSerializer(),
-		domainCache:      cache.NewDomainCache(metadataMgr, sVice.GetClusterMetadata(), sVice.GetMetricsClient(), sVice.GetBarkLogger()),
+		Service:         sVice,
+		config:          config,
+		metadataMgr:     metadataMgr,
+		historyMgr:      historyMgr,
+		historyV2Mgr:    historyV2Mgr,
+		visibilityMgr:   visibilityMgr,
+		tokenSerializer: common.NewJSONTaskTokenSerializer(),
+		domainCache: newDomainNotFoundCache(
+			cache.NewDomainCacheWithChangeNotifier(metadataMgr, sVice.GetClusterMetadata(), sVice.GetMetricsClient(), sVice.GetBarkLogger(), domainChangeNotifier),
+			config.DomainNotFoundCacheTTL,
+			sVice.GetMetricsClient(),
+		),
 		rateLimiter:      tokenbucket.New(config.RPS(), clock.NewRealTimeSource()),
 		domainReplicator: NewDomainReplicator(kafkaProducer, sVice.GetBarkLogger()),
 		blobstoreClient:  blobstoreClient,
+		openWorkflowCounts: cache.New(openWorkflowCountCacheMaxSize, &cache.Options{
+			InitialCapacity: openWorkflowCountCacheInitialSize,
+		}),
+		stackTraceQueries: cache.New(stackTraceQueryCacheMaxSize, &cache.Options{
+			InitialCapacity: stackTraceQueryCacheInitialSize,
+		}),
+		domainChangeNotifier:   domainChangeNotifier,
+		domainAdmissionHandler: NewConfigDomainAdmissionHandler(config),
+		payloadSizeLimiter:     newPayloadSizeLimiter(config.BlobSizeLimitError, config.BlobSizeLimitWarn, sVice.GetThrottledBarkLogger()),
+		versionChecker:         client.NewVersionChecker(config.EnableClientVersionCheck, config.MinSupportedClientFeatureVersion),
+		schemaValidator:        schemaValidator,
+		searchAttributes:       searchAttributes,
 	}
 	// prevent us from trying to serve requests before handler's Start() is complete
 	handler.startWG.Add(1)
@@ -177,6 +256,9 @@ func (wh *WorkflowHandler) Start() error {
 	// the registration needs to be specially handled, in the service.go
 	wh.Service.GetDispatcher().Register(metaserver.New(wh))
 	wh.Service.Start()
+	if wh.domainChangeNotifier != nil {
+		wh.domainChangeNotifier.Start()
+	}
 	wh.domainCache.Start()
 
 	wh.history = wh.GetClientBean().GetHistoryClient()
@@ -191,6 +273,9 @@ func (wh *WorkflowHandler) Start() error {
 // Stop stops the handler
 func (wh *WorkflowHandler) Stop() {
 	wh.domainCache.Stop()
+	if wh.domainChangeNotifier != nil {
+		wh.domainChangeNotifier.Stop()
+	}
 	wh.metadataMgr.Close()
 	wh.visibilityMgr.Close()
 	wh.historyMgr.Close()
@@ -250,8 +335,12 @@ func (wh *WorkflowHandler) RegisterDomain(ctx context.Context, registerRequest *
 		return wh.error(errDomainNotSet, scope)
 	}
 
+	if err := wh.domainAdmissionHandler.AdmitRegisterDomain(ctx, registerRequest); err != nil {
+		return wh.error(err, scope)
+	}
+
 	// first check if the name is already registered as the local domain
-	_, err := wh.metadataMgr.GetDomain(&persistence.GetDomainRequest{Name: registerRequest.GetName()})
+	_, err := wh.metadataMgr.GetDomain(ctx, &persistence.GetDomainRequest{Name: registerRequest.GetName()})
 	if err != nil {
 		if _, ok := err.(*gen.EntityNotExistsError); !ok {
 			return wh.error(err, scope)
@@ -329,7 +418,7 @@ func (wh *WorkflowHandler) RegisterDomain(ctx context.Context, registerRequest *
 		FailoverVersion: clusterMetadata.GetNextFailoverVersion(activeClusterName, 0),
 	}
 
-	domainResponse, err := wh.metadataMgr.CreateDomain(domainRequest)
+	domainResponse, err := wh.metadataMgr.CreateDomain(ctx, domainRequest)
 	if err != nil {
 		return wh.error(err, scope)
 	}
@@ -366,7 +455,7 @@ func (wh *WorkflowHandler) ListDomains(ctx context.Context,
 		pageSize = int(listRequest.GetPageSize())
 	}
 
-	resp, err := wh.metadataMgr.ListDomains(&persistence.ListDomainsRequest{
+	resp, err := wh.metadataMgr.ListDomains(ctx, &persistence.ListDomainsRequest{
 		PageSize:      pageSize,
 		NextPageToken: listRequest.NextPageToken,
 	})
@@ -414,7 +503,7 @@ func (wh *WorkflowHandler) DescribeDomain(ctx context.Context,
 		Name: describeRequest.GetName(),
 		ID:   describeRequest.GetUUID(),
 	}
-	resp, err := wh.metadataMgr.GetDomain(req)
+	resp, err := wh.metadataMgr.GetDomain(ctx, req)
 	if err != nil {
 		return nil, wh.error(err, scope)
 	}
@@ -457,16 +546,20 @@ func (wh *WorkflowHandler) UpdateDomain(ctx context.Context,
 		return nil, wh.error(errDomainNotSet, scope)
 	}
 
+	if err := wh.domainAdmissionHandler.AdmitUpdateDomain(ctx, updateRequest); err != nil {
+		return nil, wh.error(err, scope)
+	}
+
 	// must get the metadata (notificationVersion) first
 	// this version can be regarded as the lock on the v2 domain table
 	// and since we do not know which table will return the domain afterwards
 	// this call has to be made
-	metadata, err := wh.metadataMgr.GetMetadata()
+	metadata, err := wh.metadataMgr.GetMetadata(ctx)
 	if err != nil {
 		return nil, wh.error(err, scope)
 	}
 	notificationVersion := metadata.NotificationVersion
-	getResponse, err := wh.metadataMgr.GetDomain(&persistence.GetDomainRequest{Name: updateRequest.GetName()})
+	getResponse, err := wh.metadataMgr.GetDomain(ctx, &persistence.GetDomainRequest{Name: updateRequest.GetName()})
 	if err != nil {
 		return nil, wh.error(err, scope)
 	}
@@ -479,8 +572,9 @@ func (wh *WorkflowHandler) UpdateDomain(ctx context.Context,
 	failoverNotificationVersion := getResponse.FailoverNotificationVersion
 
 	currentArchivalState := &archivalState{
-		bucket: config.ArchivalBucket,
-		status: config.ArchivalStatus,
+		bucket:       config.ArchivalBucket,
+		targetBucket: config.ArchivalTargetBucket,
+		status:       config.ArchivalStatus,
 	}
 	nextArchivalState := currentArchivalState
 	archivalConfigChanged := false
@@ -598,6 +692,7 @@ func (wh *WorkflowHandler) UpdateDomain(ctx context.Context,
 			configurationChanged = true
 			config.ArchivalBucket = nextArchivalState.bucket
 			config.ArchivalStatus = nextArchivalState.status
+			config.ArchivalTargetBucket = nextArchivalState.targetBucket
 		}
 	}
 	if updateRequest.ReplicationConfiguration != nil {
@@ -643,10 +738,11 @@ func (wh *WorkflowHandler) UpdateDomain(ctx context.Context,
 		default:
 			return nil, wh.error(errors.New("domain table version is not set"), scope)
 		}
-		err = wh.metadataMgr.UpdateDomain(updateReq)
+		err = wh.metadataMgr.UpdateDomain(ctx, updateReq)
 		if err != nil {
 			return nil, wh.error(err, scope)
 		}
+		wh.notifyDomainChange(updateReq)
 
 		if getResponse.IsGlobalDomain {
 			err = wh.domainReplicator.HandleTransmissionTask(replicator.DomainOperationUpdate,
@@ -669,6 +765,22 @@ func (wh *WorkflowHandler) UpdateDomain(ctx context.Context,
 	return response, nil
 }
 
+// notifyDomainChange lets the domain caches of all hosts pick up the domain update right away,
+// failing to do so is not fatal since the caches still refresh periodically
+func (wh *WorkflowHandler) notifyDomainChange(updateReq *persistence.UpdateDomainRequest) {
+	// only domains in the v2 table are refreshed by the domain cache
+	if wh.domainChangeNotifier == nil || updateReq.TableVersion != persistence.DomainTableVersionV2 {
+		return
+	}
+
+	if err := wh.domainChangeNotifier.Notify(updateReq.Info.ID, updateReq.NotificationVersion); err != nil {
+		wh.GetBarkLogger().WithFields(bark.Fields{
+			logging.TagDomainID: updateReq.Info.ID,
+			logging.TagErr:      err,
+		}).Warn("Failed to publish domain change notification")
+	}
+}
+
 func (wh *WorkflowHandler) mergeDomainData(old map[string]string, new map[string]string) map[string]string {
 	if old == nil {
 		old = map[string]string{}
@@ -711,12 +823,12 @@ func (wh *WorkflowHandler) DeprecateDomain(ctx context.Context, deprecateRequest
 	// this version can be regarded as the lock on the v2 domain table
 	// and since we do not know which table will return the domain afterwards
 	// this call has to be made
-	metadata, err := wh.metadataMgr.GetMetadata()
+	metadata, err := wh.metadataMgr.GetMetadata(ctx)
 	if err != nil {
 		return wh.error(err, scope)
 	}
 	notificationVersion := metadata.NotificationVersion
-	getResponse, err := wh.metadataMgr.GetDomain(&persistence.GetDomainRequest{Name: deprecateRequest.GetName()})
+	getResponse, err := wh.metadataMgr.GetDomain(ctx, &persistence.GetDomainRequest{Name: deprecateRequest.GetName()})
 	if err != nil {
 		return wh.error(err, scope)
 	}
@@ -742,10 +854,11 @@ func (wh *WorkflowHandler) DeprecateDomain(ctx context.Context, deprecateRequest
 	default:
 		return wh.error(errors.New("domain table version is not set"), scope)
 	}
-	err = wh.metadataMgr.UpdateDomain(updateReq)
+	err = wh.metadataMgr.UpdateDomain(ctx, updateReq)
 	if err != nil {
 		return wh.error(err, scope)
 	}
+	wh.notifyDomainChange(updateReq)
 
 	if err != nil {
 		return wh.error(errDomainNotSet, scope)
@@ -989,17 +1102,15 @@ func (wh *WorkflowHandler) RecordActivityTaskHeartbeat(
 	scope = scope.Tagged(metrics.DomainTag(domainEntry.GetInfo().Name))
 
 	sizeLimitError := wh.config.BlobSizeLimitError(domainEntry.GetInfo().Name)
-	sizeLimitWarn := wh.config.BlobSizeLimitWarn(domainEntry.GetInfo().Name)
 
-	if err := common.CheckEventBlobSizeLimit(
+	if err := wh.payloadSizeLimiter.check(
+		payloadHeartbeatDetails,
 		len(heartbeatRequest.Details),
-		sizeLimitWarn,
-		sizeLimitError,
+		domainEntry.GetInfo().Name,
 		taskToken.DomainID,
 		taskToken.WorkflowID,
 		taskToken.RunID,
 		scope,
-		wh.GetThrottledBarkLogger(),
 	); err != nil {
 		// heartbeat details exceed size limit, we would fail the activity immediately with explicit error reason
 		failRequest := &gen.RespondActivityTaskFailedRequest{
@@ -1086,17 +1197,15 @@ func (wh *WorkflowHandler) RecordActivityTaskHeartbeatByID(
 	scope = scope.Tagged(metrics.DomainTag(domainEntry.GetInfo().Name))
 
 	sizeLimitError := wh.config.BlobSizeLimitError(domainEntry.GetInfo().Name)
-	sizeLimitWarn := wh.config.BlobSizeLimitWarn(domainEntry.GetInfo().Name)
 
-	if err := common.CheckEventBlobSizeLimit(
+	if err := wh.payloadSizeLimiter.check(
+		payloadHeartbeatDetails,
 		len(heartbeatRequest.Details),
-		sizeLimitWarn,
-		sizeLimitError,
+		domainEntry.GetInfo().Name,
 		taskToken.DomainID,
 		taskToken.WorkflowID,
 		taskToken.RunID,
 		scope,
-		wh.GetThrottledBarkLogger(),
 	); err != nil {
 		// heartbeat details exceed size limit, we would fail the activity immediately with explicit error reason
 		failRequest := &gen.RespondActivityTaskFailedRequest{
@@ -1172,17 +1281,15 @@ func (wh *WorkflowHandler) RespondActivityTaskCompleted(
 	scope = scope.Tagged(metrics.DomainTag(domainEntry.GetInfo().Name))
 
 	sizeLimitError := wh.config.BlobSizeLimitError(domainEntry.GetInfo().Name)
-	sizeLimitWarn := wh.config.BlobSizeLimitWarn(domainEntry.GetInfo().Name)
 
-	if err := common.CheckEventBlobSizeLimit(
+	if err := wh.payloadSizeLimiter.check(
+		payloadActivityResult,
 		len(completeRequest.Result),
-		sizeLimitWarn,
-		sizeLimitError,
+		domainEntry.GetInfo().Name,
 		taskToken.DomainID,
 		taskToken.WorkflowID,
 		taskToken.RunID,
 		scope,
-		wh.GetThrottledBarkLogger(),
 	); err != nil {
 		// result exceeds blob size limit, we would record it as failure
 		failRequest := &gen.RespondActivityTaskFailedRequest{
@@ -1271,17 +1378,15 @@ func (wh *WorkflowHandler) RespondActivityTaskCompletedByID(
 	scope = scope.Tagged(metrics.DomainTag(domainEntry.GetInfo().Name))
 
 	sizeLimitError := wh.config.BlobSizeLimitError(domainEntry.GetInfo().Name)
-	sizeLimitWarn := wh.config.BlobSizeLimitWarn(domainEntry.GetInfo().Name)
 
-	if err := common.CheckEventBlobSizeLimit(
+	if err := wh.payloadSizeLimiter.check(
+		payloadActivityResult,
 		len(completeRequest.Result),
-		sizeLimitWarn,
-		sizeLimitError,
+		domainEntry.GetInfo().Name,
 		taskToken.DomainID,
 		taskToken.WorkflowID,
 		taskToken.RunID,
 		scope,
-		wh.GetThrottledBarkLogger(),
 	); err != nil {
 		// result exceeds blob size limit, we would record it as failure
 		failRequest := &gen.RespondActivityTaskFailedRequest{
@@ -1357,17 +1462,15 @@ func (wh *WorkflowHandler) RespondActivityTaskFailed(
 	}
 
 	sizeLimitError := wh.config.BlobSizeLimitError(domainEntry.GetInfo().Name)
-	sizeLimitWarn := wh.config.BlobSizeLimitWarn(domainEntry.GetInfo().Name)
 
-	if err := common.CheckEventBlobSizeLimit(
+	if err := wh.payloadSizeLimiter.check(
+		payloadActivityFailureDetails,
 		len(failedRequest.Details),
-		sizeLimitWarn,
-		sizeLimitError,
+		domainEntry.GetInfo().Name,
 		taskToken.DomainID,
 		taskToken.WorkflowID,
 		taskToken.RunID,
 		scope,
-		wh.GetThrottledBarkLogger(),
 	); err != nil {
 		// details exceeds blob size limit, we would truncate the details and put a specific error reason
 		failedRequest.Reason = common.StringPtr(common.FailureReasonFailureDetailsExceedsLimit)
@@ -1443,17 +1546,15 @@ func (wh *WorkflowHandler) RespondActivityTaskFailedByID(
 	scope = scope.Tagged(metrics.DomainTag(domainEntry.GetInfo().Name))
 
 	sizeLimitError := wh.config.BlobSizeLimitError(domainEntry.GetInfo().Name)
-	sizeLimitWarn := wh.config.BlobSizeLimitWarn(domainEntry.GetInfo().Name)
 
-	if err := common.CheckEventBlobSizeLimit(
+	if err := wh.payloadSizeLimiter.check(
+		payloadActivityFailureDetails,
 		len(failedRequest.Details),
-		sizeLimitWarn,
-		sizeLimitError,
+		domainEntry.GetInfo().Name,
 		taskToken.DomainID,
 		taskToken.WorkflowID,
 		taskToken.RunID,
 		scope,
-		wh.GetThrottledBarkLogger(),
 	); err != nil {
 		// details exceeds blob size limit, we would truncate the details and put a specific error reason
 		failedRequest.Reason = common.StringPtr(common.FailureReasonFailureDetailsExceedsLimit)
@@ -1518,17 +1619,15 @@ func (wh *WorkflowHandler) RespondActivityTaskCanceled(
 	}
 
 	sizeLimitError := wh.config.BlobSizeLimitError(domainEntry.GetInfo().Name)
-	sizeLimitWarn := wh.config.BlobSizeLimitWarn(domainEntry.GetInfo().Name)
 
-	if err := common.CheckEventBlobSizeLimit(
+	if err := wh.payloadSizeLimiter.check(
+		payloadActivityCancelationDetails,
 		len(cancelRequest.Details),
-		sizeLimitWarn,
-		sizeLimitError,
+		domainEntry.GetInfo().Name,
 		taskToken.DomainID,
 		taskToken.WorkflowID,
 		taskToken.RunID,
 		scope,
-		wh.GetThrottledBarkLogger(),
 	); err != nil {
 		// details exceeds blob size limit, we would record it as failure
 		failRequest := &gen.RespondActivityTaskFailedRequest{
@@ -1616,17 +1715,15 @@ func (wh *WorkflowHandler) RespondActivityTaskCanceledByID(
 	scope = scope.Tagged(metrics.DomainTag(domainEntry.GetInfo().Name))
 
 	sizeLimitError := wh.config.BlobSizeLimitError(domainEntry.GetInfo().Name)
-	sizeLimitWarn := wh.config.BlobSizeLimitWarn(domainEntry.GetInfo().Name)
 
-	if err := common.CheckEventBlobSizeLimit(
+	if err := wh.payloadSizeLimiter.check(
+		payloadActivityCancelationDetails,
 		len(cancelRequest.Details),
-		sizeLimitWarn,
-		sizeLimitError,
+		domainEntry.GetInfo().Name,
 		taskToken.DomainID,
 		taskToken.WorkflowID,
 		taskToken.RunID,
 		scope,
-		wh.GetThrottledBarkLogger(),
 	); err != nil {
 		// details exceeds blob size limit, we would record it as failure
 		failRequest := &gen.RespondActivityTaskFailedRequest{
@@ -1777,17 +1874,15 @@ func (wh *WorkflowHandler) RespondDecisionTaskFailed(
 	}
 
 	sizeLimitError := wh.config.BlobSizeLimitError(domainEntry.GetInfo().Name)
-	sizeLimitWarn := wh.config.BlobSizeLimitWarn(domainEntry.GetInfo().Name)
 
-	if err := common.CheckEventBlobSizeLimit(
+	if err := wh.payloadSizeLimiter.check(
+		payloadDecisionFailureDetails,
 		len(failedRequest.Details),
-		sizeLimitWarn,
-		sizeLimitError,
+		domainEntry.GetInfo().Name,
 		taskToken.DomainID,
 		taskToken.WorkflowID,
 		taskToken.RunID,
 		scope,
-		wh.GetThrottledBarkLogger(),
 	); err != nil {
 		// details exceed, we would just truncate the size for decision task failed as the details is not used anywhere by client code
 		failedRequest.Details = failedRequest.Details[0:sizeLimitError]
@@ -1927,6 +2022,10 @@ func (wh *WorkflowHandler) StartWorkflowExecution(
 		return nil, wh.error(errRequestIDTooLong, scope)
 	}
 
+	if err := wh.validateConcurrencyGroup(startRequest, scope); err != nil {
+		return nil, err
+	}
+
 	maxDecisionTimeout := int32(wh.config.MaxDecisionStartToCloseTimeout(startRequest.GetDomain()))
 	// TODO: remove this assignment and logging in future, so that frontend will just return bad request for large decision timeout
 	if startRequest.GetTaskStartToCloseTimeoutSeconds() > startRequest.GetExecutionStartToCloseTimeoutSeconds() {
@@ -1963,21 +2062,25 @@ func (wh *WorkflowHandler) StartWorkflowExecution(
 	// add domain tag to scope, so further metrics will have the domain tag
 	scope = scope.Tagged(metrics.DomainTag(domainName))
 
-	sizeLimitError := wh.config.BlobSizeLimitError(startRequest.GetDomain())
-	sizeLimitWarn := wh.config.BlobSizeLimitWarn(startRequest.GetDomain())
-	if err := common.CheckEventBlobSizeLimit(
+	if err := wh.payloadSizeLimiter.check(
+		payloadWorkflowInput,
 		len(startRequest.Input),
-		sizeLimitWarn,
-		sizeLimitError,
+		startRequest.GetDomain(),
 		domainID,
 		startRequest.GetWorkflowId(),
 		"",
 		scope,
-		wh.GetThrottledBarkLogger(),
 	); err != nil {
 		return nil, wh.error(err, scope)
 	}
 
+	if err := wh.validatePayloadSchema(ctx, payload.SchemaKey{
+		Domain:       domainName,
+		WorkflowType: startRequest.WorkflowType.GetName(),
+	}, startRequest.Input, scope); err != nil {
+		return nil, err
+	}
+
 	wh.Service.GetBarkLogger().Debugf("Start workflow execution request domainID: %v", domainID)
 
 	resp, err = wh.history.StartWorkflowExecution(ctx, common.CreateHistoryStartWorkflowRequest(domainID, startRequest))
@@ -2014,6 +2117,11 @@ func (wh *WorkflowHandler) GetWorkflowExecutionHistory(
 		return nil, err
 	}
 
+	isReverseOrder := getRequest.GetReverseOrder()
+	if isReverseOrder && getRequest.GetWaitForNewEvent() {
+		return nil, wh.error(errReverseOrderWithLongPoll, scope)
+	}
+
 	if getRequest.GetMaximumPageSize() <= 0 {
 		getRequest.MaximumPageSize = common.Int32Ptr(int32(wh.config.HistoryMaxPageSize(getRequest.GetDomain())))
 	}
@@ -2037,11 +2145,19 @@ func (wh *WorkflowHandler) GetWorkflowExecutionHistory(
 		getRequest.MaximumPageSize = common.Int32Ptr(common.GetHistoryMaxPageSize)
 	}
 
+	// executions past retention are no longer known to history service, their history is read from archival instead
 	configuredForArchival := wh.GetClusterMetadata().ArchivalConfig().ConfiguredForArchival()
 	enableArchivalRead := wh.GetClusterMetadata().ArchivalConfig().EnableReadFromArchival()
-	historyArchived := wh.historyArchived(ctx, getRequest, domainID)
-	if configuredForArchival && enableArchivalRead && historyArchived {
-		return wh.getArchivedHistory(ctx, getRequest, domainID, scope)
+	if configuredForArchival && enableArchivalRead && wh.historyArchived(ctx, getRequest, domainID) {
+		if isReverseOrder {
+			return nil, wh.error(errReverseOrderArchivedHistory, scope)
+		}
+		resp, err := wh.getArchivedHistory(ctx, getRequest, domainID, scope)
+		if err != nil {
+			return nil, err
+		}
+		resp.History.Events = filterHistoryEvents(resp.History.Events, getRequest.EventTypes)
+		return resp, nil
 	}
 
 	// this function return the following 5 things,
@@ -2084,6 +2200,9 @@ func (wh *WorkflowHandler) GetWorkflowExecutionHistory(
 		if execution.RunId != nil && execution.GetRunId() != token.RunID {
 			return nil, wh.error(errNextPageTokenRunIDMismatch, scope)
 		}
+		if token.ReverseOrder != isReverseOrder {
+			return nil, wh.error(errInvalidNextPageToken, scope)
+		}
 
 		execution.RunId = common.StringPtr(token.RunID)
 
@@ -2106,7 +2225,7 @@ func (wh *WorkflowHandler) GetWorkflowExecutionHistory(
 		}
 		token.EventStoreVersion, token.BranchToken, runID, lastFirstEventID, nextEventID, isWorkflowRunning, err = queryHistory(domainID, execution, queryNextEventID)
 		if err != nil {
-			return nil, wh.error(err, scope)
+			return nil, wh.error(wh.toDeletedExecutionError(ctx, err, getRequest.GetDomain(), domainID, execution), scope)
 		}
 
 		execution.RunId = &runID
@@ -2116,6 +2235,7 @@ func (wh *WorkflowHandler) GetWorkflowExecutionHistory(
 		token.NextEventID = nextEventID
 		token.IsWorkflowRunning = isWorkflowRunning
 		token.PersistenceToken = nil
+		token.ReverseOrder = isReverseOrder
 	}
 
 	history := &gen.History{}
@@ -2133,6 +2253,7 @@ func (wh *WorkflowHandler) GetWorkflowExecutionHistory(
 				token.TransientDecision,
 				token.EventStoreVersion,
 				token.BranchToken,
+				false,
 			)
 			if err != nil {
 				return nil, wh.error(err, scope)
@@ -2166,6 +2287,7 @@ func (wh *WorkflowHandler) GetWorkflowExecutionHistory(
 				token.TransientDecision,
 				token.EventStoreVersion,
 				token.BranchToken,
+				token.ReverseOrder,
 			)
 			if err != nil {
 				return nil, wh.error(err, scope)
@@ -2180,6 +2302,9 @@ func (wh *WorkflowHandler) GetWorkflowExecutionHistory(
 		}
 	}
 
+	// the event type filter is applied after the page is read, so a page can be empty while more pages remain
+	history.Events = filterHistoryEvents(history.Events, getRequest.EventTypes)
+
 	nextToken, err := serializeHistoryToken(token)
 	if err != nil {
 		return nil, wh.error(err, scope)
@@ -2233,6 +2358,14 @@ func (wh *WorkflowHandler) SignalWorkflowExecution(ctx context.Context,
 		return wh.error(errRequestIDTooLong, scope)
 	}
 
+	if signalRequest.GetRequestId() != "" && uuid.Parse(signalRequest.GetRequestId()) == nil {
+		return wh.error(errInvalidSignalRequestID, scope)
+	}
+
+	if signalRequest.DeliveryTimestamp != nil && signalRequest.GetDeliveryTimestamp() <= 0 {
+		return wh.error(errInvalidDeliveryTimestamp, scope)
+	}
+
 	domainID, err := wh.domainCache.GetDomainID(signalRequest.GetDomain())
 	if err != nil {
 		return wh.error(err, scope)
@@ -2241,21 +2374,36 @@ func (wh *WorkflowHandler) SignalWorkflowExecution(ctx context.Context,
 	// add domain tag to scope, so further metrics will have the domain tag
 	scope = scope.Tagged(metrics.DomainTag(signalRequest.GetDomain()))
 
-	sizeLimitError := wh.config.BlobSizeLimitError(signalRequest.GetDomain())
-	sizeLimitWarn := wh.config.BlobSizeLimitWarn(signalRequest.GetDomain())
-	if err := common.CheckEventBlobSizeLimit(
+	if err := wh.payloadSizeLimiter.check(
+		payloadSignalInput,
 		len(signalRequest.Input),
-		sizeLimitWarn,
-		sizeLimitError,
+		signalRequest.GetDomain(),
 		domainID,
 		signalRequest.GetWorkflowExecution().GetWorkflowId(),
-		signalRequest.GetWorkflowExecution().GetWorkflowId(),
+		signalRequest.GetWorkflowExecution().GetRunId(),
 		scope,
-		wh.GetThrottledBarkLogger(),
 	); err != nil {
 		return wh.error(err, scope)
 	}
 
+	if wh.schemaValidator != nil {
+		// signal requests do not carry the workflow type, which the schema is registered for
+		response, err := wh.history.GetMutableState(ctx, &h.GetMutableStateRequest{
+			DomainUUID: common.StringPtr(domainID),
+			Execution:  signalRequest.WorkflowExecution,
+		})
+		if err != nil {
+			return wh.error(err, scope)
+		}
+		if err := wh.validatePayloadSchema(ctx, payload.SchemaKey{
+			Domain:       signalRequest.GetDomain(),
+			WorkflowType: response.WorkflowType.GetName(),
+			SignalName:   signalRequest.GetSignalName(),
+		}, signalRequest.Input, scope); err != nil {
+			return err
+		}
+	}
+
 	err = wh.history.SignalWorkflowExecution(ctx, &h.SignalWorkflowExecutionRequest{
 		DomainUUID:    common.StringPtr(domainID),
 		SignalRequest: signalRequest,
@@ -2380,33 +2528,42 @@ func (wh *WorkflowHandler) SignalWithStartWorkflowExecution(ctx context.Context,
 	// add domain tag to scope, so further metrics will have the domain tag
 	scope = scope.Tagged(metrics.DomainTag(signalWithStartRequest.GetDomain()))
 
-	sizeLimitError := wh.config.BlobSizeLimitError(signalWithStartRequest.GetDomain())
-	sizeLimitWarn := wh.config.BlobSizeLimitWarn(signalWithStartRequest.GetDomain())
-	if err := common.CheckEventBlobSizeLimit(
+	if err := wh.payloadSizeLimiter.check(
+		payloadSignalInput,
 		len(signalWithStartRequest.SignalInput),
-		sizeLimitWarn,
-		sizeLimitError,
+		signalWithStartRequest.GetDomain(),
 		domainID,
 		signalWithStartRequest.GetWorkflowId(),
 		"",
 		scope,
-		wh.GetThrottledBarkLogger(),
 	); err != nil {
 		return nil, wh.error(err, scope)
 	}
-	if err := common.CheckEventBlobSizeLimit(
+	if err := wh.payloadSizeLimiter.check(
+		payloadWorkflowInput,
 		len(signalWithStartRequest.Input),
-		sizeLimitWarn,
-		sizeLimitError,
+		signalWithStartRequest.GetDomain(),
 		domainID,
 		signalWithStartRequest.GetWorkflowId(),
 		"",
 		scope,
-		wh.GetThrottledBarkLogger(),
 	); err != nil {
 		return nil, wh.error(err, scope)
 	}
 
+	startSchemaKey := payload.SchemaKey{
+		Domain:       signalWithStartRequest.GetDomain(),
+		WorkflowType: signalWithStartRequest.WorkflowType.GetName(),
+	}
+	if err := wh.validatePayloadSchema(ctx, startSchemaKey, signalWithStartRequest.Input, scope); err != nil {
+		return nil, err
+	}
+	signalSchemaKey := startSchemaKey
+	signalSchemaKey.SignalName = signalWithStartRequest.GetSignalName()
+	if err := wh.validatePayloadSchema(ctx, signalSchemaKey, signalWithStartRequest.SignalInput, scope); err != nil {
+		return nil, err
+	}
+
 	op := func() error {
 		var err error
 		resp, err = wh.history.SignalWithStartWorkflowExecution(ctx, &h.SignalWithStartWorkflowExecutionRequest{
@@ -2469,6 +2626,58 @@ func (wh *WorkflowHandler) TerminateWorkflowExecution(ctx context.Context,
 	return nil
 }
 
+// UpdateWorkflowExecutionLabels upserts and removes the mutable labels of a running workflow execution.
+func (wh *WorkflowHandler) UpdateWorkflowExecutionLabels(ctx context.Context,
+	updateRequest *gen.UpdateWorkflowExecutionLabelsRequest) (retError error) {
+	defer log.CapturePanic(wh.GetLogger(), &retError)
+
+	scope := wh.metricsClient.Scope(metrics.FrontendUpdateWorkflowExecutionLabelsScope)
+	sw := wh.startRequestProfile(scope)
+	defer sw.Stop()
+
+	if updateRequest == nil {
+		return wh.error(errRequestNotSet, scope)
+	}
+
+	if ok, _ := wh.rateLimiter.TryConsume(1); !ok {
+		return wh.error(createServiceBusyError(), scope)
+	}
+
+	if updateRequest.GetDomain() == "" {
+		return wh.error(errDomainNotSet, scope)
+	}
+
+	if err := wh.validateExecutionAndEmitMetrics(updateRequest.WorkflowExecution, scope); err != nil {
+		return err
+	}
+
+	if len(updateRequest.GetIdentity()) > wh.config.MaxIDLengthLimit() {
+		return wh.error(errIdentityTooLong, scope)
+	}
+
+	if err := wh.validateLabels(updateRequest, scope); err != nil {
+		return err
+	}
+
+	domainID, err := wh.domainCache.GetDomainID(updateRequest.GetDomain())
+	if err != nil {
+		return wh.error(err, scope)
+	}
+
+	// add domain tag to scope, so further metrics will have the domain tag
+	scope = scope.Tagged(metrics.DomainTag(updateRequest.GetDomain()))
+
+	err = wh.history.UpdateWorkflowExecutionLabels(ctx, &h.UpdateWorkflowExecutionLabelsRequest{
+		DomainUUID:    common.StringPtr(domainID),
+		UpdateRequest: updateRequest,
+	})
+	if err != nil {
+		return wh.error(err, scope)
+	}
+
+	return nil
+}
+
 // ResetWorkflowExecution reset an existing workflow execution to the nextFirstEventID
 // in the history and immediately terminating the current execution instance.
 func (wh *WorkflowHandler) ResetWorkflowExecution(ctx context.Context,
@@ -2601,6 +2810,10 @@ func (wh *WorkflowHandler) ListOpenWorkflowExecutions(ctx context.Context,
 		listRequest.MaximumPageSize = common.Int32Ptr(int32(wh.config.VisibilityMaxPageSize(listRequest.GetDomain())))
 	}
 
+	if err := wh.validateESPageSize(listRequest.GetDomain(), listRequest.GetMaximumPageSize(), scope); err != nil {
+		return nil, err
+	}
+
 	domain := listRequest.GetDomain()
 	domainID, err := wh.domainCache.GetDomainID(domain)
 	if err != nil {
@@ -2618,13 +2831,20 @@ func (wh *WorkflowHandler) ListOpenWorkflowExecutions(ctx context.Context,
 		EarliestStartTime: listRequest.StartTimeFilter.GetEarliestTime(),
 		LatestStartTime:   listRequest.StartTimeFilter.GetLatestTime(),
 	}
+	if listRequest.LabelFilter != nil {
+		if err := validateLabelKey(listRequest.LabelFilter.GetKey()); err != nil {
+			return nil, wh.error(err, scope)
+		}
+		baseReq.LabelKey = listRequest.LabelFilter.GetKey()
+		baseReq.LabelValue = listRequest.LabelFilter.GetValue()
+	}
 
 	var persistenceResp *persistence.ListWorkflowExecutionsResponse
 	if listRequest.ExecutionFilter != nil {
 		if wh.config.DisableListVisibilityByFilter(domain) {
 			err = errNoPermission
 		} else {
-			persistenceResp, err = wh.visibilityMgr.ListOpenWorkflowExecutionsByWorkflowID(
+			persistenceResp, err = wh.visibilityMgr.ListOpenWorkflowExecutionsByWorkflowID(ctx,
 				&persistence.ListWorkflowExecutionsByWorkflowIDRequest{
 					ListWorkflowExecutionsRequest: baseReq,
 					WorkflowID:                    listRequest.ExecutionFilter.GetWorkflowId(),
@@ -2635,14 +2855,14 @@ func (wh *WorkflowHandler) ListOpenWorkflowExecutions(ctx context.Context,
 		if wh.config.DisableListVisibilityByFilter(domain) {
 			err = errNoPermission
 		} else {
-			persistenceResp, err = wh.visibilityMgr.ListOpenWorkflowExecutionsByType(&persistence.ListWorkflowExecutionsByTypeRequest{
+			persistenceResp, err = wh.visibilityMgr.ListOpenWorkflowExecutionsByType(ctx, &persistence.ListWorkflowExecutionsByTypeRequest{
 				ListWorkflowExecutionsRequest: baseReq,
 				WorkflowTypeName:              listRequest.TypeFilter.GetName(),
 			})
 		}
 		logging.LogListOpenWorkflowByFilter(wh.GetThrottledBarkLogger(), listRequest.GetDomain(), logging.ListWorkflowFilterByType)
 	} else {
-		persistenceResp, err = wh.visibilityMgr.ListOpenWorkflowExecutions(&baseReq)
+		persistenceResp, err = wh.visibilityMgr.ListOpenWorkflowExecutions(ctx, &baseReq)
 	}
 
 	if err != nil {
@@ -2705,6 +2925,10 @@ func (wh *WorkflowHandler) ListClosedWorkflowExecutions(ctx context.Context,
 		listRequest.MaximumPageSize = common.Int32Ptr(int32(wh.config.VisibilityMaxPageSize(listRequest.GetDomain())))
 	}
 
+	if err := wh.validateESPageSize(listRequest.GetDomain(), listRequest.GetMaximumPageSize(), scope); err != nil {
+		return nil, err
+	}
+
 	domain := listRequest.GetDomain()
 	domainID, err := wh.domainCache.GetDomainID(domain)
 	if err != nil {
@@ -2722,13 +2946,20 @@ func (wh *WorkflowHandler) ListClosedWorkflowExecutions(ctx context.Context,
 		EarliestStartTime: listRequest.StartTimeFilter.GetEarliestTime(),
 		LatestStartTime:   listRequest.StartTimeFilter.GetLatestTime(),
 	}
+	if listRequest.LabelFilter != nil {
+		if err := validateLabelKey(listRequest.LabelFilter.GetKey()); err != nil {
+			return nil, wh.error(err, scope)
+		}
+		baseReq.LabelKey = listRequest.LabelFilter.GetKey()
+		baseReq.LabelValue = listRequest.LabelFilter.GetValue()
+	}
 
 	var persistenceResp *persistence.ListWorkflowExecutionsResponse
 	if listRequest.ExecutionFilter != nil {
 		if wh.config.DisableListVisibilityByFilter(domain) {
 			err = errNoPermission
 		} else {
-			persistenceResp, err = wh.visibilityMgr.ListClosedWorkflowExecutionsByWorkflowID(
+			persistenceResp, err = wh.visibilityMgr.ListClosedWorkflowExecutionsByWorkflowID(ctx,
 				&persistence.ListWorkflowExecutionsByWorkflowIDRequest{
 					ListWorkflowExecutionsRequest: baseReq,
 					WorkflowID:                    listRequest.ExecutionFilter.GetWorkflowId(),
@@ -2739,7 +2970,7 @@ func (wh *WorkflowHandler) ListClosedWorkflowExecutions(ctx context.Context,
 		if wh.config.DisableListVisibilityByFilter(domain) {
 			err = errNoPermission
 		} else {
-			persistenceResp, err = wh.visibilityMgr.ListClosedWorkflowExecutionsByType(&persistence.ListWorkflowExecutionsByTypeRequest{
+			persistenceResp, err = wh.visibilityMgr.ListClosedWorkflowExecutionsByType(ctx, &persistence.ListWorkflowExecutionsByTypeRequest{
 				ListWorkflowExecutionsRequest: baseReq,
 				WorkflowTypeName:              listRequest.TypeFilter.GetName(),
 			})
@@ -2749,14 +2980,14 @@ func (wh *WorkflowHandler) ListClosedWorkflowExecutions(ctx context.Context,
 		if wh.config.DisableListVisibilityByFilter(domain) {
 			err = errNoPermission
 		} else {
-			persistenceResp, err = wh.visibilityMgr.ListClosedWorkflowExecutionsByStatus(&persistence.ListClosedWorkflowExecutionsByStatusRequest{
+			persistenceResp, err = wh.visibilityMgr.ListClosedWorkflowExecutionsByStatus(ctx, &persistence.ListClosedWorkflowExecutionsByStatusRequest{
 				ListWorkflowExecutionsRequest: baseReq,
 				Status:                        listRequest.GetStatusFilter(),
 			})
 		}
 		logging.LogListClosedWorkflowByFilter(wh.GetBarkLogger(), listRequest.GetDomain(), logging.ListWorkflowFilterByStatus)
 	} else {
-		persistenceResp, err = wh.visibilityMgr.ListClosedWorkflowExecutions(&baseReq)
+		persistenceResp, err = wh.visibilityMgr.ListClosedWorkflowExecutions(ctx, &baseReq)
 	}
 
 	if err != nil {
@@ -2769,6 +3000,116 @@ func (wh *WorkflowHandler) ListClosedWorkflowExecutions(ctx context.Context,
 	return resp, nil
 }
 
+// GetWorkflowExecutionStatistics - counts the executions of a domain closed within a time range,
+// grouped by workflow type, close status and close time
+func (wh *WorkflowHandler) GetWorkflowExecutionStatistics(ctx context.Context,
+	statisticsRequest *gen.GetWorkflowExecutionStatisticsRequest) (resp *gen.GetWorkflowExecutionStatisticsResponse, retError error) {
+	defer log.CapturePanic(wh.GetLogger(), &retError)
+
+	scope := wh.metricsClient.Scope(metrics.FrontendGetWorkflowExecutionStatisticsScope)
+	sw := wh.startRequestProfile(scope)
+	defer sw.Stop()
+
+	if statisticsRequest == nil {
+		return nil, wh.error(errRequestNotSet, scope)
+	}
+
+	if ok, _ := wh.rateLimiter.TryConsume(1); !ok {
+		return nil, wh.error(createServiceBusyError(), scope)
+	}
+
+	if statisticsRequest.GetDomain() == "" {
+		return nil, wh.error(errDomainNotSet, scope)
+	}
+
+	if statisticsRequest.EarliestCloseTime == nil || statisticsRequest.LatestCloseTime == nil {
+		return nil, wh.error(&gen.BadRequestError{Message: "EarliestCloseTime and LatestCloseTime are required"}, scope)
+	}
+
+	if statisticsRequest.GetEarliestCloseTime() > statisticsRequest.GetLatestCloseTime() {
+		return nil, wh.error(&gen.BadRequestError{Message: "EarliestCloseTime is after LatestCloseTime"}, scope)
+	}
+
+	if statisticsRequest.GetCloseTimeIntervalInSeconds() < 0 {
+		return nil, wh.error(&gen.BadRequestError{Message: "CloseTimeIntervalInSeconds cannot be negative"}, scope)
+	}
+
+	domain := statisticsRequest.GetDomain()
+	domainID, err := wh.domainCache.GetDomainID(domain)
+	if err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	// add domain tag to scope, so further metrics will have the domain tag
+	scope = scope.Tagged(metrics.DomainTag(domain))
+
+	persistenceResp, err := wh.visibilityMgr.GetWorkflowExecutionStatistics(ctx, &persistence.GetWorkflowExecutionStatisticsRequest{
+		DomainUUID:        domainID,
+		Domain:            domain,
+		EarliestCloseTime: statisticsRequest.GetEarliestCloseTime(),
+		LatestCloseTime:   statisticsRequest.GetLatestCloseTime(),
+		CloseTimeInterval: time.Duration(statisticsRequest.GetCloseTimeIntervalInSeconds()) * time.Second,
+	})
+	if err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	return &gen.GetWorkflowExecutionStatisticsResponse{
+		TotalCount:          common.Int64Ptr(persistenceResp.TotalCount),
+		CountsByType:        persistenceResp.CountsByType,
+		CountsByCloseStatus: persistenceResp.CountsByCloseStatus,
+		CountsByCloseTime:   persistenceResp.CountsByCloseTime,
+	}, nil
+}
+
+// CountOpenWorkflowExecutions - counts the open workflow executions of a domain, the count is cached
+// for FrontendOpenWorkflowCountCacheTTL so repeated calls don't hit visibility
+func (wh *WorkflowHandler) CountOpenWorkflowExecutions(ctx context.Context,
+	countRequest *gen.CountOpenWorkflowExecutionsRequest) (resp *gen.CountOpenWorkflowExecutionsResponse, retError error) {
+	defer log.CapturePanic(wh.GetLogger(), &retError)
+
+	scope := wh.metricsClient.Scope(metrics.FrontendCountOpenWorkflowExecutionsScope)
+	sw := wh.startRequestProfile(scope)
+	defer sw.Stop()
+
+	if countRequest == nil {
+		return nil, wh.error(errRequestNotSet, scope)
+	}
+
+	if ok, _ := wh.rateLimiter.TryConsume(1); !ok {
+		return nil, wh.error(createServiceBusyError(), scope)
+	}
+
+	if countRequest.GetDomain() == "" {
+		return nil, wh.error(errDomainNotSet, scope)
+	}
+
+	domain := countRequest.GetDomain()
+	domainID, err := wh.domainCache.GetDomainID(domain)
+	if err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	// add domain tag to scope, so further metrics will have the domain tag
+	scope = scope.Tagged(metrics.DomainTag(domain))
+
+	if cached, ok := wh.openWorkflowCounts.Get(domainID).(*openWorkflowCount); ok &&
+		time.Since(cached.updatedAt) < wh.config.OpenWorkflowCountCacheTTL(domain) {
+		return &gen.CountOpenWorkflowExecutionsResponse{Count: common.Int64Ptr(cached.count)}, nil
+	}
+
+	persistenceResp, err := wh.visibilityMgr.CountOpenWorkflowExecutions(ctx, &persistence.CountWorkflowExecutionsRequest{
+		DomainUUID: domainID,
+		Domain:     domain,
+	})
+	if err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	wh.openWorkflowCounts.Put(domainID, &openWorkflowCount{count: persistenceResp.Count, updatedAt: time.Now()})
+	return &gen.CountOpenWorkflowExecutionsResponse{Count: common.Int64Ptr(persistenceResp.Count)}, nil
+}
+
 // ResetStickyTaskList reset the volatile information in mutable state of a given workflow.
 func (wh *WorkflowHandler) ResetStickyTaskList(ctx context.Context, resetRequest *gen.ResetStickyTaskListRequest) (resp *gen.ResetStickyTaskListResponse, retError error) {
 	defer log.CapturePanic(wh.GetLogger(), &retError)
@@ -2857,14 +3198,19 @@ func (wh *WorkflowHandler) QueryWorkflow(ctx context.Context,
 	if err != nil {
 		return nil, wh.error(err, scope)
 	}
-	clientFeature := client.NewFeatureImpl(
-		response.GetClientLibraryVersion(),
-		response.GetClientFeatureVersion(),
+	stickyQueryErr := wh.versionChecker.SupportsFeature(
 		response.GetClientImpl(),
+		response.GetClientFeatureVersion(),
+		client.FeatureStickyQuery,
 	)
 
 	queryRequest.Execution.RunId = response.Execution.RunId
-	if len(response.StickyTaskList.GetName()) != 0 && clientFeature.SupportStickyQuery() {
+	if result, ok := wh.getCachedStackTraceQueryResult(queryRequest, domainID, response.GetPreviousStartedEventId()); ok {
+		scope.IncCounter(metrics.StackTraceQueryCacheHitsCounter)
+		return &gen.QueryWorkflowResponse{QueryResult: result}, nil
+	}
+
+	if len(response.StickyTaskList.GetName()) != 0 && stickyQueryErr == nil {
 		matchingRequest.TaskList = response.StickyTaskList
 		stickyDecisionTimeout := response.GetStickyTaskListScheduleToStartTimeout()
 		// using a clean new context in case customer provide a context which has
@@ -2873,6 +3219,7 @@ func (wh *WorkflowHandler) QueryWorkflow(ctx context.Context,
 		matchingResp, err := wh.matchingRawClient.QueryWorkflow(stickyContext, matchingRequest)
 		cancel()
 		if err == nil {
+			wh.putCachedStackTraceQueryResult(queryRequest, domainID, response.GetPreviousStartedEventId(), matchingResp)
 			return matchingResp, nil
 		}
 		if yarpcError, ok := err.(*yarpcerrors.Status); !ok || yarpcError.Code() != yarpcerrors.CodeDeadlineExceeded {
@@ -2908,9 +3255,49 @@ func (wh *WorkflowHandler) QueryWorkflow(ctx context.Context,
 		return nil, wh.error(err, scope)
 	}
 
+	wh.putCachedStackTraceQueryResult(queryRequest, domainID, response.GetPreviousStartedEventId(), matchingResp)
 	return matchingResp, nil
 }
 
+// getCachedStackTraceQueryResult returns the cached result of a stack trace query, if the workflow has not
+// completed any decision since the result was cached
+func (wh *WorkflowHandler) getCachedStackTraceQueryResult(queryRequest *gen.QueryWorkflowRequest,
+	domainID string, previousStartedEventID int64) ([]byte, bool) {
+	if queryRequest.Query.GetQueryType() != common.QueryTypeStackTrace {
+		return nil, false
+	}
+
+	key := stackTraceQueryCacheKey(domainID, queryRequest.Execution)
+	cached, ok := wh.stackTraceQueries.Get(key).(*stackTraceQueryResult)
+	if !ok {
+		return nil, false
+	}
+	if cached.previousStartedEventID != previousStartedEventID ||
+		time.Since(cached.updatedAt) >= wh.config.StackTraceQueryCacheTTL(queryRequest.GetDomain()) {
+		wh.stackTraceQueries.Delete(key)
+		return nil, false
+	}
+	return cached.result, true
+}
+
+func (wh *WorkflowHandler) putCachedStackTraceQueryResult(queryRequest *gen.QueryWorkflowRequest,
+	domainID string, previousStartedEventID int64, queryResponse *gen.QueryWorkflowResponse) {
+	if queryRequest.Query.GetQueryType() != common.QueryTypeStackTrace ||
+		wh.config.StackTraceQueryCacheTTL(queryRequest.GetDomain()) <= 0 {
+		return
+	}
+
+	wh.stackTraceQueries.Put(stackTraceQueryCacheKey(domainID, queryRequest.Execution), &stackTraceQueryResult{
+		previousStartedEventID: previousStartedEventID,
+		result:                 queryResponse.QueryResult,
+		updatedAt:              time.Now(),
+	})
+}
+
+func stackTraceQueryCacheKey(domainID string, execution *gen.WorkflowExecution) string {
+	return domainID + "/" + execution.GetWorkflowId() + "/" + execution.GetRunId()
+}
+
 // DescribeWorkflowExecution returns information about the specified workflow execution.
 func (wh *WorkflowHandler) DescribeWorkflowExecution(ctx context.Context, request *gen.DescribeWorkflowExecutionRequest) (resp *gen.DescribeWorkflowExecutionResponse, retError error) {
 	defer log.CapturePanic(wh.GetLogger(), &retError)
@@ -2947,6 +3334,58 @@ func (wh *WorkflowHandler) DescribeWorkflowExecution(ctx context.Context, reques
 		Request:    request,
 	})
 
+	if err != nil {
+		return nil, wh.error(wh.toDeletedExecutionError(ctx, err, request.GetDomain(), domainID, request.Execution), scope)
+	}
+
+	return response, nil
+}
+
+// DescribeWorkflowExecutions returns information about multiple workflow executions of a domain at once,
+// executions which don't exist are omitted.
+func (wh *WorkflowHandler) DescribeWorkflowExecutions(ctx context.Context, request *gen.DescribeWorkflowExecutionsRequest) (resp *gen.DescribeWorkflowExecutionsResponse, retError error) {
+	defer log.CapturePanic(wh.GetLogger(), &retError)
+
+	scope := wh.metricsClient.Scope(metrics.FrontendDescribeWorkflowExecutionsScope)
+	sw := wh.startRequestProfile(scope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, wh.error(errRequestNotSet, scope)
+	}
+
+	if ok, _ := wh.rateLimiter.TryConsume(1); !ok {
+		return nil, wh.error(createServiceBusyError(), scope)
+	}
+
+	if request.GetDomain() == "" {
+		return nil, wh.error(errDomainNotSet, scope)
+	}
+	if len(request.Executions) == 0 {
+		return nil, wh.error(errExecutionsNotSet, scope)
+	}
+	if len(request.Executions) > wh.config.MaxDescribeBatchSize(request.GetDomain()) {
+		return nil, wh.error(errTooManyExecutions, scope)
+	}
+	for _, execution := range request.Executions {
+		if err := wh.validateExecutionAndEmitMetrics(execution, scope); err != nil {
+			return nil, err
+		}
+	}
+
+	domainID, err := wh.domainCache.GetDomainID(request.GetDomain())
+	if err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	// add domain tag to scope, so further metrics will have the domain tag
+	scope = scope.Tagged(metrics.DomainTag(request.GetDomain()))
+
+	response, err := wh.history.DescribeWorkflowExecutions(ctx, &h.DescribeWorkflowExecutionsRequest{
+		DomainUUID: common.StringPtr(domainID),
+		Request:    request,
+	})
+
 	if err != nil {
 		return nil, wh.error(err, scope)
 	}
@@ -3009,6 +3448,25 @@ func (wh *WorkflowHandler) DescribeTaskList(ctx context.Context, request *gen.De
 	return response, nil
 }
 
+// GetSearchAttributes returns the search attribute keys which can be used in advanced visibility queries,
+// together with the type of their values, so callers can validate a query before submitting it.
+func (wh *WorkflowHandler) GetSearchAttributes(ctx context.Context) (resp *gen.GetSearchAttributesResponse, retError error) {
+	defer log.CapturePanic(wh.GetLogger(), &retError)
+
+	scope := wh.metricsClient.Scope(metrics.FrontendGetSearchAttributesScope)
+	sw := wh.startRequestProfile(scope)
+	defer sw.Stop()
+
+	keys, err := wh.searchAttributes.GetSearchAttributes(ctx)
+	if err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	return &gen.GetSearchAttributesResponse{
+		Keys: keys,
+	}, nil
+}
+
 func (wh *WorkflowHandler) getHistory(
 	scope metrics.Scope,
 	domainID string,
@@ -3019,23 +3477,36 @@ func (wh *WorkflowHandler) getHistory(
 	transientDecision *gen.TransientDecisionInfo,
 	eventStoreVersion int32,
 	branchToken []byte,
+	reverseOrder bool,
 ) (*gen.History, []byte, error) {
 
+	isFirstPage := len(nextPageToken) == 0
 	historyEvents := []*gen.HistoryEvent{}
 	var size int
 	if eventStoreVersion == persistence.EventStoreVersionV2 {
 		shardID := common.WorkflowIDToHistoryShard(*execution.WorkflowId, wh.config.NumHistoryShards)
-		var err error
-		historyEvents, size, nextPageToken, err = persistence.ReadFullPageV2Events(wh.historyV2Mgr, &persistence.ReadHistoryBranchRequest{
+		request := &persistence.ReadHistoryBranchRequest{
 			BranchToken:   branchToken,
 			MinEventID:    firstEventID,
 			MaxEventID:    nextEventID,
 			PageSize:      int(pageSize),
 			NextPageToken: nextPageToken,
 			ShardID:       common.IntPtr(shardID),
-		})
-		if err != nil {
-			return nil, nil, err
+			ReverseOrder:  reverseOrder,
+		}
+		if reverseOrder {
+			// reverse pages are already filled up to the page size by the history manager
+			response, err := wh.historyV2Mgr.ReadHistoryBranch(request)
+			if err != nil {
+				return nil, nil, err
+			}
+			historyEvents, size, nextPageToken = response.HistoryEvents, response.Size, response.NextPageToken
+		} else {
+			var err error
+			historyEvents, size, nextPageToken, err = persistence.ReadFullPageV2Events(wh.historyV2Mgr, request)
+			if err != nil {
+				return nil, nil, err
+			}
 		}
 	} else {
 		response, err := wh.historyMgr.GetWorkflowExecutionHistory(&persistence.GetWorkflowExecutionHistoryRequest{
@@ -3045,6 +3516,7 @@ func (wh *WorkflowHandler) getHistory(
 			NextEventID:   nextEventID,
 			PageSize:      int(pageSize),
 			NextPageToken: nextPageToken,
+			ReverseOrder:  reverseOrder,
 		})
 
 		if err != nil {
@@ -3071,7 +3543,10 @@ func (wh *WorkflowHandler) getHistory(
 		}
 	}
 
-	if len(nextPageToken) == 0 && transientDecision != nil {
+	if reverseOrder && isFirstPage && transientDecision != nil {
+		// The transient decision events are the newest ones, so they lead the first page when reading newest first
+		historyEvents = append([]*gen.HistoryEvent{transientDecision.StartedEvent, transientDecision.ScheduledEvent}, historyEvents...)
+	} else if !reverseOrder && len(nextPageToken) == 0 && transientDecision != nil {
 		// Append the transient decision events once we are done enumerating everything from the events table
 		historyEvents = append(historyEvents, transientDecision.ScheduledEvent, transientDecision.StartedEvent)
 	}
@@ -3081,6 +3556,24 @@ func (wh *WorkflowHandler) getHistory(
 	return executionHistory, nextPageToken, nil
 }
 
+// filterHistoryEvents returns the events of the given types, all the events are returned if no type is given
+func filterHistoryEvents(events []*gen.HistoryEvent, eventTypes []gen.EventType) []*gen.HistoryEvent {
+	if len(eventTypes) == 0 {
+		return events
+	}
+
+	filtered := make([]*gen.HistoryEvent, 0, len(events))
+	for _, event := range events {
+		for _, eventType := range eventTypes {
+			if event.GetEventType() == eventType {
+				filtered = append(filtered, event)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
 func (wh *WorkflowHandler) getLoggerForTask(taskToken []byte) bark.Logger {
 	logger := wh.Service.GetBarkLogger()
 	task, err := wh.tokenSerializer.Deserialize(taskToken)
@@ -3109,7 +3602,10 @@ func (wh *WorkflowHandler) error(err error, scope metrics.Scope) error {
 		scope.IncCounter(metrics.CadenceFailures)
 		// NOTE: For internal error, we won't return thrift error from cadence-frontend.
 		// Because in uber internal metrics, thrift errors are counted as user errors
-		return fmt.Errorf("Cadence internal error, msg: %v", err.Message)
+		return classifyError(err).newError(err.Message)
+	case *persistence.InvalidPersistenceRequestError:
+		scope.IncCounter(metrics.CadenceErrBadRequestCounter)
+		return &gen.BadRequestError{Message: err.Msg}
 	case *gen.BadRequestError:
 		scope.IncCounter(metrics.CadenceErrBadRequestCounter)
 		return err
@@ -3144,9 +3640,12 @@ func (wh *WorkflowHandler) error(err error, scope metrics.Scope) error {
 		}
 	}
 
-	logging.LogUncategorizedError(wh.Service.GetBarkLogger(), err)
+	class := classifyError(err)
+	if class == errorClassUnknown {
+		logging.LogUncategorizedError(wh.Service.GetBarkLogger(), err)
+	}
 	scope.IncCounter(metrics.CadenceFailures)
-	return fmt.Errorf("Cadence internal uncategorized error, msg: %v", err.Error())
+	return class.newError(err.Error())
 }
 
 func (wh *WorkflowHandler) validateTaskListType(t *gen.TaskListType, scope metrics.Scope) error {
@@ -3174,6 +3673,82 @@ func (wh *WorkflowHandler) validateExecutionAndEmitMetrics(w *gen.WorkflowExecut
 	return nil
 }
 
+func (wh *WorkflowHandler) validateConcurrencyGroup(startRequest *gen.StartWorkflowExecutionRequest, scope metrics.Scope) error {
+	if startRequest.GetConcurrencyGroup() == "" {
+		return nil
+	}
+	if !wh.config.EnableConcurrencyGroups(startRequest.GetDomain()) {
+		return wh.error(errConcurrencyGroupsDisabled, scope)
+	}
+	if len(startRequest.GetConcurrencyGroup()) > wh.config.MaxIDLengthLimit() {
+		return wh.error(errConcurrencyGroupTooLong, scope)
+	}
+	if startRequest.GetConcurrencyLimit() <= 0 {
+		return wh.error(errInvalidConcurrencyLimit, scope)
+	}
+	if startRequest.GetCronSchedule() != "" {
+		return wh.error(errConcurrencyGroupWithCronSchedule, scope)
+	}
+	return nil
+}
+
+// validatePayloadSchema validates the payload against the schema registered for the key, errors of the
+// schema validator which are not cadence errors are returned as bad requests
+func (wh *WorkflowHandler) validatePayloadSchema(ctx context.Context, key payload.SchemaKey, input []byte, scope metrics.Scope) error {
+	if wh.schemaValidator == nil {
+		return nil
+	}
+	err := wh.schemaValidator.Validate(ctx, key, input)
+	switch err.(type) {
+	case nil:
+		return nil
+	case *gen.BadRequestError, *gen.InternalServiceError, *gen.ServiceBusyError, *gen.EntityNotExistsError:
+		return wh.error(err, scope)
+	default:
+		return wh.error(&gen.BadRequestError{Message: fmt.Sprintf("Payload does not match its schema: %v", err)}, scope)
+	}
+}
+
+// validateESPageSize rejects pages which ElasticSearch is unable to return in one search
+func (wh *WorkflowHandler) validateESPageSize(domain string, pageSize int32, scope metrics.Scope) error {
+	if wh.config.EnableReadVisibilityFromES(domain) && int(pageSize) > wh.config.ESIndexMaxResultWindow() {
+		return wh.error(&gen.BadRequestError{
+			Message: fmt.Sprintf("MaximumPageSize exceeds the limit of %v.", wh.config.ESIndexMaxResultWindow())}, scope)
+	}
+	return nil
+}
+
+func (wh *WorkflowHandler) validateLabels(updateRequest *gen.UpdateWorkflowExecutionLabelsRequest, scope metrics.Scope) error {
+	if len(updateRequest.UpsertLabels) == 0 && len(updateRequest.RemoveLabels) == 0 {
+		return wh.error(errLabelsNotSet, scope)
+	}
+	maxLength := wh.config.MaxIDLengthLimit()
+	for key, value := range updateRequest.UpsertLabels {
+		if err := validateLabelKey(key); err != nil {
+			return wh.error(err, scope)
+		}
+		if len(key) > maxLength || len(value) > maxLength {
+			return wh.error(errLabelTooLong, scope)
+		}
+	}
+	for _, key := range updateRequest.RemoveLabels {
+		if err := validateLabelKey(key); err != nil {
+			return wh.error(err, scope)
+		}
+		if _, ok := updateRequest.UpsertLabels[key]; ok {
+			return wh.error(errLabelUpsertedAndRemoved, scope)
+		}
+	}
+	return nil
+}
+
+func validateLabelKey(key string) error {
+	if key == "" || strings.Contains(key, "=") {
+		return errInvalidLabelKey
+	}
+	return nil
+}
+
 func validateExecution(w *gen.WorkflowExecution) error {
 	if w == nil {
 		return errExecutionNotSet
@@ -3224,6 +3799,7 @@ func (wh *WorkflowHandler) createDomainResponse(
 		WorkflowExecutionRetentionPeriodInDays: common.Int32Ptr(config.Retention),
 		ArchivalStatus:                         common.ArchivalStatusPtr(config.ArchivalStatus),
 		ArchivalBucketName:                     common.StringPtr(config.ArchivalBucket),
+		ArchivalTargetBucketName:               common.StringPtr(config.ArchivalTargetBucket),
 	}
 	if wh.GetClusterMetadata().ArchivalConfig().ConfiguredForArchival() && config.ArchivalBucket != "" {
 		metadata, err := wh.blobstoreClient.BucketMetadata(ctx, config.ArchivalBucket)
@@ -3300,7 +3876,7 @@ func (wh *WorkflowHandler) createPollForDecisionTaskResponse(
 			nextEventID,
 			int32(wh.config.HistoryMaxPageSize(domain.GetInfo().Name)),
 			nil,
-			matchingResp.DecisionInfo, eventStoreVersion, branchToken,
+			matchingResp.DecisionInfo, eventStoreVersion, branchToken, false,
 		)
 		if err != nil {
 			return nil, err
@@ -3388,6 +3964,62 @@ func (wh *WorkflowHandler) validateClusterName(clusterName string) error {
 	return nil
 }
 
+// toDeletedExecutionError replaces the not found error history returns for an execution deleted due to retention
+// by one telling when the execution was deleted, the deletion is known from the tombstone left in visibility. Any
+// other error is returned as is.
+func (wh *WorkflowHandler) toDeletedExecutionError(ctx context.Context, err error, domain string, domainID string,
+	execution *gen.WorkflowExecution) error {
+	if _, ok := err.(*gen.EntityNotExistsError); !ok || wh.config.VisibilityTombstoneRetention(domain) <= 0 {
+		return err
+	}
+	tombstone := wh.getVisibilityTombstone(ctx, domain, domainID, execution)
+	if tombstone == nil {
+		return err
+	}
+	deleteTime := time.Unix(0, tombstone.GetCloseTime()).UTC()
+	return &gen.EntityNotExistsError{
+		Message: fmt.Sprintf("Workflow execution was deleted due to retention at %v. WorkflowId: %v, RunId: %v",
+			deleteTime.Format(time.RFC3339), tombstone.Execution.GetWorkflowId(), tombstone.Execution.GetRunId()),
+	}
+}
+
+// getVisibilityTombstone returns the tombstone of the given run or, when no run is given, of the latest run of the
+// workflow. It returns nil when the run is not a tombstone or the lookup fails, the lookup is best effort.
+func (wh *WorkflowHandler) getVisibilityTombstone(ctx context.Context, domain string, domainID string,
+	execution *gen.WorkflowExecution) *gen.WorkflowExecutionInfo {
+	var info *gen.WorkflowExecutionInfo
+	if execution.GetRunId() != "" {
+		resp, err := wh.visibilityMgr.GetClosedWorkflowExecution(ctx, &persistence.GetClosedWorkflowExecutionRequest{
+			DomainUUID: domainID,
+			Domain:     domain,
+			Execution:  *execution,
+		})
+		if err != nil {
+			return nil
+		}
+		info = resp.Execution
+	} else {
+		resp, err := wh.visibilityMgr.ListClosedWorkflowExecutionsByWorkflowID(ctx, &persistence.ListWorkflowExecutionsByWorkflowIDRequest{
+			ListWorkflowExecutionsRequest: persistence.ListWorkflowExecutionsRequest{
+				DomainUUID:        domainID,
+				Domain:            domain,
+				EarliestStartTime: 0,
+				LatestStartTime:   time.Now().UnixNano(),
+				PageSize:          1,
+			},
+			WorkflowID: execution.GetWorkflowId(),
+		})
+		if err != nil || len(resp.Executions) == 0 {
+			return nil
+		}
+		info = resp.Executions[0]
+	}
+	if info.GetCloseStatus() != gen.WorkflowExecutionCloseStatusDeleted {
+		return nil
+	}
+	return info
+}
+
 func (wh *WorkflowHandler) historyArchived(ctx context.Context, request *gen.GetWorkflowExecutionHistoryRequest, domainID string) bool {
 	if request.GetExecution() == nil || request.GetExecution().GetRunId() == "" {
 		return false
@@ -3423,6 +4055,9 @@ func (wh *WorkflowHandler) getArchivedHistory(
 	if archivalBucket == "" {
 		return nil, wh.error(errDomainHasNeverBeenEnabledForArchival, scope)
 	}
+	if request.GetHistoryEventFilterType() == gen.HistoryEventFilterTypeCloseEvent {
+		return wh.getArchivedCloseEvent(ctx, request, domainID, archivalBucket, scope)
+	}
 	var token *getHistoryContinuationTokenArchival
 	if request.NextPageToken != nil {
 		token, err = deserializeHistoryTokenArchival(request.NextPageToken)
@@ -3434,38 +4069,133 @@ func (wh *WorkflowHandler) getArchivedHistory(
 			BlobstorePageToken: common.FirstBlobPageToken,
 		}
 	}
-	key, err := archiver.NewHistoryBlobKey(domainID, request.Execution.GetWorkflowId(), request.Execution.GetRunId(), token.BlobstorePageToken)
+	historyBlob, err := wh.downloadArchivedHistoryBlob(ctx, request, domainID, archivalBucket, token.BlobstorePageToken)
 	if err != nil {
 		return nil, wh.error(err, scope)
 	}
-	b, err := wh.blobstoreClient.Download(ctx, archivalBucket, key)
-	if err != nil {
-		return nil, wh.error(err, scope)
+	events := historyBlob.Body.GetEvents()
+	if token.EventIndex < 0 || token.EventIndex > len(events) {
+		return nil, wh.error(errInvalidNextArchivalPageToken, scope)
 	}
-	unwrappedBlob, wrappingLayers, err := blob.Unwrap(b)
-	if err != nil {
-		return nil, wh.error(err, scope)
+	pageSize := int(request.GetMaximumPageSize())
+	if pageSize <= 0 {
+		pageSize = wh.config.HistoryMaxPageSize(request.GetDomain())
 	}
-	historyBlob := &archiver.HistoryBlob{}
-	switch *wrappingLayers.EncodingFormat {
-	case blob.JSONEncoding:
-		if err := json.Unmarshal(unwrappedBlob.Body, historyBlob); err != nil {
-			return nil, wh.error(err, scope)
-		}
+	endIndex := token.EventIndex + pageSize
+	if endIndex > len(events) {
+		endIndex = len(events)
 	}
-	token = &getHistoryContinuationTokenArchival{
-		BlobstorePageToken: *historyBlob.Header.NextPageToken,
+	history := &gen.History{
+		Events: events[token.EventIndex:endIndex],
 	}
-	if *historyBlob.Header.IsLast {
+
+	if endIndex < len(events) {
+		token = &getHistoryContinuationTokenArchival{
+			BlobstorePageToken: token.BlobstorePageToken,
+			EventIndex:         endIndex,
+		}
+	} else if isLastArchivedHistoryBlob(historyBlob) {
 		token = nil
+	} else {
+		token = &getHistoryContinuationTokenArchival{
+			BlobstorePageToken: *historyBlob.Header.NextPageToken,
+		}
 	}
 	nextToken, err := serializeHistoryTokenArchival(token)
 	if err != nil {
 		return nil, wh.error(err, scope)
 	}
 	return &gen.GetWorkflowExecutionHistoryResponse{
-		History:       historyBlob.Body,
+		History:       history,
 		NextPageToken: nextToken,
 		Archived:      common.BoolPtr(true),
 	}, nil
 }
+
+// getArchivedCloseEvent returns the close event of an archived history, which is the last event of the last blob.
+// The last blob is located using blob tags so that only the last blob has to be downloaded.
+func (wh *WorkflowHandler) getArchivedCloseEvent(
+	ctx context.Context,
+	request *gen.GetWorkflowExecutionHistoryRequest,
+	domainID string,
+	archivalBucket string,
+	scope metrics.Scope,
+) (*gen.GetWorkflowExecutionHistoryResponse, error) {
+
+	pageToken := common.FirstBlobPageToken
+	for {
+		key, err := archiver.NewHistoryBlobKey(domainID, request.Execution.GetWorkflowId(), request.Execution.GetRunId(), pageToken)
+		if err != nil {
+			return nil, wh.error(err, scope)
+		}
+		tags, err := wh.blobstoreClient.GetTags(ctx, archivalBucket, key)
+		if err != nil {
+			return nil, wh.error(err, scope)
+		}
+		if archiver.IsLast(tags) {
+			break
+		}
+		if pageToken, err = archiver.NextPageToken(tags); err != nil {
+			return nil, wh.error(err, scope)
+		}
+	}
+	historyBlob, err := wh.downloadArchivedHistoryBlob(ctx, request, domainID, archivalBucket, pageToken)
+	if err != nil {
+		return nil, wh.error(err, scope)
+	}
+	history := &gen.History{
+		Events: []*gen.HistoryEvent{},
+	}
+	if events := historyBlob.Body.GetEvents(); len(events) != 0 {
+		history.Events = events[len(events)-1:]
+	}
+	return &gen.GetWorkflowExecutionHistoryResponse{
+		History:  history,
+		Archived: common.BoolPtr(true),
+	}, nil
+}
+
+func (wh *WorkflowHandler) downloadArchivedHistoryBlob(
+	ctx context.Context,
+	request *gen.GetWorkflowExecutionHistoryRequest,
+	domainID string,
+	archivalBucket string,
+	pageToken int,
+) (*archiver.HistoryBlob, error) {
+
+	key, err := archiver.NewHistoryBlobKey(domainID, request.Execution.GetWorkflowId(), request.Execution.GetRunId(), pageToken)
+	if err != nil {
+		return nil, err
+	}
+	b, err := wh.blobstoreClient.Download(ctx, archivalBucket, key)
+	if err != nil {
+		return nil, err
+	}
+	unwrappedBlob, wrappingLayers, err := blob.Unwrap(b)
+	if err != nil {
+		return nil, err
+	}
+	historyBlob := &archiver.HistoryBlob{}
+	if wrappingLayers.EncodingFormat == nil {
+		return nil, errUnknownArchivalEncoding
+	}
+	switch *wrappingLayers.EncodingFormat {
+	case blob.JSONEncoding:
+		if err := json.Unmarshal(unwrappedBlob.Body, historyBlob); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errUnknownArchivalEncoding
+	}
+	if historyBlob.Header == nil || historyBlob.Body == nil {
+		return nil, errInvalidArchivedHistoryBlob
+	}
+	if !isLastArchivedHistoryBlob(historyBlob) && historyBlob.Header.NextPageToken == nil {
+		return nil, errInvalidArchivedHistoryBlob
+	}
+	return historyBlob, nil
+}
+
+func isLastArchivedHistoryBlob(historyBlob *archiver.HistoryBlob) bool {
+	return historyBlob.Header.IsLast != nil && *historyBlob.Header.IsLast
+}
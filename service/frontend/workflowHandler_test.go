@@ -47,6 +47,7 @@ import (
 	"github.com/uber/cadence/common/messaging"
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/mocks"
+	"github.com/uber/cadence/common/payload"
 	"github.com/uber/cadence/common/persistence"
 	cs "github.com/uber/cadence/common/service"
 	dc "github.com/uber/cadence/common/service/dynamicconfig"
@@ -74,8 +75,18 @@ type (
 		mockService         cs.Service
 		mockBlobstoreClient *mocks.BlobstoreClient
 	}
+
+	testSchemaValidator struct {
+		keys []payload.SchemaKey
+		err  error
+	}
 )
 
+func (v *testSchemaValidator) Validate(ctx context.Context, key payload.SchemaKey, input []byte) error {
+	v.keys = append(v.keys, key)
+	return v.err
+}
+
 func TestWorkflowHandlerSuite(t *testing.T) {
 	s := new(workflowHandlerSuite)
 	suite.Run(t, s)
@@ -185,7 +196,8 @@ func (s *workflowHandlerSuite) TestMergeDomainData_Nil() {
 
 func (s *workflowHandlerSuite) getWorkflowHandler(config *Config) *WorkflowHandler {
 	return NewWorkflowHandler(s.mockService, config, s.mockMetadataMgr, s.mockHistoryMgr,
-		s.mockHistoryV2Mgr, s.mockVisibilityMgr, s.mockProducer, s.mockBlobstoreClient)
+		s.mockHistoryV2Mgr, s.mockVisibilityMgr, s.mockProducer, s.mockBlobstoreClient, nil, nil,
+		NewSearchAttributesCache(config, nil))
 }
 
 func (s *workflowHandlerSuite) TestDisableListVisibilityByFilter() {
@@ -259,6 +271,83 @@ func (s *workflowHandlerSuite) TestDisableListVisibilityByFilter() {
 	assert.Equal(s.T(), errNoPermission, err)
 }
 
+func (s *workflowHandlerSuite) TestCountOpenWorkflowExecutions_Cached() {
+	domain := "test-domain"
+	domainID := uuid.New()
+	config := s.newConfig()
+	config.OpenWorkflowCountCacheTTL = dc.GetDurationPropertyFnFilteredByDomain(time.Hour)
+
+	wh := s.getWorkflowHandler(config)
+	mockDomainCache := &cache.DomainCacheMock{}
+	wh.metricsClient = wh.Service.GetMetricsClient()
+	wh.domainCache = mockDomainCache
+	wh.startWG.Done()
+
+	mockDomainCache.On("GetDomainID", domain).Return(domainID, nil)
+	s.mockVisibilityMgr.On("CountOpenWorkflowExecutions", mock.Anything, &persistence.CountWorkflowExecutionsRequest{
+		DomainUUID: domainID,
+		Domain:     domain,
+	}).Return(&persistence.CountWorkflowExecutionsResponse{Count: 5}, nil).Once()
+
+	countRequest := &shared.CountOpenWorkflowExecutionsRequest{Domain: common.StringPtr(domain)}
+	for i := 0; i < 2; i++ {
+		resp, err := wh.CountOpenWorkflowExecutions(context.Background(), countRequest)
+		s.NoError(err)
+		s.Equal(int64(5), resp.GetCount())
+	}
+
+	config.OpenWorkflowCountCacheTTL = dc.GetDurationPropertyFnFilteredByDomain(0)
+	s.mockVisibilityMgr.On("CountOpenWorkflowExecutions", mock.Anything, mock.Anything).
+		Return(&persistence.CountWorkflowExecutionsResponse{Count: 6}, nil).Once()
+	resp, err := wh.CountOpenWorkflowExecutions(context.Background(), countRequest)
+	s.NoError(err)
+	s.Equal(int64(6), resp.GetCount())
+}
+
+func (s *workflowHandlerSuite) TestStackTraceQueryCache() {
+	domainID := uuid.New()
+	config := s.newConfig()
+	config.StackTraceQueryCacheTTL = dc.GetDurationPropertyFnFilteredByDomain(time.Hour)
+	wh := s.getWorkflowHandler(config)
+
+	queryRequest := &shared.QueryWorkflowRequest{
+		Domain: common.StringPtr("test-domain"),
+		Execution: &shared.WorkflowExecution{
+			WorkflowId: common.StringPtr("workflow-id"),
+			RunId:      common.StringPtr(uuid.New()),
+		},
+		Query: &shared.WorkflowQuery{QueryType: common.StringPtr(common.QueryTypeStackTrace)},
+	}
+	queryResponse := &shared.QueryWorkflowResponse{QueryResult: []byte("stack trace")}
+
+	_, ok := wh.getCachedStackTraceQueryResult(queryRequest, domainID, 3)
+	s.False(ok)
+
+	wh.putCachedStackTraceQueryResult(queryRequest, domainID, 3, queryResponse)
+	result, ok := wh.getCachedStackTraceQueryResult(queryRequest, domainID, 3)
+	s.True(ok)
+	s.Equal(queryResponse.QueryResult, result)
+
+	// a new decision is completed, the cached result is stale
+	_, ok = wh.getCachedStackTraceQueryResult(queryRequest, domainID, 7)
+	s.False(ok)
+	_, ok = wh.getCachedStackTraceQueryResult(queryRequest, domainID, 3)
+	s.False(ok)
+
+	// other query types are never cached
+	queryRequest.Query.QueryType = common.StringPtr("custom-query")
+	wh.putCachedStackTraceQueryResult(queryRequest, domainID, 7, queryResponse)
+	_, ok = wh.getCachedStackTraceQueryResult(queryRequest, domainID, 7)
+	s.False(ok)
+
+	// caching is disabled
+	queryRequest.Query.QueryType = common.StringPtr(common.QueryTypeStackTrace)
+	config.StackTraceQueryCacheTTL = dc.GetDurationPropertyFnFilteredByDomain(0)
+	wh.putCachedStackTraceQueryResult(queryRequest, domainID, 7, queryResponse)
+	_, ok = wh.getCachedStackTraceQueryResult(queryRequest, domainID, 7)
+	s.False(ok)
+}
+
 func (s *workflowHandlerSuite) TestPollForTask_Failed_ContextTimeoutTooShort() {
 	config := s.newConfig()
 	wh := s.getWorkflowHandler(config)
@@ -519,10 +608,157 @@ func (s *workflowHandlerSuite) TestStartWorkflowExecution_Failed_InvalidTaskStar
 	assert.Equal(s.T(), errInvalidTaskStartToCloseTimeoutSeconds, err)
 }
 
+func (s *workflowHandlerSuite) TestStartWorkflowExecution_Failed_SchemaValidation() {
+	config := s.newConfig()
+	config.RPS = dc.GetIntPropertyFn(10)
+	validator := &testSchemaValidator{err: errors.New("missing field orderID")}
+	wh := NewWorkflowHandler(s.mockService, config, s.mockMetadataMgr, s.mockHistoryMgr,
+		s.mockHistoryV2Mgr, s.mockVisibilityMgr, s.mockProducer, s.mockBlobstoreClient, nil, validator, nil)
+	mockDomainCache := &cache.DomainCacheMock{}
+	wh.metricsClient = wh.Service.GetMetricsClient()
+	wh.domainCache = mockDomainCache
+	wh.startWG.Done()
+
+	mockDomainCache.On("GetDomainID", "test-domain").Return(uuid.New(), nil)
+
+	startWorkflowExecutionRequest := &shared.StartWorkflowExecutionRequest{
+		Domain:     common.StringPtr("test-domain"),
+		WorkflowId: common.StringPtr("workflow-id"),
+		WorkflowType: &shared.WorkflowType{
+			Name: common.StringPtr("workflow-type"),
+		},
+		TaskList: &shared.TaskList{
+			Name: common.StringPtr("task-list"),
+		},
+		Input:                               []byte(`{"amount": 10}`),
+		ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(1),
+		TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(1),
+		RequestId:                           common.StringPtr(uuid.New()),
+	}
+	_, err := wh.StartWorkflowExecution(context.Background(), startWorkflowExecutionRequest)
+	s.IsType(&shared.BadRequestError{}, err)
+	s.Equal([]payload.SchemaKey{{Domain: "test-domain", WorkflowType: "workflow-type"}}, validator.keys)
+}
+
+func (s *workflowHandlerSuite) TestUpdateWorkflowExecutionLabels_Failed_LabelsNotSet() {
+	config := s.newConfig()
+	config.RPS = dc.GetIntPropertyFn(10)
+	wh := s.getWorkflowHandler(config)
+	wh.metricsClient = wh.Service.GetMetricsClient()
+	wh.startWG.Done()
+
+	err := wh.UpdateWorkflowExecutionLabels(context.Background(), &shared.UpdateWorkflowExecutionLabelsRequest{
+		Domain:            common.StringPtr("test-domain"),
+		WorkflowExecution: &shared.WorkflowExecution{WorkflowId: common.StringPtr("workflow-id")},
+	})
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), errLabelsNotSet, err)
+}
+
+func (s *workflowHandlerSuite) TestUpdateWorkflowExecutionLabels_Failed_InvalidLabelKey() {
+	config := s.newConfig()
+	config.RPS = dc.GetIntPropertyFn(10)
+	wh := s.getWorkflowHandler(config)
+	wh.metricsClient = wh.Service.GetMetricsClient()
+	wh.startWG.Done()
+
+	for _, key := range []string{"", "team=payments"} {
+		err := wh.UpdateWorkflowExecutionLabels(context.Background(), &shared.UpdateWorkflowExecutionLabelsRequest{
+			Domain:            common.StringPtr("test-domain"),
+			WorkflowExecution: &shared.WorkflowExecution{WorkflowId: common.StringPtr("workflow-id")},
+			UpsertLabels:      map[string]string{key: "value"},
+		})
+		assert.Error(s.T(), err)
+		assert.Equal(s.T(), errInvalidLabelKey, err)
+	}
+}
+
+func (s *workflowHandlerSuite) TestUpdateWorkflowExecutionLabels_Failed_LabelUpsertedAndRemoved() {
+	config := s.newConfig()
+	config.RPS = dc.GetIntPropertyFn(10)
+	wh := s.getWorkflowHandler(config)
+	wh.metricsClient = wh.Service.GetMetricsClient()
+	wh.startWG.Done()
+
+	err := wh.UpdateWorkflowExecutionLabels(context.Background(), &shared.UpdateWorkflowExecutionLabelsRequest{
+		Domain:            common.StringPtr("test-domain"),
+		WorkflowExecution: &shared.WorkflowExecution{WorkflowId: common.StringPtr("workflow-id")},
+		UpsertLabels:      map[string]string{"team": "payments"},
+		RemoveLabels:      []string{"team"},
+	})
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), errLabelUpsertedAndRemoved, err)
+}
+
+func (s *workflowHandlerSuite) TestDescribeWorkflowExecutions_Failed_ExecutionsNotSet() {
+	config := s.newConfig()
+	config.RPS = dc.GetIntPropertyFn(10)
+	wh := s.getWorkflowHandler(config)
+	wh.metricsClient = wh.Service.GetMetricsClient()
+	wh.startWG.Done()
+
+	_, err := wh.DescribeWorkflowExecutions(context.Background(), &shared.DescribeWorkflowExecutionsRequest{
+		Domain: common.StringPtr("test-domain"),
+	})
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), errExecutionsNotSet, err)
+}
+
+func (s *workflowHandlerSuite) TestDescribeWorkflowExecutions_Failed_TooManyExecutions() {
+	config := s.newConfig()
+	config.RPS = dc.GetIntPropertyFn(10)
+	config.MaxDescribeBatchSize = dc.GetIntPropertyFilteredByDomain(1)
+	wh := s.getWorkflowHandler(config)
+	wh.metricsClient = wh.Service.GetMetricsClient()
+	wh.startWG.Done()
+
+	_, err := wh.DescribeWorkflowExecutions(context.Background(), &shared.DescribeWorkflowExecutionsRequest{
+		Domain: common.StringPtr("test-domain"),
+		Executions: []*shared.WorkflowExecution{
+			{WorkflowId: common.StringPtr("workflow-id-1")},
+			{WorkflowId: common.StringPtr("workflow-id-2")},
+		},
+	})
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), errTooManyExecutions, err)
+}
+
+func (s *workflowHandlerSuite) TestGetSearchAttributes() {
+	config := s.newConfig()
+	config.ValidSearchAttributes = dc.GetMapPropertyFn(map[string]interface{}{
+		"WorkflowID":    int(shared.IndexedValueTypeKeyword),
+		"HistoryLength": float64(shared.IndexedValueTypeInt),
+	})
+	wh := s.getWorkflowHandler(config)
+	wh.metricsClient = wh.Service.GetMetricsClient()
+	wh.startWG.Done()
+
+	resp, err := wh.GetSearchAttributes(context.Background())
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), map[string]shared.IndexedValueType{
+		"WorkflowID":    shared.IndexedValueTypeKeyword,
+		"HistoryLength": shared.IndexedValueTypeInt,
+	}, resp.GetKeys())
+}
+
+func (s *workflowHandlerSuite) TestGetSearchAttributes_Failed_InvalidType() {
+	config := s.newConfig()
+	config.ValidSearchAttributes = dc.GetMapPropertyFn(map[string]interface{}{
+		"WorkflowID": "keyword",
+	})
+	wh := s.getWorkflowHandler(config)
+	wh.metricsClient = wh.Service.GetMetricsClient()
+	wh.startWG.Done()
+
+	_, err := wh.GetSearchAttributes(context.Background())
+	assert.Error(s.T(), err)
+}
+
 func (s *workflowHandlerSuite) getWorkflowHandlerWithParams(mService cs.Service, config *Config,
 	mMetadataManager persistence.MetadataManager, blobStore blobstore.Client) *WorkflowHandler {
 	return NewWorkflowHandler(mService, config, mMetadataManager, s.mockHistoryMgr, s.mockHistoryV2Mgr,
-		s.mockVisibilityMgr, s.mockProducer, blobStore)
+		s.mockVisibilityMgr, s.mockProducer, blobStore, nil, nil,
+		NewSearchAttributesCache(config, nil))
 }
 
 func (s *workflowHandlerSuite) TestRegisterDomain_Failure_BucketNotExists() {
@@ -533,8 +769,8 @@ func (s *workflowHandlerSuite) TestRegisterDomain_Failure_BucketNotExists() {
 	clusterMetadata.On("ArchivalConfig").Return(cluster.NewArchivalConfig(cluster.ArchivalEnabled, "test-archival-bucket", true))
 	clusterMetadata.On("GetNextFailoverVersion", mock.Anything, mock.Anything).Return(int64(0))
 	mMetadataManager := &mocks.MetadataManager{}
-	mMetadataManager.On("GetDomain", mock.Anything).Return(nil, &shared.EntityNotExistsError{})
-	mMetadataManager.On("CreateDomain", mock.Anything).Return(&persistence.CreateDomainResponse{
+	mMetadataManager.On("GetDomain", mock.Anything, mock.Anything).Return(nil, &shared.EntityNotExistsError{})
+	mMetadataManager.On("CreateDomain", mock.Anything, mock.Anything).Return(&persistence.CreateDomainResponse{
 		ID: "test-id",
 	}, nil)
 	mBlobstore := &mocks.BlobstoreClient{}
@@ -559,8 +795,8 @@ func (s *workflowHandlerSuite) TestRegisterDomain_Success_EnabledWithNoBucket()
 	clusterMetadata.On("ArchivalConfig").Return(cluster.NewArchivalConfig(cluster.ArchivalEnabled, "test-archival-bucket", true))
 	clusterMetadata.On("GetNextFailoverVersion", mock.Anything, mock.Anything).Return(int64(0))
 	mMetadataManager := &mocks.MetadataManager{}
-	mMetadataManager.On("GetDomain", mock.Anything).Return(nil, &shared.EntityNotExistsError{})
-	mMetadataManager.On("CreateDomain", mock.Anything).Return(&persistence.CreateDomainResponse{
+	mMetadataManager.On("GetDomain", mock.Anything, mock.Anything).Return(nil, &shared.EntityNotExistsError{})
+	mMetadataManager.On("CreateDomain", mock.Anything, mock.Anything).Return(&persistence.CreateDomainResponse{
 		ID: "test-id",
 	}, nil)
 	mBlobstore := &mocks.BlobstoreClient{}
@@ -584,8 +820,8 @@ func (s *workflowHandlerSuite) TestRegisterDomain_Success_EnabledWithBucket() {
 	clusterMetadata.On("ArchivalConfig").Return(cluster.NewArchivalConfig(cluster.ArchivalEnabled, "test-archival-bucket", true))
 	clusterMetadata.On("GetNextFailoverVersion", mock.Anything, mock.Anything).Return(int64(0))
 	mMetadataManager := &mocks.MetadataManager{}
-	mMetadataManager.On("GetDomain", mock.Anything).Return(nil, &shared.EntityNotExistsError{})
-	mMetadataManager.On("CreateDomain", mock.Anything).Return(&persistence.CreateDomainResponse{
+	mMetadataManager.On("GetDomain", mock.Anything, mock.Anything).Return(nil, &shared.EntityNotExistsError{})
+	mMetadataManager.On("CreateDomain", mock.Anything, mock.Anything).Return(&persistence.CreateDomainResponse{
 		ID: "test-id",
 	}, nil)
 	mBlobstore := &mocks.BlobstoreClient{}
@@ -609,8 +845,8 @@ func (s *workflowHandlerSuite) TestRegisterDomain_Success_ClusterNotConfiguredFo
 	clusterMetadata.On("ArchivalConfig").Return(cluster.NewArchivalConfig(cluster.ArchivalDisabled, "", false))
 	clusterMetadata.On("GetNextFailoverVersion", mock.Anything, mock.Anything).Return(int64(0))
 	mMetadataManager := &mocks.MetadataManager{}
-	mMetadataManager.On("GetDomain", mock.Anything).Return(nil, &shared.EntityNotExistsError{})
-	mMetadataManager.On("CreateDomain", mock.Anything).Return(&persistence.CreateDomainResponse{
+	mMetadataManager.On("GetDomain", mock.Anything, mock.Anything).Return(nil, &shared.EntityNotExistsError{})
+	mMetadataManager.On("CreateDomain", mock.Anything, mock.Anything).Return(&persistence.CreateDomainResponse{
 		ID: "test-id",
 	}, nil)
 
@@ -632,8 +868,8 @@ func (s *workflowHandlerSuite) TestRegisterDomain_Success_NotEnabled() {
 	clusterMetadata.On("ArchivalConfig").Return(cluster.NewArchivalConfig(cluster.ArchivalEnabled, "test-archival-bucket", true))
 	clusterMetadata.On("GetNextFailoverVersion", mock.Anything, mock.Anything).Return(int64(0))
 	mMetadataManager := &mocks.MetadataManager{}
-	mMetadataManager.On("GetDomain", mock.Anything).Return(nil, &shared.EntityNotExistsError{})
-	mMetadataManager.On("CreateDomain", mock.Anything).Return(&persistence.CreateDomainResponse{
+	mMetadataManager.On("GetDomain", mock.Anything, mock.Anything).Return(nil, &shared.EntityNotExistsError{})
+	mMetadataManager.On("CreateDomain", mock.Anything, mock.Anything).Return(&persistence.CreateDomainResponse{
 		ID: "test-id",
 	}, nil)
 
@@ -652,7 +888,7 @@ func (s *workflowHandlerSuite) TestDescribeDomain_Success_ArchivalNeverEnabled()
 	clusterMetadata := &mocks.ClusterMetadata{}
 	clusterMetadata.On("ArchivalConfig").Return(cluster.NewArchivalConfig(cluster.ArchivalDisabled, "", false))
 	mMetadataManager := &mocks.MetadataManager{}
-	mMetadataManager.On("GetDomain", mock.Anything).Return(persistenceGetDomainResponse("", shared.ArchivalStatusDisabled), nil)
+	mMetadataManager.On("GetDomain", mock.Anything, mock.Anything).Return(persistenceGetDomainResponse("", shared.ArchivalStatusDisabled), nil)
 	mBlobstore := &mocks.BlobstoreClient{}
 	mService := cs.NewTestService(clusterMetadata, s.mockMessagingClient, s.mockMetricClient, s.mockClientBean, s.logger)
 	wh := s.getWorkflowHandlerWithParams(mService, config, mMetadataManager, s.mockBlobstoreClient)
@@ -679,7 +915,7 @@ func (s *workflowHandlerSuite) TestDescribeDomain_Success_ArchivalEnabled() {
 	clusterMetadata := &mocks.ClusterMetadata{}
 	clusterMetadata.On("ArchivalConfig").Return(cluster.NewArchivalConfig(cluster.ArchivalPaused, "default-bucket-name", true))
 	mMetadataManager := &mocks.MetadataManager{}
-	mMetadataManager.On("GetDomain", mock.Anything).Return(persistenceGetDomainResponse("bucket-name", shared.ArchivalStatusEnabled), nil)
+	mMetadataManager.On("GetDomain", mock.Anything, mock.Anything).Return(persistenceGetDomainResponse("bucket-name", shared.ArchivalStatusEnabled), nil)
 	mBlobstore := &mocks.BlobstoreClient{}
 	mBlobstore.On("BucketMetadata", mock.Anything, mock.Anything).Return(bucketMetadataResponse("test-owner", 10), nil)
 	mService := cs.NewTestService(clusterMetadata, s.mockMessagingClient, s.mockMetricClient, s.mockClientBean, s.logger)
@@ -707,7 +943,7 @@ func (s *workflowHandlerSuite) TestDescribeDomain_Success_ArchivalDisabled() {
 	clusterMetadata := &mocks.ClusterMetadata{}
 	clusterMetadata.On("ArchivalConfig").Return(cluster.NewArchivalConfig(cluster.ArchivalPaused, "default-bucket-name", true))
 	mMetadataManager := &mocks.MetadataManager{}
-	mMetadataManager.On("GetDomain", mock.Anything).Return(persistenceGetDomainResponse("bucket-name", shared.ArchivalStatusDisabled), nil)
+	mMetadataManager.On("GetDomain", mock.Anything, mock.Anything).Return(persistenceGetDomainResponse("bucket-name", shared.ArchivalStatusDisabled), nil)
 	mBlobstore := &mocks.BlobstoreClient{}
 	mBlobstore.On("BucketMetadata", mock.Anything, mock.Anything).Return(bucketMetadataResponse("test-owner", 10), nil)
 	mService := cs.NewTestService(clusterMetadata, s.mockMessagingClient, s.mockMetricClient, s.mockClientBean, s.logger)
@@ -735,7 +971,7 @@ func (s *workflowHandlerSuite) TestDescribeDomain_Success_BlobstoreReturnsError(
 	clusterMetadata := &mocks.ClusterMetadata{}
 	clusterMetadata.On("ArchivalConfig").Return(cluster.NewArchivalConfig(cluster.ArchivalPaused, "default-bucket-name", true))
 	mMetadataManager := &mocks.MetadataManager{}
-	mMetadataManager.On("GetDomain", mock.Anything).Return(persistenceGetDomainResponse("bucket-name", shared.ArchivalStatusDisabled), nil)
+	mMetadataManager.On("GetDomain", mock.Anything, mock.Anything).Return(persistenceGetDomainResponse("bucket-name", shared.ArchivalStatusDisabled), nil)
 	mBlobstore := &mocks.BlobstoreClient{}
 	mBlobstore.On("BucketMetadata", mock.Anything, mock.Anything).Return(nil, errors.New("blobstore error"))
 	mService := cs.NewTestService(clusterMetadata, s.mockMessagingClient, s.mockMetricClient, s.mockClientBean, s.logger)
@@ -761,10 +997,10 @@ func (s *workflowHandlerSuite) TestDescribeDomain_Success_BlobstoreReturnsError(
 func (s *workflowHandlerSuite) TestUpdateDomain_Failure_UpdateExistingBucketName() {
 	config := s.newConfig()
 	mMetadataManager := &mocks.MetadataManager{}
-	mMetadataManager.On("GetMetadata").Return(&persistence.GetMetadataResponse{
+	mMetadataManager.On("GetMetadata", mock.Anything).Return(&persistence.GetMetadataResponse{
 		NotificationVersion: int64(0),
 	}, nil)
-	mMetadataManager.On("GetDomain", mock.Anything).Return(persistenceGetDomainResponse("bucket-name", shared.ArchivalStatusDisabled), nil)
+	mMetadataManager.On("GetDomain", mock.Anything, mock.Anything).Return(persistenceGetDomainResponse("bucket-name", shared.ArchivalStatusDisabled), nil)
 	clusterMetadata := &mocks.ClusterMetadata{}
 	clusterMetadata.On("IsGlobalDomainEnabled").Return(false)
 	clusterMetadata.On("ArchivalConfig").Return(cluster.NewArchivalConfig(cluster.ArchivalPaused, "test-archival-bucket", true))
@@ -782,10 +1018,10 @@ func (s *workflowHandlerSuite) TestUpdateDomain_Failure_UpdateExistingBucketName
 func (s *workflowHandlerSuite) TestUpdateDomain_Failure_BucketNotExists() {
 	config := s.newConfig()
 	mMetadataManager := &mocks.MetadataManager{}
-	mMetadataManager.On("GetMetadata").Return(&persistence.GetMetadataResponse{
+	mMetadataManager.On("GetMetadata", mock.Anything).Return(&persistence.GetMetadataResponse{
 		NotificationVersion: int64(0),
 	}, nil)
-	mMetadataManager.On("GetDomain", mock.Anything).Return(persistenceGetDomainResponse("", shared.ArchivalStatusDisabled), nil)
+	mMetadataManager.On("GetDomain", mock.Anything, mock.Anything).Return(persistenceGetDomainResponse("", shared.ArchivalStatusDisabled), nil)
 	clusterMetadata := &mocks.ClusterMetadata{}
 	clusterMetadata.On("IsGlobalDomainEnabled").Return(false)
 	mBlobstore := &mocks.BlobstoreClient{}
@@ -805,11 +1041,11 @@ func (s *workflowHandlerSuite) TestUpdateDomain_Failure_BucketNotExists() {
 func (s *workflowHandlerSuite) TestUpdateDomain_Success_ArchivalEnabledToArchivalDisabledWithoutSettingBucket() {
 	config := s.newConfig()
 	mMetadataManager := &mocks.MetadataManager{}
-	mMetadataManager.On("GetMetadata").Return(&persistence.GetMetadataResponse{
+	mMetadataManager.On("GetMetadata", mock.Anything).Return(&persistence.GetMetadataResponse{
 		NotificationVersion: int64(0),
 	}, nil)
-	mMetadataManager.On("GetDomain", mock.Anything).Return(persistenceGetDomainResponse("bucket-name", shared.ArchivalStatusEnabled), nil)
-	mMetadataManager.On("UpdateDomain", mock.Anything).Return(nil)
+	mMetadataManager.On("GetDomain", mock.Anything, mock.Anything).Return(persistenceGetDomainResponse("bucket-name", shared.ArchivalStatusEnabled), nil)
+	mMetadataManager.On("UpdateDomain", mock.Anything, mock.Anything).Return(nil)
 	clusterMetadata := &mocks.ClusterMetadata{}
 	clusterMetadata.On("IsGlobalDomainEnabled").Return(false)
 	clusterMetadata.On("ArchivalConfig").Return(cluster.NewArchivalConfig(cluster.ArchivalEnabled, "test-archival-bucket", true))
@@ -832,14 +1068,115 @@ func (s *workflowHandlerSuite) TestUpdateDomain_Success_ArchivalEnabledToArchiva
 	assert.Equal(s.T(), result.Configuration.GetArchivalRetentionPeriodInDays(), int32(10))
 }
 
+func (s *workflowHandlerSuite) TestUpdateDomain_Success_ArchivalEnabledToArchivalMigrating() {
+	config := s.newConfig()
+	mMetadataManager := &mocks.MetadataManager{}
+	mMetadataManager.On("GetMetadata", mock.Anything).Return(&persistence.GetMetadataResponse{
+		NotificationVersion: int64(0),
+	}, nil)
+	mMetadataManager.On("GetDomain", mock.Anything, mock.Anything).Return(persistenceGetDomainResponse("bucket-name", shared.ArchivalStatusEnabled), nil)
+	mMetadataManager.On("UpdateDomain", mock.Anything, mock.Anything).Return(nil)
+	clusterMetadata := &mocks.ClusterMetadata{}
+	clusterMetadata.On("IsGlobalDomainEnabled").Return(false)
+	clusterMetadata.On("ArchivalConfig").Return(cluster.NewArchivalConfig(cluster.ArchivalEnabled, "test-archival-bucket", true))
+	mService := cs.NewTestService(clusterMetadata, s.mockMessagingClient, s.mockMetricClient, s.mockClientBean, s.logger)
+	mBlobstore := &mocks.BlobstoreClient{}
+	mBlobstore.On("BucketExists", mock.Anything, mock.Anything).Return(true, nil)
+	mBlobstore.On("BucketMetadata", mock.Anything, mock.Anything).Return(bucketMetadataResponse("test-owner", 10), nil)
+	wh := s.getWorkflowHandlerWithParams(mService, config, mMetadataManager, mBlobstore)
+	wh.metricsClient = wh.Service.GetMetricsClient()
+	wh.startWG.Done()
+
+	updateReq := updateRequest(common.StringPtr("new-bucket"), nil, nil, nil)
+	result, err := wh.UpdateDomain(context.Background(), updateReq)
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), result)
+	assert.NotNil(s.T(), result.Configuration)
+	assert.Equal(s.T(), result.Configuration.GetArchivalStatus(), shared.ArchivalStatusMigrating)
+	assert.Equal(s.T(), result.Configuration.GetArchivalBucketName(), "bucket-name")
+	assert.Equal(s.T(), result.Configuration.GetArchivalTargetBucketName(), "new-bucket")
+	mBlobstore.AssertCalled(s.T(), "BucketExists", mock.Anything, "new-bucket")
+}
+
+func (s *workflowHandlerSuite) TestUpdateDomain_Success_ArchivalMigratingToArchivalEnabled() {
+	config := s.newConfig()
+	mMetadataManager := &mocks.MetadataManager{}
+	mMetadataManager.On("GetMetadata", mock.Anything).Return(&persistence.GetMetadataResponse{
+		NotificationVersion: int64(0),
+	}, nil)
+	mMetadataManager.On("GetDomain", mock.Anything, mock.Anything).Return(persistenceGetMigratingDomainResponse("bucket-name", "new-bucket"), nil)
+	mMetadataManager.On("UpdateDomain", mock.Anything, mock.Anything).Return(nil)
+	clusterMetadata := &mocks.ClusterMetadata{}
+	clusterMetadata.On("IsGlobalDomainEnabled").Return(false)
+	clusterMetadata.On("ArchivalConfig").Return(cluster.NewArchivalConfig(cluster.ArchivalEnabled, "test-archival-bucket", true))
+	mService := cs.NewTestService(clusterMetadata, s.mockMessagingClient, s.mockMetricClient, s.mockClientBean, s.logger)
+	mBlobstore := &mocks.BlobstoreClient{}
+	mBlobstore.On("BucketExists", mock.Anything, mock.Anything).Return(true, nil)
+	mBlobstore.On("BucketMetadata", mock.Anything, mock.Anything).Return(bucketMetadataResponse("test-owner", 10), nil)
+	wh := s.getWorkflowHandlerWithParams(mService, config, mMetadataManager, mBlobstore)
+	wh.metricsClient = wh.Service.GetMetricsClient()
+	wh.startWG.Done()
+
+	updateReq := updateRequest(common.StringPtr("new-bucket"), common.ArchivalStatusPtr(shared.ArchivalStatusEnabled), nil, nil)
+	result, err := wh.UpdateDomain(context.Background(), updateReq)
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), result)
+	assert.NotNil(s.T(), result.Configuration)
+	assert.Equal(s.T(), result.Configuration.GetArchivalStatus(), shared.ArchivalStatusEnabled)
+	assert.Equal(s.T(), result.Configuration.GetArchivalBucketName(), "new-bucket")
+	assert.Equal(s.T(), result.Configuration.GetArchivalTargetBucketName(), "")
+}
+
+func (s *workflowHandlerSuite) TestUpdateDomain_Failure_ArchivalDisabledWhileMigrating() {
+	config := s.newConfig()
+	mMetadataManager := &mocks.MetadataManager{}
+	mMetadataManager.On("GetMetadata", mock.Anything).Return(&persistence.GetMetadataResponse{
+		NotificationVersion: int64(0),
+	}, nil)
+	mMetadataManager.On("GetDomain", mock.Anything, mock.Anything).Return(persistenceGetMigratingDomainResponse("bucket-name", "new-bucket"), nil)
+	clusterMetadata := &mocks.ClusterMetadata{}
+	clusterMetadata.On("IsGlobalDomainEnabled").Return(false)
+	clusterMetadata.On("ArchivalConfig").Return(cluster.NewArchivalConfig(cluster.ArchivalEnabled, "test-archival-bucket", true))
+	mService := cs.NewTestService(clusterMetadata, s.mockMessagingClient, s.mockMetricClient, s.mockClientBean, s.logger)
+	wh := s.getWorkflowHandlerWithParams(mService, config, mMetadataManager, s.mockBlobstoreClient)
+	wh.metricsClient = wh.Service.GetMetricsClient()
+	wh.startWG.Done()
+
+	updateReq := updateRequest(nil, common.ArchivalStatusPtr(shared.ArchivalStatusDisabled), nil, nil)
+	_, err := wh.UpdateDomain(context.Background(), updateReq)
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), errMigrationInProgress, err)
+}
+
+func (s *workflowHandlerSuite) TestUpdateDomain_Failure_ArchivalStatusMigrating() {
+	config := s.newConfig()
+	mMetadataManager := &mocks.MetadataManager{}
+	mMetadataManager.On("GetMetadata", mock.Anything).Return(&persistence.GetMetadataResponse{
+		NotificationVersion: int64(0),
+	}, nil)
+	mMetadataManager.On("GetDomain", mock.Anything, mock.Anything).Return(persistenceGetDomainResponse("bucket-name", shared.ArchivalStatusEnabled), nil)
+	clusterMetadata := &mocks.ClusterMetadata{}
+	clusterMetadata.On("IsGlobalDomainEnabled").Return(false)
+	clusterMetadata.On("ArchivalConfig").Return(cluster.NewArchivalConfig(cluster.ArchivalEnabled, "test-archival-bucket", true))
+	mService := cs.NewTestService(clusterMetadata, s.mockMessagingClient, s.mockMetricClient, s.mockClientBean, s.logger)
+	wh := s.getWorkflowHandlerWithParams(mService, config, mMetadataManager, s.mockBlobstoreClient)
+	wh.metricsClient = wh.Service.GetMetricsClient()
+	wh.startWG.Done()
+
+	updateReq := updateRequest(common.StringPtr("new-bucket"), common.ArchivalStatusPtr(shared.ArchivalStatusMigrating), nil, nil)
+	_, err := wh.UpdateDomain(context.Background(), updateReq)
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), errMigratingStatusUpdate, err)
+}
+
 func (s *workflowHandlerSuite) TestUpdateDomain_Success_ClusterNotConfiguredForArchival() {
 	config := s.newConfig()
 	mMetadataManager := &mocks.MetadataManager{}
-	mMetadataManager.On("GetMetadata").Return(&persistence.GetMetadataResponse{
+	mMetadataManager.On("GetMetadata", mock.Anything).Return(&persistence.GetMetadataResponse{
 		NotificationVersion: int64(0),
 	}, nil)
-	mMetadataManager.On("GetDomain", mock.Anything).Return(persistenceGetDomainResponse("bucket-name", shared.ArchivalStatusEnabled), nil)
-	mMetadataManager.On("UpdateDomain", mock.Anything).Return(nil)
+	mMetadataManager.On("GetDomain", mock.Anything, mock.Anything).Return(persistenceGetDomainResponse("bucket-name", shared.ArchivalStatusEnabled), nil)
+	mMetadataManager.On("UpdateDomain", mock.Anything, mock.Anything).Return(nil)
 	clusterMetadata := &mocks.ClusterMetadata{}
 	clusterMetadata.On("IsGlobalDomainEnabled").Return(false)
 	clusterMetadata.On("ArchivalConfig").Return(cluster.NewArchivalConfig(cluster.ArchivalDisabled, "", true))
@@ -862,11 +1199,11 @@ func (s *workflowHandlerSuite) TestUpdateDomain_Success_ClusterNotConfiguredForA
 func (s *workflowHandlerSuite) TestUpdateDomain_Success_ArchivalEnabledToArchivalDisabledWithSettingBucket() {
 	config := s.newConfig()
 	mMetadataManager := &mocks.MetadataManager{}
-	mMetadataManager.On("GetMetadata").Return(&persistence.GetMetadataResponse{
+	mMetadataManager.On("GetMetadata", mock.Anything).Return(&persistence.GetMetadataResponse{
 		NotificationVersion: int64(0),
 	}, nil)
-	mMetadataManager.On("GetDomain", mock.Anything).Return(persistenceGetDomainResponse("bucket-name", shared.ArchivalStatusEnabled), nil)
-	mMetadataManager.On("UpdateDomain", mock.Anything).Return(nil)
+	mMetadataManager.On("GetDomain", mock.Anything, mock.Anything).Return(persistenceGetDomainResponse("bucket-name", shared.ArchivalStatusEnabled), nil)
+	mMetadataManager.On("UpdateDomain", mock.Anything, mock.Anything).Return(nil)
 	clusterMetadata := &mocks.ClusterMetadata{}
 	clusterMetadata.On("IsGlobalDomainEnabled").Return(false)
 	clusterMetadata.On("ArchivalConfig").Return(cluster.NewArchivalConfig(cluster.ArchivalEnabled, "test-archival-bucket", true))
@@ -892,11 +1229,11 @@ func (s *workflowHandlerSuite) TestUpdateDomain_Success_ArchivalEnabledToArchiva
 func (s *workflowHandlerSuite) TestUpdateDomain_Success_ArchivalEnabledToEnabled() {
 	config := s.newConfig()
 	mMetadataManager := &mocks.MetadataManager{}
-	mMetadataManager.On("GetMetadata").Return(&persistence.GetMetadataResponse{
+	mMetadataManager.On("GetMetadata", mock.Anything).Return(&persistence.GetMetadataResponse{
 		NotificationVersion: int64(0),
 	}, nil)
-	mMetadataManager.On("GetDomain", mock.Anything).Return(persistenceGetDomainResponse("bucket-name", shared.ArchivalStatusEnabled), nil)
-	mMetadataManager.On("UpdateDomain", mock.Anything).Return(nil)
+	mMetadataManager.On("GetDomain", mock.Anything, mock.Anything).Return(persistenceGetDomainResponse("bucket-name", shared.ArchivalStatusEnabled), nil)
+	mMetadataManager.On("UpdateDomain", mock.Anything, mock.Anything).Return(nil)
 	clusterMetadata := &mocks.ClusterMetadata{}
 	clusterMetadata.On("IsGlobalDomainEnabled").Return(false)
 	clusterMetadata.On("ArchivalConfig").Return(cluster.NewArchivalConfig(cluster.ArchivalEnabled, "test-archival-bucket", true))
@@ -922,11 +1259,11 @@ func (s *workflowHandlerSuite) TestUpdateDomain_Success_ArchivalEnabledToEnabled
 func (s *workflowHandlerSuite) TestUpdateDomain_Success_ArchivalNeverEnabledToEnabled() {
 	config := s.newConfig()
 	mMetadataManager := &mocks.MetadataManager{}
-	mMetadataManager.On("GetMetadata").Return(&persistence.GetMetadataResponse{
+	mMetadataManager.On("GetMetadata", mock.Anything).Return(&persistence.GetMetadataResponse{
 		NotificationVersion: int64(0),
 	}, nil)
-	mMetadataManager.On("GetDomain", mock.Anything).Return(persistenceGetDomainResponse("", shared.ArchivalStatusDisabled), nil)
-	mMetadataManager.On("UpdateDomain", mock.Anything).Return(nil)
+	mMetadataManager.On("GetDomain", mock.Anything, mock.Anything).Return(persistenceGetDomainResponse("", shared.ArchivalStatusDisabled), nil)
+	mMetadataManager.On("UpdateDomain", mock.Anything, mock.Anything).Return(nil)
 	clusterMetadata := &mocks.ClusterMetadata{}
 	clusterMetadata.On("IsGlobalDomainEnabled").Return(false)
 	clusterMetadata.On("ArchivalConfig").Return(cluster.NewArchivalConfig(cluster.ArchivalEnabled, "test-archival-bucket", true))
@@ -995,10 +1332,65 @@ func (s *workflowHandlerSuite) TestHistoryArchived() {
 	s.False(wh.historyArchived(context.Background(), getHistoryRequest, "test-domain"))
 }
 
+func (s *workflowHandlerSuite) TestToDeletedExecutionError() {
+	domain := "test-domain"
+	domainID := uuid.New()
+	config := s.newConfig()
+	wh := s.getWorkflowHandler(config)
+	notExistsErr := &shared.EntityNotExistsError{Message: "workflow execution not found"}
+	deleteTime := time.Date(2019, 3, 1, 12, 0, 0, 0, time.UTC)
+	tombstone := &shared.WorkflowExecutionInfo{
+		Execution: &shared.WorkflowExecution{
+			WorkflowId: common.StringPtr("test-workflow-id"),
+			RunId:      common.StringPtr("test-run-id"),
+		},
+		CloseTime:   common.Int64Ptr(deleteTime.UnixNano()),
+		CloseStatus: shared.WorkflowExecutionCloseStatusDeleted.Ptr(),
+	}
+
+	// errors other than not found are returned as is
+	otherErr := errors.New("some random error")
+	s.Equal(otherErr, wh.toDeletedExecutionError(context.Background(), otherErr, domain, domainID, tombstone.Execution))
+
+	// the run is looked up when given
+	s.mockVisibilityMgr.On("GetClosedWorkflowExecution", mock.Anything, &persistence.GetClosedWorkflowExecutionRequest{
+		DomainUUID: domainID,
+		Domain:     domain,
+		Execution:  *tombstone.Execution,
+	}).Return(&persistence.GetClosedWorkflowExecutionResponse{Execution: tombstone}, nil).Once()
+	err := wh.toDeletedExecutionError(context.Background(), notExistsErr, domain, domainID, tombstone.Execution)
+	s.IsType(&shared.EntityNotExistsError{}, err)
+	s.Contains(err.Error(), "deleted due to retention at 2019-03-01T12:00:00Z")
+
+	// otherwise the latest run of the workflow is
+	execution := &shared.WorkflowExecution{WorkflowId: common.StringPtr("test-workflow-id")}
+	s.mockVisibilityMgr.On("ListClosedWorkflowExecutionsByWorkflowID", mock.Anything, mock.MatchedBy(
+		func(request *persistence.ListWorkflowExecutionsByWorkflowIDRequest) bool {
+			return request.WorkflowID == "test-workflow-id" && request.PageSize == 1
+		})).Return(&persistence.ListWorkflowExecutionsResponse{
+		Executions: []*shared.WorkflowExecutionInfo{tombstone},
+	}, nil).Once()
+	err = wh.toDeletedExecutionError(context.Background(), notExistsErr, domain, domainID, execution)
+	s.Contains(err.Error(), "deleted due to retention at 2019-03-01T12:00:00Z")
+
+	// runs which are not tombstones keep the original error
+	completed := &shared.WorkflowExecutionInfo{
+		Execution:   tombstone.Execution,
+		CloseStatus: shared.WorkflowExecutionCloseStatusCompleted.Ptr(),
+	}
+	s.mockVisibilityMgr.On("GetClosedWorkflowExecution", mock.Anything, mock.Anything).
+		Return(&persistence.GetClosedWorkflowExecutionResponse{Execution: completed}, nil).Once()
+	s.Equal(notExistsErr, wh.toDeletedExecutionError(context.Background(), notExistsErr, domain, domainID, tombstone.Execution))
+
+	// tombstones are not looked up when they are disabled
+	config.VisibilityTombstoneRetention = dc.GetDurationPropertyFnFilteredByDomain(0)
+	s.Equal(notExistsErr, wh.toDeletedExecutionError(context.Background(), notExistsErr, domain, domainID, tombstone.Execution))
+}
+
 func (s *workflowHandlerSuite) TestGetArchivedHistory_Failure_DomainCacheEntryError() {
 	config := s.newConfig()
 	mMetadataManager := &mocks.MetadataManager{}
-	mMetadataManager.On("GetDomain", mock.Anything).Return(nil, errors.New("error getting domain"))
+	mMetadataManager.On("GetDomain", mock.Anything, mock.Anything).Return(nil, errors.New("error getting domain"))
 	wh := s.getWorkflowHandlerWithParams(s.mockService, config, mMetadataManager, s.mockBlobstoreClient)
 	wh.metricsClient = wh.Service.GetMetricsClient()
 	wh.startWG.Done()
@@ -1010,7 +1402,7 @@ func (s *workflowHandlerSuite) TestGetArchivedHistory_Failure_DomainCacheEntryEr
 func (s *workflowHandlerSuite) TestGetArchivedHistory_Failure_ArchivalBucketEmpty() {
 	config := s.newConfig()
 	mMetadataManager := &mocks.MetadataManager{}
-	mMetadataManager.On("GetDomain", mock.Anything).Return(persistenceGetDomainResponse("", shared.ArchivalStatusDisabled), nil)
+	mMetadataManager.On("GetDomain", mock.Anything, mock.Anything).Return(persistenceGetDomainResponse("", shared.ArchivalStatusDisabled), nil)
 	clusterMetadata := &mocks.ClusterMetadata{}
 	clusterMetadata.On("IsGlobalDomainEnabled").Return(false)
 	mService := cs.NewTestService(clusterMetadata, s.mockMessagingClient, s.mockMetricClient, s.mockClientBean, s.logger)
@@ -1025,7 +1417,7 @@ func (s *workflowHandlerSuite) TestGetArchivedHistory_Failure_ArchivalBucketEmpt
 func (s *workflowHandlerSuite) TestGetArchivedHistory_Failure_InvalidPageToken() {
 	config := s.newConfig()
 	mMetadataManager := &mocks.MetadataManager{}
-	mMetadataManager.On("GetDomain", mock.Anything).Return(persistenceGetDomainResponse("test-bucket", shared.ArchivalStatusEnabled), nil)
+	mMetadataManager.On("GetDomain", mock.Anything, mock.Anything).Return(persistenceGetDomainResponse("test-bucket", shared.ArchivalStatusEnabled), nil)
 	clusterMetadata := &mocks.ClusterMetadata{}
 	clusterMetadata.On("IsGlobalDomainEnabled").Return(false)
 	mService := cs.NewTestService(clusterMetadata, s.mockMessagingClient, s.mockMetricClient, s.mockClientBean, s.logger)
@@ -1041,7 +1433,7 @@ func (s *workflowHandlerSuite) TestGetArchivedHistory_Failure_InvalidPageToken()
 func (s *workflowHandlerSuite) TestGetArchivedHistory_Failure_InvalidBlobKey() {
 	config := s.newConfig()
 	mMetadataManager := &mocks.MetadataManager{}
-	mMetadataManager.On("GetDomain", mock.Anything).Return(persistenceGetDomainResponse("test-bucket", shared.ArchivalStatusEnabled), nil)
+	mMetadataManager.On("GetDomain", mock.Anything, mock.Anything).Return(persistenceGetDomainResponse("test-bucket", shared.ArchivalStatusEnabled), nil)
 	clusterMetadata := &mocks.ClusterMetadata{}
 	clusterMetadata.On("IsGlobalDomainEnabled").Return(false)
 	mService := cs.NewTestService(clusterMetadata, s.mockMessagingClient, s.mockMetricClient, s.mockClientBean, s.logger)
@@ -1058,7 +1450,7 @@ func (s *workflowHandlerSuite) TestGetArchivedHistory_Failure_InvalidBlobKey() {
 func (s *workflowHandlerSuite) TestGetArchivedHistory_Failure_FailedToDownload() {
 	config := s.newConfig()
 	mMetadataManager := &mocks.MetadataManager{}
-	mMetadataManager.On("GetDomain", mock.Anything).Return(persistenceGetDomainResponse("test-bucket", shared.ArchivalStatusEnabled), nil)
+	mMetadataManager.On("GetDomain", mock.Anything, mock.Anything).Return(persistenceGetDomainResponse("test-bucket", shared.ArchivalStatusEnabled), nil)
 	clusterMetadata := &mocks.ClusterMetadata{}
 	clusterMetadata.On("IsGlobalDomainEnabled").Return(false)
 	mService := cs.NewTestService(clusterMetadata, s.mockMessagingClient, s.mockMetricClient, s.mockClientBean, s.logger)
@@ -1075,7 +1467,7 @@ func (s *workflowHandlerSuite) TestGetArchivedHistory_Failure_FailedToDownload()
 func (s *workflowHandlerSuite) TestGetArchivedHistory_Success_GetFirstPage() {
 	config := s.newConfig()
 	mMetadataManager := &mocks.MetadataManager{}
-	mMetadataManager.On("GetDomain", mock.Anything).Return(persistenceGetDomainResponse("test-bucket", shared.ArchivalStatusEnabled), nil)
+	mMetadataManager.On("GetDomain", mock.Anything, mock.Anything).Return(persistenceGetDomainResponse("test-bucket", shared.ArchivalStatusEnabled), nil)
 	clusterMetadata := &mocks.ClusterMetadata{}
 	clusterMetadata.On("IsGlobalDomainEnabled").Return(false)
 	mService := cs.NewTestService(clusterMetadata, s.mockMessagingClient, s.mockMetricClient, s.mockClientBean, s.logger)
@@ -1112,7 +1504,7 @@ func (s *workflowHandlerSuite) TestGetArchivedHistory_Success_GetFirstPage() {
 func (s *workflowHandlerSuite) TestGetArchivedHistory_Success_GetLastPage() {
 	config := s.newConfig()
 	mMetadataManager := &mocks.MetadataManager{}
-	mMetadataManager.On("GetDomain", mock.Anything).Return(persistenceGetDomainResponse("test-bucket", shared.ArchivalStatusEnabled), nil)
+	mMetadataManager.On("GetDomain", mock.Anything, mock.Anything).Return(persistenceGetDomainResponse("test-bucket", shared.ArchivalStatusEnabled), nil)
 	clusterMetadata := &mocks.ClusterMetadata{}
 	clusterMetadata.On("IsGlobalDomainEnabled").Return(false)
 	mService := cs.NewTestService(clusterMetadata, s.mockMessagingClient, s.mockMetricClient, s.mockClientBean, s.logger)
@@ -1141,6 +1533,104 @@ func (s *workflowHandlerSuite) TestGetArchivedHistory_Success_GetLastPage() {
 	s.Nil(resp.NextPageToken)
 }
 
+func (s *workflowHandlerSuite) TestGetArchivedHistory_Success_PaginateWithinBlob() {
+	config := s.newConfig()
+	mMetadataManager := &mocks.MetadataManager{}
+	mMetadataManager.On("GetDomain", mock.Anything, mock.Anything).Return(persistenceGetDomainResponse("test-bucket", shared.ArchivalStatusEnabled), nil)
+	clusterMetadata := &mocks.ClusterMetadata{}
+	clusterMetadata.On("IsGlobalDomainEnabled").Return(false)
+	mService := cs.NewTestService(clusterMetadata, s.mockMessagingClient, s.mockMetricClient, s.mockClientBean, s.logger)
+	mBlobstore := &mocks.BlobstoreClient{}
+	mBlobstore.On("Download", mock.Anything, mock.Anything, mock.Anything).Return(s.archivedHistoryBlob(common.FirstBlobPageToken, false, 3), nil)
+	wh := s.getWorkflowHandlerWithParams(mService, config, mMetadataManager, mBlobstore)
+	wh.metricsClient = wh.Service.GetMetricsClient()
+	wh.startWG.Done()
+
+	request := getHistoryRequest(nil)
+	request.MaximumPageSize = common.Int32Ptr(2)
+	resp, err := wh.getArchivedHistory(context.Background(), request, "test-domain-id", metrics.NoopScope(metrics.Frontend))
+	s.NoError(err)
+	s.Len(resp.History.Events, 2)
+	s.Equal(int64(1), resp.History.Events[0].GetEventId())
+	expectedNextPageToken, err := serializeHistoryTokenArchival(&getHistoryContinuationTokenArchival{
+		BlobstorePageToken: common.FirstBlobPageToken,
+		EventIndex:         2,
+	})
+	s.NoError(err)
+	s.Equal(expectedNextPageToken, resp.NextPageToken)
+
+	request = getHistoryRequest(resp.NextPageToken)
+	request.MaximumPageSize = common.Int32Ptr(2)
+	resp, err = wh.getArchivedHistory(context.Background(), request, "test-domain-id", metrics.NoopScope(metrics.Frontend))
+	s.NoError(err)
+	s.Len(resp.History.Events, 1)
+	s.Equal(int64(3), resp.History.Events[0].GetEventId())
+	expectedNextPageToken, err = serializeHistoryTokenArchival(&getHistoryContinuationTokenArchival{
+		BlobstorePageToken: common.FirstBlobPageToken + 1,
+	})
+	s.NoError(err)
+	s.Equal(expectedNextPageToken, resp.NextPageToken)
+}
+
+func (s *workflowHandlerSuite) TestGetArchivedHistory_Success_CloseEventOnly() {
+	config := s.newConfig()
+	mMetadataManager := &mocks.MetadataManager{}
+	mMetadataManager.On("GetDomain", mock.Anything, mock.Anything).Return(persistenceGetDomainResponse("test-bucket", shared.ArchivalStatusEnabled), nil)
+	clusterMetadata := &mocks.ClusterMetadata{}
+	clusterMetadata.On("IsGlobalDomainEnabled").Return(false)
+	mService := cs.NewTestService(clusterMetadata, s.mockMessagingClient, s.mockMetricClient, s.mockClientBean, s.logger)
+	mBlobstore := &mocks.BlobstoreClient{}
+	firstKey, err := archiver.NewHistoryBlobKey("test-domain-id", "test-workflow-id", "test-run-id", common.FirstBlobPageToken)
+	s.NoError(err)
+	lastKey, err := archiver.NewHistoryBlobKey("test-domain-id", "test-workflow-id", "test-run-id", common.FirstBlobPageToken+1)
+	s.NoError(err)
+	mBlobstore.On("GetTags", mock.Anything, "test-bucket", firstKey).Return(map[string]string{
+		"is_last":         "false",
+		"next_page_token": "2",
+	}, nil).Once()
+	mBlobstore.On("GetTags", mock.Anything, "test-bucket", lastKey).Return(map[string]string{
+		"is_last":         "true",
+		"next_page_token": "-1",
+	}, nil).Once()
+	mBlobstore.On("Download", mock.Anything, "test-bucket", lastKey).Return(s.archivedHistoryBlob(common.FirstBlobPageToken+1, true, 4), nil).Once()
+	wh := s.getWorkflowHandlerWithParams(mService, config, mMetadataManager, mBlobstore)
+	wh.metricsClient = wh.Service.GetMetricsClient()
+	wh.startWG.Done()
+
+	request := getHistoryRequest(nil)
+	request.HistoryEventFilterType = shared.HistoryEventFilterTypeCloseEvent.Ptr()
+	resp, err := wh.getArchivedHistory(context.Background(), request, "test-domain-id", metrics.NoopScope(metrics.Frontend))
+	s.NoError(err)
+	s.True(resp.GetArchived())
+	s.Len(resp.History.Events, 1)
+	s.Equal(int64(4), resp.History.Events[0].GetEventId())
+	s.Nil(resp.NextPageToken)
+	mBlobstore.AssertExpectations(s.T())
+}
+
+func (s *workflowHandlerSuite) archivedHistoryBlob(pageToken int, isLast bool, eventCount int) *blob.Blob {
+	nextPageToken := pageToken + 1
+	if isLast {
+		nextPageToken = common.LastBlobNextPageToken
+	}
+	history := &shared.History{}
+	for i := 1; i <= eventCount; i++ {
+		history.Events = append(history.Events, &shared.HistoryEvent{EventId: common.Int64Ptr(int64(i))})
+	}
+	bytes, err := json.Marshal(&archiver.HistoryBlob{
+		Header: &archiver.HistoryBlobHeader{
+			CurrentPageToken: common.IntPtr(pageToken),
+			NextPageToken:    common.IntPtr(nextPageToken),
+			IsLast:           common.BoolPtr(isLast),
+		},
+		Body: history,
+	})
+	s.NoError(err)
+	historyBlob, err := blob.Wrap(blob.NewBlob(bytes, map[string]string{}), blob.JSONEncoded())
+	s.NoError(err)
+	return historyBlob
+}
+
 func (s *workflowHandlerSuite) TestGetHistory() {
 	config := s.newConfig()
 	domainID := uuid.New()
@@ -1176,12 +1666,25 @@ func (s *workflowHandlerSuite) TestGetHistory() {
 	wh := s.getWorkflowHandlerWithParams(mService, config, mMetadataManager, mBlobstore)
 	wh.metricsClient = wh.Service.GetMetricsClient()
 	scope := wh.metricsClient.Scope(0)
-	history, token, err := wh.getHistory(scope, domainID, we, firstEventID, nextEventID, 0, []byte{}, nil, persistence.EventStoreVersionV2, []byte{})
+	history, token, err := wh.getHistory(scope, domainID, we, firstEventID, nextEventID, 0, []byte{}, nil, persistence.EventStoreVersionV2, []byte{}, false)
 	s.NotNil(history)
 	s.Equal([]byte{}, token)
 	s.NoError(err)
 }
 
+func (s *workflowHandlerSuite) TestFilterHistoryEvents() {
+	events := []*gen.HistoryEvent{
+		{EventId: common.Int64Ptr(1), EventType: gen.EventTypeWorkflowExecutionStarted.Ptr()},
+		{EventId: common.Int64Ptr(2), EventType: gen.EventTypeTimerFired.Ptr()},
+		{EventId: common.Int64Ptr(3), EventType: gen.EventTypeActivityTaskFailed.Ptr()},
+		{EventId: common.Int64Ptr(4), EventType: gen.EventTypeWorkflowExecutionCompleted.Ptr()},
+	}
+
+	s.Equal(events, filterHistoryEvents(events, nil))
+	s.Equal(events[1:3], filterHistoryEvents(events, []gen.EventType{gen.EventTypeActivityTaskFailed, gen.EventTypeTimerFired}))
+	s.Empty(filterHistoryEvents(events, []gen.EventType{gen.EventTypeSignalExternalWorkflowExecutionFailed}))
+}
+
 func (s *workflowHandlerSuite) newConfig() *Config {
 	return NewConfig(dc.NewCollection(dc.NewNopClient(), s.logger), numHistoryShards, false)
 }
@@ -1241,6 +1744,12 @@ func persistenceGetDomainResponse(archivalBucket string, archivalStatus shared.A
 	}
 }
 
+func persistenceGetMigratingDomainResponse(archivalBucket string, archivalTargetBucket string) *persistence.GetDomainResponse {
+	response := persistenceGetDomainResponse(archivalBucket, shared.ArchivalStatusMigrating)
+	response.Config.ArchivalTargetBucket = archivalTargetBucket
+	return response
+}
+
 func registerDomainRequest(archivalStatus *shared.ArchivalStatus, bucketName *string) *shared.RegisterDomainRequest {
 	return &shared.RegisterDomainRequest{
 		Name:                                   common.StringPtr("test-domain"),
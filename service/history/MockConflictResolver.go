@@ -54,3 +54,27 @@ func (_m *mockConflictResolver) reset(prevRunID string, requestID string, replay
 
 	return r0, r1
 }
+
+// rebuild is mock implementation for rebuild of conflictResolver
+func (_m *mockConflictResolver) rebuild(requestID string, branchToken []byte, replayEventID int64,
+	versionHistories *persistence.VersionHistories, info *persistence.WorkflowExecutionInfo) (mutableState, error) {
+	ret := _m.Called(requestID, branchToken, replayEventID, versionHistories, info)
+
+	var r0 mutableState
+	if rf, ok := ret.Get(0).(func(string, []byte, int64, *persistence.VersionHistories, *persistence.WorkflowExecutionInfo) mutableState); ok {
+		r0 = rf(requestID, branchToken, replayEventID, versionHistories, info)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(mutableState)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
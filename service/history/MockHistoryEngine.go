@@ -158,6 +158,29 @@ func (_m *MockHistoryEngine) DescribeWorkflowExecution(ctx context.Context, requ
 	return r0, r1
 }
 
+// DescribeWorkflowExecutions is mock implementation for DescribeWorkflowExecutions of HistoryEngine
+func (_m *MockHistoryEngine) DescribeWorkflowExecutions(ctx context.Context, request *gohistory.DescribeWorkflowExecutionsRequest) (*shared.DescribeWorkflowExecutionsResponse, error) {
+	ret := _m.Called(request)
+
+	var r0 *shared.DescribeWorkflowExecutionsResponse
+	if rf, ok := ret.Get(0).(func(*gohistory.DescribeWorkflowExecutionsRequest) *shared.DescribeWorkflowExecutionsResponse); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.DescribeWorkflowExecutionsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*gohistory.DescribeWorkflowExecutionsRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RecordDecisionTaskStarted is mock implementation for RecordDecisionTaskStarted of HistoryEngine
 func (_m *MockHistoryEngine) RecordDecisionTaskStarted(ctx context.Context, request *gohistory.RecordDecisionTaskStartedRequest) (*gohistory.RecordDecisionTaskStartedResponse, error) {
 	ret := _m.Called(request)
@@ -386,6 +409,20 @@ func (_m *MockHistoryEngine) TerminateWorkflowExecution(ctx context.Context, req
 	return r0
 }
 
+// UpdateWorkflowExecutionLabels is mock implementation for UpdateWorkflowExecutionLabels of HistoryEngine
+func (_m *MockHistoryEngine) UpdateWorkflowExecutionLabels(ctx context.Context, request *gohistory.UpdateWorkflowExecutionLabelsRequest) error {
+	ret := _m.Called(request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gohistory.UpdateWorkflowExecutionLabelsRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ResetWorkflowExecution is mock implementation for TerminateWorkflowExecution of HistoryEngine
 func (_m *MockHistoryEngine) ResetWorkflowExecution(ctx context.Context, request *gohistory.ResetWorkflowExecutionRequest) (*shared.ResetWorkflowExecutionResponse, error) {
 	ret := _m.Called(request)
@@ -465,6 +502,20 @@ func (_m *MockHistoryEngine) ReplicateRawEvents(ctx context.Context, request *go
 	return r0
 }
 
+// ReplicateEventsV2 is mock implementation for ReplicateEventsV2 of HistoryEngine
+func (_m *MockHistoryEngine) ReplicateEventsV2(ctx context.Context, request *gohistory.ReplicateEventsV2Request) error {
+	ret := _m.Called(request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gohistory.ReplicateEventsV2Request) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SyncShardStatus is mock implementation for SyncShardStatus of HistoryEngine
 func (_m *MockHistoryEngine) SyncShardStatus(ctx context.Context, request *gohistory.SyncShardStatusRequest) error {
 	ret := _m.Called(request)
@@ -493,4 +544,27 @@ func (_m *MockHistoryEngine) SyncActivity(ctx context.Context, request *gohistor
 	return r0
 }
 
+// GetReplicationTasks is mock implementation for GetReplicationTasks of HistoryEngine
+func (_m *MockHistoryEngine) GetReplicationTasks(ctx context.Context, request *gohistory.GetReplicationTasksRequest) (*gohistory.GetReplicationTasksResponse, error) {
+	ret := _m.Called(request)
+
+	var r0 *gohistory.GetReplicationTasksResponse
+	if rf, ok := ret.Get(0).(func(*gohistory.GetReplicationTasksRequest) *gohistory.GetReplicationTasksResponse); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gohistory.GetReplicationTasksResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*gohistory.GetReplicationTasksRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 var _ Engine = (*MockHistoryEngine)(nil)
@@ -811,13 +811,13 @@ func (_m *mockMutableState) AddWorkflowExecutionStartedEvent(_a0 shared.Workflow
 	return r0
 }
 
-// AddWorkflowExecutionTerminatedEvent provides a mock function with given fields: _a0
-func (_m *mockMutableState) AddWorkflowExecutionTerminatedEvent(_a0 *shared.TerminateWorkflowExecutionRequest) *shared.HistoryEvent {
-	ret := _m.Called(_a0)
+// AddWorkflowExecutionTerminatedEvent provides a mock function with given fields: _a0, _a1
+func (_m *mockMutableState) AddWorkflowExecutionTerminatedEvent(_a0 shared.TerminationCause, _a1 *shared.TerminateWorkflowExecutionRequest) *shared.HistoryEvent {
+	ret := _m.Called(_a0, _a1)
 
 	var r0 *shared.HistoryEvent
-	if rf, ok := ret.Get(0).(func(*shared.TerminateWorkflowExecutionRequest) *shared.HistoryEvent); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(shared.TerminationCause, *shared.TerminateWorkflowExecutionRequest) *shared.HistoryEvent); ok {
+		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*shared.HistoryEvent)
@@ -879,6 +879,11 @@ func (_m *mockMutableState) CloseUpdateSession() (*mutableStateSessionUpdates, e
 	return r0, r1
 }
 
+// CoalesceActivityProgress provides a mock function with given fields: ai, request
+func (_m *mockMutableState) CoalesceActivityProgress(ai *persistence.ActivityInfo, request *shared.RecordActivityTaskHeartbeatRequest) {
+	_m.Called(ai, request)
+}
+
 // CopyToPersistence provides a mock function with given fields:
 func (_m *mockMutableState) CopyToPersistence() *persistence.WorkflowMutableState {
 	ret := _m.Called()
@@ -1445,6 +1450,22 @@ func (_m *mockMutableState) GetPendingChildExecutionInfos() map[int64]*persisten
 	return r0
 }
 
+// GetPendingSignalRequestedIDs provides a mock function with given fields:
+func (_m *mockMutableState) GetPendingSignalRequestedIDs() map[string]struct{} {
+	ret := _m.Called()
+
+	var r0 map[string]struct{}
+	if rf, ok := ret.Get(0).(func() map[string]struct{}); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]struct{})
+		}
+	}
+
+	return r0
+}
+
 // GetPendingDecision provides a mock function with given fields: _a0
 func (_m *mockMutableState) GetPendingDecision(_a0 int64) (*decisionInfo, bool) {
 	ret := _m.Called(_a0)
@@ -1500,6 +1521,22 @@ func (_m *mockMutableState) GetReplicationState() *persistence.ReplicationState
 	return r0
 }
 
+// GetVersionHistories provides a mock function with given fields:
+func (_m *mockMutableState) GetVersionHistories() *persistence.VersionHistories {
+	ret := _m.Called()
+
+	var r0 *persistence.VersionHistories
+	if rf, ok := ret.Get(0).(func() *persistence.VersionHistories); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*persistence.VersionHistories)
+		}
+	}
+
+	return r0
+}
+
 // GetRequestCancelInfo provides a mock function with given fields: _a0
 func (_m *mockMutableState) GetRequestCancelInfo(_a0 int64) (*persistence.RequestCancelInfo, bool) {
 	ret := _m.Called(_a0)
@@ -2166,11 +2203,21 @@ func (_m *mockMutableState) SetHistoryBuilder(hBuilder *historyBuilder) {
 	_m.Called(hBuilder)
 }
 
+// SetNewRun provides a mock function with given fields: request
+func (_m *mockMutableState) SetNewRun(request *persistence.CreateWorkflowExecutionRequest) {
+	_m.Called(request)
+}
+
 // SetNewRunSize provides a mock function with given fields: size
 func (_m *mockMutableState) SetNewRunSize(size int) {
 	_m.Called(size)
 }
 
+// SetVersionHistories provides a mock function with given fields: _a0
+func (_m *mockMutableState) SetVersionHistories(_a0 *persistence.VersionHistories) {
+	_m.Called(_a0)
+}
+
 // UpdateActivity provides a mock function with given fields: _a0
 func (_m *mockMutableState) UpdateActivity(_a0 *persistence.ActivityInfo) error {
 	ret := _m.Called(_a0)
@@ -2203,11 +2250,30 @@ func (_m *mockMutableState) UpdateActivityProgress(ai *persistence.ActivityInfo,
 	_m.Called(ai, request)
 }
 
+// UpdateCurrentVersionHistory provides a mock function with given fields: _a0
+func (_m *mockMutableState) UpdateCurrentVersionHistory(_a0 []*shared.HistoryEvent) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]*shared.HistoryEvent) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateDecision provides a mock function with given fields: _a0
 func (_m *mockMutableState) UpdateDecision(_a0 *decisionInfo) {
 	_m.Called(_a0)
 }
 
+// UpdateLabels provides a mock function with given fields: upsertLabels, removeLabels
+func (_m *mockMutableState) UpdateLabels(upsertLabels map[string]string, removeLabels []string) {
+	_m.Called(upsertLabels, removeLabels)
+}
+
 // UpdateReplicationStateLastEventID provides a mock function with given fields: clusterName, lastWriteVersion, lastEventID
 func (_m *mockMutableState) UpdateReplicationStateLastEventID(clusterName string, lastWriteVersion int64, lastEventID int64) {
 	_m.Called(clusterName, lastWriteVersion, lastEventID)
@@ -50,7 +50,7 @@ func (_m *MockTimerQueueAckMgr) getFinishedChan() <-chan struct{} {
 }
 
 // readTimerTasks is mock implementation for readTimerTasks of TimerQueueAckMgr
-func (_m *MockTimerQueueAckMgr) readTimerTasks() ([]*persistence.TimerTaskInfo, *persistence.TimerTaskInfo, bool, error) {
+func (_m *MockTimerQueueAckMgr) readTimerTasks() ([]*persistence.TimerTaskInfo, []*persistence.TimerTaskInfo, bool, error) {
 	ret := _m.Called()
 
 	var r0 []*persistence.TimerTaskInfo
@@ -62,12 +62,12 @@ func (_m *MockTimerQueueAckMgr) readTimerTasks() ([]*persistence.TimerTaskInfo,
 		}
 	}
 
-	var r1 *persistence.TimerTaskInfo
-	if rf, ok := ret.Get(1).(func() *persistence.TimerTaskInfo); ok {
+	var r1 []*persistence.TimerTaskInfo
+	if rf, ok := ret.Get(1).(func() []*persistence.TimerTaskInfo); ok {
 		r1 = rf()
 	} else {
 		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*persistence.TimerTaskInfo)
+			r1 = ret.Get(1).([]*persistence.TimerTaskInfo)
 		}
 	}
 
@@ -32,6 +32,8 @@ import (
 type (
 	conflictResolver interface {
 		reset(prevRunID string, requestID string, replayEventID int64, info *persistence.WorkflowExecutionInfo) (mutableState, error)
+		rebuild(requestID string, branchToken []byte, replayEventID int64, versionHistories *persistence.VersionHistories,
+			info *persistence.WorkflowExecutionInfo) (mutableState, error)
 	}
 
 	conflictResolverImpl struct {
@@ -58,11 +60,47 @@ func newConflictResolver(shard ShardContext, context workflowExecutionContext, h
 }
 
 func (r *conflictResolverImpl) reset(prevRunID string, requestID string, replayEventID int64, info *persistence.WorkflowExecutionInfo) (mutableState, error) {
+	resetMutableStateBuilder, err := r.replay(requestID, info.EventStoreVersion, info.GetCurrentBranch(), replayEventID, info)
+	if err != nil {
+		return nil, err
+	}
+	// the version histories of the original branch still contain the events after replayEventID,
+	// this path is not aware of version histories, so they are dropped
+	resetMutableStateBuilder.SetVersionHistories(nil)
+
+	r.logger.WithField(logging.TagResetNextEventID, resetMutableStateBuilder.GetNextEventID()).Info("All events applied for execution.")
+	msBuilder, err := r.context.resetMutableState(prevRunID, resetMutableStateBuilder)
+	if err != nil {
+		r.logError("Conflict resolution err reset workflow.", err)
+	}
+	return msBuilder, err
+}
+
+// rebuild replaces the mutable state of the workflow with the one replayed from the given branch,
+// up to and including replayEventID, the given version histories are set on the rebuilt mutable state
+func (r *conflictResolverImpl) rebuild(requestID string, branchToken []byte, replayEventID int64,
+	versionHistories *persistence.VersionHistories, info *persistence.WorkflowExecutionInfo) (mutableState, error) {
+
+	rebuildMutableStateBuilder, err := r.replay(requestID, persistence.EventStoreVersionV2, branchToken, replayEventID, info)
+	if err != nil {
+		return nil, err
+	}
+	rebuildMutableStateBuilder.SetVersionHistories(versionHistories)
+
+	r.logger.WithField(logging.TagResetNextEventID, rebuildMutableStateBuilder.GetNextEventID()).Info("All events applied for execution.")
+	msBuilder, err := r.context.resetMutableState(info.RunID, rebuildMutableStateBuilder)
+	if err != nil {
+		r.logError("Conflict resolution err rebuild workflow.", err)
+	}
+	return msBuilder, err
+}
+
+func (r *conflictResolverImpl) replay(requestID string, eventStoreVersion int32, branchToken []byte, replayEventID int64,
+	info *persistence.WorkflowExecutionInfo) (*mutableStateBuilder, error) {
+
 	domainID := r.context.getDomainID()
 	execution := *r.context.getExecution()
 	startTime := info.StartTimestamp
-	eventStoreVersion := info.EventStoreVersion
-	branchToken := info.GetCurrentBranch()
 	replayNextEventID := replayEventID + 1
 
 	var nextPageToken []byte
@@ -132,13 +170,7 @@ func (r *conflictResolverImpl) reset(prevRunID string, requestID string, replayE
 
 	sourceCluster := r.clusterMetadata.ClusterNameForFailoverVersion(lastEvent.GetVersion())
 	resetMutableStateBuilder.UpdateReplicationStateLastEventID(sourceCluster, lastEvent.GetVersion(), replayEventID)
-
-	r.logger.WithField(logging.TagResetNextEventID, resetMutableStateBuilder.GetNextEventID()).Info("All events applied for execution.")
-	msBuilder, err := r.context.resetMutableState(prevRunID, resetMutableStateBuilder)
-	if err != nil {
-		r.logError("Conflict resolution err reset workflow.", err)
-	}
-	return msBuilder, err
+	return resetMutableStateBuilder, nil
 }
 
 func (r *conflictResolverImpl) getHistory(domainID string, execution shared.WorkflowExecution, firstEventID,
@@ -268,7 +268,7 @@ func (s *conflictResolverSuite) TestReset() {
 	// this is only a shallow test, meaning
 	// the mutable state only has the minimal information
 	// so we can test the conflict resolver
-	s.mockExecutionMgr.On("ResetMutableState", &persistence.ResetMutableStateRequest{
+	s.mockExecutionMgr.On("ResetMutableState", mock.Anything, &persistence.ResetMutableStateRequest{
 		PrevRunID:     prevRunID,
 		ExecutionInfo: executionInfo,
 		ReplicationState: &persistence.ReplicationState{
@@ -293,7 +293,7 @@ func (s *conflictResolverSuite) TestReset() {
 		InsertSignalRequestedIDs:  []string{},
 		Encoding:                  common.EncodingType(s.mockShard.GetConfig().EventEncodingType(domainID)),
 	}).Return(nil).Once()
-	s.mockExecutionMgr.On("GetWorkflowExecution", &persistence.GetWorkflowExecutionRequest{
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, &persistence.GetWorkflowExecutionRequest{
 		DomainID:  domainID,
 		Execution: execution,
 	}).Return(&persistence.GetWorkflowExecutionResponse{}, nil).Once() // return empty resoonse since we are not testing the load
@@ -59,6 +59,7 @@ type (
 		historyV2Mgr          persistence.HistoryV2Manager
 		executionMgrFactory   persistence.ExecutionManagerFactory
 		domainCache           cache.DomainCache
+		domainChangeNotifier  cache.DomainChangeNotifier
 		historyServiceClient  hc.Client
 		matchingServiceClient matching.Client
 		publicClient          workflowserviceclient.Interface
@@ -71,6 +72,7 @@ type (
 		historyEventNotifier  historyEventNotifier
 		publisher             messaging.Producer
 		visibilityProducer    messaging.Producer
+		mutableStateCache     cache.Cache
 		rateLimiter           tokenbucket.TokenBucket
 		service.Service
 	}
@@ -88,6 +90,7 @@ var (
 	errSourceClusterNotSet     = &gen.BadRequestError{Message: "Source Cluster not set on request."}
 	errShardIDNotSet           = &gen.BadRequestError{Message: "Shard ID not set on request."}
 	errTimestampNotSet         = &gen.BadRequestError{Message: "Timestamp not set on request."}
+	errExecutionsNotInShard    = &gen.BadRequestError{Message: "Workflow executions are not owned by the same shard."}
 	errHistoryHostThrottle     = &gen.ServiceBusyError{Message: "History host rps exceeded"}
 )
 
@@ -154,10 +157,29 @@ func (h *Handler) Start() error {
 		if err != nil {
 			h.GetBarkLogger().Fatalf("Creating visibility producer failed: %v", err)
 		}
+		if h.config.VisibilitySpillBuffer.Dir != "" {
+			h.visibilityProducer, err = messaging.NewSpillBufferProducer(h.visibilityProducer, common.VisibilityAppName,
+				h.config.VisibilitySpillBuffer, h.GetMetricsClient(), h.GetBarkLogger())
+			if err != nil {
+				h.GetBarkLogger().Fatalf("Creating visibility spill buffer failed: %v", err)
+			}
+		}
+	}
+
+	if h.config.EnableDomainChangeNotification() {
+		var err error
+		consumerName := fmt.Sprintf("%v-%v", common.HistoryServiceName, h.GetHostName())
+		h.domainChangeNotifier, err = cache.NewKafkaDomainChangeNotifier(h.GetMessagingClient(), consumerName, h.GetBarkLogger())
+		if err != nil {
+			h.GetBarkLogger().Fatalf("Creating domain change notifier failed: %v", err)
+		}
+		h.domainChangeNotifier.Start()
 	}
 
-	h.domainCache = cache.NewDomainCache(h.metadataMgr, h.GetClusterMetadata(), h.GetMetricsClient(), h.GetBarkLogger())
+	h.domainCache = cache.NewDomainCacheWithChangeNotifier(h.metadataMgr, h.GetClusterMetadata(), h.GetMetricsClient(),
+		h.GetBarkLogger(), h.domainChangeNotifier)
 	h.domainCache.Start()
+	h.mutableStateCache = newMutableStateCache(h.config, h.GetMetricsClient())
 	h.controller = newShardController(h.Service, h.GetHostInfo(), hServiceResolver, h.shardManager, h.historyMgr, h.historyV2Mgr,
 		h.domainCache, h.executionMgrFactory, h, h.config, h.GetBarkLogger(), h.GetMetricsClient())
 	h.metricsClient = h.GetMetricsClient()
@@ -172,6 +194,9 @@ func (h *Handler) Start() error {
 // Stop stops the handler
 func (h *Handler) Stop() {
 	h.domainCache.Stop()
+	if h.domainChangeNotifier != nil {
+		h.domainChangeNotifier.Stop()
+	}
 	h.controller.Stop()
 	h.shardManager.Close()
 	h.historyMgr.Close()
@@ -181,6 +206,9 @@ func (h *Handler) Stop() {
 	h.executionMgrFactory.Close()
 	h.metadataMgr.Close()
 	h.visibilityMgr.Close()
+	if h.visibilityProducer != nil {
+		h.visibilityProducer.Close()
+	}
 	h.Service.Stop()
 	h.historyEventNotifier.Stop()
 }
@@ -188,7 +216,7 @@ func (h *Handler) Stop() {
 // CreateEngine is implementation for HistoryEngineFactory used for creating the engine instance for shard
 func (h *Handler) CreateEngine(context ShardContext) Engine {
 	return NewEngineWithShardContext(context, h.visibilityMgr, h.matchingServiceClient, h.historyServiceClient,
-		h.publicClient, h.historyEventNotifier, h.publisher, h.visibilityProducer, h.config)
+		h.publicClient, h.historyEventNotifier, h.publisher, h.visibilityProducer, h.mutableStateCache, h.config)
 }
 
 // Health is for health check
@@ -740,6 +768,49 @@ func (h *Handler) DescribeWorkflowExecution(ctx context.Context, request *hist.D
 	return resp, nil
 }
 
+// DescribeWorkflowExecutions returns information about multiple workflow executions owned by the same shard
+func (h *Handler) DescribeWorkflowExecutions(ctx context.Context, request *hist.DescribeWorkflowExecutionsRequest) (resp *gen.DescribeWorkflowExecutionsResponse, retError error) {
+	defer logging.CapturePanic(h.GetBarkLogger(), &retError)
+	h.startWG.Wait()
+
+	scope := metrics.HistoryDescribeWorkflowExecutionsScope
+	h.metricsClient.IncCounter(scope, metrics.CadenceRequests)
+	sw := h.metricsClient.StartTimer(scope, metrics.CadenceLatency)
+	defer sw.Stop()
+
+	domainID := request.GetDomainUUID()
+	if domainID == "" {
+		return nil, h.error(errDomainNotSet, scope, domainID, "")
+	}
+
+	if ok, _ := h.rateLimiter.TryConsume(1); !ok {
+		return nil, h.error(errHistoryHostThrottle, scope, domainID, "")
+	}
+
+	executions := request.Request.GetExecutions()
+	if len(executions) == 0 {
+		return &gen.DescribeWorkflowExecutionsResponse{}, nil
+	}
+	workflowID := executions[0].GetWorkflowId()
+	shardID := h.config.GetShardID(workflowID)
+	for _, execution := range executions {
+		if h.config.GetShardID(execution.GetWorkflowId()) != shardID {
+			return nil, h.error(errExecutionsNotInShard, scope, domainID, execution.GetWorkflowId())
+		}
+	}
+
+	engine, err1 := h.controller.GetEngine(workflowID)
+	if err1 != nil {
+		return nil, h.error(err1, scope, domainID, workflowID)
+	}
+
+	resp, err2 := engine.DescribeWorkflowExecutions(ctx, request)
+	if err2 != nil {
+		return nil, h.error(err2, scope, domainID, workflowID)
+	}
+	return resp, nil
+}
+
 // RequestCancelWorkflowExecution - requests cancellation of a workflow
 func (h *Handler) RequestCancelWorkflowExecution(ctx context.Context,
 	request *hist.RequestCancelWorkflowExecutionRequest) (retError error) {
@@ -928,6 +999,41 @@ func (h *Handler) TerminateWorkflowExecution(ctx context.Context,
 	return nil
 }
 
+// UpdateWorkflowExecutionLabels upserts and removes labels of a running workflow execution
+func (h *Handler) UpdateWorkflowExecutionLabels(ctx context.Context,
+	wrappedRequest *hist.UpdateWorkflowExecutionLabelsRequest) (retError error) {
+	defer logging.CapturePanic(h.GetBarkLogger(), &retError)
+	h.startWG.Wait()
+
+	scope := metrics.HistoryUpdateWorkflowExecutionLabelsScope
+	h.metricsClient.IncCounter(scope, metrics.CadenceRequests)
+	sw := h.metricsClient.StartTimer(scope, metrics.CadenceLatency)
+	defer sw.Stop()
+
+	domainID := wrappedRequest.GetDomainUUID()
+	if domainID == "" {
+		return h.error(errDomainNotSet, scope, domainID, "")
+	}
+
+	if ok, _ := h.rateLimiter.TryConsume(1); !ok {
+		return h.error(errHistoryHostThrottle, scope, domainID, "")
+	}
+
+	workflowExecution := wrappedRequest.UpdateRequest.WorkflowExecution
+	workflowID := workflowExecution.GetWorkflowId()
+	engine, err1 := h.controller.GetEngine(workflowID)
+	if err1 != nil {
+		return h.error(err1, scope, domainID, workflowID)
+	}
+
+	err2 := engine.UpdateWorkflowExecutionLabels(ctx, wrappedRequest)
+	if err2 != nil {
+		return h.error(err2, scope, domainID, workflowID)
+	}
+
+	return nil
+}
+
 // ResetWorkflowExecution reset an existing workflow execution
 // in the history and immediately terminating the execution instance.
 func (h *Handler) ResetWorkflowExecution(ctx context.Context,
@@ -1151,6 +1257,40 @@ func (h *Handler) ReplicateRawEvents(ctx context.Context, replicateRequest *hist
 	return nil
 }
 
+// ReplicateEventsV2 is called by processor to replicate history events with version histories for passive domains
+func (h *Handler) ReplicateEventsV2(ctx context.Context, replicateRequest *hist.ReplicateEventsV2Request) (retError error) {
+	defer logging.CapturePanic(h.GetBarkLogger(), &retError)
+	h.startWG.Wait()
+
+	scope := metrics.HistoryReplicateEventsV2Scope
+	h.metricsClient.IncCounter(scope, metrics.CadenceRequests)
+	sw := h.metricsClient.StartTimer(scope, metrics.CadenceLatency)
+	defer sw.Stop()
+
+	domainID := replicateRequest.GetDomainUUID()
+	if domainID == "" {
+		return h.error(errDomainNotSet, scope, domainID, "")
+	}
+
+	if ok, _ := h.rateLimiter.TryConsume(1); !ok {
+		return h.error(errHistoryHostThrottle, scope, domainID, "")
+	}
+
+	workflowExecution := replicateRequest.WorkflowExecution
+	workflowID := workflowExecution.GetWorkflowId()
+	engine, err1 := h.controller.GetEngine(workflowID)
+	if err1 != nil {
+		return h.error(err1, scope, domainID, workflowID)
+	}
+
+	err2 := engine.ReplicateEventsV2(ctx, replicateRequest)
+	if err2 != nil {
+		return h.error(err2, scope, domainID, workflowID)
+	}
+
+	return nil
+}
+
 // SyncShardStatus is called by processor to sync history shard information from another cluster
 func (h *Handler) SyncShardStatus(ctx context.Context, syncShardStatusRequest *hist.SyncShardStatusRequest) (retError error) {
 	defer logging.CapturePanic(h.GetBarkLogger(), &retError)
@@ -1232,6 +1372,37 @@ func (h *Handler) SyncActivity(ctx context.Context, syncActivityRequest *hist.Sy
 	return nil
 }
 
+// GetReplicationTasks returns the replication tasks of a shard in the form they are published to remote clusters
+func (h *Handler) GetReplicationTasks(ctx context.Context, request *hist.GetReplicationTasksRequest) (resp *hist.GetReplicationTasksResponse, retError error) {
+	defer logging.CapturePanic(h.GetBarkLogger(), &retError)
+	h.startWG.Wait()
+
+	scope := metrics.HistoryGetReplicationTasksScope
+	h.metricsClient.IncCounter(scope, metrics.CadenceRequests)
+	sw := h.metricsClient.StartTimer(scope, metrics.CadenceLatency)
+	defer sw.Stop()
+
+	if ok, _ := h.rateLimiter.TryConsume(1); !ok {
+		return nil, h.error(errHistoryHostThrottle, scope, "", "")
+	}
+
+	if request.ShardId == nil {
+		return nil, h.error(errShardIDNotSet, scope, "", "")
+	}
+
+	engine, err := h.controller.getEngineForShard(int(request.GetShardId()))
+	if err != nil {
+		return nil, h.error(err, scope, "", "")
+	}
+
+	resp, err = engine.GetReplicationTasks(ctx, request)
+	if err != nil {
+		return nil, h.error(err, scope, "", "")
+	}
+
+	return resp, nil
+}
+
 // convertError is a helper method to convert ShardOwnershipLostError from persistence layer returned by various
 // HistoryEngine API calls to ShardOwnershipLost error return by HistoryService for client to be redirected to the
 // correct shard.
@@ -179,9 +179,9 @@ func (b *historyBuilder) AddTimeoutWorkflowEvent() *workflow.HistoryEvent {
 	return b.addEventToHistory(event)
 }
 
-func (b *historyBuilder) AddWorkflowExecutionTerminatedEvent(
+func (b *historyBuilder) AddWorkflowExecutionTerminatedEvent(cause workflow.TerminationCause,
 	request *workflow.TerminateWorkflowExecutionRequest) *workflow.HistoryEvent {
-	event := b.newWorkflowExecutionTerminatedEvent(request)
+	event := b.newWorkflowExecutionTerminatedEvent(cause, request)
 
 	return b.addEventToHistory(event)
 }
@@ -468,6 +468,8 @@ func (b *historyBuilder) newWorkflowExecutionStartedEvent(
 	attributes.ContinuedFailureDetails = startRequest.ContinuedFailureDetails
 	attributes.Initiator = startRequest.ContinueAsNewInitiator
 	attributes.FirstDecisionTaskBackoffSeconds = startRequest.FirstDecisionTaskBackoffSeconds
+	attributes.ConcurrencyGroup = request.ConcurrencyGroup
+	attributes.ConcurrencyLimit = request.ConcurrencyLimit
 
 	parentInfo := startRequest.ParentExecutionInfo
 	if parentInfo != nil {
@@ -655,13 +657,14 @@ func (b *historyBuilder) newWorkflowExecutionSignaledEvent(
 	return historyEvent
 }
 
-func (b *historyBuilder) newWorkflowExecutionTerminatedEvent(
+func (b *historyBuilder) newWorkflowExecutionTerminatedEvent(cause workflow.TerminationCause,
 	request *workflow.TerminateWorkflowExecutionRequest) *workflow.HistoryEvent {
 	historyEvent := b.msBuilder.CreateNewHistoryEvent(workflow.EventTypeWorkflowExecutionTerminated)
 	attributes := &workflow.WorkflowExecutionTerminatedEventAttributes{}
 	attributes.Reason = common.StringPtr(common.StringDefault(request.Reason))
 	attributes.Details = request.Details
 	attributes.Identity = common.StringPtr(common.StringDefault(request.Identity))
+	attributes.Cause = cause.Ptr()
 	historyEvent.WorkflowExecutionTerminatedEventAttributes = attributes
 
 	return historyEvent
@@ -41,7 +41,7 @@ type (
 	releaseWorkflowExecutionFunc func(err error)
 
 	historyCache struct {
-		cache.Cache
+		*shardScopedCache
 		shard            ShardContext
 		executionManager persistence.ExecutionManager
 		disabled         bool
@@ -49,6 +49,17 @@ type (
 		metricsClient    metrics.Client
 		config           *Config
 	}
+
+	// shardScopedCache scopes the entries of the host level mutable state cache to the history cache
+	// of a single shard engine, so contexts bound to a shard are never served after the shard moved
+	shardScopedCache struct {
+		cache.Cache
+	}
+
+	shardScopedCacheKey struct {
+		scope *shardScopedCache
+		key   interface{}
+	}
 )
 
 const (
@@ -56,6 +67,23 @@ const (
 	cacheReleased    int32 = 1
 )
 
+// newMutableStateCache creates the host level cache of workflow execution contexts shared by all the shards
+// of a host, it is bounded by the number of entries and the estimated bytes of mutable state
+func newMutableStateCache(config *Config, metricsClient metrics.Client) cache.Cache {
+	opts := &cache.Options{}
+	opts.InitialCapacity = config.HistoryCacheInitialSize()
+	opts.TTL = config.HistoryCacheTTL()
+	opts.Pin = true
+	opts.MaxBytes = func() int {
+		return config.HostLevelCacheMaxBytes()
+	}
+	opts.EvictedFunc = func(interface{}) {
+		metricsClient.IncCounter(metrics.HistoryCacheScope, metrics.CacheEvictionCounter)
+	}
+
+	return cache.New(config.HostLevelCacheMaxSize(), opts)
+}
+
 func newHistoryCache(shard ShardContext) *historyCache {
 	opts := &cache.Options{}
 	config := shard.GetConfig()
@@ -63,8 +91,13 @@ func newHistoryCache(shard ShardContext) *historyCache {
 	opts.TTL = config.HistoryCacheTTL()
 	opts.Pin = true
 
+	return newHistoryCacheWithMutableStateCache(shard, cache.New(config.HistoryCacheMaxSize(), opts))
+}
+
+func newHistoryCacheWithMutableStateCache(shard ShardContext, mutableStateCache cache.Cache) *historyCache {
+	config := shard.GetConfig()
 	return &historyCache{
-		Cache:            cache.New(config.HistoryCacheMaxSize(), opts),
+		shardScopedCache: &shardScopedCache{Cache: mutableStateCache},
 		shard:            shard,
 		executionManager: shard.GetExecutionManager(),
 		logger: shard.GetLogger().WithFields(bark.Fields{
@@ -129,6 +162,7 @@ func (c *historyCache) getAndCreateWorkflowExecutionWithTimeout(ctx context.Cont
 			return nil, nil, nil, false, err
 		}
 		releaseFunc = c.makeReleaseFunc(key, cacheNotReleased, contextFromCache)
+		c.metricsClient.IncCounter(metrics.HistoryCacheGetAndCreateScope, metrics.CacheHitCounter)
 	} else {
 		c.metricsClient.IncCounter(metrics.HistoryCacheGetAndCreateScope, metrics.CacheMissCounter)
 	}
@@ -157,7 +191,9 @@ func (c *historyCache) getOrCreateWorkflowExecutionWithTimeout(ctx context.Conte
 
 	key := definition.NewWorkflowIdentifier(domainID, execution.GetWorkflowId(), execution.GetRunId())
 	workflowCtx, cacheHit := c.Get(key).(workflowExecutionContext)
-	if !cacheHit {
+	if cacheHit {
+		c.metricsClient.IncCounter(metrics.HistoryCacheGetOrCreateScope, metrics.CacheHitCounter)
+	} else {
 		c.metricsClient.IncCounter(metrics.HistoryCacheGetOrCreateScope, metrics.CacheMissCounter)
 		// Let's create the workflow execution workflowCtx
 		workflowCtx = newWorkflowExecutionContext(domainID, execution, c.shard, c.executionManager, c.logger)
@@ -205,7 +241,7 @@ func (c *historyCache) getCurrentExecutionWithRetry(
 	var response *persistence.GetCurrentExecutionResponse
 	op := func() error {
 		var err error
-		response, err = c.executionManager.GetCurrentExecution(request)
+		response, err = c.executionManager.GetCurrentExecution(context.TODO(), request)
 
 		return err
 	}
@@ -218,3 +254,48 @@ func (c *historyCache) getCurrentExecutionWithRetry(
 
 	return response, nil
 }
+
+// Get retrieves the value stored under the given key of this scope
+func (c *shardScopedCache) Get(key interface{}) interface{} {
+	return c.Cache.Get(c.scopedKey(key))
+}
+
+// Put adds an element to this scope, returning the previous element
+func (c *shardScopedCache) Put(key interface{}, value interface{}) interface{} {
+	return c.Cache.Put(c.scopedKey(key), value)
+}
+
+// PutIfNotExist puts a value associated with a given key of this scope if it does not exist
+func (c *shardScopedCache) PutIfNotExist(key interface{}, value interface{}) (interface{}, error) {
+	return c.Cache.PutIfNotExist(c.scopedKey(key), value)
+}
+
+// Delete deletes an element of this scope
+func (c *shardScopedCache) Delete(key interface{}) {
+	c.Cache.Delete(c.scopedKey(key))
+}
+
+// Release decrements the ref count of a pinned element of this scope
+func (c *shardScopedCache) Release(key interface{}) {
+	c.Cache.Release(c.scopedKey(key))
+}
+
+// clear removes all the elements of this scope from the underlying cache
+func (c *shardScopedCache) clear() {
+	var keys []interface{}
+	it := c.Cache.Iterator()
+	for it.HasNext() {
+		if key, ok := it.Next().Key().(shardScopedCacheKey); ok && key.scope == c {
+			keys = append(keys, key)
+		}
+	}
+	it.Close()
+
+	for _, key := range keys {
+		c.Cache.Delete(key)
+	}
+}
+
+func (c *shardScopedCache) scopedKey(key interface{}) shardScopedCacheKey {
+	return shardScopedCacheKey{scope: c, key: key}
+}
@@ -206,6 +206,44 @@ func (s *historyCacheSuite) TestHistoryCacheClear() {
 	release(nil)
 }
 
+func (s *historyCacheSuite) TestHistoryCacheHostLevel() {
+	config := s.mockShard.GetConfig()
+	config.HostLevelCacheMaxSize = dynamicconfig.GetIntPropertyFn(20)
+	config.HostLevelCacheMaxBytes = dynamicconfig.GetIntPropertyFn(100)
+	mutableStateCache := newMutableStateCache(config, s.mockShard.GetMetricsClient())
+	s.cache = newHistoryCacheWithMutableStateCache(s.mockShard, mutableStateCache)
+	otherCache := newHistoryCacheWithMutableStateCache(s.mockShard, mutableStateCache)
+	domainID := "test_domain_id"
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wf-cache-test-host-level"),
+		RunId:      common.StringPtr(uuid.New()),
+	}
+
+	context, release, err := s.cache.getOrCreateWorkflowExecution(domainID, we)
+	s.Nil(err)
+	release(nil)
+
+	// the same workflow is a different entry for the cache of another shard engine
+	otherContext, otherRelease, err := otherCache.getOrCreateWorkflowExecution(domainID, we)
+	s.Nil(err)
+	s.False(context == otherContext)
+	otherRelease(nil)
+	s.Equal(2, mutableStateCache.Size())
+
+	// clear only removes the entries of its own shard engine
+	otherCache.clear()
+	s.Equal(1, mutableStateCache.Size())
+	newContext, release, err := s.cache.getOrCreateWorkflowExecution(domainID, we)
+	s.Nil(err)
+	s.True(context == newContext)
+
+	// the context grows past the max bytes while in use and is evicted once released
+	newContext.(*workflowExecutionContextImpl).stateSize = 200
+	newContext.(*workflowExecutionContextImpl).updateByteSize()
+	release(nil)
+	s.Equal(0, mutableStateCache.Size())
+}
+
 func (s *historyCacheSuite) TestHistoryCacheConcurrentAccess() {
 	s.mockShard.GetConfig().HistoryCacheMaxSize = dynamicconfig.GetIntPropertyFn(20)
 	domainID := "test_domain_id"
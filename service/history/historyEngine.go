@@ -37,6 +37,7 @@ import (
 	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/clock"
 	"github.com/uber/cadence/common/cluster"
+	"github.com/uber/cadence/common/codec"
 	"github.com/uber/cadence/common/cron"
 	"github.com/uber/cadence/common/definition"
 	ce "github.com/uber/cadence/common/errors"
@@ -45,6 +46,7 @@ import (
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/service/worker/archiver"
+	"github.com/uber/cadence/service/worker/concurrency"
 	"go.uber.org/cadence/.gen/go/cadence/workflowserviceclient"
 	"go.uber.org/cadence/.gen/go/shared"
 	"go.uber.org/yarpc"
@@ -55,6 +57,8 @@ const (
 	activityCancellationMsgActivityIDUnknown  = "ACTIVITY_ID_UNKNOWN"
 	activityCancellationMsgActivityNotStarted = "ACTIVITY_ID_NOT_STARTED"
 	timerCancellationMsgTimerIDUnknown        = "TIMER_ID_UNKNOWN"
+	// waitForCompletionDeadlineBuffer is reserved from the caller deadline to return the start response
+	waitForCompletionDeadlineBuffer = time.Second
 )
 
 type (
@@ -69,7 +73,7 @@ type (
 		timerProcessor       timerQueueProcessor
 		taskAllocator        taskAllocator
 		replicator           *historyReplicator
-		replicatorProcessor  queueProcessor
+		replicatorProcessor  replicatorQueueProcessor
 		historyEventNotifier historyEventNotifier
 		tokenSerializer      common.TaskTokenSerializer
 		historyCache         *historyCache
@@ -78,6 +82,7 @@ type (
 		throttledLogger      bark.Logger
 		config               *Config
 		archivalClient       archiver.Client
+		concurrencyClient    concurrency.Client
 		resetor              workflowResetor
 	}
 
@@ -125,6 +130,8 @@ var (
 	ErrSignalsLimitExceeded = &workflow.LimitExceededError{Message: "Exceeded workflow execution limit for signal events"}
 	// ErrEventsAterWorkflowFinish is the error indicating server error trying to write events after workflow finish event
 	ErrEventsAterWorkflowFinish = &shared.InternalServiceError{Message: "error validating last event being workflow finish event."}
+	// ErrReplicationNotEnabled is the error indicating the shard does not generate replication tasks
+	ErrReplicationNotEnabled = &workflow.BadRequestError{Message: "Replication is not enabled on this cluster."}
 
 	// FailedWorkflowCloseState is a set of failed workflow close states, used for start workflow policy
 	// for start workflow execution API
@@ -146,6 +153,7 @@ func NewEngineWithShardContext(
 	historyEventNotifier historyEventNotifier,
 	publisher messaging.Producer,
 	visibilityProducer messaging.Producer,
+	mutableStateCache cache.Cache,
 	config *Config,
 ) Engine {
 	currentClusterName := shard.GetService().GetClusterMetadata().GetCurrentClusterName()
@@ -159,7 +167,7 @@ func NewEngineWithShardContext(
 	executionManager := shard.GetExecutionManager()
 	historyManager := shard.GetHistoryManager()
 	historyV2Manager := shard.GetHistoryV2Manager()
-	historyCache := newHistoryCache(shard)
+	historyCache := newHistoryCacheWithMutableStateCache(shard, mutableStateCache)
 	historyEngImpl := &historyEngineImpl{
 		currentClusterName: currentClusterName,
 		shard:              shard,
@@ -179,6 +187,7 @@ func NewEngineWithShardContext(
 		historyEventNotifier: historyEventNotifier,
 		config:               config,
 		archivalClient:       archiver.NewClient(shard.GetMetricsClient(), shard.GetLogger(), publicClient, shard.GetConfig().NumArchiveSystemWorkflows),
+		concurrencyClient:    concurrency.NewClient(shard.GetLogger(), publicClient),
 	}
 
 	txProcessor := newTransferQueueProcessor(shard, historyEngImpl, visibilityMgr, visibilityProducer, matching, historyClient, logger)
@@ -225,6 +234,8 @@ func (e *historyEngineImpl) Stop() {
 	if e.replicatorProcessor != nil {
 		e.replicatorProcessor.Stop()
 	}
+	// the workflow contexts are bound to this shard, do not keep them in the host level cache
+	e.historyCache.clear()
 
 	// unset the failover callback
 	e.shard.GetDomainCache().UnregisterDomainChangeCallback(e.shard.GetShardID())
@@ -336,7 +347,7 @@ func (e *historyEngineImpl) createMutableState(clusterMetadata cluster.Metadata,
 }
 
 func (e *historyEngineImpl) generateFirstDecisionTask(domainID string, msBuilder mutableState, parentInfo *h.ParentExecutionInfo,
-	taskListName string, cronBackoffSeconds int32) ([]persistence.Task, *decisionInfo, error) {
+	taskListName string, deferFirstDecision bool) ([]persistence.Task, *decisionInfo, error) {
 	di := &decisionInfo{
 		TaskList:        taskListName,
 		Version:         common.EmptyVersion,
@@ -348,8 +359,8 @@ func (e *historyEngineImpl) generateFirstDecisionTask(domainID string, msBuilder
 	if parentInfo == nil {
 		// RecordWorkflowStartedTask is only created when it is not a Child Workflow
 		transferTasks = append(transferTasks, &persistence.RecordWorkflowStartedTask{})
-		if cronBackoffSeconds == 0 {
-			// DecisionTask is only created when it is not a Child Workflow and no backoff is needed
+		if !deferFirstDecision {
+			// DecisionTask is only created when it is not a Child Workflow and the first decision is not deferred
 			di = msBuilder.AddDecisionTaskScheduledEvent()
 			if di == nil {
 				return nil, nil, &workflow.InternalServiceError{Message: "Failed to add decision scheduled event."}
@@ -373,6 +384,9 @@ func (e *historyEngineImpl) appendFirstBatchHistoryEvents(msBuilder mutableState
 	events := msBuilder.GetHistoryBuilder().GetHistory().Events
 	startedEvent := events[0]
 	if msBuilder.GetEventStoreVersion() == persistence.EventStoreVersionV2 {
+		if err = msBuilder.UpdateCurrentVersionHistory(events); err != nil {
+			return 0, err
+		}
 		branchToken := msBuilder.GetCurrentBranch()
 		historySize, err = e.shard.AppendHistoryV2Events(&persistence.AppendHistoryNodesRequest{
 			IsNewBranch: true,
@@ -435,6 +449,15 @@ func generateFirstReplicationTask(msBuilder mutableState, clusterMetadata cluste
 func (e *historyEngineImpl) createWorkflow(startRequest *h.StartWorkflowExecutionRequest, msBuilder mutableState, createMode int, prevRunID string, prevLastWriteVersion int64,
 	firstDecisionTask *decisionInfo, transferTasks, timerTasks, replicationTasks []persistence.Task, clusterMetadata cluster.Metadata) (err error) {
 
+	createRequest := newCreateWorkflowExecutionRequest(startRequest, msBuilder, createMode, prevRunID, prevLastWriteVersion,
+		firstDecisionTask, transferTasks, timerTasks, replicationTasks)
+	_, err = e.shard.CreateWorkflowExecution(createRequest)
+	return err
+}
+
+func newCreateWorkflowExecutionRequest(startRequest *h.StartWorkflowExecutionRequest, msBuilder mutableState, createMode int, prevRunID string, prevLastWriteVersion int64,
+	firstDecisionTask *decisionInfo, transferTasks, timerTasks, replicationTasks []persistence.Task) *persistence.CreateWorkflowExecutionRequest {
+
 	request := startRequest.StartRequest
 	currExeInfo := msBuilder.GetExecutionInfo()
 	execution := workflow.WorkflowExecution{
@@ -481,6 +504,7 @@ func (e *historyEngineImpl) createWorkflow(startRequest *h.StartWorkflowExecutio
 		HasRetryPolicy:              request.RetryPolicy != nil,
 		EventStoreVersion:           msBuilder.GetEventStoreVersion(),
 		BranchToken:                 msBuilder.GetCurrentBranch(),
+		VersionHistories:            msBuilder.GetVersionHistories(),
 		CreateWorkflowMode:          createMode,
 		CronSchedule:                request.GetCronSchedule(),
 	}
@@ -499,12 +523,25 @@ func (e *historyEngineImpl) createWorkflow(startRequest *h.StartWorkflowExecutio
 		createRequest.ExpirationSeconds = request.RetryPolicy.GetExpirationIntervalInSeconds()
 	}
 
-	_, err = e.shard.CreateWorkflowExecution(createRequest)
-	return err
+	return createRequest
 }
 
 // StartWorkflowExecution starts a workflow execution
 func (e *historyEngineImpl) StartWorkflowExecution(ctx context.Context, startRequest *h.StartWorkflowExecutionRequest) (
+	*workflow.StartWorkflowExecutionResponse, error) {
+	resp, err := e.startWorkflowExecution(ctx, startRequest)
+	if err != nil || !startRequest.StartRequest.GetWaitForCompletion() {
+		return resp, err
+	}
+
+	execution := workflow.WorkflowExecution{
+		WorkflowId: startRequest.StartRequest.WorkflowId,
+		RunId:      resp.RunId,
+	}
+	return e.waitForWorkflowCompletion(ctx, startRequest.GetDomainUUID(), execution, resp)
+}
+
+func (e *historyEngineImpl) startWorkflowExecution(ctx context.Context, startRequest *h.StartWorkflowExecutionRequest) (
 	resp *workflow.StartWorkflowExecutionResponse, retError error) {
 	domainEntry, retError := e.getActiveDomainEntry(startRequest.DomainUUID)
 	if retError != nil {
@@ -544,8 +581,10 @@ func (e *historyEngineImpl) StartWorkflowExecution(ctx context.Context, startReq
 
 	taskList := request.TaskList.GetName()
 	cronBackoffSeconds := startRequest.GetFirstDecisionTaskBackoffSeconds()
-	// Generate first decision task event if not child WF and no first decision task backoff
-	transferTasks, firstDecisionTask, retError := e.generateFirstDecisionTask(domainID, msBuilder, startRequest.ParentExecutionInfo, taskList, cronBackoffSeconds)
+	// the first decision of a run in a concurrency group is scheduled when the group dispatches the run
+	deferFirstDecision := cronBackoffSeconds != 0 || request.GetConcurrencyGroup() != ""
+	// Generate first decision task event if not child WF and the first decision task is not deferred
+	transferTasks, firstDecisionTask, retError := e.generateFirstDecisionTask(domainID, msBuilder, startRequest.ParentExecutionInfo, taskList, deferFirstDecision)
 	if retError != nil {
 		return
 	}
@@ -569,6 +608,8 @@ func (e *historyEngineImpl) StartWorkflowExecution(ctx context.Context, startReq
 	replicationTasks := generateFirstReplicationTask(msBuilder, clusterMetadata, domainEntry)
 	// set versions and timestamp for timer and transfer tasks
 	setTaskInfo(msBuilder.GetCurrentVersion(), time.Now(), transferTasks, timerTasks)
+	requestID, callerIdentity := common.GetRequestInfo(ctx)
+	setTaskRequestInfo(requestID, callerIdentity, transferTasks)
 
 	historySize, retError := e.appendFirstBatchHistoryEvents(msBuilder, domainID, execution)
 	if retError != nil {
@@ -610,15 +651,21 @@ func (e *historyEngineImpl) StartWorkflowExecution(ctx context.Context, startReq
 				return
 			}
 
-			// create as ID reuse
-			createMode = persistence.CreateWorkflowModeWorkflowIDReuse
-			prevRunID = t.RunID
-			prevLastWriteVersion = t.LastWriteVersion
-			retError = e.applyWorkflowIDReusePolicyHelper(t.StartRequestID, prevRunID, t.State, t.CloseStatus, domainID, execution, startRequest.StartRequest.GetWorkflowIdReusePolicy())
-			if retError != nil {
-				return
+			if t.State != persistence.WorkflowStateCompleted &&
+				request.GetWorkflowIdReusePolicy() == workflow.WorkflowIdReusePolicyTerminateIfRunning {
+				retError = e.terminateAndStartWorkflow(ctx, domainID, t.RunID, startRequest, msBuilder,
+					firstDecisionTask, transferTasks, timerTasks, replicationTasks, clusterMetadata)
+			} else {
+				// create as ID reuse
+				createMode = persistence.CreateWorkflowModeWorkflowIDReuse
+				prevRunID = t.RunID
+				prevLastWriteVersion = t.LastWriteVersion
+				retError = e.applyWorkflowIDReusePolicyHelper(t.StartRequestID, prevRunID, t.State, t.CloseStatus, domainID, execution, startRequest.StartRequest.GetWorkflowIdReusePolicy())
+				if retError != nil {
+					return
+				}
+				retError = e.createWorkflow(startRequest, msBuilder, createMode, prevRunID, prevLastWriteVersion, firstDecisionTask, transferTasks, timerTasks, replicationTasks, clusterMetadata)
 			}
-			retError = e.createWorkflow(startRequest, msBuilder, createMode, prevRunID, prevLastWriteVersion, firstDecisionTask, transferTasks, timerTasks, replicationTasks, clusterMetadata)
 		}
 	}
 
@@ -632,6 +679,158 @@ func (e *historyEngineImpl) StartWorkflowExecution(ctx context.Context, startReq
 	return
 }
 
+// waitForWorkflowCompletion long polls until the workflow run closes or the caller deadline is about to expire,
+// and fills the close status and result of the run into the start response if it closed in time
+func (e *historyEngineImpl) waitForWorkflowCompletion(ctx context.Context, domainID string,
+	execution workflow.WorkflowExecution, resp *workflow.StartWorkflowExecutionResponse) (*workflow.StartWorkflowExecutionResponse, error) {
+
+	identifier := definition.NewWorkflowIdentifier(domainID, execution.GetWorkflowId(), execution.GetRunId())
+	subscriberID, channel, err := e.historyEventNotifier.WatchHistoryEvent(identifier)
+	if err != nil {
+		return nil, err
+	}
+	defer e.historyEventNotifier.UnwatchHistoryEvent(identifier, subscriberID)
+
+	// check after subscribing in case the run is already closed
+	closed, err := e.getWorkflowCompletion(ctx, domainID, execution, resp)
+	if err != nil || closed {
+		return resp, err
+	}
+
+	domainEntry, err := e.shard.GetDomainCache().GetDomainByID(domainID)
+	if err != nil {
+		return nil, err
+	}
+	timeout := e.config.LongPollExpirationInterval(domainEntry.GetInfo().Name)
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = deadline.Sub(time.Now()) - waitForCompletionDeadlineBuffer
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		select {
+		case event := <-channel:
+			if !event.isWorkflowRunning {
+				_, err := e.getWorkflowCompletion(ctx, domainID, execution, resp)
+				return resp, err
+			}
+		case <-timer.C:
+			return resp, nil
+		case <-ctx.Done():
+			return resp, nil
+		}
+	}
+}
+
+// getWorkflowCompletion fills the close status and result of the workflow run into the start response,
+// returns false if the run is still running
+func (e *historyEngineImpl) getWorkflowCompletion(ctx context.Context, domainID string,
+	execution workflow.WorkflowExecution, resp *workflow.StartWorkflowExecutionResponse) (closed bool, retError error) {
+
+	context, release, err0 := e.historyCache.getOrCreateWorkflowExecutionWithTimeout(ctx, domainID, execution)
+	if err0 != nil {
+		return false, err0
+	}
+	defer func() { release(retError) }()
+
+	msBuilder, err1 := context.loadWorkflowExecution()
+	if err1 != nil {
+		return false, err1
+	}
+	if msBuilder.IsWorkflowExecutionRunning() {
+		return false, nil
+	}
+
+	completionEvent, ok := msBuilder.GetCompletionEvent()
+	if !ok {
+		return false, &workflow.InternalServiceError{Message: "Unable to get workflow completion event."}
+	}
+	resp.CloseStatus = getWorkflowExecutionCloseStatus(msBuilder.GetExecutionInfo().CloseStatus).Ptr()
+	resp.Result = getWorkflowCompletionResult(completionEvent)
+	return true, nil
+}
+
+func getWorkflowCompletionResult(event *workflow.HistoryEvent) []byte {
+	switch event.GetEventType() {
+	case workflow.EventTypeWorkflowExecutionCompleted:
+		return event.WorkflowExecutionCompletedEventAttributes.Result
+	case workflow.EventTypeWorkflowExecutionFailed:
+		return event.WorkflowExecutionFailedEventAttributes.Details
+	case workflow.EventTypeWorkflowExecutionCanceled:
+		return event.WorkflowExecutionCanceledEventAttributes.Details
+	case workflow.EventTypeWorkflowExecutionTerminated:
+		return event.WorkflowExecutionTerminatedEventAttributes.Details
+	default:
+		return nil
+	}
+}
+
+// terminateAndStartWorkflow terminates the current run of the workflow and creates the new run within
+// the same persistence transaction, so no other start request can slip in between the two
+func (e *historyEngineImpl) terminateAndStartWorkflow(ctx context.Context, domainID string, prevRunID string,
+	startRequest *h.StartWorkflowExecutionRequest, msBuilder mutableState, firstDecisionTask *decisionInfo,
+	transferTasks, timerTasks, replicationTasks []persistence.Task, clusterMetadata cluster.Metadata) (retError error) {
+
+	prevExecution := workflow.WorkflowExecution{
+		WorkflowId: startRequest.StartRequest.WorkflowId,
+		RunId:      common.StringPtr(prevRunID),
+	}
+	context, release, err0 := e.historyCache.getOrCreateWorkflowExecutionWithTimeout(ctx, domainID, prevExecution)
+	if err0 != nil {
+		return err0
+	}
+	defer func() { release(retError) }()
+
+Update_History_Loop:
+	for attempt := 0; attempt < conditionalRetryCount; attempt++ {
+		prevMutableState, err1 := context.loadWorkflowExecution()
+		if err1 != nil {
+			return err1
+		}
+		if !prevMutableState.IsWorkflowExecutionRunning() {
+			// current run is closed in the meantime, start the new run as a regular ID reuse
+			return e.createWorkflow(startRequest, msBuilder, persistence.CreateWorkflowModeWorkflowIDReuse, prevRunID,
+				prevMutableState.GetLastWriteVersion(), firstDecisionTask, transferTasks, timerTasks, replicationTasks, clusterMetadata)
+		}
+
+		if err := failInFlightDecisionToClearBufferedEvents(prevMutableState); err != nil {
+			return err
+		}
+		if prevMutableState.AddWorkflowExecutionTerminatedEvent(workflow.TerminationCauseWorkflowIDReusePolicy, &workflow.TerminateWorkflowExecutionRequest{
+			Reason:   common.StringPtr(common.TerminateReasonWorkflowIDReusePolicy),
+			Identity: common.StringPtr(identityHistoryService),
+		}) == nil {
+			return &workflow.InternalServiceError{Message: "Unable to terminate workflow execution."}
+		}
+
+		tBuilder := e.getTimerBuilder(context.getExecution())
+		closeTask, deleteTask, err := e.getWorkflowHistoryCleanupTasks(domainID, prevExecution.GetWorkflowId(), tBuilder)
+		if err != nil {
+			return err
+		}
+
+		createRequest := newCreateWorkflowExecutionRequest(startRequest, msBuilder, persistence.CreateWorkflowModeContinueAsNew,
+			prevRunID, prevMutableState.GetLastWriteVersion(), firstDecisionTask, transferTasks, timerTasks, replicationTasks)
+		prevMutableState.SetNewRun(createRequest)
+
+		transactionID, err := e.shard.GetNextTransferTaskID()
+		if err != nil {
+			return err
+		}
+
+		prevTimerTasks := []persistence.Task{deleteTask}
+		if err := context.updateWorkflowExecutionWithNewRun([]persistence.Task{closeTask}, prevTimerTasks, transactionID, msBuilder); err != nil {
+			if err == ErrConflict {
+				continue Update_History_Loop
+			}
+			return err
+		}
+		e.timerProcessor.NotifyNewTimers(e.currentClusterName, e.shard.GetCurrentTime(e.currentClusterName), prevTimerTasks)
+		return nil
+	}
+	return ErrMaxAttemptsExceeded
+}
+
 // GetMutableState retrieves the mutable state of the workflow execution
 func (e *historyEngineImpl) GetMutableState(ctx context.Context,
 	request *h.GetMutableStateRequest) (*h.GetMutableStateResponse, error) {
@@ -842,6 +1041,63 @@ func (e *historyEngineImpl) DescribeWorkflowExecution(ctx context.Context,
 	if err1 != nil {
 		return nil, err1
 	}
+	return e.describeMutableState(msBuilder)
+}
+
+// DescribeWorkflowExecutions describes executions of the shard with their mutable states read in one persistence
+// round trip. The mutable states are read bypassing the history cache, so an update in flight may not be reflected.
+func (e *historyEngineImpl) DescribeWorkflowExecutions(ctx context.Context,
+	request *h.DescribeWorkflowExecutionsRequest) (*workflow.DescribeWorkflowExecutionsResponse, error) {
+	domainID, err := validateDomainUUID(request.DomainUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	executions := make([]workflow.WorkflowExecution, 0, len(request.Request.GetExecutions()))
+	for _, execution := range request.Request.GetExecutions() {
+		if execution.GetRunId() == "" {
+			response, err := e.historyCache.getCurrentExecutionWithRetry(&persistence.GetCurrentExecutionRequest{
+				DomainID:   domainID,
+				WorkflowID: execution.GetWorkflowId(),
+			})
+			if err != nil {
+				if _, ok := err.(*workflow.EntityNotExistsError); ok {
+					continue
+				}
+				return nil, err
+			}
+			execution = &workflow.WorkflowExecution{
+				WorkflowId: execution.WorkflowId,
+				RunId:      common.StringPtr(response.RunID),
+			}
+		} else if uuid.Parse(execution.GetRunId()) == nil {
+			return nil, errRunIDNotValid
+		}
+		executions = append(executions, *execution)
+	}
+
+	response, err := e.executionManager.GetWorkflowExecutions(ctx, &persistence.GetWorkflowExecutionsRequest{
+		DomainID:   domainID,
+		Executions: executions,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &workflow.DescribeWorkflowExecutionsResponse{}
+	for _, r := range response.Responses {
+		msBuilder := newMutableStateBuilder(e.currentClusterName, e.shard, e.shard.GetEventsCache(), e.logger)
+		msBuilder.Load(r.State)
+		describeResponse, err := e.describeMutableState(msBuilder)
+		if err != nil {
+			return nil, err
+		}
+		result.Executions = append(result.Executions, describeResponse)
+	}
+	return result, nil
+}
+
+func (e *historyEngineImpl) describeMutableState(msBuilder mutableState) (*workflow.DescribeWorkflowExecutionResponse, error) {
 	executionInfo := msBuilder.GetExecutionInfo()
 
 	result := &workflow.DescribeWorkflowExecutionResponse{
@@ -868,6 +1124,9 @@ func (e *historyEngineImpl) DescribeWorkflowExecution(ctx context.Context,
 		}
 		result.WorkflowExecutionInfo.ParentDomainId = common.StringPtr(executionInfo.ParentDomainID)
 	}
+	if len(executionInfo.Labels) > 0 {
+		result.WorkflowExecutionInfo.Labels = executionInfo.Labels
+	}
 	if executionInfo.State == persistence.WorkflowStateCompleted {
 		// for closed workflow
 		closeStatus := getWorkflowExecutionCloseStatus(executionInfo.CloseStatus)
@@ -914,10 +1173,49 @@ func (e *historyEngineImpl) DescribeWorkflowExecution(ctx context.Context,
 					p.MaximumAttempts = common.Int32Ptr(ai.MaximumAttempts)
 				}
 			}
+			if ai.LastFailureReason != "" {
+				p.LastFailureReason = common.StringPtr(ai.LastFailureReason)
+			}
+			if ai.StartedIdentity != "" {
+				p.LastWorkerIdentity = common.StringPtr(ai.StartedIdentity)
+			}
 			result.PendingActivities = append(result.PendingActivities, p)
 		}
 	}
 
+	for _, ci := range msBuilder.GetPendingChildExecutionInfos() {
+		p := &workflow.PendingChildExecutionInfo{
+			WorkflowTypName: common.StringPtr(ci.WorkflowTypeName),
+			InitiatedID:     common.Int64Ptr(ci.InitiatedID),
+			Domain:          common.StringPtr(ci.DomainName),
+		}
+		if ci.StartedID != common.EmptyEventID {
+			p.WorkflowID = common.StringPtr(ci.StartedWorkflowID)
+			p.RunID = common.StringPtr(ci.StartedRunID)
+		} else if ci.InitiatedEvent != nil {
+			attributes := ci.InitiatedEvent.StartChildWorkflowExecutionInitiatedEventAttributes
+			p.WorkflowID = common.StringPtr(attributes.GetWorkflowId())
+		}
+		result.PendingChildren = append(result.PendingChildren, p)
+	}
+
+	if msBuilder.HasPendingDecisionTask() {
+		di, ok := msBuilder.GetPendingDecision(executionInfo.DecisionScheduleID)
+		if !ok {
+			return nil, &workflow.InternalServiceError{Message: "Unable to get pending decision."}
+		}
+		pendingDecision := &workflow.PendingDecisionInfo{
+			State:            workflow.PendingDecisionStateScheduled.Ptr(),
+			ScheduledEventId: common.Int64Ptr(di.ScheduleID),
+			Attempt:          common.Int64Ptr(di.Attempt),
+		}
+		if di.StartedID != common.EmptyEventID {
+			pendingDecision.State = workflow.PendingDecisionStateStarted.Ptr()
+			pendingDecision.StartedTimestamp = common.Int64Ptr(di.Timestamp)
+		}
+		result.PendingDecision = pendingDecision
+	}
+
 	return result, nil
 }
 
@@ -1126,6 +1424,10 @@ type decisionBlobSizeChecker struct {
 	logger         bark.Logger
 	msBuilder      mutableState
 	completedID    int64
+
+	historySizeLimitError         int
+	localActivityMarkersSizeLimit int
+	localActivityMarkersSize      int
 }
 
 func (c *decisionBlobSizeChecker) failWorkflowIfBlobSizeExceedsLimit(blob []byte, message string) (bool, error) {
@@ -1143,13 +1445,53 @@ func (c *decisionBlobSizeChecker) failWorkflowIfBlobSizeExceedsLimit(blob []byte
 		return false, nil
 	}
 
-	err = failInFlightDecisionToClearBufferedEvents(c.msBuilder)
+	return c.failWorkflow(common.FailureReasonDecisionBlobSizeExceedsLimit, message)
+}
+
+// failWorkflowIfLocalActivityMarkersExceedLimit accounts the local activity markers recorded by the decision,
+// and fails the workflow if they exceed the per decision limit or would push the history past its size limit
+func (c *decisionBlobSizeChecker) failWorkflowIfLocalActivityMarkersExceedLimit(
+	attributes *workflow.RecordMarkerDecisionAttributes,
+) (bool, error) {
+	if attributes.GetMarkerName() != common.LocalActivityMarkerName {
+		return false, nil
+	}
+
+	c.localActivityMarkersSize += len(attributes.Details)
+	for _, value := range attributes.Header.GetFields() {
+		c.localActivityMarkersSize += len(value)
+	}
+	scope := c.metricsClient.Scope(metrics.HistoryRespondDecisionTaskCompletedScope)
+	scope.IncCounter(metrics.DecisionTypeLocalActivityMarkerCounter)
+	scope.RecordTimer(metrics.LocalActivityMarkersSize, time.Duration(c.localActivityMarkersSize))
+
+	historySize := int(c.msBuilder.GetHistorySize()) + c.localActivityMarkersSize
+	if c.localActivityMarkersSize <= c.localActivityMarkersSizeLimit && historySize <= c.historySizeLimitError {
+		return false, nil
+	}
+
+	c.logger.WithFields(bark.Fields{
+		logging.TagDomainID:            c.domainID,
+		logging.TagWorkflowExecutionID: c.workflowID,
+		logging.TagWorkflowRunID:       c.runID,
+		logging.TagSize:                c.localActivityMarkersSize,
+		logging.TagHistorySize:         historySize,
+	}).Error("Local activity markers exceed size limit.")
+
+	return c.failWorkflow(
+		common.FailureReasonLocalActivityMarkersExceedsLimit,
+		"RecordMarkerDecisionAttributes of local activities exceed size limit.",
+	)
+}
+
+func (c *decisionBlobSizeChecker) failWorkflow(reason string, message string) (bool, error) {
+	err := failInFlightDecisionToClearBufferedEvents(c.msBuilder)
 	if err != nil {
 		return false, err
 	}
 
 	attributes := &workflow.FailWorkflowExecutionDecisionAttributes{
-		Reason:  common.StringPtr(common.FailureReasonDecisionBlobSizeExceedsLimit),
+		Reason:  common.StringPtr(reason),
 		Details: []byte(message),
 	}
 
@@ -1226,6 +1568,9 @@ func (e *historyEngineImpl) RespondDecisionTaskCompleted(
 		runID:          token.RunID,
 		metricsClient:  e.metricsClient,
 		logger:         e.throttledLogger,
+
+		historySizeLimitError:         e.config.HistorySizeLimitError(domainEntry.GetInfo().Name),
+		localActivityMarkersSizeLimit: e.config.LocalActivityMarkersSizeLimit(domainEntry.GetInfo().Name),
 	}
 
 Update_History_Loop:
@@ -1293,6 +1638,7 @@ Update_History_Loop:
 
 		sizeChecker.completedID = completedID
 		sizeChecker.msBuilder = msBuilder
+		sizeChecker.localActivityMarkersSize = 0
 
 	Process_Decision_Loop:
 		for _, d := range request.Decisions {
@@ -1312,12 +1658,31 @@ Update_History_Loop:
 					targetDomainID = domainEntry.GetInfo().ID
 				}
 
+				targetDomainName := domainEntry.GetInfo().Name
+				if attributes.Domain != nil {
+					targetDomainName = attributes.GetDomain()
+				}
+				if attributes.HeartbeatTimeoutSeconds == nil {
+					if timeout := e.config.DefaultActivityHeartbeatTimeout(targetDomainName); timeout > 0 {
+						attributes.HeartbeatTimeoutSeconds = common.Int32Ptr(int32(timeout / time.Second))
+					}
+				}
+
 				if err = validateActivityScheduleAttributes(attributes, executionInfo.WorkflowTimeout, maxIDLengthLimit); err != nil {
 					failDecision = true
 					failCause = workflow.DecisionTaskFailedCauseBadScheduleActivityAttributes
 					failMessage = err.Error()
 					break Process_Decision_Loop
 				}
+				if limit := e.config.MaximumPendingActivitiesPerExecution(domainEntry.GetInfo().Name); limit > 0 &&
+					len(msBuilder.GetPendingActivityInfos()) >= limit {
+					e.metricsClient.IncCounter(metrics.HistoryRespondDecisionTaskCompletedScope,
+						metrics.PendingActivitiesLimitExceededCounter)
+					failDecision = true
+					failCause = workflow.DecisionTaskFailedCausePendingActivitiesLimitExceeded
+					failMessage = fmt.Sprintf("Exceeded workflow execution limit of %v pending activities.", limit)
+					break Process_Decision_Loop
+				}
 
 				failWorkflow, err := sizeChecker.failWorkflowIfBlobSizeExceedsLimit(attributes.Input, "ScheduleActivityTaskDecisionAttributes.Input exceeds size limit.")
 				if err != nil {
@@ -1590,6 +1955,13 @@ Update_History_Loop:
 				if failWorkflow {
 					break Process_Decision_Loop
 				}
+				failWorkflow, err = sizeChecker.failWorkflowIfLocalActivityMarkersExceedLimit(attributes)
+				if err != nil {
+					return nil, err
+				}
+				if failWorkflow {
+					break Process_Decision_Loop
+				}
 
 				msBuilder.AddRecordMarkerEvent(completedID, attributes)
 
@@ -1738,6 +2110,15 @@ Update_History_Loop:
 					failMessage = err.Error()
 					break Process_Decision_Loop
 				}
+				if limit := e.config.MaximumPendingChildWorkflowsPerExecution(domainEntry.GetInfo().Name); limit > 0 &&
+					len(msBuilder.GetPendingChildExecutionInfos()) >= limit {
+					e.metricsClient.IncCounter(metrics.HistoryRespondDecisionTaskCompletedScope,
+						metrics.PendingChildWorkflowsLimitExceededCounter)
+					failDecision = true
+					failCause = workflow.DecisionTaskFailedCausePendingChildWorkflowsLimitExceeded
+					failMessage = fmt.Sprintf("Exceeded workflow execution limit of %v pending child workflows.", limit)
+					break Process_Decision_Loop
+				}
 				failWorkflow, err := sizeChecker.failWorkflowIfBlobSizeExceedsLimit(attributes.Input, "StartChildWorkflowExecutionDecisionAttributes.Input exceeds size limit.")
 				if err != nil {
 					return nil, err
@@ -2130,9 +2511,10 @@ func (e *historyEngineImpl) RecordActivityTaskHeartbeat(ctx context.Context,
 		RunId:      common.StringPtr(token.RunID),
 	}
 
+	heartbeatMinInterval := e.config.ActivityHeartbeatMinInterval(domainEntry.GetInfo().Name)
 	var cancelRequested bool
-	err = e.updateWorkflowExecution(ctx, domainID, workflowExecution, false, false,
-		func(msBuilder mutableState, tBuilder *timerBuilder) ([]persistence.Task, error) {
+	err = e.updateWorkflowExecutionWithAction(ctx, domainID, workflowExecution,
+		func(msBuilder mutableState, tBuilder *timerBuilder) (*updateWorkflowAction, error) {
 			if !msBuilder.IsWorkflowExecutionRunning() {
 				e.logger.Debug("Heartbeat failed")
 				return nil, ErrWorkflowCompleted
@@ -2166,10 +2548,19 @@ func (e *historyEngineImpl) RecordActivityTaskHeartbeat(ctx context.Context,
 			e.logger.Debugf("Activity HeartBeat: scheduleEventID: %v, ActivityInfo: %+v, CancelRequested: %v",
 				scheduleID, ai, cancelRequested)
 
+			if shouldCoalesceActivityHeartbeat(ai, heartbeatMinInterval, time.Now()) {
+				// Keep the progress in memory only, it is saved by the next heartbeat after the min interval,
+				// or by any other update of the workflow execution before that.
+				msBuilder.CoalesceActivityProgress(ai, request)
+				e.metricsClient.IncCounter(metrics.HistoryRecordActivityTaskHeartbeatScope,
+					metrics.ActivityHeartbeatCoalescedCounter)
+				return &updateWorkflowAction{noop: true}, nil
+			}
+
 			// Save progress and last HB reported time.
 			msBuilder.UpdateActivityProgress(ai, request)
 
-			return nil, nil
+			return &updateWorkflowAction{}, nil
 		})
 
 	if err != nil {
@@ -2197,6 +2588,13 @@ func (e *historyEngineImpl) RequestCancelWorkflowExecution(ctx context.Context,
 		RunId:      request.WorkflowExecution.RunId,
 	}
 
+	if request.DeliveryTimestamp != nil {
+		deliveryTime := time.Unix(0, request.GetDeliveryTimestamp())
+		if deliveryTime.After(e.shard.GetCurrentTime(e.currentClusterName)) {
+			return e.scheduleDelayedSignal(ctx, domainID, execution, request, deliveryTime)
+		}
+	}
+
 	return e.updateWorkflowExecution(ctx, domainID, execution, false, true,
 		func(msBuilder mutableState, tBuilder *timerBuilder) ([]persistence.Task, error) {
 			if !msBuilder.IsWorkflowExecutionRunning() {
@@ -2278,12 +2676,9 @@ func (e *historyEngineImpl) SignalWorkflowExecution(ctx context.Context, signalR
 				}
 			}
 
-			// deduplicate by request id for signal decision
-			if requestID := request.GetRequestId(); requestID != "" {
-				if msBuilder.IsSignalRequested(requestID) {
-					return nil, nil
-				}
-				msBuilder.AddSignalRequested(requestID)
+			// deduplicate by request id for signal decision and client retries
+			if e.isDuplicateSignal(msBuilder, domainEntry.GetInfo().Name, request.GetRequestId()) {
+				return nil, nil
 			}
 
 			if msBuilder.AddWorkflowExecutionSignaled(request.GetSignalName(), request.GetInput(), request.GetIdentity()) == nil {
@@ -2294,6 +2689,54 @@ func (e *historyEngineImpl) SignalWorkflowExecution(ctx context.Context, signalR
 		})
 }
 
+// scheduleDelayedSignal holds the signal in the timer queue of the workflow, the signal event is appended by the
+// timer queue processor once the delivery time is reached. The request id is only recorded when the signal is
+// delivered, so a retried request creates another timer whose delivery is dropped as a duplicate.
+func (e *historyEngineImpl) scheduleDelayedSignal(ctx context.Context, domainID string, execution workflow.WorkflowExecution,
+	request *workflow.SignalWorkflowExecutionRequest, deliveryTime time.Time) error {
+
+	return e.updateWorkflowExecutionWithAction(ctx, domainID, execution,
+		func(msBuilder mutableState, tBuilder *timerBuilder) (*updateWorkflowAction, error) {
+			if !msBuilder.IsWorkflowExecutionRunning() {
+				return nil, ErrWorkflowCompleted
+			}
+
+			return &updateWorkflowAction{
+				timerTasks: []persistence.Task{&persistence.DelayedSignalTask{
+					VisibilityTimestamp: deliveryTime,
+					SignalName:          request.GetSignalName(),
+					Input:               request.Input,
+					Identity:            request.GetIdentity(),
+					RequestID:           request.GetRequestId(),
+				}},
+			}, nil
+		})
+}
+
+// isDuplicateSignal returns true if a signal with the same request id was already applied to the workflow,
+// otherwise the request id is recorded in mutable state so that a retry of the signal is dropped. The number
+// of recorded request ids is bounded by MaximumSignalRequestIDsPerExecution, when it is reached an arbitrary
+// older request id is evicted to make room for the new one.
+func (e *historyEngineImpl) isDuplicateSignal(msBuilder mutableState, domainName string, requestID string) bool {
+	if requestID == "" {
+		return false
+	}
+	if msBuilder.IsSignalRequested(requestID) {
+		return true
+	}
+
+	maxRequestIDs := e.config.MaximumSignalRequestIDsPerExecution(domainName)
+	if pendingRequestIDs := msBuilder.GetPendingSignalRequestedIDs(); maxRequestIDs > 0 && len(pendingRequestIDs) >= maxRequestIDs {
+		// only one request id can be deleted by an update of mutable state
+		for id := range pendingRequestIDs {
+			msBuilder.DeleteSignalRequested(id)
+			break
+		}
+	}
+	msBuilder.AddSignalRequested(requestID)
+	return false
+}
+
 func (e *historyEngineImpl) SignalWithStartWorkflowExecution(ctx context.Context, signalWithStartRequest *h.SignalWithStartWorkflowExecutionRequest) (
 	retResp *workflow.StartWorkflowExecutionResponse, retError error) {
 
@@ -2343,6 +2786,10 @@ func (e *historyEngineImpl) SignalWithStartWorkflowExecution(ctx context.Context
 				return nil, ErrSignalsLimitExceeded
 			}
 
+			if e.isDuplicateSignal(msBuilder, domainEntry.GetInfo().Name, sRequest.GetRequestId()) {
+				return &workflow.StartWorkflowExecutionResponse{RunId: context.getExecution().RunId}, nil
+			}
+
 			if msBuilder.AddWorkflowExecutionSignaled(sRequest.GetSignalName(), sRequest.GetSignalInput(), sRequest.GetIdentity()) == nil {
 				return nil, &workflow.InternalServiceError{Message: "Unable to signal workflow execution."}
 			}
@@ -2470,6 +2917,8 @@ func (e *historyEngineImpl) SignalWithStartWorkflowExecution(ctx context.Context
 	replicationTasks := generateFirstReplicationTask(msBuilder, clusterMetadata, domainEntry)
 	// set versions and timestamp for timer and transfer tasks
 	setTaskInfo(msBuilder.GetCurrentVersion(), time.Now(), transferTasks, timerTasks)
+	requestID, callerIdentity := common.GetRequestInfo(ctx)
+	setTaskRequestInfo(requestID, callerIdentity, transferTasks)
 
 	historySize, retError := e.appendFirstBatchHistoryEvents(msBuilder, domainID, execution)
 	if retError != nil {
@@ -2567,7 +3016,7 @@ func (e *historyEngineImpl) TerminateWorkflowExecution(ctx context.Context, term
 				return nil, err
 			}
 
-			if msBuilder.AddWorkflowExecutionTerminatedEvent(request) == nil {
+			if msBuilder.AddWorkflowExecutionTerminatedEvent(workflow.TerminationCauseUserRequest, request) == nil {
 				return nil, &workflow.InternalServiceError{Message: "Unable to terminate workflow execution."}
 			}
 
@@ -2575,6 +3024,37 @@ func (e *historyEngineImpl) TerminateWorkflowExecution(ctx context.Context, term
 		})
 }
 
+// UpdateWorkflowExecutionLabels upserts and removes labels of a running workflow execution, and creates a transfer
+// task to index the updated labels in the visibility store
+func (e *historyEngineImpl) UpdateWorkflowExecutionLabels(ctx context.Context,
+	updateRequest *h.UpdateWorkflowExecutionLabelsRequest) error {
+
+	domainEntry, err := e.getActiveDomainEntry(updateRequest.DomainUUID)
+	if err != nil {
+		return err
+	}
+	domainID := domainEntry.GetInfo().ID
+
+	request := updateRequest.UpdateRequest
+	execution := workflow.WorkflowExecution{
+		WorkflowId: request.WorkflowExecution.WorkflowId,
+		RunId:      request.WorkflowExecution.RunId,
+	}
+
+	return e.updateWorkflowExecutionWithAction(ctx, domainID, execution,
+		func(msBuilder mutableState, tBuilder *timerBuilder) (*updateWorkflowAction, error) {
+			if !msBuilder.IsWorkflowExecutionRunning() {
+				return nil, ErrWorkflowCompleted
+			}
+
+			msBuilder.UpdateLabels(request.UpsertLabels, request.RemoveLabels)
+
+			return &updateWorkflowAction{
+				transferTasks: []persistence.Task{&persistence.UpsertWorkflowLabelsTask{}},
+			}, nil
+		})
+}
+
 // ScheduleDecisionTask schedules a decision if no outstanding decision found
 func (e *historyEngineImpl) ScheduleDecisionTask(ctx context.Context, scheduleRequest *h.ScheduleDecisionTaskRequest) error {
 
@@ -2673,6 +3153,10 @@ func (e *historyEngineImpl) ReplicateRawEvents(ctx context.Context, replicateReq
 	return e.replicator.ApplyRawEvents(ctx, replicateRequest)
 }
 
+func (e *historyEngineImpl) ReplicateEventsV2(ctx context.Context, replicateRequest *h.ReplicateEventsV2Request) error {
+	return e.replicator.ApplyEventsV2(ctx, replicateRequest)
+}
+
 func (e *historyEngineImpl) SyncShardStatus(ctx context.Context, request *h.SyncShardStatusRequest) error {
 	clusterName := request.GetSourceCluster()
 	now := time.Unix(0, request.GetTimestamp())
@@ -2691,18 +3175,65 @@ func (e *historyEngineImpl) SyncActivity(ctx context.Context, request *h.SyncAct
 	return e.replicator.SyncActivity(ctx, request)
 }
 
+// GetReplicationTasks returns a page of the replication tasks of the shard in the form they are published to remote
+// clusters, encoded with thriftrw. Replication tasks are deleted once published, so only the tasks which are not
+// replicated yet are returned.
+func (e *historyEngineImpl) GetReplicationTasks(ctx context.Context, request *h.GetReplicationTasksRequest) (*h.GetReplicationTasksResponse, error) {
+	if e.replicatorProcessor == nil {
+		return nil, ErrReplicationNotEnabled
+	}
+
+	// tasks above the transfer max read level may belong to transactions which are not committed yet
+	maxReadLevel := e.shard.GetTransferMaxReadLevel()
+	if request.MaxReadLevel != nil && request.GetMaxReadLevel() < maxReadLevel {
+		maxReadLevel = request.GetMaxReadLevel()
+	}
+	pageSize := int(request.GetMaximumPageSize())
+	if pageSize <= 0 || pageSize > e.config.ReplicatorTaskBatchSize() {
+		pageSize = e.config.ReplicatorTaskBatchSize()
+	}
+
+	tasks, nextPageToken, err := e.replicatorProcessor.getTasks(request.GetReadLevel(), maxReadLevel, pageSize, request.NextPageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	encoder := codec.NewThriftRWEncoder()
+	response := &h.GetReplicationTasksResponse{
+		Tasks:         make([]*workflow.DataBlob, 0, len(tasks)),
+		NextPageToken: nextPageToken,
+	}
+	for _, task := range tasks {
+		data, err := encoder.Encode(task)
+		if err != nil {
+			return nil, err
+		}
+		response.Tasks = append(response.Tasks, &workflow.DataBlob{
+			EncodingType: workflow.EncodingTypeThriftRW.Ptr(),
+			Data:         data,
+		})
+	}
+	return response, nil
+}
+
 func (e *historyEngineImpl) ResetWorkflowExecution(ctx context.Context, resetRequest *h.ResetWorkflowExecutionRequest) (response *workflow.ResetWorkflowExecutionResponse, retError error) {
 	return e.resetor.ResetWorkflowExecution(ctx, resetRequest)
 }
 
 func (e *historyEngineImpl) DeleteExecutionFromVisibility(domainID string, runID string) error {
-	return e.visibilityMgr.DeleteWorkflowExecution(&persistence.VisibilityDeleteWorkflowExecutionRequest{
+	return e.visibilityMgr.DeleteWorkflowExecution(context.TODO(), &persistence.VisibilityDeleteWorkflowExecutionRequest{
 		DomainID: domainID,
 		RunID:    runID,
 	})
 }
 
+func (e *historyEngineImpl) RecordExecutionTombstoneToVisibility(request *persistence.RecordWorkflowExecutionClosedRequest) error {
+	return e.visibilityMgr.RecordWorkflowExecutionClosed(context.TODO(), request)
+}
+
 type updateWorkflowAction struct {
+	// noop skips the update, the action only changed in memory state which is fine to lose
+	noop           bool
 	deleteWorkflow bool
 	createDecision bool
 	timerTasks     []persistence.Task
@@ -2738,6 +3269,9 @@ Update_History_Loop:
 			// Returned error back to the caller
 			return err
 		}
+		if postActions.noop {
+			return nil
+		}
 
 		transferTasks, timerTasks := postActions.transferTasks, postActions.timerTasks
 		if postActions.deleteWorkflow {
@@ -3045,6 +3579,18 @@ func validateActivityScheduleAttributes(attributes *workflow.ScheduleActivityTas
 	return nil
 }
 
+// shouldCoalesceActivityHeartbeat returns true if the heartbeat is received within the min interval since the
+// last saved heartbeat, the interval is capped at half of the heartbeat timeout so the activity never times out
+// because of coalesced heartbeats
+func shouldCoalesceActivityHeartbeat(ai *persistence.ActivityInfo, minInterval time.Duration, now time.Time) bool {
+	if ai.HeartbeatTimeout > 0 {
+		if maxInterval := time.Duration(ai.HeartbeatTimeout) * time.Second / 2; minInterval > maxInterval {
+			minInterval = maxInterval
+		}
+	}
+	return minInterval > 0 && now.Sub(ai.LastHeartBeatUpdatedTime) < minInterval
+}
+
 func validateTimerScheduleAttributes(attributes *workflow.StartTimerDecisionAttributes, maxIDLengthLimit int) error {
 	if attributes == nil {
 		return &workflow.BadRequestError{Message: "StartTimerDecisionAttributes is not set on decision."}
@@ -3297,6 +3843,17 @@ func validateStartWorkflowExecutionRequest(request *workflow.StartWorkflowExecut
 	if len(request.WorkflowType.GetName()) > maxIDLengthLimit {
 		return &workflow.BadRequestError{Message: "WorkflowType exceeds length limit."}
 	}
+	if request.GetConcurrencyGroup() != "" {
+		if len(request.GetConcurrencyGroup()) > maxIDLengthLimit {
+			return &workflow.BadRequestError{Message: "ConcurrencyGroup exceeds length limit."}
+		}
+		if request.GetConcurrencyLimit() <= 0 {
+			return &workflow.BadRequestError{Message: "Missing or invalid ConcurrencyLimit."}
+		}
+		if request.GetCronSchedule() != "" {
+			return &workflow.BadRequestError{Message: "ConcurrencyGroup cannot be used with CronSchedule."}
+		}
+	}
 
 	return common.ValidateRetryPolicy(request.RetryPolicy)
 }
@@ -3424,6 +3981,24 @@ func setTaskInfo(version int64, timestamp time.Time, transferTasks []persistence
 	}
 }
 
+// setTaskRequestInfo stamps the request ID and caller identity on the visibility transfer tasks, so the
+// request which generated them can be traced through the visibility store and kafka
+func setTaskRequestInfo(requestID, callerIdentity string, transferTasks []persistence.Task) {
+	for _, task := range transferTasks {
+		switch t := task.(type) {
+		case *persistence.RecordWorkflowStartedTask:
+			t.RequestID = requestID
+			t.CallerIdentity = callerIdentity
+		case *persistence.CloseExecutionTask:
+			t.RequestID = requestID
+			t.CallerIdentity = callerIdentity
+		case *persistence.UpsertWorkflowLabelsTask:
+			t.RequestID = requestID
+			t.CallerIdentity = callerIdentity
+		}
+	}
+}
+
 // for startWorkflowExecution & signalWithStart to handle workflow reuse policy
 func (e *historyEngineImpl) applyWorkflowIDReusePolicyForSigWithStart(prevExecutionInfo *persistence.WorkflowExecutionInfo,
 	domainID string, execution workflow.WorkflowExecution, wfIDReusePolicy workflow.WorkflowIdReusePolicy) error {
@@ -3452,7 +4027,7 @@ func (e *historyEngineImpl) applyWorkflowIDReusePolicyHelper(prevStartRequestID,
 			msg := "Workflow execution already finished successfully. WorkflowId: %v, RunId: %v. Workflow ID reuse policy: allow duplicate workflow ID if last run failed."
 			return getWorkflowAlreadyStartedError(msg, prevStartRequestID, execution.GetWorkflowId(), prevRunID)
 		}
-	case workflow.WorkflowIdReusePolicyAllowDuplicate:
+	case workflow.WorkflowIdReusePolicyAllowDuplicate, workflow.WorkflowIdReusePolicyTerminateIfRunning:
 		// as long as workflow not running, so this case has no check
 	case workflow.WorkflowIdReusePolicyRejectDuplicate:
 		msg := "Workflow execution already finished. WorkflowId: %v, RunId: %v. Workflow ID reuse policy: reject duplicate workflow ID."
@@ -25,6 +25,7 @@ import (
 	"time"
 
 	h "github.com/uber/cadence/.gen/go/history"
+	"github.com/uber/cadence/.gen/go/replicator"
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/definition"
@@ -52,6 +53,8 @@ type (
 		ResetStickyTaskList(ctx context.Context, resetRequest *h.ResetStickyTaskListRequest) (*h.ResetStickyTaskListResponse, error)
 		DescribeWorkflowExecution(ctx context.Context,
 			request *h.DescribeWorkflowExecutionRequest) (*workflow.DescribeWorkflowExecutionResponse, error)
+		DescribeWorkflowExecutions(ctx context.Context,
+			request *h.DescribeWorkflowExecutionsRequest) (*workflow.DescribeWorkflowExecutionsResponse, error)
 		RecordDecisionTaskStarted(ctx context.Context, request *h.RecordDecisionTaskStartedRequest) (*h.RecordDecisionTaskStartedResponse, error)
 		RecordActivityTaskStarted(ctx context.Context, request *h.RecordActivityTaskStartedRequest) (*h.RecordActivityTaskStartedResponse, error)
 		RespondDecisionTaskCompleted(ctx context.Context, request *h.RespondDecisionTaskCompletedRequest) (*h.RespondDecisionTaskCompletedResponse, error)
@@ -66,13 +69,16 @@ type (
 			*workflow.StartWorkflowExecutionResponse, error)
 		RemoveSignalMutableState(ctx context.Context, request *h.RemoveSignalMutableStateRequest) error
 		TerminateWorkflowExecution(ctx context.Context, request *h.TerminateWorkflowExecutionRequest) error
+		UpdateWorkflowExecutionLabels(ctx context.Context, request *h.UpdateWorkflowExecutionLabelsRequest) error
 		ResetWorkflowExecution(ctx context.Context, request *h.ResetWorkflowExecutionRequest) (*workflow.ResetWorkflowExecutionResponse, error)
 		ScheduleDecisionTask(ctx context.Context, request *h.ScheduleDecisionTaskRequest) error
 		RecordChildExecutionCompleted(ctx context.Context, request *h.RecordChildExecutionCompletedRequest) error
 		ReplicateEvents(ctx context.Context, request *h.ReplicateEventsRequest) error
 		ReplicateRawEvents(ctx context.Context, request *h.ReplicateRawEventsRequest) error
+		ReplicateEventsV2(ctx context.Context, request *h.ReplicateEventsV2Request) error
 		SyncShardStatus(ctx context.Context, request *h.SyncShardStatusRequest) error
 		SyncActivity(ctx context.Context, request *h.SyncActivityRequest) error
+		GetReplicationTasks(ctx context.Context, request *h.GetReplicationTasksRequest) (*h.GetReplicationTasksResponse, error)
 	}
 
 	// EngineFactory is used to create an instance of sharded history engine
@@ -85,6 +91,11 @@ type (
 		notifyNewTask()
 	}
 
+	replicatorQueueProcessor interface {
+		queueProcessor
+		getTasks(readLevel int64, maxReadLevel int64, batchSize int, nextPageToken []byte) ([]*replicator.ReplicationTask, []byte, error)
+	}
+
 	queueAckMgr interface {
 		getFinishedChan() <-chan struct{}
 		readQueueTasks() ([]queueTaskInfo, bool, error)
@@ -136,7 +147,7 @@ type (
 
 	timerQueueAckMgr interface {
 		getFinishedChan() <-chan struct{}
-		readTimerTasks() ([]*persistence.TimerTaskInfo, *persistence.TimerTaskInfo, bool, error)
+		readTimerTasks() ([]*persistence.TimerTaskInfo, []*persistence.TimerTaskInfo, bool, error)
 		completeTimerTask(timerTask *persistence.TimerTaskInfo)
 		getAckLevel() TimerSequenceID
 		getReadLevel() TimerSequenceID
@@ -702,6 +702,9 @@ func (r *historyReplicator) replicateWorkflowStarted(ctx context.Context, contex
 
 	var historySize int
 	if msBuilder.GetEventStoreVersion() == persistence.EventStoreVersionV2 {
+		if err = msBuilder.UpdateCurrentVersionHistory(history.Events); err != nil {
+			return err
+		}
 		historySize, err = r.shard.AppendHistoryV2Events(&persistence.AppendHistoryNodesRequest{
 			IsNewBranch:   true,
 			Info:          historyGarbageCleanupInfo(domainID, execution.GetWorkflowId(), execution.GetRunId()),
@@ -780,6 +783,7 @@ func (r *historyReplicator) replicateWorkflowStarted(ctx context.Context, contex
 			ReplicationState:            replicationState,
 			EventStoreVersion:           msBuilder.GetEventStoreVersion(),
 			BranchToken:                 msBuilder.GetCurrentBranch(),
+			VersionHistories:            msBuilder.GetVersionHistories(),
 		}
 		createRequest.CreateWorkflowMode = persistence.CreateWorkflowModeBrandNew
 		if !isBrandNew {
@@ -897,7 +901,7 @@ func (r *historyReplicator) conflictResolutionTerminateCurrentRunningIfNotSelf(c
 	// cannot use history cache to get current workflow since there can be deadlock
 	domainID := msBuilder.GetExecutionInfo().DomainID
 	workflowID := msBuilder.GetExecutionInfo().WorkflowID
-	resp, err := r.shard.GetExecutionManager().GetCurrentExecution(&persistence.GetCurrentExecutionRequest{
+	resp, err := r.shard.GetExecutionManager().GetCurrentExecution(ctx, &persistence.GetCurrentExecutionRequest{
 		DomainID:   domainID,
 		WorkflowID: workflowID,
 	})
@@ -990,6 +994,7 @@ func (r *historyReplicator) terminateWorkflow(ctx context.Context, domainID stri
 			Reason:   common.StringPtr(workflowTerminationReason),
 			Identity: common.StringPtr(workflowTerminationIdentity),
 			Details:  nil,
+			Cause:    shared.TerminationCauseVersionConflict.Ptr(),
 		},
 	}
 	history := &shared.History{Events: []*shared.HistoryEvent{terminationEvent}}
@@ -0,0 +1,254 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"context"
+
+	"github.com/pborman/uuid"
+	"github.com/uber-common/bark"
+	h "github.com/uber/cadence/.gen/go/history"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/persistence"
+)
+
+var (
+	// ErrNDCNotEnabled is returned when replication task with version histories is received while NDC is not enabled
+	ErrNDCNotEnabled = &shared.BadRequestError{Message: "replication task with version histories received while NDC is not enabled"}
+	// ErrOverlappingReplicationEvents is returned when replicated events partially overlap with the local branch
+	ErrOverlappingReplicationEvents = &shared.BadRequestError{Message: "replicated events overlap with local history branch"}
+)
+
+// ApplyEventsV2 applies replicated events which carry the version history of the source branch.
+// The version history is used to find the lowest common ancestor (LCA) with the local branches,
+// the events are then either appended to the current branch, to an existing non current branch,
+// or to a new branch forked from the LCA. If the incoming branch wins (has a higher version than
+// the current branch), the mutable state is rebuilt from the incoming branch before applying events.
+func (r *historyReplicator) ApplyEventsV2(ctx context.Context, request *h.ReplicateEventsV2Request) (retError error) {
+	if !r.shard.GetConfig().EnableNDC() {
+		return ErrNDCNotEnabled
+	}
+
+	domainID, err := validateDomainUUID(request.DomainUUID)
+	if err != nil {
+		return err
+	}
+	if request.WorkflowExecution == nil || request.Events == nil {
+		r.logger.Warn("Dropping empty replication task")
+		r.metricsClient.IncCounter(metrics.ReplicateHistoryEventsScope, metrics.EmptyReplicationEventsCounter)
+		return nil
+	}
+	events, err := r.deserializeBlob(request.Events)
+	if err != nil {
+		return err
+	}
+	var newRunEvents []*shared.HistoryEvent
+	if request.NewRunEvents != nil {
+		newRunEvents, err = r.deserializeBlob(request.NewRunEvents)
+		if err != nil {
+			return err
+		}
+	}
+	eventsRequest := r.toReplicateEventsRequest(request, events, newRunEvents)
+
+	execution := *request.WorkflowExecution
+	firstEvent := events[0]
+	lastEvent := events[len(events)-1]
+	logger := r.logger.WithFields(bark.Fields{
+		logging.TagWorkflowExecutionID: execution.GetWorkflowId(),
+		logging.TagWorkflowRunID:       execution.GetRunId(),
+		logging.TagSourceCluster:       eventsRequest.GetSourceCluster(),
+		logging.TagIncomingVersion:     eventsRequest.GetVersion(),
+		logging.TagFirstEventID:        eventsRequest.GetFirstEventId(),
+		logging.TagNextEventID:         eventsRequest.GetNextEventId(),
+	})
+
+	context, release, err := r.historyCache.getOrCreateWorkflowExecutionWithTimeout(ctx, domainID, execution)
+	if err != nil {
+		return err
+	}
+	defer func() { release(retError) }()
+
+	msBuilder, err := context.loadWorkflowExecution()
+	if err != nil {
+		if _, ok := err.(*shared.EntityNotExistsError); !ok {
+			return err
+		}
+		if firstEvent.GetEventType() == shared.EventTypeWorkflowExecutionStarted {
+			return r.ApplyStartEvent(ctx, context, eventsRequest, logger)
+		}
+		return newRetryTaskErrorWithHint(ErrWorkflowNotFoundMsg, domainID, execution.GetWorkflowId(), execution.GetRunId(), common.FirstEventID)
+	}
+
+	localVersionHistories := msBuilder.GetVersionHistories()
+	if localVersionHistories == nil {
+		// the workflow was created before version histories are tracked, fall back to the
+		// replication logic which relies on replication info
+		release(nil)
+		return r.ApplyEvents(ctx, eventsRequest)
+	}
+
+	incomingVersionHistory := persistence.NewVersionHistory(nil, persistence.NewVersionHistoryItemsFromThrift(request.VersionHistoryItems))
+	branchIndex, lcaItem, err := localVersionHistories.FindLCAVersionHistoryIndexAndItem(incomingVersionHistory)
+	if err != nil {
+		return err
+	}
+	branchVersionHistory, err := localVersionHistories.GetVersionHistory(branchIndex)
+	if err != nil {
+		return err
+	}
+
+	if branchVersionHistory.ContainsItem(persistence.NewVersionHistoryItem(lastEvent.GetEventId(), lastEvent.GetVersion())) {
+		logger.Debugf("Dropping duplicate replication task.")
+		r.metricsClient.IncCounter(metrics.ReplicateHistoryEventsScope, metrics.DuplicateReplicationEventsCounter)
+		return nil
+	}
+	if firstEvent.GetEventId() > lcaItem.EventID+1 {
+		return newRetryTaskErrorWithHint(ErrRetryBufferEventsMsg, domainID, execution.GetWorkflowId(), execution.GetRunId(), lcaItem.EventID+1)
+	}
+	if firstEvent.GetEventId() <= lcaItem.EventID {
+		logError(logger, ErrOverlappingReplicationEvents.Message, ErrOverlappingReplicationEvents)
+		return ErrOverlappingReplicationEvents
+	}
+
+	currentVersionHistory, err := localVersionHistories.GetCurrentVersionHistory()
+	if err != nil {
+		return err
+	}
+	if branchIndex == localVersionHistories.GetCurrentVersionHistoryIndex() &&
+		currentVersionHistory.IsLCAAppendable(lcaItem) {
+		return r.ApplyReplicationTask(ctx, context, msBuilder, eventsRequest, logger)
+	}
+
+	// the events do not belong to the current branch, find or create the branch to append them to
+	r.metricsClient.IncCounter(metrics.ReplicateHistoryEventsScope, metrics.HistoryConflictsCounter)
+	versionHistories := localVersionHistories.Duplicate()
+	targetBranchIndex := branchIndex
+	if !branchVersionHistory.IsLCAAppendable(lcaItem) {
+		targetBranchIndex, err = r.forkVersionHistory(msBuilder, versionHistories, branchVersionHistory, lcaItem)
+		if err != nil {
+			return err
+		}
+	}
+	targetVersionHistory, err := versionHistories.GetVersionHistory(targetBranchIndex)
+	if err != nil {
+		return err
+	}
+
+	currentLastItem, err := currentVersionHistory.GetLastItem()
+	if err != nil {
+		return err
+	}
+	if lastEvent.GetVersion() > currentLastItem.Version {
+		// incoming branch wins, rebuild the mutable state from the target branch and apply the events
+		logger.Info("Rebuilding mutable state from incoming branch.")
+		if err := versionHistories.SetCurrentVersionHistoryIndex(targetBranchIndex); err != nil {
+			return err
+		}
+		resolver := r.getNewConflictResolver(context, logger)
+		msBuilder, err = resolver.rebuild(uuid.New(), targetVersionHistory.GetBranchToken(), lcaItem.EventID,
+			versionHistories, msBuilder.GetExecutionInfo())
+		if err != nil {
+			return err
+		}
+		return r.ApplyReplicationTask(ctx, context, msBuilder, eventsRequest, logger)
+	}
+
+	// incoming branch loses, only persist the events on the target branch, the mutable state
+	// is not affected besides the version histories, the new run (if any) is not created
+	logger.Info("Appending events to non current branch.")
+	transactionID, err := r.shard.GetNextTransferTaskID()
+	if err != nil {
+		return err
+	}
+	_, err = r.shard.AppendHistoryV2Events(&persistence.AppendHistoryNodesRequest{
+		IsNewBranch:   false,
+		BranchToken:   targetVersionHistory.GetBranchToken(),
+		Events:        events,
+		TransactionID: transactionID,
+	}, domainID, execution)
+	if err != nil {
+		return err
+	}
+	for _, event := range events {
+		if err := targetVersionHistory.AddOrUpdateItem(persistence.NewVersionHistoryItem(event.GetEventId(), event.GetVersion())); err != nil {
+			return err
+		}
+	}
+	msBuilder.SetVersionHistories(versionHistories)
+	return r.updateMutableStateOnly(context, msBuilder)
+}
+
+// forkVersionHistory forks a new history branch from the given branch at the LCA item,
+// and adds the version history of the new branch to versionHistories
+func (r *historyReplicator) forkVersionHistory(msBuilder mutableState, versionHistories *persistence.VersionHistories,
+	baseVersionHistory *persistence.VersionHistory, lcaItem *persistence.VersionHistoryItem) (retIndex int, retError error) {
+
+	executionInfo := msBuilder.GetExecutionInfo()
+	shardID := common.IntPtr(r.shard.GetShardID())
+	forkResp, err := r.shard.GetHistoryV2Manager().ForkHistoryBranch(&persistence.ForkHistoryBranchRequest{
+		ForkBranchToken: baseVersionHistory.GetBranchToken(),
+		ForkNodeID:      lcaItem.EventID + 1,
+		Info:            historyGarbageCleanupInfo(executionInfo.DomainID, executionInfo.WorkflowID, executionInfo.RunID),
+		ShardID:         shardID,
+	})
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		r.shard.GetHistoryV2Manager().CompleteForkBranch(&persistence.CompleteForkBranchRequest{
+			BranchToken: forkResp.NewBranchToken,
+			Success:     retError == nil,
+			ShardID:     shardID,
+		})
+	}()
+
+	forkedVersionHistory, err := baseVersionHistory.DuplicateUntilLCAItem(lcaItem)
+	if err != nil {
+		return 0, err
+	}
+	forkedVersionHistory.SetBranchToken(forkResp.NewBranchToken)
+	return versionHistories.AddVersionHistory(forkedVersionHistory)
+}
+
+func (r *historyReplicator) toReplicateEventsRequest(request *h.ReplicateEventsV2Request,
+	events []*shared.HistoryEvent, newRunEvents []*shared.HistoryEvent) *h.ReplicateEventsRequest {
+
+	version := events[0].GetVersion()
+	eventsRequest := &h.ReplicateEventsRequest{
+		SourceCluster:     common.StringPtr(r.clusterMetadata.ClusterNameForFailoverVersion(version)),
+		DomainUUID:        request.DomainUUID,
+		WorkflowExecution: request.WorkflowExecution,
+		FirstEventId:      common.Int64Ptr(events[0].GetEventId()),
+		NextEventId:       common.Int64Ptr(events[len(events)-1].GetEventId() + 1),
+		Version:           common.Int64Ptr(version),
+		History:           &shared.History{Events: events},
+		EventStoreVersion: common.Int32Ptr(persistence.EventStoreVersionV2),
+	}
+	if len(newRunEvents) > 0 {
+		eventsRequest.NewRunHistory = &shared.History{Events: newRunEvents}
+		eventsRequest.NewRunEventStoreVersion = common.Int32Ptr(persistence.EventStoreVersionV2)
+	}
+	return eventsRequest
+}
@@ -21,6 +21,7 @@
 package history
 
 import (
+	"context"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -41,6 +42,7 @@ import (
 	"github.com/uber/cadence/common/service"
 	cconfig "github.com/uber/cadence/common/service/config"
 	"github.com/uber/cadence/common/service/dynamicconfig"
+	"github.com/uber/cadence/common/tokenbucket"
 )
 
 var (
@@ -168,6 +170,11 @@ func (s *TestShardContext) GetEventsCache() eventsCache {
 	return s.eventsCache
 }
 
+// GetQueueHostRateLimiter test implementation
+func (s *TestShardContext) GetQueueHostRateLimiter(queue queueType) tokenbucket.TokenBucket {
+	return nil
+}
+
 // GetNextTransferTaskID test implementation
 func (s *TestShardContext) GetNextTransferTaskID() (int64, error) {
 	return atomic.AddInt64(&s.transferSequenceNumber, 1), nil
@@ -362,7 +369,7 @@ func (s *TestShardContext) UpdateDomainNotificationVersion(domainNotificationVer
 // CreateWorkflowExecution test implementation
 func (s *TestShardContext) CreateWorkflowExecution(request *persistence.CreateWorkflowExecutionRequest) (
 	*persistence.CreateWorkflowExecutionResponse, error) {
-	return s.executionMgr.CreateWorkflowExecution(request)
+	return s.executionMgr.CreateWorkflowExecution(context.Background(), request)
 }
 
 // UpdateWorkflowExecution test implementation
@@ -391,7 +398,7 @@ func (s *TestShardContext) UpdateWorkflowExecution(request *persistence.UpdateWo
 		s.logger.Infof("%v: TestShardContext: Assigning timer (timestamp: %v, seq: %v)",
 			time.Now().UTC(), visibilityTs, task.GetTaskID())
 	}
-	resp, err := s.executionMgr.UpdateWorkflowExecution(request)
+	resp, err := s.executionMgr.UpdateWorkflowExecution(context.Background(), request)
 	return resp, err
 }
 
@@ -417,12 +424,12 @@ func (s *TestShardContext) GetTimerMaxReadLevel(cluster string) time.Time {
 
 // ResetMutableState test implementation
 func (s *TestShardContext) ResetMutableState(request *persistence.ResetMutableStateRequest) error {
-	return s.executionMgr.ResetMutableState(request)
+	return s.executionMgr.ResetMutableState(context.Background(), request)
 }
 
 // ResetWorkflowExecution test implementation
 func (s *TestShardContext) ResetWorkflowExecution(request *persistence.ResetWorkflowExecutionRequest) error {
-	return s.executionMgr.ResetWorkflowExecution(request)
+	return s.executionMgr.ResetWorkflowExecution(context.Background(), request)
 }
 
 // AppendHistoryEvents test implementation
@@ -550,15 +557,15 @@ func (s *TestBase) SetupDomains() {
 		},
 		IsGlobalDomain: testDomainIsGlobalDomain,
 	}
-	s.MetadataManager.CreateDomain(createDomainRequest)
+	s.MetadataManager.CreateDomain(context.Background(), createDomainRequest)
 	createDomainRequest.Info.ID = testDomainStandbyID
 	createDomainRequest.Info.Name = testDomainStandbyName
 	createDomainRequest.ReplicationConfig.ActiveClusterName = testDomainStandbyClusterName
-	s.MetadataManager.CreateDomain(createDomainRequest)
+	s.MetadataManager.CreateDomain(context.Background(), createDomainRequest)
 }
 
 // TeardownDomains delete the domains used for testing
 func (s *TestBase) TeardownDomains() {
-	s.MetadataManager.DeleteDomain(&persistence.DeleteDomainRequest{ID: testDomainActiveID})
-	s.MetadataManager.DeleteDomain(&persistence.DeleteDomainRequest{ID: testDomainStandbyID})
+	s.MetadataManager.DeleteDomain(context.Background(), &persistence.DeleteDomainRequest{ID: testDomainActiveID})
+	s.MetadataManager.DeleteDomain(context.Background(), &persistence.DeleteDomainRequest{ID: testDomainStandbyID})
 }
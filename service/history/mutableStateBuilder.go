@@ -47,6 +47,7 @@ type (
 		updateActivityInfos             map[*persistence.ActivityInfo]struct{} // Modified activities from last update.
 		deleteActivityInfos             map[int64]struct{}                     // Deleted activities from last update.
 		syncActivityTasks               map[int64]struct{}                     // Activity to be sync to remote
+		coalescedActivityHeartbeats     map[int64]struct{}                     // Activities with heartbeat progress in memory only.
 
 		pendingTimerInfoIDs map[string]*persistence.TimerInfo   // User Timer ID -> Timer Info.
 		updateTimerInfos    map[*persistence.TimerInfo]struct{} // Modified timers from last update.
@@ -99,6 +100,7 @@ func newMutableStateBuilder(currentCluster string, shard ShardContext, eventsCac
 		pendingActivityInfoByActivityID: make(map[string]int64),
 		deleteActivityInfos:             make(map[int64]struct{}),
 		syncActivityTasks:               make(map[int64]struct{}),
+		coalescedActivityHeartbeats:     make(map[int64]struct{}),
 
 		pendingTimerInfoIDs: make(map[string]*persistence.TimerInfo),
 		updateTimerInfos:    make(map[*persistence.TimerInfo]struct{}),
@@ -201,6 +203,40 @@ func (e *mutableStateBuilder) SetHistoryTree(treeID string) error {
 	exeInfo := e.GetExecutionInfo()
 	exeInfo.EventStoreVersion = persistence.EventStoreVersionV2
 	exeInfo.BranchToken = initialBranchToken
+	// version histories are only tracked for global domains, they are what NDC replication
+	// uses to find where a replicated branch diverges from the local one
+	if e.replicationState != nil && e.config.EnableNDC() {
+		exeInfo.VersionHistories = persistence.NewVersionHistories(persistence.NewVersionHistory(initialBranchToken, nil))
+	}
+	return nil
+}
+
+func (e *mutableStateBuilder) GetVersionHistories() *persistence.VersionHistories {
+	return e.executionInfo.VersionHistories
+}
+
+func (e *mutableStateBuilder) SetVersionHistories(versionHistories *persistence.VersionHistories) {
+	e.executionInfo.VersionHistories = versionHistories
+}
+
+// UpdateCurrentVersionHistory records the versions of the given events, which are about to be
+// appended to the current branch, into the version history of that branch
+func (e *mutableStateBuilder) UpdateCurrentVersionHistory(events []*workflow.HistoryEvent) error {
+	versionHistories := e.executionInfo.VersionHistories
+	if versionHistories == nil {
+		return nil
+	}
+
+	currentVersionHistory, err := versionHistories.GetCurrentVersionHistory()
+	if err != nil {
+		return err
+	}
+	for _, event := range events {
+		item := persistence.NewVersionHistoryItem(event.GetEventId(), event.GetVersion())
+		if err := currentVersionHistory.AddOrUpdateItem(item); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -212,6 +248,11 @@ func (e *mutableStateBuilder) GetHistorySize() int64 {
 	return e.executionInfo.HistorySize
 }
 
+// SetNewRun makes the update session create the given run as the new current run of the workflow
+func (e *mutableStateBuilder) SetNewRun(request *persistence.CreateWorkflowExecutionRequest) {
+	e.continueAsNew = request
+}
+
 func (e *mutableStateBuilder) SetNewRunSize(size int) {
 	if e.continueAsNew != nil {
 		e.continueAsNew.HistorySize = int64(size)
@@ -413,6 +454,15 @@ func (e *mutableStateBuilder) CloseUpdateSession() (*mutableStateSessionUpdates,
 		return nil, err
 	}
 
+	// heartbeat progress kept in memory only is persisted along with any other update
+	for scheduleID := range e.coalescedActivityHeartbeats {
+		if ai, ok := e.pendingActivityInfoIDs[scheduleID]; ok {
+			e.updateActivityInfos[ai] = struct{}{}
+			e.syncActivityTasks[scheduleID] = struct{}{}
+		}
+	}
+	e.coalescedActivityHeartbeats = make(map[int64]struct{})
+
 	updates := &mutableStateSessionUpdates{
 		executionInfo:                    e.executionInfo,
 		newEventsBuilder:                 e.hBuilder,
@@ -1040,6 +1090,16 @@ func (e *mutableStateBuilder) UpdateActivityProgress(ai *persistence.ActivityInf
 	ai.LastHeartBeatUpdatedTime = time.Now()
 	e.updateActivityInfos[ai] = struct{}{}
 	e.syncActivityTasks[ai.ScheduleID] = struct{}{}
+	delete(e.coalescedActivityHeartbeats, ai.ScheduleID)
+}
+
+// CoalesceActivityProgress keeps the heartbeat progress of an activity in memory only, it is persisted
+// by the next update of the workflow execution, e.g. a decision completion or a timeout.
+func (e *mutableStateBuilder) CoalesceActivityProgress(ai *persistence.ActivityInfo,
+	request *workflow.RecordActivityTaskHeartbeatRequest) {
+	ai.Version = e.GetCurrentVersion()
+	ai.Details = request.Details
+	e.coalescedActivityHeartbeats[ai.ScheduleID] = struct{}{}
 }
 
 // ReplicateActivityInfo replicate the necessary activity information
@@ -1095,6 +1155,7 @@ func (e *mutableStateBuilder) DeleteActivity(scheduleEventID int64) error {
 		return errors.NewInternalFailureError(errorMsg)
 	}
 	delete(e.pendingActivityInfoByActivityID, a.ActivityID)
+	delete(e.coalescedActivityHeartbeats, scheduleEventID)
 
 	e.deleteActivityInfos[scheduleEventID] = struct{}{}
 	return nil
@@ -1279,6 +1340,10 @@ func (e *mutableStateBuilder) IsSignalRequested(requestID string) bool {
 	return false
 }
 
+func (e *mutableStateBuilder) GetPendingSignalRequestedIDs() map[string]struct{} {
+	return e.pendingSignalRequestedIDs
+}
+
 func (e *mutableStateBuilder) AddSignalRequested(requestID string) {
 	if e.pendingSignalRequestedIDs == nil {
 		e.pendingSignalRequestedIDs = make(map[string]struct{})
@@ -1295,6 +1360,22 @@ func (e *mutableStateBuilder) DeleteSignalRequested(requestID string) {
 	e.deleteSignalRequestedID = requestID
 }
 
+// UpdateLabels upserts and removes labels of the workflow execution. The labels are replaced instead of modified
+// in place, since transfer tasks keep reading them after the workflow execution lock is released.
+func (e *mutableStateBuilder) UpdateLabels(upsertLabels map[string]string, removeLabels []string) {
+	labels := make(map[string]string, len(e.executionInfo.Labels)+len(upsertLabels))
+	for key, value := range e.executionInfo.Labels {
+		labels[key] = value
+	}
+	for key, value := range upsertLabels {
+		labels[key] = value
+	}
+	for _, key := range removeLabels {
+		delete(labels, key)
+	}
+	e.executionInfo.Labels = labels
+}
+
 func (e *mutableStateBuilder) addWorkflowExecutionStartedEventForContinueAsNew(domainID string,
 	parentExecutionInfo *h.ParentExecutionInfo, execution workflow.WorkflowExecution, previousExecutionState mutableState,
 	attributes *workflow.ContinueAsNewWorkflowExecutionDecisionAttributes) *workflow.HistoryEvent {
@@ -2351,7 +2432,7 @@ func (e *mutableStateBuilder) AddRecordMarkerEvent(decisionCompletedEventID int6
 	return e.hBuilder.AddMarkerRecordedEvent(decisionCompletedEventID, attributes)
 }
 
-func (e *mutableStateBuilder) AddWorkflowExecutionTerminatedEvent(
+func (e *mutableStateBuilder) AddWorkflowExecutionTerminatedEvent(cause workflow.TerminationCause,
 	request *workflow.TerminateWorkflowExecutionRequest) *workflow.HistoryEvent {
 	if e.executionInfo.State == persistence.WorkflowStateCompleted {
 		logging.LogInvalidHistoryActionEvent(e.logger, logging.TagValueActionWorkflowTerminated, e.GetNextEventID(), fmt.Sprintf(
@@ -2359,7 +2440,7 @@ func (e *mutableStateBuilder) AddWorkflowExecutionTerminatedEvent(
 		return nil
 	}
 
-	event := e.hBuilder.AddWorkflowExecutionTerminatedEvent(request)
+	event := e.hBuilder.AddWorkflowExecutionTerminatedEvent(cause, request)
 	e.ReplicateWorkflowExecutionTerminatedEvent(event.GetEventId(), event)
 
 	return event
@@ -2521,6 +2602,7 @@ func (e *mutableStateBuilder) ReplicateWorkflowExecutionContinuedAsNewEvent(firs
 		CronSchedule:         startedAttributes.GetCronSchedule(),
 		EventStoreVersion:    newStateBuilder.GetEventStoreVersion(),
 		BranchToken:          newStateBuilder.GetCurrentBranch(),
+		VersionHistories:     newStateBuilder.GetVersionHistories(),
 	}
 
 	if continueAsNew.HasRetryPolicy {
@@ -155,6 +155,32 @@ OtherEventsLoop:
 		"This assertaion will be broken a new decision is added and no corresponding logic added to shouldBufferEvent()")
 }
 
+func (s *mutableStateSuite) TestCoalesceActivityProgress() {
+	ai := &persistence.ActivityInfo{ScheduleID: 5, ActivityID: "activity-id"}
+	s.msBuilder.pendingActivityInfoIDs[ai.ScheduleID] = ai
+	s.msBuilder.pendingActivityInfoByActivityID[ai.ActivityID] = ai.ScheduleID
+
+	s.msBuilder.CoalesceActivityProgress(ai, &workflow.RecordActivityTaskHeartbeatRequest{Details: []byte("progress")})
+	s.Equal([]byte("progress"), ai.Details)
+	s.Empty(s.msBuilder.updateActivityInfos)
+
+	// the progress is persisted along with the next update
+	updates, err := s.msBuilder.CloseUpdateSession()
+	s.Nil(err)
+	s.Equal([]*persistence.ActivityInfo{ai}, updates.updateActivityInfos)
+
+	updates, err = s.msBuilder.CloseUpdateSession()
+	s.Nil(err)
+	s.Empty(updates.updateActivityInfos)
+
+	// deleted activities are not flushed
+	s.msBuilder.CoalesceActivityProgress(ai, &workflow.RecordActivityTaskHeartbeatRequest{Details: []byte("more progress")})
+	s.Nil(s.msBuilder.DeleteActivity(ai.ScheduleID))
+	updates, err = s.msBuilder.CloseUpdateSession()
+	s.Nil(err)
+	s.Empty(updates.updateActivityInfos)
+}
+
 func (s *mutableStateSuite) TestReorderEvents() {
 	domainID := validDomainID
 	we := workflow.WorkflowExecution{
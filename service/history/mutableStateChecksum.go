@@ -0,0 +1,146 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sort"
+
+	"github.com/uber/cadence/common/checksum"
+)
+
+const (
+	mutableStateChecksumPayloadV1 = 1
+)
+
+func generateMutableStateChecksum(ms mutableState) checksum.Checksum {
+	return checksum.GenerateCRC32(newMutableStateChecksumPayload(ms), mutableStateChecksumPayloadV1)
+}
+
+func verifyMutableStateChecksum(ms mutableState, csum checksum.Checksum) error {
+	if csum.Version != mutableStateChecksumPayloadV1 {
+		// checksum written by a payload version this host doesn't know about, nothing to compare against
+		return nil
+	}
+	return checksum.Verify(newMutableStateChecksumPayload(ms), csum)
+}
+
+// newMutableStateChecksumPayload serializes the fields of mutable state covered by the checksum,
+// the encoding must be deterministic so the same state always produces the same bytes
+func newMutableStateChecksumPayload(ms mutableState) []byte {
+	var buf bytes.Buffer
+	write := func(values ...interface{}) {
+		for _, v := range values {
+			// writes into bytes.Buffer of fixed size values never fail
+			binary.Write(&buf, binary.BigEndian, v)
+		}
+	}
+	writeString := func(s string) {
+		write(int32(len(s)))
+		buf.WriteString(s)
+	}
+
+	executionInfo := ms.GetExecutionInfo()
+	write(
+		int32(executionInfo.State),
+		int32(executionInfo.CloseStatus),
+		executionInfo.LastFirstEventID,
+		executionInfo.NextEventID,
+		executionInfo.LastProcessedEvent,
+		executionInfo.SignalCount,
+		executionInfo.DecisionVersion,
+		executionInfo.DecisionScheduleID,
+		executionInfo.DecisionStartedID,
+		executionInfo.DecisionAttempt,
+		executionInfo.CancelRequested,
+	)
+
+	activityIDs := make([]int64, 0, len(ms.GetPendingActivityInfos()))
+	for scheduleID := range ms.GetPendingActivityInfos() {
+		activityIDs = append(activityIDs, scheduleID)
+	}
+	sortInt64s(activityIDs)
+	write(int32(len(activityIDs)))
+	for _, scheduleID := range activityIDs {
+		ai := ms.GetPendingActivityInfos()[scheduleID]
+		write(ai.ScheduleID, ai.StartedID)
+	}
+
+	timerIDs := make([]string, 0, len(ms.GetPendingTimerInfos()))
+	for timerID := range ms.GetPendingTimerInfos() {
+		timerIDs = append(timerIDs, timerID)
+	}
+	sort.Strings(timerIDs)
+	write(int32(len(timerIDs)))
+	for _, timerID := range timerIDs {
+		ti := ms.GetPendingTimerInfos()[timerID]
+		writeString(ti.TimerID)
+		write(ti.StartedID)
+	}
+
+	childIDs := make([]int64, 0, len(ms.GetPendingChildExecutionInfos()))
+	for initiatedID := range ms.GetPendingChildExecutionInfos() {
+		childIDs = append(childIDs, initiatedID)
+	}
+	sortInt64s(childIDs)
+	write(int32(len(childIDs)))
+	for _, initiatedID := range childIDs {
+		ci := ms.GetPendingChildExecutionInfos()[initiatedID]
+		write(ci.InitiatedID, ci.StartedID)
+	}
+
+	cancelIDs := make([]int64, 0, len(ms.GetAllRequestCancels()))
+	for initiatedID := range ms.GetAllRequestCancels() {
+		cancelIDs = append(cancelIDs, initiatedID)
+	}
+	sortInt64s(cancelIDs)
+	write(int32(len(cancelIDs)))
+	for _, initiatedID := range cancelIDs {
+		write(initiatedID)
+	}
+
+	signalIDs := make([]int64, 0, len(ms.GetAllSignalsToSend()))
+	for initiatedID := range ms.GetAllSignalsToSend() {
+		signalIDs = append(signalIDs, initiatedID)
+	}
+	sortInt64s(signalIDs)
+	write(int32(len(signalIDs)))
+	for _, initiatedID := range signalIDs {
+		write(initiatedID)
+	}
+
+	requestIDs := make([]string, 0, len(ms.GetPendingSignalRequestedIDs()))
+	for requestID := range ms.GetPendingSignalRequestedIDs() {
+		requestIDs = append(requestIDs, requestID)
+	}
+	sort.Strings(requestIDs)
+	write(int32(len(requestIDs)))
+	for _, requestID := range requestIDs {
+		writeString(requestID)
+	}
+
+	return buf.Bytes()
+}
+
+func sortInt64s(values []int64) {
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/common/checksum"
+	"github.com/uber/cadence/common/cluster"
+	"github.com/uber/cadence/common/persistence"
+)
+
+type (
+	mutableStateChecksumSuite struct {
+		suite.Suite
+		mockShard *shardContextImpl
+		logger    bark.Logger
+	}
+)
+
+func TestMutableStateChecksumSuite(t *testing.T) {
+	s := new(mutableStateChecksumSuite)
+	suite.Run(t, s)
+}
+
+func (s *mutableStateChecksumSuite) SetupTest() {
+	s.logger = bark.NewLoggerFromLogrus(log.New())
+	s.mockShard = &shardContextImpl{
+		shardInfo: &persistence.ShardInfo{ShardID: 0, RangeID: 1, TransferAckLevel: 0},
+		config:    NewDynamicConfigForTest(),
+		logger:    s.logger,
+	}
+}
+
+func (s *mutableStateChecksumSuite) TestVerify() {
+	csum := generateMutableStateChecksum(s.newMutableState())
+	s.Equal(mutableStateChecksumPayloadV1, csum.Version)
+	s.Equal(checksum.FlavorIEEECRC32OverBinary, csum.Flavor)
+
+	s.NoError(verifyMutableStateChecksum(s.newMutableState(), csum))
+}
+
+func (s *mutableStateChecksumSuite) TestVerify_Mismatch() {
+	csum := generateMutableStateChecksum(s.newMutableState())
+
+	msBuilder := s.newMutableState()
+	msBuilder.GetExecutionInfo().NextEventID++
+	s.Equal(checksum.ErrMismatch, verifyMutableStateChecksum(msBuilder, csum))
+
+	msBuilder = s.newMutableState()
+	delete(msBuilder.GetPendingActivityInfos(), 5)
+	s.Equal(checksum.ErrMismatch, verifyMutableStateChecksum(msBuilder, csum))
+
+	msBuilder = s.newMutableState()
+	msBuilder.GetPendingSignalRequestedIDs()["request-id-2"] = struct{}{}
+	s.Equal(checksum.ErrMismatch, verifyMutableStateChecksum(msBuilder, csum))
+}
+
+func (s *mutableStateChecksumSuite) TestVerify_UnknownVersion() {
+	csum := generateMutableStateChecksum(s.newMutableState())
+	csum.Version = mutableStateChecksumPayloadV1 + 1
+
+	msBuilder := s.newMutableState()
+	msBuilder.GetExecutionInfo().NextEventID++
+	s.NoError(verifyMutableStateChecksum(msBuilder, csum))
+}
+
+func (s *mutableStateChecksumSuite) newMutableState() mutableState {
+	msBuilder := newMutableStateBuilder(cluster.TestCurrentClusterName, s.mockShard, &MockEventsCache{}, s.logger)
+	msBuilder.Load(&persistence.WorkflowMutableState{
+		ExecutionInfo: &persistence.WorkflowExecutionInfo{
+			State:              persistence.WorkflowStateRunning,
+			CloseStatus:        persistence.WorkflowCloseStatusNone,
+			LastFirstEventID:   10,
+			NextEventID:        12,
+			LastProcessedEvent: 4,
+			SignalCount:        1,
+			DecisionScheduleID: 10,
+			DecisionStartedID:  11,
+			DecisionAttempt:    0,
+		},
+		ActivityInfos: map[int64]*persistence.ActivityInfo{
+			5: {ScheduleID: 5, StartedID: 6, ActivityID: "activity-5"},
+			7: {ScheduleID: 7, StartedID: 0, ActivityID: "activity-7"},
+		},
+		TimerInfos: map[string]*persistence.TimerInfo{
+			"timer-1": {TimerID: "timer-1", StartedID: 8},
+		},
+		ChildExecutionInfos: map[int64]*persistence.ChildExecutionInfo{
+			9: {InitiatedID: 9, StartedID: 0},
+		},
+		RequestCancelInfos: map[int64]*persistence.RequestCancelInfo{},
+		SignalInfos:        map[int64]*persistence.SignalInfo{},
+		SignalRequestedIDs: map[string]struct{}{"request-id-1": {}},
+	})
+	return msBuilder
+}
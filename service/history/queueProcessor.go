@@ -55,15 +55,16 @@ type (
 	}
 
 	queueProcessorBase struct {
-		clusterName   string
-		shard         ShardContext
-		options       *QueueProcessorOptions
-		processor     processor
-		logger        bark.Logger
-		metricsClient metrics.Client
-		rateLimiter   tokenbucket.TokenBucket // Read rate limiter
-		ackMgr        queueAckMgr
-		retryPolicy   backoff.RetryPolicy
+		clusterName     string
+		shard           ShardContext
+		options         *QueueProcessorOptions
+		processor       processor
+		logger          bark.Logger
+		metricsClient   metrics.Client
+		rateLimiter     tokenbucket.TokenBucket // Read rate limiter
+		hostRateLimiter tokenbucket.TokenBucket // Read rate limiter shared by all shards on this host
+		ackMgr          queueAckMgr
+		retryPolicy     backoff.RetryPolicy
 
 		// worker coroutines notification
 		workerNotificationChans []chan struct{}
@@ -84,7 +85,8 @@ var (
 	loadQueueTaskThrottleRetryDelay       = 5 * time.Second
 )
 
-func newQueueProcessorBase(clusterName string, shard ShardContext, options *QueueProcessorOptions, processor processor, queueAckMgr queueAckMgr, logger bark.Logger) *queueProcessorBase {
+func newQueueProcessorBase(clusterName string, shard ShardContext, options *QueueProcessorOptions, processor processor, queueAckMgr queueAckMgr,
+	hostRateLimiter tokenbucket.TokenBucket, logger bark.Logger) *queueProcessorBase {
 	workerNotificationChans := []chan struct{}{}
 	for index := 0; index < options.WorkerCount(); index++ {
 		workerNotificationChans = append(workerNotificationChans, make(chan struct{}, 1))
@@ -95,7 +97,8 @@ func newQueueProcessorBase(clusterName string, shard ShardContext, options *Queu
 		shard:                   shard,
 		options:                 options,
 		processor:               processor,
-		rateLimiter:             tokenbucket.New(options.MaxPollRPS(), clock.NewRealTimeSource()),
+		rateLimiter:             tokenbucket.NewDynamicTokenBucket(options.MaxPollRPS, clock.NewRealTimeSource()),
+		hostRateLimiter:         hostRateLimiter,
 		workerNotificationChans: workerNotificationChans,
 		status:                  common.DaemonStatusInitialized,
 		notifyCh:                make(chan struct{}, 1),
@@ -216,6 +219,12 @@ func (p *queueProcessorBase) processBatch(tasksCh chan<- queueTaskInfo) {
 		return
 	}
 
+	if p.hostRateLimiter != nil && !p.hostRateLimiter.Consume(1, loadQueueTaskThrottleRetryDelay) {
+		p.metricsClient.IncCounter(p.options.MetricScope, metrics.TaskBatchHostThrottledCounter)
+		p.notifyNewTask() // re-enqueue the event
+		return
+	}
+
 	p.lastPollTime = time.Now()
 	tasks, more, err := p.ackMgr.readQueueTasks()
 
@@ -119,6 +119,7 @@ func (s *queueProcessorSuite) SetupTest() {
 		},
 		s.mockProcessor,
 		s.mockQueueAckMgr,
+		nil,
 		s.logger,
 	)
 }
@@ -0,0 +1,83 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"time"
+
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+	"github.com/uber/cadence/common/tokenbucket"
+)
+
+type (
+	queueType int
+
+	// hostRateLimiter is a dynamic token bucket shared by all the shards of a history host,
+	// a non-positive rps disables the host level throttling
+	hostRateLimiter struct {
+		rps dynamicconfig.IntPropertyFn
+		tb  tokenbucket.TokenBucket
+	}
+)
+
+const (
+	transferQueueType queueType = iota
+	timerQueueType
+	replicatorQueueType
+)
+
+var _ tokenbucket.TokenBucket = (*hostRateLimiter)(nil)
+
+// newHostQueueRateLimiters creates the host level read rate limiters for the queue processors,
+// so the aggregated load of all shards on a host can be shed during persistence brownouts
+func newHostQueueRateLimiters(config *Config) map[queueType]tokenbucket.TokenBucket {
+	return map[queueType]tokenbucket.TokenBucket{
+		transferQueueType:   newHostRateLimiter(config.TransferProcessorMaxPollHostRPS),
+		timerQueueType:      newHostRateLimiter(config.TimerProcessorMaxPollHostRPS),
+		replicatorQueueType: newHostRateLimiter(config.ReplicatorProcessorMaxPollHostRPS),
+	}
+}
+
+func newHostRateLimiter(rps dynamicconfig.IntPropertyFn) *hostRateLimiter {
+	return &hostRateLimiter{
+		rps: rps,
+		tb:  tokenbucket.NewDynamicTokenBucket(rps, clock.NewRealTimeSource()),
+	}
+}
+
+func (r *hostRateLimiter) TryConsume(count int) (bool, time.Duration) {
+	if r.rps() <= 0 {
+		return true, 0
+	}
+	return r.tb.TryConsume(count)
+}
+
+func (r *hostRateLimiter) Consume(count int, timeout time.Duration) bool {
+	if r.rps() <= 0 {
+		return true
+	}
+	return r.tb.Consume(count, timeout)
+}
+
+func (r *hostRateLimiter) Reset(rps int) {
+	r.tb.Reset(rps)
+}
@@ -21,6 +21,7 @@
 package history
 
 import (
+	"context"
 	"errors"
 	"time"
 
@@ -62,7 +63,7 @@ var (
 )
 
 func newReplicatorQueueProcessor(shard ShardContext, historyCache *historyCache, replicator messaging.Producer,
-	executionMgr persistence.ExecutionManager, historyMgr persistence.HistoryManager, historyV2Mgr persistence.HistoryV2Manager, logger bark.Logger) queueProcessor {
+	executionMgr persistence.ExecutionManager, historyMgr persistence.HistoryManager, historyV2Mgr persistence.HistoryV2Manager, logger bark.Logger) replicatorQueueProcessor {
 
 	currentClusterNamer := shard.GetService().GetClusterMetadata().GetCurrentClusterName()
 
@@ -103,7 +104,8 @@ func newReplicatorQueueProcessor(shard ShardContext, historyCache *historyCache,
 	}
 
 	queueAckMgr := newQueueAckMgr(shard, options, processor, shard.GetReplicatorAckLevel(), logger)
-	queueProcessorBase := newQueueProcessorBase(currentClusterNamer, shard, options, processor, queueAckMgr, logger)
+	queueProcessorBase := newQueueProcessorBase(currentClusterNamer, shard, options, processor, queueAckMgr,
+		shard.GetQueueHostRateLimiter(replicatorQueueType), logger)
 	processor.queueAckMgr = queueAckMgr
 	processor.queueProcessorBase = queueProcessorBase
 
@@ -126,7 +128,7 @@ func (p *replicatorQueueProcessorImpl) process(qTask queueTaskInfo, shouldProces
 	case persistence.ReplicationTaskTypeSyncActivity:
 		err := p.processSyncActivityTask(task)
 		if err == nil {
-			err = p.executionMgr.CompleteReplicationTask(&persistence.CompleteReplicationTaskRequest{TaskID: task.GetTaskID()})
+			err = p.executionMgr.CompleteReplicationTask(context.TODO(), &persistence.CompleteReplicationTaskRequest{TaskID: task.GetTaskID()})
 		}
 		return metrics.ReplicatorTaskSyncActivityScope, err
 	case persistence.ReplicationTaskTypeHistory:
@@ -135,7 +137,7 @@ func (p *replicatorQueueProcessorImpl) process(qTask queueTaskInfo, shouldProces
 			err = errHistoryNotFoundTask
 		}
 		if err == nil {
-			err = p.executionMgr.CompleteReplicationTask(&persistence.CompleteReplicationTaskRequest{TaskID: task.GetTaskID()})
+			err = p.executionMgr.CompleteReplicationTask(context.TODO(), &persistence.CompleteReplicationTaskRequest{TaskID: task.GetTaskID()})
 		}
 		return metrics.ReplicatorTaskHistoryScope, err
 	default:
@@ -148,7 +150,18 @@ func (p *replicatorQueueProcessorImpl) queueShutdown() error {
 	return nil
 }
 
-func (p *replicatorQueueProcessorImpl) processSyncActivityTask(task *persistence.ReplicationTaskInfo) (retError error) {
+func (p *replicatorQueueProcessorImpl) processSyncActivityTask(task *persistence.ReplicationTaskInfo) error {
+	replicationTask, err := p.generateSyncActivityTask(task)
+	if err != nil || replicationTask == nil {
+		return err
+	}
+
+	return p.replicator.Publish(replicationTask)
+}
+
+// generateSyncActivityTask returns the replication task syncing the current state of the activity,
+// nil is returned if the workflow or the activity are already gone
+func (p *replicatorQueueProcessorImpl) generateSyncActivityTask(task *persistence.ReplicationTaskInfo) (_ *replicator.ReplicationTask, retError error) {
 	domainID := task.DomainID
 	execution := shared.WorkflowExecution{
 		WorkflowId: common.StringPtr(task.WorkflowID),
@@ -156,25 +169,25 @@ func (p *replicatorQueueProcessorImpl) processSyncActivityTask(task *persistence
 	}
 	context, release, err := p.historyCache.getOrCreateWorkflowExecution(domainID, execution)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer func() { release(retError) }()
 
 	msBuilder, err := context.loadWorkflowExecution()
 	if err != nil {
 		if _, ok := err.(*shared.EntityNotExistsError); ok {
-			return nil
+			return nil, nil
 		}
-		return err
+		return nil, err
 	}
 	if !msBuilder.IsWorkflowExecutionRunning() {
 		// workflow already finished, no need to process the timer
-		return nil
+		return nil, nil
 	}
 
 	activityInfo, ok := msBuilder.GetActivityInfo(task.ScheduledID)
 	if !ok {
-		return nil
+		return nil, nil
 	}
 
 	var startedTime *int64
@@ -186,7 +199,7 @@ func (p *replicatorQueueProcessorImpl) processSyncActivityTask(task *persistence
 	// LastHeartBeatUpdatedTime must be valid when getting the sync activity replication task
 	heartbeatTime = common.Int64Ptr(activityInfo.LastHeartBeatUpdatedTime.UnixNano())
 
-	replicationTask := &replicator.ReplicationTask{
+	return &replicator.ReplicationTask{
 		TaskType: replicator.ReplicationTaskType.Ptr(replicator.ReplicationTaskTypeSyncActivity),
 		SyncActicvityTaskAttributes: &replicator.SyncActicvityTaskAttributes{
 			DomainId:          common.StringPtr(task.DomainID),
@@ -201,27 +214,78 @@ func (p *replicatorQueueProcessorImpl) processSyncActivityTask(task *persistence
 			Details:           activityInfo.Details,
 			Attempt:           common.Int32Ptr(activityInfo.Attempt),
 		},
+		ShardId: common.Int32Ptr(int32(p.shard.GetShardID())),
+	}, nil
+}
+
+func (p *replicatorQueueProcessorImpl) processHistoryReplicationTask(task *persistence.ReplicationTaskInfo) error {
+	replicationTask, err := p.generateHistoryReplicationTask(task)
+	if err != nil || replicationTask == nil {
+		return err
 	}
 
 	return p.replicator.Publish(replicationTask)
 }
 
-func (p *replicatorQueueProcessorImpl) processHistoryReplicationTask(task *persistence.ReplicationTaskInfo) error {
-
+// generateHistoryReplicationTask returns the replication task carrying the history events of the task
+func (p *replicatorQueueProcessorImpl) generateHistoryReplicationTask(task *persistence.ReplicationTaskInfo) (*replicator.ReplicationTask, error) {
 	domainEntry, err := p.shard.GetDomainCache().GetDomainByID(task.DomainID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	targetClusters := []string{}
 	for _, cluster := range domainEntry.GetReplicationConfig().Clusters {
 		targetClusters = append(targetClusters, cluster.ClusterName)
 	}
-	replicationTask, err := GenerateReplicationTask(targetClusters, task, p.historyMgr, p.historyV2Mgr, p.metricsClient, p.logger, nil, common.IntPtr(p.shard.GetShardID()))
-	if err != nil || replicationTask == nil {
-		return err
+	shardID := common.IntPtr(p.shard.GetShardID())
+	if task.EventStoreVersion == persistence.EventStoreVersionV2 && p.shard.GetConfig().EnableNDC() {
+		versionHistory, err := p.getVersionHistory(task)
+		if err != nil {
+			return nil, err
+		}
+		if versionHistory != nil {
+			return GenerateReplicationTaskV2(task, versionHistory, p.historyMgr, p.historyV2Mgr, p.metricsClient, p.logger, shardID)
+		}
 	}
 
-	return p.replicator.Publish(replicationTask)
+	return GenerateReplicationTask(targetClusters, task, p.historyMgr, p.historyV2Mgr, p.metricsClient, p.logger, nil, shardID)
+}
+
+// getVersionHistory returns the version history of the branch the replication task belongs to,
+// up to the last event of the task, nil is returned if the workflow does not track version histories
+func (p *replicatorQueueProcessorImpl) getVersionHistory(task *persistence.ReplicationTaskInfo) (_ *persistence.VersionHistory, retError error) {
+	execution := shared.WorkflowExecution{
+		WorkflowId: common.StringPtr(task.WorkflowID),
+		RunId:      common.StringPtr(task.RunID),
+	}
+	context, release, err := p.historyCache.getOrCreateWorkflowExecution(task.DomainID, execution)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { release(retError) }()
+
+	msBuilder, err := context.loadWorkflowExecution()
+	if err != nil {
+		if _, ok := err.(*shared.EntityNotExistsError); ok {
+			return nil, nil
+		}
+		return nil, err
+	}
+	versionHistories := msBuilder.GetVersionHistories()
+	if versionHistories == nil {
+		return nil, nil
+	}
+
+	lastItem := persistence.NewVersionHistoryItem(task.NextEventID-1, task.Version)
+	index, err := versionHistories.FindFirstVersionHistoryIndexByItem(lastItem)
+	if err != nil {
+		return nil, err
+	}
+	versionHistory, err := versionHistories.GetVersionHistory(index)
+	if err != nil {
+		return nil, err
+	}
+	return versionHistory.DuplicateUntilLCAItem(lastItem)
 }
 
 // GenerateReplicationTask generate replication task
@@ -276,10 +340,87 @@ func GenerateReplicationTask(targetClusters []string, task *persistence.Replicat
 			ResetWorkflow:           common.BoolPtr(task.ResetWorkflow),
 		},
 	}
+	if shardID != nil {
+		// the shard ID is used as the partition key so all replication tasks of a shard are applied in order
+		ret.ShardId = common.Int32Ptr(int32(*shardID))
+	}
 	return ret, nil
 }
+
+// GenerateReplicationTaskV2 generate replication task which carries the version history of the branch
+func GenerateReplicationTaskV2(task *persistence.ReplicationTaskInfo, versionHistory *persistence.VersionHistory,
+	historyMgr persistence.HistoryManager, historyV2Mgr persistence.HistoryV2Manager,
+	metricsClient metrics.Client, logger bark.Logger, shardID *int,
+) (*replicator.ReplicationTask, error) {
+	history, _, err := GetAllHistory(historyMgr, historyV2Mgr, metricsClient, logger, false,
+		task.DomainID, task.WorkflowID, task.RunID, task.FirstEventID, task.NextEventID, task.EventStoreVersion, task.BranchToken, shardID)
+	if err != nil {
+		return nil, err
+	}
+	if len(history.Events) == 0 {
+		return nil, nil
+	}
+	for _, event := range history.Events {
+		if task.Version != event.GetVersion() {
+			return nil, nil
+		}
+	}
+
+	serializer := persistence.NewHistorySerializer()
+	eventsBlob, err := serializer.SerializeBatchEvents(history.Events, common.EncodingTypeThriftRW)
+	if err != nil {
+		return nil, err
+	}
+
+	var newRunEventsBlob *persistence.DataBlob
+	lastEvent := history.Events[len(history.Events)-1]
+	if lastEvent.GetEventType() == shared.EventTypeWorkflowExecutionContinuedAsNew {
+		newRunID := lastEvent.WorkflowExecutionContinuedAsNewEventAttributes.GetNewExecutionRunId()
+		newRunHistory, _, err := GetAllHistory(historyMgr, historyV2Mgr, metricsClient, logger, false,
+			task.DomainID, task.WorkflowID, newRunID, common.FirstEventID, int64(3), task.NewRunEventStoreVersion, task.NewRunBranchToken, shardID)
+		if err != nil {
+			return nil, err
+		}
+		newRunEventsBlob, err = serializer.SerializeBatchEvents(newRunHistory.Events, common.EncodingTypeThriftRW)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	versionHistoryItems := []*shared.VersionHistoryItem{}
+	for _, item := range versionHistory.Items {
+		versionHistoryItems = append(versionHistoryItems, item.ToThrift())
+	}
+
+	ret := &replicator.ReplicationTask{
+		TaskType: replicator.ReplicationTaskType.Ptr(replicator.ReplicationTaskTypeHistoryV2),
+		HistoryTaskV2Attributes: &replicator.HistoryTaskV2Attributes{
+			DomainId:            common.StringPtr(task.DomainID),
+			WorkflowId:          common.StringPtr(task.WorkflowID),
+			RunId:               common.StringPtr(task.RunID),
+			VersionHistoryItems: versionHistoryItems,
+			Events:              toThriftDataBlob(eventsBlob),
+			NewRunEvents:        toThriftDataBlob(newRunEventsBlob),
+		},
+	}
+	if shardID != nil {
+		ret.ShardId = common.Int32Ptr(int32(*shardID))
+	}
+	return ret, nil
+}
+
+func toThriftDataBlob(blob *persistence.DataBlob) *shared.DataBlob {
+	if blob == nil {
+		return nil
+	}
+	return &shared.DataBlob{
+		EncodingType: shared.EncodingTypeThriftRW.Ptr(),
+		Data:         blob.Data,
+	}
+}
+
 func (p *replicatorQueueProcessorImpl) readTasks(readLevel int64) ([]queueTaskInfo, bool, error) {
-	response, err := p.executionMgr.GetReplicationTasks(&persistence.GetReplicationTasksRequest{
+	response, err := p.executionMgr.GetReplicationTasks(context.TODO(), &persistence.GetReplicationTasksRequest{
 		ReadLevel:    readLevel,
 		MaxReadLevel: p.shard.GetTransferMaxReadLevel(),
 		BatchSize:    p.options.BatchSize(),
@@ -297,6 +438,45 @@ func (p *replicatorQueueProcessorImpl) readTasks(readLevel int64) ([]queueTaskIn
 	return tasks, len(response.NextPageToken) != 0, nil
 }
 
+// getTasks reads a page of the replication tasks with task IDs in (readLevel, maxReadLevel] and converts them into
+// the replication tasks published to remote clusters, tasks which have nothing left to replicate are skipped
+func (p *replicatorQueueProcessorImpl) getTasks(readLevel int64, maxReadLevel int64, batchSize int,
+	nextPageToken []byte) ([]*replicator.ReplicationTask, []byte, error) {
+	response, err := p.executionMgr.GetReplicationTasks(context.TODO(), &persistence.GetReplicationTasksRequest{
+		ReadLevel:     readLevel,
+		MaxReadLevel:  maxReadLevel,
+		BatchSize:     batchSize,
+		NextPageToken: nextPageToken,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tasks := make([]*replicator.ReplicationTask, 0, len(response.Tasks))
+	for _, task := range response.Tasks {
+		var replicationTask *replicator.ReplicationTask
+		switch task.TaskType {
+		case persistence.ReplicationTaskTypeSyncActivity:
+			replicationTask, err = p.generateSyncActivityTask(task)
+		case persistence.ReplicationTaskTypeHistory:
+			replicationTask, err = p.generateHistoryReplicationTask(task)
+		default:
+			err = errUnknownReplicationTask
+		}
+		if _, ok := err.(*shared.EntityNotExistsError); ok {
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		if replicationTask != nil {
+			tasks = append(tasks, replicationTask)
+		}
+	}
+
+	return tasks, response.NextPageToken, nil
+}
+
 func (p *replicatorQueueProcessorImpl) updateAckLevel(ackLevel int64) error {
 	err := p.shard.UpdateReplicatorAckLevel(ackLevel)
 
@@ -311,6 +491,7 @@ func (p *replicatorQueueProcessorImpl) updateAckLevel(ackLevel int64) error {
 				ShardId:       common.Int64Ptr(int64(p.shard.GetShardID())),
 				Timestamp:     common.Int64Ptr(now.UnixNano()),
 			},
+			ShardId: common.Int32Ptr(int32(p.shard.GetShardID())),
 		}
 		// ignore the error
 		if syncErr := p.replicator.Publish(syncStatusTask); syncErr == nil {
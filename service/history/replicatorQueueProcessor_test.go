@@ -27,6 +27,7 @@ import (
 
 	"github.com/pborman/uuid"
 	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"github.com/uber-common/bark"
 	"github.com/uber-go/tally"
@@ -139,8 +140,8 @@ func (s *replicatorQueueProcessorSuite) TestSyncActivity_WorkflowMissing() {
 		RunID:       runID,
 		ScheduledID: scheduleID,
 	}
-	s.mockExecutionMgr.On("CompleteReplicationTask", &persistence.CompleteReplicationTaskRequest{TaskID: taskID}).Return(nil).Once()
-	s.mockExecutionMgr.On("GetWorkflowExecution", &persistence.GetWorkflowExecutionRequest{
+	s.mockExecutionMgr.On("CompleteReplicationTask", mock.Anything, &persistence.CompleteReplicationTaskRequest{TaskID: taskID}).Return(nil).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, &persistence.GetWorkflowExecutionRequest{
 		DomainID: domainID,
 		Execution: shared.WorkflowExecution{
 			WorkflowId: common.StringPtr(workflowID),
@@ -168,7 +169,7 @@ func (s *replicatorQueueProcessorSuite) TestSyncActivity_WorkflowCompleted() {
 		RunID:       runID,
 		ScheduledID: scheduleID,
 	}
-	s.mockExecutionMgr.On("CompleteReplicationTask", &persistence.CompleteReplicationTaskRequest{TaskID: taskID}).Return(nil).Once()
+	s.mockExecutionMgr.On("CompleteReplicationTask", mock.Anything, &persistence.CompleteReplicationTaskRequest{TaskID: taskID}).Return(nil).Once()
 
 	context, release, _ := s.replicatorQueueProcessor.historyCache.getOrCreateWorkflowExecution(
 		domainID,
@@ -209,7 +210,7 @@ func (s *replicatorQueueProcessorSuite) TestSyncActivity_ActivityCompleted() {
 		RunID:       runID,
 		ScheduledID: scheduleID,
 	}
-	s.mockExecutionMgr.On("CompleteReplicationTask", &persistence.CompleteReplicationTaskRequest{TaskID: taskID}).Return(nil).Once()
+	s.mockExecutionMgr.On("CompleteReplicationTask", mock.Anything, &persistence.CompleteReplicationTaskRequest{TaskID: taskID}).Return(nil).Once()
 
 	context, release, _ := s.replicatorQueueProcessor.historyCache.getOrCreateWorkflowExecution(
 		domainID,
@@ -232,7 +233,7 @@ func (s *replicatorQueueProcessorSuite) TestSyncActivity_ActivityCompleted() {
 	msBuilder.On("GetLastWriteVersion").Return(version)
 	msBuilder.On("UpdateReplicationStateVersion", version, false).Once()
 	msBuilder.On("GetActivityInfo", scheduleID).Return(nil, false)
-	s.mockMetadataMgr.On("GetDomain", &persistence.GetDomainRequest{ID: domainID}).Return(
+	s.mockMetadataMgr.On("GetDomain", mock.Anything, &persistence.GetDomainRequest{ID: domainID}).Return(
 		&persistence.GetDomainResponse{
 			Info:   &persistence.DomainInfo{ID: domainID, Name: domainName},
 			Config: &persistence.DomainConfig{Retention: 1},
@@ -269,7 +270,7 @@ func (s *replicatorQueueProcessorSuite) TestSyncActivity_ActivityRetry() {
 		RunID:       runID,
 		ScheduledID: scheduleID,
 	}
-	s.mockExecutionMgr.On("CompleteReplicationTask", &persistence.CompleteReplicationTaskRequest{TaskID: taskID}).Return(nil).Once()
+	s.mockExecutionMgr.On("CompleteReplicationTask", mock.Anything, &persistence.CompleteReplicationTaskRequest{TaskID: taskID}).Return(nil).Once()
 
 	context, release, _ := s.replicatorQueueProcessor.historyCache.getOrCreateWorkflowExecution(
 		domainID,
@@ -310,7 +311,7 @@ func (s *replicatorQueueProcessorSuite) TestSyncActivity_ActivityRetry() {
 		Details:                  activityDetails,
 		Attempt:                  activityAttempt,
 	}, true)
-	s.mockMetadataMgr.On("GetDomain", &persistence.GetDomainRequest{ID: domainID}).Return(
+	s.mockMetadataMgr.On("GetDomain", mock.Anything, &persistence.GetDomainRequest{ID: domainID}).Return(
 		&persistence.GetDomainResponse{
 			Info:   &persistence.DomainInfo{ID: domainID, Name: domainName},
 			Config: &persistence.DomainConfig{Retention: 1},
@@ -340,6 +341,7 @@ func (s *replicatorQueueProcessorSuite) TestSyncActivity_ActivityRetry() {
 			Details:           activityDetails,
 			Attempt:           common.Int32Ptr(activityAttempt),
 		},
+		ShardId: common.Int32Ptr(0),
 	}).Return(nil).Once()
 
 	_, err := s.replicatorQueueProcessor.process(task, true)
@@ -363,7 +365,7 @@ func (s *replicatorQueueProcessorSuite) TestSyncActivity_ActivityRunning() {
 		RunID:       runID,
 		ScheduledID: scheduleID,
 	}
-	s.mockExecutionMgr.On("CompleteReplicationTask", &persistence.CompleteReplicationTaskRequest{TaskID: taskID}).Return(nil).Once()
+	s.mockExecutionMgr.On("CompleteReplicationTask", mock.Anything, &persistence.CompleteReplicationTaskRequest{TaskID: taskID}).Return(nil).Once()
 
 	context, release, _ := s.replicatorQueueProcessor.historyCache.getOrCreateWorkflowExecution(
 		domainID,
@@ -404,7 +406,7 @@ func (s *replicatorQueueProcessorSuite) TestSyncActivity_ActivityRunning() {
 		Details:                  activityDetails,
 		Attempt:                  activityAttempt,
 	}, true)
-	s.mockMetadataMgr.On("GetDomain", &persistence.GetDomainRequest{ID: domainID}).Return(
+	s.mockMetadataMgr.On("GetDomain", mock.Anything, &persistence.GetDomainRequest{ID: domainID}).Return(
 		&persistence.GetDomainResponse{
 			Info:   &persistence.DomainInfo{ID: domainID, Name: domainName},
 			Config: &persistence.DomainConfig{Retention: 1},
@@ -434,12 +436,51 @@ func (s *replicatorQueueProcessorSuite) TestSyncActivity_ActivityRunning() {
 			Details:           activityDetails,
 			Attempt:           common.Int32Ptr(activityAttempt),
 		},
+		ShardId: common.Int32Ptr(0),
 	}).Return(nil).Once()
 
 	_, err := s.replicatorQueueProcessor.process(task, true)
 	s.Nil(err)
 }
 
+func (s *replicatorQueueProcessorSuite) TestGetTasks_WorkflowMissing() {
+	domainID := validDomainID
+	workflowID := "some random workflow ID"
+	runID := uuid.New()
+	readLevel := int64(1000)
+	maxReadLevel := int64(2000)
+	nextPageToken := []byte("some random next page token")
+	task := &persistence.ReplicationTaskInfo{
+		TaskType:    persistence.ReplicationTaskTypeSyncActivity,
+		TaskID:      int64(1444),
+		DomainID:    domainID,
+		WorkflowID:  workflowID,
+		RunID:       runID,
+		ScheduledID: int64(144),
+	}
+	s.mockExecutionMgr.On("GetReplicationTasks", mock.Anything, &persistence.GetReplicationTasksRequest{
+		ReadLevel:    readLevel,
+		MaxReadLevel: maxReadLevel,
+		BatchSize:    10,
+	}).Return(&persistence.GetReplicationTasksResponse{
+		Tasks:         []*persistence.ReplicationTaskInfo{task},
+		NextPageToken: nextPageToken,
+	}, nil).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, &persistence.GetWorkflowExecutionRequest{
+		DomainID: domainID,
+		Execution: shared.WorkflowExecution{
+			WorkflowId: common.StringPtr(workflowID),
+			RunId:      common.StringPtr(runID),
+		},
+	}).Return(nil, &shared.EntityNotExistsError{})
+
+	// the task has nothing left to replicate, it is neither returned nor completed
+	tasks, token, err := s.replicatorQueueProcessor.getTasks(readLevel, maxReadLevel, 10, nil)
+	s.Nil(err)
+	s.Empty(tasks)
+	s.Equal(nextPageToken, token)
+}
+
 func (s *replicatorQueueProcessorSuite) TestPaginateHistoryWithShardID() {
 	domainID := validDomainID
 	workflowID := "some random workflow ID"
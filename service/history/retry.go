@@ -53,6 +53,7 @@ func prepareActivityNextRetryWithNowTime(version int64, a *persistence.ActivityI
 	a.RequestID = ""
 	a.StartedTime = time.Time{}
 	a.TimerTaskStatus = TimerTaskStatusNone
+	a.LastFailureReason = errReason
 
 	return &persistence.ActivityRetryTimerTask{
 		Version:             a.Version,
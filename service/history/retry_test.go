@@ -66,6 +66,7 @@ func Test_NextRetry(t *testing.T) {
 	a.NotNil(retryTask)
 	a.Equal(version, retryTask.GetVersion())
 	a.Equal(now.Add(time.Second), retryTask.(*persistence.ActivityRetryTimerTask).VisibilityTimestamp)
+	a.Equal(reason, ai.LastFailureReason)
 
 	retryTask = prepareActivityNextRetryWithNowTime(version, ai, reason, now)
 	a.NotNil(retryTask)
@@ -24,7 +24,9 @@ import (
 	"time"
 
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cluster"
 	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/messaging"
 	"github.com/uber/cadence/common/metrics"
 	persistencefactory "github.com/uber/cadence/common/persistence/persistence-factory"
 	"github.com/uber/cadence/common/service"
@@ -39,18 +41,32 @@ type Config struct {
 	RPS                             dynamicconfig.IntPropertyFn
 	MaxIDLengthLimit                dynamicconfig.IntPropertyFn
 	PersistenceMaxQPS               dynamicconfig.IntPropertyFn
+	PersistenceAdaptiveThrottling   *config.AdaptiveThrottlingConfig
+	PersistenceDomainMetrics        *config.DomainMetricsConfig
+	PersistenceFaultInjection       *config.FaultInjectionConfig
 	EnableVisibilitySampling        dynamicconfig.BoolPropertyFn
 	EnableReadFromClosedExecutionV2 dynamicconfig.BoolPropertyFn
 	VisibilityOpenMaxQPS            dynamicconfig.IntPropertyFnWithDomainFilter
 	VisibilityClosedMaxQPS          dynamicconfig.IntPropertyFnWithDomainFilter
+	VisibilityClosedAsync           dynamicconfig.BoolPropertyFn
+	VisibilityClosedBatchSize       dynamicconfig.IntPropertyFn
+	VisibilityClosedBatchInterval   dynamicconfig.DurationPropertyFn
 	EnableVisibilityToKafka         dynamicconfig.BoolPropertyFn
 	EmitShardDiffLog                dynamicconfig.BoolPropertyFn
+	EnableDomainChangeNotification  dynamicconfig.BoolPropertyFn
+	VisibilityTombstoneRetention    dynamicconfig.DurationPropertyFnWithDomainFilter
+
+	// VisibilitySpillBuffer is used when EnableVisibilityToKafka is on, spilling is disabled when its Dir is empty
+	VisibilitySpillBuffer *messaging.SpillBufferConfig
 
 	// HistoryCache settings
 	// Change of these configs require shard restart
 	HistoryCacheInitialSize dynamicconfig.IntPropertyFn
 	HistoryCacheMaxSize     dynamicconfig.IntPropertyFn
 	HistoryCacheTTL         dynamicconfig.DurationPropertyFn
+	// HostLevelCacheMaxSize requires service restart, HostLevelCacheMaxBytes is applied dynamically
+	HostLevelCacheMaxSize  dynamicconfig.IntPropertyFn
+	HostLevelCacheMaxBytes dynamicconfig.IntPropertyFn
 
 	// EventsCache settings
 	// Change of these configs require shard restart
@@ -78,9 +94,12 @@ type Config struct {
 	TimerProcessorCompleteTimerInterval              dynamicconfig.DurationPropertyFn
 	TimerProcessorFailoverMaxPollRPS                 dynamicconfig.IntPropertyFn
 	TimerProcessorMaxPollRPS                         dynamicconfig.IntPropertyFn
+	TimerProcessorMaxPollHostRPS                     dynamicconfig.IntPropertyFn
 	TimerProcessorMaxPollInterval                    dynamicconfig.DurationPropertyFn
 	TimerProcessorMaxPollIntervalJitterCoefficient   dynamicconfig.FloatPropertyFn
 	TimerProcessorMaxTimeShift                       dynamicconfig.DurationPropertyFn
+	TimerProcessorLookAheadBatchSize                 dynamicconfig.IntPropertyFn
+	TimerProcessorWheelTickInterval                  dynamicconfig.DurationPropertyFn
 
 	// TransferQueueProcessor settings
 	TransferTaskBatchSize                               dynamicconfig.IntPropertyFn
@@ -91,6 +110,7 @@ type Config struct {
 	TransferProcessorCompleteTransferFailureRetryCount  dynamicconfig.IntPropertyFn
 	TransferProcessorFailoverMaxPollRPS                 dynamicconfig.IntPropertyFn
 	TransferProcessorMaxPollRPS                         dynamicconfig.IntPropertyFn
+	TransferProcessorMaxPollHostRPS                     dynamicconfig.IntPropertyFn
 	TransferProcessorMaxPollInterval                    dynamicconfig.DurationPropertyFn
 	TransferProcessorMaxPollIntervalJitterCoefficient   dynamicconfig.FloatPropertyFn
 	TransferProcessorUpdateAckInterval                  dynamicconfig.DurationPropertyFn
@@ -103,6 +123,7 @@ type Config struct {
 	ReplicatorTaskMaxRetryCount                           dynamicconfig.IntPropertyFn
 	ReplicatorProcessorStartDelay                         dynamicconfig.DurationPropertyFn
 	ReplicatorProcessorMaxPollRPS                         dynamicconfig.IntPropertyFn
+	ReplicatorProcessorMaxPollHostRPS                     dynamicconfig.IntPropertyFn
 	ReplicatorProcessorMaxPollInterval                    dynamicconfig.DurationPropertyFn
 	ReplicatorProcessorMaxPollIntervalJitterCoefficient   dynamicconfig.FloatPropertyFn
 	ReplicatorProcessorUpdateAckInterval                  dynamicconfig.DurationPropertyFn
@@ -114,7 +135,26 @@ type Config struct {
 
 	// System Limits
 	MaximumBufferedEventsBatch dynamicconfig.IntPropertyFn
+	MaximumBufferedEventsSize  dynamicconfig.IntPropertyFn
+	BufferedEventsLimitPolicy  dynamicconfig.StringPropertyFn
 	MaximumSignalsPerExecution dynamicconfig.IntPropertyFnWithDomainFilter
+	// MaximumSignalRequestIDsPerExecution is the size of the signal deduplication window of single execution, zero disables it
+	MaximumSignalRequestIDsPerExecution dynamicconfig.IntPropertyFnWithDomainFilter
+	// MutableStateChecksumVerifyMode is how a checksum mismatch of the loaded mutable state is handled
+	MutableStateChecksumVerifyMode dynamicconfig.StringPropertyFnWithDomainFilter
+
+	// MaximumPendingChildWorkflowsPerExecution is max number of pending child workflows of single execution, zero disables it
+	MaximumPendingChildWorkflowsPerExecution dynamicconfig.IntPropertyFnWithDomainFilter
+	// MaximumPendingActivitiesPerExecution is max number of pending activities of single execution, zero disables it
+	MaximumPendingActivitiesPerExecution dynamicconfig.IntPropertyFnWithDomainFilter
+
+	// ActivityHeartbeatMinInterval is the min interval between persisted activity heartbeats
+	ActivityHeartbeatMinInterval dynamicconfig.DurationPropertyFnWithDomainFilter
+	// DefaultActivityHeartbeatTimeout is the heartbeat timeout of activities scheduled without one, zero disables it
+	DefaultActivityHeartbeatTimeout dynamicconfig.DurationPropertyFnWithDomainFilter
+	// StuckDecisionTimeoutThreshold is the number of consecutive decision timeouts after which an execution is
+	// reported as stuck and its next decision is recorded in history, zero disables it
+	StuckDecisionTimeoutThreshold dynamicconfig.IntPropertyFnWithDomainFilter
 
 	// ShardUpdateMinInterval the minimal time interval which the shard info can be updated
 	ShardUpdateMinInterval dynamicconfig.DurationPropertyFn
@@ -129,9 +169,14 @@ type Config struct {
 	EventEncodingType dynamicconfig.StringPropertyFnWithDomainFilter
 	// whether or not using eventsV2
 	EnableEventsV2 dynamicconfig.BoolPropertyFnWithDomainFilter
+	// whether or not tracking version histories for NDC replication
+	EnableNDC dynamicconfig.BoolPropertyFn
 
 	NumArchiveSystemWorkflows dynamicconfig.IntPropertyFn
 
+	// EnableConcurrencyGroups is whether the closed runs of a domain are released from their concurrency group
+	EnableConcurrencyGroups dynamicconfig.BoolPropertyFnWithDomainFilter
+
 	BlobSizeLimitError     dynamicconfig.IntPropertyFnWithDomainFilter
 	BlobSizeLimitWarn      dynamicconfig.IntPropertyFnWithDomainFilter
 	HistorySizeLimitError  dynamicconfig.IntPropertyFnWithDomainFilter
@@ -139,6 +184,9 @@ type Config struct {
 	HistoryCountLimitError dynamicconfig.IntPropertyFnWithDomainFilter
 	HistoryCountLimitWarn  dynamicconfig.IntPropertyFnWithDomainFilter
 
+	// LocalActivityMarkersSizeLimit is the limit of the total size of local activity markers recorded by a single decision
+	LocalActivityMarkersSizeLimit dynamicconfig.IntPropertyFnWithDomainFilter
+
 	ThrottledLogRPS dynamicconfig.IntPropertyFn
 }
 
@@ -149,15 +197,25 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int, enableVisibilit
 		RPS:                                                   dc.GetIntProperty(dynamicconfig.HistoryRPS, 3000),
 		MaxIDLengthLimit:                                      dc.GetIntProperty(dynamicconfig.MaxIDLengthLimit, 1000),
 		PersistenceMaxQPS:                                     dc.GetIntProperty(dynamicconfig.HistoryPersistenceMaxQPS, 9000),
+		PersistenceAdaptiveThrottling:                         config.NewAdaptiveThrottlingConfig(dc, dynamicconfig.HistoryEnablePersistenceAdaptiveThrottling),
+		PersistenceDomainMetrics:                              config.NewDomainMetricsConfig(dc, dynamicconfig.HistoryEnablePersistenceDomainMetrics),
+		PersistenceFaultInjection:                             config.NewFaultInjectionConfig(dc, dynamicconfig.HistoryEnablePersistenceFaultInjection),
 		EnableVisibilitySampling:                              dc.GetBoolProperty(dynamicconfig.EnableVisibilitySampling, true),
 		EnableReadFromClosedExecutionV2:                       dc.GetBoolProperty(dynamicconfig.EnableReadFromClosedExecutionV2, false),
 		VisibilityOpenMaxQPS:                                  dc.GetIntPropertyFilteredByDomain(dynamicconfig.HistoryVisibilityOpenMaxQPS, 300),
 		VisibilityClosedMaxQPS:                                dc.GetIntPropertyFilteredByDomain(dynamicconfig.HistoryVisibilityClosedMaxQPS, 300),
+		VisibilityClosedAsync:                                 dc.GetBoolProperty(dynamicconfig.HistoryVisibilityEnableAsyncClosedRecords, false),
+		VisibilityClosedBatchSize:                             dc.GetIntProperty(dynamicconfig.HistoryVisibilityClosedRecordsBatchSize, 100),
+		VisibilityClosedBatchInterval:                         dc.GetDurationProperty(dynamicconfig.HistoryVisibilityClosedRecordsBatchInterval, time.Second),
 		EnableVisibilityToKafka:                               dc.GetBoolProperty(dynamicconfig.EnableVisibilityToKafka, enableVisibilityToKafka),
 		EmitShardDiffLog:                                      dc.GetBoolProperty(dynamicconfig.EmitShardDiffLog, false),
+		EnableDomainChangeNotification:                        dc.GetBoolProperty(dynamicconfig.EnableDomainChangeNotification, false),
+		VisibilityTombstoneRetention:                          dc.GetDurationPropertyFilteredByDomain(dynamicconfig.VisibilityTombstoneRetention, 7*24*time.Hour),
 		HistoryCacheInitialSize:                               dc.GetIntProperty(dynamicconfig.HistoryCacheInitialSize, 128),
 		HistoryCacheMaxSize:                                   dc.GetIntProperty(dynamicconfig.HistoryCacheMaxSize, 512),
 		HistoryCacheTTL:                                       dc.GetDurationProperty(dynamicconfig.HistoryCacheTTL, time.Hour),
+		HostLevelCacheMaxSize:                                 dc.GetIntProperty(dynamicconfig.HistoryHostLevelCacheMaxSize, 256000),
+		HostLevelCacheMaxBytes:                                dc.GetIntProperty(dynamicconfig.HistoryHostLevelCacheMaxBytes, 512*1024*1024),
 		EventsCacheInitialSize:                                dc.GetIntProperty(dynamicconfig.EventsCacheInitialSize, 128),
 		EventsCacheMaxSize:                                    dc.GetIntProperty(dynamicconfig.EventsCacheMaxSize, 512),
 		EventsCacheTTL:                                        dc.GetDurationProperty(dynamicconfig.EventsCacheTTL, time.Hour),
@@ -176,12 +234,16 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int, enableVisibilit
 		TimerProcessorCompleteTimerInterval:                   dc.GetDurationProperty(dynamicconfig.TimerProcessorCompleteTimerInterval, 60*time.Second),
 		TimerProcessorFailoverMaxPollRPS:                      dc.GetIntProperty(dynamicconfig.TimerProcessorFailoverMaxPollRPS, 1),
 		TimerProcessorMaxPollRPS:                              dc.GetIntProperty(dynamicconfig.TimerProcessorMaxPollRPS, 20),
+		TimerProcessorMaxPollHostRPS:                          dc.GetIntProperty(dynamicconfig.TimerProcessorMaxPollHostRPS, 0),
 		TimerProcessorMaxPollInterval:                         dc.GetDurationProperty(dynamicconfig.TimerProcessorMaxPollInterval, 5*time.Minute),
 		TimerProcessorMaxPollIntervalJitterCoefficient:        dc.GetFloat64Property(dynamicconfig.TimerProcessorMaxPollIntervalJitterCoefficient, 0.15),
 		TimerProcessorMaxTimeShift:                            dc.GetDurationProperty(dynamicconfig.TimerProcessorMaxTimeShift, 1*time.Second),
+		TimerProcessorLookAheadBatchSize:                      dc.GetIntProperty(dynamicconfig.TimerProcessorLookAheadBatchSize, 100),
+		TimerProcessorWheelTickInterval:                       dc.GetDurationProperty(dynamicconfig.TimerProcessorWheelTickInterval, 100*time.Millisecond),
 		TransferTaskBatchSize:                                 dc.GetIntProperty(dynamicconfig.TransferTaskBatchSize, 100),
 		TransferProcessorFailoverMaxPollRPS:                   dc.GetIntProperty(dynamicconfig.TransferProcessorFailoverMaxPollRPS, 1),
 		TransferProcessorMaxPollRPS:                           dc.GetIntProperty(dynamicconfig.TransferProcessorMaxPollRPS, 20),
+		TransferProcessorMaxPollHostRPS:                       dc.GetIntProperty(dynamicconfig.TransferProcessorMaxPollHostRPS, 0),
 		TransferTaskWorkerCount:                               dc.GetIntProperty(dynamicconfig.TransferTaskWorkerCount, 10),
 		TransferTaskMaxRetryCount:                             dc.GetIntProperty(dynamicconfig.TransferTaskMaxRetryCount, 100),
 		TransferProcessorStartDelay:                           dc.GetDurationProperty(dynamicconfig.TransferProcessorStartDelay, 1*time.Microsecond),
@@ -197,6 +259,7 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int, enableVisibilit
 		ReplicatorTaskMaxRetryCount:                           dc.GetIntProperty(dynamicconfig.ReplicatorTaskMaxRetryCount, 100),
 		ReplicatorProcessorStartDelay:                         dc.GetDurationProperty(dynamicconfig.ReplicatorProcessorStartDelay, 1*time.Microsecond),
 		ReplicatorProcessorMaxPollRPS:                         dc.GetIntProperty(dynamicconfig.ReplicatorProcessorMaxPollRPS, 20),
+		ReplicatorProcessorMaxPollHostRPS:                     dc.GetIntProperty(dynamicconfig.ReplicatorProcessorMaxPollHostRPS, 0),
 		ReplicatorProcessorMaxPollInterval:                    dc.GetDurationProperty(dynamicconfig.ReplicatorProcessorMaxPollInterval, 1*time.Minute),
 		ReplicatorProcessorMaxPollIntervalJitterCoefficient:   dc.GetFloat64Property(dynamicconfig.ReplicatorProcessorMaxPollIntervalJitterCoefficient, 0.15),
 		ReplicatorProcessorUpdateAckInterval:                  dc.GetDurationProperty(dynamicconfig.ReplicatorProcessorUpdateAckInterval, 5*time.Second),
@@ -204,7 +267,16 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int, enableVisibilit
 		ExecutionMgrNumConns:                                  dc.GetIntProperty(dynamicconfig.ExecutionMgrNumConns, 50),
 		HistoryMgrNumConns:                                    dc.GetIntProperty(dynamicconfig.HistoryMgrNumConns, 50),
 		MaximumBufferedEventsBatch:                            dc.GetIntProperty(dynamicconfig.MaximumBufferedEventsBatch, 100),
+		MaximumBufferedEventsSize:                             dc.GetIntProperty(dynamicconfig.MaximumBufferedEventsSize, 2*1024*1024),
+		BufferedEventsLimitPolicy:                             dc.GetStringProperty(dynamicconfig.BufferedEventsLimitPolicy, BufferedEventsLimitPolicyForceNewDecision),
+		MutableStateChecksumVerifyMode:                        dc.GetStringPropertyFnWithDomainFilter(dynamicconfig.MutableStateChecksumVerifyMode, MutableStateChecksumVerifyModeLog),
 		MaximumSignalsPerExecution:                            dc.GetIntPropertyFilteredByDomain(dynamicconfig.MaximumSignalsPerExecution, 0),
+		MaximumSignalRequestIDsPerExecution:                   dc.GetIntPropertyFilteredByDomain(dynamicconfig.MaximumSignalRequestIDsPerExecution, 1000),
+		MaximumPendingChildWorkflowsPerExecution:              dc.GetIntPropertyFilteredByDomain(dynamicconfig.MaximumPendingChildWorkflowsPerExecution, 0),
+		MaximumPendingActivitiesPerExecution:                  dc.GetIntPropertyFilteredByDomain(dynamicconfig.MaximumPendingActivitiesPerExecution, 0),
+		ActivityHeartbeatMinInterval:                          dc.GetDurationPropertyFilteredByDomain(dynamicconfig.ActivityHeartbeatMinInterval, 0),
+		DefaultActivityHeartbeatTimeout:                       dc.GetDurationPropertyFilteredByDomain(dynamicconfig.DefaultActivityHeartbeatTimeout, 0),
+		StuckDecisionTimeoutThreshold:                         dc.GetIntPropertyFilteredByDomain(dynamicconfig.StuckDecisionTimeoutThreshold, 10),
 		ShardUpdateMinInterval:                                dc.GetDurationProperty(dynamicconfig.ShardUpdateMinInterval, 5*time.Minute),
 		ShardSyncMinInterval:                                  dc.GetDurationProperty(dynamicconfig.ShardSyncMinInterval, 5*time.Minute),
 
@@ -212,16 +284,27 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int, enableVisibilit
 		LongPollExpirationInterval: dc.GetDurationPropertyFilteredByDomain(dynamicconfig.HistoryLongPollExpirationInterval, time.Second*20),
 		EventEncodingType:          dc.GetStringPropertyFnWithDomainFilter(dynamicconfig.DefaultEventEncoding, string(common.EncodingTypeThriftRW)),
 		EnableEventsV2:             dc.GetBoolPropertyFnWithDomainFilter(dynamicconfig.EnableEventsV2, true),
+		EnableNDC:                  dc.GetBoolProperty(dynamicconfig.EnableNDC, false),
 
 		NumArchiveSystemWorkflows: dc.GetIntProperty(dynamicconfig.NumArchiveSystemWorkflows, 1000),
 
+		EnableConcurrencyGroups: dc.GetBoolPropertyFnWithDomainFilter(dynamicconfig.EnableConcurrencyGroups, false),
+
 		BlobSizeLimitError:     dc.GetIntPropertyFilteredByDomain(dynamicconfig.BlobSizeLimitError, 2*1024*1024),
-		BlobSizeLimitWarn:      dc.GetIntPropertyFilteredByDomain(dynamicconfig.BlobSizeLimitError, 256*1024),
+		BlobSizeLimitWarn:      dc.GetIntPropertyFilteredByDomain(dynamicconfig.BlobSizeLimitWarn, 256*1024),
 		HistorySizeLimitError:  dc.GetIntPropertyFilteredByDomain(dynamicconfig.HistorySizeLimitError, 200*1024*1024),
 		HistorySizeLimitWarn:   dc.GetIntPropertyFilteredByDomain(dynamicconfig.HistorySizeLimitWarn, 50*1024*1024),
 		HistoryCountLimitError: dc.GetIntPropertyFilteredByDomain(dynamicconfig.HistoryCountLimitError, 200*1024),
 		HistoryCountLimitWarn:  dc.GetIntPropertyFilteredByDomain(dynamicconfig.HistoryCountLimitWarn, 50*1024),
 
+		LocalActivityMarkersSizeLimit: dc.GetIntPropertyFilteredByDomain(dynamicconfig.LocalActivityMarkersSizeLimit, 2*1024*1024),
+
+		VisibilitySpillBuffer: &messaging.SpillBufferConfig{
+			Dir:           dc.GetStringProperty(dynamicconfig.HistoryVisibilitySpillBufferDir, "")(),
+			MaxMessages:   dc.GetIntProperty(dynamicconfig.HistoryVisibilitySpillBufferMaxMessages, 100000),
+			RetryInterval: dc.GetDurationProperty(dynamicconfig.HistoryVisibilitySpillBufferRetryInterval, 10*time.Second),
+		},
+
 		ThrottledLogRPS: dc.GetIntProperty(dynamicconfig.HistoryThrottledLogRPS, 20),
 	}
 
@@ -248,7 +331,7 @@ func NewService(params *service.BootstrapParams) common.Daemon {
 		params.PersistenceConfig.NumHistoryShards,
 		params.ESConfig.Enable,
 		params.PersistenceConfig.DefaultStoreType())
-	params.ThrottledBarkLogger = logging.NewThrottledLogger(params.BarkLogger, config.ThrottledLogRPS)
+	params.ThrottledBarkLogger = logging.NewThrottledLoggerWithKeys(params.BarkLogger, config.ThrottledLogRPS, logging.TagDomainID, logging.TagErr)
 	return &Service{
 		params: params,
 		stopC:  make(chan struct{}),
@@ -269,16 +352,25 @@ func (s *Service) Start() {
 
 	s.metricsClient = base.GetMetricsClient()
 
-	pConfig := params.PersistenceConfig
-	pConfig.HistoryMaxConns = s.config.HistoryMgrNumConns()
-	pConfig.SetMaxQPS(pConfig.DefaultStore, s.config.PersistenceMaxQPS())
-	pConfig.VisibilityConfig = &config.VisibilityConfig{
-		VisibilityOpenMaxQPS:            s.config.VisibilityOpenMaxQPS,
-		VisibilityClosedMaxQPS:          s.config.VisibilityClosedMaxQPS,
-		EnableSampling:                  s.config.EnableVisibilitySampling,
-		EnableReadFromClosedExecutionV2: s.config.EnableReadFromClosedExecutionV2,
+	pFactory := params.PersistenceFactory
+	if pFactory == nil {
+		pConfig := params.PersistenceConfig
+		pConfig.HistoryMaxConns = s.config.HistoryMgrNumConns()
+		pConfig.SetMaxQPS(pConfig.DefaultStore, s.config.PersistenceMaxQPS())
+		pConfig.AdaptiveThrottlingConfig = s.config.PersistenceAdaptiveThrottling
+		pConfig.DomainMetricsConfig = s.config.PersistenceDomainMetrics
+		pConfig.FaultInjectionConfig = s.config.PersistenceFaultInjection
+		pConfig.VisibilityConfig = &config.VisibilityConfig{
+			VisibilityOpenMaxQPS:            s.config.VisibilityOpenMaxQPS,
+			VisibilityClosedMaxQPS:          s.config.VisibilityClosedMaxQPS,
+			EnableSampling:                  s.config.EnableVisibilitySampling,
+			EnableReadFromClosedExecutionV2: s.config.EnableReadFromClosedExecutionV2,
+			EnableAsyncClosedRecords:        s.config.VisibilityClosedAsync,
+			ClosedRecordsBatchSize:          s.config.VisibilityClosedBatchSize,
+			ClosedRecordsBatchInterval:      s.config.VisibilityClosedBatchInterval,
+		}
+		pFactory = persistencefactory.New(&pConfig, params.ClusterMetadata.GetCurrentClusterName(), s.metricsClient, log)
 	}
-	pFactory := persistencefactory.New(&pConfig, params.ClusterMetadata.GetCurrentClusterName(), s.metricsClient, log)
 
 	shardMgr, err := pFactory.NewShardManager()
 	if err != nil {
@@ -290,6 +382,13 @@ func (s *Service) Start() {
 		log.Fatalf("failed to create metadata manager: %v", err)
 	}
 
+	clusterMetadataMgr, err := pFactory.NewClusterMetadataManager()
+	if err != nil {
+		log.Fatalf("failed to create cluster metadata manager: %v", err)
+	}
+	clusterMetadataRefresher := cluster.NewMetadataRefresher(base.GetClusterMetadata(), clusterMetadataMgr, log)
+	clusterMetadataRefresher.Start()
+
 	visibility, err := pFactory.NewVisibilityManager()
 	if err != nil {
 		log.Fatalf("failed to create visibility manager: %v", err)
@@ -311,6 +410,7 @@ func (s *Service) Start() {
 	log.Infof("%v started", common.HistoryServiceName)
 
 	<-s.stopC
+	clusterMetadataRefresher.Stop()
 	base.Stop()
 }
 
@@ -21,6 +21,7 @@
 package history
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"sync/atomic"
@@ -36,6 +37,7 @@ import (
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/service"
+	"github.com/uber/cadence/common/tokenbucket"
 )
 
 type (
@@ -78,6 +80,7 @@ type (
 		NotifyNewHistoryEvent(event *historyEventNotification) error
 		GetConfig() *Config
 		GetEventsCache() eventsCache
+		GetQueueHostRateLimiter(queue queueType) tokenbucket.TokenBucket
 		GetLogger() bark.Logger
 		GetThrottledLogger() bark.Logger
 		GetMetricsClient() metrics.Client
@@ -100,6 +103,7 @@ type (
 		executionManager persistence.ExecutionManager
 		domainCache      cache.DomainCache
 		eventsCache      eventsCache
+		hostRateLimiters map[queueType]tokenbucket.TokenBucket
 		closeCh          chan<- int
 		isClosed         bool
 		config           *Config
@@ -374,6 +378,7 @@ func (s *shardContextImpl) CreateWorkflowExecution(request *persistence.CreateWo
 	if err != nil {
 		return nil, err
 	}
+	request.Encoding = s.getDefaultEncoding(domainEntry)
 
 	s.Lock()
 	defer s.Unlock()
@@ -414,7 +419,7 @@ Create_Loop:
 		currentRangeID := s.getRangeID()
 		request.RangeID = currentRangeID
 
-		response, err := s.executionManager.CreateWorkflowExecution(request)
+		response, err := s.executionManager.CreateWorkflowExecution(context.TODO(), request)
 		if err != nil {
 			switch err.(type) {
 			case *shared.WorkflowExecutionAlreadyStartedError,
@@ -533,7 +538,7 @@ Update_Loop:
 	for attempt := 0; attempt < conditionalRetryCount; attempt++ {
 		currentRangeID := s.getRangeID()
 		request.RangeID = currentRangeID
-		resp, err := s.executionManager.UpdateWorkflowExecution(request)
+		resp, err := s.executionManager.UpdateWorkflowExecution(context.TODO(), request)
 		if err != nil {
 			switch err.(type) {
 			case *persistence.ConditionFailedError,
@@ -633,7 +638,7 @@ Reset_Loop:
 	for attempt := 0; attempt < conditionalRetryCount; attempt++ {
 		currentRangeID := s.getRangeID()
 		request.RangeID = currentRangeID
-		err := s.executionManager.ResetWorkflowExecution(request)
+		err := s.executionManager.ResetWorkflowExecution(context.TODO(), request)
 		if err != nil {
 			switch err.(type) {
 			case *persistence.ConditionFailedError,
@@ -689,7 +694,7 @@ Reset_Loop:
 	for attempt := 0; attempt < conditionalRetryCount; attempt++ {
 		currentRangeID := s.getRangeID()
 		request.RangeID = currentRangeID
-		err := s.executionManager.ResetMutableState(request)
+		err := s.executionManager.ResetMutableState(context.TODO(), request)
 		if err != nil {
 			switch err.(type) {
 			case *persistence.ConditionFailedError,
@@ -827,6 +832,10 @@ func (s *shardContextImpl) GetEventsCache() eventsCache {
 	return s.eventsCache
 }
 
+func (s *shardContextImpl) GetQueueHostRateLimiter(queue queueType) tokenbucket.TokenBucket {
+	return s.hostRateLimiters[queue]
+}
+
 func (s *shardContextImpl) GetLogger() bark.Logger {
 	return s.logger
 }
@@ -1154,6 +1163,7 @@ func acquireShard(shardItem *historyShardsItem, closeCh chan<- int) (ShardContex
 		historyV2Mgr:              shardItem.historyV2Mgr,
 		executionManager:          shardItem.executionMgr,
 		domainCache:               shardItem.domainCache,
+		hostRateLimiters:          shardItem.hostRateLimiters,
 		shardInfo:                 updatedShardInfo,
 		closeCh:                   closeCh,
 		metricsClient:             shardItem.metricsClient,
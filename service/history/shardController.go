@@ -36,6 +36,7 @@ import (
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/service"
+	"github.com/uber/cadence/common/tokenbucket"
 )
 
 const (
@@ -63,6 +64,7 @@ type (
 		throttledLoggger    bark.Logger
 		config              *Config
 		metricsClient       metrics.Client
+		hostRateLimiters    map[queueType]tokenbucket.TokenBucket
 
 		sync.RWMutex
 		historyShards map[int]*historyShardsItem
@@ -73,21 +75,22 @@ type (
 
 	historyShardsItem struct {
 		sync.RWMutex
-		shardID         int
-		status          historyShardsItemStatus
-		service         service.Service
-		shardMgr        persistence.ShardManager
-		historyMgr      persistence.HistoryManager
-		historyV2Mgr    persistence.HistoryV2Manager
-		executionMgr    persistence.ExecutionManager
-		domainCache     cache.DomainCache
-		engineFactory   EngineFactory
-		host            *membership.HostInfo
-		engine          Engine
-		config          *Config
-		logger          bark.Logger
-		throttledLogger bark.Logger
-		metricsClient   metrics.Client
+		shardID          int
+		status           historyShardsItemStatus
+		service          service.Service
+		shardMgr         persistence.ShardManager
+		historyMgr       persistence.HistoryManager
+		historyV2Mgr     persistence.HistoryV2Manager
+		executionMgr     persistence.ExecutionManager
+		domainCache      cache.DomainCache
+		engineFactory    EngineFactory
+		host             *membership.HostInfo
+		engine           Engine
+		config           *Config
+		logger           bark.Logger
+		throttledLogger  bark.Logger
+		metricsClient    metrics.Client
+		hostRateLimiters map[queueType]tokenbucket.TokenBucket
 	}
 )
 
@@ -122,13 +125,15 @@ func newShardController(svc service.Service, host *membership.HostInfo, resolver
 		throttledLoggger:    svc.GetThrottledBarkLogger(),
 		config:              config,
 		metricsClient:       metricsClient,
+		hostRateLimiters:    newHostQueueRateLimiters(config),
 	}
 }
 
 func newHistoryShardsItem(shardID int, svc service.Service, shardMgr persistence.ShardManager,
 	historyMgr persistence.HistoryManager, historyV2Mgr persistence.HistoryV2Manager, domainCache cache.DomainCache,
 	executionMgrFactory persistence.ExecutionManagerFactory, factory EngineFactory, host *membership.HostInfo,
-	config *Config, logger bark.Logger, throttledLog bark.Logger, metricsClient metrics.Client) (*historyShardsItem, error) {
+	config *Config, logger bark.Logger, throttledLog bark.Logger, metricsClient metrics.Client,
+	hostRateLimiters map[queueType]tokenbucket.TokenBucket) (*historyShardsItem, error) {
 
 	executionMgr, err := executionMgrFactory.NewExecutionManager(shardID)
 	if err != nil {
@@ -153,7 +158,8 @@ func newHistoryShardsItem(shardID int, svc service.Service, shardMgr persistence
 		throttledLogger: throttledLog.WithFields(bark.Fields{
 			logging.TagHistoryShardID: shardID,
 		}),
-		metricsClient: metricsClient,
+		metricsClient:    metricsClient,
+		hostRateLimiters: hostRateLimiters,
 	}, nil
 }
 
@@ -249,7 +255,7 @@ func (c *shardController) getOrCreateHistoryShardItem(shardID int) (*historyShar
 
 	if info.Identity() == c.host.Identity() {
 		shardItem, err := newHistoryShardsItem(shardID, c.service, c.shardMgr, c.historyMgr, c.historyV2Mgr, c.domainCache,
-			c.executionMgrFactory, c.engineFactory, c.host, c.config, c.logger, c.throttledLoggger, c.metricsClient)
+			c.executionMgrFactory, c.engineFactory, c.host, c.config, c.logger, c.throttledLoggger, c.metricsClient, c.hostRateLimiters)
 		if err != nil {
 			return nil, err
 		}
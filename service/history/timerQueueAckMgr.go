@@ -21,6 +21,7 @@
 package history
 
 import (
+	"context"
 	"math"
 	"sort"
 	"sync"
@@ -68,6 +69,9 @@ type (
 		minQueryLevel time.Time
 		maxQueryLevel time.Time
 		pageToken     []byte
+		// timer tasks up to the look ahead level are already handed out as look ahead tasks
+		lookAheadLevel    time.Time
+		lookAheadReadTime time.Time
 
 		clusterName string
 	}
@@ -159,7 +163,7 @@ func (t *timerQueueAckMgrImpl) getFinishedChan() <-chan struct{} {
 	return t.finishedChan
 }
 
-func (t *timerQueueAckMgrImpl) readTimerTasks() ([]*persistence.TimerTaskInfo, *persistence.TimerTaskInfo, bool, error) {
+func (t *timerQueueAckMgrImpl) readTimerTasks() ([]*persistence.TimerTaskInfo, []*persistence.TimerTaskInfo, bool, error) {
 	if t.maxQueryLevel == t.minQueryLevel {
 		t.maxQueryLevel = t.shard.UpdateTimerMaxReadLevel(t.clusterName)
 	}
@@ -187,10 +191,10 @@ func (t *timerQueueAckMgrImpl) readTimerTasks() ([]*persistence.TimerTaskInfo, *
 	}
 
 	// We filter tasks so read only moves to desired timer tasks.
-	// We also get a look ahead task but it doesn't move the read level, this is for timer
-	// to wait on it instead of doing queries.
+	// We also get look ahead tasks but they don't move the read level, this is for timer
+	// to wait on them instead of doing queries.
 
-	var lookAheadTask *persistence.TimerTaskInfo
+	var lookAheadTasks []*persistence.TimerTaskInfo
 	filteredTasks := []*persistence.TimerTaskInfo{}
 
 TaskFilterLoop:
@@ -205,8 +209,8 @@ TaskFilterLoop:
 		}
 
 		if !t.isProcessNow(task.VisibilityTimestamp) {
-			lookAheadTask = task                       // this means there is task in the time range (now, now + offset)
-			t.maxQueryLevel = task.VisibilityTimestamp // adjust maxQueryLevel so that this task will be read next time
+			lookAheadTasks = append(lookAheadTasks, task) // this means there is task in the time range (now, now + offset)
+			t.maxQueryLevel = task.VisibilityTimestamp    // adjust maxQueryLevel so that this task will be read next time
 			break TaskFilterLoop
 		}
 
@@ -217,7 +221,7 @@ TaskFilterLoop:
 		filteredTasks = append(filteredTasks, task)
 	}
 
-	if lookAheadTask != nil || !morePage {
+	if len(lookAheadTasks) != 0 || !morePage {
 		if t.isReadFinished {
 			t.minQueryLevel = maximumTime // set it to the maximum time to avoid any mistakenly read
 		} else {
@@ -228,9 +232,10 @@ TaskFilterLoop:
 	}
 	t.Unlock()
 
-	// only do lookahead when not in failover mode
-	if len(t.pageToken) == 0 && lookAheadTask == nil && !t.isFailover {
-		lookAheadTask, err = t.readLookAheadTask()
+	// only do lookahead when not in failover mode, and when the
+	// previously read look ahead tasks are all within the read level
+	if len(t.pageToken) == 0 && len(lookAheadTasks) == 0 && !t.isFailover && t.isLookAheadExhausted() {
+		lookAheadTasks, err = t.readLookAheadTasks()
 		if err != nil {
 			// NOTE do not return nil filtered task
 			// or otherwise the tasks are loaded and will never be dispatched
@@ -241,26 +246,47 @@ TaskFilterLoop:
 
 	// We may have large number of timers which need to be fired immediately.  Return true in such case so the pump
 	// can call back immediately to retrieve more tasks
-	moreTasks := lookAheadTask == nil && morePage
+	moreTasks := len(lookAheadTasks) == 0 && morePage
 
-	return filteredTasks, lookAheadTask, moreTasks, nil
+	return filteredTasks, lookAheadTasks, moreTasks, nil
 }
 
-// read lookAheadTask from s.GetTimerMaxReadLevel to poll interval from there.
-func (t *timerQueueAckMgrImpl) readLookAheadTask() (*persistence.TimerTaskInfo, error) {
+// read a batch of lookAheadTasks from s.GetTimerMaxReadLevel on, the timer queue processor
+// keeps them in its timer wheel, so there is no need to query again until they are all fired.
+// Timer tasks created after the read are notified to the timer queue processor.
+func (t *timerQueueAckMgrImpl) readLookAheadTasks() ([]*persistence.TimerTaskInfo, error) {
 	minQueryLevel := t.maxQueryLevel
 	maxQueryLevel := maximumTime
+	batchSize := t.config.TimerProcessorLookAheadBatchSize()
 
 	var tasks []*persistence.TimerTaskInfo
 	var err error
-	tasks, _, err = t.getTimerTasks(minQueryLevel, maxQueryLevel, 1, nil)
+	tasks, _, err = t.getTimerTasks(minQueryLevel, maxQueryLevel, batchSize, nil)
 	if err != nil {
 		return nil, err
 	}
-	if len(tasks) == 1 {
-		return tasks[0], nil
+
+	t.Lock()
+	defer t.Unlock()
+	t.lookAheadReadTime = t.timeNow()
+	if len(tasks) < batchSize {
+		t.lookAheadLevel = maximumTime
+	} else {
+		t.lookAheadLevel = tasks[len(tasks)-1].VisibilityTimestamp
+	}
+	return tasks, nil
+}
+
+// isLookAheadExhausted tells whether the look ahead tasks have to be read again, this is the case
+// when all of them are within the read level, or when they are read more than a max poll interval ago
+func (t *timerQueueAckMgrImpl) isLookAheadExhausted() bool {
+	t.Lock()
+	defer t.Unlock()
+
+	if !t.maxQueryLevel.Before(t.lookAheadLevel) {
+		return true
 	}
-	return nil, nil
+	return t.lookAheadReadTime.Add(t.config.TimerProcessorMaxPollInterval()).Before(t.timeNow())
 }
 
 func (t *timerQueueAckMgrImpl) completeTimerTask(timerTask *persistence.TimerTaskInfo) {
@@ -353,7 +379,7 @@ func (t *timerQueueAckMgrImpl) getTimerTasks(minTimestamp time.Time, maxTimestam
 
 	retryCount := t.config.TimerProcessorGetFailureRetryCount()
 	for attempt := 0; attempt < retryCount; attempt++ {
-		response, err := t.executionMgr.GetTimerIndexTasks(request)
+		response, err := t.executionMgr.GetTimerIndexTasks(context.TODO(), request)
 		if err == nil {
 			return response.Timers, response.NextPageToken, nil
 		}
@@ -213,7 +213,7 @@ func (s *timerQueueAckMgrSuite) TestGetTimerTasks_More() {
 		NextPageToken: []byte("some random output next page token"),
 	}
 
-	s.mockExecutionMgr.On("GetTimerIndexTasks", request).Return(response, nil).Once()
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything, request).Return(response, nil).Once()
 
 	timers, token, err := s.timerQueueAckMgr.getTimerTasks(minTimestamp, maxTimestamp, batchSize, request.NextPageToken)
 	s.Nil(err)
@@ -250,7 +250,7 @@ func (s *timerQueueAckMgrSuite) TestGetTimerTasks_NoMore() {
 		NextPageToken: nil,
 	}
 
-	s.mockExecutionMgr.On("GetTimerIndexTasks", request).Return(response, nil).Once()
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything, request).Return(response, nil).Once()
 
 	timers, token, err := s.timerQueueAckMgr.getTimerTasks(minTimestamp, maxTimestamp, batchSize, request.NextPageToken)
 	s.Nil(err)
@@ -287,12 +287,12 @@ func (s *timerQueueAckMgrSuite) TestReadTimerTasks_NoLookAhead_NoNextPage() {
 		NextPageToken: nil,
 	}
 	s.mockClusterMetadata.On("GetCurrentClusterName").Return(cluster.TestCurrentClusterName)
-	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything).Return(response, nil).Once()
-	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything).Return(&persistence.GetTimerIndexTasksResponse{}, nil).Once()
-	filteredTasks, lookAheadTask, moreTasks, err := s.timerQueueAckMgr.readTimerTasks()
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything, mock.Anything).Return(response, nil).Once()
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything, mock.Anything).Return(&persistence.GetTimerIndexTasksResponse{}, nil).Once()
+	filteredTasks, lookAheadTasks, moreTasks, err := s.timerQueueAckMgr.readTimerTasks()
 	s.Nil(err)
 	s.Equal([]*persistence.TimerTaskInfo{timer}, filteredTasks)
-	s.Nil(lookAheadTask)
+	s.Empty(lookAheadTasks)
 	s.False(moreTasks)
 
 	timerSequenceID := TimerSequenceID{VisibilityTimestamp: timer.VisibilityTimestamp, TaskID: timer.TaskID}
@@ -333,12 +333,12 @@ func (s *timerQueueAckMgrSuite) TestReadTimerTasks_NoLookAhead_HasNextPage() {
 		NextPageToken: []byte("some random next page token"),
 	}
 	s.mockClusterMetadata.On("GetCurrentClusterName").Return(cluster.TestCurrentClusterName)
-	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything).Return(response, nil).Once()
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything, mock.Anything).Return(response, nil).Once()
 	readTimestamp := time.Now() // the approximate time of calling readTimerTasks
-	filteredTasks, lookAheadTask, moreTasks, err := s.timerQueueAckMgr.readTimerTasks()
+	filteredTasks, lookAheadTasks, moreTasks, err := s.timerQueueAckMgr.readTimerTasks()
 	s.Nil(err)
 	s.Equal([]*persistence.TimerTaskInfo{timer}, filteredTasks)
-	s.Nil(lookAheadTask)
+	s.Empty(lookAheadTasks)
 	s.True(moreTasks)
 	timerSequenceID := TimerSequenceID{VisibilityTimestamp: timer.VisibilityTimestamp, TaskID: timer.TaskID}
 	s.Equal(map[TimerSequenceID]bool{timerSequenceID: false}, s.timerQueueAckMgr.outstandingTasks)
@@ -379,11 +379,11 @@ func (s *timerQueueAckMgrSuite) TestReadTimerTasks_HasLookAhead_NoNextPage() {
 		NextPageToken: nil,
 	}
 	s.mockClusterMetadata.On("GetCurrentClusterName").Return(cluster.TestCurrentClusterName)
-	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything).Return(response, nil).Once()
-	filteredTasks, lookAheadTask, moreTasks, err := s.timerQueueAckMgr.readTimerTasks()
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything, mock.Anything).Return(response, nil).Once()
+	filteredTasks, lookAheadTasks, moreTasks, err := s.timerQueueAckMgr.readTimerTasks()
 	s.Nil(err)
 	s.Equal([]*persistence.TimerTaskInfo{}, filteredTasks)
-	s.Equal(timer, lookAheadTask)
+	s.Equal([]*persistence.TimerTaskInfo{timer}, lookAheadTasks)
 	s.False(moreTasks)
 
 	s.Equal(map[TimerSequenceID]bool{}, s.timerQueueAckMgr.outstandingTasks)
@@ -424,11 +424,11 @@ func (s *timerQueueAckMgrSuite) TestReadTimerTasks_HasLookAhead_HasNextPage() {
 		NextPageToken: []byte("some random next page token"),
 	}
 	s.mockClusterMetadata.On("GetCurrentClusterName").Return(cluster.TestCurrentClusterName)
-	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything).Return(response, nil).Once()
-	filteredTasks, lookAheadTask, moreTasks, err := s.timerQueueAckMgr.readTimerTasks()
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything, mock.Anything).Return(response, nil).Once()
+	filteredTasks, lookAheadTasks, moreTasks, err := s.timerQueueAckMgr.readTimerTasks()
 	s.Nil(err)
 	s.Equal([]*persistence.TimerTaskInfo{}, filteredTasks)
-	s.Equal(timer, lookAheadTask)
+	s.Equal([]*persistence.TimerTaskInfo{timer}, lookAheadTasks)
 	s.False(moreTasks)
 
 	s.Equal(map[TimerSequenceID]bool{}, s.timerQueueAckMgr.outstandingTasks)
@@ -480,12 +480,12 @@ func (s *timerQueueAckMgrSuite) TestReadCompleteUpdateTimerTasks() {
 		NextPageToken: nil,
 	}
 	s.mockClusterMetadata.On("GetCurrentClusterName").Return(cluster.TestCurrentClusterName)
-	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything).Return(response, nil).Once()
-	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything).Return(&persistence.GetTimerIndexTasksResponse{}, nil).Once()
-	filteredTasks, lookAheadTask, moreTasks, err := s.timerQueueAckMgr.readTimerTasks()
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything, mock.Anything).Return(response, nil).Once()
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything, mock.Anything).Return(&persistence.GetTimerIndexTasksResponse{}, nil).Once()
+	filteredTasks, lookAheadTasks, moreTasks, err := s.timerQueueAckMgr.readTimerTasks()
 	s.Nil(err)
 	s.Equal([]*persistence.TimerTaskInfo{timer1, timer2, timer3}, filteredTasks)
-	s.Nil(lookAheadTask)
+	s.Empty(lookAheadTasks)
 	s.False(moreTasks)
 
 	// we are not testing shard context
@@ -513,7 +513,7 @@ func (s *timerQueueAckMgrSuite) TestReadCompleteUpdateTimerTasks() {
 	s.Equal(timer3.VisibilityTimestamp, s.mockShard.GetTimerClusterAckLevel(s.clusterName))
 }
 
-func (s *timerQueueAckMgrSuite) TestReadLookAheadTask() {
+func (s *timerQueueAckMgrSuite) TestReadLookAheadTasks() {
 	s.mockClusterMetadata.On("GetCurrentClusterName").Return(s.clusterName)
 	level := s.mockShard.UpdateTimerMaxReadLevel(s.clusterName)
 	s.timerQueueAckMgr.minQueryLevel = level
@@ -537,10 +537,13 @@ func (s *timerQueueAckMgrSuite) TestReadLookAheadTask() {
 		Timers:        []*persistence.TimerTaskInfo{timer},
 		NextPageToken: []byte("some random next page token"),
 	}
-	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything).Return(response, nil).Once()
-	lookAheadTask, err := s.timerQueueAckMgr.readLookAheadTask()
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything, mock.Anything).Return(response, nil).Once()
+	lookAheadTasks, err := s.timerQueueAckMgr.readLookAheadTasks()
 	s.Nil(err)
-	s.Equal(timer, lookAheadTask)
+	s.Equal([]*persistence.TimerTaskInfo{timer}, lookAheadTasks)
+	// less tasks than the batch size, so all upcoming timer tasks are known
+	s.Equal(maximumTime, s.timerQueueAckMgr.lookAheadLevel)
+	s.False(s.timerQueueAckMgr.isLookAheadExhausted())
 }
 
 // Tests for failover ack manager
@@ -683,12 +686,12 @@ func (s *timerQueueFailoverAckMgrSuite) TestReadTimerTasks_HasNextPage() {
 		NextPageToken: []byte("some random next page token"),
 	}
 
-	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything).Return(response, nil).Once()
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything, mock.Anything).Return(response, nil).Once()
 	readTimestamp := time.Now() // the approximate time of calling readTimerTasks
-	timers, lookAheadTimer, more, err := s.timerQueueFailoverAckMgr.readTimerTasks()
+	timers, lookAheadTimers, more, err := s.timerQueueFailoverAckMgr.readTimerTasks()
 	s.Nil(err)
 	s.Equal([]*persistence.TimerTaskInfo{timer1, timer2}, timers)
-	s.Nil(lookAheadTimer)
+	s.Empty(lookAheadTimers)
 	s.True(more)
 	s.Equal(ackLevel, s.timerQueueFailoverAckMgr.ackLevel)
 	s.Equal(minQueryLevel, s.timerQueueFailoverAckMgr.minQueryLevel)
@@ -714,13 +717,13 @@ func (s *timerQueueFailoverAckMgrSuite) TestReadTimerTasks_NoNextPage() {
 		NextPageToken: nil,
 	}
 	s.mockClusterMetadata.On("GetCurrentClusterName").Return(cluster.TestCurrentClusterName)
-	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything).Return(response, nil).Once()
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything, mock.Anything).Return(response, nil).Once()
 
 	readTimestamp := time.Now() // the approximate time of calling readTimerTasks
-	timers, lookAheadTimer, more, err := s.timerQueueFailoverAckMgr.readTimerTasks()
+	timers, lookAheadTimers, more, err := s.timerQueueFailoverAckMgr.readTimerTasks()
 	s.Nil(err)
 	s.Equal([]*persistence.TimerTaskInfo{}, timers)
-	s.Nil(lookAheadTimer)
+	s.Empty(lookAheadTimers)
 	s.False(more)
 
 	s.Equal(ackLevel, s.timerQueueFailoverAckMgr.ackLevel)
@@ -739,10 +742,10 @@ func (s *timerQueueFailoverAckMgrSuite) TestReadTimerTasks_InTheFuture() {
 	s.timerQueueFailoverAckMgr.minQueryLevel = maxQueryLevel.Add(1 * time.Second)
 	s.timerQueueFailoverAckMgr.maxQueryLevel = maxQueryLevel
 
-	timers, lookAheadTimer, more, err := s.timerQueueFailoverAckMgr.readTimerTasks()
+	timers, lookAheadTimers, more, err := s.timerQueueFailoverAckMgr.readTimerTasks()
 	s.Nil(err)
 	s.Equal(0, len(timers))
-	s.Nil(lookAheadTimer)
+	s.Empty(lookAheadTimers)
 	s.False(more)
 
 	s.Equal(ackLevel, s.timerQueueFailoverAckMgr.ackLevel)
@@ -796,11 +799,11 @@ func (s *timerQueueFailoverAckMgrSuite) TestReadCompleteUpdateTimerTasks() {
 		NextPageToken: nil,
 	}
 	s.mockClusterMetadata.On("GetCurrentClusterName").Return(cluster.TestCurrentClusterName)
-	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything).Return(response, nil).Once()
-	filteredTasks, lookAheadTask, moreTasks, err := s.timerQueueFailoverAckMgr.readTimerTasks()
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything, mock.Anything).Return(response, nil).Once()
+	filteredTasks, lookAheadTasks, moreTasks, err := s.timerQueueFailoverAckMgr.readTimerTasks()
 	s.Nil(err)
 	s.Equal([]*persistence.TimerTaskInfo{timer1, timer2, timer3}, filteredTasks)
-	s.Nil(lookAheadTask)
+	s.Empty(lookAheadTasks)
 	s.False(moreTasks)
 
 	timerSequenceID2 := TimerSequenceID{VisibilityTimestamp: timer2.VisibilityTimestamp, TaskID: timer2.TaskID}
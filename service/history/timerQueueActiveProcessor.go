@@ -248,6 +248,12 @@ func (t *timerQueueActiveProcessorImpl) process(timerTask *persistence.TimerTask
 		}
 		return metrics.TimerActiveTaskWorkflowBackoffTimerScope, err
 
+	case persistence.TaskTypeDelayedSignal:
+		if shouldProcessTask {
+			err = t.processDelayedSignal(timerTask)
+		}
+		return metrics.TimerActiveTaskDelayedSignalScope, err
+
 	case persistence.TaskTypeDeleteHistoryEvent:
 		if shouldProcessTask {
 			err = t.timerQueueProcessorBase.processDeleteHistoryEvent(timerTask)
@@ -523,6 +529,7 @@ Update_History_Loop:
 			if di.Attempt == task.ScheduleAttempt {
 				// Add a decision task timeout event.
 				msBuilder.AddDecisionTaskTimedOutEvent(scheduleID, di.StartedID)
+				t.checkStuckDecision(msBuilder, di)
 				scheduleNewDecision = true
 			}
 		case int(workflow.TimeoutTypeScheduleToStart):
@@ -559,6 +566,35 @@ Update_History_Loop:
 	return ErrMaxAttemptsExceeded
 }
 
+// checkStuckDecision reports executions whose decisions keep timing out. Repeatedly timed out decisions are
+// transient and never show up in history, so once the threshold is reached the decision attempt is reset,
+// which makes the next decision and its outcome to be recorded in history.
+// Stickiness does not need to be handled here, it is already cleared whenever a decision times out.
+func (t *timerQueueActiveProcessorImpl) checkStuckDecision(msBuilder mutableState, di *decisionInfo) {
+	executionInfo := msBuilder.GetExecutionInfo()
+	domainEntry, err := t.shard.GetDomainCache().GetDomainByID(executionInfo.DomainID)
+	if err != nil {
+		return
+	}
+
+	threshold := int64(t.config.StuckDecisionTimeoutThreshold(domainEntry.GetInfo().Name))
+	// attempt is zero based, and only counts the decisions which failed or timed out in a row
+	if threshold <= 0 || (di.Attempt+1)%threshold != 0 {
+		return
+	}
+
+	t.metricsClient.IncCounter(metrics.TimerActiveTaskDecisionTimeoutScope, metrics.StuckDecisionCounter)
+	t.logger.WithFields(bark.Fields{
+		logging.TagDomainID:            executionInfo.DomainID,
+		logging.TagWorkflowExecutionID: executionInfo.WorkflowID,
+		logging.TagWorkflowRunID:       executionInfo.RunID,
+		logging.TagScheduleID:          di.ScheduleID,
+		logging.TagAttempt:             di.Attempt,
+	}).Warn("Decision task timed out repeatedly, workflow execution may be stuck")
+
+	executionInfo.DecisionAttempt = 0
+}
+
 func (t *timerQueueActiveProcessorImpl) processWorkflowBackoffTimer(task *persistence.TimerTaskInfo) (retError error) {
 
 	context, release, err0 := t.cache.getOrCreateWorkflowExecution(t.timerQueueProcessorBase.getDomainIDAndWorkflowExecution(task))
@@ -601,6 +637,67 @@ Update_History_Loop:
 	return ErrMaxAttemptsExceeded
 }
 
+func (t *timerQueueActiveProcessorImpl) processDelayedSignal(task *persistence.TimerTaskInfo) (retError error) {
+
+	context, release, err0 := t.cache.getOrCreateWorkflowExecution(t.timerQueueProcessorBase.getDomainIDAndWorkflowExecution(task))
+	if err0 != nil {
+		return err0
+	}
+	defer func() { release(retError) }()
+
+	domainEntry, err := t.shard.GetDomainCache().GetDomainByID(task.DomainID)
+	if err != nil {
+		return err
+	}
+	domainName := domainEntry.GetInfo().Name
+
+Update_History_Loop:
+	for attempt := 0; attempt < conditionalRetryCount; attempt++ {
+		msBuilder, err := loadMutableStateForTimerTask(context, task, t.metricsClient, t.logger)
+		if err != nil {
+			return err
+		} else if msBuilder == nil || !msBuilder.IsWorkflowExecutionRunning() {
+			// the workflow closed before the delivery time, the signal is dropped
+			t.metricsClient.IncCounter(metrics.TimerActiveTaskDelayedSignalScope, metrics.DelayedSignalDroppedCount)
+			return nil
+		}
+
+		executionInfo := msBuilder.GetExecutionInfo()
+		maxAllowedSignals := t.config.MaximumSignalsPerExecution(domainName)
+		if maxAllowedSignals > 0 && int(executionInfo.SignalCount) >= maxAllowedSignals {
+			t.metricsClient.IncCounter(metrics.TimerActiveTaskDelayedSignalScope, metrics.DelayedSignalDroppedCount)
+			t.logger.WithFields(bark.Fields{
+				logging.TagDomainID:            executionInfo.DomainID,
+				logging.TagWorkflowExecutionID: executionInfo.WorkflowID,
+				logging.TagWorkflowRunID:       executionInfo.RunID,
+				logging.TagSignalCount:         executionInfo.SignalCount,
+			}).Info("Execution limit reached for maximum signals, dropping delayed signal")
+			return nil
+		}
+
+		// the request id deduplicates both the retried requests and the reprocessing of this task
+		if t.historyService.isDuplicateSignal(msBuilder, domainName, task.SignalRequestID) {
+			return nil
+		}
+
+		if msBuilder.AddWorkflowExecutionSignaled(task.SignalName, task.SignalInput, task.SignalIdentity) == nil {
+			return &workflow.InternalServiceError{Message: "Unable to signal workflow execution."}
+		}
+
+		err = t.updateWorkflowExecution(context, msBuilder, true, false, nil, nil)
+		if err != nil {
+			if err == ErrConflict {
+				continue Update_History_Loop
+			}
+			return err
+		}
+		t.metricsClient.IncCounter(metrics.TimerActiveTaskDelayedSignalScope, metrics.DelayedSignalDeliveredCount)
+		return nil
+	}
+
+	return ErrMaxAttemptsExceeded
+}
+
 func (t *timerQueueActiveProcessorImpl) processActivityRetryTimer(task *persistence.TimerTaskInfo) error {
 
 	processFn := func() error {
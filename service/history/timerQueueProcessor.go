@@ -24,6 +24,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -47,21 +48,23 @@ type (
 	timerQueueShutdown      func() error
 	timerTaskFilter         func(timer *persistence.TimerTaskInfo) (bool, error)
 	timerQueueProcessorImpl struct {
-		isGlobalDomainEnabled  bool
-		currentClusterName     string
-		shard                  ShardContext
-		taskAllocator          taskAllocator
-		config                 *Config
-		metricsClient          metrics.Client
-		visibilityProducer     messaging.Producer
-		historyService         *historyEngineImpl
-		ackLevel               TimerSequenceID
-		logger                 bark.Logger
-		matchingClient         matching.Client
-		isStarted              int32
-		isStopped              int32
-		shutdownChan           chan struct{}
-		activeTimerProcessor   *timerQueueActiveProcessorImpl
+		isGlobalDomainEnabled bool
+		currentClusterName    string
+		shard                 ShardContext
+		taskAllocator         taskAllocator
+		config                *Config
+		metricsClient         metrics.Client
+		visibilityProducer    messaging.Producer
+		historyService        *historyEngineImpl
+		ackLevel              TimerSequenceID
+		logger                bark.Logger
+		matchingClient        matching.Client
+		isStarted             int32
+		isStopped             int32
+		shutdownChan          chan struct{}
+		activeTimerProcessor  *timerQueueActiveProcessorImpl
+
+		sync.RWMutex
 		standbyTimerProcessors map[string]*timerQueueStandbyProcessorImpl
 	}
 )
@@ -72,27 +75,7 @@ func newTimerQueueProcessor(shard ShardContext, historyService *historyEngineImp
 		logging.TagWorkflowComponent: logging.TagValueTimerQueueComponent,
 	})
 	taskAllocator := newTaskAllocator(shard)
-	standbyTimerProcessors := make(map[string]*timerQueueStandbyProcessorImpl)
-	for clusterName := range shard.GetService().GetClusterMetadata().GetAllClusterFailoverVersions() {
-		if clusterName != shard.GetService().GetClusterMetadata().GetCurrentClusterName() {
-			historyRereplicator := xdc.NewHistoryRereplicator(
-				currentClusterName,
-				shard.GetDomainCache(),
-				shard.GetService().GetClientBean().GetRemoteAdminClient(clusterName),
-				func(ctx context.Context, request *h.ReplicateRawEventsRequest) error {
-					return historyService.ReplicateRawEvents(ctx, request)
-				},
-				persistence.NewHistorySerializer(),
-				historyRereplicationTimeout,
-				logger,
-			)
-			standbyTimerProcessors[clusterName] = newTimerQueueStandbyProcessor(
-				shard, historyService, clusterName, taskAllocator, historyRereplicator, visibilityProducer, logger,
-			)
-		}
-	}
-
-	return &timerQueueProcessorImpl{
+	processor := &timerQueueProcessorImpl{
 		isGlobalDomainEnabled:  shard.GetService().GetClusterMetadata().IsGlobalDomainEnabled(),
 		currentClusterName:     currentClusterName,
 		shard:                  shard,
@@ -106,8 +89,59 @@ func newTimerQueueProcessor(shard ShardContext, historyService *historyEngineImp
 		matchingClient:         matchingClient,
 		shutdownChan:           make(chan struct{}),
 		activeTimerProcessor:   newTimerQueueActiveProcessor(shard, historyService, matchingClient, taskAllocator, visibilityProducer, logger),
-		standbyTimerProcessors: standbyTimerProcessors,
+		standbyTimerProcessors: make(map[string]*timerQueueStandbyProcessorImpl),
+	}
+	for clusterName := range shard.GetService().GetClusterMetadata().GetAllClusterFailoverVersions() {
+		if clusterName != currentClusterName {
+			processor.standbyTimerProcessors[clusterName] = processor.newStandbyProcessor(clusterName)
+		}
+	}
+	return processor
+}
+
+func (t *timerQueueProcessorImpl) newStandbyProcessor(clusterName string) *timerQueueStandbyProcessorImpl {
+	historyService := t.historyService
+	historyRereplicator := xdc.NewHistoryRereplicator(
+		t.currentClusterName,
+		t.shard.GetDomainCache(),
+		t.shard.GetService().GetClientBean().GetRemoteAdminClient(clusterName),
+		func(ctx context.Context, request *h.ReplicateRawEventsRequest) error {
+			return historyService.ReplicateRawEvents(ctx, request)
+		},
+		persistence.NewHistorySerializer(),
+		historyRereplicationTimeout,
+		t.logger,
+	)
+	return newTimerQueueStandbyProcessor(
+		t.shard, historyService, clusterName, t.taskAllocator, historyRereplicator, t.visibilityProducer, t.logger,
+	)
+}
+
+// getStandbyProcessor returns the standby processor of the given cluster, the processor
+// is created on demand for a remote cluster added after this processor was created
+func (t *timerQueueProcessorImpl) getStandbyProcessor(clusterName string) *timerQueueStandbyProcessorImpl {
+	t.RLock()
+	standbyTimerProcessor, ok := t.standbyTimerProcessors[clusterName]
+	t.RUnlock()
+	if ok {
+		return standbyTimerProcessor
+	}
+
+	if _, ok := t.shard.GetService().GetClusterMetadata().GetAllClusterFailoverVersions()[clusterName]; !ok {
+		panic(fmt.Sprintf("Cannot find timer processor for %s.", clusterName))
+	}
+
+	t.Lock()
+	defer t.Unlock()
+	if standbyTimerProcessor, ok := t.standbyTimerProcessors[clusterName]; ok {
+		return standbyTimerProcessor
+	}
+	standbyTimerProcessor = t.newStandbyProcessor(clusterName)
+	t.standbyTimerProcessors[clusterName] = standbyTimerProcessor
+	if t.isGlobalDomainEnabled && atomic.LoadInt32(&t.isStarted) == 1 && atomic.LoadInt32(&t.isStopped) == 0 {
+		standbyTimerProcessor.Start()
 	}
+	return standbyTimerProcessor
 }
 
 func (t *timerQueueProcessorImpl) Start() {
@@ -116,9 +150,11 @@ func (t *timerQueueProcessorImpl) Start() {
 	}
 	t.activeTimerProcessor.Start()
 	if t.isGlobalDomainEnabled {
+		t.RLock()
 		for _, standbyTimerProcessor := range t.standbyTimerProcessors {
 			standbyTimerProcessor.Start()
 		}
+		t.RUnlock()
 	}
 	go t.completeTimersLoop()
 }
@@ -129,9 +165,11 @@ func (t *timerQueueProcessorImpl) Stop() {
 	}
 	t.activeTimerProcessor.Stop()
 	if t.isGlobalDomainEnabled {
+		t.RLock()
 		for _, standbyTimerProcessor := range t.standbyTimerProcessors {
 			standbyTimerProcessor.Stop()
 		}
+		t.RUnlock()
 	}
 	close(t.shutdownChan)
 }
@@ -144,10 +182,7 @@ func (t *timerQueueProcessorImpl) NotifyNewTimers(clusterName string, currentTim
 		return
 	}
 
-	standbyTimerProcessor, ok := t.standbyTimerProcessors[clusterName]
-	if !ok {
-		panic(fmt.Sprintf("Cannot find timer processor for %s.", clusterName))
-	}
+	standbyTimerProcessor := t.getStandbyProcessor(clusterName)
 	standbyTimerProcessor.setCurrentTime(currentTime)
 	standbyTimerProcessor.notifyNewTimers(timerTasks)
 	standbyTimerProcessor.retryTasks()
@@ -170,9 +205,11 @@ func (t *timerQueueProcessorImpl) FailoverDomain(domainIDs map[string]struct{})
 	updateShardAckLevel, failoverTimerProcessor := newTimerQueueFailoverProcessor(t.shard, t.historyService, domainIDs,
 		standbyClusterName, minLevel, maxLevel, t.matchingClient, t.taskAllocator, t.visibilityProducer, t.logger)
 
+	t.RLock()
 	for _, standbyTimerProcessor := range t.standbyTimerProcessors {
 		standbyTimerProcessor.retryTasks()
 	}
+	t.RUnlock()
 
 	// NOTE: READ REF BEFORE MODIFICATION
 	// ref: historyEngine.go registerDomainFailoverCallback function
@@ -193,10 +230,7 @@ func (t *timerQueueProcessorImpl) getTimerFiredCount(clusterName string) uint64
 		return t.activeTimerProcessor.getTimerFiredCount()
 	}
 
-	standbyTimerProcessor, ok := t.standbyTimerProcessors[clusterName]
-	if !ok {
-		panic(fmt.Sprintf("Cannot find timer processor for %s.", clusterName))
-	}
+	standbyTimerProcessor := t.getStandbyProcessor(clusterName)
 	return standbyTimerProcessor.getTimerFiredCount()
 }
 
@@ -231,12 +265,14 @@ func (t *timerQueueProcessorImpl) completeTimers() error {
 	upperAckLevel := t.activeTimerProcessor.timerQueueAckMgr.getAckLevel()
 
 	if t.isGlobalDomainEnabled {
+		t.RLock()
 		for _, standbyTimerProcessor := range t.standbyTimerProcessors {
 			ackLevel := standbyTimerProcessor.timerQueueAckMgr.getAckLevel()
 			if !compareTimerIDLess(&upperAckLevel, &ackLevel) {
 				upperAckLevel = ackLevel
 			}
 		}
+		t.RUnlock()
 
 		for _, failoverInfo := range t.shard.GetAllTimerFailoverLevels() {
 			if !upperAckLevel.VisibilityTimestamp.Before(failoverInfo.MinLevel) {
@@ -253,7 +289,7 @@ func (t *timerQueueProcessorImpl) completeTimers() error {
 	t.metricsClient.IncCounter(metrics.TimerQueueProcessorScope, metrics.TaskBatchCompleteCounter)
 
 	if lowerAckLevel.VisibilityTimestamp.Before(upperAckLevel.VisibilityTimestamp) {
-		err := t.shard.GetExecutionManager().RangeCompleteTimerTask(&persistence.RangeCompleteTimerTaskRequest{
+		err := t.shard.GetExecutionManager().RangeCompleteTimerTask(context.TODO(), &persistence.RangeCompleteTimerTaskRequest{
 			InclusiveBeginTimestamp: lowerAckLevel.VisibilityTimestamp,
 			ExclusiveEndTimestamp:   upperAckLevel.VisibilityTimestamp,
 		})
@@ -44,6 +44,7 @@ import (
 	"github.com/uber/cadence/common/persistence"
 	p "github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/service"
+	"github.com/uber/cadence/common/service/dynamicconfig"
 )
 
 type (
@@ -99,7 +100,7 @@ func (s *timerQueueProcessor2Suite) SetupTest() {
 	s.mockMetadataMgr = &mocks.MetadataManager{}
 	s.mockClusterMetadata = &mocks.ClusterMetadata{}
 	// ack manager will use the domain information
-	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(
+	s.mockMetadataMgr.On("GetDomain", mock.Anything, mock.Anything).Return(
 		&persistence.GetDomainResponse{
 			Info:   &persistence.DomainInfo{ID: "domainID"},
 			Config: &persistence.DomainConfig{Retention: 1},
@@ -213,23 +214,23 @@ func (s *timerQueueProcessor2Suite) TestTimerUpdateTimesOut() {
 		EventID:             di.ScheduleID}
 	timerIndexResponse := &persistence.GetTimerIndexTasksResponse{Timers: []*persistence.TimerTaskInfo{timerTask}}
 
-	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything).Return(timerIndexResponse, nil).Once()
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything, mock.Anything).Return(timerIndexResponse, nil).Once()
 
 	for i := 0; i < 2; i++ {
 		ms := createMutableState(builder)
 		wfResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
-		s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(wfResponse, nil).Once()
+		s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(wfResponse, nil).Once()
 	}
 
-	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything).Return(
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything, mock.Anything).Return(
 		&persistence.GetTimerIndexTasksResponse{Timers: []*persistence.TimerTaskInfo{}}, nil)
 
 	s.mockHistoryV2Mgr.On("AppendHistoryNodes", mock.Anything).Return(&p.AppendHistoryNodesResponse{Size: 0}, nil).Once()
-	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(&p.UpdateWorkflowExecutionResponse{MutableStateUpdateSessionStats: &p.MutableStateUpdateSessionStats{}}, errors.New("FAILED")).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(&p.UpdateWorkflowExecutionResponse{MutableStateUpdateSessionStats: &p.MutableStateUpdateSessionStats{}}, errors.New("FAILED")).Once()
 	s.mockShardManager.On("UpdateShard", mock.Anything).Return(nil)
 
 	s.mockHistoryV2Mgr.On("AppendHistoryNodes", mock.Anything).Return(&p.AppendHistoryNodesResponse{Size: 0}, nil).Once()
-	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(&p.UpdateWorkflowExecutionResponse{MutableStateUpdateSessionStats: &p.MutableStateUpdateSessionStats{}}, nil).Run(func(arguments mock.Arguments) {
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(&p.UpdateWorkflowExecutionResponse{MutableStateUpdateSessionStats: &p.MutableStateUpdateSessionStats{}}, nil).Run(func(arguments mock.Arguments) {
 		// Done.
 		waitCh <- struct{}{}
 	}).Once()
@@ -249,6 +250,88 @@ func (s *timerQueueProcessor2Suite) TestTimerUpdateTimesOut() {
 	s.mockHistoryEngine.timerProcessor.(*timerQueueProcessorImpl).activeTimerProcessor.Stop()
 }
 
+func (s *timerQueueProcessor2Suite) TestStuckDecisionRecordedInHistory() {
+	domainID := testDomainActiveID
+	we := workflow.WorkflowExecution{WorkflowId: common.StringPtr("stuck-decision-test"),
+		RunId: common.StringPtr(validRunID)}
+	taskList := "stuck-decision-queue"
+
+	s.config.StuckDecisionTimeoutThreshold = dynamicconfig.GetIntPropertyFilteredByDomain(1)
+	defer func() {
+		s.config.StuckDecisionTimeoutThreshold = dynamicconfig.GetIntPropertyFilteredByDomain(10)
+	}()
+
+	builder := newMutableStateBuilderWithEventV2(cluster.TestCurrentClusterName, s.mockShard, s.mockEventsCache, s.logger, we.GetRunId())
+	s.mockEventsCache.On("putEvent", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+		mock.Anything).Return().Once()
+	startRequest := &workflow.StartWorkflowExecutionRequest{
+		WorkflowType:                        &workflow.WorkflowType{Name: common.StringPtr("wType")},
+		TaskList:                            common.TaskListPtr(workflow.TaskList{Name: common.StringPtr(taskList)}),
+		ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(2),
+		TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(1),
+	}
+	builder.AddWorkflowExecutionStartedEvent(we, &history.StartWorkflowExecutionRequest{
+		DomainUUID:   common.StringPtr(domainID),
+		StartRequest: startRequest,
+	})
+
+	di := addDecisionTaskScheduledEvent(builder)
+	addDecisionTaskStartedEvent(builder, di.ScheduleID, taskList, uuid.New())
+
+	waitCh := make(chan struct{})
+
+	mockTS := &mockTimeSource{currTime: time.Now()}
+
+	timerTask := &persistence.TimerTaskInfo{
+		DomainID:   domainID,
+		WorkflowID: "wid",
+		RunID:      validRunID,
+		TaskID:     int64(100),
+		TaskType:   persistence.TaskTypeDecisionTimeout, TimeoutType: int(workflow.TimeoutTypeStartToClose),
+		VisibilityTimestamp: mockTS.Now(),
+		EventID:             di.ScheduleID}
+	timerIndexResponse := &persistence.GetTimerIndexTasksResponse{Timers: []*persistence.TimerTaskInfo{timerTask}}
+
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything, mock.Anything).Return(timerIndexResponse, nil).Once()
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything, mock.Anything).Return(
+		&persistence.GetTimerIndexTasksResponse{Timers: []*persistence.TimerTaskInfo{}}, nil)
+
+	ms := createMutableState(builder)
+	wfResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(wfResponse, nil).Once()
+	s.mockShardManager.On("UpdateShard", mock.Anything).Return(nil)
+
+	decisionScheduledEvent := false
+	s.mockHistoryV2Mgr.On("AppendHistoryNodes", mock.Anything).Return(&p.AppendHistoryNodesResponse{Size: 0}, nil).Run(func(arguments mock.Arguments) {
+		req := arguments.Get(0).(*persistence.AppendHistoryNodesRequest)
+		for _, event := range req.Events {
+			if event.GetEventType() == workflow.EventTypeDecisionTaskScheduled {
+				decisionScheduledEvent = true
+			}
+		}
+	}).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(&p.UpdateWorkflowExecutionResponse{MutableStateUpdateSessionStats: &p.MutableStateUpdateSessionStats{}}, nil).Run(func(arguments mock.Arguments) {
+		req := arguments.Get(0).(*persistence.UpdateWorkflowExecutionRequest)
+		s.Equal(int64(0), req.ExecutionInfo.DecisionAttempt)
+		waitCh <- struct{}{}
+	}).Once()
+
+	// Start timer Processor.
+	s.mockHistoryEngine.timerProcessor.(*timerQueueProcessorImpl).activeTimerProcessor.Start()
+
+	s.mockHistoryEngine.timerProcessor.NotifyNewTimers(
+		cluster.TestCurrentClusterName,
+		s.mockShard.GetCurrentTime(cluster.TestCurrentClusterName),
+		[]persistence.Task{&persistence.DecisionTimeoutTask{
+			VisibilityTimestamp: timerTask.VisibilityTimestamp,
+			EventID:             timerTask.EventID,
+		}})
+
+	<-waitCh
+	s.mockHistoryEngine.timerProcessor.(*timerQueueProcessorImpl).activeTimerProcessor.Stop()
+	s.True(decisionScheduledEvent)
+}
+
 func (s *timerQueueProcessor2Suite) TestWorkflowTimeout() {
 	domainID := testDomainActiveID
 	we := workflow.WorkflowExecution{WorkflowId: common.StringPtr("workflow-timesout-test"),
@@ -289,10 +372,10 @@ func (s *timerQueueProcessor2Suite) TestWorkflowTimeout() {
 
 	ms := createMutableState(builder)
 	wfResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
-	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(wfResponse, nil).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(wfResponse, nil).Once()
 
 	s.mockHistoryV2Mgr.On("AppendHistoryNodes", mock.Anything).Return(&p.AppendHistoryNodesResponse{Size: 0}, nil).Once()
-	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(&p.UpdateWorkflowExecutionResponse{MutableStateUpdateSessionStats: &p.MutableStateUpdateSessionStats{}}, nil).Run(func(arguments mock.Arguments) {
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(&p.UpdateWorkflowExecutionResponse{MutableStateUpdateSessionStats: &p.MutableStateUpdateSessionStats{}}, nil).Run(func(arguments mock.Arguments) {
 		// Done.
 		waitCh <- struct{}{}
 	}).Once()
@@ -301,18 +384,18 @@ func (s *timerQueueProcessor2Suite) TestWorkflowTimeout() {
 
 	// Start timer Processor.
 	emptyResponse := &persistence.GetTimerIndexTasksResponse{Timers: []*persistence.TimerTaskInfo{}}
-	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything).Return(emptyResponse, nil).Run(func(arguments mock.Arguments) {
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything, mock.Anything).Return(emptyResponse, nil).Run(func(arguments mock.Arguments) {
 		waitCh <- struct{}{}
 	}).Once()
-	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything).Return(emptyResponse, nil).Run(func(arguments mock.Arguments) {
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything, mock.Anything).Return(emptyResponse, nil).Run(func(arguments mock.Arguments) {
 		waitCh <- struct{}{}
-	}).Once() // for lookAheadTask
+	}).Once() // for lookAheadTasks
 	s.mockHistoryEngine.timerProcessor.(*timerQueueProcessorImpl).activeTimerProcessor.Start()
 	<-waitCh
 	<-waitCh
 
-	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything).Return(timerIndexResponse, nil).Once()
-	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything).Return(emptyResponse, nil) // for lookAheadTask
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything, mock.Anything).Return(timerIndexResponse, nil).Once()
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything, mock.Anything).Return(emptyResponse, nil).Maybe() // look ahead tasks are read on start already
 	s.mockHistoryEngine.timerProcessor.NotifyNewTimers(
 		cluster.TestCurrentClusterName,
 		s.mockShard.GetCurrentTime(cluster.TestCurrentClusterName),
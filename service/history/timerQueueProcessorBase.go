@@ -21,6 +21,7 @@
 package history
 
 import (
+	"context"
 	"errors"
 	"math"
 	"sync"
@@ -35,6 +36,7 @@ import (
 	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/clock"
 	"github.com/uber/cadence/common/cluster"
+	es "github.com/uber/cadence/common/elasticsearch"
 	"github.com/uber/cadence/common/logging"
 	"github.com/uber/cadence/common/messaging"
 	"github.com/uber/cadence/common/metrics"
@@ -72,7 +74,9 @@ type (
 		timerProcessor     timerProcessor
 		timerQueueAckMgr   timerQueueAckMgr
 		timerGate          TimerGate
+		timerWheel         *timerWheel
 		rateLimiter        tokenbucket.TokenBucket
+		hostRateLimiter    tokenbucket.TokenBucket
 		startDelay         dynamicconfig.DurationPropertyFn
 		retryPolicy        backoff.RetryPolicy
 		visibilityProducer messaging.Producer
@@ -83,6 +87,8 @@ type (
 		numOfWorker int
 
 		lastPollTime time.Time
+		// the time the timer gate is set to fire at
+		nextWakeupTime time.Time
 
 		// timer notification
 		newTimerCh  chan struct{}
@@ -120,11 +126,13 @@ func newTimerQueueProcessorBase(scope int, shard ShardContext, historyService *h
 		metricsClient:           historyService.metricsClient,
 		timerQueueAckMgr:        timerQueueAckMgr,
 		timerGate:               timerGate,
+		timerWheel:              newTimerWheel(shard.GetConfig().TimerProcessorWheelTickInterval(), time.Now()),
 		numOfWorker:             numOfWorker,
 		workerNotificationChans: workerNotificationChans,
 		newTimerCh:              make(chan struct{}, 1),
 		lastPollTime:            time.Time{},
-		rateLimiter:             tokenbucket.New(maxPollRPS(), clock.NewRealTimeSource()),
+		rateLimiter:             tokenbucket.NewDynamicTokenBucket(maxPollRPS, clock.NewRealTimeSource()),
+		hostRateLimiter:         shard.GetQueueHostRateLimiter(timerQueueType),
 		startDelay:              startDelay,
 		retryPolicy:             common.CreatePersistanceRetryPolicy(),
 		visibilityProducer:      visibilityProducer,
@@ -271,6 +279,12 @@ func (t *timerQueueProcessorBase) notifyNewTimers(timerTasks []persistence.Task)
 			} else {
 				t.metricsClient.IncCounter(metrics.TimerStandbyTaskWorkflowBackoffTimerScope, metrics.NewTimerCounter)
 			}
+		case persistence.TaskTypeDelayedSignal:
+			if isActive {
+				t.metricsClient.IncCounter(metrics.TimerActiveTaskDelayedSignalScope, metrics.NewTimerCounter)
+			} else {
+				t.metricsClient.IncCounter(metrics.TimerStandbyTaskDelayedSignalScope, metrics.NewTimerCounter)
+			}
 			// TODO add default
 		}
 	}
@@ -324,26 +338,25 @@ func (t *timerQueueProcessorBase) internalProcessor() error {
 			go t.Stop()
 			return nil
 		case <-t.timerGate.FireChan():
-			lookAheadTimer, err := t.readAndFanoutTimerTasks()
+			t.timerWheel.advance(t.nextWakeupTime)
+			lookAheadTimers, err := t.readAndFanoutTimerTasks()
 			if err != nil {
 				return err
 			}
-			if lookAheadTimer != nil {
-				t.timerGate.Update(lookAheadTimer.VisibilityTimestamp)
-			}
+			t.addLookAheadTimers(lookAheadTimers)
+			t.updateTimerGate()
 		case <-pollTimer.C:
 			pollTimer.Reset(jitter.JitDuration(
 				t.config.TimerProcessorMaxPollInterval(),
 				t.config.TimerProcessorMaxPollIntervalJitterCoefficient(),
 			))
 			if t.lastPollTime.Add(t.config.TimerProcessorMaxPollInterval()).Before(time.Now()) {
-				lookAheadTimer, err := t.readAndFanoutTimerTasks()
+				lookAheadTimers, err := t.readAndFanoutTimerTasks()
 				if err != nil {
 					return err
 				}
-				if lookAheadTimer != nil {
-					t.timerGate.Update(lookAheadTimer.VisibilityTimestamp)
-				}
+				t.addLookAheadTimers(lookAheadTimers)
+				t.updateTimerGate()
 			}
 		case <-updateAckTimer.C:
 			updateAckTimer.Reset(jitter.JitDuration(
@@ -358,19 +371,45 @@ func (t *timerQueueProcessorBase) internalProcessor() error {
 			t.newTimeLock.Unlock()
 			// New Timer has arrived.
 			t.metricsClient.IncCounter(t.scope, metrics.NewTimerNotifyCounter)
-			t.timerGate.Update(newTime)
+			t.timerWheel.add(newTime)
+			t.updateTimerGate()
 		}
 	}
 }
 
-func (t *timerQueueProcessorBase) readAndFanoutTimerTasks() (*persistence.TimerTaskInfo, error) {
+// addLookAheadTimers registers the wake up times of the look ahead timers in the timer wheel
+func (t *timerQueueProcessorBase) addLookAheadTimers(lookAheadTimers []*persistence.TimerTaskInfo) {
+	for _, timer := range lookAheadTimers {
+		t.timerWheel.add(timer.VisibilityTimestamp)
+	}
+	t.metricsClient.RecordTimer(t.scope, metrics.TimerWheelSizeTimer, time.Duration(t.timerWheel.size()))
+}
+
+// updateTimerGate sets the timer gate to fire at the earliest wake up of the timer wheel
+func (t *timerQueueProcessorBase) updateTimerGate() {
+	nextWakeupTime, ok := t.timerWheel.next()
+	if !ok {
+		return
+	}
+	if t.timerGate.Update(nextWakeupTime) {
+		t.nextWakeupTime = nextWakeupTime
+	}
+}
+
+func (t *timerQueueProcessorBase) readAndFanoutTimerTasks() ([]*persistence.TimerTaskInfo, error) {
 	if !t.rateLimiter.Consume(1, loadTimerTaskThrottleRetryDelay) {
 		t.notifyNewTimer(time.Time{}) // re-enqueue the event
 		return nil, nil
 	}
 
+	if t.hostRateLimiter != nil && !t.hostRateLimiter.Consume(1, loadTimerTaskThrottleRetryDelay) {
+		t.metricsClient.IncCounter(t.scope, metrics.TaskBatchHostThrottledCounter)
+		t.notifyNewTimer(time.Time{}) // re-enqueue the event
+		return nil, nil
+	}
+
 	t.lastPollTime = time.Now()
-	timerTasks, lookAheadTask, moreTasks, err := t.timerQueueAckMgr.readTimerTasks()
+	timerTasks, lookAheadTasks, moreTasks, err := t.timerQueueAckMgr.readTimerTasks()
 	if err != nil {
 		t.notifyNewTimer(time.Time{}) // re-enqueue the event
 		return nil, err
@@ -386,7 +425,7 @@ func (t *timerQueueProcessorBase) readAndFanoutTimerTasks() (*persistence.TimerT
 	}
 
 	if !moreTasks {
-		return lookAheadTask, nil
+		return lookAheadTasks, nil
 	}
 
 	t.notifyNewTimer(time.Time{}) // re-enqueue the event
@@ -606,7 +645,8 @@ func (t *timerQueueProcessorBase) processDeleteHistoryEvent(task *persistence.Ti
 		t.metricsClient.IncCounter(metrics.HistoryProcessDeleteHistoryEventScope, metrics.WorkflowCleanupDeleteCount)
 		return t.deleteWorkflow(task, msBuilder, context)
 	case cluster.ArchivalEnabled:
-		if domainArchivalStatus == workflow.ArchivalStatusDisabled {
+		// TODO: once archival backfill is in place domain:paused should be a nop rather than a delete
+		if domainArchivalStatus == workflow.ArchivalStatusDisabled || domainArchivalStatus == workflow.ArchivalStatusPaused {
 			t.metricsClient.IncCounter(metrics.HistoryProcessDeleteHistoryEventScope, metrics.WorkflowCleanupDeleteCount)
 			return t.deleteWorkflow(task, msBuilder, context)
 		}
@@ -627,7 +667,7 @@ func (t *timerQueueProcessorBase) deleteWorkflow(task *persistence.TimerTaskInfo
 		return err
 	}
 
-	err = t.deleteWorkflowVisibility(task)
+	err = t.deleteWorkflowVisibility(task, msBuilder)
 	if err != nil {
 		return err
 	}
@@ -666,7 +706,7 @@ func (t *timerQueueProcessorBase) archiveWorkflow(task *persistence.TimerTaskInf
 	if err != nil {
 		return err
 	}
-	err = t.deleteWorkflowVisibility(task)
+	err = t.deleteWorkflowVisibility(task, msBuilder)
 	if err != nil {
 		return err
 	}
@@ -678,7 +718,7 @@ func (t *timerQueueProcessorBase) archiveWorkflow(task *persistence.TimerTaskInf
 
 func (t *timerQueueProcessorBase) deleteWorkflowExecution(task *persistence.TimerTaskInfo) error {
 	op := func() error {
-		return t.executionManager.DeleteWorkflowExecution(&persistence.DeleteWorkflowExecutionRequest{
+		return t.executionManager.DeleteWorkflowExecution(context.TODO(), &persistence.DeleteWorkflowExecutionRequest{
 			DomainID:   task.DomainID,
 			WorkflowID: task.WorkflowID,
 			RunID:      task.RunID,
@@ -710,7 +750,16 @@ func (t *timerQueueProcessorBase) deleteWorkflowHistory(task *persistence.TimerT
 	return backoff.Retry(op, persistenceOperationRetryPolicy, common.IsPersistenceTransientError)
 }
 
-func (t *timerQueueProcessorBase) deleteWorkflowVisibility(task *persistence.TimerTaskInfo) error {
+func (t *timerQueueProcessorBase) deleteWorkflowVisibility(task *persistence.TimerTaskInfo, msBuilder mutableState) error {
+	domainEntry, err := t.shard.GetDomainCache().GetDomainByID(task.DomainID)
+	if err != nil {
+		return err
+	}
+	domain := domainEntry.GetInfo().Name
+	if tombstoneRetention := t.config.VisibilityTombstoneRetention(domain); tombstoneRetention > 0 {
+		return t.recordWorkflowVisibilityTombstone(task, msBuilder, domain, tombstoneRetention)
+	}
+
 	switch {
 	case t.visibilityProducer != nil:
 		msg := getVisibilityMessageForDeletion(task.DomainID, task.WorkflowID, task.RunID, task.GetTaskID())
@@ -726,6 +775,44 @@ func (t *timerQueueProcessorBase) deleteWorkflowVisibility(task *persistence.Tim
 	}
 }
 
+// recordWorkflowVisibilityTombstone replaces the visibility record of a workflow deleted due to retention by a
+// tombstone, a closed record with the deleted status and the deletion time as close time. The tombstone lets the
+// frontend tell a deleted workflow from one which never existed, it expires after tombstoneRetention.
+func (t *timerQueueProcessorBase) recordWorkflowVisibilityTombstone(task *persistence.TimerTaskInfo, msBuilder mutableState,
+	domain string, tombstoneRetention time.Duration) error {
+	executionInfo := msBuilder.GetExecutionInfo()
+	startTimeUnixNano := executionInfo.StartTimestamp.UnixNano()
+	deleteTimeUnixNano := time.Now().UnixNano()
+
+	switch {
+	case t.visibilityProducer != nil:
+		msg := getVisibilityMessageForTombstone(task.DomainID, task.WorkflowID, task.RunID, executionInfo.WorkflowTypeName,
+			executionInfo.TaskList, startTimeUnixNano, deleteTimeUnixNano, task.GetTaskID())
+		op := func() error {
+			return t.visibilityProducer.Publish(msg)
+		}
+		return backoff.Retry(op, kafkaOperationRetryPolicy, common.IsKafkaTransientError)
+	default:
+		_, execution := t.getDomainIDAndWorkflowExecution(task)
+		request := &persistence.RecordWorkflowExecutionClosedRequest{
+			DomainUUID:         task.DomainID,
+			Domain:             domain,
+			Execution:          execution,
+			WorkflowTypeName:   executionInfo.WorkflowTypeName,
+			StartTimestamp:     startTimeUnixNano,
+			ExecutionTimestamp: startTimeUnixNano,
+			CloseTimestamp:     deleteTimeUnixNano,
+			Status:             workflow.WorkflowExecutionCloseStatusDeleted,
+			RetentionSeconds:   int64(tombstoneRetention.Seconds()),
+			TaskList:           executionInfo.TaskList,
+		}
+		op := func() error {
+			return t.historyService.RecordExecutionTombstoneToVisibility(request)
+		}
+		return backoff.Retry(op, persistenceOperationRetryPolicy, common.IsPersistenceTransientError)
+	}
+}
+
 func getVisibilityMessageForDeletion(domainID, workflowID, runID string, docVersion int64) *indexer.Message {
 	msgType := indexer.MessageTypeDelete
 	msg := &indexer.Message{
@@ -738,6 +825,33 @@ func getVisibilityMessageForDeletion(domainID, workflowID, runID string, docVers
 	return msg
 }
 
+// getVisibilityMessageForTombstone re-indexes the document of a deleted workflow with the minimal fields of a
+// tombstone, the elasticsearch retention scavenger removes it once the tombstone retention has passed
+func getVisibilityMessageForTombstone(domainID, workflowID, runID, workflowTypeName, taskList string,
+	startTimeUnixNano, deleteTimeUnixNano int64, docVersion int64) *indexer.Message {
+	msgType := indexer.MessageTypeIndex
+	fields := map[string]*indexer.Field{
+		es.WorkflowType:  {Type: &es.FieldTypeString, StringData: common.StringPtr(workflowTypeName)},
+		es.TaskList:      {Type: &es.FieldTypeString, StringData: common.StringPtr(taskList)},
+		es.StartTime:     {Type: &es.FieldTypeInt, IntData: common.Int64Ptr(startTimeUnixNano)},
+		es.ExecutionTime: {Type: &es.FieldTypeInt, IntData: common.Int64Ptr(startTimeUnixNano)},
+		es.CloseTime:     {Type: &es.FieldTypeInt, IntData: common.Int64Ptr(deleteTimeUnixNano)},
+		es.CloseStatus:   {Type: &es.FieldTypeInt, IntData: common.Int64Ptr(int64(workflow.WorkflowExecutionCloseStatusDeleted))},
+		es.HistoryLength: {Type: &es.FieldTypeInt, IntData: common.Int64Ptr(0)},
+	}
+	msg := &indexer.Message{
+		MessageType: &msgType,
+		DomainID:    common.StringPtr(domainID),
+		WorkflowID:  common.StringPtr(workflowID),
+		RunID:       common.StringPtr(runID),
+		Version:     common.Int64Ptr(docVersion),
+		IndexAttributes: &indexer.IndexAttributes{
+			Fields: fields,
+		},
+	}
+	return msg
+}
+
 func (t *timerQueueProcessorBase) getTimerTaskType(taskType int) string {
 	switch taskType {
 	case persistence.TaskTypeUserTimer:
@@ -754,6 +868,8 @@ func (t *timerQueueProcessorBase) getTimerTaskType(taskType int) string {
 		return "ActivityRetryTimerTask"
 	case persistence.TaskTypeWorkflowBackoffTimer:
 		return "WorkflowBackoffTimerTask"
+	case persistence.TaskTypeDelayedSignal:
+		return "DelayedSignalTask"
 	}
 	return "UnKnown"
 }
@@ -183,6 +183,10 @@ func (t *timerQueueStandbyProcessorImpl) process(timerTask *persistence.TimerTas
 		}
 		return metrics.TimerStandbyTaskWorkflowBackoffTimerScope, err
 
+	case persistence.TaskTypeDelayedSignal:
+		// delayed signal timer is only created on the active cluster, the signal is not replicated until it is delivered
+		return metrics.TimerStandbyTaskDelayedSignalScope, err
+
 	case persistence.TaskTypeDeleteHistoryEvent:
 		// guarantee the processing of workflow execution history deletion
 		return metrics.TimerStandbyTaskDeleteHistoryEventScope, t.timerQueueProcessorBase.processDeleteHistoryEvent(timerTask)
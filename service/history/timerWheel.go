@@ -0,0 +1,224 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"time"
+)
+
+const (
+	timerWheelSlotBits   = 6
+	timerWheelSlots      = 1 << timerWheelSlotBits
+	timerWheelSlotMask   = timerWheelSlots - 1
+	timerWheelLevelCount = 4
+)
+
+type (
+	// timerWheel is a hierarchical timer wheel keeping track of the points in time
+	// the timer queue processor has to wake up at.
+	// Level 0 has a granularity of one tick, each following level has a granularity
+	// of the full span of the level below, entries are cascaded down as the wheel advances.
+	// Entries beyond the span of the highest level are kept aside until they come into range.
+	// Wake up times are rounded up to the end of their tick, so timers falling into
+	// the same tick are coalesced into a single wake up.
+	// NOTE: timerWheel is not thread safe
+	timerWheel struct {
+		tick time.Duration
+		// the current tick, all ticks before it are expired
+		current  int64
+		slots    [timerWheelLevelCount][timerWheelSlots][]int64
+		counts   [timerWheelLevelCount]int
+		overflow []int64
+		// ticks before the current tick, added after the wheel advanced past them
+		expired []int64
+	}
+)
+
+func newTimerWheel(tick time.Duration, now time.Time) *timerWheel {
+	if tick <= 0 {
+		tick = time.Millisecond
+	}
+	wheel := &timerWheel{
+		tick: tick,
+	}
+	wheel.current = wheel.tickOf(now)
+	return wheel
+}
+
+// add registers a wake up at the given time
+func (w *timerWheel) add(wakeupTime time.Time) {
+	tick := w.tickOf(wakeupTime)
+	if tick < w.current {
+		w.expired = append(w.expired, tick)
+		return
+	}
+
+	for level := 0; level < timerWheelLevelCount; level++ {
+		shift := uint(timerWheelSlotBits * (level + 1))
+		if tick>>shift != w.current>>shift {
+			continue
+		}
+
+		slot := (tick >> uint(timerWheelSlotBits*level)) & timerWheelSlotMask
+		if level == 0 && len(w.slots[level][slot]) != 0 {
+			// same tick is already registered
+			return
+		}
+		w.slots[level][slot] = append(w.slots[level][slot], tick)
+		w.counts[level]++
+		return
+	}
+	w.overflow = append(w.overflow, tick)
+}
+
+// advance moves the wheel up to the given time, dropping all the wake ups due by then,
+// return the number of wake ups dropped
+func (w *timerWheel) advance(now time.Time) int {
+	target := w.tickOf(now)
+	dropped := 0
+	expired := w.expired[:0]
+	for _, tick := range w.expired {
+		if tick < target {
+			dropped++
+		} else {
+			expired = append(expired, tick)
+		}
+	}
+	w.expired = expired
+
+	for w.current < target {
+		if w.counts[0] != 0 {
+			slot := w.current & timerWheelSlotMask
+			dropped += len(w.slots[0][slot])
+			w.counts[0] -= len(w.slots[0][slot])
+			w.slots[0][slot] = nil
+			w.current++
+			if w.current&timerWheelSlotMask == 0 {
+				w.cascade()
+			}
+			continue
+		}
+
+		// nothing to drop on the lower levels, jump straight to the next
+		// tick at which a non empty level cascades
+		level := 1
+		for level < timerWheelLevelCount && w.counts[level] == 0 {
+			level++
+		}
+		shift := uint(timerWheelSlotBits * level)
+		next := ((w.current >> shift) + 1) << shift
+		if next > target {
+			w.current = target
+			break
+		}
+		w.current = next
+		w.cascade()
+	}
+	return dropped
+}
+
+// next returns the time the earliest registered wake up is due,
+// false if there is no wake up registered
+func (w *timerWheel) next() (time.Time, bool) {
+	if len(w.expired) != 0 {
+		return w.timeOf(minTick(w.expired) + 1), true
+	}
+
+	for level := 0; level < timerWheelLevelCount; level++ {
+		if w.counts[level] == 0 {
+			continue
+		}
+		start := (w.current >> uint(timerWheelSlotBits*level)) & timerWheelSlotMask
+		if level > 0 {
+			// the slot the current tick is in was cascaded already
+			start++
+		}
+		for slot := start; slot < timerWheelSlots; slot++ {
+			if len(w.slots[level][slot]) != 0 {
+				return w.timeOf(minTick(w.slots[level][slot]) + 1), true
+			}
+		}
+	}
+
+	if len(w.overflow) != 0 {
+		return w.timeOf(minTick(w.overflow) + 1), true
+	}
+	return time.Time{}, false
+}
+
+// size returns the number of registered wake ups
+func (w *timerWheel) size() int {
+	size := len(w.expired) + len(w.overflow)
+	for _, count := range w.counts {
+		size += count
+	}
+	return size
+}
+
+// cascade redistributes the entries of the higher level slots the current tick
+// just moved into, must be called each time the current tick crosses a level 0 boundary
+func (w *timerWheel) cascade() {
+	level := 1
+	for level < timerWheelLevelCount &&
+		(w.current>>uint(timerWheelSlotBits*level))&timerWheelSlotMask == 0 {
+		level++
+	}
+
+	var ticks []int64
+	if level == timerWheelLevelCount {
+		// the highest level wrapped, bring the overflow into range
+		ticks = append(ticks, w.overflow...)
+		w.overflow = nil
+		level--
+	}
+	for ; level > 0; level-- {
+		slot := (w.current >> uint(timerWheelSlotBits*level)) & timerWheelSlotMask
+		ticks = append(ticks, w.slots[level][slot]...)
+		w.counts[level] -= len(w.slots[level][slot])
+		w.slots[level][slot] = nil
+	}
+
+	for _, tick := range ticks {
+		w.add(w.timeOf(tick))
+	}
+}
+
+func (w *timerWheel) tickOf(t time.Time) int64 {
+	if t.Before(time.Unix(0, 0)) {
+		// this also covers the zero time, which is used for immediate wake ups
+		return 0
+	}
+	return t.UnixNano() / int64(w.tick)
+}
+
+func (w *timerWheel) timeOf(tick int64) time.Time {
+	return time.Unix(0, tick*int64(w.tick))
+}
+
+func minTick(ticks []int64) int64 {
+	min := ticks[0]
+	for _, tick := range ticks[1:] {
+		if tick < min {
+			min = tick
+		}
+	}
+	return min
+}
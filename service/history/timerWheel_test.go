@@ -0,0 +1,147 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type (
+	timerWheelSuite struct {
+		suite.Suite
+		now        time.Time
+		timerWheel *timerWheel
+	}
+)
+
+func BenchmarkTimerWheel(b *testing.B) {
+	now := time.Now()
+	wheel := newTimerWheel(time.Millisecond, now)
+
+	for i := 0; i < b.N; i++ {
+		wheel.add(now.Add(time.Duration(i) * time.Millisecond))
+		if i%64 == 0 {
+			wheel.advance(now.Add(time.Duration(i) * time.Millisecond))
+		}
+	}
+}
+
+func TestTimerWheelSuite(t *testing.T) {
+	s := new(timerWheelSuite)
+	suite.Run(t, s)
+}
+
+func (s *timerWheelSuite) SetupTest() {
+	s.now = time.Unix(1000, 0)
+	s.timerWheel = newTimerWheel(time.Millisecond, s.now)
+}
+
+func (s *timerWheelSuite) TestEmpty() {
+	_, ok := s.timerWheel.next()
+	s.False(ok)
+	s.Equal(0, s.timerWheel.size())
+	s.Equal(0, s.timerWheel.advance(s.now.Add(time.Hour)))
+}
+
+func (s *timerWheelSuite) TestAddAdvance() {
+	wakeupTime := s.now.Add(1500 * time.Microsecond)
+	s.timerWheel.add(wakeupTime)
+	s.Equal(1, s.timerWheel.size())
+
+	// wake up is rounded up to the end of its tick
+	next, ok := s.timerWheel.next()
+	s.True(ok)
+	s.Equal(s.now.Add(2*time.Millisecond), next)
+
+	s.Equal(0, s.timerWheel.advance(wakeupTime))
+	s.Equal(1, s.timerWheel.advance(next))
+	s.Equal(0, s.timerWheel.size())
+	_, ok = s.timerWheel.next()
+	s.False(ok)
+}
+
+func (s *timerWheelSuite) TestAdd_SameTick() {
+	s.timerWheel.add(s.now.Add(10 * time.Millisecond))
+	s.timerWheel.add(s.now.Add(10*time.Millisecond + 100*time.Microsecond))
+	s.Equal(1, s.timerWheel.size())
+}
+
+func (s *timerWheelSuite) TestAdd_Expired() {
+	s.timerWheel.advance(s.now.Add(time.Second))
+
+	s.timerWheel.add(s.now)
+	s.timerWheel.add(time.Time{})
+	s.Equal(2, s.timerWheel.size())
+
+	next, ok := s.timerWheel.next()
+	s.True(ok)
+	s.True(next.Before(s.now))
+	s.Equal(1, s.timerWheel.advance(next))
+
+	next, ok = s.timerWheel.next()
+	s.True(ok)
+	s.Equal(s.now.Add(time.Millisecond), next)
+	s.Equal(1, s.timerWheel.advance(next))
+	s.Equal(0, s.timerWheel.size())
+}
+
+func (s *timerWheelSuite) TestAdvance_AllLevels() {
+	wakeupTimes := []time.Time{
+		s.now.Add(5 * time.Millisecond),
+		s.now.Add(100 * time.Millisecond),
+		s.now.Add(5 * time.Second),
+		s.now.Add(5 * time.Minute),
+		s.now.Add(24 * time.Hour),
+	}
+	// add in reverse order, so the wheel has to sort them out
+	for i := len(wakeupTimes) - 1; i >= 0; i-- {
+		s.timerWheel.add(wakeupTimes[i])
+	}
+	s.Equal(len(wakeupTimes), s.timerWheel.size())
+
+	for i, wakeupTime := range wakeupTimes {
+		next, ok := s.timerWheel.next()
+		s.True(ok)
+		s.Equal(wakeupTime.Add(time.Millisecond), next)
+		s.Equal(1, s.timerWheel.advance(next))
+		s.Equal(len(wakeupTimes)-i-1, s.timerWheel.size())
+	}
+	_, ok := s.timerWheel.next()
+	s.False(ok)
+}
+
+func (s *timerWheelSuite) TestAdvance_Partially() {
+	s.timerWheel.add(s.now.Add(3 * time.Second))
+	s.timerWheel.add(s.now.Add(10 * time.Second))
+
+	s.Equal(0, s.timerWheel.advance(s.now.Add(2*time.Second)))
+	// new wake ups are relative to the advanced wheel
+	s.timerWheel.add(s.now.Add(2*time.Second + 10*time.Millisecond))
+	s.Equal(3, s.timerWheel.size())
+
+	s.Equal(2, s.timerWheel.advance(s.now.Add(5*time.Second)))
+	next, ok := s.timerWheel.next()
+	s.True(ok)
+	s.Equal(s.now.Add(10*time.Second+time.Millisecond), next)
+}
@@ -36,6 +36,7 @@ import (
 	"github.com/uber/cadence/common/messaging"
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/service/worker/concurrency"
 )
 
 const identityHistoryService = "history-service"
@@ -111,7 +112,8 @@ func newTransferQueueActiveProcessor(shard ShardContext, historyService *history
 	}
 
 	queueAckMgr := newQueueAckMgr(shard, options, processor, shard.GetTransferClusterAckLevel(currentClusterName), logger)
-	queueProcessorBase := newQueueProcessorBase(currentClusterName, shard, options, processor, queueAckMgr, logger)
+	queueProcessorBase := newQueueProcessorBase(currentClusterName, shard, options, processor, queueAckMgr,
+		shard.GetQueueHostRateLimiter(transferQueueType), logger)
 	processor.queueAckMgr = queueAckMgr
 	processor.queueProcessorBase = queueProcessorBase
 
@@ -186,7 +188,8 @@ func newTransferQueueFailoverProcessor(shard ShardContext, historyService *histo
 	}
 
 	queueAckMgr := newQueueFailoverAckMgr(shard, options, processor, minLevel, logger)
-	queueProcessorBase := newQueueProcessorBase(currentClusterName, shard, options, processor, queueAckMgr, logger)
+	queueProcessorBase := newQueueProcessorBase(currentClusterName, shard, options, processor, queueAckMgr,
+		shard.GetQueueHostRateLimiter(transferQueueType), logger)
 	processor.queueAckMgr = queueAckMgr
 	processor.queueProcessorBase = queueProcessorBase
 	return updateTransferAckLevel, processor
@@ -250,6 +253,12 @@ func (t *transferQueueActiveProcessorImpl) process(qTask queueTaskInfo, shouldPr
 		}
 		return metrics.TransferActiveTaskRecordWorkflowStartedScope, err
 
+	case persistence.TransferTaskTypeUpsertWorkflowLabels:
+		if shouldProcessTask {
+			err = t.processUpsertWorkflowLabels(task)
+		}
+		return metrics.TransferActiveTaskUpsertWorkflowLabelsScope, err
+
 	default:
 		return metrics.TransferActiveQueueProcessorScope, errUnknownTransferTask
 	}
@@ -406,22 +415,37 @@ func (t *transferQueueActiveProcessorImpl) processCloseExecution(task *persisten
 	initiatedID := executionInfo.InitiatedID
 
 	workflowTypeName := executionInfo.WorkflowTypeName
+	workflowTaskList := executionInfo.TaskList
+	workflowParentExecution := getParentExecution(msBuilder)
+	workflowLabels := executionInfo.Labels
 	workflowStartTimestamp := executionInfo.StartTimestamp.UnixNano()
 	workflowCloseTimestamp := wfCloseTime
 	workflowCloseStatus := getWorkflowExecutionCloseStatus(executionInfo.CloseStatus)
 	workflowHistoryLength := msBuilder.GetNextEventID() - 1
 	workflowExecutionTimestamp := getWorkflowExecutionTimestamp(msBuilder).UnixNano()
+	workflowTerminationInfo := getWorkflowTerminationInfo(completionEvent)
+	concurrencyRequest, err := t.getConcurrencyGroupRequest(msBuilder)
+	if err != nil {
+		return err
+	}
 
 	// release the context lock since we no longer need mutable state builder and
 	// the rest of logic is making RPC call, which takes time.
 	release(nil)
 	err = t.recordWorkflowClosed(
-		domainID, execution, workflowTypeName, workflowStartTimestamp, workflowExecutionTimestamp, workflowCloseTimestamp, workflowCloseStatus, workflowHistoryLength, task.GetTaskID(),
+		newTransferTaskContext(task), domainID, execution, workflowTypeName, workflowTaskList, workflowParentExecution, workflowLabels, workflowStartTimestamp, workflowExecutionTimestamp, workflowCloseTimestamp, workflowCloseStatus, workflowHistoryLength, workflowTerminationInfo, task.GetTaskID(),
 	)
 	if err != nil {
 		return err
 	}
 
+	// Let the concurrency group of the execution dispatch its next queued run
+	if concurrencyRequest != nil {
+		if err := t.historyService.concurrencyClient.Release(concurrencyRequest); err != nil {
+			return err
+		}
+	}
+
 	// Communicate the result to parent execution if this is Child Workflow execution
 	if replyToParentWorkflow {
 		err = t.historyClient.RecordChildExecutionCompleted(nil, &h.RecordChildExecutionCompletedRequest{
@@ -866,11 +890,93 @@ func (t *transferQueueActiveProcessorImpl) processRecordWorkflowStarted(task *pe
 	wfTypeName := executionInfo.WorkflowTypeName
 	startTimestamp := executionInfo.StartTimestamp.UnixNano()
 	executionTimestamp := getWorkflowExecutionTimestamp(msBuilder).UnixNano()
+	taskList := executionInfo.TaskList
+	parentExecution := getParentExecution(msBuilder)
+	labels := executionInfo.Labels
+	// a run of a concurrency group is enqueued before its first decision is scheduled, the task
+	// created when the group dispatches the run only records the start of the run
+	var concurrencyRequest *concurrency.Request
+	if !msBuilder.HasPendingDecisionTask() && msBuilder.GetPreviousStartedEventID() == common.EmptyEventID {
+		if concurrencyRequest, err = t.getConcurrencyGroupRequest(msBuilder); err != nil {
+			return err
+		}
+	}
 
 	// release the context lock since we no longer need mutable state builder and
 	// the rest of logic is making RPC call, which takes time.
 	release(nil)
-	return t.recordWorkflowStarted(task.DomainID, execution, wfTypeName, startTimestamp, executionTimestamp, workflowTimeout, task.GetTaskID())
+	err = t.recordWorkflowStarted(newTransferTaskContext(task), task.DomainID, execution, wfTypeName, taskList, parentExecution,
+		labels, startTimestamp, executionTimestamp, workflowTimeout, task.GetTaskID())
+	if err != nil || concurrencyRequest == nil {
+		return err
+	}
+	return t.historyService.concurrencyClient.Enqueue(concurrencyRequest)
+}
+
+func (t *transferQueueActiveProcessorImpl) processUpsertWorkflowLabels(task *persistence.TransferTaskInfo) (retError error) {
+	var err error
+	execution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr(task.WorkflowID),
+		RunId:      common.StringPtr(task.RunID),
+	}
+
+	context, release, err := t.cache.getOrCreateWorkflowExecution(task.DomainID, execution)
+	if err != nil {
+		return err
+	}
+	defer func() { release(retError) }()
+
+	var msBuilder mutableState
+	msBuilder, err = loadMutableStateForTransferTask(context, task, t.metricsClient, t.logger)
+	if err != nil {
+		return err
+	} else if msBuilder == nil || !msBuilder.IsWorkflowExecutionRunning() {
+		// the close execution task indexes the final labels of the workflow
+		return nil
+	}
+
+	executionInfo := msBuilder.GetExecutionInfo()
+	wfTypeName := executionInfo.WorkflowTypeName
+	startTimestamp := executionInfo.StartTimestamp.UnixNano()
+	executionTimestamp := getWorkflowExecutionTimestamp(msBuilder).UnixNano()
+	taskList := executionInfo.TaskList
+	parentExecution := getParentExecution(msBuilder)
+	labels := executionInfo.Labels
+
+	// release the context lock since we no longer need mutable state builder and
+	// the rest of logic is making RPC call, which takes time.
+	release(nil)
+	return t.upsertWorkflowLabels(newTransferTaskContext(task), task.DomainID, execution, wfTypeName, taskList,
+		parentExecution, labels, startTimestamp, executionTimestamp, task.GetTaskID())
+}
+
+// getConcurrencyGroupRequest returns the concurrency group of an execution, or nil if the execution was not
+// started in a concurrency group or concurrency groups are not enabled for its domain
+func (t *transferQueueActiveProcessorImpl) getConcurrencyGroupRequest(msBuilder mutableState) (*concurrency.Request, error) {
+	executionInfo := msBuilder.GetExecutionInfo()
+	domainEntry, err := t.shard.GetDomainCache().GetDomainByID(executionInfo.DomainID)
+	if err != nil {
+		return nil, err
+	}
+	if !t.shard.GetConfig().EnableConcurrencyGroups(domainEntry.GetInfo().Name) {
+		return nil, nil
+	}
+
+	startEvent, ok := msBuilder.GetStartEvent()
+	if !ok {
+		return nil, &workflow.InternalServiceError{Message: "Unable to get workflow start event."}
+	}
+	attributes := startEvent.WorkflowExecutionStartedEventAttributes
+	if attributes.GetConcurrencyGroup() == "" {
+		return nil, nil
+	}
+	return &concurrency.Request{
+		DomainID:   executionInfo.DomainID,
+		Group:      attributes.GetConcurrencyGroup(),
+		Limit:      attributes.GetConcurrencyLimit(),
+		WorkflowID: executionInfo.WorkflowID,
+		RunID:      executionInfo.RunID,
+	}, nil
 }
 
 func (t *transferQueueActiveProcessorImpl) recordChildExecutionStarted(task *persistence.TransferTaskInfo,
@@ -24,6 +24,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -63,6 +64,8 @@ type (
 		isStopped             int32
 		shutdownChan          chan struct{}
 		activeTaskProcessor   *transferQueueActiveProcessorImpl
+
+		sync.RWMutex
 		standbyTaskProcessors map[string]*transferQueueStandbyProcessorImpl
 	}
 )
@@ -75,28 +78,7 @@ func newTransferQueueProcessor(shard ShardContext, historyService *historyEngine
 	})
 	currentClusterName := shard.GetService().GetClusterMetadata().GetCurrentClusterName()
 	taskAllocator := newTaskAllocator(shard)
-	standbyTaskProcessors := make(map[string]*transferQueueStandbyProcessorImpl)
-	for clusterName := range shard.GetService().GetClusterMetadata().GetAllClusterFailoverVersions() {
-		if clusterName != currentClusterName {
-			historyRereplicator := xdc.NewHistoryRereplicator(
-				currentClusterName,
-				shard.GetDomainCache(),
-				shard.GetService().GetClientBean().GetRemoteAdminClient(clusterName),
-				func(ctx context.Context, request *h.ReplicateRawEventsRequest) error {
-					return historyService.ReplicateRawEvents(ctx, request)
-				},
-				persistence.NewHistorySerializer(),
-				historyRereplicationTimeout,
-				logger,
-			)
-			standbyTaskProcessors[clusterName] = newTransferQueueStandbyProcessor(
-				clusterName, shard, historyService, visibilityMgr, visibilityProducer,
-				matchingClient, taskAllocator, historyRereplicator, logger,
-			)
-		}
-	}
-
-	return &transferQueueProcessorImpl{
+	processor := &transferQueueProcessorImpl{
 		isGlobalDomainEnabled: shard.GetService().GetClusterMetadata().IsGlobalDomainEnabled(),
 		currentClusterName:    currentClusterName,
 		shard:                 shard,
@@ -112,8 +94,60 @@ func newTransferQueueProcessor(shard ShardContext, historyService *historyEngine
 		logger:                logger,
 		shutdownChan:          make(chan struct{}),
 		activeTaskProcessor:   newTransferQueueActiveProcessor(shard, historyService, visibilityMgr, visibilityProducer, matchingClient, historyClient, taskAllocator, logger),
-		standbyTaskProcessors: standbyTaskProcessors,
+		standbyTaskProcessors: make(map[string]*transferQueueStandbyProcessorImpl),
+	}
+	for clusterName := range shard.GetService().GetClusterMetadata().GetAllClusterFailoverVersions() {
+		if clusterName != currentClusterName {
+			processor.standbyTaskProcessors[clusterName] = processor.newStandbyProcessor(clusterName)
+		}
 	}
+	return processor
+}
+
+func (t *transferQueueProcessorImpl) newStandbyProcessor(clusterName string) *transferQueueStandbyProcessorImpl {
+	historyService := t.historyService
+	historyRereplicator := xdc.NewHistoryRereplicator(
+		t.currentClusterName,
+		t.shard.GetDomainCache(),
+		t.shard.GetService().GetClientBean().GetRemoteAdminClient(clusterName),
+		func(ctx context.Context, request *h.ReplicateRawEventsRequest) error {
+			return historyService.ReplicateRawEvents(ctx, request)
+		},
+		persistence.NewHistorySerializer(),
+		historyRereplicationTimeout,
+		t.logger,
+	)
+	return newTransferQueueStandbyProcessor(
+		clusterName, t.shard, historyService, t.visibilityMgr, t.visibilityProducer,
+		t.matchingClient, t.taskAllocator, historyRereplicator, t.logger,
+	)
+}
+
+// getStandbyProcessor returns the standby processor of the given cluster, the processor
+// is created on demand for a remote cluster added after this processor was created
+func (t *transferQueueProcessorImpl) getStandbyProcessor(clusterName string) *transferQueueStandbyProcessorImpl {
+	t.RLock()
+	standbyTaskProcessor, ok := t.standbyTaskProcessors[clusterName]
+	t.RUnlock()
+	if ok {
+		return standbyTaskProcessor
+	}
+
+	if _, ok := t.shard.GetService().GetClusterMetadata().GetAllClusterFailoverVersions()[clusterName]; !ok {
+		panic(fmt.Sprintf("Cannot find transfer processor for %s.", clusterName))
+	}
+
+	t.Lock()
+	defer t.Unlock()
+	if standbyTaskProcessor, ok := t.standbyTaskProcessors[clusterName]; ok {
+		return standbyTaskProcessor
+	}
+	standbyTaskProcessor = t.newStandbyProcessor(clusterName)
+	t.standbyTaskProcessors[clusterName] = standbyTaskProcessor
+	if t.isGlobalDomainEnabled && atomic.LoadInt32(&t.isStarted) == 1 && atomic.LoadInt32(&t.isStopped) == 0 {
+		standbyTaskProcessor.Start()
+	}
+	return standbyTaskProcessor
 }
 
 func (t *transferQueueProcessorImpl) Start() {
@@ -122,9 +156,11 @@ func (t *transferQueueProcessorImpl) Start() {
 	}
 	t.activeTaskProcessor.Start()
 	if t.isGlobalDomainEnabled {
+		t.RLock()
 		for _, standbyTaskProcessor := range t.standbyTaskProcessors {
 			standbyTaskProcessor.Start()
 		}
+		t.RUnlock()
 	}
 
 	go t.completeTransferLoop()
@@ -136,9 +172,11 @@ func (t *transferQueueProcessorImpl) Stop() {
 	}
 	t.activeTaskProcessor.Stop()
 	if t.isGlobalDomainEnabled {
+		t.RLock()
 		for _, standbyTaskProcessor := range t.standbyTaskProcessors {
 			standbyTaskProcessor.Stop()
 		}
+		t.RUnlock()
 	}
 	close(t.shutdownChan)
 }
@@ -154,10 +192,7 @@ func (t *transferQueueProcessorImpl) NotifyNewTask(clusterName string, transferT
 		return
 	}
 
-	standbyTaskProcessor, ok := t.standbyTaskProcessors[clusterName]
-	if !ok {
-		panic(fmt.Sprintf("Cannot find transfer processor for %s.", clusterName))
-	}
+	standbyTaskProcessor := t.getStandbyProcessor(clusterName)
 	if len(transferTasks) != 0 {
 		standbyTaskProcessor.notifyNewTask()
 	}
@@ -183,9 +218,11 @@ func (t *transferQueueProcessorImpl) FailoverDomain(domainIDs map[string]struct{
 		domainIDs, standbyClusterName, minLevel, maxLevel, t.taskAllocator, t.logger,
 	)
 
+	t.RLock()
 	for _, standbyTaskProcessor := range t.standbyTaskProcessors {
 		standbyTaskProcessor.retryTasks()
 	}
+	t.RUnlock()
 
 	// NOTE: READ REF BEFORE MODIFICATION
 	// ref: historyEngine.go registerDomainFailoverCallback function
@@ -233,12 +270,14 @@ func (t *transferQueueProcessorImpl) completeTransfer() error {
 	upperAckLevel := t.activeTaskProcessor.queueAckMgr.getQueueAckLevel()
 
 	if t.isGlobalDomainEnabled {
+		t.RLock()
 		for _, standbyTaskProcessor := range t.standbyTaskProcessors {
 			ackLevel := standbyTaskProcessor.queueAckMgr.getQueueAckLevel()
 			if upperAckLevel > ackLevel {
 				upperAckLevel = ackLevel
 			}
 		}
+		t.RUnlock()
 
 		for _, failoverInfo := range t.shard.GetAllTransferFailoverLevels() {
 			if upperAckLevel > failoverInfo.MinLevel {
@@ -255,7 +294,7 @@ func (t *transferQueueProcessorImpl) completeTransfer() error {
 	t.metricsClient.IncCounter(metrics.TransferQueueProcessorScope, metrics.TaskBatchCompleteCounter)
 
 	if lowerAckLevel < upperAckLevel {
-		err := t.shard.GetExecutionManager().RangeCompleteTransferTask(&persistence.RangeCompleteTransferTaskRequest{
+		err := t.shard.GetExecutionManager().RangeCompleteTransferTask(context.TODO(), &persistence.RangeCompleteTransferTaskRequest{
 			ExclusiveBeginTaskID: lowerAckLevel,
 			InclusiveEndTaskID:   upperAckLevel,
 		})
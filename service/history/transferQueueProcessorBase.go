@@ -21,6 +21,8 @@
 package history
 
 import (
+	"context"
+	"encoding/base64"
 	"time"
 
 	"github.com/uber-common/bark"
@@ -76,7 +78,7 @@ func newTransferQueueProcessorBase(shard ShardContext, options *QueueProcessorOp
 }
 
 func (t *transferQueueProcessorBase) readTasks(readLevel int64) ([]queueTaskInfo, bool, error) {
-	response, err := t.executionManager.GetTransferTasks(&persistence.GetTransferTasksRequest{
+	response, err := t.executionManager.GetTransferTasks(context.TODO(), &persistence.GetTransferTasksRequest{
 		ReadLevel:    readLevel,
 		MaxReadLevel: t.maxReadAckLevel(),
 		BatchSize:    t.options.BatchSize(),
@@ -141,9 +143,11 @@ func (t *transferQueueProcessorBase) pushDecision(task *persistence.TransferTask
 	return err
 }
 
-func (t *transferQueueProcessorBase) recordWorkflowStarted(
-	domainID string, execution workflow.WorkflowExecution, workflowTypeName string,
-	startTimeUnixNano, executionTimeUnixNano int64, workflowTimeout int32, taskID int64) error {
+func (t *transferQueueProcessorBase) recordWorkflowStarted(ctx context.Context,
+	domainID string, execution workflow.WorkflowExecution, workflowTypeName string, taskList string,
+	parentExecution *workflow.WorkflowExecution, labels map[string]string, startTimeUnixNano, executionTimeUnixNano int64,
+	workflowTimeout int32, taskID int64) error {
+	retentionSeconds := int64(0)
 	domain := defaultDomainName
 	isSampledEnabled := false
 	wid := execution.GetWorkflowId()
@@ -154,6 +158,8 @@ func (t *transferQueueProcessorBase) recordWorkflowStarted(
 			return err
 		}
 	} else {
+		// retention in domain config is in days, convert to seconds
+		retentionSeconds = int64(domainEntry.GetRetentionDays(wid)) * int64(secondsInDay)
 		domain = domainEntry.GetInfo().Name
 		isSampledEnabled = domainEntry.IsSampledForLongerRetentionEnabled(wid)
 	}
@@ -165,14 +171,15 @@ func (t *transferQueueProcessorBase) recordWorkflowStarted(
 
 	// publish to kafka
 	if t.visibilityProducer != nil {
-		msg := getVisibilityMessageForOpenExecution(domainID, execution, workflowTypeName, startTimeUnixNano, executionTimeUnixNano, taskID)
-		err := t.visibilityProducer.Publish(msg)
+		msg := getVisibilityMessageForOpenExecution(domainID, execution, workflowTypeName, taskList, parentExecution,
+			labels, startTimeUnixNano, executionTimeUnixNano, taskID)
+		err := t.visibilityProducer.Publish(newVisibilityMessageWithRequestInfo(ctx, msg))
 		if err != nil {
 			return err
 		}
 	}
 
-	return t.visibilityMgr.RecordWorkflowExecutionStarted(&persistence.RecordWorkflowExecutionStartedRequest{
+	return t.visibilityMgr.RecordWorkflowExecutionStarted(ctx, &persistence.RecordWorkflowExecutionStartedRequest{
 		DomainUUID:         domainID,
 		Domain:             domain,
 		Execution:          execution,
@@ -180,13 +187,18 @@ func (t *transferQueueProcessorBase) recordWorkflowStarted(
 		StartTimestamp:     startTimeUnixNano,
 		ExecutionTimestamp: executionTimeUnixNano,
 		WorkflowTimeout:    int64(workflowTimeout),
+		RetentionSeconds:   retentionSeconds,
+		TaskList:           taskList,
+		ParentWorkflowID:   parentExecution.GetWorkflowId(),
+		ParentRunID:        parentExecution.GetRunId(),
 	})
 }
 
-func (t *transferQueueProcessorBase) recordWorkflowClosed(
-	domainID string, execution workflow.WorkflowExecution, workflowTypeName string,
-	startTimeUnixNano int64, executionTimeUnixNano int64, endTimeUnixNano int64, closeStatus workflow.WorkflowExecutionCloseStatus,
-	historyLength int64, taskID int64) error {
+func (t *transferQueueProcessorBase) recordWorkflowClosed(ctx context.Context,
+	domainID string, execution workflow.WorkflowExecution, workflowTypeName string, taskList string,
+	parentExecution *workflow.WorkflowExecution, labels map[string]string, startTimeUnixNano int64, executionTimeUnixNano int64,
+	endTimeUnixNano int64, closeStatus workflow.WorkflowExecutionCloseStatus, historyLength int64,
+	terminationInfo *workflow.WorkflowExecutionTerminationInfo, taskID int64) error {
 	// Record closing in visibility store
 	retentionSeconds := int64(0)
 	domain := defaultDomainName
@@ -213,15 +225,15 @@ func (t *transferQueueProcessorBase) recordWorkflowClosed(
 
 	// publish to kafka
 	if t.visibilityProducer != nil {
-		msg := getVisibilityMessageForCloseExecution(domainID, execution, workflowTypeName,
-			startTimeUnixNano, executionTimeUnixNano, endTimeUnixNano, closeStatus, historyLength, taskID)
-		err := t.visibilityProducer.Publish(msg)
+		msg := getVisibilityMessageForCloseExecution(domainID, execution, workflowTypeName, taskList, parentExecution,
+			labels, startTimeUnixNano, executionTimeUnixNano, endTimeUnixNano, closeStatus, historyLength, terminationInfo, taskID)
+		err := t.visibilityProducer.Publish(newVisibilityMessageWithRequestInfo(ctx, msg))
 		if err != nil {
 			return err
 		}
 	}
 
-	return t.visibilityMgr.RecordWorkflowExecutionClosed(&persistence.RecordWorkflowExecutionClosedRequest{
+	return t.visibilityMgr.RecordWorkflowExecutionClosed(ctx, &persistence.RecordWorkflowExecutionClosedRequest{
 		DomainUUID:         domainID,
 		Domain:             domain,
 		Execution:          execution,
@@ -232,18 +244,73 @@ func (t *transferQueueProcessorBase) recordWorkflowClosed(
 		Status:             closeStatus,
 		HistoryLength:      historyLength,
 		RetentionSeconds:   retentionSeconds,
+		TaskList:           taskList,
+		ParentWorkflowID:   parentExecution.GetWorkflowId(),
+		ParentRunID:        parentExecution.GetRunId(),
+		TerminationInfo:    terminationInfo,
 	})
 }
 
+// upsertWorkflowLabels re-indexes the open execution record with the current labels of the workflow. Labels are
+// only indexed by elasticsearch, the database visibility stores do not keep them.
+func (t *transferQueueProcessorBase) upsertWorkflowLabels(ctx context.Context,
+	domainID string, execution workflow.WorkflowExecution, workflowTypeName string, taskList string,
+	parentExecution *workflow.WorkflowExecution, labels map[string]string, startTimeUnixNano, executionTimeUnixNano int64,
+	taskID int64) error {
+	if t.visibilityProducer == nil {
+		return nil
+	}
+
+	wid := execution.GetWorkflowId()
+	domainEntry, err := t.shard.GetDomainCache().GetDomainByID(domainID)
+	if err != nil {
+		if _, ok := err.(*workflow.EntityNotExistsError); !ok {
+			return err
+		}
+	} else if domainEntry.IsSampledForLongerRetentionEnabled(wid) && !domainEntry.IsSampledForLongerRetention(wid) {
+		// the open execution was not recorded, see recordWorkflowStarted
+		return nil
+	}
+
+	msg := getVisibilityMessageForOpenExecution(domainID, execution, workflowTypeName, taskList, parentExecution,
+		labels, startTimeUnixNano, executionTimeUnixNano, taskID)
+	return t.visibilityProducer.Publish(newVisibilityMessageWithRequestInfo(ctx, msg))
+}
+
+// newTransferTaskContext returns the context carrying the info of the request which generated the transfer task
+func newTransferTaskContext(task *persistence.TransferTaskInfo) context.Context {
+	return common.NewContextWithRequestInfo(context.Background(), task.RequestID, task.CallerIdentity)
+}
+
+// newVisibilityMessageWithRequestInfo attaches the request ID and caller identity in ctx as kafka headers
+func newVisibilityMessageWithRequestInfo(ctx context.Context, msg *indexer.Message) interface{} {
+	requestID, callerIdentity := common.GetRequestInfo(ctx)
+	if requestID == "" {
+		return msg
+	}
+	headers := map[string]string{common.RequestIDHeaderName: requestID}
+	if callerIdentity != "" {
+		headers[common.CallerIdentityHeaderName] = callerIdentity
+	}
+	return &messaging.HeaderedMessage{
+		Message: msg,
+		Headers: headers,
+	}
+}
+
 func getVisibilityMessageForOpenExecution(domainID string, execution workflow.WorkflowExecution, workflowTypeName string,
+	taskList string, parentExecution *workflow.WorkflowExecution, labels map[string]string,
 	startTimeUnixNano, executionTimeUnixNano int64, taskID int64) *indexer.Message {
 
 	msgType := indexer.MessageTypeIndex
 	fields := map[string]*indexer.Field{
 		es.WorkflowType:  {Type: &es.FieldTypeString, StringData: common.StringPtr(workflowTypeName)},
+		es.TaskList:      {Type: &es.FieldTypeString, StringData: common.StringPtr(taskList)},
 		es.StartTime:     {Type: &es.FieldTypeInt, IntData: common.Int64Ptr(startTimeUnixNano)},
 		es.ExecutionTime: {Type: &es.FieldTypeInt, IntData: common.Int64Ptr(executionTimeUnixNano)},
 	}
+	addParentExecutionFields(fields, parentExecution)
+	addLabelsField(fields, labels)
 
 	msg := &indexer.Message{
 		MessageType: &msgType,
@@ -259,18 +326,30 @@ func getVisibilityMessageForOpenExecution(domainID string, execution workflow.Wo
 }
 
 func getVisibilityMessageForCloseExecution(domainID string, execution workflow.WorkflowExecution, workflowTypeName string,
-	startTimeUnixNano int64, executionTimeUnixNano int64, endTimeUnixNano int64, closeStatus workflow.WorkflowExecutionCloseStatus,
-	historyLength int64, taskID int64) *indexer.Message {
+	taskList string, parentExecution *workflow.WorkflowExecution, labels map[string]string, startTimeUnixNano int64,
+	executionTimeUnixNano int64, endTimeUnixNano int64, closeStatus workflow.WorkflowExecutionCloseStatus, historyLength int64,
+	terminationInfo *workflow.WorkflowExecutionTerminationInfo, taskID int64) *indexer.Message {
 
 	msgType := indexer.MessageTypeIndex
 	fields := map[string]*indexer.Field{
 		es.WorkflowType:  {Type: &es.FieldTypeString, StringData: common.StringPtr(workflowTypeName)},
+		es.TaskList:      {Type: &es.FieldTypeString, StringData: common.StringPtr(taskList)},
 		es.StartTime:     {Type: &es.FieldTypeInt, IntData: common.Int64Ptr(startTimeUnixNano)},
 		es.ExecutionTime: {Type: &es.FieldTypeInt, IntData: common.Int64Ptr(executionTimeUnixNano)},
 		es.CloseTime:     {Type: &es.FieldTypeInt, IntData: common.Int64Ptr(endTimeUnixNano)},
 		es.CloseStatus:   {Type: &es.FieldTypeInt, IntData: common.Int64Ptr(int64(closeStatus))},
 		es.HistoryLength: {Type: &es.FieldTypeInt, IntData: common.Int64Ptr(historyLength)},
 	}
+	addParentExecutionFields(fields, parentExecution)
+	addLabelsField(fields, labels)
+	if terminationInfo != nil {
+		fields[es.TerminateCause] = &indexer.Field{Type: &es.FieldTypeInt, IntData: common.Int64Ptr(int64(terminationInfo.GetCause()))}
+		fields[es.TerminateReason] = &indexer.Field{Type: &es.FieldTypeString, StringData: common.StringPtr(terminationInfo.GetReason())}
+		fields[es.TerminateIdentity] = &indexer.Field{Type: &es.FieldTypeString, StringData: common.StringPtr(terminationInfo.GetIdentity())}
+		// details are opaque bytes, ES stores them in a binary field which takes base64 encoded strings
+		fields[es.TerminateDetails] = &indexer.Field{Type: &es.FieldTypeString,
+			StringData: common.StringPtr(base64.StdEncoding.EncodeToString(terminationInfo.GetDetails()))}
+	}
 
 	msg := &indexer.Message{
 		MessageType: &msgType,
@@ -285,20 +364,68 @@ func getVisibilityMessageForCloseExecution(domainID string, execution workflow.W
 	return msg
 }
 
+// addParentExecutionFields indexes the parent of a child workflow, so the children of a parent can be searched
+func addParentExecutionFields(fields map[string]*indexer.Field, parentExecution *workflow.WorkflowExecution) {
+	if parentExecution == nil {
+		return
+	}
+	fields[es.ParentWorkflowID] = &indexer.Field{Type: &es.FieldTypeString, StringData: common.StringPtr(parentExecution.GetWorkflowId())}
+	fields[es.ParentRunID] = &indexer.Field{Type: &es.FieldTypeString, StringData: common.StringPtr(parentExecution.GetRunId())}
+}
+
+// addLabelsField indexes the labels of a workflow as a list of key=value keywords, so a label can be
+// matched exactly by a term query
+func addLabelsField(fields map[string]*indexer.Field, labels map[string]string) {
+	if len(labels) == 0 {
+		return
+	}
+	fields[es.Labels] = &indexer.Field{Type: &es.FieldTypeStringList, StringListData: es.LabelsToKeywords(labels)}
+}
+
+// getParentExecution returns the parent of a child workflow, or nil if the workflow has no parent
+func getParentExecution(msBuilder mutableState) *workflow.WorkflowExecution {
+	if !msBuilder.HasParentExecution() {
+		return nil
+	}
+	executionInfo := msBuilder.GetExecutionInfo()
+	return &workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr(executionInfo.ParentWorkflowID),
+		RunId:      common.StringPtr(executionInfo.ParentRunID),
+	}
+}
+
+// getWorkflowTerminationInfo returns who terminated the workflow and why, or nil if
+// the completion event is not a termination
+func getWorkflowTerminationInfo(completionEvent *workflow.HistoryEvent) *workflow.WorkflowExecutionTerminationInfo {
+	if completionEvent.GetEventType() != workflow.EventTypeWorkflowExecutionTerminated {
+		return nil
+	}
+	attributes := completionEvent.WorkflowExecutionTerminatedEventAttributes
+	cause := workflow.TerminationCauseUserRequest
+	if attributes.Cause != nil {
+		cause = attributes.GetCause()
+	}
+	return &workflow.WorkflowExecutionTerminationInfo{
+		Cause:    cause.Ptr(),
+		Reason:   attributes.Reason,
+		Details:  attributes.Details,
+		Identity: attributes.Identity,
+	}
+}
+
+// getWorkflowExecutionTimestamp returns the time the first decision of the workflow is scheduled, which
+// is the start time delayed by the backoff of cron workflows and of retried or delayed runs. The value is
+// computed when the visibility record is written so that all visibility stores return the same value.
 func getWorkflowExecutionTimestamp(msBuilder mutableState) time.Time {
-	// Use value 0 to represent workflows that don't need backoff. Since ES doesn't support
-	// comparison between two field, we need a value to differentiate them from cron workflows
-	// or later runs of a workflow that needs retry.
-	executionTimestamp := time.Unix(0, 0)
+	executionInfo := msBuilder.GetExecutionInfo()
+	executionTimestamp := executionInfo.StartTimestamp
 	startEvent, ok := msBuilder.GetStartEvent()
 	if !ok {
 		return executionTimestamp
 	}
 
-	executionInfo := msBuilder.GetExecutionInfo()
-	startTimestamp := executionInfo.StartTimestamp
 	if backoffSeconds := startEvent.WorkflowExecutionStartedEventAttributes.GetFirstDecisionTaskBackoffSeconds(); backoffSeconds != 0 {
-		executionTimestamp = startTimestamp.Add(time.Duration(backoffSeconds) * time.Second)
+		executionTimestamp = executionTimestamp.Add(time.Duration(backoffSeconds) * time.Second)
 	}
 	return executionTimestamp
 }
@@ -106,7 +106,8 @@ func newTransferQueueStandbyProcessor(clusterName string, shard ShardContext, hi
 	}
 
 	queueAckMgr := newQueueAckMgr(shard, options, processor, shard.GetTransferClusterAckLevel(clusterName), logger)
-	queueProcessorBase := newQueueProcessorBase(clusterName, shard, options, processor, queueAckMgr, logger)
+	queueProcessorBase := newQueueProcessorBase(clusterName, shard, options, processor, queueAckMgr,
+		shard.GetQueueHostRateLimiter(transferQueueType), logger)
 	processor.queueAckMgr = queueAckMgr
 	processor.queueProcessorBase = queueProcessorBase
 
@@ -171,6 +172,12 @@ func (t *transferQueueStandbyProcessorImpl) process(qTask queueTaskInfo, shouldP
 		}
 		return metrics.TransferStandbyTaskRecordWorkflowStartedScope, err
 
+	case persistence.TransferTaskTypeUpsertWorkflowLabels:
+		if shouldProcessTask {
+			err = t.processUpsertWorkflowLabels(task)
+		}
+		return metrics.TransferStandbyTaskUpsertWorkflowLabelsScope, err
+
 	default:
 		return metrics.TransferStandbyQueueProcessorScope, errUnknownTransferTask
 	}
@@ -289,11 +296,15 @@ func (t *transferQueueStandbyProcessorImpl) processCloseExecution(transferTask *
 
 		executionInfo := msBuilder.GetExecutionInfo()
 		workflowTypeName := executionInfo.WorkflowTypeName
+		workflowTaskList := executionInfo.TaskList
+		workflowParentExecution := getParentExecution(msBuilder)
+		workflowLabels := executionInfo.Labels
 		workflowStartTimestamp := executionInfo.StartTimestamp.UnixNano()
 		workflowCloseTimestamp := wfCloseTime
 		workflowCloseStatus := getWorkflowExecutionCloseStatus(executionInfo.CloseStatus)
 		workflowHistoryLength := msBuilder.GetNextEventID() - 1
 		workflowExecutionTimestamp := getWorkflowExecutionTimestamp(msBuilder).UnixNano()
+		workflowTerminationInfo := getWorkflowTerminationInfo(completionEvent)
 
 		ok, err := verifyTaskVersion(t.shard, t.logger, transferTask.DomainID, msBuilder.GetLastWriteVersion(), transferTask.Version, transferTask)
 		if err != nil {
@@ -306,7 +317,7 @@ func (t *transferQueueStandbyProcessorImpl) processCloseExecution(transferTask *
 		// since event replication should be done by active cluster
 
 		return t.recordWorkflowClosed(
-			transferTask.DomainID, execution, workflowTypeName, workflowStartTimestamp, workflowExecutionTimestamp, workflowCloseTimestamp, workflowCloseStatus, workflowHistoryLength, transferTask.GetTaskID(),
+			newTransferTaskContext(transferTask), transferTask.DomainID, execution, workflowTypeName, workflowTaskList, workflowParentExecution, workflowLabels, workflowStartTimestamp, workflowExecutionTimestamp, workflowCloseTimestamp, workflowCloseStatus, workflowHistoryLength, workflowTerminationInfo, transferTask.GetTaskID(),
 		)
 	}, standbyTaskPostActionNoOp) // no op post action, since the entire workflow is finished
 }
@@ -444,8 +455,35 @@ func (t *transferQueueStandbyProcessorImpl) processRecordWorkflowStarted(transfe
 		wfTypeName := executionInfo.WorkflowTypeName
 		startTimestamp := executionInfo.StartTimestamp.UnixNano()
 		executionTimestamp := getWorkflowExecutionTimestamp(msBuilder).UnixNano()
+		taskList := executionInfo.TaskList
+		parentExecution := getParentExecution(msBuilder)
+		labels := executionInfo.Labels
+
+		return t.recordWorkflowStarted(newTransferTaskContext(transferTask), transferTask.DomainID, execution, wfTypeName, taskList, parentExecution,
+			labels, startTimestamp, executionTimestamp, workflowTimeout, transferTask.GetTaskID())
+	}, standbyTaskPostActionNoOp)
+}
+
+func (t *transferQueueStandbyProcessorImpl) processUpsertWorkflowLabels(transferTask *persistence.TransferTaskInfo) error {
+	processTaskIfClosed := false
+
+	execution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr(transferTask.WorkflowID),
+		RunId:      common.StringPtr(transferTask.RunID),
+	}
+
+	// labels are not replicated, the task is left over from before the domain failed over
+	return t.processTransfer(processTaskIfClosed, transferTask, func(msBuilder mutableState) error {
+		executionInfo := msBuilder.GetExecutionInfo()
+		wfTypeName := executionInfo.WorkflowTypeName
+		startTimestamp := executionInfo.StartTimestamp.UnixNano()
+		executionTimestamp := getWorkflowExecutionTimestamp(msBuilder).UnixNano()
+		taskList := executionInfo.TaskList
+		parentExecution := getParentExecution(msBuilder)
+		labels := executionInfo.Labels
 
-		return t.recordWorkflowStarted(transferTask.DomainID, execution, wfTypeName, startTimestamp, executionTimestamp, workflowTimeout, transferTask.GetTaskID())
+		return t.upsertWorkflowLabels(newTransferTaskContext(transferTask), transferTask.DomainID, execution, wfTypeName, taskList,
+			parentExecution, labels, startTimestamp, executionTimestamp, transferTask.GetTaskID())
 	}, standbyTaskPostActionNoOp)
 }
 
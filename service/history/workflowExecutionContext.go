@@ -23,6 +23,7 @@ package history
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/uber-common/bark"
@@ -31,6 +32,7 @@ import (
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/backoff"
+	"github.com/uber/cadence/common/checksum"
 	"github.com/uber/cadence/common/clock"
 	"github.com/uber/cadence/common/cluster"
 	"github.com/uber/cadence/common/errors"
@@ -42,6 +44,20 @@ import (
 
 const (
 	secondsInDay = int32(24 * time.Hour / time.Second)
+
+	// BufferedEventsLimitPolicyForceNewDecision force closes the in-flight decision when the buffered events
+	// exceed the limits, so they are flushed to history, and retries the request adding the events
+	BufferedEventsLimitPolicyForceNewDecision = "forceNewDecision"
+	// BufferedEventsLimitPolicyFailDecision force closes the in-flight decision when the buffered events
+	// exceed the limits, so they are flushed to history, and fails the request adding the events
+	BufferedEventsLimitPolicyFailDecision = "failDecision"
+
+	// MutableStateChecksumVerifyModeDisabled skips the checksum verification of loaded mutable state
+	MutableStateChecksumVerifyModeDisabled = "disabled"
+	// MutableStateChecksumVerifyModeLog logs and emits a metric when the checksum of loaded mutable state mismatches
+	MutableStateChecksumVerifyModeLog = "log"
+	// MutableStateChecksumVerifyModeFail fails loading the mutable state when its checksum mismatches
+	MutableStateChecksumVerifyModeFail = "fail"
 )
 
 type (
@@ -82,6 +98,13 @@ type (
 		updateCondition       int64
 		deleteTimerTask       persistence.Task
 		createReplicationTask bool
+		requestID             string
+		callerIdentity        string
+
+		// estimated size of the loaded mutable state, read by the history cache without holding the lock
+		byteSize           int64
+		stateSize          int
+		bufferedEventsSize int
 	}
 )
 
@@ -118,10 +141,16 @@ func newWorkflowExecutionContext(
 }
 
 func (c *workflowExecutionContextImpl) lock(ctx context.Context) error {
-	return c.locker.Lock(ctx)
+	if err := c.locker.Lock(ctx); err != nil {
+		return err
+	}
+	// remember the request holding the lock so tasks generated by it can be traced back
+	c.requestID, c.callerIdentity = common.GetRequestInfo(ctx)
+	return nil
 }
 
 func (c *workflowExecutionContextImpl) unlock() {
+	c.requestID, c.callerIdentity = "", ""
 	c.locker.Unlock()
 }
 
@@ -170,11 +199,19 @@ func (c *workflowExecutionContextImpl) loadWorkflowExecutionInternal() error {
 	if response != nil && response.State != nil {
 		state := response.State
 		msBuilder.Load(state)
+		if err := c.verifyChecksum(msBuilder, state.Checksum); err != nil {
+			return err
+		}
 		info := state.ExecutionInfo
 		c.updateCondition = info.NextEventID
 	}
 
 	c.msBuilder = msBuilder
+	if stats := response.MutableStateStats; stats != nil {
+		c.stateSize = stats.MutableStateSize - stats.BufferedEventsSize
+		c.bufferedEventsSize = stats.BufferedEventsSize
+		c.updateByteSize()
+	}
 	// finally emit execution and session stats
 	c.emitWorkflowExecutionStats(response.MutableStateStats, c.msBuilder.GetHistorySize())
 	return nil
@@ -185,6 +222,7 @@ func (c *workflowExecutionContextImpl) resetMutableState(prevRunID string, reset
 	// this only resets one mutableState for a workflow
 	snapshotRequest := resetBuilder.ResetSnapshot(prevRunID)
 	snapshotRequest.Condition = c.updateCondition
+	snapshotRequest.Checksum = generateMutableStateChecksum(resetBuilder)
 
 	err := c.shard.ResetMutableState(snapshotRequest)
 	if err != nil {
@@ -214,6 +252,8 @@ func (c *workflowExecutionContextImpl) resetWorkflowExecution(currMutableState m
 	}
 	setTaskInfo(currMutableState.GetCurrentVersion(), now, currTransferTasks, currTimerTasks)
 	setTaskInfo(newMutableState.GetCurrentVersion(), now, newTransferTasks, newTimerTasks)
+	setTaskRequestInfo(c.requestID, c.callerIdentity, currTransferTasks)
+	setTaskRequestInfo(c.requestID, c.callerIdentity, newTransferTasks)
 
 	transactionID, retError := c.shard.GetNextTransferTaskID()
 	if retError != nil {
@@ -421,14 +461,13 @@ func (c *workflowExecutionContextImpl) update(transferTasks []persistence.Task,
 
 	// Take a snapshot of all updates we have accumulated for this execution
 	updates, err := c.msBuilder.CloseUpdateSession()
+	if err == nil && len(updates.newBufferedEvents) > 0 &&
+		c.bufferedEventsSize >= c.shard.GetConfig().MaximumBufferedEventsSize() {
+		err = ErrBufferedEventsLimitExceeded
+	}
 	if err != nil {
 		if err == ErrBufferedEventsLimitExceeded {
-			if err1 := c.failInflightDecision(); err1 != nil {
-				return err1
-			}
-
-			// Buffered events are flushed, we want upper layer to retry
-			return ErrConflict
+			return c.handleBufferedEventsLimitExceeded()
 		}
 		return err
 	}
@@ -551,7 +590,7 @@ func (c *workflowExecutionContextImpl) update(transferTasks []persistence.Task,
 					return err
 				}
 
-				c.msBuilder.AddWorkflowExecutionTerminatedEvent(&workflow.TerminateWorkflowExecutionRequest{
+				c.msBuilder.AddWorkflowExecutionTerminatedEvent(workflow.TerminationCauseHistoryExceedsLimit, &workflow.TerminateWorkflowExecutionRequest{
 					Reason:   common.StringPtr(common.TerminateReasonSizeExceedsLimit),
 					Identity: common.StringPtr("cadence-history-server"),
 				})
@@ -630,6 +669,7 @@ func (c *workflowExecutionContextImpl) update(transferTasks []persistence.Task,
 	}
 
 	setTaskInfo(c.msBuilder.GetCurrentVersion(), now, transferTasks, timerTasks)
+	setTaskRequestInfo(c.requestID, c.callerIdentity, transferTasks)
 
 	// Update history size on mutableState before calling UpdateWorkflowExecution
 	c.msBuilder.IncrementHistorySize(newHistorySize)
@@ -663,6 +703,7 @@ func (c *workflowExecutionContextImpl) update(transferTasks []persistence.Task,
 		ContinueAsNew:                 continueAsNew,
 		FinishExecution:               finishExecution,
 		FinishedExecutionTTL:          finishExecutionTTL,
+		Checksum:                      generateMutableStateChecksum(c.msBuilder),
 	}); err1 != nil {
 		switch err1.(type) {
 		case *persistence.ConditionFailedError:
@@ -688,10 +729,17 @@ func (c *workflowExecutionContextImpl) update(transferTasks []persistence.Task,
 		c.msBuilder.IsWorkflowExecutionRunning(),
 	))
 
+	if updates.clearBufferedEvents {
+		c.bufferedEventsSize = 0
+	}
 	// finally emit session stats
 	if resp != nil {
+		if stats := resp.MutableStateUpdateSessionStats; stats != nil {
+			c.bufferedEventsSize += stats.BufferedEventsSize
+		}
 		c.emitSessionUpdateStats(resp.MutableStateUpdateSessionStats)
 	}
+	c.updateByteSize()
 
 	// emit workflow completion stats if any
 	if executionInfo.State == persistence.WorkflowStateCompleted {
@@ -717,6 +765,9 @@ func (c *workflowExecutionContextImpl) appendHistoryEvents(history []*workflow.H
 	var err error
 
 	if c.msBuilder.GetEventStoreVersion() == persistence.EventStoreVersionV2 {
+		if err := c.msBuilder.UpdateCurrentVersionHistory(history); err != nil {
+			return 0, err
+		}
 		historySize, err = c.shard.AppendHistoryV2Events(&persistence.AppendHistoryNodesRequest{
 			IsNewBranch:   false,
 			BranchToken:   c.msBuilder.GetCurrentBranch(),
@@ -778,6 +829,9 @@ func (c *workflowExecutionContextImpl) appendFirstBatchHistoryForContinueAsNew(n
 	var historySize int
 	var err error
 	if newStateBuilder.GetEventStoreVersion() == persistence.EventStoreVersionV2 {
+		if err := newStateBuilder.UpdateCurrentVersionHistory(history.Events); err != nil {
+			return err
+		}
 		historySize, err = c.shard.AppendHistoryV2Events(&persistence.AppendHistoryNodesRequest{
 			IsNewBranch:   true,
 			Info:          historyGarbageCleanupInfo(domainID, newExecution.GetWorkflowId(), newExecution.GetRunId()),
@@ -810,7 +864,7 @@ func (c *workflowExecutionContextImpl) getWorkflowExecutionWithRetry(
 	var response *persistence.GetWorkflowExecutionResponse
 	op := func() error {
 		var err error
-		response, err = c.executionManager.GetWorkflowExecution(request)
+		response, err = c.executionManager.GetWorkflowExecution(context.TODO(), request)
 
 		return err
 	}
@@ -839,6 +893,19 @@ func (c *workflowExecutionContextImpl) updateWorkflowExecutionWithRetry(
 func (c *workflowExecutionContextImpl) clear() {
 	c.metricsClient.IncCounter(metrics.WorkflowContextScope, metrics.WorkflowContextCleared)
 	c.msBuilder = nil
+	c.stateSize = 0
+	c.bufferedEventsSize = 0
+	c.updateByteSize()
+}
+
+// ByteSize returns the estimated size of the mutable state and buffered events held by this context,
+// it is used by the history cache to bound the memory of cached workflows
+func (c *workflowExecutionContextImpl) ByteSize() int {
+	return int(atomic.LoadInt64(&c.byteSize))
+}
+
+func (c *workflowExecutionContextImpl) updateByteSize() {
+	atomic.StoreInt64(&c.byteSize, int64(c.stateSize+c.bufferedEventsSize))
 }
 
 // scheduleNewDecision is helper method which has the logic for scheduling new decision for a workflow execution.
@@ -875,6 +942,46 @@ func (c *workflowExecutionContextImpl) scheduleNewDecision(transferTasks []persi
 	return transferTasks, timerTasks, nil
 }
 
+func (c *workflowExecutionContextImpl) handleBufferedEventsLimitExceeded() error {
+	c.metricsClient.IncCounter(metrics.WorkflowContextScope, metrics.BufferedEventsLimitExceededCounter)
+	if err := c.failInflightDecision(); err != nil {
+		return err
+	}
+
+	if c.shard.GetConfig().BufferedEventsLimitPolicy() == BufferedEventsLimitPolicyFailDecision {
+		// Buffered events are flushed, the events over the limit are rejected
+		return ErrBufferedEventsLimitExceeded
+	}
+	// Buffered events are flushed, we want upper layer to retry
+	return ErrConflict
+}
+
+func (c *workflowExecutionContextImpl) verifyChecksum(msBuilder mutableState, csum checksum.Checksum) error {
+	domain := ""
+	if entry, err := c.shard.GetDomainCache().GetDomainByID(c.domainID); err == nil && entry != nil && entry.GetInfo() != nil {
+		domain = entry.GetInfo().Name
+	}
+	mode := c.shard.GetConfig().MutableStateChecksumVerifyMode(domain)
+	if mode == MutableStateChecksumVerifyModeDisabled || csum.IsEmpty() {
+		return nil
+	}
+
+	err := verifyMutableStateChecksum(msBuilder, csum)
+	if err == nil {
+		return nil
+	}
+
+	c.metricsClient.IncCounter(metrics.WorkflowContextScope, metrics.MutableStateChecksumMismatchCounter)
+	c.logger.WithFields(bark.Fields{
+		logging.TagErr: err,
+	}).Error("Mutable state checksum mismatch.")
+
+	if mode == MutableStateChecksumVerifyModeFail {
+		return &workflow.InternalServiceError{Message: "Mutable state checksum mismatch."}
+	}
+	return nil
+}
+
 func (c *workflowExecutionContextImpl) failInflightDecision() error {
 	c.clear()
 
@@ -99,7 +99,7 @@ func (w *workflowResetorImpl) ResetWorkflowExecution(ctx context.Context, resetR
 	}
 
 	// also load the current run of the workflow, it can be different from the base runID
-	resp, retError := w.eng.shard.GetExecutionManager().GetCurrentExecution(&persistence.GetCurrentExecutionRequest{
+	resp, retError := w.eng.shard.GetExecutionManager().GetCurrentExecution(ctx, &persistence.GetCurrentExecutionRequest{
 		DomainID:   domainID,
 		WorkflowID: request.WorkflowExecution.GetWorkflowId(),
 	})
@@ -396,6 +396,9 @@ func (w *workflowResetorImpl) buildNewMutableStateForReset(ctx context.Context,
 		return
 	}
 	newMutableState.GetExecutionInfo().BranchToken = forkResp.NewBranchToken
+	// version histories are not carried over to the forked branch, resetting a workflow
+	// is not reconciled with NDC replication yet
+	newMutableState.SetVersionHistories(nil)
 	return
 }
 
@@ -408,7 +411,7 @@ func (w *workflowResetorImpl) terminateIfCurrIsRunning(currMutableState mutableS
 			return
 		}
 
-		currMutableState.AddWorkflowExecutionTerminatedEvent(&workflow.TerminateWorkflowExecutionRequest{
+		currMutableState.AddWorkflowExecutionTerminatedEvent(workflow.TerminationCauseResetWorkflow, &workflow.TerminateWorkflowExecutionRequest{
 			Reason:   common.StringPtr(reason),
 			Details:  nil,
 			Identity: common.StringPtr(identityHistoryService),
@@ -800,6 +803,9 @@ func (w *workflowResetorImpl) ApplyResetEvent(ctx context.Context, request *h.Re
 		})
 	}()
 	newMsBuilder.GetExecutionInfo().BranchToken = forkResp.NewBranchToken
+	// version histories are not carried over to the forked branch, resetting a workflow
+	// is not reconciled with NDC replication yet
+	newMsBuilder.SetVersionHistories(nil)
 
 	// prepare to append history to new branch
 	hBuilder := newHistoryBuilder(newMsBuilder, w.replicator.logger)
@@ -68,6 +68,18 @@ func (pollers *pollerHistory) updatePollerInfo(id pollerIdentity, ratePerSecond
 	pollers.history.Put(id, &pollerInfo{ratePerSecond: rps})
 }
 
+// hasPollerAfter returns true if any poller polled the task list after the given time
+func (pollers *pollerHistory) hasPollerAfter(earliestAccessTime time.Time) bool {
+	ite := pollers.history.Iterator()
+	defer ite.Close()
+	for ite.HasNext() {
+		if ite.Next().CreateTime().After(earliestAccessTime) {
+			return true
+		}
+	}
+	return false
+}
+
 func (pollers *pollerHistory) getAllPollerInfo() []*shared.PollerInfo {
 	var result []*shared.PollerInfo
 
@@ -99,6 +99,12 @@ func (rl *rateLimiter) Reserve() *rate.Reservation {
 	return limiter.Reserve()
 }
 
+// Burst returns the current burst size for this ratelimiter
+func (rl *rateLimiter) Burst() int {
+	limiter := rl.globalLimiter.Load().(*rate.Limiter)
+	return limiter.Burst()
+}
+
 // Limit returns the current rate per second limit for this ratelimiter
 func (rl *rateLimiter) Limit() float64 {
 	if rl.maxDispatchPerSecond != nil {
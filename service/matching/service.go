@@ -25,6 +25,7 @@ import (
 
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/service"
+	"github.com/uber/cadence/common/service/config"
 	"github.com/uber/cadence/common/service/dynamicconfig"
 
 	"github.com/uber/cadence/common/logging"
@@ -33,9 +34,11 @@ import (
 
 // Config represents configuration for cadence-matching service
 type Config struct {
-	PersistenceMaxQPS dynamicconfig.IntPropertyFn
-	EnableSyncMatch   dynamicconfig.BoolPropertyFnWithTaskListInfoFilters
-	RPS               dynamicconfig.IntPropertyFn
+	PersistenceMaxQPS             dynamicconfig.IntPropertyFn
+	PersistenceAdaptiveThrottling *config.AdaptiveThrottlingConfig
+	PersistenceDomainMetrics      *config.DomainMetricsConfig
+	EnableSyncMatch               dynamicconfig.BoolPropertyFnWithTaskListInfoFilters
+	RPS                           dynamicconfig.IntPropertyFn
 
 	// taskListManager configuration
 	RangeSize                 int64
@@ -59,6 +62,8 @@ type Config struct {
 func NewConfig(dc *dynamicconfig.Collection) *Config {
 	return &Config{
 		PersistenceMaxQPS:               dc.GetIntProperty(dynamicconfig.MatchingPersistenceMaxQPS, 3000),
+		PersistenceAdaptiveThrottling:   config.NewAdaptiveThrottlingConfig(dc, dynamicconfig.MatchingEnablePersistenceAdaptiveThrottling),
+		PersistenceDomainMetrics:        config.NewDomainMetricsConfig(dc, dynamicconfig.MatchingEnablePersistenceDomainMetrics),
 		EnableSyncMatch:                 dc.GetBoolPropertyFilteredByTaskListInfo(dynamicconfig.MatchingEnableSyncMatch, true),
 		RPS:                             dc.GetIntProperty(dynamicconfig.MatchingRPS, 1200),
 		RangeSize:                       100000,
@@ -86,7 +91,7 @@ type Service struct {
 func NewService(params *service.BootstrapParams) common.Daemon {
 	params.UpdateLoggerWithServiceName(common.MatchingServiceName)
 	config := NewConfig(dynamicconfig.NewCollection(params.DynamicConfig, params.BarkLogger))
-	params.ThrottledBarkLogger = logging.NewThrottledLogger(params.BarkLogger, config.ThrottledLogRPS)
+	params.ThrottledBarkLogger = logging.NewThrottledLoggerWithKeys(params.BarkLogger, config.ThrottledLogRPS, logging.TagDomainID, logging.TagErr)
 	return &Service{
 		params: params,
 		config: config,
@@ -104,9 +109,14 @@ func (s *Service) Start() {
 
 	base := service.New(params)
 
-	pConfig := params.PersistenceConfig
-	pConfig.SetMaxQPS(pConfig.DefaultStore, s.config.PersistenceMaxQPS())
-	pFactory := persistencefactory.New(&pConfig, params.ClusterMetadata.GetCurrentClusterName(), base.GetMetricsClient(), log)
+	pFactory := params.PersistenceFactory
+	if pFactory == nil {
+		pConfig := params.PersistenceConfig
+		pConfig.SetMaxQPS(pConfig.DefaultStore, s.config.PersistenceMaxQPS())
+		pConfig.AdaptiveThrottlingConfig = s.config.PersistenceAdaptiveThrottling
+		pConfig.DomainMetricsConfig = s.config.PersistenceDomainMetrics
+		pFactory = persistencefactory.New(&pConfig, params.ClusterMetadata.GetCurrentClusterName(), base.GetMetricsClient(), log)
+	}
 
 	taskPersistence, err := pFactory.NewTaskManager()
 	if err != nil {
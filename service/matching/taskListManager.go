@@ -325,15 +325,30 @@ func (c *taskListManagerImpl) AddTask(execution *s.WorkflowExecution, taskInfo *
 		}
 
 		r, err := c.trySyncMatch(taskInfo)
-		if (err != nil && err != errAddTasklistThrottled) || r != nil {
+		if err == nil && r != nil {
 			syncMatch = true
-			return r, err
+			return r, nil
+		}
+		if err != nil && err != errAddTasklistThrottled {
+			// poller picked up the task but failed to start it, fall back to persisting
+			// the task so it can be dispatched again from the backlog
+			c.domainScope.IncCounter(metrics.SyncMatchFailedCounter)
 		}
 		r, err = c.taskWriter.appendTask(execution, taskInfo)
 		syncMatch = false
 		return r, err
 	})
 	if err == nil {
+		if syncMatch {
+			c.domainScope.IncCounter(metrics.TasksSyncMatchedCounter)
+		} else {
+			c.domainScope.IncCounter(metrics.TasksPersistedCounter)
+		}
+		if !syncMatch && !c.pollerHistory.hasPollerAfter(time.Now().Add(-pollerHistoryTTL)) {
+			// task went to the backlog of a task list nobody is polling, it will only be dispatched
+			// once a worker comes back, so make it visible to operators
+			c.domainScope.IncCounter(metrics.TasksAddedWithoutPollersCounter)
+		}
 		c.signalNewTask()
 	}
 	return syncMatch, err
@@ -548,8 +563,8 @@ func (c *taskListManagerImpl) GetAllPollerInfo() []*s.PollerInfo {
 }
 
 // DescribeTaskList returns information about the target tasklist, right now this API returns the
-// pollers which polled this tasklist in last few minutes and status of tasklist's ackManager
-// (readLevel, ackLevel, backlogCountHint and taskIDBlock).
+// pollers which polled this tasklist in last few minutes, status of tasklist's ackManager
+// (readLevel, ackLevel, backlogCountHint and taskIDBlock) and its dispatch rate limit.
 func (c *taskListManagerImpl) DescribeTaskList(includeTaskListStatus bool) *s.DescribeTaskListResponse {
 	response := &s.DescribeTaskListResponse{Pollers: c.GetAllPollerInfo()}
 	if !includeTaskListStatus {
@@ -562,6 +577,7 @@ func (c *taskListManagerImpl) DescribeTaskList(includeTaskListStatus bool) *s.De
 		AckLevel:         common.Int64Ptr(c.taskAckManager.getAckLevel()),
 		BacklogCountHint: common.Int64Ptr(c.taskAckManager.getBacklogCountHint()),
 		RatePerSecond:    common.Float64Ptr(c.rateLimiter.Limit()),
+		Burst:            common.Int32Ptr(int32(c.rateLimiter.Burst())),
 		TaskIDBlock: &s.TaskIDBlock{
 			StartID: common.Int64Ptr(taskIDBlock.start),
 			EndID:   common.Int64Ptr(taskIDBlock.end),
@@ -144,6 +144,7 @@ func TestDescribeTaskList(t *testing.T) {
 	require.Equal(t, taskCount, taskListStatus.GetBacklogCountHint())
 	require.True(t, taskListStatus.GetRatePerSecond() > (_defaultTaskDispatchRPS-1))
 	require.True(t, taskListStatus.GetRatePerSecond() < (_defaultTaskDispatchRPS+1))
+	require.Equal(t, int32(_defaultTaskDispatchRPS), taskListStatus.GetBurst())
 	taskIDBlock := taskListStatus.GetTaskIDBlock()
 	require.Equal(t, int64(1), taskIDBlock.GetStartID())
 	require.Equal(t, tlm.config.RangeSize, taskIDBlock.GetEndID())
@@ -96,7 +96,10 @@ func uploadHistoryActivity(ctx context.Context, request ArchiveRequest) (err err
 		metricsClient.IncCounter(metrics.ArchiverUploadHistoryActivityScope, metrics.ArchiverSkipUploadCount)
 		return nil
 	}
-	if domainCacheEntry.GetConfig().ArchivalStatus != shared.ArchivalStatusEnabled {
+	// while a bucket migration is in progress histories keep being archived to the current bucket,
+	// the migrator copies them to the target bucket before completing the migration
+	domainArchivalStatus := domainCacheEntry.GetConfig().ArchivalStatus
+	if domainArchivalStatus != shared.ArchivalStatusEnabled && domainArchivalStatus != shared.ArchivalStatusMigrating {
 		logging.LogSkipArchivalUpload(logger, "domain is not enabled for archival")
 		metricsClient.IncCounter(metrics.ArchiverUploadHistoryActivityScope, metrics.ArchiverSkipUploadCount)
 		return nil
@@ -114,6 +117,7 @@ func uploadHistoryActivity(ctx context.Context, request ArchiveRequest) (err err
 		historyBlobReader = NewHistoryBlobReader(NewHistoryBlobIterator(request, container, domainName, clusterName))
 	}
 	blobstoreClient := container.Blobstore
+	retryPolicy := newBlobstoreRetryPolicy(container.Config)
 	handledLastBlob := false
 	for pageToken := common.FirstBlobPageToken; !handledLastBlob; pageToken++ {
 		key, err := NewHistoryBlobKey(request.DomainID, request.WorkflowID, request.RunID, pageToken)
@@ -121,7 +125,7 @@ func uploadHistoryActivity(ctx context.Context, request ArchiveRequest) (err err
 			logging.LogFailArchivalUploadAttempt(logger, err, "could not construct blob key", bucket, "")
 			return cadence.NewCustomError(errConstructBlob)
 		}
-		tags, err := getTags(ctx, blobstoreClient, bucket, key)
+		tags, err := getTags(ctx, blobstoreClient, retryPolicy, bucket, key)
 		if err != nil && err != blobstore.ErrBlobNotExists {
 			logging.LogFailArchivalUploadAttempt(logger, err, "could not get blob tags", bucket, key.String())
 			return err
@@ -154,7 +158,7 @@ func uploadHistoryActivity(ctx context.Context, request ArchiveRequest) (err err
 			return cadence.NewCustomError(errConstructBlob)
 		}
 		if runConstTest {
-			existingBlob, err := downloadBlob(ctx, blobstoreClient, bucket, key)
+			existingBlob, err := downloadBlob(ctx, blobstoreClient, retryPolicy, bucket, key)
 			if err != nil {
 				logger.WithError(err).Error("failed to download blob for deterministic construction verification")
 				metricsClient.IncCounter(metrics.ArchiverUploadHistoryActivityScope, metrics.ArchiverCouldNotRunDeterministicConstructionCheckCount)
@@ -164,7 +168,7 @@ func uploadHistoryActivity(ctx context.Context, request ArchiveRequest) (err err
 			}
 			continue
 		}
-		if err := uploadBlob(ctx, blobstoreClient, bucket, key, blob); err != nil {
+		if err := uploadBlob(ctx, blobstoreClient, retryPolicy, bucket, key, blob); err != nil {
 			logging.LogFailArchivalUploadAttempt(logger, err, "could not upload blob", bucket, key.String())
 			return err
 		}
@@ -223,10 +227,11 @@ func getBlob(ctx context.Context, historyBlobReader HistoryBlobReader, blobPage
 	return blob, nil
 }
 
-func getTags(ctx context.Context, blobstoreClient blobstore.Client, bucket string, key blob.Key) (map[string]string, error) {
+func getTags(ctx context.Context, blobstoreClient blobstore.Client, retryPolicy backoff.RetryPolicy, bucket string, key blob.Key) (map[string]string, error) {
 	bCtx, cancel := context.WithTimeout(ctx, blobstoreTimeout)
 	tags, err := blobstoreClient.GetTags(bCtx, bucket, key)
 	cancel()
+	retrier := backoff.NewRetrier(retryPolicy, backoff.SystemClock)
 	for err != nil {
 		if err == blobstore.ErrBlobNotExists {
 			return nil, err
@@ -234,7 +239,7 @@ func getTags(ctx context.Context, blobstoreClient blobstore.Client, bucket strin
 		if !blobstoreClient.IsRetryableError(err) {
 			return nil, cadence.NewCustomError(errGetTags)
 		}
-		if contextExpired(ctx) {
+		if !waitForRetry(ctx, retrier) {
 			return nil, errContextTimeout
 		}
 		bCtx, cancel = context.WithTimeout(ctx, blobstoreTimeout)
@@ -244,15 +249,16 @@ func getTags(ctx context.Context, blobstoreClient blobstore.Client, bucket strin
 	return tags, nil
 }
 
-func uploadBlob(ctx context.Context, blobstoreClient blobstore.Client, bucket string, key blob.Key, blob *blob.Blob) error {
+func uploadBlob(ctx context.Context, blobstoreClient blobstore.Client, retryPolicy backoff.RetryPolicy, bucket string, key blob.Key, blob *blob.Blob) error {
 	bCtx, cancel := context.WithTimeout(ctx, blobstoreTimeout)
 	err := blobstoreClient.Upload(bCtx, bucket, key, blob)
 	cancel()
+	retrier := backoff.NewRetrier(retryPolicy, backoff.SystemClock)
 	for err != nil {
 		if !blobstoreClient.IsRetryableError(err) {
 			return cadence.NewCustomError(errUploadBlob)
 		}
-		if contextExpired(ctx) {
+		if !waitForRetry(ctx, retrier) {
 			return errContextTimeout
 		}
 		bCtx, cancel = context.WithTimeout(ctx, blobstoreTimeout)
@@ -262,15 +268,16 @@ func uploadBlob(ctx context.Context, blobstoreClient blobstore.Client, bucket st
 	return nil
 }
 
-func downloadBlob(ctx context.Context, blobstoreClient blobstore.Client, bucket string, key blob.Key) (*blob.Blob, error) {
+func downloadBlob(ctx context.Context, blobstoreClient blobstore.Client, retryPolicy backoff.RetryPolicy, bucket string, key blob.Key) (*blob.Blob, error) {
 	bCtx, cancel := context.WithTimeout(ctx, blobstoreTimeout)
 	blob, err := blobstoreClient.Download(bCtx, bucket, key)
 	cancel()
+	retrier := backoff.NewRetrier(retryPolicy, backoff.SystemClock)
 	for err != nil {
 		if !blobstoreClient.IsRetryableError(err) {
 			return nil, cadence.NewCustomError(errDownloadBlob)
 		}
-		if contextExpired(ctx) {
+		if !waitForRetry(ctx, retrier) {
 			return nil, errContextTimeout
 		}
 		bCtx, cancel = context.WithTimeout(ctx, blobstoreTimeout)
@@ -374,6 +381,34 @@ func contextExpired(ctx context.Context) bool {
 	}
 }
 
+// newBlobstoreRetryPolicy returns the backoff between retries of failed blobstore calls,
+// the retries are not limited by the policy but stop once the activity context expires
+func newBlobstoreRetryPolicy(config *Config) backoff.RetryPolicy {
+	policy := backoff.NewExponentialRetryPolicy(config.BlobstoreRetryInitialInterval())
+	policy.SetMaximumInterval(config.BlobstoreRetryMaxInterval())
+	policy.SetExpirationInterval(backoff.NoInterval)
+	return policy
+}
+
+// waitForRetry waits for the next backoff of retrier, it returns false if the context expires first
+func waitForRetry(ctx context.Context, retrier backoff.Retrier) bool {
+	if contextExpired(ctx) {
+		return false
+	}
+	next := retrier.NextBackOff()
+	if next < 0 {
+		return false
+	}
+	timer := time.NewTimer(next)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
 func runConstructionCheck(probability float64) bool {
 	if probability <= 0 {
 		return false
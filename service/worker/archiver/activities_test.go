@@ -24,6 +24,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -775,7 +776,7 @@ func (s *activitiesSuite) archivalConfig(
 	mockClusterMetadata.On("ArchivalConfig").Return(cluster.NewArchivalConfig(clusterArchivalStatus, clusterDefaultBucket, clusterEnablesArchival))
 	mockClusterMetadata.On("IsGlobalDomainEnabled").Return(false)
 	mockClusterMetadata.On("GetCurrentClusterName").Return(testCurrentClusterName)
-	mockMetadataMgr.On("GetDomain", mock.Anything).Return(
+	mockMetadataMgr.On("GetDomain", mock.Anything, mock.Anything).Return(
 		&persistence.GetDomainResponse{
 			Info: &persistence.DomainInfo{ID: testDomainID, Name: testDomain},
 			Config: &persistence.DomainConfig{
@@ -804,6 +805,8 @@ func getConfig(constCheck bool) *Config {
 	return &Config{
 		DeterministicConstructionCheckProbability: dynamicconfig.GetFloatPropertyFn(probability),
 		EnableArchivalCompression:                 dynamicconfig.GetBoolPropertyFnFilteredByDomain(true),
+		BlobstoreRetryInitialInterval:             dynamicconfig.GetDurationPropertyFn(time.Millisecond),
+		BlobstoreRetryMaxInterval:                 dynamicconfig.GetDurationPropertyFn(10 * time.Millisecond),
 	}
 }
 
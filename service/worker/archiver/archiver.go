@@ -42,6 +42,7 @@ type (
 		logger        bark.Logger
 		metricsClient metrics.Client
 		concurrency   int
+		retryPolicy   *cadence.RetryPolicy
 		requestCh     workflow.Channel
 		resultCh      workflow.Channel
 	}
@@ -53,6 +54,7 @@ func NewArchiver(
 	logger bark.Logger,
 	metricsClient metrics.Client,
 	concurrency int,
+	uploadRetryPolicy *cadence.RetryPolicy,
 	requestCh workflow.Channel,
 ) Archiver {
 	return &archiver{
@@ -60,6 +62,7 @@ func NewArchiver(
 		logger:        logger,
 		metricsClient: metricsClient,
 		concurrency:   concurrency,
+		retryPolicy:   uploadRetryPolicy,
 		requestCh:     requestCh,
 		resultCh:      workflow.NewChannel(ctx),
 	}
@@ -77,7 +80,7 @@ func (a *archiver) Start() {
 				if more := a.requestCh.Receive(ctx, &request); !more {
 					break
 				}
-				handleRequest(ctx, a.logger, a.metricsClient, request)
+				handleRequest(ctx, a.logger, a.metricsClient, a.retryPolicy, request)
 				handledHashes = append(handledHashes, hashArchiveRequest(request))
 			}
 			a.resultCh.Send(a.ctx, handledHashes)
@@ -100,31 +103,78 @@ func (a *archiver) Finished() []uint64 {
 	return handledHashes
 }
 
-func handleRequest(ctx workflow.Context, logger bark.Logger, metricsClient metrics.Client, request ArchiveRequest) {
+func handleRequest(
+	ctx workflow.Context,
+	logger bark.Logger,
+	metricsClient metrics.Client,
+	uploadRetryPolicy *cadence.RetryPolicy,
+	request ArchiveRequest,
+) {
 	sw := metricsClient.StartTimer(metrics.ArchiverScope, metrics.ArchiverHandleRequestLatency)
 	defer sw.Stop()
 
 	logger = tagLoggerWithRequest(logger, request)
+	if err := uploadHistory(ctx, metricsClient, uploadRetryPolicy, request); err != nil {
+		logger.WithField(logging.TagErr, err).Error("failed to upload history")
+		// the history is kept until the request is redriven from the archival DLQ of the domain,
+		// it is only deleted without archiving when the DLQ is disabled or cannot be reached
+		if sendToDLQ(ctx, logger, metricsClient, request, err) {
+			return
+		}
+		logger.Error("moving on to deleting history without archiving")
+	}
+	deleteHistory(ctx, logger, metricsClient, request)
+}
+
+func uploadHistory(ctx workflow.Context, metricsClient metrics.Client, uploadRetryPolicy *cadence.RetryPolicy, request ArchiveRequest) error {
+	retryPolicy := *uploadRetryPolicy
+	retryPolicy.NonRetriableErrorReasons = uploadHistoryActivityNonRetryableErrors
 	ao := workflow.ActivityOptions{
 		ScheduleToStartTimeout: 10 * time.Minute,
 		StartToCloseTimeout:    5 * time.Minute,
+		RetryPolicy:            &retryPolicy,
+	}
+	actCtx := workflow.WithActivityOptions(ctx, ao)
+	uploadSW := metricsClient.StartTimer(metrics.ArchiverScope, metrics.ArchiverUploadWithRetriesLatency)
+	defer uploadSW.Stop()
+	if err := workflow.ExecuteActivity(actCtx, uploadHistoryActivityFnName, request).Get(actCtx, nil); err != nil {
+		metricsClient.IncCounter(metrics.ArchiverScope, metrics.ArchiverUploadFailedAllRetriesCount)
+		return err
+	}
+	metricsClient.IncCounter(metrics.ArchiverScope, metrics.ArchiverUploadSuccessCount)
+	return nil
+}
+
+// sendToDLQ adds the request to the archival DLQ of its domain, it returns false if the request was not added
+func sendToDLQ(ctx workflow.Context, logger bark.Logger, metricsClient metrics.Client, request ArchiveRequest, uploadErr error) bool {
+	ao := workflow.ActivityOptions{
+		ScheduleToStartTimeout: 10 * time.Minute,
+		StartToCloseTimeout:    time.Minute,
 		RetryPolicy: &cadence.RetryPolicy{
 			InitialInterval:          time.Second,
 			BackoffCoefficient:       2.0,
 			ExpirationInterval:       10 * time.Minute,
-			NonRetriableErrorReasons: uploadHistoryActivityNonRetryableErrors,
+			NonRetriableErrorReasons: sendToDLQActivityNonRetryableErrors,
 		},
 	}
 	actCtx := workflow.WithActivityOptions(ctx, ao)
-	uploadSW := metricsClient.StartTimer(metrics.ArchiverScope, metrics.ArchiverUploadWithRetriesLatency)
-	if err := workflow.ExecuteActivity(actCtx, uploadHistoryActivityFnName, request).Get(actCtx, nil); err != nil {
-		logger.WithField(logging.TagErr, err).Error("failed to upload history, moving on to deleting history without archiving")
-		metricsClient.IncCounter(metrics.ArchiverScope, metrics.ArchiverUploadFailedAllRetriesCount)
-	} else {
-		metricsClient.IncCounter(metrics.ArchiverScope, metrics.ArchiverUploadSuccessCount)
+	entry := DLQEntry{
+		Request:  request,
+		Reason:   errorReason(uploadErr),
+		FailedAt: workflow.Now(ctx),
+	}
+	if err := workflow.ExecuteActivity(actCtx, sendToDLQActivityFnName, entry).Get(actCtx, nil); err != nil {
+		if errorReason(err) != errDLQDisabled {
+			logger.WithField(logging.TagErr, err).Error("failed to send archival request to DLQ")
+			metricsClient.IncCounter(metrics.ArchiverScope, metrics.ArchiverSendToDLQFailedCount)
+		}
+		return false
 	}
-	uploadSW.Stop()
+	metricsClient.IncCounter(metrics.ArchiverScope, metrics.ArchiverSendToDLQCount)
+	return true
+}
 
+func deleteHistory(ctx workflow.Context, logger bark.Logger, metricsClient metrics.Client, request ArchiveRequest) {
 	lao := workflow.LocalActivityOptions{
 		ScheduleToCloseTimeout: 1 * time.Minute,
 		RetryPolicy: &cadence.RetryPolicy{
@@ -144,7 +194,7 @@ func handleRequest(ctx workflow.Context, logger bark.Logger, metricsClient metri
 	}
 	metricsClient.IncCounter(metrics.ArchiverScope, metrics.ArchiverDeleteLocalFailedAllRetriesCount)
 	logger.WithField(logging.TagErr, err).Warn("deleting history though local activity failed, attempting to run as normal activity")
-	ao = workflow.ActivityOptions{
+	ao := workflow.ActivityOptions{
 		ScheduleToStartTimeout: 10 * time.Minute,
 		StartToCloseTimeout:    5 * time.Minute,
 		RetryPolicy: &cadence.RetryPolicy{
@@ -154,7 +204,7 @@ func handleRequest(ctx workflow.Context, logger bark.Logger, metricsClient metri
 			NonRetriableErrorReasons: deleteHistoryActivityNonRetryableErrors,
 		},
 	}
-	actCtx = workflow.WithActivityOptions(ctx, ao)
+	actCtx := workflow.WithActivityOptions(ctx, ao)
 	if err := workflow.ExecuteActivity(actCtx, deleteHistoryActivityFnName, request).Get(actCtx, nil); err != nil {
 		logger.WithField(logging.TagErr, err).Error("failed to delete history, this means zombie histories are left")
 		metricsClient.IncCounter(metrics.ArchiverScope, metrics.ArchiverDeleteFailedAllRetriesCount)
@@ -73,6 +73,14 @@ type (
 		ArchiverConcurrency                       dynamicconfig.IntPropertyFn
 		ArchivalsPerIteration                     dynamicconfig.IntPropertyFn
 		DeterministicConstructionCheckProbability dynamicconfig.FloatPropertyFn
+		UploadRetryInitialInterval                dynamicconfig.DurationPropertyFn
+		UploadRetryMaxInterval                    dynamicconfig.DurationPropertyFn
+		UploadRetryBackoffCoefficient             dynamicconfig.FloatPropertyFn
+		UploadRetryExpiration                     dynamicconfig.DurationPropertyFn
+		BlobstoreRetryInitialInterval             dynamicconfig.DurationPropertyFn
+		BlobstoreRetryMaxInterval                 dynamicconfig.DurationPropertyFn
+		EnableArchivalDLQ                         dynamicconfig.BoolPropertyFnWithDomainFilter
+		ArchivalDLQMaxSize                        dynamicconfig.IntPropertyFn
 	}
 
 	contextKey int
@@ -100,6 +108,8 @@ func init() {
 	workflow.RegisterWithOptions(archivalWorkflow, workflow.RegisterOptions{Name: archivalWorkflowFnName})
 	activity.RegisterWithOptions(uploadHistoryActivity, activity.RegisterOptions{Name: uploadHistoryActivityFnName})
 	activity.RegisterWithOptions(deleteHistoryActivity, activity.RegisterOptions{Name: deleteHistoryActivityFnName})
+	workflow.RegisterWithOptions(archivalDLQWorkflow, workflow.RegisterOptions{Name: archivalDLQWorkflowFnName})
+	activity.RegisterWithOptions(sendToDLQActivity, activity.RegisterOptions{Name: sendToDLQActivityFnName})
 }
 
 // NewClientWorker returns a new ClientWorker
@@ -0,0 +1,209 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package archiver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/metrics"
+	"go.uber.org/cadence"
+	cclient "go.uber.org/cadence/client"
+	"go.uber.org/cadence/workflow"
+)
+
+type (
+	// DLQEntry is an archival request whose history could not be uploaded, the history is kept until the
+	// request is redriven from the archival DLQ of its domain
+	DLQEntry struct {
+		Request  ArchiveRequest
+		Reason   string
+		FailedAt time.Time
+	}
+)
+
+const (
+	// DLQQueryType is the query type returning the entries of the archival DLQ workflow of a domain
+	DLQQueryType = "dlq"
+	// DLQRedriveSignalName is the signal making the archival DLQ workflow of a domain archive its entries again
+	DLQRedriveSignalName = "cadence-archival-dlq-redrive"
+
+	dlqWorkflowIDPrefix       = "cadence-archival-dlq"
+	dlqSignalName             = "cadence-archival-dlq-signal"
+	archivalDLQWorkflowFnName = "archivalDLQWorkflow"
+	sendToDLQActivityFnName   = "sendToDLQActivity"
+	// dlqSignalsPerRun is the number of signals handled by a run of the archival DLQ workflow before it continues as new
+	dlqSignalsPerRun = 1000
+
+	errDLQDisabled = "archival DLQ is disabled for domain"
+)
+
+var (
+	sendToDLQActivityNonRetryableErrors = []string{errGetDomainByID, errDLQDisabled}
+)
+
+// DLQWorkflowID returns the ID of the workflow in the system domain holding the archival DLQ of a domain
+func DLQWorkflowID(domainID string) string {
+	return fmt.Sprintf("%v-%v", dlqWorkflowIDPrefix, domainID)
+}
+
+func archivalDLQWorkflow(ctx workflow.Context, entries []DLQEntry) error {
+	return archivalDLQWorkflowHelper(ctx, globalLogger, globalMetricsClient, globalConfig, entries)
+}
+
+func archivalDLQWorkflowHelper(
+	ctx workflow.Context,
+	logger bark.Logger,
+	metricsClient metrics.Client,
+	config *Config,
+	entries []DLQEntry,
+) error {
+	metricsClient = NewReplayMetricsClient(metricsClient, ctx)
+	workflowInfo := workflow.GetInfo(ctx)
+	logger = NewReplayBarkLogger(logger.WithFields(bark.Fields{
+		logging.TagWorkflowExecutionID: workflowInfo.WorkflowExecution.ID,
+		logging.TagWorkflowRunID:       workflowInfo.WorkflowExecution.RunID,
+		logging.TagWorkflowType:        workflowInfo.WorkflowType.Name,
+	}), ctx, false)
+	var dcResult dynamicConfigResult
+	_ = workflow.SideEffect(
+		ctx,
+		func(ctx workflow.Context) interface{} {
+			return getDynamicConfigResult(config)
+		}).Get(&dcResult)
+	if err := workflow.SetQueryHandler(ctx, DLQQueryType, func() ([]DLQEntry, error) {
+		return entries, nil
+	}); err != nil {
+		return err
+	}
+
+	entryCh := workflow.GetSignalChannel(ctx, dlqSignalName)
+	redriveCh := workflow.GetSignalChannel(ctx, DLQRedriveSignalName)
+	timerCtx, cancelTimer := workflow.WithCancel(ctx)
+	timer := workflow.NewTimer(timerCtx, workflowStartToCloseTimeout/2)
+	timedOut := false
+	for signals := 0; signals < dlqSignalsPerRun && !timedOut; signals++ {
+		selector := workflow.NewSelector(ctx)
+		selector.AddReceive(entryCh, func(c workflow.Channel, more bool) {
+			var entry DLQEntry
+			c.Receive(ctx, &entry)
+			entries = addDLQEntry(logger, metricsClient, entries, entry, dcResult.ArchivalDLQMaxSize)
+		})
+		selector.AddReceive(redriveCh, func(c workflow.Channel, more bool) {
+			c.Receive(ctx, nil)
+			entries = redriveDLQ(ctx, logger, metricsClient, dcResult.uploadRetryPolicy(), entries)
+		})
+		selector.AddFuture(timer, func(f workflow.Future) {
+			timedOut = true
+		})
+		selector.Select(ctx)
+	}
+	cancelTimer()
+
+	for {
+		var entry DLQEntry
+		if ok := entryCh.ReceiveAsync(&entry); !ok {
+			break
+		}
+		entries = addDLQEntry(logger, metricsClient, entries, entry, dcResult.ArchivalDLQMaxSize)
+	}
+	if redriveCh.ReceiveAsync(nil) {
+		entries = redriveDLQ(ctx, logger, metricsClient, dcResult.uploadRetryPolicy(), entries)
+	}
+	if timedOut && len(entries) == 0 {
+		logger.Info("archival DLQ workflow stopping because DLQ is empty")
+		return nil
+	}
+	ctx = workflow.WithExecutionStartToCloseTimeout(ctx, workflowStartToCloseTimeout)
+	ctx = workflow.WithWorkflowTaskStartToCloseTimeout(ctx, workflowTaskStartToCloseTimeout)
+	return workflow.NewContinueAsNewError(ctx, archivalDLQWorkflowFnName, entries)
+}
+
+// addDLQEntry appends entry to entries, once the DLQ is full the oldest entry is dropped, its history is then
+// neither archived nor deleted
+func addDLQEntry(logger bark.Logger, metricsClient metrics.Client, entries []DLQEntry, entry DLQEntry, maxSize int) []DLQEntry {
+	entries = append(entries, entry)
+	for len(entries) > maxSize && len(entries) > 1 {
+		tagLoggerWithRequest(logger, entries[0].Request).Error("archival DLQ is full, dropping oldest request, this means zombie histories are left")
+		metricsClient.IncCounter(metrics.ArchiverDLQWorkflowScope, metrics.ArchiverDLQDroppedCount)
+		entries = entries[1:]
+	}
+	return entries
+}
+
+// redriveDLQ archives and deletes the histories of entries, it stops at the first upload which fails again
+// so redriving during an outage of the blobstore does not wait for the retries of every entry
+func redriveDLQ(
+	ctx workflow.Context,
+	logger bark.Logger,
+	metricsClient metrics.Client,
+	uploadRetryPolicy *cadence.RetryPolicy,
+	entries []DLQEntry,
+) []DLQEntry {
+	for i, entry := range entries {
+		requestLogger := tagLoggerWithRequest(logger, entry.Request)
+		if err := uploadHistory(ctx, metricsClient, uploadRetryPolicy, entry.Request); err != nil {
+			requestLogger.WithField(logging.TagErr, err).Error("failed to upload history redriven from archival DLQ")
+			metricsClient.IncCounter(metrics.ArchiverDLQWorkflowScope, metrics.ArchiverDLQRedriveFailedCount)
+			entries[i].Reason = errorReason(err)
+			entries[i].FailedAt = workflow.Now(ctx)
+			return entries[i:]
+		}
+		metricsClient.IncCounter(metrics.ArchiverDLQWorkflowScope, metrics.ArchiverDLQRedriveSuccessCount)
+		deleteHistory(ctx, requestLogger, metricsClient, entry.Request)
+	}
+	return nil
+}
+
+// sendToDLQActivity adds an entry to the archival DLQ workflow of its domain, the workflow is started if needed.
+// method will return errDLQDisabled if the DLQ is disabled for the domain of the entry.
+func sendToDLQActivity(ctx context.Context, entry DLQEntry) error {
+	container := ctx.Value(bootstrapContainerKey).(*BootstrapContainer)
+	domainCacheEntry, err := getDomainByID(ctx, container.DomainCache, entry.Request.DomainID)
+	if err != nil {
+		return err
+	}
+	if !container.Config.EnableArchivalDLQ(domainCacheEntry.GetInfo().Name) {
+		return cadence.NewCustomError(errDLQDisabled)
+	}
+	cadenceClient := cclient.NewClient(container.PublicClient, common.SystemDomainName, &cclient.Options{})
+	workflowID := DLQWorkflowID(entry.Request.DomainID)
+	workflowOptions := cclient.StartWorkflowOptions{
+		ID:                              workflowID,
+		TaskList:                        decisionTaskList,
+		ExecutionStartToCloseTimeout:    workflowStartToCloseTimeout,
+		DecisionTaskStartToCloseTimeout: workflowTaskStartToCloseTimeout,
+		WorkflowIDReusePolicy:           cclient.WorkflowIDReusePolicyAllowDuplicate,
+	}
+	_, err = cadenceClient.SignalWithStartWorkflow(ctx, workflowID, dlqSignalName, entry, workflowOptions, archivalDLQWorkflowFnName, nil)
+	return err
+}
+
+func errorReason(err error) string {
+	if customErr, ok := err.(*cadence.CustomError); ok {
+		return customErr.Reason()
+	}
+	return err.Error()
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package archiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/common/metrics"
+	mmocks "github.com/uber/cadence/common/metrics/mocks"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+	"go.uber.org/cadence"
+	"go.uber.org/cadence/testsuite"
+	"go.uber.org/cadence/workflow"
+)
+
+var (
+	dlqTestMetrics *mmocks.Client
+	dlqTestConfig  *Config
+)
+
+type dlqSuite struct {
+	suite.Suite
+	testsuite.WorkflowTestSuite
+}
+
+func TestDLQSuite(t *testing.T) {
+	suite.Run(t, new(dlqSuite))
+}
+
+func (s *dlqSuite) SetupSuite() {
+	workflow.Register(archivalDLQWorkflowTest)
+	workflow.Register(redriveDLQWorkflow)
+}
+
+func (s *dlqSuite) SetupTest() {
+	dlqTestMetrics = &mmocks.Client{}
+	dlqTestMetrics.On("StartTimer", mock.Anything, mock.Anything).Return(metrics.NewTestStopwatch())
+	dlqTestConfig = &Config{
+		ArchiverConcurrency:           dynamicconfig.GetIntPropertyFn(0),
+		ArchivalsPerIteration:         dynamicconfig.GetIntPropertyFn(0),
+		UploadRetryInitialInterval:    dynamicconfig.GetDurationPropertyFn(time.Second),
+		UploadRetryMaxInterval:        dynamicconfig.GetDurationPropertyFn(time.Minute),
+		UploadRetryBackoffCoefficient: dynamicconfig.GetFloatPropertyFn(2.0),
+		UploadRetryExpiration:         dynamicconfig.GetDurationPropertyFn(10 * time.Minute),
+		ArchivalDLQMaxSize:            dynamicconfig.GetIntPropertyFn(2),
+	}
+}
+
+func (s *dlqSuite) TearDownTest() {
+	dlqTestMetrics.AssertExpectations(s.T())
+}
+
+func (s *dlqSuite) TestAddDLQEntry_DropsOldestWhenFull() {
+	dlqTestMetrics.On("IncCounter", metrics.ArchiverDLQWorkflowScope, metrics.ArchiverDLQDroppedCount).Once()
+
+	var entries []DLQEntry
+	for _, runID := range []string{"run-1", "run-2", "run-3"} {
+		entries = addDLQEntry(bark.NewNopLogger(), dlqTestMetrics, entries, DLQEntry{Request: ArchiveRequest{RunID: runID}}, 2)
+	}
+	s.Len(entries, 2)
+	s.Equal("run-2", entries[0].Request.RunID)
+	s.Equal("run-3", entries[1].Request.RunID)
+}
+
+func (s *dlqSuite) TestRedriveDLQ_StopsAtFirstFailure() {
+	dlqTestMetrics.On("IncCounter", metrics.ArchiverScope, metrics.ArchiverUploadSuccessCount).Once()
+	dlqTestMetrics.On("IncCounter", metrics.ArchiverScope, metrics.ArchiverUploadFailedAllRetriesCount).Once()
+	dlqTestMetrics.On("IncCounter", metrics.ArchiverScope, metrics.ArchiverDeleteLocalSuccessCount).Once()
+	dlqTestMetrics.On("IncCounter", metrics.ArchiverDLQWorkflowScope, metrics.ArchiverDLQRedriveSuccessCount).Once()
+	dlqTestMetrics.On("IncCounter", metrics.ArchiverDLQWorkflowScope, metrics.ArchiverDLQRedriveFailedCount).Once()
+
+	env := s.NewTestWorkflowEnvironment()
+	env.OnActivity(uploadHistoryActivityFnName, mock.Anything, mock.MatchedBy(func(request ArchiveRequest) bool {
+		return request.RunID == "run-1"
+	})).Return(nil).Once()
+	env.OnActivity(uploadHistoryActivityFnName, mock.Anything, mock.MatchedBy(func(request ArchiveRequest) bool {
+		return request.RunID == "run-2"
+	})).Return(cadence.NewCustomError(errUploadBlob)).Once()
+	env.OnActivity(deleteHistoryActivityFnName, mock.Anything, mock.Anything).Return(nil).Once()
+	env.ExecuteWorkflow(redriveDLQWorkflow, []DLQEntry{
+		{Request: ArchiveRequest{RunID: "run-1"}},
+		{Request: ArchiveRequest{RunID: "run-2"}},
+		{Request: ArchiveRequest{RunID: "run-3"}},
+	})
+
+	env.AssertExpectations(s.T())
+	s.True(env.IsWorkflowCompleted())
+	s.NoError(env.GetWorkflowError())
+	var remaining []DLQEntry
+	s.NoError(env.GetWorkflowResult(&remaining))
+	s.Len(remaining, 2)
+	s.Equal("run-2", remaining[0].Request.RunID)
+	s.Equal(errUploadBlob, remaining[0].Reason)
+	s.Equal("run-3", remaining[1].Request.RunID)
+}
+
+func (s *dlqSuite) TestArchivalDLQWorkflow_ContinueAsNewWithEntries() {
+	env := s.NewTestWorkflowEnvironment()
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(dlqSignalName, DLQEntry{Request: ArchiveRequest{RunID: "run-1"}})
+	}, time.Minute)
+	env.ExecuteWorkflow(archivalDLQWorkflowTest)
+
+	s.True(env.IsWorkflowCompleted())
+	_, ok := env.GetWorkflowError().(*workflow.ContinueAsNewError)
+	s.True(ok, "Called ContinueAsNew")
+}
+
+func (s *dlqSuite) TestArchivalDLQWorkflow_Exit_Empty() {
+	env := s.NewTestWorkflowEnvironment()
+	env.ExecuteWorkflow(archivalDLQWorkflowTest)
+
+	s.True(env.IsWorkflowCompleted())
+	s.NoError(env.GetWorkflowError())
+}
+
+func archivalDLQWorkflowTest(ctx workflow.Context) error {
+	return archivalDLQWorkflowHelper(ctx, bark.NewNopLogger(), dlqTestMetrics, dlqTestConfig, nil)
+}
+
+func redriveDLQWorkflow(ctx workflow.Context, entries []DLQEntry) ([]DLQEntry, error) {
+	return redriveDLQ(ctx, bark.NewNopLogger(), dlqTestMetrics, dynamicConfigResult{}.uploadRetryPolicy(), entries), nil
+}
@@ -65,7 +65,8 @@ type (
 )
 
 var (
-	errInvalidKeyInput = errors.New("invalid input to construct history blob key")
+	errInvalidKeyInput     = errors.New("invalid input to construct history blob key")
+	errNextPageTokenNotSet = errors.New("next page token is not set on history blob tags")
 )
 
 // NewHistoryBlobKey returns a key for history blob
@@ -76,13 +77,25 @@ func NewHistoryBlobKey(domainID, workflowID, runID string, pageToken int) (blob.
 	if pageToken < common.FirstBlobPageToken {
 		return nil, errInvalidKeyInput
 	}
-	domainIDHash := fmt.Sprintf("%v", farm.Fingerprint64([]byte(domainID)))
+	domainIDHash := NewHistoryBlobKeyPrefix(domainID)
 	workflowIDHash := fmt.Sprintf("%v", farm.Fingerprint64([]byte(workflowID)))
 	runIDHash := fmt.Sprintf("%v", farm.Fingerprint64([]byte(runID)))
 	combinedHash := strings.Join([]string{domainIDHash, workflowIDHash, runIDHash}, "")
 	return blob.NewKey("history", combinedHash, StringPageToken(pageToken))
 }
 
+// NewHistoryBlobKeyPrefix returns the prefix shared by the keys of all history blobs of a domain.
+// Since prefixes of different domains can overlap, blobs listed by prefix should be filtered using IsDomainHistoryBlob.
+func NewHistoryBlobKeyPrefix(domainID string) string {
+	return fmt.Sprintf("%v", farm.Fingerprint64([]byte(domainID)))
+}
+
+// IsDomainHistoryBlob returns true if tags indicate blob is a history blob of given domain, false otherwise
+func IsDomainHistoryBlob(tags map[string]string, domainID string) bool {
+	id, ok := tags["domain_id"]
+	return ok && id == domainID
+}
+
 // StringPageToken converts input blob page token to string form
 func StringPageToken(pageToken int) string {
 	return strconv.Itoa(pageToken)
@@ -111,6 +124,15 @@ func IsLast(tags map[string]string) bool {
 	return ok && last == "true"
 }
 
+// NextPageToken returns the page token of the next blob in archived history as indicated by tags
+func NextPageToken(tags map[string]string) (int, error) {
+	next, ok := tags["next_page_token"]
+	if !ok {
+		return 0, errNextPageTokenNotSet
+	}
+	return strconv.Atoi(next)
+}
+
 func modifyBlobForConstCheck(historyBlob *HistoryBlob, existingTags map[string]string) {
 	historyBlob.Header.UploadCluster = common.StringPtr(existingTags["upload_cluster"])
 	historyBlob.Header.UploadDateTime = common.StringPtr(existingTags["upload_date_time"])
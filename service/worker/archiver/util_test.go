@@ -205,3 +205,30 @@ func (s *UtilSuite) TestIsLast() {
 		s.Equal(tc.isLast, IsLast(tags))
 	}
 }
+
+func (s *UtilSuite) TestNextPageToken() {
+	tags, err := ConvertHeaderToTags(&HistoryBlobHeader{NextPageToken: common.IntPtr(12)})
+	s.NoError(err)
+	nextPageToken, err := NextPageToken(tags)
+	s.NoError(err)
+	s.Equal(12, nextPageToken)
+
+	tags, err = ConvertHeaderToTags(&HistoryBlobHeader{NextPageToken: common.IntPtr(common.LastBlobNextPageToken)})
+	s.NoError(err)
+	nextPageToken, err = NextPageToken(tags)
+	s.NoError(err)
+	s.Equal(common.LastBlobNextPageToken, nextPageToken)
+
+	tags, err = ConvertHeaderToTags(&HistoryBlobHeader{})
+	s.NoError(err)
+	_, err = NextPageToken(tags)
+	s.Error(err)
+}
+
+func (s *UtilSuite) TestIsDomainHistoryBlob() {
+	tags, err := ConvertHeaderToTags(&HistoryBlobHeader{DomainID: common.StringPtr("test-domain-id")})
+	s.NoError(err)
+	s.True(IsDomainHistoryBlob(tags, "test-domain-id"))
+	s.False(IsDomainHistoryBlob(tags, "other-domain-id"))
+	s.False(IsDomainHistoryBlob(map[string]string{}, "test-domain-id"))
+}
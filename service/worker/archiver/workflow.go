@@ -21,15 +21,53 @@
 package archiver
 
 import (
+	"time"
+
 	"github.com/uber-common/bark"
 	"github.com/uber/cadence/common/logging"
 	"github.com/uber/cadence/common/metrics"
+	"go.uber.org/cadence"
 	"go.uber.org/cadence/workflow"
 )
 
 type dynamicConfigResult struct {
-	ArchiverConcurrency   int
-	ArchivalsPerIteration int
+	ArchiverConcurrency           int
+	ArchivalsPerIteration         int
+	UploadRetryInitialInterval    time.Duration
+	UploadRetryMaxInterval        time.Duration
+	UploadRetryBackoffCoefficient float64
+	UploadRetryExpiration         time.Duration
+	ArchivalDLQMaxSize            int
+}
+
+func getDynamicConfigResult(config *Config) dynamicConfigResult {
+	return dynamicConfigResult{
+		ArchiverConcurrency:           config.ArchiverConcurrency(),
+		ArchivalsPerIteration:         config.ArchivalsPerIteration(),
+		UploadRetryInitialInterval:    config.UploadRetryInitialInterval(),
+		UploadRetryMaxInterval:        config.UploadRetryMaxInterval(),
+		UploadRetryBackoffCoefficient: config.UploadRetryBackoffCoefficient(),
+		UploadRetryExpiration:         config.UploadRetryExpiration(),
+		ArchivalDLQMaxSize:            config.ArchivalDLQMaxSize(),
+	}
+}
+
+// uploadRetryPolicy returns the retry policy of the upload activity, the retry values are not set in
+// the side effects recorded by workflows started before they were added, these use the former policy
+func (r dynamicConfigResult) uploadRetryPolicy() *cadence.RetryPolicy {
+	if r.UploadRetryInitialInterval <= 0 || r.UploadRetryExpiration <= 0 {
+		return &cadence.RetryPolicy{
+			InitialInterval:    time.Second,
+			BackoffCoefficient: 2.0,
+			ExpirationInterval: 10 * time.Minute,
+		}
+	}
+	return &cadence.RetryPolicy{
+		InitialInterval:    r.UploadRetryInitialInterval,
+		MaximumInterval:    r.UploadRetryMaxInterval,
+		BackoffCoefficient: r.UploadRetryBackoffCoefficient,
+		ExpirationInterval: r.UploadRetryExpiration,
+	}
 }
 
 func archivalWorkflow(ctx workflow.Context, carryover []ArchiveRequest) error {
@@ -61,14 +99,11 @@ func archivalWorkflowHelper(
 	_ = workflow.SideEffect(
 		ctx,
 		func(ctx workflow.Context) interface{} {
-			return dynamicConfigResult{
-				ArchiverConcurrency:   config.ArchiverConcurrency(),
-				ArchivalsPerIteration: config.ArchivalsPerIteration(),
-			}
+			return getDynamicConfigResult(config)
 		}).Get(&dcResult)
 	requestCh := workflow.NewBufferedChannel(ctx, dcResult.ArchivalsPerIteration)
 	if archiver == nil {
-		archiver = NewArchiver(ctx, logger, metricsClient, dcResult.ArchiverConcurrency, requestCh)
+		archiver = NewArchiver(ctx, logger, metricsClient, dcResult.ArchiverConcurrency, dcResult.uploadRetryPolicy(), requestCh)
 	}
 	archiverSW := metricsClient.StartTimer(metrics.ArchiverArchivalWorkflowScope, metrics.ArchiverHandleAllRequestsLatency)
 	archiver.Start()
@@ -22,6 +22,7 @@ package archiver
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/uber-common/bark"
@@ -59,8 +60,13 @@ func (s *workflowSuite) SetupTest() {
 	workflowTestArchiver = &MockArchiver{}
 	workflowTestPump = &PumpMock{}
 	workflowTestConfig = &Config{
-		ArchiverConcurrency:   dynamicconfig.GetIntPropertyFn(0),
-		ArchivalsPerIteration: dynamicconfig.GetIntPropertyFn(0),
+		ArchiverConcurrency:           dynamicconfig.GetIntPropertyFn(0),
+		ArchivalsPerIteration:         dynamicconfig.GetIntPropertyFn(0),
+		UploadRetryInitialInterval:    dynamicconfig.GetDurationPropertyFn(time.Second),
+		UploadRetryMaxInterval:        dynamicconfig.GetDurationPropertyFn(time.Minute),
+		UploadRetryBackoffCoefficient: dynamicconfig.GetFloatPropertyFn(2.0),
+		UploadRetryExpiration:         dynamicconfig.GetDurationPropertyFn(10 * time.Minute),
+		ArchivalDLQMaxSize:            dynamicconfig.GetIntPropertyFn(10),
 	}
 }
 
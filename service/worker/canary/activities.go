@@ -0,0 +1,50 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package canary
+
+import (
+	"context"
+	"time"
+
+	"github.com/uber/cadence/common/metrics"
+	"go.uber.org/cadence/activity"
+)
+
+// echoActivity returns its input, it is the activity of the canary workflow suite
+func echoActivity(ctx context.Context, value string) (string, error) {
+	return value, nil
+}
+
+// benchActivity completes after the given latency, the metrics emitted are the time the activity waited
+// in the task list and the time it took from being scheduled to being completed
+func benchActivity(ctx context.Context, latency time.Duration) error {
+	container := ctx.Value(bootstrapContainerKey).(*BootstrapContainer)
+	info := activity.GetInfo(ctx)
+	container.MetricsClient.RecordTimer(metrics.BenchScope, metrics.BenchActivityScheduleToStartLatency, info.StartedTimestamp.Sub(info.ScheduledTimestamp))
+
+	select {
+	case <-time.After(latency):
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	container.MetricsClient.RecordTimer(metrics.BenchScope, metrics.BenchActivityLatency, time.Since(info.ScheduledTimestamp))
+	return nil
+}
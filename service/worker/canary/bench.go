@@ -0,0 +1,178 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package canary
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/pborman/uuid"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+	"go.uber.org/cadence/.gen/go/shared"
+	cclient "go.uber.org/cadence/client"
+	"golang.org/x/time/rate"
+)
+
+type (
+	// benchRequest is the input of a bench workflow
+	benchRequest struct {
+		ActivityCount int
+		MinLatency    time.Duration
+		MaxLatency    time.Duration
+	}
+
+	// benchWorkflows keeps the IDs of the most recently started bench workflows, which are the
+	// workflows signals are sent to
+	benchWorkflows struct {
+		sync.Mutex
+		ids  []string
+		next int
+	}
+)
+
+const (
+	// benchSignalTargets is the number of recently started bench workflows signals are spread across
+	benchSignalTargets = 1000
+	// benchDisabledPollInterval is how often the drivers check if the bench load was enabled
+	benchDisabledPollInterval = 10 * time.Second
+	benchRPCTimeout           = 10 * time.Second
+	benchSignalPayload        = "bench"
+)
+
+// benchStartLoop starts bench workflows at the configured rate while the bench load is enabled
+func (c *canary) benchStartLoop() {
+	defer c.stopWG.Done()
+	c.rateLoop(c.container.Config.BenchStartRPS, c.startBenchWorkflow)
+}
+
+// benchSignalLoop sends signals to recently started bench workflows at the configured rate while the
+// bench load is enabled
+func (c *canary) benchSignalLoop() {
+	defer c.stopWG.Done()
+	c.rateLoop(c.container.Config.BenchSignalRPS, c.signalBenchWorkflow)
+}
+
+// rateLoop calls fn at the given rate while the bench load is enabled
+func (c *canary) rateLoop(rps dynamicconfig.IntPropertyFn, fn func()) {
+	limiter := rate.NewLimiter(rate.Limit(rps()), 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-c.stopC
+		cancel()
+	}()
+
+	for {
+		if !c.container.Config.BenchEnabled() || rps() <= 0 {
+			select {
+			case <-c.stopC:
+				return
+			case <-time.After(benchDisabledPollInterval):
+				continue
+			}
+		}
+		if limit := rate.Limit(rps()); limiter.Limit() != limit {
+			limiter.SetLimit(limit)
+		}
+		if err := limiter.Wait(ctx); err != nil {
+			return
+		}
+		// the calls are not serialized so the rate does not depend on the latency of the frontend
+		go fn()
+	}
+}
+
+func (c *canary) startBenchWorkflow() {
+	config := c.container.Config
+	metricsClient := c.container.MetricsClient
+	request := benchRequest{
+		ActivityCount: config.BenchActivityCount(),
+		MinLatency:    config.BenchActivityMinLatency(),
+		MaxLatency:    config.BenchActivityMaxLatency(),
+	}
+	options := cclient.StartWorkflowOptions{
+		ID:                              fmt.Sprintf("%v-%v", benchWorkflowIDPrefix, uuid.New()),
+		TaskList:                        taskListName,
+		ExecutionStartToCloseTimeout:    benchWorkflowTimeout,
+		DecisionTaskStartToCloseTimeout: workflowTaskStartToCloseTimeout,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), benchRPCTimeout)
+	defer cancel()
+	sw := metricsClient.StartTimer(metrics.BenchScope, metrics.BenchWorkflowStartLatency)
+	_, err := c.client.StartWorkflow(ctx, options, benchWorkflowTypeName, request)
+	sw.Stop()
+	if err != nil {
+		metricsClient.IncCounter(metrics.BenchScope, metrics.BenchFailures)
+		c.container.Logger.WithField(logging.TagErr, err).Warn("failed to start bench workflow")
+		return
+	}
+	metricsClient.IncCounter(metrics.BenchScope, metrics.BenchWorkflowsStartedCount)
+	c.benchWorkflows.add(options.ID)
+}
+
+func (c *canary) signalBenchWorkflow() {
+	workflowID, ok := c.benchWorkflows.pick()
+	if !ok {
+		return
+	}
+	metricsClient := c.container.MetricsClient
+
+	ctx, cancel := context.WithTimeout(context.Background(), benchRPCTimeout)
+	defer cancel()
+	sw := metricsClient.StartTimer(metrics.BenchScope, metrics.BenchSignalLatency)
+	err := c.client.SignalWorkflow(ctx, workflowID, "", benchSignalName, benchSignalPayload)
+	sw.Stop()
+	switch err.(type) {
+	case nil:
+		metricsClient.IncCounter(metrics.BenchScope, metrics.BenchSignalsSentCount)
+	case *shared.EntityNotExistsError:
+		// the bench workflow completed already
+	default:
+		metricsClient.IncCounter(metrics.BenchScope, metrics.BenchFailures)
+		c.container.Logger.WithField(logging.TagErr, err).Warn("failed to signal bench workflow")
+	}
+}
+
+func (w *benchWorkflows) add(workflowID string) {
+	w.Lock()
+	defer w.Unlock()
+	if len(w.ids) < benchSignalTargets {
+		w.ids = append(w.ids, workflowID)
+		return
+	}
+	w.ids[w.next] = workflowID
+	w.next = (w.next + 1) % benchSignalTargets
+}
+
+func (w *benchWorkflows) pick() (string, bool) {
+	w.Lock()
+	defer w.Unlock()
+	if len(w.ids) == 0 {
+		return "", false
+	}
+	return w.ids[rand.Intn(len(w.ids))], true
+}
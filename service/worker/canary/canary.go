@@ -0,0 +1,83 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package canary
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pborman/uuid"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/metrics"
+	cclient "go.uber.org/cadence/client"
+)
+
+// canaryLoop runs the canary workflow suite every interval while the canary is enabled
+func (c *canary) canaryLoop() {
+	defer c.stopWG.Done()
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-c.stopC:
+			return
+		case <-timer.C:
+			if c.container.Config.CanaryEnabled() {
+				c.runCanary()
+			}
+			timer.Reset(c.container.Config.CanaryInterval())
+		}
+	}
+}
+
+// runCanary executes the canary workflow suite and waits for its result, the metrics emitted are the
+// outcome of the suite and its end to end latency
+func (c *canary) runCanary() {
+	metricsClient := c.container.MetricsClient
+	sw := metricsClient.StartTimer(metrics.CanaryScope, metrics.CanaryLatency)
+	defer sw.Stop()
+
+	if err := c.executeCanary(); err != nil {
+		metricsClient.IncCounter(metrics.CanaryScope, metrics.CanaryFailures)
+		c.container.Logger.WithField(logging.TagErr, err).Error("canary workflow suite failed")
+		return
+	}
+	metricsClient.IncCounter(metrics.CanaryScope, metrics.CanarySuccessCount)
+}
+
+func (c *canary) executeCanary() error {
+	ctx, cancel := context.WithTimeout(context.Background(), canaryWorkflowTimeout)
+	defer cancel()
+
+	options := cclient.StartWorkflowOptions{
+		ID:                              fmt.Sprintf("%v-%v", canaryWorkflowIDPrefix, uuid.New()),
+		TaskList:                        taskListName,
+		ExecutionStartToCloseTimeout:    canaryWorkflowTimeout,
+		DecisionTaskStartToCloseTimeout: workflowTaskStartToCloseTimeout,
+	}
+	run, err := c.client.ExecuteWorkflow(ctx, options, canaryWorkflowTypeName)
+	if err != nil {
+		return err
+	}
+	return run.Get(ctx, nil)
+}
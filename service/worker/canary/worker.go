@@ -0,0 +1,153 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package canary
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+	"go.uber.org/cadence/.gen/go/cadence/workflowserviceclient"
+	"go.uber.org/cadence/activity"
+	cclient "go.uber.org/cadence/client"
+	"go.uber.org/cadence/worker"
+	"go.uber.org/cadence/workflow"
+)
+
+type (
+	// Canary hosts the canary and bench workflows and drives them against the frontend, the canary workflow
+	// suite validates the health of the cluster while the bench load measures its capacity
+	Canary interface {
+		Start() error
+		Stop()
+	}
+
+	// Config contains the configuration of the canary and of the bench load
+	Config struct {
+		// CanaryEnabled indicates if the canary workflow suite is run
+		CanaryEnabled dynamicconfig.BoolPropertyFn
+		// CanaryInterval is the interval between two runs of the canary workflow suite
+		CanaryInterval dynamicconfig.DurationPropertyFn
+		// BenchEnabled indicates if the bench load is generated
+		BenchEnabled dynamicconfig.BoolPropertyFn
+		// BenchStartRPS is the rate at which bench workflows are started
+		BenchStartRPS dynamicconfig.IntPropertyFn
+		// BenchSignalRPS is the rate at which signals are sent to running bench workflows
+		BenchSignalRPS dynamicconfig.IntPropertyFn
+		// BenchActivityCount is the number of activities executed by each bench workflow
+		BenchActivityCount dynamicconfig.IntPropertyFn
+		// BenchActivityMinLatency is the minimum time a bench activity takes to complete
+		BenchActivityMinLatency dynamicconfig.DurationPropertyFn
+		// BenchActivityMaxLatency is the maximum time a bench activity takes to complete, the latency of
+		// each activity is uniformly distributed between the minimum and the maximum
+		BenchActivityMaxLatency dynamicconfig.DurationPropertyFn
+	}
+
+	// BootstrapContainer contains everything needed by the canary and bench drivers, workflows and activities
+	BootstrapContainer struct {
+		PublicClient  workflowserviceclient.Interface
+		MetricsClient metrics.Client
+		Logger        bark.Logger
+		// Domain is the domain the canary and bench workflows run in
+		Domain string
+		Config *Config
+	}
+
+	canary struct {
+		container *BootstrapContainer
+		client    cclient.Client
+		worker    worker.Worker
+		// benchWorkflows are the recently started bench workflows signals are sent to
+		benchWorkflows *benchWorkflows
+		stopC          chan struct{}
+		stopWG         sync.WaitGroup
+	}
+
+	contextKey int
+)
+
+const (
+	taskListName                    = "cadence-sys-canary-tl"
+	canaryWorkflowIDPrefix          = "cadence-sys-canary"
+	benchWorkflowIDPrefix           = "cadence-sys-bench"
+	canaryWorkflowTypeName          = "cadence-sys-canary-workflow"
+	canaryChildWorkflowTypeName     = "cadence-sys-canary-child-workflow"
+	benchWorkflowTypeName           = "cadence-sys-bench-workflow"
+	echoActivityName                = "cadence-sys-canary-echo-activity"
+	benchActivityName               = "cadence-sys-bench-activity"
+	benchSignalName                 = "cadence-sys-bench-signal"
+	canaryWorkflowTimeout           = time.Minute
+	benchWorkflowTimeout            = time.Hour
+	workflowTaskStartToCloseTimeout = 10 * time.Second
+
+	bootstrapContainerKey contextKey = iota
+)
+
+func init() {
+	workflow.RegisterWithOptions(canaryWorkflow, workflow.RegisterOptions{Name: canaryWorkflowTypeName})
+	workflow.RegisterWithOptions(canaryChildWorkflow, workflow.RegisterOptions{Name: canaryChildWorkflowTypeName})
+	workflow.RegisterWithOptions(benchWorkflow, workflow.RegisterOptions{Name: benchWorkflowTypeName})
+	activity.RegisterWithOptions(echoActivity, activity.RegisterOptions{Name: echoActivityName})
+	activity.RegisterWithOptions(benchActivity, activity.RegisterOptions{Name: benchActivityName})
+}
+
+// New returns a new Canary polling the task list of the canary and bench workflows of the domain of the container
+func New(container *BootstrapContainer) Canary {
+	container.Logger = container.Logger.WithFields(bark.Fields{
+		logging.TagWorkflowComponent: logging.TagValueCanaryComponent,
+		logging.TagDomain:            container.Domain,
+	})
+	actCtx := context.WithValue(context.Background(), bootstrapContainerKey, container)
+	wo := worker.Options{
+		BackgroundActivityContext: actCtx,
+	}
+	return &canary{
+		container:      container,
+		client:         cclient.NewClient(container.PublicClient, container.Domain, &cclient.Options{}),
+		worker:         worker.New(container.PublicClient, container.Domain, taskListName, wo),
+		benchWorkflows: &benchWorkflows{},
+		stopC:          make(chan struct{}),
+	}
+}
+
+// Start the worker of the canary and bench workflows and the drivers starting them
+func (c *canary) Start() error {
+	if err := c.worker.Start(); err != nil {
+		c.worker.Stop()
+		return err
+	}
+	c.stopWG.Add(3)
+	go c.canaryLoop()
+	go c.benchStartLoop()
+	go c.benchSignalLoop()
+	return nil
+}
+
+// Stop the drivers and the worker
+func (c *canary) Stop() {
+	close(c.stopC)
+	c.stopWG.Wait()
+	c.worker.Stop()
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package canary
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"go.uber.org/cadence/workflow"
+)
+
+const (
+	// canaryEchoValue is the value the echo activity of the canary workflow suite is expected to return
+	canaryEchoValue = "cadence-canary"
+	// canaryTimerDuration is the duration of the timer of the canary workflow suite
+	canaryTimerDuration = time.Second
+	// benchActivityTimeoutMargin is added to the maximum latency of bench activities to get their timeout
+	benchActivityTimeoutMargin = 10 * time.Second
+)
+
+var (
+	canaryActivityOptions = workflow.ActivityOptions{
+		ScheduleToStartTimeout: 10 * time.Second,
+		StartToCloseTimeout:    10 * time.Second,
+	}
+
+	canaryChildWorkflowOptions = workflow.ChildWorkflowOptions{
+		ExecutionStartToCloseTimeout: 30 * time.Second,
+		TaskStartToCloseTimeout:      workflowTaskStartToCloseTimeout,
+	}
+)
+
+// canaryWorkflow is the canary workflow suite, it fails unless an activity, a timer and a child workflow
+// all complete as expected, which covers the frontend, history and matching paths used by most workflows
+func canaryWorkflow(ctx workflow.Context) error {
+	ctx = workflow.WithActivityOptions(ctx, canaryActivityOptions)
+	var echo string
+	if err := workflow.ExecuteActivity(ctx, echoActivityName, canaryEchoValue).Get(ctx, &echo); err != nil {
+		return fmt.Errorf("activity failed: %v", err)
+	}
+	if echo != canaryEchoValue {
+		return fmt.Errorf("activity returned %q instead of %q", echo, canaryEchoValue)
+	}
+
+	if err := workflow.Sleep(ctx, canaryTimerDuration); err != nil {
+		return fmt.Errorf("timer failed: %v", err)
+	}
+
+	ctx = workflow.WithChildOptions(ctx, canaryChildWorkflowOptions)
+	echo = ""
+	if err := workflow.ExecuteChildWorkflow(ctx, canaryChildWorkflowTypeName).Get(ctx, &echo); err != nil {
+		return fmt.Errorf("child workflow failed: %v", err)
+	}
+	if echo != canaryEchoValue {
+		return fmt.Errorf("child workflow returned %q instead of %q", echo, canaryEchoValue)
+	}
+	return nil
+}
+
+// canaryChildWorkflow is the child workflow of the canary workflow suite
+func canaryChildWorkflow(ctx workflow.Context) (string, error) {
+	ctx = workflow.WithActivityOptions(ctx, canaryActivityOptions)
+	var echo string
+	err := workflow.ExecuteActivity(ctx, echoActivityName, canaryEchoValue).Get(ctx, &echo)
+	return echo, err
+}
+
+// benchWorkflow executes the requested number of activities one after the other while it receives the
+// signals of the bench load, it returns the number of signals received
+func benchWorkflow(ctx workflow.Context, request benchRequest) (int, error) {
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		ScheduleToStartTimeout: benchWorkflowTimeout,
+		StartToCloseTimeout:    request.MaxLatency + benchActivityTimeoutMargin,
+	})
+	signalCh := workflow.GetSignalChannel(ctx, benchSignalName)
+
+	signals := 0
+	for i := 0; i < request.ActivityCount; i++ {
+		var latency time.Duration
+		if err := workflow.SideEffect(ctx, func(ctx workflow.Context) interface{} {
+			return request.latency()
+		}).Get(&latency); err != nil {
+			return signals, err
+		}
+		if err := workflow.ExecuteActivity(ctx, benchActivityName, latency).Get(ctx, nil); err != nil {
+			return signals, err
+		}
+		signals += receiveSignals(signalCh)
+	}
+	signals += receiveSignals(signalCh)
+	return signals, nil
+}
+
+// receiveSignals drains the signals already delivered to the channel, it returns the number of signals received
+func receiveSignals(signalCh workflow.Channel) int {
+	count := 0
+	var payload string
+	for signalCh.ReceiveAsync(&payload) {
+		count++
+	}
+	return count
+}
+
+// latency returns the latency of a bench activity, uniformly distributed between the minimum and the maximum
+func (r benchRequest) latency() time.Duration {
+	if r.MaxLatency <= r.MinLatency {
+		return r.MinLatency
+	}
+	return r.MinLatency + time.Duration(rand.Int63n(int64(r.MaxLatency-r.MinLatency)))
+}
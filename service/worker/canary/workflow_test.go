@@ -0,0 +1,103 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package canary
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/cadence/testsuite"
+)
+
+type workflowSuite struct {
+	suite.Suite
+	testsuite.WorkflowTestSuite
+}
+
+func TestWorkflowSuite(t *testing.T) {
+	suite.Run(t, new(workflowSuite))
+}
+
+func (s *workflowSuite) TestCanaryWorkflow() {
+	env := s.NewTestWorkflowEnvironment()
+	env.OnActivity(echoActivityName, mock.Anything, canaryEchoValue).Return(canaryEchoValue, nil).Times(2)
+
+	env.ExecuteWorkflow(canaryWorkflow)
+	s.True(env.IsWorkflowCompleted())
+	s.NoError(env.GetWorkflowError())
+	env.AssertExpectations(s.T())
+}
+
+func (s *workflowSuite) TestCanaryWorkflow_ActivityFailed() {
+	env := s.NewTestWorkflowEnvironment()
+	env.OnActivity(echoActivityName, mock.Anything, canaryEchoValue).Return("", errors.New("activity error"))
+
+	env.ExecuteWorkflow(canaryWorkflow)
+	s.True(env.IsWorkflowCompleted())
+	s.Error(env.GetWorkflowError())
+}
+
+func (s *workflowSuite) TestCanaryWorkflow_UnexpectedEcho() {
+	env := s.NewTestWorkflowEnvironment()
+	env.OnActivity(echoActivityName, mock.Anything, canaryEchoValue).Return("unexpected", nil)
+
+	env.ExecuteWorkflow(canaryWorkflow)
+	s.True(env.IsWorkflowCompleted())
+	s.Error(env.GetWorkflowError())
+}
+
+func (s *workflowSuite) TestBenchWorkflow() {
+	env := s.NewTestWorkflowEnvironment()
+	var latencies []time.Duration
+	env.OnActivity(benchActivityName, mock.Anything, mock.Anything).Return(func(ctx context.Context, latency time.Duration) error {
+		latencies = append(latencies, latency)
+		return nil
+	})
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(benchSignalName, benchSignalPayload)
+		env.SignalWorkflow(benchSignalName, benchSignalPayload)
+	}, 0)
+
+	request := benchRequest{
+		ActivityCount: 3,
+		MinLatency:    time.Second,
+		MaxLatency:    2 * time.Second,
+	}
+	env.ExecuteWorkflow(benchWorkflow, request)
+	s.True(env.IsWorkflowCompleted())
+	s.NoError(env.GetWorkflowError())
+	var signals int
+	s.NoError(env.GetWorkflowResult(&signals))
+	s.Equal(2, signals)
+	s.Len(latencies, 3)
+	for _, latency := range latencies {
+		s.True(latency >= request.MinLatency && latency < request.MaxLatency)
+	}
+}
+
+func (s *workflowSuite) TestBenchRequestLatency() {
+	s.Equal(time.Second, benchRequest{MinLatency: time.Second, MaxLatency: time.Second}.latency())
+	s.Equal(time.Second, benchRequest{MinLatency: time.Second}.latency())
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package concurrency
+
+import (
+	"context"
+
+	"github.com/uber-common/bark"
+	h "github.com/uber/cadence/.gen/go/history"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/metrics"
+)
+
+// dispatchActivity schedules the first decision of a queued run, it returns false if the run
+// was closed while it was queued, in which case it does not count against the limit of the group
+func dispatchActivity(ctx context.Context, request Request) (bool, error) {
+	container := ctx.Value(bootstrapContainerKey).(*BootstrapContainer)
+	err := container.HistoryClient.ScheduleDecisionTask(ctx, &h.ScheduleDecisionTaskRequest{
+		DomainUUID: common.StringPtr(request.DomainID),
+		WorkflowExecution: &shared.WorkflowExecution{
+			WorkflowId: common.StringPtr(request.WorkflowID),
+			RunId:      common.StringPtr(request.RunID),
+		},
+		IsFirstDecision: common.BoolPtr(true),
+	})
+	switch err.(type) {
+	case nil:
+		container.MetricsClient.IncCounter(metrics.ConcurrencyGroupScope, metrics.ConcurrencyGroupRunsDispatchedCount)
+		return true, nil
+	case *shared.EntityNotExistsError:
+		return false, nil
+	default:
+		container.MetricsClient.IncCounter(metrics.ConcurrencyGroupScope, metrics.ConcurrencyGroupFailures)
+		container.Logger.WithFields(bark.Fields{
+			logging.TagDomainID:            request.DomainID,
+			logging.TagConcurrencyGroup:    request.Group,
+			logging.TagWorkflowExecutionID: request.WorkflowID,
+			logging.TagWorkflowRunID:       request.RunID,
+			logging.TagErr:                 err,
+		}).Error("failed to schedule first decision of concurrency group run")
+		return false, err
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package concurrency
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/logging"
+	"go.uber.org/cadence/.gen/go/cadence/workflowserviceclient"
+	cclient "go.uber.org/cadence/client"
+)
+
+type (
+	// Request identifies a run of a workflow started in a concurrency group
+	Request struct {
+		DomainID   string
+		Group      string
+		Limit      int32
+		WorkflowID string
+		RunID      string
+	}
+
+	// Client is used by the history service to add the runs started in a concurrency group to the queue
+	// of the group and to remove them from the group once they are closed
+	Client interface {
+		Enqueue(*Request) error
+		Release(*Request) error
+	}
+
+	client struct {
+		logger        bark.Logger
+		cadenceClient cclient.Client
+	}
+)
+
+// NewClient creates a new Client
+func NewClient(logger bark.Logger, publicClient workflowserviceclient.Interface) Client {
+	return &client{
+		logger:        logger,
+		cadenceClient: cclient.NewClient(publicClient, common.SystemDomainName, &cclient.Options{}),
+	}
+}
+
+// Enqueue adds a run to its concurrency group, the first decision of the run is scheduled once the
+// number of running runs of the group is below its limit
+func (c *client) Enqueue(request *Request) error {
+	return c.signal(enqueueSignalName, request)
+}
+
+// Release removes a closed run from its concurrency group, which lets the group dispatch the next queued run
+func (c *client) Release(request *Request) error {
+	return c.signal(releaseSignalName, request)
+}
+
+func (c *client) signal(signalName string, request *Request) error {
+	workflowID := groupWorkflowID(request.DomainID, request.Group)
+	workflowOptions := cclient.StartWorkflowOptions{
+		ID:                              workflowID,
+		TaskList:                        taskListName,
+		ExecutionStartToCloseTimeout:    workflowStartToCloseTimeout,
+		DecisionTaskStartToCloseTimeout: workflowTaskStartToCloseTimeout,
+		WorkflowIDReusePolicy:           cclient.WorkflowIDReusePolicyAllowDuplicate,
+	}
+	_, err := c.cadenceClient.SignalWithStartWorkflow(context.Background(), workflowID, signalName, *request, workflowOptions, groupWorkflowTypeName, nil)
+	if err != nil {
+		c.logger.WithFields(bark.Fields{
+			logging.TagDomainID:            request.DomainID,
+			logging.TagConcurrencyGroup:    request.Group,
+			logging.TagWorkflowExecutionID: request.WorkflowID,
+			logging.TagWorkflowRunID:       request.RunID,
+			logging.TagErr:                 err,
+		}).Error("failed to SignalWithStartWorkflow to concurrency group system workflow")
+	}
+	return err
+}
+
+func groupWorkflowID(domainID string, group string) string {
+	return fmt.Sprintf("%v-%v-%v", workflowIDPrefix, domainID, group)
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package concurrency
+
+import (
+	"context"
+	"time"
+
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/client/history"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/metrics"
+	"go.uber.org/cadence/.gen/go/cadence/workflowserviceclient"
+	"go.uber.org/cadence/activity"
+	"go.uber.org/cadence/worker"
+	"go.uber.org/cadence/workflow"
+)
+
+type (
+	// Worker hosts the concurrency group system workflows
+	Worker interface {
+		Start() error
+		Stop()
+	}
+
+	groupWorker struct {
+		worker worker.Worker
+	}
+
+	// BootstrapContainer contains everything needed by the concurrency group activities
+	BootstrapContainer struct {
+		PublicClient  workflowserviceclient.Interface
+		HistoryClient history.Client
+		MetricsClient metrics.Client
+		Logger        bark.Logger
+	}
+
+	contextKey int
+)
+
+const (
+	workflowIDPrefix                = "cadence-sys-concurrency-group"
+	taskListName                    = "cadence-sys-concurrency-group-tl"
+	enqueueSignalName               = "cadence-sys-concurrency-group-enqueue"
+	releaseSignalName               = "cadence-sys-concurrency-group-release"
+	groupWorkflowTypeName           = "cadence-sys-concurrency-group-workflow"
+	dispatchActivityName            = "cadence-sys-concurrency-group-dispatch-activity"
+	workflowStartToCloseTimeout     = time.Hour * 24 * 30
+	workflowTaskStartToCloseTimeout = time.Minute
+
+	bootstrapContainerKey contextKey = iota
+)
+
+func init() {
+	workflow.RegisterWithOptions(groupWorkflow, workflow.RegisterOptions{Name: groupWorkflowTypeName})
+	activity.RegisterWithOptions(dispatchActivity, activity.RegisterOptions{Name: dispatchActivityName})
+}
+
+// NewWorker returns a new Worker polling the task list of the concurrency group system workflows
+func NewWorker(container *BootstrapContainer) Worker {
+	container.Logger = container.Logger.WithFields(bark.Fields{
+		logging.TagWorkflowComponent: logging.TagValueConcurrencyGroupComponent,
+		logging.TagDomain:            common.SystemDomainName,
+	})
+	actCtx := context.WithValue(context.Background(), bootstrapContainerKey, container)
+	wo := worker.Options{
+		BackgroundActivityContext: actCtx,
+	}
+	return &groupWorker{
+		worker: worker.New(container.PublicClient, common.SystemDomainName, taskListName, wo),
+	}
+}
+
+// Start the Worker
+func (w *groupWorker) Start() error {
+	if err := w.worker.Start(); err != nil {
+		w.worker.Stop()
+		return err
+	}
+	return nil
+}
+
+// Stop the Worker
+func (w *groupWorker) Stop() {
+	w.worker.Stop()
+}
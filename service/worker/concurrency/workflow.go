@@ -0,0 +1,167 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package concurrency
+
+import (
+	"time"
+
+	"go.uber.org/cadence"
+	"go.uber.org/cadence/workflow"
+	"go.uber.org/zap"
+)
+
+type (
+	// groupState is the state of a concurrency group, it is carried over when the group workflow continues as new
+	groupState struct {
+		// Limit is the maximum number of running runs, it is the limit of the last enqueued run
+		Limit int32
+		// Running are the dispatched runs which are not closed yet, keyed by run key
+		Running map[string]Request
+		// Queued are the runs waiting to be dispatched, in the order they were enqueued
+		Queued []Request
+	}
+)
+
+const (
+	// signalsPerRun is the number of signals a group workflow handles before it continues as new
+	signalsPerRun = 1000
+)
+
+var (
+	dispatchActivityOptions = workflow.ActivityOptions{
+		ScheduleToStartTimeout: time.Minute,
+		StartToCloseTimeout:    time.Minute,
+		RetryPolicy: &cadence.RetryPolicy{
+			InitialInterval:    time.Second,
+			BackoffCoefficient: 2,
+			MaximumInterval:    time.Minute,
+			ExpirationInterval: time.Hour,
+		},
+	}
+)
+
+// groupWorkflow keeps track of the running and queued runs of a single concurrency group. Runs are added to the
+// group by the enqueue signal and removed by the release signal, the queued runs are dispatched in FIFO order
+// whenever the number of running runs is below the limit of the group. The workflow completes once the group
+// has neither running nor queued runs, the next enqueue signal starts it again.
+func groupWorkflow(ctx workflow.Context, state *groupState) error {
+	if state == nil {
+		state = &groupState{}
+	}
+	if state.Running == nil {
+		state.Running = make(map[string]Request)
+	}
+	logger := workflow.GetLogger(ctx)
+	ctx = workflow.WithActivityOptions(ctx, dispatchActivityOptions)
+	enqueueCh := workflow.GetSignalChannel(ctx, enqueueSignalName)
+	releaseCh := workflow.GetSignalChannel(ctx, releaseSignalName)
+
+	selector := workflow.NewSelector(ctx)
+	selector.AddReceive(enqueueCh, func(c workflow.Channel, more bool) {
+		var request Request
+		c.Receive(ctx, &request)
+		state.enqueue(request)
+	})
+	selector.AddReceive(releaseCh, func(c workflow.Channel, more bool) {
+		var request Request
+		c.Receive(ctx, &request)
+		state.release(request)
+	})
+
+	for signalCount := 0; signalCount < signalsPerRun; signalCount++ {
+		selector.Select(ctx)
+		signalCount += state.receiveAsync(enqueueCh, releaseCh)
+		if err := state.dispatch(ctx); err != nil {
+			logger.Error("failed to dispatch concurrency group run", zap.Error(err))
+		}
+		if len(state.Running) == 0 && len(state.Queued) == 0 {
+			return nil
+		}
+	}
+
+	state.receiveAsync(enqueueCh, releaseCh)
+	ctx = workflow.WithExecutionStartToCloseTimeout(ctx, workflowStartToCloseTimeout)
+	ctx = workflow.WithWorkflowTaskStartToCloseTimeout(ctx, workflowTaskStartToCloseTimeout)
+	return workflow.NewContinueAsNewError(ctx, groupWorkflowTypeName, state)
+}
+
+// receiveAsync handles the signals which are already delivered, it returns the number of handled signals
+func (s *groupState) receiveAsync(enqueueCh, releaseCh workflow.Channel) int {
+	count := 0
+	for {
+		var request Request
+		switch {
+		case enqueueCh.ReceiveAsync(&request):
+			s.enqueue(request)
+		case releaseCh.ReceiveAsync(&request):
+			s.release(request)
+		default:
+			return count
+		}
+		count++
+	}
+}
+
+func (s *groupState) enqueue(request Request) {
+	s.Limit = request.Limit
+	key := request.key()
+	if _, ok := s.Running[key]; ok {
+		return
+	}
+	for _, queued := range s.Queued {
+		if queued.key() == key {
+			return
+		}
+	}
+	s.Queued = append(s.Queued, request)
+}
+
+func (s *groupState) release(request Request) {
+	key := request.key()
+	delete(s.Running, key)
+	for i, queued := range s.Queued {
+		if queued.key() == key {
+			s.Queued = append(s.Queued[:i], s.Queued[i+1:]...)
+			return
+		}
+	}
+}
+
+// dispatch schedules the first decision of queued runs until the group is full, a run which fails to be
+// dispatched stays at the head of the queue and is retried on the next signal
+func (s *groupState) dispatch(ctx workflow.Context) error {
+	for len(s.Queued) > 0 && int32(len(s.Running)) < s.Limit {
+		request := s.Queued[0]
+		var dispatched bool
+		if err := workflow.ExecuteActivity(ctx, dispatchActivityName, request).Get(ctx, &dispatched); err != nil {
+			return err
+		}
+		s.Queued = s.Queued[1:]
+		if dispatched {
+			s.Running[request.key()] = request
+		}
+	}
+	return nil
+}
+
+func (r Request) key() string {
+	return r.WorkflowID + "/" + r.RunID
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package concurrency
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/cadence/testsuite"
+)
+
+type workflowSuite struct {
+	suite.Suite
+	testsuite.WorkflowTestSuite
+}
+
+func TestWorkflowSuite(t *testing.T) {
+	suite.Run(t, new(workflowSuite))
+}
+
+func (s *workflowSuite) TestGroupWorkflow_DispatchInOrder() {
+	env := s.NewTestWorkflowEnvironment()
+	var dispatched []string
+	env.OnActivity(dispatchActivityName, mock.Anything, mock.Anything).Return(func(ctx context.Context, request Request) (bool, error) {
+		dispatched = append(dispatched, request.RunID)
+		return true, nil
+	})
+
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(enqueueSignalName, newTestRequest("run-1"))
+		env.SignalWorkflow(enqueueSignalName, newTestRequest("run-2"))
+		env.SignalWorkflow(enqueueSignalName, newTestRequest("run-3"))
+	}, time.Minute)
+	env.RegisterDelayedCallback(func() {
+		s.Equal([]string{"run-1"}, dispatched)
+		env.SignalWorkflow(releaseSignalName, newTestRequest("run-1"))
+	}, 2*time.Minute)
+	env.RegisterDelayedCallback(func() {
+		s.Equal([]string{"run-1", "run-2"}, dispatched)
+		env.SignalWorkflow(releaseSignalName, newTestRequest("run-2"))
+	}, 3*time.Minute)
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(releaseSignalName, newTestRequest("run-3"))
+	}, 4*time.Minute)
+
+	env.ExecuteWorkflow(groupWorkflow, nil)
+	s.True(env.IsWorkflowCompleted())
+	s.NoError(env.GetWorkflowError())
+	s.Equal([]string{"run-1", "run-2", "run-3"}, dispatched)
+}
+
+func (s *workflowSuite) TestGroupWorkflow_ClosedRunNotCounted() {
+	env := s.NewTestWorkflowEnvironment()
+	var dispatched []string
+	env.OnActivity(dispatchActivityName, mock.Anything, mock.Anything).Return(func(ctx context.Context, request Request) (bool, error) {
+		dispatched = append(dispatched, request.RunID)
+		// run-1 was closed while it was queued
+		return request.RunID != "run-1", nil
+	})
+
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(enqueueSignalName, newTestRequest("run-1"))
+		env.SignalWorkflow(enqueueSignalName, newTestRequest("run-2"))
+	}, time.Minute)
+	env.RegisterDelayedCallback(func() {
+		s.Equal([]string{"run-1", "run-2"}, dispatched)
+		env.SignalWorkflow(releaseSignalName, newTestRequest("run-2"))
+	}, 2*time.Minute)
+
+	env.ExecuteWorkflow(groupWorkflow, nil)
+	s.True(env.IsWorkflowCompleted())
+	s.NoError(env.GetWorkflowError())
+}
+
+func (s *workflowSuite) TestGroupState() {
+	state := &groupState{Running: make(map[string]Request)}
+	state.enqueue(newTestRequest("run-1"))
+	state.enqueue(newTestRequest("run-2"))
+	state.enqueue(newTestRequest("run-1"))
+	s.Len(state.Queued, 2)
+	s.Equal(int32(1), state.Limit)
+
+	state.release(newTestRequest("run-1"))
+	s.Len(state.Queued, 1)
+	s.Equal("run-2", state.Queued[0].RunID)
+
+	state.Running["wid/run-3"] = newTestRequest("run-3")
+	state.enqueue(newTestRequest("run-3"))
+	s.Len(state.Queued, 1)
+	state.release(newTestRequest("run-3"))
+	s.Empty(state.Running)
+}
+
+func newTestRequest(runID string) Request {
+	return Request{
+		DomainID:   "domain-id",
+		Group:      "group",
+		Limit:      1,
+		WorkflowID: "wid",
+		RunID:      runID,
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package counter
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/client/frontend"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	// Config defines the configuration for the open workflow counter
+	Config struct {
+		// Enabled indicates if the open workflow count of each domain is emitted
+		Enabled dynamicconfig.BoolPropertyFn
+		// Interval is the time between two runs of the counter
+		Interval dynamicconfig.DurationPropertyFn
+	}
+
+	// OpenWorkflowCounter periodically asks frontend for the number of open workflows of every
+	// domain and emits the counts as gauges tagged by domain, so that runaway growth of open
+	// workflows can be alerted on
+	OpenWorkflowCounter struct {
+		status         int32
+		config         *Config
+		frontendClient frontend.Client
+		metricsClient  metrics.Client
+		logger         bark.Logger
+		shutdownCh     chan struct{}
+		shutdownWG     sync.WaitGroup
+	}
+)
+
+const (
+	listDomainsPageSize = 100
+	frontendCallTimeout = 10 * time.Second
+)
+
+// NewOpenWorkflowCounter returns a new instance of the open workflow counter daemon
+func NewOpenWorkflowCounter(config *Config, frontendClient frontend.Client, metricsClient metrics.Client,
+	logger bark.Logger) *OpenWorkflowCounter {
+	return &OpenWorkflowCounter{
+		config:         config,
+		frontendClient: frontendClient,
+		metricsClient:  metricsClient,
+		logger:         logger.WithField(logging.TagWorkflowComponent, logging.TagValueOpenWorkflowCounterComponent),
+		shutdownCh:     make(chan struct{}),
+	}
+}
+
+// Start starts the open workflow counter
+func (c *OpenWorkflowCounter) Start() {
+	if !atomic.CompareAndSwapInt32(&c.status, common.DaemonStatusInitialized, common.DaemonStatusStarted) {
+		return
+	}
+	c.shutdownWG.Add(1)
+	go c.run()
+	c.logger.Info("Open workflow counter started")
+}
+
+// Stop stops the open workflow counter
+func (c *OpenWorkflowCounter) Stop() {
+	if !atomic.CompareAndSwapInt32(&c.status, common.DaemonStatusStarted, common.DaemonStatusStopped) {
+		return
+	}
+	close(c.shutdownCh)
+	c.shutdownWG.Wait()
+	c.logger.Info("Open workflow counter stopped")
+}
+
+func (c *OpenWorkflowCounter) run() {
+	defer c.shutdownWG.Done()
+
+	timer := time.NewTimer(c.config.Interval())
+	defer timer.Stop()
+	for {
+		select {
+		case <-c.shutdownCh:
+			return
+		case <-timer.C:
+			if c.config.Enabled() {
+				c.countAllDomains()
+			}
+			timer.Reset(c.config.Interval())
+		}
+	}
+}
+
+func (c *OpenWorkflowCounter) countAllDomains() {
+	var nextPageToken []byte
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), frontendCallTimeout)
+		resp, err := c.frontendClient.ListDomains(ctx, &shared.ListDomainsRequest{
+			PageSize:      common.Int32Ptr(listDomainsPageSize),
+			NextPageToken: nextPageToken,
+		})
+		cancel()
+		if err != nil {
+			c.metricsClient.IncCounter(metrics.OpenWorkflowCounterScope, metrics.OpenWorkflowCounterFailures)
+			c.logger.WithField(logging.TagErr, err).Error("Failed to list domains")
+			return
+		}
+		for _, domain := range resp.Domains {
+			if domain.DomainInfo.GetStatus() != shared.DomainStatusRegistered {
+				continue
+			}
+			c.countDomain(domain.DomainInfo.GetName())
+		}
+		if len(resp.NextPageToken) == 0 {
+			return
+		}
+		nextPageToken = resp.NextPageToken
+	}
+}
+
+func (c *OpenWorkflowCounter) countDomain(domain string) {
+	ctx, cancel := context.WithTimeout(context.Background(), frontendCallTimeout)
+	defer cancel()
+
+	scope := c.metricsClient.Scope(metrics.OpenWorkflowCounterScope, metrics.DomainTag(domain))
+	resp, err := c.frontendClient.CountOpenWorkflowExecutions(ctx, &shared.CountOpenWorkflowExecutionsRequest{
+		Domain: common.StringPtr(domain),
+	})
+	if err != nil {
+		scope.IncCounter(metrics.OpenWorkflowCounterFailures)
+		c.logger.WithFields(bark.Fields{
+			logging.TagDomain: domain,
+			logging.TagErr:    err,
+		}).Error("Failed to count open workflows")
+		return
+	}
+	scope.UpdateGauge(metrics.OpenWorkflowExecutionsGauge, float64(resp.GetCount()))
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package counter
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	"github.com/uber-go/tally"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/mocks"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type counterSuite struct {
+	suite.Suite
+	frontendClient *mocks.FrontendClient
+	tallyScope     tally.TestScope
+	counter        *OpenWorkflowCounter
+}
+
+func TestCounterSuite(t *testing.T) {
+	suite.Run(t, new(counterSuite))
+}
+
+func (s *counterSuite) SetupTest() {
+	s.frontendClient = &mocks.FrontendClient{}
+	s.tallyScope = tally.NewTestScope("", nil)
+	config := &Config{
+		Enabled:  dynamicconfig.GetBoolPropertyFn(true),
+		Interval: dynamicconfig.GetDurationPropertyFn(time.Minute),
+	}
+	s.counter = NewOpenWorkflowCounter(config, s.frontendClient, metrics.NewClient(s.tallyScope, metrics.Worker),
+		bark.NewNopLogger())
+}
+
+func (s *counterSuite) TearDownTest() {
+	s.frontendClient.AssertExpectations(s.T())
+}
+
+func (s *counterSuite) TestCountAllDomains() {
+	s.frontendClient.On("ListDomains", mock.Anything, mock.Anything).Return(&shared.ListDomainsResponse{
+		Domains: []*shared.DescribeDomainResponse{
+			describeDomainResponse("domain1", shared.DomainStatusRegistered),
+			describeDomainResponse("domain2", shared.DomainStatusDeprecated),
+		},
+		NextPageToken: []byte("token"),
+	}, nil).Once()
+	s.frontendClient.On("ListDomains", mock.Anything, &shared.ListDomainsRequest{
+		PageSize:      common.Int32Ptr(listDomainsPageSize),
+		NextPageToken: []byte("token"),
+	}).Return(&shared.ListDomainsResponse{
+		Domains: []*shared.DescribeDomainResponse{
+			describeDomainResponse("domain3", shared.DomainStatusRegistered),
+		},
+	}, nil).Once()
+	s.frontendClient.On("CountOpenWorkflowExecutions", mock.Anything, &shared.CountOpenWorkflowExecutionsRequest{
+		Domain: common.StringPtr("domain1"),
+	}).Return(&shared.CountOpenWorkflowExecutionsResponse{Count: common.Int64Ptr(7)}, nil).Once()
+	s.frontendClient.On("CountOpenWorkflowExecutions", mock.Anything, &shared.CountOpenWorkflowExecutionsRequest{
+		Domain: common.StringPtr("domain3"),
+	}).Return(nil, errors.New("count failed")).Once()
+
+	s.counter.countAllDomains()
+
+	gauges := make(map[string]float64)
+	for _, gauge := range s.tallyScope.Snapshot().Gauges() {
+		if gauge.Name() == "open_workflow_executions" {
+			gauges[gauge.Tags()["domain"]] = gauge.Value()
+		}
+	}
+	s.Equal(map[string]float64{"domain1": 7}, gauges)
+}
+
+func (s *counterSuite) TestCountAllDomains_ListFailed() {
+	s.frontendClient.On("ListDomains", mock.Anything, mock.Anything).Return(nil, errors.New("list failed")).Once()
+	s.counter.countAllDomains()
+	s.Empty(s.tallyScope.Snapshot().Gauges())
+}
+
+func describeDomainResponse(name string, status shared.DomainStatus) *shared.DescribeDomainResponse {
+	return &shared.DescribeDomainResponse{
+		DomainInfo: &shared.DomainInfo{
+			Name:   common.StringPtr(name),
+			Status: &status,
+		},
+	}
+}
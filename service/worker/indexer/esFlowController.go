@@ -0,0 +1,152 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package indexer
+
+import (
+	"github.com/uber/cadence/common"
+	"sync"
+	"time"
+)
+
+type (
+	// esFlowController adapts the size and frequency of ES bulk commits to the feedback of ES,
+	// bulks shrink and are flushed less often when ES throttles or commits are slow, and grow
+	// back when ES is healthy. When ES keeps throttling, it signals backpressure so that the
+	// indexer stops consuming from kafka instead of buffering requests in memory.
+	esFlowController struct {
+		sync.Mutex
+		config *Config
+
+		bulkActions       int
+		flushInterval     time.Duration
+		pendingActions    int // number of requests added since the last flush
+		throttledBulks    int // number of consecutive throttled bulks
+		lastThrottledTime time.Time
+	}
+)
+
+func newESFlowController(config *Config) *esFlowController {
+	return &esFlowController{
+		config:        config,
+		bulkActions:   config.ESProcessorBulkActions(),
+		flushInterval: config.ESProcessorFlushInterval(),
+	}
+}
+
+// onRequestAdded records a request added to the bulk processor, and returns true when enough requests
+// are pending that the bulk should be flushed
+func (c *esFlowController) onRequestAdded() bool {
+	c.Lock()
+	defer c.Unlock()
+
+	c.pendingActions++
+	if c.pendingActions < c.getBulkActionsLocked() {
+		return false
+	}
+	c.pendingActions = 0
+	return true
+}
+
+// onFlushed resets the pending requests after the bulk processor is flushed
+func (c *esFlowController) onFlushed() {
+	c.Lock()
+	defer c.Unlock()
+
+	c.pendingActions = 0
+}
+
+// onBulkCommitted adapts the bulk size and flush interval to the outcome of a bulk commit
+func (c *esFlowController) onBulkCommitted(latency time.Duration, throttled bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	maxBulkActions := c.config.ESProcessorBulkActions()
+	minBulkActions := common.MinInt(c.config.ESProcessorMinBulkActions(), maxBulkActions)
+	maxFlushInterval := c.config.ESProcessorFlushInterval()
+	minFlushInterval := c.config.ESProcessorMinFlushInterval()
+	if minFlushInterval > maxFlushInterval {
+		minFlushInterval = maxFlushInterval
+	}
+
+	switch {
+	case throttled:
+		// multiplicative decrease, ES is rejecting requests
+		c.throttledBulks++
+		c.lastThrottledTime = time.Now()
+		c.bulkActions = c.bulkActions / 2
+		c.flushInterval = c.flushInterval * 2
+	case latency > c.config.ESProcessorTargetBulkLatency():
+		c.throttledBulks = 0
+		c.bulkActions = c.bulkActions * 3 / 4
+	default:
+		// additive increase, ES is keeping up
+		c.throttledBulks = 0
+		c.bulkActions += maxBulkActions/10 + 1
+		c.flushInterval = c.flushInterval / 2
+	}
+
+	if c.bulkActions < minBulkActions {
+		c.bulkActions = minBulkActions
+	}
+	if c.bulkActions > maxBulkActions {
+		c.bulkActions = maxBulkActions
+	}
+	if c.flushInterval < minFlushInterval {
+		c.flushInterval = minFlushInterval
+	}
+	if c.flushInterval > maxFlushInterval {
+		c.flushInterval = maxFlushInterval
+	}
+}
+
+// isBackpressured returns true when ES throttled the last few bulks, it stops being backpressured
+// once a bulk succeeds, or when no bulk was throttled during a full flush interval
+func (c *esFlowController) isBackpressured() bool {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.throttledBulks < c.config.ESProcessorBackpressureThreshold() {
+		return false
+	}
+	return time.Since(c.lastThrottledTime) < c.config.ESProcessorFlushInterval()
+}
+
+func (c *esFlowController) getBulkActions() int {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.getBulkActionsLocked()
+}
+
+func (c *esFlowController) getBulkActionsLocked() int {
+	// bulk processor commits on its own once the configured max is reached
+	return common.MinInt(c.bulkActions, c.config.ESProcessorBulkActions())
+}
+
+func (c *esFlowController) getFlushInterval() time.Duration {
+	c.Lock()
+	defer c.Unlock()
+
+	if maxFlushInterval := c.config.ESProcessorFlushInterval(); c.flushInterval > maxFlushInterval {
+		return maxFlushInterval
+	}
+	return c.flushInterval
+}
@@ -57,11 +57,14 @@ type (
 
 	// esProcessorImpl implements ESProcessor, it's an agent of elastic.BulkProcessor
 	esProcessorImpl struct {
-		processor     ElasticBulkProcessor
-		mapToKafkaMsg collection.ConcurrentTxMap // used to map ES request to kafka message
-		config        *Config
-		logger        bark.Logger
-		metricsClient metrics.Client
+		processor      ElasticBulkProcessor
+		mapToKafkaMsg  collection.ConcurrentTxMap // used to map ES request to kafka message
+		flowController *esFlowController
+		config         *Config
+		logger         bark.Logger
+		metricsClient  metrics.Client
+		flushCh        chan struct{}
+		shutdownCh     chan struct{}
 	}
 )
 
@@ -69,9 +72,11 @@ var _ ESProcessor = (*esProcessorImpl)(nil)
 var _ ElasticBulkProcessor = (*elastic.BulkProcessor)(nil)
 
 const (
-	// retry configs for es bulk processor
-	esProcessorInitialRetryInterval = 200 * time.Millisecond
-	esProcessorMaxRetryInterval     = 20 * time.Second
+	// ES rejects requests with 429 when its queues are full
+	esStatusTooManyRequests = 429
+
+	// interval to check whether ES recovered when Add is blocked by backpressure
+	esProcessorBackpressureCheckInterval = 100 * time.Millisecond
 )
 
 // NewESProcessorAndStart create new ESProcessor and start
@@ -82,16 +87,21 @@ func NewESProcessorAndStart(config *Config, client es.Client, processorName stri
 		logger: logger.WithFields(bark.Fields{
 			logging.TagWorkflowComponent: logging.TagValueIndexerESProcessorComponent,
 		}),
-		metricsClient: metricsClient,
+		metricsClient:  metricsClient,
+		flowController: newESFlowController(config),
+		flushCh:        make(chan struct{}, 1),
+		shutdownCh:     make(chan struct{}),
 	}
 
+	// the bulk processor commits on its own at the max bulk actions and flush interval,
+	// flushLoop commits earlier when the adaptive bulk actions or flush interval are smaller
 	params := &es.BulkProcessorParameters{
 		Name:          processorName,
 		NumOfWorkers:  config.ESProcessorNumOfWorkers(),
 		BulkActions:   config.ESProcessorBulkActions(),
 		BulkSize:      config.ESProcessorBulkSize(),
 		FlushInterval: config.ESProcessorFlushInterval(),
-		Backoff:       elastic.NewExponentialBackoff(esProcessorInitialRetryInterval, esProcessorMaxRetryInterval),
+		Backoff:       elastic.NewExponentialBackoff(config.ESProcessorRetryInitialInterval(), config.ESProcessorRetryMaxInterval()),
 		AfterFunc:     p.bulkAfterAction,
 	}
 	processor, err := client.RunBulkProcessor(context.Background(), params)
@@ -101,16 +111,24 @@ func NewESProcessorAndStart(config *Config, client es.Client, processorName stri
 
 	p.processor = processor
 	p.mapToKafkaMsg = collection.NewShardedConcurrentTxMap(1024, p.hashFn)
+	go p.flushLoop()
 	return p, nil
 }
 
 func (p *esProcessorImpl) Stop() {
+	if p.shutdownCh != nil {
+		close(p.shutdownCh)
+	}
 	p.processor.Stop()
 	p.mapToKafkaMsg = nil
 }
 
 // Add an ES request, and an map item for kafka message
 func (p *esProcessorImpl) Add(request elastic.BulkableRequest, key string, kafkaMsg messaging.Message) {
+	if !p.waitForBackpressure() {
+		return // processor is shutting down, the kafka message will be redelivered
+	}
+
 	actionWhenFoundDuplicates := func(key interface{}, value interface{}) error {
 		kafkaMsg.Ack()
 		return nil
@@ -120,6 +138,75 @@ func (p *esProcessorImpl) Add(request elastic.BulkableRequest, key string, kafka
 		return
 	}
 	p.processor.Add(request)
+	if p.flowController.onRequestAdded() {
+		select {
+		case p.flushCh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// waitForBackpressure blocks while ES signals sustained backpressure or too many requests are not yet
+// committed, which stops the indexer from consuming kafka, returns false if the processor is stopped
+func (p *esProcessorImpl) waitForBackpressure() bool {
+	if !p.isBackpressured() {
+		return true
+	}
+
+	startTime := time.Now()
+	defer func() {
+		p.metricsClient.RecordTimer(metrics.ESProcessorScope, metrics.ESProcessorBackpressureLatency, time.Since(startTime))
+	}()
+
+	ticker := time.NewTicker(esProcessorBackpressureCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.shutdownCh:
+			return false
+		case <-ticker.C:
+			if !p.isBackpressured() {
+				return true
+			}
+		}
+	}
+}
+
+func (p *esProcessorImpl) isBackpressured() bool {
+	return p.flowController.isBackpressured() ||
+		p.mapToKafkaMsg.Size() >= p.config.ESProcessorMaxOutstandingRequests()
+}
+
+// flushLoop flushes the bulk processor when the adaptive flush interval elapses,
+// or when the adaptive bulk actions are reached
+func (p *esProcessorImpl) flushLoop() {
+	timer := time.NewTimer(p.flowController.getFlushInterval())
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+		case <-p.flushCh:
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+		case <-p.shutdownCh:
+			return
+		}
+
+		p.flowController.onFlushed()
+		if err := p.processor.Flush(); err != nil {
+			p.logger.WithFields(bark.Fields{
+				logging.TagErr: err,
+			}).Warn("Error flush bulk processor.")
+		}
+		p.metricsClient.UpdateGauge(metrics.ESProcessorScope, metrics.ESProcessorBulkActionsGauge,
+			float64(p.flowController.getBulkActions()))
+		timer.Reset(p.flowController.getFlushInterval())
+	}
 }
 
 // bulkAfterAction is triggered after bulk processor commit
@@ -138,9 +225,12 @@ func (p *esProcessorImpl) bulkAfterAction(id int64, requests []elastic.BulkableR
 
 			p.metricsClient.IncCounter(metrics.ESProcessorScope, metrics.ESProcessorFailures)
 		}
+		p.metricsClient.IncCounter(metrics.ESProcessorScope, metrics.ESProcessorThrottledBulks)
+		p.flowController.onBulkCommitted(0, true)
 		return
 	}
 
+	throttled := false
 	responseItems := response.Items
 	for i := 0; i < len(requests); i++ {
 		key := p.getKeyForKafkaMsg(requests[i])
@@ -156,9 +246,15 @@ func (p *esProcessorImpl) bulkAfterAction(id int64, requests []elastic.BulkableR
 				p.nackKafkaMsg(key)
 			default:
 				// do nothing, bulk processor will retry
+				throttled = throttled || resp.Status == esStatusTooManyRequests
 			}
 		}
 	}
+
+	if throttled {
+		p.metricsClient.IncCounter(metrics.ESProcessorScope, metrics.ESProcessorThrottledBulks)
+	}
+	p.flowController.onBulkCommitted(time.Duration(response.Took)*time.Millisecond, throttled)
 }
 
 func (p *esProcessorImpl) ackKafkaMsg(key string) {
@@ -272,7 +368,7 @@ func isResponseSuccess(status int) bool {
 // 507 - Insufficient Storage
 func isResponseRetriable(status int) bool {
 	switch status {
-	case 408, 429, 503, 507:
+	case 408, esStatusTooManyRequests, 503, 507:
 		return true
 	}
 	return false
@@ -74,6 +74,12 @@ func (s *esProcessorSuite) SetupTest() {
 		ESProcessorBulkActions:   dynamicconfig.GetIntPropertyFn(10),
 		ESProcessorBulkSize:      dynamicconfig.GetIntPropertyFn(2 << 20),
 		ESProcessorFlushInterval: dynamicconfig.GetDurationPropertyFn(1 * time.Minute),
+
+		ESProcessorMinBulkActions:         dynamicconfig.GetIntPropertyFn(2),
+		ESProcessorMinFlushInterval:       dynamicconfig.GetDurationPropertyFn(1 * time.Second),
+		ESProcessorTargetBulkLatency:      dynamicconfig.GetDurationPropertyFn(1 * time.Second),
+		ESProcessorMaxOutstandingRequests: dynamicconfig.GetIntPropertyFn(1000),
+		ESProcessorBackpressureThreshold:  dynamicconfig.GetIntPropertyFn(3),
 	}
 	s.mockMetricClient = &mmocks.Client{}
 	s.mockBulkProcessor = &mocks.ElasticBulkProcessor{}
@@ -84,6 +90,9 @@ func (s *esProcessorSuite) SetupTest() {
 	}
 	p.mapToKafkaMsg = collection.NewShardedConcurrentTxMap(1024, p.hashFn)
 	p.processor = s.mockBulkProcessor
+	p.flowController = newESFlowController(config)
+	p.flushCh = make(chan struct{}, 1)
+	p.shutdownCh = make(chan struct{})
 
 	s.esProcessor = p
 
@@ -102,6 +111,9 @@ func (s *esProcessorSuite) TestNewESProcessorAndStart() {
 		ESProcessorBulkActions:   dynamicconfig.GetIntPropertyFn(10),
 		ESProcessorBulkSize:      dynamicconfig.GetIntPropertyFn(2 << 20),
 		ESProcessorFlushInterval: dynamicconfig.GetDurationPropertyFn(1 * time.Minute),
+
+		ESProcessorRetryInitialInterval: dynamicconfig.GetDurationPropertyFn(200 * time.Millisecond),
+		ESProcessorRetryMaxInterval:     dynamicconfig.GetDurationPropertyFn(20 * time.Second),
 	}
 	processorName := "test-processor"
 
@@ -264,7 +276,68 @@ func (s *esProcessorSuite) TestBulkAfterAction_Error() {
 	}
 
 	s.mockMetricClient.On("IncCounter", metrics.ESProcessorScope, metrics.ESProcessorFailures).Once()
+	s.mockMetricClient.On("IncCounter", metrics.ESProcessorScope, metrics.ESProcessorThrottledBulks).Once()
 	s.esProcessor.bulkAfterAction(0, requests, response, errors.New("some error"))
+	s.Equal(5, s.esProcessor.flowController.getBulkActions())
+}
+
+func (s *esProcessorSuite) TestBulkAfterAction_Throttled() {
+	version := int64(3)
+	testKey := "testKey"
+	request := elastic.NewBulkIndexRequest().
+		Index(testIndex).
+		Type(testType).
+		Id(testID).
+		VersionType(versionTypeExternal).
+		Version(version).
+		Doc(map[string]interface{}{es.KafkaKey: testKey})
+	requests := []elastic.BulkableRequest{request}
+
+	mThrottled := map[string]*elastic.BulkResponseItem{
+		"index": {
+			Index:   testIndex,
+			Type:    testType,
+			Id:      testID,
+			Version: version,
+			Status:  429,
+		},
+	}
+	response := &elastic.BulkResponse{
+		Took:   3,
+		Errors: true,
+		Items:  []map[string]*elastic.BulkResponseItem{mThrottled},
+	}
+
+	mockKafkaMsg := &msgMocks.Message{}
+	s.esProcessor.mapToKafkaMsg.Put(testKey, mockKafkaMsg)
+	s.mockMetricClient.On("IncCounter", metrics.ESProcessorScope, metrics.ESProcessorThrottledBulks).Times(3)
+	for i := 0; i < 3; i++ {
+		s.esProcessor.bulkAfterAction(0, requests, response, nil)
+	}
+	mockKafkaMsg.AssertExpectations(s.T())
+	s.Equal(2, s.esProcessor.flowController.getBulkActions())
+	s.True(s.esProcessor.isBackpressured())
+
+	response.Items[0]["index"].Status = 200
+	mockKafkaMsg.On("Ack").Return(nil).Once()
+	s.esProcessor.bulkAfterAction(0, requests, response, nil)
+	mockKafkaMsg.AssertExpectations(s.T())
+	s.False(s.esProcessor.isBackpressured())
+}
+
+func (s *esProcessorSuite) TestAdd_Backpressured() {
+	request := elastic.NewBulkIndexRequest()
+	mockKafkaMsg := &msgMocks.Message{}
+	for i := 0; i < 3; i++ {
+		s.esProcessor.flowController.onBulkCommitted(0, true)
+	}
+
+	// Add is blocked and gives up when processor is stopped
+	s.mockMetricClient.On("RecordTimer", metrics.ESProcessorScope, metrics.ESProcessorBackpressureLatency, mock.Anything).Once()
+	close(s.esProcessor.shutdownCh)
+	s.esProcessor.Add(request, "test-key", mockKafkaMsg)
+	s.Equal(0, s.esProcessor.mapToKafkaMsg.Size())
+	mockKafkaMsg.AssertExpectations(s.T())
 }
 
 func (s *esProcessorSuite) TestAckKafkaMsg() {
@@ -45,11 +45,24 @@ type (
 
 	// Config contains all configs for indexer
 	Config struct {
+		// Enabled pauses indexing when turned off, messages are kept in kafka until it is turned back on
+		Enabled                  dynamicconfig.BoolPropertyFn
 		IndexerConcurrency       dynamicconfig.IntPropertyFn
 		ESProcessorNumOfWorkers  dynamicconfig.IntPropertyFn
 		ESProcessorBulkActions   dynamicconfig.IntPropertyFn // max number of requests in bulk
 		ESProcessorBulkSize      dynamicconfig.IntPropertyFn // max total size of bytes in bulk
 		ESProcessorFlushInterval dynamicconfig.DurationPropertyFn
+
+		// bulks are adapted within [min, max] based on ES throttling and bulk latency
+		ESProcessorMinBulkActions         dynamicconfig.IntPropertyFn
+		ESProcessorMinFlushInterval       dynamicconfig.DurationPropertyFn
+		ESProcessorTargetBulkLatency      dynamicconfig.DurationPropertyFn
+		ESProcessorMaxOutstandingRequests dynamicconfig.IntPropertyFn // max number of requests not yet committed to ES
+		ESProcessorBackpressureThreshold  dynamicconfig.IntPropertyFn // consecutive throttled bulks to stop consuming
+
+		// retry configs for es bulk processor, read when the processor starts
+		ESProcessorRetryInitialInterval dynamicconfig.DurationPropertyFn
+		ESProcessorRetryMaxInterval     dynamicconfig.DurationPropertyFn
 	}
 )
 
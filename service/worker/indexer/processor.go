@@ -27,7 +27,6 @@ import (
 	"github.com/uber/cadence/.gen/go/indexer"
 	"github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
-	"github.com/uber/cadence/common/codec"
 	es "github.com/uber/cadence/common/elasticsearch"
 	"github.com/uber/cadence/common/logging"
 	"github.com/uber/cadence/common/messaging"
@@ -53,10 +52,12 @@ type indexProcessor struct {
 	isStopped       int32
 	shutdownWG      sync.WaitGroup
 	shutdownCh      chan struct{}
-	msgEncoder      codec.BinaryEncoder
 }
 
 const (
+	// interval to check whether indexing is enabled again while paused
+	pausedCheckInterval = 10 * time.Second
+
 	esDocIDDelimiter = "~"
 	esDocType        = "_doc"
 
@@ -82,7 +83,6 @@ func newIndexProcessor(appName, consumerName string, kafkaClient messaging.Clien
 		}),
 		metricsClient: metricsClient,
 		shutdownCh:    make(chan struct{}),
-		msgEncoder:    codec.NewThriftRWEncoder(),
 	}
 }
 
@@ -161,6 +161,15 @@ func (p *indexProcessor) messageProcessLoop(workerWG *sync.WaitGroup, workerID i
 	defer workerWG.Done()
 
 	for {
+		if !p.config.Enabled() {
+			select {
+			case <-p.shutdownCh:
+				return
+			case <-time.After(pausedCheckInterval):
+			}
+			continue
+		}
+
 		select {
 		case msg, ok := <-p.consumer.Messages():
 			if !ok {
@@ -194,12 +203,10 @@ func (p *indexProcessor) process(kafkaMsg messaging.Message) error {
 	return p.addMessageToES(indexMsg, kafkaMsg, logger)
 }
 
+// deserialize accepts both the thrift and the protobuf visibility message, producers switch from one to the other
+// with their VisibilityMessageVersion config
 func (p *indexProcessor) deserialize(payload []byte) (*indexer.Message, error) {
-	var msg indexer.Message
-	if err := p.msgEncoder.Decode(payload, &msg); err != nil {
-		return nil, err
-	}
-	return &msg, nil
+	return messaging.DecodeVisibilityMessage(payload)
 }
 
 func (p *indexProcessor) addMessageToES(indexMsg *indexer.Message, kafkaMsg messaging.Message, logger bark.Logger) error {
@@ -260,6 +267,8 @@ func (p *indexProcessor) dumpFieldsToMap(fields map[string]*indexer.Field) map[s
 			doc[k] = v.GetIntData()
 		case indexer.FieldTypeBool:
 			doc[k] = v.GetBoolData()
+		case indexer.FieldTypeStringList:
+			doc[k] = v.GetStringListData()
 		default:
 			// must be bug in code and bad deployment, check data sent from producer
 			p.logger.Fatalf("Unknown field type")
@@ -273,4 +282,11 @@ func fulfillDoc(doc map[string]interface{}, msg *indexer.Message, keyToKafkaMsg
 	doc[es.WorkflowID] = msg.GetWorkflowID()
 	doc[es.RunID] = msg.GetRunID()
 	doc[es.KafkaKey] = keyToKafkaMsg
+	// messages produced by history hosts which predate the write time execution time
+	// leave it 0 for workflows without backoff, those execute at their start time
+	if executionTime, ok := doc[es.ExecutionTime]; ok && executionTime == int64(0) {
+		if startTime, ok := doc[es.StartTime]; ok {
+			doc[es.ExecutionTime] = startTime
+		}
+	}
 }
@@ -0,0 +1,250 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package migrator
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/client/frontend"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/blobstore"
+	"github.com/uber/cadence/common/blobstore/blob"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+	"github.com/uber/cadence/service/worker/archiver"
+)
+
+type (
+	// Config defines the configuration for the archival migrator
+	Config struct {
+		// Enabled indicates if archived histories of migrating domains are copied
+		Enabled dynamicconfig.BoolPropertyFn
+		// Interval is the time between two runs of the migrator
+		Interval dynamicconfig.DurationPropertyFn
+		// AdminOperationToken is the security token used to complete migrations
+		AdminOperationToken dynamicconfig.StringPropertyFn
+	}
+
+	// ArchivalMigrator periodically looks for domains whose archival bucket is being migrated,
+	// copies all history blobs of such domains from the current bucket to the target bucket
+	// and then completes the migration by making the target bucket the archival bucket of the domain
+	ArchivalMigrator struct {
+		status          int32
+		config          *Config
+		frontendClient  frontend.Client
+		blobstoreClient blobstore.Client
+		metricsClient   metrics.Client
+		logger          bark.Logger
+		shutdownCh      chan struct{}
+		shutdownWG      sync.WaitGroup
+	}
+)
+
+const (
+	listDomainsPageSize  = 100
+	frontendCallTimeout  = 10 * time.Second
+	blobstoreCallTimeout = 30 * time.Second
+)
+
+var errShutdown = errors.New("archival migrator is shutting down")
+
+// NewArchivalMigrator returns a new instance of the archival migrator daemon
+func NewArchivalMigrator(config *Config, frontendClient frontend.Client, blobstoreClient blobstore.Client,
+	metricsClient metrics.Client, logger bark.Logger) *ArchivalMigrator {
+	return &ArchivalMigrator{
+		config:          config,
+		frontendClient:  frontendClient,
+		blobstoreClient: blobstoreClient,
+		metricsClient:   metricsClient,
+		logger:          logger.WithField(logging.TagWorkflowComponent, logging.TagValueArchivalMigratorComponent),
+		shutdownCh:      make(chan struct{}),
+	}
+}
+
+// Start starts the archival migrator
+func (m *ArchivalMigrator) Start() {
+	if !atomic.CompareAndSwapInt32(&m.status, common.DaemonStatusInitialized, common.DaemonStatusStarted) {
+		return
+	}
+	m.shutdownWG.Add(1)
+	go m.run()
+	m.logger.Info("Archival migrator started")
+}
+
+// Stop stops the archival migrator
+func (m *ArchivalMigrator) Stop() {
+	if !atomic.CompareAndSwapInt32(&m.status, common.DaemonStatusStarted, common.DaemonStatusStopped) {
+		return
+	}
+	close(m.shutdownCh)
+	m.shutdownWG.Wait()
+	m.logger.Info("Archival migrator stopped")
+}
+
+func (m *ArchivalMigrator) run() {
+	defer m.shutdownWG.Done()
+
+	timer := time.NewTimer(m.config.Interval())
+	defer timer.Stop()
+	for {
+		select {
+		case <-m.shutdownCh:
+			return
+		case <-timer.C:
+			if m.config.Enabled() {
+				m.migrateAllDomains()
+			}
+			timer.Reset(m.config.Interval())
+		}
+	}
+}
+
+func (m *ArchivalMigrator) migrateAllDomains() {
+	var nextPageToken []byte
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), frontendCallTimeout)
+		resp, err := m.frontendClient.ListDomains(ctx, &shared.ListDomainsRequest{
+			PageSize:      common.Int32Ptr(listDomainsPageSize),
+			NextPageToken: nextPageToken,
+		})
+		cancel()
+		if err != nil {
+			m.metricsClient.IncCounter(metrics.ArchivalMigratorScope, metrics.ArchivalMigratorFailures)
+			m.logger.WithField(logging.TagErr, err).Error("Failed to list domains")
+			return
+		}
+		for _, domain := range resp.Domains {
+			if domain.Configuration.GetArchivalStatus() != shared.ArchivalStatusMigrating {
+				continue
+			}
+			if err := m.migrateDomain(domain); err == errShutdown {
+				return
+			}
+		}
+		if len(resp.NextPageToken) == 0 {
+			return
+		}
+		nextPageToken = resp.NextPageToken
+	}
+}
+
+func (m *ArchivalMigrator) migrateDomain(domain *shared.DescribeDomainResponse) error {
+	domainName := domain.DomainInfo.GetName()
+	domainID := domain.DomainInfo.GetUUID()
+	bucket := domain.Configuration.GetArchivalBucketName()
+	targetBucket := domain.Configuration.GetArchivalTargetBucketName()
+	scope := m.metricsClient.Scope(metrics.ArchivalMigratorScope, metrics.DomainTag(domainName))
+	logger := m.logger.WithFields(bark.Fields{
+		logging.TagDomain:       domainName,
+		logging.TagBucket:       bucket,
+		logging.TagTargetBucket: targetBucket,
+	})
+
+	if err := m.copyBlobs(domainID, bucket, targetBucket, scope, logger); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), frontendCallTimeout)
+	defer cancel()
+	_, err := m.frontendClient.UpdateDomain(ctx, &shared.UpdateDomainRequest{
+		Name: common.StringPtr(domainName),
+		Configuration: &shared.DomainConfiguration{
+			ArchivalStatus:     common.ArchivalStatusPtr(shared.ArchivalStatusEnabled),
+			ArchivalBucketName: common.StringPtr(targetBucket),
+		},
+		SecurityToken: common.StringPtr(m.config.AdminOperationToken()),
+	})
+	if err != nil {
+		scope.IncCounter(metrics.ArchivalMigratorFailures)
+		logger.WithField(logging.TagErr, err).Error("Failed to complete archival bucket migration")
+		return err
+	}
+	scope.IncCounter(metrics.ArchivalMigratorMigrationsCompletedCount)
+	logger.Info("Archival bucket migration completed")
+
+	// histories archived to the old bucket while the migration was being completed are picked up by a final pass
+	return m.copyBlobs(domainID, bucket, targetBucket, scope, logger)
+}
+
+func (m *ArchivalMigrator) copyBlobs(domainID, bucket, targetBucket string, scope metrics.Scope, logger bark.Logger) error {
+	ctx, cancel := context.WithTimeout(context.Background(), blobstoreCallTimeout)
+	keys, err := m.blobstoreClient.ListByPrefix(ctx, bucket, archiver.NewHistoryBlobKeyPrefix(domainID))
+	cancel()
+	if err != nil {
+		scope.IncCounter(metrics.ArchivalMigratorFailures)
+		logger.WithField(logging.TagErr, err).Error("Failed to list archived history blobs")
+		return err
+	}
+	for _, key := range keys {
+		select {
+		case <-m.shutdownCh:
+			return errShutdown
+		default:
+		}
+		copied, err := m.copyBlob(domainID, bucket, targetBucket, key)
+		if err != nil {
+			scope.IncCounter(metrics.ArchivalMigratorFailures)
+			logger.WithFields(bark.Fields{
+				logging.TagBlobKey: key.String(),
+				logging.TagErr:     err,
+			}).Error("Failed to copy archived history blob")
+			return err
+		}
+		if copied {
+			scope.IncCounter(metrics.ArchivalMigratorBlobsCopiedCount)
+		}
+	}
+	return nil
+}
+
+// copyBlob copies a single blob to the target bucket, it returns false if the blob does not belong
+// to the domain or already exists in the target bucket
+func (m *ArchivalMigrator) copyBlob(domainID, bucket, targetBucket string, key blob.Key) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), blobstoreCallTimeout)
+	defer cancel()
+
+	tags, err := m.blobstoreClient.GetTags(ctx, bucket, key)
+	if err != nil {
+		return false, err
+	}
+	if !archiver.IsDomainHistoryBlob(tags, domainID) {
+		return false, nil
+	}
+	exists, err := m.blobstoreClient.Exists(ctx, targetBucket, key)
+	if err != nil || exists {
+		return false, err
+	}
+	b, err := m.blobstoreClient.Download(ctx, bucket, key)
+	if err != nil {
+		return false, err
+	}
+	if err := m.blobstoreClient.Upload(ctx, targetBucket, key, b); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package migrator
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	"github.com/uber-go/tally"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/blobstore/blob"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/mocks"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+	"github.com/uber/cadence/service/worker/archiver"
+)
+
+const (
+	testDomainID     = "test-domain-id"
+	testDomainName   = "test-domain"
+	testBucket       = "test-bucket"
+	testTargetBucket = "test-target-bucket"
+	testToken        = "test-token"
+)
+
+type migratorSuite struct {
+	suite.Suite
+	frontendClient  *mocks.FrontendClient
+	blobstoreClient *mocks.BlobstoreClient
+	tallyScope      tally.TestScope
+	migrator        *ArchivalMigrator
+}
+
+func TestMigratorSuite(t *testing.T) {
+	suite.Run(t, new(migratorSuite))
+}
+
+func (s *migratorSuite) SetupTest() {
+	s.frontendClient = &mocks.FrontendClient{}
+	s.blobstoreClient = &mocks.BlobstoreClient{}
+	s.tallyScope = tally.NewTestScope("", nil)
+	config := &Config{
+		Enabled:             dynamicconfig.GetBoolPropertyFn(true),
+		Interval:            dynamicconfig.GetDurationPropertyFn(time.Minute),
+		AdminOperationToken: dynamicconfig.GetStringPropertyFn(testToken),
+	}
+	s.migrator = NewArchivalMigrator(config, s.frontendClient, s.blobstoreClient,
+		metrics.NewClient(s.tallyScope, metrics.Worker), bark.NewNopLogger())
+}
+
+func (s *migratorSuite) TearDownTest() {
+	s.frontendClient.AssertExpectations(s.T())
+	s.blobstoreClient.AssertExpectations(s.T())
+}
+
+func (s *migratorSuite) TestMigrateAllDomains() {
+	s.frontendClient.On("ListDomains", mock.Anything, mock.Anything).Return(&shared.ListDomainsResponse{
+		Domains: []*shared.DescribeDomainResponse{
+			describeDomainResponse("other-domain-id", "other-domain", shared.ArchivalStatusEnabled, ""),
+			describeDomainResponse(testDomainID, testDomainName, shared.ArchivalStatusMigrating, testTargetBucket),
+		},
+	}, nil).Once()
+
+	copiedKey := s.newKey("1")
+	existingKey := s.newKey("2")
+	otherDomainKey := s.newKey("3")
+	s.blobstoreClient.On("ListByPrefix", mock.Anything, testBucket, archiver.NewHistoryBlobKeyPrefix(testDomainID)).
+		Return([]blob.Key{copiedKey, existingKey, otherDomainKey}, nil).Twice()
+	domainTags := map[string]string{"domain_id": testDomainID}
+	s.blobstoreClient.On("GetTags", mock.Anything, testBucket, copiedKey).Return(domainTags, nil).Twice()
+	s.blobstoreClient.On("GetTags", mock.Anything, testBucket, existingKey).Return(domainTags, nil).Twice()
+	s.blobstoreClient.On("GetTags", mock.Anything, testBucket, otherDomainKey).
+		Return(map[string]string{"domain_id": "other-domain-id"}, nil).Twice()
+	s.blobstoreClient.On("Exists", mock.Anything, testTargetBucket, copiedKey).Return(false, nil).Once()
+	s.blobstoreClient.On("Exists", mock.Anything, testTargetBucket, copiedKey).Return(true, nil).Once()
+	s.blobstoreClient.On("Exists", mock.Anything, testTargetBucket, existingKey).Return(true, nil).Twice()
+	copiedBlob := blob.NewBlob([]byte("body"), domainTags)
+	s.blobstoreClient.On("Download", mock.Anything, testBucket, copiedKey).Return(copiedBlob, nil).Once()
+	s.blobstoreClient.On("Upload", mock.Anything, testTargetBucket, copiedKey, copiedBlob).Return(nil).Once()
+	s.frontendClient.On("UpdateDomain", mock.Anything, &shared.UpdateDomainRequest{
+		Name: common.StringPtr(testDomainName),
+		Configuration: &shared.DomainConfiguration{
+			ArchivalStatus:     common.ArchivalStatusPtr(shared.ArchivalStatusEnabled),
+			ArchivalBucketName: common.StringPtr(testTargetBucket),
+		},
+		SecurityToken: common.StringPtr(testToken),
+	}).Return(&shared.UpdateDomainResponse{}, nil).Once()
+
+	s.migrator.migrateAllDomains()
+
+	counters := s.tallyScope.Snapshot().Counters()
+	s.Equal(int64(1), s.counterValue(counters, "archival_migrator_blobs_copied"))
+	s.Equal(int64(1), s.counterValue(counters, "archival_migrator_migrations_completed"))
+	s.Equal(int64(0), s.counterValue(counters, "archival_migrator_errors"))
+}
+
+func (s *migratorSuite) TestMigrateAllDomains_CopyFailed() {
+	s.frontendClient.On("ListDomains", mock.Anything, mock.Anything).Return(&shared.ListDomainsResponse{
+		Domains: []*shared.DescribeDomainResponse{
+			describeDomainResponse(testDomainID, testDomainName, shared.ArchivalStatusMigrating, testTargetBucket),
+		},
+	}, nil).Once()
+
+	key := s.newKey("1")
+	s.blobstoreClient.On("ListByPrefix", mock.Anything, testBucket, mock.Anything).Return([]blob.Key{key}, nil).Once()
+	s.blobstoreClient.On("GetTags", mock.Anything, testBucket, key).Return(map[string]string{"domain_id": testDomainID}, nil).Once()
+	s.blobstoreClient.On("Exists", mock.Anything, testTargetBucket, key).Return(false, nil).Once()
+	s.blobstoreClient.On("Download", mock.Anything, testBucket, key).Return(nil, errors.New("download failed")).Once()
+
+	s.migrator.migrateAllDomains()
+
+	counters := s.tallyScope.Snapshot().Counters()
+	s.Equal(int64(1), s.counterValue(counters, "archival_migrator_errors"))
+	s.Equal(int64(0), s.counterValue(counters, "archival_migrator_migrations_completed"))
+}
+
+func (s *migratorSuite) newKey(piece string) blob.Key {
+	key, err := blob.NewKey("history", archiver.NewHistoryBlobKeyPrefix(testDomainID)+piece)
+	s.NoError(err)
+	return key
+}
+
+func (s *migratorSuite) counterValue(counters map[string]tally.CounterSnapshot, name string) int64 {
+	var value int64
+	for _, counter := range counters {
+		if counter.Name() == name {
+			value += counter.Value()
+		}
+	}
+	return value
+}
+
+func describeDomainResponse(id string, name string, status shared.ArchivalStatus, targetBucket string) *shared.DescribeDomainResponse {
+	return &shared.DescribeDomainResponse{
+		DomainInfo: &shared.DomainInfo{
+			UUID: common.StringPtr(id),
+			Name: common.StringPtr(name),
+		},
+		Configuration: &shared.DomainConfiguration{
+			ArchivalStatus:           &status,
+			ArchivalBucketName:       common.StringPtr(testBucket),
+			ArchivalTargetBucketName: common.StringPtr(targetBucket),
+		},
+	}
+}
@@ -0,0 +1,272 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package migrator
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/.gen/go/admin"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/client"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cluster"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	// WorkflowMigratorConfig defines the configuration for the workflow migrator
+	WorkflowMigratorConfig struct {
+		// Enabled indicates if executions of migrated domains are copied from their source cluster
+		Enabled dynamicconfig.BoolPropertyFn
+		// Interval is the time between two runs of the migrator
+		Interval dynamicconfig.DurationPropertyFn
+		// SourceCluster is the remote cluster from which executions of a domain are copied, empty means no migration
+		SourceCluster dynamicconfig.StringPropertyFnWithDomainFilter
+	}
+
+	// WorkflowMigrator periodically copies all executions of domains which have a source cluster configured
+	// from the source cluster into the current cluster. Each run lists the open and closed executions of the
+	// domain in the source cluster and calls the MigrateWorkflowExecution admin API of the current cluster for
+	// each of them, which only imports the history missing in the current cluster and verifies the result.
+	// Executions which are still running in the source cluster are caught up by subsequent runs.
+	WorkflowMigrator struct {
+		status          int32
+		config          *WorkflowMigratorConfig
+		clusterMetadata cluster.Metadata
+		clientBean      client.Bean
+		metricsClient   metrics.Client
+		logger          bark.Logger
+		shutdownCh      chan struct{}
+		shutdownWG      sync.WaitGroup
+	}
+
+	// migrationProgress counts the executions processed during one run for a domain
+	migrationProgress struct {
+		migrated int
+		verified int
+		failed   int
+	}
+
+	listExecutionsFn func(nextPageToken []byte) ([]*shared.WorkflowExecutionInfo, []byte, error)
+)
+
+const (
+	listExecutionsPageSize = 100
+	adminCallTimeout       = 30 * time.Second
+)
+
+// NewWorkflowMigrator returns a new instance of the workflow migrator daemon
+func NewWorkflowMigrator(config *WorkflowMigratorConfig, clusterMetadata cluster.Metadata, clientBean client.Bean,
+	metricsClient metrics.Client, logger bark.Logger) *WorkflowMigrator {
+	return &WorkflowMigrator{
+		config:          config,
+		clusterMetadata: clusterMetadata,
+		clientBean:      clientBean,
+		metricsClient:   metricsClient,
+		logger:          logger.WithField(logging.TagWorkflowComponent, logging.TagValueWorkflowMigratorComponent),
+		shutdownCh:      make(chan struct{}),
+	}
+}
+
+// Start starts the workflow migrator
+func (m *WorkflowMigrator) Start() {
+	if !atomic.CompareAndSwapInt32(&m.status, common.DaemonStatusInitialized, common.DaemonStatusStarted) {
+		return
+	}
+	m.shutdownWG.Add(1)
+	go m.run()
+	m.logger.Info("Workflow migrator started")
+}
+
+// Stop stops the workflow migrator
+func (m *WorkflowMigrator) Stop() {
+	if !atomic.CompareAndSwapInt32(&m.status, common.DaemonStatusStarted, common.DaemonStatusStopped) {
+		return
+	}
+	close(m.shutdownCh)
+	m.shutdownWG.Wait()
+	m.logger.Info("Workflow migrator stopped")
+}
+
+func (m *WorkflowMigrator) run() {
+	defer m.shutdownWG.Done()
+
+	timer := time.NewTimer(m.config.Interval())
+	defer timer.Stop()
+	for {
+		select {
+		case <-m.shutdownCh:
+			return
+		case <-timer.C:
+			if m.config.Enabled() {
+				m.migrateAllDomains()
+			}
+			timer.Reset(m.config.Interval())
+		}
+	}
+}
+
+func (m *WorkflowMigrator) migrateAllDomains() {
+	frontendClient := m.clientBean.GetFrontendClient()
+	var nextPageToken []byte
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), frontendCallTimeout)
+		resp, err := frontendClient.ListDomains(ctx, &shared.ListDomainsRequest{
+			PageSize:      common.Int32Ptr(listDomainsPageSize),
+			NextPageToken: nextPageToken,
+		})
+		cancel()
+		if err != nil {
+			m.metricsClient.IncCounter(metrics.WorkflowMigratorScope, metrics.WorkflowMigratorFailures)
+			m.logger.WithField(logging.TagErr, err).Error("Failed to list domains")
+			return
+		}
+		for _, domain := range resp.Domains {
+			domainName := domain.DomainInfo.GetName()
+			sourceCluster := m.config.SourceCluster(domainName)
+			if sourceCluster == "" {
+				continue
+			}
+			if err := m.migrateDomain(domainName, sourceCluster); err == errShutdown {
+				return
+			}
+		}
+		if len(resp.NextPageToken) == 0 {
+			return
+		}
+		nextPageToken = resp.NextPageToken
+	}
+}
+
+func (m *WorkflowMigrator) migrateDomain(domainName, sourceCluster string) error {
+	scope := m.metricsClient.Scope(metrics.WorkflowMigratorScope, metrics.DomainTag(domainName))
+	logger := m.logger.WithFields(bark.Fields{
+		logging.TagDomain:        domainName,
+		logging.TagSourceCluster: sourceCluster,
+	})
+	if _, ok := m.clusterMetadata.GetAllClientAddress()[sourceCluster]; !ok ||
+		sourceCluster == m.clusterMetadata.GetCurrentClusterName() {
+		scope.IncCounter(metrics.WorkflowMigratorFailures)
+		logger.Error("Source cluster of workflow migration is not a remote cluster")
+		return nil
+	}
+
+	sourceClient := m.clientBean.GetRemoteFrontendClient(sourceCluster)
+	startTimeFilter := &shared.StartTimeFilter{
+		EarliestTime: common.Int64Ptr(0),
+		LatestTime:   common.Int64Ptr(time.Now().UnixNano()),
+	}
+	listClosed := func(nextPageToken []byte) ([]*shared.WorkflowExecutionInfo, []byte, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), frontendCallTimeout)
+		defer cancel()
+		resp, err := sourceClient.ListClosedWorkflowExecutions(ctx, &shared.ListClosedWorkflowExecutionsRequest{
+			Domain:          common.StringPtr(domainName),
+			MaximumPageSize: common.Int32Ptr(listExecutionsPageSize),
+			NextPageToken:   nextPageToken,
+			StartTimeFilter: startTimeFilter,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.Executions, resp.NextPageToken, nil
+	}
+	listOpen := func(nextPageToken []byte) ([]*shared.WorkflowExecutionInfo, []byte, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), frontendCallTimeout)
+		defer cancel()
+		resp, err := sourceClient.ListOpenWorkflowExecutions(ctx, &shared.ListOpenWorkflowExecutionsRequest{
+			Domain:          common.StringPtr(domainName),
+			MaximumPageSize: common.Int32Ptr(listExecutionsPageSize),
+			NextPageToken:   nextPageToken,
+			StartTimeFilter: startTimeFilter,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.Executions, resp.NextPageToken, nil
+	}
+
+	progress := &migrationProgress{}
+	for _, list := range []listExecutionsFn{listClosed, listOpen} {
+		if err := m.migrateExecutions(domainName, sourceCluster, list, progress, scope, logger); err != nil {
+			return err
+		}
+	}
+	logger.WithFields(bark.Fields{
+		logging.TagMigratedCount: progress.migrated,
+		logging.TagVerifiedCount: progress.verified,
+		logging.TagFailedCount:   progress.failed,
+	}).Info("Workflow migration run completed")
+	return nil
+}
+
+func (m *WorkflowMigrator) migrateExecutions(domainName, sourceCluster string, list listExecutionsFn,
+	progress *migrationProgress, scope metrics.Scope, logger bark.Logger) error {
+	adminClient := m.clientBean.GetRemoteAdminClient(m.clusterMetadata.GetCurrentClusterName())
+	var nextPageToken []byte
+	for {
+		executions, token, err := list(nextPageToken)
+		if err != nil {
+			scope.IncCounter(metrics.WorkflowMigratorFailures)
+			logger.WithField(logging.TagErr, err).Error("Failed to list workflow executions in source cluster")
+			return err
+		}
+		for _, execution := range executions {
+			select {
+			case <-m.shutdownCh:
+				return errShutdown
+			default:
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), adminCallTimeout)
+			resp, err := adminClient.MigrateWorkflowExecution(ctx, &admin.MigrateWorkflowExecutionRequest{
+				Domain:        common.StringPtr(domainName),
+				Execution:     execution.Execution,
+				SourceCluster: common.StringPtr(sourceCluster),
+			})
+			cancel()
+			switch {
+			case err != nil:
+				progress.failed++
+				scope.IncCounter(metrics.WorkflowMigratorFailures)
+				logger.WithFields(bark.Fields{
+					logging.TagWorkflowExecutionID: execution.Execution.GetWorkflowId(),
+					logging.TagWorkflowRunID:       execution.Execution.GetRunId(),
+					logging.TagErr:                 err,
+				}).Error("Failed to migrate workflow execution")
+			case resp.GetImportedBatchCount() > 0:
+				progress.migrated++
+				scope.IncCounter(metrics.WorkflowMigratorExecutionsMigratedCount)
+			default:
+				progress.verified++
+				scope.IncCounter(metrics.WorkflowMigratorExecutionsVerifiedCount)
+			}
+		}
+		if len(token) == 0 {
+			return nil
+		}
+		nextPageToken = token
+	}
+}
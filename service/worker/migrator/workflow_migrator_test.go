@@ -0,0 +1,188 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package migrator
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	"github.com/uber-go/tally"
+	"github.com/uber/cadence/.gen/go/admin"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/client"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cluster"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/mocks"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type workflowMigratorSuite struct {
+	suite.Suite
+	clientBean           *client.MockClientBean
+	frontendClient       *mocks.FrontendClient
+	sourceFrontendClient *mocks.FrontendClient
+	adminClient          *mocks.AdminClient
+	tallyScope           tally.TestScope
+	migrator             *WorkflowMigrator
+}
+
+func TestWorkflowMigratorSuite(t *testing.T) {
+	suite.Run(t, new(workflowMigratorSuite))
+}
+
+func (s *workflowMigratorSuite) SetupTest() {
+	s.frontendClient = &mocks.FrontendClient{}
+	s.sourceFrontendClient = &mocks.FrontendClient{}
+	s.adminClient = &mocks.AdminClient{}
+	s.clientBean = &client.MockClientBean{}
+	s.clientBean.On("GetFrontendClient").Return(s.frontendClient)
+	s.clientBean.On("GetRemoteFrontendClient", cluster.TestAlternativeClusterName).Return(s.sourceFrontendClient)
+	s.clientBean.On("GetRemoteAdminClient", cluster.TestCurrentClusterName).Return(s.adminClient)
+	s.tallyScope = tally.NewTestScope("", nil)
+	config := &WorkflowMigratorConfig{
+		Enabled:  dynamicconfig.GetBoolPropertyFn(true),
+		Interval: dynamicconfig.GetDurationPropertyFn(time.Minute),
+		SourceCluster: func(domain string) string {
+			if domain == testDomainName {
+				return cluster.TestAlternativeClusterName
+			}
+			return ""
+		},
+	}
+	s.migrator = NewWorkflowMigrator(config, cluster.GetTestClusterMetadata(true, true, false), s.clientBean,
+		metrics.NewClient(s.tallyScope, metrics.Worker), bark.NewNopLogger())
+}
+
+func (s *workflowMigratorSuite) TearDownTest() {
+	s.frontendClient.AssertExpectations(s.T())
+	s.sourceFrontendClient.AssertExpectations(s.T())
+	s.adminClient.AssertExpectations(s.T())
+}
+
+func (s *workflowMigratorSuite) TestMigrateAllDomains() {
+	s.frontendClient.On("ListDomains", mock.Anything, mock.Anything).Return(&shared.ListDomainsResponse{
+		Domains: []*shared.DescribeDomainResponse{
+			describeDomainResponse("other-domain-id", "other-domain", shared.ArchivalStatusDisabled, ""),
+			describeDomainResponse(testDomainID, testDomainName, shared.ArchivalStatusDisabled, ""),
+		},
+	}, nil).Once()
+
+	migratedExecution := s.newExecution("migrated")
+	verifiedExecution := s.newExecution("verified")
+	failedExecution := s.newExecution("failed")
+	openExecution := s.newExecution("open")
+	s.sourceFrontendClient.On("ListClosedWorkflowExecutions", mock.Anything, mock.Anything).Return(
+		&shared.ListClosedWorkflowExecutionsResponse{
+			Executions: []*shared.WorkflowExecutionInfo{
+				{Execution: migratedExecution},
+				{Execution: verifiedExecution},
+			},
+			NextPageToken: []byte("next page"),
+		}, nil).Once()
+	s.sourceFrontendClient.On("ListClosedWorkflowExecutions", mock.Anything, mock.Anything).Return(
+		&shared.ListClosedWorkflowExecutionsResponse{
+			Executions: []*shared.WorkflowExecutionInfo{{Execution: failedExecution}},
+		}, nil).Once()
+	s.sourceFrontendClient.On("ListOpenWorkflowExecutions", mock.Anything, mock.Anything).Return(
+		&shared.ListOpenWorkflowExecutionsResponse{
+			Executions: []*shared.WorkflowExecutionInfo{{Execution: openExecution}},
+		}, nil).Once()
+	s.expectMigrate(migratedExecution).Return(s.newMigrateResponse(2), nil).Once()
+	s.expectMigrate(verifiedExecution).Return(s.newMigrateResponse(0), nil).Once()
+	s.expectMigrate(failedExecution).Return(nil, errors.New("migration failed")).Once()
+	s.expectMigrate(openExecution).Return(s.newMigrateResponse(1), nil).Once()
+
+	s.migrator.migrateAllDomains()
+
+	counters := s.tallyScope.Snapshot().Counters()
+	s.Equal(int64(2), s.counterValue(counters, "workflow_migrator_executions_migrated"))
+	s.Equal(int64(1), s.counterValue(counters, "workflow_migrator_executions_verified"))
+	s.Equal(int64(1), s.counterValue(counters, "workflow_migrator_errors"))
+}
+
+func (s *workflowMigratorSuite) TestMigrateAllDomains_ListFailed() {
+	s.frontendClient.On("ListDomains", mock.Anything, mock.Anything).Return(&shared.ListDomainsResponse{
+		Domains: []*shared.DescribeDomainResponse{
+			describeDomainResponse(testDomainID, testDomainName, shared.ArchivalStatusDisabled, ""),
+		},
+	}, nil).Once()
+	s.sourceFrontendClient.On("ListClosedWorkflowExecutions", mock.Anything, mock.Anything).
+		Return(nil, errors.New("list failed")).Once()
+
+	s.migrator.migrateAllDomains()
+
+	counters := s.tallyScope.Snapshot().Counters()
+	s.Equal(int64(1), s.counterValue(counters, "workflow_migrator_errors"))
+	s.Equal(int64(0), s.counterValue(counters, "workflow_migrator_executions_migrated"))
+}
+
+func (s *workflowMigratorSuite) TestMigrateAllDomains_InvalidSourceCluster() {
+	s.migrator.config.SourceCluster = func(domain string) string {
+		return "unknown-cluster"
+	}
+	s.frontendClient.On("ListDomains", mock.Anything, mock.Anything).Return(&shared.ListDomainsResponse{
+		Domains: []*shared.DescribeDomainResponse{
+			describeDomainResponse(testDomainID, testDomainName, shared.ArchivalStatusDisabled, ""),
+		},
+	}, nil).Once()
+
+	s.migrator.migrateAllDomains()
+
+	counters := s.tallyScope.Snapshot().Counters()
+	s.Equal(int64(1), s.counterValue(counters, "workflow_migrator_errors"))
+}
+
+func (s *workflowMigratorSuite) expectMigrate(execution *shared.WorkflowExecution) *mock.Call {
+	return s.adminClient.On("MigrateWorkflowExecution", mock.Anything, &admin.MigrateWorkflowExecutionRequest{
+		Domain:        common.StringPtr(testDomainName),
+		Execution:     execution,
+		SourceCluster: common.StringPtr(cluster.TestAlternativeClusterName),
+	})
+}
+
+func (s *workflowMigratorSuite) newExecution(workflowID string) *shared.WorkflowExecution {
+	return &shared.WorkflowExecution{
+		WorkflowId: common.StringPtr(workflowID),
+		RunId:      common.StringPtr(workflowID + "-run-id"),
+	}
+}
+
+func (s *workflowMigratorSuite) newMigrateResponse(importedBatchCount int32) *admin.MigrateWorkflowExecutionResponse {
+	return &admin.MigrateWorkflowExecutionResponse{
+		ImportedBatchCount: common.Int32Ptr(importedBatchCount),
+		NextEventId:        common.Int64Ptr(10),
+	}
+}
+
+func (s *workflowMigratorSuite) counterValue(counters map[string]tally.CounterSnapshot, name string) int64 {
+	var value int64
+	for _, counter := range counters {
+		if counter.Name() == name {
+			value += counter.Value()
+		}
+	}
+	return value
+}
@@ -21,6 +21,7 @@
 package replicator
 
 import (
+	"context"
 	"errors"
 
 	"github.com/uber-common/bark"
@@ -106,10 +107,11 @@ func (domainReplicator *domainReplicatorImpl) handleDomainCreationReplicationTas
 			Data:        task.Info.Data,
 		},
 		Config: &persistence.DomainConfig{
-			Retention:      task.Config.GetWorkflowExecutionRetentionPeriodInDays(),
-			EmitMetric:     task.Config.GetEmitMetric(),
-			ArchivalBucket: task.Config.GetArchivalBucketName(),
-			ArchivalStatus: task.Config.GetArchivalStatus(),
+			Retention:            task.Config.GetWorkflowExecutionRetentionPeriodInDays(),
+			EmitMetric:           task.Config.GetEmitMetric(),
+			ArchivalBucket:       task.Config.GetArchivalBucketName(),
+			ArchivalStatus:       task.Config.GetArchivalStatus(),
+			ArchivalTargetBucket: task.Config.GetArchivalTargetBucketName(),
 		},
 		ReplicationConfig: &persistence.DomainReplicationConfig{
 			ActiveClusterName: task.ReplicationConfig.GetActiveClusterName(),
@@ -120,7 +122,7 @@ func (domainReplicator *domainReplicatorImpl) handleDomainCreationReplicationTas
 		FailoverVersion: task.GetFailoverVersion(),
 	}
 
-	_, err = domainReplicator.metadataManagerV2.CreateDomain(request)
+	_, err = domainReplicator.metadataManagerV2.CreateDomain(context.TODO(), request)
 	return err
 }
 
@@ -133,7 +135,7 @@ func (domainReplicator *domainReplicatorImpl) handleDomainUpdateReplicationTask(
 	}
 
 	// first we need to get the current notification verion since we need to it for conditional update
-	metadata, err := domainReplicator.metadataManagerV2.GetMetadata()
+	metadata, err := domainReplicator.metadataManagerV2.GetMetadata(context.TODO())
 	if err != nil {
 		return err
 	}
@@ -141,7 +143,7 @@ func (domainReplicator *domainReplicatorImpl) handleDomainUpdateReplicationTask(
 
 	// plus, we need to check whether the config version is <= the config version set in the input
 	// plus, we need to check whether the failover version is <= the failover version set in the input
-	resp, err := domainReplicator.metadataManagerV2.GetDomain(&persistence.GetDomainRequest{
+	resp, err := domainReplicator.metadataManagerV2.GetDomain(context.TODO(), &persistence.GetDomainRequest{
 		Name: task.Info.GetName(),
 	})
 	if err != nil {
@@ -175,10 +177,11 @@ func (domainReplicator *domainReplicatorImpl) handleDomainUpdateReplicationTask(
 			Data:        task.Info.Data,
 		}
 		request.Config = &persistence.DomainConfig{
-			Retention:      task.Config.GetWorkflowExecutionRetentionPeriodInDays(),
-			EmitMetric:     task.Config.GetEmitMetric(),
-			ArchivalBucket: task.Config.GetArchivalBucketName(),
-			ArchivalStatus: task.Config.GetArchivalStatus(),
+			Retention:            task.Config.GetWorkflowExecutionRetentionPeriodInDays(),
+			EmitMetric:           task.Config.GetEmitMetric(),
+			ArchivalBucket:       task.Config.GetArchivalBucketName(),
+			ArchivalStatus:       task.Config.GetArchivalStatus(),
+			ArchivalTargetBucket: task.Config.GetArchivalTargetBucketName(),
 		}
 		request.ReplicationConfig.Clusters = domainReplicator.convertClusterReplicationConfigFromThrift(task.ReplicationConfig.Clusters)
 		request.ConfigVersion = task.GetConfigVersion()
@@ -194,7 +197,7 @@ func (domainReplicator *domainReplicatorImpl) handleDomainUpdateReplicationTask(
 		return nil
 	}
 
-	return domainReplicator.metadataManagerV2.UpdateDomain(request)
+	return domainReplicator.metadataManagerV2.UpdateDomain(context.TODO(), request)
 }
 
 func (domainReplicator *domainReplicatorImpl) validateDomainReplicationTask(task *replicator.DomainTaskAttributes) error {
@@ -21,6 +21,7 @@
 package replicator
 
 import (
+	"context"
 	"log"
 	"os"
 	"testing"
@@ -122,13 +123,13 @@ func (s *domainReplicatorSuite) TestHandleReceivingTask_RegisterDomainTask() {
 		FailoverVersion: common.Int64Ptr(failoverVersion),
 	}
 
-	metadata, err := s.MetadataManagerV2.GetMetadata()
+	metadata, err := s.MetadataManagerV2.GetMetadata(context.Background())
 	s.Nil(err)
 	notificationVersion := metadata.NotificationVersion
 	err = s.domainReplicator.HandleReceivingTask(task)
 	s.Nil(err)
 
-	resp, err := s.MetadataManagerV2.GetDomain(&persistence.GetDomainRequest{ID: id})
+	resp, err := s.MetadataManagerV2.GetDomain(context.Background(), &persistence.GetDomainRequest{ID: id})
 	s.Nil(err)
 	s.NotNil(resp)
 	s.Equal(id, resp.Info.ID)
@@ -198,13 +199,13 @@ func (s *domainReplicatorSuite) TestHandleReceivingTask_UpdateDomainTask_DomainN
 		FailoverVersion: common.Int64Ptr(failoverVersion),
 	}
 
-	metadata, err := s.MetadataManagerV2.GetMetadata()
+	metadata, err := s.MetadataManagerV2.GetMetadata(context.Background())
 	s.Nil(err)
 	notificationVersion := metadata.NotificationVersion
 	err = s.domainReplicator.HandleReceivingTask(updateTask)
 	s.Nil(err)
 
-	resp, err := s.MetadataManagerV2.GetDomain(&persistence.GetDomainRequest{Name: name})
+	resp, err := s.MetadataManagerV2.GetDomain(context.Background(), &persistence.GetDomainRequest{Name: name})
 	s.Nil(err)
 	s.NotNil(resp)
 	s.Equal(id, resp.Info.ID)
@@ -321,12 +322,12 @@ func (s *domainReplicatorSuite) TestHandleReceivingTask_UpdateDomainTask_UpdateC
 		ConfigVersion:   common.Int64Ptr(updateConfigVersion),
 		FailoverVersion: common.Int64Ptr(updateFailoverVersion),
 	}
-	metadata, err := s.MetadataManagerV2.GetMetadata()
+	metadata, err := s.MetadataManagerV2.GetMetadata(context.Background())
 	s.Nil(err)
 	notificationVersion := metadata.NotificationVersion
 	err = s.domainReplicator.HandleReceivingTask(updateTask)
 	s.Nil(err)
-	resp, err := s.MetadataManagerV2.GetDomain(&persistence.GetDomainRequest{Name: name})
+	resp, err := s.MetadataManagerV2.GetDomain(context.Background(), &persistence.GetDomainRequest{Name: name})
 	s.Nil(err)
 	s.NotNil(resp)
 	s.Equal(id, resp.Info.ID)
@@ -444,12 +445,12 @@ func (s *domainReplicatorSuite) TestHandleReceivingTask_UpdateDomainTask_UpdateC
 		ConfigVersion:   common.Int64Ptr(updateConfigVersion),
 		FailoverVersion: common.Int64Ptr(updateFailoverVersion),
 	}
-	metadata, err := s.MetadataManagerV2.GetMetadata()
+	metadata, err := s.MetadataManagerV2.GetMetadata(context.Background())
 	s.Nil(err)
 	notificationVersion := metadata.NotificationVersion
 	err = s.domainReplicator.HandleReceivingTask(updateTask)
 	s.Nil(err)
-	resp, err := s.MetadataManagerV2.GetDomain(&persistence.GetDomainRequest{Name: name})
+	resp, err := s.MetadataManagerV2.GetDomain(context.Background(), &persistence.GetDomainRequest{Name: name})
 	s.Nil(err)
 	s.NotNil(resp)
 	s.Equal(id, resp.Info.ID)
@@ -565,12 +566,12 @@ func (s *domainReplicatorSuite) TestHandleReceivingTask_UpdateDomainTask_NoUpdat
 		ConfigVersion:   common.Int64Ptr(updateConfigVersion),
 		FailoverVersion: common.Int64Ptr(updateFailoverVersion),
 	}
-	metadata, err := s.MetadataManagerV2.GetMetadata()
+	metadata, err := s.MetadataManagerV2.GetMetadata(context.Background())
 	s.Nil(err)
 	notificationVersion := metadata.NotificationVersion
 	err = s.domainReplicator.HandleReceivingTask(updateTask)
 	s.Nil(err)
-	resp, err := s.MetadataManagerV2.GetDomain(&persistence.GetDomainRequest{Name: name})
+	resp, err := s.MetadataManagerV2.GetDomain(context.Background(), &persistence.GetDomainRequest{Name: name})
 	s.Nil(err)
 	s.NotNil(resp)
 	s.Equal(id, resp.Info.ID)
@@ -639,7 +640,7 @@ func (s *domainReplicatorSuite) TestHandleReceivingTask_UpdateDomainTask_NoUpdat
 		ConfigVersion:   common.Int64Ptr(configVersion),
 		FailoverVersion: common.Int64Ptr(failoverVersion),
 	}
-	metadata, err := s.MetadataManagerV2.GetMetadata()
+	metadata, err := s.MetadataManagerV2.GetMetadata(context.Background())
 	s.Nil(err)
 	notificationVersion := metadata.NotificationVersion
 	err = s.domainReplicator.HandleReceivingTask(createTask)
@@ -690,7 +691,7 @@ func (s *domainReplicatorSuite) TestHandleReceivingTask_UpdateDomainTask_NoUpdat
 	}
 	err = s.domainReplicator.HandleReceivingTask(updateTask)
 	s.Nil(err)
-	resp, err := s.MetadataManagerV2.GetDomain(&persistence.GetDomainRequest{Name: name})
+	resp, err := s.MetadataManagerV2.GetDomain(context.Background(), &persistence.GetDomainRequest{Name: name})
 	s.Nil(err)
 	s.NotNil(resp)
 	s.Equal(id, resp.Info.ID)
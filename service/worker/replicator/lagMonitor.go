@@ -0,0 +1,169 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package replicator
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/messaging"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	// consumerLagMonitor samples the lag of the consumers of the replication topics of the remote clusters, reports
+	// it as metrics and passes it to the consumer lag hook of the deployment
+	consumerLagMonitor struct {
+		currentCluster string
+		sourceClusters func() []string
+		client         messaging.Client
+		hook           messaging.ConsumerLagHook
+		interval       dynamicconfig.DurationPropertyFn
+		metricsClient  metrics.Client
+		logger         bark.Logger
+		isStopped      int32
+		shutdownWG     sync.WaitGroup
+		shutdownCh     chan struct{}
+
+		// readers and samples are keyed by source cluster and only used by the report loop
+		readers map[string]messaging.ConsumerLagReader
+		samples map[string]*messaging.ConsumerLag
+	}
+)
+
+func newConsumerLagMonitor(currentCluster string, sourceClusters func() []string, client messaging.Client,
+	hook messaging.ConsumerLagHook, interval dynamicconfig.DurationPropertyFn, metricsClient metrics.Client,
+	logger bark.Logger) *consumerLagMonitor {
+	return &consumerLagMonitor{
+		currentCluster: currentCluster,
+		sourceClusters: sourceClusters,
+		client:         client,
+		hook:           hook,
+		interval:       interval,
+		metricsClient:  metricsClient,
+		logger:         logger,
+		shutdownCh:     make(chan struct{}),
+		readers:        make(map[string]messaging.ConsumerLagReader),
+		samples:        make(map[string]*messaging.ConsumerLag),
+	}
+}
+
+func (m *consumerLagMonitor) Start() {
+	m.shutdownWG.Add(1)
+	go m.reportLoop()
+}
+
+func (m *consumerLagMonitor) Stop() {
+	if !atomic.CompareAndSwapInt32(&m.isStopped, 0, 1) {
+		return
+	}
+	close(m.shutdownCh)
+	if success := common.AwaitWaitGroup(&m.shutdownWG, time.Minute); !success {
+		m.logger.Warn("Replicator consumer lag monitor timed out on shutdown.")
+	}
+}
+
+func (m *consumerLagMonitor) reportLoop() {
+	defer m.shutdownWG.Done()
+	defer m.closeReaders()
+
+	timer := time.NewTimer(m.interval())
+	defer timer.Stop()
+	for {
+		select {
+		case <-m.shutdownCh:
+			return
+		case <-timer.C:
+			m.report()
+			timer.Reset(m.interval())
+		}
+	}
+}
+
+// report samples the lag of the consumer of each remote cluster, the hook is called with the consumers whose lag
+// could be read
+func (m *consumerLagMonitor) report() {
+	var stats []*messaging.ConsumerLagStats
+	for _, cluster := range m.sourceClusters() {
+		lag, err := m.getLag(cluster)
+		if err != nil {
+			m.metricsClient.IncCounter(metrics.ReplicatorConsumerLagScope, metrics.ReplicatorConsumerLagFailures)
+			m.logger.WithFields(bark.Fields{
+				logging.TagSourceCluster: cluster,
+				logging.TagErr:           err,
+			}).Warn("Failed to read replicator consumer lag")
+			continue
+		}
+
+		clusterStats := messaging.NewConsumerLagStats(cluster, m.samples[cluster], lag)
+		m.samples[cluster] = lag
+		m.emitMetrics(clusterStats)
+		stats = append(stats, clusterStats)
+	}
+
+	if m.hook != nil && len(stats) > 0 {
+		m.hook.OnConsumerLag(stats)
+	}
+}
+
+func (m *consumerLagMonitor) getLag(cluster string) (*messaging.ConsumerLag, error) {
+	reader, ok := m.readers[cluster]
+	if !ok {
+		var err error
+		reader, err = m.client.NewConsumerLagReader(cluster)
+		if err != nil {
+			return nil, err
+		}
+		m.readers[cluster] = reader
+	}
+
+	lag, err := reader.GetLag(messaging.GetReplicationConsumerName(m.currentCluster, cluster))
+	if err != nil {
+		// connect again on the next report, the brokers of the cluster may have changed
+		reader.Close()
+		delete(m.readers, cluster)
+		return nil, err
+	}
+	return lag, nil
+}
+
+func (m *consumerLagMonitor) emitMetrics(stats *messaging.ConsumerLagStats) {
+	scope := m.metricsClient.Scope(metrics.ReplicatorConsumerLagScope, metrics.TopicTag(stats.Topic))
+	scope.UpdateGauge(metrics.ReplicatorConsumerLag, float64(stats.Lag))
+	scope.UpdateGauge(metrics.ReplicatorProcessingRate, stats.ProcessingRate)
+	scope.UpdateGauge(metrics.ReplicatorProduceRate, stats.ProduceRate)
+	for _, partition := range stats.Partitions {
+		scope.Tagged(metrics.PartitionTag(partition.Partition)).
+			UpdateGauge(metrics.ReplicatorConsumerPartitionLag, float64(partition.Lag))
+	}
+}
+
+func (m *consumerLagMonitor) closeReaders() {
+	for cluster, reader := range m.readers {
+		reader.Close()
+		delete(m.readers, cluster)
+	}
+}
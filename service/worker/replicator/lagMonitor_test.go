@@ -0,0 +1,163 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package replicator
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	"github.com/uber-go/tally"
+	"github.com/uber/cadence/common/messaging"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	consumerLagMonitorSuite struct {
+		suite.Suite
+		client  *testLagMessagingClient
+		hook    *testConsumerLagHook
+		monitor *consumerLagMonitor
+	}
+
+	// testLagMessagingClient hands out lag readers which return the queued samples of their cluster
+	testLagMessagingClient struct {
+		messaging.Client
+		samples       map[string][]*messaging.ConsumerLag
+		readersOpened int
+		readersClosed int
+	}
+
+	testLagReader struct {
+		client  *testLagMessagingClient
+		cluster string
+	}
+
+	testConsumerLagHook struct {
+		calls [][]*messaging.ConsumerLagStats
+	}
+)
+
+var errTestLag = errors.New("no sample")
+
+func TestConsumerLagMonitorSuite(t *testing.T) {
+	s := new(consumerLagMonitorSuite)
+	suite.Run(t, s)
+}
+
+func (s *consumerLagMonitorSuite) SetupTest() {
+	s.client = &testLagMessagingClient{samples: make(map[string][]*messaging.ConsumerLag)}
+	s.hook = &testConsumerLagHook{}
+	s.monitor = newConsumerLagMonitor(
+		"active",
+		func() []string { return []string{"standby"} },
+		s.client,
+		s.hook,
+		dynamicconfig.GetDurationPropertyFn(time.Minute),
+		metrics.NewClient(tally.NoopScope, metrics.Worker),
+		bark.NewLoggerFromLogrus(log.New()),
+	)
+}
+
+func (s *consumerLagMonitorSuite) TestReport() {
+	now := time.Now()
+	s.client.samples["standby"] = []*messaging.ConsumerLag{
+		newTestConsumerLag(now, 100, 150),
+		newTestConsumerLag(now.Add(10*time.Second), 200, 200),
+	}
+
+	s.monitor.report()
+	s.Len(s.hook.calls, 1)
+	stats := s.hook.calls[0][0]
+	s.Equal("standby", stats.SourceCluster)
+	s.Equal("active_consumer_for_standby", stats.ConsumerName)
+	s.Equal(int64(50), stats.Lag)
+	s.Zero(stats.ProcessingRate)
+
+	s.monitor.report()
+	s.Len(s.hook.calls, 2)
+	stats = s.hook.calls[1][0]
+	s.Equal(int64(0), stats.Lag)
+	s.Equal(10.0, stats.ProcessingRate)
+	s.Equal(5.0, stats.ProduceRate)
+	s.Equal(1, s.client.readersOpened)
+}
+
+func (s *consumerLagMonitorSuite) TestReport_ReaderFailure() {
+	s.monitor.report()
+	s.Empty(s.hook.calls)
+	s.Equal(1, s.client.readersClosed)
+
+	s.client.samples["standby"] = []*messaging.ConsumerLag{newTestConsumerLag(time.Now(), 100, 150)}
+	s.monitor.report()
+	s.Len(s.hook.calls, 1)
+	s.Equal(2, s.client.readersOpened)
+}
+
+func (s *consumerLagMonitorSuite) TestStop() {
+	s.monitor.Start()
+	s.monitor.report()
+	s.monitor.Stop()
+	s.monitor.Stop()
+	s.Equal(s.client.readersOpened, s.client.readersClosed)
+}
+
+func newTestConsumerLag(sampledAt time.Time, committed int64, highWatermark int64) *messaging.ConsumerLag {
+	return &messaging.ConsumerLag{
+		Topic:        "standby-topic",
+		ConsumerName: "active_consumer_for_standby",
+		Partitions: []messaging.PartitionLag{{
+			Partition:       0,
+			CommittedOffset: committed,
+			HighWatermark:   highWatermark,
+			Lag:             highWatermark - committed,
+		}},
+		Lag:       highWatermark - committed,
+		SampledAt: sampledAt,
+	}
+}
+
+func (c *testLagMessagingClient) NewConsumerLagReader(sourceCluster string) (messaging.ConsumerLagReader, error) {
+	c.readersOpened++
+	return &testLagReader{client: c, cluster: sourceCluster}, nil
+}
+
+func (r *testLagReader) GetLag(consumerName string) (*messaging.ConsumerLag, error) {
+	samples := r.client.samples[r.cluster]
+	if len(samples) == 0 {
+		return nil, errTestLag
+	}
+	r.client.samples[r.cluster] = samples[1:]
+	return samples[0], nil
+}
+
+func (r *testLagReader) Close() error {
+	r.client.readersClosed++
+	return nil
+}
+
+func (h *testConsumerLagHook) OnConsumerLag(stats []*messaging.ConsumerLagStats) {
+	h.calls = append(h.calls, stats)
+}
@@ -62,10 +62,19 @@ type (
 		msgEncoder              codec.BinaryEncoder
 		sequentialTaskProcessor task.SequentialTaskProcessor
 	}
+
+	replicationTaskMessage struct {
+		msg    messaging.Message
+		task   *replicator.ReplicationTask
+		logger bark.Logger
+	}
 )
 
 const (
 	dropSyncShardTaskTimeThreshold = 10 * time.Minute
+	workerChannelBufferSize        = 16
+	// interval to check whether processing is enabled again while paused
+	pausedCheckInterval = 10 * time.Second
 )
 
 var (
@@ -149,39 +158,87 @@ func (p *replicationTaskProcessor) Stop() {
 func (p *replicationTaskProcessor) processorPump() {
 	defer p.shutdownWG.Done()
 
+	// replication tasks are partitioned by the shard which generated them, each shard is pinned to a single worker
+	// so tasks of a shard are applied in the order they are published
 	var workerWG sync.WaitGroup
-	for workerID := 0; workerID < p.config.ReplicatorMetaTaskConcurrency(); workerID++ {
+	workerChs := make([]chan *replicationTaskMessage, p.config.ReplicatorMetaTaskConcurrency())
+	for workerID := range workerChs {
+		workerChs[workerID] = make(chan *replicationTaskMessage, workerChannelBufferSize)
 		workerWG.Add(1)
-		go p.messageProcessLoop(&workerWG, workerID)
+		go p.messageProcessLoop(&workerWG, workerID, workerChs[workerID])
 	}
 
-	select {
-	case <-p.shutdownCh:
-		// Processor is shutting down, close the underlying consumer
-		p.consumer.Stop()
-	}
+	p.dispatchLoop(workerChs)
 
+	for _, workerCh := range workerChs {
+		close(workerCh)
+	}
 	p.logger.Info("Replication task processor pump shutting down.")
 	if success := common.AwaitWaitGroup(&workerWG, 10*time.Second); !success {
 		p.logger.Warn("Replication task processor timed out on worker shutdown.")
 	}
 }
 
-func (p *replicationTaskProcessor) messageProcessLoop(workerWG *sync.WaitGroup, workerID int) {
-	defer workerWG.Done()
-
+func (p *replicationTaskProcessor) dispatchLoop(workerChs []chan *replicationTaskMessage) {
 	for {
+		if !p.config.Enabled() {
+			select {
+			case <-p.shutdownCh:
+				p.consumer.Stop()
+				return
+			case <-time.After(pausedCheckInterval):
+			}
+			continue
+		}
+
 		select {
+		case <-p.shutdownCh:
+			// Processor is shutting down, close the underlying consumer
+			p.consumer.Stop()
+			return
 		case msg, ok := <-p.consumer.Messages():
 			if !ok {
-				p.logger.Info("Worker for replication task processor shutting down.")
+				p.logger.Info("Dispatcher for replication task processor shutting down.")
 				return // channel closed
 			}
-			p.decodeMsgAndSubmit(msg)
+
+			logger := p.initLogger(msg)
+			replicationTask, err := p.decodeAndValidateMsg(msg, logger)
+			if err != nil {
+				p.nackMsg(msg, err, logger)
+				continue
+			}
+
+			workerCh := workerChs[getWorkerIndex(replicationTask, msg, len(workerChs))]
+			select {
+			case workerCh <- &replicationTaskMessage{msg: msg, task: replicationTask, logger: logger}:
+			case <-p.shutdownCh:
+				p.consumer.Stop()
+				return
+			}
 		}
 	}
 }
 
+func (p *replicationTaskProcessor) messageProcessLoop(workerWG *sync.WaitGroup, workerID int,
+	workerCh <-chan *replicationTaskMessage) {
+	defer workerWG.Done()
+
+	for taskMsg := range workerCh {
+		p.submit(taskMsg.task, taskMsg.msg, taskMsg.logger)
+	}
+	p.logger.Info("Worker for replication task processor shutting down.")
+}
+
+// getWorkerIndex pins all tasks of a shard to the same worker, tasks published without a shard
+// (domain tasks or tasks from older hosts) are pinned by partition which is also ordered
+func getWorkerIndex(task *replicator.ReplicationTask, msg messaging.Message, numWorkers int) int {
+	if task.IsSetShardId() {
+		return int(uint32(task.GetShardId()) % uint32(numWorkers))
+	}
+	return int(uint32(msg.Partition()) % uint32(numWorkers))
+}
+
 func (p *replicationTaskProcessor) decodeMsgAndSubmit(msg messaging.Message) {
 	logger := p.initLogger(msg)
 	replicationTask, err := p.decodeAndValidateMsg(msg, logger)
@@ -190,6 +247,12 @@ func (p *replicationTaskProcessor) decodeMsgAndSubmit(msg messaging.Message) {
 		return
 	}
 
+	p.submit(replicationTask, msg, logger)
+}
+
+func (p *replicationTaskProcessor) submit(replicationTask *replicator.ReplicationTask, msg messaging.Message,
+	logger bark.Logger) {
+	var err error
 SubmitLoop:
 	for {
 		var scope int
@@ -209,6 +272,9 @@ SubmitLoop:
 		case replicator.ReplicationTaskTypeHistory:
 			scope = metrics.HistoryReplicationTaskScope
 			err = p.handleHistoryReplicationTask(replicationTask, msg, logger)
+		case replicator.ReplicationTaskTypeHistoryV2:
+			scope = metrics.HistoryReplicationV2TaskScope
+			err = p.handleHistoryReplicationV2Task(replicationTask, msg, logger)
 		default:
 			logger.Error("Unknown task type.")
 			scope = metrics.ReplicatorScope
@@ -333,6 +399,12 @@ func (p *replicationTaskProcessor) handleHistoryReplicationTask(task *replicator
 	return p.sequentialTaskProcessor.Submit(historyReplicationTask)
 }
 
+func (p *replicationTaskProcessor) handleHistoryReplicationV2Task(task *replicator.ReplicationTask, msg messaging.Message, logger bark.Logger) error {
+	historyReplicationV2Task := newHistoryReplicationV2Task(task, msg, logger,
+		p.config, p.historyClient, p.metricsClient, p.historyRereplicator)
+	return p.sequentialTaskProcessor.Submit(historyReplicationV2Task)
+}
+
 func (p *replicationTaskProcessor) updateFailureMetric(scope int, err error) {
 	// Always update failure counter for all replicator errors
 	p.metricsClient.IncCounter(scope, metrics.ReplicatorFailures)
@@ -28,6 +28,7 @@ import (
 
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"github.com/uber-common/bark"
 	"github.com/uber-go/tally"
@@ -352,3 +353,21 @@ func (s *replicationTaskProcessorSuite) TestDecodeMsgAndSubmit_History_FailedThe
 
 	s.processor.decodeMsgAndSubmit(s.mockMsg)
 }
+
+func TestGetWorkerIndex(t *testing.T) {
+	numWorkers := 8
+	shardTask := &replicator.ReplicationTask{
+		TaskType: replicator.ReplicationTaskTypeHistory.Ptr(),
+		ShardId:  common.Int32Ptr(13),
+	}
+	require.Equal(t, 5, getWorkerIndex(shardTask, &messageMocks.Message{}, numWorkers))
+
+	// tasks without shard are pinned by partition
+	domainTask := &replicator.ReplicationTask{
+		TaskType: replicator.ReplicationTaskTypeDomain.Ptr(),
+	}
+	msg := &messageMocks.Message{}
+	msg.On("Partition").Return(int32(10))
+	require.Equal(t, 2, getWorkerIndex(domainTask, msg, numWorkers))
+	msg.AssertExpectations(t)
+}
@@ -65,6 +65,11 @@ type (
 		workflowReplicationTask
 		req *h.ReplicateEventsRequest
 	}
+
+	historyReplicationV2Task struct {
+		workflowReplicationTask
+		req *h.ReplicateEventsV2Request
+	}
 )
 
 const (
@@ -163,6 +168,50 @@ func newHistoryReplicationTask(task *replicator.ReplicationTask, msg messaging.M
 	}
 }
 
+func newHistoryReplicationV2Task(task *replicator.ReplicationTask, msg messaging.Message, logger bark.Logger,
+	config *Config, historyClient history.Client, metricsClient metrics.Client,
+	historyRereplicator xdc.HistoryRereplicator) *historyReplicationV2Task {
+
+	attr := task.HistoryTaskV2Attributes
+	// the last item of the version history is the last event of the task
+	lastEventID := common.EmptyEventID
+	if items := attr.GetVersionHistoryItems(); len(items) > 0 {
+		lastEventID = items[len(items)-1].GetEventID()
+	}
+	return &historyReplicationV2Task{
+		workflowReplicationTask: workflowReplicationTask{
+			metricsScope: metrics.HistoryReplicationV2TaskScope,
+			startTime:    time.Now(),
+			partitionID: definition.NewWorkflowIdentifier(
+				attr.GetDomainId(), attr.GetWorkflowId(), attr.GetRunId(),
+			),
+			taskID:   lastEventID,
+			attempt:  0,
+			kafkaMsg: msg,
+			logger: logger.WithFields(bark.Fields{
+				logging.TagDomainID:            attr.GetDomainId(),
+				logging.TagWorkflowExecutionID: attr.GetWorkflowId(),
+				logging.TagWorkflowRunID:       attr.GetRunId(),
+				logging.TagEventID:             lastEventID,
+			}),
+			config:              config,
+			historyClient:       historyClient,
+			metricsClient:       metricsClient,
+			historyRereplicator: historyRereplicator,
+		},
+		req: &h.ReplicateEventsV2Request{
+			DomainUUID: attr.DomainId,
+			WorkflowExecution: &shared.WorkflowExecution{
+				WorkflowId: attr.WorkflowId,
+				RunId:      attr.RunId,
+			},
+			VersionHistoryItems: attr.VersionHistoryItems,
+			Events:              attr.Events,
+			NewRunEvents:        attr.NewRunEvents,
+		},
+	}
+}
+
 func (t *activityReplicationTask) Execute() error {
 	ctx, cancel := context.WithTimeout(context.Background(), replicationTimeout)
 	defer cancel()
@@ -252,6 +301,28 @@ func (t *historyReplicationTask) RetryErr(err error) bool {
 	return false
 }
 
+func (t *historyReplicationV2Task) Execute() error {
+	ctx, cancel := context.WithTimeout(context.Background(), replicationTimeout)
+	defer cancel()
+	return t.historyClient.ReplicateEventsV2(ctx, t.req)
+}
+
+func (t *historyReplicationV2Task) HandleErr(err error) error {
+	// resending history is based on event IDs of a single branch, which is not applicable
+	// to events with version histories, rely on the retry of the whole task instead
+	return err
+}
+
+func (t *historyReplicationV2Task) RetryErr(err error) bool {
+	t.attempt++
+
+	if t.attempt <= t.config.ReplicationTaskMaxRetry() && isTransientRetryableError(err) {
+		time.Sleep(replicationTaskRetryDelay)
+		return true
+	}
+	return false
+}
+
 func (t *workflowReplicationTask) PartitionID() interface{} {
 	return t.partitionID
 }
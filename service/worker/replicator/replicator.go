@@ -22,7 +22,8 @@ package replicator
 
 import (
 	"context"
-	"fmt"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/uber-common/bark"
@@ -45,6 +46,7 @@ import (
 type (
 	// Replicator is the processor for replication tasks
 	Replicator struct {
+		sync.Mutex
 		domainCache       cache.DomainCache
 		clusterMetadata   cluster.Metadata
 		domainReplicator  DomainReplicator
@@ -52,7 +54,9 @@ type (
 		historyClient     history.Client
 		config            *Config
 		client            messaging.Client
-		processors        []*replicationTaskProcessor
+		processors        map[string]*replicationTaskProcessor
+		lagMonitor        *consumerLagMonitor
+		isStopped         bool
 		logger            bark.Logger
 		metricsClient     metrics.Client
 		historySerializer persistence.HistorySerializer
@@ -66,6 +70,10 @@ type (
 		ReplicatorMessageConcurrency      dynamicconfig.IntPropertyFn
 		ReplicatorHistoryBufferRetryCount dynamicconfig.IntPropertyFn
 		ReplicationTaskMaxRetry           dynamicconfig.IntPropertyFn
+		// Enabled pauses processing of replication tasks when turned off, tasks are kept in kafka until it is turned back on
+		Enabled dynamicconfig.BoolPropertyFn
+		// LagReportInterval is the interval the lag of the consumers is sampled and reported at
+		LagReportInterval dynamicconfig.DurationPropertyFn
 	}
 )
 
@@ -73,16 +81,18 @@ const (
 	replicationTimeout = 30 * time.Second
 )
 
-// NewReplicator creates a new replicator for processing replication tasks
+// NewReplicator creates a new replicator for processing replication tasks, the lag of its consumers is passed to
+// lagHook when it is not nil
 func NewReplicator(clusterMetadata cluster.Metadata, metadataManagerV2 persistence.MetadataManager,
 	domainCache cache.DomainCache, clientBean client.Bean, config *Config,
-	client messaging.Client, logger bark.Logger, metricsClient metrics.Client) *Replicator {
+	client messaging.Client, logger bark.Logger, metricsClient metrics.Client,
+	lagHook messaging.ConsumerLagHook) *Replicator {
 
 	logger = logger.WithFields(bark.Fields{
 		logging.TagWorkflowComponent: logging.TagValueReplicatorComponent,
 	})
 
-	return &Replicator{
+	r := &Replicator{
 		domainCache:       domainCache,
 		clusterMetadata:   clusterMetadata,
 		domainReplicator:  NewDomainReplicator(metadataManagerV2, logger),
@@ -90,74 +100,118 @@ func NewReplicator(clusterMetadata cluster.Metadata, metadataManagerV2 persisten
 		historyClient:     clientBean.GetHistoryClient(),
 		config:            config,
 		client:            client,
+		processors:        make(map[string]*replicationTaskProcessor),
 		logger:            logger,
 		metricsClient:     metricsClient,
 		historySerializer: persistence.NewHistorySerializer(),
 	}
+	r.lagMonitor = newConsumerLagMonitor(clusterMetadata.GetCurrentClusterName(), r.getSourceClusters, client,
+		lagHook, config.LagReportInterval, metricsClient, logger)
+	return r
 }
 
 // Start is called to start replicator
 func (r *Replicator) Start() error {
-	currentClusterName := r.clusterMetadata.GetCurrentClusterName()
+	// register before reading the clusters, so remote clusters added meanwhile are not missed
+	r.clusterMetadata.RegisterClusterChangeCallback(r, r.onRemoteClusterAdded)
 	for cluster := range r.clusterMetadata.GetAllClusterFailoverVersions() {
-		if cluster != currentClusterName {
-			consumerName := getConsumerName(currentClusterName, cluster)
-			adminClient := admin.NewRetryableClient(
-				r.clientBean.GetRemoteAdminClient(cluster),
-				common.CreateAdminServiceRetryPolicy(),
-				common.IsWhitelistServiceTransientError,
-			)
-			historyClient := history.NewRetryableClient(
-				r.historyClient,
-				common.CreateHistoryServiceRetryPolicy(),
-				common.IsWhitelistServiceTransientError,
-			)
-			logger := r.logger.WithFields(bark.Fields{
-				logging.TagWorkflowComponent: logging.TagValueReplicationTaskProcessorComponent,
-				logging.TagSourceCluster:     cluster,
-				logging.TagConsumerName:      consumerName,
-			})
-			historyRereplicator := xdc.NewHistoryRereplicator(
-				currentClusterName,
-				r.domainCache,
-				adminClient,
-				func(ctx context.Context, request *h.ReplicateRawEventsRequest) error {
-					return historyClient.ReplicateRawEvents(ctx, request)
-				},
-				r.historySerializer,
-				replicationTimeout,
-				logger,
-			)
-			r.processors = append(r.processors, newReplicationTaskProcessor(
-				currentClusterName, cluster, consumerName, r.client,
-				r.config, logger, r.metricsClient, r.domainReplicator,
-				historyRereplicator, r.historyClient,
-				task.NewSequentialTaskProcessor(
-					r.config.ReplicatorTaskConcurrency(),
-					r.config.ReplicatorMessageConcurrency(),
-					logger,
-				),
-			))
-		}
-	}
-
-	for _, processor := range r.processors {
-		if err := processor.Start(); err != nil {
+		if err := r.startProcessor(cluster); err != nil {
 			return err
 		}
 	}
-
+	r.lagMonitor.Start()
 	return nil
 }
 
 // Stop is called to stop replicator
 func (r *Replicator) Stop() {
+	r.clusterMetadata.UnregisterClusterChangeCallback(r)
+	// stopped before taking the lock, the monitor reads the source clusters with it
+	r.lagMonitor.Stop()
+
+	r.Lock()
+	defer r.Unlock()
+	r.isStopped = true
 	for _, processor := range r.processors {
 		processor.Stop()
 	}
 	r.domainCache.Stop()
 }
 
-func getConsumerName(currentCluster, remoteCluster string) string {
-	return fmt.Sprintf("%v_consumer_for_%v", currentCluster, remoteCluster)
+// onRemoteClusterAdded starts consuming the replication tasks of a remote cluster added at runtime
+func (r *Replicator) onRemoteClusterAdded(cluster string) {
+	if err := r.startProcessor(cluster); err != nil {
+		r.logger.WithFields(bark.Fields{
+			logging.TagSourceCluster: cluster,
+			logging.TagErr:           err,
+		}).Error("Failed to start replication task processor for remote cluster")
+	}
+}
+
+func (r *Replicator) startProcessor(cluster string) error {
+	currentClusterName := r.clusterMetadata.GetCurrentClusterName()
+	if cluster == currentClusterName {
+		return nil
+	}
+
+	r.Lock()
+	defer r.Unlock()
+	if _, ok := r.processors[cluster]; ok || r.isStopped {
+		return nil
+	}
+
+	consumerName := messaging.GetReplicationConsumerName(currentClusterName, cluster)
+	adminClient := admin.NewRetryableClient(
+		r.clientBean.GetRemoteAdminClient(cluster),
+		common.CreateAdminServiceRetryPolicy(),
+		common.IsWhitelistServiceTransientError,
+	)
+	historyClient := history.NewRetryableClient(
+		r.historyClient,
+		common.CreateHistoryServiceRetryPolicy(),
+		common.IsWhitelistServiceTransientError,
+	)
+	logger := r.logger.WithFields(bark.Fields{
+		logging.TagWorkflowComponent: logging.TagValueReplicationTaskProcessorComponent,
+		logging.TagSourceCluster:     cluster,
+		logging.TagConsumerName:      consumerName,
+	})
+	historyRereplicator := xdc.NewHistoryRereplicator(
+		currentClusterName,
+		r.domainCache,
+		adminClient,
+		func(ctx context.Context, request *h.ReplicateRawEventsRequest) error {
+			return historyClient.ReplicateRawEvents(ctx, request)
+		},
+		r.historySerializer,
+		replicationTimeout,
+		logger,
+	)
+	processor := newReplicationTaskProcessor(
+		currentClusterName, cluster, consumerName, r.client,
+		r.config, logger, r.metricsClient, r.domainReplicator,
+		historyRereplicator, r.historyClient,
+		task.NewSequentialTaskProcessor(
+			r.config.ReplicatorTaskConcurrency(),
+			r.config.ReplicatorMessageConcurrency(),
+			logger,
+		),
+	)
+	if err := processor.Start(); err != nil {
+		return err
+	}
+	r.processors[cluster] = processor
+	return nil
+}
+
+// getSourceClusters returns the remote clusters the replicator consumes the replication tasks of
+func (r *Replicator) getSourceClusters() []string {
+	r.Lock()
+	defer r.Unlock()
+	clusters := make([]string, 0, len(r.processors))
+	for cluster := range r.processors {
+		clusters = append(clusters, cluster)
+	}
+	sort.Strings(clusters)
+	return clusters
 }
@@ -0,0 +1,208 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package esbackfill
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/olivere/elastic"
+	"github.com/uber-common/bark"
+	s "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common/clock"
+	es "github.com/uber/cadence/common/elasticsearch"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/metrics"
+	p "github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+	"github.com/uber/cadence/common/tokenbucket"
+)
+
+type (
+	// Config contains the configuration for the ElasticSearch visibility backfill
+	Config struct {
+		// PageSize is the number of closed executions read from the visibility store and indexed per bulk request
+		PageSize dynamicconfig.IntPropertyFn
+		// RPS is the maximum rate of pages read from the visibility store
+		RPS dynamicconfig.IntPropertyFn
+	}
+
+	// Params are the input of a backfill run
+	Params struct {
+		// DomainID is the domain whose closed executions are re-indexed
+		DomainID string
+		// EarliestStartTime and LatestStartTime bound the start time, in unix nanos, of the re-indexed executions
+		EarliestStartTime int64
+		LatestStartTime   int64
+	}
+
+	// Progress is the checkpoint of a backfill run, a run resumed from a progress
+	// continues with the page after the last indexed one
+	Progress struct {
+		NextPageToken []byte
+		IndexedCount  int64
+	}
+
+	// Backfiller re-indexes closed executions from the visibility store into ElasticSearch
+	Backfiller struct {
+		client        es.Client
+		index         string
+		visibilityMgr p.VisibilityManager
+		config        *Config
+		rateLimiter   tokenbucket.TokenBucket
+		metrics       metrics.Client
+		logger        bark.Logger
+	}
+)
+
+const (
+	esDocIDDelimiter    = "~"
+	esDocType           = "_doc"
+	versionTypeExternal = "external"
+	// backfillDocVersion is the external version of backfilled documents, documents
+	// written by the indexer always carry a higher version and are never overwritten
+	backfillDocVersion = int64(1)
+)
+
+var (
+	requestTimeout   = time.Minute
+	rateLimitTimeout = time.Minute
+)
+
+// NewBackfiller returns a new ElasticSearch visibility backfiller
+func NewBackfiller(
+	client es.Client,
+	index string,
+	visibilityMgr p.VisibilityManager,
+	config *Config,
+	metricsClient metrics.Client,
+	logger bark.Logger,
+) *Backfiller {
+	return &Backfiller{
+		client:        client,
+		index:         index,
+		visibilityMgr: visibilityMgr,
+		config:        config,
+		rateLimiter:   tokenbucket.NewDynamicTokenBucket(config.RPS, clock.NewRealTimeSource()),
+		metrics:       metricsClient,
+		logger:        logger,
+	}
+}
+
+// Run pages through the closed executions matching params, starting after progress, and
+// indexes every page into ElasticSearch with a single bulk request. Documents which
+// already exist in the index are left untouched. checkpoint is invoked with the updated
+// progress after every indexed page.
+func (b *Backfiller) Run(ctx context.Context, params Params, progress Progress, checkpoint func(Progress)) (Progress, error) {
+	logger := b.logger.WithFields(bark.Fields{
+		logging.TagDomainID: params.DomainID,
+	})
+	logger.Info("ElasticSearch visibility backfill starting")
+	for {
+		if ctx.Err() != nil {
+			return progress, ctx.Err()
+		}
+		if !b.rateLimiter.Consume(1, rateLimitTimeout) {
+			continue
+		}
+		resp, err := b.visibilityMgr.ListClosedWorkflowExecutions(ctx, &p.ListWorkflowExecutionsRequest{
+			DomainUUID:        params.DomainID,
+			EarliestStartTime: params.EarliestStartTime,
+			LatestStartTime:   params.LatestStartTime,
+			PageSize:          b.config.PageSize(),
+			NextPageToken:     progress.NextPageToken,
+		})
+		if err != nil {
+			b.metrics.IncCounter(metrics.ESBackfillScope, metrics.ESBackfillFailures)
+			logger.WithFields(bark.Fields{logging.TagErr: err}).Error("failed to list closed executions")
+			return progress, err
+		}
+
+		if len(resp.Executions) > 0 {
+			if err := b.indexPage(ctx, params.DomainID, resp.Executions); err != nil {
+				b.metrics.IncCounter(metrics.ESBackfillScope, metrics.ESBackfillFailures)
+				logger.WithFields(bark.Fields{logging.TagErr: err}).Error("failed to index closed executions")
+				return progress, err
+			}
+			b.metrics.AddCounter(metrics.ESBackfillScope, metrics.ESBackfillIndexedCount, int64(len(resp.Executions)))
+		}
+
+		progress.NextPageToken = resp.NextPageToken
+		progress.IndexedCount += int64(len(resp.Executions))
+		checkpoint(progress)
+		if len(progress.NextPageToken) == 0 {
+			logger.Infof("ElasticSearch visibility backfill completed, %v executions indexed", progress.IndexedCount)
+			return progress, nil
+		}
+	}
+}
+
+func (b *Backfiller) indexPage(ctx context.Context, domainID string, executions []*s.WorkflowExecutionInfo) error {
+	requests := make([]elastic.BulkableRequest, 0, len(executions))
+	for _, execution := range executions {
+		requests = append(requests, b.newIndexRequest(domainID, execution))
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+	resp, err := b.client.Bulk(ctx, requests)
+	if err != nil {
+		return err
+	}
+	if !resp.Errors {
+		return nil
+	}
+	for _, item := range resp.Items {
+		for _, result := range item {
+			// a conflict means the document was already written with an equal or higher version
+			if result.Status == http.StatusConflict || (result.Status >= 200 && result.Status < 300) {
+				continue
+			}
+			return fmt.Errorf("failed to index document %v, status %v", result.Id, result.Status)
+		}
+	}
+	return nil
+}
+
+func (b *Backfiller) newIndexRequest(domainID string, execution *s.WorkflowExecutionInfo) elastic.BulkableRequest {
+	workflowID := execution.Execution.GetWorkflowId()
+	runID := execution.Execution.GetRunId()
+	doc := map[string]interface{}{
+		es.DomainID:      domainID,
+		es.WorkflowID:    workflowID,
+		es.RunID:         runID,
+		es.WorkflowType:  execution.Type.GetName(),
+		es.StartTime:     execution.GetStartTime(),
+		es.ExecutionTime: execution.GetExecutionTime(),
+		es.CloseTime:     execution.GetCloseTime(),
+		es.CloseStatus:   int64(execution.GetCloseStatus()),
+		es.HistoryLength: execution.GetHistoryLength(),
+	}
+	return elastic.NewBulkIndexRequest().
+		Index(b.index).
+		Type(esDocType).
+		Id(workflowID + esDocIDDelimiter + runID).
+		VersionType(versionTypeExternal).
+		Version(backfillDocVersion).
+		Doc(doc)
+}
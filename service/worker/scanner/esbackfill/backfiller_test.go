@@ -0,0 +1,169 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package esbackfill
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/olivere/elastic"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	"github.com/uber-go/tally"
+	s "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	esMocks "github.com/uber/cadence/common/elasticsearch/mocks"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/mocks"
+	p "github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	BackfillerTestSuite struct {
+		suite.Suite
+		esClient      *esMocks.Client
+		visibilityMgr *mocks.VisibilityManager
+		backfiller    *Backfiller
+	}
+)
+
+const (
+	testIndex    = "test-index"
+	testPageSize = 2
+	testDomainID = "domain-id"
+)
+
+var testParams = Params{
+	DomainID:          testDomainID,
+	EarliestStartTime: 10,
+	LatestStartTime:   20,
+}
+
+func TestBackfillerTestSuite(t *testing.T) {
+	suite.Run(t, new(BackfillerTestSuite))
+}
+
+func (t *BackfillerTestSuite) SetupTest() {
+	t.esClient = &esMocks.Client{}
+	t.visibilityMgr = &mocks.VisibilityManager{}
+	config := &Config{
+		PageSize: dynamicconfig.GetIntPropertyFn(testPageSize),
+		RPS:      dynamicconfig.GetIntPropertyFn(1000),
+	}
+	t.backfiller = NewBackfiller(t.esClient, testIndex, t.visibilityMgr, config,
+		metrics.NewClient(tally.NoopScope, metrics.Worker), bark.NewLoggerFromLogrus(logrus.New()))
+}
+
+func (t *BackfillerTestSuite) TearDownTest() {
+	t.esClient.AssertExpectations(t.T())
+	t.visibilityMgr.AssertExpectations(t.T())
+}
+
+func (t *BackfillerTestSuite) TestIndexAllPages() {
+	t.visibilityMgr.On("ListClosedWorkflowExecutions", mock.Anything, t.newListRequest(nil)).Return(&p.ListWorkflowExecutionsResponse{
+		Executions:    []*s.WorkflowExecutionInfo{t.newExecution("wid-1"), t.newExecution("wid-2")},
+		NextPageToken: []byte("token"),
+	}, nil).Once()
+	t.visibilityMgr.On("ListClosedWorkflowExecutions", mock.Anything, t.newListRequest([]byte("token"))).Return(&p.ListWorkflowExecutionsResponse{
+		Executions: []*s.WorkflowExecutionInfo{t.newExecution("wid-3")},
+	}, nil).Once()
+	t.esClient.On("Bulk", mock.Anything, mock.MatchedBy(t.matchBulk(2))).Return(&elastic.BulkResponse{}, nil).Once()
+	t.esClient.On("Bulk", mock.Anything, mock.MatchedBy(t.matchBulk(1))).Return(&elastic.BulkResponse{
+		Errors: true,
+		Items:  []map[string]*elastic.BulkResponseItem{{"index": {Status: http.StatusConflict}}},
+	}, nil).Once()
+
+	var checkpoints []Progress
+	progress, err := t.backfiller.Run(context.Background(), testParams, Progress{}, func(progress Progress) {
+		checkpoints = append(checkpoints, progress)
+	})
+	t.NoError(err)
+	t.Equal(int64(3), progress.IndexedCount)
+	t.Empty(progress.NextPageToken)
+	t.Equal([]Progress{{NextPageToken: []byte("token"), IndexedCount: 2}, {IndexedCount: 3}}, checkpoints)
+}
+
+func (t *BackfillerTestSuite) TestResumeFromProgress() {
+	t.visibilityMgr.On("ListClosedWorkflowExecutions", mock.Anything, t.newListRequest([]byte("token"))).Return(&p.ListWorkflowExecutionsResponse{
+		Executions: []*s.WorkflowExecutionInfo{t.newExecution("wid-3")},
+	}, nil).Once()
+	t.esClient.On("Bulk", mock.Anything, mock.MatchedBy(t.matchBulk(1))).Return(&elastic.BulkResponse{}, nil).Once()
+
+	progress, err := t.backfiller.Run(context.Background(), testParams, Progress{NextPageToken: []byte("token"), IndexedCount: 2}, func(Progress) {})
+	t.NoError(err)
+	t.Equal(int64(3), progress.IndexedCount)
+}
+
+func (t *BackfillerTestSuite) TestIndexFailureReturnsLastProgress() {
+	t.visibilityMgr.On("ListClosedWorkflowExecutions", mock.Anything, t.newListRequest(nil)).Return(&p.ListWorkflowExecutionsResponse{
+		Executions:    []*s.WorkflowExecutionInfo{t.newExecution("wid-1")},
+		NextPageToken: []byte("token"),
+	}, nil).Once()
+	t.esClient.On("Bulk", mock.Anything, mock.MatchedBy(t.matchBulk(1))).Return(&elastic.BulkResponse{
+		Errors: true,
+		Items:  []map[string]*elastic.BulkResponseItem{{"index": {Status: http.StatusTooManyRequests}}},
+	}, nil).Once()
+
+	progress, err := t.backfiller.Run(context.Background(), testParams, Progress{}, func(Progress) {})
+	t.Error(err)
+	t.Equal(Progress{}, progress)
+}
+
+func (t *BackfillerTestSuite) TestListError() {
+	t.visibilityMgr.On("ListClosedWorkflowExecutions", mock.Anything, mock.Anything).Return(nil, errors.New("persistence error")).Once()
+	_, err := t.backfiller.Run(context.Background(), testParams, Progress{}, func(Progress) {})
+	t.Error(err)
+}
+
+func (t *BackfillerTestSuite) newListRequest(token []byte) *p.ListWorkflowExecutionsRequest {
+	return &p.ListWorkflowExecutionsRequest{
+		DomainUUID:        testDomainID,
+		EarliestStartTime: testParams.EarliestStartTime,
+		LatestStartTime:   testParams.LatestStartTime,
+		PageSize:          testPageSize,
+		NextPageToken:     token,
+	}
+}
+
+func (t *BackfillerTestSuite) matchBulk(count int) func([]elastic.BulkableRequest) bool {
+	return func(requests []elastic.BulkableRequest) bool {
+		return len(requests) == count
+	}
+}
+
+func (t *BackfillerTestSuite) newExecution(workflowID string) *s.WorkflowExecutionInfo {
+	return &s.WorkflowExecutionInfo{
+		Execution: &s.WorkflowExecution{
+			WorkflowId: common.StringPtr(workflowID),
+			RunId:      common.StringPtr("run-id"),
+		},
+		Type:          &s.WorkflowType{Name: common.StringPtr("workflow-type")},
+		StartTime:     common.Int64Ptr(15),
+		CloseTime:     common.Int64Ptr(30),
+		CloseStatus:   s.WorkflowExecutionCloseStatusCompleted.Ptr(),
+		HistoryLength: common.Int64Ptr(5),
+	}
+}
@@ -0,0 +1,244 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package esretention
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/olivere/elastic"
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/clock"
+	es "github.com/uber/cadence/common/elasticsearch"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/metrics"
+	p "github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+	"github.com/uber/cadence/common/tokenbucket"
+)
+
+type (
+	// Config contains the configuration for the ElasticSearch retention scavenger
+	Config struct {
+		// BatchSize is the maximum number of documents deleted by one delete by query request
+		BatchSize dynamicconfig.IntPropertyFn
+		// RPS is the maximum rate of delete by query requests
+		RPS dynamicconfig.IntPropertyFn
+		// TombstoneRetention is how long the tombstones of workflows deleted due to retention are kept
+		TombstoneRetention dynamicconfig.DurationPropertyFnWithDomainFilter
+	}
+
+	// Scavenger is the type that holds the state for the ElasticSearch retention scavenger daemon
+	Scavenger struct {
+		client      es.Client
+		index       string
+		domainDB    p.MetadataManager
+		config      *Config
+		rateLimiter tokenbucket.TokenBucket
+		timeSource  clock.TimeSource
+		metrics     metrics.Client
+		logger      bark.Logger
+		status      int32
+		stopC       chan struct{}
+		stopWG      sync.WaitGroup
+		ctx         context.Context
+		cancel      context.CancelFunc
+	}
+)
+
+var (
+	domainPageSize   = 100
+	requestTimeout   = 5 * time.Minute
+	rateLimitTimeout = time.Minute
+)
+
+// NewScavenger returns an instance of the ElasticSearch retention scavenger daemon.
+// ElasticSearch visibility records are never deleted by the visibility manager, the
+// scavenger does one complete iteration over all domains when started and deletes the
+// closed workflow documents of each domain whose close time is older than the domain
+// retention. Documents are deleted in rate limited batches of delete by query requests.
+//
+// Failures on a domain are logged and the scavenger moves on to the next domain, the
+// remaining documents are picked up by the next run.
+func NewScavenger(
+	client es.Client,
+	index string,
+	domainDB p.MetadataManager,
+	config *Config,
+	metricsClient metrics.Client,
+	logger bark.Logger,
+) *Scavenger {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Scavenger{
+		client:      client,
+		index:       index,
+		domainDB:    domainDB,
+		config:      config,
+		rateLimiter: tokenbucket.NewDynamicTokenBucket(config.RPS, clock.NewRealTimeSource()),
+		timeSource:  clock.NewRealTimeSource(),
+		metrics:     metricsClient,
+		logger:      logger,
+		stopC:       make(chan struct{}),
+		ctx:         ctx,
+		cancel:      cancel,
+	}
+}
+
+// Start starts the scavenger
+func (s *Scavenger) Start() {
+	if !atomic.CompareAndSwapInt32(&s.status, common.DaemonStatusInitialized, common.DaemonStatusStarted) {
+		return
+	}
+	s.logger.Info("ElasticSearch retention scavenger starting")
+	s.stopWG.Add(1)
+	go s.run()
+	s.metrics.IncCounter(metrics.ESRetentionScavengerScope, metrics.StartedCount)
+	s.logger.Info("ElasticSearch retention scavenger started")
+}
+
+// Stop stops the scavenger
+func (s *Scavenger) Stop() {
+	if !atomic.CompareAndSwapInt32(&s.status, common.DaemonStatusStarted, common.DaemonStatusStopped) {
+		return
+	}
+	s.metrics.IncCounter(metrics.ESRetentionScavengerScope, metrics.StoppedCount)
+	s.logger.Info("ElasticSearch retention scavenger stopping")
+	close(s.stopC)
+	s.cancel()
+	s.stopWG.Wait()
+	s.logger.Info("ElasticSearch retention scavenger stopped")
+}
+
+// Alive returns true if the scavenger is still running
+func (s *Scavenger) Alive() bool {
+	return atomic.LoadInt32(&s.status) == common.DaemonStatusStarted
+}
+
+// run does a single run over all domains
+func (s *Scavenger) run() {
+	defer func() {
+		go s.Stop()
+		s.stopWG.Done()
+	}()
+
+	var pageToken []byte
+	for {
+		resp, err := s.domainDB.ListDomains(s.ctx, &p.ListDomainsRequest{
+			PageSize:      domainPageSize,
+			NextPageToken: pageToken,
+		})
+		if err != nil {
+			s.metrics.IncCounter(metrics.ESRetentionScavengerScope, metrics.ESRetentionFailures)
+			s.logger.WithFields(bark.Fields{logging.TagErr: err}).Error("listDomains error")
+			return
+		}
+
+		for _, domain := range resp.Domains {
+			if s.isStopped() {
+				return
+			}
+			s.processDomain(domain)
+		}
+
+		pageToken = resp.NextPageToken
+		if len(pageToken) == 0 {
+			return
+		}
+	}
+}
+
+func (s *Scavenger) processDomain(domain *p.GetDomainResponse) {
+	logger := s.logger.WithFields(bark.Fields{
+		logging.TagDomainID: domain.Info.ID,
+	})
+	if domain.Config.Retention <= 0 {
+		return
+	}
+	now := s.timeSource.Now()
+	retention := time.Duration(domain.Config.Retention) * 24 * time.Hour
+	deletedStatus := int(shared.WorkflowExecutionCloseStatusDeleted)
+	// tombstones are closed at deletion time, they expire after the tombstone retention instead of the domain one
+	expiredQuery := elastic.NewBoolQuery().
+		Filter(elastic.NewTermQuery(es.DomainID, domain.Info.ID)).
+		Filter(elastic.NewExistsQuery(es.CloseStatus)).
+		Filter(elastic.NewRangeQuery(es.CloseTime).Lt(now.Add(-retention).UnixNano())).
+		MustNot(elastic.NewTermQuery(es.CloseStatus, deletedStatus))
+	if !s.deleteExpired(expiredQuery, logger) {
+		return
+	}
+	tombstoneRetention := s.config.TombstoneRetention(domain.Info.Name)
+	expiredTombstoneQuery := elastic.NewBoolQuery().
+		Filter(elastic.NewTermQuery(es.DomainID, domain.Info.ID)).
+		Filter(elastic.NewTermQuery(es.CloseStatus, deletedStatus)).
+		Filter(elastic.NewRangeQuery(es.CloseTime).Lt(now.Add(-tombstoneRetention).UnixNano()))
+	if !s.deleteExpired(expiredTombstoneQuery, logger) {
+		return
+	}
+	s.metrics.IncCounter(metrics.ESRetentionScavengerScope, metrics.ESRetentionDomainProcessedCount)
+}
+
+// deleteExpired deletes the documents matching the query in batches, it returns false when a batch failed
+func (s *Scavenger) deleteExpired(query elastic.Query, logger bark.Logger) bool {
+	for !s.isStopped() {
+		if !s.rateLimiter.Consume(1, rateLimitTimeout) {
+			continue
+		}
+		batchSize := s.config.BatchSize()
+		deleted, err := s.deleteBatch(query, batchSize)
+		if err != nil {
+			s.metrics.IncCounter(metrics.ESRetentionScavengerScope, metrics.ESRetentionFailures)
+			logger.WithFields(bark.Fields{logging.TagErr: err}).Error("failed to delete expired visibility documents")
+			return false
+		}
+		s.metrics.AddCounter(metrics.ESRetentionScavengerScope, metrics.ESRetentionDeletedCount, deleted)
+		if deleted == 0 || deleted < int64(batchSize) {
+			break
+		}
+	}
+	return true
+}
+
+func (s *Scavenger) deleteBatch(query elastic.Query, batchSize int) (int64, error) {
+	ctx, cancel := context.WithTimeout(s.ctx, requestTimeout)
+	defer cancel()
+	resp, err := s.client.DeleteByQuery(ctx, &es.DeleteByQueryParameters{
+		Index:   s.index,
+		Query:   query,
+		MaxDocs: batchSize,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return resp.Deleted, nil
+}
+
+func (s *Scavenger) isStopped() bool {
+	select {
+	case <-s.stopC:
+		return true
+	default:
+		return false
+	}
+}
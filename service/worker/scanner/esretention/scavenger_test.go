@@ -0,0 +1,162 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package esretention
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/olivere/elastic"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	"github.com/uber-go/tally"
+	es "github.com/uber/cadence/common/elasticsearch"
+	esMocks "github.com/uber/cadence/common/elasticsearch/mocks"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/mocks"
+	p "github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	ScavengerTestSuite struct {
+		suite.Suite
+		esClient *esMocks.Client
+		domainDB *mocks.MetadataManager
+		scvgr    *Scavenger
+	}
+)
+
+const (
+	testIndex     = "test-index"
+	testBatchSize = 10
+)
+
+func TestScavengerTestSuite(t *testing.T) {
+	suite.Run(t, new(ScavengerTestSuite))
+}
+
+func (s *ScavengerTestSuite) SetupTest() {
+	s.esClient = &esMocks.Client{}
+	s.domainDB = &mocks.MetadataManager{}
+	config := &Config{
+		BatchSize:          dynamicconfig.GetIntPropertyFn(testBatchSize),
+		RPS:                dynamicconfig.GetIntPropertyFn(1000),
+		TombstoneRetention: dynamicconfig.GetDurationPropertyFnFilteredByDomain(time.Hour),
+	}
+	s.scvgr = NewScavenger(s.esClient, testIndex, s.domainDB, config,
+		metrics.NewClient(tally.NoopScope, metrics.Worker), bark.NewLoggerFromLogrus(logrus.New()))
+}
+
+func (s *ScavengerTestSuite) TearDownTest() {
+	s.esClient.AssertExpectations(s.T())
+	s.domainDB.AssertExpectations(s.T())
+}
+
+func (s *ScavengerTestSuite) TestDeleteInBatches() {
+	s.domainDB.On("ListDomains", mock.Anything, &p.ListDomainsRequest{PageSize: domainPageSize}).Return(&p.ListDomainsResponse{
+		Domains:       []*p.GetDomainResponse{s.newDomain("domain-1", 1)},
+		NextPageToken: []byte("token"),
+	}, nil).Once()
+	s.domainDB.On("ListDomains", mock.Anything, &p.ListDomainsRequest{PageSize: domainPageSize, NextPageToken: []byte("token")}).Return(&p.ListDomainsResponse{
+		Domains: []*p.GetDomainResponse{s.newDomain("domain-2", 0), s.newDomain("domain-3", 7)},
+	}, nil).Once()
+
+	s.esClient.On("DeleteByQuery", mock.Anything, mock.MatchedBy(s.matchDelete)).Return(&elastic.BulkIndexByScrollResponse{Deleted: testBatchSize}, nil).Twice()
+	s.esClient.On("DeleteByQuery", mock.Anything, mock.MatchedBy(s.matchDelete)).Return(&elastic.BulkIndexByScrollResponse{Deleted: 3}, nil).Once()
+	s.esClient.On("DeleteByQuery", mock.Anything, mock.MatchedBy(s.matchDelete)).Return(&elastic.BulkIndexByScrollResponse{Deleted: 0}, nil).Times(3)
+
+	s.runScavenger()
+}
+
+func (s *ScavengerTestSuite) TestDeleteErrorMovesToNextDomain() {
+	s.domainDB.On("ListDomains", mock.Anything, mock.Anything).Return(&p.ListDomainsResponse{
+		Domains: []*p.GetDomainResponse{s.newDomain("domain-1", 1), s.newDomain("domain-2", 1)},
+	}, nil).Once()
+
+	s.esClient.On("DeleteByQuery", mock.Anything, mock.MatchedBy(s.matchDelete)).Return(nil, errors.New("es error")).Once()
+	s.esClient.On("DeleteByQuery", mock.Anything, mock.MatchedBy(s.matchDelete)).Return(&elastic.BulkIndexByScrollResponse{Deleted: 1}, nil).Twice()
+
+	s.runScavenger()
+}
+
+func (s *ScavengerTestSuite) TestDeleteExpiredTombstones() {
+	s.domainDB.On("ListDomains", mock.Anything, mock.Anything).Return(&p.ListDomainsResponse{
+		Domains: []*p.GetDomainResponse{s.newDomain("domain-1", 1)},
+	}, nil).Once()
+
+	s.esClient.On("DeleteByQuery", mock.Anything, mock.MatchedBy(func(params *es.DeleteByQueryParameters) bool {
+		return s.matchDelete(params) && !s.isTombstoneQuery(params.Query)
+	})).Return(&elastic.BulkIndexByScrollResponse{Deleted: 0}, nil).Once()
+	s.esClient.On("DeleteByQuery", mock.Anything, mock.MatchedBy(func(params *es.DeleteByQueryParameters) bool {
+		return s.matchDelete(params) && s.isTombstoneQuery(params.Query)
+	})).Return(&elastic.BulkIndexByScrollResponse{Deleted: testBatchSize}, nil).Once()
+	s.esClient.On("DeleteByQuery", mock.Anything, mock.MatchedBy(func(params *es.DeleteByQueryParameters) bool {
+		return s.matchDelete(params) && s.isTombstoneQuery(params.Query)
+	})).Return(&elastic.BulkIndexByScrollResponse{Deleted: 2}, nil).Once()
+
+	s.runScavenger()
+}
+
+func (s *ScavengerTestSuite) TestListDomainsError() {
+	s.domainDB.On("ListDomains", mock.Anything, mock.Anything).Return(nil, errors.New("persistence error")).Once()
+	s.runScavenger()
+}
+
+func (s *ScavengerTestSuite) runScavenger() {
+	s.scvgr.Start()
+	timer := time.NewTimer(10 * time.Second)
+	defer timer.Stop()
+	for s.scvgr.Alive() {
+		select {
+		case <-timer.C:
+			s.Fail("timed out waiting for scavenger to finish")
+			return
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func (s *ScavengerTestSuite) matchDelete(params *es.DeleteByQueryParameters) bool {
+	return params.Index == testIndex && params.MaxDocs == testBatchSize && params.Query != nil
+}
+
+// isTombstoneQuery tells the tombstone query, which filters on the deleted close status, from the retention
+// query which excludes the deleted close status
+func (s *ScavengerTestSuite) isTombstoneQuery(query elastic.Query) bool {
+	source, err := query.Source()
+	s.NoError(err)
+	body, err := json.Marshal(source)
+	s.NoError(err)
+	return !strings.Contains(string(body), "must_not")
+}
+
+func (s *ScavengerTestSuite) newDomain(id string, retentionDays int32) *p.GetDomainResponse {
+	return &p.GetDomainResponse{
+		Info:   &p.DomainInfo{ID: id, Name: id},
+		Config: &p.DomainConfig{Retention: retentionDays},
+	}
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/backoff"
 	"github.com/uber/cadence/common/cluster"
+	es "github.com/uber/cadence/common/elasticsearch"
 	"github.com/uber/cadence/common/logging"
 	"github.com/uber/cadence/common/metrics"
 	p "github.com/uber/cadence/common/persistence"
@@ -52,6 +53,28 @@ type (
 		Persistence *config.Persistence
 		// ClusterMetadata contains the metadata for this cluster
 		ClusterMetadata cluster.Metadata
+		// TaskListScannerEnabled indicates if the task list scanner should be started
+		TaskListScannerEnabled bool
+		// ESRetentionScannerEnabled indicates if expired ElasticSearch visibility documents should be deleted
+		ESRetentionScannerEnabled dynamicconfig.BoolPropertyFn
+		// ESRetentionScannerBatchSize is the maximum number of documents deleted by one delete by query request
+		ESRetentionScannerBatchSize dynamicconfig.IntPropertyFn
+		// ESRetentionScannerRPS is the maximum rate of delete by query requests sent to ElasticSearch
+		ESRetentionScannerRPS dynamicconfig.IntPropertyFn
+		// VisibilityTombstoneRetention is how long the tombstones of workflows deleted due to retention are kept
+		VisibilityTombstoneRetention dynamicconfig.DurationPropertyFnWithDomainFilter
+		// ESBackfillPageSize is the number of closed executions re-indexed by one bulk request of the ElasticSearch backfill
+		ESBackfillPageSize dynamicconfig.IntPropertyFn
+		// ESBackfillRPS is the maximum rate of pages read from the visibility store by the ElasticSearch backfill
+		ESBackfillRPS dynamicconfig.IntPropertyFn
+		// VisibilityReconcilerEnabled indicates if visibility records should be verified against the execution store
+		VisibilityReconcilerEnabled dynamicconfig.BoolPropertyFn
+		// VisibilityReconcilerSampleSize is the number of open and of closed visibility records verified per domain
+		VisibilityReconcilerSampleSize dynamicconfig.IntPropertyFn
+		// VisibilityReconcilerMinAge is the minimum age of the executions verified by the visibility reconciler
+		VisibilityReconcilerMinAge dynamicconfig.DurationPropertyFn
+		// VisibilityReconcilerRepairEnabled indicates if open visibility records of closed executions should be closed
+		VisibilityReconcilerRepairEnabled dynamicconfig.BoolPropertyFn
 	}
 
 	// BootstrapParams contains the set of params needed to bootstrap
@@ -67,6 +90,10 @@ type (
 		Logger bark.Logger
 		// TallyScope is an instance of tally metrics scope
 		TallyScope tally.Scope
+		// ESClient is the ElasticSearch client, nil if ElasticSearch visibility is not enabled
+		ESClient es.Client
+		// ESIndex is the name of the ElasticSearch visibility index
+		ESIndex string
 	}
 
 	// scannerContext is the context object that get's
@@ -74,6 +101,10 @@ type (
 	scannerContext struct {
 		taskDB        p.TaskManager
 		domainDB      p.MetadataManager
+		visibilityDB  p.VisibilityManager
+		pFactory      pfactory.Factory
+		esClient      es.Client
+		esIndex       string
 		cfg           Config
 		sdkClient     workflowserviceclient.Interface
 		metricsClient metrics.Client
@@ -109,6 +140,8 @@ func New(params *BootstrapParams) *Scanner {
 			metricsClient: params.MetricsClient,
 			logger:        params.Logger,
 			tallyScope:    params.TallyScope,
+			esClient:      params.ESClient,
+			esIndex:       params.ESIndex,
 			zapLogger:     zapLogger,
 		},
 	}
@@ -126,35 +159,53 @@ func (s *Scanner) Start() error {
 		MaxConcurrentDecisionTaskExecutionSize: maxConcurrentDecisionTaskExecutionSize,
 		BackgroundActivityContext:              context.WithValue(context.Background(), scannerContextKey, s.context),
 	}
-	go s.startWorkflowWithRetry()
-	worker := worker.New(s.context.sdkClient, common.SystemDomainName, tlScannerTaskListName, workerOpts)
-	return worker.Start()
+
+	var taskLists []string
+	if s.context.cfg.TaskListScannerEnabled {
+		go s.startWorkflowWithRetry(tlScannerWFStartOptions, tlScannerWFTypeName)
+		taskLists = append(taskLists, tlScannerTaskListName)
+	}
+	go s.startWorkflowWithRetry(visibilityReconcilerWFStartOptions, visibilityReconcilerWFTypeName)
+	taskLists = append(taskLists, visibilityReconcilerTaskListName)
+	if s.context.esClient != nil {
+		go s.startWorkflowWithRetry(esRetentionScannerWFStartOptions, esRetentionScannerWFTypeName)
+		taskLists = append(taskLists, esRetentionScannerTaskListName, ESBackfillTaskListName)
+	}
+
+	for _, taskList := range taskLists {
+		worker := worker.New(s.context.sdkClient, common.SystemDomainName, taskList, workerOpts)
+		if err := worker.Start(); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-func (s *Scanner) startWorkflowWithRetry() error {
+func (s *Scanner) startWorkflowWithRetry(options cclient.StartWorkflowOptions, workflowType string) error {
 	client := cclient.NewClient(s.context.sdkClient, common.SystemDomainName, &cclient.Options{})
 	policy := backoff.NewExponentialRetryPolicy(time.Second)
 	policy.SetMaximumInterval(time.Minute)
 	policy.SetExpirationInterval(backoff.NoInterval)
 	return backoff.Retry(func() error {
-		return s.startWorkflow(client)
+		return s.startWorkflow(client, options, workflowType)
 	}, policy, func(err error) bool {
 		return true
 	})
 }
 
-func (s *Scanner) startWorkflow(client cclient.Client) error {
+func (s *Scanner) startWorkflow(client cclient.Client, options cclient.StartWorkflowOptions, workflowType string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
-	_, err := client.StartWorkflow(ctx, tlScannerWFStartOptions, tlScannerWFTypeName)
+	_, err := client.StartWorkflow(ctx, options, workflowType)
 	cancel()
+	logger := s.context.logger.WithField(logging.TagWorkflowType, workflowType)
 	if err != nil {
 		if _, ok := err.(*shared.WorkflowExecutionAlreadyStartedError); ok {
 			return nil
 		}
-		s.context.logger.WithFields(bark.Fields{logging.TagErr: err}).Error("error starting scanner workflow")
+		logger.WithFields(bark.Fields{logging.TagErr: err}).Error("error starting scanner workflow")
 		return err
 	}
-	s.context.logger.Info("Scanner workflow successfully started")
+	logger.Info("Scanner workflow successfully started")
 	return nil
 }
 
@@ -169,7 +220,13 @@ func (s *Scanner) buildContext() error {
 	if err != nil {
 		return err
 	}
+	visibilityDB, err := pFactory.NewVisibilityManager()
+	if err != nil {
+		return err
+	}
 	s.context.taskDB = taskDB
+	s.context.visibilityDB = visibilityDB
+	s.context.pFactory = pFactory
 	s.context.domainDB = domainDB
 	return nil
 }
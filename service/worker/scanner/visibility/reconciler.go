@@ -0,0 +1,378 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package visibility
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/olivere/elastic"
+	"github.com/uber-common/bark"
+	s "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/clock"
+	es "github.com/uber/cadence/common/elasticsearch"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/metrics"
+	p "github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	// Config contains the configuration for the visibility reconciler
+	Config struct {
+		// SampleSize is the number of open and of closed visibility records checked per domain and run
+		SampleSize dynamicconfig.IntPropertyFn
+		// MinAge is the minimum age of checked executions, younger records may still be in flight
+		MinAge dynamicconfig.DurationPropertyFn
+		// RepairEnabled indicates if open visibility records of closed executions are closed by the reconciler
+		RepairEnabled dynamicconfig.BoolPropertyFn
+	}
+
+	// ExecutionManagerProvider returns the execution manager of a history shard
+	ExecutionManagerProvider func(shardID int) (p.ExecutionManager, error)
+
+	// Reconciler is the type that holds the state for the visibility reconciler daemon
+	Reconciler struct {
+		visibilityDB  p.VisibilityManager
+		domainDB      p.MetadataManager
+		executionDBs  ExecutionManagerProvider
+		numShards     int
+		esClient      es.Client
+		esIndex       string
+		config        *Config
+		timeSource    clock.TimeSource
+		metrics       metrics.Client
+		logger        bark.Logger
+		executionMgrs map[int]p.ExecutionManager
+		status        int32
+		stopC         chan struct{}
+		stopWG        sync.WaitGroup
+		ctx           context.Context
+		cancel        context.CancelFunc
+	}
+)
+
+var (
+	domainPageSize = 100
+	requestTimeout = 10 * time.Second
+)
+
+// NewReconciler returns an instance of the visibility reconciler daemon. Visibility records are
+// written by a different pipeline than the execution store, directly to the database or through
+// kafka to ElasticSearch, and can silently drift from the executions they describe. The reconciler
+// does one iteration over all domains when started and, for each domain, samples open and closed
+// visibility records from a random point in time of the domain retention and verifies them against
+// the execution store:
+//   - an open record must belong to a running execution, an open record of a closed execution is
+//     closed if repair is enabled, an open record of a deleted execution can only be reported
+//   - a closed record must not belong to a running execution
+//   - when ElasticSearch is configured, the document of the execution must exist and be closed
+//     if and only if the execution is closed
+//
+// Mismatches are emitted as metrics and logged.
+func NewReconciler(
+	visibilityDB p.VisibilityManager,
+	domainDB p.MetadataManager,
+	executionDBs ExecutionManagerProvider,
+	numShards int,
+	esClient es.Client,
+	esIndex string,
+	config *Config,
+	metricsClient metrics.Client,
+	logger bark.Logger,
+) *Reconciler {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Reconciler{
+		visibilityDB:  visibilityDB,
+		domainDB:      domainDB,
+		executionDBs:  executionDBs,
+		numShards:     numShards,
+		esClient:      esClient,
+		esIndex:       esIndex,
+		config:        config,
+		timeSource:    clock.NewRealTimeSource(),
+		metrics:       metricsClient,
+		logger:        logger,
+		executionMgrs: make(map[int]p.ExecutionManager),
+		stopC:         make(chan struct{}),
+		ctx:           ctx,
+		cancel:        cancel,
+	}
+}
+
+// Start starts the reconciler
+func (r *Reconciler) Start() {
+	if !atomic.CompareAndSwapInt32(&r.status, common.DaemonStatusInitialized, common.DaemonStatusStarted) {
+		return
+	}
+	r.logger.Info("Visibility reconciler starting")
+	r.stopWG.Add(1)
+	go r.run()
+	r.metrics.IncCounter(metrics.VisibilityReconcilerScope, metrics.StartedCount)
+	r.logger.Info("Visibility reconciler started")
+}
+
+// Stop stops the reconciler
+func (r *Reconciler) Stop() {
+	if !atomic.CompareAndSwapInt32(&r.status, common.DaemonStatusStarted, common.DaemonStatusStopped) {
+		return
+	}
+	r.metrics.IncCounter(metrics.VisibilityReconcilerScope, metrics.StoppedCount)
+	r.logger.Info("Visibility reconciler stopping")
+	close(r.stopC)
+	r.cancel()
+	r.stopWG.Wait()
+	r.logger.Info("Visibility reconciler stopped")
+}
+
+// Alive returns true if the reconciler is still running
+func (r *Reconciler) Alive() bool {
+	return atomic.LoadInt32(&r.status) == common.DaemonStatusStarted
+}
+
+// run does a single run over all domains
+func (r *Reconciler) run() {
+	defer func() {
+		go r.Stop()
+		r.stopWG.Done()
+	}()
+
+	var pageToken []byte
+	for {
+		resp, err := r.domainDB.ListDomains(r.ctx, &p.ListDomainsRequest{
+			PageSize:      domainPageSize,
+			NextPageToken: pageToken,
+		})
+		if err != nil {
+			r.metrics.IncCounter(metrics.VisibilityReconcilerScope, metrics.VisibilityReconcilerFailures)
+			r.logger.WithFields(bark.Fields{logging.TagErr: err}).Error("listDomains error")
+			return
+		}
+
+		for _, domain := range resp.Domains {
+			if r.isStopped() {
+				return
+			}
+			if domain.Info.Status != p.DomainStatusRegistered {
+				continue
+			}
+			r.processDomain(domain)
+		}
+
+		pageToken = resp.NextPageToken
+		if len(pageToken) == 0 {
+			return
+		}
+	}
+}
+
+func (r *Reconciler) processDomain(domain *p.GetDomainResponse) {
+	logger := r.logger.WithFields(bark.Fields{
+		logging.TagDomainID: domain.Info.ID,
+	})
+	now := r.timeSource.Now()
+	latest := now.Add(-r.config.MinAge())
+	if retention := time.Duration(domain.Config.Retention) * 24 * time.Hour; retention > 0 {
+		latest = latest.Add(-time.Duration(rand.Int63n(int64(retention))))
+	}
+	request := &p.ListWorkflowExecutionsRequest{
+		DomainUUID:        domain.Info.ID,
+		Domain:            domain.Info.Name,
+		EarliestStartTime: 0,
+		LatestStartTime:   latest.UnixNano(),
+		PageSize:          r.config.SampleSize(),
+	}
+
+	openResp, err := r.visibilityDB.ListOpenWorkflowExecutions(r.ctx, request)
+	if err != nil {
+		r.metrics.IncCounter(metrics.VisibilityReconcilerScope, metrics.VisibilityReconcilerFailures)
+		logger.WithFields(bark.Fields{logging.TagErr: err}).Error("failed to list open executions")
+		return
+	}
+	for _, record := range openResp.Executions {
+		if r.isStopped() {
+			return
+		}
+		r.checkRecord(domain, record, false, logger)
+	}
+
+	closedResp, err := r.visibilityDB.ListClosedWorkflowExecutions(r.ctx, request)
+	if err != nil {
+		r.metrics.IncCounter(metrics.VisibilityReconcilerScope, metrics.VisibilityReconcilerFailures)
+		logger.WithFields(bark.Fields{logging.TagErr: err}).Error("failed to list closed executions")
+		return
+	}
+	for _, record := range closedResp.Executions {
+		if r.isStopped() {
+			return
+		}
+		r.checkRecord(domain, record, true, logger)
+	}
+}
+
+func (r *Reconciler) checkRecord(domain *p.GetDomainResponse, record *s.WorkflowExecutionInfo, recordClosed bool, logger bark.Logger) {
+	logger = logger.WithFields(bark.Fields{
+		logging.TagWorkflowExecutionID: record.Execution.GetWorkflowId(),
+		logging.TagWorkflowRunID:       record.Execution.GetRunId(),
+	})
+	r.metrics.IncCounter(metrics.VisibilityReconcilerScope, metrics.VisibilityReconcilerCheckedCount)
+
+	executionInfo, err := r.getExecution(domain.Info.ID, record.Execution)
+	if err != nil {
+		r.metrics.IncCounter(metrics.VisibilityReconcilerScope, metrics.VisibilityReconcilerFailures)
+		logger.WithFields(bark.Fields{logging.TagErr: err}).Error("failed to load execution")
+		return
+	}
+	if executionInfo == nil {
+		// closed executions are deleted after retention, their visibility records expire with them
+		if !recordClosed {
+			r.metrics.IncCounter(metrics.VisibilityReconcilerScope, metrics.VisibilityReconcilerMissingExecutionCount)
+			logger.Warn("Open visibility record of a deleted execution")
+		}
+		return
+	}
+
+	executionClosed := executionInfo.State == p.WorkflowStateCompleted
+	switch {
+	case !recordClosed && executionClosed:
+		r.metrics.IncCounter(metrics.VisibilityReconcilerScope, metrics.VisibilityReconcilerStaleOpenCount)
+		logger.Warn("Open visibility record of a closed execution")
+		if r.config.RepairEnabled() {
+			r.closeRecord(domain, record, executionInfo, logger)
+		}
+	case recordClosed && !executionClosed:
+		r.metrics.IncCounter(metrics.VisibilityReconcilerScope, metrics.VisibilityReconcilerStaleClosedCount)
+		logger.Warn("Closed visibility record of a running execution")
+	}
+
+	if r.esClient != nil {
+		r.checkESDocument(domain.Info.ID, record.Execution, executionClosed, logger)
+	}
+}
+
+// getExecution returns the execution info of the execution, or nil if the execution does not exist
+func (r *Reconciler) getExecution(domainID string, execution *s.WorkflowExecution) (*p.WorkflowExecutionInfo, error) {
+	shardID := common.WorkflowIDToHistoryShard(execution.GetWorkflowId(), r.numShards)
+	executionMgr, ok := r.executionMgrs[shardID]
+	if !ok {
+		var err error
+		executionMgr, err = r.executionDBs(shardID)
+		if err != nil {
+			return nil, err
+		}
+		r.executionMgrs[shardID] = executionMgr
+	}
+
+	ctx, cancel := context.WithTimeout(r.ctx, requestTimeout)
+	defer cancel()
+	resp, err := executionMgr.GetWorkflowExecution(ctx, &p.GetWorkflowExecutionRequest{
+		DomainID:  domainID,
+		Execution: *execution,
+	})
+	if err != nil {
+		if _, ok := err.(*s.EntityNotExistsError); ok {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return resp.State.ExecutionInfo, nil
+}
+
+func (r *Reconciler) closeRecord(domain *p.GetDomainResponse, record *s.WorkflowExecutionInfo, executionInfo *p.WorkflowExecutionInfo, logger bark.Logger) {
+	ctx, cancel := context.WithTimeout(r.ctx, requestTimeout)
+	defer cancel()
+	err := r.visibilityDB.RecordWorkflowExecutionClosed(ctx, &p.RecordWorkflowExecutionClosedRequest{
+		DomainUUID:         domain.Info.ID,
+		Domain:             domain.Info.Name,
+		Execution:          *record.Execution,
+		WorkflowTypeName:   record.Type.GetName(),
+		StartTimestamp:     record.GetStartTime(),
+		ExecutionTimestamp: record.GetExecutionTime(),
+		CloseTimestamp:     executionInfo.LastUpdatedTimestamp.UnixNano(),
+		Status:             getCloseStatus(executionInfo.CloseStatus),
+		HistoryLength:      executionInfo.NextEventID - common.FirstEventID,
+		RetentionSeconds:   int64(domain.Config.Retention) * int64(24*time.Hour/time.Second),
+		TaskList:           executionInfo.TaskList,
+		ParentWorkflowID:   executionInfo.ParentWorkflowID,
+		ParentRunID:        executionInfo.ParentRunID,
+	})
+	if err != nil {
+		r.metrics.IncCounter(metrics.VisibilityReconcilerScope, metrics.VisibilityReconcilerFailures)
+		logger.WithFields(bark.Fields{logging.TagErr: err}).Error("failed to close visibility record")
+		return
+	}
+	r.metrics.IncCounter(metrics.VisibilityReconcilerScope, metrics.VisibilityReconcilerRepairedCount)
+}
+
+func (r *Reconciler) checkESDocument(domainID string, execution *s.WorkflowExecution, executionClosed bool, logger bark.Logger) {
+	query := elastic.NewBoolQuery().
+		Filter(elastic.NewTermQuery(es.DomainID, domainID)).
+		Filter(elastic.NewTermQuery(es.WorkflowID, execution.GetWorkflowId())).
+		Filter(elastic.NewTermQuery(es.RunID, execution.GetRunId()))
+	if executionClosed {
+		query = query.Filter(elastic.NewExistsQuery(es.CloseStatus))
+	} else {
+		query = query.MustNot(elastic.NewExistsQuery(es.CloseStatus))
+	}
+
+	ctx, cancel := context.WithTimeout(r.ctx, requestTimeout)
+	defer cancel()
+	count, err := r.esClient.Count(ctx, r.esIndex, query)
+	if err != nil {
+		r.metrics.IncCounter(metrics.VisibilityReconcilerScope, metrics.VisibilityReconcilerFailures)
+		logger.WithFields(bark.Fields{logging.TagErr: err}).Error("failed to count ElasticSearch documents")
+		return
+	}
+	if count == 0 {
+		r.metrics.IncCounter(metrics.VisibilityReconcilerScope, metrics.VisibilityReconcilerESMismatchCount)
+		logger.Warn("ElasticSearch visibility document is missing or does not match the execution status")
+	}
+}
+
+func (r *Reconciler) isStopped() bool {
+	select {
+	case <-r.stopC:
+		return true
+	default:
+		return false
+	}
+}
+
+func getCloseStatus(status int) s.WorkflowExecutionCloseStatus {
+	switch status {
+	case p.WorkflowCloseStatusFailed:
+		return s.WorkflowExecutionCloseStatusFailed
+	case p.WorkflowCloseStatusCanceled:
+		return s.WorkflowExecutionCloseStatusCanceled
+	case p.WorkflowCloseStatusTerminated:
+		return s.WorkflowExecutionCloseStatusTerminated
+	case p.WorkflowCloseStatusContinuedAsNew:
+		return s.WorkflowExecutionCloseStatusContinuedAsNew
+	case p.WorkflowCloseStatusTimedOut:
+		return s.WorkflowExecutionCloseStatusTimedOut
+	default:
+		return s.WorkflowExecutionCloseStatusCompleted
+	}
+}
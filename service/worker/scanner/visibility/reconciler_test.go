@@ -0,0 +1,201 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package visibility
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	"github.com/uber-go/tally"
+	s "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	es "github.com/uber/cadence/common/elasticsearch"
+	esMocks "github.com/uber/cadence/common/elasticsearch/mocks"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/mocks"
+	p "github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	ReconcilerTestSuite struct {
+		suite.Suite
+		visibilityDB *mocks.VisibilityManager
+		domainDB     *mocks.MetadataManager
+		executionDB  *mocks.ExecutionManager
+		esClient     *esMocks.Client
+		config       *Config
+	}
+)
+
+const (
+	testIndex      = "test-index"
+	testDomainID   = "domain-id"
+	testDomainName = "domain-name"
+)
+
+func TestReconcilerTestSuite(t *testing.T) {
+	suite.Run(t, new(ReconcilerTestSuite))
+}
+
+func (t *ReconcilerTestSuite) SetupTest() {
+	t.visibilityDB = &mocks.VisibilityManager{}
+	t.domainDB = &mocks.MetadataManager{}
+	t.executionDB = &mocks.ExecutionManager{}
+	t.esClient = &esMocks.Client{}
+	t.config = &Config{
+		SampleSize:    dynamicconfig.GetIntPropertyFn(10),
+		MinAge:        dynamicconfig.GetDurationPropertyFn(time.Hour),
+		RepairEnabled: dynamicconfig.GetBoolPropertyFn(true),
+	}
+}
+
+func (t *ReconcilerTestSuite) TearDownTest() {
+	t.visibilityDB.AssertExpectations(t.T())
+	t.domainDB.AssertExpectations(t.T())
+	t.executionDB.AssertExpectations(t.T())
+	t.esClient.AssertExpectations(t.T())
+}
+
+func (t *ReconcilerTestSuite) TestRepairOpenRecordOfClosedExecution() {
+	t.visibilityDB.On("ListOpenWorkflowExecutions", mock.Anything, mock.Anything).Return(&p.ListWorkflowExecutionsResponse{
+		Executions: []*s.WorkflowExecutionInfo{t.newRecord("wid-closed"), t.newRecord("wid-deleted"), t.newRecord("wid-running")},
+	}, nil).Once()
+	t.visibilityDB.On("ListClosedWorkflowExecutions", mock.Anything, mock.Anything).Return(&p.ListWorkflowExecutionsResponse{}, nil).Once()
+	t.mockExecution("wid-closed", p.WorkflowStateCompleted, p.WorkflowCloseStatusTimedOut)
+	t.mockExecution("wid-running", p.WorkflowStateRunning, p.WorkflowCloseStatusNone)
+	t.executionDB.On("GetWorkflowExecution", mock.Anything, mock.MatchedBy(t.matchExecution("wid-deleted"))).
+		Return(nil, &s.EntityNotExistsError{}).Once()
+	t.visibilityDB.On("RecordWorkflowExecutionClosed", mock.Anything, mock.MatchedBy(func(request *p.RecordWorkflowExecutionClosedRequest) bool {
+		return request.DomainUUID == testDomainID &&
+			request.Execution.GetWorkflowId() == "wid-closed" &&
+			request.Status == s.WorkflowExecutionCloseStatusTimedOut &&
+			request.HistoryLength == 9 &&
+			request.RetentionSeconds == 24*60*60
+	})).Return(nil).Once()
+
+	t.newReconciler(nil).processDomain(t.newDomain())
+}
+
+func (t *ReconcilerTestSuite) TestRepairDisabled() {
+	t.config.RepairEnabled = dynamicconfig.GetBoolPropertyFn(false)
+	t.visibilityDB.On("ListOpenWorkflowExecutions", mock.Anything, mock.Anything).Return(&p.ListWorkflowExecutionsResponse{
+		Executions: []*s.WorkflowExecutionInfo{t.newRecord("wid-closed")},
+	}, nil).Once()
+	t.visibilityDB.On("ListClosedWorkflowExecutions", mock.Anything, mock.Anything).Return(&p.ListWorkflowExecutionsResponse{
+		Executions: []*s.WorkflowExecutionInfo{t.newRecord("wid-running")},
+	}, nil).Once()
+	t.mockExecution("wid-closed", p.WorkflowStateCompleted, p.WorkflowCloseStatusCompleted)
+	t.mockExecution("wid-running", p.WorkflowStateRunning, p.WorkflowCloseStatusNone)
+
+	t.newReconciler(nil).processDomain(t.newDomain())
+}
+
+func (t *ReconcilerTestSuite) TestCheckESDocument() {
+	t.config.RepairEnabled = dynamicconfig.GetBoolPropertyFn(false)
+	t.visibilityDB.On("ListOpenWorkflowExecutions", mock.Anything, mock.Anything).Return(&p.ListWorkflowExecutionsResponse{
+		Executions: []*s.WorkflowExecutionInfo{t.newRecord("wid-running")},
+	}, nil).Once()
+	t.visibilityDB.On("ListClosedWorkflowExecutions", mock.Anything, mock.Anything).Return(&p.ListWorkflowExecutionsResponse{}, nil).Once()
+	t.mockExecution("wid-running", p.WorkflowStateRunning, p.WorkflowCloseStatusNone)
+	t.esClient.On("Count", mock.Anything, testIndex, mock.Anything).Return(int64(0), nil).Once()
+
+	t.newReconciler(t.esClient).processDomain(t.newDomain())
+}
+
+func (t *ReconcilerTestSuite) TestListError() {
+	t.visibilityDB.On("ListOpenWorkflowExecutions", mock.Anything, mock.Anything).Return(nil, errors.New("persistence error")).Once()
+	t.newReconciler(nil).processDomain(t.newDomain())
+}
+
+func (t *ReconcilerTestSuite) TestRun() {
+	t.domainDB.On("ListDomains", mock.Anything, mock.Anything).Return(&p.ListDomainsResponse{
+		Domains: []*p.GetDomainResponse{t.newDomain()},
+	}, nil).Once()
+	t.visibilityDB.On("ListOpenWorkflowExecutions", mock.Anything, mock.Anything).Return(&p.ListWorkflowExecutionsResponse{}, nil).Once()
+	t.visibilityDB.On("ListClosedWorkflowExecutions", mock.Anything, mock.Anything).Return(&p.ListWorkflowExecutionsResponse{}, nil).Once()
+
+	reconciler := t.newReconciler(nil)
+	reconciler.Start()
+	timer := time.NewTimer(10 * time.Second)
+	defer timer.Stop()
+	for reconciler.Alive() {
+		select {
+		case <-timer.C:
+			t.Fail("timed out waiting for reconciler to finish")
+			return
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func (t *ReconcilerTestSuite) newReconciler(esClient es.Client) *Reconciler {
+	executionDBs := func(shardID int) (p.ExecutionManager, error) {
+		return t.executionDB, nil
+	}
+	return NewReconciler(t.visibilityDB, t.domainDB, executionDBs, 4, esClient, testIndex, t.config,
+		metrics.NewClient(tally.NoopScope, metrics.Worker), bark.NewLoggerFromLogrus(logrus.New()))
+}
+
+func (t *ReconcilerTestSuite) mockExecution(workflowID string, state int, closeStatus int) {
+	t.executionDB.On("GetWorkflowExecution", mock.Anything, mock.MatchedBy(t.matchExecution(workflowID))).
+		Return(&p.GetWorkflowExecutionResponse{
+			State: &p.WorkflowMutableState{
+				ExecutionInfo: &p.WorkflowExecutionInfo{
+					DomainID:             testDomainID,
+					WorkflowID:           workflowID,
+					State:                state,
+					CloseStatus:          closeStatus,
+					NextEventID:          10,
+					LastUpdatedTimestamp: time.Now(),
+				},
+			},
+		}, nil).Once()
+}
+
+func (t *ReconcilerTestSuite) matchExecution(workflowID string) func(*p.GetWorkflowExecutionRequest) bool {
+	return func(request *p.GetWorkflowExecutionRequest) bool {
+		return request.DomainID == testDomainID && request.Execution.GetWorkflowId() == workflowID
+	}
+}
+
+func (t *ReconcilerTestSuite) newRecord(workflowID string) *s.WorkflowExecutionInfo {
+	return &s.WorkflowExecutionInfo{
+		Execution: &s.WorkflowExecution{
+			WorkflowId: common.StringPtr(workflowID),
+			RunId:      common.StringPtr("run-id"),
+		},
+		Type:      &s.WorkflowType{Name: common.StringPtr("workflow-type")},
+		StartTime: common.Int64Ptr(time.Now().Add(-2 * time.Hour).UnixNano()),
+	}
+}
+
+func (t *ReconcilerTestSuite) newDomain() *p.GetDomainResponse {
+	return &p.GetDomainResponse{
+		Info:   &p.DomainInfo{ID: testDomainID, Name: testDomainName, Status: p.DomainStatusRegistered},
+		Config: &p.DomainConfig{Retention: 1},
+	}
+}
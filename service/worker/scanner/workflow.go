@@ -29,7 +29,10 @@ import (
 	cclient "go.uber.org/cadence/client"
 	"go.uber.org/cadence/workflow"
 
+	"github.com/uber/cadence/service/worker/scanner/esbackfill"
+	"github.com/uber/cadence/service/worker/scanner/esretention"
 	"github.com/uber/cadence/service/worker/scanner/tasklist"
+	"github.com/uber/cadence/service/worker/scanner/visibility"
 )
 
 type contextKey int
@@ -45,6 +48,22 @@ const (
 	tlScannerWFTypeName           = "cadence-sys-tl-scanner-workflow"
 	tlScannerTaskListName         = "cadence-sys-tl-scanner-tasklist-0"
 	taskListScavengerActivityName = "cadence-sys-tl-scanner-scvg-activity"
+
+	esRetentionScannerWFID           = "cadence-sys-es-retention-scanner"
+	esRetentionScannerWFTypeName     = "cadence-sys-es-retention-scanner-workflow"
+	esRetentionScannerTaskListName   = "cadence-sys-es-retention-scanner-tasklist-0"
+	esRetentionScavengerActivityName = "cadence-sys-es-retention-scanner-scvg-activity"
+
+	visibilityReconcilerWFID         = "cadence-sys-visibility-reconciler"
+	visibilityReconcilerWFTypeName   = "cadence-sys-visibility-reconciler-workflow"
+	visibilityReconcilerTaskListName = "cadence-sys-visibility-reconciler-tasklist-0"
+	visibilityReconcilerActivityName = "cadence-sys-visibility-reconciler-activity"
+
+	// ESBackfillWFTypeName is the type of the workflow re-indexing closed executions of a domain into ElasticSearch
+	ESBackfillWFTypeName = "cadence-sys-es-backfill-workflow"
+	// ESBackfillTaskListName is the task list of the ElasticSearch backfill workflow
+	ESBackfillTaskListName = "cadence-sys-es-backfill-tasklist-0"
+	esBackfillActivityName = "cadence-sys-es-backfill-activity"
 )
 
 var (
@@ -63,11 +82,40 @@ var (
 		WorkflowIDReusePolicy:        cclient.WorkflowIDReusePolicyAllowDuplicate,
 		CronSchedule:                 "0 */12 * * *",
 	}
+	esRetentionScannerWFStartOptions = cclient.StartWorkflowOptions{
+		ID:                           esRetentionScannerWFID,
+		TaskList:                     esRetentionScannerTaskListName,
+		ExecutionStartToCloseTimeout: 5 * 24 * time.Hour,
+		WorkflowIDReusePolicy:        cclient.WorkflowIDReusePolicyAllowDuplicate,
+		CronSchedule:                 "0 */12 * * *",
+	}
+	visibilityReconcilerWFStartOptions = cclient.StartWorkflowOptions{
+		ID:                           visibilityReconcilerWFID,
+		TaskList:                     visibilityReconcilerTaskListName,
+		ExecutionStartToCloseTimeout: 5 * 24 * time.Hour,
+		WorkflowIDReusePolicy:        cclient.WorkflowIDReusePolicyAllowDuplicate,
+		CronSchedule:                 "0 */6 * * *",
+	}
+)
+
+type (
+	// scavenger is a daemon doing one full iteration of a scan when started
+	scavenger interface {
+		Start()
+		Stop()
+		Alive() bool
+	}
 )
 
 func init() {
 	workflow.RegisterWithOptions(TaskListScannerWorkflow, workflow.RegisterOptions{Name: tlScannerWFTypeName})
 	activity.RegisterWithOptions(TaskListScavengerActivity, activity.RegisterOptions{Name: taskListScavengerActivityName})
+	workflow.RegisterWithOptions(ESRetentionScannerWorkflow, workflow.RegisterOptions{Name: esRetentionScannerWFTypeName})
+	activity.RegisterWithOptions(ESRetentionScavengerActivity, activity.RegisterOptions{Name: esRetentionScavengerActivityName})
+	workflow.RegisterWithOptions(VisibilityReconcilerWorkflow, workflow.RegisterOptions{Name: visibilityReconcilerWFTypeName})
+	activity.RegisterWithOptions(VisibilityReconcilerActivity, activity.RegisterOptions{Name: visibilityReconcilerActivityName})
+	workflow.RegisterWithOptions(ESBackfillWorkflow, workflow.RegisterOptions{Name: ESBackfillWFTypeName})
+	activity.RegisterWithOptions(ESBackfillActivity, activity.RegisterOptions{Name: esBackfillActivityName})
 }
 
 // TaskListScannerWorkflow is the workflow that runs the task-list scanner background daemon
@@ -87,6 +135,107 @@ func TaskListScavengerActivity(aCtx context.Context) error {
 	ctx := aCtx.Value(scannerContextKey).(scannerContext)
 	scavenger := tasklist.NewScavenger(ctx.taskDB, ctx.metricsClient, ctx.logger)
 	ctx.logger.Info("Starting task list scavenger")
+	return runScavenger(aCtx, ctx, scavenger)
+}
+
+// ESRetentionScannerWorkflow is the workflow that runs the ElasticSearch retention scanner background daemon
+func ESRetentionScannerWorkflow(ctx workflow.Context) error {
+	opts := workflow.ActivityOptions{
+		ScheduleToStartTimeout: 5 * time.Minute,
+		StartToCloseTimeout:    infiniteDuration,
+		HeartbeatTimeout:       5 * time.Minute,
+		RetryPolicy:            &tlScavengerActivityRetryPolicy,
+	}
+	future := workflow.ExecuteActivity(workflow.WithActivityOptions(ctx, opts), esRetentionScavengerActivityName)
+	return future.Get(ctx, nil)
+}
+
+// ESRetentionScavengerActivity is the activity that runs ElasticSearch retention scavenger
+func ESRetentionScavengerActivity(aCtx context.Context) error {
+	ctx := aCtx.Value(scannerContextKey).(scannerContext)
+	if !ctx.cfg.ESRetentionScannerEnabled() {
+		ctx.logger.Info("ElasticSearch retention scavenger is disabled")
+		return nil
+	}
+	config := &esretention.Config{
+		BatchSize:          ctx.cfg.ESRetentionScannerBatchSize,
+		RPS:                ctx.cfg.ESRetentionScannerRPS,
+		TombstoneRetention: ctx.cfg.VisibilityTombstoneRetention,
+	}
+	scavenger := esretention.NewScavenger(ctx.esClient, ctx.esIndex, ctx.domainDB, config, ctx.metricsClient, ctx.logger)
+	ctx.logger.Info("Starting ElasticSearch retention scavenger")
+	return runScavenger(aCtx, ctx, scavenger)
+}
+
+// VisibilityReconcilerWorkflow is the workflow that runs the visibility reconciler background daemon
+func VisibilityReconcilerWorkflow(ctx workflow.Context) error {
+	opts := workflow.ActivityOptions{
+		ScheduleToStartTimeout: 5 * time.Minute,
+		StartToCloseTimeout:    infiniteDuration,
+		HeartbeatTimeout:       5 * time.Minute,
+		RetryPolicy:            &tlScavengerActivityRetryPolicy,
+	}
+	future := workflow.ExecuteActivity(workflow.WithActivityOptions(ctx, opts), visibilityReconcilerActivityName)
+	return future.Get(ctx, nil)
+}
+
+// VisibilityReconcilerActivity is the activity that runs the visibility reconciler
+func VisibilityReconcilerActivity(aCtx context.Context) error {
+	ctx := aCtx.Value(scannerContextKey).(scannerContext)
+	if !ctx.cfg.VisibilityReconcilerEnabled() {
+		ctx.logger.Info("Visibility reconciler is disabled")
+		return nil
+	}
+	config := &visibility.Config{
+		SampleSize:    ctx.cfg.VisibilityReconcilerSampleSize,
+		MinAge:        ctx.cfg.VisibilityReconcilerMinAge,
+		RepairEnabled: ctx.cfg.VisibilityReconcilerRepairEnabled,
+	}
+	reconciler := visibility.NewReconciler(ctx.visibilityDB, ctx.domainDB, ctx.pFactory.NewExecutionManager,
+		ctx.cfg.Persistence.NumHistoryShards, ctx.esClient, ctx.esIndex, config, ctx.metricsClient, ctx.logger)
+	ctx.logger.Info("Starting visibility reconciler")
+	return runScavenger(aCtx, ctx, reconciler)
+}
+
+// ESBackfillWorkflow is the workflow that re-indexes the closed executions of a domain from the
+// visibility store into ElasticSearch. It is not started by the scanner, operators start it on
+// demand through the admin CLI.
+func ESBackfillWorkflow(ctx workflow.Context, params esbackfill.Params) (int64, error) {
+	opts := workflow.ActivityOptions{
+		ScheduleToStartTimeout: 5 * time.Minute,
+		StartToCloseTimeout:    infiniteDuration,
+		HeartbeatTimeout:       5 * time.Minute,
+		RetryPolicy:            &tlScavengerActivityRetryPolicy,
+	}
+	var indexed int64
+	future := workflow.ExecuteActivity(workflow.WithActivityOptions(ctx, opts), esBackfillActivityName, params)
+	err := future.Get(ctx, &indexed)
+	return indexed, err
+}
+
+// ESBackfillActivity is the activity that runs the ElasticSearch backfill, a retried
+// attempt resumes from the progress recorded by the last heartbeat
+func ESBackfillActivity(aCtx context.Context, params esbackfill.Params) (int64, error) {
+	ctx := aCtx.Value(scannerContextKey).(scannerContext)
+	var progress esbackfill.Progress
+	if activity.HasHeartbeatDetails(aCtx) {
+		if err := activity.GetHeartbeatDetails(aCtx, &progress); err != nil {
+			ctx.logger.Errorf("failed to load ElasticSearch backfill progress, restarting: %v", err)
+			progress = esbackfill.Progress{}
+		}
+	}
+	config := &esbackfill.Config{
+		PageSize: ctx.cfg.ESBackfillPageSize,
+		RPS:      ctx.cfg.ESBackfillRPS,
+	}
+	backfiller := esbackfill.NewBackfiller(ctx.esClient, ctx.esIndex, ctx.visibilityDB, config, ctx.metricsClient, ctx.logger)
+	progress, err := backfiller.Run(aCtx, params, progress, func(p esbackfill.Progress) {
+		activity.RecordHeartbeat(aCtx, p)
+	})
+	return progress.IndexedCount, err
+}
+
+func runScavenger(aCtx context.Context, ctx scannerContext, scavenger scavenger) error {
 	scavenger.Start()
 	for scavenger.Alive() {
 		activity.RecordHeartbeat(aCtx)
@@ -30,9 +30,11 @@ import (
 	"github.com/stretchr/testify/suite"
 	"github.com/uber-common/bark"
 	"github.com/uber-go/tally"
+	esMocks "github.com/uber/cadence/common/elasticsearch/mocks"
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/mocks"
 	p "github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/dynamicconfig"
 	"go.uber.org/cadence/testsuite"
 	"go.uber.org/cadence/worker"
 	"go.uber.org/zap"
@@ -73,3 +75,44 @@ func (s *scannerWorkflowTestSuite) TestScavengerActivity() {
 	_, err := env.ExecuteActivity(taskListScavengerActivityName)
 	s.NoError(err)
 }
+
+func (s *scannerWorkflowTestSuite) TestESRetentionWorkflow() {
+	env := s.NewTestWorkflowEnvironment()
+	env.OnActivity(esRetentionScavengerActivityName, mock.Anything).Return(nil)
+	env.ExecuteWorkflow(esRetentionScannerWFTypeName)
+	s.True(env.IsWorkflowCompleted())
+}
+
+func (s *scannerWorkflowTestSuite) TestESRetentionScavengerActivity() {
+	env := s.NewTestActivityEnvironment()
+	domainDB := &mocks.MetadataManager{}
+	domainDB.On("ListDomains", mock.Anything, mock.Anything).Return(&p.ListDomainsResponse{}, nil)
+	ctx := scannerContext{
+		domainDB: domainDB,
+		esClient: &esMocks.Client{},
+		esIndex:  "test-index",
+		cfg: Config{
+			ESRetentionScannerEnabled:   dynamicconfig.GetBoolPropertyFn(true),
+			ESRetentionScannerBatchSize: dynamicconfig.GetIntPropertyFn(100),
+			ESRetentionScannerRPS:       dynamicconfig.GetIntPropertyFn(1),
+		},
+		metricsClient: metrics.NewClient(tally.NoopScope, metrics.Worker),
+		zapLogger:     zap.NewNop(),
+		logger:        bark.NewLoggerFromLogrus(logrus.New()),
+	}
+	env.SetTestTimeout(time.Second * 5)
+	env.SetWorkerOptions(worker.Options{
+		BackgroundActivityContext: context.WithValue(context.Background(), scannerContextKey, ctx),
+	})
+	tlScavengerHBInterval = time.Millisecond * 10
+	_, err := env.ExecuteActivity(esRetentionScavengerActivityName)
+	s.NoError(err)
+	domainDB.AssertExpectations(s.T())
+}
+
+func (s *scannerWorkflowTestSuite) TestVisibilityReconcilerWorkflow() {
+	env := s.NewTestWorkflowEnvironment()
+	env.OnActivity(visibilityReconcilerActivityName, mock.Anything).Return(nil)
+	env.ExecuteWorkflow(visibilityReconcilerWFTypeName)
+	s.True(env.IsWorkflowCompleted())
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/blobstore"
 	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/cluster"
 	"github.com/uber/cadence/common/logging"
 	"github.com/uber/cadence/common/metrics"
 	persistencefactory "github.com/uber/cadence/common/persistence/persistence-factory"
@@ -36,7 +37,11 @@ import (
 	"github.com/uber/cadence/common/service/config"
 	"github.com/uber/cadence/common/service/dynamicconfig"
 	"github.com/uber/cadence/service/worker/archiver"
+	"github.com/uber/cadence/service/worker/canary"
+	"github.com/uber/cadence/service/worker/concurrency"
+	"github.com/uber/cadence/service/worker/counter"
 	"github.com/uber/cadence/service/worker/indexer"
+	"github.com/uber/cadence/service/worker/migrator"
 	"github.com/uber/cadence/service/worker/replicator"
 	"github.com/uber/cadence/service/worker/scanner"
 	"go.uber.org/cadence/.gen/go/cadence/workflowserviceclient"
@@ -48,6 +53,11 @@ type (
 	// 1. Replicator: Handles applying replication tasks generated by remote clusters.
 	// 2. Indexer: Handles uploading of visibility records to elastic search.
 	// 3. Archiver: Handles archival of workflow histories.
+	// 4. OpenWorkflowCounter: Emits the number of open workflows of each domain as gauges.
+	// 5. ArchivalMigrator: Copies archived histories of domains migrating to a new archival bucket.
+	// 6. WorkflowMigrator: Copies workflow executions of domains migrating from a remote cluster.
+	// 7. ConcurrencyGroup: Dispatches the runs of workflows started in a concurrency group.
+	// 8. Canary: Runs the canary workflow suite and the bench load against the frontend.
 	Service struct {
 		stopC         chan struct{}
 		isStopped     int32
@@ -59,11 +69,21 @@ type (
 
 	// Config contains all the service config for worker
 	Config struct {
-		ReplicationCfg  *replicator.Config
-		ArchiverConfig  *archiver.Config
-		IndexerCfg      *indexer.Config
-		ScannerCfg      *scanner.Config
-		ThrottledLogRPS dynamicconfig.IntPropertyFn
+		ReplicationCfg      *replicator.Config
+		ArchiverConfig      *archiver.Config
+		IndexerCfg          *indexer.Config
+		ScannerCfg          *scanner.Config
+		CounterCfg          *counter.Config
+		MigratorCfg         *migrator.Config
+		WorkflowMigratorCfg *migrator.WorkflowMigratorConfig
+		ThrottledLogRPS     dynamicconfig.IntPropertyFn
+
+		ConcurrencyGroupWorkerEnabled dynamicconfig.BoolPropertyFn
+		CanaryCfg                     *canary.Config
+		CanaryDomain                  dynamicconfig.StringPropertyFn
+
+		PersistenceAdaptiveThrottling *config.AdaptiveThrottlingConfig
+		PersistenceDomainMetrics      *config.DomainMetricsConfig
 	}
 )
 
@@ -71,7 +91,7 @@ type (
 func NewService(params *service.BootstrapParams) common.Daemon {
 	params.UpdateLoggerWithServiceName(common.WorkerServiceName)
 	config := NewConfig(params)
-	params.ThrottledBarkLogger = logging.NewThrottledLogger(params.BarkLogger, config.ThrottledLogRPS)
+	params.ThrottledBarkLogger = logging.NewThrottledLoggerWithKeys(params.BarkLogger, config.ThrottledLogRPS, logging.TagDomainID, logging.TagErr)
 	return &Service{
 		params: params,
 		config: config,
@@ -90,6 +110,8 @@ func NewConfig(params *service.BootstrapParams) *Config {
 			ReplicatorMessageConcurrency:      dc.GetIntProperty(dynamicconfig.WorkerReplicatorMessageConcurrency, 2048),
 			ReplicatorHistoryBufferRetryCount: dc.GetIntProperty(dynamicconfig.WorkerReplicatorHistoryBufferRetryCount, 8),
 			ReplicationTaskMaxRetry:           dc.GetIntProperty(dynamicconfig.WorkerReplicationTaskMaxRetry, 400),
+			Enabled:                           dc.GetBoolProperty(dynamicconfig.WorkerReplicatorEnabled, true),
+			LagReportInterval:                 dc.GetDurationProperty(dynamicconfig.WorkerReplicatorLagReportInterval, time.Minute),
 		},
 		ArchiverConfig: &archiver.Config{
 			EnableArchivalCompression:                 dc.GetBoolPropertyFnWithDomainFilter(dynamicconfig.EnableArchivalCompression, true),
@@ -98,20 +120,74 @@ func NewConfig(params *service.BootstrapParams) *Config {
 			ArchiverConcurrency:                       dc.GetIntProperty(dynamicconfig.WorkerArchiverConcurrency, 50),
 			ArchivalsPerIteration:                     dc.GetIntProperty(dynamicconfig.WorkerArchivalsPerIteration, 1000),
 			DeterministicConstructionCheckProbability: dc.GetFloat64Property(dynamicconfig.WorkerDeterministicConstructionCheckProbability, 0.002),
+			UploadRetryInitialInterval:                dc.GetDurationProperty(dynamicconfig.WorkerArchiverUploadRetryInitialInterval, time.Second),
+			UploadRetryMaxInterval:                    dc.GetDurationProperty(dynamicconfig.WorkerArchiverUploadRetryMaxInterval, time.Minute),
+			UploadRetryBackoffCoefficient:             dc.GetFloat64Property(dynamicconfig.WorkerArchiverUploadRetryBackoffCoefficient, 2.0),
+			UploadRetryExpiration:                     dc.GetDurationProperty(dynamicconfig.WorkerArchiverUploadRetryExpiration, 10*time.Minute),
+			BlobstoreRetryInitialInterval:             dc.GetDurationProperty(dynamicconfig.WorkerArchiverBlobstoreRetryInitialInterval, 100*time.Millisecond),
+			BlobstoreRetryMaxInterval:                 dc.GetDurationProperty(dynamicconfig.WorkerArchiverBlobstoreRetryMaxInterval, 10*time.Second),
+			EnableArchivalDLQ:                         dc.GetBoolPropertyFnWithDomainFilter(dynamicconfig.WorkerEnableArchivalDLQ, true),
+			ArchivalDLQMaxSize:                        dc.GetIntProperty(dynamicconfig.WorkerArchivalDLQMaxSize, 10000),
 		},
 		IndexerCfg: &indexer.Config{
-			IndexerConcurrency:       dc.GetIntProperty(dynamicconfig.WorkerIndexerConcurrency, 1000),
-			ESProcessorNumOfWorkers:  dc.GetIntProperty(dynamicconfig.WorkerESProcessorNumOfWorkers, 1),
-			ESProcessorBulkActions:   dc.GetIntProperty(dynamicconfig.WorkerESProcessorBulkActions, 1000),
-			ESProcessorBulkSize:      dc.GetIntProperty(dynamicconfig.WorkerESProcessorBulkSize, 2<<24), // 16MB
-			ESProcessorFlushInterval: dc.GetDurationProperty(dynamicconfig.WorkerESProcessorFlushInterval, 1*time.Second),
+			Enabled:                           dc.GetBoolProperty(dynamicconfig.WorkerIndexerEnabled, true),
+			IndexerConcurrency:                dc.GetIntProperty(dynamicconfig.WorkerIndexerConcurrency, 1000),
+			ESProcessorNumOfWorkers:           dc.GetIntProperty(dynamicconfig.WorkerESProcessorNumOfWorkers, 1),
+			ESProcessorBulkActions:            dc.GetIntProperty(dynamicconfig.WorkerESProcessorBulkActions, 1000),
+			ESProcessorBulkSize:               dc.GetIntProperty(dynamicconfig.WorkerESProcessorBulkSize, 2<<24), // 16MB
+			ESProcessorFlushInterval:          dc.GetDurationProperty(dynamicconfig.WorkerESProcessorFlushInterval, 1*time.Second),
+			ESProcessorMinBulkActions:         dc.GetIntProperty(dynamicconfig.WorkerESProcessorMinBulkActions, 100),
+			ESProcessorMinFlushInterval:       dc.GetDurationProperty(dynamicconfig.WorkerESProcessorMinFlushInterval, 100*time.Millisecond),
+			ESProcessorTargetBulkLatency:      dc.GetDurationProperty(dynamicconfig.WorkerESProcessorTargetBulkLatency, 1*time.Second),
+			ESProcessorMaxOutstandingRequests: dc.GetIntProperty(dynamicconfig.WorkerESProcessorMaxOutstandingRequests, 100000),
+			ESProcessorBackpressureThreshold:  dc.GetIntProperty(dynamicconfig.WorkerESProcessorBackpressureThreshold, 3),
+			ESProcessorRetryInitialInterval:   dc.GetDurationProperty(dynamicconfig.WorkerESProcessorRetryInitialInterval, 200*time.Millisecond),
+			ESProcessorRetryMaxInterval:       dc.GetDurationProperty(dynamicconfig.WorkerESProcessorRetryMaxInterval, 20*time.Second),
 		},
 		ScannerCfg: &scanner.Config{
-			PersistenceMaxQPS: dc.GetIntProperty(dynamicconfig.ScannerPersistenceMaxQPS, 100),
-			Persistence:       &params.PersistenceConfig,
-			ClusterMetadata:   params.ClusterMetadata,
+			PersistenceMaxQPS:                 dc.GetIntProperty(dynamicconfig.ScannerPersistenceMaxQPS, 100),
+			Persistence:                       &params.PersistenceConfig,
+			ClusterMetadata:                   params.ClusterMetadata,
+			ESRetentionScannerEnabled:         dc.GetBoolProperty(dynamicconfig.ESRetentionScannerEnabled, true),
+			ESRetentionScannerBatchSize:       dc.GetIntProperty(dynamicconfig.ESRetentionScannerBatchSize, 1000),
+			ESRetentionScannerRPS:             dc.GetIntProperty(dynamicconfig.ESRetentionScannerRPS, 1),
+			VisibilityTombstoneRetention:      dc.GetDurationPropertyFilteredByDomain(dynamicconfig.VisibilityTombstoneRetention, 7*24*time.Hour),
+			ESBackfillPageSize:                dc.GetIntProperty(dynamicconfig.ESBackfillPageSize, 500),
+			ESBackfillRPS:                     dc.GetIntProperty(dynamicconfig.ESBackfillRPS, 10),
+			VisibilityReconcilerEnabled:       dc.GetBoolProperty(dynamicconfig.VisibilityReconcilerEnabled, true),
+			VisibilityReconcilerSampleSize:    dc.GetIntProperty(dynamicconfig.VisibilityReconcilerSampleSize, 100),
+			VisibilityReconcilerMinAge:        dc.GetDurationProperty(dynamicconfig.VisibilityReconcilerMinAge, time.Hour),
+			VisibilityReconcilerRepairEnabled: dc.GetBoolProperty(dynamicconfig.VisibilityReconcilerRepairEnabled, false),
+		},
+		CounterCfg: &counter.Config{
+			Enabled:  dc.GetBoolProperty(dynamicconfig.OpenWorkflowCounterEnabled, true),
+			Interval: dc.GetDurationProperty(dynamicconfig.OpenWorkflowCounterInterval, time.Minute),
+		},
+		MigratorCfg: &migrator.Config{
+			Enabled:             dc.GetBoolProperty(dynamicconfig.ArchivalMigratorEnabled, true),
+			Interval:            dc.GetDurationProperty(dynamicconfig.ArchivalMigratorInterval, 5*time.Minute),
+			AdminOperationToken: dc.GetStringProperty(dynamicconfig.AdminOperationToken, "CadenceTeamONLY"),
 		},
-		ThrottledLogRPS: dc.GetIntProperty(dynamicconfig.WorkerThrottledLogRPS, 20),
+		WorkflowMigratorCfg: &migrator.WorkflowMigratorConfig{
+			Enabled:       dc.GetBoolProperty(dynamicconfig.WorkflowMigratorEnabled, true),
+			Interval:      dc.GetDurationProperty(dynamicconfig.WorkflowMigratorInterval, 5*time.Minute),
+			SourceCluster: dc.GetStringPropertyFnWithDomainFilter(dynamicconfig.WorkflowMigrationSourceCluster, ""),
+		},
+		ThrottledLogRPS:               dc.GetIntProperty(dynamicconfig.WorkerThrottledLogRPS, 20),
+		ConcurrencyGroupWorkerEnabled: dc.GetBoolProperty(dynamicconfig.ConcurrencyGroupWorkerEnabled, false),
+		CanaryCfg: &canary.Config{
+			CanaryEnabled:           dc.GetBoolProperty(dynamicconfig.CanaryEnabled, false),
+			CanaryInterval:          dc.GetDurationProperty(dynamicconfig.CanaryInterval, time.Minute),
+			BenchEnabled:            dc.GetBoolProperty(dynamicconfig.BenchEnabled, false),
+			BenchStartRPS:           dc.GetIntProperty(dynamicconfig.BenchStartRPS, 10),
+			BenchSignalRPS:          dc.GetIntProperty(dynamicconfig.BenchSignalRPS, 10),
+			BenchActivityCount:      dc.GetIntProperty(dynamicconfig.BenchActivityCount, 3),
+			BenchActivityMinLatency: dc.GetDurationProperty(dynamicconfig.BenchActivityMinLatency, 0),
+			BenchActivityMaxLatency: dc.GetDurationProperty(dynamicconfig.BenchActivityMaxLatency, time.Second),
+		},
+		CanaryDomain:                  dc.GetStringProperty(dynamicconfig.CanaryDomain, common.SystemDomainName),
+		PersistenceAdaptiveThrottling: config.NewAdaptiveThrottlingConfig(dc, dynamicconfig.WorkerEnablePersistenceAdaptiveThrottling),
+		PersistenceDomainMetrics:      config.NewDomainMetricsConfig(dc, dynamicconfig.WorkerEnablePersistenceDomainMetrics),
 	}
 }
 
@@ -123,18 +199,35 @@ func (s *Service) Start() {
 	s.metricsClient = base.GetMetricsClient()
 	s.logger.Infof("%v starting", common.WorkerServiceName)
 
-	pConfig := s.params.PersistenceConfig
-	pConfig.SetMaxQPS(pConfig.DefaultStore, s.config.ReplicationCfg.PersistenceMaxQPS())
-	pFactory := persistencefactory.New(&pConfig, s.params.ClusterMetadata.GetCurrentClusterName(), s.metricsClient, s.logger)
+	pFactory := s.params.PersistenceFactory
+	if pFactory == nil {
+		pConfig := s.params.PersistenceConfig
+		pConfig.SetMaxQPS(pConfig.DefaultStore, s.config.ReplicationCfg.PersistenceMaxQPS())
+		pConfig.AdaptiveThrottlingConfig = s.config.PersistenceAdaptiveThrottling
+		pConfig.DomainMetricsConfig = s.config.PersistenceDomainMetrics
+		pFactory = persistencefactory.New(&pConfig, s.params.ClusterMetadata.GetCurrentClusterName(), s.metricsClient, s.logger)
+	}
 
 	if base.GetClusterMetadata().IsGlobalDomainEnabled() {
 		s.startReplicator(base, pFactory)
+		s.startWorkflowMigrator(base)
 	}
 	if base.GetClusterMetadata().ArchivalConfig().ConfiguredForArchival() {
 		s.startArchiver(base, pFactory)
+		s.startArchivalMigrator(base)
 	}
 	if s.params.ESConfig.Enable {
 		s.startIndexer(base)
+		// open workflows can only be counted with ElasticSearch visibility
+		s.startOpenWorkflowCounter(base)
+	}
+
+	if s.config.ConcurrencyGroupWorkerEnabled() {
+		s.startConcurrencyGroupWorker(base)
+	}
+
+	if s.config.CanaryCfg.CanaryEnabled() || s.config.CanaryCfg.BenchEnabled() {
+		s.startCanary()
 	}
 
 	s.startScanner(base)
@@ -154,11 +247,6 @@ func (s *Service) Stop() {
 }
 
 func (s *Service) startScanner(base service.Service) {
-	storeType := s.config.ScannerCfg.Persistence.DefaultStoreType()
-	if storeType != config.StoreTypeSQL {
-		s.logger.Infof("Scanner not started: incompatible persistence store type %v", storeType)
-		return
-	}
 	params := &scanner.BootstrapParams{
 		Config:        *s.config.ScannerCfg,
 		SDKClient:     s.params.PublicClient,
@@ -166,6 +254,13 @@ func (s *Service) startScanner(base service.Service) {
 		Logger:        s.logger,
 		TallyScope:    s.params.MetricScope,
 	}
+	storeType := s.config.ScannerCfg.Persistence.DefaultStoreType()
+	params.Config.TaskListScannerEnabled = storeType == config.StoreTypeSQL
+	if s.params.ESConfig != nil && s.params.ESConfig.Enable {
+		// documents in ElasticSearch are not deleted by visibility persistence
+		params.ESClient = s.params.ESClient
+		params.ESIndex = s.params.ESConfig.Indices[common.VisibilityAppName]
+	}
 	scanner := scanner.New(params)
 	if err := scanner.Start(); err != nil {
 		s.logger.Fatalf("error starting scanner:%v", err)
@@ -188,11 +283,19 @@ func (s *Service) startReplicator(base service.Service, pFactory persistencefact
 		s.config.ReplicationCfg,
 		base.GetMessagingClient(),
 		s.logger,
-		s.metricsClient)
+		s.metricsClient,
+		s.params.ConsumerLagHook)
 	if err := replicator.Start(); err != nil {
 		replicator.Stop()
 		s.logger.Fatalf("fail to start replicator: %v", err)
 	}
+
+	// remote clusters added at runtime are picked up by the replicator through the cluster metadata
+	clusterMetadataMgr, err := pFactory.NewClusterMetadataManager()
+	if err != nil {
+		s.logger.Fatalf("failed to start replicator, could not create ClusterMetadataManager: %v", err)
+	}
+	cluster.NewMetadataRefresher(base.GetClusterMetadata(), clusterMetadataMgr, s.logger).Start()
 }
 
 func (s *Service) startIndexer(base service.Service) {
@@ -209,6 +312,15 @@ func (s *Service) startIndexer(base service.Service) {
 	}
 }
 
+func (s *Service) startOpenWorkflowCounter(base service.Service) {
+	openWorkflowCounter := counter.NewOpenWorkflowCounter(
+		s.config.CounterCfg,
+		base.GetClientBean().GetFrontendClient(),
+		s.metricsClient,
+		s.logger)
+	openWorkflowCounter.Start()
+}
+
 func (s *Service) startArchiver(base service.Service, pFactory persistencefactory.Factory) {
 	publicClient := s.params.PublicClient
 	s.ensureSystemDomainExists(publicClient)
@@ -228,11 +340,6 @@ func (s *Service) startArchiver(base service.Service, pFactory persistencefactor
 	domainCache := cache.NewDomainCache(metadataMgr, s.params.ClusterMetadata, s.metricsClient, s.logger)
 	domainCache.Start()
 
-	blobstoreClient := blobstore.NewRetryableClient(
-		blobstore.NewMetricClient(s.params.BlobstoreClient, s.metricsClient),
-		s.params.BlobstoreClient.GetRetryPolicy(),
-		s.params.BlobstoreClient.IsRetryableError)
-
 	bc := &archiver.BootstrapContainer{
 		PublicClient:     publicClient,
 		MetricsClient:    s.metricsClient,
@@ -240,7 +347,7 @@ func (s *Service) startArchiver(base service.Service, pFactory persistencefactor
 		ClusterMetadata:  base.GetClusterMetadata(),
 		HistoryManager:   historyManager,
 		HistoryV2Manager: historyV2Manager,
-		Blobstore:        blobstoreClient,
+		Blobstore:        s.newBlobstoreClient(),
 		DomainCache:      domainCache,
 		Config:           s.config.ArchiverConfig,
 	}
@@ -251,6 +358,61 @@ func (s *Service) startArchiver(base service.Service, pFactory persistencefactor
 	}
 }
 
+func (s *Service) startArchivalMigrator(base service.Service) {
+	archivalMigrator := migrator.NewArchivalMigrator(
+		s.config.MigratorCfg,
+		base.GetClientBean().GetFrontendClient(),
+		s.newBlobstoreClient(),
+		s.metricsClient,
+		s.logger)
+	archivalMigrator.Start()
+}
+
+func (s *Service) startWorkflowMigrator(base service.Service) {
+	workflowMigrator := migrator.NewWorkflowMigrator(
+		s.config.WorkflowMigratorCfg,
+		base.GetClusterMetadata(),
+		base.GetClientBean(),
+		s.metricsClient,
+		s.logger)
+	workflowMigrator.Start()
+}
+
+func (s *Service) startConcurrencyGroupWorker(base service.Service) {
+	publicClient := s.params.PublicClient
+	s.ensureSystemDomainExists(publicClient)
+
+	groupWorker := concurrency.NewWorker(&concurrency.BootstrapContainer{
+		PublicClient:  publicClient,
+		HistoryClient: base.GetClientBean().GetHistoryClient(),
+		MetricsClient: s.metricsClient,
+		Logger:        s.logger,
+	})
+	if err := groupWorker.Start(); err != nil {
+		s.logger.WithError(err).Fatal("failed to start concurrency group worker")
+	}
+}
+
+func (s *Service) startCanary() {
+	canary := canary.New(&canary.BootstrapContainer{
+		PublicClient:  s.params.PublicClient,
+		MetricsClient: s.metricsClient,
+		Logger:        s.logger,
+		Domain:        s.config.CanaryDomain(),
+		Config:        s.config.CanaryCfg,
+	})
+	if err := canary.Start(); err != nil {
+		s.logger.WithError(err).Fatal("failed to start canary")
+	}
+}
+
+func (s *Service) newBlobstoreClient() blobstore.Client {
+	return blobstore.NewRetryableClient(
+		blobstore.NewMetricClient(s.params.BlobstoreClient, s.metricsClient),
+		s.params.BlobstoreClient.GetRetryPolicy(),
+		s.params.BlobstoreClient.IsRetryableError)
+}
+
 func (s *Service) ensureSystemDomainExists(publicClient workflowserviceclient.Interface) {
 	request := &shared.DescribeDomainRequest{
 		Name: common.StringPtr(common.SystemDomainName),
@@ -0,0 +1,189 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package bench
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/service/config"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+	"github.com/uber/cadence/service/worker/canary"
+	"github.com/urfave/cli"
+	"go.uber.org/cadence/.gen/go/cadence/workflowserviceclient"
+	"go.uber.org/yarpc"
+	"go.uber.org/yarpc/transport/tchannel"
+)
+
+const (
+	flagAddress            = "address"
+	flagDomain             = "domain"
+	flagDuration           = "duration"
+	flagCanaryInterval     = "canary_interval"
+	flagStartRPS           = "start_rps"
+	flagSignalRPS          = "signal_rps"
+	flagActivityCount      = "activity_count"
+	flagActivityMinLatency = "activity_min_latency"
+	flagActivityMaxLatency = "activity_max_latency"
+	flagStatsdAddress      = "statsd_address"
+	flagStatsdPrefix       = "statsd_prefix"
+
+	benchClientName        = "cadence-bench"
+	cadenceFrontendService = "cadence-frontend"
+)
+
+// RunTool runs the cadence-bench command line tool
+func RunTool(args []string) error {
+	app := buildCLIOptions()
+	return app.Run(args)
+}
+
+func buildCLIOptions() *cli.App {
+	app := cli.NewApp()
+	app.Name = "cadence-bench"
+	app.Usage = "Runs the canary workflow suite and generates load against a cadence frontend"
+	app.Version = "0.0.1"
+
+	app.Flags = []cli.Flag{
+		cli.StringFlag{
+			Name:   flagAddress,
+			Value:  "127.0.0.1:7933",
+			Usage:  "host:port of the cadence frontend",
+			EnvVar: "CADENCE_CLI_ADDRESS",
+		},
+		cli.StringFlag{
+			Name:  flagDomain,
+			Value: common.SystemDomainName,
+			Usage: "domain the canary and bench workflows run in, it must be registered",
+		},
+		cli.DurationFlag{
+			Name:  flagDuration,
+			Usage: "how long to run for, runs until interrupted if not set",
+		},
+		cli.DurationFlag{
+			Name:  flagCanaryInterval,
+			Value: time.Minute,
+			Usage: "interval between two runs of the canary workflow suite, the canary is disabled if zero",
+		},
+		cli.IntFlag{
+			Name:  flagStartRPS,
+			Usage: "rate at which bench workflows are started, the bench load is disabled if zero",
+		},
+		cli.IntFlag{
+			Name:  flagSignalRPS,
+			Usage: "rate at which signals are sent to running bench workflows",
+		},
+		cli.IntFlag{
+			Name:  flagActivityCount,
+			Value: 3,
+			Usage: "number of activities executed by each bench workflow",
+		},
+		cli.DurationFlag{
+			Name:  flagActivityMinLatency,
+			Usage: "minimum time a bench activity takes to complete",
+		},
+		cli.DurationFlag{
+			Name:  flagActivityMaxLatency,
+			Value: time.Second,
+			Usage: "maximum time a bench activity takes to complete, latencies are uniformly distributed",
+		},
+		cli.StringFlag{
+			Name:  flagStatsdAddress,
+			Usage: "host:port of the statsd server metrics are emitted to, no metrics are emitted if not set",
+		},
+		cli.StringFlag{
+			Name:  flagStatsdPrefix,
+			Value: "cadence-bench",
+			Usage: "prefix of the metrics emitted to statsd",
+		},
+	}
+	app.Action = run
+	return app
+}
+
+func run(c *cli.Context) error {
+	logger := bark.NewLoggerFromLogrus(logrus.New())
+
+	ch, err := tchannel.NewChannelTransport(tchannel.ServiceName(benchClientName), tchannel.ListenAddr("127.0.0.1:0"))
+	if err != nil {
+		return fmt.Errorf("failed to create transport channel: %v", err)
+	}
+	dispatcher := yarpc.NewDispatcher(yarpc.Config{
+		Name: benchClientName,
+		Outbounds: yarpc.Outbounds{
+			cadenceFrontendService: {Unary: ch.NewSingleOutbound(c.GlobalString(flagAddress))},
+		},
+	})
+	if err := dispatcher.Start(); err != nil {
+		return fmt.Errorf("failed to start outbound transport channel: %v", err)
+	}
+	defer dispatcher.Stop()
+
+	metricsConfig := &config.Metrics{}
+	if address := c.GlobalString(flagStatsdAddress); address != "" {
+		metricsConfig.Statsd = &config.Statsd{
+			HostPort: address,
+			Prefix:   c.GlobalString(flagStatsdPrefix),
+		}
+	}
+
+	canaryInterval := c.GlobalDuration(flagCanaryInterval)
+	startRPS := c.GlobalInt(flagStartRPS)
+	bench := canary.New(&canary.BootstrapContainer{
+		PublicClient:  workflowserviceclient.New(dispatcher.ClientConfig(cadenceFrontendService)),
+		MetricsClient: metrics.NewClient(metricsConfig.NewScope(), metrics.Worker),
+		Logger:        logger,
+		Domain:        c.GlobalString(flagDomain),
+		Config: &canary.Config{
+			CanaryEnabled:           dynamicconfig.GetBoolPropertyFn(canaryInterval > 0),
+			CanaryInterval:          dynamicconfig.GetDurationPropertyFn(canaryInterval),
+			BenchEnabled:            dynamicconfig.GetBoolPropertyFn(startRPS > 0),
+			BenchStartRPS:           dynamicconfig.GetIntPropertyFn(startRPS),
+			BenchSignalRPS:          dynamicconfig.GetIntPropertyFn(c.GlobalInt(flagSignalRPS)),
+			BenchActivityCount:      dynamicconfig.GetIntPropertyFn(c.GlobalInt(flagActivityCount)),
+			BenchActivityMinLatency: dynamicconfig.GetDurationPropertyFn(c.GlobalDuration(flagActivityMinLatency)),
+			BenchActivityMaxLatency: dynamicconfig.GetDurationPropertyFn(c.GlobalDuration(flagActivityMaxLatency)),
+		},
+	})
+	if err := bench.Start(); err != nil {
+		return fmt.Errorf("failed to start bench: %v", err)
+	}
+	defer bench.Stop()
+
+	stopC := make(chan os.Signal, 1)
+	signal.Notify(stopC, syscall.SIGINT, syscall.SIGTERM)
+	var timeoutC <-chan time.Time
+	if duration := c.GlobalDuration(flagDuration); duration > 0 {
+		timeoutC = time.After(duration)
+	}
+	select {
+	case <-stopC:
+	case <-timeoutC:
+	}
+	return nil
+}
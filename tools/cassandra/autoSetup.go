@@ -0,0 +1,114 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+
+package cassandra
+
+import (
+	"fmt"
+	"log"
+	"path"
+
+	"github.com/uber/cadence/common/service/config"
+)
+
+// autoSetupReplicationFactor is the replication factor used for
+// keyspaces created by AutoSetupSchema, which targets single node
+// development clusters
+const autoSetupReplicationFactor = 1
+
+// AutoSetupSchema creates the cadence and visibility keyspaces, if they
+// don't exist, and brings their schema up to the latest version found
+// under rootPath. It is meant to make a development server work against
+// an empty cassandra and is a no-op for stores that are not cassandra.
+func AutoSetupSchema(cfg config.Persistence, rootPath string) error {
+	ds, ok := cfg.DataStores[cfg.DefaultStore]
+	if ok && ds.Cassandra != nil {
+		schemaPath := path.Join(rootPath, "schema/cassandra/cadence/versioned")
+		if err := autoSetupKeyspace(*ds.Cassandra, ds.Cassandra.Keyspace, schemaPath); err != nil {
+			return err
+		}
+	}
+	ds, ok = cfg.DataStores[cfg.VisibilityStore]
+	if ok && ds.Cassandra != nil {
+		schemaPath := path.Join(rootPath, "schema/cassandra/visibility/versioned")
+		return autoSetupKeyspace(*ds.Cassandra, ds.Cassandra.Keyspace, schemaPath)
+	}
+	return nil
+}
+
+// autoSetupKeyspace creates the keyspace along with the schema version tables
+// when missing and then applies all pending versioned schema updates
+func autoSetupKeyspace(cfg config.Cassandra, keyspace string, schemaDir string) error {
+	base := BaseConfig{
+		CassHosts:    cfg.Hosts,
+		CassPort:     cfg.Port,
+		CassUser:     cfg.User,
+		CassPassword: cfg.Password,
+		CassKeyspace: keyspace,
+		CassTimeout:  defaultTimeout,
+	}
+	if base.CassPort == 0 {
+		base.CassPort = defaultCassandraPort
+	}
+
+	client, err := newCQLClient(base.CassHosts, base.CassPort, base.CassUser, base.CassPassword, "system", base.CassTimeout)
+	if err != nil {
+		return fmt.Errorf("error creating cql client:%v", err)
+	}
+	err = client.CreateKeyspace(keyspace, autoSetupReplicationFactor)
+	client.Close()
+	if err != nil {
+		return fmt.Errorf("error creating keyspace %v:%v", keyspace, err)
+	}
+
+	client, err = newCQLClient(base.CassHosts, base.CassPort, base.CassUser, base.CassPassword, keyspace, base.CassTimeout)
+	if err != nil {
+		return fmt.Errorf("error creating cql client:%v", err)
+	}
+	currVer, err := client.ReadSchemaVersion()
+	client.Close()
+	if err != nil {
+		log.Printf("Keyspace %v has no schema version, setting up schema\n", keyspace)
+		setupConfig := &SetupSchemaConfig{
+			BaseConfig:     base,
+			InitialVersion: "0.0",
+		}
+		if err := handleSetupSchema(setupConfig); err != nil {
+			return err
+		}
+		currVer = "0.0"
+	}
+
+	expectedVer, err := getExpectedVersion(schemaDir)
+	if err != nil {
+		return fmt.Errorf("unable to read expected schema version: %v", err.Error())
+	}
+	if cmpVersion(currVer, expectedVer) >= 0 {
+		return nil
+	}
+
+	updateConfig := &UpdateSchemaConfig{
+		BaseConfig:    base,
+		SchemaDir:     schemaDir,
+		TargetVersion: expectedVer,
+	}
+	return handleUpdateSchema(updateConfig)
+}
@@ -47,6 +47,13 @@ type (
 		IsDryRun      bool
 	}
 
+	// VerifySchemaConfig holds the config
+	// params for executing a VerifySchemaTask
+	VerifySchemaConfig struct {
+		BaseConfig
+		SchemaDir string
+	}
+
 	// SetupSchemaConfig holds the config
 	// params need by the SetupSchemaTask
 	SetupSchemaConfig struct {
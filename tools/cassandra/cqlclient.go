@@ -60,9 +60,18 @@ type (
 		UpdateSchemaVersion(newVersion string, minCompatibleVersion string) error
 		// WriteSchemaUpdateLog adds an entry to the schema update history table
 		WriteSchemaUpdateLog(oldVersion string, newVersion string, manifestMD5 string, desc string) error
+		// ReadSchemaUpdateLog returns all entries of the schema update history table
+		ReadSchemaUpdateLog() ([]SchemaUpdateLogEntry, error)
 		// Close gracefully closes the client object
 		Close()
 	}
+	// SchemaUpdateLogEntry is an entry of the schema update history table
+	SchemaUpdateLogEntry struct {
+		UpdateTime  time.Time
+		OldVersion  string
+		NewVersion  string
+		ManifestMD5 string
+	}
 	cqlClient struct {
 		session       *gocql.Session
 		clusterConfig *gocql.ClusterConfig
@@ -86,6 +95,7 @@ const (
 	listTypesCQL                = `SELECT type_name from system_schema.types where keyspace_name=?`
 	writeSchemaVersionCQL       = `INSERT into schema_version(keyspace_name, creation_time, curr_version, min_compatible_version) VALUES (?,?,?,?)`
 	writeSchemaUpdateHistoryCQL = `INSERT into schema_update_history(year, month, update_time, old_version, new_version, manifest_md5, description) VALUES(?,?,?,?,?,?,?)`
+	readSchemaUpdateHistoryCQL  = `SELECT update_time, old_version, new_version, manifest_md5 from schema_update_history`
 
 	createSchemaVersionTableCQL = `CREATE TABLE schema_version(keyspace_name text PRIMARY KEY, ` +
 		`creation_time timestamp, ` +
@@ -234,6 +244,20 @@ func (client *cqlClient) WriteSchemaUpdateLog(oldVersion string, newVersion stri
 	return query.Exec()
 }
 
+// ReadSchemaUpdateLog returns all entries of the schema update history table
+func (client *cqlClient) ReadSchemaUpdateLog() ([]SchemaUpdateLogEntry, error) {
+	iter := client.session.Query(readSchemaUpdateHistoryCQL).Iter()
+	var entries []SchemaUpdateLogEntry
+	var entry SchemaUpdateLogEntry
+	for iter.Scan(&entry.UpdateTime, &entry.OldVersion, &entry.NewVersion, &entry.ManifestMD5) {
+		entries = append(entries, entry)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
 // Exec executes a cql statement
 func (client *cqlClient) Exec(stmt string) error {
 	return client.session.Query(stmt).Exec()
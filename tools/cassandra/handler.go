@@ -53,6 +53,19 @@ func updateSchema(cli *cli.Context) error {
 	return nil
 }
 
+// verifySchema executes the verifySchemaTask
+// using the given command line args as input
+func verifySchema(cli *cli.Context) error {
+	config, err := newVerifySchemaConfig(cli)
+	if err != nil {
+		return handleErr(newConfigError(err.Error()))
+	}
+	if err := handleVerifySchema(config); err != nil {
+		return handleErr(err)
+	}
+	return nil
+}
+
 // createKeyspace creates a cassandra keyspace
 func createKeyspace(cli *cli.Context) error {
 	config, err := newCreateKeyspaceConfig(cli)
@@ -82,6 +95,17 @@ func handleUpdateSchema(config *UpdateSchemaConfig) error {
 	return nil
 }
 
+func handleVerifySchema(config *VerifySchemaConfig) error {
+	task, err := newVerifySchemaTask(config)
+	if err != nil {
+		return fmt.Errorf("error creating task, err=%v", err)
+	}
+	if err := task.run(); err != nil {
+		return fmt.Errorf("error verifying schema, err=%v", err)
+	}
+	return nil
+}
+
 func handleSetupSchema(config *SetupSchemaConfig) error {
 	task, err := newSetupSchemaTask(config)
 	if err != nil {
@@ -186,6 +210,40 @@ func newUpdateSchemaConfig(cli *cli.Context) (*UpdateSchemaConfig, error) {
 	return config, nil
 }
 
+func validateVerifySchemaConfig(config *VerifySchemaConfig) error {
+	if len(config.CassHosts) == 0 {
+		return newConfigError("missing cassandra endpoint argument " + flag(cliOptEndpoint))
+	}
+	if config.CassPort == 0 {
+		config.CassPort = defaultCassandraPort
+	}
+	if len(config.CassKeyspace) == 0 {
+		return newConfigError("missing " + flag(cliOptKeyspace) + " argument ")
+	}
+	if len(config.SchemaDir) == 0 {
+		return newConfigError("missing " + flag(cliOptSchemaDir) + " argument ")
+	}
+	return nil
+}
+
+func newVerifySchemaConfig(cli *cli.Context) (*VerifySchemaConfig, error) {
+
+	config := new(VerifySchemaConfig)
+	config.CassHosts = cli.GlobalString(cliOptEndpoint)
+	config.CassPort = cli.GlobalInt(cliOptPort)
+	config.CassUser = cli.GlobalString(cliOptUser)
+	config.CassPassword = cli.GlobalString(cliOptPassword)
+	config.CassTimeout = cli.GlobalInt(cliOptTimeout)
+	config.CassKeyspace = cli.GlobalString(cliOptKeyspace)
+	config.SchemaDir = cli.String(cliOptSchemaDir)
+
+	if err := validateVerifySchemaConfig(config); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
 func newCreateKeyspaceConfig(cli *cli.Context) (*CreateKeyspaceConfig, error) {
 	config := new(CreateKeyspaceConfig)
 	config.CassHosts = cli.GlobalString(cliOptEndpoint)
@@ -140,13 +140,27 @@ func buildCLIOptions() *cli.App {
 				},
 				cli.BoolFlag{
 					Name:  cliFlagDryrun,
-					Usage: "do a dryrun",
+					Usage: "print the statements of the update and their rollback statements without executing them",
 				},
 			},
 			Action: func(c *cli.Context) {
 				cliHandler(c, updateSchema)
 			},
 		},
+		{
+			Name:    "verify-schema",
+			Aliases: []string{"verify"},
+			Usage:   "detect drift between cassandra schema and its current version",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  cliFlagSchemaDir,
+					Usage: "path to directory containing versioned schema",
+				},
+			},
+			Action: func(c *cli.Context) {
+				cliHandler(c, verifySchema)
+			},
+		},
 		{
 			Name:    "create-keyspace",
 			Aliases: []string{"create"},
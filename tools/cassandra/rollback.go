@@ -0,0 +1,92 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cassandra
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+)
+
+const (
+	rollbackTableFormat = "DROP TABLE IF EXISTS %v;"
+	rollbackTypeFormat  = "DROP TYPE IF EXISTS %v;"
+	rollbackIndexFormat = "DROP INDEX IF EXISTS %v;"
+	rollbackAlterFormat = "ALTER TABLE %v DROP %v;"
+)
+
+var (
+	createTableRegex   = regexp.MustCompile(`(?i)^CREATE\s+TABLE\s+(?:IF\s+NOT\s+EXISTS\s+)?(\w+)`)
+	createTypeRegex    = regexp.MustCompile(`(?i)^CREATE\s+TYPE\s+(?:IF\s+NOT\s+EXISTS\s+)?(\w+)`)
+	createIndexRegex   = regexp.MustCompile(`(?i)^CREATE\s+(?:CUSTOM\s+)?INDEX\s+(?:IF\s+NOT\s+EXISTS\s+)?(\w+)\s+ON\s`)
+	alterTableAddRegex = regexp.MustCompile(`(?i)^ALTER\s+TABLE\s+(\w+)\s+ADD\s+(\w+)\s`)
+)
+
+// rollbackStmt returns the statement that reverts the given
+// statement, the second return value is false when the statement
+// cannot be reverted safely, e.g. fields cannot be dropped from
+// user defined types and inserted rows may have been updated since
+func rollbackStmt(stmt string) (string, bool) {
+	stmt = strings.TrimSpace(rmspaceRegex.ReplaceAllString(stmt, " "))
+	if m := createTableRegex.FindStringSubmatch(stmt); m != nil {
+		return fmt.Sprintf(rollbackTableFormat, m[1]), true
+	}
+	if m := createTypeRegex.FindStringSubmatch(stmt); m != nil {
+		return fmt.Sprintf(rollbackTypeFormat, m[1]), true
+	}
+	if m := createIndexRegex.FindStringSubmatch(stmt); m != nil {
+		return fmt.Sprintf(rollbackIndexFormat, m[1]), true
+	}
+	if m := alterTableAddRegex.FindStringSubmatch(stmt); m != nil {
+		return fmt.Sprintf(rollbackAlterFormat, m[1], m[2]), true
+	}
+	return "", false
+}
+
+// rollbackStmts returns the statements that revert the given
+// statements in the reverse order of execution, along with the
+// statements which cannot be reverted safely
+func rollbackStmts(stmts []string) (rollback []string, unsafe []string) {
+	for i := len(stmts) - 1; i >= 0; i-- {
+		stmt, ok := rollbackStmt(stmts[i])
+		if !ok {
+			unsafe = append(unsafe, rmspaceRegex.ReplaceAllString(stmts[i], " "))
+			continue
+		}
+		rollback = append(rollback, stmt)
+	}
+	return rollback, unsafe
+}
+
+func printRollbackStmts(ver string, stmts []string) {
+	if len(stmts) == 0 {
+		return
+	}
+	rollback, unsafe := rollbackStmts(stmts)
+	log.Printf("---- Rollback statements for version %v ----\n", ver)
+	for _, stmt := range rollback {
+		log.Println(stmt)
+	}
+	for _, stmt := range unsafe {
+		log.Printf("cannot be rolled back safely: %v\n", stmt)
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cassandra
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type (
+	RollbackTestSuite struct {
+		*require.Assertions // override suite.Suite.Assertions with require.Assertions; this means that s.NotNil(nil) will stop the test, not merely log an error
+		suite.Suite
+	}
+)
+
+func TestRollbackTestSuite(t *testing.T) {
+	suite.Run(t, new(RollbackTestSuite))
+}
+
+func (s *RollbackTestSuite) SetupTest() {
+	s.Assertions = require.New(s.T()) // Have to define our overridden assertions in the test setup. If we did it earlier, s.T() will return nil
+}
+
+func (s *RollbackTestSuite) TestRollbackStmt() {
+	tests := []struct {
+		stmt     string
+		rollback string
+		ok       bool
+	}{
+		{"CREATE TABLE domains (id uuid, PRIMARY KEY (id));", "DROP TABLE IF EXISTS domains;", true},
+		{"CREATE TABLE IF NOT EXISTS domains (id uuid, PRIMARY KEY (id));", "DROP TABLE IF EXISTS domains;", true},
+		{"create  table\n  domains (id uuid, PRIMARY KEY (id));", "DROP TABLE IF EXISTS domains;", true},
+		{"CREATE TYPE serialized_event_batch (encoding_type text, version int, data blob);", "DROP TYPE IF EXISTS serialized_event_batch;", true},
+		{"CREATE INDEX domain_name_idx ON domains (name);", "DROP INDEX IF EXISTS domain_name_idx;", true},
+		{"CREATE CUSTOM INDEX domain_name_idx ON domains (name) USING 'org.apache.cassandra.index.sasi.SASIIndex';", "DROP INDEX IF EXISTS domain_name_idx;", true},
+		{"ALTER TABLE executions ADD shard_id int;", "ALTER TABLE executions DROP shard_id;", true},
+		{"ALTER TYPE workflow_execution ADD last_event_task_id bigint;", "", false},
+		{"ALTER TABLE executions ADD (a int, b int);", "", false},
+		{"ALTER TABLE executions WITH default_time_to_live = 10;", "", false},
+		{"INSERT INTO domains (id) VALUES (uuid());", "", false},
+	}
+
+	for _, tc := range tests {
+		rollback, ok := rollbackStmt(tc.stmt)
+		s.Equal(tc.ok, ok, tc.stmt)
+		s.Equal(tc.rollback, rollback, tc.stmt)
+	}
+}
+
+func (s *RollbackTestSuite) TestRollbackStmts() {
+	stmts := []string{
+		"CREATE TYPE domain_config (retention int);",
+		"CREATE TABLE domains (id uuid, config frozen<domain_config>, PRIMARY KEY (id));",
+		"INSERT INTO domains (id) VALUES (uuid());",
+		"ALTER TABLE domains ADD name text;",
+	}
+
+	rollback, unsafe := rollbackStmts(stmts)
+	s.Equal([]string{
+		"ALTER TABLE domains DROP name;",
+		"DROP TABLE IF EXISTS domains;",
+		"DROP TYPE IF EXISTS domain_config;",
+	}, rollback)
+	s.Equal([]string{"INSERT INTO domains (id) VALUES (uuid());"}, unsafe)
+}
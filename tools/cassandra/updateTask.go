@@ -56,6 +56,7 @@ type (
 		version  string
 		manifest *manifest
 		cqlStmts []string
+		checksum string
 	}
 
 	// byVersion is a comparator type
@@ -65,8 +66,6 @@ type (
 )
 
 const (
-	dryrunKeyspace   = "dryrun_"
-	systemKeyspace   = "system"
 	manifestFileName = "manifest.json"
 )
 
@@ -76,18 +75,8 @@ var (
 
 // NewUpdateSchemaTask returns a new instance of UpdateSchemaTask
 func NewUpdateSchemaTask(config *UpdateSchemaConfig) (*UpdateSchemaTask, error) {
-
-	keyspace := config.CassKeyspace
-	if config.IsDryRun {
-		keyspace = dryrunKeyspace
-		err := setupDryrunKeyspace(config)
-		if err != nil {
-			return nil, fmt.Errorf("error creating dryrun keyspace:%v", err.Error())
-		}
-	}
-
-	client, err := newCQLClient(config.CassHosts, config.CassPort, config.CassUser, config.CassPassword, keyspace,
-		config.CassTimeout)
+	client, err := newCQLClient(config.CassHosts, config.CassPort, config.CassUser, config.CassPassword,
+		config.CassKeyspace, config.CassTimeout)
 	if err != nil {
 		return nil, err
 	}
@@ -104,9 +93,6 @@ func (task *UpdateSchemaTask) run() error {
 	config := task.config
 
 	defer func() {
-		if config.IsDryRun {
-			task.client.DropKeyspace(dryrunKeyspace)
-		}
 		task.client.Close()
 	}()
 
@@ -122,6 +108,12 @@ func (task *UpdateSchemaTask) run() error {
 		return err
 	}
 
+	if config.IsDryRun {
+		printUpdates(currVer, updates)
+		log.Printf("UpdateSchemeTask done, dryrun did not execute any statement\n")
+		return nil
+	}
+
 	err = task.executeUpdates(currVer, updates)
 	if err != nil {
 		return err
@@ -154,10 +146,13 @@ func (task *UpdateSchemaTask) executeUpdates(currVer string, updates []changeSet
 
 func (task *UpdateSchemaTask) execCQLStmts(ver string, stmts []string) error {
 	log.Printf("---- Executing updates for version %v ----\n", ver)
-	for _, stmt := range stmts {
+	for i, stmt := range stmts {
 		log.Println(rmspaceRegex.ReplaceAllString(stmt, " "))
 		e := task.client.Exec(stmt)
 		if e != nil {
+			// the schema version is not updated, print what is needed to undo the partial update
+			// so that the update of this version can be retried from a clean state
+			printRollbackStmts(ver, stmts[:i])
 			return fmt.Errorf("error executing CQL statement:%v", e)
 		}
 	}
@@ -172,7 +167,7 @@ func (task *UpdateSchemaTask) updateSchemaVersion(oldVer string, cs *changeSet)
 		return fmt.Errorf("failed to update schema_version table, err=%v", err.Error())
 	}
 
-	err = task.client.WriteSchemaUpdateLog(oldVer, cs.manifest.CurrVersion, cs.manifest.md5, cs.manifest.Description)
+	err = task.client.WriteSchemaUpdateLog(oldVer, cs.manifest.CurrVersion, cs.checksum, cs.manifest.Description)
 	if err != nil {
 		return fmt.Errorf("failed to add entry to schema_update_history, err=%v", err.Error())
 	}
@@ -181,22 +176,27 @@ func (task *UpdateSchemaTask) updateSchemaVersion(oldVer string, cs *changeSet)
 }
 
 func (task *UpdateSchemaTask) buildChangeSet(currVer string) ([]changeSet, error) {
-
 	config := task.config
+	return loadChangeSets(config.SchemaDir, currVer, config.TargetVersion)
+}
+
+// loadChangeSets reads, validates and returns the changes
+// of all versions in the range (startVer - endVer]
+func loadChangeSets(schemaDir string, startVer string, endVer string) ([]changeSet, error) {
 
-	verDirs, err := readSchemaDir(config.SchemaDir, currVer, config.TargetVersion)
+	verDirs, err := readSchemaDir(schemaDir, startVer, endVer)
 	if err != nil {
 		return nil, fmt.Errorf("error listing schema dir:%v", err.Error())
 	}
 	if len(verDirs) == 0 {
-		return nil, fmt.Errorf("no schema dirs in version range [%v-%v]", currVer, config.TargetVersion)
+		return nil, fmt.Errorf("no schema dirs in version range [%v-%v]", startVer, endVer)
 	}
 
 	var result []changeSet
 
 	for _, vd := range verDirs {
 
-		dirPath := config.SchemaDir + "/" + vd
+		dirPath := schemaDir + "/" + vd
 
 		m, e := readManifest(dirPath)
 		if e != nil {
@@ -218,16 +218,52 @@ func (task *UpdateSchemaTask) buildChangeSet(currVer string) ([]changeSet, error
 			return nil, fmt.Errorf("error processing version %v:%v", vd, e.Error())
 		}
 
+		checksum, e := computeChecksum(dirPath, m)
+		if e != nil {
+			return nil, fmt.Errorf("error computing checksum for version %v:%v", vd, e.Error())
+		}
+
 		cs := changeSet{}
 		cs.manifest = m
 		cs.cqlStmts = stmts
 		cs.version = m.CurrVersion
+		cs.checksum = checksum
 		result = append(result, cs)
 	}
 
 	return result, nil
 }
 
+// computeChecksum returns the md5 checksum of the manifest and
+// the content of all the cql files of a schema version, which
+// is recorded in the schema update history when applying it
+func computeChecksum(dirPath string, m *manifest) (string, error) {
+	hash := md5.New()
+	hash.Write([]byte(m.md5))
+	for _, file := range m.SchemaUpdateCqlFiles {
+		content, err := ioutil.ReadFile(dirPath + "/" + file)
+		if err != nil {
+			return "", err
+		}
+		hash.Write(content)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// printUpdates prints the statements of each change set
+// along with the statements that would revert them
+func printUpdates(currVer string, updates []changeSet) {
+	for _, cs := range updates {
+		log.Printf("---- Updates from version %v to %v ----\n", currVer, cs.version)
+		for _, stmt := range cs.cqlStmts {
+			log.Println(rmspaceRegex.ReplaceAllString(stmt, " "))
+		}
+		printRollbackStmts(cs.version, cs.cqlStmts)
+		currVer = cs.version
+	}
+	log.Printf("---- Done ----\n")
+}
+
 func parseCQLStmts(dir string, manifest *manifest) ([]string, error) {
 
 	result := make([]string, 0, 4)
@@ -355,39 +391,6 @@ func readSchemaDir(dir string, startVer string, endVer string) ([]string, error)
 	return result, nil
 }
 
-// sets up a temporary dryrun keyspace for
-// executing the cassandra schema update
-func setupDryrunKeyspace(config *UpdateSchemaConfig) error {
-	client, err := newCQLClient(config.CassHosts, config.CassPort, config.CassUser, config.CassPassword, systemKeyspace,
-		config.CassTimeout)
-	if err != nil {
-		return err
-	}
-	defer client.Close()
-
-	err = client.CreateKeyspace(dryrunKeyspace, 1)
-	if err != nil {
-		return err
-	}
-
-	setupConfig := &SetupSchemaConfig{
-		BaseConfig: BaseConfig{
-			CassHosts:    config.CassHosts,
-			CassPort:     config.CassPort,
-			CassKeyspace: dryrunKeyspace,
-		},
-		Overwrite:      true,
-		InitialVersion: "0.0",
-	}
-
-	setupTask, err := newSetupSchemaTask(setupConfig)
-	if err != nil {
-		return err
-	}
-
-	return setupTask.run()
-}
-
 func dirToVersion(dir string) string {
 	return dir[1:]
 }
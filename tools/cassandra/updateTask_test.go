@@ -25,6 +25,7 @@ import (
 	"io/ioutil"
 	"math/rand"
 	"os"
+	"sort"
 	"testing"
 	"time"
 
@@ -131,7 +132,81 @@ func (s *UpdateSchemaTestSuite) TestDryrun() {
 	s.Nil(err)
 	// update the version to the latest
 	s.log.Infof("Ver: %v", ver)
-	s.Equal(0, cmpVersion(ver, "0.14"))
+	s.Equal(0, cmpVersion(ver, "0.24"))
+
+	dropAllTablesTypes(client)
+}
+
+func (s *UpdateSchemaTestSuite) TestVerifySchema() {
+
+	client, err := newCQLClient(environment.GetCassandraAddress(), defaultCassandraPort, "", "", s.keyspace, defaultTimeout)
+	s.Nil(err)
+	defer client.Close()
+
+	tmpDir, err := ioutil.TempDir("", "verify_schema_test")
+	s.Nil(err)
+	defer os.RemoveAll(tmpDir)
+
+	s.makeSchemaVersionDirs(tmpDir)
+
+	RunTool([]string{"./tool", "-k", s.keyspace, "-q", "setup-schema", "-v", "0.0"})
+	RunTool([]string{"./tool", "-k", s.keyspace, "-q", "update-schema", "-d", tmpDir, "-v", "1.0"})
+
+	config := &VerifySchemaConfig{
+		BaseConfig: BaseConfig{
+			CassHosts:    environment.GetCassandraAddress(),
+			CassPort:     defaultCassandraPort,
+			CassKeyspace: s.keyspace,
+			CassTimeout:  defaultTimeout,
+		},
+		SchemaDir: tmpDir,
+	}
+	s.Nil(handleVerifySchema(config))
+
+	// a table of the next version exists, the update to it looks partially applied
+	s.Nil(client.Exec("CREATE TABLE domains(id uuid, domain text, config text, PRIMARY KEY (id));"))
+	s.NotNil(handleVerifySchema(config))
+
+	RunTool([]string{"./tool", "-k", s.keyspace, "-q", "update-schema", "-d", tmpDir, "-v", "2.0"})
+	ver, err := client.ReadSchemaVersion()
+	s.Nil(err)
+	s.Equal("1.0", ver)
+
+	s.Nil(client.DropTable("domains"))
+	RunTool([]string{"./tool", "-k", s.keyspace, "-q", "update-schema", "-d", tmpDir, "-v", "2.0"})
+	s.Nil(handleVerifySchema(config))
+
+	// the cql file of an applied version changed after it was applied
+	err = ioutil.WriteFile(tmpDir+"/v2.0/domain.cql", []byte("CREATE TABLE domains(id uuid, PRIMARY KEY (id));"), os.FileMode(0600))
+	s.Nil(err)
+	s.NotNil(handleVerifySchema(config))
+
+	dropAllTablesTypes(client)
+}
+
+func (s *UpdateSchemaTestSuite) TestDryrunDoesNotExecute() {
+
+	client, err := newCQLClient(environment.GetCassandraAddress(), defaultCassandraPort, "", "", s.keyspace, defaultTimeout)
+	s.Nil(err)
+	defer client.Close()
+
+	tmpDir, err := ioutil.TempDir("", "update_schema_test")
+	s.Nil(err)
+	defer os.RemoveAll(tmpDir)
+
+	s.makeSchemaVersionDirs(tmpDir)
+
+	RunTool([]string{"./tool", "-k", s.keyspace, "-q", "setup-schema", "-v", "0.0"})
+	RunTool([]string{"./tool", "-k", s.keyspace, "-q", "update-schema", "-d", tmpDir, "-v", "2.0", "-y"})
+
+	ver, err := client.ReadSchemaVersion()
+	s.Nil(err)
+	s.Equal("0.0", ver)
+
+	tables, err := client.ListTables()
+	s.Nil(err)
+	sort.Strings(tables)
+	s.Equal([]string{schemaUpdateHistoryTableName, schemaVersionTableName}, tables)
 
 	dropAllTablesTypes(client)
 }
@@ -0,0 +1,224 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cassandra
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+)
+
+// VerifySchemaTask represents a task that detects
+// drift between the live schema of a keyspace and
+// the versioned schema it claims to be at
+type VerifySchemaTask struct {
+	client CQLClient
+	config *VerifySchemaConfig
+}
+
+const (
+	schemaVersionTableName       = "schema_version"
+	schemaUpdateHistoryTableName = "schema_update_history"
+	// initialSchemaUpdateVersion is the old version recorded by setup-schema
+	initialSchemaUpdateVersion = "0"
+)
+
+func newVerifySchemaTask(config *VerifySchemaConfig) (*VerifySchemaTask, error) {
+	client, err := newCQLClient(config.CassHosts, config.CassPort, config.CassUser, config.CassPassword,
+		config.CassKeyspace, config.CassTimeout)
+	if err != nil {
+		return nil, err
+	}
+	return &VerifySchemaTask{
+		client: client,
+		config: config,
+	}, nil
+}
+
+// run executes the task
+func (task *VerifySchemaTask) run() error {
+
+	config := task.config
+
+	defer func() {
+		task.client.Close()
+	}()
+
+	log.Printf("VerifySchemaTask started, config=%+v\n", config)
+
+	currVer, err := task.client.ReadSchemaVersion()
+	if err != nil {
+		return fmt.Errorf("error reading current schema version:%v", err.Error())
+	}
+
+	drifts, err := task.detectDrift(currVer)
+	if err != nil {
+		return err
+	}
+	for _, drift := range drifts {
+		log.Println(drift)
+	}
+	if len(drifts) > 0 {
+		return fmt.Errorf("schema drifted from version %v, found %v problems", currVer, len(drifts))
+	}
+
+	log.Printf("VerifySchemaTask done, schema matches version %v\n", currVer)
+
+	return nil
+}
+
+// detectDrift returns a description of every difference found between the live
+// schema and the versioned schema up to currVer. Versions applied by update-schema
+// must have a schema update history entry whose checksum matches the checksum of
+// their schema dir, versions included in the initial setup are only checked for
+// missing tables and types.
+func (task *VerifySchemaTask) detectDrift(currVer string) ([]string, error) {
+
+	config := task.config
+
+	entries, err := task.client.ReadSchemaUpdateLog()
+	if err != nil {
+		return nil, fmt.Errorf("error reading schema update history:%v", err.Error())
+	}
+	initialVer := "0.0"
+	checksums := make(map[string][]string)
+	for _, entry := range entries {
+		if entry.OldVersion == initialSchemaUpdateVersion {
+			if cmpVersion(entry.NewVersion, initialVer) > 0 {
+				initialVer = entry.NewVersion
+			}
+			continue
+		}
+		checksums[entry.NewVersion] = append(checksums[entry.NewVersion], entry.ManifestMD5)
+	}
+
+	var drifts []string
+	var applied []changeSet
+	if cmpVersion(currVer, "0.0") > 0 {
+		applied, err = loadChangeSets(config.SchemaDir, "0.0", currVer)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, cs := range applied {
+		if cmpVersion(cs.version, initialVer) <= 0 {
+			continue
+		}
+		recorded, ok := checksums[cs.version]
+		if !ok {
+			drifts = append(drifts, fmt.Sprintf("version %v has no entry in schema update history", cs.version))
+			continue
+		}
+		// entries written before checksums were introduced hold the md5 of the manifest only
+		if !containsString(recorded, cs.checksum) && !containsString(recorded, cs.manifest.md5) {
+			drifts = append(drifts, fmt.Sprintf("version %v was applied with checksum %v, schema dir has checksum %v",
+				cs.version, strings.Join(recorded, ","), cs.checksum))
+		}
+	}
+
+	latestVer, err := getExpectedVersion(config.SchemaDir)
+	if err != nil {
+		return nil, err
+	}
+	var pending []changeSet
+	if cmpVersion(latestVer, currVer) > 0 {
+		pending, err = loadChangeSets(config.SchemaDir, currVer, latestVer)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tables, err := task.client.ListTables()
+	if err != nil {
+		return nil, fmt.Errorf("error listing tables:%v", err.Error())
+	}
+	types, err := task.client.ListTypes()
+	if err != nil {
+		return nil, fmt.Errorf("error listing types:%v", err.Error())
+	}
+	tables = removeString(removeString(tables, schemaVersionTableName), schemaUpdateHistoryTableName)
+
+	drifts = append(drifts, compareObjects("table", tables, createTableRegex, applied, pending)...)
+	drifts = append(drifts, compareObjects("type", types, createTypeRegex, applied, pending)...)
+	return drifts, nil
+}
+
+// compareObjects compares the live objects of a kind with the objects created
+// by the applied change sets, live objects created by a pending change set
+// indicate a partially applied schema update
+func compareObjects(kind string, live []string, createRegex *regexp.Regexp, applied []changeSet, pending []changeSet) []string {
+	var drifts []string
+	expected := createdObjects(createRegex, applied)
+	next := createdObjects(createRegex, pending)
+	liveSet := make(map[string]struct{}, len(live))
+	for _, name := range live {
+		liveSet[name] = struct{}{}
+		if _, ok := expected[name]; ok {
+			continue
+		}
+		if ver, ok := next[name]; ok {
+			drifts = append(drifts, fmt.Sprintf("%v %v of version %v exists, version %v is partially applied", kind, name, ver, ver))
+			continue
+		}
+		drifts = append(drifts, fmt.Sprintf("%v %v is not part of the versioned schema", kind, name))
+	}
+	for name, ver := range expected {
+		if _, ok := liveSet[name]; !ok {
+			drifts = append(drifts, fmt.Sprintf("%v %v of version %v is missing", kind, name, ver))
+		}
+	}
+	return drifts
+}
+
+// createdObjects returns the name of the objects created by the
+// given change sets, mapped to the version that created them
+func createdObjects(createRegex *regexp.Regexp, changeSets []changeSet) map[string]string {
+	result := make(map[string]string)
+	for _, cs := range changeSets {
+		for _, stmt := range cs.cqlStmts {
+			stmt = strings.TrimSpace(rmspaceRegex.ReplaceAllString(stmt, " "))
+			if m := createRegex.FindStringSubmatch(stmt); m != nil {
+				result[strings.ToLower(m[1])] = cs.version
+			}
+		}
+	}
+	return result
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func removeString(values []string, value string) []string {
+	result := values[:0]
+	for _, v := range values {
+		if v != value {
+			result = append(result, v)
+		}
+	}
+	return result
+}
@@ -201,6 +201,44 @@ func (s *VersionTestSuite) TestVerifyCompatibleVersion() {
 	s.NoError(VerifyCompatibleVersion(cfg, root))
 }
 
+func (s *VersionTestSuite) TestAutoSetupSchema() {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	keyspace := fmt.Sprintf("auto_setup_test_%v", r.Int63())
+	visKeyspace := fmt.Sprintf("auto_setup_visibility_test_%v", r.Int63())
+	_, filename, _, ok := runtime.Caller(0)
+	s.True(ok)
+	root := path.Dir(path.Dir(path.Dir(filename)))
+
+	defaultCfg := config.Cassandra{
+		Hosts:    environment.GetCassandraAddress(),
+		Port:     defaultCassandraPort,
+		Keyspace: keyspace,
+	}
+	visibilityCfg := defaultCfg
+	visibilityCfg.Keyspace = visKeyspace
+	cfg := config.Persistence{
+		DefaultStore:    "default",
+		VisibilityStore: "visibility",
+		DataStores: map[string]config.DataStore{
+			"default":    {Cassandra: &defaultCfg},
+			"visibility": {Cassandra: &visibilityCfg},
+		},
+	}
+
+	client, err := newCQLClient(environment.GetCassandraAddress(), defaultCassandraPort, "", "", "system", defaultTimeout)
+	s.NoError(err)
+	defer client.Close()
+	defer client.DropKeyspace(keyspace)
+	defer client.DropKeyspace(visKeyspace)
+
+	// keyspaces don't exist yet
+	s.NoError(AutoSetupSchema(cfg, root))
+	s.NoError(VerifyCompatibleVersion(cfg, root))
+	// already set up keyspaces are left untouched
+	s.NoError(AutoSetupSchema(cfg, root))
+	s.NoError(VerifyCompatibleVersion(cfg, root))
+}
+
 func (s *VersionTestSuite) TestCheckCompatibleVersion() {
 	flags := []struct {
 		expectedVersion string
@@ -106,6 +106,31 @@ func newAdminWorkflowCommands() []cli.Command {
 				AdminDescribeWorkflow(c)
 			},
 		},
+		{
+			Name:  "diff",
+			Usage: "Compare the history of a run between two clusters, or against an exported history bundle, and report where they diverge",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagWorkflowIDWithAlias,
+					Usage: "WorkflowID",
+				},
+				cli.StringFlag{
+					Name:  FlagRunIDWithAlias,
+					Usage: "RunID",
+				},
+				cli.StringFlag{
+					Name:  FlagRemoteAddressWithAlias,
+					Usage: "host:port of the frontend of the other cluster",
+				},
+				cli.StringFlag{
+					Name:  FlagInputFileWithAlias,
+					Usage: "history bundle written by `workflow export --binary`, e.g. of an archived run",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminDiffWorkflowHistory(c)
+			},
+		},
 		{
 			Name:    "delete",
 			Aliases: []string{"del"},
@@ -203,6 +228,116 @@ func newAdminHistoryHostCommands() []cli.Command {
 	}
 }
 
+func newAdminShardCommands() []cli.Command {
+	return []cli.Command{
+		{
+			Name:    "migrate",
+			Aliases: []string{"mig"},
+			Usage:   "Offline copy of executions into a keyspace with a different number of history shards, cluster must be stopped",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagAddress,
+					Usage: "cassandra host address",
+				},
+				cli.IntFlag{
+					Name:  FlagPort,
+					Value: 9042,
+					Usage: "cassandra port for the host (default is 9042)",
+				},
+				cli.StringFlag{
+					Name:  FlagUsername,
+					Usage: "cassandra username",
+				},
+				cli.StringFlag{
+					Name:  FlagPassword,
+					Usage: "cassandra password",
+				},
+				cli.StringFlag{
+					Name:  FlagKeyspace,
+					Usage: "cassandra keyspace to read executions from",
+				},
+				cli.StringFlag{
+					Name:  FlagTargetKeyspace,
+					Usage: "cassandra keyspace to write executions to, schema must already be set up",
+				},
+				cli.IntFlag{
+					Name:  FlagNumberOfShards,
+					Usage: "current NumberOfShards for the cadence cluster(see config for numHistoryShards)",
+				},
+				cli.IntFlag{
+					Name:  FlagTargetNumberOfShards,
+					Usage: "new NumberOfShards for the cadence cluster",
+				},
+				cli.IntFlag{
+					Name:  FlagBatchSizeWithAlias,
+					Value: 1000,
+					Usage: "page size used when reading executions",
+				},
+				cli.BoolFlag{
+					Name:  FlagDryRun,
+					Usage: "only print the distribution of executions over the new shards",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminMigrateShards(c)
+			},
+		},
+	}
+}
+
+func newAdminVisibilityCommands() []cli.Command {
+	return []cli.Command{
+		{
+			Name:    "backfill_ttl",
+			Aliases: []string{"bttl"},
+			Usage:   "Set TTL on cassandra visibility records of a domain which were written without one",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagAddress,
+					Usage: "cassandra host address",
+				},
+				cli.IntFlag{
+					Name:  FlagPort,
+					Value: 9042,
+					Usage: "cassandra port for the host (default is 9042)",
+				},
+				cli.StringFlag{
+					Name:  FlagUsername,
+					Usage: "cassandra username",
+				},
+				cli.StringFlag{
+					Name:  FlagPassword,
+					Usage: "cassandra password",
+				},
+				cli.StringFlag{
+					Name:  FlagKeyspace,
+					Usage: "cassandra visibility keyspace",
+				},
+				cli.StringFlag{
+					Name:  FlagDomainID,
+					Usage: "DomainID",
+				},
+				cli.IntFlag{
+					Name:  FlagRetentionDaysWithAlias,
+					Usage: "retention in days, default is the retention of the domain",
+				},
+				cli.IntFlag{
+					Name:  FlagBatchSizeWithAlias,
+					Value: 1000,
+					Usage: "page size used when reading visibility records",
+				},
+				cli.BoolFlag{
+					Name:  FlagDryRun,
+					Usage: "only count the records without TTL",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminBackfillVisibilityTTL(c)
+			},
+		},
+	}
+}
+
 func newAdminDomainCommands() []cli.Command {
 	return []cli.Command{
 		{
@@ -245,6 +380,59 @@ func newAdminDomainCommands() []cli.Command {
 				AdminGetDomainIDOrName(c)
 			},
 		},
+		{
+			Name:    "dump",
+			Aliases: []string{"dp"},
+			Usage:   "Export the metadata of all domains into a file",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagOutputFilenameWithAlias,
+					Usage: "File the domains are dumped into",
+				},
+				cli.IntFlag{
+					Name:  FlagPageSizeWithAlias,
+					Value: defaultDomainDumpPageSize,
+					Usage: "Page size used when listing domains",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminDumpDomains(c)
+			},
+		},
+		{
+			Name:    "restore",
+			Aliases: []string{"rs"},
+			Usage:   "Register the domains of a dump file, updating the ones that already exist",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagInputFileWithAlias,
+					Usage: "File generated by the dump command",
+				},
+				cli.StringFlag{
+					Name:  FlagSecurityTokenWithAlias,
+					Usage: "Optional token for security check",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminRestoreDomains(c)
+			},
+		},
+		{
+			Name:    "archival_dlq",
+			Aliases: []string{"adlq"},
+			Usage:   "Show the archival requests of the domain whose histories failed to be archived",
+			Action: func(c *cli.Context) {
+				AdminDescribeArchivalDLQ(c)
+			},
+		},
+		{
+			Name:    "redrive_archival_dlq",
+			Aliases: []string{"radlq"},
+			Usage:   "Archive the histories in the archival DLQ of the domain again, the requests which fail again stay in the DLQ",
+			Action: func(c *cli.Context) {
+				AdminRedriveArchivalDLQ(c)
+			},
+		},
 	}
 }
 
@@ -505,6 +693,176 @@ func newAdminElasticSearchCommands() []cli.Command {
 				AdminIndex(c)
 			},
 		},
+		{
+			Name:    "createIndex",
+			Aliases: []string{"ci"},
+			Usage:   "Put the visibility index template and create the visibility index if it does not exist",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagURL,
+					Usage: "URL of ElasticSearch cluster",
+				},
+				cli.StringFlag{
+					Name:  FlagMuttleyDestinationWithAlias,
+					Usage: "Optional muttely destination to ElasticSearch cluster",
+				},
+				cli.StringFlag{
+					Name:  FlagIndex,
+					Usage: "ElasticSearch target index",
+				},
+				cli.StringFlag{
+					Name:  FlagTemplateFile,
+					Value: defaultVisibilityTemplateFile,
+					Usage: "Index template file in json format",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminCreateIndex(c)
+			},
+		},
+		{
+			Name:    "updateMapping",
+			Aliases: []string{"um"},
+			Usage:   "Add a field to the mapping of the visibility index and template, to make a new search attribute indexable",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagURL,
+					Usage: "URL of ElasticSearch cluster",
+				},
+				cli.StringFlag{
+					Name:  FlagMuttleyDestinationWithAlias,
+					Usage: "Optional muttely destination to ElasticSearch cluster",
+				},
+				cli.StringFlag{
+					Name:  FlagIndex,
+					Usage: "ElasticSearch target index",
+				},
+				cli.StringFlag{
+					Name:  FlagNameWithAlias,
+					Usage: "Name of the field",
+				},
+				cli.StringFlag{
+					Name:  FlagFieldType,
+					Usage: "ElasticSearch type of the field [keyword|long|integer|double|boolean|date]",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminUpdateMapping(c)
+			},
+		},
+		{
+			Name:    "health",
+			Aliases: []string{"hl"},
+			Usage:   "Show the health of the visibility index and its document count per domain",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagURL,
+					Usage: "URL of ElasticSearch cluster",
+				},
+				cli.StringFlag{
+					Name:  FlagMuttleyDestinationWithAlias,
+					Usage: "Optional muttely destination to ElasticSearch cluster",
+				},
+				cli.StringFlag{
+					Name:  FlagIndex,
+					Usage: "ElasticSearch target index",
+				},
+				cli.IntFlag{
+					Name:  FlagPageSizeWithAlias,
+					Value: 100,
+					Usage: "Maximum number of domains listed, domains with the most documents first",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminIndexHealth(c)
+			},
+		},
+		{
+			Name:    "retention",
+			Aliases: []string{"rt"},
+			Usage:   "Delete the documents of closed executions of a domain which are older than the retention",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagURL,
+					Usage: "URL of ElasticSearch cluster",
+				},
+				cli.StringFlag{
+					Name:  FlagMuttleyDestinationWithAlias,
+					Usage: "Optional muttely destination to ElasticSearch cluster",
+				},
+				cli.StringFlag{
+					Name:  FlagIndex,
+					Usage: "ElasticSearch target index",
+				},
+				cli.StringFlag{
+					Name:  FlagDomainID,
+					Usage: "DomainID",
+				},
+				cli.IntFlag{
+					Name:  FlagRetentionDaysWithAlias,
+					Usage: "retention in days, default is the retention of the domain",
+				},
+				cli.BoolFlag{
+					Name:  FlagDryRun,
+					Usage: "only count the expired documents",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminIndexRetention(c)
+			},
+		},
+		{
+			Name:    "backfill",
+			Aliases: []string{"bf"},
+			Usage:   "Start a system workflow which re-indexes the closed executions of a domain from the visibility store into ElasticSearch",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagDomainID,
+					Usage: "DomainID",
+				},
+				cli.StringFlag{
+					Name:  FlagEarliestTimeWithAlias,
+					Usage: "EarliestTime of start time, supported formats are '2006-01-02T15:04:05Z07:00' and raw UnixNano",
+				},
+				cli.StringFlag{
+					Name:  FlagLatestTimeWithAlias,
+					Usage: "LatestTime of start time, supported formats are '2006-01-02T15:04:05Z07:00' and raw UnixNano",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminBackfillElasticSearch(c)
+			},
+		},
+		{
+			Name:    "backfill-execution-time",
+			Aliases: []string{"bfet"},
+			Usage:   "Set the execution time of documents written without one to their start time",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagURL,
+					Usage: "URL of ElasticSearch cluster",
+				},
+				cli.StringFlag{
+					Name:  FlagMuttleyDestinationWithAlias,
+					Usage: "Optional muttely destination to ElasticSearch cluster",
+				},
+				cli.StringFlag{
+					Name:  FlagIndex,
+					Usage: "ElasticSearch target index",
+				},
+				cli.StringFlag{
+					Name:  FlagDomainID,
+					Usage: "Optional DomainID, default is all domains",
+				},
+				cli.BoolFlag{
+					Name:  FlagDryRun,
+					Usage: "only count the documents without execution time",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminBackfillExecutionTime(c)
+			},
+		},
 	}
 }
 
@@ -531,3 +889,131 @@ func newAdminTaskListCommands() []cli.Command {
 		},
 	}
 }
+
+func newAdminClusterCommands() []cli.Command {
+	return []cli.Command{
+		{
+			Name:  "add_remote",
+			Usage: "Add a remote cluster to the cluster metadata without redeploying the hosts",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagCluster,
+					Usage: "Name of the remote cluster",
+				},
+				cli.Int64Flag{
+					Name:  FlagInitialFailoverVersion,
+					Usage: "Initial failover version of the remote cluster",
+				},
+				cli.StringFlag{
+					Name:  FlagRPCName,
+					Usage: "RPC service name of the remote cluster frontend",
+				},
+				cli.StringFlag{
+					Name:  FlagRPCAddress,
+					Usage: "RPC address of the remote cluster frontend",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminAddRemoteCluster(c)
+			},
+		},
+		{
+			Name:    "add_search_attr",
+			Aliases: []string{"asa"},
+			Usage:   "Add a search attribute key to the ElasticSearch mapping and the valid search attributes",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagSearchAttributeKey,
+					Usage: "Search attribute key",
+				},
+				cli.StringFlag{
+					Name:  FlagSearchAttributeType,
+					Usage: "Search attribute value type [string|keyword|int|double|bool|datetime]",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminAddSearchAttribute(c)
+			},
+		},
+		{
+			Name:    "replication_tasks",
+			Aliases: []string{"rt"},
+			Usage:   "Read the replication tasks of a range of shards which are not replicated yet",
+			Flags: []cli.Flag{
+				cli.IntFlag{
+					Name:  FlagMinShardID,
+					Usage: "First shard to read, defaults to the first shard of the cluster",
+				},
+				cli.IntFlag{
+					Name:  FlagMaxShardID,
+					Usage: "Last shard to read, defaults to the last shard of the cluster",
+				},
+				cli.Int64Flag{
+					Name:  FlagMinTaskID,
+					Usage: "Lowest task ID to read",
+				},
+				cli.Int64Flag{
+					Name:  FlagMaxTaskID,
+					Usage: "Highest task ID to read",
+				},
+				cli.IntFlag{
+					Name:  FlagPageSizeWithAlias,
+					Value: 100,
+					Usage: "Number of replication tasks fetched per request",
+				},
+				cli.StringFlag{
+					Name:  FlagOutputFilenameWithAlias,
+					Usage: "Output file to write the replication tasks to as JSON lines, stdout by default",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminGetReplicationTasks(c)
+			},
+		},
+		{
+			Name:    "dlq_replication_tasks",
+			Aliases: []string{"dlq"},
+			Usage:   "Read the replication tasks this cluster failed to apply from its DLQ topic, the output can be fed to 'admin kafka mergeDLQ'",
+			Flags: []cli.Flag{
+				cli.IntFlag{
+					Name:  FlagMinShardID,
+					Usage: "First shard to read, defaults to the first shard of the cluster",
+				},
+				cli.IntFlag{
+					Name:  FlagMaxShardID,
+					Usage: "Last shard to read, defaults to the last shard of the cluster",
+				},
+				cli.IntFlag{
+					Name:  FlagPageSizeWithAlias,
+					Value: 100,
+					Usage: "Number of replication tasks fetched per request",
+				},
+				cli.StringFlag{
+					Name:  FlagOutputFilenameWithAlias,
+					Usage: "Output file to write the replication tasks to as JSON lines, stdout by default",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminGetDLQReplicationTasks(c)
+			},
+		},
+		{
+			Name:    "replication_lag",
+			Aliases: []string{"lag"},
+			Usage:   "Show how far the replicator of this cluster is behind on the replication topics of the remote clusters",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagSourceCluster,
+					Usage: "Remote cluster to show the lag for, all remote clusters by default",
+				},
+				cli.IntFlag{
+					Name:  FlagSampleInterval,
+					Usage: "Seconds between the two samples the rates are computed from, between 1 and 5, 2 by default",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminGetReplicationConsumerLag(c)
+			},
+		},
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/uber/cadence/.gen/go/admin"
+	"github.com/uber/cadence/.gen/go/replicator"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/urfave/cli"
+)
+
+// AdminAddRemoteCluster adds a remote cluster to the persisted cluster metadata
+func AdminAddRemoteCluster(c *cli.Context) {
+	adminClient := cFactory.ServerAdminClient(c)
+	clusterName := getRequiredOption(c, FlagCluster)
+	rpcName := getRequiredOption(c, FlagRPCName)
+	rpcAddress := getRequiredOption(c, FlagRPCAddress)
+	if !c.IsSet(FlagInitialFailoverVersion) {
+		ErrorAndExit(fmt.Sprintf("Option %s is required", FlagInitialFailoverVersion), nil)
+	}
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+	request := &admin.AddRemoteClusterRequest{
+		ClusterName:            common.StringPtr(clusterName),
+		InitialFailoverVersion: common.Int64Ptr(c.Int64(FlagInitialFailoverVersion)),
+		RpcName:                common.StringPtr(rpcName),
+		RpcAddress:             common.StringPtr(rpcAddress),
+	}
+
+	if _, err := adminClient.AddRemoteCluster(ctx, request); err != nil {
+		ErrorAndExit("Operation AddRemoteCluster failed.", err)
+	}
+	fmt.Printf("Remote cluster %v is added.\n", clusterName)
+}
+
+// AdminAddSearchAttribute adds a search attribute key to the ones visibility queries can use
+func AdminAddSearchAttribute(c *cli.Context) {
+	adminClient := cFactory.ServerAdminClient(c)
+	key := getRequiredOption(c, FlagSearchAttributeKey)
+	var valueType shared.IndexedValueType
+	if err := valueType.UnmarshalText([]byte(strings.ToUpper(getRequiredOption(c, FlagSearchAttributeType)))); err != nil {
+		ErrorAndExit(fmt.Sprintf("Option %s is invalid", FlagSearchAttributeType), err)
+	}
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+	request := &admin.AddSearchAttributeRequest{
+		SearchAttribute: map[string]shared.IndexedValueType{key: valueType},
+	}
+
+	if _, err := adminClient.AddSearchAttribute(ctx, request); err != nil {
+		ErrorAndExit("Operation AddSearchAttribute failed.", err)
+	}
+	fmt.Printf("Search attribute %v of type %v is added.\n", key, valueType)
+}
+
+// AdminGetReplicationTasks writes the replication tasks of a range of shards to the output file
+func AdminGetReplicationTasks(c *cli.Context) {
+	adminClient := cFactory.ServerAdminClient(c)
+	outputFile := getOutputFile(c.String(FlagOutputFilename))
+	defer outputFile.Close()
+
+	request := &admin.GetReplicationTasksRequest{
+		MaximumPageSize: common.Int32Ptr(int32(c.Int(FlagPageSize))),
+	}
+	if c.IsSet(FlagMinShardID) {
+		request.MinShardId = common.Int32Ptr(int32(c.Int(FlagMinShardID)))
+	}
+	if c.IsSet(FlagMaxShardID) {
+		request.MaxShardId = common.Int32Ptr(int32(c.Int(FlagMaxShardID)))
+	}
+	if c.IsSet(FlagMinTaskID) {
+		request.MinTaskId = common.Int64Ptr(c.Int64(FlagMinTaskID))
+	}
+	if c.IsSet(FlagMaxTaskID) {
+		request.MaxTaskId = common.Int64Ptr(c.Int64(FlagMaxTaskID))
+	}
+
+	for {
+		ctx, cancel := newContext(c)
+		response, err := adminClient.GetReplicationTasks(ctx, request)
+		cancel()
+		if err != nil {
+			ErrorAndExit("Operation GetReplicationTasks failed.", err)
+		}
+		writeReplicationTasks(outputFile, response.Tasks)
+		if len(response.NextPageToken) == 0 {
+			return
+		}
+		request.NextPageToken = response.NextPageToken
+	}
+}
+
+// AdminGetDLQReplicationTasks writes the replication tasks of the DLQ topic of the cluster to the output file
+func AdminGetDLQReplicationTasks(c *cli.Context) {
+	adminClient := cFactory.ServerAdminClient(c)
+	outputFile := getOutputFile(c.String(FlagOutputFilename))
+	defer outputFile.Close()
+
+	request := &admin.GetDLQReplicationTasksRequest{
+		MaximumPageSize: common.Int32Ptr(int32(c.Int(FlagPageSize))),
+	}
+	if c.IsSet(FlagMinShardID) {
+		request.MinShardId = common.Int32Ptr(int32(c.Int(FlagMinShardID)))
+	}
+	if c.IsSet(FlagMaxShardID) {
+		request.MaxShardId = common.Int32Ptr(int32(c.Int(FlagMaxShardID)))
+	}
+
+	for {
+		ctx, cancel := newContext(c)
+		response, err := adminClient.GetDLQReplicationTasks(ctx, request)
+		cancel()
+		if err != nil {
+			ErrorAndExit("Operation GetDLQReplicationTasks failed.", err)
+		}
+		writeReplicationTasks(outputFile, response.Tasks)
+		if len(response.NextPageToken) == 0 {
+			return
+		}
+		request.NextPageToken = response.NextPageToken
+	}
+}
+
+// AdminGetReplicationConsumerLag prints the lag of the replicator consumers of this cluster
+func AdminGetReplicationConsumerLag(c *cli.Context) {
+	adminClient := cFactory.ServerAdminClient(c)
+
+	request := &admin.GetReplicationConsumerLagRequest{}
+	if c.IsSet(FlagSourceCluster) {
+		request.SourceCluster = common.StringPtr(c.String(FlagSourceCluster))
+	}
+	if c.IsSet(FlagSampleInterval) {
+		request.SampleIntervalInSeconds = common.Int32Ptr(int32(c.Int(FlagSampleInterval)))
+	}
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+	response, err := adminClient.GetReplicationConsumerLag(ctx, request)
+	if err != nil {
+		ErrorAndExit("Operation GetReplicationConsumerLag failed.", err)
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetBorder(false)
+	table.SetColumnSeparator("|")
+	table.SetHeader([]string{"Source Cluster", "Topic", "Partition", "Committed Offset", "High Watermark", "Lag", "Processing Rate", "Produce Rate"})
+	table.SetHeaderLine(false)
+	table.SetHeaderColor(tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue)
+	for _, consumer := range response.Consumers {
+		for _, partition := range consumer.Partitions {
+			table.Append([]string{consumer.GetSourceCluster(), consumer.GetTopic(),
+				strconv.Itoa(int(partition.GetPartition())),
+				strconv.FormatInt(partition.GetCommittedOffset(), 10),
+				strconv.FormatInt(partition.GetHighWatermark(), 10),
+				strconv.FormatInt(partition.GetLag(), 10), "", ""})
+		}
+		table.Append([]string{consumer.GetSourceCluster(), consumer.GetTopic(), "total", "", "",
+			strconv.FormatInt(consumer.GetLag(), 10),
+			strconv.FormatFloat(consumer.GetProcessingRate(), 'f', 2, 64),
+			strconv.FormatFloat(consumer.GetProduceRate(), 'f', 2, 64)})
+	}
+	table.Render()
+}
+
+// writeReplicationTasks writes one replication task per line, the format read by 'admin kafka mergeDLQ'
+func writeReplicationTasks(outputFile *os.File, tasks []*replicator.ReplicationTask) {
+	for _, task := range tasks {
+		data, err := json.Marshal(task)
+		if err != nil {
+			ErrorAndExit("Failed to encode replication task.", err)
+		}
+		if _, err := fmt.Fprintln(outputFile, string(data)); err != nil {
+			ErrorAndExit("Failed to write replication task.", err)
+		}
+	}
+}
@@ -21,6 +21,7 @@
 package cli
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
@@ -241,7 +242,7 @@ func AdminDeleteWorkflow(c *cli.Context) {
 		RunID:      rid,
 	}
 
-	err = exeStore.DeleteWorkflowExecution(req)
+	err = exeStore.DeleteWorkflowExecution(context.TODO(), req)
 	if err != nil {
 		if skipError {
 			fmt.Println("delete mutableState row failed, ", err)
@@ -269,6 +270,10 @@ func readOneRow(query *gocql.Query) (map[string]interface{}, error) {
 }
 
 func connectToCassandra(c *cli.Context) *gocql.Session {
+	return connectToCassandraKeyspace(c, getRequiredOption(c, FlagKeyspace))
+}
+
+func connectToCassandraKeyspace(c *cli.Context, ksp string) *gocql.Session {
 	host := getRequiredOption(c, FlagAddress)
 	if !c.IsSet(FlagPort) {
 		ErrorAndExit("port is required", nil)
@@ -276,7 +281,6 @@ func connectToCassandra(c *cli.Context) *gocql.Session {
 	port := c.Int(FlagPort)
 	user := c.String(FlagUsername)
 	pw := c.String(FlagPassword)
-	ksp := getRequiredOption(c, FlagKeyspace)
 
 	clusterCfg, err := cassandra.NewCassandraCluster(host, port, user, pw, ksp, 10)
 	clusterCfg.SerialConsistency = gocql.LocalSerial
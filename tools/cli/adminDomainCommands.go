@@ -0,0 +1,241 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/service/worker/archiver"
+	"github.com/urfave/cli"
+)
+
+const defaultDomainDumpPageSize = 100
+
+// AdminDumpDomains exports the metadata of all domains into a file
+func AdminDumpDomains(c *cli.Context) {
+	outputFile := getRequiredOption(c, FlagOutputFilename)
+	pageSize := c.Int(FlagPageSize)
+	if pageSize <= 0 {
+		pageSize = defaultDomainDumpPageSize
+	}
+
+	frontendClient := cFactory.ServerFrontendClient(c)
+	domains := []*shared.DescribeDomainResponse{}
+	var nextPageToken []byte
+	for {
+		ctx, cancel := newContext(c)
+		resp, err := frontendClient.ListDomains(ctx, &shared.ListDomainsRequest{
+			PageSize:      common.Int32Ptr(int32(pageSize)),
+			NextPageToken: nextPageToken,
+		})
+		cancel()
+		if err != nil {
+			ErrorAndExit("Failed to list domains.", err)
+		}
+		domains = append(domains, resp.Domains...)
+		nextPageToken = resp.NextPageToken
+		if len(nextPageToken) == 0 {
+			break
+		}
+	}
+
+	data, err := json.MarshalIndent(domains, "", "  ")
+	if err != nil {
+		ErrorAndExit("Failed to serialize domains.", err)
+	}
+	if err := ioutil.WriteFile(outputFile, data, 0644); err != nil {
+		ErrorAndExit("Failed to write dump file.", err)
+	}
+	fmt.Printf("%v domains are dumped into %v.\n", len(domains), outputFile)
+}
+
+// AdminRestoreDomains registers the domains of a dump file, updating the ones that already exist
+func AdminRestoreDomains(c *cli.Context) {
+	inputFile := getRequiredOption(c, FlagInputFile)
+	securityToken := c.String(FlagSecurityToken)
+
+	data, err := ioutil.ReadFile(inputFile)
+	if err != nil {
+		ErrorAndExit("Failed to read dump file.", err)
+	}
+	var domains []*shared.DescribeDomainResponse
+	if err := json.Unmarshal(data, &domains); err != nil {
+		ErrorAndExit("Failed to deserialize domains.", err)
+	}
+	for i, d := range domains {
+		if d.DomainInfo.GetName() == "" || d.Configuration == nil {
+			ErrorAndExit(fmt.Sprintf("Invalid domain at index %v of the dump file.", i), nil)
+		}
+	}
+
+	frontendClient := cFactory.ServerFrontendClient(c)
+	var registered, updated, skipped, failed int
+	for _, d := range domains {
+		name := d.DomainInfo.GetName()
+		if name == common.SystemDomainName { // registered by every cluster itself
+			skipped++
+			continue
+		}
+
+		ctx, cancel := newContext(c)
+		err := frontendClient.RegisterDomain(ctx, toRegisterDomainRequest(d, securityToken))
+		cancel()
+		_, exists := err.(*shared.DomainAlreadyExistsError)
+		if err != nil && !exists {
+			fmt.Printf("%s domain %v: %v\n", colorRed("Failed to register"), name, err)
+			failed++
+			continue
+		}
+
+		// registration does not carry every setting, so it is always followed by an update
+		ctx, cancel = newContext(c)
+		_, err = frontendClient.UpdateDomain(ctx, toUpdateDomainRequest(d, securityToken))
+		cancel()
+		if err != nil {
+			fmt.Printf("%s domain %v: %v\n", colorRed("Failed to update"), name, err)
+			failed++
+			continue
+		}
+		if exists {
+			updated++
+		} else {
+			registered++
+		}
+	}
+	fmt.Printf("Restored %v domains: %v registered, %v updated, %v skipped, %v failed.\n",
+		len(domains), registered, updated, skipped, failed)
+	if failed > 0 {
+		ErrorAndExit(fmt.Sprintf("%v domains failed to restore.", failed), nil)
+	}
+}
+
+func toRegisterDomainRequest(d *shared.DescribeDomainResponse, securityToken string) *shared.RegisterDomainRequest {
+	request := &shared.RegisterDomainRequest{
+		Name:                                   d.DomainInfo.Name,
+		Description:                            d.DomainInfo.Description,
+		OwnerEmail:                             d.DomainInfo.OwnerEmail,
+		Data:                                   d.DomainInfo.Data,
+		WorkflowExecutionRetentionPeriodInDays: d.Configuration.WorkflowExecutionRetentionPeriodInDays,
+		EmitMetric:                             d.Configuration.EmitMetric,
+		ArchivalStatus:                         d.Configuration.ArchivalStatus,
+		ArchivalBucketName:                     d.Configuration.ArchivalBucketName,
+	}
+	if d.ReplicationConfiguration != nil {
+		request.ActiveClusterName = d.ReplicationConfiguration.ActiveClusterName
+		request.Clusters = d.ReplicationConfiguration.Clusters
+	}
+	if securityToken != "" {
+		request.SecurityToken = common.StringPtr(securityToken)
+	}
+	return request
+}
+
+// toUpdateDomainRequest leaves the replication configuration untouched: the clusters of a domain cannot be modified
+// and changing the active cluster would fail the domain over
+func toUpdateDomainRequest(d *shared.DescribeDomainResponse, securityToken string) *shared.UpdateDomainRequest {
+	request := &shared.UpdateDomainRequest{
+		Name: d.DomainInfo.Name,
+		UpdatedInfo: &shared.UpdateDomainInfo{
+			Description: d.DomainInfo.Description,
+			OwnerEmail:  d.DomainInfo.OwnerEmail,
+			Data:        d.DomainInfo.Data,
+		},
+		Configuration: d.Configuration,
+	}
+	if securityToken != "" {
+		request.SecurityToken = common.StringPtr(securityToken)
+	}
+	return request
+}
+
+// AdminDescribeArchivalDLQ prints the archival requests in the archival DLQ of a domain
+func AdminDescribeArchivalDLQ(c *cli.Context) {
+	frontendClient := cFactory.ServerFrontendClient(c)
+	domainID := getDomainID(c)
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+	resp, err := frontendClient.QueryWorkflow(ctx, &shared.QueryWorkflowRequest{
+		Domain: common.StringPtr(common.SystemDomainName),
+		Execution: &shared.WorkflowExecution{
+			WorkflowId: common.StringPtr(archiver.DLQWorkflowID(domainID)),
+		},
+		Query: &shared.WorkflowQuery{
+			QueryType: common.StringPtr(archiver.DLQQueryType),
+		},
+	})
+	if err != nil {
+		if _, ok := err.(*shared.EntityNotExistsError); ok {
+			fmt.Println("Archival DLQ is empty.")
+			return
+		}
+		ErrorAndExit("Failed to query archival DLQ.", err)
+	}
+	var entries []archiver.DLQEntry
+	if err := json.Unmarshal(resp.QueryResult, &entries); err != nil {
+		ErrorAndExit("Failed to decode archival DLQ.", err)
+	}
+	prettyPrintJSONObject(entries)
+}
+
+// AdminRedriveArchivalDLQ makes the archival DLQ of a domain archive the histories of its requests again
+func AdminRedriveArchivalDLQ(c *cli.Context) {
+	frontendClient := cFactory.ServerFrontendClient(c)
+	domainID := getDomainID(c)
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+	err := frontendClient.SignalWorkflowExecution(ctx, &shared.SignalWorkflowExecutionRequest{
+		Domain: common.StringPtr(common.SystemDomainName),
+		WorkflowExecution: &shared.WorkflowExecution{
+			WorkflowId: common.StringPtr(archiver.DLQWorkflowID(domainID)),
+		},
+		SignalName: common.StringPtr(archiver.DLQRedriveSignalName),
+		Identity:   common.StringPtr(getCliIdentity()),
+	})
+	if err != nil {
+		if _, ok := err.(*shared.EntityNotExistsError); ok {
+			fmt.Println("Archival DLQ is empty.")
+			return
+		}
+		ErrorAndExit("Failed to redrive archival DLQ.", err)
+	}
+	fmt.Println("Archival DLQ redrive is started.")
+}
+
+func getDomainID(c *cli.Context) string {
+	frontendClient := cFactory.ServerFrontendClient(c)
+	domain := getRequiredGlobalOption(c, FlagDomain)
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+	resp, err := frontendClient.DescribeDomain(ctx, &shared.DescribeDomainRequest{
+		Name: common.StringPtr(domain),
+	})
+	if err != nil {
+		ErrorAndExit("Failed to describe domain.", err)
+	}
+	return resp.DomainInfo.GetUUID()
+}
@@ -27,9 +27,15 @@ import (
 	"fmt"
 	"github.com/olekukonko/tablewriter"
 	"github.com/olivere/elastic"
+	"github.com/pborman/uuid"
 	"github.com/uber/cadence/.gen/go/indexer"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
 	es "github.com/uber/cadence/common/elasticsearch"
+	"github.com/uber/cadence/service/worker/scanner"
+	"github.com/uber/cadence/service/worker/scanner/esbackfill"
 	"github.com/urfave/cli"
+	"io/ioutil"
 	"net/http"
 	"os"
 	"strconv"
@@ -42,8 +48,25 @@ const (
 	esDocType        = "_doc"
 
 	versionTypeExternal = "external"
+
+	// esBackfillTimeout is the execution timeout of the ElasticSearch backfill workflow
+	esBackfillTimeout = 7 * 24 * time.Hour
+
+	// visibilityIndexTemplateName is the name of the template applied to visibility indices
+	visibilityIndexTemplateName   = "cadence-visibility-template"
+	defaultVisibilityTemplateFile = "schema/elasticsearch/visibility/index_template.json"
+	esDomainsAggregation          = "domains"
 )
 
+var esFieldTypes = map[string]bool{
+	"keyword": true,
+	"long":    true,
+	"integer": true,
+	"double":  true,
+	"boolean": true,
+	"date":    true,
+}
+
 const (
 	headerSource      = "rpc-caller"
 	headerDestination = "rpc-service"
@@ -121,6 +144,194 @@ func AdminCatIndices(c *cli.Context) {
 	table.Render()
 }
 
+// AdminCreateIndex puts the visibility index template and creates the visibility index, if it doesn't exist
+func AdminCreateIndex(c *cli.Context) {
+	esClient := getESClient(c)
+	indexName := getRequiredOption(c, FlagIndex)
+	templateFile := c.String(FlagTemplateFile)
+
+	template, err := ioutil.ReadFile(templateFile)
+	if err != nil {
+		ErrorAndExit(fmt.Sprintf("Unable to read index template %v", templateFile), err)
+	}
+	ctx := context.Background()
+	if _, err := esClient.IndexPutTemplate(visibilityIndexTemplateName).BodyString(string(template)).Do(ctx); err != nil {
+		ErrorAndExit("Unable to put index template", err)
+	}
+	exists, err := esClient.IndexExists(indexName).Do(ctx)
+	if err != nil {
+		ErrorAndExit(fmt.Sprintf("Unable to check index %v", indexName), err)
+	}
+	if exists {
+		fmt.Printf("Index template %v updated, index %v already exists\n", visibilityIndexTemplateName, indexName)
+		return
+	}
+	if _, err := esClient.CreateIndex(indexName).Do(ctx); err != nil {
+		ErrorAndExit(fmt.Sprintf("Unable to create index %v", indexName), err)
+	}
+	fmt.Printf("Index template %v updated, index %v created\n", visibilityIndexTemplateName, indexName)
+}
+
+// AdminUpdateMapping adds a field to the mapping of the visibility index and of the index template,
+// so that the field is indexed in the existing index as well as in indices created later on.
+// Existing fields cannot be changed by ElasticSearch.
+func AdminUpdateMapping(c *cli.Context) {
+	esClient := getESClient(c)
+	indexName := getRequiredOption(c, FlagIndex)
+	fieldName := getRequiredOption(c, FlagName)
+	fieldType := getRequiredOption(c, FlagFieldType)
+	if !esFieldTypes[fieldType] {
+		ErrorAndExit(fmt.Sprintf("Unsupported field type %v", fieldType), nil)
+	}
+	field := map[string]interface{}{"type": fieldType}
+
+	ctx := context.Background()
+	_, err := esClient.PutMapping().Index(indexName).Type(esDocType).BodyJson(map[string]interface{}{
+		"properties": map[string]interface{}{fieldName: field},
+	}).Do(ctx)
+	if err != nil {
+		ErrorAndExit(fmt.Sprintf("Unable to update mapping of index %v", indexName), err)
+	}
+
+	templates, err := esClient.IndexGetTemplate(visibilityIndexTemplateName).Do(ctx)
+	if err != nil {
+		ErrorAndExit("Unable to get index template", err)
+	}
+	template, ok := templates[visibilityIndexTemplateName]
+	if !ok {
+		ErrorAndExit(fmt.Sprintf("Index template %v not found", visibilityIndexTemplateName), nil)
+	}
+	docMapping, ok := template.Mappings[esDocType].(map[string]interface{})
+	if !ok {
+		ErrorAndExit(fmt.Sprintf("Index template %v has no %v mapping", visibilityIndexTemplateName, esDocType), nil)
+	}
+	properties, ok := docMapping["properties"].(map[string]interface{})
+	if !ok {
+		properties = make(map[string]interface{})
+		docMapping["properties"] = properties
+	}
+	properties[fieldName] = field
+	if _, err := esClient.IndexPutTemplate(visibilityIndexTemplateName).BodyJson(template).Do(ctx); err != nil {
+		ErrorAndExit("Unable to put index template", err)
+	}
+	fmt.Printf("Field %v of type %v added to index %v and index template %v\n", fieldName, fieldType, indexName, visibilityIndexTemplateName)
+}
+
+// AdminIndexHealth shows the health of the visibility index and its document count per domain
+func AdminIndexHealth(c *cli.Context) {
+	esClient := getESClient(c)
+	indexName := getRequiredOption(c, FlagIndex)
+	maxDomains := c.Int(FlagPageSize)
+
+	ctx := context.Background()
+	health, err := esClient.ClusterHealth().Index(indexName).Do(ctx)
+	if err != nil {
+		ErrorAndExit(fmt.Sprintf("Unable to get health of index %v", indexName), err)
+	}
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"status", "nodes", "active primary shards", "active shards", "relocating shards", "initializing shards", "unassigned shards"})
+	table.Append([]string{
+		health.Status,
+		strconv.Itoa(health.NumberOfNodes),
+		strconv.Itoa(health.ActivePrimaryShards),
+		strconv.Itoa(health.ActiveShards),
+		strconv.Itoa(health.RelocatingShards),
+		strconv.Itoa(health.InitializingShards),
+		strconv.Itoa(health.UnassignedShards),
+	})
+	table.Render()
+
+	resp, err := esClient.Search(indexName).
+		Size(0).
+		Aggregation(esDomainsAggregation, elastic.NewTermsAggregation().Field(es.DomainID).Size(maxDomains)).
+		Do(ctx)
+	if err != nil {
+		ErrorAndExit(fmt.Sprintf("Unable to count documents of index %v", indexName), err)
+	}
+	fmt.Printf("Total documents: %v\n", resp.Hits.TotalHits)
+	terms, ok := resp.Aggregations.Terms(esDomainsAggregation)
+	if !ok {
+		return
+	}
+	table = tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"domain id", "docs.count"})
+	for _, bucket := range terms.Buckets {
+		table.Append([]string{fmt.Sprintf("%v", bucket.Key), strconv.FormatInt(bucket.DocCount, 10)})
+	}
+	table.Render()
+}
+
+// AdminIndexRetention deletes the documents of closed executions of a domain whose close time is older than
+// the retention, which is what the ElasticSearch retention scanner of the worker service does for all domains
+func AdminIndexRetention(c *cli.Context) {
+	esClient := getESClient(c)
+	indexName := getRequiredOption(c, FlagIndex)
+	domainID := getRequiredOption(c, FlagDomainID)
+	retentionDays := c.Int(FlagRetentionDays)
+	if retentionDays <= 0 {
+		retentionDays = int(getDomainRetentionDays(c, domainID))
+	}
+	if retentionDays <= 0 {
+		ErrorAndExit("retention of the domain must be positive", nil)
+	}
+
+	cutoff := time.Now().Add(-time.Duration(retentionDays) * 24 * time.Hour).UnixNano()
+	query := elastic.NewBoolQuery().
+		Filter(elastic.NewTermQuery(es.DomainID, domainID)).
+		Filter(elastic.NewExistsQuery(es.CloseStatus)).
+		Filter(elastic.NewRangeQuery(es.CloseTime).Lt(cutoff))
+
+	ctx := context.Background()
+	if c.Bool(FlagDryRun) {
+		count, err := esClient.Count(indexName).Query(query).Do(ctx)
+		if err != nil {
+			ErrorAndExit("Unable to count expired documents", err)
+		}
+		fmt.Printf("%v expired documents\n", count)
+		return
+	}
+	resp, err := esClient.DeleteByQuery(indexName).Query(query).Do(ctx)
+	if err != nil {
+		ErrorAndExit("Unable to delete expired documents", err)
+	}
+	fmt.Printf("%v expired documents deleted\n", resp.Deleted)
+}
+
+// AdminBackfillExecutionTime sets the execution time of the documents written without one, which
+// is what history hosts did for workflows without backoff before the execution time was always
+// written, to their start time
+func AdminBackfillExecutionTime(c *cli.Context) {
+	esClient := getESClient(c)
+	indexName := getRequiredOption(c, FlagIndex)
+
+	query := elastic.NewBoolQuery().
+		Filter(elastic.NewTermQuery(es.ExecutionTime, 0)).
+		Filter(elastic.NewExistsQuery(es.StartTime))
+	if domainID := c.String(FlagDomainID); domainID != "" {
+		query = query.Filter(elastic.NewTermQuery(es.DomainID, domainID))
+	}
+
+	ctx := context.Background()
+	if c.Bool(FlagDryRun) {
+		count, err := esClient.Count(indexName).Query(query).Do(ctx)
+		if err != nil {
+			ErrorAndExit("Unable to count documents without execution time", err)
+		}
+		fmt.Printf("%v documents without execution time\n", count)
+		return
+	}
+	script := elastic.NewScript(fmt.Sprintf("ctx._source.%v = ctx._source.%v", es.ExecutionTime, es.StartTime))
+	resp, err := esClient.UpdateByQuery(indexName).
+		Query(query).
+		Script(script).
+		ProceedOnVersionConflict().
+		Do(ctx)
+	if err != nil {
+		ErrorAndExit("Unable to backfill execution time", err)
+	}
+	fmt.Printf("%v documents updated, %v version conflicts\n", resp.Updated, resp.VersionConflicts)
+}
+
 // AdminIndex used to bulk insert message from kafka parse
 func AdminIndex(c *cli.Context) {
 	esClient := getESClient(c)
@@ -230,3 +441,47 @@ func generateESDoc(msg *indexer.Message) map[string]interface{} {
 	}
 	return doc
 }
+
+// AdminBackfillElasticSearch starts the ElasticSearch backfill system workflow, which re-indexes the closed
+// executions of a domain started in the given time range from the visibility store into ElasticSearch.
+// The workflow runs in the cadence system domain and is picked up by the worker service scanner.
+func AdminBackfillElasticSearch(c *cli.Context) {
+	domainID := getRequiredOption(c, FlagDomainID)
+	params := esbackfill.Params{
+		DomainID:          domainID,
+		EarliestStartTime: parseTime(c.String(FlagEarliestTime), 0),
+		LatestStartTime:   parseTime(c.String(FlagLatestTime), time.Now().UnixNano()),
+	}
+	input, err := json.Marshal(params)
+	if err != nil {
+		ErrorAndExit("Failed to encode backfill params.", err)
+	}
+
+	workflowID := fmt.Sprintf("%v-%v", scanner.ESBackfillWFTypeName, domainID)
+	request := &shared.StartWorkflowExecutionRequest{
+		RequestId:  common.StringPtr(uuid.New()),
+		Domain:     common.StringPtr(common.SystemDomainName),
+		WorkflowId: common.StringPtr(workflowID),
+		WorkflowType: &shared.WorkflowType{
+			Name: common.StringPtr(scanner.ESBackfillWFTypeName),
+		},
+		TaskList: &shared.TaskList{
+			Name: common.StringPtr(scanner.ESBackfillTaskListName),
+		},
+		Input:                               input,
+		ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(int32(esBackfillTimeout / time.Second)),
+		TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(int32(defaultDecisionTimeoutInSeconds)),
+		Identity:                            common.StringPtr(getCliIdentity()),
+		WorkflowIdReusePolicy:               shared.WorkflowIdReusePolicyAllowDuplicate.Ptr(),
+	}
+
+	frontendClient := cFactory.ServerFrontendClient(c)
+	ctx, cancel := newContext(c)
+	defer cancel()
+	resp, err := frontendClient.StartWorkflowExecution(ctx, request)
+	if err != nil {
+		ErrorAndExit("Failed to start ElasticSearch backfill workflow.", err)
+	}
+	fmt.Printf("Started ElasticSearch backfill in domain %v, Workflow Id: %s, run Id: %s\n",
+		common.SystemDomainName, workflowID, resp.GetRunId())
+}
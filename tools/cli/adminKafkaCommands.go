@@ -23,6 +23,7 @@ package cli
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -70,6 +71,7 @@ const (
 const (
 	bufferSize                 = 4096
 	preambleVersion0      byte = 0x59
+	preambleVersion1      byte = 0x5A
 	malformedMessage           = "Input was malformed"
 	chanBufferSize             = 10000
 	maxRereplicateEventID      = 999999
@@ -355,7 +357,12 @@ func splitBuffer(buffer []byte) ([]byte, []byte) {
 }
 
 func parse(bytes []byte, skipErrors bool, skippedCount *int32, writerCh *writerChannel) {
-	messages, skippedGetMsgCount := getMessages(bytes, skipErrors)
+	preambles := []byte{preambleVersion0}
+	if writerCh.Type == kafkaMessageTypeVisibilityMsg {
+		// visibility messages are either thrift or protobuf encoded
+		preambles = append(preambles, preambleVersion1)
+	}
+	messages, skippedGetMsgCount := getMessages(bytes, preambles, skipErrors)
 	switch writerCh.Type {
 	case kafkaMessageTypeReplicationTask:
 		msgs, skippedDeserializeCount := deserializeMessages(messages, skipErrors)
@@ -372,7 +379,7 @@ func parse(bytes []byte, skipErrors bool, skippedCount *int32, writerCh *writerC
 	}
 }
 
-func getMessages(data []byte, skipErrors bool) ([][]byte, int32) {
+func getMessages(data []byte, preambles []byte, skipErrors bool) ([][]byte, int32) {
 	str := string(data)
 	messagesWithHeaders := r.Split(str, -1)
 	if len(messagesWithHeaders[0]) != 0 {
@@ -386,7 +393,7 @@ func getMessages(data []byte, skipErrors bool) ([][]byte, int32) {
 			ErrorAndExit(malformedMessage, errors.New("got empty message between valid headers"))
 		}
 		curr := []byte(m)
-		messageStart := bytes.Index(curr, []byte{preambleVersion0})
+		messageStart := bytes.IndexAny(curr, string(preambles))
 		if messageStart == -1 {
 			if !skipErrors {
 				ErrorAndExit(malformedMessage, errors.New("failed to find message preamble"))
@@ -432,8 +439,7 @@ func deserializeVisibilityMessages(messages [][]byte, skipErrors bool) ([]*index
 	var visibilityMessages []*indexer.Message
 	var skipped int32
 	for _, m := range messages {
-		var msg indexer.Message
-		err := decodeVisibility(m, &msg)
+		msg, err := messaging.DecodeVisibilityMessage(m)
 		if err != nil {
 			if !skipErrors {
 				ErrorAndExit(malformedMessage, err)
@@ -442,20 +448,11 @@ func deserializeVisibilityMessages(messages [][]byte, skipErrors bool) ([]*index
 				continue
 			}
 		}
-		visibilityMessages = append(visibilityMessages, &msg)
+		visibilityMessages = append(visibilityMessages, msg)
 	}
 	return visibilityMessages, skipped
 }
 
-func decodeVisibility(message []byte, val *indexer.Message) error {
-	reader := bytes.NewReader(message[1:])
-	wireVal, err := protocol.Binary.Decode(reader, wire.TStruct)
-	if err != nil {
-		return err
-	}
-	return val.FromWire(wireVal)
-}
-
 // ClustersConfig describes the kafka clusters
 type ClustersConfig struct {
 	Clusters map[string]messaging.ClusterConfig
@@ -470,17 +467,17 @@ func doRereplicate(shardID int, domainID, wid, rid string, minID, maxID int64, t
 	}
 
 	histV1 := cassandra.NewHistoryPersistenceFromSession(session, bark.NewNopLogger())
-	historyMgr := persistence.NewHistoryManagerImpl(histV1, bark.NewNopLogger())
+	historyMgr := persistence.NewHistoryManagerImpl(histV1, persistence.NewHistorySerializer(), bark.NewNopLogger())
 
 	histV2 := cassandra.NewHistoryV2PersistenceFromSession(session, bark.NewNopLogger())
-	historyV2Mgr := persistence.NewHistoryV2ManagerImpl(histV2, bark.NewNopLogger())
+	historyV2Mgr := persistence.NewHistoryV2ManagerImpl(histV2, persistence.NewHistorySerializer(), bark.NewNopLogger())
 
 	exeM := cassandra.NewWorkflowExecutionPersistenceFromSession(session, shardID, bark.NewNopLogger())
-	exeMgr := persistence.NewExecutionManagerImpl(exeM, bark.NewNopLogger())
+	exeMgr := persistence.NewExecutionManagerImpl(exeM, persistence.NewHistorySerializer(), bark.NewNopLogger())
 
 	for {
 		fmt.Printf("Start rereplicate for wid: %v, rid:%v \n", wid, rid)
-		resp, err := exeMgr.GetWorkflowExecution(&persistence.GetWorkflowExecutionRequest{
+		resp, err := exeMgr.GetWorkflowExecution(context.TODO(), &persistence.GetWorkflowExecutionRequest{
 			DomainID: domainID,
 			Execution: shared.WorkflowExecution{
 				WorkflowId: common.StringPtr(wid),
@@ -527,7 +524,7 @@ func doRereplicate(shardID int, domainID, wid, rid string, minID, maxID int64, t
 			if lastEvent.GetEventType() == shared.EventTypeWorkflowExecutionContinuedAsNew {
 				continueAsNew = true
 				newRunID = lastEvent.WorkflowExecutionContinuedAsNewEventAttributes.GetNewExecutionRunId()
-				resp, err := exeMgr.GetWorkflowExecution(&persistence.GetWorkflowExecutionRequest{
+				resp, err := exeMgr.GetWorkflowExecution(context.TODO(), &persistence.GetWorkflowExecutionRequest{
 					DomainID: domainID,
 					Execution: shared.WorkflowExecution{
 						WorkflowId: common.StringPtr(wid),
@@ -0,0 +1,265 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gocql/gocql"
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/persistence"
+	cassp "github.com/uber/cadence/common/persistence/cassandra"
+	"github.com/urfave/cli"
+)
+
+// row types of the executions table, see cassandra persistence
+const (
+	executionsRowTypeShard = iota
+	executionsRowTypeExecution
+	executionsRowTypeTransferTask
+	executionsRowTypeTimerTask
+	executionsRowTypeReplicationTask
+)
+
+const (
+	// permanentRunID is the run_id of the current execution row, see cassandra persistence
+	permanentRunID = "30000000-0000-f000-f000-000000000001"
+	// shardRangeSizeBits must match the RangeSizeBits of history service
+	shardRangeSizeBits = 20
+
+	templateSelectExecutionsJSON = `SELECT JSON * FROM executions WHERE shard_id = ?`
+	templateInsertExecutionJSON  = `INSERT INTO executions JSON ?`
+	templateSelectShardRangeID   = `SELECT range_id FROM executions WHERE shard_id = ? and type = ?`
+)
+
+type (
+	// shardMigrationTarget keeps the state of a shard in the target keyspace
+	shardMigrationTarget struct {
+		nextTaskID    int64
+		numExecutions int
+		numTasks      int
+	}
+)
+
+// AdminMigrateShards copies all rows of the executions table from a keyspace created with
+// number_of_shards into a keyspace for target_number_of_shards. Executions are re-mapped by
+// workflowID, task IDs are re-generated above the highest range of the source shards so they
+// stay unique and every new shard starts from a range that is above the copied tasks.
+// All other tables are not partitioned by shard and need to be copied by the operator.
+func AdminMigrateShards(c *cli.Context) {
+	sourceNumShards := c.Int(FlagNumberOfShards)
+	targetNumShards := c.Int(FlagTargetNumberOfShards)
+	if sourceNumShards <= 0 || targetNumShards <= 0 {
+		ErrorAndExit("number_of_shards and target_number_of_shards are required", nil)
+	}
+	dryRun := c.Bool(FlagDryRun)
+	batchSize := c.Int(FlagBatchSize)
+
+	source := connectToCassandra(c)
+	defer source.Close()
+
+	var target *gocql.Session
+	if !dryRun {
+		targetKeyspace := getRequiredOption(c, FlagTargetKeyspace)
+		if targetKeyspace == getRequiredOption(c, FlagKeyspace) {
+			ErrorAndExit("target_keyspace must be different from keyspace", nil)
+		}
+		target = connectToCassandraKeyspace(c, targetKeyspace)
+		defer target.Close()
+	}
+
+	// all task IDs in the target shards are allocated above every source range
+	var maxRangeID int64
+	for shardID := 0; shardID < sourceNumShards; shardID++ {
+		var rangeID int64
+		query := source.Query(templateSelectShardRangeID, shardID, executionsRowTypeShard)
+		if err := query.Scan(&rangeID); err != nil && err != gocql.ErrNotFound {
+			ErrorAndExit(fmt.Sprintf("failed to read shard %v", shardID), err)
+		}
+		if rangeID > maxRangeID {
+			maxRangeID = rangeID
+		}
+	}
+	baseRangeID := maxRangeID + 1
+
+	targets := make([]*shardMigrationTarget, targetNumShards)
+	for i := range targets {
+		targets[i] = &shardMigrationTarget{nextTaskID: baseRangeID << shardRangeSizeBits}
+	}
+
+	for shardID := 0; shardID < sourceNumShards; shardID++ {
+		iter := source.Query(templateSelectExecutionsJSON, shardID).PageSize(batchSize).Iter()
+		var rowJSON string
+		for iter.Scan(&rowJSON) {
+			row, err := decodeExecutionsRow(rowJSON)
+			if err != nil {
+				ErrorAndExit(fmt.Sprintf("failed to decode row of shard %v", shardID), err)
+			}
+			rowType, err := getExecutionsRowInt(row, "type")
+			if err != nil {
+				ErrorAndExit(fmt.Sprintf("failed to decode row type of shard %v", shardID), err)
+			}
+			if rowType == executionsRowTypeShard {
+				continue
+			}
+
+			workflowID, err := getExecutionsRowWorkflowID(row, rowType)
+			if err != nil {
+				ErrorAndExit(fmt.Sprintf("failed to decode workflowID of shard %v", shardID), err)
+			}
+			targetShardID := common.WorkflowIDToHistoryShard(workflowID, targetNumShards)
+			shard := targets[targetShardID]
+			row["shard_id"] = targetShardID
+
+			switch rowType {
+			case executionsRowTypeExecution:
+				if row["run_id"] != permanentRunID {
+					shard.numExecutions++
+				}
+			case executionsRowTypeTransferTask:
+				shard.reassignTaskID(row, "transfer")
+			case executionsRowTypeTimerTask:
+				shard.reassignTaskID(row, "timer")
+			case executionsRowTypeReplicationTask:
+				shard.reassignTaskID(row, "replication")
+			}
+
+			if dryRun {
+				continue
+			}
+			data, err := json.Marshal(row)
+			if err != nil {
+				ErrorAndExit("failed to encode row", err)
+			}
+			if err := target.Query(templateInsertExecutionJSON, string(data)).Exec(); err != nil {
+				ErrorAndExit(fmt.Sprintf("failed to write row for workflowID %v", workflowID), err)
+			}
+		}
+		if err := iter.Close(); err != nil {
+			ErrorAndExit(fmt.Sprintf("failed to read executions of shard %v", shardID), err)
+		}
+		fmt.Printf("shard %v of %v copied\n", shardID+1, sourceNumShards)
+	}
+
+	if !dryRun {
+		shardStore := cassp.NewWorkflowExecutionPersistenceFromSession(target, -1, bark.NewNopLogger())
+		for shardID, shard := range targets {
+			// history service renews the range on acquire, so every allocated task ID stays below the next range
+			rangeID := (shard.nextTaskID >> shardRangeSizeBits) + 1
+			err := shardStore.CreateShard(&persistence.CreateShardRequest{
+				ShardInfo: &persistence.ShardInfo{
+					ShardID:          shardID,
+					RangeID:          rangeID,
+					TransferAckLevel: 0,
+				},
+			})
+			if err != nil {
+				ErrorAndExit(fmt.Sprintf("failed to create shard %v", shardID), err)
+			}
+		}
+	}
+
+	printShardDistribution(targets)
+}
+
+func (s *shardMigrationTarget) reassignTaskID(row map[string]interface{}, taskColumn string) {
+	taskID := s.nextTaskID
+	s.nextTaskID++
+	s.numTasks++
+	row["task_id"] = taskID
+	if task, ok := row[taskColumn].(map[string]interface{}); ok {
+		task["task_id"] = taskID
+	}
+}
+
+func decodeExecutionsRow(rowJSON string) (map[string]interface{}, error) {
+	row := make(map[string]interface{})
+	decoder := json.NewDecoder(bytes.NewReader([]byte(rowJSON)))
+	// keep int64 values such as IDs and versions intact
+	decoder.UseNumber()
+	if err := decoder.Decode(&row); err != nil {
+		return nil, err
+	}
+	return row, nil
+}
+
+func getExecutionsRowInt(row map[string]interface{}, column string) (int, error) {
+	number, ok := row[column].(json.Number)
+	if !ok {
+		return 0, fmt.Errorf("column %v is not a number", column)
+	}
+	value, err := strconv.Atoi(number.String())
+	return value, err
+}
+
+// getExecutionsRowWorkflowID returns the workflowID a row belongs to, task rows
+// use a constant workflow_id column and carry the real one in the task itself
+func getExecutionsRowWorkflowID(row map[string]interface{}, rowType int) (string, error) {
+	column := ""
+	switch rowType {
+	case executionsRowTypeTransferTask:
+		column = "transfer"
+	case executionsRowTypeTimerTask:
+		column = "timer"
+	case executionsRowTypeReplicationTask:
+		column = "replication"
+	}
+
+	source := row
+	if len(column) > 0 {
+		task, ok := row[column].(map[string]interface{})
+		if !ok {
+			return "", fmt.Errorf("column %v is missing", column)
+		}
+		source = task
+	}
+	workflowID, ok := source["workflow_id"].(string)
+	if !ok || len(workflowID) == 0 {
+		return "", fmt.Errorf("workflow_id is missing")
+	}
+	return workflowID, nil
+}
+
+func printShardDistribution(targets []*shardMigrationTarget) {
+	if len(targets) == 0 {
+		return
+	}
+	minExecutions, maxExecutions, totalExecutions, totalTasks := targets[0].numExecutions, 0, 0, 0
+	for _, shard := range targets {
+		if shard.numExecutions < minExecutions {
+			minExecutions = shard.numExecutions
+		}
+		if shard.numExecutions > maxExecutions {
+			maxExecutions = shard.numExecutions
+		}
+		totalExecutions += shard.numExecutions
+		totalTasks += shard.numTasks
+	}
+	fmt.Println(strings.Repeat("-", 40))
+	fmt.Printf("shards: %v, executions: %v, tasks: %v\n", len(targets), totalExecutions, totalTasks)
+	fmt.Printf("executions per shard min: %v, max: %v, avg: %.2f\n",
+		minExecutions, maxExecutions, float64(totalExecutions)/float64(len(targets)))
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/urfave/cli"
+)
+
+const (
+	// visibilityDomainPartition is the only domain_partition used by cassandra visibility persistence
+	visibilityDomainPartition = 0
+	// maxVisibilityTTL is the maximum TTL supported by cassandra, see cassandra visibility persistence
+	maxVisibilityTTL = int64(630720000)
+	// visibilityTimestampLayout is the format of timestamp columns returned by SELECT JSON
+	visibilityTimestampLayout = "2006-01-02 15:04:05.000Z"
+
+	templateSelectVisibilityRowsJSON = `SELECT JSON %v, TTL(workflow_type_name) AS ttl, WRITETIME(workflow_type_name) AS writetime ` +
+		`FROM %v WHERE domain_id = ? AND domain_partition = ?`
+	templateInsertVisibilityRowJSON = `INSERT INTO %v JSON ? USING TTL ? AND TIMESTAMP ?`
+)
+
+type (
+	// visibilityTable describes a cassandra visibility table and the column its TTL is based on
+	visibilityTable struct {
+		name       string
+		columns    []string
+		timeColumn string
+		closed     bool
+	}
+)
+
+var (
+	openVisibilityColumns   = []string{"domain_id", "domain_partition", "workflow_id", "run_id", "start_time", "execution_time", "workflow_type_name"}
+	closedVisibilityColumns = []string{"domain_id", "domain_partition", "workflow_id", "run_id", "start_time", "execution_time", "close_time",
+		"status", "workflow_type_name", "history_length"}
+
+	visibilityTables = []visibilityTable{
+		{name: "open_executions", columns: openVisibilityColumns, timeColumn: "start_time"},
+		{name: "closed_executions", columns: closedVisibilityColumns, timeColumn: "close_time", closed: true},
+		{name: "closed_executions_v2", columns: closedVisibilityColumns, timeColumn: "close_time", closed: true},
+	}
+)
+
+// AdminBackfillVisibilityTTL sets a TTL on the visibility rows of a domain which were written without one.
+// Closed rows expire after the domain retention counted from their close time. Open rows are normally
+// deleted when the execution closes, so they get the maximum TTL counted from their start time which is
+// what the visibility persistence uses for workflows with a very long timeout.
+// Rows are re-written with a write time right after the original one, so newer updates and deletes win.
+func AdminBackfillVisibilityTTL(c *cli.Context) {
+	domainID := getRequiredOption(c, FlagDomainID)
+	dryRun := c.Bool(FlagDryRun)
+	batchSize := c.Int(FlagBatchSize)
+
+	retentionDays := c.Int(FlagRetentionDays)
+	if retentionDays <= 0 {
+		retentionDays = int(getDomainRetentionDays(c, domainID))
+	}
+	retention := int64(retentionDays) * int64(24*time.Hour/time.Second)
+	if retention <= 0 {
+		ErrorAndExit("retention of the domain must be positive", nil)
+	}
+
+	session := connectToCassandra(c)
+	defer session.Close()
+
+	now := time.Now()
+	for _, table := range visibilityTables {
+		numRows, numBackfilled := 0, 0
+		selectQuery := fmt.Sprintf(templateSelectVisibilityRowsJSON, strings.Join(table.columns, ", "), table.name)
+		insertQuery := fmt.Sprintf(templateInsertVisibilityRowJSON, table.name)
+
+		iter := session.Query(selectQuery, domainID, visibilityDomainPartition).PageSize(batchSize).Iter()
+		var rowJSON string
+		for iter.Scan(&rowJSON) {
+			numRows++
+			row, err := decodeExecutionsRow(rowJSON)
+			if err != nil {
+				ErrorAndExit(fmt.Sprintf("failed to decode row of %v", table.name), err)
+			}
+			if row["ttl"] != nil {
+				continue
+			}
+			writeTime, err := getVisibilityRowInt64(row, "writetime")
+			if err != nil {
+				ErrorAndExit(fmt.Sprintf("failed to decode write time of %v", table.name), err)
+			}
+			rowTime, err := getVisibilityRowTime(row, table.timeColumn)
+			if err != nil {
+				ErrorAndExit(fmt.Sprintf("failed to decode %v of %v", table.timeColumn, table.name), err)
+			}
+			delete(row, "ttl")
+			delete(row, "writetime")
+
+			ttl := maxVisibilityTTL
+			if table.closed {
+				ttl = retention
+			}
+			ttl -= int64(now.Sub(rowTime) / time.Second)
+			if ttl > maxVisibilityTTL {
+				ttl = maxVisibilityTTL
+			}
+			if ttl < 1 {
+				// already past retention, let it expire right away
+				ttl = 1
+			}
+
+			numBackfilled++
+			if dryRun {
+				continue
+			}
+			data, err := json.Marshal(row)
+			if err != nil {
+				ErrorAndExit("failed to encode row", err)
+			}
+			if err := session.Query(insertQuery, string(data), ttl, writeTime+1).Exec(); err != nil {
+				ErrorAndExit(fmt.Sprintf("failed to write row of %v for workflowID %v", table.name, row["workflow_id"]), err)
+			}
+		}
+		if err := iter.Close(); err != nil {
+			ErrorAndExit(fmt.Sprintf("failed to read %v", table.name), err)
+		}
+		fmt.Printf("%v: %v rows scanned, %v rows without TTL backfilled\n", table.name, numRows, numBackfilled)
+	}
+}
+
+func getDomainRetentionDays(c *cli.Context, domainID string) int32 {
+	frontendClient := cFactory.ServerFrontendClient(c)
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	resp, err := frontendClient.DescribeDomain(ctx, &shared.DescribeDomainRequest{
+		UUID: common.StringPtr(domainID),
+	})
+	if err != nil {
+		ErrorAndExit("Operation DescribeDomain failed.", err)
+	}
+	return resp.GetConfiguration().GetWorkflowExecutionRetentionPeriodInDays()
+}
+
+func getVisibilityRowInt64(row map[string]interface{}, column string) (int64, error) {
+	number, ok := row[column].(json.Number)
+	if !ok {
+		return 0, fmt.Errorf("column %v is not a number", column)
+	}
+	return strconv.ParseInt(number.String(), 10, 64)
+}
+
+func getVisibilityRowTime(row map[string]interface{}, column string) (time.Time, error) {
+	value, ok := row[column].(string)
+	if !ok {
+		return time.Time{}, fmt.Errorf("column %v is not a timestamp", column)
+	}
+	return time.Parse(visibilityTimestampLayout, value)
+}
@@ -52,6 +52,16 @@ func NewCliApp() *cli.App {
 			Usage:  "Optional timeout for context of RPC call in seconds",
 			EnvVar: "CADENCE_CONTEXT_TIMEOUT",
 		},
+		cli.StringFlag{
+			Name:   FlagOutputWithAlias,
+			Value:  outputFormatTable,
+			Usage:  "Optional output format of list and describe commands: table, json or go-template",
+			EnvVar: "CADENCE_CLI_OUTPUT",
+		},
+		cli.StringFlag{
+			Name:  FlagOutputTemplateWithAlias,
+			Usage: "Go template used to render the output when output format is go-template",
+		},
 	}
 	app.Commands = []cli.Command{
 		{
@@ -89,6 +99,12 @@ func NewCliApp() *cli.App {
 					Usage:       "Run admin operation on history host",
 					Subcommands: newAdminHistoryHostCommands(),
 				},
+				{
+					Name:        "shard",
+					Aliases:     []string{"shar"},
+					Usage:       "Run admin operation on history shards",
+					Subcommands: newAdminShardCommands(),
+				},
 				{
 					Name:        "kafka",
 					Aliases:     []string{"ka"},
@@ -101,6 +117,12 @@ func NewCliApp() *cli.App {
 					Usage:       "Run admin operation on domain",
 					Subcommands: newAdminDomainCommands(),
 				},
+				{
+					Name:        "visibility",
+					Aliases:     []string{"vis"},
+					Usage:       "Run admin operation on cassandra visibility records",
+					Subcommands: newAdminVisibilityCommands(),
+				},
 				{
 					Name:        "elasticsearch",
 					Aliases:     []string{"es"},
@@ -113,6 +135,12 @@ func NewCliApp() *cli.App {
 					Usage:       "Run admin operation on taskList",
 					Subcommands: newAdminTaskListCommands(),
 				},
+				{
+					Name:        "cluster",
+					Aliases:     []string{"cl"},
+					Usage:       "Run admin operation on cluster metadata",
+					Subcommands: newAdminClusterCommands(),
+				},
 			},
 		},
 	}
@@ -21,6 +21,8 @@
 package cli
 
 import (
+	"io/ioutil"
+	"os"
 	"strings"
 	"testing"
 	"time"
@@ -36,6 +38,7 @@ import (
 	serverFrontendTest "github.com/uber/cadence/.gen/go/cadence/workflowservicetest"
 	serverShared "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/persistence"
 	"github.com/urfave/cli"
 	clientFrontend "go.uber.org/cadence/.gen/go/cadence/workflowserviceclient"
 	clientFrontendTest "go.uber.org/cadence/.gen/go/cadence/workflowservicetest"
@@ -49,12 +52,14 @@ type cliAppSuite struct {
 	clientFrontendClient *clientFrontendTest.MockClient
 	serverFrontendClient *serverFrontendTest.MockClient
 	serverAdminClient    *serverAdminTest.MockClient
+	remoteAdminClient    *serverAdminTest.MockClient
 }
 
 type clientFactoryMock struct {
 	clientFrontendClient clientFrontend.Interface
 	serverFrontendClient serverFrontend.Interface
 	serverAdminClient    serverAdmin.Interface
+	remoteAdminClient    serverAdmin.Interface
 }
 
 func (m *clientFactoryMock) ClientFrontendClient(c *cli.Context) clientFrontend.Interface {
@@ -69,6 +74,10 @@ func (m *clientFactoryMock) ServerAdminClient(c *cli.Context) serverAdmin.Interf
 	return m.serverAdminClient
 }
 
+func (m *clientFactoryMock) RemoteServerAdminClient(c *cli.Context, hostPort string) serverAdmin.Interface {
+	return m.remoteAdminClient
+}
+
 // this is the mock for yarpcCallOptions, make sure length are the same
 var callOptions = []interface{}{gomock.Any(), gomock.Any(), gomock.Any()}
 
@@ -95,10 +104,12 @@ func (s *cliAppSuite) SetupTest() {
 	s.clientFrontendClient = clientFrontendTest.NewMockClient(s.mockCtrl)
 	s.serverFrontendClient = serverFrontendTest.NewMockClient(s.mockCtrl)
 	s.serverAdminClient = serverAdminTest.NewMockClient(s.mockCtrl)
+	s.remoteAdminClient = serverAdminTest.NewMockClient(s.mockCtrl)
 	SetFactory(&clientFactoryMock{
 		clientFrontendClient: s.clientFrontendClient,
 		serverFrontendClient: s.serverFrontendClient,
 		serverAdminClient:    s.serverAdminClient,
+		remoteAdminClient:    s.remoteAdminClient,
 	})
 }
 
@@ -241,6 +252,52 @@ func (s *cliAppSuite) TestDomainDescribe_Failed() {
 	s.Equal(1, errorCode)
 }
 
+func (s *cliAppSuite) TestAdminDomainDumpAndRestore() {
+	file, err := ioutil.TempFile("", "domains")
+	s.NoError(err)
+	file.Close()
+	defer os.Remove(file.Name())
+
+	s.serverFrontendClient.EXPECT().ListDomains(gomock.Any(), gomock.Any()).Return(&serverShared.ListDomainsResponse{
+		Domains:       []*serverShared.DescribeDomainResponse{describeDomainResponseServer},
+		NextPageToken: []byte("next"),
+	}, nil)
+	s.serverFrontendClient.EXPECT().ListDomains(gomock.Any(), gomock.Any()).Return(&serverShared.ListDomainsResponse{
+		Domains: []*serverShared.DescribeDomainResponse{
+			{
+				DomainInfo:    &serverShared.DomainInfo{Name: common.StringPtr(common.SystemDomainName)},
+				Configuration: &serverShared.DomainConfiguration{},
+			},
+		},
+	}, nil)
+	err = s.app.Run([]string{"", "admin", "domain", "dump", "-of", file.Name()})
+	s.Nil(err)
+
+	s.serverFrontendClient.EXPECT().RegisterDomain(gomock.Any(), gomock.Any()).Return(nil)
+	s.serverFrontendClient.EXPECT().UpdateDomain(gomock.Any(), gomock.Any()).
+		Do(func(_ interface{}, request *serverShared.UpdateDomainRequest, _ ...interface{}) {
+			s.Equal("test-domain", request.GetName())
+			s.Equal(int32(3), request.Configuration.GetWorkflowExecutionRetentionPeriodInDays())
+			s.Nil(request.ReplicationConfiguration)
+		}).Return(&serverShared.UpdateDomainResponse{}, nil)
+	err = s.app.Run([]string{"", "admin", "domain", "restore", "-if", file.Name()})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestAdminDomainRestore_Failed() {
+	file, err := ioutil.TempFile("", "domains")
+	s.NoError(err)
+	defer os.Remove(file.Name())
+	_, err = file.WriteString(`[{"domainInfo":{"name":"test-domain"},"configuration":{}}]`)
+	s.NoError(err)
+	file.Close()
+
+	s.serverFrontendClient.EXPECT().RegisterDomain(gomock.Any(), gomock.Any()).Return(&serverShared.DomainAlreadyExistsError{})
+	s.serverFrontendClient.EXPECT().UpdateDomain(gomock.Any(), gomock.Any()).Return(nil, &serverShared.BadRequestError{"faked error"})
+	errorCode := s.RunErrorExitCode([]string{"", "admin", "domain", "restore", "-if", file.Name()})
+	s.Equal(1, errorCode)
+}
+
 var (
 	eventType = shared.EventTypeWorkflowExecutionStarted
 
@@ -270,6 +327,63 @@ func (s *cliAppSuite) TestShowHistory() {
 	s.Nil(err)
 }
 
+func (s *cliAppSuite) TestExportHistory() {
+	dir, err := ioutil.TempDir("", "export-history")
+	s.NoError(err)
+	defer os.RemoveAll(dir)
+	jsonFile, bundleFile, decodedFile := dir+"/history.json", dir+"/history.bin", dir+"/decoded.json"
+
+	startedEventType := serverShared.EventTypeWorkflowExecutionStarted
+	completedEventType := serverShared.EventTypeWorkflowExecutionCompleted
+	firstPage := &serverShared.GetWorkflowExecutionHistoryResponse{
+		History: &serverShared.History{
+			Events: []*serverShared.HistoryEvent{
+				{
+					EventId:   common.Int64Ptr(1),
+					EventType: &startedEventType,
+					WorkflowExecutionStartedEventAttributes: &serverShared.WorkflowExecutionStartedEventAttributes{
+						WorkflowType: &serverShared.WorkflowType{Name: common.StringPtr("TestWorkflow")},
+						Input:        []byte(`"input"`),
+					},
+				},
+			},
+		},
+		NextPageToken: []byte("next"),
+	}
+	secondPage := &serverShared.GetWorkflowExecutionHistoryResponse{
+		History: &serverShared.History{
+			Events: []*serverShared.HistoryEvent{
+				{
+					EventId:   common.Int64Ptr(2),
+					EventType: &completedEventType,
+				},
+			},
+		},
+		Archived: common.BoolPtr(true),
+	}
+	for i := 0; i < 2; i++ {
+		s.serverFrontendClient.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any()).Return(firstPage, nil)
+		s.serverFrontendClient.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any()).Return(secondPage, nil)
+	}
+
+	err = s.app.Run([]string{"", "--do", domainName, "workflow", "export-history", "-w", "wid", "-of", jsonFile})
+	s.Nil(err)
+	err = s.app.Run([]string{"", "--do", domainName, "workflow", "export-history", "-w", "wid", "-of", bundleFile, "--binary"})
+	s.Nil(err)
+	err = s.app.Run([]string{"", "--do", domainName, "workflow", "export-history", "-if", bundleFile, "-of", decodedFile})
+	s.Nil(err)
+
+	exported, err := ioutil.ReadFile(jsonFile)
+	s.NoError(err)
+	decoded, err := ioutil.ReadFile(decodedFile)
+	s.NoError(err)
+	s.Equal(string(exported), string(decoded))
+
+	history, err := (&JSONHistorySerializer{}).Deserialize(exported)
+	s.NoError(err)
+	s.Len(history.Events, 2)
+}
+
 func (s *cliAppSuite) TestShowHistoryWithID() {
 	resp := getWorkflowExecutionHistoryResponse
 	s.clientFrontendClient.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any(), callOptions...).Return(resp, nil)
@@ -345,6 +459,33 @@ func (s *cliAppSuite) TestTerminateWorkflow_Failed() {
 	s.Equal(1, errorCode)
 }
 
+func (s *cliAppSuite) TestBatchTerminateWorkflow() {
+	file, err := ioutil.TempFile("", "workflow-ids")
+	s.NoError(err)
+	defer os.Remove(file.Name())
+	_, err = file.WriteString("wid1\n# comment\n\nwid2, rid2\n")
+	s.NoError(err)
+	s.NoError(file.Close())
+
+	s.clientFrontendClient.EXPECT().TerminateWorkflowExecution(gomock.Any(), gomock.Any(), callOptions...).Return(nil)
+	s.clientFrontendClient.EXPECT().TerminateWorkflowExecution(gomock.Any(), gomock.Any(), callOptions...).Return(&shared.BadRequestError{"faked error"})
+	err = s.app.Run([]string{"", "--do", domainName, "workflow", "batch", "terminate", "-wif", file.Name(), "--yes"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestBatchSignalWorkflow_ListFilter() {
+	resp := listOpenWorkflowExecutionsResponse
+	s.clientFrontendClient.EXPECT().ListOpenWorkflowExecutions(gomock.Any(), gomock.Any(), callOptions...).Return(resp, nil)
+	s.clientFrontendClient.EXPECT().SignalWorkflowExecution(gomock.Any(), gomock.Any(), callOptions...).Return(nil).Times(len(resp.Executions))
+	err := s.app.Run([]string{"", "--do", domainName, "workflow", "batch", "signal", "-wt", "testWorkflowType", "-n", "signal", "--yes"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestBatchWorkflow_NoSelection() {
+	errorCode := s.RunErrorExitCode([]string{"", "--do", domainName, "workflow", "batch", "terminate", "--yes"})
+	s.Equal(1, errorCode)
+}
+
 func (s *cliAppSuite) TestCancelWorkflow() {
 	s.clientFrontendClient.EXPECT().RequestCancelWorkflowExecution(gomock.Any(), gomock.Any(), callOptions...).Return(nil)
 	err := s.app.Run([]string{"", "--do", domainName, "workflow", "cancel", "-w", "wid"})
@@ -491,6 +632,20 @@ func (s *cliAppSuite) TestListWorkflow_Open_WithWorkflowType() {
 	s.Nil(err)
 }
 
+func (s *cliAppSuite) TestListWorkflow_JSONOutput() {
+	resp := listClosedWorkflowExecutionsResponse
+	s.clientFrontendClient.EXPECT().ListClosedWorkflowExecutions(gomock.Any(), gomock.Any(), callOptions...).Return(resp, nil)
+	err := s.app.Run([]string{"", "--do", domainName, "--output", "json", "workflow", "list"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestListWorkflow_TemplateOutput() {
+	resp := listClosedWorkflowExecutionsResponse
+	s.clientFrontendClient.EXPECT().ListClosedWorkflowExecutions(gomock.Any(), gomock.Any(), callOptions...).Return(resp, nil)
+	err := s.app.Run([]string{"", "--do", domainName, "-o", "go-template", "--tpl", "{{range .}}{{.Execution.WorkflowId}}\n{{end}}", "workflow", "list"})
+	s.Nil(err)
+}
+
 var describeTaskListResponse = &shared.DescribeTaskListResponse{
 	Pollers: []*shared.PollerInfo{
 		{
@@ -518,6 +673,79 @@ func (s *cliAppSuite) TestAdminDescribeWorkflow_Failed() {
 	s.Equal(1, errorCode)
 }
 
+func (s *cliAppSuite) newRawHistoryResponse(events []*serverShared.HistoryEvent) *admin.GetWorkflowExecutionRawHistoryResponse {
+	blob, err := persistence.NewHistorySerializer().SerializeBatchEvents(events, common.EncodingTypeThriftRW)
+	s.Nil(err)
+	return &admin.GetWorkflowExecutionRawHistoryResponse{
+		HistoryBatches: []*serverShared.DataBlob{{
+			EncodingType: serverShared.EncodingTypeThriftRW.Ptr(),
+			Data:         blob.Data,
+		}},
+	}
+}
+
+func newDiffTestEvent(eventID, version int64, eventType serverShared.EventType) *serverShared.HistoryEvent {
+	return &serverShared.HistoryEvent{
+		EventId:   common.Int64Ptr(eventID),
+		Version:   common.Int64Ptr(version),
+		EventType: eventType.Ptr(),
+		TaskId:    common.Int64Ptr(eventID * 10),
+	}
+}
+
+func (s *cliAppSuite) TestAdminDiffWorkflowHistory() {
+	events := []*serverShared.HistoryEvent{
+		newDiffTestEvent(1, 1, serverShared.EventTypeWorkflowExecutionStarted),
+		newDiffTestEvent(2, 1, serverShared.EventTypeDecisionTaskScheduled),
+	}
+	s.serverAdminClient.EXPECT().GetWorkflowExecutionRawHistory(gomock.Any(), gomock.Any()).Return(s.newRawHistoryResponse(events), nil)
+	s.remoteAdminClient.EXPECT().GetWorkflowExecutionRawHistory(gomock.Any(), gomock.Any()).Return(s.newRawHistoryResponse(events), nil)
+	err := s.app.Run([]string{"", "--do", domainName, "admin", "wf", "diff", "-w", "test-wf-id", "-r", "test-run-id", "--rad", "remote:7933"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestAdminDiffWorkflowHistory_NoRemoteSource() {
+	errorCode := s.RunErrorExitCode([]string{"", "--do", domainName, "admin", "wf", "diff", "-w", "test-wf-id", "-r", "test-run-id"})
+	s.Equal(1, errorCode)
+}
+
+func (s *cliAppSuite) TestCompareHistories() {
+	local := []*serverShared.HistoryEvent{
+		newDiffTestEvent(1, 1, serverShared.EventTypeWorkflowExecutionStarted),
+		newDiffTestEvent(2, 1, serverShared.EventTypeDecisionTaskScheduled),
+		newDiffTestEvent(3, 1, serverShared.EventTypeDecisionTaskStarted),
+	}
+	remote := []*serverShared.HistoryEvent{
+		newDiffTestEvent(1, 1, serverShared.EventTypeWorkflowExecutionStarted),
+		newDiffTestEvent(2, 1, serverShared.EventTypeDecisionTaskScheduled),
+	}
+	remote[1].TaskId = common.Int64Ptr(1234)
+
+	s.Nil(compareHistories(local[:2], remote))
+
+	divergence := compareHistories(local, remote)
+	s.NotNil(divergence)
+	s.Equal(int64(3), divergence.EventID)
+	s.Equal(divergenceLength, divergence.Reason)
+	s.Equal(int64(2), divergence.LastCommonEventID)
+	s.Nil(divergence.Remote)
+
+	remote = append(remote, newDiffTestEvent(3, 11, serverShared.EventTypeDecisionTaskStarted))
+	divergence = compareHistories(local, remote)
+	s.NotNil(divergence)
+	s.Equal(int64(3), divergence.EventID)
+	s.Equal(divergenceVersion, divergence.Reason)
+	s.Equal(int64(1), divergence.LastCommonVersion)
+
+	remote[2] = newDiffTestEvent(3, 1, serverShared.EventTypeDecisionTaskStarted)
+	remote[2].DecisionTaskStartedEventAttributes = &serverShared.DecisionTaskStartedEventAttributes{
+		Identity: common.StringPtr("remote-worker"),
+	}
+	divergence = compareHistories(local, remote)
+	s.NotNil(divergence)
+	s.Equal(divergenceAttributes, divergence.Reason)
+}
+
 func (s *cliAppSuite) TestDescribeTaskList() {
 	resp := describeTaskListResponse
 	s.clientFrontendClient.EXPECT().DescribeTaskList(gomock.Any(), gomock.Any(), callOptions...).Return(resp, nil)
@@ -532,6 +760,22 @@ func (s *cliAppSuite) TestDescribeTaskList_Activity() {
 	s.Nil(err)
 }
 
+func (s *cliAppSuite) TestDescribeTaskList_JSONOutput() {
+	resp := &shared.DescribeTaskListResponse{}
+	s.clientFrontendClient.EXPECT().DescribeTaskList(gomock.Any(), gomock.Any(), callOptions...).Return(resp, nil)
+	err := s.app.Run([]string{"", "--do", domainName, "--output", "json", "tasklist", "describe", "-tl", "test-taskList"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestDescribeTaskList_InvalidOutput() {
+	resp := describeTaskListResponse
+	s.clientFrontendClient.EXPECT().DescribeTaskList(gomock.Any(), gomock.Any(), callOptions...).Return(resp, nil).Times(2)
+	errorCode := s.RunErrorExitCode([]string{"", "--do", domainName, "--output", "yaml", "tasklist", "describe", "-tl", "test-taskList"})
+	s.Equal(1, errorCode)
+	errorCode = s.RunErrorExitCode([]string{"", "--do", domainName, "--output", "go-template", "tasklist", "describe", "-tl", "test-taskList"})
+	s.Equal(1, errorCode)
+}
+
 func (s *cliAppSuite) TestObserveWorkflow() {
 	history := getWorkflowExecutionHistoryResponse
 	s.clientFrontendClient.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any(), callOptions...).Return(history, nil).Times(2)
@@ -550,6 +794,39 @@ func (s *cliAppSuite) TestObserveWorkflowWithID() {
 	s.Nil(err)
 }
 
+func (s *cliAppSuite) TestObserveWorkflowFollowRuns() {
+	signaledType := shared.EventTypeWorkflowExecutionSignaled
+	continuedAsNewType := shared.EventTypeWorkflowExecutionContinuedAsNew
+	firstRun := &shared.GetWorkflowExecutionHistoryResponse{
+		History: &shared.History{
+			Events: []*shared.HistoryEvent{
+				{
+					EventType: &signaledType,
+					WorkflowExecutionSignaledEventAttributes: &shared.WorkflowExecutionSignaledEventAttributes{
+						SignalName: common.StringPtr("signal"),
+						Input:      []byte("input"),
+					},
+				},
+				{
+					EventType: &continuedAsNewType,
+					WorkflowExecutionContinuedAsNewEventAttributes: &shared.WorkflowExecutionContinuedAsNewEventAttributes{
+						NewExecutionRunId: common.StringPtr("rid2"),
+					},
+				},
+			},
+		},
+	}
+	gomock.InOrder(
+		s.clientFrontendClient.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any(), callOptions...).Return(firstRun, nil),
+		s.clientFrontendClient.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any(), callOptions...).
+			Do(func(_ interface{}, request *shared.GetWorkflowExecutionHistoryRequest, _ ...interface{}) {
+				s.Equal("rid2", request.Execution.GetRunId())
+			}).Return(getWorkflowExecutionHistoryResponse, nil),
+	)
+	err := s.app.Run([]string{"", "--do", domainName, "workflow", "observe", "-w", "wid", "-fr"})
+	s.Nil(err)
+}
+
 func (s *cliAppSuite) TestParseTime() {
 	s.Equal(int64(100), parseTime("", 100))
 	s.Equal(int64(1528383845000000000), parseTime("2018-06-07T15:04:05+00:00", 0))
@@ -0,0 +1,283 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pborman/uuid"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/tokenbucket"
+	"github.com/urfave/cli"
+	s "go.uber.org/cadence/.gen/go/shared"
+	"go.uber.org/cadence/client"
+)
+
+const (
+	defaultBatchConcurrency = 10
+	defaultBatchRPS         = 50
+	batchListPageSize       = 1000
+	batchRateLimitTimeout   = time.Minute
+
+	resetTypeFirstDecisionCompleted = "FirstDecisionCompleted"
+	resetTypeLastDecisionCompleted  = "LastDecisionCompleted"
+)
+
+type (
+	// batchOperation is applied to every workflow execution of a batch
+	batchOperation func(ctx context.Context, execution *s.WorkflowExecution) error
+
+	batchResult struct {
+		execution *s.WorkflowExecution
+		err       error
+	}
+)
+
+var errNoDecisionCompleted = errors.New("no DecisionTaskCompleted event to reset to")
+
+// BatchSignalWorkflow signals a batch of workflow executions
+func BatchSignalWorkflow(c *cli.Context) {
+	serviceClient := cFactory.ClientFrontendClient(c)
+	domain := getRequiredGlobalOption(c, FlagDomain)
+	name := getRequiredOption(c, FlagName)
+	input := processJSONInput(c)
+
+	runBatch(c, "signal", func(ctx context.Context, execution *s.WorkflowExecution) error {
+		return serviceClient.SignalWorkflowExecution(ctx, &s.SignalWorkflowExecutionRequest{
+			Domain:            common.StringPtr(domain),
+			WorkflowExecution: execution,
+			SignalName:        common.StringPtr(name),
+			Input:             []byte(input),
+			Identity:          common.StringPtr(getCliIdentity()),
+		})
+	})
+}
+
+// BatchTerminateWorkflow terminates a batch of workflow executions
+func BatchTerminateWorkflow(c *cli.Context) {
+	wfClient := getWorkflowClient(c)
+	reason := c.String(FlagReason)
+
+	runBatch(c, "terminate", func(ctx context.Context, execution *s.WorkflowExecution) error {
+		return wfClient.TerminateWorkflow(ctx, execution.GetWorkflowId(), execution.GetRunId(), reason, nil)
+	})
+}
+
+// BatchResetWorkflow resets a batch of workflow executions to their first or last completed decision
+func BatchResetWorkflow(c *cli.Context) {
+	domain := getRequiredGlobalOption(c, FlagDomain)
+	reason := getRequiredOption(c, FlagReason)
+	resetType := c.String(FlagResetType)
+	if resetType != resetTypeFirstDecisionCompleted && resetType != resetTypeLastDecisionCompleted {
+		ErrorAndExit(fmt.Sprintf("Option %s must be %s or %s", FlagResetType,
+			resetTypeFirstDecisionCompleted, resetTypeLastDecisionCompleted), nil)
+	}
+	wfClient := getWorkflowClient(c)
+	frontendClient := cFactory.ServerFrontendClient(c)
+
+	runBatch(c, "reset", func(ctx context.Context, execution *s.WorkflowExecution) error {
+		runID := execution.GetRunId()
+		if len(runID) == 0 {
+			resp, err := wfClient.DescribeWorkflowExecution(ctx, execution.GetWorkflowId(), "")
+			if err != nil {
+				return err
+			}
+			runID = resp.WorkflowExecutionInfo.Execution.GetRunId()
+		}
+		eventID, err := getResetEventID(ctx, wfClient, execution.GetWorkflowId(), runID, resetType)
+		if err != nil {
+			return err
+		}
+		_, err = frontendClient.ResetWorkflowExecution(ctx, &shared.ResetWorkflowExecutionRequest{
+			Domain: common.StringPtr(domain),
+			WorkflowExecution: &shared.WorkflowExecution{
+				WorkflowId: execution.WorkflowId,
+				RunId:      common.StringPtr(runID),
+			},
+			Reason:                common.StringPtr(reason),
+			DecisionFinishEventId: common.Int64Ptr(eventID),
+			RequestId:             common.StringPtr(uuid.New()),
+		})
+		return err
+	})
+}
+
+// getResetEventID returns the ID of the first or last DecisionTaskCompleted event of the run
+func getResetEventID(ctx context.Context, wfClient client.Client, wid, rid, resetType string) (int64, error) {
+	var eventID int64
+	iter := wfClient.GetWorkflowHistory(ctx, wid, rid, false, s.HistoryEventFilterTypeAllEvent)
+	for iter.HasNext() {
+		event, err := iter.Next()
+		if err != nil {
+			return 0, err
+		}
+		if event.GetEventType() != s.EventTypeDecisionTaskCompleted {
+			continue
+		}
+		eventID = event.GetEventId()
+		if resetType == resetTypeFirstDecisionCompleted {
+			break
+		}
+	}
+	if eventID == 0 {
+		return 0, errNoDecisionCompleted
+	}
+	return eventID, nil
+}
+
+// runBatch applies the operation to the executions read from the workflow ID file, or to the open
+// executions matching the list filters, with a pool of concurrent workers sharing a rate limit.
+// The result of every execution is printed as soon as it is known.
+func runBatch(c *cli.Context, operationName string, operation batchOperation) {
+	executions := getBatchExecutions(c)
+	if len(executions) == 0 {
+		fmt.Println("No workflow executions selected.")
+		return
+	}
+	if !c.Bool(FlagYes) && !confirmBatch(operationName, len(executions)) {
+		return
+	}
+
+	concurrency := c.Int(FlagConcurrency)
+	if concurrency <= 0 {
+		concurrency = defaultBatchConcurrency
+	}
+	rps := c.Int(FlagRPS)
+	if rps <= 0 {
+		rps = defaultBatchRPS
+	}
+	rateLimiter := tokenbucket.New(rps, clock.NewRealTimeSource())
+
+	executionC := make(chan *s.WorkflowExecution)
+	resultC := make(chan batchResult)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for execution := range executionC {
+				for !rateLimiter.Consume(1, batchRateLimitTimeout) {
+				}
+				ctx, cancel := newContext(c)
+				err := operation(ctx, execution)
+				cancel()
+				resultC <- batchResult{execution: execution, err: err}
+			}
+		}()
+	}
+	go func() {
+		for _, execution := range executions {
+			executionC <- execution
+		}
+		close(executionC)
+		wg.Wait()
+		close(resultC)
+	}()
+
+	succeeded, failed := 0, 0
+	for result := range resultC {
+		wid, rid := result.execution.GetWorkflowId(), result.execution.GetRunId()
+		if result.err != nil {
+			failed++
+			fmt.Printf("  %s, %s, %s: %v\n", wid, rid, colorRed("FAILED"), result.err)
+			continue
+		}
+		succeeded++
+		fmt.Printf("  %s, %s, %s\n", wid, rid, colorGreen("OK"))
+	}
+	fmt.Printf("Batch %s done: %d succeeded, %d failed\n", operationName, succeeded, failed)
+}
+
+func confirmBatch(operationName string, count int) bool {
+	fmt.Printf("%d workflow executions selected for %s, continue? (Y/N): ", count, operationName)
+	var input string
+	fmt.Scanln(&input)
+	return strings.ToLower(strings.TrimSpace(input)) == "y"
+}
+
+// getBatchExecutions returns the executions of the workflow ID file if one is given,
+// otherwise all open executions matching the workflow type and start time filters
+func getBatchExecutions(c *cli.Context) []*s.WorkflowExecution {
+	if c.IsSet(FlagWorkflowIDFile) {
+		executions, err := readWorkflowIDFile(c.String(FlagWorkflowIDFile))
+		if err != nil {
+			ErrorAndExit("Unable to read workflow ID file.", err)
+		}
+		return executions
+	}
+
+	wfClient := getWorkflowClient(c)
+	earliestTime := parseTime(c.String(FlagEarliestTime), 0)
+	latestTime := parseTime(c.String(FlagLatestTime), time.Now().UnixNano())
+	workflowType := c.String(FlagWorkflowType)
+	if len(workflowType) == 0 && !c.IsSet(FlagEarliestTime) && !c.IsSet(FlagLatestTime) {
+		ErrorAndExit(fmt.Sprintf("Option %s, or one of %s, %s and %s is required",
+			FlagWorkflowIDFile, FlagWorkflowType, FlagEarliestTime, FlagLatestTime), nil)
+	}
+
+	var executions []*s.WorkflowExecution
+	var nextPageToken []byte
+	for {
+		var result []*s.WorkflowExecutionInfo
+		result, nextPageToken = listOpenWorkflow(wfClient, batchListPageSize, earliestTime, latestTime, "", workflowType, nextPageToken, c)
+		for _, info := range result {
+			executions = append(executions, info.Execution)
+		}
+		if len(nextPageToken) == 0 {
+			return executions
+		}
+	}
+}
+
+// readWorkflowIDFile reads one execution per line, a workflow ID optionally followed by a run ID
+// separated by a comma or spaces. Empty lines and lines starting with # are skipped.
+func readWorkflowIDFile(fileName string) ([]*s.WorkflowExecution, error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var executions []*s.WorkflowExecution
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(strings.Replace(line, ",", " ", -1))
+		execution := &s.WorkflowExecution{WorkflowId: common.StringPtr(fields[0])}
+		if len(fields) > 1 {
+			execution.RunId = common.StringPtr(fields[1])
+		}
+		executions = append(executions, execution)
+	}
+	return executions, scanner.Err()
+}
@@ -89,6 +89,7 @@ var (
 		"terminated":     s.WorkflowExecutionCloseStatusTerminated,
 		"continuedasnew": s.WorkflowExecutionCloseStatusContinuedAsNew,
 		"timedout":       s.WorkflowExecutionCloseStatusTimedOut,
+		"deleted":        s.WorkflowExecutionCloseStatusDeleted,
 		// below are some alias
 		"c":         s.WorkflowExecutionCloseStatusCompleted,
 		"complete":  s.WorkflowExecutionCloseStatusCompleted,
@@ -330,6 +331,10 @@ func DescribeDomain(c *cli.Context) {
 		ErrorAndExit(fmt.Sprintf("Domain %s does not exist.", domainName), err)
 	}
 
+	printOutput(c, resp, func() { printDomainDescription(resp) })
+}
+
+func printDomainDescription(resp *shared.DescribeDomainResponse) {
 	var formatStr = "Name: %v\nDescription: %v\nOwnerEmail: %v\nDomainData: %v\nStatus: %v\nRetentionInDays: %v\n" +
 		"EmitMetrics: %v\nActiveClusterName: %v\nClusters: %v\nArchivalStatus: %v\n"
 	descValues := []interface{}{
@@ -538,7 +543,7 @@ func startWorkflowHelper(c *cli.Context, shouldPrintProgress bool) {
 		table.AppendBulk(executionData) // Add Bulk Data
 		table.Render()
 
-		printWorkflowProgress(c, wid, resp.GetRunId())
+		printWorkflowProgress(c, wid, resp.GetRunId(), false)
 	}
 
 	if shouldPrintProgress {
@@ -548,8 +553,11 @@ func startWorkflowHelper(c *cli.Context, shouldPrintProgress bool) {
 	}
 }
 
-// helper function to print workflow progress with time refresh every second
-func printWorkflowProgress(c *cli.Context, wid, rid string) {
+// helper function to print workflow progress with time refresh every second.
+// When watching, the history is followed until the execution closes regardless of
+// the context timeout, unless one is set explicitly, and runs continued as new
+// are followed as well if requested
+func printWorkflowProgress(c *cli.Context, wid, rid string, watch bool) {
 	fmt.Println(colorMagenta("Progress:"))
 
 	wfClient := getWorkflowClient(c)
@@ -560,6 +568,10 @@ func printWorkflowProgress(c *cli.Context, wid, rid string) {
 	ticker := time.NewTicker(time.Second).C
 
 	tcCtx, cancel := newContextForLongPoll(c)
+	if watch && !c.GlobalIsSet(FlagContextTimeout) {
+		cancel()
+		tcCtx, cancel = context.WithCancel(context.Background())
+	}
 	defer cancel()
 
 	showDetails := c.Bool(FlagShowDetail)
@@ -567,24 +579,40 @@ func printWorkflowProgress(c *cli.Context, wid, rid string) {
 	if c.IsSet(FlagMaxFieldLength) {
 		maxFieldLength = c.Int(FlagMaxFieldLength)
 	}
+	followRuns := watch && c.Bool(FlagFollowRuns)
 
 	go func() {
-		iter := wfClient.GetWorkflowHistory(tcCtx, wid, rid, true, s.HistoryEventFilterTypeAllEvent)
-		for iter.HasNext() {
-			event, err := iter.Next()
-			if err != nil {
-				ErrorAndExit("Unable to read event.", err)
+		for {
+			iter := wfClient.GetWorkflowHistory(tcCtx, wid, rid, true, s.HistoryEventFilterTypeAllEvent)
+			for iter.HasNext() {
+				event, err := iter.Next()
+				if err != nil {
+					ErrorAndExit("Unable to read event.", err)
+				}
+				if isTimeElapseExist {
+					removePrevious2LinesFromTerminal()
+					isTimeElapseExist = false
+				}
+				if showDetails {
+					fmt.Printf("  %d, %s, %s, %s\n", event.GetEventId(), convertTime(event.GetTimestamp(), false), ColorEvent(event), HistoryEventToString(event, true, maxFieldLength))
+				} else if event.GetEventType() == s.EventTypeWorkflowExecutionSignaled {
+					attributes := event.WorkflowExecutionSignaledEventAttributes
+					fmt.Printf("  %d, %s, %s, Signal: %s, Input: %s\n", event.GetEventId(), convertTime(event.GetTimestamp(), false), ColorEvent(event),
+						attributes.GetSignalName(), string(attributes.Input))
+				} else {
+					fmt.Printf("  %d, %s, %s\n", event.GetEventId(), convertTime(event.GetTimestamp(), false), ColorEvent(event))
+				}
+				lastEvent = event
+			}
+			if !followRuns || lastEvent.GetEventType() != s.EventTypeWorkflowExecutionContinuedAsNew {
+				break
 			}
+			rid = lastEvent.WorkflowExecutionContinuedAsNewEventAttributes.GetNewExecutionRunId()
 			if isTimeElapseExist {
 				removePrevious2LinesFromTerminal()
 				isTimeElapseExist = false
 			}
-			if showDetails {
-				fmt.Printf("  %d, %s, %s, %s\n", event.GetEventId(), convertTime(event.GetTimestamp(), false), ColorEvent(event), HistoryEventToString(event, true, maxFieldLength))
-			} else {
-				fmt.Printf("  %d, %s, %s\n", event.GetEventId(), convertTime(event.GetTimestamp(), false), ColorEvent(event))
-			}
-			lastEvent = event
+			fmt.Println(colorMagenta(fmt.Sprintf("Continued as new, run Id: %s", rid)))
 		}
 		doneChan <- true
 	}()
@@ -654,9 +682,7 @@ func SignalWorkflow(c *cli.Context) {
 	name := getRequiredOption(c, FlagName)
 	input := processJSONInput(c)
 
-	tcCtx, cancel := newContext(c)
-	defer cancel()
-	err := serviceClient.SignalWorkflowExecution(tcCtx, &s.SignalWorkflowExecutionRequest{
+	request := &s.SignalWorkflowExecutionRequest{
 		Domain: common.StringPtr(domain),
 		WorkflowExecution: &s.WorkflowExecution{
 			WorkflowId: common.StringPtr(wid),
@@ -665,7 +691,14 @@ func SignalWorkflow(c *cli.Context) {
 		SignalName: common.StringPtr(name),
 		Input:      []byte(input),
 		Identity:   common.StringPtr(getCliIdentity()),
-	})
+	}
+	if delay := c.Int(FlagSignalDelay); delay > 0 {
+		request.DeliveryTimestamp = common.Int64Ptr(time.Now().Add(time.Duration(delay) * time.Second).UnixNano())
+	}
+
+	tcCtx, cancel := newContext(c)
+	defer cancel()
+	err := serviceClient.SignalWorkflowExecution(tcCtx, request)
 
 	if err != nil {
 		ErrorAndExit("Signal workflow failed.", err)
@@ -685,7 +718,7 @@ func QueryWorkflow(c *cli.Context) {
 
 // QueryWorkflowUsingStackTrace query workflow execution using __stack_trace as query type
 func QueryWorkflowUsingStackTrace(c *cli.Context) {
-	queryWorkflowHelper(c, "__stack_trace")
+	queryWorkflowHelper(c, common.QueryTypeStackTrace)
 }
 
 func queryWorkflowHelper(c *cli.Context, queryType string) {
@@ -727,6 +760,15 @@ func ListWorkflow(c *cli.Context) {
 	pageSize := c.Int(FlagPageSize)
 
 	queryOpen := c.Bool(FlagOpen)
+	if getOutputFormat(c) != outputFormatTable { // structured output only shows one page and never prompts
+		executions, _ := listWorkflowExecutions(c, queryOpen)(nil)
+		if executions == nil {
+			executions = []*s.WorkflowExecutionInfo{}
+		}
+		printStructuredOutput(c, executions)
+		return
+	}
+
 	table := createTableForListWorkflow(false, queryOpen)
 	prepareTable := listWorkflow(c, table, queryOpen)
 
@@ -761,6 +803,22 @@ func ListWorkflow(c *cli.Context) {
 // ListAllWorkflow list all workflow executions based on filters
 func ListAllWorkflow(c *cli.Context) {
 	queryOpen := c.Bool(FlagOpen)
+	if getOutputFormat(c) != outputFormatTable {
+		listExecutions := listWorkflowExecutions(c, queryOpen)
+		executions := []*s.WorkflowExecutionInfo{}
+		var nextPageToken []byte
+		for {
+			var result []*s.WorkflowExecutionInfo
+			result, nextPageToken = listExecutions(nextPageToken)
+			executions = append(executions, result...)
+			if len(result) < defaultPageSizeForList {
+				break
+			}
+		}
+		printStructuredOutput(c, executions)
+		return
+	}
+
 	table := createTableForListWorkflow(true, queryOpen)
 	prepareTable := listWorkflow(c, table, queryOpen)
 	var resultSize int
@@ -821,7 +879,7 @@ func describeWorkflowHelper(c *cli.Context, wid, rid string) {
 	} else {
 		o = convertDescribeWorkflowExecutionResponse(resp)
 	}
-	prettyPrintJSONObject(o)
+	printOutput(c, o, func() { prettyPrintJSONObject(o) })
 }
 
 func prettyPrintJSONObject(o interface{}) {
@@ -839,6 +897,8 @@ type describeWorkflowExecutionResponse struct {
 	ExecutionConfiguration *shared.WorkflowExecutionConfiguration
 	WorkflowExecutionInfo  workflowExecutionInfo
 	PendingActivities      []*pendingActivityInfo
+	PendingChildren        []*shared.PendingChildExecutionInfo
+	PendingDecision        *pendingDecisionInfo
 }
 
 // workflowExecutionInfo has same fields as shared.WorkflowExecutionInfo, but has datetime instead of raw time
@@ -865,6 +925,16 @@ type pendingActivityInfo struct {
 	Attempt                *int32  `json:",omitempty"`
 	MaximumAttempts        *int32  `json:",omitempty"`
 	ExpirationTimestamp    *string `json:",omitempty"` // change from *int64
+	LastFailureReason      *string `json:",omitempty"`
+	LastWorkerIdentity     *string `json:",omitempty"`
+}
+
+// pendingDecisionInfo has same fields as shared.PendingDecisionInfo, but has datetime instead of raw time
+type pendingDecisionInfo struct {
+	State            *shared.PendingDecisionState
+	ScheduledEventID *int64
+	StartedTimestamp *string `json:",omitempty"` // change from *int64
+	Attempt          *int64  `json:",omitempty"`
 }
 
 func convertDescribeWorkflowExecutionResponse(resp *shared.DescribeWorkflowExecutionResponse) *describeWorkflowExecutionResponse {
@@ -892,6 +962,8 @@ func convertDescribeWorkflowExecutionResponse(resp *shared.DescribeWorkflowExecu
 			Attempt:                pa.Attempt,
 			MaximumAttempts:        pa.MaximumAttempts,
 			ExpirationTimestamp:    timestampPtrToStringPtr(pa.ExpirationTimestamp, false),
+			LastFailureReason:      pa.LastFailureReason,
+			LastWorkerIdentity:     pa.LastWorkerIdentity,
 		}
 		if pa.HeartbeatDetails != nil {
 			tmpAct.HeartbeatDetails = common.StringPtr(string(pa.HeartbeatDetails))
@@ -899,10 +971,22 @@ func convertDescribeWorkflowExecutionResponse(resp *shared.DescribeWorkflowExecu
 		pendingActs = append(pendingActs, tmpAct)
 	}
 
+	var pendingDecision *pendingDecisionInfo
+	if pd := resp.PendingDecision; pd != nil {
+		pendingDecision = &pendingDecisionInfo{
+			State:            pd.State,
+			ScheduledEventID: pd.ScheduledEventId,
+			StartedTimestamp: timestampPtrToStringPtr(pd.StartedTimestamp, false),
+			Attempt:          pd.Attempt,
+		}
+	}
+
 	return &describeWorkflowExecutionResponse{
 		ExecutionConfiguration: resp.ExecutionConfiguration,
 		WorkflowExecutionInfo:  executionInfo,
 		PendingActivities:      pendingActs,
+		PendingChildren:        resp.PendingChildren,
+		PendingDecision:        pendingDecision,
 	}
 }
 
@@ -925,14 +1009,43 @@ func createTableForListWorkflow(listAll bool, queryOpen bool) *tablewriter.Table
 }
 
 func listWorkflow(c *cli.Context, table *tablewriter.Table, queryOpen bool) func([]byte) ([]byte, int) {
+	listExecutions := listWorkflowExecutions(c, queryOpen)
+	printRawTime := c.Bool(FlagPrintRawTime)
+	printDateTime := c.Bool(FlagPrintDateTime)
+
+	prepareTable := func(next []byte) ([]byte, int) {
+		result, nextPageToken := listExecutions(next)
+		for _, e := range result {
+			var startTime, executionTime, closeTime string
+			if printRawTime {
+				startTime = fmt.Sprintf("%d", e.GetStartTime())
+				executionTime = fmt.Sprintf("%d", e.GetExecutionTime())
+				closeTime = fmt.Sprintf("%d", e.GetCloseTime())
+			} else {
+				startTime = convertTime(e.GetStartTime(), !printDateTime)
+				executionTime = convertTime(e.GetExecutionTime(), !printDateTime)
+				closeTime = convertTime(e.GetCloseTime(), !printDateTime)
+			}
+			row := []string{trimWorkflowType(e.Type.GetName()), e.Execution.GetWorkflowId(), e.Execution.GetRunId(), startTime, executionTime}
+			if !queryOpen {
+				row = append(row, closeTime)
+			}
+			table.Append(row)
+		}
+
+		return nextPageToken, len(result)
+	}
+	return prepareTable
+}
+
+// listWorkflowExecutions validates the list filters and returns a function fetching one page of executions
+func listWorkflowExecutions(c *cli.Context, queryOpen bool) func([]byte) ([]*s.WorkflowExecutionInfo, []byte) {
 	wfClient := getWorkflowClient(c)
 
 	earliestTime := parseTime(c.String(FlagEarliestTime), 0)
 	latestTime := parseTime(c.String(FlagLatestTime), time.Now().UnixNano())
 	workflowID := c.String(FlagWorkflowID)
 	workflowType := c.String(FlagWorkflowType)
-	printRawTime := c.Bool(FlagPrintRawTime)
-	printDateTime := c.Bool(FlagPrintDateTime)
 	pageSize := c.Int(FlagPageSize)
 	if pageSize <= 0 {
 		pageSize = defaultPageSizeForList
@@ -952,36 +1065,12 @@ func listWorkflow(c *cli.Context, table *tablewriter.Table, queryOpen bool) func
 		ErrorAndExit(optionErr, errors.New("you can filter on workflow_id or workflow_type, but not on both"))
 	}
 
-	prepareTable := func(next []byte) ([]byte, int) {
-		var result []*s.WorkflowExecutionInfo
-		var nextPageToken []byte
+	return func(next []byte) ([]*s.WorkflowExecutionInfo, []byte) {
 		if queryOpen {
-			result, nextPageToken = listOpenWorkflow(wfClient, pageSize, earliestTime, latestTime, workflowID, workflowType, next, c)
-		} else {
-			result, nextPageToken = listClosedWorkflow(wfClient, pageSize, earliestTime, latestTime, workflowID, workflowType, workflowStatus, next, c)
-		}
-
-		for _, e := range result {
-			var startTime, executionTime, closeTime string
-			if printRawTime {
-				startTime = fmt.Sprintf("%d", e.GetStartTime())
-				executionTime = fmt.Sprintf("%d", e.GetExecutionTime())
-				closeTime = fmt.Sprintf("%d", e.GetCloseTime())
-			} else {
-				startTime = convertTime(e.GetStartTime(), !printDateTime)
-				executionTime = convertTime(e.GetExecutionTime(), !printDateTime)
-				closeTime = convertTime(e.GetCloseTime(), !printDateTime)
-			}
-			row := []string{trimWorkflowType(e.Type.GetName()), e.Execution.GetWorkflowId(), e.Execution.GetRunId(), startTime, executionTime}
-			if !queryOpen {
-				row = append(row, closeTime)
-			}
-			table.Append(row)
+			return listOpenWorkflow(wfClient, pageSize, earliestTime, latestTime, workflowID, workflowType, next, c)
 		}
-
-		return nextPageToken, len(result)
+		return listClosedWorkflow(wfClient, pageSize, earliestTime, latestTime, workflowID, workflowType, workflowStatus, next, c)
 	}
-	return prepareTable
 }
 
 func listOpenWorkflow(client client.Client, pageSize int, earliestTime, latestTime int64, workflowID, workflowType string,
@@ -1054,7 +1143,10 @@ func DescribeTaskList(c *cli.Context) {
 		ErrorAndExit("Operation DescribeTaskList failed.", err)
 	}
 
-	pollers := response.Pollers
+	printOutput(c, response, func() { printTaskListPollers(taskList, taskListType, response.Pollers) })
+}
+
+func printTaskListPollers(taskList string, taskListType s.TaskListType, pollers []*s.PollerInfo) {
 	if len(pollers) == 0 {
 		ErrorAndExit(colorMagenta("No poller for tasklist: "+taskList), nil)
 	}
@@ -1080,7 +1172,7 @@ func ObserveHistory(c *cli.Context) {
 	wid := getRequiredOption(c, FlagWorkflowID)
 	rid := c.String(FlagRunID)
 
-	printWorkflowProgress(c, wid, rid)
+	printWorkflowProgress(c, wid, rid, true)
 }
 
 // ResetWorkflow reset workflow
@@ -1189,7 +1281,7 @@ func ObserveHistoryWithID(c *cli.Context) {
 		rid = c.Args().Get(1)
 	}
 
-	printWorkflowProgress(c, wid, rid)
+	printWorkflowProgress(c, wid, rid, true)
 }
 
 func getDomainClient(c *cli.Context) client.DomainClient {
@@ -1360,6 +1452,13 @@ func printRunStatus(event *s.HistoryEvent) {
 	case s.EventTypeWorkflowExecutionCanceled:
 		fmt.Printf("  Status: %s\n", colorRed("CANCELED"))
 		fmt.Printf("  Detail: %s\n", string(event.WorkflowExecutionCanceledEventAttributes.Details))
+	case s.EventTypeWorkflowExecutionTerminated:
+		fmt.Printf("  Status: %s\n", colorRed("TERMINATED"))
+		fmt.Printf("  Reason: %s\n", event.WorkflowExecutionTerminatedEventAttributes.GetReason())
+		fmt.Printf("  Detail: %s\n", string(event.WorkflowExecutionTerminatedEventAttributes.Details))
+	case s.EventTypeWorkflowExecutionContinuedAsNew:
+		fmt.Printf("  Status: %s\n", colorMagenta("CONTINUED_AS_NEW"))
+		fmt.Printf("  New Run Id: %s\n", event.WorkflowExecutionContinuedAsNewEventAttributes.GetNewExecutionRunId())
 	}
 }
 
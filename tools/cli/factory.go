@@ -40,6 +40,7 @@ type ClientFactory interface {
 	ClientFrontendClient(c *cli.Context) clientFrontend.Interface
 	ServerFrontendClient(c *cli.Context) serverFrontend.Interface
 	ServerAdminClient(c *cli.Context) serverAdmin.Interface
+	RemoteServerAdminClient(c *cli.Context, hostPort string) serverAdmin.Interface
 }
 
 type clientFactory struct {
@@ -78,6 +79,13 @@ func (b *clientFactory) ServerAdminClient(c *cli.Context) serverAdmin.Interface
 	return serverAdmin.New(b.dispatcher.ClientConfig(cadenceFrontendService))
 }
 
+// RemoteServerAdminClient builds an admin client for the frontend of another cluster, the dispatcher is not shared
+// with the other clients since it targets a different host
+func (b *clientFactory) RemoteServerAdminClient(c *cli.Context, hostPort string) serverAdmin.Interface {
+	dispatcher := b.newDispatcher(hostPort)
+	return serverAdmin.New(dispatcher.ClientConfig(cadenceFrontendService))
+}
+
 func (b *clientFactory) ensureDispatcher(c *cli.Context) {
 	if b.dispatcher != nil {
 		return
@@ -87,21 +95,25 @@ func (b *clientFactory) ensureDispatcher(c *cli.Context) {
 	if addr := c.GlobalString(FlagAddress); addr != "" {
 		b.hostPort = addr
 	}
+	b.dispatcher = b.newDispatcher(b.hostPort)
+}
 
+func (b *clientFactory) newDispatcher(hostPort string) *yarpc.Dispatcher {
 	ch, err := tchannel.NewChannelTransport(tchannel.ServiceName(cadenceClientName), tchannel.ListenAddr("127.0.0.1:0"))
 	if err != nil {
 		b.logger.Fatal("Failed to create transport channel", zap.Error(err))
 	}
 
-	b.dispatcher = yarpc.NewDispatcher(yarpc.Config{
+	dispatcher := yarpc.NewDispatcher(yarpc.Config{
 		Name: cadenceClientName,
 		Outbounds: yarpc.Outbounds{
-			cadenceFrontendService: {Unary: ch.NewSingleOutbound(b.hostPort)},
+			cadenceFrontendService: {Unary: ch.NewSingleOutbound(hostPort)},
 		},
 	})
 
-	if err := b.dispatcher.Start(); err != nil {
-		b.dispatcher.Stop()
+	if err := dispatcher.Start(); err != nil {
+		dispatcher.Stop()
 		b.logger.Fatal("Failed to create outbound transport channel: %v", zap.Error(err))
 	}
+	return dispatcher
 }
@@ -145,6 +145,38 @@ const (
 	FlagIndex                       = "index"
 	FlagBatchSize                   = "batch_size"
 	FlagBatchSizeWithAlias          = FlagBatchSize + ", bs"
+	FlagTargetKeyspace              = "target_keyspace"
+	FlagTargetNumberOfShards        = "target_number_of_shards"
+	FlagDryRun                      = "dry_run"
+	FlagTemplateFile                = "template_file"
+	FlagFieldType                   = "field_type"
+	FlagFollowRuns                  = "follow_runs"
+	FlagFollowRunsWithAlias         = FlagFollowRuns + ", fr"
+	FlagWorkflowIDFile              = "workflow_id_file"
+	FlagWorkflowIDFileWithAlias     = FlagWorkflowIDFile + ", wif"
+	FlagConcurrency                 = "concurrency"
+	FlagRPS                         = "rps"
+	FlagYes                         = "yes"
+	FlagResetType                   = "reset_type"
+	FlagOutput                      = "output"
+	FlagOutputWithAlias             = FlagOutput + ", o"
+	FlagOutputTemplate              = "template"
+	FlagOutputTemplateWithAlias     = FlagOutputTemplate + ", tpl"
+	FlagBinary                      = "binary"
+	FlagSignalDelay                 = "delay"
+	FlagInitialFailoverVersion      = "initial_failover_version"
+	FlagRPCName                     = "rpc_name"
+	FlagRPCAddress                  = "rpc_address"
+	FlagSearchAttributeKey          = "search_attr_key"
+	FlagSearchAttributeType         = "search_attr_type"
+	FlagMinShardID                  = "min_shard_id"
+	FlagMaxShardID                  = "max_shard_id"
+	FlagMinTaskID                   = "min_task_id"
+	FlagMaxTaskID                   = "max_task_id"
+	FlagSourceCluster               = "source_cluster"
+	FlagSampleInterval              = "sample_interval"
+	FlagRemoteAddress               = "remote_address"
+	FlagRemoteAddressWithAlias      = FlagRemoteAddress + ", rad"
 )
 
 var flagsForExecution = []cli.Flag{
@@ -196,6 +228,23 @@ func getFlagsForShowID() []cli.Flag {
 	}
 }
 
+func getFlagsForExportHistory() []cli.Flag {
+	return append(flagsForExecution,
+		cli.StringFlag{
+			Name:  FlagOutputFilenameWithAlias,
+			Usage: "File the history is written into",
+		},
+		cli.BoolFlag{
+			Name:  FlagBinary,
+			Usage: "Write a compact ThriftRW encoded bundle instead of pretty JSON",
+		},
+		cli.StringFlag{
+			Name:  FlagInputFileWithAlias,
+			Usage: "Decode a previously exported bundle into pretty JSON instead of fetching the history",
+		},
+	)
+}
+
 func getFlagsForStart() []cli.Flag {
 	return []cli.Flag{
 		cli.StringFlag{
@@ -233,7 +282,7 @@ func getFlagsForStart() []cli.Flag {
 		cli.IntFlag{
 			Name: FlagWorkflowIDReusePolicyAlias,
 			Usage: "Optional input to configure if the same workflow ID is allow to use for new workflow execution. " +
-				"Available options: 0: AllowDuplicateFailedOnly, 1: AllowDuplicate, 2: RejectDuplicate",
+				"Available options: 0: AllowDuplicateFailedOnly, 1: AllowDuplicate, 2: RejectDuplicate, 3: TerminateIfRunning",
 		},
 		cli.StringFlag{
 			Name:  FlagInputWithAlias,
@@ -310,7 +359,7 @@ func getFlagsForListAll() []cli.Flag {
 		},
 		cli.StringFlag{
 			Name:  FlagWorkflowStatusWithAlias,
-			Usage: "Closed workflow status [completed, failed, canceled, terminated, continueasnew, timedout]",
+			Usage: "Closed workflow status [completed, failed, canceled, terminated, continueasnew, timedout, deleted]",
 		},
 	}
 }
@@ -365,6 +414,41 @@ func getFlagsForDescribeID() []cli.Flag {
 	}
 }
 
+func getFlagsForBatch() []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:  FlagWorkflowIDFileWithAlias,
+			Usage: "File of the workflow executions, one workflow_id per line optionally followed by a run_id",
+		},
+		cli.StringFlag{
+			Name:  FlagWorkflowTypeWithAlias,
+			Usage: "Select the open workflows of this type, when no workflow_id file is given",
+		},
+		cli.StringFlag{
+			Name:  FlagEarliestTimeWithAlias,
+			Usage: "Select the open workflows started after EarliestTime, supported formats are '2006-01-02T15:04:05Z07:00' and raw UnixNano",
+		},
+		cli.StringFlag{
+			Name:  FlagLatestTimeWithAlias,
+			Usage: "Select the open workflows started before LatestTime, supported formats are '2006-01-02T15:04:05Z07:00' and raw UnixNano",
+		},
+		cli.IntFlag{
+			Name:  FlagConcurrency,
+			Value: defaultBatchConcurrency,
+			Usage: "Number of workflow executions processed concurrently",
+		},
+		cli.IntFlag{
+			Name:  FlagRPS,
+			Value: defaultBatchRPS,
+			Usage: "Maximum number of workflow executions processed per second",
+		},
+		cli.BoolFlag{
+			Name:  FlagYes,
+			Usage: "Skip the confirmation prompt",
+		},
+	}
+}
+
 func getFlagsForObserve() []cli.Flag {
 	return append(flagsForExecution, getFlagsForObserveID()...)
 }
@@ -379,5 +463,9 @@ func getFlagsForObserveID() []cli.Flag {
 			Name:  FlagMaxFieldLengthWithAlias,
 			Usage: "Optional maximum length for each attribute field when show details",
 		},
+		cli.BoolFlag{
+			Name:  FlagFollowRunsWithAlias,
+			Usage: "Optional keep observing the new run when the workflow continues as new",
+		},
 	}
 }
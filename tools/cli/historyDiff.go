@@ -0,0 +1,169 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"fmt"
+
+	"github.com/uber/cadence/.gen/go/admin"
+	serverAdmin "github.com/uber/cadence/.gen/go/admin/adminserviceclient"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/urfave/cli"
+)
+
+type (
+	// historyDivergence describes the first point where two histories of the same run stop matching
+	historyDivergence struct {
+		EventID           int64
+		Reason            string
+		LastCommonEventID int64
+		LastCommonVersion int64
+		Local             *shared.HistoryEvent
+		Remote            *shared.HistoryEvent
+	}
+)
+
+const (
+	divergenceEventID    = "event id mismatch"
+	divergenceEventType  = "event type mismatch"
+	divergenceVersion    = "event version mismatch"
+	divergenceAttributes = "event attributes mismatch"
+	divergenceLength     = "history length mismatch"
+)
+
+// AdminDiffWorkflowHistory compares the history of one run read from the current cluster with the history of the
+// same run read from another cluster, or from a history bundle exported with `workflow export` (which reads from
+// archival once the run is past retention), and reports the first event where they diverge
+func AdminDiffWorkflowHistory(c *cli.Context) {
+	domain := getRequiredGlobalOption(c, FlagDomain)
+	wid := getRequiredOption(c, FlagWorkflowID)
+	rid := getRequiredOption(c, FlagRunID)
+	remoteAddress := c.String(FlagRemoteAddress)
+	inputFileName := c.String(FlagInputFile)
+	if (len(remoteAddress) == 0) == (len(inputFileName) == 0) {
+		ErrorAndExit(fmt.Sprintf("Exactly one of %v and %v is required.", FlagRemoteAddress, FlagInputFile), nil)
+		return
+	}
+
+	local := getRawHistory(c, cFactory.ServerAdminClient(c), domain, wid, rid)
+	remoteSource := inputFileName
+	var remote []*shared.HistoryEvent
+	if len(remoteAddress) > 0 {
+		remoteSource = remoteAddress
+		remote = getRawHistory(c, cFactory.RemoteServerAdminClient(c, remoteAddress), domain, wid, rid)
+	} else {
+		remote = readHistoryBundle(inputFileName)
+	}
+
+	fmt.Printf("Local history has %v events, %v history has %v events.\n", len(local), remoteSource, len(remote))
+	divergence := compareHistories(local, remote)
+	if divergence == nil {
+		fmt.Println(colorGreen("Histories are identical."))
+		return
+	}
+
+	fmt.Printf("%s at event %v: %v\n", colorRed("Histories diverge"), divergence.EventID, divergence.Reason)
+	fmt.Printf("Last common event: %v, version: %v\n", divergence.LastCommonEventID, divergence.LastCommonVersion)
+	fmt.Println(colorMagenta("Local event:"))
+	prettyPrintJSONObject(divergence.Local)
+	fmt.Println(colorMagenta("Remote event:"))
+	prettyPrintJSONObject(divergence.Remote)
+}
+
+// getRawHistory pages through the complete persisted history of a run with the admin raw history API, this is what
+// the replication stack sends across clusters so the events are compared before any conversion by the frontend
+func getRawHistory(c *cli.Context, adminClient serverAdmin.Interface, domain, wid, rid string) []*shared.HistoryEvent {
+	var events []*shared.HistoryEvent
+	var nextPageToken []byte
+	for {
+		ctx, cancel := newContext(c)
+		resp, err := adminClient.GetWorkflowExecutionRawHistory(ctx, &admin.GetWorkflowExecutionRawHistoryRequest{
+			Domain: common.StringPtr(domain),
+			Execution: &shared.WorkflowExecution{
+				WorkflowId: common.StringPtr(wid),
+				RunId:      common.StringPtr(rid),
+			},
+			FirstEventId:    common.Int64Ptr(common.FirstEventID),
+			NextEventId:     common.Int64Ptr(common.EndEventID),
+			MaximumPageSize: common.Int32Ptr(common.GetHistoryMaxPageSize),
+			NextPageToken:   nextPageToken,
+		})
+		cancel()
+		if err != nil {
+			ErrorAndExit(fmt.Sprintf("Failed to get raw history on workflow id: %s, run id: %s.", wid, rid), err)
+		}
+		for _, blob := range resp.HistoryBatches {
+			batch, err := deserializeHistoryBlob(blob)
+			if err != nil {
+				ErrorAndExit("Failed to deserialize history batch.", err)
+			}
+			events = append(events, batch...)
+		}
+		nextPageToken = resp.NextPageToken
+		if len(nextPageToken) == 0 {
+			break
+		}
+	}
+	return events
+}
+
+// compareHistories walks both histories event by event and returns the first divergence, or nil if they match.
+// TaskId is assigned by each cluster when the events are persisted and is excluded from the comparison.
+func compareHistories(local, remote []*shared.HistoryEvent) *historyDivergence {
+	var lastCommon *shared.HistoryEvent
+	newDivergence := func(eventID int64, reason string, l, r *shared.HistoryEvent) *historyDivergence {
+		return &historyDivergence{
+			EventID:           eventID,
+			Reason:            reason,
+			LastCommonEventID: lastCommon.GetEventId(),
+			LastCommonVersion: lastCommon.GetVersion(),
+			Local:             l,
+			Remote:            r,
+		}
+	}
+
+	for i := 0; i < len(local) && i < len(remote); i++ {
+		l, r := local[i], remote[i]
+		switch {
+		case l.GetEventId() != r.GetEventId():
+			return newDivergence(l.GetEventId(), divergenceEventID, l, r)
+		case l.GetVersion() != r.GetVersion():
+			return newDivergence(l.GetEventId(), divergenceVersion, l, r)
+		case l.GetEventType() != r.GetEventType():
+			return newDivergence(l.GetEventId(), divergenceEventType, l, r)
+		}
+		lCopy, rCopy := *l, *r
+		lCopy.TaskId, rCopy.TaskId = nil, nil
+		if !lCopy.Equals(&rCopy) {
+			return newDivergence(l.GetEventId(), divergenceAttributes, l, r)
+		}
+		lastCommon = l
+	}
+
+	switch {
+	case len(local) > len(remote):
+		return newDivergence(local[len(remote)].GetEventId(), divergenceLength, local[len(remote)], nil)
+	case len(local) < len(remote):
+		return newDivergence(remote[len(local)].GetEventId(), divergenceLength, nil, remote[len(local)])
+	}
+	return nil
+}
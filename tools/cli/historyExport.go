@@ -0,0 +1,137 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/codec"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/urfave/cli"
+)
+
+// ExportHistory writes the complete history of a workflow execution into a file. The pretty JSON output has the same
+// layout as `workflow show --of` so it can be replayed in SDK unit tests, the binary output is a ThriftRW encoded
+// DataBlob which is much smaller and can be decoded back into JSON with --input_file.
+func ExportHistory(c *cli.Context) {
+	outputFileName := getRequiredOption(c, FlagOutputFilename)
+
+	var events []*shared.HistoryEvent
+	if inputFileName := c.String(FlagInputFile); inputFileName != "" {
+		events = readHistoryBundle(inputFileName)
+	} else {
+		events = getCompleteHistory(c, getRequiredOption(c, FlagWorkflowID), c.String(FlagRunID))
+	}
+
+	var data []byte
+	var err error
+	if c.Bool(FlagBinary) {
+		data, err = encodeHistoryBundle(events)
+	} else {
+		data, err = json.MarshalIndent(events, "", "  ")
+	}
+	if err != nil {
+		ErrorAndExit("Failed to serialize history data.", err)
+	}
+	if err := ioutil.WriteFile(outputFileName, data, 0644); err != nil {
+		ErrorAndExit("Failed to export history data file.", err)
+	}
+	fmt.Printf("%v history events are exported into %v.\n", len(events), outputFileName)
+}
+
+// getCompleteHistory pages through the history with the server frontend client, which reads it from archival once
+// the execution is past retention
+func getCompleteHistory(c *cli.Context, wid, rid string) []*shared.HistoryEvent {
+	frontendClient := cFactory.ServerFrontendClient(c)
+	domain := getRequiredGlobalOption(c, FlagDomain)
+
+	events := []*shared.HistoryEvent{}
+	archived := false
+	var nextPageToken []byte
+	for {
+		ctx, cancel := newContext(c)
+		resp, err := frontendClient.GetWorkflowExecutionHistory(ctx, &shared.GetWorkflowExecutionHistoryRequest{
+			Domain: common.StringPtr(domain),
+			Execution: &shared.WorkflowExecution{
+				WorkflowId: common.StringPtr(wid),
+				RunId:      common.StringPtr(rid),
+			},
+			MaximumPageSize: common.Int32Ptr(common.GetHistoryMaxPageSize),
+			NextPageToken:   nextPageToken,
+		})
+		cancel()
+		if err != nil {
+			ErrorAndExit(fmt.Sprintf("Failed to get history on workflow id: %s, run id: %s.", wid, rid), err)
+		}
+		events = append(events, resp.History.GetEvents()...)
+		archived = archived || resp.GetArchived()
+		nextPageToken = resp.NextPageToken
+		if len(nextPageToken) == 0 {
+			break
+		}
+	}
+	if archived {
+		fmt.Println(colorMagenta("History is read from archival."))
+	}
+	return events
+}
+
+func encodeHistoryBundle(events []*shared.HistoryEvent) ([]byte, error) {
+	blob, err := persistence.NewHistorySerializer().SerializeBatchEvents(events, common.EncodingTypeThriftRW)
+	if err != nil {
+		return nil, err
+	}
+	return codec.NewThriftRWEncoder().Encode(&shared.DataBlob{
+		EncodingType: shared.EncodingTypeThriftRW.Ptr(),
+		Data:         blob.Data,
+	})
+}
+
+func readHistoryBundle(fileName string) []*shared.HistoryEvent {
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		ErrorAndExit("Failed to read history bundle.", err)
+	}
+	var blob shared.DataBlob
+	if err := codec.NewThriftRWEncoder().Decode(data, &blob); err != nil {
+		ErrorAndExit("Failed to decode history bundle.", err)
+	}
+
+	events, err := deserializeHistoryBlob(&blob)
+	if err != nil {
+		ErrorAndExit("Failed to decode history bundle.", err)
+	}
+	return events
+}
+
+// deserializeHistoryBlob decodes a batch of history events from a DataBlob as returned by the history bundle and the
+// admin raw history API
+func deserializeHistoryBlob(blob *shared.DataBlob) ([]*shared.HistoryEvent, error) {
+	encoding := common.EncodingTypeThriftRW
+	if blob.GetEncodingType() == shared.EncodingTypeJSON {
+		encoding = common.EncodingTypeJSON
+	}
+	return persistence.NewHistorySerializer().DeserializeBatchEvents(persistence.NewDataBlob(blob.Data, encoding))
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/template"
+
+	"github.com/urfave/cli"
+)
+
+const (
+	outputFormatTable    = "table"
+	outputFormatJSON     = "json"
+	outputFormatTemplate = "go-template"
+)
+
+var outputTemplateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+// getOutputFormat returns the validated value of the global output flag
+func getOutputFormat(c *cli.Context) string {
+	format := c.GlobalString(FlagOutput)
+	switch format {
+	case "", outputFormatTable:
+		return outputFormatTable
+	case outputFormatJSON:
+		return outputFormatJSON
+	case outputFormatTemplate:
+		if c.GlobalString(FlagOutputTemplate) == "" {
+			ErrorAndExit(optionErr, fmt.Errorf("option %s is required when output format is %s", FlagOutputTemplate, outputFormatTemplate))
+		}
+		return outputFormatTemplate
+	default:
+		ErrorAndExit(optionErr, fmt.Errorf("unknown output format %q, supported formats are %s, %s and %s",
+			format, outputFormatTable, outputFormatJSON, outputFormatTemplate))
+	}
+	return ""
+}
+
+// printOutput prints o according to the global output flag, using printTable for the table format
+func printOutput(c *cli.Context, o interface{}, printTable func()) {
+	if getOutputFormat(c) == outputFormatTable {
+		printTable()
+		return
+	}
+	printStructuredOutput(c, o)
+}
+
+// printStructuredOutput prints o as JSON or renders it with the output template
+func printStructuredOutput(c *cli.Context, o interface{}) {
+	if getOutputFormat(c) != outputFormatTemplate {
+		prettyPrintJSONObject(o)
+		return
+	}
+	tmpl, err := template.New("output").Funcs(outputTemplateFuncs).Parse(c.GlobalString(FlagOutputTemplate))
+	if err != nil {
+		ErrorAndExit("Failed to parse output template.", err)
+	}
+	if err := tmpl.Execute(os.Stdout, o); err != nil {
+		ErrorAndExit("Failed to render output template.", err)
+	}
+	fmt.Println()
+}
@@ -47,6 +47,15 @@ func newWorkflowCommands() []cli.Command {
 				ShowHistoryWithWID(c)
 			},
 		},
+		{
+			Name:    "export-history",
+			Aliases: []string{"eh"},
+			Usage:   "export the complete workflow history, including archived history, into a JSON file or a binary bundle",
+			Flags:   getFlagsForExportHistory(),
+			Action: func(c *cli.Context) {
+				ExportHistory(c)
+			},
+		},
 		{
 			Name:  "start",
 			Usage: "start a new workflow execution",
@@ -97,6 +106,10 @@ func newWorkflowCommands() []cli.Command {
 					Name:  FlagInputFileWithAlias,
 					Usage: "Input for the signal from JSON file.",
 				},
+				cli.IntFlag{
+					Name:  FlagSignalDelay,
+					Usage: "Optional delay in seconds, the signal is delivered to the workflow once the delay elapsed",
+				},
 			},
 			Action: func(c *cli.Context) {
 				SignalWorkflow(c)
@@ -181,7 +194,7 @@ func newWorkflowCommands() []cli.Command {
 		{
 			Name:    "observe",
 			Aliases: []string{"ob"},
-			Usage:   "show the progress of workflow history",
+			Usage:   "follow the workflow history in real time, printing events and signals as they occur",
 			Flags:   getFlagsForObserve(),
 			Action: func(c *cli.Context) {
 				ObserveHistory(c)
@@ -196,6 +209,11 @@ func newWorkflowCommands() []cli.Command {
 				ObserveHistoryWithID(c)
 			},
 		},
+		{
+			Name:        "batch",
+			Usage:       "signal, terminate or reset a batch of workflow executions",
+			Subcommands: newWorkflowBatchCommands(),
+		},
 		{
 			Name:    "reset",
 			Aliases: []string{"rs"},
@@ -225,6 +243,66 @@ func newWorkflowCommands() []cli.Command {
 	}
 }
 
+func newWorkflowBatchCommands() []cli.Command {
+	return []cli.Command{
+		{
+			Name:    "signal",
+			Aliases: []string{"s"},
+			Usage:   "signal a batch of workflow executions",
+			Flags: append(getFlagsForBatch(),
+				cli.StringFlag{
+					Name:  FlagNameWithAlias,
+					Usage: "SignalName",
+				},
+				cli.StringFlag{
+					Name:  FlagInputWithAlias,
+					Usage: "Input for the signal, in JSON format.",
+				},
+				cli.StringFlag{
+					Name:  FlagInputFileWithAlias,
+					Usage: "Input for the signal from JSON file.",
+				},
+			),
+			Action: func(c *cli.Context) {
+				BatchSignalWorkflow(c)
+			},
+		},
+		{
+			Name:    "terminate",
+			Aliases: []string{"term"},
+			Usage:   "terminate a batch of workflow executions",
+			Flags: append(getFlagsForBatch(),
+				cli.StringFlag{
+					Name:  FlagReasonWithAlias,
+					Usage: "The reason you want to terminate the workflows",
+				},
+			),
+			Action: func(c *cli.Context) {
+				BatchTerminateWorkflow(c)
+			},
+		},
+		{
+			Name:    "reset",
+			Aliases: []string{"rs"},
+			Usage:   "reset a batch of workflow executions to their first or last completed decision",
+			Flags: append(getFlagsForBatch(),
+				cli.StringFlag{
+					Name:  FlagReason,
+					Usage: "reason to do the reset",
+				},
+				cli.StringFlag{
+					Name:  FlagResetType,
+					Value: resetTypeLastDecisionCompleted,
+					Usage: "Event to reset to [FirstDecisionCompleted|LastDecisionCompleted]",
+				},
+			),
+			Action: func(c *cli.Context) {
+				BatchResetWorkflow(c)
+			},
+		},
+	}
+}
+
 func newActivityCommands() []cli.Command {
 	return []cli.Command{
 		{